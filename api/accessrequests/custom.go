@@ -0,0 +1,155 @@
+package accessrequests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// WithJustification sets the reason given for requesting access to the
+// target.
+func WithJustification(justification string) Option {
+	return func(o *options) {
+		o.postMap["justification"] = justification
+	}
+}
+
+// WithDurationSeconds sets how long, in seconds, access is requested for once
+// the request is approved.
+func WithDurationSeconds(durationSeconds uint32) Option {
+	return func(o *options) {
+		o.postMap["duration_seconds"] = durationSeconds
+	}
+}
+
+// WithComment sets the comment recorded when an access request is approved or
+// denied.
+func WithComment(comment string) Option {
+	return func(o *options) {
+		o.postMap["comment"] = comment
+	}
+}
+
+// Create requests access to the target on behalf of the user making the
+// request. The access request is created in the scope of the target.
+func (c *Client) Create(ctx context.Context, targetId string, opt ...Option) (*AccessRequestCreateResult, error) {
+	if targetId == "" {
+		return nil, fmt.Errorf("empty targetId value passed into Create request")
+	}
+	if c.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	opts.postMap["target_id"] = targetId
+
+	req, err := c.client.NewRequest(ctx, "POST", "access-requests", opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Create request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Create call: %w", err)
+	}
+
+	target := new(AccessRequestCreateResult)
+	target.Item = new(AccessRequest)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Create response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.responseBody = resp.Body
+	target.responseMap = resp.Map
+	return target, nil
+}
+
+// Approve approves a pending access request, granting its user access to the
+// target for the requested duration.
+func (c *Client) Approve(ctx context.Context, accessRequestId string, version uint32, opt ...Option) (*AccessRequestUpdateResult, error) {
+	return c.review(ctx, "approve", accessRequestId, version, opt...)
+}
+
+// Deny denies a pending access request.
+func (c *Client) Deny(ctx context.Context, accessRequestId string, version uint32, opt ...Option) (*AccessRequestUpdateResult, error) {
+	return c.review(ctx, "deny", accessRequestId, version, opt...)
+}
+
+func (c *Client) review(ctx context.Context, action, accessRequestId string, version uint32, opt ...Option) (*AccessRequestUpdateResult, error) {
+	if accessRequestId == "" {
+		return nil, fmt.Errorf("empty accessRequestId value passed into %s request", action)
+	}
+	if c.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	if version == 0 {
+		if !opts.withAutomaticVersioning {
+			return nil, fmt.Errorf("zero version number passed into %s request", action)
+		}
+		existingRequest, existingErr := c.Read(ctx, accessRequestId, opt...)
+		if existingErr != nil {
+			if api.AsServerError(existingErr) != nil {
+				return nil, fmt.Errorf("error from controller when performing initial check-and-set read: %w", existingErr)
+			}
+			return nil, fmt.Errorf("error performing initial check-and-set read: %w", existingErr)
+		}
+		if existingRequest == nil {
+			return nil, errors.New("nil resource response found when performing initial check-and-set read")
+		}
+		if existingRequest.Item == nil {
+			return nil, errors.New("nil resource found when performing initial check-and-set read")
+		}
+		version = existingRequest.Item.Version
+	}
+
+	opts.postMap["version"] = version
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("access-requests/%s:%s", accessRequestId, action), opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s request: %w", action, err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during %s call: %w", action, err)
+	}
+
+	target := new(AccessRequestUpdateResult)
+	target.Item = new(AccessRequest)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding %s response: %w", action, err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.responseBody = resp.Body
+	target.responseMap = resp.Map
+	return target, nil
+}
@@ -0,0 +1,79 @@
+package accessrequests
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// Option is a func that sets optional attributes for a call. This does not need
+// to be used directly, but instead option arguments are built from the
+// functions in this package. WithX options set a value to that given in the
+// argument; DefaultX options indicate that the value should be set to its
+// default. When an API call is made options are processed in ther order they
+// appear in the function call, so for a given argument X, a succession of WithX
+// or DefaultX calls will result in the last call taking effect.
+type Option func(*options)
+
+type options struct {
+	postMap                 map[string]interface{}
+	queryMap                map[string]string
+	withAutomaticVersioning bool
+}
+
+func getDefaultOptions() options {
+	return options{
+		postMap:  make(map[string]interface{}),
+		queryMap: make(map[string]string),
+	}
+}
+
+func getOpts(opt ...Option) (options, []api.Option) {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	var apiOpts []api.Option
+	return opts, apiOpts
+}
+
+// If set, and if the version is zero during an update, the API will perform a
+// fetch to get the current version of the resource and populate it during the
+// update call. This is convenient but opens up the possibility for subtle
+// order-of-modification issues, so use carefully.
+func WithAutomaticVersioning(enable bool) Option {
+	return func(o *options) {
+		o.withAutomaticVersioning = enable
+	}
+}
+
+// WithFilter tells the API to only return resources matching the given filter
+// expression when listing, e.g. "/item/name" == "prod". Only valid for List.
+func WithFilter(filter string) Option {
+	return func(o *options) {
+		o.queryMap["filter"] = filter
+	}
+}
+
+// WithPageSize tells the API to return at most the given number of resources
+// when listing. The NextPageToken of the result can be passed to WithPageToken
+// to fetch the next page. Only valid for List.
+func WithPageSize(pageSize uint32) Option {
+	return func(o *options) {
+		o.queryMap["page_size"] = fmt.Sprintf("%d", pageSize)
+	}
+}
+
+// WithPageToken tells the API to continue listing after the page that
+// returned the given token. Only valid for List.
+func WithPageToken(pageToken string) Option {
+	return func(o *options) {
+		o.queryMap["page_token"] = pageToken
+	}
+}
+
+func WithRecursive(inRecursive bool) Option {
+	return func(o *options) {
+		o.queryMap["recursive"] = fmt.Sprintf("%v", inRecursive)
+	}
+}
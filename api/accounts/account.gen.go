@@ -14,16 +14,17 @@ import (
 )
 
 type Account struct {
-	Id           string                 `json:"id,omitempty"`
-	Scope        *scopes.ScopeInfo      `json:"scope,omitempty"`
-	Name         string                 `json:"name,omitempty"`
-	Description  string                 `json:"description,omitempty"`
-	CreatedTime  time.Time              `json:"created_time,omitempty"`
-	UpdatedTime  time.Time              `json:"updated_time,omitempty"`
-	Version      uint32                 `json:"version,omitempty"`
-	Type         string                 `json:"type,omitempty"`
-	AuthMethodId string                 `json:"auth_method_id,omitempty"`
-	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+	Id                string                 `json:"id,omitempty"`
+	Scope             *scopes.ScopeInfo      `json:"scope,omitempty"`
+	Name              string                 `json:"name,omitempty"`
+	Description       string                 `json:"description,omitempty"`
+	CreatedTime       time.Time              `json:"created_time,omitempty"`
+	UpdatedTime       time.Time              `json:"updated_time,omitempty"`
+	Version           uint32                 `json:"version,omitempty"`
+	Type              string                 `json:"type,omitempty"`
+	AuthMethodId      string                 `json:"auth_method_id,omitempty"`
+	Attributes        map[string]interface{} `json:"attributes,omitempty"`
+	AuthorizedActions []string               `json:"authorized_actions,omitempty"`
 
 	responseBody *bytes.Buffer
 	responseMap  map[string]interface{}
@@ -72,15 +73,20 @@ func (n AccountDeleteResult) GetResponseMap() map[string]interface{} {
 }
 
 type AccountListResult struct {
-	Items        []*Account
-	responseBody *bytes.Buffer
-	responseMap  map[string]interface{}
+	Items         []*Account
+	NextPageToken string `json:"next_page_token,omitempty"`
+	responseBody  *bytes.Buffer
+	responseMap   map[string]interface{}
 }
 
 func (n AccountListResult) GetItems() interface{} {
 	return n.Items
 }
 
+func (n AccountListResult) GetNextPageToken() string {
+	return n.NextPageToken
+}
+
 func (n AccountListResult) GetResponseBody() *bytes.Buffer {
 	return n.responseBody
 }
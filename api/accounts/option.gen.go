@@ -1,6 +1,8 @@
 package accounts
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/boundary/api"
 )
 
@@ -45,6 +47,31 @@ func WithAutomaticVersioning(enable bool) Option {
 	}
 }
 
+// WithFilter tells the API to only return resources matching the given filter
+// expression when listing, e.g. "/item/name" == "prod". Only valid for List.
+func WithFilter(filter string) Option {
+	return func(o *options) {
+		o.queryMap["filter"] = filter
+	}
+}
+
+// WithPageSize tells the API to return at most the given number of resources
+// when listing. The NextPageToken of the result can be passed to WithPageToken
+// to fetch the next page. Only valid for List.
+func WithPageSize(pageSize uint32) Option {
+	return func(o *options) {
+		o.queryMap["page_size"] = fmt.Sprintf("%d", pageSize)
+	}
+}
+
+// WithPageToken tells the API to continue listing after the page that
+// returned the given token. Only valid for List.
+func WithPageToken(pageToken string) Option {
+	return func(o *options) {
+		o.queryMap["page_token"] = pageToken
+	}
+}
+
 func WithAttributes(inAttributes map[string]interface{}) Option {
 	return func(o *options) {
 		o.postMap["attributes"] = inAttributes
@@ -93,6 +120,30 @@ func DefaultPasswordAccountLoginName() Option {
 	}
 }
 
+func WithPasswordAccountMustChangePassword(inMustChangePassword bool) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["must_change_password"] = inMustChangePassword
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAccountMustChangePassword() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["must_change_password"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithName(inName string) Option {
 	return func(o *options) {
 		o.postMap["name"] = inName
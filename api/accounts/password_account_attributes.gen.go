@@ -1,7 +1,13 @@
 // Code generated by "make api"; DO NOT EDIT.
 package accounts
 
+import (
+	"time"
+)
+
 type PasswordAccountAttributes struct {
-	LoginName string `json:"login_name,omitempty"`
-	Password  string `json:"password,omitempty"`
+	LoginName          string    `json:"login_name,omitempty"`
+	Password           string    `json:"password,omitempty"`
+	MustChangePassword bool      `json:"must_change_password,omitempty"`
+	LockedTime         time.Time `json:"locked_time,omitempty"`
 }
@@ -0,0 +1,63 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+func (c *Client) Unlock(ctx context.Context, accountId string, version uint32, opt ...Option) (*AccountUpdateResult, error) {
+	if accountId == "" {
+		return nil, fmt.Errorf("empty accountId value passed into Unlock request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in Unlock request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	if version == 0 {
+		if !opts.withAutomaticVersioning {
+			return nil, errors.New("zero version number passed into Update request and automatic versioning not specified")
+		}
+		existingTarget, existingErr := c.Read(ctx, accountId, opt...)
+		if existingErr != nil {
+			if api.AsServerError(existingErr) != nil {
+				return nil, fmt.Errorf("error from controller when performing initial check-and-set read: %w", existingErr)
+			}
+			return nil, fmt.Errorf("error performing initial check-and-set read: %w", existingErr)
+		}
+		if existingTarget == nil {
+			return nil, errors.New("nil resource found when performing initial check-and-set read")
+		}
+		version = existingTarget.Item.Version
+	}
+
+	reqBody := map[string]interface{}{
+		"version": version,
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("accounts/%s:unlock", accountId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Unlock request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Unlock call: %w", err)
+	}
+
+	target := new(AccountUpdateResult)
+	target.Item = new(Account)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Unlock response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	return target, nil
+}
@@ -0,0 +1,17 @@
+// Code generated by "make api"; DO NOT EDIT.
+package authmethods
+
+type LdapAuthMethodAttributes struct {
+	Url               string `json:"url,omitempty"`
+	StartTls          bool   `json:"start_tls,omitempty"`
+	InsecureTls       bool   `json:"insecure_tls,omitempty"`
+	Certificate       string `json:"certificate,omitempty"`
+	BindDn            string `json:"bind_dn,omitempty"`
+	BindPassword      string `json:"bind_password,omitempty"`
+	BindPasswordHmac  string `json:"bind_password_hmac,omitempty"`
+	UserSearchBase    string `json:"user_search_base,omitempty"`
+	UserSearchFilter  string `json:"user_search_filter,omitempty"`
+	GroupSearchBase   string `json:"group_search_base,omitempty"`
+	GroupSearchFilter string `json:"group_search_filter,omitempty"`
+	GroupAttr         string `json:"group_attr,omitempty"`
+}
@@ -0,0 +1,87 @@
+package authmethods
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api/authtokens"
+)
+
+// AuthenticateStartResult contains the information needed to complete an
+// authentication attempt with an OIDC auth method.
+type AuthenticateStartResult struct {
+	// AuthUrl is the URL the user should visit to authenticate with the
+	// provider.
+	AuthUrl string `json:"auth_url,omitempty"`
+	// TokenId is used with AuthenticateToken to retrieve the auth token once
+	// the user has authenticated.
+	TokenId string `json:"token_id,omitempty"`
+}
+
+// AuthenticateStart begins an authentication attempt with an OIDC auth
+// method.
+func (c *Client) AuthenticateStart(ctx context.Context, authMethodId string, opt ...Option) (*AuthenticateStartResult, error) {
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in AuthenticateStart request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("auth-methods/%s:authenticate-start", authMethodId), map[string]interface{}{}, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating AuthenticateStart request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during AuthenticateStart call: %w", err)
+	}
+
+	target := new(AuthenticateStartResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding AuthenticateStart response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	return target, nil
+}
+
+// AuthenticateToken retrieves the auth token issued for an authentication
+// attempt started with AuthenticateStart. If the user has not yet completed
+// authentication with the provider, the returned result has a nil Item and
+// the call should be retried.
+func (c *Client) AuthenticateToken(ctx context.Context, authMethodId, tokenId string, opt ...Option) (*authtokens.AuthTokenReadResult, error) {
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in AuthenticateToken request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	reqBody := map[string]interface{}{
+		"token_id": tokenId,
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("auth-methods/%s:authenticate-token", authMethodId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating AuthenticateToken request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during AuthenticateToken call: %w", err)
+	}
+
+	target := new(authtokens.AuthTokenReadResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding AuthenticateToken response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	return target, nil
+}
@@ -0,0 +1,11 @@
+// Code generated by "make api"; DO NOT EDIT.
+package authmethods
+
+type OidcAuthMethodAttributes struct {
+	Issuer           string   `json:"issuer,omitempty"`
+	ClientId         string   `json:"client_id,omitempty"`
+	ClientSecret     string   `json:"client_secret,omitempty"`
+	ClientSecretHmac string   `json:"client_secret_hmac,omitempty"`
+	CallbackUrl      string   `json:"callback_url,omitempty"`
+	AllowedAudiences []string `json:"allowed_audiences,omitempty"`
+}
@@ -1,6 +1,8 @@
 package authmethods
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/boundary/api"
 )
 
@@ -45,6 +47,55 @@ func WithAutomaticVersioning(enable bool) Option {
 	}
 }
 
+// WithFilter tells the API to only return resources matching the given filter
+// expression when listing, e.g. "/item/name" == "prod". Only valid for List.
+func WithFilter(filter string) Option {
+	return func(o *options) {
+		o.queryMap["filter"] = filter
+	}
+}
+
+// WithPageSize tells the API to return at most the given number of resources
+// when listing. The NextPageToken of the result can be passed to WithPageToken
+// to fetch the next page. Only valid for List.
+func WithPageSize(pageSize uint32) Option {
+	return func(o *options) {
+		o.queryMap["page_size"] = fmt.Sprintf("%d", pageSize)
+	}
+}
+
+// WithPageToken tells the API to continue listing after the page that
+// returned the given token. Only valid for List.
+func WithPageToken(pageToken string) Option {
+	return func(o *options) {
+		o.queryMap["page_token"] = pageToken
+	}
+}
+
+func WithOidcAuthMethodAllowedAudiences(inAllowedAudiences []string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["allowed_audiences"] = inAllowedAudiences
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultOidcAuthMethodAllowedAudiences() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["allowed_audiences"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithAttributes(inAttributes map[string]interface{}) Option {
 	return func(o *options) {
 		o.postMap["attributes"] = inAttributes
@@ -57,6 +108,150 @@ func DefaultAttributes() Option {
 	}
 }
 
+func WithLdapAuthMethodBindDn(inBindDn string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["bind_dn"] = inBindDn
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultLdapAuthMethodBindDn() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["bind_dn"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithLdapAuthMethodBindPassword(inBindPassword string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["bind_password"] = inBindPassword
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultLdapAuthMethodBindPassword() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["bind_password"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithOidcAuthMethodCallbackUrl(inCallbackUrl string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["callback_url"] = inCallbackUrl
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultOidcAuthMethodCallbackUrl() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["callback_url"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithLdapAuthMethodCertificate(inCertificate string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["certificate"] = inCertificate
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultLdapAuthMethodCertificate() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["certificate"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithOidcAuthMethodClientId(inClientId string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["client_id"] = inClientId
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultOidcAuthMethodClientId() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["client_id"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithOidcAuthMethodClientSecret(inClientSecret string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["client_secret"] = inClientSecret
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultOidcAuthMethodClientSecret() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["client_secret"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithDescription(inDescription string) Option {
 	return func(o *options) {
 		o.postMap["description"] = inDescription
@@ -69,62 +264,500 @@ func DefaultDescription() Option {
 	}
 }
 
-func WithPasswordAuthMethodMinLoginNameLength(inMinLoginNameLength uint32) Option {
+func WithLdapAuthMethodGroupAttr(inGroupAttr string) Option {
 	return func(o *options) {
 		raw, ok := o.postMap["attributes"]
 		if !ok {
 			raw = interface{}(map[string]interface{}{})
 		}
 		val := raw.(map[string]interface{})
-		val["min_login_name_length"] = inMinLoginNameLength
+		val["group_attr"] = inGroupAttr
 		o.postMap["attributes"] = val
 	}
 }
 
-func DefaultPasswordAuthMethodMinLoginNameLength() Option {
+func DefaultLdapAuthMethodGroupAttr() Option {
 	return func(o *options) {
 		raw, ok := o.postMap["attributes"]
 		if !ok {
 			raw = interface{}(map[string]interface{}{})
 		}
 		val := raw.(map[string]interface{})
-		val["min_login_name_length"] = nil
+		val["group_attr"] = nil
 		o.postMap["attributes"] = val
 	}
 }
 
-func WithPasswordAuthMethodMinPasswordLength(inMinPasswordLength uint32) Option {
+func WithLdapAuthMethodGroupSearchBase(inGroupSearchBase string) Option {
 	return func(o *options) {
 		raw, ok := o.postMap["attributes"]
 		if !ok {
 			raw = interface{}(map[string]interface{}{})
 		}
 		val := raw.(map[string]interface{})
-		val["min_password_length"] = inMinPasswordLength
+		val["group_search_base"] = inGroupSearchBase
 		o.postMap["attributes"] = val
 	}
 }
 
-func DefaultPasswordAuthMethodMinPasswordLength() Option {
+func DefaultLdapAuthMethodGroupSearchBase() Option {
 	return func(o *options) {
 		raw, ok := o.postMap["attributes"]
 		if !ok {
 			raw = interface{}(map[string]interface{}{})
 		}
 		val := raw.(map[string]interface{})
-		val["min_password_length"] = nil
+		val["group_search_base"] = nil
 		o.postMap["attributes"] = val
 	}
 }
 
-func WithName(inName string) Option {
+func WithLdapAuthMethodGroupSearchFilter(inGroupSearchFilter string) Option {
 	return func(o *options) {
-		o.postMap["name"] = inName
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["group_search_filter"] = inGroupSearchFilter
+		o.postMap["attributes"] = val
 	}
 }
 
-func DefaultName() Option {
+func DefaultLdapAuthMethodGroupSearchFilter() Option {
 	return func(o *options) {
-		o.postMap["name"] = nil
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["group_search_filter"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithLdapAuthMethodInsecureTls(inInsecureTls bool) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["insecure_tls"] = inInsecureTls
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultLdapAuthMethodInsecureTls() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["insecure_tls"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithOidcAuthMethodIssuer(inIssuer string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["issuer"] = inIssuer
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultOidcAuthMethodIssuer() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["issuer"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithPasswordAuthMethodLockoutDurationSeconds(inLockoutDurationSeconds uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["lockout_duration_seconds"] = inLockoutDurationSeconds
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodLockoutDurationSeconds() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["lockout_duration_seconds"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithPasswordAuthMethodLockoutThreshold(inLockoutThreshold uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["lockout_threshold"] = inLockoutThreshold
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodLockoutThreshold() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["lockout_threshold"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithPasswordAuthMethodLockoutWindowSeconds(inLockoutWindowSeconds uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["lockout_window_seconds"] = inLockoutWindowSeconds
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodLockoutWindowSeconds() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["lockout_window_seconds"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithPasswordAuthMethodMaxPasswordAgeSeconds(inMaxPasswordAgeSeconds uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["max_password_age_seconds"] = inMaxPasswordAgeSeconds
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodMaxPasswordAgeSeconds() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["max_password_age_seconds"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithPasswordAuthMethodMinLoginNameLength(inMinLoginNameLength uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["min_login_name_length"] = inMinLoginNameLength
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodMinLoginNameLength() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["min_login_name_length"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithPasswordAuthMethodMinPasswordLength(inMinPasswordLength uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["min_password_length"] = inMinPasswordLength
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodMinPasswordLength() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["min_password_length"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithName(inName string) Option {
+	return func(o *options) {
+		o.postMap["name"] = inName
+	}
+}
+
+func DefaultName() Option {
+	return func(o *options) {
+		o.postMap["name"] = nil
+	}
+}
+
+func WithPasswordAuthMethodPasswordHistoryCount(inPasswordHistoryCount uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["password_history_count"] = inPasswordHistoryCount
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodPasswordHistoryCount() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["password_history_count"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithRecursive(inRecursive bool) Option {
+	return func(o *options) {
+		o.queryMap["recursive"] = fmt.Sprintf("%v", inRecursive)
+	}
+}
+
+func WithPasswordAuthMethodRequireDigit(inRequireDigit bool) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["require_digit"] = inRequireDigit
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodRequireDigit() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["require_digit"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithPasswordAuthMethodRequireLowercase(inRequireLowercase bool) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["require_lowercase"] = inRequireLowercase
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodRequireLowercase() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["require_lowercase"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithPasswordAuthMethodRequireSymbol(inRequireSymbol bool) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["require_symbol"] = inRequireSymbol
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodRequireSymbol() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["require_symbol"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithPasswordAuthMethodRequireUppercase(inRequireUppercase bool) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["require_uppercase"] = inRequireUppercase
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodRequireUppercase() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["require_uppercase"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithLdapAuthMethodStartTls(inStartTls bool) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["start_tls"] = inStartTls
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultLdapAuthMethodStartTls() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["start_tls"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithLdapAuthMethodUrl(inUrl string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["url"] = inUrl
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultLdapAuthMethodUrl() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["url"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithLdapAuthMethodUserSearchBase(inUserSearchBase string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["user_search_base"] = inUserSearchBase
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultLdapAuthMethodUserSearchBase() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["user_search_base"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithLdapAuthMethodUserSearchFilter(inUserSearchFilter string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["user_search_filter"] = inUserSearchFilter
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultLdapAuthMethodUserSearchFilter() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["user_search_filter"] = nil
+		o.postMap["attributes"] = val
 	}
 }
@@ -2,6 +2,15 @@
 package authmethods
 
 type PasswordAuthMethodAttributes struct {
-	MinLoginNameLength uint32 `json:"min_login_name_length,omitempty"`
-	MinPasswordLength  uint32 `json:"min_password_length,omitempty"`
+	MinLoginNameLength     uint32 `json:"min_login_name_length,omitempty"`
+	MinPasswordLength      uint32 `json:"min_password_length,omitempty"`
+	RequireUppercase       bool   `json:"require_uppercase,omitempty"`
+	RequireLowercase       bool   `json:"require_lowercase,omitempty"`
+	RequireDigit           bool   `json:"require_digit,omitempty"`
+	RequireSymbol          bool   `json:"require_symbol,omitempty"`
+	PasswordHistoryCount   uint32 `json:"password_history_count,omitempty"`
+	MaxPasswordAgeSeconds  uint32 `json:"max_password_age_seconds,omitempty"`
+	LockoutThreshold       uint32 `json:"lockout_threshold,omitempty"`
+	LockoutWindowSeconds   uint32 `json:"lockout_window_seconds,omitempty"`
+	LockoutDurationSeconds uint32 `json:"lockout_duration_seconds,omitempty"`
 }
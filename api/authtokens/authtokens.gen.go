@@ -24,6 +24,7 @@ type AuthToken struct {
 	UpdatedTime             time.Time         `json:"updated_time,omitempty"`
 	ApproximateLastUsedTime time.Time         `json:"approximate_last_used_time,omitempty"`
 	ExpirationTime          time.Time         `json:"expiration_time,omitempty"`
+	AuthorizedActions       []string          `json:"authorized_actions,omitempty"`
 
 	responseBody *bytes.Buffer
 	responseMap  map[string]interface{}
@@ -72,15 +73,20 @@ func (n AuthTokenDeleteResult) GetResponseMap() map[string]interface{} {
 }
 
 type AuthTokenListResult struct {
-	Items        []*AuthToken
-	responseBody *bytes.Buffer
-	responseMap  map[string]interface{}
+	Items         []*AuthToken
+	NextPageToken string `json:"next_page_token,omitempty"`
+	responseBody  *bytes.Buffer
+	responseMap   map[string]interface{}
 }
 
 func (n AuthTokenListResult) GetItems() interface{} {
 	return n.Items
 }
 
+func (n AuthTokenListResult) GetNextPageToken() string {
+	return n.NextPageToken
+}
+
 func (n AuthTokenListResult) GetResponseBody() *bytes.Buffer {
 	return n.responseBody
 }
@@ -1,6 +1,8 @@
 package authtokens
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/boundary/api"
 )
 
@@ -44,3 +46,34 @@ func WithAutomaticVersioning(enable bool) Option {
 		o.withAutomaticVersioning = enable
 	}
 }
+
+// WithFilter tells the API to only return resources matching the given filter
+// expression when listing, e.g. "/item/name" == "prod". Only valid for List.
+func WithFilter(filter string) Option {
+	return func(o *options) {
+		o.queryMap["filter"] = filter
+	}
+}
+
+// WithPageSize tells the API to return at most the given number of resources
+// when listing. The NextPageToken of the result can be passed to WithPageToken
+// to fetch the next page. Only valid for List.
+func WithPageSize(pageSize uint32) Option {
+	return func(o *options) {
+		o.queryMap["page_size"] = fmt.Sprintf("%d", pageSize)
+	}
+}
+
+// WithPageToken tells the API to continue listing after the page that
+// returned the given token. Only valid for List.
+func WithPageToken(pageToken string) Option {
+	return func(o *options) {
+		o.queryMap["page_token"] = pageToken
+	}
+}
+
+func WithRecursive(inRecursive bool) Option {
+	return func(o *options) {
+		o.queryMap["recursive"] = fmt.Sprintf("%v", inRecursive)
+	}
+}
@@ -14,16 +14,19 @@ import (
 )
 
 type Group struct {
-	Id          string            `json:"id,omitempty"`
-	ScopeId     string            `json:"scope_id,omitempty"`
-	Scope       *scopes.ScopeInfo `json:"scope,omitempty"`
-	Name        string            `json:"name,omitempty"`
-	Description string            `json:"description,omitempty"`
-	CreatedTime time.Time         `json:"created_time,omitempty"`
-	UpdatedTime time.Time         `json:"updated_time,omitempty"`
-	Version     uint32            `json:"version,omitempty"`
-	MemberIds   []string          `json:"member_ids,omitempty"`
-	Members     []*Member         `json:"members,omitempty"`
+	Id                string            `json:"id,omitempty"`
+	ScopeId           string            `json:"scope_id,omitempty"`
+	Scope             *scopes.ScopeInfo `json:"scope,omitempty"`
+	Name              string            `json:"name,omitempty"`
+	Description       string            `json:"description,omitempty"`
+	CreatedTime       time.Time         `json:"created_time,omitempty"`
+	UpdatedTime       time.Time         `json:"updated_time,omitempty"`
+	Version           uint32            `json:"version,omitempty"`
+	MemberIds         []string          `json:"member_ids,omitempty"`
+	Members           []*Member         `json:"members,omitempty"`
+	DirectoryType     string            `json:"directory_type,omitempty"`
+	DirectoryGroup    string            `json:"directory_group,omitempty"`
+	AuthorizedActions []string          `json:"authorized_actions,omitempty"`
 
 	responseBody *bytes.Buffer
 	responseMap  map[string]interface{}
@@ -72,15 +75,20 @@ func (n GroupDeleteResult) GetResponseMap() map[string]interface{} {
 }
 
 type GroupListResult struct {
-	Items        []*Group
-	responseBody *bytes.Buffer
-	responseMap  map[string]interface{}
+	Items         []*Group
+	NextPageToken string `json:"next_page_token,omitempty"`
+	responseBody  *bytes.Buffer
+	responseMap   map[string]interface{}
 }
 
 func (n GroupListResult) GetItems() interface{} {
 	return n.Items
 }
 
+func (n GroupListResult) GetNextPageToken() string {
+	return n.NextPageToken
+}
+
 func (n GroupListResult) GetResponseBody() *bytes.Buffer {
 	return n.responseBody
 }
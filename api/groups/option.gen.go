@@ -1,6 +1,8 @@
 package groups
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/boundary/api"
 )
 
@@ -45,6 +47,31 @@ func WithAutomaticVersioning(enable bool) Option {
 	}
 }
 
+// WithFilter tells the API to only return resources matching the given filter
+// expression when listing, e.g. "/item/name" == "prod". Only valid for List.
+func WithFilter(filter string) Option {
+	return func(o *options) {
+		o.queryMap["filter"] = filter
+	}
+}
+
+// WithPageSize tells the API to return at most the given number of resources
+// when listing. The NextPageToken of the result can be passed to WithPageToken
+// to fetch the next page. Only valid for List.
+func WithPageSize(pageSize uint32) Option {
+	return func(o *options) {
+		o.queryMap["page_size"] = fmt.Sprintf("%d", pageSize)
+	}
+}
+
+// WithPageToken tells the API to continue listing after the page that
+// returned the given token. Only valid for List.
+func WithPageToken(pageToken string) Option {
+	return func(o *options) {
+		o.queryMap["page_token"] = pageToken
+	}
+}
+
 func WithDescription(inDescription string) Option {
 	return func(o *options) {
 		o.postMap["description"] = inDescription
@@ -57,6 +84,30 @@ func DefaultDescription() Option {
 	}
 }
 
+func WithDirectoryGroup(inDirectoryGroup string) Option {
+	return func(o *options) {
+		o.postMap["directory_group"] = inDirectoryGroup
+	}
+}
+
+func DefaultDirectoryGroup() Option {
+	return func(o *options) {
+		o.postMap["directory_group"] = nil
+	}
+}
+
+func WithDirectoryType(inDirectoryType string) Option {
+	return func(o *options) {
+		o.postMap["directory_type"] = inDirectoryType
+	}
+}
+
+func DefaultDirectoryType() Option {
+	return func(o *options) {
+		o.postMap["directory_type"] = nil
+	}
+}
+
 func WithName(inName string) Option {
 	return func(o *options) {
 		o.postMap["name"] = inName
@@ -68,3 +119,9 @@ func DefaultName() Option {
 		o.postMap["name"] = nil
 	}
 }
+
+func WithRecursive(inRecursive bool) Option {
+	return func(o *options) {
+		o.queryMap["recursive"] = fmt.Sprintf("%v", inRecursive)
+	}
+}
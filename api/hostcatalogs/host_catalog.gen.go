@@ -14,16 +14,17 @@ import (
 )
 
 type HostCatalog struct {
-	Id          string                 `json:"id,omitempty"`
-	ScopeId     string                 `json:"scope_id,omitempty"`
-	Scope       *scopes.ScopeInfo      `json:"scope,omitempty"`
-	Name        string                 `json:"name,omitempty"`
-	Description string                 `json:"description,omitempty"`
-	CreatedTime time.Time              `json:"created_time,omitempty"`
-	UpdatedTime time.Time              `json:"updated_time,omitempty"`
-	Version     uint32                 `json:"version,omitempty"`
-	Type        string                 `json:"type,omitempty"`
-	Attributes  map[string]interface{} `json:"attributes,omitempty"`
+	Id                string                 `json:"id,omitempty"`
+	ScopeId           string                 `json:"scope_id,omitempty"`
+	Scope             *scopes.ScopeInfo      `json:"scope,omitempty"`
+	Name              string                 `json:"name,omitempty"`
+	Description       string                 `json:"description,omitempty"`
+	CreatedTime       time.Time              `json:"created_time,omitempty"`
+	UpdatedTime       time.Time              `json:"updated_time,omitempty"`
+	Version           uint32                 `json:"version,omitempty"`
+	Type              string                 `json:"type,omitempty"`
+	Attributes        map[string]interface{} `json:"attributes,omitempty"`
+	AuthorizedActions []string               `json:"authorized_actions,omitempty"`
 
 	responseBody *bytes.Buffer
 	responseMap  map[string]interface{}
@@ -72,15 +73,20 @@ func (n HostCatalogDeleteResult) GetResponseMap() map[string]interface{} {
 }
 
 type HostCatalogListResult struct {
-	Items        []*HostCatalog
-	responseBody *bytes.Buffer
-	responseMap  map[string]interface{}
+	Items         []*HostCatalog
+	NextPageToken string `json:"next_page_token,omitempty"`
+	responseBody  *bytes.Buffer
+	responseMap   map[string]interface{}
 }
 
 func (n HostCatalogListResult) GetItems() interface{} {
 	return n.Items
 }
 
+func (n HostCatalogListResult) GetNextPageToken() string {
+	return n.NextPageToken
+}
+
 func (n HostCatalogListResult) GetResponseBody() *bytes.Buffer {
 	return n.responseBody
 }
@@ -14,17 +14,18 @@ import (
 )
 
 type Host struct {
-	Id            string                 `json:"id,omitempty"`
-	HostCatalogId string                 `json:"host_catalog_id,omitempty"`
-	Scope         *scopes.ScopeInfo      `json:"scope,omitempty"`
-	Name          string                 `json:"name,omitempty"`
-	Description   string                 `json:"description,omitempty"`
-	CreatedTime   time.Time              `json:"created_time,omitempty"`
-	UpdatedTime   time.Time              `json:"updated_time,omitempty"`
-	Version       uint32                 `json:"version,omitempty"`
-	Type          string                 `json:"type,omitempty"`
-	HostSetIds    []string               `json:"host_set_ids,omitempty"`
-	Attributes    map[string]interface{} `json:"attributes,omitempty"`
+	Id                string                 `json:"id,omitempty"`
+	HostCatalogId     string                 `json:"host_catalog_id,omitempty"`
+	Scope             *scopes.ScopeInfo      `json:"scope,omitempty"`
+	Name              string                 `json:"name,omitempty"`
+	Description       string                 `json:"description,omitempty"`
+	CreatedTime       time.Time              `json:"created_time,omitempty"`
+	UpdatedTime       time.Time              `json:"updated_time,omitempty"`
+	Version           uint32                 `json:"version,omitempty"`
+	Type              string                 `json:"type,omitempty"`
+	HostSetIds        []string               `json:"host_set_ids,omitempty"`
+	Attributes        map[string]interface{} `json:"attributes,omitempty"`
+	AuthorizedActions []string               `json:"authorized_actions,omitempty"`
 
 	responseBody *bytes.Buffer
 	responseMap  map[string]interface{}
@@ -73,15 +74,20 @@ func (n HostDeleteResult) GetResponseMap() map[string]interface{} {
 }
 
 type HostListResult struct {
-	Items        []*Host
-	responseBody *bytes.Buffer
-	responseMap  map[string]interface{}
+	Items         []*Host
+	NextPageToken string `json:"next_page_token,omitempty"`
+	responseBody  *bytes.Buffer
+	responseMap   map[string]interface{}
 }
 
 func (n HostListResult) GetItems() interface{} {
 	return n.Items
 }
 
+func (n HostListResult) GetNextPageToken() string {
+	return n.NextPageToken
+}
+
 func (n HostListResult) GetResponseBody() *bytes.Buffer {
 	return n.responseBody
 }
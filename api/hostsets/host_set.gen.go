@@ -14,17 +14,18 @@ import (
 )
 
 type HostSet struct {
-	Id            string                 `json:"id,omitempty"`
-	HostCatalogId string                 `json:"host_catalog_id,omitempty"`
-	Scope         *scopes.ScopeInfo      `json:"scope,omitempty"`
-	Name          string                 `json:"name,omitempty"`
-	Description   string                 `json:"description,omitempty"`
-	CreatedTime   time.Time              `json:"created_time,omitempty"`
-	UpdatedTime   time.Time              `json:"updated_time,omitempty"`
-	Version       uint32                 `json:"version,omitempty"`
-	Type          string                 `json:"type,omitempty"`
-	HostIds       []string               `json:"host_ids,omitempty"`
-	Attributes    map[string]interface{} `json:"attributes,omitempty"`
+	Id                string                 `json:"id,omitempty"`
+	HostCatalogId     string                 `json:"host_catalog_id,omitempty"`
+	Scope             *scopes.ScopeInfo      `json:"scope,omitempty"`
+	Name              string                 `json:"name,omitempty"`
+	Description       string                 `json:"description,omitempty"`
+	CreatedTime       time.Time              `json:"created_time,omitempty"`
+	UpdatedTime       time.Time              `json:"updated_time,omitempty"`
+	Version           uint32                 `json:"version,omitempty"`
+	Type              string                 `json:"type,omitempty"`
+	HostIds           []string               `json:"host_ids,omitempty"`
+	Attributes        map[string]interface{} `json:"attributes,omitempty"`
+	AuthorizedActions []string               `json:"authorized_actions,omitempty"`
 
 	responseBody *bytes.Buffer
 	responseMap  map[string]interface{}
@@ -73,15 +74,20 @@ func (n HostSetDeleteResult) GetResponseMap() map[string]interface{} {
 }
 
 type HostSetListResult struct {
-	Items        []*HostSet
-	responseBody *bytes.Buffer
-	responseMap  map[string]interface{}
+	Items         []*HostSet
+	NextPageToken string `json:"next_page_token,omitempty"`
+	responseBody  *bytes.Buffer
+	responseMap   map[string]interface{}
 }
 
 func (n HostSetListResult) GetItems() interface{} {
 	return n.Items
 }
 
+func (n HostSetListResult) GetNextPageToken() string {
+	return n.NextPageToken
+}
+
 func (n HostSetListResult) GetResponseBody() *bytes.Buffer {
 	return n.responseBody
 }
@@ -0,0 +1,14 @@
+// Code generated by "make api"; DO NOT EDIT.
+package roles
+
+type AuthorizationExplanation struct {
+	UserId       string              `json:"user_id,omitempty"`
+	ResourceId   string              `json:"resource_id,omitempty"`
+	ResourceType string              `json:"resource_type,omitempty"`
+	ScopeId      string              `json:"scope_id,omitempty"`
+	ParentId     string              `json:"parent_id,omitempty"`
+	Action       string              `json:"action,omitempty"`
+	Allowed      bool                `json:"allowed,omitempty"`
+	Reason       string              `json:"reason,omitempty"`
+	Grants       []*GrantExplanation `json:"grants,omitempty"`
+}
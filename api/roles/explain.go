@@ -0,0 +1,82 @@
+package roles
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+type AuthorizationExplanationResult struct {
+	Item         *AuthorizationExplanation
+	responseBody *bytes.Buffer
+	responseMap  map[string]interface{}
+}
+
+func (n AuthorizationExplanationResult) GetItem() interface{} {
+	return n.Item
+}
+
+func (n AuthorizationExplanationResult) GetResponseBody() *bytes.Buffer {
+	return n.responseBody
+}
+
+func (n AuthorizationExplanationResult) GetResponseMap() map[string]interface{} {
+	return n.responseMap
+}
+
+// Explain explains whether the grants of the user allow the action on the
+// resource in the given scope, returning how each grant applying to the scope
+// was evaluated.
+func (c *Client) Explain(ctx context.Context, scopeId, userId, resourceId, action string, opt ...Option) (*AuthorizationExplanationResult, error) {
+	switch {
+	case scopeId == "":
+		return nil, fmt.Errorf("empty scopeId value passed into Explain request")
+	case userId == "":
+		return nil, fmt.Errorf("empty userId value passed into Explain request")
+	case resourceId == "":
+		return nil, fmt.Errorf("empty resourceId value passed into Explain request")
+	case action == "":
+		return nil, fmt.Errorf("empty action value passed into Explain request")
+	}
+	if c.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	opts.queryMap["scope_id"] = scopeId
+	opts.queryMap["user_id"] = userId
+	opts.queryMap["resource_id"] = resourceId
+	opts.queryMap["action"] = action
+
+	req, err := c.client.NewRequest(ctx, "GET", "roles:explain", nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Explain request: %w", err)
+	}
+
+	q := url.Values{}
+	for k, v := range opts.queryMap {
+		q.Add(k, v)
+	}
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Explain call: %w", err)
+	}
+
+	target := new(AuthorizationExplanationResult)
+	target.Item = new(AuthorizationExplanation)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Explain response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.responseBody = resp.Body
+	target.responseMap = resp.Map
+	return target, nil
+}
@@ -2,7 +2,9 @@
 package roles
 
 type GrantJson struct {
-	Id      string   `json:"id,omitempty"`
-	Type    string   `json:"type,omitempty"`
-	Actions []string `json:"actions,omitempty"`
+	Id           string   `json:"id,omitempty"`
+	Type         string   `json:"type,omitempty"`
+	Actions      []string `json:"actions,omitempty"`
+	Effect       string   `json:"effect,omitempty"`
+	OutputFields []string `json:"output_fields,omitempty"`
 }
@@ -1,6 +1,9 @@
 package roles
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/hashicorp/boundary/api"
 )
 
@@ -45,6 +48,31 @@ func WithAutomaticVersioning(enable bool) Option {
 	}
 }
 
+// WithFilter tells the API to only return resources matching the given filter
+// expression when listing, e.g. "/item/name" == "prod". Only valid for List.
+func WithFilter(filter string) Option {
+	return func(o *options) {
+		o.queryMap["filter"] = filter
+	}
+}
+
+// WithPageSize tells the API to return at most the given number of resources
+// when listing. The NextPageToken of the result can be passed to WithPageToken
+// to fetch the next page. Only valid for List.
+func WithPageSize(pageSize uint32) Option {
+	return func(o *options) {
+		o.queryMap["page_size"] = fmt.Sprintf("%d", pageSize)
+	}
+}
+
+// WithPageToken tells the API to continue listing after the page that
+// returned the given token. Only valid for List.
+func WithPageToken(pageToken string) Option {
+	return func(o *options) {
+		o.queryMap["page_token"] = pageToken
+	}
+}
+
 func WithDescription(inDescription string) Option {
 	return func(o *options) {
 		o.postMap["description"] = inDescription
@@ -57,6 +85,12 @@ func DefaultDescription() Option {
 	}
 }
 
+func WithExpirationTime(inExpirationTime time.Time) Option {
+	return func(o *options) {
+		o.postMap["expiration_time"] = inExpirationTime
+	}
+}
+
 func WithGrantScopeId(inGrantScopeId string) Option {
 	return func(o *options) {
 		o.postMap["grant_scope_id"] = inGrantScopeId
@@ -80,3 +114,15 @@ func DefaultName() Option {
 		o.postMap["name"] = nil
 	}
 }
+
+func WithNotBeforeTime(inNotBeforeTime time.Time) Option {
+	return func(o *options) {
+		o.postMap["not_before_time"] = inNotBeforeTime
+	}
+}
+
+func WithRecursive(inRecursive bool) Option {
+	return func(o *options) {
+		o.queryMap["recursive"] = fmt.Sprintf("%v", inRecursive)
+	}
+}
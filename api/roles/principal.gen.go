@@ -1,8 +1,14 @@
 // Code generated by "make api"; DO NOT EDIT.
 package roles
 
+import (
+	"time"
+)
+
 type Principal struct {
-	Id      string `json:"id,omitempty"`
-	Type    string `json:"type,omitempty"`
-	ScopeId string `json:"scope_id,omitempty"`
+	Id             string    `json:"id,omitempty"`
+	Type           string    `json:"type,omitempty"`
+	ScopeId        string    `json:"scope_id,omitempty"`
+	NotBeforeTime  time.Time `json:"not_before_time,omitempty"`
+	ExpirationTime time.Time `json:"expiration_time,omitempty"`
 }
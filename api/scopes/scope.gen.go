@@ -13,15 +13,16 @@ import (
 )
 
 type Scope struct {
-	Id          string     `json:"id,omitempty"`
-	ScopeId     string     `json:"scope_id,omitempty"`
-	Scope       *ScopeInfo `json:"scope,omitempty"`
-	Name        string     `json:"name,omitempty"`
-	Description string     `json:"description,omitempty"`
-	CreatedTime time.Time  `json:"created_time,omitempty"`
-	UpdatedTime time.Time  `json:"updated_time,omitempty"`
-	Version     uint32     `json:"version,omitempty"`
-	Type        string     `json:"type,omitempty"`
+	Id                string     `json:"id,omitempty"`
+	ScopeId           string     `json:"scope_id,omitempty"`
+	Scope             *ScopeInfo `json:"scope,omitempty"`
+	Name              string     `json:"name,omitempty"`
+	Description       string     `json:"description,omitempty"`
+	CreatedTime       time.Time  `json:"created_time,omitempty"`
+	UpdatedTime       time.Time  `json:"updated_time,omitempty"`
+	Version           uint32     `json:"version,omitempty"`
+	Type              string     `json:"type,omitempty"`
+	AuthorizedActions []string   `json:"authorized_actions,omitempty"`
 
 	responseBody *bytes.Buffer
 	responseMap  map[string]interface{}
@@ -70,15 +71,20 @@ func (n ScopeDeleteResult) GetResponseMap() map[string]interface{} {
 }
 
 type ScopeListResult struct {
-	Items        []*Scope
-	responseBody *bytes.Buffer
-	responseMap  map[string]interface{}
+	Items         []*Scope
+	NextPageToken string `json:"next_page_token,omitempty"`
+	responseBody  *bytes.Buffer
+	responseMap   map[string]interface{}
 }
 
 func (n ScopeListResult) GetItems() interface{} {
 	return n.Items
 }
 
+func (n ScopeListResult) GetNextPageToken() string {
+	return n.NextPageToken
+}
+
 func (n ScopeListResult) GetResponseBody() *bytes.Buffer {
 	return n.responseBody
 }
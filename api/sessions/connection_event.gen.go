@@ -0,0 +1,22 @@
+// Code generated by "make api"; DO NOT EDIT.
+package sessions
+
+import (
+	"time"
+)
+
+type SessionConnectionEvent struct {
+	Type             string    `json:"type,omitempty"`
+	Time             time.Time `json:"time,omitempty"`
+	SessionId        string    `json:"session_id,omitempty"`
+	ConnectionId     string    `json:"connection_id,omitempty"`
+	ScopeId          string    `json:"scope_id,omitempty"`
+	TargetId         string    `json:"target_id,omitempty"`
+	UserId           string    `json:"user_id,omitempty"`
+	WorkerId         string    `json:"worker_id,omitempty"`
+	ClientTcpAddress string    `json:"client_tcp_address,omitempty"`
+	ClientTcpPort    uint32    `json:"client_tcp_port,omitempty"`
+	BytesUp          uint64    `json:"bytes_up,omitempty,string"`
+	BytesDown        uint64    `json:"bytes_down,omitempty,string"`
+	ClosedReason     string    `json:"closed_reason,omitempty"`
+}
@@ -0,0 +1,75 @@
+package sessions
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+type SessionRecordingListResult struct {
+	Items        []*SessionRecording
+	responseBody *bytes.Buffer
+	responseMap  map[string]interface{}
+}
+
+func (n SessionRecordingListResult) GetItems() interface{} {
+	return n.Items
+}
+
+func (n SessionRecordingListResult) GetResponseBody() *bytes.Buffer {
+	return n.responseBody
+}
+
+func (n SessionRecordingListResult) GetResponseMap() map[string]interface{} {
+	return n.responseMap
+}
+
+// DownloadRecording fetches the recordings of the channels of a session. If
+// connectionId is not empty only the recordings of that connection are
+// returned.
+func (c *Client) DownloadRecording(ctx context.Context, sessionId, connectionId string, opt ...Option) (*SessionRecordingListResult, error) {
+	if sessionId == "" {
+		return nil, fmt.Errorf("empty sessionId value passed into DownloadRecording request")
+	}
+	if c.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	if connectionId != "" {
+		opts.queryMap["connection_id"] = connectionId
+	}
+
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("sessions/%s:download-recording", sessionId), nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating DownloadRecording request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during DownloadRecording call: %w", err)
+	}
+
+	target := new(SessionRecordingListResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding DownloadRecording response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.responseBody = resp.Body
+	target.responseMap = resp.Map
+	return target, nil
+}
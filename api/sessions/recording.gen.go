@@ -0,0 +1,16 @@
+// Code generated by "make api"; DO NOT EDIT.
+package sessions
+
+import (
+	"time"
+)
+
+type SessionRecording struct {
+	Id           string    `json:"id,omitempty"`
+	SessionId    string    `json:"session_id,omitempty"`
+	ConnectionId string    `json:"connection_id,omitempty"`
+	ChannelId    uint32    `json:"channel_id,omitempty"`
+	StartTime    time.Time `json:"start_time,omitempty"`
+	EndTime      time.Time `json:"end_time,omitempty"`
+	Asciicast    string    `json:"asciicast,omitempty"`
+}
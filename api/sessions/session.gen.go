@@ -32,6 +32,7 @@ type Session struct {
 	WorkerInfo        []*WorkerInfo     `json:"worker_info,omitempty"`
 	Certificate       []byte            `json:"certificate,omitempty"`
 	TerminationReason string            `json:"termination_reason,omitempty"`
+	AuthorizedActions []string          `json:"authorized_actions,omitempty"`
 
 	responseBody *bytes.Buffer
 	responseMap  map[string]interface{}
@@ -80,15 +81,20 @@ func (n SessionDeleteResult) GetResponseMap() map[string]interface{} {
 }
 
 type SessionListResult struct {
-	Items        []*Session
-	responseBody *bytes.Buffer
-	responseMap  map[string]interface{}
+	Items         []*Session
+	NextPageToken string `json:"next_page_token,omitempty"`
+	responseBody  *bytes.Buffer
+	responseMap   map[string]interface{}
 }
 
 func (n SessionListResult) GetItems() interface{} {
 	return n.Items
 }
 
+func (n SessionListResult) GetNextPageToken() string {
+	return n.NextPageToken
+}
+
 func (n SessionListResult) GetResponseBody() *bytes.Buffer {
 	return n.responseBody
 }
@@ -0,0 +1,57 @@
+package sessions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// TerminateConnection closes a single connection of a session, leaving the
+// session and its other connections open.
+func (c *Client) TerminateConnection(ctx context.Context, sessionId, connectionId string, opt ...Option) (*SessionUpdateResult, error) {
+	if sessionId == "" {
+		return nil, fmt.Errorf("empty sessionId value passed into TerminateConnection request")
+	}
+	if connectionId == "" {
+		return nil, fmt.Errorf("empty connectionId value passed into TerminateConnection request")
+	}
+	if c.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	opts.postMap["connection_id"] = connectionId
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("sessions/%s:terminate-connection", sessionId), opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating TerminateConnection request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during TerminateConnection call: %w", err)
+	}
+
+	target := new(SessionUpdateResult)
+	target.Item = new(Session)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding TerminateConnection response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.responseBody = resp.Body
+	target.responseMap = resp.Map
+	return target, nil
+}
@@ -0,0 +1,91 @@
+package sessions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Watch streams the events of the sessions in the given scope, or in it and
+// its descendants if WithRecursive is set, and of their connections to fn. The
+// controller ends each stream after about 25 seconds and Watch opens another
+// one, until ctx is done or fn returns an error, which Watch returns. Events
+// happening between two streams may be missed. Status events are only
+// delivered for the workers reporting to the controller serving a stream.
+func (c *Client) Watch(ctx context.Context, scopeId string, fn func(*SessionConnectionEvent) error, opt ...Option) error {
+	if scopeId == "" {
+		return fmt.Errorf("empty scopeId value passed into Watch request")
+	}
+	if fn == nil {
+		return fmt.Errorf("nil fn value passed into Watch request")
+	}
+	if c.client == nil {
+		return errors.New("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+	opts.queryMap["scope_id"] = scopeId
+
+	for ctx.Err() == nil {
+		req, err := c.client.NewRequest(ctx, "GET", "sessions:watch", nil, apiOpts...)
+		if err != nil {
+			return fmt.Errorf("error creating Watch request: %w", err)
+		}
+
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			return fmt.Errorf("error performing client request during Watch call: %w", err)
+		}
+
+		if resp.HttpResponse().StatusCode != http.StatusOK {
+			apiErr, err := resp.Decode(nil)
+			if err != nil {
+				return fmt.Errorf("error decoding Watch response: %w", err)
+			}
+			if apiErr != nil {
+				return apiErr
+			}
+			return fmt.Errorf("unexpected status code %d in Watch response", resp.HttpResponse().StatusCode)
+		}
+
+		if err := decodeEvents(resp.HttpResponse().Body, fn); err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeEvents passes the newline-delimited events of body to fn until the
+// end of body, and closes it
+func decodeEvents(body io.ReadCloser, fn func(*SessionConnectionEvent) error) error {
+	defer body.Close()
+	dec := json.NewDecoder(body)
+	for {
+		event := new(SessionConnectionEvent)
+		switch err := dec.Decode(event); {
+		case err == io.EOF:
+			return nil
+		case err != nil:
+			return fmt.Errorf("error decoding Watch response: %w", err)
+		}
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package targets
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/boundary/api"
 )
 
@@ -45,6 +47,31 @@ func WithAutomaticVersioning(enable bool) Option {
 	}
 }
 
+// WithFilter tells the API to only return resources matching the given filter
+// expression when listing, e.g. "/item/name" == "prod". Only valid for List.
+func WithFilter(filter string) Option {
+	return func(o *options) {
+		o.queryMap["filter"] = filter
+	}
+}
+
+// WithPageSize tells the API to return at most the given number of resources
+// when listing. The NextPageToken of the result can be passed to WithPageToken
+// to fetch the next page. Only valid for List.
+func WithPageSize(pageSize uint32) Option {
+	return func(o *options) {
+		o.queryMap["page_size"] = fmt.Sprintf("%d", pageSize)
+	}
+}
+
+// WithPageToken tells the API to continue listing after the page that
+// returned the given token. Only valid for List.
+func WithPageToken(pageToken string) Option {
+	return func(o *options) {
+		o.queryMap["page_token"] = pageToken
+	}
+}
+
 func WithAttributes(inAttributes map[string]interface{}) Option {
 	return func(o *options) {
 		o.postMap["attributes"] = inAttributes
@@ -57,6 +84,54 @@ func DefaultAttributes() Option {
 	}
 }
 
+func WithHttpTargetDefaultPort(inDefaultPort uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["default_port"] = inDefaultPort
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultHttpTargetDefaultPort() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["default_port"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithSshTargetDefaultPort(inDefaultPort uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["default_port"] = inDefaultPort
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultSshTargetDefaultPort() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["default_port"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithTcpTargetDefaultPort(inDefaultPort uint32) Option {
 	return func(o *options) {
 		raw, ok := o.postMap["attributes"]
@@ -81,6 +156,30 @@ func DefaultTcpTargetDefaultPort() Option {
 	}
 }
 
+func WithUdpTargetDefaultPort(inDefaultPort uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["default_port"] = inDefaultPort
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultUdpTargetDefaultPort() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["default_port"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithDescription(inDescription string) Option {
 	return func(o *options) {
 		o.postMap["description"] = inDescription
@@ -93,12 +192,108 @@ func DefaultDescription() Option {
 	}
 }
 
+func WithHttpTargetHeaders(inHeaders map[string]string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["headers"] = inHeaders
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultHttpTargetHeaders() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["headers"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithSshTargetHostCaKeys(inHostCaKeys string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["host_ca_keys"] = inHostCaKeys
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultSshTargetHostCaKeys() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["host_ca_keys"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithHostId(inHostId string) Option {
 	return func(o *options) {
 		o.postMap["host_id"] = inHostId
 	}
 }
 
+func WithSshTargetHostKeys(inHostKeys string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["host_keys"] = inHostKeys
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultSshTargetHostKeys() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["host_keys"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithSshTargetInsecureIgnoreHostKey(inInsecureIgnoreHostKey bool) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["insecure_ignore_host_key"] = inInsecureIgnoreHostKey
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultSshTargetInsecureIgnoreHostKey() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["insecure_ignore_host_key"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithName(inName string) Option {
 	return func(o *options) {
 		o.postMap["name"] = inName
@@ -111,6 +306,12 @@ func DefaultName() Option {
 	}
 }
 
+func WithRecursive(inRecursive bool) Option {
+	return func(o *options) {
+		o.queryMap["recursive"] = fmt.Sprintf("%v", inRecursive)
+	}
+}
+
 func WithScopeId(inScopeId string) Option {
 	return func(o *options) {
 		o.postMap["scope_id"] = inScopeId
@@ -135,6 +336,18 @@ func DefaultSessionConnectionLimit() Option {
 	}
 }
 
+func WithSessionIdleTimeoutSeconds(inSessionIdleTimeoutSeconds uint32) Option {
+	return func(o *options) {
+		o.postMap["session_idle_timeout_seconds"] = inSessionIdleTimeoutSeconds
+	}
+}
+
+func DefaultSessionIdleTimeoutSeconds() Option {
+	return func(o *options) {
+		o.postMap["session_idle_timeout_seconds"] = nil
+	}
+}
+
 func WithSessionMaxSeconds(inSessionMaxSeconds uint32) Option {
 	return func(o *options) {
 		o.postMap["session_max_seconds"] = inSessionMaxSeconds
@@ -146,3 +359,15 @@ func DefaultSessionMaxSeconds() Option {
 		o.postMap["session_max_seconds"] = nil
 	}
 }
+
+func WithWorkerFilter(inWorkerFilter string) Option {
+	return func(o *options) {
+		o.postMap["worker_filter"] = inWorkerFilter
+	}
+}
+
+func DefaultWorkerFilter() Option {
+	return func(o *options) {
+		o.postMap["worker_filter"] = nil
+	}
+}
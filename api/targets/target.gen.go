@@ -14,20 +14,24 @@ import (
 )
 
 type Target struct {
-	Id                     string                 `json:"id,omitempty"`
-	ScopeId                string                 `json:"scope_id,omitempty"`
-	Scope                  *scopes.ScopeInfo      `json:"scope,omitempty"`
-	Name                   string                 `json:"name,omitempty"`
-	Description            string                 `json:"description,omitempty"`
-	CreatedTime            time.Time              `json:"created_time,omitempty"`
-	UpdatedTime            time.Time              `json:"updated_time,omitempty"`
-	Version                uint32                 `json:"version,omitempty"`
-	Type                   string                 `json:"type,omitempty"`
-	HostSetIds             []string               `json:"host_set_ids,omitempty"`
-	HostSets               []*HostSet             `json:"host_sets,omitempty"`
-	SessionMaxSeconds      uint32                 `json:"session_max_seconds,omitempty"`
-	SessionConnectionLimit int32                  `json:"session_connection_limit,omitempty"`
-	Attributes             map[string]interface{} `json:"attributes,omitempty"`
+	Id                        string                 `json:"id,omitempty"`
+	ScopeId                   string                 `json:"scope_id,omitempty"`
+	Scope                     *scopes.ScopeInfo      `json:"scope,omitempty"`
+	Name                      string                 `json:"name,omitempty"`
+	Description               string                 `json:"description,omitempty"`
+	CreatedTime               time.Time              `json:"created_time,omitempty"`
+	UpdatedTime               time.Time              `json:"updated_time,omitempty"`
+	Version                   uint32                 `json:"version,omitempty"`
+	Type                      string                 `json:"type,omitempty"`
+	HostSetIds                []string               `json:"host_set_ids,omitempty"`
+	HostSets                  []*HostSet             `json:"host_sets,omitempty"`
+	SessionMaxSeconds         uint32                 `json:"session_max_seconds,omitempty"`
+	SessionConnectionLimit    int32                  `json:"session_connection_limit,omitempty"`
+	WorkerFilter              string                 `json:"worker_filter,omitempty"`
+	SessionIdleTimeoutSeconds uint32                 `json:"session_idle_timeout_seconds,omitempty"`
+	CredentialIds             []string               `json:"credential_ids,omitempty"`
+	Attributes                map[string]interface{} `json:"attributes,omitempty"`
+	AuthorizedActions         []string               `json:"authorized_actions,omitempty"`
 
 	responseBody *bytes.Buffer
 	responseMap  map[string]interface{}
@@ -76,15 +80,20 @@ func (n TargetDeleteResult) GetResponseMap() map[string]interface{} {
 }
 
 type TargetListResult struct {
-	Items        []*Target
-	responseBody *bytes.Buffer
-	responseMap  map[string]interface{}
+	Items         []*Target
+	NextPageToken string `json:"next_page_token,omitempty"`
+	responseBody  *bytes.Buffer
+	responseMap   map[string]interface{}
 }
 
 func (n TargetListResult) GetItems() interface{} {
 	return n.Items
 }
 
+func (n TargetListResult) GetNextPageToken() string {
+	return n.NextPageToken
+}
+
 func (n TargetListResult) GetResponseBody() *bytes.Buffer {
 	return n.responseBody
 }
@@ -352,6 +361,74 @@ func (c *Client) List(ctx context.Context, scopeId string, opt ...Option) (*Targ
 	return target, nil
 }
 
+func (c *Client) AddCredentials(ctx context.Context, targetId string, version uint32, credentialIds []string, opt ...Option) (*TargetUpdateResult, error) {
+	if targetId == "" {
+		return nil, fmt.Errorf("empty targetId value passed into AddCredentials request")
+	}
+	if len(credentialIds) == 0 {
+		return nil, errors.New("empty credentialIds passed into AddCredentials request")
+	}
+	if c.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	if version == 0 {
+		if !opts.withAutomaticVersioning {
+			return nil, errors.New("zero version number passed into AddCredentials request")
+		}
+		existingTarget, existingErr := c.Read(ctx, targetId, opt...)
+		if existingErr != nil {
+			if api.AsServerError(existingErr) != nil {
+				return nil, fmt.Errorf("error from controller when performing initial check-and-set read: %w", existingErr)
+			}
+			return nil, fmt.Errorf("error performing initial check-and-set read: %w", existingErr)
+		}
+		if existingTarget == nil {
+			return nil, errors.New("nil resource response found when performing initial check-and-set read")
+		}
+		if existingTarget.Item == nil {
+			return nil, errors.New("nil resource found when performing initial check-and-set read")
+		}
+		version = existingTarget.Item.Version
+	}
+
+	opts.postMap["version"] = version
+	opts.postMap["credential_ids"] = credentialIds
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("targets/%s:add-credentials", targetId), opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating AddCredentials request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during AddCredentials call: %w", err)
+	}
+
+	target := new(TargetUpdateResult)
+	target.Item = new(Target)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding AddCredentials response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.responseBody = resp.Body
+	target.responseMap = resp.Map
+	return target, nil
+}
+
 func (c *Client) AddHostSets(ctx context.Context, targetId string, version uint32, hostSetIds []string, opt ...Option) (*TargetUpdateResult, error) {
 	if targetId == "" {
 		return nil, fmt.Errorf("empty targetId value passed into AddHostSets request")
@@ -420,6 +497,72 @@ func (c *Client) AddHostSets(ctx context.Context, targetId string, version uint3
 	return target, nil
 }
 
+func (c *Client) SetCredentials(ctx context.Context, targetId string, version uint32, credentialIds []string, opt ...Option) (*TargetUpdateResult, error) {
+	if targetId == "" {
+		return nil, fmt.Errorf("empty targetId value passed into SetCredentials request")
+	}
+
+	if c.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	if version == 0 {
+		if !opts.withAutomaticVersioning {
+			return nil, errors.New("zero version number passed into SetCredentials request")
+		}
+		existingTarget, existingErr := c.Read(ctx, targetId, opt...)
+		if existingErr != nil {
+			if api.AsServerError(existingErr) != nil {
+				return nil, fmt.Errorf("error from controller when performing initial check-and-set read: %w", existingErr)
+			}
+			return nil, fmt.Errorf("error performing initial check-and-set read: %w", existingErr)
+		}
+		if existingTarget == nil {
+			return nil, errors.New("nil resource response found when performing initial check-and-set read")
+		}
+		if existingTarget.Item == nil {
+			return nil, errors.New("nil resource found when performing initial check-and-set read")
+		}
+		version = existingTarget.Item.Version
+	}
+
+	opts.postMap["version"] = version
+	opts.postMap["credential_ids"] = credentialIds
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("targets/%s:set-credentials", targetId), opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating SetCredentials request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during SetCredentials call: %w", err)
+	}
+
+	target := new(TargetUpdateResult)
+	target.Item = new(Target)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding SetCredentials response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.responseBody = resp.Body
+	target.responseMap = resp.Map
+	return target, nil
+}
+
 func (c *Client) SetHostSets(ctx context.Context, targetId string, version uint32, hostSetIds []string, opt ...Option) (*TargetUpdateResult, error) {
 	if targetId == "" {
 		return nil, fmt.Errorf("empty targetId value passed into SetHostSets request")
@@ -486,6 +629,74 @@ func (c *Client) SetHostSets(ctx context.Context, targetId string, version uint3
 	return target, nil
 }
 
+func (c *Client) RemoveCredentials(ctx context.Context, targetId string, version uint32, credentialIds []string, opt ...Option) (*TargetUpdateResult, error) {
+	if targetId == "" {
+		return nil, fmt.Errorf("empty targetId value passed into RemoveCredentials request")
+	}
+	if len(credentialIds) == 0 {
+		return nil, errors.New("empty credentialIds passed into RemoveCredentials request")
+	}
+	if c.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	if version == 0 {
+		if !opts.withAutomaticVersioning {
+			return nil, errors.New("zero version number passed into RemoveCredentials request")
+		}
+		existingTarget, existingErr := c.Read(ctx, targetId, opt...)
+		if existingErr != nil {
+			if api.AsServerError(existingErr) != nil {
+				return nil, fmt.Errorf("error from controller when performing initial check-and-set read: %w", existingErr)
+			}
+			return nil, fmt.Errorf("error performing initial check-and-set read: %w", existingErr)
+		}
+		if existingTarget == nil {
+			return nil, errors.New("nil resource response found when performing initial check-and-set read")
+		}
+		if existingTarget.Item == nil {
+			return nil, errors.New("nil resource found when performing initial check-and-set read")
+		}
+		version = existingTarget.Item.Version
+	}
+
+	opts.postMap["version"] = version
+	opts.postMap["credential_ids"] = credentialIds
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("targets/%s:remove-credentials", targetId), opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating RemoveCredentials request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during RemoveCredentials call: %w", err)
+	}
+
+	target := new(TargetUpdateResult)
+	target.Item = new(Target)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding RemoveCredentials response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.responseBody = resp.Body
+	target.responseMap = resp.Map
+	return target, nil
+}
+
 func (c *Client) RemoveHostSets(ctx context.Context, targetId string, version uint32, hostSetIds []string, opt ...Option) (*TargetUpdateResult, error) {
 	if targetId == "" {
 		return nil, fmt.Errorf("empty targetId value passed into RemoveHostSets request")
@@ -23,6 +23,7 @@ type GenericDeleteResult interface {
 
 type GenericListResult interface {
 	GetItems() interface{}
+	GetNextPageToken() string
 	GetResponseBody() *bytes.Buffer
 	GetResponseMap() map[string]interface{}
 }
@@ -12,6 +12,13 @@ import "time"
 const (
 	TcpProxyV1     = "boundary-tcp-proxy-v1"
 	ServiceTokenV1 = "s1"
+
+	// UdpProxyV1 carries each datagram of a udp target session in its own
+	// binary websocket message
+	UdpProxyV1 = "boundary-udp-proxy-v1"
+
+	// MaxUdpDatagramSize is the largest datagram relayed for udp targets
+	MaxUdpDatagramSize = 65535
 )
 
 type ContextMaxRequestSizeType int
@@ -10,9 +10,12 @@ require (
 	github.com/99designs/keyring v1.1.6
 	github.com/armon/go-metrics v0.3.4
 	github.com/bufbuild/buf v0.30.0
+	github.com/coreos/go-oidc v2.2.1+incompatible
 	github.com/fatih/color v1.10.0
 	github.com/favadi/protoc-go-inject-tag v1.1.0
+	github.com/go-asn1-ber/asn1-ber v1.5.1
 	github.com/go-bindata/go-bindata/v3 v3.1.3
+	github.com/go-ldap/ldap/v3 v3.3.0
 	github.com/go-swagger/go-swagger v0.25.0
 	github.com/golang-migrate/migrate/v4 v4.13.0
 	github.com/golang-sql/civil v0.0.0-20190719163853-cb61b32ac6fe
@@ -23,6 +26,7 @@ require (
 	github.com/hashicorp/boundary/sdk v0.0.1
 	github.com/hashicorp/dbassert v0.0.0-20200930125617-6218396928df
 	github.com/hashicorp/errwrap v1.1.0
+	github.com/hashicorp/go-bexpr v0.1.10
 	github.com/hashicorp/go-cleanhttp v0.5.1
 	github.com/hashicorp/go-hclog v0.15.0
 	github.com/hashicorp/go-kms-wrapping v0.5.16
@@ -32,6 +36,7 @@ require (
 	github.com/hashicorp/hcl v1.0.0
 	github.com/hashicorp/shared-secure-libs v0.0.2
 	github.com/hashicorp/vault/sdk v0.1.14-0.20200916184745-5576096032f8
+	github.com/hashicorp/yamux v0.0.0-20181012175058-2f1d1f20f75d
 	github.com/iancoleman/strcase v0.1.2
 	github.com/jackc/pgx/v4 v4.9.2
 	github.com/jinzhu/gorm v1.9.16
@@ -42,6 +47,7 @@ require (
 	github.com/mitchellh/cli v1.1.2
 	github.com/mitchellh/go-wordwrap v1.0.1
 	github.com/mitchellh/gox v1.0.1
+	github.com/mitchellh/pointerstructure v1.2.0
 	github.com/mr-tron/base58 v1.2.0
 	github.com/oligot/go-mod-upgrade v0.2.1
 	github.com/ory/dockertest/v3 v3.6.0
@@ -49,14 +55,16 @@ require (
 	github.com/pires/go-proxyproto v0.3.1
 	github.com/pkg/errors v0.9.1
 	github.com/posener/complete v1.2.3
-	github.com/stretchr/testify v1.6.1
+	github.com/stretchr/testify v1.7.0
 	github.com/zalando/go-keyring v0.1.0
 	go.uber.org/atomic v1.7.0
 	golang.org/x/crypto v0.0.0-20201016220609-9e8e0b390897
+	golang.org/x/oauth2 v0.0.0-20200902213428-5d25da1a8d43
 	golang.org/x/tools v0.0.0-20201111133315-69daaf961d65
 	google.golang.org/genproto v0.0.0-20201111145450-ac7456db90a6
 	google.golang.org/grpc v1.33.2
 	google.golang.org/grpc/cmd/protoc-gen-go-grpc v1.0.1
 	google.golang.org/protobuf v1.25.0
+	gopkg.in/square/go-jose.v2 v2.5.1
 	nhooyr.io/websocket v1.8.6
 )
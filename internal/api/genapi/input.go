@@ -4,9 +4,12 @@ import (
 	"text/template"
 
 	"github.com/hashicorp/boundary/internal/gen/controller/api"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/accessrequests"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/accounts"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/authmethods"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/authtokens"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/credentials"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/credentialstores"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/groups"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/hostcatalogs"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/hosts"
@@ -33,6 +36,8 @@ type fieldInfo struct {
 	SubtypeName       string
 	Query             bool
 	SkipDefault       bool
+	// JsonString is set for 64-bit integers, which the API encodes as strings
+	JsonString bool
 }
 
 type structInfo struct {
@@ -138,6 +143,13 @@ var inputStructs = []*structInfo{
 				FieldType: "bool",
 				Query:     true,
 			},
+			{
+				Name:        "Recursive",
+				ProtoName:   "recursive",
+				FieldType:   "bool",
+				Query:       true,
+				SkipDefault: true,
+			},
 		},
 		versionEnabled:      true,
 		createResponseTypes: true,
@@ -162,7 +174,16 @@ var inputStructs = []*structInfo{
 		sliceSubTypes: map[string]string{
 			"Accounts": "accountIds",
 		},
-		pathArgs:            []string{"user"},
+		pathArgs: []string{"user"},
+		extraOptions: []fieldInfo{
+			{
+				Name:        "Recursive",
+				ProtoName:   "recursive",
+				FieldType:   "bool",
+				Query:       true,
+				SkipDefault: true,
+			},
+		},
 		versionEnabled:      true,
 		createResponseTypes: true,
 	},
@@ -186,7 +207,16 @@ var inputStructs = []*structInfo{
 		sliceSubTypes: map[string]string{
 			"Members": "memberIds",
 		},
-		pathArgs:            []string{"group"},
+		pathArgs: []string{"group"},
+		extraOptions: []fieldInfo{
+			{
+				Name:        "Recursive",
+				ProtoName:   "recursive",
+				FieldType:   "bool",
+				Query:       true,
+				SkipDefault: true,
+			},
+		},
 		versionEnabled:      true,
 		createResponseTypes: true,
 	},
@@ -206,6 +236,16 @@ var inputStructs = []*structInfo{
 		outFile:    "roles/grant_json.gen.go",
 		outputOnly: true,
 	},
+	{
+		inProto:    &roles.AuthorizationExplanation{},
+		outFile:    "roles/authorization_explanation.gen.go",
+		outputOnly: true,
+	},
+	{
+		inProto:    &roles.GrantExplanation{},
+		outFile:    "roles/grant_explanation.gen.go",
+		outputOnly: true,
+	},
 	{
 		inProto: &roles.Role{},
 		outFile: "roles/role.gen.go",
@@ -221,7 +261,28 @@ var inputStructs = []*structInfo{
 			"Principals": "principalIds",
 			"Grants":     "grantStrings",
 		},
-		pathArgs:            []string{"role"},
+		pathArgs: []string{"role"},
+		extraOptions: []fieldInfo{
+			{
+				Name:        "Recursive",
+				ProtoName:   "recursive",
+				FieldType:   "bool",
+				Query:       true,
+				SkipDefault: true,
+			},
+			{
+				Name:        "NotBeforeTime",
+				ProtoName:   "not_before_time",
+				FieldType:   "time.Time",
+				SkipDefault: true,
+			},
+			{
+				Name:        "ExpirationTime",
+				ProtoName:   "expiration_time",
+				FieldType:   "time.Time",
+				SkipDefault: true,
+			},
+		},
 		versionEnabled:      true,
 		createResponseTypes: true,
 	},
@@ -237,7 +298,16 @@ var inputStructs = []*structInfo{
 			deleteTemplate,
 			listTemplate,
 		},
-		pathArgs:            []string{"auth-method"},
+		pathArgs: []string{"auth-method"},
+		extraOptions: []fieldInfo{
+			{
+				Name:        "Recursive",
+				ProtoName:   "recursive",
+				FieldType:   "bool",
+				Query:       true,
+				SkipDefault: true,
+			},
+		},
 		typeOnCreate:        true,
 		versionEnabled:      true,
 		createResponseTypes: true,
@@ -247,6 +317,16 @@ var inputStructs = []*structInfo{
 		outFile:     "authmethods/password_auth_method_attributes.gen.go",
 		subtypeName: "PasswordAuthMethod",
 	},
+	{
+		inProto:     &authmethods.OidcAuthMethodAttributes{},
+		outFile:     "authmethods/oidc_auth_method_attributes.gen.go",
+		subtypeName: "OidcAuthMethod",
+	},
+	{
+		inProto:     &authmethods.LdapAuthMethodAttributes{},
+		outFile:     "authmethods/ldap_auth_method_attributes.gen.go",
+		subtypeName: "LdapAuthMethod",
+	},
 	// Accounts
 	{
 		inProto: &accounts.Account{},
@@ -279,7 +359,16 @@ var inputStructs = []*structInfo{
 			deleteTemplate,
 			listTemplate,
 		},
-		pathArgs:            []string{"auth-token"},
+		pathArgs: []string{"auth-token"},
+		extraOptions: []fieldInfo{
+			{
+				Name:        "Recursive",
+				ProtoName:   "recursive",
+				FieldType:   "bool",
+				Query:       true,
+				SkipDefault: true,
+			},
+		},
 		createResponseTypes: true,
 	},
 	// Host related resources
@@ -294,11 +383,25 @@ var inputStructs = []*structInfo{
 			deleteTemplate,
 			listTemplate,
 		},
-		pathArgs:            []string{"host-catalog"},
+		pathArgs: []string{"host-catalog"},
+		extraOptions: []fieldInfo{
+			{
+				Name:        "Recursive",
+				ProtoName:   "recursive",
+				FieldType:   "bool",
+				Query:       true,
+				SkipDefault: true,
+			},
+		},
 		typeOnCreate:        true,
 		versionEnabled:      true,
 		createResponseTypes: true,
 	},
+	{
+		inProto:     &hostcatalogs.PluginHostCatalogAttributes{},
+		outFile:     "hostcatalogs/plugin_host_catalog_attributes.gen.go",
+		subtypeName: "PluginHostCatalog",
+	},
 	{
 		inProto: &hosts.Host{},
 		outFile: "hosts/host.gen.go",
@@ -339,6 +442,58 @@ var inputStructs = []*structInfo{
 		versionEnabled:      true,
 		createResponseTypes: true,
 	},
+	{
+		inProto:     &hostsets.PluginHostSetAttributes{},
+		outFile:     "hostsets/plugin_host_set_attributes.gen.go",
+		subtypeName: "PluginHostSet",
+	},
+	// Credential related resources
+	{
+		inProto: &credentialstores.CredentialStore{},
+		outFile: "credentialstores/credential_store.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			createTemplate,
+			readTemplate,
+			updateTemplate,
+			deleteTemplate,
+			listTemplate,
+		},
+		pathArgs: []string{"credential-store"},
+		extraOptions: []fieldInfo{
+			{
+				Name:        "Recursive",
+				ProtoName:   "recursive",
+				FieldType:   "bool",
+				Query:       true,
+				SkipDefault: true,
+			},
+		},
+		typeOnCreate:        true,
+		versionEnabled:      true,
+		createResponseTypes: true,
+	},
+	{
+		inProto: &credentials.Credential{},
+		outFile: "credentials/credential.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			createTemplate,
+			readTemplate,
+			updateTemplate,
+			deleteTemplate,
+			listTemplate,
+		},
+		pathArgs:            []string{"credential"},
+		parentTypeName:      "credential-store",
+		versionEnabled:      true,
+		createResponseTypes: true,
+	},
+	{
+		inProto:     &credentials.UsernamePasswordCredentialAttributes{},
+		outFile:     "credentials/username_password_credential_attributes.gen.go",
+		subtypeName: "UsernamePasswordCredential",
+	},
 	{
 		inProto: &targets.HostSet{},
 		outFile: "targets/host_set.gen.go",
@@ -356,7 +511,8 @@ var inputStructs = []*structInfo{
 		},
 		pathArgs: []string{"target"},
 		sliceSubTypes: map[string]string{
-			"HostSets": "hostSetIds",
+			"HostSets":    "hostSetIds",
+			"Credentials": "credentialIds",
 		},
 		extraOptions: []fieldInfo{
 			{
@@ -377,6 +533,13 @@ var inputStructs = []*structInfo{
 				FieldType:   "string",
 				SkipDefault: true,
 			},
+			{
+				Name:        "Recursive",
+				ProtoName:   "recursive",
+				FieldType:   "bool",
+				Query:       true,
+				SkipDefault: true,
+			},
 		},
 		versionEnabled:      true,
 		typeOnCreate:        true,
@@ -387,6 +550,21 @@ var inputStructs = []*structInfo{
 		outFile:     "targets/tcp_target_attributes.gen.go",
 		subtypeName: "TcpTarget",
 	},
+	{
+		inProto:     &targets.HttpTargetAttributes{},
+		outFile:     "targets/http_target_attributes.gen.go",
+		subtypeName: "HttpTarget",
+	},
+	{
+		inProto:     &targets.SshTargetAttributes{},
+		outFile:     "targets/ssh_target_attributes.gen.go",
+		subtypeName: "SshTarget",
+	},
+	{
+		inProto:     &targets.UdpTargetAttributes{},
+		outFile:     "targets/udp_target_attributes.gen.go",
+		subtypeName: "UdpTarget",
+	},
 	{
 		inProto: &sessions.Session{},
 		outFile: "sessions/session.gen.go",
@@ -395,10 +573,39 @@ var inputStructs = []*structInfo{
 			readTemplate,
 			listTemplate,
 		},
-		pathArgs:            []string{"session"},
+		pathArgs: []string{"session"},
+		extraOptions: []fieldInfo{
+			{
+				Name:        "Recursive",
+				ProtoName:   "recursive",
+				FieldType:   "bool",
+				Query:       true,
+				SkipDefault: true,
+			},
+		},
 		createResponseTypes: true,
 		fieldFilter:         []string{"private_key"},
 	},
+	{
+		inProto: &accessrequests.AccessRequest{},
+		outFile: "accessrequests/access_request.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			readTemplate,
+			listTemplate,
+		},
+		pathArgs: []string{"access-request"},
+		extraOptions: []fieldInfo{
+			{
+				Name:        "Recursive",
+				ProtoName:   "recursive",
+				FieldType:   "bool",
+				Query:       true,
+				SkipDefault: true,
+			},
+		},
+		createResponseTypes: true,
+	},
 	{
 		inProto: &sessions.SessionState{},
 		outFile: "sessions/state.gen.go",
@@ -407,6 +614,14 @@ var inputStructs = []*structInfo{
 		inProto: &sessions.WorkerInfo{},
 		outFile: "sessions/workers.gen.go",
 	},
+	{
+		inProto: &sessions.SessionRecording{},
+		outFile: "sessions/recording.gen.go",
+	},
+	{
+		inProto: &sessions.SessionConnectionEvent{},
+		outFile: "sessions/connection_event.gen.go",
+	},
 	{
 		inProto:     &targets.SessionAuthorization{},
 		outFile:     "targets/session_authorization.gen.go",
@@ -417,4 +632,9 @@ var inputStructs = []*structInfo{
 		outFile:     "targets/worker_info.gen.go",
 		subtypeName: "WorkerInfo",
 	},
+	{
+		inProto:     &targets.SessionCredential{},
+		outFile:     "targets/session_credential.gen.go",
+		subtypeName: "SessionCredential",
+	},
 }
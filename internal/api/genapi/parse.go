@@ -59,17 +59,24 @@ func parsePBs() {
 			if proto.GetExtension(opts, protooptions.E_GenerateSdkOption).(bool) {
 				fi.GenerateSdkOption = true
 			}
-			switch k := fd.Kind(); k {
-			case protoreflect.MessageKind:
+			switch k := fd.Kind(); {
+			case fd.IsMap():
+				fi.FieldType = fmt.Sprintf("map[%s]%s", fd.MapKey().Kind().String(), fd.MapValue().Kind().String())
+			case k == protoreflect.MessageKind:
 				ptr, pkg, name := messageKind(fd)
 				if pkg != "" && pkg != in.generatedStructure.pkg {
 					name = fmt.Sprintf("%s.%s", pkg, name)
 				}
 				fi.FieldType = sliceText + ptr + name
-			case protoreflect.BytesKind:
+			case k == protoreflect.BytesKind:
 				fi.FieldType = "[]byte"
 			default:
 				fi.FieldType = sliceText + k.String()
+				switch k {
+				case protoreflect.Int64Kind, protoreflect.Uint64Kind,
+					protoreflect.Sint64Kind, protoreflect.Fixed64Kind, protoreflect.Sfixed64Kind:
+					fi.JsonString = sliceText == ""
+				}
 			}
 			in.generatedStructure.fields = append(in.generatedStructure.fields, fi)
 		}
@@ -90,13 +90,14 @@ func fillTemplates() {
 		// We want to generate options per-package, not per-struct, so we
 		// collate them all here for writing later. The map argument of the
 		// package map is to prevent duplicates since we may have multiple e.g.
-		// Name or Description fields.
+		// Name or Description fields. Subtype fields are keyed by subtype as
+		// well since subtypes may share attribute names.
 		if !in.outputOnly {
 			pkgOptionMap := map[string]fieldInfo{}
 			for _, val := range input.Fields {
 				if val.GenerateSdkOption {
 					val.SubtypeName = in.subtypeName
-					pkgOptionMap[val.Name] = val
+					pkgOptionMap[val.SubtypeName+val.Name] = val
 				}
 			}
 			optionMap := optionsMap[input.Package]
@@ -139,16 +140,16 @@ func fillTemplates() {
 	for pkg, options := range optionsMap {
 		outBuf := new(bytes.Buffer)
 
-		var fieldNames []string
-		for _, v := range options {
-			fieldNames = append(fieldNames, v.Name)
-		}
-		sort.Strings(fieldNames)
-
 		var fields []fieldInfo
-		for _, v := range fieldNames {
-			fields = append(fields, options[v])
+		for _, v := range options {
+			fields = append(fields, v)
 		}
+		sort.Slice(fields, func(i, j int) bool {
+			if fields[i].Name != fields[j].Name {
+				return fields[i].Name < fields[j].Name
+			}
+			return fields[i].SubtypeName < fields[j].SubtypeName
+		})
 
 		input := templateInput{
 			Package: pkg,
@@ -543,7 +544,7 @@ import (
 )
 
 type {{ .Name }} struct { {{ range .Fields }}
-{{ .Name }}  {{ .FieldType }} `, "`json:\"{{ .ProtoName }},omitempty\"`", `{{ end }}
+{{ .Name }}  {{ .FieldType }} `, "`json:\"{{ .ProtoName }},omitempty{{ if .JsonString }},string{{ end }}\"`", `{{ end }}
 {{ if ( or .CreateResponseTypes ( eq .Name "Error" ) ) }}
 	responseBody *bytes.Buffer
 	responseMap map[string]interface{}
@@ -597,6 +598,7 @@ func (n {{ .Name }}DeleteResult) GetResponseMap() map[string]interface{} {
 
 type {{ .Name }}ListResult struct {
 	Items []*{{ .Name }}
+	NextPageToken string `+"`json:\"next_page_token,omitempty\"`"+`
 	responseBody *bytes.Buffer
 	responseMap map[string]interface{}
 }
@@ -605,6 +607,10 @@ func (n {{ .Name }}ListResult) GetItems() interface{} {
 	return n.Items
 }
 
+func (n {{ .Name }}ListResult) GetNextPageToken() string {
+	return n.NextPageToken
+}
+
 func (n {{ .Name }}ListResult) GetResponseBody() *bytes.Buffer {
 	return n.responseBody
 }
@@ -686,6 +692,31 @@ func WithAutomaticVersioning(enable bool) Option {
 		o.withAutomaticVersioning = enable
 	}
 }
+
+// WithFilter tells the API to only return resources matching the given filter
+// expression when listing, e.g. "/item/name" == "prod". Only valid for List.
+func WithFilter(filter string) Option {
+	return func(o *options) {
+		o.queryMap["filter"] = filter
+	}
+}
+
+// WithPageSize tells the API to return at most the given number of resources
+// when listing. The NextPageToken of the result can be passed to WithPageToken
+// to fetch the next page. Only valid for List.
+func WithPageSize(pageSize uint32) Option {
+	return func(o *options) {
+		o.queryMap["page_size"] = fmt.Sprintf("%d", pageSize)
+	}
+}
+
+// WithPageToken tells the API to continue listing after the page that
+// returned the given token. Only valid for List.
+func WithPageToken(pageToken string) Option {
+	return func(o *options) {
+		o.queryMap["page_token"] = pageToken
+	}
+}
 {{ range .Fields }}
 func With{{ .SubtypeName }}{{ .Name }}(in{{ .Name }} {{ .FieldType }}) Option {
 	return func(o *options) {		{{ if ( not ( eq .SubtypeName "" ) ) }}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
@@ -23,6 +24,7 @@ import (
 	wrapping "github.com/hashicorp/go-kms-wrapping"
 	"github.com/kr/pretty"
 	"github.com/mr-tron/base58"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -68,6 +70,12 @@ type VerifyResults struct {
 	Error       error
 	Scope       *scopes.ScopeInfo
 
+	// AuthenticationFinished is true when the user was looked up and the
+	// authorization check was performed, even if it failed. A recursive list
+	// can proceed when it failed, as the user may still be authorized in the
+	// descendants of the requested scope.
+	AuthenticationFinished bool
+
 	// RoundTripValue can be set to allow the function performing authentication
 	// (often accompanied by lookup(s)) to return a result of that lookup to the
 	// calling function. It is opaque to this package.
@@ -146,16 +154,18 @@ func Verify(ctx context.Context, opt ...Option) (ret VerifyResults) {
 			ret.Scope.Type = scope.Project.String()
 		}
 		ret.UserId = v.requestInfo.userIdOverride
+		ret.AuthenticationFinished = true
 		ret.Error = nil
 		return
 	}
 
 	v.act = opts.withAction
 	v.res = &perms.Resource{
-		ScopeId: opts.withScopeId,
-		Id:      opts.withId,
-		Pin:     opts.withPin,
-		Type:    opts.withType,
+		ScopeId:    opts.withScopeId,
+		Id:         opts.withId,
+		Pin:        opts.withPin,
+		Type:       opts.withType,
+		Attributes: opts.withAttributes,
 	}
 	// Global scope has no parent ID; account for this
 	if opts.withId == scope.Global.String() && opts.withType == resource.Scope {
@@ -173,6 +183,7 @@ func Verify(ctx context.Context, opt ...Option) (ret VerifyResults) {
 		v.logger.Error("error performing authn/authz check", "error", err)
 		return
 	}
+	ret.AuthenticationFinished = true
 
 	ret.AuthTokenId = v.requestInfo.PublicId
 	if !authResults.Allowed {
@@ -212,10 +223,11 @@ func (r *VerifyResults) AdditionalVerification(ctx context.Context, opt ...Optio
 
 	act := opts.withAction
 	res := perms.Resource{
-		ScopeId: opts.withScopeId,
-		Id:      opts.withId,
-		Pin:     opts.withPin,
-		Type:    opts.withType,
+		ScopeId:    opts.withScopeId,
+		Id:         opts.withId,
+		Pin:        opts.withPin,
+		Type:       opts.withType,
+		Attributes: opts.withAttributes,
 	}
 	// Global scope has no parent ID; account for this
 	if opts.withId == scope.Global.String() && opts.withType == resource.Scope {
@@ -290,6 +302,184 @@ func (r *VerifyResults) AdditionalVerification(ctx context.Context, opt ...Optio
 	return
 }
 
+// FetchActionSetForId returns the actions out of availableActions that the
+// user is allowed to perform on the resource with the given id. The scope, pin
+// and type of the resource default to those of the request and can be
+// overridden with WithScopeId, WithPin and WithType, e.g. for resources listed
+// recursively out of descendant scopes. The attributes of the resource default
+// to those of the request if it is for the same resource and can be provided
+// with WithAttributes.
+func (r *VerifyResults) FetchActionSetForId(ctx context.Context, id string, availableActions action.ActionSet, opt ...Option) action.ActionSet {
+	v := r.v
+	if v == nil {
+		return nil
+	}
+	if v.requestInfo.DisableAuthEntirely ||
+		v.requestInfo.DisableAuthzFailures ||
+		v.requestInfo.TokenFormat == AuthTokenTypeRecoveryKms {
+		return availableActions
+	}
+
+	res := r.resourceForId(id, opt...)
+	ret := make(action.ActionSet, 0, len(availableActions))
+	for _, act := range availableActions {
+		if v.acl.Allowed(res, act).Allowed {
+			ret = append(ret, act)
+		}
+	}
+	return ret
+}
+
+// DeniedForId reports whether a deny grant matches the action on the resource
+// with the given id, which is described the same way as for
+// FetchActionSetForId. List RPCs use it to leave out the resources whose read
+// is denied. It is false if authorization is not being enforced.
+func (r *VerifyResults) DeniedForId(ctx context.Context, id string, a action.Type, opt ...Option) bool {
+	v := r.v
+	if v == nil {
+		return false
+	}
+	if v.requestInfo.DisableAuthEntirely ||
+		v.requestInfo.DisableAuthzFailures ||
+		v.requestInfo.TokenFormat == AuthTokenTypeRecoveryKms {
+		return false
+	}
+	return v.acl.Denied(r.resourceForId(id, opt...), a)
+}
+
+// FetchOutputFields returns the fields of the resource with the given id that
+// the user's grants allow to be returned in responses. The resource is
+// described the same way as for FetchActionSetForId. The result places no
+// restriction on the fields if authorization is not being enforced.
+func (r *VerifyResults) FetchOutputFields(ctx context.Context, id string, opt ...Option) perms.OutputFieldsMap {
+	v := r.v
+	if v == nil {
+		return nil
+	}
+	if v.requestInfo.DisableAuthEntirely ||
+		v.requestInfo.DisableAuthzFailures ||
+		v.requestInfo.TokenFormat == AuthTokenTypeRecoveryKms {
+		return nil
+	}
+	return v.acl.OutputFields(r.resourceForId(id, opt...))
+}
+
+// resourceForId returns the resource with the given id, with its scope, pin,
+// type and attributes defaulting to those of the request.
+func (r *VerifyResults) resourceForId(id string, opt ...Option) perms.Resource {
+	v := r.v
+	opts := getOpts(opt...)
+	res := perms.Resource{
+		ScopeId: r.Scope.GetId(),
+		Id:      id,
+	}
+	if v.res != nil {
+		res.Pin = v.res.Pin
+		res.Type = v.res.Type
+		if v.res.Id == id {
+			res.Attributes = v.res.Attributes
+		}
+	}
+	if opts.withScopeId != "" {
+		res.ScopeId = opts.withScopeId
+	}
+	if opts.withPin != "" {
+		res.Pin = opts.withPin
+	}
+	if opts.withType != resource.Unknown {
+		res.Type = opts.withType
+	}
+	if opts.withAttributes != nil {
+		res.Attributes = opts.withAttributes
+	}
+	return res
+}
+
+// ScopesForList returns the IDs of the scopes whose resources of the given
+// type a List request lists, along with their info keyed by scope ID. Unless
+// the request is recursive, this is the requested scope and the authorization
+// check must have succeeded. A recursive request lists the resources of the
+// requested scope and its descendants in which the user is authorized to list
+// them, which may be none of them.
+func (r *VerifyResults) ScopesForList(ctx context.Context, rt resource.Type, recursive bool) ([]string, map[string]*scopes.ScopeInfo, error) {
+	return r.ScopesForAction(ctx, rt, action.List, recursive)
+}
+
+// ScopesForAction is like ScopesForList for other actions performed on the
+// collection of resources of the given type in a scope, such as watching
+// sessions.
+func (r *VerifyResults) ScopesForAction(ctx context.Context, rt resource.Type, a action.Type, recursive bool) ([]string, map[string]*scopes.ScopeInfo, error) {
+	if r.Error != nil {
+		// A user that is authenticated but not authorized in the requested
+		// scope may still be authorized in its descendants
+		if !recursive || !r.AuthenticationFinished || !errors.Is(r.Error, handlers.ForbiddenError()) {
+			return nil, nil, r.Error
+		}
+	}
+	if !recursive {
+		return []string{r.Scope.GetId()}, map[string]*scopes.ScopeInfo{r.Scope.GetId(): r.Scope}, nil
+	}
+	infos, err := r.scopesAuthorized(ctx, r.Scope.GetId(), rt, a)
+	if err != nil {
+		return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to determine the scopes to %s: %v", a.String(), err)
+	}
+	ids := make([]string, 0, len(infos))
+	for id := range infos {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, infos, nil
+}
+
+// scopesAuthorized returns the scopes in which the user is allowed to perform
+// the action on the collection of resources of the given type, out of the
+// given root scope and all of its descendants. The returned map is keyed by
+// scope ID.
+func (r *VerifyResults) scopesAuthorized(ctx context.Context, rootScopeId string, rt resource.Type, a action.Type) (map[string]*scopes.ScopeInfo, error) {
+	v := r.v
+	if v == nil {
+		return nil, errors.New("scopes authorized: verification was not performed")
+	}
+	iamRepo, err := v.iamRepoFn()
+	if err != nil {
+		return nil, fmt.Errorf("scopes authorized: failed to get iam repo: %w", err)
+	}
+	scps, err := iamRepo.ListScopesRecursively(ctx, rootScopeId)
+	if err != nil {
+		return nil, fmt.Errorf("scopes authorized: %w", err)
+	}
+
+	allowAll := v.requestInfo.DisableAuthEntirely ||
+		v.requestInfo.DisableAuthzFailures ||
+		v.requestInfo.TokenFormat == AuthTokenTypeRecoveryKms
+	ret := make(map[string]*scopes.ScopeInfo, len(scps))
+	for _, scp := range scps {
+		// Projects don't contain scopes, so there is nothing to list in them
+		if rt == resource.Scope && scp.GetType() == scope.Project.String() {
+			continue
+		}
+		if !allowAll {
+			res := perms.Resource{ScopeId: scp.GetPublicId(), Type: rt}
+			if !v.acl.Allowed(res, a).Allowed {
+				continue
+			}
+		}
+		info := &scopes.ScopeInfo{
+			Id:            scp.GetPublicId(),
+			Type:          scp.GetType(),
+			Name:          scp.GetName(),
+			Description:   scp.GetDescription(),
+			ParentScopeId: scp.GetParentId(),
+		}
+		if scp.GetPublicId() == scope.Global.String() {
+			info.Name = scope.Global.String()
+			info.Description = "Global Scope"
+		}
+		ret[info.Id] = info
+	}
+	return ret, nil
+}
+
 func (v verifier) performAuthCheck() (aclResults perms.ACLResults, userId string, scopeInfo *scopes.ScopeInfo, retAcl perms.ACL, retErr error) {
 	// Ensure we return an error by default if we forget to set this somewhere
 	retErr = errors.New("unknown")
@@ -391,19 +581,25 @@ func (v verifier) performAuthCheck() (aclResults perms.ACLResults, userId string
 		retErr = fmt.Errorf("perform auth check: failed to query for user grants: %w", err)
 		return
 	}
-	parsedGrants = make([]perms.Grant, 0, len(grantPairs))
-	for _, pair := range grantPairs {
-		parsed, err := perms.Parse(
-			pair.ScopeId,
-			pair.Grant,
-			perms.WithUserId(userId),
-			perms.WithAccountId(accountId),
-			perms.WithSkipFinalValidation(true))
+	parseOpts := []perms.Option{
+		perms.WithUserId(userId),
+		perms.WithAccountId(accountId),
+		perms.WithSkipFinalValidation(true),
+	}
+	// Only look up the values for templates beyond the user and account IDs
+	// when a grant uses them
+	if perms.RequiresTemplateData(grantPairs) {
+		data, err := iamRepo.GrantTemplateData(v.ctx, userId, accountId)
 		if err != nil {
-			retErr = fmt.Errorf("perform auth check: failed to parse grant %#v: %w", pair.Grant, err)
+			retErr = fmt.Errorf("perform auth check: failed to query for grant template data: %w", err)
 			return
 		}
-		parsedGrants = append(parsedGrants, parsed)
+		parseOpts = append(parseOpts, perms.WithTemplateData(data))
+	}
+	parsedGrants, err = perms.ParseGrants(grantPairs, parseOpts...)
+	if err != nil {
+		retErr = fmt.Errorf("perform auth check: failed to parse grants: %w", err)
+		return
 	}
 
 	retAcl = perms.NewACL(parsedGrants...)
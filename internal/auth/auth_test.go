@@ -9,10 +9,14 @@ import (
 
 	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/servers"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -133,3 +137,86 @@ func TestAuthTokenAuthenticator(t *testing.T) {
 		})
 	}
 }
+
+func TestFetchActionSetForId(t *testing.T) {
+	available := action.ActionSet{action.Read, action.Update, action.Delete, action.AuthorizeSession}
+	grant := func(scopeId, g string) perms.Grant {
+		ret, err := perms.Parse(scopeId, g)
+		require.NoError(t, err)
+		return ret
+	}
+	results := func(reqInfo RequestInfo, grants ...perms.Grant) *VerifyResults {
+		return &VerifyResults{
+			Scope: &scopes.ScopeInfo{Id: "p_1234567890"},
+			v: &verifier{
+				requestInfo: reqInfo,
+				res:         &perms.Resource{Type: resource.Target},
+				acl:         perms.NewACL(grants...),
+			},
+		}
+	}
+
+	cases := []struct {
+		name    string
+		results *VerifyResults
+		id      string
+		opt     []Option
+		want    action.ActionSet
+	}{
+		{
+			name:    "no grants",
+			results: results(RequestInfo{}),
+			id:      "ttcp_1234567890",
+			want:    action.ActionSet{},
+		},
+		{
+			name:    "id grant",
+			results: results(RequestInfo{}, grant("p_1234567890", "id=ttcp_1234567890;actions=read,authorize-session")),
+			id:      "ttcp_1234567890",
+			want:    action.ActionSet{action.Read, action.AuthorizeSession},
+		},
+		{
+			name:    "other id",
+			results: results(RequestInfo{}, grant("p_1234567890", "id=ttcp_1234567890;actions=read,authorize-session")),
+			id:      "ttcp_0987654321",
+			want:    action.ActionSet{},
+		},
+		{
+			name:    "type grant",
+			results: results(RequestInfo{}, grant("p_1234567890", "id=*;type=target;actions=*")),
+			id:      "ttcp_1234567890",
+			want:    available,
+		},
+		{
+			name:    "grant in other scope",
+			results: results(RequestInfo{}, grant("p_0987654321", "id=*;type=target;actions=read")),
+			id:      "ttcp_1234567890",
+			want:    action.ActionSet{},
+		},
+		{
+			name:    "with scope id",
+			results: results(RequestInfo{}, grant("p_0987654321", "id=*;type=target;actions=read")),
+			id:      "ttcp_1234567890",
+			opt:     []Option{WithScopeId("p_0987654321")},
+			want:    action.ActionSet{action.Read},
+		},
+		{
+			name:    "disabled authz failures",
+			results: results(RequestInfo{DisableAuthzFailures: true}),
+			id:      "ttcp_1234567890",
+			want:    available,
+		},
+		{
+			name:    "recovery token",
+			results: results(RequestInfo{TokenFormat: AuthTokenTypeRecoveryKms}),
+			id:      "ttcp_1234567890",
+			want:    available,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.results.FetchActionSetForId(context.Background(), tc.id, available, tc.opt...)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
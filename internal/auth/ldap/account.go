@@ -0,0 +1,107 @@
+package ldap
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/auth/ldap/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"google.golang.org/protobuf/proto"
+)
+
+// An Account is identified by the DN of a user's entry in the directory of
+// its auth method. It is owned by an auth method.
+type Account struct {
+	*store.Account
+	tableName string
+}
+
+func allocAccount() *Account {
+	return &Account{
+		Account: &store.Account{},
+	}
+}
+
+// NewAccount creates a new in memory Account for the entry dn which the user
+// authenticated to as loginName. Email, full name, member of groups, name,
+// and description are the only valid options. All other options are
+// ignored.
+func NewAccount(authMethodId, dn, loginName string, opt ...Option) (*Account, error) {
+	// NOTE: The scopeId in the embedded *store.Account is populated by a
+	// trigger in the database.
+	switch {
+	case authMethodId == "":
+		return nil, fmt.Errorf("new: ldap account: no auth method id: %w", errors.ErrInvalidParameter)
+	case dn == "":
+		return nil, fmt.Errorf("new: ldap account: no dn: %w", errors.ErrInvalidParameter)
+	case loginName == "":
+		return nil, fmt.Errorf("new: ldap account: no login name: %w", errors.ErrInvalidParameter)
+	}
+
+	opts := getOpts(opt...)
+	a := &Account{
+		Account: &store.Account{
+			AuthMethodId: authMethodId,
+			Dn:           dn,
+			LoginName:    loginName,
+			Email:        opts.withEmail,
+			FullName:     opts.withFullName,
+			Name:         opts.withName,
+			Description:  opts.withDescription,
+		},
+	}
+	if len(opts.withMemberOfGroups) > 0 {
+		groups, err := json.Marshal(opts.withMemberOfGroups)
+		if err != nil {
+			return nil, fmt.Errorf("new: ldap account: unable to encode groups: %w", err)
+		}
+		a.MemberOfGroups = string(groups)
+	}
+	return a, nil
+}
+
+// Groups returns the names of the groups the user was a member of when they
+// last authenticated.
+func (a *Account) Groups() ([]string, error) {
+	if a.MemberOfGroups == "" {
+		return nil, nil
+	}
+	var groups []string
+	if err := json.Unmarshal([]byte(a.MemberOfGroups), &groups); err != nil {
+		return nil, fmt.Errorf("ldap account: unable to decode groups: %w", err)
+	}
+	return groups, nil
+}
+
+func (a *Account) clone() *Account {
+	cp := proto.Clone(a.Account)
+	return &Account{
+		Account: cp.(*store.Account),
+	}
+}
+
+// TableName returns the table name.
+func (a *Account) TableName() string {
+	if a.tableName != "" {
+		return a.tableName
+	}
+	return "auth_ldap_account"
+}
+
+// SetTableName sets the table name.
+func (a *Account) SetTableName(n string) {
+	a.tableName = n
+}
+
+func (a *Account) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{a.GetPublicId()},
+		"resource-type":      []string{"ldap account"},
+		"op-type":            []string{op.String()},
+	}
+	if a.AuthMethodId != "" {
+		metadata["auth-method-id"] = []string{a.AuthMethodId}
+	}
+	return metadata
+}
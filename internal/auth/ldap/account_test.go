@@ -0,0 +1,38 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccount_New(t *testing.T) {
+	t.Parallel()
+	const dn = "uid=jim,ou=people,dc=example,dc=com"
+
+	_, err := NewAccount("", dn, "jim")
+	assert.Truef(t, errors.Is(err, errors.ErrInvalidParameter), "want err: %q got: %q", errors.ErrInvalidParameter, err)
+	_, err = NewAccount("amldap_1234567890", "", "jim")
+	assert.Truef(t, errors.Is(err, errors.ErrInvalidParameter), "want err: %q got: %q", errors.ErrInvalidParameter, err)
+	_, err = NewAccount("amldap_1234567890", dn, "")
+	assert.Truef(t, errors.Is(err, errors.ErrInvalidParameter), "want err: %q got: %q", errors.ErrInvalidParameter, err)
+
+	a, err := NewAccount("amldap_1234567890", dn, "jim", WithEmail("jim@example.com"), WithFullName("Jim Smith"), WithMemberOfGroups("admins", "ops"))
+	require.NoError(t, err)
+	assert.Equal(t, dn, a.Dn)
+	assert.Equal(t, "jim", a.LoginName)
+	assert.Equal(t, "jim@example.com", a.Email)
+	assert.Equal(t, "Jim Smith", a.FullName)
+	groups, err := a.Groups()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admins", "ops"}, groups)
+
+	a, err = NewAccount("amldap_1234567890", dn, "jim")
+	require.NoError(t, err)
+	assert.Empty(t, a.MemberOfGroups)
+	groups, err = a.Groups()
+	require.NoError(t, err)
+	assert.Empty(t, groups)
+}
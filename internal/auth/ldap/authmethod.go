@@ -0,0 +1,263 @@
+package ldap
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/boundary/internal/auth/ldap/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	ldapclient "github.com/hashicorp/boundary/internal/libs/ldap"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/structwrapping"
+	"github.com/hashicorp/go-kms-wrapping/wrappers/aead"
+	"github.com/hashicorp/go-kms-wrapping/wrappers/multiwrapper"
+	"google.golang.org/protobuf/proto"
+)
+
+// Placeholders which are replaced in the search filters of an auth method.
+const (
+	LoginNamePlaceholder = "{{login_name}}"
+	UserDnPlaceholder    = "{{user_dn}}"
+)
+
+// Defaults for the optional search settings of an auth method.
+const (
+	DefaultUserSearchFilter  = "(uid=" + LoginNamePlaceholder + ")"
+	DefaultGroupSearchFilter = "(member=" + UserDnPlaceholder + ")"
+	DefaultGroupAttr         = "cn"
+)
+
+// An AuthMethod contains the connection settings of an LDAP server or an
+// Active Directory domain controller and how users and their groups are
+// found in it. It is owned by a scope.
+type AuthMethod struct {
+	*store.AuthMethod
+	tableName string
+}
+
+func allocAuthMethod() AuthMethod {
+	return AuthMethod{
+		AuthMethod: &store.AuthMethod{},
+	}
+}
+
+// NewAuthMethod creates a new in memory AuthMethod assigned to scopeId.
+// The url must be an ldap or ldaps URL and users are searched for under
+// userSearchBase. If WithUserSearchFilter is not used, users are found with
+// DefaultUserSearchFilter. Name, description, StartTLS, insecure TLS,
+// certificate, bind credential, user search filter, and group search are the
+// only valid options. All other options are ignored.
+func NewAuthMethod(scopeId, url, userSearchBase string, opt ...Option) (*AuthMethod, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("new: ldap auth method: no scope id: %w", errors.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	a := &AuthMethod{
+		AuthMethod: &store.AuthMethod{
+			ScopeId:           scopeId,
+			Name:              opts.withName,
+			Description:       opts.withDescription,
+			Url:               url,
+			StartTls:          opts.withStartTls,
+			InsecureTls:       opts.withInsecureTls,
+			Certificate:       opts.withCertificate,
+			BindDn:            opts.withBindDn,
+			BindPassword:      opts.withBindPassword,
+			UserSearchBase:    userSearchBase,
+			UserSearchFilter:  opts.withUserSearchFilter,
+			GroupSearchBase:   opts.withGroupSearchBase,
+			GroupSearchFilter: opts.withGroupSearchFilter,
+			GroupAttr:         opts.withGroupAttr,
+		},
+	}
+	if a.UserSearchFilter == "" {
+		a.UserSearchFilter = DefaultUserSearchFilter
+	}
+	if err := a.validate(); err != nil {
+		return nil, fmt.Errorf("new: ldap auth method: %w", err)
+	}
+	return a, nil
+}
+
+// validate returns an error if the settings of the auth method are not
+// valid.
+func (a *AuthMethod) validate() error {
+	if err := validateUrl(a.Url, a.StartTls); err != nil {
+		return fmt.Errorf("url: %w", err)
+	}
+	if err := validateCertificate(a.Certificate); err != nil {
+		return fmt.Errorf("certificate: %w", err)
+	}
+	if a.BindPassword != "" && a.BindDn == "" {
+		return fmt.Errorf("bind password without bind dn: %w", errors.ErrInvalidParameter)
+	}
+	if a.UserSearchBase == "" {
+		return fmt.Errorf("no user search base: %w", errors.ErrInvalidParameter)
+	}
+	if err := validateFilter(a.UserSearchFilter); err != nil {
+		return fmt.Errorf("user search filter: %w", err)
+	}
+	if a.GroupSearchBase == "" && (a.GroupSearchFilter != "" || a.GroupAttr != "") {
+		return fmt.Errorf("group search filter or attribute without group search base: %w", errors.ErrInvalidParameter)
+	}
+	if a.GroupSearchFilter != "" {
+		if err := validateFilter(a.GroupSearchFilter); err != nil {
+			return fmt.Errorf("group search filter: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateUrl returns an error if u is not an ldap or ldaps URL with a host.
+// StartTLS can only be used with ldap URLs.
+func validateUrl(u string, startTls bool) error {
+	if u == "" {
+		return fmt.Errorf("missing url: %w", errors.ErrInvalidParameter)
+	}
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return fmt.Errorf("%q is not a valid url: %w", u, errors.ErrInvalidParameter)
+	}
+	if parsed.Scheme != "ldap" && parsed.Scheme != "ldaps" || parsed.Host == "" {
+		return fmt.Errorf("%q is not an ldap or ldaps url: %w", u, errors.ErrInvalidParameter)
+	}
+	if startTls && parsed.Scheme != "ldap" {
+		return fmt.Errorf("start tls requires an ldap url: %w", errors.ErrInvalidParameter)
+	}
+	return nil
+}
+
+// validateCertificate returns an error if pem is set and does not contain
+// at least one PEM encoded certificate.
+func validateCertificate(pem string) error {
+	if pem == "" {
+		return nil
+	}
+	if !x509.NewCertPool().AppendCertsFromPEM([]byte(pem)) {
+		return fmt.Errorf("no PEM encoded certificates found: %w", errors.ErrInvalidParameter)
+	}
+	return nil
+}
+
+// validateFilter returns an error if f is not a valid search filter once its
+// placeholders have been replaced.
+func validateFilter(f string) error {
+	if f == "" {
+		return fmt.Errorf("missing filter: %w", errors.ErrInvalidParameter)
+	}
+	if _, err := ldap.CompileFilter(expandFilter(f, "x", "x")); err != nil {
+		return fmt.Errorf("%q: %v: %w", f, err, errors.ErrInvalidParameter)
+	}
+	return nil
+}
+
+// expandFilter replaces the placeholders in f with the escaped login name
+// and user DN.
+func expandFilter(f, loginName, userDn string) string {
+	return strings.NewReplacer(
+		LoginNamePlaceholder, ldap.EscapeFilter(loginName),
+		UserDnPlaceholder, ldap.EscapeFilter(userDn),
+	).Replace(f)
+}
+
+// UserSearchRequest returns the search for the entry of the user who
+// authenticates as loginName, requesting attrs. More than one matching entry
+// makes the search fail with LDAPResultSizeLimitExceeded.
+func (a *AuthMethod) UserSearchRequest(loginName string, attrs ...string) *ldap.SearchRequest {
+	return ldap.NewSearchRequest(a.UserSearchBase, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, 0, false,
+		expandFilter(a.UserSearchFilter, loginName, ""), attrs, nil)
+}
+
+// clientConfig returns the configuration used to connect to the server of
+// the auth method.
+func (a *AuthMethod) clientConfig() ldapclient.Config {
+	return ldapclient.Config{
+		Url:         a.Url,
+		StartTls:    a.StartTls,
+		InsecureTls: a.InsecureTls,
+		CaCert:      a.Certificate,
+	}
+}
+
+func (a *AuthMethod) clone() *AuthMethod {
+	cp := proto.Clone(a.AuthMethod)
+	return &AuthMethod{
+		AuthMethod: cp.(*store.AuthMethod),
+	}
+}
+
+// TableName returns the table name.
+func (a *AuthMethod) TableName() string {
+	if a.tableName != "" {
+		return a.tableName
+	}
+	return "auth_ldap_method"
+}
+
+// SetTableName sets the table name.
+func (a *AuthMethod) SetTableName(n string) {
+	a.tableName = n
+}
+
+func (a *AuthMethod) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{a.GetPublicId()},
+		"resource-type":      []string{"ldap auth method"},
+		"op-type":            []string{op.String()},
+	}
+	if a.ScopeId != "" {
+		metadata["scope-id"] = []string{a.ScopeId}
+	}
+	return metadata
+}
+
+// encrypt encrypts the bind password and sets the bind password hmac and
+// the key id.
+func (a *AuthMethod) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	// structwrapping doesn't support embedding, so we'll pass in the store.AuthMethod directly
+	if err := structwrapping.WrapStruct(ctx, cipher, a.AuthMethod, nil); err != nil {
+		return fmt.Errorf("error encrypting ldap bind password: %w", err)
+	}
+	h, err := hmacBindPassword(cipher, a.BindPassword)
+	if err != nil {
+		return err
+	}
+	a.BindPasswordHmac = h
+	a.KeyId = cipher.KeyID()
+	return nil
+}
+
+func (a *AuthMethod) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	// structwrapping doesn't support embedding, so we'll pass in the store.AuthMethod directly
+	if err := structwrapping.UnwrapStruct(ctx, cipher, a.AuthMethod, nil); err != nil {
+		return fmt.Errorf("error decrypting ldap bind password: %w", err)
+	}
+	return nil
+}
+
+// hmacBindPassword returns a sha256-hmac of password keyed with the key of
+// cipher. The hmac lets callers detect a change to the bind password
+// without the password being returned to them.
+func hmacBindPassword(cipher wrapping.Wrapper, password string) (string, error) {
+	w := cipher
+	if mw, ok := w.(*multiwrapper.MultiWrapper); ok {
+		w = mw.WrapperForKeyID(mw.KeyID())
+	}
+	aw, ok := w.(*aead.Wrapper)
+	if !ok {
+		return "", fmt.Errorf("unable to hmac ldap bind password: unsupported wrapper type %T: %w", cipher, errors.ErrInvalidParameter)
+	}
+	mac := hmac.New(sha256.New, aw.GetKeyBytes())
+	if _, err := mac.Write([]byte(password)); err != nil {
+		return "", fmt.Errorf("unable to hmac ldap bind password: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
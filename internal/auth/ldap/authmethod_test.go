@@ -0,0 +1,155 @@
+package ldap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth/ldap/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	ldapclient "github.com/hashicorp/boundary/internal/libs/ldap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthMethod_New(t *testing.T) {
+	t.Parallel()
+	const (
+		scopeId = "o_1234567890"
+		url     = "ldap://dc.example.com"
+		base    = "ou=people,dc=example,dc=com"
+	)
+	s := ldapclient.NewTestServer(t, ldapclient.WithTestLdaps())
+
+	type args struct {
+		scopeId, url, userSearchBase string
+		opts                         []Option
+	}
+
+	var tests = []struct {
+		name      string
+		args      args
+		want      *AuthMethod
+		wantIsErr error
+	}{
+		{
+			name: "valid-no-options",
+			args: args{scopeId, url, base, nil},
+			want: &AuthMethod{
+				AuthMethod: &store.AuthMethod{
+					ScopeId:          scopeId,
+					Url:              url,
+					UserSearchBase:   base,
+					UserSearchFilter: DefaultUserSearchFilter,
+				},
+			},
+		},
+		{
+			name: "valid-with-options",
+			args: args{scopeId, url, base, []Option{
+				WithName("test-name"),
+				WithDescription("test-description"),
+				WithStartTls(true),
+				WithCertificate(s.CaCert()),
+				WithBindCredential("cn=reader,dc=example,dc=com", "secret"),
+				WithUserSearchFilter("(sAMAccountName={{login_name}})"),
+				WithGroupSearch("ou=groups,dc=example,dc=com", "", "name"),
+			}},
+			want: &AuthMethod{
+				AuthMethod: &store.AuthMethod{
+					ScopeId:          scopeId,
+					Name:             "test-name",
+					Description:      "test-description",
+					Url:              url,
+					StartTls:         true,
+					Certificate:      s.CaCert(),
+					BindDn:           "cn=reader,dc=example,dc=com",
+					BindPassword:     "secret",
+					UserSearchBase:   base,
+					UserSearchFilter: "(sAMAccountName={{login_name}})",
+					GroupSearchBase:  "ou=groups,dc=example,dc=com",
+					GroupAttr:        "name",
+				},
+			},
+		},
+		{
+			name:      "no-scope-id",
+			args:      args{"", url, base, nil},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name:      "not-ldap-url",
+			args:      args{scopeId, "https://dc.example.com", base, nil},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name:      "start-tls-with-ldaps",
+			args:      args{scopeId, "ldaps://dc.example.com", base, []Option{WithStartTls(true)}},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name:      "no-user-search-base",
+			args:      args{scopeId, url, "", nil},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name:      "invalid-user-search-filter",
+			args:      args{scopeId, url, base, []Option{WithUserSearchFilter("uid={{login_name}}")}},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name:      "invalid-certificate",
+			args:      args{scopeId, url, base, []Option{WithCertificate("not a certificate")}},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name:      "bind-password-without-dn",
+			args:      args{scopeId, url, base, []Option{WithBindCredential("", "secret")}},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name:      "group-filter-without-base",
+			args:      args{scopeId, url, base, []Option{WithGroupSearch("", "(member={{user_dn}})", "")}},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewAuthMethod(tt.args.scopeId, tt.args.url, tt.args.userSearchBase, tt.args.opts...)
+			if tt.wantIsErr != nil {
+				assert.Truef(errors.Is(err, tt.wantIsErr), "want err: %q got: %q", tt.wantIsErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestAuthMethod_Encrypt(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	wrapper := db.TestWrapper(t)
+
+	am, err := NewAuthMethod("o_1234567890", "ldap://dc.example.com", "dc=example,dc=com", WithBindCredential("cn=reader,dc=example,dc=com", "secret"))
+	require.NoError(err)
+	require.NoError(am.encrypt(ctx, wrapper))
+	assert.NotEmpty(am.CtBindPassword)
+	assert.NotEmpty(am.BindPasswordHmac)
+	assert.Equal(wrapper.KeyID(), am.KeyId)
+
+	am.BindPassword = ""
+	require.NoError(am.decrypt(ctx, wrapper))
+	assert.Equal("secret", am.BindPassword)
+}
+
+func TestExpandFilter(t *testing.T) {
+	t.Parallel()
+	got := expandFilter("(&(uid={{login_name}})(member={{user_dn}}))", "jim*)(uid=*", "cn=jim (admin),dc=example,dc=com")
+	assert.Equal(t, `(&(uid=jim\2a\29\28uid=\2a)(member=cn=jim \28admin\29,dc=example,dc=com))`, got)
+}
@@ -0,0 +1,150 @@
+package ldap
+
+// getOpts - iterate the inbound Options and return a struct.
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments.
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withName              string
+	withDescription       string
+	withPublicId          string
+	withLimit             int
+	withStartPageAfterId  string
+	withStartTls          bool
+	withInsecureTls       bool
+	withCertificate       string
+	withBindDn            string
+	withBindPassword      string
+	withUserSearchFilter  string
+	withGroupSearchBase   string
+	withGroupSearchFilter string
+	withGroupAttr         string
+	withEmail             string
+	withFullName          string
+	withMemberOfGroups    []string
+}
+
+func getDefaultOptions() options {
+	return options{}
+}
+
+// WithPublicId provides an optional public id
+func WithPublicId(id string) Option {
+	return func(o *options) {
+		o.withPublicId = id
+	}
+}
+
+// WithDescription provides an optional description.
+func WithDescription(desc string) Option {
+	return func(o *options) {
+		o.withDescription = desc
+	}
+}
+
+// WithName provides an optional name.
+func WithName(name string) Option {
+	return func(o *options) {
+		o.withName = name
+	}
+}
+
+// WithLimit provides an option to provide a limit.  Intentionally allowing
+// negative integers.   If WithLimit < 0, then unlimited results are returned.
+// If WithLimit == 0, then default limits are used for results.
+func WithLimit(l int) Option {
+	return func(o *options) {
+		o.withLimit = l
+	}
+}
+
+// WithStartPageAfterId provides an option to list only the resources whose
+// public ids sort after the given id. Results are ordered by public id when
+// it is used, allowing listing to be resumed where a previous page ended.
+func WithStartPageAfterId(id string) Option {
+	return func(o *options) {
+		o.withStartPageAfterId = id
+	}
+}
+
+// WithStartTls provides an option to upgrade ldap connections with StartTLS.
+func WithStartTls(b bool) Option {
+	return func(o *options) {
+		o.withStartTls = b
+	}
+}
+
+// WithInsecureTls provides an option to skip the verification of the
+// server's certificate.
+func WithInsecureTls(b bool) Option {
+	return func(o *options) {
+		o.withInsecureTls = b
+	}
+}
+
+// WithCertificate provides an optional PEM encoded bundle of CA
+// certificates used to verify the server's certificate.
+func WithCertificate(pem string) Option {
+	return func(o *options) {
+		o.withCertificate = pem
+	}
+}
+
+// WithBindCredential provides an optional DN and password used to search for
+// users. The password may be empty.
+func WithBindCredential(dn, password string) Option {
+	return func(o *options) {
+		o.withBindDn = dn
+		o.withBindPassword = password
+	}
+}
+
+// WithUserSearchFilter provides an optional filter used to find a user's
+// entry.
+func WithUserSearchFilter(f string) Option {
+	return func(o *options) {
+		o.withUserSearchFilter = f
+	}
+}
+
+// WithGroupSearch provides an optional base DN, filter, and name attribute
+// used to find a user's groups. The filter and attribute may be empty to use
+// their defaults.
+func WithGroupSearch(base, filter, attr string) Option {
+	return func(o *options) {
+		o.withGroupSearchBase = base
+		o.withGroupSearchFilter = filter
+		o.withGroupAttr = attr
+	}
+}
+
+// WithEmail provides an optional email address.
+func WithEmail(email string) Option {
+	return func(o *options) {
+		o.withEmail = email
+	}
+}
+
+// WithFullName provides an optional full name.
+func WithFullName(n string) Option {
+	return func(o *options) {
+		o.withFullName = n
+	}
+}
+
+// WithMemberOfGroups provides the optional names of the groups an account is
+// a member of.
+func WithMemberOfGroups(groups ...string) Option {
+	return func(o *options) {
+		o.withMemberOfGroups = groups
+	}
+}
@@ -0,0 +1,221 @@
+package ldap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// LookupAccount will look up an account in the repository.  If the account is not
+// found, it will return nil, nil.  All options are ignored.
+func (r *Repository) LookupAccount(ctx context.Context, withPublicId string, opt ...Option) (*Account, error) {
+	if withPublicId == "" {
+		return nil, fmt.Errorf("lookup: ldap account: missing public id %w", errors.ErrInvalidParameter)
+	}
+	a := allocAccount()
+	a.PublicId = withPublicId
+	if err := r.reader.LookupByPublicId(ctx, a); err != nil {
+		if errors.Is(err, errors.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup: ldap account: failed %w for %s", err, withPublicId)
+	}
+	return a, nil
+}
+
+// LookupAccountByDn will look up the account in an auth method for the entry
+// dn. If the account is not found, it will return nil, nil. All options are
+// ignored.
+func (r *Repository) LookupAccountByDn(ctx context.Context, authMethodId, dn string, opt ...Option) (*Account, error) {
+	switch {
+	case authMethodId == "":
+		return nil, fmt.Errorf("lookup: ldap account: missing auth method id %w", errors.ErrInvalidParameter)
+	case dn == "":
+		return nil, fmt.Errorf("lookup: ldap account: missing dn %w", errors.ErrInvalidParameter)
+	}
+	a := allocAccount()
+	if err := r.reader.LookupWhere(ctx, a, "auth_method_id = ? and dn = ?", authMethodId, dn); err != nil {
+		if errors.Is(err, errors.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup: ldap account: failed %w for %s", err, dn)
+	}
+	return a, nil
+}
+
+// LookupAccountByLoginName will look up the account in an auth method whose
+// user most recently authenticated as loginName. If the account is not found,
+// it will return nil, nil. All options are ignored.
+func (r *Repository) LookupAccountByLoginName(ctx context.Context, authMethodId, loginName string, opt ...Option) (*Account, error) {
+	switch {
+	case authMethodId == "":
+		return nil, fmt.Errorf("lookup: ldap account: missing auth method id %w", errors.ErrInvalidParameter)
+	case loginName == "":
+		return nil, fmt.Errorf("lookup: ldap account: missing login name %w", errors.ErrInvalidParameter)
+	}
+	var accts []*Account
+	err := r.reader.SearchWhere(ctx, &accts, "auth_method_id = ? and login_name = ?", []interface{}{authMethodId, loginName},
+		db.WithLimit(1), db.WithOrder("update_time desc"))
+	if err != nil {
+		return nil, fmt.Errorf("lookup: ldap account: failed %w for %s", err, loginName)
+	}
+	if len(accts) == 0 {
+		return nil, nil
+	}
+	return accts[0], nil
+}
+
+// EnsureAccount returns the account in an auth method for the entry dn,
+// creating it with loginName if it does not exist, so that an account can be
+// referred to before its user first authenticates. Unlike authenticating, it
+// does not change an existing account. All options are ignored.
+func (r *Repository) EnsureAccount(ctx context.Context, authMethodId, dn, loginName string, opt ...Option) (*Account, error) {
+	a, err := r.LookupAccountByDn(ctx, authMethodId, dn)
+	if err != nil || a != nil {
+		return a, err
+	}
+	am, err := r.lookupAuthMethod(ctx, r.reader, authMethodId)
+	if err != nil {
+		return nil, fmt.Errorf("ensure: ldap account: %w", err)
+	}
+	if am == nil {
+		return nil, fmt.Errorf("ensure: ldap account: auth method %s: %w", authMethodId, errors.ErrRecordNotFound)
+	}
+	return r.upsertAccount(ctx, am.ScopeId, authMethodId, dn, loginName)
+}
+
+// ListAccounts in an auth method and supports the WithLimit and
+// WithStartPageAfterId options.
+func (r *Repository) ListAccounts(ctx context.Context, withAuthMethodId string, opt ...Option) ([]*Account, error) {
+	if withAuthMethodId == "" {
+		return nil, fmt.Errorf("list: ldap account: missing auth method id %w", errors.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	where, args := "auth_method_id = ?", []interface{}{withAuthMethodId}
+	if opts.withStartPageAfterId != "" {
+		where, args = where+" and public_id > ?", append(args, opts.withStartPageAfterId)
+	}
+	var accts []*Account
+	err := r.reader.SearchWhere(ctx, &accts, where, args, db.WithLimit(limit), db.WithOrder("public_id"))
+	if err != nil {
+		return nil, fmt.Errorf("list: ldap account: %w", err)
+	}
+	return accts, nil
+}
+
+// DeleteAccount deletes the account for the provided id from the repository returning a count of the
+// number of records deleted.  All options are ignored.
+func (r *Repository) DeleteAccount(ctx context.Context, scopeId, withPublicId string, opt ...Option) (int, error) {
+	if withPublicId == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete: ldap account: missing public id: %w", errors.ErrInvalidParameter)
+	}
+	if scopeId == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete: ldap account: scope id empty: %w", errors.ErrInvalidParameter)
+	}
+	ac := allocAccount()
+	ac.PublicId = withPublicId
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete: ldap account: unable to get oplog wrapper: %w", err)
+	}
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) (err error) {
+			metadata := ac.oplog(oplog.OpType_OP_TYPE_DELETE)
+			dAc := ac.clone()
+			rowsDeleted, err = w.Delete(ctx, dAc, db.WithOplog(oplogWrapper, metadata))
+			if err == nil && rowsDeleted > 1 {
+				return errors.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete: ldap account: %s: %w", withPublicId, err)
+	}
+
+	return rowsDeleted, nil
+}
+
+// upsertAccount returns the account in authMethodId for the entry dn,
+// creating it if it does not exist. The login name, email, full name, and
+// groups of an existing account are updated if they have changed.
+func (r *Repository) upsertAccount(ctx context.Context, scopeId, authMethodId, dn, loginName string, opt ...Option) (*Account, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("upsert: ldap account: scope id empty: %w", errors.ErrInvalidParameter)
+	}
+	a, err := NewAccount(authMethodId, dn, loginName, opt...)
+	if err != nil {
+		return nil, fmt.Errorf("upsert: ldap account: %w", err)
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, fmt.Errorf("upsert: ldap account: unable to get oplog wrapper: %w", err)
+	}
+
+	var acct *Account
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			existing := allocAccount()
+			err := reader.LookupWhere(ctx, existing, "auth_method_id = ? and dn = ?", authMethodId, dn)
+			switch {
+			case errors.Is(err, errors.ErrRecordNotFound):
+				acct = a.clone()
+				acct.PublicId, err = newAccountId()
+				if err != nil {
+					return err
+				}
+				return w.Create(ctx, acct, db.WithOplog(oplogWrapper, acct.oplog(oplog.OpType_OP_TYPE_CREATE)))
+			case err != nil:
+				return err
+			}
+
+			acct = existing
+			if existing.LoginName == a.LoginName && existing.Email == a.Email &&
+				existing.FullName == a.FullName && existing.MemberOfGroups == a.MemberOfGroups {
+				return nil
+			}
+			dbMask := []string{"LoginName"}
+			var nullFields []string
+			for f, v := range map[string]string{"Email": a.Email, "FullName": a.FullName, "MemberOfGroups": a.MemberOfGroups} {
+				if v == "" {
+					nullFields = append(nullFields, f)
+				} else {
+					dbMask = append(dbMask, f)
+				}
+			}
+			acct = existing.clone()
+			acct.LoginName, acct.Email, acct.FullName, acct.MemberOfGroups = a.LoginName, a.Email, a.FullName, a.MemberOfGroups
+			version := existing.Version
+			rowsUpdated, err := w.Update(ctx, acct, dbMask, nullFields,
+				db.WithOplog(oplogWrapper, acct.oplog(oplog.OpType_OP_TYPE_UPDATE)),
+				db.WithVersion(&version))
+			if err == nil && rowsUpdated > 1 {
+				return errors.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("upsert: ldap account: %s: %w", dn, err)
+	}
+	return acct, nil
+}
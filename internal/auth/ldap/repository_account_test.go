@@ -0,0 +1,60 @@
+package ldap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_EnsureAccount(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+	am := TestAuthMethods(t, conn, org.PublicId, "ldap://127.0.0.1", 1)[0]
+
+	const jimDn = "uid=jim,ou=people,dc=example,dc=com"
+	got, err := repo.LookupAccountByDn(ctx, am.PublicId, jimDn)
+	require.NoError(err)
+	assert.Nil(got)
+
+	created, err := repo.EnsureAccount(ctx, am.PublicId, jimDn, "jim")
+	require.NoError(err)
+	assert.Equal(jimDn, created.Dn)
+	assert.Equal("jim", created.LoginName)
+
+	// An existing account is returned unchanged.
+	again, err := repo.EnsureAccount(ctx, am.PublicId, jimDn, "jsmith")
+	require.NoError(err)
+	assert.Equal(created.PublicId, again.PublicId)
+	assert.Equal("jim", again.LoginName)
+
+	got, err = repo.LookupAccountByDn(ctx, am.PublicId, jimDn)
+	require.NoError(err)
+	require.NotNil(got)
+	assert.Equal(created.PublicId, got.PublicId)
+	got, err = repo.LookupAccountByLoginName(ctx, am.PublicId, "jim")
+	require.NoError(err)
+	require.NotNil(got)
+	assert.Equal(created.PublicId, got.PublicId)
+	got, err = repo.LookupAccountByLoginName(ctx, am.PublicId, "sue")
+	require.NoError(err)
+	assert.Nil(got)
+
+	_, err = repo.EnsureAccount(ctx, "amldap_1234567890", jimDn, "jim")
+	assert.True(errors.Is(err, errors.ErrRecordNotFound))
+	_, err = repo.EnsureAccount(ctx, am.PublicId, "", "jim")
+	assert.True(errors.Is(err, errors.ErrInvalidParameter))
+}
@@ -0,0 +1,134 @@
+package ldap
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/boundary/internal/errors"
+	ldapclient "github.com/hashicorp/boundary/internal/libs/ldap"
+)
+
+// Attributes of a user's entry which are recorded on its account.
+const (
+	emailAttr       = "mail"
+	displayNameAttr = "displayName"
+	commonNameAttr  = "cn"
+)
+
+// Authenticate authenticates loginName and password with the directory of
+// the auth method. The user's entry is found by searching under the user
+// search base with the user search filter, bound as the bind DN of the auth
+// method or anonymously, and the user is authenticated by binding as the DN
+// of the entry with password. If the auth method has a group search base,
+// the names of the user's groups are recorded on the account.
+//
+// The account for the entry is created if it does not exist and returned. If
+// no single entry matches loginName or the password is not valid, nil, nil
+// is returned. All options are ignored.
+func (r *Repository) Authenticate(ctx context.Context, scopeId, authMethodId, loginName, password string, opt ...Option) (*Account, error) {
+	switch {
+	case scopeId == "":
+		return nil, fmt.Errorf("ldap authenticate: no scope id: %w", errors.ErrInvalidParameter)
+	case authMethodId == "":
+		return nil, fmt.Errorf("ldap authenticate: no auth method id: %w", errors.ErrInvalidParameter)
+	case loginName == "":
+		return nil, fmt.Errorf("ldap authenticate: no login name: %w", errors.ErrInvalidParameter)
+	case password == "":
+		// An empty password would be an unauthenticated bind, which many
+		// servers accept for any DN.
+		return nil, fmt.Errorf("ldap authenticate: no password: %w", errors.ErrInvalidParameter)
+	}
+
+	am, err := r.lookupAuthMethodWithPassword(ctx, authMethodId)
+	if err != nil {
+		return nil, fmt.Errorf("ldap authenticate: %w", err)
+	}
+	if am == nil || am.ScopeId != scopeId {
+		return nil, fmt.Errorf("ldap authenticate: auth method %s: %w", authMethodId, errors.ErrRecordNotFound)
+	}
+
+	conn, err := ldapclient.Dial(ctx, am.clientConfig())
+	if err != nil {
+		return nil, fmt.Errorf("ldap authenticate: %w", err)
+	}
+	defer conn.Close()
+
+	if am.BindDn != "" {
+		if err := conn.Bind(am.BindDn, am.BindPassword); err != nil {
+			return nil, fmt.Errorf("ldap authenticate: unable to bind as %s: %w", am.BindDn, err)
+		}
+	}
+	res, err := conn.Search(am.UserSearchRequest(loginName, emailAttr, displayNameAttr, commonNameAttr))
+	switch {
+	case ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded):
+		// More than one entry matches.
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("ldap authenticate: unable to search for user: %w", err)
+	}
+	if len(res.Entries) != 1 {
+		return nil, nil
+	}
+	user := res.Entries[0]
+
+	if err := conn.Bind(user.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("ldap authenticate: unable to bind as %s: %w", user.DN, err)
+	}
+
+	acctOpts := []Option{WithEmail(user.GetEqualFoldAttributeValue(emailAttr))}
+	if n := user.GetEqualFoldAttributeValue(displayNameAttr); n != "" {
+		acctOpts = append(acctOpts, WithFullName(n))
+	} else {
+		acctOpts = append(acctOpts, WithFullName(user.GetEqualFoldAttributeValue(commonNameAttr)))
+	}
+	if am.GroupSearchBase != "" {
+		// Groups are searched for as the bind DN of the auth method since
+		// users may not be allowed to read them.
+		if am.BindDn != "" {
+			if err := conn.Bind(am.BindDn, am.BindPassword); err != nil {
+				return nil, fmt.Errorf("ldap authenticate: unable to bind as %s: %w", am.BindDn, err)
+			}
+		}
+		groups, err := searchGroups(conn, am, loginName, user.DN)
+		if err != nil {
+			return nil, fmt.Errorf("ldap authenticate: %w", err)
+		}
+		acctOpts = append(acctOpts, WithMemberOfGroups(groups...))
+	}
+
+	acct, err := r.upsertAccount(ctx, am.ScopeId, am.PublicId, user.DN, loginName, acctOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("ldap authenticate: %w", err)
+	}
+	return acct, nil
+}
+
+// searchGroups returns the sorted names of the groups of the user with the
+// entry userDn.
+func searchGroups(conn *ldap.Conn, am *AuthMethod, loginName, userDn string) ([]string, error) {
+	filter, attr := am.GroupSearchFilter, am.GroupAttr
+	if filter == "" {
+		filter = DefaultGroupSearchFilter
+	}
+	if attr == "" {
+		attr = DefaultGroupAttr
+	}
+	res, err := conn.Search(ldap.NewSearchRequest(am.GroupSearchBase, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		expandFilter(filter, loginName, userDn), []string{attr}, nil))
+	if err != nil {
+		return nil, fmt.Errorf("unable to search for groups: %w", err)
+	}
+	var groups []string
+	for _, e := range res.Entries {
+		if n := e.GetEqualFoldAttributeValue(attr); n != "" {
+			groups = append(groups, n)
+		}
+	}
+	sort.Strings(groups)
+	return groups, nil
+}
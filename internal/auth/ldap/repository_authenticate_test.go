@@ -0,0 +1,110 @@
+package ldap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	ldapclient "github.com/hashicorp/boundary/internal/libs/ldap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Authenticate(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	ctx := context.Background()
+
+	const jimDn = "cn=Jim Smith,ou=people,dc=example,dc=com"
+	s := ldapclient.NewTestServer(t, ldapclient.WithTestStartTls())
+	s.AddEntry("dc=example,dc=com", map[string][]string{"objectClass": {"domain"}})
+	s.AddEntry(jimDn, map[string][]string{
+		"objectClass":    {"user"},
+		"sAMAccountName": {"jim"},
+		"cn":             {"Jim Smith"},
+		"mail":           {"jim@example.com"},
+	})
+	s.SetPassword(jimDn, "jim-password")
+	s.AddEntry("cn=admins,ou=groups,dc=example,dc=com", map[string][]string{
+		"objectClass": {"group"},
+		"cn":          {"admins"},
+		"member":      {jimDn},
+	})
+	s.SetPassword("cn=reader,dc=example,dc=com", "reader-password")
+
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+	atRepo, err := authtoken.NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	in, err := NewAuthMethod(org.PublicId, s.Url(), "ou=people,dc=example,dc=com",
+		WithStartTls(true),
+		WithCertificate(s.CaCert()),
+		WithBindCredential("cn=reader,dc=example,dc=com", "reader-password"),
+		WithUserSearchFilter("(&(objectClass=user)(sAMAccountName={{login_name}}))"),
+		WithGroupSearch("ou=groups,dc=example,dc=com", "", ""))
+	require.NoError(t, err)
+	am, err := repo.CreateAuthMethod(ctx, in)
+	require.NoError(t, err)
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		acct, err := repo.Authenticate(ctx, org.PublicId, am.PublicId, "jim", "jim-password")
+		require.NoError(err)
+		require.NotNil(acct)
+		assert.Equal(jimDn, acct.Dn)
+		assert.Equal("jim", acct.LoginName)
+		assert.Equal("jim@example.com", acct.Email)
+		assert.Equal("Jim Smith", acct.FullName)
+		groups, err := acct.Groups()
+		require.NoError(err)
+		assert.Equal([]string{"admins"}, groups)
+
+		// An auth token can be issued for the account.
+		u, err := iamRepo.LookupUserWithLogin(ctx, acct.PublicId, iam.WithAutoVivify(true))
+		require.NoError(err)
+		tok, err := atRepo.CreateAuthToken(ctx, u, acct.PublicId)
+		require.NoError(err)
+		assert.Equal(am.PublicId, tok.AuthMethodId)
+
+		// Authenticating again returns the same account with updated groups.
+		s.RemoveEntry("cn=admins,ou=groups,dc=example,dc=com")
+		s.AddEntry("ou=groups,dc=example,dc=com", map[string][]string{"objectClass": {"organizationalUnit"}})
+		again, err := repo.Authenticate(ctx, org.PublicId, am.PublicId, "jim", "jim-password")
+		require.NoError(err)
+		assert.Equal(acct.PublicId, again.PublicId)
+		assert.Empty(again.MemberOfGroups)
+	})
+
+	t.Run("invalid-credentials", func(t *testing.T) {
+		assert := assert.New(t)
+		acct, err := repo.Authenticate(ctx, org.PublicId, am.PublicId, "jim", "wrong")
+		assert.NoError(err)
+		assert.Nil(acct)
+
+		acct, err = repo.Authenticate(ctx, org.PublicId, am.PublicId, "nobody", "jim-password")
+		assert.NoError(err)
+		assert.Nil(acct)
+
+		// The login name is escaped in the search filter.
+		acct, err = repo.Authenticate(ctx, org.PublicId, am.PublicId, "*", "jim-password")
+		assert.NoError(err)
+		assert.Nil(acct)
+
+		_, err = repo.Authenticate(ctx, org.PublicId, am.PublicId, "jim", "")
+		assert.Truef(errors.Is(err, errors.ErrInvalidParameter), "want err: %q got: %q", errors.ErrInvalidParameter, err)
+	})
+
+	t.Run("wrong-scope", func(t *testing.T) {
+		_, err := repo.Authenticate(ctx, "o_1234567890", am.PublicId, "jim", "jim-password")
+		assert.Truef(t, errors.Is(err, errors.ErrRecordNotFound), "want err: %q got: %q", errors.ErrRecordNotFound, err)
+	})
+}
@@ -0,0 +1,359 @@
+package ldap
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	dbcommon "github.com/hashicorp/boundary/internal/db/common"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// CreateAuthMethod inserts m into the repository and returns a new
+// AuthMethod containing the auth method's PublicId. m is not changed. m must
+// contain a valid ScopeId, Url, and UserSearchBase. m must not contain a
+// PublicId. The PublicId is generated and assigned by this method. If
+// m.UserSearchFilter is empty, DefaultUserSearchFilter is used. The bind
+// password is encrypted before it is stored and is not included in the
+// returned AuthMethod.
+//
+// WithPublicId is the only valid option. All other options are ignored.
+//
+// Both m.Name and m.Description are optional. If m.Name is set, it must be
+// unique within m.ScopeId.
+func (r *Repository) CreateAuthMethod(ctx context.Context, m *AuthMethod, opt ...Option) (*AuthMethod, error) {
+	if m == nil {
+		return nil, fmt.Errorf("create: ldap auth method: %w", errors.ErrInvalidParameter)
+	}
+	if m.AuthMethod == nil {
+		return nil, fmt.Errorf("create: ldap auth method: embedded AuthMethod: %w", errors.ErrInvalidParameter)
+	}
+	if m.ScopeId == "" {
+		return nil, fmt.Errorf("create: ldap auth method: no scope id: %w", errors.ErrInvalidParameter)
+	}
+	if m.PublicId != "" {
+		return nil, fmt.Errorf("create: ldap auth method: public id not empty: %w", errors.ErrInvalidParameter)
+	}
+	m = m.clone()
+	if m.UserSearchFilter == "" {
+		m.UserSearchFilter = DefaultUserSearchFilter
+	}
+	if err := m.validate(); err != nil {
+		return nil, fmt.Errorf("create: ldap auth method: %w", err)
+	}
+
+	opts := getOpts(opt...)
+
+	if opts.withPublicId != "" {
+		if !strings.HasPrefix(opts.withPublicId, AuthMethodPrefix+"_") {
+			return nil, fmt.Errorf("create: ldap auth method: passed-in public ID %q has wrong prefix, should be %q: %w", opts.withPublicId, AuthMethodPrefix, errors.ErrInvalidPublicId)
+		}
+		m.PublicId = opts.withPublicId
+	} else {
+		id, err := newAuthMethodId()
+		if err != nil {
+			return nil, fmt.Errorf("create: ldap auth method: %w", err)
+		}
+		m.PublicId = id
+	}
+
+	if m.BindPassword != "" {
+		databaseWrapper, err := r.kms.GetWrapper(ctx, m.ScopeId, kms.KeyPurposeDatabase)
+		if err != nil {
+			return nil, fmt.Errorf("create: ldap auth method: unable to get database wrapper: %w", err)
+		}
+		if err := m.encrypt(ctx, databaseWrapper); err != nil {
+			return nil, fmt.Errorf("create: ldap auth method: %w", err)
+		}
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, m.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, fmt.Errorf("create: ldap auth method: unable to get oplog wrapper: %w", err)
+	}
+
+	var newAuthMethod *AuthMethod
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			newAuthMethod = m.clone()
+			return w.Create(ctx, newAuthMethod, db.WithOplog(oplogWrapper, newAuthMethod.oplog(oplog.OpType_OP_TYPE_CREATE)))
+		},
+	)
+
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, fmt.Errorf("create: ldap auth method: in scope: %s: name %s already exists: %w",
+				m.ScopeId, m.Name, errors.ErrNotUnique)
+		}
+		return nil, fmt.Errorf("create: ldap auth method: in scope: %s: %w", m.ScopeId, err)
+	}
+	newAuthMethod.BindPassword = ""
+	newAuthMethod.CtBindPassword = nil
+	return newAuthMethod, nil
+}
+
+// LookupAuthMethod will look up an auth method in the repository. If the
+// auth method is not found, it will return nil, nil. The bind password is
+// not included in the returned AuthMethod. All options are ignored.
+func (r *Repository) LookupAuthMethod(ctx context.Context, publicId string, opt ...Option) (*AuthMethod, error) {
+	if publicId == "" {
+		return nil, fmt.Errorf("lookup: ldap auth method: missing public id %w", errors.ErrInvalidParameter)
+	}
+	a, err := r.lookupAuthMethod(ctx, r.reader, publicId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup: ldap auth method: %w", err)
+	}
+	if a == nil {
+		return nil, nil
+	}
+	a.CtBindPassword = nil
+	return a, nil
+}
+
+// lookupAuthMethod returns the auth method with its encrypted bind password.
+// If the auth method is not found, it returns nil, nil.
+func (r *Repository) lookupAuthMethod(ctx context.Context, reader db.Reader, publicId string) (*AuthMethod, error) {
+	a := allocAuthMethod()
+	a.PublicId = publicId
+	if err := reader.LookupByPublicId(ctx, &a); err != nil {
+		if errors.Is(err, errors.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed %w for %s", err, publicId)
+	}
+	return &a, nil
+}
+
+// lookupAuthMethodWithPassword returns the auth method with its bind
+// password decrypted. If the auth method is not found, it returns nil, nil.
+func (r *Repository) lookupAuthMethodWithPassword(ctx context.Context, publicId string) (*AuthMethod, error) {
+	a, err := r.lookupAuthMethod(ctx, r.reader, publicId)
+	if err != nil || a == nil {
+		return nil, err
+	}
+	if len(a.CtBindPassword) == 0 {
+		return a, nil
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, a.ScopeId, kms.KeyPurposeDatabase, kms.WithKeyId(a.KeyId))
+	if err != nil {
+		return nil, fmt.Errorf("unable to get database wrapper: %w", err)
+	}
+	if err := a.decrypt(ctx, databaseWrapper); err != nil {
+		return nil, err
+	}
+	a.CtBindPassword = nil
+	return a, nil
+}
+
+// ListAuthMethods returns a slice of AuthMethods in the given scopes. The
+// bind passwords are not included in the returned AuthMethods. Supports the
+// WithLimit and WithStartPageAfterId options.
+func (r *Repository) ListAuthMethods(ctx context.Context, scopeIds []string, opt ...Option) ([]*AuthMethod, error) {
+	if len(scopeIds) == 0 {
+		return nil, fmt.Errorf("list: ldap auth method: missing scope id: %w", errors.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	where, args := "scope_id in (?)", []interface{}{scopeIds}
+	if opts.withStartPageAfterId != "" {
+		where, args = where+" and public_id > ?", append(args, opts.withStartPageAfterId)
+	}
+	var authMethods []*AuthMethod
+	err := r.reader.SearchWhere(ctx, &authMethods, where, args, db.WithLimit(limit), db.WithOrder("public_id"))
+	if err != nil {
+		return nil, fmt.Errorf("list: ldap auth method: %w", err)
+	}
+	for _, am := range authMethods {
+		am.CtBindPassword = nil
+	}
+	return authMethods, nil
+}
+
+// DeleteAuthMethod deletes the auth method for the provided id from the
+// repository returning a count of the number of records deleted. All
+// options are ignored.
+func (r *Repository) DeleteAuthMethod(ctx context.Context, scopeId, publicId string, opt ...Option) (int, error) {
+	if publicId == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete: ldap auth method: missing public id: %w", errors.ErrInvalidParameter)
+	}
+	am := allocAuthMethod()
+	am.PublicId = publicId
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete: ldap auth method: unable to get oplog wrapper: %w", err)
+	}
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) (err error) {
+			metadata := am.oplog(oplog.OpType_OP_TYPE_DELETE)
+			dAc := am.clone()
+			rowsDeleted, err = w.Delete(ctx, dAc, db.WithOplog(oplogWrapper, metadata))
+			if err == nil && rowsDeleted > 1 {
+				return errors.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete: ldap auth method: %s: %w", publicId, err)
+	}
+
+	return rowsDeleted, nil
+}
+
+// UpdateAuthMethod will update an auth method in the repository and return
+// the written auth method. fieldMaskPaths provides field_mask.proto paths
+// for fields that should be updated. Fields will be set to NULL if the field
+// is a zero value and included in fieldMask, with these exceptions: Url and
+// UserSearchBase cannot be set to NULL, StartTls and InsecureTls are set to
+// false, and UserSearchFilter is set to DefaultUserSearchFilter. Setting
+// BindPassword to a zero value removes the bind password. If no updatable
+// fields are included in the fieldMaskPaths, then an error is returned.
+func (r *Repository) UpdateAuthMethod(ctx context.Context, authMethod *AuthMethod, version uint32, fieldMaskPaths []string, opt ...Option) (*AuthMethod, int, error) {
+	if authMethod == nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: ldap auth method: missing authMethod: %w", errors.ErrInvalidParameter)
+	}
+	if authMethod.PublicId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: ldap auth method: missing authMethod public id: %w", errors.ErrInvalidParameter)
+	}
+	if authMethod.ScopeId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: ldap auth method: scope id empty: %w", errors.ErrInvalidParameter)
+	}
+	upAuthMethod := authMethod.clone()
+	var updatePassword bool
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold("name", f):
+		case strings.EqualFold("description", f):
+		case strings.EqualFold("StartTls", f):
+		case strings.EqualFold("InsecureTls", f):
+		case strings.EqualFold("BindDn", f):
+		case strings.EqualFold("GroupSearchBase", f):
+		case strings.EqualFold("GroupAttr", f):
+		case strings.EqualFold("Url", f):
+			if err := validateUrl(upAuthMethod.Url, false); err != nil {
+				return nil, db.NoRowsAffected, fmt.Errorf("update: ldap auth method: url: %w", err)
+			}
+		case strings.EqualFold("Certificate", f):
+			if err := validateCertificate(upAuthMethod.Certificate); err != nil {
+				return nil, db.NoRowsAffected, fmt.Errorf("update: ldap auth method: certificate: %w", err)
+			}
+		case strings.EqualFold("BindPassword", f):
+			updatePassword = true
+		case strings.EqualFold("UserSearchBase", f):
+			if upAuthMethod.UserSearchBase == "" {
+				return nil, db.NoRowsAffected, fmt.Errorf("update: ldap auth method: no user search base: %w", errors.ErrInvalidParameter)
+			}
+		case strings.EqualFold("UserSearchFilter", f):
+			if upAuthMethod.UserSearchFilter == "" {
+				upAuthMethod.UserSearchFilter = DefaultUserSearchFilter
+			}
+			if err := validateFilter(upAuthMethod.UserSearchFilter); err != nil {
+				return nil, db.NoRowsAffected, fmt.Errorf("update: ldap auth method: user search filter: %w", err)
+			}
+		case strings.EqualFold("GroupSearchFilter", f):
+			if upAuthMethod.GroupSearchFilter != "" {
+				if err := validateFilter(upAuthMethod.GroupSearchFilter); err != nil {
+					return nil, db.NoRowsAffected, fmt.Errorf("update: ldap auth method: group search filter: %w", err)
+				}
+			}
+		default:
+			return nil, db.NoRowsAffected, fmt.Errorf("update: ldap auth method: field: %s: %w", f, errors.ErrInvalidFieldMask)
+		}
+	}
+	var dbMask, nullFields []string
+	dbMask, nullFields = dbcommon.BuildUpdatePaths(
+		map[string]interface{}{
+			"Name":              upAuthMethod.Name,
+			"Description":       upAuthMethod.Description,
+			"Url":               upAuthMethod.Url,
+			"StartTls":          upAuthMethod.StartTls,
+			"InsecureTls":       upAuthMethod.InsecureTls,
+			"Certificate":       upAuthMethod.Certificate,
+			"BindDn":            upAuthMethod.BindDn,
+			"UserSearchBase":    upAuthMethod.UserSearchBase,
+			"UserSearchFilter":  upAuthMethod.UserSearchFilter,
+			"GroupSearchBase":   upAuthMethod.GroupSearchBase,
+			"GroupSearchFilter": upAuthMethod.GroupSearchFilter,
+			"GroupAttr":         upAuthMethod.GroupAttr,
+		},
+		fieldMaskPaths,
+		[]string{"StartTls", "InsecureTls"},
+	)
+	if len(dbMask) == 0 && len(nullFields) == 0 && !updatePassword {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: ldap auth method: %w", errors.ErrEmptyFieldMask)
+	}
+
+	if updatePassword {
+		passwordFields := []string{"CtBindPassword", "BindPasswordHmac", "KeyId"}
+		if upAuthMethod.BindPassword == "" {
+			nullFields = append(nullFields, passwordFields...)
+		} else {
+			databaseWrapper, err := r.kms.GetWrapper(ctx, authMethod.ScopeId, kms.KeyPurposeDatabase)
+			if err != nil {
+				return nil, db.NoRowsAffected, fmt.Errorf("update: ldap auth method: unable to get database wrapper: %w", err)
+			}
+			if err := upAuthMethod.encrypt(ctx, databaseWrapper); err != nil {
+				return nil, db.NoRowsAffected, fmt.Errorf("update: ldap auth method: %w", err)
+			}
+			dbMask = append(dbMask, passwordFields...)
+		}
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, authMethod.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: ldap auth method: unable to get oplog wrapper: %w", err)
+	}
+
+	var returnedAuthMethod *AuthMethod
+	var rowsUpdated int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			rowsUpdated, err = w.Update(
+				ctx,
+				upAuthMethod.clone(),
+				dbMask,
+				nullFields,
+				db.WithOplog(oplogWrapper, upAuthMethod.oplog(oplog.OpType_OP_TYPE_UPDATE)),
+				db.WithVersion(&version),
+			)
+			switch {
+			case err != nil:
+				return err
+			case rowsUpdated > 1:
+				return errors.ErrMultipleRecords
+			case rowsUpdated == 0:
+				return nil
+			}
+			returnedAuthMethod, err = r.lookupAuthMethod(ctx, reader, upAuthMethod.PublicId)
+			return err
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, db.NoRowsAffected, fmt.Errorf("update: ldap auth method: authMethod %s already exists in scope %s: %w", authMethod.Name, authMethod.ScopeId, errors.ErrNotUnique)
+		}
+		return nil, db.NoRowsAffected, fmt.Errorf("update: ldap auth method: %w for %s", err, authMethod.PublicId)
+	}
+	if rowsUpdated == 0 {
+		return nil, db.NoRowsAffected, nil
+	}
+	returnedAuthMethod.CtBindPassword = nil
+	return returnedAuthMethod, rowsUpdated, nil
+}
@@ -0,0 +1,222 @@
+package ldap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testUrl        = "ldap://dc.example.com"
+	testSearchBase = "ou=people,dc=example,dc=com"
+)
+
+func TestRepository_CreateAuthMethod(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	ctx := context.Background()
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	t.Run("with-bind-password", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		in, err := NewAuthMethod(org.PublicId, testUrl, testSearchBase, WithName("with-password"), WithBindCredential("cn=reader,dc=example,dc=com", "secret"))
+		require.NoError(err)
+		got, err := repo.CreateAuthMethod(ctx, in)
+		require.NoError(err)
+		assert.True(len(got.PublicId) > len(AuthMethodPrefix))
+		assert.Empty(got.BindPassword)
+		assert.Empty(got.CtBindPassword)
+		assert.NotEmpty(got.BindPasswordHmac)
+		assert.Equal("secret", in.BindPassword, "input should not be changed")
+		assert.NoError(db.TestVerifyOplog(t, rw, got.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_CREATE)))
+
+		withPassword, err := repo.lookupAuthMethodWithPassword(ctx, got.PublicId)
+		require.NoError(err)
+		assert.Equal("secret", withPassword.BindPassword)
+
+		_, err = repo.CreateAuthMethod(ctx, in)
+		assert.Truef(errors.Is(err, errors.ErrNotUnique), "want err: %q got: %q", errors.ErrNotUnique, err)
+	})
+	t.Run("anonymous", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		in, err := NewAuthMethod(org.PublicId, testUrl, testSearchBase)
+		require.NoError(err)
+		got, err := repo.CreateAuthMethod(ctx, in)
+		require.NoError(err)
+		assert.Empty(got.BindPasswordHmac)
+		assert.Empty(got.KeyId)
+		assert.Equal(DefaultUserSearchFilter, got.UserSearchFilter)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		assert := assert.New(t)
+		_, err := repo.CreateAuthMethod(ctx, nil)
+		assert.Truef(errors.Is(err, errors.ErrInvalidParameter), "want err: %q got: %q", errors.ErrInvalidParameter, err)
+		in, err := NewAuthMethod(org.PublicId, testUrl, testSearchBase)
+		require.NoError(t, err)
+		in.Url = "ldaps://dc.example.com"
+		in.StartTls = true
+		_, err = repo.CreateAuthMethod(ctx, in)
+		assert.Truef(errors.Is(err, errors.ErrInvalidParameter), "want err: %q got: %q", errors.ErrInvalidParameter, err)
+		_, err = repo.CreateAuthMethod(ctx, in, WithPublicId("amoidc_1234567890"))
+		assert.Truef(errors.Is(err, errors.ErrInvalidParameter), "want err: %q got: %q", errors.ErrInvalidParameter, err)
+	})
+}
+
+func TestRepository_ListAuthMethods(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+
+	ams := TestAuthMethods(t, conn, org.PublicId, testUrl, 3)
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+	got, err := repo.ListAuthMethods(ctx, []string{org.PublicId})
+	require.NoError(err)
+	assert.Len(got, len(ams))
+
+	got, err = repo.ListAuthMethods(ctx, []string{org.PublicId}, WithLimit(1))
+	require.NoError(err)
+	assert.Len(got, 1)
+
+	_, err = repo.ListAuthMethods(ctx, nil)
+	assert.Truef(errors.Is(err, errors.ErrInvalidParameter), "want err: %q got: %q", errors.ErrInvalidParameter, err)
+}
+
+func TestRepository_UpdateAuthMethod(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	ctx := context.Background()
+
+	newAuthMethod := func(t *testing.T, repo *Repository) *AuthMethod {
+		in, err := NewAuthMethod(org.PublicId, testUrl, testSearchBase,
+			WithBindCredential("cn=reader,dc=example,dc=com", "secret"),
+			WithStartTls(true),
+			WithGroupSearch("ou=groups,dc=example,dc=com", "", ""))
+		require.NoError(t, err)
+		got, err := repo.CreateAuthMethod(ctx, in)
+		require.NoError(t, err)
+		return got
+	}
+
+	t.Run("settings", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		repo, err := NewRepository(rw, rw, kms)
+		require.NoError(err)
+		am := newAuthMethod(t, repo)
+		am.Name = "updated"
+		am.StartTls = false
+		am.UserSearchFilter = ""
+		am.GroupSearchBase = ""
+		got, rows, err := repo.UpdateAuthMethod(ctx, am, am.Version, []string{"Name", "StartTls", "UserSearchFilter", "GroupSearchBase"})
+		require.NoError(err)
+		assert.Equal(1, rows)
+		assert.Equal("updated", got.Name)
+		assert.False(got.StartTls)
+		assert.Equal(DefaultUserSearchFilter, got.UserSearchFilter)
+		assert.Empty(got.GroupSearchBase)
+		assert.Equal(am.BindPasswordHmac, got.BindPasswordHmac)
+		assert.Equal(am.Version+1, got.Version)
+		assert.NoError(db.TestVerifyOplog(t, rw, am.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_UPDATE)))
+	})
+	t.Run("bind-password", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		repo, err := NewRepository(rw, rw, kms)
+		require.NoError(err)
+		am := newAuthMethod(t, repo)
+		am.BindPassword = "new secret"
+		got, rows, err := repo.UpdateAuthMethod(ctx, am, am.Version, []string{"BindPassword"})
+		require.NoError(err)
+		assert.Equal(1, rows)
+		assert.NotEqual(am.BindPasswordHmac, got.BindPasswordHmac)
+		assert.Empty(got.CtBindPassword)
+
+		withPassword, err := repo.lookupAuthMethodWithPassword(ctx, am.PublicId)
+		require.NoError(err)
+		assert.Equal("new secret", withPassword.BindPassword)
+
+		// Removing the password and the bind dn makes searches anonymous.
+		got.BindPassword, got.BindDn = "", ""
+		got, _, err = repo.UpdateAuthMethod(ctx, got, got.Version, []string{"BindPassword", "BindDn"})
+		require.NoError(err)
+		assert.Empty(got.BindDn)
+		assert.Empty(got.BindPasswordHmac)
+		assert.Empty(got.KeyId)
+	})
+	t.Run("wrong-version", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		repo, err := NewRepository(rw, rw, kms)
+		require.NoError(err)
+		am := newAuthMethod(t, repo)
+		am.Name = "wrong version"
+		got, rows, err := repo.UpdateAuthMethod(ctx, am, am.Version+1, []string{"Name"})
+		require.NoError(err)
+		assert.Equal(0, rows)
+		assert.Nil(got)
+	})
+	t.Run("invalid-field-mask", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		repo, err := NewRepository(rw, rw, kms)
+		require.NoError(err)
+		am := newAuthMethod(t, repo)
+		_, _, err = repo.UpdateAuthMethod(ctx, am, am.Version, []string{"ScopeId"})
+		assert.Truef(errors.Is(err, errors.ErrInvalidFieldMask), "want err: %q got: %q", errors.ErrInvalidFieldMask, err)
+		_, _, err = repo.UpdateAuthMethod(ctx, am, am.Version, nil)
+		assert.Truef(errors.Is(err, errors.ErrEmptyFieldMask), "want err: %q got: %q", errors.ErrEmptyFieldMask, err)
+		am.Url = "https://dc.example.com"
+		_, _, err = repo.UpdateAuthMethod(ctx, am, am.Version, []string{"Url"})
+		assert.Truef(errors.Is(err, errors.ErrInvalidParameter), "want err: %q got: %q", errors.ErrInvalidParameter, err)
+		am.UserSearchBase = ""
+		_, _, err = repo.UpdateAuthMethod(ctx, am, am.Version, []string{"UserSearchBase"})
+		assert.Truef(errors.Is(err, errors.ErrInvalidParameter), "want err: %q got: %q", errors.ErrInvalidParameter, err)
+	})
+}
+
+func TestRepository_DeleteAuthMethod(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+
+	am := TestAuthMethods(t, conn, org.PublicId, testUrl, 1)[0]
+	TestAccounts(t, conn, am.PublicId, 2)
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+	rows, err := repo.DeleteAuthMethod(ctx, org.PublicId, am.PublicId)
+	require.NoError(err)
+	assert.Equal(1, rows)
+
+	found, err := repo.LookupAuthMethod(ctx, am.PublicId)
+	require.NoError(err)
+	assert.Nil(found)
+	accts, err := repo.ListAccounts(ctx, am.PublicId)
+	require.NoError(err)
+	assert.Empty(accts)
+}
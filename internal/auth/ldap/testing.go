@@ -0,0 +1,74 @@
+package ldap
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAuthMethods creates count number of ldap auth methods without a bind
+// password to the provided DB with the provided scope id and url. If any
+// errors are encountered during the creation of the auth methods, the test
+// will fail.
+func TestAuthMethods(t *testing.T, conn *gorm.DB, scopeId, url string, count int) []*AuthMethod {
+	t.Helper()
+	assert, require := assert.New(t), require.New(t)
+	w := db.New(conn)
+	var auts []*AuthMethod
+	for i := 0; i < count; i++ {
+		cat, err := NewAuthMethod(scopeId, url, fmt.Sprintf("ou=people%d,dc=example,dc=com", i))
+		assert.NoError(err)
+		require.NotNil(cat)
+		id, err := newAuthMethodId()
+		assert.NoError(err)
+		require.NotEmpty(id)
+		cat.PublicId = id
+
+		ctx := context.Background()
+		_, err2 := w.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+			func(_ db.Reader, iw db.Writer) error {
+				return iw.Create(ctx, cat)
+			},
+		)
+
+		require.NoError(err2)
+		auts = append(auts, cat)
+	}
+	return auts
+}
+
+// TestAccounts creates count number of ldap account to the provided DB
+// with the provided auth method id. The auth method must have been created
+// previously. If any errors are encountered during the creation of the
+// account, the test will fail.
+func TestAccounts(t *testing.T, conn *gorm.DB, authMethodId string, count int) []*Account {
+	t.Helper()
+	assert, require := assert.New(t), require.New(t)
+	w := db.New(conn)
+	var auts []*Account
+	for i := 0; i < count; i++ {
+		cat, err := NewAccount(authMethodId, fmt.Sprintf("uid=user%d,ou=people,dc=example,dc=com", i), fmt.Sprintf("user%d", i))
+		assert.NoError(err)
+		require.NotNil(cat)
+		id, err := newAccountId()
+		assert.NoError(err)
+		require.NotEmpty(id)
+		cat.PublicId = id
+
+		ctx := context.Background()
+		_, err2 := w.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+			func(_ db.Reader, iw db.Writer) error {
+				return iw.Create(ctx, cat)
+			},
+		)
+
+		require.NoError(err2)
+		auts = append(auts, cat)
+	}
+	return auts
+}
@@ -0,0 +1,81 @@
+package oidc
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/auth/oidc/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"google.golang.org/protobuf/proto"
+)
+
+// An Account is identified by the sub claim of the ID tokens issued by the
+// provider of its auth method. It is owned by an auth method.
+type Account struct {
+	*store.Account
+	tableName string
+}
+
+func allocAccount() *Account {
+	return &Account{
+		Account: &store.Account{},
+	}
+}
+
+// NewAccount creates a new in memory Account. Email, full name, name, and
+// description are the only valid options. All other options are ignored.
+func NewAccount(authMethodId, subject string, opt ...Option) (*Account, error) {
+	// NOTE: The scopeId in the embedded *store.Account is populated by a
+	// trigger in the database.
+	switch {
+	case authMethodId == "":
+		return nil, fmt.Errorf("new: oidc account: no auth method id: %w", errors.ErrInvalidParameter)
+	case subject == "":
+		return nil, fmt.Errorf("new: oidc account: no subject: %w", errors.ErrInvalidParameter)
+	}
+
+	opts := getOpts(opt...)
+	a := &Account{
+		Account: &store.Account{
+			AuthMethodId: authMethodId,
+			Subject:      subject,
+			Email:        opts.withEmail,
+			FullName:     opts.withFullName,
+			Name:         opts.withName,
+			Description:  opts.withDescription,
+		},
+	}
+	return a, nil
+}
+
+func (a *Account) clone() *Account {
+	cp := proto.Clone(a.Account)
+	return &Account{
+		Account: cp.(*store.Account),
+	}
+}
+
+// TableName returns the table name.
+func (a *Account) TableName() string {
+	if a.tableName != "" {
+		return a.tableName
+	}
+	return "auth_oidc_account"
+}
+
+// SetTableName sets the table name.
+func (a *Account) SetTableName(n string) {
+	a.tableName = n
+}
+
+func (a *Account) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{a.GetPublicId()},
+		"resource-type":      []string{"oidc account"},
+		"op-type":            []string{op.String()},
+	}
+	if a.AuthMethodId != "" {
+		metadata["auth-method-id"] = []string{a.AuthMethodId}
+	}
+	return metadata
+}
@@ -0,0 +1,82 @@
+package oidc
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth/oidc/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccount_New(t *testing.T) {
+	t.Parallel()
+	const authMethodId = "amoidc_1234567890"
+
+	type args struct {
+		authMethodId, subject string
+		opts                  []Option
+	}
+
+	var tests = []struct {
+		name      string
+		args      args
+		want      *Account
+		wantIsErr error
+	}{
+		{
+			name: "valid-no-options",
+			args: args{authMethodId, "alice", nil},
+			want: &Account{
+				Account: &store.Account{
+					AuthMethodId: authMethodId,
+					Subject:      "alice",
+				},
+			},
+		},
+		{
+			name: "valid-with-options",
+			args: args{authMethodId, "alice", []Option{
+				WithName("test-name"),
+				WithDescription("test-description"),
+				WithEmail("alice@example.com"),
+				WithFullName("Alice Smith"),
+			}},
+			want: &Account{
+				Account: &store.Account{
+					AuthMethodId: authMethodId,
+					Subject:      "alice",
+					Name:         "test-name",
+					Description:  "test-description",
+					Email:        "alice@example.com",
+					FullName:     "Alice Smith",
+				},
+			},
+		},
+		{
+			name:      "no-auth-method-id",
+			args:      args{"", "alice", nil},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name:      "no-subject",
+			args:      args{authMethodId, "", nil},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewAccount(tt.args.authMethodId, tt.args.subject, tt.args.opts...)
+			if tt.wantIsErr != nil {
+				assert.Truef(errors.Is(err, tt.wantIsErr), "want err: %q got: %q", tt.wantIsErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package oidc
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/auth/oidc/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"google.golang.org/protobuf/proto"
+)
+
+// An AudClaim is an audience, in addition to the client id, which is
+// accepted in the aud claim of an ID token issued for an AuthMethod.
+type AudClaim struct {
+	*store.AudClaim
+	tableName string
+}
+
+// NewAudClaim creates a new in memory AudClaim for the auth method.
+func NewAudClaim(authMethodId, aud string) (*AudClaim, error) {
+	switch {
+	case authMethodId == "":
+		return nil, fmt.Errorf("new: oidc aud claim: no auth method id: %w", errors.ErrInvalidParameter)
+	case aud == "":
+		return nil, fmt.Errorf("new: oidc aud claim: no audience: %w", errors.ErrInvalidParameter)
+	}
+	return &AudClaim{
+		AudClaim: &store.AudClaim{
+			OidcMethodId: authMethodId,
+			AudClaim:     aud,
+		},
+	}, nil
+}
+
+func (c *AudClaim) clone() *AudClaim {
+	cp := proto.Clone(c.AudClaim)
+	return &AudClaim{
+		AudClaim: cp.(*store.AudClaim),
+	}
+}
+
+// TableName returns the table name.
+func (c *AudClaim) TableName() string {
+	if c.tableName != "" {
+		return c.tableName
+	}
+	return "auth_oidc_aud_claim"
+}
+
+// SetTableName sets the table name.
+func (c *AudClaim) SetTableName(n string) {
+	c.tableName = n
+}
+
+func (c *AudClaim) oplog(op oplog.OpType) oplog.Metadata {
+	return oplog.Metadata{
+		"resource-public-id": []string{c.GetOidcMethodId()},
+		"resource-type":      []string{"oidc aud claim"},
+		"op-type":            []string{op.String()},
+	}
+}
@@ -0,0 +1,158 @@
+package oidc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/boundary/internal/auth/oidc/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/structwrapping"
+	"github.com/hashicorp/go-kms-wrapping/wrappers/aead"
+	"github.com/hashicorp/go-kms-wrapping/wrappers/multiwrapper"
+	"google.golang.org/protobuf/proto"
+)
+
+// An AuthMethod contains the configuration of an OIDC provider and the
+// OAuth 2.0 client registered with it. It is owned by a scope.
+type AuthMethod struct {
+	*store.AuthMethod
+	tableName string
+}
+
+func allocAuthMethod() AuthMethod {
+	return AuthMethod{
+		AuthMethod: &store.AuthMethod{},
+	}
+}
+
+// NewAuthMethod creates a new in memory AuthMethod assigned to scopeId.
+// The issuer and callbackUrl must be absolute http or https URLs. Name,
+// description, and allowed audiences are the only valid options. All other
+// options are ignored.
+func NewAuthMethod(scopeId, issuer, clientId, clientSecret, callbackUrl string, opt ...Option) (*AuthMethod, error) {
+	switch {
+	case scopeId == "":
+		return nil, fmt.Errorf("new: oidc auth method: no scope id: %w", errors.ErrInvalidParameter)
+	case clientId == "":
+		return nil, fmt.Errorf("new: oidc auth method: no client id: %w", errors.ErrInvalidParameter)
+	case clientSecret == "":
+		return nil, fmt.Errorf("new: oidc auth method: no client secret: %w", errors.ErrInvalidParameter)
+	}
+	if err := validateUrl(issuer); err != nil {
+		return nil, fmt.Errorf("new: oidc auth method: issuer: %w", err)
+	}
+	if err := validateUrl(callbackUrl); err != nil {
+		return nil, fmt.Errorf("new: oidc auth method: callback url: %w", err)
+	}
+
+	opts := getOpts(opt...)
+	a := &AuthMethod{
+		AuthMethod: &store.AuthMethod{
+			ScopeId:          scopeId,
+			Name:             opts.withName,
+			Description:      opts.withDescription,
+			Issuer:           issuer,
+			ClientId:         clientId,
+			ClientSecret:     clientSecret,
+			CallbackUrl:      callbackUrl,
+			AllowedAudiences: opts.withAllowedAudiences,
+		},
+	}
+	return a, nil
+}
+
+// validateUrl returns an error if u is not an absolute http or https URL.
+func validateUrl(u string) error {
+	if u == "" {
+		return fmt.Errorf("missing url: %w", errors.ErrInvalidParameter)
+	}
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return fmt.Errorf("%q is not a valid url: %w", u, errors.ErrInvalidParameter)
+	}
+	if parsed.Scheme != "https" && parsed.Scheme != "http" || parsed.Host == "" {
+		return fmt.Errorf("%q is not an absolute http or https url: %w", u, errors.ErrInvalidParameter)
+	}
+	return nil
+}
+
+func (a *AuthMethod) clone() *AuthMethod {
+	cp := proto.Clone(a.AuthMethod)
+	return &AuthMethod{
+		AuthMethod: cp.(*store.AuthMethod),
+	}
+}
+
+// TableName returns the table name.
+func (a *AuthMethod) TableName() string {
+	if a.tableName != "" {
+		return a.tableName
+	}
+	return "auth_oidc_method"
+}
+
+// SetTableName sets the table name.
+func (a *AuthMethod) SetTableName(n string) {
+	a.tableName = n
+}
+
+func (a *AuthMethod) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{a.GetPublicId()},
+		"resource-type":      []string{"oidc auth method"},
+		"op-type":            []string{op.String()},
+	}
+	if a.ScopeId != "" {
+		metadata["scope-id"] = []string{a.ScopeId}
+	}
+	return metadata
+}
+
+// encrypt encrypts the client secret and sets the client secret hmac and
+// the key id.
+func (a *AuthMethod) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	// structwrapping doesn't support embedding, so we'll pass in the store.AuthMethod directly
+	if err := structwrapping.WrapStruct(ctx, cipher, a.AuthMethod, nil); err != nil {
+		return fmt.Errorf("error encrypting oidc client secret: %w", err)
+	}
+	h, err := hmacClientSecret(cipher, a.ClientSecret)
+	if err != nil {
+		return err
+	}
+	a.ClientSecretHmac = h
+	a.KeyId = cipher.KeyID()
+	return nil
+}
+
+func (a *AuthMethod) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	// structwrapping doesn't support embedding, so we'll pass in the store.AuthMethod directly
+	if err := structwrapping.UnwrapStruct(ctx, cipher, a.AuthMethod, nil); err != nil {
+		return fmt.Errorf("error decrypting oidc client secret: %w", err)
+	}
+	return nil
+}
+
+// hmacClientSecret returns a sha256-hmac of secret keyed with the key of
+// cipher. The hmac lets callers detect a change to the client secret
+// without the secret being returned to them.
+func hmacClientSecret(cipher wrapping.Wrapper, secret string) (string, error) {
+	w := cipher
+	if mw, ok := w.(*multiwrapper.MultiWrapper); ok {
+		w = mw.WrapperForKeyID(mw.KeyID())
+	}
+	aw, ok := w.(*aead.Wrapper)
+	if !ok {
+		return "", fmt.Errorf("unable to hmac oidc client secret: unsupported wrapper type %T: %w", cipher, errors.ErrInvalidParameter)
+	}
+	mac := hmac.New(sha256.New, aw.GetKeyBytes())
+	if _, err := mac.Write([]byte(secret)); err != nil {
+		return "", fmt.Errorf("unable to hmac oidc client secret: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
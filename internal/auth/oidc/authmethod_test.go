@@ -0,0 +1,136 @@
+package oidc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth/oidc/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthMethod_New(t *testing.T) {
+	t.Parallel()
+	const (
+		scopeId  = "o_1234567890"
+		issuer   = "https://idp.example.com"
+		clientId = "client"
+		secret   = "secret"
+		callback = "https://boundary.example.com/v1/auth-methods/amoidc_1234567890:authenticate-callback"
+	)
+
+	type args struct {
+		scopeId, issuer, clientId, clientSecret, callbackUrl string
+		opts                                                 []Option
+	}
+
+	var tests = []struct {
+		name      string
+		args      args
+		want      *AuthMethod
+		wantIsErr error
+	}{
+		{
+			name: "valid-no-options",
+			args: args{scopeId, issuer, clientId, secret, callback, nil},
+			want: &AuthMethod{
+				AuthMethod: &store.AuthMethod{
+					ScopeId:      scopeId,
+					Issuer:       issuer,
+					ClientId:     clientId,
+					ClientSecret: secret,
+					CallbackUrl:  callback,
+				},
+			},
+		},
+		{
+			name: "valid-with-options",
+			args: args{scopeId, issuer, clientId, secret, callback, []Option{
+				WithName("test-name"),
+				WithDescription("test-description"),
+				WithAllowedAudiences("aud1", "aud2"),
+			}},
+			want: &AuthMethod{
+				AuthMethod: &store.AuthMethod{
+					ScopeId:          scopeId,
+					Name:             "test-name",
+					Description:      "test-description",
+					Issuer:           issuer,
+					ClientId:         clientId,
+					ClientSecret:     secret,
+					CallbackUrl:      callback,
+					AllowedAudiences: []string{"aud1", "aud2"},
+				},
+			},
+		},
+		{
+			name:      "no-scope-id",
+			args:      args{"", issuer, clientId, secret, callback, nil},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name:      "no-client-id",
+			args:      args{scopeId, issuer, "", secret, callback, nil},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name:      "no-client-secret",
+			args:      args{scopeId, issuer, clientId, "", callback, nil},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name:      "no-issuer",
+			args:      args{scopeId, "", clientId, secret, callback, nil},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name:      "relative-issuer",
+			args:      args{scopeId, "idp.example.com", clientId, secret, callback, nil},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name:      "unsupported-callback-scheme",
+			args:      args{scopeId, issuer, clientId, secret, "ftp://boundary.example.com", nil},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewAuthMethod(tt.args.scopeId, tt.args.issuer, tt.args.clientId, tt.args.clientSecret, tt.args.callbackUrl, tt.args.opts...)
+			if tt.wantIsErr != nil {
+				assert.Truef(errors.Is(err, tt.wantIsErr), "want err: %q got: %q", tt.wantIsErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestAuthMethod_Encrypt(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	wrapper := db.TestWrapper(t)
+
+	am, err := NewAuthMethod("o_1234567890", "https://idp.example.com", "client", "secret", "https://boundary.example.com/callback")
+	require.NoError(err)
+	require.NoError(am.encrypt(ctx, wrapper))
+	assert.NotEmpty(am.CtClientSecret)
+	assert.NotEmpty(am.ClientSecretHmac)
+	assert.Equal(wrapper.KeyID(), am.KeyId)
+
+	other := am.clone()
+	require.NoError(other.encrypt(ctx, wrapper))
+	assert.Equal(am.ClientSecretHmac, other.ClientSecretHmac, "hmac should not depend on the ciphertext")
+
+	am.ClientSecret = ""
+	require.NoError(am.decrypt(ctx, wrapper))
+	assert.Equal("secret", am.ClientSecret)
+}
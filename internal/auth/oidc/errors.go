@@ -0,0 +1,17 @@
+package oidc
+
+import "errors"
+
+var (
+	// ErrInvalidState results from a callback with a state which was not
+	// issued by StartAuth for the auth method.
+	ErrInvalidState = errors.New("invalid state")
+
+	// ErrTokenRequestExpired results from completing or redeeming a token
+	// request after its expiration time.
+	ErrTokenRequestExpired = errors.New("token request expired")
+
+	// ErrInvalidIdToken results from a callback where the ID token returned
+	// by the provider fails verification.
+	ErrInvalidIdToken = errors.New("invalid id token")
+)
@@ -0,0 +1,106 @@
+package oidc
+
+import "time"
+
+// getOpts - iterate the inbound Options and return a struct.
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments.
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withName             string
+	withDescription      string
+	withPublicId         string
+	withLimit            int
+	withStartPageAfterId string
+	withAllowedAudiences []string
+	withEmail            string
+	withFullName         string
+	withRequestTimeout   time.Duration
+}
+
+func getDefaultOptions() options {
+	return options{
+		withRequestTimeout: defaultTokenRequestTimeout,
+	}
+}
+
+// WithPublicId provides an optional public id
+func WithPublicId(id string) Option {
+	return func(o *options) {
+		o.withPublicId = id
+	}
+}
+
+// WithDescription provides an optional description.
+func WithDescription(desc string) Option {
+	return func(o *options) {
+		o.withDescription = desc
+	}
+}
+
+// WithName provides an optional name.
+func WithName(name string) Option {
+	return func(o *options) {
+		o.withName = name
+	}
+}
+
+// WithLimit provides an option to provide a limit.  Intentionally allowing
+// negative integers.   If WithLimit < 0, then unlimited results are returned.
+// If WithLimit == 0, then default limits are used for results.
+func WithLimit(l int) Option {
+	return func(o *options) {
+		o.withLimit = l
+	}
+}
+
+// WithStartPageAfterId provides an option to list only the resources whose
+// public ids sort after the given id. Results are ordered by public id when
+// it is used, allowing listing to be resumed where a previous page ended.
+func WithStartPageAfterId(id string) Option {
+	return func(o *options) {
+		o.withStartPageAfterId = id
+	}
+}
+
+// WithAllowedAudiences provides optional audiences which are accepted in
+// the aud claim of an ID token in addition to the client id.
+func WithAllowedAudiences(aud ...string) Option {
+	return func(o *options) {
+		o.withAllowedAudiences = aud
+	}
+}
+
+// WithEmail provides an optional email address.
+func WithEmail(email string) Option {
+	return func(o *options) {
+		o.withEmail = email
+	}
+}
+
+// WithFullName provides an optional full name.
+func WithFullName(n string) Option {
+	return func(o *options) {
+		o.withFullName = n
+	}
+}
+
+// WithRequestTimeout provides an optional duration after which a token
+// request started with StartAuth expires. Durations less than or equal to
+// zero are ignored.
+func WithRequestTimeout(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.withRequestTimeout = d
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package oidc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetOpts(t *testing.T) {
+	t.Parallel()
+	t.Run("WithPublicId", func(t *testing.T) {
+		opts := getOpts(WithPublicId("test id"))
+		testOpts := getDefaultOptions()
+		testOpts.withPublicId = "test id"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithName", func(t *testing.T) {
+		opts := getOpts(WithName("test"))
+		testOpts := getDefaultOptions()
+		testOpts.withName = "test"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithDescription", func(t *testing.T) {
+		opts := getOpts(WithDescription("test desc"))
+		testOpts := getDefaultOptions()
+		testOpts.withDescription = "test desc"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithLimit", func(t *testing.T) {
+		opts := getOpts(WithLimit(5))
+		testOpts := getDefaultOptions()
+		testOpts.withLimit = 5
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithStartPageAfterId", func(t *testing.T) {
+		opts := getOpts(WithStartPageAfterId("aoidc_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withStartPageAfterId = "aoidc_1234567890"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithAllowedAudiences", func(t *testing.T) {
+		opts := getOpts(WithAllowedAudiences("aud1", "aud2"))
+		testOpts := getDefaultOptions()
+		testOpts.withAllowedAudiences = []string{"aud1", "aud2"}
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithEmail", func(t *testing.T) {
+		opts := getOpts(WithEmail("alice@example.com"))
+		testOpts := getDefaultOptions()
+		testOpts.withEmail = "alice@example.com"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithFullName", func(t *testing.T) {
+		opts := getOpts(WithFullName("Alice Smith"))
+		testOpts := getDefaultOptions()
+		testOpts.withFullName = "Alice Smith"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithRequestTimeout", func(t *testing.T) {
+		opts := getOpts(WithRequestTimeout(time.Minute))
+		testOpts := getDefaultOptions()
+		testOpts.withRequestTimeout = time.Minute
+		assert.Equal(t, opts, testOpts)
+
+		opts = getOpts(WithRequestTimeout(-time.Minute))
+		assert.Equal(t, getDefaultOptions(), opts)
+	})
+}
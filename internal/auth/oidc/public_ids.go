@@ -0,0 +1,38 @@
+package oidc
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+)
+
+// PublicId prefixes for the resources in the oidc package.
+const (
+	AuthMethodPrefix   = "amoidc"
+	AccountPrefix      = "aoidc"
+	TokenRequestPrefix = "atroidc"
+)
+
+func newAuthMethodId() (string, error) {
+	id, err := db.NewPublicId(AuthMethodPrefix)
+	if err != nil {
+		return "", fmt.Errorf("new oidc auth method id: %w", err)
+	}
+	return id, err
+}
+
+func newAccountId() (string, error) {
+	id, err := db.NewPublicId(AccountPrefix)
+	if err != nil {
+		return "", fmt.Errorf("new oidc account id: %w", err)
+	}
+	return id, err
+}
+
+func newTokenRequestId() (string, error) {
+	id, err := db.NewPublicId(TokenRequestPrefix)
+	if err != nil {
+		return "", fmt.Errorf("new oidc token request id: %w", err)
+	}
+	return id, err
+}
@@ -0,0 +1,17 @@
+package oidc
+
+const (
+	deleteExpiredTokenRequests = `
+delete from auth_oidc_token_request
+ where auth_method_id = $1
+   and expiration_time < current_timestamp;
+`
+
+	completeTokenRequest = `
+update auth_oidc_token_request
+   set auth_account_id = $1
+ where public_id = $2
+   and auth_account_id is null
+   and expiration_time >= current_timestamp;
+`
+)
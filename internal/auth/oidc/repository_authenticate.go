@@ -0,0 +1,292 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/coreos/go-oidc"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/vault/sdk/helper/base62"
+	"github.com/mr-tron/base58"
+	"golang.org/x/oauth2"
+	"google.golang.org/protobuf/proto"
+)
+
+// StartAuth begins an authentication attempt with the auth method. It
+// returns the URL of the provider's authorization endpoint the user must
+// visit to authenticate and the id of the token request which is used to
+// redeem the auth token once the provider has redirected the user to the
+// callback url of the auth method.
+//
+// WithRequestTimeout is the only valid option. All other options are
+// ignored.
+func (r *Repository) StartAuth(ctx context.Context, authMethodId string, opt ...Option) (authUrl string, tokenRequestId string, err error) {
+	if authMethodId == "" {
+		return "", "", fmt.Errorf("start auth: oidc: missing auth method id: %w", errors.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+
+	am, err := r.lookupAuthMethod(ctx, r.reader, authMethodId)
+	if err != nil {
+		return "", "", fmt.Errorf("start auth: oidc: %w", err)
+	}
+	if am == nil {
+		return "", "", fmt.Errorf("start auth: oidc: auth method %s: %w", authMethodId, errors.ErrRecordNotFound)
+	}
+
+	req := allocTokenRequest()
+	if req.PublicId, err = newTokenRequestId(); err != nil {
+		return "", "", fmt.Errorf("start auth: oidc: %w", err)
+	}
+	if req.Nonce, err = base62.Random(32); err != nil {
+		return "", "", fmt.Errorf("start auth: oidc: unable to generate nonce: %w", err)
+	}
+	req.AuthMethodId = authMethodId
+	// We truncate the expiration time to the nearest second to make testing in different platforms with
+	// different time resolutions easier.
+	expiration, err := ptypes.TimestampProto(time.Now().Add(opts.withRequestTimeout).Truncate(time.Second))
+	if err != nil {
+		return "", "", fmt.Errorf("start auth: oidc: %w", err)
+	}
+	req.ExpirationTime = &timestamp.Timestamp{Timestamp: expiration}
+
+	state, err := r.encryptState(ctx, am, req.PublicId)
+	if err != nil {
+		return "", "", fmt.Errorf("start auth: oidc: %w", err)
+	}
+
+	provider, err := oidc.NewProvider(ctx, am.Issuer)
+	if err != nil {
+		return "", "", fmt.Errorf("start auth: oidc: unable to discover provider %s: %w", am.Issuer, err)
+	}
+	// The client secret is not needed to build the authorization URL.
+	cfg := oauth2Config(am, provider)
+
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			// Expired token requests are removed whenever a new request is
+			// started for the auth method.
+			if _, err := w.Exec(ctx, deleteExpiredTokenRequests, []interface{}{authMethodId}); err != nil {
+				return fmt.Errorf("unable to delete expired token requests: %w", err)
+			}
+			// token requests are not replicated, so they don't need oplog entries.
+			return w.Create(ctx, req.clone())
+		},
+	)
+	if err != nil {
+		return "", "", fmt.Errorf("start auth: oidc: %w", err)
+	}
+
+	return cfg.AuthCodeURL(state, oidc.Nonce(req.Nonce)), req.PublicId, nil
+}
+
+// Callback completes an authentication attempt started with StartAuth. The
+// code is exchanged with the provider for an ID token which must be issued
+// for the auth method's client id or one of its allowed audiences and
+// contain the nonce of the token request. The account for the sub claim of
+// the ID token is created if it does not exist and is recorded on the token
+// request so it can be redeemed for an auth token. Callback returns the
+// account. All options are ignored.
+func (r *Repository) Callback(ctx context.Context, authMethodId, state, code string, opt ...Option) (*Account, error) {
+	switch {
+	case authMethodId == "":
+		return nil, fmt.Errorf("callback: oidc: missing auth method id: %w", errors.ErrInvalidParameter)
+	case state == "":
+		return nil, fmt.Errorf("callback: oidc: missing state: %w", errors.ErrInvalidParameter)
+	case code == "":
+		return nil, fmt.Errorf("callback: oidc: missing code: %w", errors.ErrInvalidParameter)
+	}
+
+	am, err := r.lookupAuthMethodWithSecret(ctx, authMethodId)
+	if err != nil {
+		return nil, fmt.Errorf("callback: oidc: %w", err)
+	}
+	if am == nil {
+		return nil, fmt.Errorf("callback: oidc: auth method %s: %w", authMethodId, errors.ErrRecordNotFound)
+	}
+
+	reqId, err := r.decryptState(ctx, am, state)
+	if err != nil {
+		return nil, fmt.Errorf("callback: oidc: %w", err)
+	}
+	req := allocTokenRequest()
+	req.PublicId = reqId
+	if err := r.reader.LookupByPublicId(ctx, req); err != nil {
+		if errors.Is(err, errors.ErrRecordNotFound) {
+			return nil, fmt.Errorf("callback: oidc: token request %s: %w", reqId, ErrInvalidState)
+		}
+		return nil, fmt.Errorf("callback: oidc: %w", err)
+	}
+	switch {
+	case req.AuthMethodId != authMethodId:
+		return nil, fmt.Errorf("callback: oidc: token request %s: %w", reqId, ErrInvalidState)
+	case req.AuthAccountId != "":
+		return nil, fmt.Errorf("callback: oidc: token request %s already completed: %w", reqId, ErrInvalidState)
+	case req.expired():
+		return nil, fmt.Errorf("callback: oidc: token request %s: %w", reqId, ErrTokenRequestExpired)
+	}
+
+	provider, err := oidc.NewProvider(ctx, am.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("callback: oidc: unable to discover provider %s: %w", am.Issuer, err)
+	}
+	oauthToken, err := oauth2Config(am, provider).Exchange(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("callback: oidc: unable to exchange code: %w", err)
+	}
+	rawIdToken, ok := oauthToken.Extra("id_token").(string)
+	if !ok || rawIdToken == "" {
+		return nil, fmt.Errorf("callback: oidc: provider response has no id token: %w", ErrInvalidIdToken)
+	}
+
+	// The audience is checked below against the client id and the allowed
+	// audiences of the auth method.
+	verifier := provider.Verifier(&oidc.Config{SkipClientIDCheck: true})
+	idToken, err := verifier.Verify(ctx, rawIdToken)
+	if err != nil {
+		return nil, fmt.Errorf("callback: oidc: %v: %w", err, ErrInvalidIdToken)
+	}
+	if !am.acceptsAudience(idToken.Audience) {
+		return nil, fmt.Errorf("callback: oidc: audience %v not allowed: %w", idToken.Audience, ErrInvalidIdToken)
+	}
+	if idToken.Nonce != req.Nonce {
+		return nil, fmt.Errorf("callback: oidc: nonce mismatch: %w", ErrInvalidIdToken)
+	}
+	var claims struct {
+		Email string `json:"email"`
+		Name  string `json:"name"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("callback: oidc: unable to parse claims: %v: %w", err, ErrInvalidIdToken)
+	}
+
+	acct, err := r.upsertAccount(ctx, am.ScopeId, authMethodId, idToken.Subject, WithEmail(claims.Email), WithFullName(claims.Name))
+	if err != nil {
+		return nil, fmt.Errorf("callback: oidc: %w", err)
+	}
+
+	rowsUpdated, err := r.writer.Exec(ctx, completeTokenRequest, []interface{}{acct.PublicId, reqId})
+	if err != nil {
+		return nil, fmt.Errorf("callback: oidc: token request %s: %w", reqId, err)
+	}
+	if rowsUpdated == 0 {
+		// The request expired or was completed by a concurrent callback.
+		return nil, fmt.Errorf("callback: oidc: token request %s: %w", reqId, ErrTokenRequestExpired)
+	}
+	return acct, nil
+}
+
+// RedeemTokenRequest returns the id of the account authenticated by the
+// callback for the token request and deletes the token request. An empty
+// id is returned if the callback for the token request has not yet
+// completed. All options are ignored.
+func (r *Repository) RedeemTokenRequest(ctx context.Context, authMethodId, tokenRequestId string, opt ...Option) (string, error) {
+	switch {
+	case authMethodId == "":
+		return "", fmt.Errorf("redeem token request: oidc: missing auth method id: %w", errors.ErrInvalidParameter)
+	case tokenRequestId == "":
+		return "", fmt.Errorf("redeem token request: oidc: missing token request id: %w", errors.ErrInvalidParameter)
+	}
+	var accountId string
+	_, err := r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			req := allocTokenRequest()
+			req.PublicId = tokenRequestId
+			if err := reader.LookupByPublicId(ctx, req); err != nil {
+				return err
+			}
+			switch {
+			case req.AuthMethodId != authMethodId:
+				return errors.ErrRecordNotFound
+			case req.expired():
+				return ErrTokenRequestExpired
+			case req.AuthAccountId == "":
+				return nil
+			}
+			accountId = req.AuthAccountId
+			rowsDeleted, err := w.Delete(ctx, req)
+			if err == nil && rowsDeleted > 1 {
+				return errors.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+	if err != nil {
+		return "", fmt.Errorf("redeem token request: oidc: %s: %w", tokenRequestId, err)
+	}
+	return accountId, nil
+}
+
+// acceptsAudience reports whether any of auds is the client id or one of
+// the allowed audiences of the auth method.
+func (a *AuthMethod) acceptsAudience(auds []string) bool {
+	for _, aud := range auds {
+		if aud == a.ClientId {
+			return true
+		}
+		for _, allowed := range a.AllowedAudiences {
+			if aud == allowed {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func oauth2Config(am *AuthMethod, provider *oidc.Provider) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     am.ClientId,
+		ClientSecret: am.ClientSecret,
+		Endpoint:     provider.Endpoint(),
+		RedirectURL:  am.CallbackUrl,
+		Scopes:       []string{oidc.ScopeOpenID, "email", "profile"},
+	}
+}
+
+// encryptState returns the state sent to the provider for a token request.
+// The state is the token request id encrypted with the database key of the
+// auth method's scope so a callback can only complete requests started by
+// StartAuth for the same auth method.
+func (r *Repository) encryptState(ctx context.Context, am *AuthMethod, tokenRequestId string) (string, error) {
+	databaseWrapper, err := r.kms.GetWrapper(ctx, am.ScopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return "", fmt.Errorf("unable to get database wrapper: %w", err)
+	}
+	blobInfo, err := databaseWrapper.Encrypt(ctx, []byte(tokenRequestId), []byte(am.PublicId))
+	if err != nil {
+		return "", fmt.Errorf("error encrypting state: %w", err)
+	}
+	marshaledBlob, err := proto.Marshal(blobInfo)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling encrypted state: %w", err)
+	}
+	return base58.FastBase58Encoding(marshaledBlob), nil
+}
+
+// decryptState returns the token request id in a state created by
+// encryptState.
+func (r *Repository) decryptState(ctx context.Context, am *AuthMethod, state string) (string, error) {
+	marshaledBlob, err := base58.FastBase58Decoding(state)
+	if err != nil {
+		return "", fmt.Errorf("unable to decode state: %v: %w", err, ErrInvalidState)
+	}
+	blobInfo := new(wrapping.EncryptedBlobInfo)
+	if err := proto.Unmarshal(marshaledBlob, blobInfo); err != nil {
+		return "", fmt.Errorf("unable to unmarshal state: %v: %w", err, ErrInvalidState)
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, am.ScopeId, kms.KeyPurposeDatabase, kms.WithKeyId(blobInfo.GetKeyInfo().GetKeyID()))
+	if err != nil {
+		return "", fmt.Errorf("unable to get database wrapper: %w", err)
+	}
+	id, err := databaseWrapper.Decrypt(ctx, blobInfo, []byte(am.PublicId))
+	if err != nil {
+		return "", fmt.Errorf("unable to decrypt state: %v: %w", err, ErrInvalidState)
+	}
+	return string(id), nil
+}
@@ -0,0 +1,158 @@
+package oidc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/auth/oidc/store"
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthMethod_acceptsAudience(t *testing.T) {
+	t.Parallel()
+	am := &AuthMethod{
+		AuthMethod: &store.AuthMethod{
+			ClientId:         "client",
+			AllowedAudiences: []string{"aud1", "aud2"},
+		},
+	}
+	var tests = []struct {
+		name string
+		auds []string
+		want bool
+	}{
+		{"none", nil, false},
+		{"client-id", []string{"client"}, true},
+		{"allowed", []string{"other", "aud2"}, true},
+		{"not-allowed", []string{"other"}, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, am.acceptsAudience(tt.auds))
+		})
+	}
+}
+
+func TestRepository_Authenticate(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	ctx := context.Background()
+
+	provider := NewTestProvider(t, "client", "secret")
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+	atRepo, err := authtoken.NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	in, err := NewAuthMethod(org.PublicId, provider.Issuer(), provider.ClientId, provider.ClientSecret, testCallbackUrl, WithAllowedAudiences("extra"))
+	require.NoError(t, err)
+	am, err := repo.CreateAuthMethod(ctx, in)
+	require.NoError(t, err)
+
+	t.Run("full-flow", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		authUrl, reqId, err := repo.StartAuth(ctx, am.PublicId)
+		require.NoError(err)
+		assert.NotEmpty(authUrl)
+		assert.NotEmpty(reqId)
+
+		// The callback has not completed yet.
+		acctId, err := repo.RedeemTokenRequest(ctx, am.PublicId, reqId)
+		require.NoError(err)
+		assert.Empty(acctId)
+
+		code, state := provider.Authorize(t, authUrl, "alice", "alice@example.com", "Alice Smith", "")
+		acct, err := repo.Callback(ctx, am.PublicId, state, code)
+		require.NoError(err)
+		assert.Equal("alice", acct.Subject)
+		assert.Equal("alice@example.com", acct.Email)
+		assert.Equal("Alice Smith", acct.FullName)
+
+		// A token request can only be completed once.
+		code, _ = provider.Authorize(t, authUrl, "alice", "", "", "")
+		_, err = repo.Callback(ctx, am.PublicId, state, code)
+		assert.Truef(errors.Is(err, ErrInvalidState), "want err: %q got: %q", ErrInvalidState, err)
+
+		acctId, err = repo.RedeemTokenRequest(ctx, am.PublicId, reqId)
+		require.NoError(err)
+		assert.Equal(acct.PublicId, acctId)
+
+		// A token request can only be redeemed once.
+		_, err = repo.RedeemTokenRequest(ctx, am.PublicId, reqId)
+		assert.Truef(errors.Is(err, errors.ErrRecordNotFound), "want err: %q got: %q", errors.ErrRecordNotFound, err)
+
+		// An auth token can be issued for the account.
+		u, err := iamRepo.LookupUserWithLogin(ctx, acctId, iam.WithAutoVivify(true))
+		require.NoError(err)
+		tok, err := atRepo.CreateAuthToken(ctx, u, acctId)
+		require.NoError(err)
+		assert.Equal(am.PublicId, tok.AuthMethodId)
+
+		// Authenticating again returns the same account with updated claims.
+		authUrl, _, err = repo.StartAuth(ctx, am.PublicId)
+		require.NoError(err)
+		code, state = provider.Authorize(t, authUrl, "alice", "alice@example.org", "", "extra")
+		again, err := repo.Callback(ctx, am.PublicId, state, code)
+		require.NoError(err)
+		assert.Equal(acct.PublicId, again.PublicId)
+		assert.Equal("alice@example.org", again.Email)
+		assert.Empty(again.FullName)
+	})
+
+	t.Run("audience-not-allowed", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		authUrl, _, err := repo.StartAuth(ctx, am.PublicId)
+		require.NoError(err)
+		code, state := provider.Authorize(t, authUrl, "bob", "", "", "someone-else")
+		acct, err := repo.Callback(ctx, am.PublicId, state, code)
+		assert.Truef(errors.Is(err, ErrInvalidIdToken), "want err: %q got: %q", ErrInvalidIdToken, err)
+		assert.Nil(acct)
+	})
+
+	t.Run("invalid-state", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		authUrl, _, err := repo.StartAuth(ctx, am.PublicId)
+		require.NoError(err)
+		code, _ := provider.Authorize(t, authUrl, "bob", "", "", "")
+		acct, err := repo.Callback(ctx, am.PublicId, "not-a-state", code)
+		assert.Truef(errors.Is(err, ErrInvalidState), "want err: %q got: %q", ErrInvalidState, err)
+		assert.Nil(acct)
+	})
+
+	t.Run("state-for-other-auth-method", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		other, err := repo.CreateAuthMethod(ctx, in)
+		require.NoError(err)
+		authUrl, _, err := repo.StartAuth(ctx, other.PublicId)
+		require.NoError(err)
+		code, state := provider.Authorize(t, authUrl, "bob", "", "", "")
+		acct, err := repo.Callback(ctx, am.PublicId, state, code)
+		assert.Truef(errors.Is(err, ErrInvalidState), "want err: %q got: %q", ErrInvalidState, err)
+		assert.Nil(acct)
+	})
+
+	t.Run("expired-request", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		authUrl, reqId, err := repo.StartAuth(ctx, am.PublicId, WithRequestTimeout(time.Second))
+		require.NoError(err)
+		time.Sleep(2 * time.Second)
+		code, state := provider.Authorize(t, authUrl, "bob", "", "", "")
+		acct, err := repo.Callback(ctx, am.PublicId, state, code)
+		assert.Truef(errors.Is(err, ErrTokenRequestExpired), "want err: %q got: %q", ErrTokenRequestExpired, err)
+		assert.Nil(acct)
+		_, err = repo.RedeemTokenRequest(ctx, am.PublicId, reqId)
+		assert.Truef(errors.Is(err, ErrTokenRequestExpired), "want err: %q got: %q", ErrTokenRequestExpired, err)
+	})
+}
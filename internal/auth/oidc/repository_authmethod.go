@@ -0,0 +1,468 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	dbcommon "github.com/hashicorp/boundary/internal/db/common"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// CreateAuthMethod inserts m into the repository and returns a new
+// AuthMethod containing the auth method's PublicId. m is not changed. m must
+// contain a valid ScopeId, Issuer, ClientId, ClientSecret, and CallbackUrl.
+// m must not contain a PublicId. The PublicId is generated and assigned by
+// this method. The client secret is encrypted before it is stored and is
+// not included in the returned AuthMethod.
+//
+// WithPublicId is the only valid option. All other options are ignored.
+//
+// Both m.Name and m.Description are optional. If m.Name is set, it must be
+// unique within m.ScopeId.
+func (r *Repository) CreateAuthMethod(ctx context.Context, m *AuthMethod, opt ...Option) (*AuthMethod, error) {
+	if m == nil {
+		return nil, fmt.Errorf("create: oidc auth method: %w", errors.ErrInvalidParameter)
+	}
+	if m.AuthMethod == nil {
+		return nil, fmt.Errorf("create: oidc auth method: embedded AuthMethod: %w", errors.ErrInvalidParameter)
+	}
+	if m.ScopeId == "" {
+		return nil, fmt.Errorf("create: oidc auth method: no scope id: %w", errors.ErrInvalidParameter)
+	}
+	if m.PublicId != "" {
+		return nil, fmt.Errorf("create: oidc auth method: public id not empty: %w", errors.ErrInvalidParameter)
+	}
+	if m.ClientId == "" {
+		return nil, fmt.Errorf("create: oidc auth method: no client id: %w", errors.ErrInvalidParameter)
+	}
+	if m.ClientSecret == "" {
+		return nil, fmt.Errorf("create: oidc auth method: no client secret: %w", errors.ErrInvalidParameter)
+	}
+	if err := validateUrl(m.Issuer); err != nil {
+		return nil, fmt.Errorf("create: oidc auth method: issuer: %w", err)
+	}
+	if err := validateUrl(m.CallbackUrl); err != nil {
+		return nil, fmt.Errorf("create: oidc auth method: callback url: %w", err)
+	}
+	m = m.clone()
+
+	opts := getOpts(opt...)
+
+	if opts.withPublicId != "" {
+		if !strings.HasPrefix(opts.withPublicId, AuthMethodPrefix+"_") {
+			return nil, fmt.Errorf("create: oidc auth method: passed-in public ID %q has wrong prefix, should be %q: %w", opts.withPublicId, AuthMethodPrefix, errors.ErrInvalidPublicId)
+		}
+		m.PublicId = opts.withPublicId
+	} else {
+		id, err := newAuthMethodId()
+		if err != nil {
+			return nil, fmt.Errorf("create: oidc auth method: %w", err)
+		}
+		m.PublicId = id
+	}
+
+	claims, err := newAudClaims(m.PublicId, m.AllowedAudiences)
+	if err != nil {
+		return nil, fmt.Errorf("create: oidc auth method: %w", err)
+	}
+
+	databaseWrapper, err := r.kms.GetWrapper(ctx, m.ScopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, fmt.Errorf("create: oidc auth method: unable to get database wrapper: %w", err)
+	}
+	if err := m.encrypt(ctx, databaseWrapper); err != nil {
+		return nil, fmt.Errorf("create: oidc auth method: %w", err)
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, m.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, fmt.Errorf("create: oidc auth method: unable to get oplog wrapper: %w", err)
+	}
+
+	var newAuthMethod *AuthMethod
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			newAuthMethod = m.clone()
+			var msgs []*oplog.Message
+			ticket, err := w.GetTicket(newAuthMethod)
+			if err != nil {
+				return fmt.Errorf("unable to get ticket: %w", err)
+			}
+
+			amMsg := new(oplog.Message)
+			if err := w.Create(ctx, newAuthMethod, db.NewOplogMsg(amMsg)); err != nil {
+				return err
+			}
+			msgs = append(msgs, amMsg)
+
+			if len(claims) > 0 {
+				if err := w.CreateItems(ctx, claims, db.NewOplogMsgs(&msgs)); err != nil {
+					return fmt.Errorf("unable to create aud claims: %w", err)
+				}
+			}
+
+			metadata := newAuthMethod.oplog(oplog.OpType_OP_TYPE_CREATE)
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, ticket, metadata, msgs); err != nil {
+				return fmt.Errorf("unable to write oplog: %w", err)
+			}
+			return nil
+		},
+	)
+
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, fmt.Errorf("create: oidc auth method: in scope: %s: name %s already exists: %w",
+				m.ScopeId, m.Name, errors.ErrNotUnique)
+		}
+		return nil, fmt.Errorf("create: oidc auth method: in scope: %s: %w", m.ScopeId, err)
+	}
+	newAuthMethod.ClientSecret = ""
+	newAuthMethod.CtClientSecret = nil
+	return newAuthMethod, nil
+}
+
+// LookupAuthMethod will look up an auth method in the repository. If the
+// auth method is not found, it will return nil, nil. The client secret is
+// not included in the returned AuthMethod. All options are ignored.
+func (r *Repository) LookupAuthMethod(ctx context.Context, publicId string, opt ...Option) (*AuthMethod, error) {
+	if publicId == "" {
+		return nil, fmt.Errorf("lookup: oidc auth method: missing public id %w", errors.ErrInvalidParameter)
+	}
+	a, err := r.lookupAuthMethod(ctx, r.reader, publicId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup: oidc auth method: %w", err)
+	}
+	if a == nil {
+		return nil, nil
+	}
+	a.CtClientSecret = nil
+	return a, nil
+}
+
+// lookupAuthMethod returns the auth method with its allowed audiences and
+// its encrypted client secret. If the auth method is not found, it returns
+// nil, nil.
+func (r *Repository) lookupAuthMethod(ctx context.Context, reader db.Reader, publicId string) (*AuthMethod, error) {
+	a := allocAuthMethod()
+	a.PublicId = publicId
+	if err := reader.LookupByPublicId(ctx, &a); err != nil {
+		if errors.Is(err, errors.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed %w for %s", err, publicId)
+	}
+	if err := loadAudClaims(ctx, reader, &a); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// lookupAuthMethodWithSecret returns the auth method with its client secret
+// decrypted. If the auth method is not found, it returns nil, nil.
+func (r *Repository) lookupAuthMethodWithSecret(ctx context.Context, publicId string) (*AuthMethod, error) {
+	a, err := r.lookupAuthMethod(ctx, r.reader, publicId)
+	if err != nil || a == nil {
+		return nil, err
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, a.ScopeId, kms.KeyPurposeDatabase, kms.WithKeyId(a.KeyId))
+	if err != nil {
+		return nil, fmt.Errorf("unable to get database wrapper: %w", err)
+	}
+	if err := a.decrypt(ctx, databaseWrapper); err != nil {
+		return nil, err
+	}
+	a.CtClientSecret = nil
+	return a, nil
+}
+
+// ListAuthMethods returns a slice of AuthMethods in the given scopes. The
+// client secrets are not included in the returned AuthMethods. Supports the
+// WithLimit and WithStartPageAfterId options.
+func (r *Repository) ListAuthMethods(ctx context.Context, scopeIds []string, opt ...Option) ([]*AuthMethod, error) {
+	if len(scopeIds) == 0 {
+		return nil, fmt.Errorf("list: oidc auth method: missing scope id: %w", errors.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	where, args := "scope_id in (?)", []interface{}{scopeIds}
+	if opts.withStartPageAfterId != "" {
+		where, args = where+" and public_id > ?", append(args, opts.withStartPageAfterId)
+	}
+	var authMethods []*AuthMethod
+	err := r.reader.SearchWhere(ctx, &authMethods, where, args, db.WithLimit(limit), db.WithOrder("public_id"))
+	if err != nil {
+		return nil, fmt.Errorf("list: oidc auth method: %w", err)
+	}
+	for _, am := range authMethods {
+		if err := loadAudClaims(ctx, r.reader, am); err != nil {
+			return nil, fmt.Errorf("list: oidc auth method: %w", err)
+		}
+		am.CtClientSecret = nil
+	}
+	return authMethods, nil
+}
+
+// DeleteAuthMethod deletes the auth method for the provided id from the
+// repository returning a count of the number of records deleted. All
+// options are ignored.
+func (r *Repository) DeleteAuthMethod(ctx context.Context, scopeId, publicId string, opt ...Option) (int, error) {
+	if publicId == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete: oidc auth method: missing public id: %w", errors.ErrInvalidParameter)
+	}
+	am := allocAuthMethod()
+	am.PublicId = publicId
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete: oidc auth method: unable to get oplog wrapper: %w", err)
+	}
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) (err error) {
+			metadata := am.oplog(oplog.OpType_OP_TYPE_DELETE)
+			dAc := am.clone()
+			rowsDeleted, err = w.Delete(ctx, dAc, db.WithOplog(oplogWrapper, metadata))
+			if err == nil && rowsDeleted > 1 {
+				return errors.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete: oidc auth method: %s: %w", publicId, err)
+	}
+
+	return rowsDeleted, nil
+}
+
+// UpdateAuthMethod will update an auth method in the repository and return
+// the written auth method. fieldMaskPaths provides field_mask.proto paths
+// for fields that should be updated. Name and Description will be set to
+// NULL if the field is a zero value and included in fieldMask. Issuer,
+// ClientId, ClientSecret, and CallbackUrl cannot be set to NULL.
+// AllowedAudiences replaces the allowed audiences of the auth method and
+// may be empty. If no updatable fields are included in the fieldMaskPaths,
+// then an error is returned.
+func (r *Repository) UpdateAuthMethod(ctx context.Context, authMethod *AuthMethod, version uint32, fieldMaskPaths []string, opt ...Option) (*AuthMethod, int, error) {
+	if authMethod == nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: oidc auth method: missing authMethod: %w", errors.ErrInvalidParameter)
+	}
+	if authMethod.PublicId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: oidc auth method: missing authMethod public id: %w", errors.ErrInvalidParameter)
+	}
+	if authMethod.ScopeId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: oidc auth method: scope id empty: %w", errors.ErrInvalidParameter)
+	}
+	var updateSecret, updateAudiences bool
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold("name", f):
+		case strings.EqualFold("description", f):
+		case strings.EqualFold("Issuer", f):
+			if err := validateUrl(authMethod.Issuer); err != nil {
+				return nil, db.NoRowsAffected, fmt.Errorf("update: oidc auth method: issuer: %w", err)
+			}
+		case strings.EqualFold("ClientId", f):
+			if authMethod.ClientId == "" {
+				return nil, db.NoRowsAffected, fmt.Errorf("update: oidc auth method: no client id: %w", errors.ErrInvalidParameter)
+			}
+		case strings.EqualFold("ClientSecret", f):
+			if authMethod.ClientSecret == "" {
+				return nil, db.NoRowsAffected, fmt.Errorf("update: oidc auth method: no client secret: %w", errors.ErrInvalidParameter)
+			}
+			updateSecret = true
+		case strings.EqualFold("CallbackUrl", f):
+			if err := validateUrl(authMethod.CallbackUrl); err != nil {
+				return nil, db.NoRowsAffected, fmt.Errorf("update: oidc auth method: callback url: %w", err)
+			}
+		case strings.EqualFold("AllowedAudiences", f):
+			updateAudiences = true
+		default:
+			return nil, db.NoRowsAffected, fmt.Errorf("update: oidc auth method: field: %s: %w", f, errors.ErrInvalidFieldMask)
+		}
+	}
+	var dbMask, nullFields []string
+	dbMask, nullFields = dbcommon.BuildUpdatePaths(
+		map[string]interface{}{
+			"Name":        authMethod.Name,
+			"Description": authMethod.Description,
+			"Issuer":      authMethod.Issuer,
+			"ClientId":    authMethod.ClientId,
+			"CallbackUrl": authMethod.CallbackUrl,
+		},
+		fieldMaskPaths,
+		nil,
+	)
+	if len(dbMask) == 0 && len(nullFields) == 0 && !updateSecret && !updateAudiences {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: oidc auth method: %w", errors.ErrEmptyFieldMask)
+	}
+
+	upAuthMethod := authMethod.clone()
+	if updateSecret {
+		databaseWrapper, err := r.kms.GetWrapper(ctx, authMethod.ScopeId, kms.KeyPurposeDatabase)
+		if err != nil {
+			return nil, db.NoRowsAffected, fmt.Errorf("update: oidc auth method: unable to get database wrapper: %w", err)
+		}
+		if err := upAuthMethod.encrypt(ctx, databaseWrapper); err != nil {
+			return nil, db.NoRowsAffected, fmt.Errorf("update: oidc auth method: %w", err)
+		}
+		dbMask = append(dbMask, "CtClientSecret", "ClientSecretHmac", "KeyId")
+	}
+	if len(dbMask) == 0 && len(nullFields) == 0 {
+		// Only the allowed audiences are changing; the version of the auth
+		// method is still incremented.
+		upAuthMethod.Version = version
+		dbMask = append(dbMask, "Version")
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, authMethod.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: oidc auth method: unable to get oplog wrapper: %w", err)
+	}
+
+	var returnedAuthMethod *AuthMethod
+	var rowsUpdated int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			var msgs []*oplog.Message
+			ticket, err := w.GetTicket(upAuthMethod)
+			if err != nil {
+				return fmt.Errorf("unable to get ticket: %w", err)
+			}
+
+			amMsg := new(oplog.Message)
+			rowsUpdated, err = w.Update(
+				ctx,
+				upAuthMethod.clone(),
+				dbMask,
+				nullFields,
+				db.NewOplogMsg(amMsg),
+				db.WithVersion(&version),
+			)
+			switch {
+			case err != nil:
+				return err
+			case rowsUpdated > 1:
+				return errors.ErrMultipleRecords
+			case rowsUpdated == 0:
+				return nil
+			}
+			msgs = append(msgs, amMsg)
+
+			if updateAudiences {
+				claimMsgs, err := r.replaceAudClaims(ctx, reader, w, upAuthMethod.PublicId, upAuthMethod.AllowedAudiences)
+				if err != nil {
+					return err
+				}
+				msgs = append(msgs, claimMsgs...)
+			}
+
+			metadata := upAuthMethod.oplog(oplog.OpType_OP_TYPE_UPDATE)
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, ticket, metadata, msgs); err != nil {
+				return fmt.Errorf("unable to write oplog: %w", err)
+			}
+
+			returnedAuthMethod, err = r.lookupAuthMethod(ctx, reader, upAuthMethod.PublicId)
+			return err
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, db.NoRowsAffected, fmt.Errorf("update: oidc auth method: authMethod %s already exists in scope %s: %w", authMethod.Name, authMethod.ScopeId, errors.ErrNotUnique)
+		}
+		return nil, db.NoRowsAffected, fmt.Errorf("update: oidc auth method: %w for %s", err, authMethod.PublicId)
+	}
+	if rowsUpdated == 0 {
+		return nil, db.NoRowsAffected, nil
+	}
+	returnedAuthMethod.CtClientSecret = nil
+	return returnedAuthMethod, rowsUpdated, nil
+}
+
+// replaceAudClaims makes the aud claims of the auth method match auds and
+// returns the oplog messages for the changes.
+func (r *Repository) replaceAudClaims(ctx context.Context, reader db.Reader, w db.Writer, authMethodId string, auds []string) ([]*oplog.Message, error) {
+	var current []*AudClaim
+	if err := reader.SearchWhere(ctx, &current, "oidc_method_id = ?", []interface{}{authMethodId}, db.WithLimit(-1)); err != nil {
+		return nil, fmt.Errorf("unable to search aud claims: %w", err)
+	}
+	want := make(map[string]bool, len(auds))
+	for _, a := range auds {
+		want[a] = true
+	}
+	var deletes []interface{}
+	for _, c := range current {
+		if !want[c.AudClaim.AudClaim] {
+			deletes = append(deletes, c.clone())
+		}
+		delete(want, c.AudClaim.AudClaim)
+	}
+	var added []string
+	for a := range want {
+		added = append(added, a)
+	}
+	sort.Strings(added)
+	creates, err := newAudClaims(authMethodId, added)
+	if err != nil {
+		return nil, err
+	}
+
+	var msgs []*oplog.Message
+	if len(deletes) > 0 {
+		if _, err := w.DeleteItems(ctx, deletes, db.NewOplogMsgs(&msgs)); err != nil {
+			return nil, fmt.Errorf("unable to delete aud claims: %w", err)
+		}
+	}
+	if len(creates) > 0 {
+		if err := w.CreateItems(ctx, creates, db.NewOplogMsgs(&msgs)); err != nil {
+			return nil, fmt.Errorf("unable to create aud claims: %w", err)
+		}
+	}
+	return msgs, nil
+}
+
+func newAudClaims(authMethodId string, auds []string) ([]interface{}, error) {
+	var claims []interface{}
+	seen := make(map[string]bool, len(auds))
+	for _, a := range auds {
+		if seen[a] {
+			continue
+		}
+		seen[a] = true
+		c, err := NewAudClaim(authMethodId, a)
+		if err != nil {
+			return nil, err
+		}
+		claims = append(claims, c)
+	}
+	return claims, nil
+}
+
+func loadAudClaims(ctx context.Context, reader db.Reader, am *AuthMethod) error {
+	var claims []*AudClaim
+	if err := reader.SearchWhere(ctx, &claims, "oidc_method_id = ?", []interface{}{am.PublicId}, db.WithLimit(-1), db.WithOrder("aud_claim")); err != nil {
+		return fmt.Errorf("unable to search aud claims: %w", err)
+	}
+	am.AllowedAudiences = nil
+	for _, c := range claims {
+		am.AllowedAudiences = append(am.AllowedAudiences, c.AudClaim.AudClaim)
+	}
+	return nil
+}
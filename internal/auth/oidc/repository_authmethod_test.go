@@ -0,0 +1,344 @@
+package oidc
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth/oidc/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testIssuer      = "https://idp.example.com"
+	testCallbackUrl = "https://boundary.example.com/v1/auth-methods/callback"
+)
+
+func TestRepository_CreateAuthMethod(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+
+	var tests = []struct {
+		name      string
+		in        *AuthMethod
+		opts      []Option
+		wantIsErr error
+	}{
+		{
+			name:      "nil-AuthMethod",
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name:      "nil-embedded-AuthMethod",
+			in:        &AuthMethod{},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name: "invalid-no-scope-id",
+			in: &AuthMethod{
+				AuthMethod: &store.AuthMethod{
+					Issuer:       testIssuer,
+					ClientId:     "client",
+					ClientSecret: "secret",
+					CallbackUrl:  testCallbackUrl,
+				},
+			},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name: "invalid-no-client-secret",
+			in: &AuthMethod{
+				AuthMethod: &store.AuthMethod{
+					ScopeId:     org.PublicId,
+					Issuer:      testIssuer,
+					ClientId:    "client",
+					CallbackUrl: testCallbackUrl,
+				},
+			},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name: "invalid-public-id-set",
+			in: &AuthMethod{
+				AuthMethod: &store.AuthMethod{
+					ScopeId:      org.PublicId,
+					PublicId:     "amoidc_OOOOOOOOOO",
+					Issuer:       testIssuer,
+					ClientId:     "client",
+					ClientSecret: "secret",
+					CallbackUrl:  testCallbackUrl,
+				},
+			},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name: "invalid-public-id-prefix",
+			in: &AuthMethod{
+				AuthMethod: &store.AuthMethod{
+					ScopeId:      org.PublicId,
+					Issuer:       testIssuer,
+					ClientId:     "client",
+					ClientSecret: "secret",
+					CallbackUrl:  testCallbackUrl,
+				},
+			},
+			opts:      []Option{WithPublicId("ampw_OOOOOOOOOO")},
+			wantIsErr: errors.ErrInvalidPublicId,
+		},
+		{
+			name: "valid-no-options",
+			in: &AuthMethod{
+				AuthMethod: &store.AuthMethod{
+					ScopeId:      org.PublicId,
+					Issuer:       testIssuer,
+					ClientId:     "client",
+					ClientSecret: "secret",
+					CallbackUrl:  testCallbackUrl,
+				},
+			},
+		},
+		{
+			name: "valid-with-audiences",
+			in: &AuthMethod{
+				AuthMethod: &store.AuthMethod{
+					ScopeId:          org.PublicId,
+					Name:             "test-name",
+					Issuer:           testIssuer,
+					ClientId:         "client",
+					ClientSecret:     "secret",
+					CallbackUrl:      testCallbackUrl,
+					AllowedAudiences: []string{"aud1", "aud2", "aud1"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := context.Background()
+			repo, err := NewRepository(rw, rw, kms)
+			require.NoError(err)
+			require.NotNil(repo)
+			got, err := repo.CreateAuthMethod(ctx, tt.in, tt.opts...)
+			if tt.wantIsErr != nil {
+				assert.Truef(errors.Is(err, tt.wantIsErr), "want err: %q got: %q", tt.wantIsErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			require.NotNil(got)
+			assert.True(strings.HasPrefix(got.PublicId, AuthMethodPrefix+"_"))
+			assert.Empty(got.ClientSecret)
+			assert.Empty(got.CtClientSecret)
+			assert.NotEmpty(got.ClientSecretHmac)
+			assert.NotEmpty(got.KeyId)
+			assert.Equal(tt.in.ClientId, got.ClientId)
+
+			found, err := repo.LookupAuthMethod(ctx, got.PublicId)
+			require.NoError(err)
+			require.NotNil(found)
+			assert.Equal(got.ClientSecretHmac, found.ClientSecretHmac)
+			assert.Empty(found.CtClientSecret)
+			assert.ElementsMatch(newAudSet(tt.in.AllowedAudiences), found.AllowedAudiences)
+
+			withSecret, err := repo.lookupAuthMethodWithSecret(ctx, got.PublicId)
+			require.NoError(err)
+			assert.Equal(tt.in.ClientSecret, withSecret.ClientSecret)
+
+			assert.NoError(db.TestVerifyOplog(t, rw, got.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_CREATE)))
+		})
+	}
+
+	t.Run("duplicate-name", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ctx := context.Background()
+		repo, err := NewRepository(rw, rw, kms)
+		require.NoError(err)
+		in, err := NewAuthMethod(org.PublicId, testIssuer, "client", "secret", testCallbackUrl, WithName("duplicate"))
+		require.NoError(err)
+		_, err = repo.CreateAuthMethod(ctx, in)
+		require.NoError(err)
+		got, err := repo.CreateAuthMethod(ctx, in)
+		assert.Truef(errors.Is(err, errors.ErrNotUnique), "want err: %q got: %q", errors.ErrNotUnique, err)
+		assert.Nil(got)
+	})
+}
+
+func newAudSet(auds []string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, a := range auds {
+		if !seen[a] {
+			seen[a] = true
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+func TestRepository_ListAuthMethods(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+
+	ams := TestAuthMethods(t, conn, org.PublicId, testIssuer, 3)
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+	got, err := repo.ListAuthMethods(ctx, []string{org.PublicId})
+	require.NoError(err)
+	assert.Len(got, len(ams))
+	for _, am := range got {
+		assert.Empty(am.CtClientSecret)
+		assert.Empty(am.ClientSecret)
+	}
+
+	got, err = repo.ListAuthMethods(ctx, []string{org.PublicId}, WithLimit(1))
+	require.NoError(err)
+	assert.Len(got, 1)
+
+	_, err = repo.ListAuthMethods(ctx, nil)
+	assert.Truef(errors.Is(err, errors.ErrInvalidParameter), "want err: %q got: %q", errors.ErrInvalidParameter, err)
+}
+
+func TestRepository_UpdateAuthMethod(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	ctx := context.Background()
+
+	newAuthMethod := func(t *testing.T, repo *Repository) *AuthMethod {
+		in, err := NewAuthMethod(org.PublicId, testIssuer, "client", "secret", testCallbackUrl, WithAllowedAudiences("aud1", "aud2"))
+		require.NoError(t, err)
+		got, err := repo.CreateAuthMethod(ctx, in)
+		require.NoError(t, err)
+		return got
+	}
+
+	t.Run("name-and-issuer", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		repo, err := NewRepository(rw, rw, kms)
+		require.NoError(err)
+		am := newAuthMethod(t, repo)
+		am.Name = "updated"
+		am.Issuer = "https://other.example.com"
+		got, rows, err := repo.UpdateAuthMethod(ctx, am, am.Version, []string{"Name", "Issuer"})
+		require.NoError(err)
+		assert.Equal(1, rows)
+		assert.Equal("updated", got.Name)
+		assert.Equal("https://other.example.com", got.Issuer)
+		assert.Equal(am.ClientSecretHmac, got.ClientSecretHmac)
+		assert.Equal([]string{"aud1", "aud2"}, got.AllowedAudiences)
+		assert.Equal(am.Version+1, got.Version)
+		assert.NoError(db.TestVerifyOplog(t, rw, am.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_UPDATE)))
+	})
+	t.Run("client-secret", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		repo, err := NewRepository(rw, rw, kms)
+		require.NoError(err)
+		am := newAuthMethod(t, repo)
+		am.ClientSecret = "new secret"
+		got, rows, err := repo.UpdateAuthMethod(ctx, am, am.Version, []string{"ClientSecret"})
+		require.NoError(err)
+		assert.Equal(1, rows)
+		assert.NotEqual(am.ClientSecretHmac, got.ClientSecretHmac)
+		assert.Empty(got.CtClientSecret)
+
+		withSecret, err := repo.lookupAuthMethodWithSecret(ctx, am.PublicId)
+		require.NoError(err)
+		assert.Equal("new secret", withSecret.ClientSecret)
+	})
+	t.Run("allowed-audiences-only", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		repo, err := NewRepository(rw, rw, kms)
+		require.NoError(err)
+		am := newAuthMethod(t, repo)
+		am.AllowedAudiences = []string{"aud2", "aud3"}
+		got, rows, err := repo.UpdateAuthMethod(ctx, am, am.Version, []string{"AllowedAudiences"})
+		require.NoError(err)
+		assert.Equal(1, rows)
+		assert.Equal([]string{"aud2", "aud3"}, got.AllowedAudiences)
+		assert.Equal(am.Version+1, got.Version)
+
+		am = got
+		am.AllowedAudiences = nil
+		got, _, err = repo.UpdateAuthMethod(ctx, am, am.Version, []string{"AllowedAudiences"})
+		require.NoError(err)
+		assert.Empty(got.AllowedAudiences)
+	})
+	t.Run("wrong-version", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		repo, err := NewRepository(rw, rw, kms)
+		require.NoError(err)
+		am := newAuthMethod(t, repo)
+		am.Name = "wrong version"
+		got, rows, err := repo.UpdateAuthMethod(ctx, am, am.Version+1, []string{"Name"})
+		require.NoError(err)
+		assert.Equal(0, rows)
+		assert.Nil(got)
+	})
+	t.Run("invalid-field-mask", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		repo, err := NewRepository(rw, rw, kms)
+		require.NoError(err)
+		am := newAuthMethod(t, repo)
+		_, _, err = repo.UpdateAuthMethod(ctx, am, am.Version, []string{"ScopeId"})
+		assert.Truef(errors.Is(err, errors.ErrInvalidFieldMask), "want err: %q got: %q", errors.ErrInvalidFieldMask, err)
+		_, _, err = repo.UpdateAuthMethod(ctx, am, am.Version, nil)
+		assert.Truef(errors.Is(err, errors.ErrEmptyFieldMask), "want err: %q got: %q", errors.ErrEmptyFieldMask, err)
+		am.ClientSecret = ""
+		_, _, err = repo.UpdateAuthMethod(ctx, am, am.Version, []string{"ClientSecret"})
+		assert.Truef(errors.Is(err, errors.ErrInvalidParameter), "want err: %q got: %q", errors.ErrInvalidParameter, err)
+	})
+}
+
+func TestRepository_DeleteAuthMethod(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+
+	am := TestAuthMethods(t, conn, org.PublicId, testIssuer, 1)[0]
+	TestAccounts(t, conn, am.PublicId, 2)
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+	rows, err := repo.DeleteAuthMethod(ctx, org.PublicId, am.PublicId)
+	require.NoError(err)
+	assert.Equal(1, rows)
+
+	found, err := repo.LookupAuthMethod(ctx, am.PublicId)
+	require.NoError(err)
+	assert.Nil(found)
+	accts, err := repo.ListAccounts(ctx, am.PublicId)
+	require.NoError(err)
+	assert.Empty(accts)
+
+	rows, err = repo.DeleteAuthMethod(ctx, org.PublicId, am.PublicId)
+	require.NoError(err)
+	assert.Equal(0, rows)
+}
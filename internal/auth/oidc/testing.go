@@ -0,0 +1,239 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/vault/sdk/helper/base62"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// TestAuthMethods creates count number of oidc auth methods to the provided
+// DB with the provided scope id and issuer. The client secret of the auth
+// methods is not encrypted with a kms key and cannot be decrypted. If any
+// errors are encountered during the creation of the auth methods, the test
+// will fail.
+func TestAuthMethods(t *testing.T, conn *gorm.DB, scopeId, issuer string, count int) []*AuthMethod {
+	t.Helper()
+	assert, require := assert.New(t), require.New(t)
+	w := db.New(conn)
+	var auts []*AuthMethod
+	for i := 0; i < count; i++ {
+		cat, err := NewAuthMethod(scopeId, issuer, fmt.Sprintf("client%d", i), "secret", "https://boundary.example.com/callback")
+		assert.NoError(err)
+		require.NotNil(cat)
+		id, err := newAuthMethodId()
+		assert.NoError(err)
+		require.NotEmpty(id)
+		cat.PublicId = id
+		cat.CtClientSecret = []byte("not encrypted")
+		cat.ClientSecretHmac = "hmac"
+		cat.KeyId = "key"
+		cat.ClientSecret = ""
+
+		ctx := context.Background()
+		_, err2 := w.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+			func(_ db.Reader, iw db.Writer) error {
+				return iw.Create(ctx, cat)
+			},
+		)
+
+		require.NoError(err2)
+		cat.CtClientSecret = nil
+		auts = append(auts, cat)
+	}
+	return auts
+}
+
+// TestAccounts creates count number of oidc account to the provided DB
+// with the provided auth method id. The auth method must have been created
+// previously. If any errors are encountered during the creation of the
+// account, the test will fail.
+func TestAccounts(t *testing.T, conn *gorm.DB, authMethodId string, count int) []*Account {
+	t.Helper()
+	assert, require := assert.New(t), require.New(t)
+	w := db.New(conn)
+	var auts []*Account
+	for i := 0; i < count; i++ {
+		cat, err := NewAccount(authMethodId, fmt.Sprintf("subject%d", i))
+		assert.NoError(err)
+		require.NotNil(cat)
+		id, err := newAccountId()
+		assert.NoError(err)
+		require.NotEmpty(id)
+		cat.PublicId = id
+
+		ctx := context.Background()
+		_, err2 := w.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+			func(_ db.Reader, iw db.Writer) error {
+				return iw.Create(ctx, cat)
+			},
+		)
+
+		require.NoError(err2)
+		auts = append(auts, cat)
+	}
+	return auts
+}
+
+// TestProvider is an OIDC provider for use in tests. It supports discovery,
+// the token endpoint of the authorization code flow, and the key set used
+// to sign its ID tokens. The authorization endpoint is simulated by
+// Authorize.
+type TestProvider struct {
+	ClientId     string
+	ClientSecret string
+
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]*testIdTokenClaims
+}
+
+type testIdTokenClaims struct {
+	jwt.Claims
+	Nonce string `json:"nonce,omitempty"`
+	Email string `json:"email,omitempty"`
+	Name  string `json:"name,omitempty"`
+}
+
+// NewTestProvider starts a TestProvider which issues ID tokens for
+// clientId. The provider is stopped when the test completes.
+func NewTestProvider(t *testing.T, clientId, clientSecret string) *TestProvider {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	p := &TestProvider{
+		ClientId:     clientId,
+		ClientSecret: clientSecret,
+		key:          key,
+		codes:        make(map[string]*testIdTokenClaims),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.handleDiscovery)
+	mux.HandleFunc("/keys", p.handleKeys)
+	mux.HandleFunc("/token", p.handleToken)
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+// Issuer returns the issuer URL of the provider.
+func (p *TestProvider) Issuer() string {
+	return p.server.URL
+}
+
+// Authorize simulates the user with subject authenticating at the URL
+// returned by StartAuth. It returns the code and state the provider would
+// send to the callback URL. The ID token issued for the code contains the
+// audience aud, or the client id of the provider if aud is empty.
+func (p *TestProvider) Authorize(t *testing.T, authUrl, subject, email, name, aud string) (code, state string) {
+	t.Helper()
+	u, err := url.Parse(authUrl)
+	require.NoError(t, err)
+	q := u.Query()
+	require.Equal(t, p.ClientId, q.Get("client_id"))
+	if aud == "" {
+		aud = p.ClientId
+	}
+	code, err = base62.Random(20)
+	require.NoError(t, err)
+	now := time.Now()
+	p.mu.Lock()
+	p.codes[code] = &testIdTokenClaims{
+		Claims: jwt.Claims{
+			Issuer:   p.Issuer(),
+			Subject:  subject,
+			Audience: jwt.Audience{aud},
+			IssuedAt: jwt.NewNumericDate(now),
+			Expiry:   jwt.NewNumericDate(now.Add(5 * time.Minute)),
+		},
+		Nonce: q.Get("nonce"),
+		Email: email,
+		Name:  name,
+	}
+	p.mu.Unlock()
+	return code, q.Get("state")
+}
+
+func (p *TestProvider) handleDiscovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, map[string]interface{}{
+		"issuer":                                p.Issuer(),
+		"authorization_endpoint":                p.Issuer() + "/authorize",
+		"token_endpoint":                        p.Issuer() + "/token",
+		"jwks_uri":                              p.Issuer() + "/keys",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+	})
+}
+
+func (p *TestProvider) handleKeys(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, jose.JSONWebKeySet{
+		Keys: []jose.JSONWebKey{{Key: &p.key.PublicKey, KeyID: "test", Algorithm: string(jose.RS256), Use: "sig"}},
+	})
+}
+
+func (p *TestProvider) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	clientId, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientId, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientId != p.ClientId || clientSecret != p.ClientSecret {
+		w.WriteHeader(http.StatusUnauthorized)
+		writeJSON(w, map[string]string{"error": "invalid_client"})
+		return
+	}
+	p.mu.Lock()
+	claims, ok := p.codes[r.PostForm.Get("code")]
+	delete(p.codes, r.PostForm.Get("code"))
+	p.mu.Unlock()
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		writeJSON(w, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: p.key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", "test"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	idToken, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, map[string]interface{}{
+		"access_token": "access-token",
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     idToken,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,53 @@
+package oidc
+
+import (
+	"time"
+
+	"github.com/hashicorp/boundary/internal/auth/oidc/store"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultTokenRequestTimeout is how long a user has to complete
+// authentication with the provider after StartAuth is called.
+const defaultTokenRequestTimeout = 5 * time.Minute
+
+// A TokenRequest tracks an authentication attempt from StartAuth until it
+// has been redeemed for an auth token. Token requests are
+// not replicated and do not have oplog entries.
+type TokenRequest struct {
+	*store.TokenRequest
+	tableName string
+}
+
+func allocTokenRequest() *TokenRequest {
+	return &TokenRequest{
+		TokenRequest: &store.TokenRequest{},
+	}
+}
+
+func (r *TokenRequest) clone() *TokenRequest {
+	cp := proto.Clone(r.TokenRequest)
+	return &TokenRequest{
+		TokenRequest: cp.(*store.TokenRequest),
+	}
+}
+
+// expired reports whether the token request can no longer be completed or
+// redeemed.
+func (r *TokenRequest) expired() bool {
+	exp := r.GetExpirationTime().GetTimestamp().AsTime()
+	return time.Now().After(exp)
+}
+
+// TableName returns the table name.
+func (r *TokenRequest) TableName() string {
+	if r.tableName != "" {
+		return r.tableName
+	}
+	return "auth_oidc_token_request"
+}
+
+// SetTableName sets the table name.
+func (r *TokenRequest) SetTableName(n string) {
+	r.tableName = n
+}
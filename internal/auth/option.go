@@ -19,13 +19,14 @@ type Option func(*options)
 
 // options = how options are represented
 type options struct {
-	withScopeId string
-	withPin     string
-	withId      string
-	withAction  action.Type
-	withType    resource.Type
-	withUserId  string
-	withKms     *kms.Kms
+	withScopeId    string
+	withPin        string
+	withId         string
+	withAction     action.Type
+	withType       resource.Type
+	withUserId     string
+	withKms        *kms.Kms
+	withAttributes map[string]interface{}
 }
 
 func getDefaultOptions() options {
@@ -76,3 +77,11 @@ func WithKms(kms *kms.Kms) Option {
 		o.withKms = kms
 	}
 }
+
+// WithAttributes provides the API representation of the resource, which grant
+// filters are evaluated against
+func WithAttributes(attrs map[string]interface{}) Option {
+	return func(o *options) {
+		o.withAttributes = attrs
+	}
+}
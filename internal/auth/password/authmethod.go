@@ -25,7 +25,7 @@ func allocAuthMethod() AuthMethod {
 // NewAuthMethod creates a new in memory AuthMethod assigned to scopeId.
 // Name and description are the only valid options. All other options are
 // ignored.  MinLoginNameLength and MinPasswordLength are pre-set to the
-// default values of 5 and 8 respectively.
+// default values of 5 and 8 respectively and LockoutWindowSeconds to 900.
 func NewAuthMethod(scopeId string, opt ...Option) (*AuthMethod, error) {
 	if scopeId == "" {
 		return nil, fmt.Errorf("new: password auth method: no scope id: %w", errors.ErrInvalidParameter)
@@ -34,11 +34,12 @@ func NewAuthMethod(scopeId string, opt ...Option) (*AuthMethod, error) {
 	opts := getOpts(opt...)
 	a := &AuthMethod{
 		AuthMethod: &store.AuthMethod{
-			ScopeId:            scopeId,
-			Name:               opts.withName,
-			Description:        opts.withDescription,
-			MinLoginNameLength: 3,
-			MinPasswordLength:  8,
+			ScopeId:              scopeId,
+			Name:                 opts.withName,
+			Description:          opts.withDescription,
+			MinLoginNameLength:   3,
+			MinPasswordLength:    8,
+			LockoutWindowSeconds: 900,
 		},
 	}
 	return a, nil
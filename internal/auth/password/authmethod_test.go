@@ -32,8 +32,9 @@ func TestAuthMethod_New(t *testing.T) {
 			args: args{},
 			want: &AuthMethod{
 				AuthMethod: &store.AuthMethod{
-					MinLoginNameLength: 3,
-					MinPasswordLength:  8,
+					MinLoginNameLength:   3,
+					MinPasswordLength:    8,
+					LockoutWindowSeconds: 900,
 				},
 			},
 		},
@@ -46,9 +47,10 @@ func TestAuthMethod_New(t *testing.T) {
 			},
 			want: &AuthMethod{
 				AuthMethod: &store.AuthMethod{
-					Name:               "test-name",
-					MinLoginNameLength: 3,
-					MinPasswordLength:  8,
+					Name:                 "test-name",
+					MinLoginNameLength:   3,
+					MinPasswordLength:    8,
+					LockoutWindowSeconds: 900,
 				},
 			},
 		},
@@ -61,9 +63,10 @@ func TestAuthMethod_New(t *testing.T) {
 			},
 			want: &AuthMethod{
 				AuthMethod: &store.AuthMethod{
-					Description:        "test-description",
-					MinLoginNameLength: 3,
-					MinPasswordLength:  8,
+					Description:          "test-description",
+					MinLoginNameLength:   3,
+					MinPasswordLength:    8,
+					LockoutWindowSeconds: 900,
 				},
 			},
 		},
@@ -18,4 +18,22 @@ var (
 	// ErrPasswordsEqual is returned from ChangePassword when the old and
 	// new passwords are equal.
 	ErrPasswordsEqual = errors.New("old and new password are equal")
+
+	// ErrPasswordComplexity results from attempting to set a password which
+	// does not contain the character classes required by the auth method.
+	ErrPasswordComplexity = errors.New("does not meet complexity requirements")
+
+	// ErrPasswordReused results from attempting to set a password which
+	// equals one of the recent passwords of the account.
+	ErrPasswordReused = errors.New("password was recently used")
+
+	// ErrAccountLocked is returned from Authenticate and ChangePassword
+	// when the account is locked after too many failed authentication
+	// attempts.
+	ErrAccountLocked = errors.New("account locked")
+
+	// ErrPasswordChangeRequired is returned from Authenticate when the
+	// password of the account has expired or must be changed and no new
+	// password was provided.
+	ErrPasswordChangeRequired = errors.New("password change required")
 )
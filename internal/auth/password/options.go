@@ -14,14 +14,16 @@ type Option func(*options)
 
 // options = how options are represented
 type options struct {
-	withName        string
-	withDescription string
-	withLoginName   string
-	withLimit       int
-	withConfig      Configuration
-	withPublicId    string
-	password        string
-	withPassword    bool
+	withName             string
+	withDescription      string
+	withLoginName        string
+	withLimit            int
+	withStartPageAfterId string
+	withConfig           Configuration
+	withPublicId         string
+	password             string
+	withPassword         bool
+	withNewPassword      string
 }
 
 func getDefaultOptions() options {
@@ -67,6 +69,15 @@ func WithLimit(l int) Option {
 	}
 }
 
+// WithStartPageAfterId provides an option to list only the resources whose
+// public ids sort after the given id. Results are ordered by public id when
+// it is used, allowing listing to be resumed where a previous page ended.
+func WithStartPageAfterId(id string) Option {
+	return func(o *options) {
+		o.withStartPageAfterId = id
+	}
+}
+
 // WithPassword provides an optional password.
 func WithPassword(password string) Option {
 	return func(o *options) {
@@ -81,3 +92,12 @@ func WithConfiguration(config Configuration) Option {
 		o.withConfig = config
 	}
 }
+
+// WithNewPassword provides an optional new password to Authenticate. It is
+// used to change the password of an account which must change its password
+// before it can authenticate.
+func WithNewPassword(password string) Option {
+	return func(o *options) {
+		o.withNewPassword = password
+	}
+}
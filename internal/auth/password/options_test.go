@@ -39,6 +39,12 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withLimit = 5
 		assert.Equal(t, opts, testOpts)
 	})
+	t.Run("WithStartPageAfterId", func(t *testing.T) {
+		opts := getOpts(WithStartPageAfterId("apw_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withStartPageAfterId = "apw_1234567890"
+		assert.Equal(t, opts, testOpts)
+	})
 	t.Run("WithPassword", func(t *testing.T) {
 		opts := getOpts(WithPassword("test password"))
 		testOpts := getDefaultOptions()
@@ -46,6 +52,12 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withPassword = true
 		assert.Equal(t, opts, testOpts)
 	})
+	t.Run("WithNewPassword", func(t *testing.T) {
+		opts := getOpts(WithNewPassword("new password"))
+		testOpts := getDefaultOptions()
+		testOpts.withNewPassword = "new password"
+		assert.Equal(t, opts, testOpts)
+	})
 	t.Run("WithConfiguration", func(t *testing.T) {
 		conf := NewArgon2Configuration()
 		conf.KeyLength = conf.KeyLength * 2
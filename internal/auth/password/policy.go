@@ -0,0 +1,42 @@
+package password
+
+import (
+	"fmt"
+	"unicode"
+)
+
+// checkPassword returns an error if password is shorter than the minimum
+// password length or is missing one of the character classes required by
+// the password policy of c.
+func (c *currentConfig) checkPassword(password string) error {
+	if c.MinPasswordLength > len(password) {
+		return ErrTooShort
+	}
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case !unicode.IsLetter(r) && !unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+	var missing string
+	switch {
+	case c.RequireUppercase && !upper:
+		missing = "an upper case letter"
+	case c.RequireLowercase && !lower:
+		missing = "a lower case letter"
+	case c.RequireDigit && !digit:
+		missing = "a digit"
+	case c.RequireSymbol && !symbol:
+		missing = "a symbol"
+	default:
+		return nil
+	}
+	return fmt.Errorf("must contain %s: %w", missing, ErrPasswordComplexity)
+}
@@ -0,0 +1,83 @@
+package password
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurrentConfig_checkPassword(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		conf     currentConfig
+		password string
+		wantErr  error
+	}{
+		{
+			name:     "too-short",
+			conf:     currentConfig{MinPasswordLength: 8},
+			password: "abc",
+			wantErr:  ErrTooShort,
+		},
+		{
+			name:     "no-requirements",
+			conf:     currentConfig{MinPasswordLength: 8},
+			password: "abcdefgh",
+		},
+		{
+			name:     "missing-uppercase",
+			conf:     currentConfig{MinPasswordLength: 8, RequireUppercase: true},
+			password: "abcdefgh",
+			wantErr:  ErrPasswordComplexity,
+		},
+		{
+			name:     "missing-lowercase",
+			conf:     currentConfig{MinPasswordLength: 8, RequireLowercase: true},
+			password: "ABCDEFGH",
+			wantErr:  ErrPasswordComplexity,
+		},
+		{
+			name:     "missing-digit",
+			conf:     currentConfig{MinPasswordLength: 8, RequireDigit: true},
+			password: "abcdefgh",
+			wantErr:  ErrPasswordComplexity,
+		},
+		{
+			name:     "digit-is-not-a-symbol",
+			conf:     currentConfig{MinPasswordLength: 8, RequireSymbol: true},
+			password: "abcd1234",
+			wantErr:  ErrPasswordComplexity,
+		},
+		{
+			name:     "space-is-not-a-symbol",
+			conf:     currentConfig{MinPasswordLength: 8, RequireSymbol: true},
+			password: "abcd efgh",
+			wantErr:  ErrPasswordComplexity,
+		},
+		{
+			name: "all-requirements",
+			conf: currentConfig{
+				MinPasswordLength: 8,
+				RequireUppercase:  true,
+				RequireLowercase:  true,
+				RequireDigit:      true,
+				RequireSymbol:     true,
+			},
+			password: "Abcd123!",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.conf.checkPassword(tt.password)
+			if tt.wantErr != nil {
+				assert.Truef(t, errors.Is(err, tt.wantErr), "want err: %v got: %v", tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
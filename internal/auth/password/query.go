@@ -20,7 +20,19 @@ select acct.name,                        -- Account.Name
        conf.iterations,                  -- Argon2Configuration.Iterations
        conf.memory,                      -- Argon2Configuration.Memory
        conf.threads,                     -- Argon2Configuration.Threads
-       meth.password_conf_id = cred.password_conf_id as is_current_conf
+       acct.must_change_password,        -- Account.MustChangePassword
+       acct.locked_time,                 -- Account.LockedTime
+       acct.unlock_time,                 -- Account.UnlockTime
+       meth.lockout_threshold,
+       meth.lockout_window_seconds,
+       meth.password_conf_id = cred.password_conf_id as is_current_conf,
+       acct.locked_time is not null
+         and (meth.lockout_duration_seconds = 0
+           or acct.locked_time + make_interval(secs => meth.lockout_duration_seconds) > now())
+         as is_locked,
+       meth.max_password_age_seconds > 0
+         and cred.create_time + make_interval(secs => meth.max_password_age_seconds) <= now()
+         as is_password_expired
   from auth_password_argon2_cred cred,
        auth_password_argon2_conf conf,
        auth_password_account acct,
@@ -41,4 +53,91 @@ select *
         where public_id = $1
     );
 `
+
+	// recordFailedAttemptQuery records a failed authentication attempt for
+	// an account and returns the time of the attempt and the number of
+	// failed attempts, including this one, made within the lockout window
+	// since the account was last locked, unlocked or successfully
+	// authenticated.
+	recordFailedAttemptQuery = `
+with attempt as (
+  insert into auth_password_failed_attempt
+    (password_account_id)
+  values
+    ($1)
+  returning create_time
+)
+select attempt.create_time as attempt_time,
+       (
+         select count(*)
+           from auth_password_failed_attempt fa,
+                auth_password_account acct
+          where fa.password_account_id = acct.public_id
+            and acct.public_id = $1
+            and fa.create_time > now() - make_interval(secs => $2)
+            and fa.create_time > coalesce(acct.locked_time, '-infinity')
+            and fa.create_time > coalesce(acct.unlock_time, '-infinity')
+            and fa.create_time > coalesce(
+                  (select sa.success_time
+                     from auth_password_successful_attempt sa
+                    where sa.password_account_id = $1),
+                  '-infinity')
+       ) + 1 as failed_attempts
+  from attempt;
+`
+	// recordSuccessfulAttemptQuery records the time of a successful
+	// authentication of an account, after which earlier failed attempts no
+	// longer count towards the lockout threshold.
+	recordSuccessfulAttemptQuery = `
+insert into auth_password_successful_attempt
+  (password_account_id)
+values
+  ($1)
+on conflict (password_account_id) do update
+  set success_time = excluded.success_time;
+`
+	insertCredentialHistoryQuery = `
+insert into auth_password_argon2_cred_history
+  (private_id, password_account_id, password_conf_id, salt, derived_key, key_id, create_time)
+select private_id, password_account_id, password_conf_id, salt, derived_key, key_id, create_time
+  from auth_password_argon2_cred
+ where private_id = $1;
+`
+	pruneCredentialHistoryQuery = `
+delete from auth_password_argon2_cred_history
+ where password_account_id = $1
+   and private_id not in (
+       select private_id
+         from auth_password_argon2_cred_history
+        where password_account_id = $1
+     order by create_time desc
+        limit $2
+   );
+`
+	// recentCredentialsQuery returns the current credential of an account
+	// followed by the credentials in its history, most recent first.
+	recentCredentialsQuery = `
+select cred.private_id,
+       cred.password_conf_id,
+       cred.salt,
+       cred.derived_key,
+       cred.key_id,
+       conf.key_length,
+       conf.iterations,
+       conf.memory,
+       conf.threads
+  from (
+         select private_id, password_conf_id, salt, derived_key, key_id, create_time
+           from auth_password_argon2_cred
+          where password_account_id = $1
+      union all
+         select private_id, password_conf_id, salt, derived_key, key_id, create_time
+           from auth_password_argon2_cred_history
+          where password_account_id = $1
+       ) cred,
+       auth_password_argon2_conf conf
+ where cred.password_conf_id = conf.private_id
+ order by cred.create_time desc
+ limit $2;
+`
 )
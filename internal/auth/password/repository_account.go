@@ -8,9 +8,11 @@ import (
 
 	"github.com/hashicorp/boundary/internal/db"
 	dbcommon "github.com/hashicorp/boundary/internal/db/common"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // CreateAccount inserts a into the repository and returns a new Account
@@ -65,8 +67,8 @@ func (r *Repository) CreateAccount(ctx context.Context, scopeId string, a *Accou
 
 	var cred *Argon2Credential
 	if opts.withPassword {
-		if cc.MinPasswordLength > len(opts.password) {
-			return nil, fmt.Errorf("create: password account: password: %w", ErrTooShort)
+		if err := cc.checkPassword(opts.password); err != nil {
+			return nil, fmt.Errorf("create: password account: password: %w", err)
 		}
 		if cred, err = newArgon2Credential(id, opts.password, cc.argon2()); err != nil {
 			return nil, fmt.Errorf("create: password account: %w", err)
@@ -131,7 +133,8 @@ func (r *Repository) LookupAccount(ctx context.Context, withPublicId string, opt
 	return a, nil
 }
 
-// ListAccounts in an auth method and supports WithLimit option.
+// ListAccounts in an auth method and supports the WithLimit and
+// WithStartPageAfterId options.
 func (r *Repository) ListAccounts(ctx context.Context, withAuthMethodId string, opt ...Option) ([]*Account, error) {
 	if withAuthMethodId == "" {
 		return nil, fmt.Errorf("list: password account: missing auth method id %w", errors.ErrInvalidParameter)
@@ -142,8 +145,12 @@ func (r *Repository) ListAccounts(ctx context.Context, withAuthMethodId string,
 		// non-zero signals an override of the default limit for the repo.
 		limit = opts.withLimit
 	}
+	where, args := "auth_method_id = ?", []interface{}{withAuthMethodId}
+	if opts.withStartPageAfterId != "" {
+		where, args = where+" and public_id > ?", append(args, opts.withStartPageAfterId)
+	}
 	var accts []*Account
-	err := r.reader.SearchWhere(ctx, &accts, "auth_method_id = ?", []interface{}{withAuthMethodId}, db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &accts, where, args, db.WithLimit(limit), db.WithOrder("public_id"))
 	if err != nil {
 		return nil, fmt.Errorf("list: password account: %w", err)
 	}
@@ -190,6 +197,50 @@ func (r *Repository) DeleteAccount(ctx context.Context, scopeId, withPublicId st
 	return rowsDeleted, nil
 }
 
+// UnlockAccount unlocks the account for accountId which was locked after
+// too many failed authentication attempts. Failed attempts made before the
+// account was unlocked no longer count towards the lockout threshold of
+// the auth method. The account is returned with its new version.
+func (r *Repository) UnlockAccount(ctx context.Context, scopeId, accountId string, version uint32) (*Account, error) {
+	if accountId == "" {
+		return nil, fmt.Errorf("unlock: password account: missing public id: %w", errors.ErrInvalidParameter)
+	}
+	if version == 0 {
+		return nil, fmt.Errorf("unlock: password account: no version supplied: %w", errors.ErrInvalidParameter)
+	}
+	if scopeId == "" {
+		return nil, fmt.Errorf("unlock: password account: scope id empty: %w", errors.ErrInvalidParameter)
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, fmt.Errorf("unlock: password account: unable to get oplog wrapper: %w", err)
+	}
+
+	a := allocAccount()
+	a.PublicId = accountId
+	a.UnlockTime = &timestamp.Timestamp{Timestamp: timestamppb.Now()}
+
+	var rowsUpdated int
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			var err error
+			rowsUpdated, err = w.Update(ctx, a, []string{"UnlockTime"}, []string{"LockedTime"}, db.WithOplog(oplogWrapper, a.oplog(oplog.OpType_OP_TYPE_UPDATE)), db.WithVersion(&version))
+			if err == nil && rowsUpdated > 1 {
+				return errors.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unlock: password account: %s: %w", accountId, err)
+	}
+	if rowsUpdated == 0 {
+		return nil, fmt.Errorf("unlock: password account: %s: %w", accountId, errors.ErrRecordNotFound)
+	}
+	return a, nil
+}
+
 var reInvalidLoginName = regexp.MustCompile("[^a-z0-9.]")
 
 func validLoginName(u string) bool {
@@ -204,10 +255,11 @@ func validLoginName(u string) bool {
 // Account containing the updated values and a count of the number of
 // records updated. a is not changed.
 //
-// a must contain a valid PublicId. Only a.Name, a.Description and
-// a.LoginName can be updated. If a.Name is set to a non-empty string, it
-// must be unique within a.AuthMethodId. If a.LoginName is set to a
-// non-empty string, it must be unique within a.AuthMethodId.
+// a must contain a valid PublicId. Only a.Name, a.Description,
+// a.LoginName and a.MustChangePassword can be updated. If a.Name is set to
+// a non-empty string, it must be unique within a.AuthMethodId. If
+// a.LoginName is set to a non-empty string, it must be unique within
+// a.AuthMethodId.
 //
 // An attribute of a will be set to NULL in the database if the attribute
 // in a is the zero value and it is included in fieldMaskPaths. a.LoginName
@@ -239,6 +291,7 @@ func (r *Repository) UpdateAccount(ctx context.Context, scopeId string, a *Accou
 				return nil, db.NoRowsAffected, fmt.Errorf("update: password account: invalid user name: %w", errors.ErrInvalidParameter)
 			}
 			changeLoginName = true
+		case strings.EqualFold("MustChangePassword", f):
 		default:
 			return nil, db.NoRowsAffected, fmt.Errorf("update: password account: field: %s: %w", f, errors.ErrInvalidFieldMask)
 		}
@@ -246,12 +299,13 @@ func (r *Repository) UpdateAccount(ctx context.Context, scopeId string, a *Accou
 	var dbMask, nullFields []string
 	dbMask, nullFields = dbcommon.BuildUpdatePaths(
 		map[string]interface{}{
-			"Name":        a.Name,
-			"Description": a.Description,
-			"LoginName":   a.LoginName,
+			"Name":               a.Name,
+			"Description":        a.Description,
+			"LoginName":          a.LoginName,
+			"MustChangePassword": a.MustChangePassword,
 		},
 		fieldMaskPaths,
-		nil,
+		[]string{"MustChangePassword"},
 	)
 	if len(dbMask) == 0 && len(nullFields) == 0 {
 		return nil, db.NoRowsAffected, fmt.Errorf("update: password account: %w", errors.ErrEmptyFieldMask)
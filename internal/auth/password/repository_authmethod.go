@@ -112,9 +112,10 @@ func (r *Repository) LookupAuthMethod(ctx context.Context, publicId string, opt
 	return &a, nil
 }
 
-// ListAuthMethods returns a slice of AuthMethods for the scopeId. WithLimit is the only option supported.
-func (r *Repository) ListAuthMethods(ctx context.Context, scopeId string, opt ...Option) ([]*AuthMethod, error) {
-	if scopeId == "" {
+// ListAuthMethods returns a slice of AuthMethods in the given scopes.
+// Supports the WithLimit and WithStartPageAfterId options.
+func (r *Repository) ListAuthMethods(ctx context.Context, scopeIds []string, opt ...Option) ([]*AuthMethod, error) {
+	if len(scopeIds) == 0 {
 		return nil, fmt.Errorf("list: password auth method: missing scope id: %w", errors.ErrInvalidParameter)
 	}
 	opts := getOpts(opt...)
@@ -123,8 +124,12 @@ func (r *Repository) ListAuthMethods(ctx context.Context, scopeId string, opt ..
 		// non-zero signals an override of the default limit for the repo.
 		limit = opts.withLimit
 	}
+	where, args := "scope_id in (?)", []interface{}{scopeIds}
+	if opts.withStartPageAfterId != "" {
+		where, args = where+" and public_id > ?", append(args, opts.withStartPageAfterId)
+	}
 	var authMethods []*AuthMethod
-	err := r.reader.SearchWhere(ctx, &authMethods, "scope_id = ?", []interface{}{scopeId}, db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &authMethods, where, args, db.WithLimit(limit), db.WithOrder("public_id"))
 	if err != nil {
 		return nil, fmt.Errorf("list: password auth method: %w", err)
 	}
@@ -177,9 +182,11 @@ func (r *Repository) DeleteAuthMethod(ctx context.Context, scopeId, publicId str
 // not be set to null, but instead use the default values returned by
 // NewAuthMethod.  fieldMaskPaths provides field_mask.proto paths for fields
 // that should be updated.  Fields will be set to NULL if the field is a zero
-// value and included in fieldMask. Name, Description, MinPasswordLength,
-// and MinLoginNameLength are the only updatable fields, If no updatable fields
-// are included in the fieldMaskPaths, then an error is returned.
+// value and included in fieldMask, except for the fields of the password
+// policy which are set to their zero value. Name, Description,
+// MinPasswordLength, MinLoginNameLength and the fields of the password
+// policy are the only updatable fields, If no updatable fields are included
+// in the fieldMaskPaths, then an error is returned.
 func (r *Repository) UpdateAuthMethod(ctx context.Context, authMethod *AuthMethod, version uint32, fieldMaskPaths []string, opt ...Option) (*AuthMethod, int, error) {
 	if authMethod == nil {
 		return nil, db.NoRowsAffected, fmt.Errorf("update: password auth method: missing authMethod: %w", errors.ErrInvalidParameter)
@@ -196,6 +203,15 @@ func (r *Repository) UpdateAuthMethod(ctx context.Context, authMethod *AuthMetho
 		case strings.EqualFold("description", f):
 		case strings.EqualFold("MinLoginNameLength", f):
 		case strings.EqualFold("MinPasswordLength", f):
+		case strings.EqualFold("RequireUppercase", f):
+		case strings.EqualFold("RequireLowercase", f):
+		case strings.EqualFold("RequireDigit", f):
+		case strings.EqualFold("RequireSymbol", f):
+		case strings.EqualFold("PasswordHistoryCount", f):
+		case strings.EqualFold("MaxPasswordAgeSeconds", f):
+		case strings.EqualFold("LockoutThreshold", f):
+		case strings.EqualFold("LockoutWindowSeconds", f):
+		case strings.EqualFold("LockoutDurationSeconds", f):
 		default:
 			return nil, db.NoRowsAffected, fmt.Errorf("update: password auth method: field: %s: %w", f, errors.ErrInvalidFieldMask)
 		}
@@ -203,13 +219,27 @@ func (r *Repository) UpdateAuthMethod(ctx context.Context, authMethod *AuthMetho
 	var dbMask, nullFields []string
 	dbMask, nullFields = dbcommon.BuildUpdatePaths(
 		map[string]interface{}{
-			"Name":               authMethod.Name,
-			"Description":        authMethod.Description,
-			"MinPasswordLength":  authMethod.MinPasswordLength,
-			"MinLoginNameLength": authMethod.MinLoginNameLength,
+			"Name":                   authMethod.Name,
+			"Description":            authMethod.Description,
+			"MinPasswordLength":      authMethod.MinPasswordLength,
+			"MinLoginNameLength":     authMethod.MinLoginNameLength,
+			"RequireUppercase":       authMethod.RequireUppercase,
+			"RequireLowercase":       authMethod.RequireLowercase,
+			"RequireDigit":           authMethod.RequireDigit,
+			"RequireSymbol":          authMethod.RequireSymbol,
+			"PasswordHistoryCount":   authMethod.PasswordHistoryCount,
+			"MaxPasswordAgeSeconds":  authMethod.MaxPasswordAgeSeconds,
+			"LockoutThreshold":       authMethod.LockoutThreshold,
+			"LockoutWindowSeconds":   authMethod.LockoutWindowSeconds,
+			"LockoutDurationSeconds": authMethod.LockoutDurationSeconds,
 		},
 		fieldMaskPaths,
-		nil,
+		// The password policy is disabled by zero values rather than nulls.
+		[]string{
+			"RequireUppercase", "RequireLowercase", "RequireDigit", "RequireSymbol",
+			"PasswordHistoryCount", "MaxPasswordAgeSeconds",
+			"LockoutThreshold", "LockoutWindowSeconds", "LockoutDurationSeconds",
+		},
 	)
 	if len(dbMask) == 0 && len(nullFields) == 0 {
 		return nil, db.NoRowsAffected, fmt.Errorf("update: password auth method: %w", errors.ErrEmptyFieldMask)
@@ -114,9 +114,14 @@ func (r *Repository) setArgon2Conf(ctx context.Context, scopeId string, c *Argon
 }
 
 type currentConfig struct {
-	ConfType           string
-	MinLoginNameLength int
-	MinPasswordLength  int
+	ConfType             string
+	MinLoginNameLength   int
+	MinPasswordLength    int
+	RequireUppercase     bool
+	RequireLowercase     bool
+	RequireDigit         bool
+	RequireSymbol        bool
+	PasswordHistoryCount int
 
 	*Argon2Configuration
 }
@@ -4,9 +4,12 @@ import (
 	"context"
 	"crypto/subtle"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
@@ -17,7 +20,11 @@ type authAccount struct {
 	*Account
 	*Argon2Credential
 	*Argon2Configuration
-	IsCurrentConf bool
+	IsCurrentConf        bool
+	IsLocked             bool
+	IsPasswordExpired    bool
+	LockoutThreshold     int
+	LockoutWindowSeconds int
 }
 
 // Authenticate authenticates loginName and password match for loginName in
@@ -31,7 +38,17 @@ type authAccount struct {
 // Authenticate will update the stored values for password to the current
 // password settings for authMethodId if authentication is successful and
 // the stored values are not using the current password settings.
-func (r *Repository) Authenticate(ctx context.Context, scopeId, authMethodId, loginName, password string) (*Account, error) {
+//
+// Returns nil, ErrAccountLocked if the account is locked. A failed attempt
+// is recorded for the account if password does not match, which locks the
+// account once the lockout threshold of authMethodId is reached.
+//
+// If the account must change its password or its password is older than
+// the maximum password age of authMethodId, Authenticate returns nil,
+// ErrPasswordChangeRequired unless the WithNewPassword option is provided,
+// in which case the password is changed to the new password and the
+// account is returned with its new CredentialId.
+func (r *Repository) Authenticate(ctx context.Context, scopeId, authMethodId, loginName, password string, opt ...Option) (*Account, error) {
 	if authMethodId == "" {
 		return nil, fmt.Errorf("password authenticate: no authMethodId: %w", errors.ErrInvalidParameter)
 	}
@@ -58,6 +75,28 @@ func (r *Repository) Authenticate(ctx context.Context, scopeId, authMethodId, lo
 		return nil, nil
 	}
 
+	if acct.MustChangePassword || acct.IsPasswordExpired {
+		opts := getOpts(opt...)
+		if opts.withNewPassword == "" {
+			return nil, fmt.Errorf("password authenticate: %w", ErrPasswordChangeRequired)
+		}
+		if opts.withNewPassword == password {
+			return nil, fmt.Errorf("password authenticate: %w", ErrPasswordsEqual)
+		}
+		updatedAccount, err := r.changePassword(ctx, scopeId, acct, opts.withNewPassword, acct.Version)
+		if err != nil {
+			return nil, fmt.Errorf("password authenticate: %w", err)
+		}
+		return updatedAccount, nil
+	}
+
+	if acct.LockedTime != nil {
+		// The lock has expired, clear it.
+		if err := r.clearLock(ctx, scopeId, acct.Account); err != nil {
+			return nil, fmt.Errorf("password authenticate: %w", err)
+		}
+	}
+
 	if !acct.IsCurrentConf {
 		cc, err := r.currentConfig(ctx, authMethodId)
 		if err != nil {
@@ -105,6 +144,10 @@ func (r *Repository) Authenticate(ctx context.Context, scopeId, authMethodId, lo
 // Returns nil, db.ErrorRecordNotFound if the account doesn't exist.
 // Returns nil, nil if old does not match the stored password for accountId.
 // Returns nil, ErrPasswordsEqual if old and new are equal.
+// Returns nil, ErrAccountLocked if the account is locked.
+//
+// new must satisfy the password policy of the account's auth method. The
+// account no longer needs to change its password once it is changed.
 func (r *Repository) ChangePassword(ctx context.Context, scopeId, accountId, old, new string, version uint32) (*Account, error) {
 	if accountId == "" {
 		return nil, fmt.Errorf("change password: no account id: %w", errors.ErrInvalidParameter)
@@ -133,37 +176,51 @@ func (r *Repository) ChangePassword(ctx context.Context, scopeId, accountId, old
 		return nil, fmt.Errorf("change password: lookup account: account not found: %w", errors.ErrRecordNotFound)
 	}
 
-	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	acct, err := r.authenticate(ctx, scopeId, authAccount.GetAuthMethodId(), authAccount.GetLoginName(), old)
 	if err != nil {
-		return nil, fmt.Errorf("change password: unable to get oplog wrapper: %w", err)
+		return nil, fmt.Errorf("change password: %w", err)
 	}
-	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
-	if err != nil {
-		return nil, fmt.Errorf("change password: unable to get database wrapper: %w", err)
+	if acct == nil {
+		return nil, nil
 	}
 
-	acct, err := r.authenticate(ctx, scopeId, authAccount.GetAuthMethodId(), authAccount.GetLoginName(), old)
+	updatedAccount, err := r.changePassword(ctx, scopeId, acct, new, version)
 	if err != nil {
 		return nil, fmt.Errorf("change password: %w", err)
 	}
-	if acct == nil {
-		return nil, nil
+	return updatedAccount, nil
+}
+
+// changePassword replaces the credential of acct with a credential for new
+// after checking new against the password policy of the account's auth
+// method. The account's version must match version.
+func (r *Repository) changePassword(ctx context.Context, scopeId string, acct *authAccount, new string, version uint32) (*Account, error) {
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get oplog wrapper: %w", err)
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get database wrapper: %w", err)
 	}
 
-	cc, err := r.currentConfig(ctx, authAccount.GetAuthMethodId())
+	cc, err := r.currentConfig(ctx, acct.GetAuthMethodId())
 	if err != nil {
-		return nil, fmt.Errorf("change password: retrieve current password configuration: %w", err)
+		return nil, fmt.Errorf("retrieve current password configuration: %w", err)
+	}
+	if err := cc.checkPassword(new); err != nil {
+		return nil, fmt.Errorf("new password: %w", err)
 	}
-	if cc.MinPasswordLength > len(new) {
-		return nil, fmt.Errorf("change password: %w", ErrTooShort)
+	if err := r.checkPasswordHistory(ctx, scopeId, acct.PublicId, cc.PasswordHistoryCount, new); err != nil {
+		return nil, fmt.Errorf("new password: %w", err)
 	}
-	newCred, err := newArgon2Credential(accountId, new, cc.argon2())
+	newCred, err := newArgon2Credential(acct.PublicId, new, cc.argon2())
 	if err != nil {
-		return nil, fmt.Errorf("change password: %w", err)
+		return nil, err
 	}
 
 	if err := newCred.encrypt(ctx, databaseWrapper); err != nil {
-		return nil, fmt.Errorf("change password: encrypt: %w", err)
+		return nil, fmt.Errorf("encrypt: %w", err)
 	}
 
 	oldCred := acct.Argon2Credential
@@ -172,16 +229,19 @@ func (r *Repository) ChangePassword(ctx context.Context, scopeId, accountId, old
 	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
 		func(_ db.Reader, w db.Writer) error {
 			updatedAccount = allocAccount()
-			updatedAccount.PublicId = accountId
+			updatedAccount.PublicId = acct.PublicId
 			updatedAccount.Version = version + 1
-			rowsUpdated, err := w.Update(ctx, updatedAccount, []string{"Version"}, nil, db.WithOplog(oplogWrapper, acct.Account.oplog(oplog.OpType_OP_TYPE_UPDATE)), db.WithVersion(&version))
+			rowsUpdated, err := w.Update(ctx, updatedAccount, []string{"Version", "MustChangePassword"}, []string{"LockedTime"}, db.WithOplog(oplogWrapper, acct.Account.oplog(oplog.OpType_OP_TYPE_UPDATE)), db.WithVersion(&version))
 			if err != nil {
-				return fmt.Errorf("change password: unable to update account version: %w", err)
+				return fmt.Errorf("unable to update account version: %w", err)
 			}
 			if rowsUpdated != 1 {
-				return fmt.Errorf("change password: updated account and %d rows updated", rowsUpdated)
+				return fmt.Errorf("updated account and %d rows updated", rowsUpdated)
 			}
 
+			if err := saveCredentialHistory(ctx, w, acct.PublicId, oldCred.PrivateId, cc.PasswordHistoryCount); err != nil {
+				return err
+			}
 			rowsDeleted, err := w.Delete(ctx, oldCred, db.WithOplog(oplogWrapper, oldCred.oplog(oplog.OpType_OP_TYPE_DELETE)))
 			if err == nil && rowsDeleted > 1 {
 				return errors.ErrMultipleRecords
@@ -193,7 +253,7 @@ func (r *Repository) ChangePassword(ctx context.Context, scopeId, accountId, old
 		},
 	)
 	if err != nil {
-		return nil, fmt.Errorf("change password: %w", err)
+		return nil, err
 	}
 
 	// change the Credential Id
@@ -228,6 +288,10 @@ func (r *Repository) authenticate(ctx context.Context, scopeId, authMethodId, lo
 		acct = accts[0]
 	}
 
+	if acct.IsLocked {
+		return nil, ErrAccountLocked
+	}
+
 	// We don't pass a wrapper in here because for ecryption we want to indicate the expected key ID
 	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(acct.GetKeyId()))
 	if err != nil {
@@ -238,16 +302,149 @@ func (r *Repository) authenticate(ctx context.Context, scopeId, authMethodId, lo
 		return nil, fmt.Errorf("cannot decrypt credential: %w", err)
 	}
 
-	inputKey := argon2.IDKey([]byte(password), acct.Salt, acct.Iterations, acct.Memory, uint8(acct.Threads), acct.KeyLength)
-	if subtle.ConstantTimeCompare(inputKey, acct.DerivedKey) == 0 {
+	if !passwordMatches(password, acct.Argon2Credential, acct.Argon2Configuration) {
 		// authentication failed, password does not match
+		if err := r.recordFailedAttempt(ctx, scopeId, &acct); err != nil {
+			return nil, fmt.Errorf("record failed attempt: %w", err)
+		}
 		return nil, nil
 	}
+	if _, err := r.writer.Exec(ctx, recordSuccessfulAttemptQuery, []interface{}{acct.PublicId}); err != nil {
+		return nil, fmt.Errorf("record successful attempt: %w", err)
+	}
 	return &acct, nil
 }
 
+// passwordMatches reports whether password derives the key of cred using
+// the settings in conf. cred must be decrypted.
+func passwordMatches(password string, cred *Argon2Credential, conf *Argon2Configuration) bool {
+	inputKey := argon2.IDKey([]byte(password), cred.Salt, conf.Iterations, conf.Memory, uint8(conf.Threads), conf.KeyLength)
+	return subtle.ConstantTimeCompare(inputKey, cred.DerivedKey) == 1
+}
+
+// recordFailedAttempt records a failed authentication attempt for acct and
+// locks the account if the lockout threshold of its auth method is reached.
+func (r *Repository) recordFailedAttempt(ctx context.Context, scopeId string, acct *authAccount) error {
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return fmt.Errorf("unable to get oplog wrapper: %w", err)
+	}
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(rr db.Reader, w db.Writer) error {
+			rows, err := rr.Query(ctx, recordFailedAttemptQuery, []interface{}{acct.PublicId, acct.LockoutWindowSeconds})
+			if err != nil {
+				return err
+			}
+			defer rows.Close()
+			var attemptTime time.Time
+			var failedAttempts int
+			for rows.Next() {
+				if err := rows.Scan(&attemptTime, &failedAttempts); err != nil {
+					return err
+				}
+			}
+			if err := rows.Err(); err != nil {
+				return err
+			}
+			if acct.LockoutThreshold == 0 || failedAttempts < acct.LockoutThreshold {
+				return nil
+			}
+
+			lockedAccount := allocAccount()
+			lockedAccount.PublicId = acct.PublicId
+			lockedAccount.LockedTime = &timestamp.Timestamp{Timestamp: timestamppb.New(attemptTime)}
+			rowsUpdated, err := w.Update(ctx, lockedAccount, []string{"LockedTime"}, nil, db.WithOplog(oplogWrapper, lockedAccount.oplog(oplog.OpType_OP_TYPE_UPDATE)))
+			if err == nil && rowsUpdated > 1 {
+				return errors.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+	return err
+}
+
+// clearLock clears the expired lock of acct.
+func (r *Repository) clearLock(ctx context.Context, scopeId string, acct *Account) error {
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return fmt.Errorf("unable to get oplog wrapper: %w", err)
+	}
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			unlockedAccount := allocAccount()
+			unlockedAccount.PublicId = acct.PublicId
+			rowsUpdated, err := w.Update(ctx, unlockedAccount, nil, []string{"LockedTime"}, db.WithOplog(oplogWrapper, unlockedAccount.oplog(oplog.OpType_OP_TYPE_UPDATE)))
+			if err == nil && rowsUpdated > 1 {
+				return errors.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("clear expired lock: %w", err)
+	}
+	return nil
+}
+
+type recentCredential struct {
+	*Argon2Credential
+	*Argon2Configuration
+}
+
+// checkPasswordHistory returns ErrPasswordReused if password equals one of
+// the count most recent passwords of accountId, including the current one.
+func (r *Repository) checkPasswordHistory(ctx context.Context, scopeId, accountId string, count int, password string) error {
+	if count == 0 {
+		return nil
+	}
+	rows, err := r.reader.Query(ctx, recentCredentialsQuery, []interface{}{accountId, count})
+	if err != nil {
+		return fmt.Errorf("retrieve password history: %w", err)
+	}
+	defer rows.Close()
+	var creds []recentCredential
+	for rows.Next() {
+		var rc recentCredential
+		if err := r.reader.ScanRows(rows, &rc); err != nil {
+			return fmt.Errorf("retrieve password history: %w", err)
+		}
+		creds = append(creds, rc)
+	}
+
+	for _, rc := range creds {
+		databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(rc.GetKeyId()))
+		if err != nil {
+			return fmt.Errorf("unable to get database wrapper: %w", err)
+		}
+		if err := rc.decrypt(ctx, databaseWrapper); err != nil {
+			return fmt.Errorf("cannot decrypt credential: %w", err)
+		}
+		if passwordMatches(password, rc.Argon2Credential, rc.Argon2Configuration) {
+			return ErrPasswordReused
+		}
+	}
+	return nil
+}
+
+// saveCredentialHistory adds the credential credentialId to the password
+// history of accountId and removes all but the count most recent
+// credentials from the history. It must be called before the credential is
+// deleted.
+func saveCredentialHistory(ctx context.Context, w db.Writer, accountId, credentialId string, count int) error {
+	if count > 0 && credentialId != "" {
+		if _, err := w.Exec(ctx, insertCredentialHistoryQuery, []interface{}{credentialId}); err != nil {
+			return fmt.Errorf("save password history: %w", err)
+		}
+	}
+	if _, err := w.Exec(ctx, pruneCredentialHistoryQuery, []interface{}{accountId, count}); err != nil {
+		return fmt.Errorf("prune password history: %w", err)
+	}
+	return nil
+}
+
 // SetPassword sets the password for accountId to password. If password
 // contains an empty string, the password for accountId will be deleted.
+// password must satisfy the password policy of the account's auth method.
 func (r *Repository) SetPassword(ctx context.Context, scopeId, accountId, password string, version uint32) (*Account, error) {
 	if accountId == "" {
 		return nil, fmt.Errorf("set password: no accountId: %w", errors.ErrInvalidParameter)
@@ -268,17 +465,21 @@ func (r *Repository) SetPassword(ctx context.Context, scopeId, accountId, passwo
 		return nil, fmt.Errorf("set password: unable to get database wrapper: %w", err)
 	}
 
+	cc, err := r.currentConfigForAccount(ctx, accountId)
+	if err != nil {
+		return nil, fmt.Errorf("set password: retrieve current configuration: %w", err)
+	}
+	if cc == nil {
+		return nil, fmt.Errorf("set password: retrieve current configuration: %w", errors.ErrRecordNotFound)
+	}
+
 	var newCred *Argon2Credential
 	if password != "" {
-		cc, err := r.currentConfigForAccount(ctx, accountId)
-		if err != nil {
-			return nil, fmt.Errorf("set password: retrieve current configuration: %w", err)
+		if err := cc.checkPassword(password); err != nil {
+			return nil, fmt.Errorf("set password: new password: %w", err)
 		}
-		if cc == nil {
-			return nil, fmt.Errorf("set password: retrieve current configuration: %w", errors.ErrRecordNotFound)
-		}
-		if cc.MinPasswordLength > len(password) {
-			return nil, fmt.Errorf("set password: new password: %w", ErrTooShort)
+		if err := r.checkPasswordHistory(ctx, scopeId, accountId, cc.PasswordHistoryCount, password); err != nil {
+			return nil, fmt.Errorf("set password: new password: %w", err)
 		}
 		newCred, err = newArgon2Credential(accountId, password, cc.argon2())
 		if err != nil {
@@ -311,6 +512,9 @@ func (r *Repository) SetPassword(ctx context.Context, scopeId, accountId, passwo
 				}
 			}
 			if oldCred.PrivateId != "" {
+				if err := saveCredentialHistory(ctx, w, accountId, oldCred.PrivateId, cc.PasswordHistoryCount); err != nil {
+					return err
+				}
 				dCred := oldCred.clone()
 				rowsDeleted, err := w.Delete(ctx, dCred, db.WithOplog(oplogWrapper, oldCred.oplog(oplog.OpType_OP_TYPE_DELETE)))
 				if err == nil && rowsDeleted > 1 {
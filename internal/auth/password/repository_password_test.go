@@ -540,3 +540,171 @@ func TestRepository_SetPassword(t *testing.T) {
 	}
 
 }
+
+func TestRepository_AuthenticateLockout(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	authMethod := TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+
+	assert, require := assert.New(t), require.New(t)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+	require.NotNil(repo)
+
+	authMethod.LockoutThreshold = 2
+	authMethod, _, err = repo.UpdateAuthMethod(context.Background(), authMethod, authMethod.Version, []string{"LockoutThreshold"})
+	require.NoError(err)
+
+	passwd := "12345678"
+	acct := TestAccounts(t, conn, authMethod.PublicId, 1)[0]
+	acct, err = repo.SetPassword(context.Background(), o.GetPublicId(), acct.PublicId, passwd, acct.Version)
+	require.NoError(err)
+
+	for i := 0; i < 2; i++ {
+		authAcct, err := repo.Authenticate(context.Background(), o.GetPublicId(), authMethod.PublicId, acct.LoginName, "wrong-password")
+		require.NoError(err)
+		assert.Nil(authAcct)
+	}
+
+	authAcct, err := repo.Authenticate(context.Background(), o.GetPublicId(), authMethod.PublicId, acct.LoginName, passwd)
+	assert.Truef(errors.Is(err, ErrAccountLocked), "want err: %q got: %q", ErrAccountLocked, err)
+	assert.Nil(authAcct)
+
+	locked, err := repo.LookupAccount(context.Background(), acct.PublicId)
+	require.NoError(err)
+	require.NotNil(locked.LockedTime)
+
+	unlocked, err := repo.UnlockAccount(context.Background(), o.GetPublicId(), acct.PublicId, locked.Version)
+	require.NoError(err)
+	assert.Nil(unlocked.LockedTime)
+	assert.NotNil(unlocked.UnlockTime)
+
+	authAcct, err = repo.Authenticate(context.Background(), o.GetPublicId(), authMethod.PublicId, acct.LoginName, passwd)
+	require.NoError(err)
+	assert.NotNil(authAcct)
+}
+
+func TestRepository_AuthenticateSuccessResetsFailedAttempts(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	authMethod := TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+
+	assert, require := assert.New(t), require.New(t)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+	require.NotNil(repo)
+
+	authMethod.LockoutThreshold = 2
+	authMethod, _, err = repo.UpdateAuthMethod(context.Background(), authMethod, authMethod.Version, []string{"LockoutThreshold"})
+	require.NoError(err)
+
+	passwd := "12345678"
+	acct := TestAccounts(t, conn, authMethod.PublicId, 1)[0]
+	acct, err = repo.SetPassword(context.Background(), o.GetPublicId(), acct.PublicId, passwd, acct.Version)
+	require.NoError(err)
+
+	// failure
+	authAcct, err := repo.Authenticate(context.Background(), o.GetPublicId(), authMethod.PublicId, acct.LoginName, "wrong-password")
+	require.NoError(err)
+	assert.Nil(authAcct)
+
+	// success
+	authAcct, err = repo.Authenticate(context.Background(), o.GetPublicId(), authMethod.PublicId, acct.LoginName, passwd)
+	require.NoError(err)
+	assert.NotNil(authAcct)
+
+	// failure, which is the first since the success so the account stays
+	// unlocked
+	authAcct, err = repo.Authenticate(context.Background(), o.GetPublicId(), authMethod.PublicId, acct.LoginName, "wrong-password")
+	require.NoError(err)
+	assert.Nil(authAcct)
+
+	got, err := repo.LookupAccount(context.Background(), acct.PublicId)
+	require.NoError(err)
+	assert.Nil(got.LockedTime)
+
+	authAcct, err = repo.Authenticate(context.Background(), o.GetPublicId(), authMethod.PublicId, acct.LoginName, passwd)
+	require.NoError(err)
+	assert.NotNil(authAcct)
+}
+
+func TestRepository_AuthenticateMustChangePassword(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	authMethod := TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+
+	assert, require := assert.New(t), require.New(t)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+	require.NotNil(repo)
+
+	passwd := "12345678"
+	acct := TestAccounts(t, conn, authMethod.PublicId, 1)[0]
+	acct, err = repo.SetPassword(context.Background(), o.GetPublicId(), acct.PublicId, passwd, acct.Version)
+	require.NoError(err)
+
+	acct.MustChangePassword = true
+	acct, _, err = repo.UpdateAccount(context.Background(), o.GetPublicId(), acct, acct.Version, []string{"MustChangePassword"})
+	require.NoError(err)
+
+	authAcct, err := repo.Authenticate(context.Background(), o.GetPublicId(), authMethod.PublicId, acct.LoginName, passwd)
+	assert.Truef(errors.Is(err, ErrPasswordChangeRequired), "want err: %q got: %q", ErrPasswordChangeRequired, err)
+	assert.Nil(authAcct)
+
+	authAcct, err = repo.Authenticate(context.Background(), o.GetPublicId(), authMethod.PublicId, acct.LoginName, passwd, WithNewPassword(passwd))
+	assert.Truef(errors.Is(err, ErrPasswordsEqual), "want err: %q got: %q", ErrPasswordsEqual, err)
+	assert.Nil(authAcct)
+
+	newPasswd := "87654321"
+	authAcct, err = repo.Authenticate(context.Background(), o.GetPublicId(), authMethod.PublicId, acct.LoginName, passwd, WithNewPassword(newPasswd))
+	require.NoError(err)
+	require.NotNil(authAcct)
+	assert.False(authAcct.MustChangePassword)
+
+	authAcct, err = repo.Authenticate(context.Background(), o.GetPublicId(), authMethod.PublicId, acct.LoginName, newPasswd)
+	require.NoError(err)
+	assert.NotNil(authAcct)
+}
+
+func TestRepository_ChangePasswordHistory(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	authMethod := TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+
+	assert, require := assert.New(t), require.New(t)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+	require.NotNil(repo)
+
+	authMethod.PasswordHistoryCount = 2
+	authMethod, _, err = repo.UpdateAuthMethod(context.Background(), authMethod, authMethod.Version, []string{"PasswordHistoryCount"})
+	require.NoError(err)
+
+	acct := TestAccounts(t, conn, authMethod.PublicId, 1)[0]
+	acct, err = repo.SetPassword(context.Background(), o.GetPublicId(), acct.PublicId, "first-password", acct.Version)
+	require.NoError(err)
+	acct, err = repo.ChangePassword(context.Background(), o.GetPublicId(), acct.PublicId, "first-password", "second-password", acct.Version)
+	require.NoError(err)
+
+	_, err = repo.ChangePassword(context.Background(), o.GetPublicId(), acct.PublicId, "second-password", "first-password", acct.Version)
+	assert.Truef(errors.Is(err, ErrPasswordReused), "want err: %q got: %q", ErrPasswordReused, err)
+
+	acct, err = repo.ChangePassword(context.Background(), o.GetPublicId(), acct.PublicId, "second-password", "third-password", acct.Version)
+	require.NoError(err)
+
+	// Only the two most recent passwords are remembered.
+	_, err = repo.ChangePassword(context.Background(), o.GetPublicId(), acct.PublicId, "third-password", "first-password", acct.Version)
+	assert.NoError(err)
+}
@@ -1,6 +1,18 @@
 package auth
 
-import "context"
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/servers"
+	"github.com/hashicorp/go-hclog"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/require"
+)
 
 // DisabledAuthTestContext is meant for testing, and uses a context that has auth checking entirely disabled
 func DisabledAuthTestContext(opt ...Option) context.Context {
@@ -10,3 +22,28 @@ func DisabledAuthTestContext(opt ...Option) context.Context {
 	reqInfo.userIdOverride = opts.withUserId
 	return NewVerifierContext(context.Background(), nil, nil, nil, nil, opts.withKms, reqInfo)
 }
+
+// TestAuthContextFromToken is meant for testing, and uses a context whose
+// requests are made with the auth token, so they are authorized against the
+// grants of the token's user.
+func TestAuthContextFromToken(t *testing.T, conn *gorm.DB, kmsCache *kms.Kms, at *authtoken.AuthToken) context.Context {
+	t.Helper()
+	require := require.New(t)
+	rw := db.New(conn)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.NewRepository(rw, rw, kmsCache)
+	}
+	authTokenRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kmsCache)
+	}
+	serversRepoFn := func() (*servers.Repository, error) {
+		return servers.NewRepository(rw, rw, kmsCache)
+	}
+	encToken, err := authtoken.EncryptToken(context.Background(), kmsCache, at.GetScopeId(), at.GetPublicId(), at.GetToken())
+	require.NoError(err)
+	return NewVerifierContext(context.Background(), hclog.NewNullLogger(), iamRepoFn, authTokenRepoFn, serversRepoFn, kmsCache, RequestInfo{
+		PublicId:       at.GetPublicId(),
+		EncryptedToken: encToken,
+		TokenFormat:    AuthTokenTypeBearer,
+	})
+}
@@ -29,6 +29,7 @@ type options struct {
 	withTokenTimeToLiveDuration  time.Duration
 	withTokenTimeToStaleDuration time.Duration
 	withLimit                    int
+	withStartPageAfterId         string
 }
 
 func getDefaultOptions() options {
@@ -75,3 +76,12 @@ func WithLimit(limit int) Option {
 		}
 	}
 }
+
+// WithStartPageAfterId provides an option to list only the resources whose
+// public ids sort after the given id. Results are ordered by public id when
+// it is used, allowing listing to be resumed where a previous page ended.
+func WithStartPageAfterId(id string) Option {
+	return func(o *options) {
+		o.withStartPageAfterId = id
+	}
+}
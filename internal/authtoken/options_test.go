@@ -34,4 +34,12 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withTokenTimeToStaleDuration = 1 * time.Hour
 		assert.Equal(opts, testOpts)
 	})
+
+	t.Run("WithStartPageAfterId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithStartPageAfterId("at_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withStartPageAfterId = "at_1234567890"
+		assert.Equal(opts, testOpts)
+	})
 }
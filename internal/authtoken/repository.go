@@ -267,15 +267,21 @@ func (r *Repository) ValidateToken(ctx context.Context, id, token string, opt ..
 	return retAT, nil
 }
 
-// ListAuthTokens in an org and supports the WithLimit option.
-func (r *Repository) ListAuthTokens(ctx context.Context, withOrgId string, opt ...Option) ([]*AuthToken, error) {
-	if withOrgId == "" {
-		return nil, fmt.Errorf("list users: missing org id %w", errors.ErrInvalidParameter)
+// ListAuthTokens in the given scopes and supports the WithLimit and
+// WithStartPageAfterId options.
+func (r *Repository) ListAuthTokens(ctx context.Context, withScopeIds []string, opt ...Option) ([]*AuthToken, error) {
+	if len(withScopeIds) == 0 {
+		return nil, fmt.Errorf("list users: missing scope id %w", errors.ErrInvalidParameter)
 	}
 	opts := getOpts(opt...)
 
+	where, args := "auth_account_id in (select public_id from auth_account where scope_id in (?))", []interface{}{withScopeIds}
+	if opts.withStartPageAfterId != "" {
+		where, args = where+" and public_id > ?", append(args, opts.withStartPageAfterId)
+	}
+
 	var authTokens []*AuthToken
-	if err := r.reader.SearchWhere(ctx, &authTokens, "auth_account_id in (select public_id from auth_account where scope_id = ?)", []interface{}{withOrgId}, db.WithLimit(opts.withLimit)); err != nil {
+	if err := r.reader.SearchWhere(ctx, &authTokens, where, args, db.WithLimit(opts.withLimit), db.WithOrder("public_id")); err != nil {
 		return nil, fmt.Errorf("list users: %w", err)
 	}
 	for _, at := range authTokens {
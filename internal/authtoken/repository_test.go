@@ -611,23 +611,23 @@ func TestRepository_ListAuthTokens(t *testing.T) {
 
 	var tests = []struct {
 		name    string
-		orgId   string
+		orgIds  []string
 		want    []*AuthToken
 		wantErr error
 	}{
 		{
-			name:  "populated",
-			orgId: org.GetPublicId(),
-			want:  []*AuthToken{at1, at2, at3},
+			name:   "populated",
+			orgIds: []string{org.GetPublicId()},
+			want:   []*AuthToken{at1, at2, at3},
 		},
 		{
-			name:  "empty",
-			orgId: emptyOrg.GetPublicId(),
-			want:  []*AuthToken{},
+			name:   "empty",
+			orgIds: []string{emptyOrg.GetPublicId()},
+			want:   []*AuthToken{},
 		},
 		{
 			name:    "empty-org-id",
-			orgId:   "",
+			orgIds:  nil,
 			want:    nil,
 			wantErr: errors.ErrInvalidParameter,
 		},
@@ -641,7 +641,7 @@ func TestRepository_ListAuthTokens(t *testing.T) {
 			require.NoError(err)
 			require.NotNil(repo)
 
-			got, err := repo.ListAuthTokens(context.Background(), tt.orgId)
+			got, err := repo.ListAuthTokens(context.Background(), tt.orgIds)
 			if tt.wantErr != nil {
 				assert.Truef(errors.Is(err, tt.wantErr), "want err: %q got: %q", tt.wantErr, err)
 				return
@@ -68,14 +68,19 @@ type Command struct {
 	FlagRecoveryConfig   string
 	flagOutputCurlString bool
 
-	FlagScopeId       string
-	FlagScopeName     string
-	FlagId            string
-	FlagName          string
-	FlagDescription   string
-	FlagAuthMethodId  string
-	FlagHostCatalogId string
-	FlagVersion       int
+	FlagScopeId           string
+	FlagScopeName         string
+	FlagId                string
+	FlagName              string
+	FlagDescription       string
+	FlagAuthMethodId      string
+	FlagHostCatalogId     string
+	FlagCredentialStoreId string
+	FlagVersion           int
+	FlagFilter            string
+	FlagPageSize          int
+	FlagPageToken         string
+	FlagRecursive         bool
 
 	client *api.Client
 }
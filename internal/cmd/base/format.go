@@ -202,3 +202,18 @@ func Format(ui cli.Ui) string {
 
 	return format
 }
+
+// PrintNextPageToken prints the token of the next page of a list command, if
+// any. For JSON output it is written to stderr so that stdout remains valid
+// JSON.
+func PrintNextPageToken(ui cli.Ui, token string) {
+	if token == "" {
+		return
+	}
+	switch Format(ui) {
+	case "json":
+		ui.Warn(fmt.Sprintf("Next page token: %s", token))
+	default:
+		ui.Output(fmt.Sprintf("\nNext page token: %s", token))
+	}
+}
@@ -64,7 +64,7 @@ func NewListener(l *configutil.Listener, logger hclog.Logger, ui cli.Ui) (*alpnm
 	switch purpose {
 	case "cluster":
 		l.TLSDisable = true
-	case "proxy":
+	case "proxy", "controller-proxy":
 		// TODO: Eventually we'll support bringing your own cert, and we'd only
 		// want to disable if you aren't actually bringing your own
 		l.TLSDisable = true
@@ -123,6 +123,8 @@ func tcpListenerFactory(purpose string, l *configutil.Listener, logger hclog.Log
 			l.Address = "127.0.0.1:9201"
 		case "proxy":
 			l.Address = "127.0.0.1:9202"
+		case "controller-proxy":
+			l.Address = "127.0.0.1:9203"
 		default:
 			l.Address = "127.0.0.1:9200"
 		}
@@ -136,6 +138,8 @@ func tcpListenerFactory(purpose string, l *configutil.Listener, logger hclog.Log
 				port = "9201"
 			case "proxy":
 				port = "9202"
+			case "controller-proxy":
+				port = "9203"
 			default:
 				port = "9200"
 			}
@@ -627,6 +627,42 @@ func (b *Server) SetupControllerPublicClusterAddress(conf *config.Config, flagVa
 	return nil
 }
 
+// SetupControllerPublicProxyAddress sets the controller's public proxy
+// address, which is left empty if there is no "controller-proxy" listener.
+func (b *Server) SetupControllerPublicProxyAddress(conf *config.Config, flagValue string) error {
+	if conf.Controller == nil {
+		conf.Controller = new(config.Controller)
+	}
+	if flagValue != "" {
+		conf.Controller.PublicProxyAddr = flagValue
+	}
+	if conf.Controller.PublicProxyAddr == "" {
+	FindAddr:
+		for _, listener := range conf.Listeners {
+			for _, purpose := range listener.Purpose {
+				if purpose == "controller-proxy" {
+					conf.Controller.PublicProxyAddr = listener.Address
+					break FindAddr
+				}
+			}
+		}
+		if conf.Controller.PublicProxyAddr == "" {
+			return nil
+		}
+	}
+	host, port, err := net.SplitHostPort(conf.Controller.PublicProxyAddr)
+	if err != nil {
+		if strings.Contains(err.Error(), "missing port") {
+			port = "9203"
+			host = conf.Controller.PublicProxyAddr
+		} else {
+			return fmt.Errorf("Error splitting public proxy adddress host/port: %w", err)
+		}
+	}
+	conf.Controller.PublicProxyAddr = net.JoinHostPort(host, port)
+	return nil
+}
+
 func (b *Server) SetupWorkerPublicAddress(conf *config.Config, flagValue string) error {
 	if conf.Worker == nil {
 		conf.Worker = new(config.Worker)
@@ -644,6 +680,11 @@ func (b *Server) SetupWorkerPublicAddress(conf *config.Config, flagValue string)
 				}
 			}
 		}
+		if conf.Worker.PublicAddr == "" {
+			// A worker without a proxy listener is only reached through its
+			// upstreams or the controller it is reverse-connected to
+			return nil
+		}
 	}
 	host, port, err := net.SplitHostPort(conf.Worker.PublicAddr)
 	if err != nil {
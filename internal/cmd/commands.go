@@ -6,12 +6,15 @@ import (
 	"syscall"
 
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/commands/accessrequests"
 	"github.com/hashicorp/boundary/internal/cmd/commands/accounts"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authenticate"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authmethods"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authtokens"
 	"github.com/hashicorp/boundary/internal/cmd/commands/config"
 	"github.com/hashicorp/boundary/internal/cmd/commands/connect"
+	"github.com/hashicorp/boundary/internal/cmd/commands/credentials"
+	"github.com/hashicorp/boundary/internal/cmd/commands/credentialstores"
 	"github.com/hashicorp/boundary/internal/cmd/commands/database"
 	"github.com/hashicorp/boundary/internal/cmd/commands/dev"
 	"github.com/hashicorp/boundary/internal/cmd/commands/groups"
@@ -70,6 +73,16 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Command: base.NewCommand(ui),
 			}, nil
 		},
+		"authenticate oidc": func() (cli.Command, error) {
+			return &authenticate.OidcCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"authenticate ldap": func() (cli.Command, error) {
+			return &authenticate.LdapCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
 
 		"accounts": func() (cli.Command, error) {
 			return &accounts.Command{
@@ -106,6 +119,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "change-password",
 			}, nil
 		},
+		"accounts unlock": func() (cli.Command, error) {
+			return &accounts.Command{
+				Command: base.NewCommand(ui),
+				Func:    "unlock",
+			}, nil
+		},
 		"accounts create": func() (cli.Command, error) {
 			return &accounts.Command{
 				Command: base.NewCommand(ui),
@@ -276,6 +295,102 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			}, nil
 		},
 
+		"credential-stores": func() (cli.Command, error) {
+			return &credentialstores.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"credential-stores read": func() (cli.Command, error) {
+			return &credentialstores.Command{
+				Command: base.NewCommand(ui),
+				Func:    "read",
+			}, nil
+		},
+		"credential-stores delete": func() (cli.Command, error) {
+			return &credentialstores.Command{
+				Command: base.NewCommand(ui),
+				Func:    "delete",
+			}, nil
+		},
+		"credential-stores list": func() (cli.Command, error) {
+			return &credentialstores.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list",
+			}, nil
+		},
+		"credential-stores create": func() (cli.Command, error) {
+			return &credentialstores.Command{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"credential-stores create static": func() (cli.Command, error) {
+			return &credentialstores.StaticCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"credential-stores update": func() (cli.Command, error) {
+			return &credentialstores.Command{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+		"credential-stores update static": func() (cli.Command, error) {
+			return &credentialstores.StaticCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+
+		"credentials": func() (cli.Command, error) {
+			return &credentials.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"credentials read": func() (cli.Command, error) {
+			return &credentials.Command{
+				Command: base.NewCommand(ui),
+				Func:    "read",
+			}, nil
+		},
+		"credentials delete": func() (cli.Command, error) {
+			return &credentials.Command{
+				Command: base.NewCommand(ui),
+				Func:    "delete",
+			}, nil
+		},
+		"credentials list": func() (cli.Command, error) {
+			return &credentials.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list",
+			}, nil
+		},
+		"credentials create": func() (cli.Command, error) {
+			return &credentials.Command{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"credentials create username-password": func() (cli.Command, error) {
+			return &credentials.UsernamePasswordCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"credentials update": func() (cli.Command, error) {
+			return &credentials.Command{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+		"credentials update username-password": func() (cli.Command, error) {
+			return &credentials.UsernamePasswordCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+
 		"database": func() (cli.Command, error) {
 			return &database.Command{
 				Command: base.NewCommand(ui),
@@ -376,6 +491,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "create",
 			}, nil
 		},
+		"host-catalogs create plugin": func() (cli.Command, error) {
+			return &hostcatalogs.PluginCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
 		"host-catalogs update": func() (cli.Command, error) {
 			return &hostcatalogs.Command{
 				Command: base.NewCommand(ui),
@@ -388,6 +509,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "update",
 			}, nil
 		},
+		"host-catalogs update plugin": func() (cli.Command, error) {
+			return &hostcatalogs.PluginCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
 
 		"host-sets": func() (cli.Command, error) {
 			return &hostsets.Command{
@@ -424,6 +551,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "create",
 			}, nil
 		},
+		"host-sets create plugin": func() (cli.Command, error) {
+			return &hostsets.PluginCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
 		"host-sets update": func() (cli.Command, error) {
 			return &hostsets.Command{
 				Command: base.NewCommand(ui),
@@ -436,6 +569,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "update",
 			}, nil
 		},
+		"host-sets update plugin": func() (cli.Command, error) {
+			return &hostsets.PluginCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
 		"host-sets add-hosts": func() (cli.Command, error) {
 			return &hostsets.Command{
 				Command: base.NewCommand(ui),
@@ -574,6 +713,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "remove-grants",
 			}, nil
 		},
+		"roles explain": func() (cli.Command, error) {
+			return &roles.Command{
+				Command: base.NewCommand(ui),
+				Func:    "explain",
+			}, nil
+		},
 
 		"scopes": func() (cli.Command, error) {
 			return &scopes.Command{
@@ -611,6 +756,42 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			}, nil
 		},
 
+		"access-requests": func() (cli.Command, error) {
+			return &accessrequests.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"access-requests create": func() (cli.Command, error) {
+			return &accessrequests.Command{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"access-requests read": func() (cli.Command, error) {
+			return &accessrequests.Command{
+				Command: base.NewCommand(ui),
+				Func:    "read",
+			}, nil
+		},
+		"access-requests list": func() (cli.Command, error) {
+			return &accessrequests.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list",
+			}, nil
+		},
+		"access-requests approve": func() (cli.Command, error) {
+			return &accessrequests.Command{
+				Command: base.NewCommand(ui),
+				Func:    "approve",
+			}, nil
+		},
+		"access-requests deny": func() (cli.Command, error) {
+			return &accessrequests.Command{
+				Command: base.NewCommand(ui),
+				Func:    "deny",
+			}, nil
+		},
+
 		"sessions": func() (cli.Command, error) {
 			return &sessions.Command{
 				Command: base.NewCommand(ui),
@@ -634,6 +815,24 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "cancel",
 			}, nil
 		},
+		"sessions download-recording": func() (cli.Command, error) {
+			return &sessions.Command{
+				Command: base.NewCommand(ui),
+				Func:    "download-recording",
+			}, nil
+		},
+		"sessions terminate-connection": func() (cli.Command, error) {
+			return &sessions.Command{
+				Command: base.NewCommand(ui),
+				Func:    "terminate-connection",
+			}, nil
+		},
+		"sessions watch": func() (cli.Command, error) {
+			return &sessions.Command{
+				Command: base.NewCommand(ui),
+				Func:    "watch",
+			}, nil
+		},
 
 		"targets": func() (cli.Command, error) {
 			return &targets.Command{
@@ -676,6 +875,24 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "create",
 			}, nil
 		},
+		"targets create http": func() (cli.Command, error) {
+			return &targets.HttpCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"targets create ssh": func() (cli.Command, error) {
+			return &targets.SshCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"targets create udp": func() (cli.Command, error) {
+			return &targets.UdpCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
 		"targets update": func() (cli.Command, error) {
 			return &targets.Command{
 				Command: base.NewCommand(ui),
@@ -688,6 +905,24 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "update",
 			}, nil
 		},
+		"targets update http": func() (cli.Command, error) {
+			return &targets.HttpCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+		"targets update ssh": func() (cli.Command, error) {
+			return &targets.SshCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+		"targets update udp": func() (cli.Command, error) {
+			return &targets.UdpCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
 		"targets add-host-sets": func() (cli.Command, error) {
 			return &targets.Command{
 				Command: base.NewCommand(ui),
@@ -706,6 +941,24 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "set-host-sets",
 			}, nil
 		},
+		"targets add-credentials": func() (cli.Command, error) {
+			return &targets.Command{
+				Command: base.NewCommand(ui),
+				Func:    "add-credentials",
+			}, nil
+		},
+		"targets remove-credentials": func() (cli.Command, error) {
+			return &targets.Command{
+				Command: base.NewCommand(ui),
+				Func:    "remove-credentials",
+			}, nil
+		},
+		"targets set-credentials": func() (cli.Command, error) {
+			return &targets.Command{
+				Command: base.NewCommand(ui),
+				Func:    "set-credentials",
+			}, nil
+		},
 
 		"users": func() (cli.Command, error) {
 			return &users.Command{
@@ -0,0 +1,313 @@
+package accessrequests
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/accessrequests"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/sdk/strutil"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*Command)(nil)
+var _ cli.CommandAutocomplete = (*Command)(nil)
+
+type Command struct {
+	*base.Command
+
+	Func string
+
+	flagTargetId      string
+	flagJustification string
+	flagDuration      time.Duration
+	flagComment       string
+}
+
+func (c *Command) Synopsis() string {
+	return common.SynopsisFunc(c.Func, "access request")
+}
+
+var flagsMap = map[string][]string{
+	"create":  {},
+	"read":    {"id"},
+	"approve": {"id", "version"},
+	"deny":    {"id", "version"},
+	"list":    {"scope-id", "recursive", "filter", "page-size", "page-token"},
+}
+
+func (c *Command) Help() string {
+	helpMap := common.HelpMap("access-request")
+	var helpStr string
+	switch c.Func {
+	case "":
+		return base.WrapForHelpText([]string{
+			"Usage: boundary access-requests [sub command] [options] [args]",
+			"",
+			"  This command allows operations on Boundary access requests.",
+			"",
+			"    Request access to a target:",
+			"",
+			`      $ boundary access-requests create -target-id ttcp_1234567890 -justification "Incident 42" -duration 1h`,
+			"",
+			"  Please see the access-requests subcommand help for detailed usage information.",
+		})
+	case "create":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary access-requests create [options] [args]",
+			"",
+			"  Request access to a target for the current user. Once approved, the user can authorize sessions to the target for the requested duration. Example:",
+			"",
+			`    $ boundary access-requests create -target-id ttcp_1234567890 -justification "Incident 42" -duration 1h`,
+			"",
+			"",
+		})
+	case "approve":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary access-requests approve [options] [args]",
+			"",
+			"  Approve the pending access request specified by ID. Example:",
+			"",
+			`    $ boundary access-requests approve -id areq_1234567890 -comment "Approved for incident 42"`,
+			"",
+			"",
+		})
+	case "deny":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary access-requests deny [options] [args]",
+			"",
+			"  Deny the pending access request specified by ID. Example:",
+			"",
+			`    $ boundary access-requests deny -id areq_1234567890 -comment "Use the staging environment"`,
+			"",
+			"",
+		})
+	default:
+		helpStr = helpMap[c.Func]()
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func (c *Command) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, resource.AccessRequest.String(), flagsMap[c.Func])
+
+	switch c.Func {
+	case "create":
+		f.StringVar(&base.StringVar{
+			Name:   "target-id",
+			Target: &c.flagTargetId,
+			Usage:  "The target to which access is requested.",
+		})
+		f.StringVar(&base.StringVar{
+			Name:   "justification",
+			Target: &c.flagJustification,
+			Usage:  "The reason access is requested.",
+		})
+		f.DurationVar(&base.DurationVar{
+			Name:   "duration",
+			Target: &c.flagDuration,
+			Usage:  "How long access is requested for once the request is approved.",
+		})
+	case "approve", "deny":
+		f.StringVar(&base.StringVar{
+			Name:   "comment",
+			Target: &c.flagComment,
+			Usage:  "A comment to record with the review.",
+		})
+	}
+
+	return set
+}
+
+func (c *Command) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *Command) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *Command) Run(args []string) int {
+	if c.Func == "" {
+		return cli.RunResultHelp
+	}
+
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if strutil.StrListContains(flagsMap[c.Func], "id") && c.FlagId == "" {
+		c.UI.Error("ID is required but not passed in via -id")
+		return 1
+	}
+	if strutil.StrListContains(flagsMap[c.Func], "scope-id") && c.FlagScopeId == "" {
+		c.UI.Error("Scope ID must be passed in via -scope-id")
+		return 1
+	}
+	if c.Func == "create" {
+		switch {
+		case c.flagTargetId == "":
+			c.UI.Error("Target ID must be passed in via -target-id")
+			return 1
+		case c.flagJustification == "":
+			c.UI.Error("Justification must be passed in via -justification")
+			return 1
+		case c.flagDuration < time.Second:
+			c.UI.Error("Duration of at least one second must be passed in via -duration")
+			return 1
+		}
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+
+	var opts []accessrequests.Option
+	switch c.Func {
+	case "create":
+		opts = append(opts,
+			accessrequests.WithJustification(c.flagJustification),
+			accessrequests.WithDurationSeconds(uint32(c.flagDuration/time.Second)))
+	case "approve", "deny":
+		if c.flagComment != "" {
+			opts = append(opts, accessrequests.WithComment(c.flagComment))
+		}
+	case "list":
+		if c.FlagFilter != "" {
+			opts = append(opts, accessrequests.WithFilter(c.FlagFilter))
+		}
+		if c.FlagRecursive {
+			opts = append(opts, accessrequests.WithRecursive(true))
+		}
+		if c.FlagPageSize > 0 {
+			opts = append(opts, accessrequests.WithPageSize(uint32(c.FlagPageSize)))
+		}
+		if c.FlagPageToken != "" {
+			opts = append(opts, accessrequests.WithPageToken(c.FlagPageToken))
+		}
+	}
+
+	var version uint32
+	switch c.Func {
+	case "approve", "deny":
+		switch c.FlagVersion {
+		case 0:
+			opts = append(opts, accessrequests.WithAutomaticVersioning(true))
+		default:
+			version = uint32(c.FlagVersion)
+		}
+	}
+
+	arClient := accessrequests.NewClient(client)
+
+	var result api.GenericResult
+	var listResult api.GenericListResult
+
+	switch c.Func {
+	case "create":
+		result, err = arClient.Create(c.Context, c.flagTargetId, opts...)
+	case "read":
+		result, err = arClient.Read(c.Context, c.FlagId, opts...)
+	case "approve":
+		result, err = arClient.Approve(c.Context, c.FlagId, version, opts...)
+	case "deny":
+		result, err = arClient.Deny(c.Context, c.FlagId, version, opts...)
+	case "list":
+		listResult, err = arClient.List(c.Context, c.FlagScopeId, opts...)
+	}
+
+	plural := "access request"
+	if c.Func == "list" {
+		plural = "access requests"
+	}
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.UI.Error(fmt.Sprintf("Error from controller when performing %s on %s: %s", c.Func, plural, base.PrintApiError(apiErr)))
+			return 1
+		}
+		c.UI.Error(fmt.Sprintf("Error trying to %s %s: %s", c.Func, plural, err.Error()))
+		return 2
+	}
+
+	switch c.Func {
+	case "list":
+		listedRequests := listResult.GetItems().([]*accessrequests.AccessRequest)
+		switch base.Format(c.UI) {
+		case "json":
+			if len(listedRequests) == 0 {
+				c.UI.Output("null")
+				return 0
+			}
+			b, err := base.JsonFormatter{}.Format(listedRequests)
+			if err != nil {
+				c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+				return 1
+			}
+			c.UI.Output(string(b))
+
+		case "table":
+			if len(listedRequests) == 0 {
+				c.UI.Output("No access requests found")
+				return 0
+			}
+			var output []string
+			output = []string{
+				"",
+				"Access request information:",
+			}
+			for i, r := range listedRequests {
+				if i > 0 {
+					output = append(output, "")
+				}
+				output = append(output,
+					fmt.Sprintf("  ID:                 %s", r.Id),
+					fmt.Sprintf("    Status:           %s", r.Status),
+					fmt.Sprintf("    Target ID:        %s", r.TargetId),
+					fmt.Sprintf("    User ID:          %s", r.UserId),
+					fmt.Sprintf("    Justification:    %s", r.Justification),
+					fmt.Sprintf("    Created Time:     %s", r.CreatedTime.Local().Format(time.RFC1123)),
+				)
+				if c.FlagRecursive {
+					output = append(output,
+						fmt.Sprintf("    Scope ID:         %s", r.ScopeId),
+					)
+				}
+				if len(r.AuthorizedActions) > 0 {
+					output = append(output,
+						"    Authorized Actions:",
+						base.WrapSlice(6, r.AuthorizedActions),
+					)
+				}
+			}
+			c.UI.Output(base.WrapForHelpText(output))
+		}
+		base.PrintNextPageToken(c.UI, listResult.GetNextPageToken())
+		return 0
+	}
+
+	ar := result.GetItem().(*accessrequests.AccessRequest)
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateAccessRequestTableOutput(ar))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(ar)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	}
+
+	return 0
+}
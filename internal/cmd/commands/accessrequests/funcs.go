@@ -0,0 +1,55 @@
+package accessrequests
+
+import (
+	"time"
+
+	"github.com/hashicorp/boundary/api/accessrequests"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+func generateAccessRequestTableOutput(in *accessrequests.AccessRequest) string {
+	nonAttributeMap := map[string]interface{}{
+		"ID":            in.Id,
+		"Target ID":     in.TargetId,
+		"User ID":       in.UserId,
+		"Justification": in.Justification,
+		"Duration":      (time.Duration(in.DurationSeconds) * time.Second).String(),
+		"Status":        in.Status,
+		"Created Time":  in.CreatedTime.Local().Format(time.RFC1123),
+		"Updated Time":  in.UpdatedTime.Local().Format(time.RFC1123),
+		"Version":       in.Version,
+	}
+	if in.ReviewerId != "" {
+		nonAttributeMap["Reviewer ID"] = in.ReviewerId
+	}
+	if in.ReviewComment != "" {
+		nonAttributeMap["Review Comment"] = in.ReviewComment
+	}
+	if !in.ReviewTime.IsZero() {
+		nonAttributeMap["Review Time"] = in.ReviewTime.Local().Format(time.RFC1123)
+	}
+	if !in.ExpirationTime.IsZero() {
+		nonAttributeMap["Expiration Time"] = in.ExpirationTime.Local().Format(time.RFC1123)
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	ret := []string{
+		"",
+		"Access request information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+		"",
+		"  Scope:",
+		base.ScopeInfoForOutput(in.Scope, maxLength),
+	}
+
+	if len(in.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, in.AuthorizedActions),
+		)
+	}
+
+	return base.WrapForHelpText(ret)
+}
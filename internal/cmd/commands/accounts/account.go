@@ -36,6 +36,8 @@ func (c *Command) Synopsis() string {
 		return "Directly set the password on an account resource"
 	case "change-password":
 		return "Change the password on an account resource"
+	case "unlock":
+		return "Unlock an account resource locked after failed authentication attempts"
 	default:
 		return common.SynopsisFunc(c.Func, "account")
 	}
@@ -44,9 +46,10 @@ func (c *Command) Synopsis() string {
 var flagsMap = map[string][]string{
 	"read":            {"id"},
 	"delete":          {"id"},
-	"list":            {"auth-method-id"},
+	"list":            {"auth-method-id", "filter", "page-size", "page-token"},
 	"set-password":    {"id", "password", "version"},
 	"change-password": {"id", "current-password", "new-password", "version"},
+	"unlock":          {"id", "version"},
 }
 
 func (c *Command) Help() string {
@@ -113,6 +116,18 @@ func (c *Command) Help() string {
 			"",
 			"",
 		})
+	case "unlock":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary accounts unlock [options] [args]",
+			"",
+			"  This command allows unlocking an account-type resource which was locked after too many failed authentication attempts, if the operation is allowed by the given account type. Example:",
+			"",
+			"    Unlock a password-type account:",
+			"",
+			`      $ boundary accounts unlock -id apw_1234567890`,
+			"",
+			"",
+		})
 	default:
 		helpStr = helpMap[c.Func]()
 	}
@@ -255,6 +270,18 @@ func (c *Command) Run(args []string) int {
 		}
 	}
 
+	if c.Func == "list" {
+		if c.FlagFilter != "" {
+			opts = append(opts, accounts.WithFilter(c.FlagFilter))
+		}
+		if c.FlagPageSize > 0 {
+			opts = append(opts, accounts.WithPageSize(uint32(c.FlagPageSize)))
+		}
+		if c.FlagPageToken != "" {
+			opts = append(opts, accounts.WithPageToken(c.FlagPageToken))
+		}
+	}
+
 	accountClient := accounts.NewClient(client)
 
 	existed := true
@@ -276,6 +303,8 @@ func (c *Command) Run(args []string) int {
 		result, err = accountClient.SetPassword(c.Context, c.FlagId, c.flagPassword, version, opts...)
 	case "change-password":
 		result, err = accountClient.ChangePassword(c.Context, c.FlagId, c.flagCurrentPassword, c.flagNewPassword, version, opts...)
+	case "unlock":
+		result, err = accountClient.Unlock(c.Context, c.FlagId, version, opts...)
 	}
 
 	plural := "account"
@@ -354,9 +383,16 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("    Description:  %s", m.Description),
 					)
 				}
+				if len(m.AuthorizedActions) > 0 {
+					output = append(output,
+						"    Authorized Actions:",
+						base.WrapSlice(6, m.AuthorizedActions),
+					)
+				}
 			}
 			c.UI.Output(base.WrapForHelpText(output))
 		}
+		base.PrintNextPageToken(c.UI, listResult.GetNextPageToken())
 		return 0
 	}
 
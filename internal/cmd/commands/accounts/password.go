@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/textproto"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/boundary/api"
@@ -24,8 +25,9 @@ type PasswordCommand struct {
 
 	Func string
 
-	flagLoginName string
-	flagPassword  string
+	flagLoginName          string
+	flagPassword           string
+	flagMustChangePassword string
 }
 
 func (c *PasswordCommand) Synopsis() string {
@@ -33,8 +35,8 @@ func (c *PasswordCommand) Synopsis() string {
 }
 
 var passwordFlagsMap = map[string][]string{
-	"create": {"auth-method-id", "name", "description", "login-name", "password"},
-	"update": {"id", "name", "description", "version", "login-name"},
+	"create": {"auth-method-id", "name", "description", "login-name", "password", "must-change-password"},
+	"update": {"id", "name", "description", "version", "login-name", "must-change-password"},
 }
 
 func (c *PasswordCommand) Help() string {
@@ -89,6 +91,12 @@ func (c *PasswordCommand) Flags() *base.FlagSets {
 				Target: &c.flagPassword,
 				Usage:  "The password for the account. If not specified, the command will prompt for the password to be entered in a non-echoing way.",
 			})
+		case "must-change-password":
+			f.StringVar(&base.StringVar{
+				Name:   "must-change-password",
+				Target: &c.flagMustChangePassword,
+				Usage:  "Whether the password must be changed the next time the account authenticates",
+			})
 		}
 	}
 
@@ -160,6 +168,19 @@ func (c *PasswordCommand) Run(args []string) int {
 		opts = append(opts, accounts.WithPasswordAccountLoginName(c.flagLoginName))
 	}
 
+	switch c.flagMustChangePassword {
+	case "":
+	case "null":
+		opts = append(opts, accounts.DefaultPasswordAccountMustChangePassword())
+	default:
+		mustChange, err := strconv.ParseBool(c.flagMustChangePassword)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagMustChangePassword, err))
+			return 1
+		}
+		opts = append(opts, accounts.WithPasswordAccountMustChangePassword(mustChange))
+	}
+
 	if strutil.StrListContains(passwordFlagsMap[c.Func], "password") {
 		switch c.flagPassword {
 		case "":
@@ -1,9 +1,17 @@
 package authenticate
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	nkeyring "github.com/99designs/keyring"
+	"github.com/hashicorp/boundary/api/authtokens"
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/mitchellh/cli"
 	"github.com/mitchellh/go-wordwrap"
+	zkeyring "github.com/zalando/go-keyring"
 )
 
 var _ cli.Command = (*Command)(nil)
@@ -26,6 +34,14 @@ func (c *Command) Help() string {
 		"",
 		"      $ boundary authenticate password -auth-method-id ampw_1234567890 -login-name foo -password \"bar\"",
 		"",
+		"    Authenticate with OIDC auth method:",
+		"",
+		"      $ boundary authenticate oidc -auth-method-id amoidc_1234567890",
+		"",
+		"    Authenticate with LDAP auth method:",
+		"",
+		"      $ boundary authenticate ldap -auth-method-id amldap_1234567890 -login-name foo",
+		"",
 		"  Please see the auth method subcommand help for detailed usage information.",
 	})
 }
@@ -33,3 +49,81 @@ func (c *Command) Help() string {
 func (c *Command) Run(args []string) int {
 	return cli.RunResultHelp
 }
+
+// saveAndOrPrintToken prints the token in the requested format and stores it
+// in the configured keyring.
+func saveAndOrPrintToken(c *base.Command, token *authtokens.AuthToken) int {
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(base.WrapForHelpText([]string{
+			"",
+			"Authentication information:",
+			fmt.Sprintf("  Account ID:      %s", token.AccountId),
+			fmt.Sprintf("  Auth Method ID:  %s", token.AuthMethodId),
+			fmt.Sprintf("  Expiration Time: %s", token.ExpirationTime.Local().Format(time.RFC1123)),
+			fmt.Sprintf("  Token:           %s", token.Token),
+			fmt.Sprintf("  User ID:         %s", token.UserId),
+		}))
+
+	case "json":
+		jsonOut, err := base.JsonFormatter{}.Format(token)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(jsonOut))
+	}
+
+	var gotErr bool
+	keyringType, tokenName, err := c.DiscoverKeyringTokenInfo()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error fetching keyring information: %s", err))
+		gotErr = true
+	} else if keyringType != "none" &&
+		tokenName != "none" &&
+		keyringType != "" &&
+		tokenName != "" {
+		marshaled, err := json.Marshal(token)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error marshaling auth token to save to keyring: %s", err))
+			gotErr = true
+		} else {
+			switch keyringType {
+			case "wincred", "keychain":
+				if err := zkeyring.Set("HashiCorp Boundary Auth Token", tokenName, base64.RawStdEncoding.EncodeToString(marshaled)); err != nil {
+					c.UI.Error(fmt.Sprintf("Error saving auth token to %q keyring: %s", keyringType, err))
+					gotErr = true
+				}
+
+			default:
+				krConfig := nkeyring.Config{
+					LibSecretCollectionName: "login",
+					PassPrefix:              "HashiCorp_Boundary",
+					AllowedBackends:         []nkeyring.BackendType{nkeyring.BackendType(keyringType)},
+				}
+
+				kr, err := nkeyring.Open(krConfig)
+				if err != nil {
+					c.UI.Error(fmt.Sprintf("Error opening %q keyring: %s", keyringType, err))
+					gotErr = true
+					break
+				}
+
+				if err := kr.Set(nkeyring.Item{
+					Key:  tokenName,
+					Data: []byte(base64.RawStdEncoding.EncodeToString(marshaled)),
+				}); err != nil {
+					c.UI.Error(fmt.Sprintf("Error storing token in %q keyring: %s", keyringType, err))
+					gotErr = true
+					break
+				}
+			}
+		}
+	}
+
+	if gotErr {
+		c.UI.Warn("The token printed above must be manually passed in via the BOUNDARY_TOKEN env var or -token flag. Storing the token can also be disabled via -keyring-type=none.")
+	}
+
+	return 0
+}
@@ -0,0 +1,141 @@
+package authenticate
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/authmethods"
+	"github.com/hashicorp/boundary/api/authtokens"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/vault/sdk/helper/password"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*LdapCommand)(nil)
+var _ cli.CommandAutocomplete = (*LdapCommand)(nil)
+
+var envLdapPassword = "BOUNDARY_AUTHENTICATE_LDAP_PASSWORD"
+var envLdapLoginName = "BOUNDARY_AUTHENTICATE_LDAP_LOGIN_NAME"
+
+type LdapCommand struct {
+	*base.Command
+
+	flagLoginName string
+	flagPassword  string
+}
+
+func (c *LdapCommand) Synopsis() string {
+	return wordwrap.WrapString("Invoke the LDAP auth method to authenticate with Boundary", base.TermWidth)
+}
+
+func (c *LdapCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary authenticate ldap [options] [args]",
+		"",
+		"  Invoke the LDAP auth method to authenticate the Boundary CLI with the credentials of a directory user. The login name and password are prompted for if they are not provided:",
+		"",
+		`    $ boundary authenticate ldap -auth-method-id amldap_1234567890 -login-name foo`,
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *LdapCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "login-name",
+		Target: &c.flagLoginName,
+		EnvVar: envLdapLoginName,
+		Usage:  "The login name of the user in the directory of the given auth method",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "password",
+		Target: &c.flagPassword,
+		EnvVar: envLdapPassword,
+		Usage:  "The directory password of the user",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "auth-method-id",
+		EnvVar: "BOUNDARY_AUTH_METHOD_ID",
+		Target: &c.FlagAuthMethodId,
+		Usage:  "The auth-method resource to use for the operation",
+	})
+
+	return set
+}
+
+func (c *LdapCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *LdapCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *LdapCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if c.FlagAuthMethodId == "" {
+		c.UI.Error("Auth method ID must be provided via -auth-method-id")
+		return 1
+	}
+
+	if c.flagLoginName == "" {
+		value, err := c.UI.Ask("Login name is not set as flag or in env, please enter it now:")
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("An error occurred attempting to read the login name: %s", err.Error()))
+			return 2
+		}
+		c.flagLoginName = strings.TrimSpace(value)
+		if c.flagLoginName == "" {
+			c.UI.Error("Login name must be provided")
+			return 1
+		}
+	}
+
+	if c.flagPassword == "" {
+		fmt.Print("Password is not set as flag or in env, please enter it now (will be hidden): ")
+		value, err := password.Read(os.Stdin)
+		fmt.Print("\n")
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("An error occurred attempting to read the password. The raw error message is shown below but usually this is because you attempted to pipe a value into the command or you are executing outside of a terminal (TTY). The raw error was:\n\n%s", err.Error()))
+			return 2
+		}
+		c.flagPassword = strings.TrimSpace(value)
+	}
+
+	client, err := c.Client(base.WithNoTokenScope(), base.WithNoTokenValue())
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+
+	result, err := authmethods.NewClient(client).Authenticate(c.Context, c.FlagAuthMethodId,
+		map[string]interface{}{
+			"login_name": c.flagLoginName,
+			"password":   c.flagPassword,
+		})
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.UI.Error(fmt.Sprintf("Error from controller when performing authentication: %s", base.PrintApiError(apiErr)))
+			return 1
+		}
+		c.UI.Error(fmt.Sprintf("Error trying to perform authentication: %s", err.Error()))
+		return 2
+	}
+
+	return saveAndOrPrintToken(c.Command, result.GetItem().(*authtokens.AuthToken))
+}
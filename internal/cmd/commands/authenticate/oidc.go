@@ -0,0 +1,159 @@
+package authenticate
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/authmethods"
+	"github.com/hashicorp/boundary/api/authtokens"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*OidcCommand)(nil)
+var _ cli.CommandAutocomplete = (*OidcCommand)(nil)
+
+const (
+	// oidcPollInterval is how often the token endpoint is polled while
+	// waiting for the user to authenticate with the provider.
+	oidcPollInterval = 2 * time.Second
+	// oidcPollTimeout matches the lifetime of a token request on the
+	// controller.
+	oidcPollTimeout = 5 * time.Minute
+)
+
+type OidcCommand struct {
+	*base.Command
+
+	flagNoBrowser bool
+}
+
+func (c *OidcCommand) Synopsis() string {
+	return wordwrap.WrapString("Invoke the OIDC auth method to authenticate with Boundary", base.TermWidth)
+}
+
+func (c *OidcCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary authenticate oidc [options] [args]",
+		"",
+		"  Invoke the OIDC auth method to authenticate the Boundary CLI. A browser is opened to the provider's login page and the command waits until authentication completes:",
+		"",
+		`    $ boundary authenticate oidc -auth-method-id amoidc_1234567890`,
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *OidcCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "auth-method-id",
+		EnvVar: "BOUNDARY_AUTH_METHOD_ID",
+		Target: &c.FlagAuthMethodId,
+		Usage:  "The auth-method resource to use for the operation",
+	})
+
+	f.BoolVar(&base.BoolVar{
+		Name:   "no-browser",
+		Target: &c.flagNoBrowser,
+		Usage:  "If set, the login URL is printed but a browser is not opened",
+	})
+
+	return set
+}
+
+func (c *OidcCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *OidcCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *OidcCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if c.FlagAuthMethodId == "" {
+		c.UI.Error("Auth method ID must be provided via -auth-method-id")
+		return 1
+	}
+
+	client, err := c.Client(base.WithNoTokenScope(), base.WithNoTokenValue())
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+	amClient := authmethods.NewClient(client)
+
+	start, err := amClient.AuthenticateStart(c.Context, c.FlagAuthMethodId)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.UI.Error(fmt.Sprintf("Error from controller when starting authentication: %s", base.PrintApiError(apiErr)))
+			return 1
+		}
+		c.UI.Error(fmt.Sprintf("Error trying to start authentication: %s", err.Error()))
+		return 2
+	}
+
+	c.UI.Info(fmt.Sprintf("Complete the authentication in your browser. If a browser does not open, visit the following URL:\n\n  %s\n", start.AuthUrl))
+	if !c.flagNoBrowser {
+		if err := openURL(start.AuthUrl); err != nil {
+			c.UI.Warn(fmt.Sprintf("Unable to open a browser: %s", err))
+		}
+	}
+
+	ticker := time.NewTicker(oidcPollInterval)
+	defer ticker.Stop()
+	timeout := time.After(oidcPollTimeout)
+	for {
+		select {
+		case <-c.Context.Done():
+			c.UI.Error("Authentication canceled")
+			return 1
+		case <-timeout:
+			c.UI.Error("Timed out waiting for authentication to complete")
+			return 1
+		case <-ticker.C:
+		}
+
+		result, err := amClient.AuthenticateToken(c.Context, c.FlagAuthMethodId, start.TokenId)
+		if err != nil {
+			if apiErr := api.AsServerError(err); apiErr != nil {
+				c.UI.Error(fmt.Sprintf("Error from controller when performing authentication: %s", base.PrintApiError(apiErr)))
+				return 1
+			}
+			c.UI.Error(fmt.Sprintf("Error trying to perform authentication: %s", err.Error()))
+			return 2
+		}
+		if result.Item == nil {
+			continue
+		}
+		return saveAndOrPrintToken(c.Command, result.GetItem().(*authtokens.AuthToken))
+	}
+}
+
+// openURL opens u in the user's default browser.
+func openURL(u string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", u)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", u)
+	default:
+		cmd = exec.Command("xdg-open", u)
+	}
+	return cmd.Start()
+}
@@ -1,14 +1,10 @@
 package authenticate
 
 import (
-	"encoding/base64"
-	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
-	"time"
 
-	nkeyring "github.com/99designs/keyring"
 	"github.com/hashicorp/boundary/api"
 	"github.com/hashicorp/boundary/api/authmethods"
 	"github.com/hashicorp/boundary/api/authtokens"
@@ -17,7 +13,6 @@ import (
 	"github.com/mitchellh/cli"
 	"github.com/mitchellh/go-wordwrap"
 	"github.com/posener/complete"
-	zkeyring "github.com/zalando/go-keyring"
 )
 
 var _ cli.Command = (*PasswordCommand)(nil)
@@ -123,11 +118,23 @@ func (c *PasswordCommand) Run(args []string) int {
 	// note: Authenticate() calls SetToken() under the hood to set the
 	// auth bearer on the client so we do not need to do anything with the
 	// returned token after this call, so we ignore it
-	result, err := authmethods.NewClient(client).Authenticate(c.Context, c.FlagAuthMethodId,
-		map[string]interface{}{
-			"login_name": c.flagLoginName,
-			"password":   c.flagPassword,
-		})
+	credentials := map[string]interface{}{
+		"login_name": c.flagLoginName,
+		"password":   c.flagPassword,
+	}
+	result, err := authmethods.NewClient(client).Authenticate(c.Context, c.FlagAuthMethodId, credentials)
+	if apiErr := api.AsServerError(err); apiErr != nil && apiErr.Code == "FailedPrecondition" {
+		// The account's password must be changed before it can authenticate.
+		fmt.Print("The password must be changed, please enter a new password now (will be hidden): ")
+		value, readErr := password.Read(os.Stdin)
+		fmt.Print("\n")
+		if readErr != nil {
+			c.UI.Error(fmt.Sprintf("An error occurred attempting to read the password. The raw error message is shown below but usually this is because you attempted to pipe a value into the command or you are executing outside of a terminal (TTY). The raw error was:\n\n%s", readErr.Error()))
+			return 2
+		}
+		credentials["new_password"] = strings.TrimSpace(value)
+		result, err = authmethods.NewClient(client).Authenticate(c.Context, c.FlagAuthMethodId, credentials)
+	}
 	if err != nil {
 		if apiErr := api.AsServerError(err); apiErr != nil {
 			c.UI.Error(fmt.Sprintf("Error from controller when performing authentication: %s", base.PrintApiError(apiErr)))
@@ -137,78 +144,5 @@ func (c *PasswordCommand) Run(args []string) int {
 		return 2
 	}
 
-	token := result.GetItem().(*authtokens.AuthToken)
-	switch base.Format(c.UI) {
-	case "table":
-		c.UI.Output(base.WrapForHelpText([]string{
-			"",
-			"Authentication information:",
-			fmt.Sprintf("  Account ID:      %s", token.AccountId),
-			fmt.Sprintf("  Auth Method ID:  %s", token.AuthMethodId),
-			fmt.Sprintf("  Expiration Time: %s", token.ExpirationTime.Local().Format(time.RFC1123)),
-			fmt.Sprintf("  Token:           %s", token.Token),
-			fmt.Sprintf("  User ID:         %s", token.UserId),
-		}))
-
-	case "json":
-		jsonOut, err := base.JsonFormatter{}.Format(token)
-		if err != nil {
-			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
-			return 1
-		}
-		c.UI.Output(string(jsonOut))
-	}
-
-	var gotErr bool
-	keyringType, tokenName, err := c.DiscoverKeyringTokenInfo()
-	if err != nil {
-		c.UI.Error(fmt.Sprintf("Error fetching keyring information: %s", err))
-		gotErr = true
-	} else if keyringType != "none" &&
-		tokenName != "none" &&
-		keyringType != "" &&
-		tokenName != "" {
-		marshaled, err := json.Marshal(token)
-		if err != nil {
-			c.UI.Error(fmt.Sprintf("Error marshaling auth token to save to keyring: %s", err))
-			gotErr = true
-		} else {
-			switch keyringType {
-			case "wincred", "keychain":
-				if err := zkeyring.Set("HashiCorp Boundary Auth Token", tokenName, base64.RawStdEncoding.EncodeToString(marshaled)); err != nil {
-					c.UI.Error(fmt.Sprintf("Error saving auth token to %q keyring: %s", keyringType, err))
-					gotErr = true
-				}
-
-			default:
-				krConfig := nkeyring.Config{
-					LibSecretCollectionName: "login",
-					PassPrefix:              "HashiCorp_Boundary",
-					AllowedBackends:         []nkeyring.BackendType{nkeyring.BackendType(keyringType)},
-				}
-
-				kr, err := nkeyring.Open(krConfig)
-				if err != nil {
-					c.UI.Error(fmt.Sprintf("Error opening %q keyring: %s", keyringType, err))
-					gotErr = true
-					break
-				}
-
-				if err := kr.Set(nkeyring.Item{
-					Key:  tokenName,
-					Data: []byte(base64.RawStdEncoding.EncodeToString(marshaled)),
-				}); err != nil {
-					c.UI.Error(fmt.Sprintf("Error storing token in %q keyring: %s", keyringType, err))
-					gotErr = true
-					break
-				}
-			}
-		}
-	}
-
-	if gotErr {
-		c.UI.Warn("The token printed above must be manually passed in via the BOUNDARY_TOKEN env var or -token flag. Storing the token can also be disabled via -keyring-type=none.")
-	}
-
-	return 0
+	return saveAndOrPrintToken(c.Command, result.GetItem().(*authtokens.AuthToken))
 }
@@ -30,7 +30,7 @@ func (c *Command) Synopsis() string {
 var flagsMap = map[string][]string{
 	"read":   {"id"},
 	"delete": {"id"},
-	"list":   {"scope-id"},
+	"list":   {"scope-id", "recursive", "filter", "page-size", "page-token"},
 }
 
 func (c *Command) Help() string {
@@ -145,6 +145,21 @@ func (c *Command) Run(args []string) int {
 		opts = append(opts, authmethods.WithDescription(c.FlagDescription))
 	}
 
+	if c.Func == "list" {
+		if c.FlagFilter != "" {
+			opts = append(opts, authmethods.WithFilter(c.FlagFilter))
+		}
+		if c.FlagRecursive {
+			opts = append(opts, authmethods.WithRecursive(true))
+		}
+		if c.FlagPageSize > 0 {
+			opts = append(opts, authmethods.WithPageSize(uint32(c.FlagPageSize)))
+		}
+		if c.FlagPageToken != "" {
+			opts = append(opts, authmethods.WithPageToken(c.FlagPageToken))
+		}
+	}
+
 	authmethodClient := authmethods.NewClient(client)
 
 	existed := true
@@ -228,6 +243,11 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("  ID:             %s", m.Id),
 					)
 				}
+				if c.FlagRecursive {
+					output = append(output,
+						fmt.Sprintf("    Scope ID:     %s", m.ScopeId),
+					)
+				}
 				if m.Description != "" {
 					output = append(output,
 						fmt.Sprintf("    Description:  %s", m.Description),
@@ -244,9 +264,16 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("    Version:      %d", m.Version),
 					)
 				}
+				if len(m.AuthorizedActions) > 0 {
+					output = append(output,
+						"    Authorized Actions:",
+						base.WrapSlice(6, m.AuthorizedActions),
+					)
+				}
 			}
 			c.UI.Output(base.WrapForHelpText(output))
 		}
+		base.PrintNextPageToken(c.UI, listResult.GetNextPageToken())
 		return 0
 	}
 
@@ -18,6 +18,51 @@ func addPasswordFlags(c *PasswordCommand, f *base.FlagSet) {
 		Target: &c.flagMinPasswordLength,
 		Usage:  "The minimum length of passwords",
 	})
+	f.StringVar(&base.StringVar{
+		Name:   "require-uppercase",
+		Target: &c.flagRequireUppercase,
+		Usage:  "Whether passwords must contain an upper case letter",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "require-lowercase",
+		Target: &c.flagRequireLowercase,
+		Usage:  "Whether passwords must contain a lower case letter",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "require-digit",
+		Target: &c.flagRequireDigit,
+		Usage:  "Whether passwords must contain a digit",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "require-symbol",
+		Target: &c.flagRequireSymbol,
+		Usage:  "Whether passwords must contain a character which is not a letter, digit or space",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "password-history-count",
+		Target: &c.flagPasswordHistoryCount,
+		Usage:  "The number of most recent passwords, including the current one, which a new password must not equal",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "max-password-age-seconds",
+		Target: &c.flagMaxPasswordAgeSeconds,
+		Usage:  "The age in seconds after which a password must be changed the next time the account authenticates",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "lockout-threshold",
+		Target: &c.flagLockoutThreshold,
+		Usage:  "The number of failed authentication attempts within the lockout window after which an account is locked",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "lockout-window-seconds",
+		Target: &c.flagLockoutWindowSeconds,
+		Usage:  "The window in seconds in which failed authentication attempts are counted",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "lockout-duration-seconds",
+		Target: &c.flagLockoutDurationSeconds,
+		Usage:  "How long in seconds an account stays locked. Zero keeps it locked until it is unlocked.",
+	})
 }
 
 func generateAuthMethodTableOutput(in *authmethods.AuthMethod) string {
@@ -47,6 +92,14 @@ func generateAuthMethodTableOutput(in *authmethods.AuthMethod) string {
 		base.ScopeInfoForOutput(in.Scope, maxLength),
 	}
 
+	if len(in.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, in.AuthorizedActions),
+		)
+	}
+
 	if len(in.Attributes) > 0 {
 		ret = append(ret,
 			"",
@@ -59,6 +112,15 @@ func generateAuthMethodTableOutput(in *authmethods.AuthMethod) string {
 }
 
 var keySubstMap = map[string]string{
-	"min_login_name_length": "Minimum Login Name Length",
-	"min_password_length":   "Minimum Password Length",
+	"min_login_name_length":    "Minimum Login Name Length",
+	"min_password_length":      "Minimum Password Length",
+	"require_uppercase":        "Require Uppercase",
+	"require_lowercase":        "Require Lowercase",
+	"require_digit":            "Require Digit",
+	"require_symbol":           "Require Symbol",
+	"password_history_count":   "Password History Count",
+	"max_password_age_seconds": "Max Password Age Seconds",
+	"lockout_threshold":        "Lockout Threshold",
+	"lockout_window_seconds":   "Lockout Window Seconds",
+	"lockout_duration_seconds": "Lockout Duration Seconds",
 }
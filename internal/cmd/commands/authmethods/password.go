@@ -24,6 +24,16 @@ type PasswordCommand struct {
 
 	flagMinLoginNameLength string
 	flagMinPasswordLength  string
+
+	flagRequireUppercase       string
+	flagRequireLowercase       string
+	flagRequireDigit           string
+	flagRequireSymbol          string
+	flagPasswordHistoryCount   string
+	flagMaxPasswordAgeSeconds  string
+	flagLockoutThreshold       string
+	flagLockoutWindowSeconds   string
+	flagLockoutDurationSeconds string
 }
 
 func (c *PasswordCommand) Synopsis() string {
@@ -161,6 +171,51 @@ func (c *PasswordCommand) Run(args []string) int {
 		addAttribute("min_password_length", uint32(length))
 	}
 
+	for _, b := range []struct {
+		name, value string
+	}{
+		{"require_uppercase", c.flagRequireUppercase},
+		{"require_lowercase", c.flagRequireLowercase},
+		{"require_digit", c.flagRequireDigit},
+		{"require_symbol", c.flagRequireSymbol},
+	} {
+		switch b.value {
+		case "":
+		case "null":
+			addAttribute(b.name, nil)
+		default:
+			v, err := strconv.ParseBool(b.value)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", b.value, err))
+				return 1
+			}
+			addAttribute(b.name, v)
+		}
+	}
+
+	for _, n := range []struct {
+		name, value string
+	}{
+		{"password_history_count", c.flagPasswordHistoryCount},
+		{"max_password_age_seconds", c.flagMaxPasswordAgeSeconds},
+		{"lockout_threshold", c.flagLockoutThreshold},
+		{"lockout_window_seconds", c.flagLockoutWindowSeconds},
+		{"lockout_duration_seconds", c.flagLockoutDurationSeconds},
+	} {
+		switch n.value {
+		case "":
+		case "null":
+			addAttribute(n.name, nil)
+		default:
+			v, err := strconv.ParseUint(n.value, 10, 32)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", n.value, err))
+				return 1
+			}
+			addAttribute(n.name, uint32(v))
+		}
+	}
+
 	if attributes != nil {
 		opts = append(opts, authmethods.WithAttributes(attributes))
 	}
@@ -31,7 +31,7 @@ func (c *Command) Synopsis() string {
 var flagsMap = map[string][]string{
 	"read":   {"id"},
 	"delete": {"id"},
-	"list":   {"scope-id"},
+	"list":   {"scope-id", "recursive", "filter", "page-size", "page-token"},
 }
 
 func (c *Command) Help() string {
@@ -88,6 +88,22 @@ func (c *Command) Run(args []string) int {
 		return 2
 	}
 
+	var opts []authtokens.Option
+	if c.Func == "list" {
+		if c.FlagFilter != "" {
+			opts = append(opts, authtokens.WithFilter(c.FlagFilter))
+		}
+		if c.FlagRecursive {
+			opts = append(opts, authtokens.WithRecursive(true))
+		}
+		if c.FlagPageSize > 0 {
+			opts = append(opts, authtokens.WithPageSize(uint32(c.FlagPageSize)))
+		}
+		if c.FlagPageToken != "" {
+			opts = append(opts, authtokens.WithPageToken(c.FlagPageToken))
+		}
+	}
+
 	authtokenClient := authtokens.NewClient(client)
 
 	existed := true
@@ -104,7 +120,7 @@ func (c *Command) Run(args []string) int {
 			err = nil
 		}
 	case "list":
-		listResult, err = authtokenClient.List(c.Context, c.FlagScopeId)
+		listResult, err = authtokenClient.List(c.Context, c.FlagScopeId, opts...)
 	}
 
 	plural := "auth token"
@@ -175,9 +191,21 @@ func (c *Command) Run(args []string) int {
 					fmt.Sprintf("    Updated Time:                %s", t.UpdatedTime.Local().Format(time.RFC1123)),
 					fmt.Sprintf("    User ID:                     %s", t.UserId),
 				)
+				if c.FlagRecursive {
+					output = append(output,
+						fmt.Sprintf("    Scope ID:                    %s", t.ScopeId),
+					)
+				}
+				if len(t.AuthorizedActions) > 0 {
+					output = append(output,
+						"    Authorized Actions:",
+						base.WrapSlice(6, t.AuthorizedActions),
+					)
+				}
 			}
 			c.UI.Output(base.WrapForHelpText(output))
 		}
+		base.PrintNextPageToken(c.UI, listResult.GetNextPageToken())
 		return 0
 	}
 
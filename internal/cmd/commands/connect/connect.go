@@ -38,12 +38,13 @@ import (
 )
 
 type SessionInfo struct {
-	Address         string    `json:"address"`
-	Port            int       `json:"port"`
-	Protocol        string    `json:"protocol"`
-	Expiration      time.Time `json:"expiration"`
-	ConnectionLimit int32     `json:"connection_limit"`
-	SessionId       string    `json:"session_id"`
+	Address         string                       `json:"address"`
+	Port            int                          `json:"port"`
+	Protocol        string                       `json:"protocol"`
+	Expiration      time.Time                    `json:"expiration"`
+	ConnectionLimit int32                        `json:"connection_limit"`
+	SessionId       string                       `json:"session_id"`
+	Credentials     []*targets.SessionCredential `json:"credentials,omitempty"`
 }
 
 type ConnectionInfo struct {
@@ -69,6 +70,8 @@ type Command struct {
 	flagExec       string
 	flagUsername   string
 
+	flagUdpIdleTimeout time.Duration
+
 	// HTTP
 	httpFlags
 
@@ -88,6 +91,7 @@ type Command struct {
 	connWg             *sync.WaitGroup
 	listenerCloseOnce  sync.Once
 	listener           *net.TCPListener
+	udpListener        *net.UDPConn
 	listenerAddr       *net.TCPAddr
 	connsLeftCh        chan int32
 	connectionsLeft    atomic.Int32
@@ -216,6 +220,15 @@ func (c *Command) Flags() *base.FlagSets {
 			Usage:      `If set, the CLI will attempt to bind its listening port to the given value. If it cannot, the command will error.`,
 		})
 
+		f.DurationVar(&base.DurationVar{
+			Name:       "udp-idle-timeout",
+			Target:     &c.flagUdpIdleTimeout,
+			Default:    defaultUdpIdleTimeout,
+			EnvVar:     "BOUNDARY_CONNECT_UDP_IDLE_TIMEOUT",
+			Completion: complete.PredictAnything,
+			Usage:      `For udp targets, how long a client of the local listener may send and receive no datagrams before its connection to the worker is closed. The next datagram from the client opens a new connection.`,
+		})
+
 	case "http":
 		httpOptions(c, set)
 
@@ -440,10 +453,20 @@ func (c *Command) Run(args []string) (retCode int) {
 	// hijacked, just setting for completeness
 	transport.IdleConnTimeout = 0
 
-	c.listener, err = net.ListenTCP("tcp", &net.TCPAddr{
-		IP:   listenAddr,
-		Port: c.flagListenPort,
-	})
+	protocol := "tcp"
+	switch c.sessionAuthzData.GetType() {
+	case "udp":
+		protocol = "udp"
+		c.udpListener, err = net.ListenUDP("udp", &net.UDPAddr{
+			IP:   listenAddr,
+			Port: c.flagListenPort,
+		})
+	default:
+		c.listener, err = net.ListenTCP("tcp", &net.TCPAddr{
+			IP:   listenAddr,
+			Port: c.flagListenPort,
+		})
+	}
 	if err != nil {
 		c.UI.Error(fmt.Errorf("Error starting listening port: %w", err).Error())
 		return 1
@@ -452,7 +475,13 @@ func (c *Command) Run(args []string) (retCode int) {
 	listenerCloseFunc := func() {
 		// Forces the for loop to exist instead of spinning on errors
 		c.connectionsLeft.Store(0)
-		if err := c.listener.Close(); err != nil {
+		var err error
+		if c.udpListener != nil {
+			err = c.udpListener.Close()
+		} else {
+			err = c.listener.Close()
+		}
+		if err != nil {
 			c.UI.Error(fmt.Errorf("Error closing listener on shutdown: %w", err).Error())
 			retCode = 1
 		}
@@ -463,17 +492,33 @@ func (c *Command) Run(args []string) (retCode int) {
 		c.listenerCloseOnce.Do(listenerCloseFunc)
 	}()
 
-	c.listenerAddr = c.listener.Addr().(*net.TCPAddr)
+	if c.udpListener != nil {
+		// Only the IP and port of the listener address are used
+		udpAddr := c.udpListener.LocalAddr().(*net.UDPAddr)
+		c.listenerAddr = &net.TCPAddr{IP: udpAddr.IP, Port: udpAddr.Port}
+	} else {
+		c.listenerAddr = c.listener.Addr().(*net.TCPAddr)
+	}
 
 	if c.flagExec == "" {
 		sessInfo := SessionInfo{
-			Protocol:        "tcp",
+			Protocol:        protocol,
 			Address:         c.listenerAddr.IP.String(),
 			Port:            c.listenerAddr.Port,
 			Expiration:      c.expiration,
 			ConnectionLimit: c.sessionAuthzData.GetConnectionLimit(),
 			SessionId:       c.sessionAuthzData.GetSessionId(),
 		}
+		for _, cred := range c.sessionAuthzData.GetCredentials() {
+			sessInfo.Credentials = append(sessInfo.Credentials, &targets.SessionCredential{
+				CredentialId:      cred.GetCredentialId(),
+				CredentialStoreId: cred.GetCredentialStoreId(),
+				Name:              cred.GetName(),
+				Type:              cred.GetType(),
+				Username:          cred.GetUsername(),
+				Password:          cred.GetPassword(),
+			})
+		}
 
 		switch base.Format(c.UI) {
 		case "table":
@@ -491,39 +536,43 @@ func (c *Command) Run(args []string) (retCode int) {
 	c.connWg = new(sync.WaitGroup)
 
 	c.connWg.Add(1)
-	go func() {
-		defer c.connWg.Done()
-		for {
-			listeningConn, err := c.listener.AcceptTCP()
-			if err != nil {
-				select {
-				case <-c.proxyCtx.Done():
-					return
-				case <-c.Context.Done():
-					return
-				default:
-					// When this hits zero we trigger listener close so this
-					// isn't actually an error condition
-					if c.connectionsLeft.Load() == 0 {
+	if c.udpListener != nil {
+		go c.serveUdp(workerAddr, tofuToken, transport)
+	} else {
+		go func() {
+			defer c.connWg.Done()
+			for {
+				listeningConn, err := c.listener.AcceptTCP()
+				if err != nil {
+					select {
+					case <-c.proxyCtx.Done():
 						return
+					case <-c.Context.Done():
+						return
+					default:
+						// When this hits zero we trigger listener close so this
+						// isn't actually an error condition
+						if c.connectionsLeft.Load() == 0 {
+							return
+						}
+						c.UI.Error(fmt.Errorf("Error accepting connection: %w", err).Error())
+						continue
 					}
-					c.UI.Error(fmt.Errorf("Error accepting connection: %w", err).Error())
-					continue
 				}
+				c.connWg.Add(1)
+				go func() {
+					defer listeningConn.Close()
+					if err := c.handleConnection(
+						listeningConn,
+						workerAddr,
+						tofuToken,
+						transport); err != nil {
+						c.UI.Error(err.Error())
+					}
+				}()
 			}
-			c.connWg.Add(1)
-			go func() {
-				defer listeningConn.Close()
-				if err := c.handleConnection(
-					listeningConn,
-					workerAddr,
-					tofuToken,
-					transport); err != nil {
-					c.UI.Error(err.Error())
-				}
-			}()
-		}
-	}()
+		}()
+	}
 
 	timer := time.NewTimer(time.Until(c.expiration))
 	c.connWg.Add(1)
@@ -604,58 +653,95 @@ func (c *Command) handleConnection(
 
 	defer c.connWg.Done()
 
+	conn, err := c.dialWorker(c.proxyCtx, globals.TcpProxyV1, workerAddr, tofuToken, transport)
+	if err != nil {
+		return err
+	}
+
+	// Get a wrapped net.Conn so we can use io.Copy
+	netConn := websocket.NetConn(c.proxyCtx, conn, websocket.MessageBinary)
+
+	localWg := new(sync.WaitGroup)
+	localWg.Add(2)
+
+	go func() {
+		defer localWg.Done()
+		io.Copy(netConn, listeningConn)
+		netConn.Close()
+		listeningConn.Close()
+	}()
+	go func() {
+		defer localWg.Done()
+		io.Copy(listeningConn, netConn)
+		listeningConn.Close()
+		netConn.Close()
+	}()
+	localWg.Wait()
+
+	return nil
+}
+
+// dialWorker opens a connection to the worker speaking the given proxy
+// subprotocol and performs the proxy handshake.
+func (c *Command) dialWorker(
+	ctx context.Context,
+	subprotocol string,
+	workerAddr string,
+	tofuToken string,
+	transport *http.Transport) (*websocket.Conn, error) {
+
 	conn, resp, err := websocket.Dial(
-		c.proxyCtx,
+		ctx,
 		fmt.Sprintf("wss://%s/v1/proxy", workerAddr),
 		&websocket.DialOptions{
 			HTTPClient: &http.Client{
 				Transport: transport,
 			},
-			Subprotocols: []string{globals.TcpProxyV1},
+			Subprotocols: []string{subprotocol},
 		},
 	)
 	if err != nil {
 		switch {
 		case strings.Contains(err.Error(), "tls: internal error"):
-			return errors.New("Session is unauthorized")
+			return nil, errors.New("Session is unauthorized")
 		case strings.Contains(err.Error(), "connect: connection refused"):
-			return fmt.Errorf("Unable to connect to worker at %s", workerAddr)
+			return nil, fmt.Errorf("Unable to connect to worker at %s", workerAddr)
 		default:
-			return fmt.Errorf("Error dialing the worker: %w", err)
+			return nil, fmt.Errorf("Error dialing the worker: %w", err)
 		}
 	}
 
 	if resp == nil {
-		return errors.New("Response from worker is nil")
+		return nil, errors.New("Response from worker is nil")
 	}
 	if resp.Header == nil {
-		return errors.New("Response header is nil")
+		return nil, errors.New("Response header is nil")
 	}
 	negProto := resp.Header.Get("Sec-WebSocket-Protocol")
-	if negProto != globals.TcpProxyV1 {
-		return fmt.Errorf("Unexpected negotiated protocol: %s", negProto)
+	if negProto != subprotocol {
+		return nil, fmt.Errorf("Unexpected negotiated protocol: %s", negProto)
 	}
 
 	handshake := proxy.ClientHandshake{TofuToken: tofuToken}
-	if err := wspb.Write(c.proxyCtx, conn, &handshake); err != nil {
-		return fmt.Errorf("error sending handshake to worker: %w", err)
+	if err := wspb.Write(ctx, conn, &handshake); err != nil {
+		return nil, fmt.Errorf("error sending handshake to worker: %w", err)
 	}
 	var handshakeResult proxy.HandshakeResult
-	if err := wspb.Read(c.proxyCtx, conn, &handshakeResult); err != nil {
+	if err := wspb.Read(ctx, conn, &handshakeResult); err != nil {
 		switch {
 		case strings.Contains(err.Error(), "unable to authorize connection"):
 			// There's no reason to think we'd be able to authorize any more
 			// connections after the first has failed
 			c.connsLeftCh <- 0
-			return errors.New("Unable to authorize connection")
+			return nil, errors.New("Unable to authorize connection")
 		}
 		switch {
 		case strings.Contains(err.Error(), "tofu token not allowed"):
 			// Nothing will be able to be done here, so cancel the context too
 			c.proxyCancel()
-			return errors.New("Session is already in use")
+			return nil, errors.New("Session is already in use")
 		default:
-			return fmt.Errorf("error reading handshake result: %w", err)
+			return nil, fmt.Errorf("error reading handshake result: %w", err)
 		}
 	}
 
@@ -663,27 +749,7 @@ func (c *Command) handleConnection(
 		c.connsLeftCh <- handshakeResult.GetConnectionsLeft()
 	}
 
-	// Get a wrapped net.Conn so we can use io.Copy
-	netConn := websocket.NetConn(c.proxyCtx, conn, websocket.MessageBinary)
-
-	localWg := new(sync.WaitGroup)
-	localWg.Add(2)
-
-	go func() {
-		defer localWg.Done()
-		io.Copy(netConn, listeningConn)
-		netConn.Close()
-		listeningConn.Close()
-	}()
-	go func() {
-		defer localWg.Done()
-		io.Copy(listeningConn, netConn)
-		listeningConn.Close()
-		netConn.Close()
-	}()
-	localWg.Wait()
-
-	return nil
+	return conn, nil
 }
 
 func (c *Command) updateConnsLeft(connsLeft int32) {
@@ -715,7 +781,7 @@ func (c *Command) handleExec(passthroughArgs []string) {
 	ip := c.listenerAddr.IP.String()
 	addr := c.listenerAddr.String()
 
-	var args []string
+	var args, envs []string
 
 	switch c.Func {
 	case "http":
@@ -723,14 +789,18 @@ func (c *Command) handleExec(passthroughArgs []string) {
 
 	case "postgres":
 		args = append(args, c.postgresFlags.buildArgs(c, port, ip, addr)...)
+		envs = append(envs, c.postgresFlags.buildEnvs(c)...)
 
 	case "rdp":
 		args = append(args, c.rdpFlags.buildArgs(c, port, ip, addr)...)
 
 	case "ssh":
 		args = append(args, c.sshFlags.buildArgs(c, port, ip, addr)...)
+		defer c.sshFlags.cleanup()
 	}
 
+	execPath := c.flagExec
+
 	args = append(passthroughArgs, args...)
 
 	stringReplacer := func(in, typ, replacer string) string {
@@ -751,15 +821,22 @@ func (c *Command) handleExec(passthroughArgs []string) {
 		args[i] = stringReplacer(args[i], "addr", addr)
 	}
 
+	if c.Func == "ssh" {
+		var sshEnvs []string
+		execPath, args, sshEnvs = c.sshFlags.wrapExec(c, execPath, args)
+		envs = append(envs, sshEnvs...)
+	}
+
 	// NOTE: exec.CommandContext is a hard kill, so if used it leaves the
 	// terminal in a weird state. It suffices to simply close the connection,
 	// which already happens, so we don't need/want CommandContext here.
-	cmd := exec.Command(c.flagExec, args...)
+	cmd := exec.Command(execPath, args...)
 	cmd.Env = append(os.Environ(),
 		fmt.Sprintf("BOUNDARY_PROXIED_PORT=%s", port),
 		fmt.Sprintf("BOUNDARY_PROXIED_IP=%s", ip),
 		fmt.Sprintf("BOUNDARY_PROXIED_ADDR=%s", addr),
 	)
+	cmd.Env = append(cmd.Env, envs...)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -784,3 +861,23 @@ func (c *Command) handleExec(passthroughArgs []string) {
 	}
 	c.execCmdReturnValue.Store(0)
 }
+
+// usernamePasswordCredential returns the first username and password
+// credential brokered for the session, or nil if there is none.
+func (c *Command) usernamePasswordCredential() *targetspb.SessionCredential {
+	for _, cred := range c.sessionAuthzData.GetCredentials() {
+		if cred.GetType() == "username_password" {
+			return cred
+		}
+	}
+	return nil
+}
+
+// username returns the username to pass to the client. A username given on
+// the command line takes precedence over a brokered credential.
+func (c *Command) username() string {
+	if c.flagUsername != "" {
+		return c.flagUsername
+	}
+	return c.usernamePasswordCredential().GetUsername()
+}
@@ -24,6 +24,28 @@ func generateSessionInfoTableOutput(in SessionInfo) string {
 		base.WrapMap(2, maxLength+2, nonAttributeMap),
 	}
 
+	if len(in.Credentials) > 0 {
+		ret = append(ret,
+			"",
+			"  Credentials:",
+		)
+		for i, cred := range in.Credentials {
+			if i > 0 {
+				ret = append(ret, "")
+			}
+			m := map[string]interface{}{
+				"Credential ID": cred.CredentialId,
+				"Type":          cred.Type,
+				"Username":      cred.Username,
+				"Password":      cred.Password,
+			}
+			if cred.Name != "" {
+				m["Name"] = cred.Name
+			}
+			ret = append(ret, base.WrapMap(4, maxLength, m))
+		}
+	}
+
 	return base.WrapForHelpText(ret)
 }
 
@@ -1,6 +1,7 @@
 package connect
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/hashicorp/boundary/internal/cmd/base"
@@ -45,9 +46,24 @@ func (p *postgresFlags) buildArgs(c *Command, port, ip, addr string) []string {
 	switch p.flagPostgresStyle {
 	case "psql":
 		args = append(args, "-p", port, "-h", ip)
-		if c.flagUsername != "" {
-			args = append(args, "-U", c.flagUsername)
+		if username := c.username(); username != "" {
+			args = append(args, "-U", username)
 		}
 	}
 	return args
 }
+
+// buildEnvs passes the password of a brokered credential to the client. It is
+// not used when a username is given on the command line since the password
+// belongs to the credential's user.
+func (p *postgresFlags) buildEnvs(c *Command) []string {
+	cred := c.usernamePasswordCredential()
+	if cred.GetPassword() == "" || c.flagUsername != "" {
+		return nil
+	}
+	switch p.flagPostgresStyle {
+	case "psql":
+		return []string{fmt.Sprintf("PGPASSWORD=%s", cred.GetPassword())}
+	}
+	return nil
+}
@@ -1,11 +1,16 @@
 package connect
 
 import (
+	"crypto/ed25519"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
 	"strings"
 
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/posener/complete"
+	"golang.org/x/crypto/ssh"
 )
 
 const (
@@ -35,6 +40,10 @@ func sshOptions(c *Command, set *base.FlagSets) {
 
 type sshFlags struct {
 	flagSshStyle string
+
+	// knownHostsPath is a temporary known hosts file holding the host key of
+	// the worker for sessions to ssh targets
+	knownHostsPath string
 }
 
 func (s *sshFlags) defaultExec() string {
@@ -48,11 +57,68 @@ func (s *sshFlags) buildArgs(c *Command, port, ip, addr string) []string {
 	case "ssh":
 		args = append(args, "-p", port, ip)
 		args = append(args, "-o", fmt.Sprintf("HostKeyAlias=%s", c.sessionAuthzData.HostId))
+		if c.sessionAuthzData.GetType() == "ssh" {
+			// The worker terminates SSH for ssh targets using a host key
+			// derived from the session key
+			if err := s.writeKnownHosts(c); err != nil {
+				c.UI.Warn(fmt.Sprintf("Unable to write known hosts file for the worker's host key: %s", err))
+			} else {
+				args = append(args, "-o", fmt.Sprintf("UserKnownHostsFile=%s", s.knownHostsPath), "-o", "StrictHostKeyChecking=yes")
+			}
+		}
 	case "putty":
 		args = append(args, "-P", port, ip)
 	}
-	if c.flagUsername != "" {
-		args = append(args, "-l", c.flagUsername)
+	if username := c.username(); username != "" {
+		args = append(args, "-l", username)
 	}
 	return args
 }
+
+// wrapExec arranges for the password of a brokered credential to be passed to
+// the client. OpenSSH cannot read a password from its arguments or
+// environment, so it is run through sshpass when that is available. It
+// returns the command to run along with its arguments and any extra
+// environment variables.
+func (s *sshFlags) wrapExec(c *Command, execPath string, args []string) (string, []string, []string) {
+	cred := c.usernamePasswordCredential()
+	if cred.GetPassword() == "" || c.flagUsername != "" {
+		return execPath, args, nil
+	}
+	switch s.flagSshStyle {
+	case "ssh":
+		sshpassPath, err := exec.LookPath("sshpass")
+		if err != nil {
+			c.UI.Warn("The target has a brokered password but sshpass was not found in the PATH; the password must be entered manually. Run boundary connect without -exec to view it.")
+			return execPath, args, nil
+		}
+		return sshpassPath, append([]string{"-e", execPath}, args...), []string{fmt.Sprintf("SSHPASS=%s", cred.GetPassword())}
+	case "putty":
+		return execPath, append(args, "-pw", cred.GetPassword()), nil
+	}
+	return execPath, args, nil
+}
+
+// writeKnownHosts writes a known hosts file containing the worker's host key
+// for the session.
+func (s *sshFlags) writeKnownHosts(c *Command) error {
+	pubKey, err := ssh.NewPublicKey(ed25519.PrivateKey(c.sessionAuthzData.GetPrivateKey()).Public())
+	if err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile("", "boundary-known-hosts-")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	s.knownHostsPath = f.Name()
+	_, err = fmt.Fprintf(f, "%s %s", c.sessionAuthzData.HostId, ssh.MarshalAuthorizedKey(pubKey))
+	return err
+}
+
+// cleanup removes the temporary known hosts file, if one was written.
+func (s *sshFlags) cleanup() {
+	if s.knownHostsPath != "" {
+		os.Remove(s.knownHostsPath)
+	}
+}
@@ -0,0 +1,167 @@
+package connect
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/globals"
+	"go.uber.org/atomic"
+	"nhooyr.io/websocket"
+)
+
+const (
+	defaultUdpIdleTimeout = 60 * time.Second
+
+	// udpFlowQueueLength is the number of datagrams from a client queued while
+	// its connection to the worker is opened or busy. Further datagrams are
+	// dropped, as the network would.
+	udpFlowQueueLength = 64
+)
+
+// udpFlow is a client of the local UDP listener. Its datagrams are proxied
+// over its own connection to the worker, which counts against the session's
+// connection limit.
+type udpFlow struct {
+	clientAddr *net.UDPAddr
+	datagrams  chan []byte
+
+	// lastActive is the time in Unix nanoseconds a datagram was last sent or
+	// received
+	lastActive *atomic.Int64
+}
+
+// serveUdp reads datagrams from the local UDP listener and hands them to the
+// flow of their client, starting one for clients without a flow.
+func (c *Command) serveUdp(workerAddr, tofuToken string, transport *http.Transport) {
+	defer c.connWg.Done()
+
+	var flowsLock sync.Mutex
+	flows := make(map[string]*udpFlow)
+
+	buf := make([]byte, globals.MaxUdpDatagramSize)
+	for {
+		n, clientAddr, err := c.udpListener.ReadFromUDP(buf)
+		if err != nil {
+			select {
+			case <-c.proxyCtx.Done():
+				return
+			case <-c.Context.Done():
+				return
+			default:
+				// When this hits zero we trigger listener close so this
+				// isn't actually an error condition
+				if c.connectionsLeft.Load() == 0 {
+					return
+				}
+				c.UI.Error(fmt.Errorf("Error reading datagram: %w", err).Error())
+				continue
+			}
+		}
+		datagram := make([]byte, n)
+		copy(datagram, buf[:n])
+
+		flowsLock.Lock()
+		flow, ok := flows[clientAddr.String()]
+		if !ok {
+			flow = &udpFlow{
+				clientAddr: clientAddr,
+				datagrams:  make(chan []byte, udpFlowQueueLength),
+				lastActive: atomic.NewInt64(time.Now().UnixNano()),
+			}
+			flows[clientAddr.String()] = flow
+			c.connWg.Add(1)
+			go func() {
+				if err := c.handleUdpFlow(flow, workerAddr, tofuToken, transport); err != nil {
+					c.UI.Error(err.Error())
+				}
+				flowsLock.Lock()
+				delete(flows, flow.clientAddr.String())
+				flowsLock.Unlock()
+			}()
+		}
+		flowsLock.Unlock()
+
+		select {
+		case flow.datagrams <- datagram:
+		default:
+		}
+	}
+}
+
+// handleUdpFlow proxies the datagrams of flow over a connection to the worker
+// until the flow has been idle for the idle timeout or the session ends.
+func (c *Command) handleUdpFlow(flow *udpFlow, workerAddr, tofuToken string, transport *http.Transport) error {
+	defer c.connWg.Done()
+
+	flowCtx, flowCancel := context.WithCancel(c.proxyCtx)
+	defer flowCancel()
+
+	conn, err := c.dialWorker(flowCtx, globals.UdpProxyV1, workerAddr, tofuToken, transport)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(websocket.StatusNormalClosure, "done")
+	conn.SetReadLimit(globals.MaxUdpDatagramSize)
+
+	idleTimeout := c.flagUdpIdleTimeout
+	if idleTimeout <= 0 {
+		idleTimeout = defaultUdpIdleTimeout
+	}
+
+	localWg := new(sync.WaitGroup)
+	localWg.Add(3)
+	go func() {
+		defer localWg.Done()
+		defer flowCancel()
+		for {
+			select {
+			case <-flowCtx.Done():
+				return
+			case datagram := <-flow.datagrams:
+				if err := conn.Write(flowCtx, websocket.MessageBinary, datagram); err != nil {
+					return
+				}
+				flow.lastActive.Store(time.Now().UnixNano())
+			}
+		}
+	}()
+	go func() {
+		defer localWg.Done()
+		defer flowCancel()
+		for {
+			_, datagram, err := conn.Read(flowCtx)
+			if err != nil {
+				return
+			}
+			if _, err := c.udpListener.WriteToUDP(datagram, flow.clientAddr); err != nil {
+				return
+			}
+			flow.lastActive.Store(time.Now().UnixNano())
+		}
+	}()
+	go func() {
+		defer localWg.Done()
+		defer flowCancel()
+		timer := time.NewTimer(idleTimeout)
+		defer timer.Stop()
+		for {
+			select {
+			case <-flowCtx.Done():
+				return
+			case <-timer.C:
+				idle := time.Since(time.Unix(0, flow.lastActive.Load()))
+				if idle >= idleTimeout {
+					return
+				}
+				timer.Reset(idleTimeout - idle)
+			}
+		}
+	}()
+	localWg.Wait()
+
+	return nil
+}
@@ -0,0 +1,282 @@
+package credentials
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/credentials"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/sdk/strutil"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*Command)(nil)
+var _ cli.CommandAutocomplete = (*Command)(nil)
+
+type Command struct {
+	*base.Command
+
+	Func string
+}
+
+func (c *Command) Synopsis() string {
+	return common.SynopsisFunc(c.Func, "credential")
+}
+
+var flagsMap = map[string][]string{
+	"read":   {"id"},
+	"delete": {"id"},
+	"list":   {"credential-store-id", "filter", "page-size", "page-token"},
+}
+
+func (c *Command) Help() string {
+	helpMap := common.HelpMap("credential")
+	var helpStr string
+	switch c.Func {
+	case "":
+		return base.WrapForHelpText([]string{
+			"Usage: boundary credentials [sub command] [options] [args]",
+			"",
+			"  This command allows operations on Boundary credential resources. Example:",
+			"",
+			"    Read a credential:",
+			"",
+			`      $ boundary credentials read -id credup_1234567890`,
+			"",
+			"  Please see the credentials subcommand help for detailed usage information.",
+		})
+	case "create":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary credentials create [type] [sub command] [options] [args]",
+			"",
+			"  This command allows create operations on Boundary credential resources. Example:",
+			"",
+			"    Create a username-password-type credential:",
+			"",
+			`      $ boundary credentials create username-password -credential-store-id csst_1234567890 -username prodops`,
+			"",
+			"  Please see the typed subcommand help for detailed usage information.",
+		})
+	case "update":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary credentials update [type] [sub command] [options] [args]",
+			"",
+			"  This command allows update operations on Boundary credential resources. Example:",
+			"",
+			"    Update a username-password-type credential:",
+			"",
+			`      $ boundary credentials update username-password -id credup_1234567890 -username devops`,
+			"",
+			"  Please see the typed subcommand help for detailed usage information.",
+		})
+	default:
+		helpStr = helpMap[c.Func]()
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func (c *Command) Flags() *base.FlagSets {
+	if len(flagsMap[c.Func]) == 0 {
+		return c.FlagSet(base.FlagSetNone)
+	}
+
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, resource.Credential.String(), flagsMap[c.Func])
+
+	return set
+}
+
+func (c *Command) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *Command) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *Command) Run(args []string) int {
+	switch c.Func {
+	case "", "create", "update":
+		return cli.RunResultHelp
+	}
+
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if strutil.StrListContains(flagsMap[c.Func], "id") && c.FlagId == "" {
+		c.UI.Error("ID is required but not passed in via -id")
+		return 1
+	}
+	if strutil.StrListContains(flagsMap[c.Func], "credential-store-id") && c.FlagCredentialStoreId == "" {
+		c.UI.Error("Credential Store ID must be passed in via -credential-store-id")
+		return 1
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+
+	var opts []credentials.Option
+
+	switch c.FlagName {
+	case "":
+	case "null":
+		opts = append(opts, credentials.DefaultName())
+	default:
+		opts = append(opts, credentials.WithName(c.FlagName))
+	}
+
+	switch c.FlagDescription {
+	case "":
+	case "null":
+		opts = append(opts, credentials.DefaultDescription())
+	default:
+		opts = append(opts, credentials.WithDescription(c.FlagDescription))
+	}
+
+	if c.Func == "list" {
+		if c.FlagFilter != "" {
+			opts = append(opts, credentials.WithFilter(c.FlagFilter))
+		}
+		if c.FlagPageSize > 0 {
+			opts = append(opts, credentials.WithPageSize(uint32(c.FlagPageSize)))
+		}
+		if c.FlagPageToken != "" {
+			opts = append(opts, credentials.WithPageToken(c.FlagPageToken))
+		}
+	}
+
+	credentialClient := credentials.NewClient(client)
+
+	existed := true
+	var result api.GenericResult
+	var listResult api.GenericListResult
+
+	switch c.Func {
+	case "read":
+		result, err = credentialClient.Read(c.Context, c.FlagId, opts...)
+	case "delete":
+		_, err = credentialClient.Delete(c.Context, c.FlagId, opts...)
+		if apiErr := api.AsServerError(err); apiErr != nil && apiErr.Status == int32(http.StatusNotFound) {
+			existed = false
+			err = nil
+		}
+	case "list":
+		listResult, err = credentialClient.List(c.Context, c.FlagCredentialStoreId, opts...)
+	}
+
+	plural := "credential"
+	if c.Func == "list" {
+		plural = "credentials"
+	}
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.UI.Error(fmt.Sprintf("Error from controller when performing %s on %s: %s", c.Func, plural, base.PrintApiError(apiErr)))
+			return 1
+		}
+		c.UI.Error(fmt.Sprintf("Error trying to %s %s: %s", c.Func, plural, err.Error()))
+		return 2
+	}
+
+	switch c.Func {
+	case "delete":
+		switch base.Format(c.UI) {
+		case "json":
+			c.UI.Output("null")
+		case "table":
+			output := "The delete operation completed successfully"
+			switch existed {
+			case true:
+				output += "."
+			default:
+				output += ", however the resource did not exist at the time."
+			}
+			c.UI.Output(output)
+		}
+		return 0
+
+	case "list":
+		listedCredentials := listResult.GetItems().([]*credentials.Credential)
+		switch base.Format(c.UI) {
+		case "json":
+			if len(listedCredentials) == 0 {
+				c.UI.Output("null")
+				return 0
+			}
+			b, err := base.JsonFormatter{}.Format(listedCredentials)
+			if err != nil {
+				c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+				return 1
+			}
+			c.UI.Output(string(b))
+
+		case "table":
+			if len(listedCredentials) == 0 {
+				c.UI.Output("No credentials found")
+				return 0
+			}
+			var output []string
+			output = []string{
+				"",
+				"Credential information:",
+			}
+			for i, m := range listedCredentials {
+				if i > 0 {
+					output = append(output, "")
+				}
+				if true {
+					output = append(output,
+						fmt.Sprintf("  ID:             %s", m.Id),
+						fmt.Sprintf("    Version:      %d", m.Version),
+						fmt.Sprintf("    Type:         %s", m.Type),
+					)
+				}
+				if m.Name != "" {
+					output = append(output,
+						fmt.Sprintf("    Name:         %s", m.Name),
+					)
+				}
+				if m.Description != "" {
+					output = append(output,
+						fmt.Sprintf("    Description:  %s", m.Description),
+					)
+				}
+				if len(m.AuthorizedActions) > 0 {
+					output = append(output,
+						"    Authorized Actions:",
+						base.WrapSlice(6, m.AuthorizedActions),
+					)
+				}
+			}
+			c.UI.Output(base.WrapForHelpText(output))
+		}
+		base.PrintNextPageToken(c.UI, listResult.GetNextPageToken())
+		return 0
+	}
+
+	credential := result.GetItem().(*credentials.Credential)
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateCredentialTableOutput(credential))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(credential)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	}
+
+	return 0
+}
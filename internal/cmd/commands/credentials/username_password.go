@@ -0,0 +1,223 @@
+package credentials
+
+import (
+	"fmt"
+	"net/textproto"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/credentials"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/sdk/strutil"
+	"github.com/hashicorp/vault/sdk/helper/password"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*UsernamePasswordCommand)(nil)
+var _ cli.CommandAutocomplete = (*UsernamePasswordCommand)(nil)
+
+type UsernamePasswordCommand struct {
+	*base.Command
+
+	Func string
+
+	flagUsername string
+	flagPassword string
+}
+
+func (c *UsernamePasswordCommand) Synopsis() string {
+	return fmt.Sprintf("%s a username-password-type credential", textproto.CanonicalMIMEHeaderKey(c.Func))
+}
+
+var usernamePasswordFlagsMap = map[string][]string{
+	"create": {"credential-store-id", "name", "description", "username", "password"},
+	"update": {"id", "name", "description", "version", "username", "password"},
+}
+
+func (c *UsernamePasswordCommand) Help() string {
+	var info string
+	switch c.Func {
+	case "create":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary credentials create username-password [options] [args]",
+			"",
+			"  Create a username-password-type credential. Example:",
+			"",
+			`    $ boundary credentials create username-password -credential-store-id csst_1234567890 -username prodops -description "Database credential for ProdOps"`,
+			"",
+			"",
+		})
+
+	case "update":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary credentials update username-password [options] [args]",
+			"",
+			"  Update a username-password-type credential given its ID. Example:",
+			"",
+			`    $ boundary credentials update username-password -id credup_1234567890 -name "devops" -username devops`,
+			"",
+			"",
+		})
+	}
+	return info + c.Flags().Help()
+}
+
+func (c *UsernamePasswordCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "username-password-type credential", usernamePasswordFlagsMap[c.Func])
+
+	f = set.NewFlagSet("Username Password Credential Options")
+
+	for _, name := range usernamePasswordFlagsMap[c.Func] {
+		switch name {
+		case "username":
+			f.StringVar(&base.StringVar{
+				Name:   "username",
+				Target: &c.flagUsername,
+				Usage:  "The username of the credential.",
+			})
+		case "password":
+			usage := "The password of the credential."
+			if c.Func == "create" {
+				usage += " If not specified, the command will prompt for the password to be entered in a non-echoing way."
+			}
+			f.StringVar(&base.StringVar{
+				Name:   "password",
+				Target: &c.flagPassword,
+				Usage:  usage,
+			})
+		}
+	}
+
+	return set
+}
+
+func (c *UsernamePasswordCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *UsernamePasswordCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *UsernamePasswordCommand) Run(args []string) int {
+	if c.Func == "" {
+		return cli.RunResultHelp
+	}
+
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if strutil.StrListContains(usernamePasswordFlagsMap[c.Func], "id") && c.FlagId == "" {
+		c.UI.Error("ID is required but not passed in via -id")
+		return 1
+	}
+	if strutil.StrListContains(usernamePasswordFlagsMap[c.Func], "credential-store-id") && c.FlagCredentialStoreId == "" {
+		c.UI.Error("Credential Store ID must be passed in via -credential-store-id")
+		return 1
+	}
+	if c.Func == "create" && c.flagUsername == "" {
+		c.UI.Error("Username must be passed in via -username")
+		return 1
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+
+	var opts []credentials.Option
+
+	switch c.FlagName {
+	case "":
+	case "null":
+		opts = append(opts, credentials.DefaultName())
+	default:
+		opts = append(opts, credentials.WithName(c.FlagName))
+	}
+
+	switch c.FlagDescription {
+	case "":
+	case "null":
+		opts = append(opts, credentials.DefaultDescription())
+	default:
+		opts = append(opts, credentials.WithDescription(c.FlagDescription))
+	}
+
+	if c.flagUsername != "" {
+		opts = append(opts, credentials.WithUsernamePasswordCredentialUsername(c.flagUsername))
+	}
+
+	switch {
+	case c.flagPassword != "":
+		opts = append(opts, credentials.WithUsernamePasswordCredentialPassword(c.flagPassword))
+	case c.Func == "create":
+		fmt.Print("Password is not set as flag, please enter it now (will be hidden): ")
+		value, err := password.Read(os.Stdin)
+		fmt.Print("\n")
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("An error occurred attempting to read the password. The raw error message is shown below but usually this is because you attempted to pipe a value into the command or you are executing outside of a terminal (TTY). The raw error was:\n\n%s", err.Error()))
+			return 2
+		}
+		opts = append(opts, credentials.WithUsernamePasswordCredentialPassword(strings.TrimSpace(value)))
+	}
+
+	credentialClient := credentials.NewClient(client)
+
+	// Perform check-and-set when needed
+	var version uint32
+	switch c.Func {
+	case "create":
+		// These don't update so don't need the existing version
+	default:
+		switch c.FlagVersion {
+		case 0:
+			opts = append(opts, credentials.WithAutomaticVersioning(true))
+		default:
+			version = uint32(c.FlagVersion)
+		}
+	}
+
+	var result api.GenericResult
+
+	switch c.Func {
+	case "create":
+		result, err = credentialClient.Create(c.Context, c.FlagCredentialStoreId, opts...)
+	case "update":
+		result, err = credentialClient.Update(c.Context, c.FlagId, version, opts...)
+	}
+
+	plural := "username-password-type credential"
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.UI.Error(fmt.Sprintf("Error from controller when performing %s on %s: %s", c.Func, plural, base.PrintApiError(apiErr)))
+			return 1
+		}
+		c.UI.Error(fmt.Sprintf("Error trying to %s %s: %s", c.Func, plural, err.Error()))
+		return 2
+	}
+
+	credential := result.GetItem().(*credentials.Credential)
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateCredentialTableOutput(credential))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(credential)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	}
+
+	return 0
+}
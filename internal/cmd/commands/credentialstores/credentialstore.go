@@ -0,0 +1,291 @@
+package credentialstores
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/credentialstores"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/sdk/strutil"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*Command)(nil)
+var _ cli.CommandAutocomplete = (*Command)(nil)
+
+type Command struct {
+	*base.Command
+
+	Func string
+}
+
+func (c *Command) Synopsis() string {
+	return common.SynopsisFunc(c.Func, "credential store")
+}
+
+var flagsMap = map[string][]string{
+	"read":   {"id"},
+	"delete": {"id"},
+	"list":   {"scope-id", "recursive", "filter", "page-size", "page-token"},
+}
+
+func (c *Command) Help() string {
+	helpMap := common.HelpMap("credential store")
+	var helpStr string
+	switch c.Func {
+	case "":
+		return base.WrapForHelpText([]string{
+			"Usage: boundary credential-stores [sub command] [options] [args]",
+			"",
+			"  This command allows operations on Boundary credential store resources. Example:",
+			"",
+			"    Read a credential store:",
+			"",
+			`      $ boundary credential-stores read -id csst_1234567890`,
+			"",
+			"  Please see the credential-stores subcommand help for detailed usage information.",
+		})
+	case "create":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary credential-stores create [type] [sub command] [options] [args]",
+			"",
+			"  This command allows create operations on Boundary credential store resources. Example:",
+			"",
+			"    Create a static-type credential store:",
+			"",
+			`      $ boundary credential-stores create static -name prodops -description "For ProdOps usage"`,
+			"",
+			"  Please see the typed subcommand help for detailed usage information.",
+		})
+	case "update":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary credential-stores update [type] [sub command] [options] [args]",
+			"",
+			"  This command allows update operations on Boundary credential store resources. Example:",
+			"",
+			"    Update a static-type credential store:",
+			"",
+			`      $ boundary credential-stores update static -id csst_1234567890 -name devops -description "For DevOps usage"`,
+			"",
+			"  Please see the typed subcommand help for detailed usage information.",
+		})
+	default:
+		helpStr = helpMap[c.Func]()
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func (c *Command) Flags() *base.FlagSets {
+	if len(flagsMap[c.Func]) == 0 {
+		return c.FlagSet(base.FlagSetNone)
+	}
+
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, resource.CredentialStore.String(), flagsMap[c.Func])
+
+	return set
+}
+
+func (c *Command) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *Command) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *Command) Run(args []string) int {
+	switch c.Func {
+	case "", "create", "update":
+		return cli.RunResultHelp
+	}
+
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if strutil.StrListContains(flagsMap[c.Func], "id") && c.FlagId == "" {
+		c.UI.Error("ID is required but not passed in via -id")
+		return 1
+	}
+	if strutil.StrListContains(flagsMap[c.Func], "scope-id") && c.FlagScopeId == "" {
+		c.UI.Error("Scope ID must be passed in via -scope-id")
+		return 1
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+
+	var opts []credentialstores.Option
+
+	switch c.FlagName {
+	case "":
+	case "null":
+		opts = append(opts, credentialstores.DefaultName())
+	default:
+		opts = append(opts, credentialstores.WithName(c.FlagName))
+	}
+
+	switch c.FlagDescription {
+	case "":
+	case "null":
+		opts = append(opts, credentialstores.DefaultDescription())
+	default:
+		opts = append(opts, credentialstores.WithDescription(c.FlagDescription))
+	}
+
+	if c.Func == "list" {
+		if c.FlagFilter != "" {
+			opts = append(opts, credentialstores.WithFilter(c.FlagFilter))
+		}
+		if c.FlagRecursive {
+			opts = append(opts, credentialstores.WithRecursive(true))
+		}
+		if c.FlagPageSize > 0 {
+			opts = append(opts, credentialstores.WithPageSize(uint32(c.FlagPageSize)))
+		}
+		if c.FlagPageToken != "" {
+			opts = append(opts, credentialstores.WithPageToken(c.FlagPageToken))
+		}
+	}
+
+	credentialstoreClient := credentialstores.NewClient(client)
+
+	existed := true
+	var result api.GenericResult
+	var listResult api.GenericListResult
+
+	switch c.Func {
+	case "read":
+		result, err = credentialstoreClient.Read(c.Context, c.FlagId, opts...)
+	case "delete":
+		_, err = credentialstoreClient.Delete(c.Context, c.FlagId, opts...)
+		if apiErr := api.AsServerError(err); apiErr != nil && apiErr.Status == int32(http.StatusNotFound) {
+			existed = false
+			err = nil
+		}
+	case "list":
+		listResult, err = credentialstoreClient.List(c.Context, c.FlagScopeId, opts...)
+	}
+
+	plural := "credential store"
+	if c.Func == "list" {
+		plural = "credential stores"
+	}
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.UI.Error(fmt.Sprintf("Error from controller when performing %s on %s: %s", c.Func, plural, base.PrintApiError(apiErr)))
+			return 1
+		}
+		c.UI.Error(fmt.Sprintf("Error trying to %s %s: %s", c.Func, plural, err.Error()))
+		return 2
+	}
+
+	switch c.Func {
+	case "delete":
+		switch base.Format(c.UI) {
+		case "json":
+			c.UI.Output("null")
+		case "table":
+			output := "The delete operation completed successfully"
+			switch existed {
+			case true:
+				output += "."
+			default:
+				output += ", however the resource did not exist at the time."
+			}
+			c.UI.Output(output)
+		}
+		return 0
+
+	case "list":
+		listedStores := listResult.GetItems().([]*credentialstores.CredentialStore)
+		switch base.Format(c.UI) {
+		case "json":
+			if len(listedStores) == 0 {
+				c.UI.Output("null")
+				return 0
+			}
+			b, err := base.JsonFormatter{}.Format(listedStores)
+			if err != nil {
+				c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+				return 1
+			}
+			c.UI.Output(string(b))
+
+		case "table":
+			if len(listedStores) == 0 {
+				c.UI.Output("No credential stores found")
+				return 0
+			}
+			var output []string
+			output = []string{
+				"",
+				"Credential Store information:",
+			}
+			for i, m := range listedStores {
+				if i > 0 {
+					output = append(output, "")
+				}
+				if true {
+					output = append(output,
+						fmt.Sprintf("  ID:             %s", m.Id),
+						fmt.Sprintf("    Version:      %d", m.Version),
+						fmt.Sprintf("    Type:         %s", m.Type),
+					)
+				}
+				if c.FlagRecursive {
+					output = append(output,
+						fmt.Sprintf("    Scope ID:     %s", m.ScopeId),
+					)
+				}
+				if m.Name != "" {
+					output = append(output,
+						fmt.Sprintf("    Name:         %s", m.Name),
+					)
+				}
+				if m.Description != "" {
+					output = append(output,
+						fmt.Sprintf("    Description:  %s", m.Description),
+					)
+				}
+				if len(m.AuthorizedActions) > 0 {
+					output = append(output,
+						"    Authorized Actions:",
+						base.WrapSlice(6, m.AuthorizedActions),
+					)
+				}
+			}
+			c.UI.Output(base.WrapForHelpText(output))
+		}
+		base.PrintNextPageToken(c.UI, listResult.GetNextPageToken())
+		return 0
+	}
+
+	store := result.GetItem().(*credentialstores.CredentialStore)
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateCredentialStoreTableOutput(store))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(store)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	}
+
+	return 0
+}
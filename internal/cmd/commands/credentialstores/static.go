@@ -0,0 +1,172 @@
+package credentialstores
+
+import (
+	"fmt"
+	"net/textproto"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/credentialstores"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/sdk/strutil"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*StaticCommand)(nil)
+var _ cli.CommandAutocomplete = (*StaticCommand)(nil)
+
+type StaticCommand struct {
+	*base.Command
+
+	Func string
+}
+
+func (c *StaticCommand) Synopsis() string {
+	return fmt.Sprintf("%s a static-type credential store", textproto.CanonicalMIMEHeaderKey(c.Func))
+}
+
+var staticFlagsMap = map[string][]string{
+	"create": {"scope-id", "name", "description"},
+	"update": {"id", "name", "description", "version"},
+}
+
+func (c *StaticCommand) Help() string {
+	var info string
+	switch c.Func {
+	case "create":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary credential-stores create static [options] [args]",
+			"",
+			"  Create a static-type credential store. Example:",
+			"",
+			`    $ boundary credential-stores create static -name prodops -description "Static credential store for ProdOps"`,
+			"",
+			"",
+		})
+
+	case "update":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary credential-stores update static [options] [args]",
+			"",
+			"  Update a static-type credential store given its ID. Example:",
+			"",
+			`    $ boundary credential-stores update static -id csst_1234567890 -name "devops" -description "Static credential store for DevOps"`,
+			"",
+			"",
+		})
+	}
+	return info + c.Flags().Help()
+}
+
+func (c *StaticCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "static-type credential store", staticFlagsMap[c.Func])
+
+	return set
+}
+
+func (c *StaticCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *StaticCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *StaticCommand) Run(args []string) int {
+	if c.Func == "" {
+		return cli.RunResultHelp
+	}
+
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if strutil.StrListContains(staticFlagsMap[c.Func], "id") && c.FlagId == "" {
+		c.UI.Error("ID is required but not passed in via -id")
+		return 1
+	}
+	if strutil.StrListContains(staticFlagsMap[c.Func], "scope-id") && c.FlagScopeId == "" {
+		c.UI.Error("Scope ID must be passed in via -scope-id")
+		return 1
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+
+	var opts []credentialstores.Option
+
+	switch c.FlagName {
+	case "":
+	case "null":
+		opts = append(opts, credentialstores.DefaultName())
+	default:
+		opts = append(opts, credentialstores.WithName(c.FlagName))
+	}
+
+	switch c.FlagDescription {
+	case "":
+	case "null":
+		opts = append(opts, credentialstores.DefaultDescription())
+	default:
+		opts = append(opts, credentialstores.WithDescription(c.FlagDescription))
+	}
+
+	credentialstoreClient := credentialstores.NewClient(client)
+
+	// Perform check-and-set when needed
+	var version uint32
+	switch c.Func {
+	case "create":
+		// These don't update so don't need the existing version
+	default:
+		switch c.FlagVersion {
+		case 0:
+			opts = append(opts, credentialstores.WithAutomaticVersioning(true))
+		default:
+			version = uint32(c.FlagVersion)
+		}
+	}
+
+	var result api.GenericResult
+
+	switch c.Func {
+	case "create":
+		result, err = credentialstoreClient.Create(c.Context, "static", c.FlagScopeId, opts...)
+	case "update":
+		result, err = credentialstoreClient.Update(c.Context, c.FlagId, version, opts...)
+	}
+
+	plural := "static-type credential store"
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.UI.Error(fmt.Sprintf("Error from controller when performing %s on %s: %s", c.Func, plural, base.PrintApiError(apiErr)))
+			return 1
+		}
+		c.UI.Error(fmt.Sprintf("Error trying to %s %s: %s", c.Func, plural, err.Error()))
+		return 2
+	}
+
+	store := result.GetItem().(*credentialstores.CredentialStore)
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateCredentialStoreTableOutput(store))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(store)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	}
+
+	return 0
+}
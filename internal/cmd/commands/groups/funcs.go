@@ -72,6 +72,18 @@ func populateFlags(c *Command, f *base.FlagSet, flagNames []string) {
 				Target: &c.flagMembers,
 				Usage:  "The members (users) to add, remove, or set. May be specified multiple times.",
 			})
+		case "directory-type":
+			f.StringVar(&base.StringVar{
+				Name:   "directory-type",
+				Target: &c.flagDirectoryType,
+				Usage:  `The type of the external directory the members of the group are synchronized from, "ldap" or "scim".`,
+			})
+		case "directory-group":
+			f.StringVar(&base.StringVar{
+				Name:   "directory-group",
+				Target: &c.flagDirectoryGroup,
+				Usage:  "The group in the external directory the members of the group are synchronized from: the DN of an LDAP group or the display name of a SCIM group.",
+			})
 		}
 	}
 }
@@ -90,6 +102,12 @@ func generateGroupTableOutput(in *groups.Group) string {
 	if in.Description != "" {
 		nonAttributeMap["Description"] = in.Description
 	}
+	if in.DirectoryType != "" {
+		nonAttributeMap["Directory Type"] = in.DirectoryType
+	}
+	if in.DirectoryGroup != "" {
+		nonAttributeMap["Directory Group"] = in.DirectoryGroup
+	}
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
 
@@ -115,6 +133,14 @@ func generateGroupTableOutput(in *groups.Group) string {
 		base.ScopeInfoForOutput(in.Scope, maxLength),
 	}
 
+	if len(in.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, in.AuthorizedActions),
+		)
+	}
+
 	if len(in.Members) > 0 {
 		ret = append(ret,
 			"",
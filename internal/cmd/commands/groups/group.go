@@ -21,7 +21,9 @@ type Command struct {
 
 	Func string
 
-	flagMembers []string
+	flagMembers        []string
+	flagDirectoryType  string
+	flagDirectoryGroup string
 }
 
 func (c *Command) Synopsis() string {
@@ -42,11 +44,11 @@ var helpMap = func() map[string]func() string {
 }
 
 var flagsMap = map[string][]string{
-	"create":         {"scope-id", "name", "description"},
-	"update":         {"id", "name", "description", "version"},
+	"create":         {"scope-id", "name", "description", "directory-type", "directory-group"},
+	"update":         {"id", "name", "description", "directory-type", "directory-group", "version"},
 	"read":           {"id"},
 	"delete":         {"id"},
-	"list":           {"scope-id"},
+	"list":           {"scope-id", "recursive", "filter", "page-size", "page-token"},
 	"add-members":    {"id", "member", "version"},
 	"set-members":    {"id", "member", "version"},
 	"remove-members": {"id", "member", "version"},
@@ -121,6 +123,22 @@ func (c *Command) Run(args []string) int {
 		opts = append(opts, groups.WithDescription(c.FlagDescription))
 	}
 
+	switch c.flagDirectoryType {
+	case "":
+	case "null":
+		opts = append(opts, groups.DefaultDirectoryType())
+	default:
+		opts = append(opts, groups.WithDirectoryType(c.flagDirectoryType))
+	}
+
+	switch c.flagDirectoryGroup {
+	case "":
+	case "null":
+		opts = append(opts, groups.DefaultDirectoryGroup())
+	default:
+		opts = append(opts, groups.WithDirectoryGroup(c.flagDirectoryGroup))
+	}
+
 	members := c.flagMembers
 	switch c.Func {
 	case "add-members", "remove-members":
@@ -141,6 +159,21 @@ func (c *Command) Run(args []string) int {
 		}
 	}
 
+	if c.Func == "list" {
+		if c.FlagFilter != "" {
+			opts = append(opts, groups.WithFilter(c.FlagFilter))
+		}
+		if c.FlagRecursive {
+			opts = append(opts, groups.WithRecursive(true))
+		}
+		if c.FlagPageSize > 0 {
+			opts = append(opts, groups.WithPageSize(uint32(c.FlagPageSize)))
+		}
+		if c.FlagPageToken != "" {
+			opts = append(opts, groups.WithPageToken(c.FlagPageToken))
+		}
+	}
+
 	groupClient := groups.NewClient(client)
 
 	// Perform check-and-set when needed
@@ -249,6 +282,11 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("    Version:     %d", g.Version),
 					)
 				}
+				if c.FlagRecursive {
+					output = append(output,
+						fmt.Sprintf("    Scope ID:    %s", g.ScopeId),
+					)
+				}
 				if g.Name != "" {
 					output = append(output,
 						fmt.Sprintf("    Name:        %s", g.Name),
@@ -259,9 +297,16 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("    Description: %s", g.Description),
 					)
 				}
+				if len(g.AuthorizedActions) > 0 {
+					output = append(output,
+						"    Authorized Actions:",
+						base.WrapSlice(6, g.AuthorizedActions),
+					)
+				}
 			}
 			c.UI.Output(base.WrapForHelpText(output))
 		}
+		base.PrintNextPageToken(c.UI, listResult.GetNextPageToken())
 		return 0
 	}
 
@@ -30,7 +30,7 @@ func (c *Command) Synopsis() string {
 var flagsMap = map[string][]string{
 	"read":   {"id"},
 	"delete": {"id"},
-	"list":   {"scope-id"},
+	"list":   {"scope-id", "recursive", "filter", "page-size", "page-token"},
 }
 
 func (c *Command) Help() string {
@@ -59,6 +59,10 @@ func (c *Command) Help() string {
 			"",
 			`      $ boundary host-catalogs create static -name prodops -description "For ProdOps usage"`,
 			"",
+			"    Create a plugin-type host catalog:",
+			"",
+			`      $ boundary host-catalogs create plugin -name prodops -plugin-name file -plugin-config '{"path": "/etc/boundary/hosts.json"}'`,
+			"",
 			"  Please see the typed subcommand help for detailed usage information.",
 		})
 	case "update":
@@ -71,6 +75,10 @@ func (c *Command) Help() string {
 			"",
 			`      $ boundary host-catalogs update static -id hcst_1234567890 -name devops -description "For DevOps usage"`,
 			"",
+			"    Update a plugin-type host catalog:",
+			"",
+			`      $ boundary host-catalogs update plugin -id hcplg_1234567890 -plugin-config '{"path": "/etc/boundary/prod-hosts.json"}'`,
+			"",
 			"  Please see the typed subcommand help for detailed usage information.",
 		})
 	default:
@@ -146,6 +154,21 @@ func (c *Command) Run(args []string) int {
 		opts = append(opts, hostcatalogs.WithDescription(c.FlagDescription))
 	}
 
+	if c.Func == "list" {
+		if c.FlagFilter != "" {
+			opts = append(opts, hostcatalogs.WithFilter(c.FlagFilter))
+		}
+		if c.FlagRecursive {
+			opts = append(opts, hostcatalogs.WithRecursive(true))
+		}
+		if c.FlagPageSize > 0 {
+			opts = append(opts, hostcatalogs.WithPageSize(uint32(c.FlagPageSize)))
+		}
+		if c.FlagPageToken != "" {
+			opts = append(opts, hostcatalogs.WithPageToken(c.FlagPageToken))
+		}
+	}
+
 	hostcatalogClient := hostcatalogs.NewClient(client)
 
 	existed := true
@@ -231,6 +254,11 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("    Type:         %s", m.Type),
 					)
 				}
+				if c.FlagRecursive {
+					output = append(output,
+						fmt.Sprintf("    Scope ID:     %s", m.ScopeId),
+					)
+				}
 				if m.Name != "" {
 					output = append(output,
 						fmt.Sprintf("    Name:         %s", m.Name),
@@ -241,9 +269,16 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("    Description:  %s", m.Description),
 					)
 				}
+				if len(m.AuthorizedActions) > 0 {
+					output = append(output,
+						"    Authorized Actions:",
+						base.WrapSlice(6, m.AuthorizedActions),
+					)
+				}
 			}
 			c.UI.Output(base.WrapForHelpText(output))
 		}
+		base.PrintNextPageToken(c.UI, listResult.GetNextPageToken())
 		return 0
 	}
 
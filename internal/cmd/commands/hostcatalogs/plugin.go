@@ -0,0 +1,213 @@
+package hostcatalogs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/textproto"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/hostcatalogs"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/sdk/strutil"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*PluginCommand)(nil)
+var _ cli.CommandAutocomplete = (*PluginCommand)(nil)
+
+type PluginCommand struct {
+	*base.Command
+
+	Func             string
+	flagPluginName   string
+	flagPluginConfig string
+}
+
+func (c *PluginCommand) Synopsis() string {
+	return fmt.Sprintf("%s a plugin-type host catalog", textproto.CanonicalMIMEHeaderKey(c.Func))
+}
+
+var pluginFlagsMap = map[string][]string{
+	"create": {"scope-id", "name", "description", "plugin-name", "plugin-config"},
+	"update": {"id", "name", "description", "version", "plugin-config"},
+}
+
+func (c *PluginCommand) Help() string {
+	var info string
+	switch c.Func {
+	case "create":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary host-catalogs create plugin [options] [args]",
+			"",
+			"  Create a plugin-type host catalog. Example:",
+			"",
+			`    $ boundary host-catalogs create plugin -name prodops -plugin-name file -plugin-config '{"path": "/etc/boundary/hosts.json"}'`,
+			"",
+			"",
+		})
+
+	case "update":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary host-catalogs update plugin [options] [args]",
+			"",
+			"  Update a plugin-type host catalog given its ID. Example:",
+			"",
+			`    $ boundary host-catalogs update plugin -id hcplg_1234567890 -plugin-config '{"path": "/etc/boundary/prod-hosts.json"}'`,
+			"",
+			"",
+		})
+	}
+	return info + c.Flags().Help()
+}
+
+func (c *PluginCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "plugin-type host catalog", pluginFlagsMap[c.Func])
+
+	for _, name := range pluginFlagsMap[c.Func] {
+		switch name {
+		case "plugin-name":
+			f.StringVar(&base.StringVar{
+				Name:   "plugin-name",
+				Target: &c.flagPluginName,
+				Usage:  "The name of the plugin which provides the hosts of the catalog.",
+			})
+		case "plugin-config":
+			f.StringVar(&base.StringVar{
+				Name:   "plugin-config",
+				Target: &c.flagPluginConfig,
+				Usage:  `A JSON object containing the configuration passed to the plugin, e.g. '{"path": "/etc/boundary/hosts.json"}'.`,
+			})
+		}
+	}
+
+	return set
+}
+
+func (c *PluginCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *PluginCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *PluginCommand) Run(args []string) int {
+	if c.Func == "" {
+		return cli.RunResultHelp
+	}
+
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if strutil.StrListContains(pluginFlagsMap[c.Func], "id") && c.FlagId == "" {
+		c.UI.Error("ID is required but not passed in via -id")
+		return 1
+	}
+	if strutil.StrListContains(pluginFlagsMap[c.Func], "scope-id") && c.FlagScopeId == "" {
+		c.UI.Error("Scope ID must be passed in via -scope-id")
+		return 1
+	}
+	if c.Func == "create" && c.flagPluginName == "" {
+		c.UI.Error("Plugin name must be passed in via -plugin-name")
+		return 1
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+
+	var opts []hostcatalogs.Option
+
+	switch c.FlagName {
+	case "":
+	case "null":
+		opts = append(opts, hostcatalogs.DefaultName())
+	default:
+		opts = append(opts, hostcatalogs.WithName(c.FlagName))
+	}
+
+	switch c.FlagDescription {
+	case "":
+	case "null":
+		opts = append(opts, hostcatalogs.DefaultDescription())
+	default:
+		opts = append(opts, hostcatalogs.WithDescription(c.FlagDescription))
+	}
+
+	if c.flagPluginName != "" {
+		opts = append(opts, hostcatalogs.WithPluginHostCatalogPluginName(c.flagPluginName))
+	}
+
+	switch c.flagPluginConfig {
+	case "":
+	case "null":
+		opts = append(opts, hostcatalogs.DefaultPluginHostCatalogPluginConfig())
+	default:
+		var config map[string]interface{}
+		if err := json.Unmarshal([]byte(c.flagPluginConfig), &config); err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing -plugin-config as a JSON object: %s", err.Error()))
+			return 1
+		}
+		opts = append(opts, hostcatalogs.WithPluginHostCatalogPluginConfig(config))
+	}
+
+	hostcatalogClient := hostcatalogs.NewClient(client)
+
+	// Perform check-and-set when needed
+	var version uint32
+	switch c.Func {
+	case "create":
+		// These don't update so don't need the existing version
+	default:
+		switch c.FlagVersion {
+		case 0:
+			opts = append(opts, hostcatalogs.WithAutomaticVersioning(true))
+		default:
+			version = uint32(c.FlagVersion)
+		}
+	}
+
+	var result api.GenericResult
+
+	switch c.Func {
+	case "create":
+		result, err = hostcatalogClient.Create(c.Context, "plugin", c.FlagScopeId, opts...)
+	case "update":
+		result, err = hostcatalogClient.Update(c.Context, c.FlagId, version, opts...)
+	}
+
+	plural := "plugin-type host-catalog"
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.UI.Error(fmt.Sprintf("Error from controller when performing %s on %s: %s", c.Func, plural, base.PrintApiError(apiErr)))
+			return 1
+		}
+		c.UI.Error(fmt.Sprintf("Error trying to %s %s: %s", c.Func, plural, err.Error()))
+		return 2
+	}
+
+	catalog := result.GetItem().(*hostcatalogs.HostCatalog)
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateHostCatalogTableOutput(catalog))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(catalog)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	}
+
+	return 0
+}
@@ -35,6 +35,14 @@ func generateHostTableOutput(in *hosts.Host) string {
 		base.ScopeInfoForOutput(in.Scope, maxLength),
 	}
 
+	if len(in.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, in.AuthorizedActions),
+		)
+	}
+
 	if len(in.HostSetIds) > 0 {
 		ret = append(ret,
 			"",
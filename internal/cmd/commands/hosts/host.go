@@ -30,7 +30,7 @@ func (c *Command) Synopsis() string {
 var flagsMap = map[string][]string{
 	"read":   {"id"},
 	"delete": {"id"},
-	"list":   {"host-catalog-id"},
+	"list":   {"host-catalog-id", "filter", "page-size", "page-token"},
 }
 
 func (c *Command) Help() string {
@@ -145,6 +145,18 @@ func (c *Command) Run(args []string) int {
 		opts = append(opts, hosts.WithDescription(c.FlagDescription))
 	}
 
+	if c.Func == "list" {
+		if c.FlagFilter != "" {
+			opts = append(opts, hosts.WithFilter(c.FlagFilter))
+		}
+		if c.FlagPageSize > 0 {
+			opts = append(opts, hosts.WithPageSize(uint32(c.FlagPageSize)))
+		}
+		if c.FlagPageToken != "" {
+			opts = append(opts, hosts.WithPageToken(c.FlagPageToken))
+		}
+	}
+
 	hostClient := hosts.NewClient(client)
 
 	existed := true
@@ -240,9 +252,16 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("    Description:  %s", m.Description),
 					)
 				}
+				if len(m.AuthorizedActions) > 0 {
+					output = append(output,
+						"    Authorized Actions:",
+						base.WrapSlice(6, m.AuthorizedActions),
+					)
+				}
 			}
 			c.UI.Output(base.WrapForHelpText(output))
 		}
+		base.PrintNextPageToken(c.UI, listResult.GetNextPageToken())
 		return 0
 	}
 
@@ -41,7 +41,7 @@ func (c *Command) Synopsis() string {
 var flagsMap = map[string][]string{
 	"read":         {"id"},
 	"delete":       {"id"},
-	"list":         {"host-catalog-id"},
+	"list":         {"host-catalog-id", "filter", "page-size", "page-token"},
 	"add-hosts":    {"id", "host", "version"},
 	"set-hosts":    {"id", "host", "version"},
 	"remove-hosts": {"id", "host", "version"},
@@ -73,6 +73,10 @@ func (c *Command) Help() string {
 			"",
 			`      $ boundary host-sets create static -name prodops -description "For ProdOps usage"`,
 			"",
+			"    Create a plugin-type host set:",
+			"",
+			`      $ boundary host-sets create plugin -host-catalog-id hcplg_1234567890 -name prodops -filter '"prod" in "/tags/env"'`,
+			"",
 			"  Please see the typed subcommand help for detailed usage information.",
 		})
 	case "update":
@@ -85,6 +89,10 @@ func (c *Command) Help() string {
 			"",
 			`      $ boundary host-sets update static -id hsst_1234567890 -name devops -description "For DevOps usage"`,
 			"",
+			"    Update a plugin-type host set:",
+			"",
+			`      $ boundary host-sets update plugin -id hsplg_1234567890 -filter '"dev" in "/tags/env"'`,
+			"",
 			"  Please see the typed subcommand help for detailed usage information.",
 		})
 	case "add-hosts":
@@ -234,6 +242,18 @@ func (c *Command) Run(args []string) int {
 		// file
 	}
 
+	if c.Func == "list" {
+		if c.FlagFilter != "" {
+			opts = append(opts, hostsets.WithFilter(c.FlagFilter))
+		}
+		if c.FlagPageSize > 0 {
+			opts = append(opts, hostsets.WithPageSize(uint32(c.FlagPageSize)))
+		}
+		if c.FlagPageToken != "" {
+			opts = append(opts, hostsets.WithPageToken(c.FlagPageToken))
+		}
+	}
+
 	hostsetClient := hostsets.NewClient(client)
 
 	existed := true
@@ -335,9 +355,16 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("    Description:  %s", m.Description),
 					)
 				}
+				if len(m.AuthorizedActions) > 0 {
+					output = append(output,
+						"    Authorized Actions:",
+						base.WrapSlice(6, m.AuthorizedActions),
+					)
+				}
 			}
 			c.UI.Output(base.WrapForHelpText(output))
 		}
+		base.PrintNextPageToken(c.UI, listResult.GetNextPageToken())
 		return 0
 	}
 
@@ -0,0 +1,192 @@
+package hostsets
+
+import (
+	"fmt"
+	"net/textproto"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/hostsets"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/sdk/strutil"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*PluginCommand)(nil)
+var _ cli.CommandAutocomplete = (*PluginCommand)(nil)
+
+type PluginCommand struct {
+	*base.Command
+
+	Func       string
+	flagFilter string
+}
+
+func (c *PluginCommand) Synopsis() string {
+	return fmt.Sprintf("%s a plugin-type host set", textproto.CanonicalMIMEHeaderKey(c.Func))
+}
+
+var pluginFlagsMap = map[string][]string{
+	"create": {"host-catalog-id", "name", "description", "filter"},
+	"update": {"id", "name", "description", "version", "filter"},
+}
+
+func (c *PluginCommand) Help() string {
+	var info string
+	switch c.Func {
+	case "create":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary host-sets create plugin [options] [args]",
+			"",
+			"  Create a plugin-type host set. Example:",
+			"",
+			`    $ boundary host-sets create plugin -host-catalog-id hcplg_1234567890 -name prodops -filter '"prod" in "/tags/env"'`,
+			"",
+			"",
+		})
+
+	case "update":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary host-sets update plugin [options] [args]",
+			"",
+			"  Update a plugin-type host set given its ID. Example:",
+			"",
+			`    $ boundary host-sets update plugin -id hsplg_1234567890 -filter '"dev" in "/tags/env"'`,
+			"",
+			"",
+		})
+	}
+	return info + c.Flags().Help()
+}
+
+func (c *PluginCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "plugin-type host set", pluginFlagsMap[c.Func])
+
+	for _, name := range pluginFlagsMap[c.Func] {
+		switch name {
+		case "filter":
+			f.StringVar(&base.StringVar{
+				Name:   "filter",
+				Target: &c.flagFilter,
+				Usage:  "The filter passed to the plugin of the host catalog to select the hosts of the set.",
+			})
+		}
+	}
+
+	return set
+}
+
+func (c *PluginCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *PluginCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *PluginCommand) Run(args []string) int {
+	if c.Func == "" {
+		return cli.RunResultHelp
+	}
+
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if strutil.StrListContains(pluginFlagsMap[c.Func], "id") && c.FlagId == "" {
+		c.UI.Error("ID is required but not passed in via -id")
+		return 1
+	}
+	if strutil.StrListContains(pluginFlagsMap[c.Func], "host-catalog-id") && c.FlagHostCatalogId == "" {
+		c.UI.Error("Host Catalog ID must be passed in via -host-catalog-id")
+		return 1
+	}
+	if c.Func == "create" && c.flagFilter == "" {
+		c.UI.Error("Filter must be passed in via -filter")
+		return 1
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+
+	var opts []hostsets.Option
+
+	switch c.FlagName {
+	case "":
+	case "null":
+		opts = append(opts, hostsets.DefaultName())
+	default:
+		opts = append(opts, hostsets.WithName(c.FlagName))
+	}
+
+	switch c.FlagDescription {
+	case "":
+	case "null":
+		opts = append(opts, hostsets.DefaultDescription())
+	default:
+		opts = append(opts, hostsets.WithDescription(c.FlagDescription))
+	}
+
+	if c.flagFilter != "" {
+		opts = append(opts, hostsets.WithPluginHostSetFilter(c.flagFilter))
+	}
+
+	hostsetClient := hostsets.NewClient(client)
+
+	// Perform check-and-set when needed
+	var version uint32
+	switch c.Func {
+	case "create":
+		// These don't update so don't need the existing version
+	default:
+		switch c.FlagVersion {
+		case 0:
+			opts = append(opts, hostsets.WithAutomaticVersioning(true))
+		default:
+			version = uint32(c.FlagVersion)
+		}
+	}
+
+	var result api.GenericResult
+
+	switch c.Func {
+	case "create":
+		result, err = hostsetClient.Create(c.Context, c.FlagHostCatalogId, opts...)
+	case "update":
+		result, err = hostsetClient.Update(c.Context, c.FlagId, version, opts...)
+	}
+
+	plural := "plugin-type host-set"
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.UI.Error(fmt.Sprintf("Error from controller when performing %s on %s: %s", c.Func, plural, base.PrintApiError(apiErr)))
+			return 1
+		}
+		c.UI.Error(fmt.Sprintf("Error trying to %s %s: %s", c.Func, plural, err.Error()))
+		return 2
+	}
+
+	set := result.GetItem().(*hostsets.HostSet)
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateHostSetTableOutput(set))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(set)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	}
+
+	return 0
+}
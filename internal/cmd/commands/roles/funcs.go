@@ -36,6 +36,10 @@ func addPrincipalsHelp() string {
 		`  Adds principals (users, groups) to a role given its ID. The "principal" flag can be specified multiple times. Example:`,
 		"",
 		`    $ boundary roles add-principals -id r_1234567890 -principal u_1234567890`,
+		"",
+		`  The "expiration-time" flag removes the principals from the role at the given time, which can be a duration from now. Example:`,
+		"",
+		`    $ boundary roles add-principals -id r_1234567890 -principal u_1234567890 -expiration-time 4h`,
 	})
 }
 
@@ -59,6 +63,16 @@ func removePrincipalsHelp() string {
 	})
 }
 
+func explainHelp() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary roles explain [options] [args]",
+		"",
+		`  Explains whether the grants of a user allow an action on a resource, listing each grant in the resource's scope, the role it comes from, and why it did or did not match. The resource must be in the scope given by -scope-id. Example:`,
+		"",
+		`    $ boundary roles explain -scope-id p_1234567890 -user-id u_1234567890 -resource-id ttcp_1234567890 -action authorize-session`,
+	})
+}
+
 func populateFlags(c *Command, f *base.FlagSet, flagNames []string) {
 	common.PopulateCommonFlags(c.Command, f, resource.Role.String(), flagNames)
 
@@ -68,7 +82,7 @@ func populateFlags(c *Command, f *base.FlagSet, flagNames []string) {
 			f.StringVar(&base.StringVar{
 				Name:   "grant-scope-id",
 				Target: &c.flagGrantScopeId,
-				Usage:  "The scope ID for grants set on the role",
+				Usage:  `The scope ID for grants set on the role. Use "children" or "descendants" to apply the grants to the role's scope and its child or descendant scopes.`,
 			})
 		case "principal":
 			f.StringSliceVar(&base.StringSliceVar{
@@ -76,12 +90,42 @@ func populateFlags(c *Command, f *base.FlagSet, flagNames []string) {
 				Target: &c.flagPrincipals,
 				Usage:  "The principals (users or groups) to add, remove, or set. May be specified multiple times.",
 			})
+		case "notbeforetime":
+			f.StringVar(&base.StringVar{
+				Name:   "not-before-time",
+				Target: &c.flagNotBeforeTime,
+				Usage:  `The time before which the added principals do not receive the role's grants, either in RFC 3339 format or as a duration from now such as "30m".`,
+			})
+		case "expirationtime":
+			f.StringVar(&base.StringVar{
+				Name:   "expiration-time",
+				Target: &c.flagExpirationTime,
+				Usage:  `The time at which the added principals are removed from the role, either in RFC 3339 format or as a duration from now such as "4h".`,
+			})
 		case "grant":
 			f.StringSliceVar(&base.StringSliceVar{
 				Name:   "grant",
 				Target: &c.flagGrants,
 				Usage:  "The grants to add, remove, or set. May be specified multiple times. Can be in compact string format or JSON (be sure to escape JSON properly).",
 			})
+		case "userid":
+			f.StringVar(&base.StringVar{
+				Name:   "user-id",
+				Target: &c.flagUserId,
+				Usage:  "The ID of the user whose grants to explain",
+			})
+		case "resourceid":
+			f.StringVar(&base.StringVar{
+				Name:   "resource-id",
+				Target: &c.flagResourceId,
+				Usage:  "The ID of the resource the action is performed on",
+			})
+		case "action":
+			f.StringVar(&base.StringVar{
+				Name:   "action",
+				Target: &c.flagAction,
+				Usage:  "The action to explain",
+			})
 		}
 	}
 }
@@ -115,6 +159,14 @@ func generateRoleTableOutput(in *roles.Role) string {
 		base.ScopeInfoForOutput(in.Scope, maxLength),
 	}
 
+	if len(in.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, in.AuthorizedActions),
+		)
+	}
+
 	if len(in.Principals) > 0 {
 		ret = append(ret,
 			"",
@@ -127,6 +179,16 @@ func generateRoleTableOutput(in *roles.Role) string {
 			fmt.Sprintf("      Type:         %s", principal.Type),
 			fmt.Sprintf("      Scope ID:     %s", principal.ScopeId),
 		)
+		if !principal.NotBeforeTime.IsZero() {
+			ret = append(ret,
+				fmt.Sprintf("      Not Before:   %s", principal.NotBeforeTime.Local().Format(time.RFC1123)),
+			)
+		}
+		if !principal.ExpirationTime.IsZero() {
+			ret = append(ret,
+				fmt.Sprintf("      Expiration:   %s", principal.ExpirationTime.Local().Format(time.RFC1123)),
+			)
+		}
 	}
 	if len(in.Grants) > 0 {
 		ret = append(ret,
@@ -142,3 +204,56 @@ func generateRoleTableOutput(in *roles.Role) string {
 	}
 	return base.WrapForHelpText(ret)
 }
+
+func generateExplanationTableOutput(in *roles.AuthorizationExplanation) string {
+	nonAttributeMap := map[string]interface{}{
+		"User ID":       in.UserId,
+		"Resource ID":   in.ResourceId,
+		"Resource Type": in.ResourceType,
+		"Scope ID":      in.ScopeId,
+		"Action":        in.Action,
+		"Allowed":       in.Allowed,
+		"Reason":        in.Reason,
+	}
+	if in.ParentId != "" {
+		nonAttributeMap["Parent ID"] = in.ParentId
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	ret := []string{
+		"",
+		"Authorization explanation:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	if len(in.Grants) > 0 {
+		ret = append(ret,
+			"",
+			"  Grants:",
+		)
+	}
+	for i, grant := range in.Grants {
+		if i > 0 {
+			ret = append(ret, "")
+		}
+		ret = append(ret,
+			fmt.Sprintf("    Grant:        %s", grant.CanonicalGrant),
+			fmt.Sprintf("      Role ID:    %s", grant.RoleId),
+			fmt.Sprintf("      Effect:     %s", grant.Effect),
+			fmt.Sprintf("      Matched:    %t", grant.Matched),
+			fmt.Sprintf("      Decisive:   %t", grant.Decisive),
+			fmt.Sprintf("      Reason:     %s", grant.Reason),
+		)
+	}
+	return base.WrapForHelpText(ret)
+}
+
+// parseTime parses a time given either in RFC 3339 format or as a duration
+// relative to now.
+func parseTime(in string) (time.Time, error) {
+	if d, err := time.ParseDuration(in); err == nil {
+		return time.Now().Add(d), nil
+	}
+	return time.Parse(time.RFC3339, in)
+}
@@ -22,10 +22,15 @@ type Command struct {
 
 	Func string
 
-	flagScope        string
-	flagGrantScopeId string
-	flagPrincipals   []string
-	flagGrants       []string
+	flagScope          string
+	flagGrantScopeId   string
+	flagPrincipals     []string
+	flagGrants         []string
+	flagNotBeforeTime  string
+	flagExpirationTime string
+	flagUserId         string
+	flagResourceId     string
+	flagAction         string
 }
 
 func (c *Command) Synopsis() string {
@@ -36,6 +41,8 @@ func (c *Command) Synopsis() string {
 		return principalsGrantsSynopsisFunc(c.Func, true)
 	case "add-grants", "set-grants", "remove-grants":
 		return principalsGrantsSynopsisFunc(c.Func, false)
+	case "explain":
+		return "Explain whether a user's grants allow an action on a resource"
 	}
 	return ""
 }
@@ -48,6 +55,7 @@ var helpMap = func() map[string]func() string {
 	ret["add-grants"] = addPrincipalsHelp
 	ret["set-grants"] = setPrincipalsHelp
 	ret["remove-grants"] = removePrincipalsHelp
+	ret["explain"] = explainHelp
 	return ret
 }
 
@@ -56,13 +64,14 @@ var flagsMap = map[string][]string{
 	"update":            {"id", "name", "description", "grantscopeid", "version"},
 	"read":              {"id"},
 	"delete":            {"id"},
-	"list":              {"scope-id"},
-	"add-principals":    {"id", "principal", "version"},
+	"list":              {"scope-id", "recursive", "filter", "page-size", "page-token"},
+	"add-principals":    {"id", "principal", "version", "notbeforetime", "expirationtime"},
 	"set-principals":    {"id", "principal", "version"},
 	"remove-principals": {"id", "principal", "version"},
 	"add-grants":        {"id", "grant", "version"},
 	"set-grants":        {"id", "grant", "version"},
 	"remove-grants":     {"id", "grant", "version"},
+	"explain":           {"scope-id", "userid", "resourceid", "action"},
 }
 
 func (c *Command) Help() string {
@@ -116,6 +125,10 @@ func (c *Command) Run(args []string) int {
 		return 2
 	}
 
+	if c.Func == "explain" {
+		return c.runExplain(roles.NewClient(client))
+	}
+
 	var opts []roles.Option
 
 	switch c.FlagName {
@@ -148,6 +161,22 @@ func (c *Command) Run(args []string) int {
 			c.UI.Error("No principals supplied via -principal")
 			return 1
 		}
+		if c.flagNotBeforeTime != "" {
+			t, err := parseTime(c.flagNotBeforeTime)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing -not-before-time: %s", err))
+				return 1
+			}
+			opts = append(opts, roles.WithNotBeforeTime(t))
+		}
+		if c.flagExpirationTime != "" {
+			t, err := parseTime(c.flagExpirationTime)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing -expiration-time: %s", err))
+				return 1
+			}
+			opts = append(opts, roles.WithExpirationTime(t))
+		}
 
 	case "add-grants", "remove-grants":
 		if len(c.flagGrants) == 0 {
@@ -188,6 +217,21 @@ func (c *Command) Run(args []string) int {
 		}
 	}
 
+	if c.Func == "list" {
+		if c.FlagFilter != "" {
+			opts = append(opts, roles.WithFilter(c.FlagFilter))
+		}
+		if c.FlagRecursive {
+			opts = append(opts, roles.WithRecursive(true))
+		}
+		if c.FlagPageSize > 0 {
+			opts = append(opts, roles.WithPageSize(uint32(c.FlagPageSize)))
+		}
+		if c.FlagPageToken != "" {
+			opts = append(opts, roles.WithPageToken(c.FlagPageToken))
+		}
+	}
+
 	roleClient := roles.NewClient(client)
 
 	// Perform check-and-set when needed
@@ -302,6 +346,11 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("    Version:     %d", r.Version),
 					)
 				}
+				if c.FlagRecursive {
+					output = append(output,
+						fmt.Sprintf("    Scope ID:    %s", r.ScopeId),
+					)
+				}
 				if r.Name != "" {
 					output = append(output,
 						fmt.Sprintf("    Name:        %s", r.Name),
@@ -312,9 +361,16 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("    Description: %s", r.Description),
 					)
 				}
+				if len(r.AuthorizedActions) > 0 {
+					output = append(output,
+						"    Authorized Actions:",
+						base.WrapSlice(6, r.AuthorizedActions),
+					)
+				}
 			}
 			c.UI.Output(base.WrapForHelpText(output))
 		}
+		base.PrintNextPageToken(c.UI, listResult.GetNextPageToken())
 		return 0
 	}
 
@@ -333,3 +389,42 @@ func (c *Command) Run(args []string) int {
 
 	return 0
 }
+
+func (c *Command) runExplain(roleClient *roles.Client) int {
+	switch {
+	case c.flagUserId == "":
+		c.UI.Error("User ID must be passed in via -user-id")
+		return 1
+	case c.flagResourceId == "":
+		c.UI.Error("Resource ID must be passed in via -resource-id")
+		return 1
+	case c.flagAction == "":
+		c.UI.Error("Action must be passed in via -action")
+		return 1
+	}
+
+	result, err := roleClient.Explain(c.Context, c.FlagScopeId, c.flagUserId, c.flagResourceId, c.flagAction)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.UI.Error(fmt.Sprintf("Error from controller when performing explain on roles: %s", base.PrintApiError(apiErr)))
+			return 1
+		}
+		c.UI.Error(fmt.Sprintf("Error trying to explain roles: %s", err.Error()))
+		return 2
+	}
+
+	explanation := result.GetItem().(*roles.AuthorizationExplanation)
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateExplanationTableOutput(explanation))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(explanation)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	}
+
+	return 0
+}
@@ -35,7 +35,7 @@ var flagsMap = map[string][]string{
 	"update": {"id", "name", "description", "version"},
 	"read":   {"id"},
 	"delete": {"id"},
-	"list":   {"scope-id"},
+	"list":   {"scope-id", "recursive", "filter", "page-size", "page-token"},
 }
 
 func (c *Command) Help() string {
@@ -127,6 +127,21 @@ func (c *Command) Run(args []string) int {
 		opts = append(opts, scopes.WithSkipDefaultRoleCreation(c.flagSkipDefaultRoleCreation))
 	}
 
+	if c.Func == "list" {
+		if c.FlagFilter != "" {
+			opts = append(opts, scopes.WithFilter(c.FlagFilter))
+		}
+		if c.FlagRecursive {
+			opts = append(opts, scopes.WithRecursive(true))
+		}
+		if c.FlagPageSize > 0 {
+			opts = append(opts, scopes.WithPageSize(uint32(c.FlagPageSize)))
+		}
+		if c.FlagPageToken != "" {
+			opts = append(opts, scopes.WithPageToken(c.FlagPageToken))
+		}
+	}
+
 	scopeClient := scopes.NewClient(client)
 
 	// Perform check-and-set when needed
@@ -229,6 +244,11 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("    Version:      %d", s.Version),
 					)
 				}
+				if c.FlagRecursive {
+					output = append(output,
+						fmt.Sprintf("    Scope ID:     %s", s.ScopeId),
+					)
+				}
 				if s.Name != "" {
 					output = append(output,
 						fmt.Sprintf("    Name:         %s", s.Name),
@@ -239,9 +259,16 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("    Description:  %s", s.Description),
 					)
 				}
+				if len(s.AuthorizedActions) > 0 {
+					output = append(output,
+						"    Authorized Actions:",
+						base.WrapSlice(6, s.AuthorizedActions),
+					)
+				}
 			}
 			c.UI.Output(base.WrapForHelpText(output))
 		}
+		base.PrintNextPageToken(c.UI, listResult.GetNextPageToken())
 		return 0
 	}
 
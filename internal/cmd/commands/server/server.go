@@ -175,6 +175,7 @@ func (c *Command) Run(args []string) int {
 	var clusterAddr string
 	var foundApi bool
 	var foundProxy bool
+	var foundControllerProxy bool
 	for _, lnConfig := range c.Config.Listeners {
 		switch len(lnConfig.Purpose) {
 		case 0:
@@ -193,6 +194,8 @@ func (c *Command) Run(args []string) int {
 				foundApi = true
 			case "proxy":
 				foundProxy = true
+			case "controller-proxy":
+				foundControllerProxy = true
 			default:
 				c.UI.Error(fmt.Sprintf("Unknown listener purpose %q", lnConfig.Purpose[0]))
 				return 1
@@ -212,10 +215,17 @@ func (c *Command) Run(args []string) int {
 			c.UI.Error(`Config activates controller but no listener with "cluster" purpose found`)
 			return 1
 		}
+	} else if foundControllerProxy {
+		c.UI.Error(`Listener with "controller-proxy" purpose found but config does not activate controller`)
+		return 1
 	}
 	if c.Config.Worker != nil {
-		if !foundProxy {
-			c.UI.Error(`Config activates worker but no listener with "proxy" purpose found`)
+		if !foundProxy && len(c.Config.Worker.Upstreams) == 0 && !c.Config.Worker.ReverseConnect {
+			c.UI.Error(`Config activates worker but no listener with "proxy" purpose, upstreams or reverse_connect found`)
+			return 1
+		}
+		if len(c.Config.Worker.Upstreams) > 0 && c.Config.Worker.ReverseConnect {
+			c.UI.Error(`Worker "upstreams" and "reverse_connect" cannot both be set`)
 			return 1
 		}
 		if c.Config.Controller != nil {
@@ -233,7 +243,7 @@ func (c *Command) Run(args []string) int {
 			c.Config.Worker.Controllers = []string{clusterAddr}
 		}
 	}
-	if err := c.SetupListeners(c.UI, c.Config.SharedConfig, []string{"api", "cluster", "proxy"}); err != nil {
+	if err := c.SetupListeners(c.UI, c.Config.SharedConfig, []string{"api", "cluster", "proxy", "controller-proxy"}); err != nil {
 		c.UI.Error(err.Error())
 		return 1
 	}
@@ -253,6 +263,14 @@ func (c *Command) Run(args []string) int {
 		}
 		c.InfoKeys = append(c.InfoKeys, "public cluster addr")
 		c.Info["public cluster addr"] = c.Config.Controller.PublicClusterAddr
+		if err := c.SetupControllerPublicProxyAddress(c.Config, ""); err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+		if c.Config.Controller.PublicProxyAddr != "" {
+			c.InfoKeys = append(c.InfoKeys, "public proxy addr")
+			c.Info["public proxy addr"] = c.Config.Controller.PublicProxyAddr
+		}
 	}
 
 	// Write out the PID to the file now that server has successfully started
@@ -70,6 +70,14 @@ func generateSessionTableOutput(in *sessions.Session) string {
 		base.ScopeInfoForOutput(in.Scope, maxLength),
 	}
 
+	if len(in.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, in.AuthorizedActions),
+		)
+	}
+
 	if len(in.States) > 0 {
 		ret = append(ret,
 			"",
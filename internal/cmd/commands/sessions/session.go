@@ -2,6 +2,8 @@ package sessions
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/hashicorp/boundary/api"
@@ -21,6 +23,9 @@ type Command struct {
 	*base.Command
 
 	Func string
+
+	flagConnectionId string
+	flagOutputDir    string
 }
 
 func (c *Command) Synopsis() string {
@@ -28,9 +33,12 @@ func (c *Command) Synopsis() string {
 }
 
 var flagsMap = map[string][]string{
-	"read":   {"id"},
-	"cancel": {"id"},
-	"list":   {"scope-id"},
+	"read":                 {"id"},
+	"cancel":               {"id"},
+	"list":                 {"scope-id", "recursive", "filter", "page-size", "page-token"},
+	"download-recording":   {"id"},
+	"terminate-connection": {"id"},
+	"watch":                {"scope-id", "recursive"},
 }
 
 func (c *Command) Help() string {
@@ -59,6 +67,36 @@ func (c *Command) Help() string {
 			"",
 			"",
 		})
+	case "terminate-connection":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary sessions terminate-connection [options] [args]",
+			"",
+			"  Terminate a single connection of the session specified by ID, leaving the session and its other connections open. Example:",
+			"",
+			`    $ boundary sessions terminate-connection -id s_1234567890 -connection-id sc_1234567890`,
+			"",
+			"",
+		})
+	case "watch":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary sessions watch [options] [args]",
+			"",
+			"  Print the events of the sessions in the scope specified by ID and of their connections as they happen, until interrupted. Example:",
+			"",
+			`    $ boundary sessions watch -scope-id p_1234567890`,
+			"",
+			"",
+		})
+	case "download-recording":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary sessions download-recording [options] [args]",
+			"",
+			"  Download the recordings of the ssh channels of the session specified by ID. If an output directory is given, each recording is written to a file named after its connection and channel that can be played back with asciinema. Example:",
+			"",
+			`    $ boundary sessions download-recording -id s_1234567890 -output-dir ./recordings`,
+			"",
+			"",
+		})
 	default:
 		helpStr = helpMap[c.Func]()
 	}
@@ -70,6 +108,26 @@ func (c *Command) Flags() *base.FlagSets {
 	f := set.NewFlagSet("Command Options")
 	common.PopulateCommonFlags(c.Command, f, resource.Session.String(), flagsMap[c.Func])
 
+	switch c.Func {
+	case "terminate-connection":
+		f.StringVar(&base.StringVar{
+			Name:   "connection-id",
+			Target: &c.flagConnectionId,
+			Usage:  "The ID of the connection to terminate.",
+		})
+	case "download-recording":
+		f.StringVar(&base.StringVar{
+			Name:   "connection-id",
+			Target: &c.flagConnectionId,
+			Usage:  "If set, only the recordings of the given connection are downloaded.",
+		})
+		f.StringVar(&base.StringVar{
+			Name:   "output-dir",
+			Target: &c.flagOutputDir,
+			Usage:  "If set, recordings are written to files in the given directory instead of being printed.",
+		})
+	}
+
 	return set
 }
 
@@ -101,6 +159,10 @@ func (c *Command) Run(args []string) int {
 		c.UI.Error("Scope ID must be passed in via -scope-id")
 		return 1
 	}
+	if c.Func == "terminate-connection" && c.flagConnectionId == "" {
+		c.UI.Error("Connection ID must be passed in via -connection-id")
+		return 1
+	}
 
 	client, err := c.Client()
 	if err != nil {
@@ -108,10 +170,32 @@ func (c *Command) Run(args []string) int {
 		return 2
 	}
 
+	var opts []sessions.Option
+	switch c.Func {
+	case "watch":
+		if c.FlagRecursive {
+			opts = append(opts, sessions.WithRecursive(true))
+		}
+	case "list":
+		if c.FlagFilter != "" {
+			opts = append(opts, sessions.WithFilter(c.FlagFilter))
+		}
+		if c.FlagRecursive {
+			opts = append(opts, sessions.WithRecursive(true))
+		}
+		if c.FlagPageSize > 0 {
+			opts = append(opts, sessions.WithPageSize(uint32(c.FlagPageSize)))
+		}
+		if c.FlagPageToken != "" {
+			opts = append(opts, sessions.WithPageToken(c.FlagPageToken))
+		}
+	}
+
 	sessionClient := sessions.NewClient(client)
 
 	var result api.GenericResult
 	var listResult api.GenericListResult
+	var recordingResult *sessions.SessionRecordingListResult
 
 	switch c.Func {
 	case "read":
@@ -119,11 +203,22 @@ func (c *Command) Run(args []string) int {
 	case "cancel":
 		result, err = sessionClient.Cancel(c.Context, c.FlagId, 0, sessions.WithAutomaticVersioning(true))
 	case "list":
-		listResult, err = sessionClient.List(c.Context, c.FlagScopeId)
+		listResult, err = sessionClient.List(c.Context, c.FlagScopeId, opts...)
+	case "terminate-connection":
+		result, err = sessionClient.TerminateConnection(c.Context, c.FlagId, c.flagConnectionId)
+	case "download-recording":
+		recordingResult, err = sessionClient.DownloadRecording(c.Context, c.FlagId, c.flagConnectionId)
+	case "watch":
+		err = sessionClient.Watch(c.Context, c.FlagScopeId, c.printEvent, opts...)
 	}
 
 	plural := "session"
-	if c.Func == "list" {
+	switch c.Func {
+	case "list":
+		plural = "sessions"
+	case "download-recording":
+		plural = "session recordings"
+	case "watch":
 		plural = "sessions"
 	}
 	if err != nil {
@@ -136,6 +231,10 @@ func (c *Command) Run(args []string) int {
 	}
 
 	switch c.Func {
+	case "watch":
+		return 0
+	case "download-recording":
+		return c.printRecordings(recordingResult.Items)
 	case "list":
 		listedSessions := listResult.GetItems().([]*sessions.Session)
 		switch base.Format(c.UI) {
@@ -174,9 +273,21 @@ func (c *Command) Run(args []string) int {
 					fmt.Sprintf("    User ID:          %s", t.UserId),
 					fmt.Sprintf("    Target ID:        %s", t.TargetId),
 				)
+				if c.FlagRecursive {
+					output = append(output,
+						fmt.Sprintf("    Scope ID:         %s", t.ScopeId),
+					)
+				}
+				if len(t.AuthorizedActions) > 0 {
+					output = append(output,
+						"    Authorized Actions:",
+						base.WrapSlice(6, t.AuthorizedActions),
+					)
+				}
 			}
 			c.UI.Output(base.WrapForHelpText(output))
 		}
+		base.PrintNextPageToken(c.UI, listResult.GetNextPageToken())
 		return 0
 	}
 
@@ -195,3 +306,91 @@ func (c *Command) Run(args []string) int {
 
 	return 0
 }
+
+func (c *Command) printRecordings(recordings []*sessions.SessionRecording) int {
+	if c.flagOutputDir != "" {
+		if err := os.MkdirAll(c.flagOutputDir, 0o700); err != nil {
+			c.UI.Error(fmt.Sprintf("Error creating output directory: %s", err))
+			return 2
+		}
+		for _, r := range recordings {
+			path := filepath.Join(c.flagOutputDir, fmt.Sprintf("%s-%d.cast", r.ConnectionId, r.ChannelId))
+			if err := os.WriteFile(path, []byte(r.Asciicast), 0o600); err != nil {
+				c.UI.Error(fmt.Sprintf("Error writing recording %s: %s", r.Id, err))
+				return 2
+			}
+			c.UI.Info(fmt.Sprintf("Wrote recording %s to %s", r.Id, path))
+		}
+		if len(recordings) == 0 {
+			c.UI.Output("No session recordings found")
+		}
+		return 0
+	}
+
+	switch base.Format(c.UI) {
+	case "json":
+		if len(recordings) == 0 {
+			c.UI.Output("null")
+			return 0
+		}
+		b, err := base.JsonFormatter{}.Format(recordings)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+
+	case "table":
+		if len(recordings) == 0 {
+			c.UI.Output("No session recordings found")
+			return 0
+		}
+		output := []string{
+			"",
+			"Session recording information:",
+		}
+		for i, r := range recordings {
+			if i > 0 {
+				output = append(output, "")
+			}
+			output = append(output,
+				fmt.Sprintf("  ID:                 %s", r.Id),
+				fmt.Sprintf("    Connection ID:    %s", r.ConnectionId),
+				fmt.Sprintf("    Channel ID:       %d", r.ChannelId),
+				fmt.Sprintf("    Start Time:       %s", r.StartTime.Local().Format(time.RFC1123)),
+				fmt.Sprintf("    End Time:         %s", r.EndTime.Local().Format(time.RFC1123)),
+			)
+		}
+		c.UI.Output(base.WrapForHelpText(output))
+	}
+	return 0
+}
+
+func (c *Command) printEvent(e *sessions.SessionConnectionEvent) error {
+	switch base.Format(c.UI) {
+	case "json":
+		b, err := base.JsonFormatter{}.Format(e)
+		if err != nil {
+			return fmt.Errorf("error formatting as JSON: %w", err)
+		}
+		c.UI.Output(string(b))
+
+	case "table":
+		line := fmt.Sprintf("%s  %-18s  %s", e.Time.Local().Format(time.RFC3339), e.Type, e.SessionId)
+		if e.ConnectionId != "" {
+			line += "  " + e.ConnectionId
+		}
+		switch e.Type {
+		case "connected":
+			line += fmt.Sprintf("  client %s:%d", e.ClientTcpAddress, e.ClientTcpPort)
+		case "status":
+			line += fmt.Sprintf("  up %d  down %d", e.BytesUp, e.BytesDown)
+		case "closed":
+			line += fmt.Sprintf("  up %d  down %d  reason %q", e.BytesUp, e.BytesDown, e.ClosedReason)
+		case "session_terminated":
+			line += fmt.Sprintf("  reason %q", e.ClosedReason)
+		}
+		c.UI.Output(line)
+	}
+	return nil
+}
@@ -24,6 +24,19 @@ func hostSetSynopsisFunc(inFunc string) string {
 	return wordwrap.WrapString(fmt.Sprintf("%s a target", in), base.TermWidth)
 }
 
+func credentialSynopsisFunc(inFunc string) string {
+	var in string
+	switch {
+	case strings.HasPrefix(inFunc, "add"):
+		in = "Add credentials to"
+	case strings.HasPrefix(inFunc, "set"):
+		in = "Set the full contents of the credentials on"
+	case strings.HasPrefix(inFunc, "remove"):
+		in = "Remove credentials from"
+	}
+	return wordwrap.WrapString(fmt.Sprintf("%s a target", in), base.TermWidth)
+}
+
 func generateTargetTableOutput(in *targets.Target) string {
 	nonAttributeMap := map[string]interface{}{
 		"ID":                       in.Id,
@@ -41,6 +54,12 @@ func generateTargetTableOutput(in *targets.Target) string {
 	if in.Description != "" {
 		nonAttributeMap["Description"] = in.Description
 	}
+	if in.WorkerFilter != "" {
+		nonAttributeMap["Worker Filter"] = in.WorkerFilter
+	}
+	if in.SessionIdleTimeoutSeconds != 0 {
+		nonAttributeMap["Session Idle Timeout Seconds"] = in.SessionIdleTimeoutSeconds
+	}
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, in.Attributes, keySubstMap)
 
@@ -67,6 +86,14 @@ func generateTargetTableOutput(in *targets.Target) string {
 		base.ScopeInfoForOutput(in.Scope, maxLength),
 	}
 
+	if len(in.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, in.AuthorizedActions),
+		)
+	}
+
 	if len(in.HostSets) > 0 {
 		ret = append(ret,
 			"",
@@ -80,6 +107,14 @@ func generateTargetTableOutput(in *targets.Target) string {
 		}
 	}
 
+	if len(in.CredentialIds) > 0 {
+		ret = append(ret,
+			"  Credential IDs:",
+			base.WrapSlice(4, in.CredentialIds),
+			"",
+		)
+	}
+
 	if len(in.Attributes) > 0 {
 		ret = append(ret,
 			"  Attributes:",
@@ -118,11 +153,38 @@ func generateAuthorizationTableOutput(in *targets.SessionAuthorization) string {
 		base.WrapMap(2, maxLength+2, nonAttributeMap),
 	)
 
+	if len(in.Credentials) > 0 {
+		ret = append(ret,
+			"",
+			"  Credentials:",
+		)
+		for i, cred := range in.Credentials {
+			if i > 0 {
+				ret = append(ret, "")
+			}
+			m := map[string]interface{}{
+				"Credential ID":       cred.CredentialId,
+				"Credential Store ID": cred.CredentialStoreId,
+				"Type":                cred.Type,
+				"Username":            cred.Username,
+				"Password":            cred.Password,
+			}
+			if cred.Name != "" {
+				m["Name"] = cred.Name
+			}
+			ret = append(ret, base.WrapMap(4, len("Credential Store ID"), m))
+		}
+	}
+
 	return base.WrapForHelpText(ret)
 }
 
 var keySubstMap = map[string]string{
-	"default_port": "Default Port",
+	"default_port":             "Default Port",
+	"header_hmacs":             "Header HMACs",
+	"host_keys":                "Host Keys",
+	"host_ca_keys":             "Host CA Keys",
+	"insecure_ignore_host_key": "Insecure Ignore Host Key",
 }
 
 func exampleOutput() string {
@@ -0,0 +1,313 @@
+package targets
+
+import (
+	"fmt"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/targets"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/sdk/strutil"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*HttpCommand)(nil)
+var _ cli.CommandAutocomplete = (*HttpCommand)(nil)
+
+type HttpCommand struct {
+	*base.Command
+
+	Func                       string
+	flagDefaultPort            string
+	flagSessionMaxSeconds      string
+	flagSessionConnectionLimit string
+	flagSessionIdleTimeout     string
+	flagWorkerFilter           string
+	flagHeaders                []string
+}
+
+func (c *HttpCommand) Synopsis() string {
+	return fmt.Sprintf("%s an http-type target", textproto.CanonicalMIMEHeaderKey(c.Func))
+}
+
+var httpFlagsMap = map[string][]string{
+	"create": {"scope-id", "name", "description", "default-port", "session-max-seconds", "session-connection-limit", "session-idle-timeout", "worker-filter", "header"},
+	"update": {"id", "name", "description", "version", "default-port", "session-max-seconds", "session-connection-limit", "session-idle-timeout", "worker-filter", "header"},
+}
+
+func (c *HttpCommand) Help() string {
+	var info string
+	switch c.Func {
+	case "create":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary targets create http [options] [args]",
+			"",
+			"  Create an http-type target. The worker proxies each HTTP request to the endpoint, adding the given headers. Example:",
+			"",
+			`    $ boundary targets create http -name admin-ui -default-port 8080 -header "X-Forwarded-User: ops"`,
+			"",
+			"",
+		})
+
+	case "update":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary targets update http [options] [args]",
+			"",
+			"  Update an http-type target given its ID. Example:",
+			"",
+			`    $ boundary targets update http -id thttp_1234567890 -header "X-Forwarded-User: devops"`,
+			"",
+			"",
+		})
+	}
+	return info + c.Flags().Help()
+}
+
+func (c *HttpCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "http-type target", httpFlagsMap[c.Func])
+
+	for _, name := range httpFlagsMap[c.Func] {
+		switch name {
+		case "default-port":
+			f.StringVar(&base.StringVar{
+				Name:   "default-port",
+				Target: &c.flagDefaultPort,
+				Usage:  "The default port to set on the target.",
+			})
+		case "session-max-seconds":
+			f.StringVar(&base.StringVar{
+				Name:   "session-max-seconds",
+				Target: &c.flagSessionMaxSeconds,
+				Usage:  `The maximum lifetime of the session, including all connections. Can be specified as an integer number of seconds or a duration string.`,
+			})
+		case "session-connection-limit":
+			f.StringVar(&base.StringVar{
+				Name:   "session-connection-limit",
+				Target: &c.flagSessionConnectionLimit,
+				Usage:  "The maximum number of connections allowed for a session. -1 means unlimited.",
+			})
+		case "session-idle-timeout":
+			f.StringVar(&base.StringVar{
+				Name:   "session-idle-timeout",
+				Target: &c.flagSessionIdleTimeout,
+				Usage:  `The time without traffic after which a connection of a session is closed. A session whose connections have all been closed this way is terminated. Can be specified as an integer number of seconds or a duration string; 0 means connections are never closed for being idle.`,
+			})
+		case "worker-filter":
+			f.StringVar(&base.StringVar{
+				Name:   "worker-filter",
+				Target: &c.flagWorkerFilter,
+				Usage:  `A boolean expression to filter which workers can handle sessions for this target, e.g. '"/tags/region" contains "us-east-1"'.`,
+			})
+		case "header":
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   "header",
+				Target: &c.flagHeaders,
+				Usage:  `A header, in "Name: value" form, which the worker adds to every request it proxies to the endpoint. May be specified multiple times; on update, the given headers replace all existing headers. Use "null" to remove all headers.`,
+			})
+		}
+	}
+
+	return set
+}
+
+func (c *HttpCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *HttpCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *HttpCommand) Run(args []string) int {
+	if c.Func == "" {
+		return cli.RunResultHelp
+	}
+
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if strutil.StrListContains(httpFlagsMap[c.Func], "id") && c.FlagId == "" {
+		c.UI.Error("ID is required but not passed in via -id")
+		return 1
+	}
+	if strutil.StrListContains(httpFlagsMap[c.Func], "scope-id") && c.FlagScopeId == "" {
+		c.UI.Error("Scope ID must be passed in via -scope-id")
+		return 1
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+
+	var opts []targets.Option
+
+	switch c.FlagName {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultName())
+	default:
+		opts = append(opts, targets.WithName(c.FlagName))
+	}
+
+	switch c.FlagDescription {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultDescription())
+	default:
+		opts = append(opts, targets.WithDescription(c.FlagDescription))
+	}
+
+	switch c.flagDefaultPort {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultHttpTargetDefaultPort())
+	default:
+		port, err := strconv.ParseUint(c.flagDefaultPort, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagDefaultPort, err))
+			return 1
+		}
+		opts = append(opts, targets.WithHttpTargetDefaultPort(uint32(port)))
+	}
+
+	switch c.flagSessionMaxSeconds {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultSessionMaxSeconds())
+	default:
+		var final uint32
+		dur, err := strconv.ParseUint(c.flagSessionMaxSeconds, 10, 32)
+		if err == nil {
+			final = uint32(dur)
+		} else {
+			dur, err := time.ParseDuration(c.flagSessionMaxSeconds)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionMaxSeconds, err))
+				return 1
+			}
+			final = uint32(dur.Seconds())
+		}
+		opts = append(opts, targets.WithSessionMaxSeconds(final))
+	}
+
+	switch c.flagSessionConnectionLimit {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultSessionConnectionLimit())
+	default:
+		limit, err := strconv.ParseInt(c.flagSessionConnectionLimit, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionConnectionLimit, err))
+			return 1
+		}
+		opts = append(opts, targets.WithSessionConnectionLimit(int32(limit)))
+	}
+
+	switch c.flagSessionIdleTimeout {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultSessionIdleTimeoutSeconds())
+	default:
+		var final uint32
+		dur, err := strconv.ParseUint(c.flagSessionIdleTimeout, 10, 32)
+		if err == nil {
+			final = uint32(dur)
+		} else {
+			dur, err := time.ParseDuration(c.flagSessionIdleTimeout)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionIdleTimeout, err))
+				return 1
+			}
+			final = uint32(dur.Seconds())
+		}
+		opts = append(opts, targets.WithSessionIdleTimeoutSeconds(final))
+	}
+
+	switch c.flagWorkerFilter {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultWorkerFilter())
+	default:
+		opts = append(opts, targets.WithWorkerFilter(c.flagWorkerFilter))
+	}
+
+	switch {
+	case len(c.flagHeaders) == 0:
+	case len(c.flagHeaders) == 1 && c.flagHeaders[0] == "null":
+		opts = append(opts, targets.DefaultHttpTargetHeaders())
+	default:
+		headers := make(map[string]string, len(c.flagHeaders))
+		for _, h := range c.flagHeaders {
+			name, value, ok := strings.Cut(h, ":")
+			if !ok || strings.TrimSpace(name) == "" {
+				c.UI.Error(fmt.Sprintf("Error parsing header %q: must be in \"Name: value\" form", h))
+				return 1
+			}
+			headers[strings.TrimSpace(name)] = strings.TrimSpace(value)
+		}
+		opts = append(opts, targets.WithHttpTargetHeaders(headers))
+	}
+
+	targetClient := targets.NewClient(client)
+
+	// Perform check-and-set when needed
+	var version uint32
+	switch c.Func {
+	case "create":
+		// These don't update so don't need the existing version
+	default:
+		switch c.FlagVersion {
+		case 0:
+			opts = append(opts, targets.WithAutomaticVersioning(true))
+		default:
+			version = uint32(c.FlagVersion)
+		}
+	}
+
+	var result api.GenericResult
+
+	switch c.Func {
+	case "create":
+		result, err = targetClient.Create(c.Context, "http", c.FlagScopeId, opts...)
+	case "update":
+		result, err = targetClient.Update(c.Context, c.FlagId, version, opts...)
+	}
+
+	plural := "http-type target"
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.UI.Error(fmt.Sprintf("Error from controller when performing %s on %s: %s", c.Func, plural, base.PrintApiError(apiErr)))
+			return 1
+		}
+		c.UI.Error(fmt.Sprintf("Error trying to %s %s: %s", c.Func, plural, err.Error()))
+		return 2
+	}
+
+	target := result.GetItem().(*targets.Target)
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateTargetTableOutput(target))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(target)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	}
+
+	return 0
+}
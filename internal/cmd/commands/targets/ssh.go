@@ -0,0 +1,349 @@
+package targets
+
+import (
+	"fmt"
+	"net/textproto"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/targets"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/sdk/strutil"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*SshCommand)(nil)
+var _ cli.CommandAutocomplete = (*SshCommand)(nil)
+
+type SshCommand struct {
+	*base.Command
+
+	Func                       string
+	flagDefaultPort            string
+	flagSessionMaxSeconds      string
+	flagSessionConnectionLimit string
+	flagSessionIdleTimeout     string
+	flagWorkerFilter           string
+	flagHostKeys               string
+	flagHostCaKeys             string
+	flagInsecureIgnoreHostKey  string
+}
+
+func (c *SshCommand) Synopsis() string {
+	return fmt.Sprintf("%s a ssh-type target", textproto.CanonicalMIMEHeaderKey(c.Func))
+}
+
+var sshFlagsMap = map[string][]string{
+	"create": {"scope-id", "name", "description", "default-port", "session-max-seconds", "session-connection-limit", "session-idle-timeout", "worker-filter", "host-keys", "host-ca-keys", "insecure-ignore-host-key"},
+	"update": {"id", "name", "description", "version", "default-port", "session-max-seconds", "session-connection-limit", "session-idle-timeout", "worker-filter", "host-keys", "host-ca-keys", "insecure-ignore-host-key"},
+}
+
+func (c *SshCommand) Help() string {
+	var info string
+	switch c.Func {
+	case "create":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary targets create ssh [options] [args]",
+			"",
+			"  Create a ssh-type target. The worker refuses to connect to the endpoint unless its host key matches one of -host-keys or is certified by one of -host-ca-keys, or -insecure-ignore-host-key is set. Example:",
+			"",
+			`    $ boundary targets create ssh -name prodops -description "Ssh target for ProdOps" -host-keys file:///etc/ssh/ssh_host_ed25519_key.pub`,
+			"",
+			"",
+		})
+
+	case "update":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary targets ssh update [options] [args]",
+			"",
+			"  Update a ssh-type target given its ID. Example:",
+			"",
+			`    $ boundary targets ssh update -id tssh_1234567890 -name "devops" -description "Ssh target for DevOps"`,
+			"",
+			"",
+		})
+	}
+	return info + c.Flags().Help()
+}
+
+func (c *SshCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "ssh-type target", sshFlagsMap[c.Func])
+
+	for _, name := range sshFlagsMap[c.Func] {
+		switch name {
+		case "default-port":
+			f.StringVar(&base.StringVar{
+				Name:   "default-port",
+				Target: &c.flagDefaultPort,
+				Usage:  "The default port to set on the target.",
+			})
+		case "session-max-seconds":
+			f.StringVar(&base.StringVar{
+				Name:   "session-max-seconds",
+				Target: &c.flagSessionMaxSeconds,
+				Usage:  `The maximum lifetime of the session, including all connections. Can be specified as an integer number of seconds or a duration string.`,
+			})
+		case "session-connection-limit":
+			f.StringVar(&base.StringVar{
+				Name:   "session-connection-limit",
+				Target: &c.flagSessionConnectionLimit,
+				Usage:  "The maximum number of connections allowed for a session. -1 means unlimited.",
+			})
+		case "session-idle-timeout":
+			f.StringVar(&base.StringVar{
+				Name:   "session-idle-timeout",
+				Target: &c.flagSessionIdleTimeout,
+				Usage:  `The time without traffic after which a connection of a session is closed. A session whose connections have all been closed this way is terminated. Can be specified as an integer number of seconds or a duration string; 0 means connections are never closed for being idle.`,
+			})
+		case "worker-filter":
+			f.StringVar(&base.StringVar{
+				Name:   "worker-filter",
+				Target: &c.flagWorkerFilter,
+				Usage:  `A boolean expression to filter which workers can handle sessions for this target, e.g. '"/tags/region" contains "us-east-1"'.`,
+			})
+		case "host-keys":
+			f.StringVar(&base.StringVar{
+				Name:   "host-keys",
+				Target: &c.flagHostKeys,
+				Usage:  `The public keys of the endpoint's host, one per line in authorized_keys format. This can refer to a file on disk (file://) or an env var (env://) from which the keys will be read.`,
+			})
+		case "host-ca-keys":
+			f.StringVar(&base.StringVar{
+				Name:   "host-ca-keys",
+				Target: &c.flagHostCaKeys,
+				Usage:  `The public keys of certificate authorities, one per line in authorized_keys format, whose signed host certificates are accepted from the endpoint. This can refer to a file on disk (file://) or an env var (env://) from which the keys will be read.`,
+			})
+		case "insecure-ignore-host-key":
+			f.StringVar(&base.StringVar{
+				Name:   "insecure-ignore-host-key",
+				Target: &c.flagInsecureIgnoreHostKey,
+				Usage:  `If "true", the worker accepts any host key from the endpoint. Only use this if the endpoint's host key cannot be known in advance.`,
+			})
+		}
+	}
+
+	return set
+}
+
+func (c *SshCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *SshCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *SshCommand) Run(args []string) int {
+	if c.Func == "" {
+		return cli.RunResultHelp
+	}
+
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if strutil.StrListContains(sshFlagsMap[c.Func], "id") && c.FlagId == "" {
+		c.UI.Error("ID is required but not passed in via -id")
+		return 1
+	}
+	if strutil.StrListContains(sshFlagsMap[c.Func], "scope-id") && c.FlagScopeId == "" {
+		c.UI.Error("Scope ID must be passed in via -scope-id")
+		return 1
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+
+	var opts []targets.Option
+
+	switch c.FlagName {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultName())
+	default:
+		opts = append(opts, targets.WithName(c.FlagName))
+	}
+
+	switch c.FlagDescription {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultDescription())
+	default:
+		opts = append(opts, targets.WithDescription(c.FlagDescription))
+	}
+
+	switch c.flagDefaultPort {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultSshTargetDefaultPort())
+	default:
+		port, err := strconv.ParseUint(c.flagDefaultPort, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagDefaultPort, err))
+			return 1
+		}
+		opts = append(opts, targets.WithSshTargetDefaultPort(uint32(port)))
+	}
+
+	switch c.flagSessionMaxSeconds {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultSessionMaxSeconds())
+	default:
+		var final uint32
+		dur, err := strconv.ParseUint(c.flagSessionMaxSeconds, 10, 32)
+		if err == nil {
+			final = uint32(dur)
+		} else {
+			dur, err := time.ParseDuration(c.flagSessionMaxSeconds)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionMaxSeconds, err))
+				return 1
+			}
+			final = uint32(dur.Seconds())
+		}
+		opts = append(opts, targets.WithSessionMaxSeconds(final))
+	}
+
+	switch c.flagSessionConnectionLimit {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultSessionConnectionLimit())
+	default:
+		limit, err := strconv.ParseInt(c.flagSessionConnectionLimit, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionConnectionLimit, err))
+			return 1
+		}
+		opts = append(opts, targets.WithSessionConnectionLimit(int32(limit)))
+	}
+
+	switch c.flagSessionIdleTimeout {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultSessionIdleTimeoutSeconds())
+	default:
+		var final uint32
+		dur, err := strconv.ParseUint(c.flagSessionIdleTimeout, 10, 32)
+		if err == nil {
+			final = uint32(dur)
+		} else {
+			dur, err := time.ParseDuration(c.flagSessionIdleTimeout)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionIdleTimeout, err))
+				return 1
+			}
+			final = uint32(dur.Seconds())
+		}
+		opts = append(opts, targets.WithSessionIdleTimeoutSeconds(final))
+	}
+
+	switch c.flagWorkerFilter {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultWorkerFilter())
+	default:
+		opts = append(opts, targets.WithWorkerFilter(c.flagWorkerFilter))
+	}
+
+	switch c.flagHostKeys {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultSshTargetHostKeys())
+	default:
+		keys, err := config.ParseAddress(c.flagHostKeys)
+		if err != nil && err != config.ErrNotAUrl {
+			c.UI.Error(fmt.Sprintf("Error parsing host keys: %s", err))
+			return 1
+		}
+		opts = append(opts, targets.WithSshTargetHostKeys(keys))
+	}
+
+	switch c.flagHostCaKeys {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultSshTargetHostCaKeys())
+	default:
+		keys, err := config.ParseAddress(c.flagHostCaKeys)
+		if err != nil && err != config.ErrNotAUrl {
+			c.UI.Error(fmt.Sprintf("Error parsing host ca keys: %s", err))
+			return 1
+		}
+		opts = append(opts, targets.WithSshTargetHostCaKeys(keys))
+	}
+
+	switch c.flagInsecureIgnoreHostKey {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultSshTargetInsecureIgnoreHostKey())
+	default:
+		ignore, err := strconv.ParseBool(c.flagInsecureIgnoreHostKey)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagInsecureIgnoreHostKey, err))
+			return 1
+		}
+		opts = append(opts, targets.WithSshTargetInsecureIgnoreHostKey(ignore))
+	}
+
+	targetClient := targets.NewClient(client)
+
+	// Perform check-and-set when needed
+	var version uint32
+	switch c.Func {
+	case "create":
+		// These don't update so don't need the existing version
+	default:
+		switch c.FlagVersion {
+		case 0:
+			opts = append(opts, targets.WithAutomaticVersioning(true))
+		default:
+			version = uint32(c.FlagVersion)
+		}
+	}
+
+	var result api.GenericResult
+
+	switch c.Func {
+	case "create":
+		result, err = targetClient.Create(c.Context, "ssh", c.FlagScopeId, opts...)
+	case "update":
+		result, err = targetClient.Update(c.Context, c.FlagId, version, opts...)
+	}
+
+	plural := "ssh-type target"
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.UI.Error(fmt.Sprintf("Error from controller when performing %s on %s: %s", c.Func, plural, base.PrintApiError(apiErr)))
+			return 1
+		}
+		c.UI.Error(fmt.Sprintf("Error trying to %s %s: %s", c.Func, plural, err.Error()))
+		return 2
+	}
+
+	target := result.GetItem().(*targets.Target)
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateTargetTableOutput(target))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(target)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	}
+
+	return 0
+}
@@ -23,14 +23,17 @@ type Command struct {
 
 	Func string
 
-	flagHostSets []string
-	flagHostId   string
+	flagHostSets    []string
+	flagCredentials []string
+	flagHostId      string
 }
 
 func (c *Command) Synopsis() string {
 	switch c.Func {
 	case "add-host-sets", "set-host-sets", "remove-host-sets":
 		return hostSetSynopsisFunc(c.Func)
+	case "add-credentials", "set-credentials", "remove-credentials":
+		return credentialSynopsisFunc(c.Func)
 	case "authorize-session":
 		return "Request session authorization against the target"
 	default:
@@ -39,13 +42,16 @@ func (c *Command) Synopsis() string {
 }
 
 var flagsMap = map[string][]string{
-	"authorize-session": {"id", "host-id"},
-	"read":              {"id"},
-	"delete":            {"id"},
-	"list":              {"scope-id"},
-	"add-host-sets":     {"id", "host-set", "version"},
-	"remove-host-sets":  {"id", "host-set", "version"},
-	"set-host-sets":     {"id", "host-set", "version"},
+	"authorize-session":  {"id", "host-id"},
+	"read":               {"id"},
+	"delete":             {"id"},
+	"list":               {"scope-id", "recursive", "filter", "page-size", "page-token"},
+	"add-host-sets":      {"id", "host-set", "version"},
+	"remove-host-sets":   {"id", "host-set", "version"},
+	"set-host-sets":      {"id", "host-set", "version"},
+	"add-credentials":    {"id", "credential", "version"},
+	"remove-credentials": {"id", "credential", "version"},
+	"set-credentials":    {"id", "credential", "version"},
 }
 
 func (c *Command) Help() string {
@@ -74,6 +80,18 @@ func (c *Command) Help() string {
 			"",
 			`      $ boundary targets create tcp -name prodops -description "For ProdOps usage"`,
 			"",
+			"    Create an http-type target:",
+			"",
+			`      $ boundary targets create http -name admin-ui -header "X-Forwarded-User: ops"`,
+			"",
+			"    Create an ssh-type target:",
+			"",
+			`      $ boundary targets create ssh -name prod-shell -default-port 22`,
+			"",
+			"    Create a udp-type target:",
+			"",
+			`      $ boundary targets create udp -name prod-dns -default-port 53`,
+			"",
 			"  Please see the typed subcommand help for detailed usage information.",
 		})
 	case "update":
@@ -86,6 +104,18 @@ func (c *Command) Help() string {
 			"",
 			`      $ boundary targets update tcp -id ttcp_1234567890 -name devops -description "For DevOps usage"`,
 			"",
+			"    Update an http-type target:",
+			"",
+			`      $ boundary targets update http -id thttp_1234567890 -header "X-Forwarded-User: devops"`,
+			"",
+			"    Update an ssh-type target:",
+			"",
+			`      $ boundary targets update ssh -id tssh_1234567890 -default-port 2222`,
+			"",
+			"    Update a udp-type target:",
+			"",
+			`      $ boundary targets update udp -id tudp_1234567890 -default-port 5353`,
+			"",
 			"  Please see the typed subcommand help for detailed usage information.",
 		})
 	case "add-host-sets":
@@ -124,6 +154,42 @@ func (c *Command) Help() string {
 			"",
 			"",
 		})
+	case "add-credentials":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary target add-credentials [options] [args]",
+			"",
+			"  This command allows adding credential resources to target resources. Example:",
+			"",
+			"    Add credential resources to a tcp-type target:",
+			"",
+			`      $ boundary targets add-credentials -id ttcp_1234567890 -credential credup_1234567890 -credential credup_0987654321`,
+			"",
+			"",
+		})
+	case "remove-credentials":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary target remove-credentials [options] [args]",
+			"",
+			"  This command allows removing credential resources from target resources. Example:",
+			"",
+			"    Remove credential resources from a tcp-type target:",
+			"",
+			`      $ boundary targets remove-credentials -id ttcp_1234567890 -credential credup_1234567890`,
+			"",
+			"",
+		})
+	case "set-credentials":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary target set-credentials [options] [args]",
+			"",
+			"  This command allows setting the complete set of credential resources on a target resource. Example:",
+			"",
+			"    Set credential resources on a tcp-type target:",
+			"",
+			`      $ boundary targets set-credentials -id ttcp_1234567890 -credential credup_1234567890`,
+			"",
+			"",
+		})
 	case "authorize-session":
 		helpStr = base.WrapForHelpText([]string{
 			"Usage: boundary target authorize-session [options] [args]",
@@ -163,6 +229,12 @@ func (c *Command) Flags() *base.FlagSets {
 				Target: &c.flagHostSets,
 				Usage:  "The host-set resources to add, remove, or set. May be specified multiple times.",
 			})
+		case "credential":
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   "credential",
+				Target: &c.flagCredentials,
+				Usage:  "The credential resources to add, remove, or set. May be specified multiple times.",
+			})
 		case "host-id":
 			f.StringVar(&base.StringVar{
 				Name:   "host-id",
@@ -295,6 +367,7 @@ func (c *Command) Run(args []string) int {
 	}
 
 	hostSets := c.flagHostSets
+	credentials := c.flagCredentials
 	switch c.Func {
 	case "add-host-sets", "remove-host-sets":
 		if len(c.flagHostSets) == 0 {
@@ -312,6 +385,22 @@ func (c *Command) Run(args []string) int {
 				hostSets = nil
 			}
 		}
+	case "add-credentials", "remove-credentials":
+		if len(c.flagCredentials) == 0 {
+			c.UI.Error("No credentials supplied via -credential")
+			return 1
+		}
+
+	case "set-credentials":
+		switch len(c.flagCredentials) {
+		case 0:
+			c.UI.Error("No credentials supplied via -credential")
+			return 1
+		case 1:
+			if c.flagCredentials[0] == "null" {
+				credentials = nil
+			}
+		}
 	case "authorize-session":
 		if len(c.flagHostId) != 0 {
 			opts = append(opts, targets.WithHostId(c.flagHostId))
@@ -321,7 +410,8 @@ func (c *Command) Run(args []string) int {
 	// Perform check-and-set when needed
 	var version uint32
 	switch c.Func {
-	case "add-host-sets", "remove-host-sets", "set-host-sets":
+	case "add-host-sets", "remove-host-sets", "set-host-sets",
+		"add-credentials", "remove-credentials", "set-credentials":
 		switch c.FlagVersion {
 		case 0:
 			opts = append(opts, targets.WithAutomaticVersioning(true))
@@ -333,6 +423,21 @@ func (c *Command) Run(args []string) int {
 		// file
 	}
 
+	if c.Func == "list" {
+		if c.FlagFilter != "" {
+			opts = append(opts, targets.WithFilter(c.FlagFilter))
+		}
+		if c.FlagRecursive {
+			opts = append(opts, targets.WithRecursive(true))
+		}
+		if c.FlagPageSize > 0 {
+			opts = append(opts, targets.WithPageSize(uint32(c.FlagPageSize)))
+		}
+		if c.FlagPageToken != "" {
+			opts = append(opts, targets.WithPageToken(c.FlagPageToken))
+		}
+	}
+
 	targetClient := targets.NewClient(client)
 
 	existed := true
@@ -357,6 +462,12 @@ func (c *Command) Run(args []string) int {
 		result, err = targetClient.RemoveHostSets(c.Context, c.FlagId, version, hostSets, opts...)
 	case "set-host-sets":
 		result, err = targetClient.SetHostSets(c.Context, c.FlagId, version, hostSets, opts...)
+	case "add-credentials":
+		result, err = targetClient.AddCredentials(c.Context, c.FlagId, version, credentials, opts...)
+	case "remove-credentials":
+		result, err = targetClient.RemoveCredentials(c.Context, c.FlagId, version, credentials, opts...)
+	case "set-credentials":
+		result, err = targetClient.SetCredentials(c.Context, c.FlagId, version, credentials, opts...)
 	case "authorize-session":
 		sar, err = targetClient.AuthorizeSession(c.Context, c.FlagId, opts...)
 	}
@@ -430,6 +541,11 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("    Type:         %s", m.Type),
 					)
 				}
+				if c.FlagRecursive {
+					output = append(output,
+						fmt.Sprintf("    Scope ID:     %s", m.ScopeId),
+					)
+				}
 				if m.Name != "" {
 					output = append(output,
 						fmt.Sprintf("    Name:         %s", m.Name),
@@ -440,9 +556,16 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("    Description:  %s", m.Description),
 					)
 				}
+				if len(m.AuthorizedActions) > 0 {
+					output = append(output,
+						"    Authorized Actions:",
+						base.WrapSlice(6, m.AuthorizedActions),
+					)
+				}
 			}
 			c.UI.Output(base.WrapForHelpText(output))
 		}
+		base.PrintNextPageToken(c.UI, listResult.GetNextPageToken())
 		return 0
 
 	case "authorize-session":
@@ -25,6 +25,8 @@ type TcpCommand struct {
 	flagDefaultPort            string
 	flagSessionMaxSeconds      string
 	flagSessionConnectionLimit string
+	flagSessionIdleTimeout     string
+	flagWorkerFilter           string
 }
 
 func (c *TcpCommand) Synopsis() string {
@@ -32,8 +34,8 @@ func (c *TcpCommand) Synopsis() string {
 }
 
 var tcpFlagsMap = map[string][]string{
-	"create": {"scope-id", "name", "description", "default-port", "session-max-seconds", "session-connection-limit"},
-	"update": {"id", "name", "description", "version", "default-port", "session-max-seconds", "session-connection-limit"},
+	"create": {"scope-id", "name", "description", "default-port", "session-max-seconds", "session-connection-limit", "session-idle-timeout", "worker-filter"},
+	"update": {"id", "name", "description", "version", "default-port", "session-max-seconds", "session-connection-limit", "session-idle-timeout", "worker-filter"},
 }
 
 func (c *TcpCommand) Help() string {
@@ -89,6 +91,18 @@ func (c *TcpCommand) Flags() *base.FlagSets {
 				Target: &c.flagSessionConnectionLimit,
 				Usage:  "The maximum number of connections allowed for a session. -1 means unlimited.",
 			})
+		case "session-idle-timeout":
+			f.StringVar(&base.StringVar{
+				Name:   "session-idle-timeout",
+				Target: &c.flagSessionIdleTimeout,
+				Usage:  `The time without traffic after which a connection of a session is closed. A session whose connections have all been closed this way is terminated. Can be specified as an integer number of seconds or a duration string; 0 means connections are never closed for being idle.`,
+			})
+		case "worker-filter":
+			f.StringVar(&base.StringVar{
+				Name:   "worker-filter",
+				Target: &c.flagWorkerFilter,
+				Usage:  `A boolean expression to filter which workers can handle sessions for this target, e.g. '"/tags/region" contains "us-east-1"'.`,
+			})
 		}
 	}
 
@@ -194,6 +208,34 @@ func (c *TcpCommand) Run(args []string) int {
 		opts = append(opts, targets.WithSessionConnectionLimit(int32(limit)))
 	}
 
+	switch c.flagSessionIdleTimeout {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultSessionIdleTimeoutSeconds())
+	default:
+		var final uint32
+		dur, err := strconv.ParseUint(c.flagSessionIdleTimeout, 10, 32)
+		if err == nil {
+			final = uint32(dur)
+		} else {
+			dur, err := time.ParseDuration(c.flagSessionIdleTimeout)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionIdleTimeout, err))
+				return 1
+			}
+			final = uint32(dur.Seconds())
+		}
+		opts = append(opts, targets.WithSessionIdleTimeoutSeconds(final))
+	}
+
+	switch c.flagWorkerFilter {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultWorkerFilter())
+	default:
+		opts = append(opts, targets.WithWorkerFilter(c.flagWorkerFilter))
+	}
+
 	targetClient := targets.NewClient(client)
 
 	// Perform check-and-set when needed
@@ -110,6 +110,14 @@ func generateUserTableOutput(in *users.User) string {
 		base.ScopeInfoForOutput(in.Scope, maxLength),
 	}
 
+	if len(in.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, in.AuthorizedActions),
+		)
+	}
+
 	if len(in.Accounts) > 0 {
 		ret = append(ret,
 			"",
@@ -46,7 +46,7 @@ var flagsMap = map[string][]string{
 	"update":          {"id", "name", "description", "version"},
 	"read":            {"id"},
 	"delete":          {"id"},
-	"list":            {"scope-id"},
+	"list":            {"scope-id", "recursive", "filter", "page-size", "page-token"},
 	"add-accounts":    {"id", "account", "version"},
 	"set-accounts":    {"id", "account", "version"},
 	"remove-accounts": {"id", "account", "version"},
@@ -141,6 +141,21 @@ func (c *Command) Run(args []string) int {
 		}
 	}
 
+	if c.Func == "list" {
+		if c.FlagFilter != "" {
+			opts = append(opts, users.WithFilter(c.FlagFilter))
+		}
+		if c.FlagRecursive {
+			opts = append(opts, users.WithRecursive(true))
+		}
+		if c.FlagPageSize > 0 {
+			opts = append(opts, users.WithPageSize(uint32(c.FlagPageSize)))
+		}
+		if c.FlagPageToken != "" {
+			opts = append(opts, users.WithPageToken(c.FlagPageToken))
+		}
+	}
+
 	userClient := users.NewClient(client)
 
 	// Perform check-and-set when needed
@@ -249,6 +264,11 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("    Version:      %d", u.Version),
 					)
 				}
+				if c.FlagRecursive {
+					output = append(output,
+						fmt.Sprintf("    Scope ID:     %s", u.ScopeId),
+					)
+				}
 				if u.Name != "" {
 					output = append(output,
 						fmt.Sprintf("    Name:         %s", u.Name),
@@ -259,9 +279,16 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("    Description:  %s", u.Description),
 					)
 				}
+				if len(u.AuthorizedActions) > 0 {
+					output = append(output,
+						"    Authorized Actions:",
+						base.WrapSlice(6, u.AuthorizedActions),
+					)
+				}
 			}
 			c.UI.Output(base.WrapForHelpText(output))
 		}
+		base.PrintNextPageToken(c.UI, listResult.GetNextPageToken())
 		return 0
 	}
 
@@ -65,6 +65,37 @@ func PopulateCommonFlags(c *base.Command, f *base.FlagSet, resourceType string,
 				Target: &c.FlagHostCatalogId,
 				Usage:  "The host-catalog resource to use for the operation.",
 			})
+		case "credential-store-id":
+			f.StringVar(&base.StringVar{
+				Name:   "credential-store-id",
+				EnvVar: "BOUNDARY_CREDENTIAL_STORE_ID",
+				Target: &c.FlagCredentialStoreId,
+				Usage:  "The credential-store resource to use for the operation.",
+			})
+		case "filter":
+			f.StringVar(&base.StringVar{
+				Name:   "filter",
+				Target: &c.FlagFilter,
+				Usage:  fmt.Sprintf(`If set, only the %s resources matching the filter expression are listed, e.g. "/item/name" == "prod".`, resourceType),
+			})
+		case "page-size":
+			f.IntVar(&base.IntVar{
+				Name:   "page-size",
+				Target: &c.FlagPageSize,
+				Usage:  "If set, at most this many resources are listed. When more remain, a token for the next page is printed.",
+			})
+		case "recursive":
+			f.BoolVar(&base.BoolVar{
+				Name:   "recursive",
+				Target: &c.FlagRecursive,
+				Usage:  fmt.Sprintf("If set, %s resources in the descendants of the scope are listed as well, limited to the scopes in which listing them is authorized.", resourceType),
+			})
+		case "page-token":
+			f.StringVar(&base.StringVar{
+				Name:   "page-token",
+				Target: &c.FlagPageToken,
+				Usage:  "The token of the page to list, as printed by a previous list command.",
+			})
 		}
 	}
 }
@@ -102,6 +102,11 @@ type Controller struct {
 	Database          *Database `hcl:"database"`
 	PublicClusterAddr string    `hcl:"public_cluster_addr"`
 
+	// PublicProxyAddr is the address of the controller's "controller-proxy"
+	// listener, through which clients reach the sessions of reverse-connected
+	// workers.
+	PublicProxyAddr string `hcl:"public_proxy_addr"`
+
 	// AuthTokenTimeToLive is the total valid lifetime of a token denoted by time.Duration
 	AuthTokenTimeToLive         interface{} `hcl:"auth_token_time_to_live"`
 	AuthTokenTimeToLiveDuration time.Duration
@@ -110,6 +115,58 @@ type Controller struct {
 	// denoted by time.Duration
 	AuthTokenTimeToStale         interface{} `hcl:"auth_token_time_to_stale"`
 	AuthTokenTimeToStaleDuration time.Duration
+
+	// GroupSync configures the synchronization of the members of groups
+	// bound to external directories.
+	GroupSync *GroupSync `hcl:"group_sync"`
+}
+
+// GroupSync configures the directories the members of groups are
+// synchronized from.
+type GroupSync struct {
+	// Interval is the time between reads of the members of LDAP groups. It
+	// defaults to DefaultGroupSyncInterval.
+	Interval         interface{} `hcl:"interval"`
+	IntervalDuration time.Duration
+
+	Ldap *GroupSyncLdap `hcl:"ldap"`
+	Scim *GroupSyncScim `hcl:"scim"`
+}
+
+// DefaultGroupSyncInterval is the time between reads of the members of LDAP
+// groups if it is not configured.
+const DefaultGroupSyncInterval = 5 * time.Minute
+
+// GroupSyncLdap is the LDAP server the members of ldap groups are read from.
+type GroupSyncLdap struct {
+	Url          string `hcl:"url"`
+	StartTls     bool   `hcl:"start_tls"`
+	InsecureTls  bool   `hcl:"insecure_tls"`
+	CaCert       string `hcl:"ca_cert"`
+	BindDn       string `hcl:"bind_dn"`
+	BindPassword string `hcl:"bind_password"`
+
+	// AuthMethodId is the LDAP auth method of the directory. Members are
+	// matched to its accounts by the DN of their entry, so a member is the
+	// same user whether the group is synchronized or the member logs in
+	// first.
+	AuthMethodId string `hcl:"auth_method_id"`
+
+	// UserAttr is the attribute of a member's entry holding the login name
+	// recorded on an account created for it. It defaults to "uid".
+	UserAttr string `hcl:"user_attr"`
+}
+
+// GroupSyncScim configures the SCIM endpoint members of scim groups are
+// pushed to.
+type GroupSyncScim struct {
+	// BearerToken authenticates the SCIM client.
+	BearerToken string `hcl:"bearer_token"`
+
+	// AuthMethodId is the LDAP auth method whose accounts SCIM users are.
+	// A user's externalId is the DN of its entry in the auth method's
+	// directory.
+	AuthMethodId string `hcl:"auth_method_id"`
 }
 
 type Worker struct {
@@ -117,6 +174,29 @@ type Worker struct {
 	Description string   `hcl:"description"`
 	Controllers []string `hcl:"controllers"`
 	PublicAddr  string   `hcl:"public_addr"`
+
+	// TagsRaw is parsed as a raw value so that tags can be given either as a
+	// block mapping keys to one or more values or as a list of "key=value"
+	// strings. The result is normalized into Tags in Parse.
+	TagsRaw interface{}         `hcl:"tags"`
+	Tags    map[string][]string `hcl:"-"`
+
+	// RecordingStoragePath is the local directory in which the worker writes
+	// the recordings of sessions to ssh targets. If unset, a directory under
+	// the system temporary directory is used.
+	RecordingStoragePath string `hcl:"recording_storage_path"`
+
+	// Upstreams are the addresses of the proxy listeners of workers to
+	// which this worker connects to receive sessions, for workers in
+	// networks which accept no inbound connections. The worker is connected
+	// to one upstream at a time, trying them in order.
+	Upstreams []string `hcl:"upstreams"`
+
+	// ReverseConnect makes the worker keep a connection to the cluster
+	// listener of one of its controllers through which the controller
+	// forwards the worker's sessions. Clients connect to the controller
+	// instead of the worker.
+	ReverseConnect bool `hcl:"reverse_connect"`
 }
 
 type Database struct {
@@ -239,6 +319,44 @@ func Parse(d string) (*Config, error) {
 			}
 			result.Controller.AuthTokenTimeToStaleDuration = t
 		}
+
+		if gs := result.Controller.GroupSync; gs != nil {
+			gs.IntervalDuration = DefaultGroupSyncInterval
+			if gs.Interval != nil {
+				t, err := parseutil.ParseDurationSecond(gs.Interval)
+				if err != nil {
+					return result, fmt.Errorf("error parsing group sync interval: %w", err)
+				}
+				gs.IntervalDuration = t
+			}
+			if gs.Ldap != nil {
+				if gs.Ldap.Url == "" {
+					return result, errors.New("group sync ldap url is required")
+				}
+				if gs.Ldap.AuthMethodId == "" {
+					return result, errors.New("group sync ldap auth method id is required")
+				}
+				if gs.Ldap.UserAttr == "" {
+					gs.Ldap.UserAttr = "uid"
+				}
+			}
+			if gs.Scim != nil {
+				if gs.Scim.BearerToken == "" {
+					return result, errors.New("group sync scim bearer token is required")
+				}
+				if gs.Scim.AuthMethodId == "" {
+					return result, errors.New("group sync scim auth method id is required")
+				}
+			}
+		}
+	}
+
+	if result.Worker != nil && result.Worker.TagsRaw != nil {
+		tags, err := parseWorkerTags(result.Worker.TagsRaw)
+		if err != nil {
+			return result, fmt.Errorf("error parsing worker tags: %w", err)
+		}
+		result.Worker.Tags = tags
 	}
 
 	sharedConfig, err := configutil.ParseConfig(d)
@@ -250,6 +368,68 @@ func Parse(d string) (*Config, error) {
 	return result, nil
 }
 
+// parseWorkerTags normalizes the raw tags value from a worker stanza. HCL turns
+// a tags block into a slice of maps (one per block) whose values are either a
+// string or a list of strings; a list of "key=value" strings is also accepted.
+// Keys may be repeated, in which case their values are aggregated.
+func parseWorkerTags(raw interface{}) (map[string][]string, error) {
+	tags := make(map[string][]string)
+	addTag := func(k, v string) error {
+		k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+		if k == "" {
+			return errors.New("tag key cannot be empty")
+		}
+		if v == "" {
+			return fmt.Errorf("value for tag %q cannot be empty", k)
+		}
+		tags[k] = append(tags[k], v)
+		return nil
+	}
+
+	switch t := raw.(type) {
+	case []map[string]interface{}:
+		for _, m := range t {
+			for k, v := range m {
+				switch val := v.(type) {
+				case string:
+					if err := addTag(k, val); err != nil {
+						return nil, err
+					}
+				case []interface{}:
+					for _, rawVal := range val {
+						strVal, ok := rawVal.(string)
+						if !ok {
+							return nil, fmt.Errorf("value for tag %q must be a string or list of strings", k)
+						}
+						if err := addTag(k, strVal); err != nil {
+							return nil, err
+						}
+					}
+				default:
+					return nil, fmt.Errorf("value for tag %q must be a string or list of strings", k)
+				}
+			}
+		}
+	case []interface{}:
+		for _, rawTag := range t {
+			tag, ok := rawTag.(string)
+			if !ok {
+				return nil, errors.New("tags given as a list must be strings in key=value format")
+			}
+			kv := strings.SplitN(tag, "=", 2)
+			if len(kv) != 2 {
+				return nil, fmt.Errorf("tag %q is not in key=value format", tag)
+			}
+			if err := addTag(kv[0], kv[1]); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, errors.New("tags must be a block or a list of key=value strings")
+	}
+	return tags, nil
+}
+
 // Sanitized returns a copy of the config with all values that are considered
 // sensitive stripped. It also strips all `*Raw` values that are mainly
 // used for parsing.
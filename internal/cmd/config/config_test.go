@@ -115,3 +115,217 @@ func TestDevWorker(t *testing.T) {
 
 	assert.Equal(t, exp, actual)
 }
+
+func TestWorkerTags(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        string
+		want      map[string][]string
+		wantError bool
+	}{
+		{
+			name: "block",
+			in: `
+worker {
+	name = "w"
+	tags {
+		type   = ["prod", "web"]
+		region = "us-east-1"
+	}
+}`,
+			want: map[string][]string{
+				"type":   {"prod", "web"},
+				"region": {"us-east-1"},
+			},
+		},
+		{
+			name: "list",
+			in: `
+worker {
+	name = "w"
+	tags = ["type=prod", "type=web", "region=us-east-1"]
+}`,
+			want: map[string][]string{
+				"type":   {"prod", "web"},
+				"region": {"us-east-1"},
+			},
+		},
+		{
+			name: "bad list entry",
+			in: `
+worker {
+	name = "w"
+	tags = ["type"]
+}`,
+			wantError: true,
+		},
+		{
+			name: "empty value",
+			in: `
+worker {
+	name = "w"
+	tags = ["type="]
+}`,
+			wantError: true,
+		},
+		{
+			name: "non-string value",
+			in: `
+worker {
+	name = "w"
+	tags {
+		type = 5
+	}
+}`,
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			got, err := Parse(tt.in)
+			if tt.wantError {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.want, got.Worker.Tags)
+		})
+	}
+}
+
+func TestGroupSync(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        string
+		want      *GroupSync
+		wantError bool
+	}{
+		{
+			name: "ldap",
+			in: `
+controller {
+	name = "c"
+	group_sync {
+		interval = "1m"
+		ldap {
+			url = "ldaps://ldap.example.com"
+			bind_dn = "cn=boundary,dc=example,dc=com"
+			bind_password = "secret"
+			auth_method_id = "amldap_1234567890"
+		}
+	}
+}`,
+			want: &GroupSync{
+				Interval:         "1m",
+				IntervalDuration: time.Minute,
+				Ldap: &GroupSyncLdap{
+					Url:          "ldaps://ldap.example.com",
+					BindDn:       "cn=boundary,dc=example,dc=com",
+					BindPassword: "secret",
+					AuthMethodId: "amldap_1234567890",
+					UserAttr:     "uid",
+				},
+			},
+		},
+		{
+			name: "scim",
+			in: `
+controller {
+	name = "c"
+	group_sync {
+		scim {
+			bearer_token = "token"
+			auth_method_id = "amldap_1234567890"
+		}
+	}
+}`,
+			want: &GroupSync{
+				IntervalDuration: DefaultGroupSyncInterval,
+				Scim: &GroupSyncScim{
+					BearerToken:  "token",
+					AuthMethodId: "amldap_1234567890",
+				},
+			},
+		},
+		{
+			name: "missing ldap url",
+			in: `
+controller {
+	name = "c"
+	group_sync {
+		ldap {
+			bind_dn = "cn=boundary,dc=example,dc=com"
+		}
+	}
+}`,
+			wantError: true,
+		},
+		{
+			name: "missing ldap auth method",
+			in: `
+controller {
+	name = "c"
+	group_sync {
+		ldap {
+			url = "ldaps://ldap.example.com"
+		}
+	}
+}`,
+			wantError: true,
+		},
+		{
+			name: "missing scim auth method",
+			in: `
+controller {
+	name = "c"
+	group_sync {
+		scim {
+			bearer_token = "token"
+		}
+	}
+}`,
+			wantError: true,
+		},
+		{
+			name: "missing scim token",
+			in: `
+controller {
+	name = "c"
+	group_sync {
+		scim {
+			auth_method_id = "amldap_1234567890"
+		}
+	}
+}`,
+			wantError: true,
+		},
+		{
+			name: "bad interval",
+			in: `
+controller {
+	name = "c"
+	group_sync {
+		interval = "soon"
+	}
+}`,
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			got, err := Parse(tt.in)
+			if tt.wantError {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.want, got.Controller.GroupSync)
+		})
+	}
+}
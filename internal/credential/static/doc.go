@@ -0,0 +1,35 @@
+// Package static provides a credential store and credentials whose secrets
+// are stored in Boundary.
+//
+// A credential store contains a collection of credentials and is owned by a
+// project. If a credential store is deleted, all credentials owned by it are
+// also deleted. A username/password credential holds a username and a
+// password. The password is encrypted with a database key of the project
+// before it is stored and is never returned by the API; a sha256-hmac of the
+// password is returned in its place.
+//
+// Credentials can be attached to targets in the same project. When a session
+// is authorized for a target, the decrypted credentials attached to the
+// target are returned to the client so that it can pass them to the program
+// connecting to the endpoint.
+//
+// Repository
+//
+// A repository provides methods for creating, updating, retrieving, and
+// deleting credential stores and credentials. A new repository should be
+// created for each transaction. For example:
+//
+//  var wrapper wrapping.Wrapper
+//  ... init wrapper...
+//
+//  // db implements both the reader and writer interfaces.
+//  db, _ := db.Open(db.Postgres, url)
+//
+//  kmsCache, _ := kms.NewKms(kmsRepo)
+//
+//  var repo *static.Repository
+//
+//  repo, _ = static.NewRepository(db, db, kmsCache)
+//  cred, _ := repo.LookupCredential(ctx, credentialId)
+//
+package static
@@ -0,0 +1,41 @@
+package static
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetOpts(t *testing.T) {
+	t.Parallel()
+	t.Run("WithName", func(t *testing.T) {
+		opts := getOpts(WithName("test"))
+		testOpts := getDefaultOptions()
+		testOpts.withName = "test"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithDescription", func(t *testing.T) {
+		opts := getOpts(WithDescription("test desc"))
+		testOpts := getDefaultOptions()
+		testOpts.withDescription = "test desc"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithLimit", func(t *testing.T) {
+		opts := getOpts(WithLimit(5))
+		testOpts := getDefaultOptions()
+		testOpts.withLimit = 5
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithStartPageAfterId", func(t *testing.T) {
+		opts := getOpts(WithStartPageAfterId("credup_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withStartPageAfterId = "credup_1234567890"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithPublicId", func(t *testing.T) {
+		opts := getOpts(WithPublicId("credup_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withPublicId = "credup_1234567890"
+		assert.Equal(t, opts, testOpts)
+	})
+}
@@ -0,0 +1,29 @@
+package static
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+)
+
+// PublicId prefixes for the resources in the static package.
+const (
+	CredentialStorePrefix            = "csst"
+	UsernamePasswordCredentialPrefix = "credup"
+)
+
+func newCredentialStoreId() (string, error) {
+	id, err := db.NewPublicId(CredentialStorePrefix)
+	if err != nil {
+		return "", fmt.Errorf("new credential store id: %w", err)
+	}
+	return id, err
+}
+
+func newUsernamePasswordCredentialId() (string, error) {
+	id, err := db.NewPublicId(UsernamePasswordCredentialPrefix)
+	if err != nil {
+		return "", fmt.Errorf("new username password credential id: %w", err)
+	}
+	return id, err
+}
@@ -0,0 +1,314 @@
+package static
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	dbcommon "github.com/hashicorp/boundary/internal/db/common"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// CreateCredential inserts c into the repository and returns a new
+// UsernamePasswordCredential containing the credential's PublicId. c is not
+// changed. c must contain a valid StoreId. c must not contain a PublicId.
+// The PublicId is generated and assigned by this method. scopeId must be the
+// scope of the credential store; its database key is used to encrypt the
+// password.
+//
+// c must contain a Username and a Password. The returned credential does
+// not contain the password.
+//
+// Both c.Name and c.Description are optional. If c.Name is set, it must be
+// unique within c.StoreId.
+func (r *Repository) CreateCredential(ctx context.Context, scopeId string, c *UsernamePasswordCredential, opt ...Option) (*UsernamePasswordCredential, error) {
+	if c == nil {
+		return nil, fmt.Errorf("create: static credential: %w", errors.ErrInvalidParameter)
+	}
+	if c.UsernamePasswordCredential == nil {
+		return nil, fmt.Errorf("create: static credential: embedded UsernamePasswordCredential: %w", errors.ErrInvalidParameter)
+	}
+	if c.StoreId == "" {
+		return nil, fmt.Errorf("create: static credential: no store id: %w", errors.ErrInvalidParameter)
+	}
+	if c.PublicId != "" {
+		return nil, fmt.Errorf("create: static credential: public id not empty: %w", errors.ErrInvalidParameter)
+	}
+	if scopeId == "" {
+		return nil, fmt.Errorf("create: static credential: no scopeId: %w", errors.ErrInvalidParameter)
+	}
+	if strings.TrimSpace(c.Username) == "" {
+		return nil, fmt.Errorf("create: static credential: no username: %w", errors.ErrInvalidParameter)
+	}
+	if c.Password == "" {
+		return nil, fmt.Errorf("create: static credential: no password: %w", errors.ErrInvalidParameter)
+	}
+	c = c.clone()
+
+	opts := getOpts(opt...)
+
+	if opts.withPublicId != "" {
+		if !strings.HasPrefix(opts.withPublicId, UsernamePasswordCredentialPrefix+"_") {
+			return nil, fmt.Errorf("create: static credential: passed-in public ID %q has wrong prefix, should be %q: %w", opts.withPublicId, UsernamePasswordCredentialPrefix, errors.ErrInvalidPublicId)
+		}
+		c.PublicId = opts.withPublicId
+	} else {
+		id, err := newUsernamePasswordCredentialId()
+		if err != nil {
+			return nil, fmt.Errorf("create: static credential: %w", err)
+		}
+		c.PublicId = id
+	}
+
+	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, fmt.Errorf("create: static credential: unable to get database wrapper: %w", err)
+	}
+	if err := c.encrypt(ctx, databaseWrapper); err != nil {
+		return nil, fmt.Errorf("create: static credential: %w", err)
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, fmt.Errorf("create: static credential: unable to get oplog wrapper: %w", err)
+	}
+
+	var newCred *UsernamePasswordCredential
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			newCred = c.clone()
+			return w.Create(ctx, newCred, db.WithOplog(oplogWrapper, c.oplog(oplog.OpType_OP_TYPE_CREATE)))
+		},
+	)
+
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, fmt.Errorf("create: static credential: in store: %s: name %s already exists: %w",
+				c.StoreId, c.Name, errors.ErrNotUnique)
+		}
+		return nil, fmt.Errorf("create: static credential: in store: %s: %w", c.StoreId, err)
+	}
+	newCred.Password = ""
+	newCred.CtPassword = nil
+	return newCred, nil
+}
+
+// UpdateCredential updates the repository entry for c.PublicId with the
+// values in c for the fields listed in fieldMaskPaths. It returns a new
+// UsernamePasswordCredential containing the updated values and a count of
+// the number of records updated. c is not changed.
+//
+// c must contain a valid PublicId. Only c.Name, c.Description, c.Username
+// and c.Password can be updated. If c.Name is set to a non-empty string, it
+// must be unique within c.StoreId. Username and Password cannot be cleared.
+//
+// An attribute of c will be set to NULL in the database if the attribute
+// in c is the zero value and it is included in fieldMaskPaths.
+func (r *Repository) UpdateCredential(ctx context.Context, scopeId string, c *UsernamePasswordCredential, version uint32, fieldMaskPaths []string, opt ...Option) (*UsernamePasswordCredential, int, error) {
+	if c == nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential: %w", errors.ErrInvalidParameter)
+	}
+	if c.UsernamePasswordCredential == nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential: embedded UsernamePasswordCredential: %w", errors.ErrInvalidParameter)
+	}
+	if c.PublicId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential: missing public id: %w", errors.ErrInvalidParameter)
+	}
+	if version == 0 {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential: no version supplied: %w", errors.ErrInvalidParameter)
+	}
+	if scopeId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential: no scopeId: %w", errors.ErrInvalidParameter)
+	}
+
+	var updatePassword bool
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold("Name", f):
+		case strings.EqualFold("Description", f):
+		case strings.EqualFold("Username", f):
+			if strings.TrimSpace(c.Username) == "" {
+				return nil, db.NoRowsAffected, fmt.Errorf("update: static credential: no username: %w", errors.ErrInvalidParameter)
+			}
+		case strings.EqualFold("Password", f):
+			if c.Password == "" {
+				return nil, db.NoRowsAffected, fmt.Errorf("update: static credential: no password: %w", errors.ErrInvalidParameter)
+			}
+			updatePassword = true
+		default:
+			return nil, db.NoRowsAffected, fmt.Errorf("update: static credential: field: %s: %w", f, errors.ErrInvalidFieldMask)
+		}
+	}
+	var dbMask, nullFields []string
+	dbMask, nullFields = dbcommon.BuildUpdatePaths(
+		map[string]interface{}{
+			"Name":        c.Name,
+			"Description": c.Description,
+			"Username":    c.Username,
+		},
+		fieldMaskPaths,
+		nil,
+	)
+	if len(dbMask) == 0 && len(nullFields) == 0 && !updatePassword {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential: %w", errors.ErrEmptyFieldMask)
+	}
+
+	c = c.clone()
+	if updatePassword {
+		databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+		if err != nil {
+			return nil, db.NoRowsAffected, fmt.Errorf("update: static credential: unable to get database wrapper: %w", err)
+		}
+		if err := c.encrypt(ctx, databaseWrapper); err != nil {
+			return nil, db.NoRowsAffected, fmt.Errorf("update: static credential: %w", err)
+		}
+		dbMask = append(dbMask, "CtPassword", "PasswordHmac", "KeyId")
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential: unable to get oplog wrapper: %w", err)
+	}
+
+	var rowsUpdated int
+	var returnedCred *UsernamePasswordCredential
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedCred = c.clone()
+			var err error
+			rowsUpdated, err = w.Update(ctx, returnedCred, dbMask, nullFields,
+				db.WithOplog(oplogWrapper, c.oplog(oplog.OpType_OP_TYPE_UPDATE)),
+				db.WithVersion(&version))
+			if err == nil && rowsUpdated > 1 {
+				return errors.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, db.NoRowsAffected, fmt.Errorf("update: static credential: %s: name %s already exists: %w",
+				c.PublicId, c.Name, errors.ErrNotUnique)
+		}
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential: %s: %w", c.PublicId, err)
+	}
+
+	returnedCred.Password = ""
+	returnedCred.CtPassword = nil
+	return returnedCred, rowsUpdated, nil
+}
+
+// LookupCredential will look up a credential in the repository. The password
+// of the credential is not included. If the credential is not found, it
+// will return nil, nil. All options are ignored.
+func (r *Repository) LookupCredential(ctx context.Context, publicId string, opt ...Option) (*UsernamePasswordCredential, error) {
+	if publicId == "" {
+		return nil, fmt.Errorf("lookup: static credential: missing public id %w", errors.ErrInvalidParameter)
+	}
+	c := allocUsernamePasswordCredential()
+	c.PublicId = publicId
+	if err := r.reader.LookupByPublicId(ctx, c); err != nil {
+		if errors.Is(err, errors.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup: static credential: failed %w for %s", err, publicId)
+	}
+	c.CtPassword = nil
+	return c, nil
+}
+
+// ListCredentials returns a slice of UsernamePasswordCredentials for the
+// storeId. The passwords of the credentials are not included. Supports the WithLimit
+// and WithStartPageAfterId options.
+func (r *Repository) ListCredentials(ctx context.Context, storeId string, opt ...Option) ([]*UsernamePasswordCredential, error) {
+	if storeId == "" {
+		return nil, fmt.Errorf("list: static credential: missing store id: %w", errors.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	where, args := "store_id = ?", []interface{}{storeId}
+	if opts.withStartPageAfterId != "" {
+		where, args = where+" and public_id > ?", append(args, opts.withStartPageAfterId)
+	}
+	var creds []*UsernamePasswordCredential
+	err := r.reader.SearchWhere(ctx, &creds, where, args, db.WithLimit(limit), db.WithOrder("public_id"))
+	if err != nil {
+		return nil, fmt.Errorf("list: static credential: %w", err)
+	}
+	for _, c := range creds {
+		c.CtPassword = nil
+	}
+	return creds, nil
+}
+
+// ListCredentialsWithPasswords returns the credentials for credentialIds
+// with their passwords decrypted. The credentials must be owned by stores in
+// scopeId. Ids that do not match a credential are ignored. All options are
+// ignored.
+func (r *Repository) ListCredentialsWithPasswords(ctx context.Context, scopeId string, credentialIds []string, opt ...Option) ([]*UsernamePasswordCredential, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("list with passwords: static credential: missing scope id: %w", errors.ErrInvalidParameter)
+	}
+	if len(credentialIds) == 0 {
+		return nil, nil
+	}
+	var creds []*UsernamePasswordCredential
+	err := r.reader.SearchWhere(ctx, &creds, "public_id in (?)", []interface{}{credentialIds}, db.WithLimit(-1))
+	if err != nil {
+		return nil, fmt.Errorf("list with passwords: static credential: %w", err)
+	}
+	for _, c := range creds {
+		databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(c.KeyId))
+		if err != nil {
+			return nil, fmt.Errorf("list with passwords: static credential: unable to get database wrapper: %w", err)
+		}
+		if err := c.decrypt(ctx, databaseWrapper); err != nil {
+			return nil, fmt.Errorf("list with passwords: static credential: %s: %w", c.PublicId, err)
+		}
+		c.CtPassword = nil
+	}
+	return creds, nil
+}
+
+// DeleteCredential deletes the credential for the provided id from the
+// repository returning a count of the number of records deleted. All
+// options are ignored.
+func (r *Repository) DeleteCredential(ctx context.Context, scopeId string, publicId string, opt ...Option) (int, error) {
+	if publicId == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete: static credential: missing public id: %w", errors.ErrInvalidParameter)
+	}
+	c := allocUsernamePasswordCredential()
+	c.PublicId = publicId
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete: static credential: unable to get oplog wrapper: %w", err)
+	}
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) (err error) {
+			dc := c.clone()
+			rowsDeleted, err = w.Delete(ctx, dc, db.WithOplog(oplogWrapper, c.oplog(oplog.OpType_OP_TYPE_DELETE)))
+			if err == nil && rowsDeleted > 1 {
+				return errors.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete: static credential: %s: %w", publicId, err)
+	}
+
+	return rowsDeleted, nil
+}
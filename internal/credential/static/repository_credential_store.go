@@ -0,0 +1,266 @@
+package static
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// CreateCredentialStore inserts c into the repository and returns a new
+// CredentialStore containing the store's PublicId. c is not changed. c must
+// contain a valid ScopeID. c must not contain a PublicId. The PublicId is
+// generated and assigned by the this method. opt is ignored.
+//
+// Both c.Name and c.Description are optional. If c.Name is set, it must be
+// unique within c.ScopeID.
+//
+// Both c.CreateTime and c.UpdateTime are ignored.
+func (r *Repository) CreateCredentialStore(ctx context.Context, c *CredentialStore, opt ...Option) (*CredentialStore, error) {
+	if c == nil {
+		return nil, fmt.Errorf("create: static credential store: %w", errors.ErrInvalidParameter)
+	}
+	if c.CredentialStore == nil {
+		return nil, fmt.Errorf("create: static credential store: embedded CredentialStore: %w", errors.ErrInvalidParameter)
+	}
+	if c.ScopeId == "" {
+		return nil, fmt.Errorf("create: static credential store: no scope id: %w", errors.ErrInvalidParameter)
+	}
+	if c.PublicId != "" {
+		return nil, fmt.Errorf("create: static credential store: public id not empty: %w", errors.ErrInvalidParameter)
+	}
+	c = c.clone()
+
+	opts := getOpts(opt...)
+
+	if opts.withPublicId != "" {
+		if !strings.HasPrefix(opts.withPublicId, CredentialStorePrefix+"_") {
+			return nil, fmt.Errorf("create: static credential store: passed-in public ID %q has wrong prefix, should be %q: %w", opts.withPublicId, CredentialStorePrefix, errors.ErrInvalidPublicId)
+		}
+		c.PublicId = opts.withPublicId
+	} else {
+		id, err := newCredentialStoreId()
+		if err != nil {
+			return nil, fmt.Errorf("create: static credential store: %w", err)
+		}
+		c.PublicId = id
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, c.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, fmt.Errorf("create: static credential store: unable to get oplog wrapper: %w", err)
+	}
+
+	metadata := c.oplog(oplog.OpType_OP_TYPE_CREATE)
+
+	var newCredentialStore *CredentialStore
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			newCredentialStore = c.clone()
+			return w.Create(
+				ctx,
+				newCredentialStore,
+				db.WithOplog(oplogWrapper, metadata),
+			)
+		},
+	)
+
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, fmt.Errorf("create: static credential store: in scope: %s: name %s already exists: %w",
+				c.ScopeId, c.Name, errors.ErrNotUnique)
+		}
+		return nil, fmt.Errorf("create: static credential store: in scope: %s: %w", c.ScopeId, err)
+	}
+	return newCredentialStore, nil
+}
+
+// UpdateCredentialStore updates the repository entry for c.PublicId with the
+// values in c for the fields listed in fieldMask. It returns a new
+// CredentialStore containing the updated values and a count of the number of
+// records updated. c is not changed.
+//
+// c must contain a valid PublicId. Only c.Name and c.Description can be
+// updated. If c.Name is set to a non-empty string, it must be unique
+// within c.ScopeID.
+//
+// An attribute of c will be set to NULL in the database if the attribute
+// in c is the zero value and it is included in fieldMask.
+func (r *Repository) UpdateCredentialStore(ctx context.Context, c *CredentialStore, version uint32, fieldMask []string, opt ...Option) (*CredentialStore, int, error) {
+	if c == nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential store: %w", errors.ErrInvalidParameter)
+	}
+	if c.CredentialStore == nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential store: embedded CredentialStore: %w", errors.ErrInvalidParameter)
+	}
+	if c.PublicId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential store: missing public id: %w", errors.ErrInvalidParameter)
+	}
+	if c.ScopeId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential store: missing scope id: %w", errors.ErrInvalidParameter)
+	}
+	if len(fieldMask) == 0 {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential store: %w", errors.ErrEmptyFieldMask)
+	}
+
+	var dbMask, nullFields []string
+	for _, f := range fieldMask {
+		switch {
+		case strings.EqualFold("name", f) && c.Name == "":
+			nullFields = append(nullFields, "name")
+		case strings.EqualFold("name", f) && c.Name != "":
+			dbMask = append(dbMask, "name")
+		case strings.EqualFold("description", f) && c.Description == "":
+			nullFields = append(nullFields, "description")
+		case strings.EqualFold("description", f) && c.Description != "":
+			dbMask = append(dbMask, "description")
+
+		default:
+			return nil, db.NoRowsAffected, fmt.Errorf("update: static credential store: field: %s: %w", f, errors.ErrInvalidFieldMask)
+		}
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, c.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential store: unable to get oplog wrapper: %w", err)
+	}
+
+	c = c.clone()
+
+	metadata := c.oplog(oplog.OpType_OP_TYPE_UPDATE)
+
+	var rowsUpdated int
+	var returnedStore *CredentialStore
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedStore = c.clone()
+			var err error
+			rowsUpdated, err = w.Update(
+				ctx,
+				returnedStore,
+				dbMask,
+				nullFields,
+				db.WithOplog(oplogWrapper, metadata),
+				db.WithVersion(&version),
+			)
+			if err == nil && rowsUpdated > 1 {
+				return errors.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, db.NoRowsAffected, fmt.Errorf("update: static credential store: %s: name %s already exists: %w",
+				c.PublicId, c.Name, errors.ErrNotUnique)
+		}
+		return nil, db.NoRowsAffected, fmt.Errorf("update: static credential store: %s: %w", c.PublicId, err)
+	}
+
+	return returnedStore, rowsUpdated, nil
+}
+
+// LookupCredentialStore returns the CredentialStore for id. Returns nil, nil if no
+// CredentialStore is found for id.
+func (r *Repository) LookupCredentialStore(ctx context.Context, id string, opt ...Option) (*CredentialStore, error) {
+	if id == "" {
+		return nil, fmt.Errorf("lookup: static credential store: missing public id: %w", errors.ErrInvalidParameter)
+	}
+	c := allocCredentialStore()
+	c.PublicId = id
+	if err := r.reader.LookupByPublicId(ctx, c); err != nil {
+		if err == errors.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup: static credential store: %s: %w", id, err)
+	}
+	return c, nil
+}
+
+// ListCredentialStores returns a slice of CredentialStores in the given scopes.
+// Supports the WithLimit and WithStartPageAfterId options.
+func (r *Repository) ListCredentialStores(ctx context.Context, scopeIds []string, opt ...Option) ([]*CredentialStore, error) {
+	if len(scopeIds) == 0 {
+		return nil, fmt.Errorf("list: static credential store: missing scope id: %w", errors.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	where, args := "scope_id in (?)", []interface{}{scopeIds}
+	if opts.withStartPageAfterId != "" {
+		where, args = where+" and public_id > ?", append(args, opts.withStartPageAfterId)
+	}
+	var credentialStores []*CredentialStore
+	err := r.reader.SearchWhere(ctx, &credentialStores, where, args, db.WithLimit(limit), db.WithOrder("public_id"))
+	if err != nil {
+		return nil, fmt.Errorf("list: static credential store: %w", err)
+	}
+	return credentialStores, nil
+}
+
+// DeleteCredentialStore deletes id from the repository returning a count of the
+// number of records deleted.
+func (r *Repository) DeleteCredentialStore(ctx context.Context, id string, opt ...Option) (int, error) {
+	if id == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete: static credential store: missing public id: %w", errors.ErrInvalidParameter)
+	}
+
+	c := allocCredentialStore()
+	c.PublicId = id
+	if err := r.reader.LookupByPublicId(ctx, c); err != nil {
+		if errors.Is(err, errors.ErrRecordNotFound) {
+			return db.NoRowsAffected, nil
+		}
+		return db.NoRowsAffected, fmt.Errorf("delete: static credential store: failed %w for %s", err, id)
+	}
+	if c.ScopeId == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete: static credential store: missing scope id: %w", errors.ErrInvalidParameter)
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, c.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete: static credential store: unable to get oplog wrapper: %w", err)
+	}
+
+	metadata := c.oplog(oplog.OpType_OP_TYPE_DELETE)
+
+	var rowsDeleted int
+	var deleteStore *CredentialStore
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			deleteStore = c.clone()
+			var err error
+			rowsDeleted, err = w.Delete(
+				ctx,
+				deleteStore,
+				db.WithOplog(oplogWrapper, metadata),
+			)
+			if err == nil && rowsDeleted > 1 {
+				return errors.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete: static credential store: %s: %w", c.PublicId, err)
+	}
+
+	return rowsDeleted, nil
+}
@@ -0,0 +1,170 @@
+package static
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/credential/static/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_CreateCredentialStore(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	tests := []struct {
+		name      string
+		in        *CredentialStore
+		opts      []Option
+		wantIsErr error
+	}{
+		{
+			name:      "nil-store",
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name:      "nil-embedded-store",
+			in:        &CredentialStore{},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name: "invalid-public-id-set",
+			in: &CredentialStore{
+				CredentialStore: &store.CredentialStore{ScopeId: prj.GetPublicId(), PublicId: "csst_OOOOOOOOOO"},
+			},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name: "invalid-wrong-public-id-prefix",
+			in: &CredentialStore{
+				CredentialStore: &store.CredentialStore{ScopeId: prj.GetPublicId()},
+			},
+			opts:      []Option{WithPublicId("hcst_1234567890")},
+			wantIsErr: errors.ErrInvalidPublicId,
+		},
+		{
+			name: "valid-with-name",
+			in: &CredentialStore{
+				CredentialStore: &store.CredentialStore{ScopeId: prj.GetPublicId(), Name: "test-name-repo"},
+			},
+		},
+		{
+			name: "valid-with-description",
+			in: &CredentialStore{
+				CredentialStore: &store.CredentialStore{ScopeId: prj.GetPublicId(), Description: "test-description-repo"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			repo, err := NewRepository(rw, rw, kms)
+			require.NoError(err)
+			got, err := repo.CreateCredentialStore(context.Background(), tt.in, tt.opts...)
+			if tt.wantIsErr != nil {
+				assert.Truef(errors.Is(err, tt.wantIsErr), "want err: %q got: %q", tt.wantIsErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Empty(tt.in.PublicId)
+			assert.True(strings.HasPrefix(got.PublicId, CredentialStorePrefix+"_"))
+			assert.Equal(tt.in.Name, got.Name)
+			assert.Equal(tt.in.Description, got.Description)
+			assert.Equal(got.CreateTime, got.UpdateTime)
+		})
+	}
+
+	t.Run("invalid-duplicate-names", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		repo, err := NewRepository(rw, rw, kms)
+		require.NoError(err)
+		in := &CredentialStore{
+			CredentialStore: &store.CredentialStore{ScopeId: prj.GetPublicId(), Name: "duplicate-name"},
+		}
+		got, err := repo.CreateCredentialStore(context.Background(), in)
+		require.NoError(err)
+		require.NotNil(got)
+
+		got2, err := repo.CreateCredentialStore(context.Background(), in)
+		assert.Truef(errors.Is(err, errors.ErrNotUnique), "want err: %v got: %v", errors.ErrNotUnique, err)
+		assert.Nil(got2)
+	})
+}
+
+func TestRepository_UpdateCredentialStore(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	ctx := context.Background()
+
+	assert, require := assert.New(t), require.New(t)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+
+	cs := TestCredentialStores(t, conn, prj.GetPublicId(), 1)[0]
+	upd := cs.clone()
+	upd.Name = "updated-name"
+	got, rows, err := repo.UpdateCredentialStore(ctx, upd, cs.Version, []string{"name"})
+	require.NoError(err)
+	assert.Equal(1, rows)
+	assert.Equal("updated-name", got.Name)
+
+	_, _, err = repo.UpdateCredentialStore(ctx, upd, got.Version, []string{"scope_id"})
+	assert.Truef(errors.Is(err, errors.ErrInvalidFieldMask), "want err: %v got: %v", errors.ErrInvalidFieldMask, err)
+
+	_, rows, err = repo.UpdateCredentialStore(ctx, upd, got.Version+5, []string{"name"})
+	assert.Error(err)
+	assert.Equal(db.NoRowsAffected, rows)
+}
+
+func TestRepository_LookupListDeleteCredentialStore(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	ctx := context.Background()
+
+	assert, require := assert.New(t), require.New(t)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+
+	stores := TestCredentialStores(t, conn, prj.GetPublicId(), 3)
+
+	got, err := repo.LookupCredentialStore(ctx, stores[0].GetPublicId())
+	require.NoError(err)
+	assert.Equal(stores[0].GetPublicId(), got.GetPublicId())
+
+	got, err = repo.LookupCredentialStore(ctx, "csst_doesntexist")
+	assert.NoError(err)
+	assert.Nil(got)
+
+	list, err := repo.ListCredentialStores(ctx, []string{prj.GetPublicId()})
+	require.NoError(err)
+	assert.Len(list, 3)
+
+	list, err = repo.ListCredentialStores(ctx, []string{prj.GetPublicId()}, WithLimit(1))
+	require.NoError(err)
+	assert.Len(list, 1)
+
+	rows, err := repo.DeleteCredentialStore(ctx, stores[0].GetPublicId())
+	require.NoError(err)
+	assert.Equal(1, rows)
+
+	rows, err = repo.DeleteCredentialStore(ctx, stores[0].GetPublicId())
+	require.NoError(err)
+	assert.Equal(db.NoRowsAffected, rows)
+}
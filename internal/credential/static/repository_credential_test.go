@@ -0,0 +1,182 @@
+package static
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/credential/static/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_CreateCredential(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cs := TestCredentialStores(t, conn, prj.GetPublicId(), 1)[0]
+
+	tests := []struct {
+		name      string
+		in        *UsernamePasswordCredential
+		opts      []Option
+		wantIsErr error
+	}{
+		{
+			name:      "nil-credential",
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name:      "nil-embedded-credential",
+			in:        &UsernamePasswordCredential{},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name: "invalid-no-username",
+			in: &UsernamePasswordCredential{
+				UsernamePasswordCredential: &store.UsernamePasswordCredential{StoreId: cs.GetPublicId(), Password: "password"},
+			},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name: "invalid-no-password",
+			in: &UsernamePasswordCredential{
+				UsernamePasswordCredential: &store.UsernamePasswordCredential{StoreId: cs.GetPublicId(), Username: "user"},
+			},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name: "invalid-wrong-public-id-prefix",
+			in: &UsernamePasswordCredential{
+				UsernamePasswordCredential: &store.UsernamePasswordCredential{StoreId: cs.GetPublicId(), Username: "user", Password: "password"},
+			},
+			opts:      []Option{WithPublicId("hst_1234567890")},
+			wantIsErr: errors.ErrInvalidPublicId,
+		},
+		{
+			name: "valid",
+			in: &UsernamePasswordCredential{
+				UsernamePasswordCredential: &store.UsernamePasswordCredential{StoreId: cs.GetPublicId(), Username: "user", Password: "password", Name: "test-name"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			repo, err := NewRepository(rw, rw, kms)
+			require.NoError(err)
+			got, err := repo.CreateCredential(context.Background(), prj.GetPublicId(), tt.in, tt.opts...)
+			if tt.wantIsErr != nil {
+				assert.Truef(errors.Is(err, tt.wantIsErr), "want err: %q got: %q", tt.wantIsErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Empty(tt.in.PublicId)
+			assert.True(strings.HasPrefix(got.PublicId, UsernamePasswordCredentialPrefix+"_"))
+			assert.Equal(tt.in.Username, got.Username)
+			assert.Equal(tt.in.Name, got.Name)
+			assert.Empty(got.Password)
+			assert.Empty(got.CtPassword)
+			assert.NotEmpty(got.PasswordHmac)
+			assert.NotEmpty(got.KeyId)
+		})
+	}
+}
+
+func TestRepository_UpdateCredential(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	ctx := context.Background()
+	databaseWrapper, err := kmsCache.GetWrapper(ctx, prj.GetPublicId(), kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+	cs := TestCredentialStores(t, conn, prj.GetPublicId(), 1)[0]
+
+	assert, require := assert.New(t), require.New(t)
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+
+	c := TestCredentials(t, conn, databaseWrapper, cs.GetPublicId(), 1)[0]
+	upd := c.clone()
+	upd.Username = "new-user"
+	upd.Password = "new-password"
+	got, rows, err := repo.UpdateCredential(ctx, prj.GetPublicId(), upd, c.Version, []string{"Username", "Password"})
+	require.NoError(err)
+	assert.Equal(1, rows)
+	assert.Equal("new-user", got.Username)
+	assert.Empty(got.Password)
+	assert.NotEqual(c.PasswordHmac, got.PasswordHmac)
+
+	creds, err := repo.ListCredentialsWithPasswords(ctx, prj.GetPublicId(), []string{c.GetPublicId()})
+	require.NoError(err)
+	require.Len(creds, 1)
+	assert.Equal("new-password", creds[0].Password)
+
+	upd.Password = ""
+	_, _, err = repo.UpdateCredential(ctx, prj.GetPublicId(), upd, got.Version, []string{"Password"})
+	assert.Truef(errors.Is(err, errors.ErrInvalidParameter), "want err: %v got: %v", errors.ErrInvalidParameter, err)
+
+	_, _, err = repo.UpdateCredential(ctx, prj.GetPublicId(), upd, got.Version, []string{"StoreId"})
+	assert.Truef(errors.Is(err, errors.ErrInvalidFieldMask), "want err: %v got: %v", errors.ErrInvalidFieldMask, err)
+}
+
+func TestRepository_LookupListDeleteCredential(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	ctx := context.Background()
+	databaseWrapper, err := kmsCache.GetWrapper(ctx, prj.GetPublicId(), kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+	cs := TestCredentialStores(t, conn, prj.GetPublicId(), 1)[0]
+
+	assert, require := assert.New(t), require.New(t)
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+
+	creds := TestCredentials(t, conn, databaseWrapper, cs.GetPublicId(), 3)
+
+	got, err := repo.LookupCredential(ctx, creds[0].GetPublicId())
+	require.NoError(err)
+	assert.Equal(creds[0].GetUsername(), got.GetUsername())
+	assert.Empty(got.GetPassword())
+	assert.Empty(got.GetCtPassword())
+
+	list, err := repo.ListCredentials(ctx, cs.GetPublicId())
+	require.NoError(err)
+	assert.Len(list, 3)
+	for _, c := range list {
+		assert.Empty(c.GetCtPassword())
+	}
+
+	withPasswords, err := repo.ListCredentialsWithPasswords(ctx, prj.GetPublicId(), []string{creds[1].GetPublicId(), "credup_doesntexist"})
+	require.NoError(err)
+	require.Len(withPasswords, 1)
+	assert.Equal(creds[1].GetPassword(), withPasswords[0].GetPassword())
+
+	rows, err := repo.DeleteCredential(ctx, prj.GetPublicId(), creds[0].GetPublicId())
+	require.NoError(err)
+	assert.Equal(1, rows)
+
+	got, err = repo.LookupCredential(ctx, creds[0].GetPublicId())
+	assert.NoError(err)
+	assert.Nil(got)
+
+	// Deleting the store deletes its credentials
+	_, err = repo.DeleteCredentialStore(ctx, cs.GetPublicId())
+	require.NoError(err)
+	list, err = repo.ListCredentials(ctx, cs.GetPublicId())
+	require.NoError(err)
+	assert.Empty(list)
+}
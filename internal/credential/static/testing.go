@@ -0,0 +1,68 @@
+package static
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCredentialStores creates count number of static credential stores to
+// the provided DB with the provided scope id. If any errors are encountered
+// during the creation of the credential stores, the test will fail.
+func TestCredentialStores(t *testing.T, conn *gorm.DB, scopeId string, count int) []*CredentialStore {
+	t.Helper()
+	assert := assert.New(t)
+	var stores []*CredentialStore
+	for i := 0; i < count; i++ {
+		cs, err := NewCredentialStore(scopeId)
+		assert.NoError(err)
+		assert.NotNil(cs)
+		id, err := newCredentialStoreId()
+		assert.NoError(err)
+		assert.NotEmpty(id)
+		cs.PublicId = id
+
+		w := db.New(conn)
+		err2 := w.Create(context.Background(), cs)
+		assert.NoError(err2)
+		stores = append(stores, cs)
+	}
+	return stores
+}
+
+// TestCredentials creates count number of username password credentials to
+// the provided DB with the provided store id. The store must have been
+// created previously. The passwords are encrypted with wrapper, which should
+// be the database wrapper of the store's scope for the passwords to be
+// decrypted by a Repository. The returned credentials contain the plain-text
+// passwords. If any errors are encountered during the creation of the
+// credentials, the test will fail.
+func TestCredentials(t *testing.T, conn *gorm.DB, wrapper wrapping.Wrapper, storeId string, count int) []*UsernamePasswordCredential {
+	t.Helper()
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	var creds []*UsernamePasswordCredential
+	for i := 0; i < count; i++ {
+		c, err := NewUsernamePasswordCredential(storeId, fmt.Sprintf("user%d", i), fmt.Sprintf("password%d", i))
+		assert.NoError(err)
+		require.NotNil(c)
+		id, err := newUsernamePasswordCredentialId()
+		assert.NoError(err)
+		require.NotEmpty(id)
+		c.PublicId = id
+		require.NoError(c.encrypt(ctx, wrapper))
+
+		w := db.New(conn)
+		err2 := w.Create(ctx, c)
+		require.NoError(err2)
+		c.CtPassword = nil
+		creds = append(creds, c)
+	}
+	return creds
+}
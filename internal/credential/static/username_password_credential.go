@@ -0,0 +1,128 @@
+package static
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/credential/static/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/structwrapping"
+	"github.com/hashicorp/go-kms-wrapping/wrappers/aead"
+	"github.com/hashicorp/go-kms-wrapping/wrappers/multiwrapper"
+	"google.golang.org/protobuf/proto"
+)
+
+// A UsernamePasswordCredential contains a username and a password. It is
+// owned by a credential store.
+type UsernamePasswordCredential struct {
+	*store.UsernamePasswordCredential
+	tableName string `gorm:"-"`
+}
+
+// NewUsernamePasswordCredential creates a new in memory
+// UsernamePasswordCredential for username and password assigned to storeId.
+// Name and description are the only valid options. All other options are
+// ignored.
+func NewUsernamePasswordCredential(storeId, username, password string, opt ...Option) (*UsernamePasswordCredential, error) {
+	if storeId == "" {
+		return nil, fmt.Errorf("new: static username password credential: no store id: %w", errors.ErrInvalidParameter)
+	}
+
+	opts := getOpts(opt...)
+	c := &UsernamePasswordCredential{
+		UsernamePasswordCredential: &store.UsernamePasswordCredential{
+			StoreId:     storeId,
+			Username:    username,
+			Password:    password,
+			Name:        opts.withName,
+			Description: opts.withDescription,
+		},
+	}
+	return c, nil
+}
+
+// TableName returns the table name for the credential.
+func (c *UsernamePasswordCredential) TableName() string {
+	if c.tableName != "" {
+		return c.tableName
+	}
+	return "credential_static_username_password"
+}
+
+// SetTableName sets the table name. If the caller attempts to
+// set the name to "" the name will be reset to the default name.
+func (c *UsernamePasswordCredential) SetTableName(n string) {
+	c.tableName = n
+}
+
+func allocUsernamePasswordCredential() *UsernamePasswordCredential {
+	return &UsernamePasswordCredential{
+		UsernamePasswordCredential: &store.UsernamePasswordCredential{},
+	}
+}
+
+func (c *UsernamePasswordCredential) clone() *UsernamePasswordCredential {
+	cp := proto.Clone(c.UsernamePasswordCredential)
+	return &UsernamePasswordCredential{
+		UsernamePasswordCredential: cp.(*store.UsernamePasswordCredential),
+	}
+}
+
+func (c *UsernamePasswordCredential) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{c.PublicId},
+		"resource-type":      []string{"static username password credential"},
+		"op-type":            []string{op.String()},
+	}
+	if c.StoreId != "" {
+		metadata["store-id"] = []string{c.StoreId}
+	}
+	return metadata
+}
+
+// encrypt encrypts the password and sets the password hmac and the key id.
+func (c *UsernamePasswordCredential) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	// structwrapping doesn't support embedding, so we'll pass in the store.UsernamePasswordCredential directly
+	if err := structwrapping.WrapStruct(ctx, cipher, c.UsernamePasswordCredential, nil); err != nil {
+		return fmt.Errorf("error encrypting password: %w", err)
+	}
+	h, err := hmacPassword(cipher, c.Password)
+	if err != nil {
+		return err
+	}
+	c.PasswordHmac = h
+	c.KeyId = cipher.KeyID()
+	return nil
+}
+
+func (c *UsernamePasswordCredential) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	// structwrapping doesn't support embedding, so we'll pass in the store.UsernamePasswordCredential directly
+	if err := structwrapping.UnwrapStruct(ctx, cipher, c.UsernamePasswordCredential, nil); err != nil {
+		return fmt.Errorf("error decrypting password: %w", err)
+	}
+	return nil
+}
+
+// hmacPassword returns a sha256-hmac of password keyed with the key of
+// cipher. The hmac lets callers detect a change to the password without the
+// password being returned to them.
+func hmacPassword(cipher wrapping.Wrapper, password string) (string, error) {
+	w := cipher
+	if mw, ok := w.(*multiwrapper.MultiWrapper); ok {
+		w = mw.WrapperForKeyID(mw.KeyID())
+	}
+	aw, ok := w.(*aead.Wrapper)
+	if !ok {
+		return "", fmt.Errorf("unable to hmac password: unsupported wrapper type %T: %w", cipher, errors.ErrInvalidParameter)
+	}
+	mac := hmac.New(sha256.New, aw.GetKeyBytes())
+	if _, err := mac.Write([]byte(password)); err != nil {
+		return "", fmt.Errorf("unable to hmac password: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
@@ -0,0 +1,82 @@
+package static
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/credential/static/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsernamePasswordCredential_New(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		storeId   string
+		opts      []Option
+		want      *UsernamePasswordCredential
+		wantIsErr error
+	}{
+		{
+			name:      "blank-store-id",
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name:    "valid-with-name-and-description",
+			storeId: "csst_1234567890",
+			opts:    []Option{WithName("test-name"), WithDescription("test-description")},
+			want: &UsernamePasswordCredential{
+				UsernamePasswordCredential: &store.UsernamePasswordCredential{
+					StoreId:     "csst_1234567890",
+					Username:    "user",
+					Password:    "password",
+					Name:        "test-name",
+					Description: "test-description",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			got, err := NewUsernamePasswordCredential(tt.storeId, "user", "password", tt.opts...)
+			if tt.wantIsErr != nil {
+				assert.Truef(errors.Is(err, tt.wantIsErr), "want err: %q got: %q", tt.wantIsErr, err)
+				assert.Nil(got)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestUsernamePasswordCredential_EncryptDecrypt(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	wrapper := db.TestWrapper(t)
+
+	c, err := NewUsernamePasswordCredential("csst_1234567890", "user", "password")
+	require.NoError(err)
+	require.NoError(c.encrypt(ctx, wrapper))
+	assert.NotEmpty(c.CtPassword)
+	assert.NotEmpty(c.PasswordHmac)
+	assert.Equal(wrapper.KeyID(), c.KeyId)
+
+	// The hmac does not change for the same password and key
+	h, err := hmacPassword(wrapper, "password")
+	require.NoError(err)
+	assert.Equal(h, c.PasswordHmac)
+	h, err = hmacPassword(wrapper, "other")
+	require.NoError(err)
+	assert.NotEqual(h, c.PasswordHmac)
+
+	c.Password = ""
+	require.NoError(c.decrypt(ctx, wrapper))
+	assert.Equal("password", c.Password)
+}
@@ -5355,6 +5355,2999 @@ begin;
 
 commit;
 
+`),
+	},
+	"migrations/70_server_tags.down.sql": {
+		name: "70_server_tags.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop table server_tag;
+
+commit;
+
+`),
+	},
+	"migrations/70_server_tags.up.sql": {
+		name: "70_server_tags.up.sql",
+		bytes: []byte(`
+begin;
+
+  -- server_tag holds the key/value tags reported by a server in its status
+  -- updates. A key can have multiple values, so the full tuple makes up the
+  -- primary key.
+  create table server_tag (
+    server_id text not null,
+    server_type text not null,
+    key text not null
+      constraint server_tag_key_must_not_be_empty
+      check(length(trim(key)) > 0),
+    value text not null
+      constraint server_tag_value_must_not_be_empty
+      check(length(trim(value)) > 0),
+    primary key (server_id, server_type, key, value),
+    foreign key (server_id, server_type)
+      references server (private_id, type)
+      on delete cascade
+      on update cascade
+  );
+
+commit;
+
+`),
+	},
+	"migrations/71_target_worker_filter.down.sql": {
+		name: "71_target_worker_filter.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop view target_all_subtypes;
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp;
+
+  alter table target_tcp
+    drop column worker_filter;
+
+commit;
+
+`),
+	},
+	"migrations/71_target_worker_filter.up.sql": {
+		name: "71_target_worker_filter.up.sql",
+		bytes: []byte(`
+begin;
+
+  -- worker_filter is a boolean expression evaluated against the name and tags
+  -- of each worker to decide which workers can handle sessions for the
+  -- target. A null filter allows any worker.
+  alter table target_tcp
+    add column worker_filter text
+      constraint worker_filter_must_not_be_empty
+      check(length(trim(worker_filter)) > 0);
+
+  -- target_all_subtypes is recreated to include the worker_filter column.
+  drop view target_all_subtypes;
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp;
+
+commit;
+
+`),
+	},
+	"migrations/72_host_plugin.down.sql": {
+		name: "72_host_plugin.down.sql",
+		bytes: []byte(`
+begin;
+
+  -- Restores the view created in 65_wh_session_dimensions.
+  create or replace view whx_host_dimension_source as
+  select -- id is the first column in the target view
+         h.public_id                     as host_id,
+         'static host'                   as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'static host set'               as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'static host catalog'           as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         'tcp target'                    as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from static_host as h,
+         static_host_catalog as c,
+         static_host_set_member as m,
+         static_host_set as s,
+         target_host_set as ts,
+         target_tcp as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  ;
+
+  drop table host_plugin_set_sync cascade;
+  drop table host_plugin_set_member cascade;
+  drop table host_plugin_set cascade;
+  drop table host_plugin_host cascade;
+  drop table host_plugin_catalog cascade;
+  drop function insert_host_plugin_set_member;
+
+  delete
+    from oplog_ticket
+   where name in (
+          'host_plugin_catalog',
+          'host_plugin_set'
+        );
+
+commit;
+
+`),
+	},
+	"migrations/72_host_plugin.up.sql": {
+		name: "72_host_plugin.up.sql",
+		bytes: []byte(`
+begin;
+
+/*
+
+  ┌─────────────────┐          ┌─────────────────────┐
+  │      host       │          │  host_plugin_host   │
+  ├─────────────────┤          ├─────────────────────┤
+  │ public_id  (pk) │          │ public_id  (pk)     │
+  │ catalog_id (fk) │┼┼──────○┼│ catalog_id (fk)     │┼┼─────────────────────┐
+  │                 │          │ external_id         │             ◀fk1      │
+  │                 │          │ address             │                       │
+  └─────────────────┘          └─────────────────────┘                       │
+          ╲│╱                            ╲│╱                                 │
+           ○                              ○                                  │
+           │                              │                                  │
+           ┼                              ┼                                  ○
+           ┼                              ┼                                 ╱│╲
+  ┌─────────────────┐          ┌─────────────────────┐          ┌────────────────────────┐
+  │  host_catalog   │          │ host_plugin_catalog │          │ host_plugin_set_member │
+  ├─────────────────┤          ├─────────────────────┤          ├────────────────────────┤
+  │ public_id (pk)  │          │ public_id (pk)      │          │ host_id    (pk,fk1)    │
+  │ scope_id  (fk)  │┼┼──────○┼│ scope_id  (fk)      │          │ set_id     (pk,fk2)    │
+  │                 │          │ plugin_name         │          │ catalog_id (fk1,fk2)   │
+  │                 │          │ attributes          │          │                        │
+  └─────────────────┘          └─────────────────────┘          └────────────────────────┘
+           ┼                              ┼                                 ╲│╱
+           ┼                              ┼                                  ○
+           │                              │                                  │
+           ○                              ○                                  │
+          ╱│╲                            ╱│╲                                 │
+  ┌─────────────────┐          ┌─────────────────────┐                       │
+  │    host_set     │          │   host_plugin_set   │                       │
+  ├─────────────────┤          ├─────────────────────┤                       │
+  │ public_id  (pk) │          │ public_id  (pk)     │             ◀fk2      │
+  │ catalog_id (fk) │┼┼──────○┼│ catalog_id (fk)     │┼┼─────────────────────┘
+  │                 │          │ filter              │
+  └─────────────────┘          └─────────────────────┘
+
+  The hosts and the set members of a plugin host catalog are a cache of the
+  external inventory queried through the plugin. The last time the members of
+  a set were retrieved is recorded in host_plugin_set_sync.
+
+*/
+
+  create table host_plugin_catalog (
+    public_id wt_public_id
+      primary key,
+    scope_id wt_scope_id
+      not null
+      references iam_scope (public_id)
+      on delete cascade
+      on update cascade,
+    name text,
+    description text,
+    plugin_name text not null
+      constraint plugin_name_must_not_be_empty
+      check(length(trim(plugin_name)) > 0),
+    attributes bytea not null,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    foreign key (scope_id, public_id)
+      references host_catalog (scope_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(scope_id, name)
+  );
+
+  create trigger update_version_column after update on host_plugin_catalog
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on host_plugin_catalog
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on host_plugin_catalog
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on host_plugin_catalog
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'plugin_name', 'create_time');
+
+  create trigger insert_host_catalog_subtype before insert on host_plugin_catalog
+    for each row execute procedure insert_host_catalog_subtype();
+
+  create trigger delete_host_catalog_subtype after delete on host_plugin_catalog
+    for each row execute procedure delete_host_catalog_subtype();
+
+  create table host_plugin_host (
+    public_id wt_public_id primary key,
+    catalog_id wt_public_id not null
+      references host_plugin_catalog (public_id)
+      on delete cascade
+      on update cascade,
+    external_id text not null
+      constraint external_id_must_not_be_empty
+      check(length(trim(external_id)) > 0),
+    name text,
+    address text not null
+      constraint address_must_be_more_than_2_characters
+      check(length(trim(address)) > 2)
+      constraint address_must_be_less_than_256_characters
+      check(length(trim(address)) < 256),
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    unique(catalog_id, external_id),
+
+    foreign key (catalog_id, public_id)
+      references host (catalog_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(catalog_id, public_id)
+  );
+
+  create trigger update_version_column after update on host_plugin_host
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on host_plugin_host
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on host_plugin_host
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on host_plugin_host
+    for each row execute procedure immutable_columns('public_id', 'catalog_id', 'external_id', 'create_time');
+
+  create trigger insert_host_subtype before insert on host_plugin_host
+    for each row execute procedure insert_host_subtype();
+
+  create trigger delete_host_subtype after delete on host_plugin_host
+    for each row execute procedure delete_host_subtype();
+
+  create table host_plugin_set (
+    public_id wt_public_id primary key,
+    catalog_id wt_public_id not null
+      references host_plugin_catalog (public_id)
+      on delete cascade
+      on update cascade,
+    name text,
+    description text,
+    filter text not null
+      constraint filter_must_not_be_empty
+      check(length(trim(filter)) > 0),
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    unique(catalog_id, name),
+    foreign key (catalog_id, public_id)
+      references host_set (catalog_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(catalog_id, public_id)
+  );
+
+  create trigger update_version_column after update on host_plugin_set
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on host_plugin_set
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on host_plugin_set
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on host_plugin_set
+    for each row execute procedure immutable_columns('public_id', 'catalog_id', 'create_time');
+
+  create trigger insert_host_set_subtype before insert on host_plugin_set
+    for each row execute procedure insert_host_set_subtype();
+
+  create trigger delete_host_set_subtype after delete on host_plugin_set
+    for each row execute procedure delete_host_set_subtype();
+
+  create table host_plugin_set_member (
+    host_id wt_public_id not null,
+    set_id wt_public_id not null,
+    catalog_id wt_public_id not null,
+    primary key(host_id, set_id),
+    foreign key (catalog_id, host_id) -- fk1
+      references host_plugin_host (catalog_id, public_id)
+      on delete cascade
+      on update cascade,
+    foreign key (catalog_id, set_id) -- fk2
+      references host_plugin_set (catalog_id, public_id)
+      on delete cascade
+      on update cascade
+  );
+
+  create trigger immutable_columns before update on host_plugin_set_member
+    for each row execute procedure immutable_columns('host_id', 'set_id', 'catalog_id');
+
+  create or replace function insert_host_plugin_set_member()
+    returns trigger
+  as $$
+  begin
+    select host_plugin_set.catalog_id
+      into new.catalog_id
+    from host_plugin_set
+    where host_plugin_set.public_id = new.set_id;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger insert_host_plugin_set_member before insert on host_plugin_set_member
+    for each row execute procedure insert_host_plugin_set_member();
+
+  -- host_plugin_set_sync is kept separate from host_plugin_set so that
+  -- refreshing the members of a set does not change the version of the set.
+  create table host_plugin_set_sync (
+    set_id wt_public_id primary key
+      references host_plugin_set (public_id)
+      on delete cascade
+      on update cascade,
+    last_sync_time wt_timestamp
+  );
+
+  insert into oplog_ticket (name, version)
+  values
+    ('host_plugin_catalog', 1),
+    ('host_plugin_set', 1);
+
+  -- Replaces the view created in 65_wh_session_dimensions to include plugin
+  -- hosts.
+  create or replace view whx_host_dimension_source as
+  select -- id is the first column in the target view
+         h.public_id                     as host_id,
+         'static host'                   as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'static host set'               as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'static host catalog'           as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         'tcp target'                    as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from static_host as h,
+         static_host_catalog as c,
+         static_host_set_member as m,
+         static_host_set as s,
+         target_host_set as ts,
+         target_tcp as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+   union all
+  select h.public_id                     as host_id,
+         'plugin host'                   as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         'None'                          as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'plugin host set'               as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'plugin host catalog'           as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         'tcp target'                    as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from host_plugin_host as h,
+         host_plugin_catalog as c,
+         host_plugin_set_member as m,
+         host_plugin_set as s,
+         target_host_set as ts,
+         target_tcp as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  ;
+
+commit;
+
+`),
+	},
+	"migrations/73_auth_oidc.down.sql": {
+		name: "73_auth_oidc.down.sql",
+		bytes: []byte(`
+begin;
+
+  -- Restores the view created in 65_wh_session_dimensions.
+  create or replace view whx_user_dimension_source as
+       select -- id is the first column in the target view
+              u.public_id                       as user_id,
+              coalesce(u.name, 'None')          as user_name,
+              coalesce(u.description, 'None')   as user_description,
+              coalesce(aa.public_id, 'None')    as auth_account_id,
+              case when aa.public_id is null then 'None'
+                   else 'password auth account'
+                   end                          as auth_account_type,
+              coalesce(apa.name, 'None')        as auth_account_name,
+              coalesce(apa.description, 'None') as auth_account_description,
+              coalesce(am.public_id, 'None')    as auth_method_id,
+              case when am.public_id is null then 'None'
+                   else 'password auth method'
+                   end                          as auth_method_type,
+              coalesce(apm.name, 'None')        as auth_method_name,
+              coalesce(apm.description, 'None') as auth_method_description,
+              org.public_id                     as user_organization_id,
+              coalesce(org.name, 'None')        as user_organization_name,
+              coalesce(org.description, 'None') as user_organization_description
+         from iam_user as u
+    left join auth_account as aa on           u.public_id = aa.iam_user_id
+    left join auth_method as am on            aa.auth_method_id = am.public_id
+    left join auth_password_account as apa on aa.public_id = apa.public_id
+    left join auth_password_method as apm on  am.public_id = apm.public_id
+         join iam_scope as org on             u.scope_id = org.public_id
+  ;
+
+  drop table auth_oidc_token_request cascade;
+  drop table auth_oidc_account cascade;
+  drop table auth_oidc_aud_claim cascade;
+  drop table auth_oidc_method cascade;
+
+  delete
+    from oplog_ticket
+   where name in (
+          'auth_oidc_method',
+          'auth_oidc_aud_claim',
+          'auth_oidc_account'
+        );
+
+commit;
+
+`),
+	},
+	"migrations/73_auth_oidc.up.sql": {
+		name: "73_auth_oidc.up.sql",
+		bytes: []byte(`
+begin;
+
+/*
+
+       ┌────────────────┐                 ┌──────────────────────┐             ┌────────────────────────────┐
+       │  auth_method   │                 │   auth_oidc_method   │             │    auth_oidc_aud_claim     │
+       ├────────────────┤                 ├──────────────────────┤             ├────────────────────────────┤
+       │ public_id (pk) │                 │ public_id (pk,fk)    │            ╱│ oidc_method_id (pk,fk)     │
+       │ scope_id  (fk) │┼┼─────────────○┼│ scope_id  (fk)       │┼┼─────────○─│ aud_claim      (pk)        │
+       │                │                 │ ...                  │            ╲│                            │
+       └────────────────┘                 └──────────────────────┘             └────────────────────────────┘
+                ┼                                     ┼
+                ┼                                     ┼
+                │                                     │
+                │ ▲fk1                                │ ▲fk1
+                │                                     │
+                ○                                     ○
+               ╱│╲                                   ╱│╲
+  ┌──────────────────────────┐          ┌──────────────────────────┐
+  │       auth_account       │          │    auth_oidc_account     │
+  ├──────────────────────────┤          ├──────────────────────────┤
+  │ public_id         (pk)   │          │ public_id      (pk,fk2)  │
+  │ scope_id          (fk1)  │   ◀fk2   │ scope_id       (fk1,fk2) │
+  │ auth_method_id    (fk1)  │┼┼──────○┼│ auth_method_id (fk1,fk2) │
+  │ iam_user_scope_id (fk2)  │          │ subject                  │
+  │ iam_user_id       (fk2)  │          │ ...                      │
+  └──────────────────────────┘          └──────────────────────────┘
+
+  An auth_oidc_method is an auth_method subtype and an auth_oidc_account is
+  an auth_account subtype.
+
+  An auth_oidc_method can have 0 to many auth_oidc_aud_claims. The client_id
+  of the method is always an accepted audience and is not stored in
+  auth_oidc_aud_claim.
+
+  An auth_oidc_account is identified within its auth method by the sub claim
+  of the ID tokens issued by the provider.
+
+  An auth_oidc_token_request tracks an authentication attempt from the time
+  it is started until the client redeems it for an auth token. The
+  auth_account_id is set when the provider redirects the user to the
+  callback.
+
+*/
+
+  create table auth_oidc_method (
+    public_id wt_public_id
+      primary key,
+    scope_id wt_scope_id
+      not null,
+    name text,
+    description text,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    issuer text not null
+      constraint issuer_must_not_be_empty
+      check(length(trim(issuer)) > 0),
+    client_id text not null
+      constraint client_id_must_not_be_empty
+      check(length(trim(client_id)) > 0),
+    client_secret bytea not null, -- encrypted
+    client_secret_hmac text not null
+      constraint client_secret_hmac_must_not_be_empty
+      check(length(trim(client_secret_hmac)) > 0),
+    -- TODO: Make key_id a foreign key once we have DEKs
+    key_id text not null
+      constraint key_id_must_not_be_empty
+      check(length(trim(key_id)) > 0),
+    callback_url text not null
+      constraint callback_url_must_not_be_empty
+      check(length(trim(callback_url)) > 0),
+    foreign key (scope_id, public_id)
+      references auth_method (scope_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(scope_id, name),
+    unique(scope_id, public_id)
+  );
+
+  create trigger update_version_column after update on auth_oidc_method
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on auth_oidc_method
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on auth_oidc_method
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on auth_oidc_method
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create trigger insert_auth_method_subtype before insert on auth_oidc_method
+    for each row execute procedure insert_auth_method_subtype();
+
+  create table auth_oidc_aud_claim (
+    oidc_method_id wt_public_id
+      not null
+      references auth_oidc_method (public_id)
+      on delete cascade
+      on update cascade,
+    aud_claim text not null
+      constraint aud_claim_must_not_be_empty
+      check(length(trim(aud_claim)) > 0)
+      constraint aud_claim_must_be_less_than_1024_characters
+      check(length(trim(aud_claim)) < 1024),
+    create_time wt_timestamp,
+    primary key(oidc_method_id, aud_claim)
+  );
+
+  create trigger default_create_time_column before insert on auth_oidc_aud_claim
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on auth_oidc_aud_claim
+    for each row execute procedure immutable_columns('oidc_method_id', 'aud_claim', 'create_time');
+
+  create table auth_oidc_account (
+    public_id wt_public_id
+      primary key,
+    auth_method_id wt_public_id
+      not null,
+    -- NOTE(mgaffney): The scope_id type is not wt_scope_id because the domain
+    -- check is executed before the insert trigger which retrieves the scope_id
+    -- causing an insert to fail.
+    scope_id text not null,
+    name text,
+    description text,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    subject text not null
+      constraint subject_must_not_be_empty
+      check(length(trim(subject)) > 0)
+      constraint subject_must_be_less_than_256_characters
+      check(length(subject) < 256),
+    email text
+      constraint email_must_be_less_than_1024_characters
+      check(length(email) < 1024),
+    full_name text
+      constraint full_name_must_be_less_than_1024_characters
+      check(length(full_name) < 1024),
+    foreign key (scope_id, auth_method_id)
+      references auth_oidc_method (scope_id, public_id)
+      on delete cascade
+      on update cascade,
+    foreign key (scope_id, auth_method_id, public_id)
+      references auth_account (scope_id, auth_method_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(auth_method_id, name),
+    unique(auth_method_id, subject),
+    unique(auth_method_id, public_id)
+  );
+
+  create trigger update_version_column after update on auth_oidc_account
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on auth_oidc_account
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on auth_oidc_account
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on auth_oidc_account
+    for each row execute procedure immutable_columns('public_id', 'auth_method_id', 'scope_id', 'subject', 'create_time');
+
+  create trigger insert_auth_account_subtype before insert on auth_oidc_account
+    for each row execute procedure insert_auth_account_subtype();
+
+  create table auth_oidc_token_request (
+    public_id wt_public_id
+      primary key,
+    auth_method_id wt_public_id
+      not null
+      references auth_oidc_method (public_id)
+      on delete cascade
+      on update cascade,
+    auth_account_id wt_public_id
+      references auth_oidc_account (public_id)
+      on delete cascade
+      on update cascade,
+    nonce text not null
+      constraint nonce_must_not_be_empty
+      check(length(trim(nonce)) > 0),
+    create_time wt_timestamp,
+    expiration_time wt_timestamp
+      constraint create_time_must_not_be_after_expiration_time
+      check(
+        create_time <= expiration_time
+      )
+  );
+
+  create trigger default_create_time_column before insert on auth_oidc_token_request
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on auth_oidc_token_request
+    for each row execute procedure immutable_columns('public_id', 'auth_method_id', 'nonce', 'create_time', 'expiration_time');
+
+  insert into oplog_ticket (name, version)
+  values
+    ('auth_oidc_method', 1),
+    ('auth_oidc_aud_claim', 1),
+    ('auth_oidc_account', 1);
+
+  -- Replaces the view created in 65_wh_session_dimensions to include oidc
+  -- accounts and auth methods.
+  create or replace view whx_user_dimension_source as
+       select -- id is the first column in the target view
+              u.public_id                       as user_id,
+              coalesce(u.name, 'None')          as user_name,
+              coalesce(u.description, 'None')   as user_description,
+              coalesce(aa.public_id, 'None')    as auth_account_id,
+              case when aa.public_id is null then 'None'
+                   when aoa.public_id is not null then 'oidc auth account'
+                   else 'password auth account'
+                   end                          as auth_account_type,
+              coalesce(apa.name, aoa.name, 'None')               as auth_account_name,
+              coalesce(apa.description, aoa.description, 'None') as auth_account_description,
+              coalesce(am.public_id, 'None')    as auth_method_id,
+              case when am.public_id is null then 'None'
+                   when aom.public_id is not null then 'oidc auth method'
+                   else 'password auth method'
+                   end                          as auth_method_type,
+              coalesce(apm.name, aom.name, 'None')               as auth_method_name,
+              coalesce(apm.description, aom.description, 'None') as auth_method_description,
+              org.public_id                     as user_organization_id,
+              coalesce(org.name, 'None')        as user_organization_name,
+              coalesce(org.description, 'None') as user_organization_description
+         from iam_user as u
+    left join auth_account as aa on           u.public_id = aa.iam_user_id
+    left join auth_method as am on            aa.auth_method_id = am.public_id
+    left join auth_password_account as apa on aa.public_id = apa.public_id
+    left join auth_password_method as apm on  am.public_id = apm.public_id
+    left join auth_oidc_account as aoa on     aa.public_id = aoa.public_id
+    left join auth_oidc_method as aom on      am.public_id = aom.public_id
+         join iam_scope as org on             u.scope_id = org.public_id
+  ;
+
+commit;
+
+`),
+	},
+	"migrations/74_target_http.down.sql": {
+		name: "74_target_http.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop table session_connection_http_request;
+
+  drop view target_all_subtypes;
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp;
+
+  drop trigger target_name_unique_in_scope on target_tcp;
+
+  drop table target_http_header;
+  drop table target_http;
+
+  drop function target_name_unique_in_scope;
+
+  delete from oplog_ticket where name in ('target_http');
+
+commit;
+
+`),
+	},
+	"migrations/74_target_http.up.sql": {
+		name: "74_target_http.up.sql",
+		bytes: []byte(`
+begin;
+
+/*
+
+  ┌─────────────────┐          ┌────────────────────┐
+  │     target      │          │    target_http     │
+  ├─────────────────┤          ├────────────────────┤
+  │ public_id  (pk) │┼┼──────○┼│ public_id  (pk,fk) │
+  │ scope_id   (fk) │          │ scope_id   (fk)    │
+  │                 │          │ default_port       │
+  └─────────────────┘          └────────────────────┘
+                                         ┼
+                                         ┼
+                                         │
+                                         ○
+                                        ╱│╲
+                               ┌────────────────────┐
+                               │ target_http_header │
+                               ├────────────────────┤
+                               │ target_id (pk,fk)  │
+                               │ name      (pk)     │
+                               │ value              │
+                               │ value_hmac         │
+                               │ key_id             │
+                               └────────────────────┘
+
+  The worker terminates the client stream of a session for an http target and
+  forwards each HTTP request to the endpoint, adding the target's headers. The
+  value of a header is encrypted with a database key of the target's scope.
+  The method, path and status of each forwarded request is recorded in
+  session_connection_http_request.
+
+*/
+
+  create table target_http (
+    public_id wt_public_id primary key
+      references target(public_id)
+      on delete cascade
+      on update cascade,
+    scope_id wt_scope_id not null
+      references iam_scope(public_id)
+      on delete cascade
+      on update cascade,
+    name text not null, -- name is not optional for a target subtype
+    description text,
+    default_port int, -- default_port can be null
+    -- max duration of the session in seconds.
+    -- default is 8 hours
+    session_max_seconds int not null default 28800
+      constraint session_max_seconds_must_be_greater_than_0
+      check(session_max_seconds > 0),
+    -- limit on number of session connections allowed. -1 equals no limit
+    session_connection_limit int not null default 1
+      constraint session_connection_limit_must_be_greater_than_0_or_negative_1
+      check(session_connection_limit > 0 or session_connection_limit = -1),
+    worker_filter text
+      constraint worker_filter_must_not_be_empty
+      check(length(trim(worker_filter)) > 0),
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    unique(scope_id, name) -- name must be unique within a scope
+  );
+
+  create trigger insert_target_subtype before insert on target_http
+    for each row execute procedure insert_target_subtype();
+
+  create trigger delete_target_subtype after delete on target_http
+    for each row execute procedure delete_target_subtype();
+
+  create trigger immutable_columns before update on target_http
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create trigger update_version_column after update on target_http
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on target_http
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on target_http
+    for each row execute procedure default_create_time();
+
+  create trigger target_scope_valid before insert on target_http
+    for each row execute procedure target_scope_valid();
+
+  -- target_name_unique_in_scope() is a before insert and update trigger
+  -- function which ensures the name of a target is unique within its scope
+  -- across all target subtypes.
+  create or replace function target_name_unique_in_scope()
+    returns trigger
+  as $$
+  begin
+    if exists (
+      select 1
+        from target_all_subtypes
+       where scope_id = new.scope_id
+         and lower(name) = lower(new.name)
+         and public_id != new.public_id
+    ) then
+      raise exception 'target name % already exists in scope %', new.name, new.scope_id
+        using errcode = '23505';
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger target_name_unique_in_scope before insert or update of name on target_tcp
+    for each row execute procedure target_name_unique_in_scope();
+
+  create trigger target_name_unique_in_scope before insert or update of name on target_http
+    for each row execute procedure target_name_unique_in_scope();
+
+  create table target_http_header (
+    target_id wt_public_id not null
+      references target_http (public_id)
+      on delete cascade
+      on update cascade,
+    name text not null
+      constraint name_must_not_be_empty
+      check(length(trim(name)) > 0),
+    value bytea not null, -- encrypted
+    value_hmac text not null
+      constraint value_hmac_must_not_be_empty
+      check(length(trim(value_hmac)) > 0),
+    -- TODO: Make key_id a foreign key once we have DEKs
+    key_id text not null
+      constraint key_id_must_not_be_empty
+      check(length(trim(key_id)) > 0),
+    create_time wt_timestamp,
+    primary key(target_id, name)
+  );
+
+  create trigger immutable_columns before update on target_http_header
+    for each row execute procedure immutable_columns('target_id', 'name', 'create_time');
+
+  create trigger default_create_time_column before insert on target_http_header
+    for each row execute procedure default_create_time();
+
+  -- target_all_subtypes is recreated to include http targets.
+  drop view target_all_subtypes;
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'http' as type
+    from target_http;
+
+  create table session_connection_http_request (
+    connection_id wt_public_id not null
+      references session_connection (public_id)
+      on delete cascade
+      on update cascade,
+    -- requests on a connection are proxied one at a time so the create_time
+    -- of each request is unique for the connection
+    create_time wt_timestamp,
+    method text not null
+      constraint method_must_not_be_empty
+      check(length(trim(method)) > 0),
+    path text not null,
+    status_code int not null
+      constraint status_code_must_be_a_valid_http_status
+      check(status_code >= 100 and status_code <= 599),
+    primary key(connection_id, create_time)
+  );
+
+  create trigger immutable_columns before update on session_connection_http_request
+    for each row execute procedure immutable_columns('connection_id', 'create_time', 'method', 'path', 'status_code');
+
+  create trigger default_create_time_column before insert on session_connection_http_request
+    for each row execute procedure default_create_time();
+
+  insert into oplog_ticket (name, version)
+  values
+    ('target_http', 1);
+
+commit;
+
+`),
+	},
+	"migrations/75_target_ssh.down.sql": {
+		name: "75_target_ssh.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop table session_recording;
+
+  drop view target_all_subtypes;
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'http' as type
+    from target_http;
+
+  drop table target_ssh;
+
+  delete from oplog_ticket where name in ('target_ssh');
+
+commit;
+
+`),
+	},
+	"migrations/75_target_ssh.up.sql": {
+		name: "75_target_ssh.up.sql",
+		bytes: []byte(`
+begin;
+
+/*
+
+  ┌─────────────────┐          ┌────────────────────┐
+  │     target      │          │    target_ssh      │
+  ├─────────────────┤          ├────────────────────┤
+  │ public_id  (pk) │┼┼──────○┼│ public_id  (pk,fk) │
+  │ scope_id   (fk) │          │ scope_id   (fk)    │
+  │                 │          │ default_port       │
+  └─────────────────┘          └────────────────────┘
+
+  ┌─────────────────┐          ┌────────────────────┐
+  │    session      │          │ session_recording  │
+  ├─────────────────┤          ├────────────────────┤
+  │ public_id  (pk) │┼┼──────○<│ public_id     (pk) │
+  │                 │          │ session_id    (fk) │
+  └─────────────────┘          │ connection_id (fk) │
+                               │ channel_id         │
+                               │ data               │
+                               └────────────────────┘
+
+  The worker terminates the SSH protocol of a session for an ssh target and
+  records the terminal I/O of each session channel in asciicast format. When a
+  channel closes the worker sends its recording to the controller, which stores
+  it in session_recording.
+
+*/
+
+  create table target_ssh (
+    public_id wt_public_id primary key
+      references target(public_id)
+      on delete cascade
+      on update cascade,
+    scope_id wt_scope_id not null
+      references iam_scope(public_id)
+      on delete cascade
+      on update cascade,
+    name text not null, -- name is not optional for a target subtype
+    description text,
+    default_port int, -- default_port can be null
+    -- max duration of the session in seconds.
+    -- default is 8 hours
+    session_max_seconds int not null default 28800
+      constraint session_max_seconds_must_be_greater_than_0
+      check(session_max_seconds > 0),
+    -- limit on number of session connections allowed. -1 equals no limit
+    session_connection_limit int not null default 1
+      constraint session_connection_limit_must_be_greater_than_0_or_negative_1
+      check(session_connection_limit > 0 or session_connection_limit = -1),
+    worker_filter text
+      constraint worker_filter_must_not_be_empty
+      check(length(trim(worker_filter)) > 0),
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    unique(scope_id, name) -- name must be unique within a scope
+  );
+
+  create trigger insert_target_subtype before insert on target_ssh
+    for each row execute procedure insert_target_subtype();
+
+  create trigger delete_target_subtype after delete on target_ssh
+    for each row execute procedure delete_target_subtype();
+
+  create trigger immutable_columns before update on target_ssh
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create trigger update_version_column after update on target_ssh
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on target_ssh
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on target_ssh
+    for each row execute procedure default_create_time();
+
+  create trigger target_scope_valid before insert on target_ssh
+    for each row execute procedure target_scope_valid();
+
+  create trigger target_name_unique_in_scope before insert or update of name on target_ssh
+    for each row execute procedure target_name_unique_in_scope();
+
+  -- target_all_subtypes is recreated to include ssh targets.
+  drop view target_all_subtypes;
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'http' as type
+    from target_http
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'ssh' as type
+    from target_ssh;
+
+  create table session_recording (
+    public_id wt_public_id primary key,
+    session_id wt_public_id not null
+      references session (public_id)
+      on delete cascade
+      on update cascade,
+    connection_id wt_public_id not null
+      references session_connection (public_id)
+      on delete cascade
+      on update cascade,
+    -- channel_id is the position of the recorded channel among the session
+    -- channels opened on the connection
+    channel_id int not null
+      constraint channel_id_must_not_be_negative
+      check(channel_id >= 0),
+    start_time wt_timestamp,
+    end_time wt_timestamp,
+    -- data is the recording in asciicast v2 format
+    data bytea not null
+      constraint data_must_not_be_empty
+      check(length(data) > 0),
+    create_time wt_timestamp,
+    constraint end_time_must_not_be_before_start_time
+      check(end_time >= start_time),
+    unique(connection_id, channel_id)
+  );
+
+  create trigger immutable_columns before update on session_recording
+    for each row execute procedure immutable_columns('public_id', 'session_id', 'connection_id', 'channel_id', 'start_time', 'end_time', 'data', 'create_time');
+
+  create trigger default_create_time_column before insert on session_recording
+    for each row execute procedure default_create_time();
+
+  insert into oplog_ticket (name, version)
+  values
+    ('target_ssh', 1);
+
+commit;
+
+`),
+	},
+	"migrations/76_credential_static.down.sql": {
+		name: "76_credential_static.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop table target_credential cascade;
+  drop table credential_static_username_password cascade;
+  drop table credential_static_store cascade;
+  drop table credential cascade;
+  drop table credential_store cascade;
+
+  drop function target_credential_scope_valid;
+  drop function insert_credential_subtype;
+  drop function delete_credential_subtype;
+  drop function insert_credential_store_subtype;
+  drop function delete_credential_store_subtype;
+
+  delete
+    from oplog_ticket
+   where name in (
+          'credential_static_store',
+          'credential_static_username_password',
+          'target_credential'
+        );
+
+commit;
+
+`),
+	},
+	"migrations/76_credential_static.up.sql": {
+		name: "76_credential_static.up.sql",
+		bytes: []byte(`
+begin;
+
+/*
+
+  ┌─────────────────┐          ┌─────────────────────────┐
+  │   credential    │          │ credential_static_      │
+  │                 │          │ username_password       │
+  ├─────────────────┤          ├─────────────────────────┤
+  │ public_id  (pk) │          │ public_id  (pk)         │
+  │ store_id   (fk) │┼┼──────○┼│ store_id   (fk)         │
+  │                 │          │ username                │
+  │                 │          │ password                │
+  └─────────────────┘          └─────────────────────────┘
+          ╲│╱                              ╲│╱
+           ○                                ○
+           │                                │
+           ┼                                ┼
+           ┼                                ┼
+  ┌─────────────────┐          ┌─────────────────────────┐
+  │credential_store │          │ credential_static_store │
+  ├─────────────────┤          ├─────────────────────────┤
+  │ public_id (pk)  │          │ public_id (pk)          │
+  │ scope_id  (fk)  │┼┼──────○┼│ scope_id  (fk)          │
+  │                 │          │                         │
+  └─────────────────┘          └─────────────────────────┘
+
+  Credential stores are owned by a project. The credentials in a store can be
+  attached to targets in the same project through target_credential and are
+  returned to the client when a session is authorized for the target. The
+  password of a static credential is encrypted with a database key of the
+  project.
+
+*/
+
+  -- credential_store
+  create table credential_store (
+    public_id wt_public_id
+      primary key,
+    scope_id wt_scope_id
+      not null
+      references iam_scope (public_id)
+      on delete cascade
+      on update cascade,
+    unique(scope_id, public_id)
+  );
+
+  create trigger immutable_columns before update on credential_store
+    for each row execute procedure immutable_columns('public_id', 'scope_id');
+
+  -- insert_credential_store_subtype() is a before insert trigger
+  -- function for subtypes of credential_store
+  create or replace function insert_credential_store_subtype()
+    returns trigger
+  as $$
+  begin
+    insert into credential_store
+      (public_id, scope_id)
+    values
+      (new.public_id, new.scope_id);
+    return new;
+  end;
+  $$ language plpgsql;
+
+  -- delete_credential_store_subtype() is an after delete trigger
+  -- function for subtypes of credential_store
+  create or replace function delete_credential_store_subtype()
+    returns trigger
+  as $$
+  begin
+    delete from credential_store
+    where public_id = old.public_id;
+    return null; -- result is ignored since this is an after trigger
+  end;
+  $$ language plpgsql;
+
+  -- credential
+  create table credential (
+    public_id wt_public_id primary key,
+    store_id wt_public_id not null
+      references credential_store (public_id)
+      on delete cascade
+      on update cascade,
+    unique(store_id, public_id)
+  );
+
+  create trigger immutable_columns before update on credential
+    for each row execute procedure immutable_columns('public_id', 'store_id');
+
+  -- insert_credential_subtype() is a before insert trigger
+  -- function for subtypes of credential
+  create or replace function insert_credential_subtype()
+    returns trigger
+  as $$
+  begin
+    insert into credential
+      (public_id, store_id)
+    values
+      (new.public_id, new.store_id);
+    return new;
+  end;
+  $$ language plpgsql;
+
+  -- delete_credential_subtype() is an after delete trigger
+  -- function for subtypes of credential
+  create or replace function delete_credential_subtype()
+    returns trigger
+  as $$
+  begin
+    delete from credential
+    where public_id = old.public_id;
+    return null; -- result is ignored since this is an after trigger
+  end;
+  $$ language plpgsql;
+
+  create table credential_static_store (
+    public_id wt_public_id
+      primary key,
+    scope_id wt_scope_id
+      not null
+      references iam_scope (public_id)
+      on delete cascade
+      on update cascade,
+    name text,
+    description text,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    foreign key (scope_id, public_id)
+      references credential_store (scope_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(scope_id, name)
+  );
+
+  create trigger update_version_column after update on credential_static_store
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on credential_static_store
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on credential_static_store
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on credential_static_store
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create trigger insert_credential_store_subtype before insert on credential_static_store
+    for each row execute procedure insert_credential_store_subtype();
+
+  create trigger delete_credential_store_subtype after delete on credential_static_store
+    for each row execute procedure delete_credential_store_subtype();
+
+  create table credential_static_username_password (
+    public_id wt_public_id primary key,
+    store_id wt_public_id not null
+      references credential_static_store (public_id)
+      on delete cascade
+      on update cascade,
+    name text,
+    description text,
+    username text not null
+      constraint username_must_not_be_empty
+      check(length(trim(username)) > 0),
+    password bytea not null -- encrypted
+      constraint password_must_not_be_empty
+      check(length(password) > 0),
+    password_hmac text not null
+      constraint password_hmac_must_not_be_empty
+      check(length(trim(password_hmac)) > 0),
+    -- TODO: Make key_id a foreign key once we have DEKs
+    key_id text not null
+      constraint key_id_must_not_be_empty
+      check(length(trim(key_id)) > 0),
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    unique(store_id, name),
+    foreign key (store_id, public_id)
+      references credential (store_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(store_id, public_id)
+  );
+
+  create trigger update_version_column after update on credential_static_username_password
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on credential_static_username_password
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on credential_static_username_password
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on credential_static_username_password
+    for each row execute procedure immutable_columns('public_id', 'store_id', 'create_time');
+
+  create trigger insert_credential_subtype before insert on credential_static_username_password
+    for each row execute procedure insert_credential_subtype();
+
+  create trigger delete_credential_subtype after delete on credential_static_username_password
+    for each row execute procedure delete_credential_subtype();
+
+  create table target_credential (
+    target_id wt_public_id
+      references target (public_id)
+      on delete cascade
+      on update cascade,
+    credential_id wt_public_id
+      references credential (public_id)
+      on delete cascade
+      on update cascade,
+    primary key(target_id, credential_id),
+    create_time wt_timestamp
+  );
+
+  create trigger immutable_columns before update on target_credential
+    for each row execute procedure immutable_columns('target_id', 'credential_id', 'create_time');
+
+  create trigger default_create_time_column before insert on target_credential
+    for each row execute procedure default_create_time();
+
+  -- target_credential_scope_valid() is a before insert trigger function for
+  -- target_credential
+  create or replace function target_credential_scope_valid()
+    returns trigger
+  as $$
+  begin
+    perform from
+      credential_store cs,
+      credential c,
+      target t
+    where
+      cs.public_id = c.store_id and
+      cs.scope_id = t.scope_id and
+      c.public_id = new.credential_id and
+      t.public_id = new.target_id;
+    if not found then
+      raise exception 'target scope and credential scope are not equal';
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger target_credential_scope_valid before insert on target_credential
+    for each row execute procedure target_credential_scope_valid();
+
+  insert into oplog_ticket (name, version)
+  values
+    ('credential_static_store', 1),
+    ('credential_static_username_password', 1),
+    ('target_credential', 1);
+
+commit;
+
+`),
+	},
+	"migrations/77_iam_principal_role_time_bounds.down.sql": {
+		name: "77_iam_principal_role_time_bounds.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop view iam_principal_role;
+  create view iam_principal_role as
+  select
+    ur.create_time,
+    ur.principal_id,
+    ur.role_id,
+    u.scope_id as principal_scope_id,
+    r.scope_id as role_scope_id,
+    get_scoped_principal_id(r.scope_id, u.scope_id, ur.principal_id) as scoped_principal_id,
+    'user' as type
+  from
+    iam_user_role ur,
+    iam_role r,
+    iam_user u
+  where
+    ur.role_id = r.public_id and
+    u.public_id = ur.principal_id
+  union
+  select
+    gr.create_time,
+    gr.principal_id,
+    gr.role_id,
+    g.scope_id as principal_scope_id,
+    r.scope_id as role_scope_id,
+    get_scoped_principal_id(r.scope_id, g.scope_id, gr.principal_id) as scoped_principal_id,
+    'group' as type
+  from
+    iam_group_role gr,
+    iam_role r,
+    iam_group g
+  where
+    gr.role_id = r.public_id and
+    g.public_id = gr.principal_id;
+
+  drop index iam_user_role_expiration_time_ix;
+  drop index iam_group_role_expiration_time_ix;
+
+  alter table iam_user_role
+    drop constraint not_before_time_must_be_before_expiration_time,
+    drop column not_before_time,
+    drop column expiration_time;
+
+  alter table iam_group_role
+    drop constraint not_before_time_must_be_before_expiration_time,
+    drop column not_before_time,
+    drop column expiration_time;
+
+commit;
+
+`),
+	},
+	"migrations/77_iam_principal_role_time_bounds.up.sql": {
+		name: "77_iam_principal_role_time_bounds.up.sql",
+		bytes: []byte(`
+begin;
+
+/*
+  Role assignments can be bounded in time. A user or group assigned to a role
+  with a not_before_time only receives the role's grants once that time is
+  reached, and one assigned with an expiration_time stops receiving them once
+  that time is reached. Expired assignments are removed by the controller.
+*/
+
+  alter table iam_user_role
+    add column not_before_time timestamp with time zone,
+    add column expiration_time timestamp with time zone,
+    add constraint not_before_time_must_be_before_expiration_time
+      check(not_before_time < expiration_time);
+
+  alter table iam_group_role
+    add column not_before_time timestamp with time zone,
+    add column expiration_time timestamp with time zone,
+    add constraint not_before_time_must_be_before_expiration_time
+      check(not_before_time < expiration_time);
+
+  create index iam_user_role_expiration_time_ix
+    on iam_user_role (expiration_time);
+  create index iam_group_role_expiration_time_ix
+    on iam_group_role (expiration_time);
+
+  drop view iam_principal_role;
+  create view iam_principal_role as
+  select
+    ur.create_time,
+    ur.principal_id,
+    ur.role_id,
+    u.scope_id as principal_scope_id,
+    r.scope_id as role_scope_id,
+    get_scoped_principal_id(r.scope_id, u.scope_id, ur.principal_id) as scoped_principal_id,
+    'user' as type,
+    ur.not_before_time,
+    ur.expiration_time
+  from
+    iam_user_role ur,
+    iam_role r,
+    iam_user u
+  where
+    ur.role_id = r.public_id and
+    u.public_id = ur.principal_id
+  union
+  select
+    gr.create_time,
+    gr.principal_id,
+    gr.role_id,
+    g.scope_id as principal_scope_id,
+    r.scope_id as role_scope_id,
+    get_scoped_principal_id(r.scope_id, g.scope_id, gr.principal_id) as scoped_principal_id,
+    'group' as type,
+    gr.not_before_time,
+    gr.expiration_time
+  from
+    iam_group_role gr,
+    iam_role r,
+    iam_group g
+  where
+    gr.role_id = r.public_id and
+    g.public_id = gr.principal_id;
+
+commit;
+
+`),
+	},
+	"migrations/78_iam_access_request.down.sql": {
+		name: "78_iam_access_request.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop table iam_access_request cascade;
+
+  drop function iam_access_request_scope_valid;
+  drop function iam_access_request_review;
+
+  delete
+    from oplog_ticket
+   where name in (
+          'iam_access_request'
+        );
+
+commit;
+
+`),
+	},
+	"migrations/78_iam_access_request.up.sql": {
+		name: "78_iam_access_request.up.sql",
+		bytes: []byte(`
+begin;
+
+/*
+  An access request is a user's request for the authorize-session action on a
+  target for a duration, along with a justification. The request is created
+  in the scope of the target and starts out pending. A user with the approve
+  or deny action on the request reviews it once, which records the reviewer
+  and the review time. An approved request grants the user authorize-session
+  on the target until its expiration time, which is the review time plus the
+  requested duration.
+*/
+
+  create table iam_access_request (
+    public_id wt_public_id
+      primary key,
+    scope_id wt_scope_id
+      not null
+      references iam_scope (public_id)
+      on delete cascade
+      on update cascade,
+    target_id wt_public_id
+      not null
+      references target (public_id)
+      on delete cascade
+      on update cascade,
+    user_id wt_user_id
+      not null
+      references iam_user (public_id)
+      on delete cascade
+      on update cascade,
+    justification text not null
+      constraint justification_must_not_be_empty
+      check(length(trim(justification)) > 0),
+    duration_seconds integer not null
+      constraint duration_seconds_must_be_greater_than_0
+      check(duration_seconds > 0),
+    status text not null
+      default 'pending'
+      constraint status_must_be_known
+      check(status in ('pending', 'approved', 'denied')),
+    reviewer_id wt_user_id
+      references iam_user (public_id)
+      on delete set null
+      on update cascade,
+    review_comment text,
+    review_time timestamp with time zone,
+    expiration_time timestamp with time zone,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    constraint reviewer_must_not_be_requester
+      check(reviewer_id <> user_id),
+    constraint review_time_must_be_set_when_reviewed
+      check((status = 'pending') = (review_time is null)),
+    constraint expiration_time_must_be_set_when_approved
+      check((status = 'approved') = (expiration_time is not null))
+  );
+
+  create index iam_access_request_user_id_ix
+    on iam_access_request (user_id);
+
+  create trigger update_version_column after update on iam_access_request
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on iam_access_request
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on iam_access_request
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on iam_access_request
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'target_id', 'user_id', 'justification', 'duration_seconds', 'create_time');
+
+  -- iam_access_request_scope_valid() is a before insert trigger function for
+  -- iam_access_request that ensures the request is in the scope of its target
+  create or replace function iam_access_request_scope_valid()
+    returns trigger
+  as $$
+  begin
+    perform from target
+    where
+      public_id = new.target_id and
+      scope_id = new.scope_id;
+    if not found then
+      raise exception 'access request scope and target scope are not equal';
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger iam_access_request_scope_valid before insert on iam_access_request
+    for each row execute procedure iam_access_request_scope_valid();
+
+  -- iam_access_request_review() is a before update trigger function for
+  -- iam_access_request. A request can only be reviewed while pending, and the
+  -- review sets the review time and, for approvals, the expiration time.
+  create or replace function iam_access_request_review()
+    returns trigger
+  as $$
+  begin
+    if new.status is distinct from old.status then
+      if old.status <> 'pending' then
+        raise exception 'access request % has already been reviewed', old.public_id;
+      end if;
+      new.review_time = current_timestamp;
+      if new.status = 'approved' then
+        new.expiration_time = current_timestamp + make_interval(secs => new.duration_seconds);
+      end if;
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger iam_access_request_review before update on iam_access_request
+    for each row execute procedure iam_access_request_review();
+
+  insert into oplog_ticket (name, version)
+  values
+    ('iam_access_request', 1);
+
+commit;
+
+`),
+	},
+	"migrations/79_iam_role_grant_scope_inheritance.down.sql": {
+		name: "79_iam_role_grant_scope_inheritance.down.sql",
+		bytes: []byte(`
+begin;
+
+  alter table iam_role
+    drop constraint grant_scope_inheritance_requires_role_scope,
+    drop column grant_scope_inheritance;
+
+commit;
+
+`),
+	},
+	"migrations/79_iam_role_grant_scope_inheritance.up.sql": {
+		name: "79_iam_role_grant_scope_inheritance.up.sql",
+		bytes: []byte(`
+begin;
+
+/*
+  A role's grants can apply to the scopes below its grant scope in addition to
+  the grant scope itself. With a grant_scope_inheritance of children, the
+  grants also apply to the direct child scopes of the grant scope; with
+  descendants, they apply to all of its descendant scopes. Inheritance is only
+  allowed when the grant scope is the role's own scope, and scopes created
+  after the role are covered without updating it.
+*/
+
+  alter table iam_role
+    add column grant_scope_inheritance text not null
+      default 'none'
+      constraint grant_scope_inheritance_must_be_known
+      check(grant_scope_inheritance in ('none', 'children', 'descendants')),
+    add constraint grant_scope_inheritance_requires_role_scope
+      check(grant_scope_inheritance = 'none' or grant_scope_id = scope_id);
+
+commit;
+
+`),
+	},
+	"migrations/80_iam_group_directory.down.sql": {
+		name: "80_iam_group_directory.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop index iam_group_scim_directory_group_uq;
+
+  alter table iam_group
+    drop constraint directory_group_requires_directory_type,
+    drop column directory_group,
+    drop column directory_type;
+
+commit;
+
+`),
+	},
+	"migrations/80_iam_group_directory.up.sql": {
+		name: "80_iam_group_directory.up.sql",
+		bytes: []byte(`
+begin;
+
+/*
+  A group can be bound to a group in an external directory, in which case its
+  members are kept in sync with the directory's group. With a directory_type
+  of ldap, the controllers periodically read the members of the LDAP group
+  with the DN in directory_group. With scim, a SCIM client pushes the members
+  of the group with the display name in directory_group to the controller.
+  Users that are not yet known are created when they are added to a group.
+*/
+
+  alter table iam_group
+    add column directory_type text
+      constraint directory_type_must_be_known
+      check(directory_type in ('ldap', 'scim')),
+    add column directory_group text
+      constraint directory_group_must_not_be_empty
+      check(length(trim(directory_group)) > 0),
+    add constraint directory_group_requires_directory_type
+      check((directory_type is null) = (directory_group is null));
+
+  -- SCIM clients refer to groups by their display name alone, so a SCIM group
+  -- can only be bound to one group.
+  create unique index iam_group_scim_directory_group_uq
+    on iam_group (directory_group)
+    where directory_type = 'scim';
+
+commit;
+
+`),
+	},
+	"migrations/81_auth_ldap.down.sql": {
+		name: "81_auth_ldap.down.sql",
+		bytes: []byte(`
+begin;
+
+  -- Restores the view created in 73_auth_oidc.
+  create or replace view whx_user_dimension_source as
+       select -- id is the first column in the target view
+              u.public_id                       as user_id,
+              coalesce(u.name, 'None')          as user_name,
+              coalesce(u.description, 'None')   as user_description,
+              coalesce(aa.public_id, 'None')    as auth_account_id,
+              case when aa.public_id is null then 'None'
+                   when aoa.public_id is not null then 'oidc auth account'
+                   else 'password auth account'
+                   end                          as auth_account_type,
+              coalesce(apa.name, aoa.name, 'None')               as auth_account_name,
+              coalesce(apa.description, aoa.description, 'None') as auth_account_description,
+              coalesce(am.public_id, 'None')    as auth_method_id,
+              case when am.public_id is null then 'None'
+                   when aom.public_id is not null then 'oidc auth method'
+                   else 'password auth method'
+                   end                          as auth_method_type,
+              coalesce(apm.name, aom.name, 'None')               as auth_method_name,
+              coalesce(apm.description, aom.description, 'None') as auth_method_description,
+              org.public_id                     as user_organization_id,
+              coalesce(org.name, 'None')        as user_organization_name,
+              coalesce(org.description, 'None') as user_organization_description
+         from iam_user as u
+    left join auth_account as aa on           u.public_id = aa.iam_user_id
+    left join auth_method as am on            aa.auth_method_id = am.public_id
+    left join auth_password_account as apa on aa.public_id = apa.public_id
+    left join auth_password_method as apm on  am.public_id = apm.public_id
+    left join auth_oidc_account as aoa on     aa.public_id = aoa.public_id
+    left join auth_oidc_method as aom on      am.public_id = aom.public_id
+         join iam_scope as org on             u.scope_id = org.public_id
+  ;
+
+  drop table auth_ldap_account cascade;
+  drop table auth_ldap_method cascade;
+
+  delete
+    from oplog_ticket
+   where name in (
+          'auth_ldap_method',
+          'auth_ldap_account'
+        );
+
+commit;
+
+`),
+	},
+	"migrations/81_auth_ldap.up.sql": {
+		name: "81_auth_ldap.up.sql",
+		bytes: []byte(`
+begin;
+
+/*
+
+       ┌────────────────┐                 ┌──────────────────────┐
+       │  auth_method   │                 │   auth_ldap_method   │
+       ├────────────────┤                 ├──────────────────────┤
+       │ public_id (pk) │                 │ public_id (pk,fk)    │
+       │ scope_id  (fk) │┼┼─────────────○┼│ scope_id  (fk)       │
+       │                │                 │ ...                  │
+       └────────────────┘                 └──────────────────────┘
+                ┼                                     ┼
+                ┼                                     ┼
+                │                                     │
+                │ ▲fk1                                │ ▲fk1
+                │                                     │
+                ○                                     ○
+               ╱│╲                                   ╱│╲
+  ┌──────────────────────────┐          ┌──────────────────────────┐
+  │       auth_account       │          │    auth_ldap_account     │
+  ├──────────────────────────┤          ├──────────────────────────┤
+  │ public_id         (pk)   │          │ public_id      (pk,fk2)  │
+  │ scope_id          (fk1)  │   ◀fk2   │ scope_id       (fk1,fk2) │
+  │ auth_method_id    (fk1)  │┼┼──────○┼│ auth_method_id (fk1,fk2) │
+  │ iam_user_scope_id (fk2)  │          │ dn                       │
+  │ iam_user_id       (fk2)  │          │ ...                      │
+  └──────────────────────────┘          └──────────────────────────┘
+
+  An auth_ldap_method is an auth_method subtype and an auth_ldap_account is
+  an auth_account subtype.
+
+  An auth_ldap_method contains the connection settings of an LDAP server or
+  Active Directory domain controller and how users and their groups are found
+  in it. Users are searched for with the bind_dn, or anonymously if it is not
+  set, and authenticate by binding with the DN of their entry.
+
+  An auth_ldap_account is identified within its auth method by the DN of the
+  user's entry. It is created the first time the user authenticates.
+
+*/
+
+  create table auth_ldap_method (
+    public_id wt_public_id
+      primary key,
+    scope_id wt_scope_id
+      not null,
+    name text,
+    description text,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    url text not null
+      constraint url_must_be_ldap_or_ldaps
+      check(url ~ '^ldaps?://.+'),
+    start_tls boolean not null default false,
+    insecure_tls boolean not null default false,
+    certificate text
+      constraint certificate_must_not_be_empty
+      check(length(trim(certificate)) > 0),
+    bind_dn text
+      constraint bind_dn_must_not_be_empty
+      check(length(trim(bind_dn)) > 0),
+    bind_password bytea, -- encrypted
+    bind_password_hmac text
+      constraint bind_password_hmac_must_not_be_empty
+      check(length(trim(bind_password_hmac)) > 0),
+    -- TODO: Make key_id a foreign key once we have DEKs
+    key_id text
+      constraint key_id_must_not_be_empty
+      check(length(trim(key_id)) > 0),
+    user_search_base text not null
+      constraint user_search_base_must_not_be_empty
+      check(length(trim(user_search_base)) > 0),
+    user_search_filter text not null
+      constraint user_search_filter_must_not_be_empty
+      check(length(trim(user_search_filter)) > 0),
+    group_search_base text
+      constraint group_search_base_must_not_be_empty
+      check(length(trim(group_search_base)) > 0),
+    group_search_filter text
+      constraint group_search_filter_must_not_be_empty
+      check(length(trim(group_search_filter)) > 0),
+    group_attr text
+      constraint group_attr_must_not_be_empty
+      check(length(trim(group_attr)) > 0),
+    constraint start_tls_requires_ldap_url
+      check(not start_tls or url ~ '^ldap://'),
+    constraint bind_password_requires_bind_dn
+      check(bind_password is null or bind_dn is not null),
+    constraint bind_password_requires_hmac_and_key_id
+      check((bind_password is null) = (bind_password_hmac is null)
+        and (bind_password is null) = (key_id is null)),
+    foreign key (scope_id, public_id)
+      references auth_method (scope_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(scope_id, name),
+    unique(scope_id, public_id)
+  );
+
+  create trigger update_version_column after update on auth_ldap_method
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on auth_ldap_method
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on auth_ldap_method
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on auth_ldap_method
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create trigger insert_auth_method_subtype before insert on auth_ldap_method
+    for each row execute procedure insert_auth_method_subtype();
+
+  create table auth_ldap_account (
+    public_id wt_public_id
+      primary key,
+    auth_method_id wt_public_id
+      not null,
+    -- NOTE(mgaffney): The scope_id type is not wt_scope_id because the domain
+    -- check is executed before the insert trigger which retrieves the scope_id
+    -- causing an insert to fail.
+    scope_id text not null,
+    name text,
+    description text,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    dn text not null
+      constraint dn_must_not_be_empty
+      check(length(trim(dn)) > 0)
+      constraint dn_must_be_less_than_1024_characters
+      check(length(dn) < 1024),
+    login_name text not null
+      constraint login_name_must_not_be_empty
+      check(length(trim(login_name)) > 0),
+    email text
+      constraint email_must_be_less_than_1024_characters
+      check(length(email) < 1024),
+    full_name text
+      constraint full_name_must_be_less_than_1024_characters
+      check(length(full_name) < 1024),
+    -- member_of_groups is a JSON array of the names of the groups the user
+    -- was a member of when they last authenticated.
+    member_of_groups text,
+    foreign key (scope_id, auth_method_id)
+      references auth_ldap_method (scope_id, public_id)
+      on delete cascade
+      on update cascade,
+    foreign key (scope_id, auth_method_id, public_id)
+      references auth_account (scope_id, auth_method_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(auth_method_id, name),
+    unique(auth_method_id, dn),
+    unique(auth_method_id, public_id)
+  );
+
+  create trigger update_version_column after update on auth_ldap_account
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on auth_ldap_account
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on auth_ldap_account
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on auth_ldap_account
+    for each row execute procedure immutable_columns('public_id', 'auth_method_id', 'scope_id', 'dn', 'create_time');
+
+  create trigger insert_auth_account_subtype before insert on auth_ldap_account
+    for each row execute procedure insert_auth_account_subtype();
+
+  insert into oplog_ticket (name, version)
+  values
+    ('auth_ldap_method', 1),
+    ('auth_ldap_account', 1);
+
+  -- Replaces the view created in 73_auth_oidc to include ldap accounts and
+  -- auth methods.
+  create or replace view whx_user_dimension_source as
+       select -- id is the first column in the target view
+              u.public_id                       as user_id,
+              coalesce(u.name, 'None')          as user_name,
+              coalesce(u.description, 'None')   as user_description,
+              coalesce(aa.public_id, 'None')    as auth_account_id,
+              case when aa.public_id is null then 'None'
+                   when aoa.public_id is not null then 'oidc auth account'
+                   when ala.public_id is not null then 'ldap auth account'
+                   else 'password auth account'
+                   end                          as auth_account_type,
+              coalesce(apa.name, aoa.name, ala.name, 'None')                      as auth_account_name,
+              coalesce(apa.description, aoa.description, ala.description, 'None') as auth_account_description,
+              coalesce(am.public_id, 'None')    as auth_method_id,
+              case when am.public_id is null then 'None'
+                   when aom.public_id is not null then 'oidc auth method'
+                   when alm.public_id is not null then 'ldap auth method'
+                   else 'password auth method'
+                   end                          as auth_method_type,
+              coalesce(apm.name, aom.name, alm.name, 'None')                      as auth_method_name,
+              coalesce(apm.description, aom.description, alm.description, 'None') as auth_method_description,
+              org.public_id                     as user_organization_id,
+              coalesce(org.name, 'None')        as user_organization_name,
+              coalesce(org.description, 'None') as user_organization_description
+         from iam_user as u
+    left join auth_account as aa on           u.public_id = aa.iam_user_id
+    left join auth_method as am on            aa.auth_method_id = am.public_id
+    left join auth_password_account as apa on aa.public_id = apa.public_id
+    left join auth_password_method as apm on  am.public_id = apm.public_id
+    left join auth_oidc_account as aoa on     aa.public_id = aoa.public_id
+    left join auth_oidc_method as aom on      am.public_id = aom.public_id
+    left join auth_ldap_account as ala on     aa.public_id = ala.public_id
+    left join auth_ldap_method as alm on      am.public_id = alm.public_id
+         join iam_scope as org on             u.scope_id = org.public_id
+  ;
+
+commit;
+
+`),
+	},
+	"migrations/82_auth_password_policy.down.sql": {
+		name: "82_auth_password_policy.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop view auth_password_current_conf;
+  create view auth_password_current_conf as
+      select pm.min_login_name_length, pm.min_password_length, c.*
+        from auth_password_method pm
+  inner join auth_password_conf_union c
+          on pm.password_conf_id = c.password_conf_id;
+
+  drop table auth_password_argon2_cred_history;
+  drop table auth_password_failed_attempt;
+
+  alter table auth_password_account
+    drop column unlock_time,
+    drop column locked_time,
+    drop column must_change_password;
+
+  alter table auth_password_method
+    drop constraint lockout_threshold_requires_lockout_window,
+    drop column lockout_duration_seconds,
+    drop column lockout_window_seconds,
+    drop column lockout_threshold,
+    drop column max_password_age_seconds,
+    drop column password_history_count,
+    drop column require_symbol,
+    drop column require_digit,
+    drop column require_lowercase,
+    drop column require_uppercase;
+
+commit;
+
+`),
+	},
+	"migrations/82_auth_password_policy.up.sql": {
+		name: "82_auth_password_policy.up.sql",
+		bytes: []byte(`
+begin;
+
+/*
+  A password auth method can enforce a password policy on the passwords of
+  its accounts and lock accounts after repeated failed authentication
+  attempts.
+
+  The require_* columns are the character classes a new password must
+  contain. A new password must not equal any of the last
+  password_history_count passwords of the account, including the current
+  one. A password older than max_password_age_seconds must be changed the
+  next time the account authenticates, as must the password of an account
+  with must_change_password set. A zero password_history_count or
+  max_password_age_seconds disables the check.
+
+  An account is locked when lockout_threshold failed authentication attempts
+  are made within lockout_window_seconds. A locked account is unlocked after
+  lockout_duration_seconds or, if it is zero, when an administrator unlocks
+  it. Failed attempts made before an account was locked or unlocked do not
+  count towards the threshold. A zero lockout_threshold disables lockout.
+
+  Replaced credentials are kept in auth_password_argon2_cred_history so new
+  passwords can be checked against them.
+*/
+
+  alter table auth_password_method
+    add column require_uppercase boolean not null default false,
+    add column require_lowercase boolean not null default false,
+    add column require_digit boolean not null default false,
+    add column require_symbol boolean not null default false,
+    add column password_history_count int not null default 0
+      constraint password_history_count_must_not_be_negative
+      check(password_history_count >= 0),
+    add column max_password_age_seconds int not null default 0
+      constraint max_password_age_seconds_must_not_be_negative
+      check(max_password_age_seconds >= 0),
+    add column lockout_threshold int not null default 0
+      constraint lockout_threshold_must_not_be_negative
+      check(lockout_threshold >= 0),
+    add column lockout_window_seconds int not null default 900
+      constraint lockout_window_seconds_must_not_be_negative
+      check(lockout_window_seconds >= 0),
+    add column lockout_duration_seconds int not null default 0
+      constraint lockout_duration_seconds_must_not_be_negative
+      check(lockout_duration_seconds >= 0),
+    add constraint lockout_threshold_requires_lockout_window
+      check(lockout_threshold = 0 or lockout_window_seconds > 0);
+
+  alter table auth_password_account
+    add column must_change_password boolean not null default false,
+    add column locked_time timestamp with time zone,
+    add column unlock_time timestamp with time zone;
+
+  create table auth_password_failed_attempt (
+    password_account_id wt_public_id not null
+      references auth_password_account (public_id)
+      on delete cascade
+      on update cascade,
+    create_time wt_timestamp
+  );
+
+  create index auth_password_failed_attempt_account_time_ix
+    on auth_password_failed_attempt (password_account_id, create_time);
+
+  create table auth_password_argon2_cred_history (
+    private_id wt_private_id primary key,
+    password_account_id wt_public_id not null
+      references auth_password_account (public_id)
+      on delete cascade
+      on update cascade,
+    password_conf_id wt_private_id not null
+      references auth_password_argon2_conf (private_id)
+      on delete cascade
+      on update cascade,
+    salt bytea not null
+      constraint salt_must_not_be_empty
+      check(length(salt) > 0),
+    derived_key bytea not null
+      constraint derived_key_must_not_be_empty
+      check(length(derived_key) > 0),
+    key_id text not null
+      constraint key_id_must_not_be_empty
+      check(length(trim(key_id)) > 0),
+    -- create_time is the create_time of the replaced credential.
+    create_time wt_timestamp
+  );
+
+  create index auth_password_argon2_cred_history_account_time_ix
+    on auth_password_argon2_cred_history (password_account_id, create_time);
+
+  -- Replaces the view created in 14_auth_password_views to include the
+  -- password policy of the auth method.
+  drop view auth_password_current_conf;
+  create view auth_password_current_conf as
+      -- Rerun this query whenever auth_password_conf_union is updated.
+      select pm.min_login_name_length, pm.min_password_length,
+             pm.require_uppercase, pm.require_lowercase,
+             pm.require_digit, pm.require_symbol,
+             pm.password_history_count,
+             c.*
+        from auth_password_method pm
+  inner join auth_password_conf_union c
+          on pm.password_conf_id = c.password_conf_id;
+
+commit;
+
+`),
+	},
+	"migrations/83_server_upstream.down.sql": {
+		name: "83_server_upstream.down.sql",
+		bytes: []byte(`
+begin;
+
+  alter table server
+    drop column upstream;
+
+commit;
+
+`),
+	},
+	"migrations/83_server_upstream.up.sql": {
+		name: "83_server_upstream.up.sql",
+		bytes: []byte(`
+begin;
+
+  -- upstream is the name of the worker to which a worker which accepts no
+  -- inbound connections is connected. Sessions for such a worker are routed
+  -- to it through its upstream worker.
+  alter table server
+    add column upstream text
+      constraint upstream_must_not_be_empty
+      check(length(trim(upstream)) > 0);
+
+commit;
+
+`),
+	},
+	"migrations/84_target_udp.down.sql": {
+		name: "84_target_udp.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop view target_all_subtypes;
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'http' as type
+    from target_http
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'ssh' as type
+    from target_ssh;
+
+  drop table target_udp;
+
+  delete from oplog_ticket where name in ('target_udp');
+
+commit;
+
+`),
+	},
+	"migrations/84_target_udp.up.sql": {
+		name: "84_target_udp.up.sql",
+		bytes: []byte(`
+begin;
+
+/*
+
+  ┌─────────────────┐          ┌────────────────────┐
+  │     target      │          │    target_udp      │
+  ├─────────────────┤          ├────────────────────┤
+  │ public_id  (pk) │┼┼──────○┼│ public_id  (pk,fk) │
+  │ scope_id   (fk) │          │ scope_id   (fk)    │
+  │                 │          │ default_port       │
+  └─────────────────┘          └────────────────────┘
+
+  The worker relays the datagrams of a session for a udp target, framed on the
+  client stream, to and from the endpoint.
+
+*/
+
+  create table target_udp (
+    public_id wt_public_id primary key
+      references target(public_id)
+      on delete cascade
+      on update cascade,
+    scope_id wt_scope_id not null
+      references iam_scope(public_id)
+      on delete cascade
+      on update cascade,
+    name text not null, -- name is not optional for a target subtype
+    description text,
+    default_port int, -- default_port can be null
+    -- max duration of the session in seconds.
+    -- default is 8 hours
+    session_max_seconds int not null default 28800
+      constraint session_max_seconds_must_be_greater_than_0
+      check(session_max_seconds > 0),
+    -- limit on number of session connections allowed. -1 equals no limit
+    session_connection_limit int not null default 1
+      constraint session_connection_limit_must_be_greater_than_0_or_negative_1
+      check(session_connection_limit > 0 or session_connection_limit = -1),
+    worker_filter text
+      constraint worker_filter_must_not_be_empty
+      check(length(trim(worker_filter)) > 0),
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    unique(scope_id, name) -- name must be unique within a scope
+  );
+
+  create trigger insert_target_subtype before insert on target_udp
+    for each row execute procedure insert_target_subtype();
+
+  create trigger delete_target_subtype after delete on target_udp
+    for each row execute procedure delete_target_subtype();
+
+  create trigger immutable_columns before update on target_udp
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create trigger update_version_column after update on target_udp
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on target_udp
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on target_udp
+    for each row execute procedure default_create_time();
+
+  create trigger target_scope_valid before insert on target_udp
+    for each row execute procedure target_scope_valid();
+
+  create trigger target_name_unique_in_scope before insert or update of name on target_udp
+    for each row execute procedure target_name_unique_in_scope();
+
+  -- target_all_subtypes is recreated to include udp targets.
+  drop view target_all_subtypes;
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'http' as type
+    from target_http
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'ssh' as type
+    from target_ssh
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'udp' as type
+    from target_udp;
+
+  insert into oplog_ticket (name, version)
+  values
+    ('target_udp', 1);
+
+commit;
+
+`),
+	},
+	"migrations/85_session_idle_timeout.down.sql": {
+		name: "85_session_idle_timeout.down.sql",
+		bytes: []byte(`
+begin;
+
+  delete from session_termination_reason_enm where name = 'idle timeout';
+  alter table session_termination_reason_enm
+    drop constraint only_predefined_session_termination_reasons_allowed;
+  alter table session_termination_reason_enm
+    add constraint only_predefined_session_termination_reasons_allowed
+      check (
+        name in (
+          'unknown',
+          'timed out',
+          'closed by end-user',
+          'terminated',
+          'network error',
+          'system error',
+          'connection limit',
+          'canceled'
+        )
+      );
+
+  delete from session_connection_closed_reason_enm where name = 'idle timeout';
+  alter table session_connection_closed_reason_enm
+    drop constraint only_predefined_session_connection_closed_reasons_allowed;
+  alter table session_connection_closed_reason_enm
+    add constraint only_predefined_session_connection_closed_reasons_allowed
+      check (
+        name in (
+          'unknown',
+          'timed out',
+          'closed by end-user',
+          'canceled',
+          'network error',
+          'system error'
+        )
+      );
+
+  drop view session_with_state;
+  create view session_with_state as
+  select
+    s.public_id,
+    s.user_id,
+    s.host_id,
+    s.server_id,
+    s.server_type,
+    s.target_id,
+    s.host_set_id,
+    s.auth_token_id,
+    s.scope_id,
+    s.certificate,
+    s.expiration_time,
+    s.connection_limit,
+    s.tofu_token,
+    s.key_id,
+    s.termination_reason,
+    s.version,
+    s.create_time,
+    s.update_time,
+    s.endpoint,
+    ss.state,
+    ss.previous_end_time,
+    ss.start_time,
+    ss.end_time
+  from
+    session s,
+    session_state ss
+  where
+    s.public_id = ss.session_id;
+
+  drop trigger immutable_columns on session;
+  create trigger
+    immutable_columns
+  before
+  update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint');
+
+  alter table session
+    drop column idle_timeout_seconds;
+
+  drop view target_all_subtypes;
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'http' as type
+    from target_http
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'ssh' as type
+    from target_ssh
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'udp' as type
+    from target_udp;
+
+  alter table target_tcp
+    drop column session_idle_timeout_seconds;
+  alter table target_http
+    drop column session_idle_timeout_seconds;
+  alter table target_ssh
+    drop column session_idle_timeout_seconds;
+  alter table target_udp
+    drop column session_idle_timeout_seconds;
+
+commit;
+
+`),
+	},
+	"migrations/85_session_idle_timeout.up.sql": {
+		name: "85_session_idle_timeout.up.sql",
+		bytes: []byte(`
+begin;
+
+  -- session_idle_timeout_seconds is the number of seconds without traffic
+  -- after which a worker closes a connection of a session for the target.
+  -- Zero means connections are never closed for being idle.
+  alter table target_tcp
+    add column session_idle_timeout_seconds int not null default 0
+      constraint session_idle_timeout_seconds_must_not_be_negative
+      check(session_idle_timeout_seconds >= 0);
+
+  alter table target_http
+    add column session_idle_timeout_seconds int not null default 0
+      constraint session_idle_timeout_seconds_must_not_be_negative
+      check(session_idle_timeout_seconds >= 0);
+
+  alter table target_ssh
+    add column session_idle_timeout_seconds int not null default 0
+      constraint session_idle_timeout_seconds_must_not_be_negative
+      check(session_idle_timeout_seconds >= 0);
+
+  alter table target_udp
+    add column session_idle_timeout_seconds int not null default 0
+      constraint session_idle_timeout_seconds_must_not_be_negative
+      check(session_idle_timeout_seconds >= 0);
+
+  -- target_all_subtypes is recreated to include the
+  -- session_idle_timeout_seconds column.
+  drop view target_all_subtypes;
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    session_idle_timeout_seconds,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    session_idle_timeout_seconds,
+    version,
+    create_time,
+    update_time,
+    'http' as type
+    from target_http
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    session_idle_timeout_seconds,
+    version,
+    create_time,
+    update_time,
+    'ssh' as type
+    from target_ssh
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    session_idle_timeout_seconds,
+    version,
+    create_time,
+    update_time,
+    'udp' as type
+    from target_udp;
+
+  -- idle_timeout_seconds is copied from the target when the session is
+  -- created and sent to the worker handling the session.
+  alter table session
+    add column idle_timeout_seconds int not null default 0
+      constraint idle_timeout_seconds_must_not_be_negative
+      check(idle_timeout_seconds >= 0);
+
+  drop trigger immutable_columns on session;
+  create trigger
+    immutable_columns
+  before
+  update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'idle_timeout_seconds', 'create_time', 'endpoint');
+
+  -- session_with_state is recreated to include the idle_timeout_seconds
+  -- column.
+  drop view session_with_state;
+  create view session_with_state as
+  select
+    s.public_id,
+    s.user_id,
+    s.host_id,
+    s.server_id,
+    s.server_type,
+    s.target_id,
+    s.host_set_id,
+    s.auth_token_id,
+    s.scope_id,
+    s.certificate,
+    s.expiration_time,
+    s.connection_limit,
+    s.idle_timeout_seconds,
+    s.tofu_token,
+    s.key_id,
+    s.termination_reason,
+    s.version,
+    s.create_time,
+    s.update_time,
+    s.endpoint,
+    ss.state,
+    ss.previous_end_time,
+    ss.start_time,
+    ss.end_time
+  from
+    session s,
+    session_state ss
+  where
+    s.public_id = ss.session_id;
+
+  -- A connection without traffic for the idle timeout of its session is closed
+  -- with the 'idle timeout' reason, and a session whose connections have all
+  -- been closed once one was closed for being idle is terminated with the same
+  -- reason.
+  alter table session_connection_closed_reason_enm
+    drop constraint only_predefined_session_connection_closed_reasons_allowed;
+  alter table session_connection_closed_reason_enm
+    add constraint only_predefined_session_connection_closed_reasons_allowed
+      check (
+        name in (
+          'unknown',
+          'timed out',
+          'closed by end-user',
+          'canceled',
+          'network error',
+          'system error',
+          'idle timeout'
+        )
+      );
+
+  insert into session_connection_closed_reason_enm (name)
+  values
+    ('idle timeout');
+
+  alter table session_termination_reason_enm
+    drop constraint only_predefined_session_termination_reasons_allowed;
+  alter table session_termination_reason_enm
+    add constraint only_predefined_session_termination_reasons_allowed
+      check (
+        name in (
+          'unknown',
+          'timed out',
+          'closed by end-user',
+          'terminated',
+          'network error',
+          'system error',
+          'connection limit',
+          'canceled',
+          'idle timeout'
+        )
+      );
+
+  insert into session_termination_reason_enm (name)
+  values
+    ('idle timeout');
+
+commit;
+
+`),
+	},
+	"migrations/86_target_ssh_host_keys.down.sql": {
+		name: "86_target_ssh_host_keys.down.sql",
+		bytes: []byte(`
+begin;
+
+  alter table target_ssh
+    drop column host_keys,
+    drop column host_ca_keys,
+    drop column insecure_ignore_host_key;
+
+commit;
+
+`),
+	},
+	"migrations/86_target_ssh_host_keys.up.sql": {
+		name: "86_target_ssh_host_keys.up.sql",
+		bytes: []byte(`
+begin;
+
+  -- host_keys and host_ca_keys hold public keys, one per line in
+  -- authorized_keys format, which the worker checks the host key of the
+  -- endpoint of an ssh target against. When neither is set the worker refuses
+  -- to connect to the endpoint unless insecure_ignore_host_key is true.
+  alter table target_ssh
+    add column host_keys text
+      constraint host_keys_must_not_be_empty
+      check(length(trim(host_keys)) > 0),
+    add column host_ca_keys text
+      constraint host_ca_keys_must_not_be_empty
+      check(length(trim(host_ca_keys)) > 0),
+    add column insecure_ignore_host_key boolean not null default false;
+
+commit;
+
+`),
+	},
+	"migrations/87_auth_password_successful_attempt.down.sql": {
+		name: "87_auth_password_successful_attempt.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop table auth_password_successful_attempt;
+
+commit;
+
+`),
+	},
+	"migrations/87_auth_password_successful_attempt.up.sql": {
+		name: "87_auth_password_successful_attempt.up.sql",
+		bytes: []byte(`
+begin;
+
+/*
+  auth_password_successful_attempt holds the time of the last successful
+  authentication of a password account. Failed attempts made before it do not
+  count towards the lockout threshold of the auth method, so only consecutive
+  failures lock an account.
+*/
+
+  create table auth_password_successful_attempt (
+    password_account_id wt_public_id primary key
+      references auth_password_account (public_id)
+      on delete cascade
+      on update cascade,
+    success_time wt_timestamp
+  );
+
+commit;
+
 `),
 	},
 }
@@ -0,0 +1,22 @@
+begin;
+
+  -- server_tag holds the key/value tags reported by a server in its status
+  -- updates. A key can have multiple values, so the full tuple makes up the
+  -- primary key.
+  create table server_tag (
+    server_id text not null,
+    server_type text not null,
+    key text not null
+      constraint server_tag_key_must_not_be_empty
+      check(length(trim(key)) > 0),
+    value text not null
+      constraint server_tag_value_must_not_be_empty
+      check(length(trim(value)) > 0),
+    primary key (server_id, server_type, key, value),
+    foreign key (server_id, server_type)
+      references server (private_id, type)
+      on delete cascade
+      on update cascade
+  );
+
+commit;
@@ -0,0 +1,23 @@
+begin;
+
+  drop view target_all_subtypes;
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp;
+
+  alter table target_tcp
+    drop column worker_filter;
+
+commit;
@@ -0,0 +1,30 @@
+begin;
+
+  -- worker_filter is a boolean expression evaluated against the name and tags
+  -- of each worker to decide which workers can handle sessions for the
+  -- target. A null filter allows any worker.
+  alter table target_tcp
+    add column worker_filter text
+      constraint worker_filter_must_not_be_empty
+      check(length(trim(worker_filter)) > 0);
+
+  -- target_all_subtypes is recreated to include the worker_filter column.
+  drop view target_all_subtypes;
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp;
+
+commit;
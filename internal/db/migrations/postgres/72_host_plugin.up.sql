@@ -0,0 +1,313 @@
+begin;
+
+/*
+
+  ┌─────────────────┐          ┌─────────────────────┐
+  │      host       │          │  host_plugin_host   │
+  ├─────────────────┤          ├─────────────────────┤
+  │ public_id  (pk) │          │ public_id  (pk)     │
+  │ catalog_id (fk) │┼┼──────○┼│ catalog_id (fk)     │┼┼─────────────────────┐
+  │                 │          │ external_id         │             ◀fk1      │
+  │                 │          │ address             │                       │
+  └─────────────────┘          └─────────────────────┘                       │
+          ╲│╱                            ╲│╱                                 │
+           ○                              ○                                  │
+           │                              │                                  │
+           ┼                              ┼                                  ○
+           ┼                              ┼                                 ╱│╲
+  ┌─────────────────┐          ┌─────────────────────┐          ┌────────────────────────┐
+  │  host_catalog   │          │ host_plugin_catalog │          │ host_plugin_set_member │
+  ├─────────────────┤          ├─────────────────────┤          ├────────────────────────┤
+  │ public_id (pk)  │          │ public_id (pk)      │          │ host_id    (pk,fk1)    │
+  │ scope_id  (fk)  │┼┼──────○┼│ scope_id  (fk)      │          │ set_id     (pk,fk2)    │
+  │                 │          │ plugin_name         │          │ catalog_id (fk1,fk2)   │
+  │                 │          │ attributes          │          │                        │
+  └─────────────────┘          └─────────────────────┘          └────────────────────────┘
+           ┼                              ┼                                 ╲│╱
+           ┼                              ┼                                  ○
+           │                              │                                  │
+           ○                              ○                                  │
+          ╱│╲                            ╱│╲                                 │
+  ┌─────────────────┐          ┌─────────────────────┐                       │
+  │    host_set     │          │   host_plugin_set   │                       │
+  ├─────────────────┤          ├─────────────────────┤                       │
+  │ public_id  (pk) │          │ public_id  (pk)     │             ◀fk2      │
+  │ catalog_id (fk) │┼┼──────○┼│ catalog_id (fk)     │┼┼─────────────────────┘
+  │                 │          │ filter              │
+  └─────────────────┘          └─────────────────────┘
+
+  The hosts and the set members of a plugin host catalog are a cache of the
+  external inventory queried through the plugin. The last time the members of
+  a set were retrieved is recorded in host_plugin_set_sync.
+
+*/
+
+  create table host_plugin_catalog (
+    public_id wt_public_id
+      primary key,
+    scope_id wt_scope_id
+      not null
+      references iam_scope (public_id)
+      on delete cascade
+      on update cascade,
+    name text,
+    description text,
+    plugin_name text not null
+      constraint plugin_name_must_not_be_empty
+      check(length(trim(plugin_name)) > 0),
+    attributes bytea not null,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    foreign key (scope_id, public_id)
+      references host_catalog (scope_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(scope_id, name)
+  );
+
+  create trigger update_version_column after update on host_plugin_catalog
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on host_plugin_catalog
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on host_plugin_catalog
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on host_plugin_catalog
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'plugin_name', 'create_time');
+
+  create trigger insert_host_catalog_subtype before insert on host_plugin_catalog
+    for each row execute procedure insert_host_catalog_subtype();
+
+  create trigger delete_host_catalog_subtype after delete on host_plugin_catalog
+    for each row execute procedure delete_host_catalog_subtype();
+
+  create table host_plugin_host (
+    public_id wt_public_id primary key,
+    catalog_id wt_public_id not null
+      references host_plugin_catalog (public_id)
+      on delete cascade
+      on update cascade,
+    external_id text not null
+      constraint external_id_must_not_be_empty
+      check(length(trim(external_id)) > 0),
+    name text,
+    address text not null
+      constraint address_must_be_more_than_2_characters
+      check(length(trim(address)) > 2)
+      constraint address_must_be_less_than_256_characters
+      check(length(trim(address)) < 256),
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    unique(catalog_id, external_id),
+
+    foreign key (catalog_id, public_id)
+      references host (catalog_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(catalog_id, public_id)
+  );
+
+  create trigger update_version_column after update on host_plugin_host
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on host_plugin_host
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on host_plugin_host
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on host_plugin_host
+    for each row execute procedure immutable_columns('public_id', 'catalog_id', 'external_id', 'create_time');
+
+  create trigger insert_host_subtype before insert on host_plugin_host
+    for each row execute procedure insert_host_subtype();
+
+  create trigger delete_host_subtype after delete on host_plugin_host
+    for each row execute procedure delete_host_subtype();
+
+  create table host_plugin_set (
+    public_id wt_public_id primary key,
+    catalog_id wt_public_id not null
+      references host_plugin_catalog (public_id)
+      on delete cascade
+      on update cascade,
+    name text,
+    description text,
+    filter text not null
+      constraint filter_must_not_be_empty
+      check(length(trim(filter)) > 0),
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    unique(catalog_id, name),
+    foreign key (catalog_id, public_id)
+      references host_set (catalog_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(catalog_id, public_id)
+  );
+
+  create trigger update_version_column after update on host_plugin_set
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on host_plugin_set
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on host_plugin_set
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on host_plugin_set
+    for each row execute procedure immutable_columns('public_id', 'catalog_id', 'create_time');
+
+  create trigger insert_host_set_subtype before insert on host_plugin_set
+    for each row execute procedure insert_host_set_subtype();
+
+  create trigger delete_host_set_subtype after delete on host_plugin_set
+    for each row execute procedure delete_host_set_subtype();
+
+  create table host_plugin_set_member (
+    host_id wt_public_id not null,
+    set_id wt_public_id not null,
+    catalog_id wt_public_id not null,
+    primary key(host_id, set_id),
+    foreign key (catalog_id, host_id) -- fk1
+      references host_plugin_host (catalog_id, public_id)
+      on delete cascade
+      on update cascade,
+    foreign key (catalog_id, set_id) -- fk2
+      references host_plugin_set (catalog_id, public_id)
+      on delete cascade
+      on update cascade
+  );
+
+  create trigger immutable_columns before update on host_plugin_set_member
+    for each row execute procedure immutable_columns('host_id', 'set_id', 'catalog_id');
+
+  create or replace function insert_host_plugin_set_member()
+    returns trigger
+  as $$
+  begin
+    select host_plugin_set.catalog_id
+      into new.catalog_id
+    from host_plugin_set
+    where host_plugin_set.public_id = new.set_id;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger insert_host_plugin_set_member before insert on host_plugin_set_member
+    for each row execute procedure insert_host_plugin_set_member();
+
+  -- host_plugin_set_sync is kept separate from host_plugin_set so that
+  -- refreshing the members of a set does not change the version of the set.
+  create table host_plugin_set_sync (
+    set_id wt_public_id primary key
+      references host_plugin_set (public_id)
+      on delete cascade
+      on update cascade,
+    last_sync_time wt_timestamp
+  );
+
+  insert into oplog_ticket (name, version)
+  values
+    ('host_plugin_catalog', 1),
+    ('host_plugin_set', 1);
+
+  -- Replaces the view created in 65_wh_session_dimensions to include plugin
+  -- hosts.
+  create or replace view whx_host_dimension_source as
+  select -- id is the first column in the target view
+         h.public_id                     as host_id,
+         'static host'                   as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         coalesce(h.description, 'None') as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'static host set'               as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'static host catalog'           as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         'tcp target'                    as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from static_host as h,
+         static_host_catalog as c,
+         static_host_set_member as m,
+         static_host_set as s,
+         target_host_set as ts,
+         target_tcp as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+   union all
+  select h.public_id                     as host_id,
+         'plugin host'                   as host_type,
+         coalesce(h.name, 'None')        as host_name,
+         'None'                          as host_description,
+         coalesce(h.address, 'Unknown')  as host_address,
+         s.public_id                     as host_set_id,
+         'plugin host set'               as host_set_type,
+         coalesce(s.name, 'None')        as host_set_name,
+         coalesce(s.description, 'None') as host_set_description,
+         c.public_id                     as host_catalog_id,
+         'plugin host catalog'           as host_catalog_type,
+         coalesce(c.name, 'None')        as host_catalog_name,
+         coalesce(c.description, 'None') as host_catalog_description,
+         t.public_id                     as target_id,
+         'tcp target'                    as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         p.public_id                     as project_id,
+         coalesce(p.name, 'None')        as project_name,
+         coalesce(p.description, 'None') as project_description,
+         o.public_id                     as host_organization_id,
+         coalesce(o.name, 'None')        as host_organization_name,
+         coalesce(o.description, 'None') as host_organization_description
+    from host_plugin_host as h,
+         host_plugin_catalog as c,
+         host_plugin_set_member as m,
+         host_plugin_set as s,
+         target_host_set as ts,
+         target_tcp as t,
+         iam_scope as p,
+         iam_scope as o
+   where h.catalog_id = c.public_id
+     and h.public_id = m.host_id
+     and s.public_id = m.set_id
+     and t.public_id = ts.target_id
+     and s.public_id = ts.host_set_id
+     and p.public_id = t.scope_id
+     and p.type = 'project'
+     and o.public_id = p.parent_id
+     and o.type = 'org'
+  ;
+
+commit;
@@ -0,0 +1,45 @@
+begin;
+
+  -- Restores the view created in 65_wh_session_dimensions.
+  create or replace view whx_user_dimension_source as
+       select -- id is the first column in the target view
+              u.public_id                       as user_id,
+              coalesce(u.name, 'None')          as user_name,
+              coalesce(u.description, 'None')   as user_description,
+              coalesce(aa.public_id, 'None')    as auth_account_id,
+              case when aa.public_id is null then 'None'
+                   else 'password auth account'
+                   end                          as auth_account_type,
+              coalesce(apa.name, 'None')        as auth_account_name,
+              coalesce(apa.description, 'None') as auth_account_description,
+              coalesce(am.public_id, 'None')    as auth_method_id,
+              case when am.public_id is null then 'None'
+                   else 'password auth method'
+                   end                          as auth_method_type,
+              coalesce(apm.name, 'None')        as auth_method_name,
+              coalesce(apm.description, 'None') as auth_method_description,
+              org.public_id                     as user_organization_id,
+              coalesce(org.name, 'None')        as user_organization_name,
+              coalesce(org.description, 'None') as user_organization_description
+         from iam_user as u
+    left join auth_account as aa on           u.public_id = aa.iam_user_id
+    left join auth_method as am on            aa.auth_method_id = am.public_id
+    left join auth_password_account as apa on aa.public_id = apa.public_id
+    left join auth_password_method as apm on  am.public_id = apm.public_id
+         join iam_scope as org on             u.scope_id = org.public_id
+  ;
+
+  drop table auth_oidc_token_request cascade;
+  drop table auth_oidc_account cascade;
+  drop table auth_oidc_aud_claim cascade;
+  drop table auth_oidc_method cascade;
+
+  delete
+    from oplog_ticket
+   where name in (
+          'auth_oidc_method',
+          'auth_oidc_aud_claim',
+          'auth_oidc_account'
+        );
+
+commit;
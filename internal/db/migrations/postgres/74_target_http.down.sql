@@ -0,0 +1,32 @@
+begin;
+
+  drop table session_connection_http_request;
+
+  drop view target_all_subtypes;
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp;
+
+  drop trigger target_name_unique_in_scope on target_tcp;
+
+  drop table target_http_header;
+  drop table target_http;
+
+  drop function target_name_unique_in_scope;
+
+  delete from oplog_ticket where name in ('target_http');
+
+commit;
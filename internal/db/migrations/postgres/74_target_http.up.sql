@@ -0,0 +1,201 @@
+begin;
+
+/*
+
+  ┌─────────────────┐          ┌────────────────────┐
+  │     target      │          │    target_http     │
+  ├─────────────────┤          ├────────────────────┤
+  │ public_id  (pk) │┼┼──────○┼│ public_id  (pk,fk) │
+  │ scope_id   (fk) │          │ scope_id   (fk)    │
+  │                 │          │ default_port       │
+  └─────────────────┘          └────────────────────┘
+                                         ┼
+                                         ┼
+                                         │
+                                         ○
+                                        ╱│╲
+                               ┌────────────────────┐
+                               │ target_http_header │
+                               ├────────────────────┤
+                               │ target_id (pk,fk)  │
+                               │ name      (pk)     │
+                               │ value              │
+                               │ value_hmac         │
+                               │ key_id             │
+                               └────────────────────┘
+
+  The worker terminates the client stream of a session for an http target and
+  forwards each HTTP request to the endpoint, adding the target's headers. The
+  value of a header is encrypted with a database key of the target's scope.
+  The method, path and status of each forwarded request is recorded in
+  session_connection_http_request.
+
+*/
+
+  create table target_http (
+    public_id wt_public_id primary key
+      references target(public_id)
+      on delete cascade
+      on update cascade,
+    scope_id wt_scope_id not null
+      references iam_scope(public_id)
+      on delete cascade
+      on update cascade,
+    name text not null, -- name is not optional for a target subtype
+    description text,
+    default_port int, -- default_port can be null
+    -- max duration of the session in seconds.
+    -- default is 8 hours
+    session_max_seconds int not null default 28800
+      constraint session_max_seconds_must_be_greater_than_0
+      check(session_max_seconds > 0),
+    -- limit on number of session connections allowed. -1 equals no limit
+    session_connection_limit int not null default 1
+      constraint session_connection_limit_must_be_greater_than_0_or_negative_1
+      check(session_connection_limit > 0 or session_connection_limit = -1),
+    worker_filter text
+      constraint worker_filter_must_not_be_empty
+      check(length(trim(worker_filter)) > 0),
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    unique(scope_id, name) -- name must be unique within a scope
+  );
+
+  create trigger insert_target_subtype before insert on target_http
+    for each row execute procedure insert_target_subtype();
+
+  create trigger delete_target_subtype after delete on target_http
+    for each row execute procedure delete_target_subtype();
+
+  create trigger immutable_columns before update on target_http
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create trigger update_version_column after update on target_http
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on target_http
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on target_http
+    for each row execute procedure default_create_time();
+
+  create trigger target_scope_valid before insert on target_http
+    for each row execute procedure target_scope_valid();
+
+  -- target_name_unique_in_scope() is a before insert and update trigger
+  -- function which ensures the name of a target is unique within its scope
+  -- across all target subtypes.
+  create or replace function target_name_unique_in_scope()
+    returns trigger
+  as $$
+  begin
+    if exists (
+      select 1
+        from target_all_subtypes
+       where scope_id = new.scope_id
+         and lower(name) = lower(new.name)
+         and public_id != new.public_id
+    ) then
+      raise exception 'target name % already exists in scope %', new.name, new.scope_id
+        using errcode = '23505';
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger target_name_unique_in_scope before insert or update of name on target_tcp
+    for each row execute procedure target_name_unique_in_scope();
+
+  create trigger target_name_unique_in_scope before insert or update of name on target_http
+    for each row execute procedure target_name_unique_in_scope();
+
+  create table target_http_header (
+    target_id wt_public_id not null
+      references target_http (public_id)
+      on delete cascade
+      on update cascade,
+    name text not null
+      constraint name_must_not_be_empty
+      check(length(trim(name)) > 0),
+    value bytea not null, -- encrypted
+    value_hmac text not null
+      constraint value_hmac_must_not_be_empty
+      check(length(trim(value_hmac)) > 0),
+    -- TODO: Make key_id a foreign key once we have DEKs
+    key_id text not null
+      constraint key_id_must_not_be_empty
+      check(length(trim(key_id)) > 0),
+    create_time wt_timestamp,
+    primary key(target_id, name)
+  );
+
+  create trigger immutable_columns before update on target_http_header
+    for each row execute procedure immutable_columns('target_id', 'name', 'create_time');
+
+  create trigger default_create_time_column before insert on target_http_header
+    for each row execute procedure default_create_time();
+
+  -- target_all_subtypes is recreated to include http targets.
+  drop view target_all_subtypes;
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'http' as type
+    from target_http;
+
+  create table session_connection_http_request (
+    connection_id wt_public_id not null
+      references session_connection (public_id)
+      on delete cascade
+      on update cascade,
+    -- requests on a connection are proxied one at a time so the create_time
+    -- of each request is unique for the connection
+    create_time wt_timestamp,
+    method text not null
+      constraint method_must_not_be_empty
+      check(length(trim(method)) > 0),
+    path text not null,
+    status_code int not null
+      constraint status_code_must_be_a_valid_http_status
+      check(status_code >= 100 and status_code <= 599),
+    primary key(connection_id, create_time)
+  );
+
+  create trigger immutable_columns before update on session_connection_http_request
+    for each row execute procedure immutable_columns('connection_id', 'create_time', 'method', 'path', 'status_code');
+
+  create trigger default_create_time_column before insert on session_connection_http_request
+    for each row execute procedure default_create_time();
+
+  insert into oplog_ticket (name, version)
+  values
+    ('target_http', 1);
+
+commit;
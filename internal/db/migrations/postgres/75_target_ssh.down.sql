@@ -0,0 +1,42 @@
+begin;
+
+  drop table session_recording;
+
+  drop view target_all_subtypes;
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'http' as type
+    from target_http;
+
+  drop table target_ssh;
+
+  delete from oplog_ticket where name in ('target_ssh');
+
+commit;
@@ -0,0 +1,170 @@
+begin;
+
+/*
+
+  ┌─────────────────┐          ┌────────────────────┐
+  │     target      │          │    target_ssh      │
+  ├─────────────────┤          ├────────────────────┤
+  │ public_id  (pk) │┼┼──────○┼│ public_id  (pk,fk) │
+  │ scope_id   (fk) │          │ scope_id   (fk)    │
+  │                 │          │ default_port       │
+  └─────────────────┘          └────────────────────┘
+
+  ┌─────────────────┐          ┌────────────────────┐
+  │    session      │          │ session_recording  │
+  ├─────────────────┤          ├────────────────────┤
+  │ public_id  (pk) │┼┼──────○<│ public_id     (pk) │
+  │                 │          │ session_id    (fk) │
+  └─────────────────┘          │ connection_id (fk) │
+                               │ channel_id         │
+                               │ data               │
+                               └────────────────────┘
+
+  The worker terminates the SSH protocol of a session for an ssh target and
+  records the terminal I/O of each session channel in asciicast format. When a
+  channel closes the worker sends its recording to the controller, which stores
+  it in session_recording.
+
+*/
+
+  create table target_ssh (
+    public_id wt_public_id primary key
+      references target(public_id)
+      on delete cascade
+      on update cascade,
+    scope_id wt_scope_id not null
+      references iam_scope(public_id)
+      on delete cascade
+      on update cascade,
+    name text not null, -- name is not optional for a target subtype
+    description text,
+    default_port int, -- default_port can be null
+    -- max duration of the session in seconds.
+    -- default is 8 hours
+    session_max_seconds int not null default 28800
+      constraint session_max_seconds_must_be_greater_than_0
+      check(session_max_seconds > 0),
+    -- limit on number of session connections allowed. -1 equals no limit
+    session_connection_limit int not null default 1
+      constraint session_connection_limit_must_be_greater_than_0_or_negative_1
+      check(session_connection_limit > 0 or session_connection_limit = -1),
+    worker_filter text
+      constraint worker_filter_must_not_be_empty
+      check(length(trim(worker_filter)) > 0),
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    unique(scope_id, name) -- name must be unique within a scope
+  );
+
+  create trigger insert_target_subtype before insert on target_ssh
+    for each row execute procedure insert_target_subtype();
+
+  create trigger delete_target_subtype after delete on target_ssh
+    for each row execute procedure delete_target_subtype();
+
+  create trigger immutable_columns before update on target_ssh
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create trigger update_version_column after update on target_ssh
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on target_ssh
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on target_ssh
+    for each row execute procedure default_create_time();
+
+  create trigger target_scope_valid before insert on target_ssh
+    for each row execute procedure target_scope_valid();
+
+  create trigger target_name_unique_in_scope before insert or update of name on target_ssh
+    for each row execute procedure target_name_unique_in_scope();
+
+  -- target_all_subtypes is recreated to include ssh targets.
+  drop view target_all_subtypes;
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'http' as type
+    from target_http
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'ssh' as type
+    from target_ssh;
+
+  create table session_recording (
+    public_id wt_public_id primary key,
+    session_id wt_public_id not null
+      references session (public_id)
+      on delete cascade
+      on update cascade,
+    connection_id wt_public_id not null
+      references session_connection (public_id)
+      on delete cascade
+      on update cascade,
+    -- channel_id is the position of the recorded channel among the session
+    -- channels opened on the connection
+    channel_id int not null
+      constraint channel_id_must_not_be_negative
+      check(channel_id >= 0),
+    start_time wt_timestamp,
+    end_time wt_timestamp,
+    -- data is the recording in asciicast v2 format
+    data bytea not null
+      constraint data_must_not_be_empty
+      check(length(data) > 0),
+    create_time wt_timestamp,
+    constraint end_time_must_not_be_before_start_time
+      check(end_time >= start_time),
+    unique(connection_id, channel_id)
+  );
+
+  create trigger immutable_columns before update on session_recording
+    for each row execute procedure immutable_columns('public_id', 'session_id', 'connection_id', 'channel_id', 'start_time', 'end_time', 'data', 'create_time');
+
+  create trigger default_create_time_column before insert on session_recording
+    for each row execute procedure default_create_time();
+
+  insert into oplog_ticket (name, version)
+  values
+    ('target_ssh', 1);
+
+commit;
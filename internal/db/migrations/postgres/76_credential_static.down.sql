@@ -0,0 +1,23 @@
+begin;
+
+  drop table target_credential cascade;
+  drop table credential_static_username_password cascade;
+  drop table credential_static_store cascade;
+  drop table credential cascade;
+  drop table credential_store cascade;
+
+  drop function target_credential_scope_valid;
+  drop function insert_credential_subtype;
+  drop function delete_credential_subtype;
+  drop function insert_credential_store_subtype;
+  drop function delete_credential_store_subtype;
+
+  delete
+    from oplog_ticket
+   where name in (
+          'credential_static_store',
+          'credential_static_username_password',
+          'target_credential'
+        );
+
+commit;
@@ -0,0 +1,253 @@
+begin;
+
+/*
+
+  ┌─────────────────┐          ┌─────────────────────────┐
+  │   credential    │          │ credential_static_      │
+  │                 │          │ username_password       │
+  ├─────────────────┤          ├─────────────────────────┤
+  │ public_id  (pk) │          │ public_id  (pk)         │
+  │ store_id   (fk) │┼┼──────○┼│ store_id   (fk)         │
+  │                 │          │ username                │
+  │                 │          │ password                │
+  └─────────────────┘          └─────────────────────────┘
+          ╲│╱                              ╲│╱
+           ○                                ○
+           │                                │
+           ┼                                ┼
+           ┼                                ┼
+  ┌─────────────────┐          ┌─────────────────────────┐
+  │credential_store │          │ credential_static_store │
+  ├─────────────────┤          ├─────────────────────────┤
+  │ public_id (pk)  │          │ public_id (pk)          │
+  │ scope_id  (fk)  │┼┼──────○┼│ scope_id  (fk)          │
+  │                 │          │                         │
+  └─────────────────┘          └─────────────────────────┘
+
+  Credential stores are owned by a project. The credentials in a store can be
+  attached to targets in the same project through target_credential and are
+  returned to the client when a session is authorized for the target. The
+  password of a static credential is encrypted with a database key of the
+  project.
+
+*/
+
+  -- credential_store
+  create table credential_store (
+    public_id wt_public_id
+      primary key,
+    scope_id wt_scope_id
+      not null
+      references iam_scope (public_id)
+      on delete cascade
+      on update cascade,
+    unique(scope_id, public_id)
+  );
+
+  create trigger immutable_columns before update on credential_store
+    for each row execute procedure immutable_columns('public_id', 'scope_id');
+
+  -- insert_credential_store_subtype() is a before insert trigger
+  -- function for subtypes of credential_store
+  create or replace function insert_credential_store_subtype()
+    returns trigger
+  as $$
+  begin
+    insert into credential_store
+      (public_id, scope_id)
+    values
+      (new.public_id, new.scope_id);
+    return new;
+  end;
+  $$ language plpgsql;
+
+  -- delete_credential_store_subtype() is an after delete trigger
+  -- function for subtypes of credential_store
+  create or replace function delete_credential_store_subtype()
+    returns trigger
+  as $$
+  begin
+    delete from credential_store
+    where public_id = old.public_id;
+    return null; -- result is ignored since this is an after trigger
+  end;
+  $$ language plpgsql;
+
+  -- credential
+  create table credential (
+    public_id wt_public_id primary key,
+    store_id wt_public_id not null
+      references credential_store (public_id)
+      on delete cascade
+      on update cascade,
+    unique(store_id, public_id)
+  );
+
+  create trigger immutable_columns before update on credential
+    for each row execute procedure immutable_columns('public_id', 'store_id');
+
+  -- insert_credential_subtype() is a before insert trigger
+  -- function for subtypes of credential
+  create or replace function insert_credential_subtype()
+    returns trigger
+  as $$
+  begin
+    insert into credential
+      (public_id, store_id)
+    values
+      (new.public_id, new.store_id);
+    return new;
+  end;
+  $$ language plpgsql;
+
+  -- delete_credential_subtype() is an after delete trigger
+  -- function for subtypes of credential
+  create or replace function delete_credential_subtype()
+    returns trigger
+  as $$
+  begin
+    delete from credential
+    where public_id = old.public_id;
+    return null; -- result is ignored since this is an after trigger
+  end;
+  $$ language plpgsql;
+
+  create table credential_static_store (
+    public_id wt_public_id
+      primary key,
+    scope_id wt_scope_id
+      not null
+      references iam_scope (public_id)
+      on delete cascade
+      on update cascade,
+    name text,
+    description text,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    foreign key (scope_id, public_id)
+      references credential_store (scope_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(scope_id, name)
+  );
+
+  create trigger update_version_column after update on credential_static_store
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on credential_static_store
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on credential_static_store
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on credential_static_store
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create trigger insert_credential_store_subtype before insert on credential_static_store
+    for each row execute procedure insert_credential_store_subtype();
+
+  create trigger delete_credential_store_subtype after delete on credential_static_store
+    for each row execute procedure delete_credential_store_subtype();
+
+  create table credential_static_username_password (
+    public_id wt_public_id primary key,
+    store_id wt_public_id not null
+      references credential_static_store (public_id)
+      on delete cascade
+      on update cascade,
+    name text,
+    description text,
+    username text not null
+      constraint username_must_not_be_empty
+      check(length(trim(username)) > 0),
+    password bytea not null -- encrypted
+      constraint password_must_not_be_empty
+      check(length(password) > 0),
+    password_hmac text not null
+      constraint password_hmac_must_not_be_empty
+      check(length(trim(password_hmac)) > 0),
+    -- TODO: Make key_id a foreign key once we have DEKs
+    key_id text not null
+      constraint key_id_must_not_be_empty
+      check(length(trim(key_id)) > 0),
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    unique(store_id, name),
+    foreign key (store_id, public_id)
+      references credential (store_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(store_id, public_id)
+  );
+
+  create trigger update_version_column after update on credential_static_username_password
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on credential_static_username_password
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on credential_static_username_password
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on credential_static_username_password
+    for each row execute procedure immutable_columns('public_id', 'store_id', 'create_time');
+
+  create trigger insert_credential_subtype before insert on credential_static_username_password
+    for each row execute procedure insert_credential_subtype();
+
+  create trigger delete_credential_subtype after delete on credential_static_username_password
+    for each row execute procedure delete_credential_subtype();
+
+  create table target_credential (
+    target_id wt_public_id
+      references target (public_id)
+      on delete cascade
+      on update cascade,
+    credential_id wt_public_id
+      references credential (public_id)
+      on delete cascade
+      on update cascade,
+    primary key(target_id, credential_id),
+    create_time wt_timestamp
+  );
+
+  create trigger immutable_columns before update on target_credential
+    for each row execute procedure immutable_columns('target_id', 'credential_id', 'create_time');
+
+  create trigger default_create_time_column before insert on target_credential
+    for each row execute procedure default_create_time();
+
+  -- target_credential_scope_valid() is a before insert trigger function for
+  -- target_credential
+  create or replace function target_credential_scope_valid()
+    returns trigger
+  as $$
+  begin
+    perform from
+      credential_store cs,
+      credential c,
+      target t
+    where
+      cs.public_id = c.store_id and
+      cs.scope_id = t.scope_id and
+      c.public_id = new.credential_id and
+      t.public_id = new.target_id;
+    if not found then
+      raise exception 'target scope and credential scope are not equal';
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger target_credential_scope_valid before insert on target_credential
+    for each row execute procedure target_credential_scope_valid();
+
+  insert into oplog_ticket (name, version)
+  values
+    ('credential_static_store', 1),
+    ('credential_static_username_password', 1),
+    ('target_credential', 1);
+
+commit;
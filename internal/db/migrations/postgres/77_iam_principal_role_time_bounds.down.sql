@@ -0,0 +1,50 @@
+begin;
+
+  drop view iam_principal_role;
+  create view iam_principal_role as
+  select
+    ur.create_time,
+    ur.principal_id,
+    ur.role_id,
+    u.scope_id as principal_scope_id,
+    r.scope_id as role_scope_id,
+    get_scoped_principal_id(r.scope_id, u.scope_id, ur.principal_id) as scoped_principal_id,
+    'user' as type
+  from
+    iam_user_role ur,
+    iam_role r,
+    iam_user u
+  where
+    ur.role_id = r.public_id and
+    u.public_id = ur.principal_id
+  union
+  select
+    gr.create_time,
+    gr.principal_id,
+    gr.role_id,
+    g.scope_id as principal_scope_id,
+    r.scope_id as role_scope_id,
+    get_scoped_principal_id(r.scope_id, g.scope_id, gr.principal_id) as scoped_principal_id,
+    'group' as type
+  from
+    iam_group_role gr,
+    iam_role r,
+    iam_group g
+  where
+    gr.role_id = r.public_id and
+    g.public_id = gr.principal_id;
+
+  drop index iam_user_role_expiration_time_ix;
+  drop index iam_group_role_expiration_time_ix;
+
+  alter table iam_user_role
+    drop constraint not_before_time_must_be_before_expiration_time,
+    drop column not_before_time,
+    drop column expiration_time;
+
+  alter table iam_group_role
+    drop constraint not_before_time_must_be_before_expiration_time,
+    drop column not_before_time,
+    drop column expiration_time;
+
+commit;
@@ -0,0 +1,65 @@
+begin;
+
+/*
+  Role assignments can be bounded in time. A user or group assigned to a role
+  with a not_before_time only receives the role's grants once that time is
+  reached, and one assigned with an expiration_time stops receiving them once
+  that time is reached. Expired assignments are removed by the controller.
+*/
+
+  alter table iam_user_role
+    add column not_before_time timestamp with time zone,
+    add column expiration_time timestamp with time zone,
+    add constraint not_before_time_must_be_before_expiration_time
+      check(not_before_time < expiration_time);
+
+  alter table iam_group_role
+    add column not_before_time timestamp with time zone,
+    add column expiration_time timestamp with time zone,
+    add constraint not_before_time_must_be_before_expiration_time
+      check(not_before_time < expiration_time);
+
+  create index iam_user_role_expiration_time_ix
+    on iam_user_role (expiration_time);
+  create index iam_group_role_expiration_time_ix
+    on iam_group_role (expiration_time);
+
+  drop view iam_principal_role;
+  create view iam_principal_role as
+  select
+    ur.create_time,
+    ur.principal_id,
+    ur.role_id,
+    u.scope_id as principal_scope_id,
+    r.scope_id as role_scope_id,
+    get_scoped_principal_id(r.scope_id, u.scope_id, ur.principal_id) as scoped_principal_id,
+    'user' as type,
+    ur.not_before_time,
+    ur.expiration_time
+  from
+    iam_user_role ur,
+    iam_role r,
+    iam_user u
+  where
+    ur.role_id = r.public_id and
+    u.public_id = ur.principal_id
+  union
+  select
+    gr.create_time,
+    gr.principal_id,
+    gr.role_id,
+    g.scope_id as principal_scope_id,
+    r.scope_id as role_scope_id,
+    get_scoped_principal_id(r.scope_id, g.scope_id, gr.principal_id) as scoped_principal_id,
+    'group' as type,
+    gr.not_before_time,
+    gr.expiration_time
+  from
+    iam_group_role gr,
+    iam_role r,
+    iam_group g
+  where
+    gr.role_id = r.public_id and
+    g.public_id = gr.principal_id;
+
+commit;
@@ -0,0 +1,14 @@
+begin;
+
+  drop table iam_access_request cascade;
+
+  drop function iam_access_request_scope_valid;
+  drop function iam_access_request_review;
+
+  delete
+    from oplog_ticket
+   where name in (
+          'iam_access_request'
+        );
+
+commit;
@@ -0,0 +1,121 @@
+begin;
+
+/*
+  An access request is a user's request for the authorize-session action on a
+  target for a duration, along with a justification. The request is created
+  in the scope of the target and starts out pending. A user with the approve
+  or deny action on the request reviews it once, which records the reviewer
+  and the review time. An approved request grants the user authorize-session
+  on the target until its expiration time, which is the review time plus the
+  requested duration.
+*/
+
+  create table iam_access_request (
+    public_id wt_public_id
+      primary key,
+    scope_id wt_scope_id
+      not null
+      references iam_scope (public_id)
+      on delete cascade
+      on update cascade,
+    target_id wt_public_id
+      not null
+      references target (public_id)
+      on delete cascade
+      on update cascade,
+    user_id wt_user_id
+      not null
+      references iam_user (public_id)
+      on delete cascade
+      on update cascade,
+    justification text not null
+      constraint justification_must_not_be_empty
+      check(length(trim(justification)) > 0),
+    duration_seconds integer not null
+      constraint duration_seconds_must_be_greater_than_0
+      check(duration_seconds > 0),
+    status text not null
+      default 'pending'
+      constraint status_must_be_known
+      check(status in ('pending', 'approved', 'denied')),
+    reviewer_id wt_user_id
+      references iam_user (public_id)
+      on delete set null
+      on update cascade,
+    review_comment text,
+    review_time timestamp with time zone,
+    expiration_time timestamp with time zone,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    constraint reviewer_must_not_be_requester
+      check(reviewer_id <> user_id),
+    constraint review_time_must_be_set_when_reviewed
+      check((status = 'pending') = (review_time is null)),
+    constraint expiration_time_must_be_set_when_approved
+      check((status = 'approved') = (expiration_time is not null))
+  );
+
+  create index iam_access_request_user_id_ix
+    on iam_access_request (user_id);
+
+  create trigger update_version_column after update on iam_access_request
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on iam_access_request
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on iam_access_request
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on iam_access_request
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'target_id', 'user_id', 'justification', 'duration_seconds', 'create_time');
+
+  -- iam_access_request_scope_valid() is a before insert trigger function for
+  -- iam_access_request that ensures the request is in the scope of its target
+  create or replace function iam_access_request_scope_valid()
+    returns trigger
+  as $$
+  begin
+    perform from target
+    where
+      public_id = new.target_id and
+      scope_id = new.scope_id;
+    if not found then
+      raise exception 'access request scope and target scope are not equal';
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger iam_access_request_scope_valid before insert on iam_access_request
+    for each row execute procedure iam_access_request_scope_valid();
+
+  -- iam_access_request_review() is a before update trigger function for
+  -- iam_access_request. A request can only be reviewed while pending, and the
+  -- review sets the review time and, for approvals, the expiration time.
+  create or replace function iam_access_request_review()
+    returns trigger
+  as $$
+  begin
+    if new.status is distinct from old.status then
+      if old.status <> 'pending' then
+        raise exception 'access request % has already been reviewed', old.public_id;
+      end if;
+      new.review_time = current_timestamp;
+      if new.status = 'approved' then
+        new.expiration_time = current_timestamp + make_interval(secs => new.duration_seconds);
+      end if;
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger iam_access_request_review before update on iam_access_request
+    for each row execute procedure iam_access_request_review();
+
+  insert into oplog_ticket (name, version)
+  values
+    ('iam_access_request', 1);
+
+commit;
@@ -0,0 +1,7 @@
+begin;
+
+  alter table iam_role
+    drop constraint grant_scope_inheritance_requires_role_scope,
+    drop column grant_scope_inheritance;
+
+commit;
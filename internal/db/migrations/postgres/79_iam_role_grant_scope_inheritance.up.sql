@@ -0,0 +1,20 @@
+begin;
+
+/*
+  A role's grants can apply to the scopes below its grant scope in addition to
+  the grant scope itself. With a grant_scope_inheritance of children, the
+  grants also apply to the direct child scopes of the grant scope; with
+  descendants, they apply to all of its descendant scopes. Inheritance is only
+  allowed when the grant scope is the role's own scope, and scopes created
+  after the role are covered without updating it.
+*/
+
+  alter table iam_role
+    add column grant_scope_inheritance text not null
+      default 'none'
+      constraint grant_scope_inheritance_must_be_known
+      check(grant_scope_inheritance in ('none', 'children', 'descendants')),
+    add constraint grant_scope_inheritance_requires_role_scope
+      check(grant_scope_inheritance = 'none' or grant_scope_id = scope_id);
+
+commit;
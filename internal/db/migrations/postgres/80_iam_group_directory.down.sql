@@ -0,0 +1,10 @@
+begin;
+
+  drop index iam_group_scim_directory_group_uq;
+
+  alter table iam_group
+    drop constraint directory_group_requires_directory_type,
+    drop column directory_group,
+    drop column directory_type;
+
+commit;
@@ -0,0 +1,28 @@
+begin;
+
+/*
+  A group can be bound to a group in an external directory, in which case its
+  members are kept in sync with the directory's group. With a directory_type
+  of ldap, the controllers periodically read the members of the LDAP group
+  with the DN in directory_group. With scim, a SCIM client pushes the members
+  of the group with the display name in directory_group to the controller.
+  Users that are not yet known are created when they are added to a group.
+*/
+
+  alter table iam_group
+    add column directory_type text
+      constraint directory_type_must_be_known
+      check(directory_type in ('ldap', 'scim')),
+    add column directory_group text
+      constraint directory_group_must_not_be_empty
+      check(length(trim(directory_group)) > 0),
+    add constraint directory_group_requires_directory_type
+      check((directory_type is null) = (directory_group is null));
+
+  -- SCIM clients refer to groups by their display name alone, so a SCIM group
+  -- can only be bound to one group.
+  create unique index iam_group_scim_directory_group_uq
+    on iam_group (directory_group)
+    where directory_type = 'scim';
+
+commit;
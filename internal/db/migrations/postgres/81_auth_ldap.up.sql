@@ -0,0 +1,218 @@
+begin;
+
+/*
+
+       ┌────────────────┐                 ┌──────────────────────┐
+       │  auth_method   │                 │   auth_ldap_method   │
+       ├────────────────┤                 ├──────────────────────┤
+       │ public_id (pk) │                 │ public_id (pk,fk)    │
+       │ scope_id  (fk) │┼┼─────────────○┼│ scope_id  (fk)       │
+       │                │                 │ ...                  │
+       └────────────────┘                 └──────────────────────┘
+                ┼                                     ┼
+                ┼                                     ┼
+                │                                     │
+                │ ▲fk1                                │ ▲fk1
+                │                                     │
+                ○                                     ○
+               ╱│╲                                   ╱│╲
+  ┌──────────────────────────┐          ┌──────────────────────────┐
+  │       auth_account       │          │    auth_ldap_account     │
+  ├──────────────────────────┤          ├──────────────────────────┤
+  │ public_id         (pk)   │          │ public_id      (pk,fk2)  │
+  │ scope_id          (fk1)  │   ◀fk2   │ scope_id       (fk1,fk2) │
+  │ auth_method_id    (fk1)  │┼┼──────○┼│ auth_method_id (fk1,fk2) │
+  │ iam_user_scope_id (fk2)  │          │ dn                       │
+  │ iam_user_id       (fk2)  │          │ ...                      │
+  └──────────────────────────┘          └──────────────────────────┘
+
+  An auth_ldap_method is an auth_method subtype and an auth_ldap_account is
+  an auth_account subtype.
+
+  An auth_ldap_method contains the connection settings of an LDAP server or
+  Active Directory domain controller and how users and their groups are found
+  in it. Users are searched for with the bind_dn, or anonymously if it is not
+  set, and authenticate by binding with the DN of their entry.
+
+  An auth_ldap_account is identified within its auth method by the DN of the
+  user's entry. It is created the first time the user authenticates.
+
+*/
+
+  create table auth_ldap_method (
+    public_id wt_public_id
+      primary key,
+    scope_id wt_scope_id
+      not null,
+    name text,
+    description text,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    url text not null
+      constraint url_must_be_ldap_or_ldaps
+      check(url ~ '^ldaps?://.+'),
+    start_tls boolean not null default false,
+    insecure_tls boolean not null default false,
+    certificate text
+      constraint certificate_must_not_be_empty
+      check(length(trim(certificate)) > 0),
+    bind_dn text
+      constraint bind_dn_must_not_be_empty
+      check(length(trim(bind_dn)) > 0),
+    bind_password bytea, -- encrypted
+    bind_password_hmac text
+      constraint bind_password_hmac_must_not_be_empty
+      check(length(trim(bind_password_hmac)) > 0),
+    -- TODO: Make key_id a foreign key once we have DEKs
+    key_id text
+      constraint key_id_must_not_be_empty
+      check(length(trim(key_id)) > 0),
+    user_search_base text not null
+      constraint user_search_base_must_not_be_empty
+      check(length(trim(user_search_base)) > 0),
+    user_search_filter text not null
+      constraint user_search_filter_must_not_be_empty
+      check(length(trim(user_search_filter)) > 0),
+    group_search_base text
+      constraint group_search_base_must_not_be_empty
+      check(length(trim(group_search_base)) > 0),
+    group_search_filter text
+      constraint group_search_filter_must_not_be_empty
+      check(length(trim(group_search_filter)) > 0),
+    group_attr text
+      constraint group_attr_must_not_be_empty
+      check(length(trim(group_attr)) > 0),
+    constraint start_tls_requires_ldap_url
+      check(not start_tls or url ~ '^ldap://'),
+    constraint bind_password_requires_bind_dn
+      check(bind_password is null or bind_dn is not null),
+    constraint bind_password_requires_hmac_and_key_id
+      check((bind_password is null) = (bind_password_hmac is null)
+        and (bind_password is null) = (key_id is null)),
+    foreign key (scope_id, public_id)
+      references auth_method (scope_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(scope_id, name),
+    unique(scope_id, public_id)
+  );
+
+  create trigger update_version_column after update on auth_ldap_method
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on auth_ldap_method
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on auth_ldap_method
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on auth_ldap_method
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create trigger insert_auth_method_subtype before insert on auth_ldap_method
+    for each row execute procedure insert_auth_method_subtype();
+
+  create table auth_ldap_account (
+    public_id wt_public_id
+      primary key,
+    auth_method_id wt_public_id
+      not null,
+    -- NOTE(mgaffney): The scope_id type is not wt_scope_id because the domain
+    -- check is executed before the insert trigger which retrieves the scope_id
+    -- causing an insert to fail.
+    scope_id text not null,
+    name text,
+    description text,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    dn text not null
+      constraint dn_must_not_be_empty
+      check(length(trim(dn)) > 0)
+      constraint dn_must_be_less_than_1024_characters
+      check(length(dn) < 1024),
+    login_name text not null
+      constraint login_name_must_not_be_empty
+      check(length(trim(login_name)) > 0),
+    email text
+      constraint email_must_be_less_than_1024_characters
+      check(length(email) < 1024),
+    full_name text
+      constraint full_name_must_be_less_than_1024_characters
+      check(length(full_name) < 1024),
+    -- member_of_groups is a JSON array of the names of the groups the user
+    -- was a member of when they last authenticated.
+    member_of_groups text,
+    foreign key (scope_id, auth_method_id)
+      references auth_ldap_method (scope_id, public_id)
+      on delete cascade
+      on update cascade,
+    foreign key (scope_id, auth_method_id, public_id)
+      references auth_account (scope_id, auth_method_id, public_id)
+      on delete cascade
+      on update cascade,
+    unique(auth_method_id, name),
+    unique(auth_method_id, dn),
+    unique(auth_method_id, public_id)
+  );
+
+  create trigger update_version_column after update on auth_ldap_account
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on auth_ldap_account
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on auth_ldap_account
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on auth_ldap_account
+    for each row execute procedure immutable_columns('public_id', 'auth_method_id', 'scope_id', 'dn', 'create_time');
+
+  create trigger insert_auth_account_subtype before insert on auth_ldap_account
+    for each row execute procedure insert_auth_account_subtype();
+
+  insert into oplog_ticket (name, version)
+  values
+    ('auth_ldap_method', 1),
+    ('auth_ldap_account', 1);
+
+  -- Replaces the view created in 73_auth_oidc to include ldap accounts and
+  -- auth methods.
+  create or replace view whx_user_dimension_source as
+       select -- id is the first column in the target view
+              u.public_id                       as user_id,
+              coalesce(u.name, 'None')          as user_name,
+              coalesce(u.description, 'None')   as user_description,
+              coalesce(aa.public_id, 'None')    as auth_account_id,
+              case when aa.public_id is null then 'None'
+                   when aoa.public_id is not null then 'oidc auth account'
+                   when ala.public_id is not null then 'ldap auth account'
+                   else 'password auth account'
+                   end                          as auth_account_type,
+              coalesce(apa.name, aoa.name, ala.name, 'None')                      as auth_account_name,
+              coalesce(apa.description, aoa.description, ala.description, 'None') as auth_account_description,
+              coalesce(am.public_id, 'None')    as auth_method_id,
+              case when am.public_id is null then 'None'
+                   when aom.public_id is not null then 'oidc auth method'
+                   when alm.public_id is not null then 'ldap auth method'
+                   else 'password auth method'
+                   end                          as auth_method_type,
+              coalesce(apm.name, aom.name, alm.name, 'None')                      as auth_method_name,
+              coalesce(apm.description, aom.description, alm.description, 'None') as auth_method_description,
+              org.public_id                     as user_organization_id,
+              coalesce(org.name, 'None')        as user_organization_name,
+              coalesce(org.description, 'None') as user_organization_description
+         from iam_user as u
+    left join auth_account as aa on           u.public_id = aa.iam_user_id
+    left join auth_method as am on            aa.auth_method_id = am.public_id
+    left join auth_password_account as apa on aa.public_id = apa.public_id
+    left join auth_password_method as apm on  am.public_id = apm.public_id
+    left join auth_oidc_account as aoa on     aa.public_id = aoa.public_id
+    left join auth_oidc_method as aom on      am.public_id = aom.public_id
+    left join auth_ldap_account as ala on     aa.public_id = ala.public_id
+    left join auth_ldap_method as alm on      am.public_id = alm.public_id
+         join iam_scope as org on             u.scope_id = org.public_id
+  ;
+
+commit;
@@ -0,0 +1,30 @@
+begin;
+
+  drop view auth_password_current_conf;
+  create view auth_password_current_conf as
+      select pm.min_login_name_length, pm.min_password_length, c.*
+        from auth_password_method pm
+  inner join auth_password_conf_union c
+          on pm.password_conf_id = c.password_conf_id;
+
+  drop table auth_password_argon2_cred_history;
+  drop table auth_password_failed_attempt;
+
+  alter table auth_password_account
+    drop column unlock_time,
+    drop column locked_time,
+    drop column must_change_password;
+
+  alter table auth_password_method
+    drop constraint lockout_threshold_requires_lockout_window,
+    drop column lockout_duration_seconds,
+    drop column lockout_window_seconds,
+    drop column lockout_threshold,
+    drop column max_password_age_seconds,
+    drop column password_history_count,
+    drop column require_symbol,
+    drop column require_digit,
+    drop column require_lowercase,
+    drop column require_uppercase;
+
+commit;
@@ -0,0 +1,105 @@
+begin;
+
+/*
+  A password auth method can enforce a password policy on the passwords of
+  its accounts and lock accounts after repeated failed authentication
+  attempts.
+
+  The require_* columns are the character classes a new password must
+  contain. A new password must not equal any of the last
+  password_history_count passwords of the account, including the current
+  one. A password older than max_password_age_seconds must be changed the
+  next time the account authenticates, as must the password of an account
+  with must_change_password set. A zero password_history_count or
+  max_password_age_seconds disables the check.
+
+  An account is locked when lockout_threshold failed authentication attempts
+  are made within lockout_window_seconds. A locked account is unlocked after
+  lockout_duration_seconds or, if it is zero, when an administrator unlocks
+  it. Failed attempts made before an account was locked or unlocked do not
+  count towards the threshold. A zero lockout_threshold disables lockout.
+
+  Replaced credentials are kept in auth_password_argon2_cred_history so new
+  passwords can be checked against them.
+*/
+
+  alter table auth_password_method
+    add column require_uppercase boolean not null default false,
+    add column require_lowercase boolean not null default false,
+    add column require_digit boolean not null default false,
+    add column require_symbol boolean not null default false,
+    add column password_history_count int not null default 0
+      constraint password_history_count_must_not_be_negative
+      check(password_history_count >= 0),
+    add column max_password_age_seconds int not null default 0
+      constraint max_password_age_seconds_must_not_be_negative
+      check(max_password_age_seconds >= 0),
+    add column lockout_threshold int not null default 0
+      constraint lockout_threshold_must_not_be_negative
+      check(lockout_threshold >= 0),
+    add column lockout_window_seconds int not null default 900
+      constraint lockout_window_seconds_must_not_be_negative
+      check(lockout_window_seconds >= 0),
+    add column lockout_duration_seconds int not null default 0
+      constraint lockout_duration_seconds_must_not_be_negative
+      check(lockout_duration_seconds >= 0),
+    add constraint lockout_threshold_requires_lockout_window
+      check(lockout_threshold = 0 or lockout_window_seconds > 0);
+
+  alter table auth_password_account
+    add column must_change_password boolean not null default false,
+    add column locked_time timestamp with time zone,
+    add column unlock_time timestamp with time zone;
+
+  create table auth_password_failed_attempt (
+    password_account_id wt_public_id not null
+      references auth_password_account (public_id)
+      on delete cascade
+      on update cascade,
+    create_time wt_timestamp
+  );
+
+  create index auth_password_failed_attempt_account_time_ix
+    on auth_password_failed_attempt (password_account_id, create_time);
+
+  create table auth_password_argon2_cred_history (
+    private_id wt_private_id primary key,
+    password_account_id wt_public_id not null
+      references auth_password_account (public_id)
+      on delete cascade
+      on update cascade,
+    password_conf_id wt_private_id not null
+      references auth_password_argon2_conf (private_id)
+      on delete cascade
+      on update cascade,
+    salt bytea not null
+      constraint salt_must_not_be_empty
+      check(length(salt) > 0),
+    derived_key bytea not null
+      constraint derived_key_must_not_be_empty
+      check(length(derived_key) > 0),
+    key_id text not null
+      constraint key_id_must_not_be_empty
+      check(length(trim(key_id)) > 0),
+    -- create_time is the create_time of the replaced credential.
+    create_time wt_timestamp
+  );
+
+  create index auth_password_argon2_cred_history_account_time_ix
+    on auth_password_argon2_cred_history (password_account_id, create_time);
+
+  -- Replaces the view created in 14_auth_password_views to include the
+  -- password policy of the auth method.
+  drop view auth_password_current_conf;
+  create view auth_password_current_conf as
+      -- Rerun this query whenever auth_password_conf_union is updated.
+      select pm.min_login_name_length, pm.min_password_length,
+             pm.require_uppercase, pm.require_lowercase,
+             pm.require_digit, pm.require_symbol,
+             pm.password_history_count,
+             c.*
+        from auth_password_method pm
+  inner join auth_password_conf_union c
+          on pm.password_conf_id = c.password_conf_id;
+
+commit;
@@ -0,0 +1,11 @@
+begin;
+
+  -- upstream is the name of the worker to which a worker which accepts no
+  -- inbound connections is connected. Sessions for such a worker are routed
+  -- to it through its upstream worker.
+  alter table server
+    add column upstream text
+      constraint upstream_must_not_be_empty
+      check(length(trim(upstream)) > 0);
+
+commit;
@@ -0,0 +1,140 @@
+begin;
+
+/*
+
+  ┌─────────────────┐          ┌────────────────────┐
+  │     target      │          │    target_udp      │
+  ├─────────────────┤          ├────────────────────┤
+  │ public_id  (pk) │┼┼──────○┼│ public_id  (pk,fk) │
+  │ scope_id   (fk) │          │ scope_id   (fk)    │
+  │                 │          │ default_port       │
+  └─────────────────┘          └────────────────────┘
+
+  The worker relays the datagrams of a session for a udp target, framed on the
+  client stream, to and from the endpoint.
+
+*/
+
+  create table target_udp (
+    public_id wt_public_id primary key
+      references target(public_id)
+      on delete cascade
+      on update cascade,
+    scope_id wt_scope_id not null
+      references iam_scope(public_id)
+      on delete cascade
+      on update cascade,
+    name text not null, -- name is not optional for a target subtype
+    description text,
+    default_port int, -- default_port can be null
+    -- max duration of the session in seconds.
+    -- default is 8 hours
+    session_max_seconds int not null default 28800
+      constraint session_max_seconds_must_be_greater_than_0
+      check(session_max_seconds > 0),
+    -- limit on number of session connections allowed. -1 equals no limit
+    session_connection_limit int not null default 1
+      constraint session_connection_limit_must_be_greater_than_0_or_negative_1
+      check(session_connection_limit > 0 or session_connection_limit = -1),
+    worker_filter text
+      constraint worker_filter_must_not_be_empty
+      check(length(trim(worker_filter)) > 0),
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    unique(scope_id, name) -- name must be unique within a scope
+  );
+
+  create trigger insert_target_subtype before insert on target_udp
+    for each row execute procedure insert_target_subtype();
+
+  create trigger delete_target_subtype after delete on target_udp
+    for each row execute procedure delete_target_subtype();
+
+  create trigger immutable_columns before update on target_udp
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create trigger update_version_column after update on target_udp
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on target_udp
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on target_udp
+    for each row execute procedure default_create_time();
+
+  create trigger target_scope_valid before insert on target_udp
+    for each row execute procedure target_scope_valid();
+
+  create trigger target_name_unique_in_scope before insert or update of name on target_udp
+    for each row execute procedure target_name_unique_in_scope();
+
+  -- target_all_subtypes is recreated to include udp targets.
+  drop view target_all_subtypes;
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'http' as type
+    from target_http
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'ssh' as type
+    from target_ssh
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'udp' as type
+    from target_udp;
+
+  insert into oplog_ticket (name, version)
+  values
+    ('target_udp', 1);
+
+commit;
@@ -0,0 +1,151 @@
+begin;
+
+  delete from session_termination_reason_enm where name = 'idle timeout';
+  alter table session_termination_reason_enm
+    drop constraint only_predefined_session_termination_reasons_allowed;
+  alter table session_termination_reason_enm
+    add constraint only_predefined_session_termination_reasons_allowed
+      check (
+        name in (
+          'unknown',
+          'timed out',
+          'closed by end-user',
+          'terminated',
+          'network error',
+          'system error',
+          'connection limit',
+          'canceled'
+        )
+      );
+
+  delete from session_connection_closed_reason_enm where name = 'idle timeout';
+  alter table session_connection_closed_reason_enm
+    drop constraint only_predefined_session_connection_closed_reasons_allowed;
+  alter table session_connection_closed_reason_enm
+    add constraint only_predefined_session_connection_closed_reasons_allowed
+      check (
+        name in (
+          'unknown',
+          'timed out',
+          'closed by end-user',
+          'canceled',
+          'network error',
+          'system error'
+        )
+      );
+
+  drop view session_with_state;
+  create view session_with_state as
+  select
+    s.public_id,
+    s.user_id,
+    s.host_id,
+    s.server_id,
+    s.server_type,
+    s.target_id,
+    s.host_set_id,
+    s.auth_token_id,
+    s.scope_id,
+    s.certificate,
+    s.expiration_time,
+    s.connection_limit,
+    s.tofu_token,
+    s.key_id,
+    s.termination_reason,
+    s.version,
+    s.create_time,
+    s.update_time,
+    s.endpoint,
+    ss.state,
+    ss.previous_end_time,
+    ss.start_time,
+    ss.end_time
+  from
+    session s,
+    session_state ss
+  where
+    s.public_id = ss.session_id;
+
+  drop trigger immutable_columns on session;
+  create trigger
+    immutable_columns
+  before
+  update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint');
+
+  alter table session
+    drop column idle_timeout_seconds;
+
+  drop view target_all_subtypes;
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'http' as type
+    from target_http
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'ssh' as type
+    from target_ssh
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    version,
+    create_time,
+    update_time,
+    'udp' as type
+    from target_udp;
+
+  alter table target_tcp
+    drop column session_idle_timeout_seconds;
+  alter table target_http
+    drop column session_idle_timeout_seconds;
+  alter table target_ssh
+    drop column session_idle_timeout_seconds;
+  alter table target_udp
+    drop column session_idle_timeout_seconds;
+
+commit;
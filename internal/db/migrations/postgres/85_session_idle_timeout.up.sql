@@ -0,0 +1,190 @@
+begin;
+
+  -- session_idle_timeout_seconds is the number of seconds without traffic
+  -- after which a worker closes a connection of a session for the target.
+  -- Zero means connections are never closed for being idle.
+  alter table target_tcp
+    add column session_idle_timeout_seconds int not null default 0
+      constraint session_idle_timeout_seconds_must_not_be_negative
+      check(session_idle_timeout_seconds >= 0);
+
+  alter table target_http
+    add column session_idle_timeout_seconds int not null default 0
+      constraint session_idle_timeout_seconds_must_not_be_negative
+      check(session_idle_timeout_seconds >= 0);
+
+  alter table target_ssh
+    add column session_idle_timeout_seconds int not null default 0
+      constraint session_idle_timeout_seconds_must_not_be_negative
+      check(session_idle_timeout_seconds >= 0);
+
+  alter table target_udp
+    add column session_idle_timeout_seconds int not null default 0
+      constraint session_idle_timeout_seconds_must_not_be_negative
+      check(session_idle_timeout_seconds >= 0);
+
+  -- target_all_subtypes is recreated to include the
+  -- session_idle_timeout_seconds column.
+  drop view target_all_subtypes;
+  create view target_all_subtypes
+  as
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    session_idle_timeout_seconds,
+    version,
+    create_time,
+    update_time,
+    'tcp' as type
+    from target_tcp
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    session_idle_timeout_seconds,
+    version,
+    create_time,
+    update_time,
+    'http' as type
+    from target_http
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    session_idle_timeout_seconds,
+    version,
+    create_time,
+    update_time,
+    'ssh' as type
+    from target_ssh
+  union
+  select
+    public_id,
+    scope_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    worker_filter,
+    session_idle_timeout_seconds,
+    version,
+    create_time,
+    update_time,
+    'udp' as type
+    from target_udp;
+
+  -- idle_timeout_seconds is copied from the target when the session is
+  -- created and sent to the worker handling the session.
+  alter table session
+    add column idle_timeout_seconds int not null default 0
+      constraint idle_timeout_seconds_must_not_be_negative
+      check(idle_timeout_seconds >= 0);
+
+  drop trigger immutable_columns on session;
+  create trigger
+    immutable_columns
+  before
+  update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'idle_timeout_seconds', 'create_time', 'endpoint');
+
+  -- session_with_state is recreated to include the idle_timeout_seconds
+  -- column.
+  drop view session_with_state;
+  create view session_with_state as
+  select
+    s.public_id,
+    s.user_id,
+    s.host_id,
+    s.server_id,
+    s.server_type,
+    s.target_id,
+    s.host_set_id,
+    s.auth_token_id,
+    s.scope_id,
+    s.certificate,
+    s.expiration_time,
+    s.connection_limit,
+    s.idle_timeout_seconds,
+    s.tofu_token,
+    s.key_id,
+    s.termination_reason,
+    s.version,
+    s.create_time,
+    s.update_time,
+    s.endpoint,
+    ss.state,
+    ss.previous_end_time,
+    ss.start_time,
+    ss.end_time
+  from
+    session s,
+    session_state ss
+  where
+    s.public_id = ss.session_id;
+
+  -- A connection without traffic for the idle timeout of its session is closed
+  -- with the 'idle timeout' reason, and a session whose connections have all
+  -- been closed once one was closed for being idle is terminated with the same
+  -- reason.
+  alter table session_connection_closed_reason_enm
+    drop constraint only_predefined_session_connection_closed_reasons_allowed;
+  alter table session_connection_closed_reason_enm
+    add constraint only_predefined_session_connection_closed_reasons_allowed
+      check (
+        name in (
+          'unknown',
+          'timed out',
+          'closed by end-user',
+          'canceled',
+          'network error',
+          'system error',
+          'idle timeout'
+        )
+      );
+
+  insert into session_connection_closed_reason_enm (name)
+  values
+    ('idle timeout');
+
+  alter table session_termination_reason_enm
+    drop constraint only_predefined_session_termination_reasons_allowed;
+  alter table session_termination_reason_enm
+    add constraint only_predefined_session_termination_reasons_allowed
+      check (
+        name in (
+          'unknown',
+          'timed out',
+          'closed by end-user',
+          'terminated',
+          'network error',
+          'system error',
+          'connection limit',
+          'canceled',
+          'idle timeout'
+        )
+      );
+
+  insert into session_termination_reason_enm (name)
+  values
+    ('idle timeout');
+
+commit;
@@ -0,0 +1,8 @@
+begin;
+
+  alter table target_ssh
+    drop column host_keys,
+    drop column host_ca_keys,
+    drop column insecure_ignore_host_key;
+
+commit;
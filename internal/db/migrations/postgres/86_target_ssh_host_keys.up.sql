@@ -0,0 +1,16 @@
+begin;
+
+  -- host_keys and host_ca_keys hold public keys, one per line in
+  -- authorized_keys format, which the worker checks the host key of the
+  -- endpoint of an ssh target against. When neither is set the worker refuses
+  -- to connect to the endpoint unless insecure_ignore_host_key is true.
+  alter table target_ssh
+    add column host_keys text
+      constraint host_keys_must_not_be_empty
+      check(length(trim(host_keys)) > 0),
+    add column host_ca_keys text
+      constraint host_ca_keys_must_not_be_empty
+      check(length(trim(host_ca_keys)) > 0),
+    add column insecure_ignore_host_key boolean not null default false;
+
+commit;
@@ -0,0 +1,5 @@
+begin;
+
+  drop table auth_password_successful_attempt;
+
+commit;
@@ -0,0 +1,18 @@
+begin;
+
+/*
+  auth_password_successful_attempt holds the time of the last successful
+  authentication of a password account. Failed attempts made before it do not
+  count towards the lockout threshold of the auth method, so only consecutive
+  failures lock an account.
+*/
+
+  create table auth_password_successful_attempt (
+    password_account_id wt_public_id primary key
+      references auth_password_account (public_id)
+      on delete cascade
+      on update cascade,
+    success_time wt_timestamp
+  );
+
+commit;
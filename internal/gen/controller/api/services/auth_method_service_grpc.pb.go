@@ -45,6 +45,21 @@ type AuthMethodServiceClient interface {
 	DeleteAuthMethod(ctx context.Context, in *DeleteAuthMethodRequest, opts ...grpc.CallOption) (*DeleteAuthMethodResponse, error)
 	// Authenticate validates credentials provided and returns an Auth Token.
 	Authenticate(ctx context.Context, in *AuthenticateRequest, opts ...grpc.CallOption) (*AuthenticateResponse, error)
+	// AuthenticateStart begins an authentication attempt with an auth method
+	// which delegates authentication to an external provider, such as an OIDC
+	// auth method. The response contains the URL the user should visit to
+	// authenticate and a token id which is used with AuthenticateToken to
+	// retrieve the resulting auth token.
+	AuthenticateStart(ctx context.Context, in *AuthenticateStartRequest, opts ...grpc.CallOption) (*AuthenticateStartResponse, error)
+	// AuthenticateCallback receives the redirect from the external provider
+	// after the user has authenticated. On success an auth token is issued
+	// which can be retrieved with AuthenticateToken.
+	AuthenticateCallback(ctx context.Context, in *AuthenticateCallbackRequest, opts ...grpc.CallOption) (*AuthenticateCallbackResponse, error)
+	// AuthenticateToken returns the auth token issued for an authentication
+	// attempt started with AuthenticateStart. If the attempt has not yet been
+	// completed the response does not contain an item and the caller should
+	// retry.
+	AuthenticateToken(ctx context.Context, in *AuthenticateTokenRequest, opts ...grpc.CallOption) (*AuthenticateResponse, error)
 }
 
 type authMethodServiceClient struct {
@@ -109,6 +124,33 @@ func (c *authMethodServiceClient) Authenticate(ctx context.Context, in *Authenti
 	return out, nil
 }
 
+func (c *authMethodServiceClient) AuthenticateStart(ctx context.Context, in *AuthenticateStartRequest, opts ...grpc.CallOption) (*AuthenticateStartResponse, error) {
+	out := new(AuthenticateStartResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.AuthMethodService/AuthenticateStart", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authMethodServiceClient) AuthenticateCallback(ctx context.Context, in *AuthenticateCallbackRequest, opts ...grpc.CallOption) (*AuthenticateCallbackResponse, error) {
+	out := new(AuthenticateCallbackResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.AuthMethodService/AuthenticateCallback", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authMethodServiceClient) AuthenticateToken(ctx context.Context, in *AuthenticateTokenRequest, opts ...grpc.CallOption) (*AuthenticateResponse, error) {
+	out := new(AuthenticateResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.AuthMethodService/AuthenticateToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthMethodServiceServer is the server API for AuthMethodService service.
 // All implementations must embed UnimplementedAuthMethodServiceServer
 // for forward compatibility
@@ -141,6 +183,21 @@ type AuthMethodServiceServer interface {
 	DeleteAuthMethod(context.Context, *DeleteAuthMethodRequest) (*DeleteAuthMethodResponse, error)
 	// Authenticate validates credentials provided and returns an Auth Token.
 	Authenticate(context.Context, *AuthenticateRequest) (*AuthenticateResponse, error)
+	// AuthenticateStart begins an authentication attempt with an auth method
+	// which delegates authentication to an external provider, such as an OIDC
+	// auth method. The response contains the URL the user should visit to
+	// authenticate and a token id which is used with AuthenticateToken to
+	// retrieve the resulting auth token.
+	AuthenticateStart(context.Context, *AuthenticateStartRequest) (*AuthenticateStartResponse, error)
+	// AuthenticateCallback receives the redirect from the external provider
+	// after the user has authenticated. On success an auth token is issued
+	// which can be retrieved with AuthenticateToken.
+	AuthenticateCallback(context.Context, *AuthenticateCallbackRequest) (*AuthenticateCallbackResponse, error)
+	// AuthenticateToken returns the auth token issued for an authentication
+	// attempt started with AuthenticateStart. If the attempt has not yet been
+	// completed the response does not contain an item and the caller should
+	// retry.
+	AuthenticateToken(context.Context, *AuthenticateTokenRequest) (*AuthenticateResponse, error)
 	mustEmbedUnimplementedAuthMethodServiceServer()
 }
 
@@ -166,6 +223,15 @@ func (UnimplementedAuthMethodServiceServer) DeleteAuthMethod(context.Context, *D
 func (UnimplementedAuthMethodServiceServer) Authenticate(context.Context, *AuthenticateRequest) (*AuthenticateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Authenticate not implemented")
 }
+func (UnimplementedAuthMethodServiceServer) AuthenticateStart(context.Context, *AuthenticateStartRequest) (*AuthenticateStartResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthenticateStart not implemented")
+}
+func (UnimplementedAuthMethodServiceServer) AuthenticateCallback(context.Context, *AuthenticateCallbackRequest) (*AuthenticateCallbackResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthenticateCallback not implemented")
+}
+func (UnimplementedAuthMethodServiceServer) AuthenticateToken(context.Context, *AuthenticateTokenRequest) (*AuthenticateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthenticateToken not implemented")
+}
 func (UnimplementedAuthMethodServiceServer) mustEmbedUnimplementedAuthMethodServiceServer() {}
 
 // UnsafeAuthMethodServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -287,6 +353,60 @@ func _AuthMethodService_Authenticate_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthMethodService_AuthenticateStart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthenticateStartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthMethodServiceServer).AuthenticateStart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.AuthMethodService/AuthenticateStart",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthMethodServiceServer).AuthenticateStart(ctx, req.(*AuthenticateStartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthMethodService_AuthenticateCallback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthenticateCallbackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthMethodServiceServer).AuthenticateCallback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.AuthMethodService/AuthenticateCallback",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthMethodServiceServer).AuthenticateCallback(ctx, req.(*AuthenticateCallbackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthMethodService_AuthenticateToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthenticateTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthMethodServiceServer).AuthenticateToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.AuthMethodService/AuthenticateToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthMethodServiceServer).AuthenticateToken(ctx, req.(*AuthenticateTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _AuthMethodService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "controller.api.services.v1.AuthMethodService",
 	HandlerType: (*AuthMethodServiceServer)(nil),
@@ -315,6 +435,18 @@ var _AuthMethodService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Authenticate",
 			Handler:    _AuthMethodService_Authenticate_Handler,
 		},
+		{
+			MethodName: "AuthenticateStart",
+			Handler:    _AuthMethodService_AuthenticateStart_Handler,
+		},
+		{
+			MethodName: "AuthenticateCallback",
+			Handler:    _AuthMethodService_AuthenticateCallback_Handler,
+		},
+		{
+			MethodName: "AuthenticateToken",
+			Handler:    _AuthMethodService_AuthenticateToken_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/auth_method_service.proto",
@@ -78,6 +78,14 @@ type RoleServiceClient interface {
 	// grants will be removed. If missing, malformed, or references a non-existing
 	// resource, an error is returned.
 	RemoveRoleGrants(ctx context.Context, in *RemoveRoleGrantsRequest, opts ...grpc.CallOption) (*RemoveRoleGrantsResponse, error)
+	// ExplainAuthorization explains whether the grants of a user allow an action
+	// on a resource. It returns each grant of the user's roles that applies to
+	// the resource's scope, whether it matched and which one determined the
+	// result. The request must include the ID of the scope containing the
+	// resource, the user ID, resource ID and action. If any ID is malformed or
+	// references a non-existing resource, or the resource is not in the scope,
+	// an error is returned.
+	ExplainAuthorization(ctx context.Context, in *ExplainAuthorizationRequest, opts ...grpc.CallOption) (*ExplainAuthorizationResponse, error)
 }
 
 type roleServiceClient struct {
@@ -187,6 +195,15 @@ func (c *roleServiceClient) RemoveRoleGrants(ctx context.Context, in *RemoveRole
 	return out, nil
 }
 
+func (c *roleServiceClient) ExplainAuthorization(ctx context.Context, in *ExplainAuthorizationRequest, opts ...grpc.CallOption) (*ExplainAuthorizationResponse, error) {
+	out := new(ExplainAuthorizationResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.RoleService/ExplainAuthorization", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RoleServiceServer is the server API for RoleService service.
 // All implementations must embed UnimplementedRoleServiceServer
 // for forward compatibility
@@ -252,6 +269,14 @@ type RoleServiceServer interface {
 	// grants will be removed. If missing, malformed, or references a non-existing
 	// resource, an error is returned.
 	RemoveRoleGrants(context.Context, *RemoveRoleGrantsRequest) (*RemoveRoleGrantsResponse, error)
+	// ExplainAuthorization explains whether the grants of a user allow an action
+	// on a resource. It returns each grant of the user's roles that applies to
+	// the resource's scope, whether it matched and which one determined the
+	// result. The request must include the ID of the scope containing the
+	// resource, the user ID, resource ID and action. If any ID is malformed or
+	// references a non-existing resource, or the resource is not in the scope,
+	// an error is returned.
+	ExplainAuthorization(context.Context, *ExplainAuthorizationRequest) (*ExplainAuthorizationResponse, error)
 	mustEmbedUnimplementedRoleServiceServer()
 }
 
@@ -292,6 +317,9 @@ func (UnimplementedRoleServiceServer) SetRoleGrants(context.Context, *SetRoleGra
 func (UnimplementedRoleServiceServer) RemoveRoleGrants(context.Context, *RemoveRoleGrantsRequest) (*RemoveRoleGrantsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveRoleGrants not implemented")
 }
+func (UnimplementedRoleServiceServer) ExplainAuthorization(context.Context, *ExplainAuthorizationRequest) (*ExplainAuthorizationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExplainAuthorization not implemented")
+}
 func (UnimplementedRoleServiceServer) mustEmbedUnimplementedRoleServiceServer() {}
 
 // UnsafeRoleServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -503,6 +531,24 @@ func _RoleService_RemoveRoleGrants_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RoleService_ExplainAuthorization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainAuthorizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServiceServer).ExplainAuthorization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.RoleService/ExplainAuthorization",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServiceServer).ExplainAuthorization(ctx, req.(*ExplainAuthorizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _RoleService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "controller.api.services.v1.RoleService",
 	HandlerType: (*RoleServiceServer)(nil),
@@ -551,6 +597,10 @@ var _RoleService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveRoleGrants",
 			Handler:    _RoleService_RemoveRoleGrants_Handler,
 		},
+		{
+			MethodName: "ExplainAuthorization",
+			Handler:    _RoleService_ExplainAuthorization_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/role_service.proto",
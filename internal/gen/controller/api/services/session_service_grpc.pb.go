@@ -31,6 +31,15 @@ type SessionServiceClient interface {
 	// is returned if the request attempts to cancel a Session that does
 	// not exist.
 	CancelSession(ctx context.Context, in *CancelSessionRequest, opts ...grpc.CallOption) (*CancelSessionResponse, error)
+	// TerminateSessionConnection closes a single connection of a Session,
+	// leaving the Session and its other connections open. The worker handling
+	// the connection is told to close it immediately. An error is returned if
+	// the connection does not belong to the Session or is already closed.
+	TerminateSessionConnection(ctx context.Context, in *TerminateSessionConnectionRequest, opts ...grpc.CallOption) (*TerminateSessionConnectionResponse, error)
+	// DownloadSessionRecording returns the recordings of a Session. Sessions
+	// of ssh targets record the terminal I/O of each channel opened on their
+	// connections. An empty list is returned if nothing was recorded.
+	DownloadSessionRecording(ctx context.Context, in *DownloadSessionRecordingRequest, opts ...grpc.CallOption) (*DownloadSessionRecordingResponse, error)
 }
 
 type sessionServiceClient struct {
@@ -68,6 +77,24 @@ func (c *sessionServiceClient) CancelSession(ctx context.Context, in *CancelSess
 	return out, nil
 }
 
+func (c *sessionServiceClient) TerminateSessionConnection(ctx context.Context, in *TerminateSessionConnectionRequest, opts ...grpc.CallOption) (*TerminateSessionConnectionResponse, error) {
+	out := new(TerminateSessionConnectionResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.SessionService/TerminateSessionConnection", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionServiceClient) DownloadSessionRecording(ctx context.Context, in *DownloadSessionRecordingRequest, opts ...grpc.CallOption) (*DownloadSessionRecordingResponse, error) {
+	out := new(DownloadSessionRecordingResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.SessionService/DownloadSessionRecording", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SessionServiceServer is the server API for SessionService service.
 // All implementations must embed UnimplementedSessionServiceServer
 // for forward compatibility
@@ -86,6 +113,15 @@ type SessionServiceServer interface {
 	// is returned if the request attempts to cancel a Session that does
 	// not exist.
 	CancelSession(context.Context, *CancelSessionRequest) (*CancelSessionResponse, error)
+	// TerminateSessionConnection closes a single connection of a Session,
+	// leaving the Session and its other connections open. The worker handling
+	// the connection is told to close it immediately. An error is returned if
+	// the connection does not belong to the Session or is already closed.
+	TerminateSessionConnection(context.Context, *TerminateSessionConnectionRequest) (*TerminateSessionConnectionResponse, error)
+	// DownloadSessionRecording returns the recordings of a Session. Sessions
+	// of ssh targets record the terminal I/O of each channel opened on their
+	// connections. An empty list is returned if nothing was recorded.
+	DownloadSessionRecording(context.Context, *DownloadSessionRecordingRequest) (*DownloadSessionRecordingResponse, error)
 	mustEmbedUnimplementedSessionServiceServer()
 }
 
@@ -102,6 +138,12 @@ func (UnimplementedSessionServiceServer) ListSessions(context.Context, *ListSess
 func (UnimplementedSessionServiceServer) CancelSession(context.Context, *CancelSessionRequest) (*CancelSessionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelSession not implemented")
 }
+func (UnimplementedSessionServiceServer) TerminateSessionConnection(context.Context, *TerminateSessionConnectionRequest) (*TerminateSessionConnectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TerminateSessionConnection not implemented")
+}
+func (UnimplementedSessionServiceServer) DownloadSessionRecording(context.Context, *DownloadSessionRecordingRequest) (*DownloadSessionRecordingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DownloadSessionRecording not implemented")
+}
 func (UnimplementedSessionServiceServer) mustEmbedUnimplementedSessionServiceServer() {}
 
 // UnsafeSessionServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -169,6 +211,42 @@ func _SessionService_CancelSession_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SessionService_TerminateSessionConnection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TerminateSessionConnectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).TerminateSessionConnection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.SessionService/TerminateSessionConnection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).TerminateSessionConnection(ctx, req.(*TerminateSessionConnectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionService_DownloadSessionRecording_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DownloadSessionRecordingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).DownloadSessionRecording(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.SessionService/DownloadSessionRecording",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).DownloadSessionRecording(ctx, req.(*DownloadSessionRecordingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _SessionService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "controller.api.services.v1.SessionService",
 	HandlerType: (*SessionServiceServer)(nil),
@@ -185,6 +263,14 @@ var _SessionService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CancelSession",
 			Handler:    _SessionService_CancelSession_Handler,
 		},
+		{
+			MethodName: "TerminateSessionConnection",
+			Handler:    _SessionService_TerminateSessionConnection_Handler,
+		},
+		{
+			MethodName: "DownloadSessionRecording",
+			Handler:    _SessionService_DownloadSessionRecording_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/session_service.proto",
@@ -70,6 +70,27 @@ type TargetServiceClient interface {
 	// returned.  An error is returned if a Host Set is attempted to be
 	// removed from the Target when the Target does not have the Host Set.
 	RemoveTargetHostSets(ctx context.Context, in *RemoveTargetHostSetsRequest, opts ...grpc.CallOption) (*RemoveTargetHostSetsResponse, error)
+	// AddTargetCredentials adds Credentials to this Target. The provided request
+	// must include the Target ID to which the Credentials will be added. All
+	// Credentials added to the provided Target must be a child of a Credential
+	// Store that is in the same scope as this Target. If the Target or
+	// Credential IDs are missing, malformed, or reference non-existing
+	// resources, an error is returned. An error is returned if a Credential is
+	// already present on the Target.
+	AddTargetCredentials(ctx context.Context, in *AddTargetCredentialsRequest, opts ...grpc.CallOption) (*AddTargetCredentialsResponse, error)
+	// SetTargetCredentials sets the Target's Credentials. Any existing
+	// Credentials on the Target are removed if they are not included in this
+	// request. All Credentials in the request must be a child of a Credential
+	// Store that is in the same scope as the provided Target. If any IDs are
+	// missing, malformed, or reference a non-existing resource, an error is
+	// returned.
+	SetTargetCredentials(ctx context.Context, in *SetTargetCredentialsRequest, opts ...grpc.CallOption) (*SetTargetCredentialsResponse, error)
+	// RemoveTargetCredentials removes the Credentials from the specified Target.
+	// The provided request must include the Target ID from which the
+	// Credentials will be removed. An error is returned if a Credential is
+	// attempted to be removed from the Target when the Target does not have
+	// the Credential.
+	RemoveTargetCredentials(ctx context.Context, in *RemoveTargetCredentialsRequest, opts ...grpc.CallOption) (*RemoveTargetCredentialsResponse, error)
 }
 
 type targetServiceClient struct {
@@ -161,6 +182,33 @@ func (c *targetServiceClient) RemoveTargetHostSets(ctx context.Context, in *Remo
 	return out, nil
 }
 
+func (c *targetServiceClient) AddTargetCredentials(ctx context.Context, in *AddTargetCredentialsRequest, opts ...grpc.CallOption) (*AddTargetCredentialsResponse, error) {
+	out := new(AddTargetCredentialsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.TargetService/AddTargetCredentials", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *targetServiceClient) SetTargetCredentials(ctx context.Context, in *SetTargetCredentialsRequest, opts ...grpc.CallOption) (*SetTargetCredentialsResponse, error) {
+	out := new(SetTargetCredentialsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.TargetService/SetTargetCredentials", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *targetServiceClient) RemoveTargetCredentials(ctx context.Context, in *RemoveTargetCredentialsRequest, opts ...grpc.CallOption) (*RemoveTargetCredentialsResponse, error) {
+	out := new(RemoveTargetCredentialsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.TargetService/RemoveTargetCredentials", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TargetServiceServer is the server API for TargetService service.
 // All implementations must embed UnimplementedTargetServiceServer
 // for forward compatibility
@@ -218,6 +266,27 @@ type TargetServiceServer interface {
 	// returned.  An error is returned if a Host Set is attempted to be
 	// removed from the Target when the Target does not have the Host Set.
 	RemoveTargetHostSets(context.Context, *RemoveTargetHostSetsRequest) (*RemoveTargetHostSetsResponse, error)
+	// AddTargetCredentials adds Credentials to this Target. The provided request
+	// must include the Target ID to which the Credentials will be added. All
+	// Credentials added to the provided Target must be a child of a Credential
+	// Store that is in the same scope as this Target. If the Target or
+	// Credential IDs are missing, malformed, or reference non-existing
+	// resources, an error is returned. An error is returned if a Credential is
+	// already present on the Target.
+	AddTargetCredentials(context.Context, *AddTargetCredentialsRequest) (*AddTargetCredentialsResponse, error)
+	// SetTargetCredentials sets the Target's Credentials. Any existing
+	// Credentials on the Target are removed if they are not included in this
+	// request. All Credentials in the request must be a child of a Credential
+	// Store that is in the same scope as the provided Target. If any IDs are
+	// missing, malformed, or reference a non-existing resource, an error is
+	// returned.
+	SetTargetCredentials(context.Context, *SetTargetCredentialsRequest) (*SetTargetCredentialsResponse, error)
+	// RemoveTargetCredentials removes the Credentials from the specified Target.
+	// The provided request must include the Target ID from which the
+	// Credentials will be removed. An error is returned if a Credential is
+	// attempted to be removed from the Target when the Target does not have
+	// the Credential.
+	RemoveTargetCredentials(context.Context, *RemoveTargetCredentialsRequest) (*RemoveTargetCredentialsResponse, error)
 	mustEmbedUnimplementedTargetServiceServer()
 }
 
@@ -252,6 +321,15 @@ func (UnimplementedTargetServiceServer) SetTargetHostSets(context.Context, *SetT
 func (UnimplementedTargetServiceServer) RemoveTargetHostSets(context.Context, *RemoveTargetHostSetsRequest) (*RemoveTargetHostSetsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveTargetHostSets not implemented")
 }
+func (UnimplementedTargetServiceServer) AddTargetCredentials(context.Context, *AddTargetCredentialsRequest) (*AddTargetCredentialsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTargetCredentials not implemented")
+}
+func (UnimplementedTargetServiceServer) SetTargetCredentials(context.Context, *SetTargetCredentialsRequest) (*SetTargetCredentialsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTargetCredentials not implemented")
+}
+func (UnimplementedTargetServiceServer) RemoveTargetCredentials(context.Context, *RemoveTargetCredentialsRequest) (*RemoveTargetCredentialsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTargetCredentials not implemented")
+}
 func (UnimplementedTargetServiceServer) mustEmbedUnimplementedTargetServiceServer() {}
 
 // UnsafeTargetServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -427,6 +505,60 @@ func _TargetService_RemoveTargetHostSets_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TargetService_AddTargetCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTargetCredentialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TargetServiceServer).AddTargetCredentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.TargetService/AddTargetCredentials",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TargetServiceServer).AddTargetCredentials(ctx, req.(*AddTargetCredentialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TargetService_SetTargetCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTargetCredentialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TargetServiceServer).SetTargetCredentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.TargetService/SetTargetCredentials",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TargetServiceServer).SetTargetCredentials(ctx, req.(*SetTargetCredentialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TargetService_RemoveTargetCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTargetCredentialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TargetServiceServer).RemoveTargetCredentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.TargetService/RemoveTargetCredentials",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TargetServiceServer).RemoveTargetCredentials(ctx, req.(*RemoveTargetCredentialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _TargetService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "controller.api.services.v1.TargetService",
 	HandlerType: (*TargetServiceServer)(nil),
@@ -467,6 +599,18 @@ var _TargetService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveTargetHostSets",
 			Handler:    _TargetService_RemoveTargetHostSets_Handler,
 		},
+		{
+			MethodName: "AddTargetCredentials",
+			Handler:    _TargetService_AddTargetCredentials_Handler,
+		},
+		{
+			MethodName: "SetTargetCredentials",
+			Handler:    _TargetService_SetTargetCredentials_Handler,
+		},
+		{
+			MethodName: "RemoveTargetCredentials",
+			Handler:    _TargetService_RemoveTargetCredentials_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/target_service.proto",
@@ -21,6 +21,11 @@ type ServerCoordinationServiceClient interface {
 	// returns the status response which includes the changes the controller would like to make to
 	// jobs as well as provide a list of the controllers in the system.
 	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	// JobChanges streams the job change requests the controller makes of a
+	// worker as soon as they are made, such as the termination of a connection
+	// requested through the API. Requests made while the worker has no stream
+	// open to the controller are returned in the response to its next status.
+	JobChanges(ctx context.Context, in *JobChangesRequest, opts ...grpc.CallOption) (ServerCoordinationService_JobChangesClient, error)
 }
 
 type serverCoordinationServiceClient struct {
@@ -40,6 +45,38 @@ func (c *serverCoordinationServiceClient) Status(ctx context.Context, in *Status
 	return out, nil
 }
 
+func (c *serverCoordinationServiceClient) JobChanges(ctx context.Context, in *JobChangesRequest, opts ...grpc.CallOption) (ServerCoordinationService_JobChangesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ServerCoordinationService_serviceDesc.Streams[0], "/controller.servers.services.v1.ServerCoordinationService/JobChanges", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serverCoordinationServiceJobChangesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ServerCoordinationService_JobChangesClient interface {
+	Recv() (*JobChangeRequest, error)
+	grpc.ClientStream
+}
+
+type serverCoordinationServiceJobChangesClient struct {
+	grpc.ClientStream
+}
+
+func (x *serverCoordinationServiceJobChangesClient) Recv() (*JobChangeRequest, error) {
+	m := new(JobChangeRequest)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ServerCoordinationServiceServer is the server API for ServerCoordinationService service.
 // All implementations must embed UnimplementedServerCoordinationServiceServer
 // for forward compatibility
@@ -48,6 +85,11 @@ type ServerCoordinationServiceServer interface {
 	// returns the status response which includes the changes the controller would like to make to
 	// jobs as well as provide a list of the controllers in the system.
 	Status(context.Context, *StatusRequest) (*StatusResponse, error)
+	// JobChanges streams the job change requests the controller makes of a
+	// worker as soon as they are made, such as the termination of a connection
+	// requested through the API. Requests made while the worker has no stream
+	// open to the controller are returned in the response to its next status.
+	JobChanges(*JobChangesRequest, ServerCoordinationService_JobChangesServer) error
 	mustEmbedUnimplementedServerCoordinationServiceServer()
 }
 
@@ -58,6 +100,9 @@ type UnimplementedServerCoordinationServiceServer struct {
 func (UnimplementedServerCoordinationServiceServer) Status(context.Context, *StatusRequest) (*StatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
 }
+func (UnimplementedServerCoordinationServiceServer) JobChanges(*JobChangesRequest, ServerCoordinationService_JobChangesServer) error {
+	return status.Errorf(codes.Unimplemented, "method JobChanges not implemented")
+}
 func (UnimplementedServerCoordinationServiceServer) mustEmbedUnimplementedServerCoordinationServiceServer() {
 }
 
@@ -90,6 +135,27 @@ func _ServerCoordinationService_Status_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ServerCoordinationService_JobChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(JobChangesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServerCoordinationServiceServer).JobChanges(m, &serverCoordinationServiceJobChangesServer{stream})
+}
+
+type ServerCoordinationService_JobChangesServer interface {
+	Send(*JobChangeRequest) error
+	grpc.ServerStream
+}
+
+type serverCoordinationServiceJobChangesServer struct {
+	grpc.ServerStream
+}
+
+func (x *serverCoordinationServiceJobChangesServer) Send(m *JobChangeRequest) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _ServerCoordinationService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "controller.servers.services.v1.ServerCoordinationService",
 	HandlerType: (*ServerCoordinationServiceServer)(nil),
@@ -99,6 +165,12 @@ var _ServerCoordinationService_serviceDesc = grpc.ServiceDesc{
 			Handler:    _ServerCoordinationService_Status_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "JobChanges",
+			Handler:       _ServerCoordinationService_JobChanges_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "controller/servers/services/v1/server_coordination_service.proto",
 }
@@ -28,6 +28,13 @@ type SessionServiceClient interface {
 	ConnectConnection(ctx context.Context, in *ConnectConnectionRequest, opts ...grpc.CallOption) (*ConnectConnectionResponse, error)
 	// CloseConnections updates a connection to set it to closed
 	CloseConnection(ctx context.Context, in *CloseConnectionRequest, opts ...grpc.CallOption) (*CloseConnectionResponse, error)
+	// RecordHttpRequest records a request proxied by a worker on a connection
+	// to an http target
+	RecordHttpRequest(ctx context.Context, in *RecordHttpRequestRequest, opts ...grpc.CallOption) (*RecordHttpRequestResponse, error)
+	// UploadSessionRecording stores the recording of a channel of a connection
+	// to an ssh target. The first message carries the recording's metadata and
+	// every message may carry a chunk of its data.
+	UploadSessionRecording(ctx context.Context, opts ...grpc.CallOption) (SessionService_UploadSessionRecordingClient, error)
 }
 
 type sessionServiceClient struct {
@@ -83,6 +90,49 @@ func (c *sessionServiceClient) CloseConnection(ctx context.Context, in *CloseCon
 	return out, nil
 }
 
+func (c *sessionServiceClient) RecordHttpRequest(ctx context.Context, in *RecordHttpRequestRequest, opts ...grpc.CallOption) (*RecordHttpRequestResponse, error) {
+	out := new(RecordHttpRequestResponse)
+	err := c.cc.Invoke(ctx, "/controller.servers.services.v1.SessionService/RecordHttpRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionServiceClient) UploadSessionRecording(ctx context.Context, opts ...grpc.CallOption) (SessionService_UploadSessionRecordingClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SessionService_serviceDesc.Streams[0], "/controller.servers.services.v1.SessionService/UploadSessionRecording", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sessionServiceUploadSessionRecordingClient{stream}
+	return x, nil
+}
+
+type SessionService_UploadSessionRecordingClient interface {
+	Send(*UploadSessionRecordingRequest) error
+	CloseAndRecv() (*UploadSessionRecordingResponse, error)
+	grpc.ClientStream
+}
+
+type sessionServiceUploadSessionRecordingClient struct {
+	grpc.ClientStream
+}
+
+func (x *sessionServiceUploadSessionRecordingClient) Send(m *UploadSessionRecordingRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *sessionServiceUploadSessionRecordingClient) CloseAndRecv() (*UploadSessionRecordingResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadSessionRecordingResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // SessionServiceServer is the server API for SessionService service.
 // All implementations must embed UnimplementedSessionServiceServer
 // for forward compatibility
@@ -98,6 +148,13 @@ type SessionServiceServer interface {
 	ConnectConnection(context.Context, *ConnectConnectionRequest) (*ConnectConnectionResponse, error)
 	// CloseConnections updates a connection to set it to closed
 	CloseConnection(context.Context, *CloseConnectionRequest) (*CloseConnectionResponse, error)
+	// RecordHttpRequest records a request proxied by a worker on a connection
+	// to an http target
+	RecordHttpRequest(context.Context, *RecordHttpRequestRequest) (*RecordHttpRequestResponse, error)
+	// UploadSessionRecording stores the recording of a channel of a connection
+	// to an ssh target. The first message carries the recording's metadata and
+	// every message may carry a chunk of its data.
+	UploadSessionRecording(SessionService_UploadSessionRecordingServer) error
 	mustEmbedUnimplementedSessionServiceServer()
 }
 
@@ -120,6 +177,12 @@ func (UnimplementedSessionServiceServer) ConnectConnection(context.Context, *Con
 func (UnimplementedSessionServiceServer) CloseConnection(context.Context, *CloseConnectionRequest) (*CloseConnectionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CloseConnection not implemented")
 }
+func (UnimplementedSessionServiceServer) RecordHttpRequest(context.Context, *RecordHttpRequestRequest) (*RecordHttpRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordHttpRequest not implemented")
+}
+func (UnimplementedSessionServiceServer) UploadSessionRecording(SessionService_UploadSessionRecordingServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadSessionRecording not implemented")
+}
 func (UnimplementedSessionServiceServer) mustEmbedUnimplementedSessionServiceServer() {}
 
 // UnsafeSessionServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -223,6 +286,50 @@ func _SessionService_CloseConnection_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SessionService_RecordHttpRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordHttpRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).RecordHttpRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.servers.services.v1.SessionService/RecordHttpRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).RecordHttpRequest(ctx, req.(*RecordHttpRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionService_UploadSessionRecording_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SessionServiceServer).UploadSessionRecording(&sessionServiceUploadSessionRecordingServer{stream})
+}
+
+type SessionService_UploadSessionRecordingServer interface {
+	SendAndClose(*UploadSessionRecordingResponse) error
+	Recv() (*UploadSessionRecordingRequest, error)
+	grpc.ServerStream
+}
+
+type sessionServiceUploadSessionRecordingServer struct {
+	grpc.ServerStream
+}
+
+func (x *sessionServiceUploadSessionRecordingServer) SendAndClose(m *UploadSessionRecordingResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *sessionServiceUploadSessionRecordingServer) Recv() (*UploadSessionRecordingRequest, error) {
+	m := new(UploadSessionRecordingRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 var _SessionService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "controller.servers.services.v1.SessionService",
 	HandlerType: (*SessionServiceServer)(nil),
@@ -247,7 +354,17 @@ var _SessionService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CloseConnection",
 			Handler:    _SessionService_CloseConnection_Handler,
 		},
+		{
+			MethodName: "RecordHttpRequest",
+			Handler:    _SessionService_RecordHttpRequest_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadSessionRecording",
+			Handler:       _SessionService_UploadSessionRecording_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/servers/services/v1/session_service.proto",
 }
@@ -0,0 +1,40 @@
+// Package plugin provides a host, a host catalog, and a host set suitable
+// for hosts which are discovered from an external inventory.
+//
+// A host catalog is associated with a HostPlugin which is used to query
+// the external inventory. The attributes of the host catalog are passed to
+// the plugin and describe the inventory, for example the path to a file or
+// the credentials for a cloud provider. A host set contains a filter which
+// is passed to the plugin to select the hosts which are members of the
+// set.
+//
+// The hosts and set members of a plugin host catalog are not managed
+// directly. They are a cache of the external inventory which is refreshed
+// by calling SyncSet or, when the cache is older than the sync interval of
+// the repository, by calling ListSetMembers. Hosts are identified by the
+// id assigned to them by the external inventory so a host keeps the same
+// public id across refreshes. Hosts which are no longer a member of any
+// host set in the catalog are deleted.
+//
+// Repository
+//
+// A repository provides methods for creating, updating, retrieving, and
+// deleting host catalogs and host sets and for retrieving the hosts in a
+// host set. A new repository should be created for each transaction. For
+// example:
+//
+//  var wrapper wrapping.Wrapper
+//  ... init wrapper...
+//
+//  // db implements both the reader and writer interfaces.
+//  db, _ := db.Open(db.Postgres, url)
+//
+//  plugins := map[string]plugin.HostPlugin{
+//  	file.PluginName: file.New(),
+//  }
+//
+//  var repo *plugin.Repository
+//
+//  repo, _ = plugin.NewRepository(db, db, wrapper, plugins)
+//  hosts, _ := repo.ListSetMembers(ctx, setId)
+package plugin
@@ -0,0 +1,17 @@
+package plugin
+
+import "errors"
+
+var (
+	// ErrUnknownPlugin results from attempting to use a plugin name which
+	// has not been registered with the repository.
+	ErrUnknownPlugin = errors.New("unknown plugin")
+
+	// ErrInvalidAttributes results from attempting to set attributes on a
+	// host catalog which are rejected by the catalog's plugin.
+	ErrInvalidAttributes = errors.New("invalid attributes")
+
+	// ErrInvalidFilter results from attempting to set a filter on a host
+	// set which is rejected by the catalog's plugin.
+	ErrInvalidFilter = errors.New("invalid filter")
+)
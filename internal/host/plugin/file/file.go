@@ -0,0 +1,135 @@
+// Package file provides a reference HostPlugin which reads the hosts of a
+// host catalog from a JSON file on the controller.
+//
+// The path to the file is set in the "path" attribute of the host catalog.
+// The file contains an array of hosts:
+//
+//  [
+//    {
+//      "id": "i-0123456789",
+//      "name": "web-1",
+//      "address": "10.0.0.1",
+//      "tags": {
+//        "env": ["prod"],
+//        "role": ["web", "api"]
+//      }
+//    }
+//  ]
+//
+// The filter of a host set is a boolean expression evaluated against each
+// host using the same syntax as worker filters, for example:
+//
+//  "prod" in "/tags/env" and "web" in "/tags/role"
+package file
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/hashicorp/boundary/internal/host/plugin"
+	"github.com/hashicorp/go-bexpr"
+	"github.com/mitchellh/pointerstructure"
+)
+
+// PluginName is the name the plugin is registered as in the controller.
+const PluginName = "file"
+
+// Plugin is a HostPlugin which reads hosts from a JSON file.
+type Plugin struct{}
+
+var _ plugin.HostPlugin = (*Plugin)(nil)
+
+// New creates a new Plugin.
+func New() *Plugin {
+	return &Plugin{}
+}
+
+type fileHost struct {
+	Id      string              `json:"id"`
+	Name    string              `json:"name"`
+	Address string              `json:"address"`
+	Tags    map[string][]string `json:"tags"`
+}
+
+// ValidateCatalog checks that attributes contains the path of a readable
+// file.
+func (p *Plugin) ValidateCatalog(_ context.Context, attributes map[string]interface{}) error {
+	path, err := pathFromAttributes(attributes)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("unable to read %q: %w", path, err)
+	}
+	return nil
+}
+
+// ValidateSet checks that filter can be parsed.
+func (p *Plugin) ValidateSet(_ context.Context, filter string) error {
+	if _, err := bexpr.CreateEvaluator(filter); err != nil {
+		return fmt.Errorf("unable to parse filter: %w", err)
+	}
+	return nil
+}
+
+// ListHosts returns the hosts in the file set in attributes which match
+// filter.
+func (p *Plugin) ListHosts(_ context.Context, attributes map[string]interface{}, filter string) ([]*plugin.HostInfo, error) {
+	path, err := pathFromAttributes(attributes)
+	if err != nil {
+		return nil, err
+	}
+	eval, err := bexpr.CreateEvaluator(filter)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse filter: %w", err)
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %q: %w", path, err)
+	}
+	var fileHosts []*fileHost
+	if err := json.Unmarshal(b, &fileHosts); err != nil {
+		return nil, fmt.Errorf("unable to parse %q: %w", path, err)
+	}
+
+	var hosts []*plugin.HostInfo
+	for _, h := range fileHosts {
+		if h.Id == "" || h.Address == "" {
+			return nil, fmt.Errorf("host in %q is missing an id or address", path)
+		}
+		ok, err := eval.Evaluate(map[string]interface{}{
+			"id":      h.Id,
+			"name":    h.Name,
+			"address": h.Address,
+			"tags":    h.Tags,
+		})
+		if err != nil && !stderrors.Is(err, pointerstructure.ErrNotFound) {
+			return nil, fmt.Errorf("error evaluating filter: %w", err)
+		}
+		if !ok {
+			continue
+		}
+		hosts = append(hosts, &plugin.HostInfo{
+			ExternalId: h.Id,
+			Name:       h.Name,
+			Address:    h.Address,
+		})
+	}
+	return hosts, nil
+}
+
+func pathFromAttributes(attributes map[string]interface{}) (string, error) {
+	raw, ok := attributes["path"]
+	if !ok {
+		return "", fmt.Errorf("missing path attribute")
+	}
+	path, ok := raw.(string)
+	if !ok || path == "" {
+		return "", fmt.Errorf("path attribute must be a non-empty string")
+	}
+	return path, nil
+}
@@ -0,0 +1,127 @@
+package file
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/host/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testHosts = `[
+  {"id": "i-1", "name": "web-1", "address": "10.0.0.1", "tags": {"env": ["prod"], "role": ["web"]}},
+  {"id": "i-2", "name": "web-2", "address": "10.0.0.2", "tags": {"env": ["dev"], "role": ["web"]}},
+  {"id": "i-3", "address": "10.0.0.3"}
+]`
+
+func testFile(t *testing.T, contents string) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "boundary-host-plugin-file")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, "hosts.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0600))
+	return path
+}
+
+func TestPlugin_ValidateCatalog(t *testing.T) {
+	path := testFile(t, testHosts)
+	tests := []struct {
+		name    string
+		attrs   map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name:  "valid",
+			attrs: map[string]interface{}{"path": path},
+		},
+		{
+			name:    "missing-path",
+			attrs:   map[string]interface{}{},
+			wantErr: true,
+		},
+		{
+			name:    "non-string-path",
+			attrs:   map[string]interface{}{"path": 5},
+			wantErr: true,
+		},
+		{
+			name:    "missing-file",
+			attrs:   map[string]interface{}{"path": path + ".missing"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := New().ValidateCatalog(context.Background(), tt.attrs)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPlugin_ValidateSet(t *testing.T) {
+	p := New()
+	assert.NoError(t, p.ValidateSet(context.Background(), `"prod" in "/tags/env"`))
+	assert.Error(t, p.ValidateSet(context.Background(), `"prod" in`))
+}
+
+func TestPlugin_ListHosts(t *testing.T) {
+	path := testFile(t, testHosts)
+	tests := []struct {
+		name    string
+		filter  string
+		want    []*plugin.HostInfo
+		wantErr bool
+	}{
+		{
+			name:   "by-tag",
+			filter: `"prod" in "/tags/env"`,
+			want: []*plugin.HostInfo{
+				{ExternalId: "i-1", Name: "web-1", Address: "10.0.0.1"},
+			},
+		},
+		{
+			name:   "by-shared-tag",
+			filter: `"web" in "/tags/role"`,
+			want: []*plugin.HostInfo{
+				{ExternalId: "i-1", Name: "web-1", Address: "10.0.0.1"},
+				{ExternalId: "i-2", Name: "web-2", Address: "10.0.0.2"},
+			},
+		},
+		{
+			name:   "by-address",
+			filter: `"/address" == "10.0.0.3"`,
+			want: []*plugin.HostInfo{
+				{ExternalId: "i-3", Address: "10.0.0.3"},
+			},
+		},
+		{
+			name:   "no-match",
+			filter: `"staging" in "/tags/env"`,
+		},
+		{
+			name:    "bad-filter",
+			filter:  `"prod" in`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New().ListHosts(context.Background(), map[string]interface{}{"path": path}, tt.filter)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package plugin
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/host/plugin/store"
+	"google.golang.org/protobuf/proto"
+)
+
+// A Host is a host from the external inventory of a plugin host catalog.
+type Host struct {
+	*store.Host
+	tableName string `gorm:"-"`
+}
+
+// NewHost creates a new in memory Host for the host with externalId in the
+// inventory of catalogId. Name is the only valid option. All other options
+// are ignored.
+func NewHost(catalogId, externalId, address string, opt ...Option) (*Host, error) {
+	if catalogId == "" {
+		return nil, fmt.Errorf("new: plugin host: no catalog id: %w", errors.ErrInvalidParameter)
+	}
+	if externalId == "" {
+		return nil, fmt.Errorf("new: plugin host: no external id: %w", errors.ErrInvalidParameter)
+	}
+	if address == "" {
+		return nil, fmt.Errorf("new: plugin host: no address: %w", errors.ErrInvalidParameter)
+	}
+
+	opts := getOpts(opt...)
+	host := &Host{
+		Host: &store.Host{
+			CatalogId:  catalogId,
+			ExternalId: externalId,
+			Address:    address,
+			Name:       opts.withName,
+		},
+	}
+	return host, nil
+}
+
+// TableName returns the table name for the host.
+func (h *Host) TableName() string {
+	if h.tableName != "" {
+		return h.tableName
+	}
+	return "host_plugin_host"
+}
+
+// SetTableName sets the table name. If the caller attempts to
+// set the name to "" the name will be reset to the default name.
+func (h *Host) SetTableName(n string) {
+	h.tableName = n
+}
+
+func allocHost() *Host {
+	return &Host{
+		Host: &store.Host{},
+	}
+}
+
+func (h *Host) clone() *Host {
+	cp := proto.Clone(h.Host)
+	return &Host{
+		Host: cp.(*store.Host),
+	}
+}
@@ -0,0 +1,121 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/host/plugin/store"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"google.golang.org/protobuf/proto"
+)
+
+// A HostCatalog contains plugin hosts and plugin host sets. It is owned by
+// a scope.
+type HostCatalog struct {
+	*store.HostCatalog
+	tableName string `gorm:"-"`
+}
+
+// NewHostCatalog creates a new in memory HostCatalog assigned to scopeId
+// which uses the plugin registered as pluginName. Name, description, and
+// attributes are the only valid options. All other options are ignored.
+func NewHostCatalog(scopeId, pluginName string, opt ...Option) (*HostCatalog, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("new: plugin host catalog: no scope id: %w", errors.ErrInvalidParameter)
+	}
+	if pluginName == "" {
+		return nil, fmt.Errorf("new: plugin host catalog: no plugin name: %w", errors.ErrInvalidParameter)
+	}
+
+	opts := getOpts(opt...)
+	attrs, err := marshalAttributes(opts.withAttributes)
+	if err != nil {
+		return nil, fmt.Errorf("new: plugin host catalog: %w", err)
+	}
+	hc := &HostCatalog{
+		HostCatalog: &store.HostCatalog{
+			ScopeId:     scopeId,
+			PluginName:  pluginName,
+			Name:        opts.withName,
+			Description: opts.withDescription,
+			Attributes:  attrs,
+		},
+	}
+	return hc, nil
+}
+
+func (c *HostCatalog) clone() *HostCatalog {
+	cp := proto.Clone(c.HostCatalog)
+	return &HostCatalog{
+		HostCatalog: cp.(*store.HostCatalog),
+	}
+}
+
+// TableName returns the table name for the host catalog.
+func (c *HostCatalog) TableName() string {
+	if c.tableName != "" {
+		return c.tableName
+	}
+	return "host_plugin_catalog"
+}
+
+// SetTableName sets the table name. If the caller attempts to
+// set the name to "" the name will be reset to the default name.
+func (c *HostCatalog) SetTableName(n string) {
+	c.tableName = n
+}
+
+// DecodedAttributes returns the attributes of the catalog which are passed
+// to the plugin.
+func (c *HostCatalog) DecodedAttributes() (map[string]interface{}, error) {
+	attrs := make(map[string]interface{})
+	if len(c.GetAttributes()) == 0 {
+		return attrs, nil
+	}
+	if err := json.Unmarshal(c.GetAttributes(), &attrs); err != nil {
+		return nil, fmt.Errorf("unable to decode attributes: %w", err)
+	}
+	return attrs, nil
+}
+
+// SetAttributes sets the attributes of the catalog which are passed to the
+// plugin.
+func (c *HostCatalog) SetAttributes(attrs map[string]interface{}) error {
+	b, err := marshalAttributes(attrs)
+	if err != nil {
+		return err
+	}
+	c.Attributes = b
+	return nil
+}
+
+func marshalAttributes(attrs map[string]interface{}) ([]byte, error) {
+	if attrs == nil {
+		attrs = make(map[string]interface{})
+	}
+	b, err := json.Marshal(attrs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode attributes: %w", err)
+	}
+	return b, nil
+}
+
+func allocCatalog() *HostCatalog {
+	fresh := &HostCatalog{
+		HostCatalog: &store.HostCatalog{},
+	}
+	return fresh
+}
+
+func newCatalogMetadata(c *HostCatalog, op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{c.GetPublicId()},
+		"resource-type":      []string{"plugin host catalog"},
+		"op-type":            []string{op.String()},
+	}
+	if c.ScopeId != "" {
+		metadata["scope-id"] = []string{c.ScopeId}
+	}
+	return metadata
+}
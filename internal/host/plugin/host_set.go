@@ -0,0 +1,100 @@
+package plugin
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/host/plugin/store"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"google.golang.org/protobuf/proto"
+)
+
+// A HostSet is a collection of hosts from the set's catalog which match
+// the set's filter.
+type HostSet struct {
+	*store.HostSet
+	tableName string `gorm:"-"`
+}
+
+// NewHostSet creates a new in memory HostSet assigned to catalogId with
+// filter. Name and description are the only valid options. All other
+// options are ignored.
+func NewHostSet(catalogId, filter string, opt ...Option) (*HostSet, error) {
+	if catalogId == "" {
+		return nil, fmt.Errorf("new: plugin host set: no catalog id: %w", errors.ErrInvalidParameter)
+	}
+	if filter == "" {
+		return nil, fmt.Errorf("new: plugin host set: no filter: %w", errors.ErrInvalidParameter)
+	}
+
+	opts := getOpts(opt...)
+	set := &HostSet{
+		HostSet: &store.HostSet{
+			CatalogId:   catalogId,
+			Filter:      filter,
+			Name:        opts.withName,
+			Description: opts.withDescription,
+		},
+	}
+	return set, nil
+}
+
+// TableName returns the table name for the host set.
+func (s *HostSet) TableName() string {
+	if s.tableName != "" {
+		return s.tableName
+	}
+	return "host_plugin_set"
+}
+
+// SetTableName sets the table name. If the caller attempts to
+// set the name to "" the name will be reset to the default name.
+func (s *HostSet) SetTableName(n string) {
+	s.tableName = n
+}
+
+func allocHostSet() *HostSet {
+	return &HostSet{
+		HostSet: &store.HostSet{},
+	}
+}
+
+func (s *HostSet) clone() *HostSet {
+	cp := proto.Clone(s.HostSet)
+	return &HostSet{
+		HostSet: cp.(*store.HostSet),
+	}
+}
+
+func (s *HostSet) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{s.PublicId},
+		"resource-type":      []string{"plugin-host-set"},
+		"op-type":            []string{op.String()},
+	}
+	if s.CatalogId != "" {
+		metadata["catalog-id"] = []string{s.CatalogId}
+	}
+	return metadata
+}
+
+// hostSetSync records the last time the members of a host set were
+// retrieved from the plugin.
+type hostSetSync struct {
+	*store.HostSetSync
+	tableName string `gorm:"-"`
+}
+
+// TableName returns the table name for the host set sync.
+func (s *hostSetSync) TableName() string {
+	if s.tableName != "" {
+		return s.tableName
+	}
+	return "host_plugin_set_sync"
+}
+
+// SetTableName sets the table name. If the caller attempts to
+// set the name to "" the name will be reset to the default name.
+func (s *hostSetSync) SetTableName(n string) {
+	s.tableName = n
+}
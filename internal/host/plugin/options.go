@@ -0,0 +1,97 @@
+package plugin
+
+import "time"
+
+// getOpts - iterate the inbound Options and return a struct
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments.
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withName             string
+	withDescription      string
+	withLimit            int
+	withStartPageAfterId string
+	withPublicId         string
+	withAttributes       map[string]interface{}
+	withFilter           string
+	withSyncInterval     time.Duration
+}
+
+func getDefaultOptions() options {
+	return options{
+		withDescription: "",
+		withName:        "",
+	}
+}
+
+// WithDescription provides an optional description.
+func WithDescription(desc string) Option {
+	return func(o *options) {
+		o.withDescription = desc
+	}
+}
+
+// WithName provides an optional name.
+func WithName(name string) Option {
+	return func(o *options) {
+		o.withName = name
+	}
+}
+
+// WithLimit provides an option to provide a limit. Intentionally allowing
+// negative integers. If WithLimit < 0, then unlimited results are
+// returned. If WithLimit == 0, then default limits are used for results.
+func WithLimit(l int) Option {
+	return func(o *options) {
+		o.withLimit = l
+	}
+}
+
+// WithStartPageAfterId provides an option to list only the resources whose
+// public ids sort after the given id. Results are ordered by public id when
+// it is used, allowing listing to be resumed where a previous page ended.
+func WithStartPageAfterId(id string) Option {
+	return func(o *options) {
+		o.withStartPageAfterId = id
+	}
+}
+
+// WithPublicId provides an optional public id
+func WithPublicId(id string) Option {
+	return func(o *options) {
+		o.withPublicId = id
+	}
+}
+
+// WithAttributes provides optional attributes which are passed to the
+// plugin of a host catalog.
+func WithAttributes(attrs map[string]interface{}) Option {
+	return func(o *options) {
+		o.withAttributes = attrs
+	}
+}
+
+// WithFilter provides an optional filter for a host set.
+func WithFilter(filter string) Option {
+	return func(o *options) {
+		o.withFilter = filter
+	}
+}
+
+// WithSyncInterval provides an option to set how long the members of a
+// host set are cached before they are retrieved from the plugin again. If
+// WithSyncInterval == 0, then the default interval is used.
+func WithSyncInterval(d time.Duration) Option {
+	return func(o *options) {
+		o.withSyncInterval = d
+	}
+}
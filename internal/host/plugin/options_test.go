@@ -0,0 +1,60 @@
+package plugin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetOpts(t *testing.T) {
+	t.Parallel()
+	t.Run("WithName", func(t *testing.T) {
+		opts := getOpts(WithName("test"))
+		testOpts := getDefaultOptions()
+		testOpts.withName = "test"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithDescription", func(t *testing.T) {
+		opts := getOpts(WithDescription("test desc"))
+		testOpts := getDefaultOptions()
+		testOpts.withDescription = "test desc"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithLimit", func(t *testing.T) {
+		opts := getOpts(WithLimit(5))
+		testOpts := getDefaultOptions()
+		testOpts.withLimit = 5
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithStartPageAfterId", func(t *testing.T) {
+		opts := getOpts(WithStartPageAfterId("hsplg_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withStartPageAfterId = "hsplg_1234567890"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithPublicId", func(t *testing.T) {
+		opts := getOpts(WithPublicId("test"))
+		testOpts := getDefaultOptions()
+		testOpts.withPublicId = "test"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithAttributes", func(t *testing.T) {
+		opts := getOpts(WithAttributes(map[string]interface{}{"path": "/tmp/hosts.json"}))
+		testOpts := getDefaultOptions()
+		testOpts.withAttributes = map[string]interface{}{"path": "/tmp/hosts.json"}
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithFilter", func(t *testing.T) {
+		opts := getOpts(WithFilter(`"prod" in "/tags/env"`))
+		testOpts := getDefaultOptions()
+		testOpts.withFilter = `"prod" in "/tags/env"`
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithSyncInterval", func(t *testing.T) {
+		opts := getOpts(WithSyncInterval(time.Minute))
+		testOpts := getDefaultOptions()
+		testOpts.withSyncInterval = time.Minute
+		assert.Equal(t, opts, testOpts)
+	})
+}
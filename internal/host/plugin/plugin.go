@@ -0,0 +1,34 @@
+package plugin
+
+import "context"
+
+// A HostPlugin provides the hosts for a plugin host catalog from an
+// external inventory. The attributes of a host catalog and the filter of a
+// host set are opaque to the repository and are only interpreted by the
+// plugin.
+type HostPlugin interface {
+	// ValidateCatalog returns an error if attributes cannot be used to
+	// query the inventory.
+	ValidateCatalog(ctx context.Context, attributes map[string]interface{}) error
+
+	// ValidateSet returns an error if filter is not a valid host set
+	// filter for the plugin.
+	ValidateSet(ctx context.Context, filter string) error
+
+	// ListHosts returns the hosts in the inventory described by
+	// attributes which match filter.
+	ListHosts(ctx context.Context, attributes map[string]interface{}, filter string) ([]*HostInfo, error)
+}
+
+// HostInfo describes a host returned by a HostPlugin.
+type HostInfo struct {
+	// ExternalId is the id of the host in the external inventory. It must
+	// be set and it must be unique within the inventory.
+	ExternalId string
+
+	// Name is an optional name for the host.
+	Name string
+
+	// Address is the IP address or DNS name of the host. It must be set.
+	Address string
+}
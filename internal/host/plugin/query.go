@@ -0,0 +1,49 @@
+package plugin
+
+const (
+	deleteCatalogSetSyncsQuery = `
+delete from host_plugin_set_sync
+ where set_id in (
+       select public_id
+         from host_plugin_set
+        where catalog_id = $1
+       );
+`
+
+	deleteSetSyncQuery = `
+delete from host_plugin_set_sync
+ where set_id = $1;
+`
+
+	upsertSetSyncQuery = `
+insert into host_plugin_set_sync
+  (set_id, last_sync_time)
+values
+  ($1, current_timestamp)
+    on conflict (set_id)
+    do update set last_sync_time = current_timestamp;
+`
+
+	deleteSetMembersQuery = `
+delete from host_plugin_set_member
+ where set_id = $1;
+`
+
+	// deleteOrphanedHostsQuery deletes the hosts in a catalog which are
+	// no longer a member of any set in the catalog.
+	deleteOrphanedHostsQuery = `
+delete from host_plugin_host
+ where catalog_id = $1
+   and public_id not in (
+       select host_id
+         from host_plugin_set_member
+        where catalog_id = $1
+       );
+`
+
+	setMembersWhere = `public_id in
+       ( select host_id
+           from host_plugin_set_member
+          where set_id = $1
+       )`
+)
@@ -0,0 +1,73 @@
+package plugin
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+)
+
+// DefaultSyncInterval is the default amount of time the members of a host
+// set are cached before they are retrieved from the plugin again.
+const DefaultSyncInterval = 5 * time.Minute
+
+// A Repository stores and retrieves the persistent types in the plugin
+// package. It is not safe to use a repository concurrently.
+type Repository struct {
+	reader  db.Reader
+	writer  db.Writer
+	kms     *kms.Kms
+	plugins map[string]HostPlugin
+	// defaultLimit provides a default for limiting the number of results
+	// returned from the repo
+	defaultLimit int
+	syncInterval time.Duration
+}
+
+// NewRepository creates a new Repository. The returned repository should
+// only be used for one transaction and it is not safe for concurrent go
+// routines to access it. plugins contains the plugins which can be used by
+// host catalogs keyed by the plugin name. WithLimit option is used as a
+// repo wide default limit applied to all ListX methods. WithSyncInterval
+// sets how long the members of a host set are cached.
+func NewRepository(r db.Reader, w db.Writer, kms *kms.Kms, plugins map[string]HostPlugin, opt ...Option) (*Repository, error) {
+	switch {
+	case r == nil:
+		return nil, fmt.Errorf("db.Reader: %w", errors.ErrInvalidParameter)
+	case w == nil:
+		return nil, fmt.Errorf("db.Writer: %w", errors.ErrInvalidParameter)
+	case kms == nil:
+		return nil, fmt.Errorf("kms: %w", errors.ErrInvalidParameter)
+	case plugins == nil:
+		return nil, fmt.Errorf("plugins: %w", errors.ErrInvalidParameter)
+	}
+
+	opts := getOpts(opt...)
+	if opts.withLimit == 0 {
+		// zero signals the boundary defaults should be used.
+		opts.withLimit = db.DefaultLimit
+	}
+	if opts.withSyncInterval == 0 {
+		opts.withSyncInterval = DefaultSyncInterval
+	}
+
+	return &Repository{
+		reader:       r,
+		writer:       w,
+		kms:          kms,
+		plugins:      plugins,
+		defaultLimit: opts.withLimit,
+		syncInterval: opts.withSyncInterval,
+	}, nil
+}
+
+// plugin returns the plugin registered as name.
+func (r *Repository) plugin(name string) (HostPlugin, error) {
+	p, ok := r.plugins[name]
+	if !ok || p == nil {
+		return nil, fmt.Errorf("%s: %w", name, ErrUnknownPlugin)
+	}
+	return p, nil
+}
@@ -0,0 +1,174 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/host/plugin/store"
+)
+
+// LookupHost will look up a host in the repository. If the host is not
+// found, it will return nil, nil. All options are ignored.
+func (r *Repository) LookupHost(ctx context.Context, publicId string, opt ...Option) (*Host, error) {
+	if publicId == "" {
+		return nil, fmt.Errorf("lookup: plugin host: missing public id: %w", errors.ErrInvalidParameter)
+	}
+	h := allocHost()
+	h.PublicId = publicId
+	if err := r.reader.LookupByPublicId(ctx, h); err != nil {
+		if errors.Is(err, errors.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup: plugin host: failed %w for %s", err, publicId)
+	}
+	return h, nil
+}
+
+// ListSetMembers returns the hosts in the host set setId. The members are
+// retrieved from the plugin of the set's catalog if they have not been
+// retrieved within the sync interval of the repository, otherwise the
+// cached members are returned. All options are ignored.
+func (r *Repository) ListSetMembers(ctx context.Context, setId string, opt ...Option) ([]*Host, error) {
+	if setId == "" {
+		return nil, fmt.Errorf("list: plugin host set members: missing set id: %w", errors.ErrInvalidParameter)
+	}
+
+	setSync := &hostSetSync{HostSetSync: &store.HostSetSync{}}
+	err := r.reader.LookupWhere(ctx, setSync, "set_id = ?", setId)
+	switch {
+	case errors.Is(err, errors.ErrRecordNotFound):
+		return r.SyncSet(ctx, setId)
+	case err != nil:
+		return nil, fmt.Errorf("list: plugin host set members: %w", err)
+	}
+	if time.Since(setSync.GetLastSyncTime().GetTimestamp().AsTime()) > r.syncInterval {
+		return r.SyncSet(ctx, setId)
+	}
+
+	var hosts []*Host
+	if err := r.reader.SearchWhere(ctx, &hosts, setMembersWhere, []interface{}{setId}, db.WithLimit(-1)); err != nil {
+		return nil, fmt.Errorf("list: plugin host set members: %w", err)
+	}
+	return hosts, nil
+}
+
+// SyncSet retrieves the hosts matching the filter of the host set setId
+// from the plugin of the set's catalog and replaces the cached members of
+// the set with them. Hosts keep their public id across syncs. Hosts which
+// are no longer a member of any set in the catalog are deleted. It returns
+// the members of the set. All options are ignored.
+func (r *Repository) SyncSet(ctx context.Context, setId string, opt ...Option) ([]*Host, error) {
+	if setId == "" {
+		return nil, fmt.Errorf("sync: plugin host set: missing set id: %w", errors.ErrInvalidParameter)
+	}
+	s, err := r.LookupSet(ctx, setId)
+	if err != nil {
+		return nil, fmt.Errorf("sync: plugin host set: %w", err)
+	}
+	if s == nil {
+		return nil, fmt.Errorf("sync: plugin host set: %s: %w", setId, errors.ErrRecordNotFound)
+	}
+	c, err := r.LookupCatalog(ctx, s.CatalogId)
+	if err != nil {
+		return nil, fmt.Errorf("sync: plugin host set: %w", err)
+	}
+	if c == nil {
+		return nil, fmt.Errorf("sync: plugin host set: catalog %s: %w", s.CatalogId, errors.ErrRecordNotFound)
+	}
+	p, err := r.plugin(c.PluginName)
+	if err != nil {
+		return nil, fmt.Errorf("sync: plugin host set: %w", err)
+	}
+	attrs, err := c.DecodedAttributes()
+	if err != nil {
+		return nil, fmt.Errorf("sync: plugin host set: %w", err)
+	}
+	infos, err := p.ListHosts(ctx, attrs, s.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("sync: plugin host set: %s: plugin %s: %w", setId, c.PluginName, err)
+	}
+	found := make(map[string]*HostInfo, len(infos))
+	for _, info := range infos {
+		if info == nil || info.ExternalId == "" || info.Address == "" {
+			return nil, fmt.Errorf("sync: plugin host set: %s: plugin %s: host without external id or address: %w", setId, c.PluginName, errors.ErrInvalidParameter)
+		}
+		found[info.ExternalId] = info
+	}
+
+	var hosts []*Host
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{}, func(reader db.Reader, w db.Writer) error {
+		hosts = nil
+		var existing []*Host
+		if err := reader.SearchWhere(ctx, &existing, "catalog_id = ?", []interface{}{c.PublicId}, db.WithLimit(-1)); err != nil {
+			return fmt.Errorf("unable to list hosts: %w", err)
+		}
+		byExternalId := make(map[string]*Host, len(existing))
+		for _, h := range existing {
+			byExternalId[h.ExternalId] = h
+		}
+
+		for _, info := range found {
+			h, ok := byExternalId[info.ExternalId]
+			if !ok {
+				var err error
+				if h, err = NewHost(c.PublicId, info.ExternalId, info.Address, WithName(info.Name)); err != nil {
+					return err
+				}
+				if h.PublicId, err = newHostId(); err != nil {
+					return err
+				}
+				if err := w.Create(ctx, h); err != nil {
+					return fmt.Errorf("unable to create host: %w", err)
+				}
+				hosts = append(hosts, h)
+				continue
+			}
+			if h.Name != info.Name || h.Address != info.Address {
+				h = h.clone()
+				h.Name, h.Address = info.Name, info.Address
+				var dbMask, nullFields []string
+				dbMask = append(dbMask, "Address")
+				if h.Name == "" {
+					nullFields = append(nullFields, "Name")
+				} else {
+					dbMask = append(dbMask, "Name")
+				}
+				if _, err := w.Update(ctx, h, dbMask, nullFields); err != nil {
+					return fmt.Errorf("unable to update host: %w", err)
+				}
+			}
+			hosts = append(hosts, h)
+		}
+
+		if _, err := w.Exec(ctx, deleteSetMembersQuery, []interface{}{setId}); err != nil {
+			return fmt.Errorf("unable to delete host set members: %w", err)
+		}
+		if len(hosts) > 0 {
+			members := make([]interface{}, 0, len(hosts))
+			for _, h := range hosts {
+				m, err := NewHostSetMember(setId, h.PublicId)
+				if err != nil {
+					return err
+				}
+				members = append(members, m)
+			}
+			if err := w.CreateItems(ctx, members); err != nil {
+				return fmt.Errorf("unable to create host set members: %w", err)
+			}
+		}
+		if _, err := w.Exec(ctx, deleteOrphanedHostsQuery, []interface{}{c.PublicId}); err != nil {
+			return fmt.Errorf("unable to delete orphaned hosts: %w", err)
+		}
+		if _, err := w.Exec(ctx, upsertSetSyncQuery, []interface{}{setId}); err != nil {
+			return fmt.Errorf("unable to update host set sync time: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sync: plugin host set: %s: %w", setId, err)
+	}
+	return hosts, nil
+}
@@ -0,0 +1,323 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// CreateCatalog inserts c into the repository and returns a new
+// HostCatalog containing the catalog's PublicId. c is not changed. c must
+// contain a valid ScopeID and a PluginName which has been registered with
+// the repository. c must not contain a PublicId. The PublicId is generated
+// and assigned by the this method. c.Attributes are validated by the
+// plugin before c is inserted.
+//
+// Both c.Name and c.Description are optional. If c.Name is set, it must be
+// unique within c.ScopeID.
+//
+// Both c.CreateTime and c.UpdateTime are ignored.
+func (r *Repository) CreateCatalog(ctx context.Context, c *HostCatalog, opt ...Option) (*HostCatalog, error) {
+	if c == nil {
+		return nil, fmt.Errorf("create: plugin host catalog: %w", errors.ErrInvalidParameter)
+	}
+	if c.HostCatalog == nil {
+		return nil, fmt.Errorf("create: plugin host catalog: embedded HostCatalog: %w", errors.ErrInvalidParameter)
+	}
+	if c.ScopeId == "" {
+		return nil, fmt.Errorf("create: plugin host catalog: no scope id: %w", errors.ErrInvalidParameter)
+	}
+	if c.PublicId != "" {
+		return nil, fmt.Errorf("create: plugin host catalog: public id not empty: %w", errors.ErrInvalidParameter)
+	}
+	if c.PluginName == "" {
+		return nil, fmt.Errorf("create: plugin host catalog: no plugin name: %w", errors.ErrInvalidParameter)
+	}
+	c = c.clone()
+
+	if err := r.validateCatalog(ctx, c); err != nil {
+		return nil, fmt.Errorf("create: plugin host catalog: %w", err)
+	}
+
+	opts := getOpts(opt...)
+
+	if opts.withPublicId != "" {
+		if !strings.HasPrefix(opts.withPublicId, HostCatalogPrefix+"_") {
+			return nil, fmt.Errorf("create: plugin host catalog: passed-in public ID %q has wrong prefix, should be %q: %w", opts.withPublicId, HostCatalogPrefix, errors.ErrInvalidPublicId)
+		}
+		c.PublicId = opts.withPublicId
+	} else {
+		id, err := newHostCatalogId()
+		if err != nil {
+			return nil, fmt.Errorf("create: plugin host catalog: %w", err)
+		}
+		c.PublicId = id
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, c.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, fmt.Errorf("create: plugin host catalog: unable to get oplog wrapper: %w", err)
+	}
+
+	metadata := newCatalogMetadata(c, oplog.OpType_OP_TYPE_CREATE)
+
+	var newHostCatalog *HostCatalog
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			newHostCatalog = c.clone()
+			return w.Create(
+				ctx,
+				newHostCatalog,
+				db.WithOplog(oplogWrapper, metadata),
+			)
+		},
+	)
+
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, fmt.Errorf("create: plugin host catalog: in scope: %s: name %s already exists: %w",
+				c.ScopeId, c.Name, errors.ErrNotUnique)
+		}
+		return nil, fmt.Errorf("create: plugin host catalog: in scope: %s: %w", c.ScopeId, err)
+	}
+	return newHostCatalog, nil
+}
+
+// UpdateCatalog updates the repository entry for c.PublicId with the
+// values in c for the fields listed in fieldMask. It returns a new
+// HostCatalog containing the updated values and a count of the number of
+// records updated. c is not changed.
+//
+// c must contain a valid PublicId. Only c.Name, c.Description, and
+// c.Attributes can be updated. If c.Name is set to a non-empty string, it
+// must be unique within c.ScopeID. If c.Attributes is updated, it is
+// validated by the catalog's plugin. The cached members of the catalog's
+// host sets are refreshed the next time they are listed.
+//
+// An attribute of c will be set to NULL in the database if the attribute
+// in c is the zero value and it is included in fieldMask.
+func (r *Repository) UpdateCatalog(ctx context.Context, c *HostCatalog, version uint32, fieldMask []string, opt ...Option) (*HostCatalog, int, error) {
+	if c == nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: plugin host catalog: %w", errors.ErrInvalidParameter)
+	}
+	if c.HostCatalog == nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: plugin host catalog: embedded HostCatalog: %w", errors.ErrInvalidParameter)
+	}
+	if c.PublicId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: plugin host catalog: missing public id: %w", errors.ErrInvalidParameter)
+	}
+	if c.ScopeId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: plugin host catalog: missing scope id: %w", errors.ErrInvalidParameter)
+	}
+	if len(fieldMask) == 0 {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: plugin host catalog: %w", errors.ErrEmptyFieldMask)
+	}
+
+	var dbMask, nullFields []string
+	var updateAttributes bool
+	for _, f := range fieldMask {
+		switch {
+		case strings.EqualFold("name", f) && c.Name == "":
+			nullFields = append(nullFields, "name")
+		case strings.EqualFold("name", f) && c.Name != "":
+			dbMask = append(dbMask, "name")
+		case strings.EqualFold("description", f) && c.Description == "":
+			nullFields = append(nullFields, "description")
+		case strings.EqualFold("description", f) && c.Description != "":
+			dbMask = append(dbMask, "description")
+		case strings.EqualFold("attributes", f):
+			dbMask = append(dbMask, "attributes")
+			updateAttributes = true
+
+		default:
+			return nil, db.NoRowsAffected, fmt.Errorf("update: plugin host catalog: field: %s: %w", f, errors.ErrInvalidFieldMask)
+		}
+	}
+
+	c = c.clone()
+
+	if updateAttributes {
+		if len(c.Attributes) == 0 {
+			if err := c.SetAttributes(nil); err != nil {
+				return nil, db.NoRowsAffected, fmt.Errorf("update: plugin host catalog: %w", err)
+			}
+		}
+		current, err := r.LookupCatalog(ctx, c.PublicId)
+		if err != nil {
+			return nil, db.NoRowsAffected, fmt.Errorf("update: plugin host catalog: %w", err)
+		}
+		if current == nil {
+			return nil, db.NoRowsAffected, fmt.Errorf("update: plugin host catalog: %s: %w", c.PublicId, errors.ErrRecordNotFound)
+		}
+		c.PluginName = current.PluginName
+		if err := r.validateCatalog(ctx, c); err != nil {
+			return nil, db.NoRowsAffected, fmt.Errorf("update: plugin host catalog: %w", err)
+		}
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, c.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: plugin host catalog: unable to get oplog wrapper: %w", err)
+	}
+
+	metadata := newCatalogMetadata(c, oplog.OpType_OP_TYPE_UPDATE)
+
+	var rowsUpdated int
+	var returnedCatalog *HostCatalog
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedCatalog = c.clone()
+			var err error
+			rowsUpdated, err = w.Update(
+				ctx,
+				returnedCatalog,
+				dbMask,
+				nullFields,
+				db.WithOplog(oplogWrapper, metadata),
+				db.WithVersion(&version),
+			)
+			if err == nil && rowsUpdated > 1 {
+				return errors.ErrMultipleRecords
+			}
+			if err != nil || !updateAttributes {
+				return err
+			}
+			// The inventory may have changed, force the sets in the
+			// catalog to be refreshed.
+			_, err = w.Exec(ctx, deleteCatalogSetSyncsQuery, []interface{}{c.PublicId})
+			return err
+		},
+	)
+
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, db.NoRowsAffected, fmt.Errorf("update: plugin host catalog: %s: name %s already exists: %w",
+				c.PublicId, c.Name, errors.ErrNotUnique)
+		}
+		return nil, db.NoRowsAffected, fmt.Errorf("update: plugin host catalog: %s: %w", c.PublicId, err)
+	}
+
+	return returnedCatalog, rowsUpdated, nil
+}
+
+// LookupCatalog returns the HostCatalog for id. Returns nil, nil if no
+// HostCatalog is found for id.
+func (r *Repository) LookupCatalog(ctx context.Context, id string, opt ...Option) (*HostCatalog, error) {
+	if id == "" {
+		return nil, fmt.Errorf("lookup: plugin host catalog: missing public id: %w", errors.ErrInvalidParameter)
+	}
+	c := allocCatalog()
+	c.PublicId = id
+	if err := r.reader.LookupByPublicId(ctx, c); err != nil {
+		if errors.Is(err, errors.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup: plugin host catalog: %s: %w", id, err)
+	}
+	return c, nil
+}
+
+// ListCatalogs returns a slice of HostCatalogs in the given scopes.
+// Supports the WithLimit and WithStartPageAfterId options.
+func (r *Repository) ListCatalogs(ctx context.Context, scopeIds []string, opt ...Option) ([]*HostCatalog, error) {
+	if len(scopeIds) == 0 {
+		return nil, fmt.Errorf("list: plugin host catalog: missing scope id: %w", errors.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	where, args := "scope_id in (?)", []interface{}{scopeIds}
+	if opts.withStartPageAfterId != "" {
+		where, args = where+" and public_id > ?", append(args, opts.withStartPageAfterId)
+	}
+	var hostCatalogs []*HostCatalog
+	err := r.reader.SearchWhere(ctx, &hostCatalogs, where, args, db.WithLimit(limit), db.WithOrder("public_id"))
+	if err != nil {
+		return nil, fmt.Errorf("list: plugin host catalog: %w", err)
+	}
+	return hostCatalogs, nil
+}
+
+// DeleteCatalog deletes id from the repository returning a count of the
+// number of records deleted. All hosts and host sets in the catalog are
+// also deleted.
+func (r *Repository) DeleteCatalog(ctx context.Context, id string, opt ...Option) (int, error) {
+	if id == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete: plugin host catalog: missing public id: %w", errors.ErrInvalidParameter)
+	}
+
+	c := allocCatalog()
+	c.PublicId = id
+	if err := r.reader.LookupByPublicId(ctx, c); err != nil {
+		if errors.Is(err, errors.ErrRecordNotFound) {
+			return db.NoRowsAffected, nil
+		}
+		return db.NoRowsAffected, fmt.Errorf("delete: plugin host catalog: failed %w for %s", err, id)
+	}
+	if c.ScopeId == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete: plugin host catalog: missing scope id: %w", errors.ErrInvalidParameter)
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, c.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete: plugin host catalog: unable to get oplog wrapper: %w", err)
+	}
+
+	metadata := newCatalogMetadata(c, oplog.OpType_OP_TYPE_DELETE)
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			deleteCatalog := c.clone()
+			var err error
+			rowsDeleted, err = w.Delete(
+				ctx,
+				deleteCatalog,
+				db.WithOplog(oplogWrapper, metadata),
+			)
+			if err == nil && rowsDeleted > 1 {
+				return errors.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete: plugin host catalog: %s: %w", c.PublicId, err)
+	}
+
+	return rowsDeleted, nil
+}
+
+// validateCatalog checks that the plugin of c is registered and that it
+// accepts the attributes of c.
+func (r *Repository) validateCatalog(ctx context.Context, c *HostCatalog) error {
+	p, err := r.plugin(c.PluginName)
+	if err != nil {
+		return err
+	}
+	attrs, err := c.DecodedAttributes()
+	if err != nil {
+		return fmt.Errorf("%v: %w", err, ErrInvalidAttributes)
+	}
+	if err := p.ValidateCatalog(ctx, attrs); err != nil {
+		return fmt.Errorf("%v: %w", err, ErrInvalidAttributes)
+	}
+	return nil
+}
@@ -0,0 +1,304 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	dbcommon "github.com/hashicorp/boundary/internal/db/common"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// CreateSet inserts s into the repository and returns a new HostSet
+// containing the host set's PublicId. s is not changed. s must contain a
+// valid CatalogId and a Filter which is accepted by the catalog's plugin.
+// s must not contain a PublicId. The PublicId is generated and assigned by
+// this method.
+//
+// Both s.Name and s.Description are optional. If s.Name is set, it must be
+// unique within s.CatalogId.
+func (r *Repository) CreateSet(ctx context.Context, scopeId string, s *HostSet, opt ...Option) (*HostSet, error) {
+	if s == nil {
+		return nil, fmt.Errorf("create: plugin host set: %w", errors.ErrInvalidParameter)
+	}
+	if s.HostSet == nil {
+		return nil, fmt.Errorf("create: plugin host set: embedded HostSet: %w", errors.ErrInvalidParameter)
+	}
+	if s.CatalogId == "" {
+		return nil, fmt.Errorf("create: plugin host set: no catalog id: %w", errors.ErrInvalidParameter)
+	}
+	if s.PublicId != "" {
+		return nil, fmt.Errorf("create: plugin host set: public id not empty: %w", errors.ErrInvalidParameter)
+	}
+	if s.Filter == "" {
+		return nil, fmt.Errorf("create: plugin host set: no filter: %w", errors.ErrInvalidParameter)
+	}
+	if scopeId == "" {
+		return nil, fmt.Errorf("create: plugin host set: no scopeId: %w", errors.ErrInvalidParameter)
+	}
+	s = s.clone()
+
+	if err := r.validateSet(ctx, s); err != nil {
+		return nil, fmt.Errorf("create: plugin host set: %w", err)
+	}
+
+	opts := getOpts(opt...)
+
+	if opts.withPublicId != "" {
+		if !strings.HasPrefix(opts.withPublicId, HostSetPrefix+"_") {
+			return nil, fmt.Errorf("create: plugin host set: passed-in public ID %q has wrong prefix, should be %q: %w", opts.withPublicId, HostSetPrefix, errors.ErrInvalidPublicId)
+		}
+		s.PublicId = opts.withPublicId
+	} else {
+		id, err := newHostSetId()
+		if err != nil {
+			return nil, fmt.Errorf("create: plugin host set: %w", err)
+		}
+		s.PublicId = id
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, fmt.Errorf("create: plugin host set: unable to get oplog wrapper: %w", err)
+	}
+
+	var newHostSet *HostSet
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			newHostSet = s.clone()
+			return w.Create(ctx, newHostSet, db.WithOplog(oplogWrapper, s.oplog(oplog.OpType_OP_TYPE_CREATE)))
+		},
+	)
+
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, fmt.Errorf("create: plugin host set: in catalog: %s: name %s already exists: %w",
+				s.CatalogId, s.Name, errors.ErrNotUnique)
+		}
+		return nil, fmt.Errorf("create: plugin host set: in catalog: %s: %w", s.CatalogId, err)
+	}
+	return newHostSet, nil
+}
+
+// UpdateSet updates the repository entry for s.PublicId with the values in
+// s for the fields listed in fieldMaskPaths. It returns a new HostSet
+// containing the updated values and a count of the number of records
+// updated. s is not changed.
+//
+// s must contain a valid PublicId. Only s.Name, s.Description, and
+// s.Filter can be updated. If s.Name is set to a non-empty string, it must
+// be unique within s.CatalogId. s.Filter cannot be set to NULL and it is
+// validated by the catalog's plugin. If s.Filter is updated, the cached
+// members of the set are refreshed the next time they are listed.
+//
+// An attribute of s will be set to NULL in the database if the attribute
+// in s is the zero value and it is included in fieldMaskPaths.
+func (r *Repository) UpdateSet(ctx context.Context, scopeId string, s *HostSet, version uint32, fieldMaskPaths []string, opt ...Option) (*HostSet, int, error) {
+	if s == nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: plugin host set: %w", errors.ErrInvalidParameter)
+	}
+	if s.HostSet == nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: plugin host set: embedded HostSet: %w", errors.ErrInvalidParameter)
+	}
+	if s.PublicId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: plugin host set: missing public id: %w", errors.ErrInvalidParameter)
+	}
+	if version == 0 {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: plugin host set: no version supplied: %w", errors.ErrInvalidParameter)
+	}
+	if scopeId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: plugin host set: no scopeId: %w", errors.ErrInvalidParameter)
+	}
+
+	var updateFilter bool
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold("Name", f):
+		case strings.EqualFold("Description", f):
+		case strings.EqualFold("Filter", f):
+			if s.Filter == "" {
+				return nil, db.NoRowsAffected, fmt.Errorf("update: plugin host set: filter cannot be empty: %w", errors.ErrInvalidParameter)
+			}
+			updateFilter = true
+		default:
+			return nil, db.NoRowsAffected, fmt.Errorf("update: plugin host set: field: %s: %w", f, errors.ErrInvalidFieldMask)
+		}
+	}
+	var dbMask, nullFields []string
+	dbMask, nullFields = dbcommon.BuildUpdatePaths(
+		map[string]interface{}{
+			"Name":        s.Name,
+			"Description": s.Description,
+			"Filter":      s.Filter,
+		},
+		fieldMaskPaths,
+		nil,
+	)
+	if len(dbMask) == 0 && len(nullFields) == 0 {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: plugin host set: %w", errors.ErrEmptyFieldMask)
+	}
+
+	s = s.clone()
+
+	if updateFilter {
+		current, err := r.LookupSet(ctx, s.PublicId)
+		if err != nil {
+			return nil, db.NoRowsAffected, fmt.Errorf("update: plugin host set: %w", err)
+		}
+		if current == nil {
+			return nil, db.NoRowsAffected, fmt.Errorf("update: plugin host set: %s: %w", s.PublicId, errors.ErrRecordNotFound)
+		}
+		s.CatalogId = current.CatalogId
+		if err := r.validateSet(ctx, s); err != nil {
+			return nil, db.NoRowsAffected, fmt.Errorf("update: plugin host set: %w", err)
+		}
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update: plugin host set: unable to get oplog wrapper: %w", err)
+	}
+
+	var rowsUpdated int
+	var returnedHostSet *HostSet
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedHostSet = s.clone()
+			var err error
+			rowsUpdated, err = w.Update(ctx, returnedHostSet, dbMask, nullFields,
+				db.WithOplog(oplogWrapper, s.oplog(oplog.OpType_OP_TYPE_UPDATE)),
+				db.WithVersion(&version))
+			if err == nil && rowsUpdated > 1 {
+				return errors.ErrMultipleRecords
+			}
+			if err != nil || !updateFilter {
+				return err
+			}
+			_, err = w.Exec(ctx, deleteSetSyncQuery, []interface{}{s.PublicId})
+			return err
+		},
+	)
+
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, db.NoRowsAffected, fmt.Errorf("update: plugin host set: %s: name %s already exists: %w",
+				s.PublicId, s.Name, errors.ErrNotUnique)
+		}
+		return nil, db.NoRowsAffected, fmt.Errorf("update: plugin host set: %s: %w", s.PublicId, err)
+	}
+
+	return returnedHostSet, rowsUpdated, nil
+}
+
+// LookupSet will look up a host set in the repository and return the host
+// set. If the host set is not found, it will return nil, nil. All options
+// are ignored.
+func (r *Repository) LookupSet(ctx context.Context, publicId string, opt ...Option) (*HostSet, error) {
+	if publicId == "" {
+		return nil, fmt.Errorf("lookup: plugin host set: missing public id %w", errors.ErrInvalidParameter)
+	}
+	s := allocHostSet()
+	s.PublicId = publicId
+	if err := r.reader.LookupByPublicId(ctx, s); err != nil {
+		if errors.Is(err, errors.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup: plugin host set: failed %w for %s", err, publicId)
+	}
+	return s, nil
+}
+
+// ListSets returns a slice of HostSets for the catalogId. Supports the
+// WithLimit and WithStartPageAfterId options.
+func (r *Repository) ListSets(ctx context.Context, catalogId string, opt ...Option) ([]*HostSet, error) {
+	if catalogId == "" {
+		return nil, fmt.Errorf("list: plugin host set: missing catalog id: %w", errors.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	where, args := "catalog_id = ?", []interface{}{catalogId}
+	if opts.withStartPageAfterId != "" {
+		where, args = where+" and public_id > ?", append(args, opts.withStartPageAfterId)
+	}
+	var sets []*HostSet
+	err := r.reader.SearchWhere(ctx, &sets, where, args, db.WithLimit(limit), db.WithOrder("public_id"))
+	if err != nil {
+		return nil, fmt.Errorf("list: plugin host set: %w", err)
+	}
+	return sets, nil
+}
+
+// DeleteSet deletes the host set for the provided id from the repository
+// returning a count of the number of records deleted. Hosts which are no
+// longer a member of any set in the catalog are also deleted. All options
+// are ignored.
+func (r *Repository) DeleteSet(ctx context.Context, scopeId string, publicId string, opt ...Option) (int, error) {
+	if publicId == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete: plugin host set: missing public id: %w", errors.ErrInvalidParameter)
+	}
+	if scopeId == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete: plugin host set: no scopeId: %w", errors.ErrInvalidParameter)
+	}
+	s, err := r.LookupSet(ctx, publicId)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete: plugin host set: %w", err)
+	}
+	if s == nil {
+		return db.NoRowsAffected, nil
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete: plugin host set: unable to get oplog wrapper: %w", err)
+	}
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) (err error) {
+			ds := s.clone()
+			rowsDeleted, err = w.Delete(ctx, ds, db.WithOplog(oplogWrapper, s.oplog(oplog.OpType_OP_TYPE_DELETE)))
+			if err == nil && rowsDeleted > 1 {
+				return errors.ErrMultipleRecords
+			}
+			if err != nil {
+				return err
+			}
+			_, err = w.Exec(ctx, deleteOrphanedHostsQuery, []interface{}{s.CatalogId})
+			return err
+		},
+	)
+
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete: plugin host set: %s: %w", publicId, err)
+	}
+
+	return rowsDeleted, nil
+}
+
+// validateSet checks that the plugin of the catalog of s accepts the
+// filter of s.
+func (r *Repository) validateSet(ctx context.Context, s *HostSet) error {
+	c, err := r.LookupCatalog(ctx, s.CatalogId)
+	if err != nil {
+		return err
+	}
+	if c == nil {
+		return fmt.Errorf("catalog %s: %w", s.CatalogId, errors.ErrRecordNotFound)
+	}
+	p, err := r.plugin(c.PluginName)
+	if err != nil {
+		return err
+	}
+	if err := p.ValidateSet(ctx, s.Filter); err != nil {
+		return fmt.Errorf("%v: %w", err, ErrInvalidFilter)
+	}
+	return nil
+}
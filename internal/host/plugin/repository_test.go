@@ -0,0 +1,320 @@
+package plugin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+)
+
+func TestRepository_New(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	plugins := TestPlugins(&TestPlugin{})
+
+	type args struct {
+		r       db.Reader
+		w       db.Writer
+		kms     *kms.Kms
+		plugins map[string]HostPlugin
+		opts    []Option
+	}
+
+	var tests = []struct {
+		name      string
+		args      args
+		want      *Repository
+		wantIsErr error
+	}{
+		{
+			name: "valid",
+			args: args{
+				r:       rw,
+				w:       rw,
+				kms:     kmsCache,
+				plugins: plugins,
+			},
+			want: &Repository{
+				reader:       rw,
+				writer:       rw,
+				kms:          kmsCache,
+				plugins:      plugins,
+				defaultLimit: db.DefaultLimit,
+				syncInterval: DefaultSyncInterval,
+			},
+		},
+		{
+			name: "valid-with-options",
+			args: args{
+				r:       rw,
+				w:       rw,
+				kms:     kmsCache,
+				plugins: plugins,
+				opts:    []Option{WithLimit(5), WithSyncInterval(time.Minute)},
+			},
+			want: &Repository{
+				reader:       rw,
+				writer:       rw,
+				kms:          kmsCache,
+				plugins:      plugins,
+				defaultLimit: 5,
+				syncInterval: time.Minute,
+			},
+		},
+		{
+			name: "nil-reader",
+			args: args{
+				w:       rw,
+				kms:     kmsCache,
+				plugins: plugins,
+			},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name: "nil-writer",
+			args: args{
+				r:       rw,
+				kms:     kmsCache,
+				plugins: plugins,
+			},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name: "nil-kms",
+			args: args{
+				r:       rw,
+				w:       rw,
+				plugins: plugins,
+			},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name: "nil-plugins",
+			args: args{
+				r:   rw,
+				w:   rw,
+				kms: kmsCache,
+			},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewRepository(tt.args.r, tt.args.w, tt.args.kms, tt.args.plugins, tt.args.opts...)
+			if tt.wantIsErr != nil {
+				assert.Truef(errors.Is(err, tt.wantIsErr), "want err: %q got: %q", tt.wantIsErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestRepository_CreateCatalog(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kmsCache, TestPlugins(&TestPlugin{}))
+	require.NoError(t, err)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	var tests = []struct {
+		name       string
+		pluginName string
+		opts       []Option
+		wantIsErr  error
+	}{
+		{
+			name:       "valid",
+			pluginName: TestPluginName,
+			opts:       []Option{WithName("catalog"), WithAttributes(map[string]interface{}{"region": "us-east-1"})},
+		},
+		{
+			name:       "unknown-plugin",
+			pluginName: "unknown",
+			wantIsErr:  ErrUnknownPlugin,
+		},
+		{
+			name:       "invalid-attributes",
+			pluginName: TestPluginName,
+			opts:       []Option{WithAttributes(map[string]interface{}{"invalid": true})},
+			wantIsErr:  ErrInvalidAttributes,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			in, err := NewHostCatalog(prj.GetPublicId(), tt.pluginName, tt.opts...)
+			require.NoError(err)
+			got, err := repo.CreateCatalog(context.Background(), in)
+			if tt.wantIsErr != nil {
+				assert.Truef(errors.Is(err, tt.wantIsErr), "want err: %q got: %q", tt.wantIsErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.NotEmpty(got.PublicId)
+			assert.Equal(tt.pluginName, got.PluginName)
+			assert.Equal(in.Attributes, got.Attributes)
+
+			found, err := repo.LookupCatalog(context.Background(), got.PublicId)
+			require.NoError(err)
+			assert.Equal(got.PluginName, found.PluginName)
+			attrs, err := found.DecodedAttributes()
+			require.NoError(err)
+			assert.Equal(map[string]interface{}{"region": "us-east-1"}, attrs)
+		})
+	}
+}
+
+func TestRepository_CreateSet(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kmsCache, TestPlugins(&TestPlugin{}))
+	require.NoError(t, err)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	catalog := TestCatalogs(t, conn, prj.GetPublicId(), 1)[0]
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		in, err := NewHostSet(catalog.PublicId, "filter", WithName("set"))
+		require.NoError(err)
+		got, err := repo.CreateSet(context.Background(), prj.GetPublicId(), in)
+		require.NoError(err)
+		assert.NotEmpty(got.PublicId)
+		assert.Equal("filter", got.Filter)
+		assert.Equal("set", got.Name)
+	})
+	t.Run("invalid-filter", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		in, err := NewHostSet(catalog.PublicId, "invalid")
+		require.NoError(err)
+		got, err := repo.CreateSet(context.Background(), prj.GetPublicId(), in)
+		assert.Truef(errors.Is(err, ErrInvalidFilter), "want err: %q got: %q", ErrInvalidFilter, err)
+		assert.Nil(got)
+	})
+	t.Run("update-filter", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		set := TestSets(t, conn, catalog.PublicId, 1)[0]
+		set.Filter = "updated"
+		got, n, err := repo.UpdateSet(context.Background(), prj.GetPublicId(), set, set.Version, []string{"Filter"})
+		require.NoError(err)
+		assert.Equal(1, n)
+		assert.Equal("updated", got.Filter)
+
+		set.Filter = ""
+		got, n, err = repo.UpdateSet(context.Background(), prj.GetPublicId(), set, got.Version, []string{"Filter"})
+		assert.Truef(errors.Is(err, errors.ErrInvalidParameter), "want err: %q got: %q", errors.ErrInvalidParameter, err)
+		assert.Nil(got)
+		assert.Equal(db.NoRowsAffected, n)
+	})
+}
+
+func TestRepository_SyncSet(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	p := &TestPlugin{}
+	repo, err := NewRepository(rw, rw, kmsCache, TestPlugins(p))
+	require.NoError(err)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	catalog := TestCatalogs(t, conn, prj.GetPublicId(), 1)[0]
+	sets := TestSets(t, conn, catalog.PublicId, 2)
+	ctx := context.Background()
+
+	p.Hosts = []*HostInfo{
+		{ExternalId: "i-1", Name: "one", Address: "10.0.0.1"},
+		{ExternalId: "i-2", Address: "10.0.0.2"},
+	}
+	hosts, err := repo.SyncSet(ctx, sets[0].PublicId)
+	require.NoError(err)
+	require.Len(hosts, 2)
+	ids := make(map[string]string)
+	for _, h := range hosts {
+		assert.Equal(catalog.PublicId, h.CatalogId)
+		ids[h.ExternalId] = h.PublicId
+	}
+
+	// Hosts keep their public id and pick up changes from the inventory.
+	p.Hosts = []*HostInfo{
+		{ExternalId: "i-2", Name: "two", Address: "10.0.0.22"},
+	}
+	hosts, err = repo.SyncSet(ctx, sets[1].PublicId)
+	require.NoError(err)
+	require.Len(hosts, 1)
+	assert.Equal(ids["i-2"], hosts[0].PublicId)
+	assert.Equal("two", hosts[0].Name)
+	assert.Equal("10.0.0.22", hosts[0].Address)
+
+	// i-1 is no longer in the inventory so it is removed from the set and
+	// deleted because no other set contains it.
+	hosts, err = repo.SyncSet(ctx, sets[0].PublicId)
+	require.NoError(err)
+	require.Len(hosts, 1)
+	assert.Equal(ids["i-2"], hosts[0].PublicId)
+	h, err := repo.LookupHost(ctx, ids["i-1"])
+	require.NoError(err)
+	assert.Nil(h)
+}
+
+func TestRepository_ListSetMembers(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	p := &TestPlugin{
+		Hosts: []*HostInfo{{ExternalId: "i-1", Address: "10.0.0.1"}},
+	}
+	repo, err := NewRepository(rw, rw, kmsCache, TestPlugins(p))
+	require.NoError(err)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	catalog := TestCatalogs(t, conn, prj.GetPublicId(), 1)[0]
+	set := TestSets(t, conn, catalog.PublicId, 1)[0]
+	ctx := context.Background()
+
+	hosts, err := repo.ListSetMembers(ctx, set.PublicId)
+	require.NoError(err)
+	assert.Len(hosts, 1)
+	assert.Equal(1, p.ListCalls)
+
+	// The members are cached until the sync interval has passed.
+	hosts, err = repo.ListSetMembers(ctx, set.PublicId)
+	require.NoError(err)
+	assert.Len(hosts, 1)
+	assert.Equal(1, p.ListCalls)
+
+	// Updating the filter invalidates the cache.
+	set.Filter = "updated"
+	_, _, err = repo.UpdateSet(ctx, prj.GetPublicId(), set, set.Version, []string{"Filter"})
+	require.NoError(err)
+	_, err = repo.ListSetMembers(ctx, set.PublicId)
+	require.NoError(err)
+	assert.Equal(2, p.ListCalls)
+
+	// Errors from the plugin are returned.
+	p.Err = errors.ErrInvalidParameter
+	_, err = repo.SyncSet(ctx, set.PublicId)
+	assert.Truef(errors.Is(err, errors.ErrInvalidParameter), "want err: %q got: %q", errors.ErrInvalidParameter, err)
+}
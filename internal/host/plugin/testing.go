@@ -0,0 +1,105 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPluginName is the name TestPlugin is registered as by TestPlugins.
+const TestPluginName = "test"
+
+// TestPlugin is a HostPlugin for tests which returns Hosts from every call
+// to ListHosts. ValidateCatalog rejects attributes containing the key
+// "invalid" and ValidateSet rejects the filter "invalid".
+type TestPlugin struct {
+	Hosts []*HostInfo
+	Err   error
+
+	// ListCalls is incremented on every call to ListHosts.
+	ListCalls int
+}
+
+var _ HostPlugin = (*TestPlugin)(nil)
+
+// ValidateCatalog implements HostPlugin.
+func (p *TestPlugin) ValidateCatalog(_ context.Context, attributes map[string]interface{}) error {
+	if _, ok := attributes["invalid"]; ok {
+		return fmt.Errorf("invalid attribute")
+	}
+	return nil
+}
+
+// ValidateSet implements HostPlugin.
+func (p *TestPlugin) ValidateSet(_ context.Context, filter string) error {
+	if filter == "invalid" {
+		return fmt.Errorf("invalid filter")
+	}
+	return nil
+}
+
+// ListHosts implements HostPlugin.
+func (p *TestPlugin) ListHosts(_ context.Context, _ map[string]interface{}, _ string) ([]*HostInfo, error) {
+	p.ListCalls++
+	return p.Hosts, p.Err
+}
+
+// TestPlugins returns a plugin map containing p registered as
+// TestPluginName.
+func TestPlugins(p *TestPlugin) map[string]HostPlugin {
+	return map[string]HostPlugin{TestPluginName: p}
+}
+
+// TestCatalogs creates count number of plugin host catalogs using
+// TestPluginName to the provided DB with the provided scope id. If any
+// errors are encountered during the creation of the host catalog, the
+// test will fail.
+func TestCatalogs(t *testing.T, conn *gorm.DB, scopeId string, count int) []*HostCatalog {
+	t.Helper()
+	assert := assert.New(t)
+	var cats []*HostCatalog
+	for i := 0; i < count; i++ {
+		cat, err := NewHostCatalog(scopeId, TestPluginName)
+		assert.NoError(err)
+		assert.NotNil(cat)
+		id, err := newHostCatalogId()
+		assert.NoError(err)
+		assert.NotEmpty(id)
+		cat.PublicId = id
+
+		w := db.New(conn)
+		err2 := w.Create(context.Background(), cat)
+		assert.NoError(err2)
+		cats = append(cats, cat)
+	}
+	return cats
+}
+
+// TestSets creates count number of plugin host sets in the provided DB
+// with the provided catalog id. The catalog must have been created
+// previously. The test will fail if any errors are encountered.
+func TestSets(t *testing.T, conn *gorm.DB, catalogId string, count int) []*HostSet {
+	t.Helper()
+	assert := assert.New(t)
+	var sets []*HostSet
+
+	for i := 0; i < count; i++ {
+		set, err := NewHostSet(catalogId, fmt.Sprintf("filter-%d", i))
+		assert.NoError(err)
+		assert.NotNil(set)
+		id, err := newHostSetId()
+		assert.NoError(err)
+		assert.NotEmpty(id)
+		set.PublicId = id
+
+		w := db.New(conn)
+		err2 := w.Create(context.Background(), set)
+		assert.NoError(err2)
+		sets = append(sets, set)
+	}
+	return sets
+}
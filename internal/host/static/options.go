@@ -14,11 +14,12 @@ type Option func(*options)
 
 // options = how options are represented
 type options struct {
-	withName        string
-	withDescription string
-	withLimit       int
-	withAddress     string
-	withPublicId    string
+	withName             string
+	withDescription      string
+	withLimit            int
+	withStartPageAfterId string
+	withAddress          string
+	withPublicId         string
 }
 
 func getDefaultOptions() options {
@@ -52,6 +53,15 @@ func WithLimit(l int) Option {
 	}
 }
 
+// WithStartPageAfterId provides an option to list only the resources whose
+// public ids sort after the given id. Results are ordered by public id when
+// it is used, allowing listing to be resumed where a previous page ended.
+func WithStartPageAfterId(id string) Option {
+	return func(o *options) {
+		o.withStartPageAfterId = id
+	}
+}
+
 // WithAddress provides an optional address.
 func WithAddress(address string) Option {
 	return func(o *options) {
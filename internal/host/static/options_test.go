@@ -26,6 +26,12 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withLimit = 5
 		assert.Equal(t, opts, testOpts)
 	})
+	t.Run("WithStartPageAfterId", func(t *testing.T) {
+		opts := getOpts(WithStartPageAfterId("hst_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withStartPageAfterId = "hst_1234567890"
+		assert.Equal(t, opts, testOpts)
+	})
 	t.Run("WithAddress", func(t *testing.T) {
 		opts := getOpts(WithAddress("test"))
 		testOpts := getDefaultOptions()
@@ -188,9 +188,10 @@ func (r *Repository) LookupCatalog(ctx context.Context, id string, opt ...Option
 	return c, nil
 }
 
-// ListCatalogs returns a slice of HostCatalogs for the scopeId. WithLimit is the only option supported.
-func (r *Repository) ListCatalogs(ctx context.Context, scopeId string, opt ...Option) ([]*HostCatalog, error) {
-	if scopeId == "" {
+// ListCatalogs returns a slice of HostCatalogs in the given scopes.
+// Supports the WithLimit and WithStartPageAfterId options.
+func (r *Repository) ListCatalogs(ctx context.Context, scopeIds []string, opt ...Option) ([]*HostCatalog, error) {
+	if len(scopeIds) == 0 {
 		return nil, fmt.Errorf("list: static host catalog: missing scope id: %w", errors.ErrInvalidParameter)
 	}
 	opts := getOpts(opt...)
@@ -199,8 +200,12 @@ func (r *Repository) ListCatalogs(ctx context.Context, scopeId string, opt ...Op
 		// non-zero signals an override of the default limit for the repo.
 		limit = opts.withLimit
 	}
+	where, args := "scope_id in (?)", []interface{}{scopeIds}
+	if opts.withStartPageAfterId != "" {
+		where, args = where+" and public_id > ?", append(args, opts.withStartPageAfterId)
+	}
 	var hostCatalogs []*HostCatalog
-	err := r.reader.SearchWhere(ctx, &hostCatalogs, "scope_id = ?", []interface{}{scopeId}, db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &hostCatalogs, where, args, db.WithLimit(limit), db.WithOrder("public_id"))
 	if err != nil {
 		return nil, fmt.Errorf("list: static host catalog: %w", err)
 	}
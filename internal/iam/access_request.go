@@ -0,0 +1,121 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultAccessRequestTableName = "iam_access_request"
+)
+
+// AccessRequestStatus is the review status of an access request.
+type AccessRequestStatus string
+
+const (
+	AccessRequestPending  AccessRequestStatus = "pending"
+	AccessRequestApproved AccessRequestStatus = "approved"
+	AccessRequestDenied   AccessRequestStatus = "denied"
+)
+
+// AccessRequest is a user's request for the authorize-session action on a
+// target for a limited duration. Access requests are created pending and are
+// reviewed once, by a user other than the requester. An approved request
+// grants the requester authorize-session on the target until its expiration
+// time.
+type AccessRequest struct {
+	*store.AccessRequest
+	tableName string `gorm:"-"`
+}
+
+// ensure that AccessRequest implements the interfaces of: Cloneable and
+// db.VetForWriter.
+var _ Cloneable = (*AccessRequest)(nil)
+var _ db.VetForWriter = (*AccessRequest)(nil)
+
+// NewAccessRequest creates a new in memory pending access request by the user
+// for the target, which must be in the scope. No options are currently
+// supported.
+func NewAccessRequest(scopeId, targetId, userId, justification string, durationSeconds uint32, opt ...Option) (*AccessRequest, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("new access request: missing scope id %w", errors.ErrInvalidParameter)
+	}
+	if targetId == "" {
+		return nil, fmt.Errorf("new access request: missing target id %w", errors.ErrInvalidParameter)
+	}
+	if userId == "" {
+		return nil, fmt.Errorf("new access request: missing user id %w", errors.ErrInvalidParameter)
+	}
+	if justification == "" {
+		return nil, fmt.Errorf("new access request: missing justification %w", errors.ErrInvalidParameter)
+	}
+	if durationSeconds == 0 {
+		return nil, fmt.Errorf("new access request: missing duration %w", errors.ErrInvalidParameter)
+	}
+	return &AccessRequest{
+		AccessRequest: &store.AccessRequest{
+			ScopeId:         scopeId,
+			TargetId:        targetId,
+			UserId:          userId,
+			Justification:   justification,
+			DurationSeconds: durationSeconds,
+			Status:          string(AccessRequestPending),
+		},
+	}, nil
+}
+
+func allocAccessRequest() AccessRequest {
+	return AccessRequest{
+		AccessRequest: &store.AccessRequest{},
+	}
+}
+
+// Clone creates a clone of the AccessRequest.
+func (a *AccessRequest) Clone() interface{} {
+	cp := proto.Clone(a.AccessRequest)
+	return &AccessRequest{
+		AccessRequest: cp.(*store.AccessRequest),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface and validates the access
+// request before it's written.
+func (a *AccessRequest) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType, opt ...db.Option) error {
+	if a.PublicId == "" {
+		return fmt.Errorf("access request vet for write: missing public id: %w", errors.ErrInvalidParameter)
+	}
+	if err := validateScopeForWrite(ctx, r, a, opType, opt...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (a *AccessRequest) validScopeTypes() []scope.Type {
+	return []scope.Type{scope.Project}
+}
+
+// GetScope returns the scope for the AccessRequest.
+func (a *AccessRequest) GetScope(ctx context.Context, r db.Reader) (*Scope, error) {
+	return LookupScope(ctx, r, a)
+}
+
+// TableName returns the tablename to override the default gorm table name.
+func (a *AccessRequest) TableName() string {
+	if a.tableName != "" {
+		return a.tableName
+	}
+	return defaultAccessRequestTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (a *AccessRequest) SetTableName(n string) {
+	a.tableName = n
+}
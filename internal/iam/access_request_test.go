@@ -0,0 +1,111 @@
+package iam
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNewAccessRequest(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		scopeId         string
+		targetId        string
+		userId          string
+		justification   string
+		durationSeconds uint32
+	}
+	valid := args{
+		scopeId:         "p_1234567890",
+		targetId:        "ttcp_1234567890",
+		userId:          "u_1234567890",
+		justification:   "on-call",
+		durationSeconds: 3600,
+	}
+	tests := []struct {
+		name    string
+		args    func(args) args
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			args: func(a args) args { return a },
+		},
+		{
+			name:    "missing-scope",
+			args:    func(a args) args { a.scopeId = ""; return a },
+			wantErr: true,
+		},
+		{
+			name:    "missing-target",
+			args:    func(a args) args { a.targetId = ""; return a },
+			wantErr: true,
+		},
+		{
+			name:    "missing-user",
+			args:    func(a args) args { a.userId = ""; return a },
+			wantErr: true,
+		},
+		{
+			name:    "missing-justification",
+			args:    func(a args) args { a.justification = ""; return a },
+			wantErr: true,
+		},
+		{
+			name:    "missing-duration",
+			args:    func(a args) args { a.durationSeconds = 0; return a },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			a := tt.args(valid)
+			got, err := NewAccessRequest(a.scopeId, a.targetId, a.userId, a.justification, a.durationSeconds)
+			if tt.wantErr {
+				require.Error(err)
+				assert.True(errors.Is(err, errors.ErrInvalidParameter))
+				return
+			}
+			require.NoError(err)
+			want := &AccessRequest{
+				AccessRequest: &store.AccessRequest{
+					ScopeId:         a.scopeId,
+					TargetId:        a.targetId,
+					UserId:          a.userId,
+					Justification:   a.justification,
+					DurationSeconds: a.durationSeconds,
+					Status:          string(AccessRequestPending),
+				},
+			}
+			assert.Equal(want, got)
+		})
+	}
+}
+
+func TestAccessRequest_Clone(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	req, err := NewAccessRequest("p_1234567890", "ttcp_1234567890", "u_1234567890", "on-call", 3600)
+	require.NoError(err)
+	req.PublicId = "areq_1234567890"
+	cp := req.Clone()
+	assert.True(proto.Equal(cp.(*AccessRequest).AccessRequest, req.AccessRequest))
+
+	cp.(*AccessRequest).Justification = "other"
+	assert.Equal("on-call", req.Justification)
+}
+
+func TestAccessRequest_SetTableName(t *testing.T) {
+	t.Parallel()
+	req := allocAccessRequest()
+	assert.Equal(t, defaultAccessRequestTableName, req.TableName())
+	req.SetTableName("overridden")
+	assert.Equal(t, "overridden", req.TableName())
+	req.SetTableName("")
+	assert.Equal(t, defaultAccessRequestTableName, req.TableName())
+}
@@ -17,6 +17,20 @@ const (
 	defaultGroupTableName = "iam_group"
 )
 
+// The directory type of a group is the type of the external directory its
+// members are synchronized from.
+const (
+	// DirectoryLdap groups are bound to the LDAP group with the DN in the
+	// group's directory group. Their members are read from the directory
+	// periodically.
+	DirectoryLdap = "ldap"
+
+	// DirectoryScim groups are bound to the SCIM group with the display name
+	// in the group's directory group. Their members are pushed by a SCIM
+	// client.
+	DirectoryScim = "scim"
+)
+
 // Group is made up of principals which are scoped to an org.
 type Group struct {
 	*store.Group
@@ -29,17 +43,25 @@ var _ Cloneable = (*Group)(nil)
 var _ db.VetForWriter = (*Group)(nil)
 
 // NewGroup creates a new in memory group with a scope (project/org)
-// and allowed options include: withDescripion, WithName.
+// and allowed options include: withDescripion, WithName, WithDirectoryType,
+// WithDirectoryGroup.
 func NewGroup(scopeId string, opt ...Option) (*Group, error) {
 	if scopeId == "" {
 		return nil, fmt.Errorf("new group: missing scope id %w", errors.ErrInvalidParameter)
 	}
 	opts := getOpts(opt...)
+	switch opts.withDirectoryType {
+	case "", DirectoryLdap, DirectoryScim:
+	default:
+		return nil, fmt.Errorf("new group: unknown directory type %q: %w", opts.withDirectoryType, errors.ErrInvalidParameter)
+	}
 	g := &Group{
 		Group: &store.Group{
-			Name:        opts.withName,
-			Description: opts.withDescription,
-			ScopeId:     scopeId,
+			Name:           opts.withName,
+			Description:    opts.withDescription,
+			ScopeId:        scopeId,
+			DirectoryType:  opts.withDirectoryType,
+			DirectoryGroup: opts.withDirectoryGroup,
 		},
 	}
 	return g, nil
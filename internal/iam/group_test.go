@@ -30,13 +30,14 @@ func TestNewGroup(t *testing.T) {
 		opt           []Option
 	}
 	tests := []struct {
-		name            string
-		args            args
-		wantErr         bool
-		wantErrMsg      string
-		wantIsErr       error
-		wantName        string
-		wantDescription string
+		name              string
+		args              args
+		wantErr           bool
+		wantErrMsg        string
+		wantIsErr         error
+		wantName          string
+		wantDescription   string
+		wantDirectoryType string
 	}{
 		{
 			name: "valid",
@@ -74,6 +75,25 @@ func TestNewGroup(t *testing.T) {
 			wantErrMsg: "new group: missing scope id invalid parameter:",
 			wantIsErr:  errors.ErrInvalidParameter,
 		},
+		{
+			name: "valid-directory",
+			args: args{
+				scopePublicId: org.PublicId,
+				opt:           []Option{WithDirectoryType(DirectoryLdap), WithDirectoryGroup("cn=admins,dc=example,dc=com")},
+			},
+			wantErr:           false,
+			wantDirectoryType: DirectoryLdap,
+		},
+		{
+			name: "unknown-directory-type",
+			args: args{
+				scopePublicId: org.PublicId,
+				opt:           []Option{WithDirectoryType("nis"), WithDirectoryGroup("admins")},
+			},
+			wantErr:    true,
+			wantErrMsg: `new group: unknown directory type "nis": invalid parameter`,
+			wantIsErr:  errors.ErrInvalidParameter,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -90,6 +110,7 @@ func TestNewGroup(t *testing.T) {
 			require.NoError(err)
 			assert.Equal(tt.wantName, got.Name)
 			assert.Equal(tt.wantDescription, got.Description)
+			assert.Equal(tt.wantDirectoryType, got.DirectoryType)
 			assert.Empty(got.PublicId)
 		})
 	}
@@ -9,10 +9,11 @@ import (
 )
 
 const (
-	UserPrefix      = "u"
-	GroupPrefix     = "g"
-	RolePrefix      = "r"
-	RoleGrantPrefix = "rg"
+	UserPrefix          = "u"
+	GroupPrefix         = "g"
+	RolePrefix          = "r"
+	RoleGrantPrefix     = "rg"
+	AccessRequestPrefix = "areq"
 )
 
 func newRoleId() (string, error) {
@@ -39,6 +40,14 @@ func newGroupId() (string, error) {
 	return id, nil
 }
 
+func newAccessRequestId() (string, error) {
+	id, err := db.NewPublicId(AccessRequestPrefix)
+	if err != nil {
+		return "", fmt.Errorf("new access request id: %w", err)
+	}
+	return id, nil
+}
+
 func newScopeId(scopeType scope.Type) (string, error) {
 	if scopeType == scope.Unknown {
 		return "", fmt.Errorf("new scope id: unknown is not supported %w", errors.ErrInvalidParameter)
@@ -1,6 +1,9 @@
 package iam
 
-import "io"
+import (
+	"io"
+	"time"
+)
 
 // getOpts - iterate the inbound Options and return a struct
 func getOpts(opt ...Option) options {
@@ -21,14 +24,20 @@ type options struct {
 	withDescription             string
 	withGroupGrants             bool
 	withLimit                   int
+	withStartPageAfterId        string
 	withAutoVivify              bool
 	withGrantScopeId            string
+	withGrantScopeInheritance   string
 	withSkipVetForWrite         bool
 	withDisassociate            bool
 	withSkipAdminRoleCreation   bool
 	withSkipDefaultRoleCreation bool
 	withUserId                  string
 	withRandomReader            io.Reader
+	withNotBeforeTime           time.Time
+	withExpirationTime          time.Time
+	withDirectoryType           string
+	withDirectoryGroup          string
 }
 
 func getDefaultOptions() options {
@@ -81,6 +90,15 @@ func WithLimit(limit int) Option {
 	}
 }
 
+// WithStartPageAfterId provides an option to list only the resources whose
+// public ids sort after the given id. Results are ordered by public id when
+// it is used, allowing listing to be resumed where a previous page ended.
+func WithStartPageAfterId(id string) Option {
+	return func(o *options) {
+		o.withStartPageAfterId = id
+	}
+}
+
 // WithAutoVivify provides an option to enable user auto vivification when
 // calling repo.LookupUserWithLogin().
 func WithAutoVivify(enable bool) Option {
@@ -97,6 +115,16 @@ func WithGrantScopeId(id string) Option {
 	}
 }
 
+// WithGrantScopeInheritance provides an option to specify whether the grants
+// of roles also apply to the children or to all descendants of their grant
+// scope. It must be one of GrantScopeNoInheritance, GrantScopeChildren and
+// GrantScopeDescendants.
+func WithGrantScopeInheritance(inheritance string) Option {
+	return func(o *options) {
+		o.withGrantScopeInheritance = inheritance
+	}
+}
+
 // WithSkipVetForWrite provides an option to allow skipping vet checks to allow
 // testing lower-level SQL triggers and constraints
 func WithSkipVetForWrite(enable bool) Option {
@@ -142,3 +170,36 @@ func WithRandomReader(reader io.Reader) Option {
 		o.withRandomReader = reader
 	}
 }
+
+// WithNotBeforeTime provides an option to specify the time before which a
+// principal's assignment to a role does not apply.
+func WithNotBeforeTime(t time.Time) Option {
+	return func(o *options) {
+		o.withNotBeforeTime = t
+	}
+}
+
+// WithExpirationTime provides an option to specify the time at which a
+// principal's assignment to a role expires.
+func WithExpirationTime(t time.Time) Option {
+	return func(o *options) {
+		o.withExpirationTime = t
+	}
+}
+
+// WithDirectoryType provides an option to specify the type of the external
+// directory a group is bound to. It must be one of DirectoryLdap and
+// DirectoryScim.
+func WithDirectoryType(t string) Option {
+	return func(o *options) {
+		o.withDirectoryType = t
+	}
+}
+
+// WithDirectoryGroup provides an option to specify the group in the external
+// directory a group is bound to.
+func WithDirectoryGroup(g string) Option {
+	return func(o *options) {
+		o.withDirectoryGroup = g
+	}
+}
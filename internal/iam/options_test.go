@@ -2,6 +2,7 @@ package iam
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -41,6 +42,13 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withLimit = 1
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithStartPageAfterId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithStartPageAfterId("o_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withStartPageAfterId = "o_1234567890"
+		assert.Equal(opts, testOpts)
+	})
 	t.Run("WithAutoVivify", func(t *testing.T) {
 		assert := assert.New(t)
 		// test default of false
@@ -74,4 +82,34 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withDisassociate = true
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithNotBeforeTime", func(t *testing.T) {
+		assert := assert.New(t)
+		now := time.Now()
+		opts := getOpts(WithNotBeforeTime(now))
+		testOpts := getDefaultOptions()
+		testOpts.withNotBeforeTime = now
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithExpirationTime", func(t *testing.T) {
+		assert := assert.New(t)
+		now := time.Now()
+		opts := getOpts(WithExpirationTime(now))
+		testOpts := getDefaultOptions()
+		testOpts.withExpirationTime = now
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithDirectoryType", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithDirectoryType(DirectoryLdap))
+		testOpts := getDefaultOptions()
+		testOpts.withDirectoryType = DirectoryLdap
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithDirectoryGroup", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithDirectoryGroup("cn=admins,dc=example,dc=com"))
+		testOpts := getDefaultOptions()
+		testOpts.withDirectoryGroup = "cn=admins,dc=example,dc=com"
+		assert.Equal(opts, testOpts)
+	})
 }
@@ -5,9 +5,11 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/iam/store"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // RoleType defines the possible types for roles.
@@ -71,8 +73,9 @@ type UserRole struct {
 var _ Cloneable = (*UserRole)(nil)
 var _ db.VetForWriter = (*UserRole)(nil)
 
-// NewUserRole creates a new user role in memory. No options are supported
-// currently.
+// NewUserRole creates a new user role in memory. The WithNotBeforeTime and
+// WithExpirationTime options bound the time during which the assignment
+// applies.
 func NewUserRole(roleId, userId string, opt ...Option) (*UserRole, error) {
 	if roleId == "" {
 		return nil, fmt.Errorf("new user role: missing role id %w", errors.ErrInvalidParameter)
@@ -80,12 +83,23 @@ func NewUserRole(roleId, userId string, opt ...Option) (*UserRole, error) {
 	if userId == "" {
 		return nil, fmt.Errorf("new user role: missing user id %w", errors.ErrInvalidParameter)
 	}
-	return &UserRole{
+	opts := getOpts(opt...)
+	r := &UserRole{
 		UserRole: &store.UserRole{
 			PrincipalId: userId,
 			RoleId:      roleId,
 		},
-	}, nil
+	}
+	if !opts.withNotBeforeTime.IsZero() {
+		r.NotBeforeTime = &timestamp.Timestamp{Timestamp: timestamppb.New(opts.withNotBeforeTime)}
+	}
+	if !opts.withExpirationTime.IsZero() {
+		r.ExpirationTime = &timestamp.Timestamp{Timestamp: timestamppb.New(opts.withExpirationTime)}
+	}
+	if err := validateTimeBounds(r.NotBeforeTime, r.ExpirationTime); err != nil {
+		return nil, fmt.Errorf("new user role: %w", err)
+	}
+	return r, nil
 }
 
 func allocUserRole() UserRole {
@@ -110,6 +124,9 @@ func (role *UserRole) VetForWrite(ctx context.Context, r db.Reader, opType db.Op
 	if role.PrincipalId == "" {
 		return fmt.Errorf("new user role: missing user id %w", errors.ErrInvalidParameter)
 	}
+	if err := validateTimeBounds(role.NotBeforeTime, role.ExpirationTime); err != nil {
+		return fmt.Errorf("new user role: %w", err)
+	}
 	return nil
 }
 
@@ -144,8 +161,9 @@ type GroupRole struct {
 var _ Cloneable = (*GroupRole)(nil)
 var _ db.VetForWriter = (*GroupRole)(nil)
 
-// NewGroupRole creates a new group role in memory. No options are supported
-// currently.
+// NewGroupRole creates a new group role in memory. The WithNotBeforeTime and
+// WithExpirationTime options bound the time during which the assignment
+// applies.
 func NewGroupRole(roleId, groupId string, opt ...Option) (*GroupRole, error) {
 	if roleId == "" {
 		return nil, fmt.Errorf("new group role: missing role id %w", errors.ErrInvalidParameter)
@@ -153,12 +171,23 @@ func NewGroupRole(roleId, groupId string, opt ...Option) (*GroupRole, error) {
 	if groupId == "" {
 		return nil, fmt.Errorf("new group role: missing group id %w", errors.ErrInvalidParameter)
 	}
-	return &GroupRole{
+	opts := getOpts(opt...)
+	r := &GroupRole{
 		GroupRole: &store.GroupRole{
 			PrincipalId: groupId,
 			RoleId:      roleId,
 		},
-	}, nil
+	}
+	if !opts.withNotBeforeTime.IsZero() {
+		r.NotBeforeTime = &timestamp.Timestamp{Timestamp: timestamppb.New(opts.withNotBeforeTime)}
+	}
+	if !opts.withExpirationTime.IsZero() {
+		r.ExpirationTime = &timestamp.Timestamp{Timestamp: timestamppb.New(opts.withExpirationTime)}
+	}
+	if err := validateTimeBounds(r.NotBeforeTime, r.ExpirationTime); err != nil {
+		return nil, fmt.Errorf("new group role: %w", err)
+	}
+	return r, nil
 }
 
 func allocGroupRole() GroupRole {
@@ -183,6 +212,9 @@ func (role *GroupRole) VetForWrite(ctx context.Context, r db.Reader, opType db.O
 	if role.PrincipalId == "" {
 		return fmt.Errorf("new group role: missing user id %w", errors.ErrInvalidParameter)
 	}
+	if err := validateTimeBounds(role.NotBeforeTime, role.ExpirationTime); err != nil {
+		return fmt.Errorf("new group role: %w", err)
+	}
 	return nil
 }
 
@@ -205,3 +237,15 @@ func (r *GroupRole) SetTableName(n string) {
 		r.tableName = n
 	}
 }
+
+// validateTimeBounds returns an error if both times of a principal role are
+// set and the not before time is not before the expiration time.
+func validateTimeBounds(notBefore, expiration *timestamp.Timestamp) error {
+	if notBefore.GetTimestamp() == nil || expiration.GetTimestamp() == nil {
+		return nil
+	}
+	if !notBefore.GetTimestamp().AsTime().Before(expiration.GetTimestamp().AsTime()) {
+		return fmt.Errorf("not before time must be before expiration time: %w", errors.ErrInvalidParameter)
+	}
+	return nil
+}
@@ -3,13 +3,16 @@ package iam
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/iam/store"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestNewUserRole(t *testing.T) {
@@ -21,6 +24,8 @@ func TestNewUserRole(t *testing.T) {
 	orgRole := TestRole(t, conn, org.PublicId)
 	projRole := TestRole(t, conn, proj.PublicId)
 	user := TestUser(t, repo, org.PublicId)
+	notBefore := time.Now().Truncate(time.Second)
+	expiration := notBefore.Add(4 * time.Hour)
 
 	type args struct {
 		roleId string
@@ -60,6 +65,33 @@ func TestNewUserRole(t *testing.T) {
 				return &r
 			}(),
 		},
+		{
+			name: "valid-time-bounds",
+			args: args{
+				roleId: orgRole.PublicId,
+				userId: user.PublicId,
+				opt:    []Option{WithNotBeforeTime(notBefore), WithExpirationTime(expiration)},
+			},
+			want: func() *UserRole {
+				r := allocUserRole()
+				r.RoleId = orgRole.PublicId
+				r.PrincipalId = user.PublicId
+				r.NotBeforeTime = &timestamp.Timestamp{Timestamp: timestamppb.New(notBefore)}
+				r.ExpirationTime = &timestamp.Timestamp{Timestamp: timestamppb.New(expiration)}
+				return &r
+			}(),
+		},
+		{
+			name: "expiration-before-not-before",
+			args: args{
+				roleId: orgRole.PublicId,
+				userId: user.PublicId,
+				opt:    []Option{WithNotBeforeTime(expiration), WithExpirationTime(notBefore)},
+			},
+			want:      nil,
+			wantErr:   true,
+			wantIsErr: errors.ErrInvalidParameter,
+		},
 		{
 			name: "empty-role-id",
 			args: args{
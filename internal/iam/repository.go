@@ -60,7 +60,10 @@ func (r *Repository) list(ctx context.Context, resources interface{}, where stri
 		// non-zero signals an override of the default limit for the repo.
 		limit = opts.withLimit
 	}
-	return r.reader.SearchWhere(ctx, resources, where, args, db.WithLimit(limit))
+	if opts.withStartPageAfterId != "" {
+		where, args = fmt.Sprintf("(%s) and public_id > ?", where), append(args, opts.withStartPageAfterId)
+	}
+	return r.reader.SearchWhere(ctx, resources, where, args, db.WithLimit(limit), db.WithOrder("public_id"))
 }
 
 // create will create a new iam resource in the db repository with an oplog entry
@@ -0,0 +1,210 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+// CreateAccessRequest will create an access request in the repository and
+// return the written access request. The scope id of the access request must
+// be the scope of its target. No options are currently supported.
+func (r *Repository) CreateAccessRequest(ctx context.Context, req *AccessRequest, opt ...Option) (*AccessRequest, error) {
+	if req == nil {
+		return nil, fmt.Errorf("create access request: missing access request %w", errors.ErrInvalidParameter)
+	}
+	if req.AccessRequest == nil {
+		return nil, fmt.Errorf("create access request: missing access request store %w", errors.ErrInvalidParameter)
+	}
+	if req.PublicId != "" {
+		return nil, fmt.Errorf("create access request: public id not empty: %w", errors.ErrInvalidParameter)
+	}
+	if req.ScopeId == "" {
+		return nil, fmt.Errorf("create access request: missing scope id: %w", errors.ErrInvalidParameter)
+	}
+	if req.TargetId == "" {
+		return nil, fmt.Errorf("create access request: missing target id: %w", errors.ErrInvalidParameter)
+	}
+	if req.UserId == "" {
+		return nil, fmt.Errorf("create access request: missing user id: %w", errors.ErrInvalidParameter)
+	}
+	id, err := newAccessRequestId()
+	if err != nil {
+		return nil, fmt.Errorf("create access request: %w", err)
+	}
+	newReq := req.Clone().(*AccessRequest)
+	newReq.PublicId = id
+	newReq.Status = string(AccessRequestPending)
+
+	metadata, oplogWrapper, err := r.accessRequestOplog(ctx, newReq, oplog.OpType_OP_TYPE_CREATE)
+	if err != nil {
+		return nil, fmt.Errorf("create access request: %w", err)
+	}
+
+	var returnedReq *AccessRequest
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedReq = newReq.Clone().(*AccessRequest)
+			return w.Create(ctx, returnedReq, db.WithOplog(oplogWrapper, metadata))
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create access request: %w for %s", err, newReq.PublicId)
+	}
+	return returnedReq, nil
+}
+
+// LookupAccessRequest will look up an access request in the repository. If
+// the access request is not found, it will return nil, nil.
+func (r *Repository) LookupAccessRequest(ctx context.Context, withPublicId string, opt ...Option) (*AccessRequest, error) {
+	if withPublicId == "" {
+		return nil, fmt.Errorf("lookup access request: missing public id %w", errors.ErrInvalidParameter)
+	}
+	req := allocAccessRequest()
+	req.PublicId = withPublicId
+	if err := r.reader.LookupByPublicId(ctx, &req); err != nil {
+		if errors.Is(err, errors.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup access request: failed %w for %s", err, withPublicId)
+	}
+	return &req, nil
+}
+
+// ListAccessRequests in the scopes and supports the WithLimit and
+// WithStartPageAfterId options.
+func (r *Repository) ListAccessRequests(ctx context.Context, withScopeIds []string, opt ...Option) ([]*AccessRequest, error) {
+	if len(withScopeIds) == 0 {
+		return nil, fmt.Errorf("list access requests: missing scope id %w", errors.ErrInvalidParameter)
+	}
+	var reqs []*AccessRequest
+	if err := r.list(ctx, &reqs, "scope_id in (?)", []interface{}{withScopeIds}, opt...); err != nil {
+		return nil, fmt.Errorf("list access requests: %w", err)
+	}
+	return reqs, nil
+}
+
+// ApproveAccessRequest approves the pending access request on behalf of the
+// reviewer, who must not be the requester. The requester is granted
+// authorize-session on the target from then until the requested duration has
+// passed. The access request's current db version must match the version or
+// an error will be returned.
+func (r *Repository) ApproveAccessRequest(ctx context.Context, withPublicId string, version uint32, reviewerId, comment string, opt ...Option) (*AccessRequest, error) {
+	req, err := r.reviewAccessRequest(ctx, withPublicId, version, reviewerId, comment, AccessRequestApproved)
+	if err != nil {
+		return nil, fmt.Errorf("approve access request: %w", err)
+	}
+	return req, nil
+}
+
+// DenyAccessRequest denies the pending access request on behalf of the
+// reviewer, who must not be the requester. The access request's current db
+// version must match the version or an error will be returned.
+func (r *Repository) DenyAccessRequest(ctx context.Context, withPublicId string, version uint32, reviewerId, comment string, opt ...Option) (*AccessRequest, error) {
+	req, err := r.reviewAccessRequest(ctx, withPublicId, version, reviewerId, comment, AccessRequestDenied)
+	if err != nil {
+		return nil, fmt.Errorf("deny access request: %w", err)
+	}
+	return req, nil
+}
+
+func (r *Repository) reviewAccessRequest(ctx context.Context, withPublicId string, version uint32, reviewerId, comment string, status AccessRequestStatus) (*AccessRequest, error) {
+	if withPublicId == "" {
+		return nil, fmt.Errorf("missing public id %w", errors.ErrInvalidParameter)
+	}
+	if version == 0 {
+		return nil, fmt.Errorf("version cannot be zero: %w", errors.ErrInvalidParameter)
+	}
+	if reviewerId == "" {
+		return nil, fmt.Errorf("missing reviewer id %w", errors.ErrInvalidParameter)
+	}
+	existing, err := r.LookupAccessRequest(ctx, withPublicId)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, fmt.Errorf("access request %s: %w", withPublicId, errors.ErrRecordNotFound)
+	}
+	if existing.GetStatus() != string(AccessRequestPending) {
+		return nil, fmt.Errorf("access request %s is already %s: %w", withPublicId, existing.GetStatus(), errors.ErrInvalidParameter)
+	}
+	if existing.GetUserId() == reviewerId {
+		return nil, fmt.Errorf("user %s cannot review their own access request: %w", reviewerId, errors.ErrInvalidParameter)
+	}
+
+	metadata, oplogWrapper, err := r.accessRequestOplog(ctx, existing, oplog.OpType_OP_TYPE_UPDATE)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := allocAccessRequest()
+	updated.PublicId = withPublicId
+	updated.Status = string(status)
+	updated.ReviewerId = reviewerId
+	updated.ReviewComment = comment
+	fieldMask := []string{"Status", "ReviewerId"}
+	var nullFields []string
+	if comment != "" {
+		fieldMask = append(fieldMask, "ReviewComment")
+	} else {
+		nullFields = append(nullFields, "ReviewComment")
+	}
+
+	var returnedReq *AccessRequest
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			rowsUpdated, err := w.Update(ctx, &updated, fieldMask, nullFields, db.WithOplog(oplogWrapper, metadata), db.WithVersion(&version))
+			if err != nil {
+				return err
+			}
+			if rowsUpdated != 1 {
+				return fmt.Errorf("access request %s or version %d: %w", withPublicId, version, errors.ErrRecordNotFound)
+			}
+			// the review and expiration times are set by the database
+			req := allocAccessRequest()
+			req.PublicId = withPublicId
+			if err := reader.LookupByPublicId(ctx, &req); err != nil {
+				return err
+			}
+			returnedReq = &req
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return returnedReq, nil
+}
+
+// accessRequestOplog returns the oplog metadata and wrapper for writing the
+// access request.
+func (r *Repository) accessRequestOplog(ctx context.Context, req *AccessRequest, opType oplog.OpType) (oplog.Metadata, wrapping.Wrapper, error) {
+	scope, err := req.GetScope(ctx, r.reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to get scope: %w", err)
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scope.GetPublicId(), kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to get oplog wrapper: %w", err)
+	}
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{req.GetPublicId()},
+		"scope-id":           []string{scope.GetPublicId()},
+		"scope-type":         []string{scope.GetType()},
+		"resource-type":      []string{resource.AccessRequest.String()},
+		"op-type":            []string{opType.String()},
+	}
+	return metadata, oplogWrapper, nil
+}
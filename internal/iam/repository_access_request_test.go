@@ -0,0 +1,181 @@
+package iam
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testTarget creates a tcp target in the project and returns its id. Targets
+// are created with SQL since the target package depends on this one.
+func testTarget(t *testing.T, conn *gorm.DB, projId string) string {
+	t.Helper()
+	id, err := db.NewPublicId("ttcp")
+	require.NoError(t, err)
+	_, err = db.New(conn).Exec(context.Background(),
+		"insert into target_tcp (public_id, scope_id, name) values (?, ?, ?)",
+		[]interface{}{id, projId, id})
+	require.NoError(t, err)
+	return id
+}
+
+func TestRepository_CreateAccessRequest(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+	user := TestUser(t, repo, org.PublicId)
+	targetId := testTarget(t, conn, proj.PublicId)
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		req, err := NewAccessRequest(proj.PublicId, targetId, user.PublicId, "on-call", 4*3600)
+		require.NoError(err)
+		got, err := repo.CreateAccessRequest(context.Background(), req)
+		require.NoError(err)
+		assert.NotEmpty(got.PublicId)
+		assert.Equal(string(AccessRequestPending), got.Status)
+		assert.NotNil(got.CreateTime)
+
+		found, err := repo.LookupAccessRequest(context.Background(), got.PublicId)
+		require.NoError(err)
+		assert.Equal(got.TargetId, found.TargetId)
+		assert.Equal(got.UserId, found.UserId)
+		assert.Nil(found.ExpirationTime)
+
+		err = db.TestVerifyOplog(t, db.New(conn), got.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_CREATE), db.WithCreateNotBefore(10*time.Second))
+		assert.NoError(err)
+	})
+	t.Run("target-in-other-scope", func(t *testing.T) {
+		require := require.New(t)
+		_, otherProj := TestScopes(t, repo)
+		req, err := NewAccessRequest(otherProj.PublicId, targetId, user.PublicId, "on-call", 3600)
+		require.NoError(err)
+		_, err = repo.CreateAccessRequest(context.Background(), req)
+		require.Error(err)
+	})
+	t.Run("public-id-set", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		req, err := NewAccessRequest(proj.PublicId, targetId, user.PublicId, "on-call", 3600)
+		require.NoError(err)
+		req.PublicId = "areq_1234567890"
+		_, err = repo.CreateAccessRequest(context.Background(), req)
+		require.Error(err)
+		assert.True(errors.Is(err, errors.ErrInvalidParameter))
+	})
+}
+
+func TestRepository_ListAccessRequests(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+	user := TestUser(t, repo, org.PublicId)
+	targetId := testTarget(t, conn, proj.PublicId)
+
+	for i := 0; i < 3; i++ {
+		TestAccessRequest(t, conn, proj.PublicId, targetId, user.PublicId)
+	}
+	got, err := repo.ListAccessRequests(context.Background(), []string{proj.PublicId})
+	require.NoError(err)
+	assert.Len(got, 3)
+
+	got, err = repo.ListAccessRequests(context.Background(), []string{proj.PublicId}, WithLimit(1))
+	require.NoError(err)
+	assert.Len(got, 1)
+
+	_, err = repo.ListAccessRequests(context.Background(), nil)
+	assert.True(errors.Is(err, errors.ErrInvalidParameter))
+}
+
+func TestRepository_ReviewAccessRequest(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+	requester := TestUser(t, repo, org.PublicId)
+	reviewer := TestUser(t, repo, org.PublicId)
+	targetId := testTarget(t, conn, proj.PublicId)
+
+	hasTargetGrant := func(t *testing.T) bool {
+		t.Helper()
+		grants, err := repo.GrantsForUser(context.Background(), requester.PublicId)
+		require.NoError(t, err)
+		for _, g := range grants {
+			if g.ScopeId == proj.PublicId && g.Grant == "id="+targetId+";actions=authorize-session" {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Run("approve", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		req := TestAccessRequest(t, conn, proj.PublicId, targetId, requester.PublicId)
+		assert.False(hasTargetGrant(t))
+
+		got, err := repo.ApproveAccessRequest(context.Background(), req.PublicId, req.Version, reviewer.PublicId, "approved for on-call")
+		require.NoError(err)
+		assert.Equal(string(AccessRequestApproved), got.Status)
+		assert.Equal(reviewer.PublicId, got.ReviewerId)
+		assert.Equal("approved for on-call", got.ReviewComment)
+		require.NotNil(got.ReviewTime)
+		require.NotNil(got.ExpirationTime)
+		assert.Equal(time.Duration(req.DurationSeconds)*time.Second,
+			got.ExpirationTime.Timestamp.AsTime().Sub(got.ReviewTime.Timestamp.AsTime()))
+		assert.True(hasTargetGrant(t))
+
+		err = db.TestVerifyOplog(t, db.New(conn), req.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_UPDATE), db.WithCreateNotBefore(10*time.Second))
+		assert.NoError(err)
+
+		// a request can only be reviewed once
+		_, err = repo.DenyAccessRequest(context.Background(), req.PublicId, got.Version, reviewer.PublicId, "")
+		require.Error(err)
+		assert.True(errors.Is(err, errors.ErrInvalidParameter))
+	})
+	t.Run("deny", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		otherTargetId := testTarget(t, conn, proj.PublicId)
+		req := TestAccessRequest(t, conn, proj.PublicId, otherTargetId, requester.PublicId)
+		got, err := repo.DenyAccessRequest(context.Background(), req.PublicId, req.Version, reviewer.PublicId, "")
+		require.NoError(err)
+		assert.Equal(string(AccessRequestDenied), got.Status)
+		assert.NotNil(got.ReviewTime)
+		assert.Nil(got.ExpirationTime)
+
+		grants, err := repo.GrantsForUser(context.Background(), requester.PublicId)
+		require.NoError(err)
+		assert.NotContains(grants, perms.GrantPair{ScopeId: proj.PublicId, Grant: "id=" + otherTargetId + ";actions=authorize-session", RoleId: req.PublicId})
+	})
+	t.Run("own-request", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		req := TestAccessRequest(t, conn, proj.PublicId, targetId, requester.PublicId)
+		_, err := repo.ApproveAccessRequest(context.Background(), req.PublicId, req.Version, requester.PublicId, "")
+		require.Error(err)
+		assert.True(errors.Is(err, errors.ErrInvalidParameter))
+	})
+	t.Run("bad-version", func(t *testing.T) {
+		require := require.New(t)
+		req := TestAccessRequest(t, conn, proj.PublicId, targetId, requester.PublicId)
+		_, err := repo.ApproveAccessRequest(context.Background(), req.PublicId, req.Version+1, reviewer.PublicId, "")
+		require.Error(err)
+	})
+	t.Run("not-found", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		_, err := repo.ApproveAccessRequest(context.Background(), "areq_1234567890", 1, reviewer.PublicId, "")
+		require.Error(err)
+		assert.True(errors.Is(err, errors.ErrRecordNotFound))
+	})
+}
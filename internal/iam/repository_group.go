@@ -46,8 +46,9 @@ func (r *Repository) CreateGroup(ctx context.Context, group *Group, opt ...Optio
 // UpdateGroup will update a group in the repository and return the written
 // group. fieldMaskPaths provides field_mask.proto paths for fields that should
 // be updated.  Fields will be set to NULL if the field is a zero value and
-// included in fieldMask. Name and Description are the only updatable fields,
-// If no updatable fields are included in the fieldMaskPaths, then an error is returned.
+// included in fieldMask. Name, Description, DirectoryType and DirectoryGroup
+// are the only updatable fields, If no updatable fields are included in the
+// fieldMaskPaths, then an error is returned.
 func (r *Repository) UpdateGroup(ctx context.Context, group *Group, version uint32, fieldMaskPaths []string, opt ...Option) (*Group, []*GroupMember, int, error) {
 	if group == nil {
 		return nil, nil, db.NoRowsAffected, fmt.Errorf("update group: missing group %w", errors.ErrInvalidParameter)
@@ -62,6 +63,8 @@ func (r *Repository) UpdateGroup(ctx context.Context, group *Group, version uint
 		switch {
 		case strings.EqualFold("name", f):
 		case strings.EqualFold("description", f):
+		case strings.EqualFold("directorytype", f):
+		case strings.EqualFold("directorygroup", f):
 		default:
 			return nil, nil, db.NoRowsAffected, fmt.Errorf("update group: field: %s: %w", f, errors.ErrInvalidFieldMask)
 		}
@@ -69,8 +72,10 @@ func (r *Repository) UpdateGroup(ctx context.Context, group *Group, version uint
 	var dbMask, nullFields []string
 	dbMask, nullFields = dbcommon.BuildUpdatePaths(
 		map[string]interface{}{
-			"name":        group.Name,
-			"description": group.Description,
+			"name":           group.Name,
+			"description":    group.Description,
+			"DirectoryType":  group.DirectoryType,
+			"DirectoryGroup": group.DirectoryGroup,
 		},
 		fieldMaskPaths,
 		nil,
@@ -166,13 +171,14 @@ func (r *Repository) DeleteGroup(ctx context.Context, withPublicId string, opt .
 	return rowsDeleted, nil
 }
 
-// ListGroups in a scope and supports WithLimit option.
-func (r *Repository) ListGroups(ctx context.Context, withScopeId string, opt ...Option) ([]*Group, error) {
-	if withScopeId == "" {
+// ListGroups in the given scopes and supports the WithLimit and
+// WithStartPageAfterId options.
+func (r *Repository) ListGroups(ctx context.Context, withScopeIds []string, opt ...Option) ([]*Group, error) {
+	if len(withScopeIds) == 0 {
 		return nil, fmt.Errorf("list groups: missing scope id %w", errors.ErrInvalidParameter)
 	}
 	var grps []*Group
-	err := r.list(ctx, &grps, "scope_id = ?", []interface{}{withScopeId}, opt...)
+	err := r.list(ctx, &grps, "scope_id in (?)", []interface{}{withScopeIds}, opt...)
 	if err != nil {
 		return nil, fmt.Errorf("list groups: %w", err)
 	}
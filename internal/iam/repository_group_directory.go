@@ -0,0 +1,92 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// ListDirectoryGroups returns the groups in every scope that are bound to an
+// external directory of the given type and supports the WithLimit option.
+func (r *Repository) ListDirectoryGroups(ctx context.Context, directoryType string, opt ...Option) ([]*Group, error) {
+	switch directoryType {
+	case DirectoryLdap, DirectoryScim:
+	default:
+		return nil, fmt.Errorf("list directory groups: unknown directory type %q: %w", directoryType, errors.ErrInvalidParameter)
+	}
+	var grps []*Group
+	if err := r.list(ctx, &grps, "directory_type = ?", []interface{}{directoryType}, opt...); err != nil {
+		return nil, fmt.Errorf("list directory groups: %w", err)
+	}
+	return grps, nil
+}
+
+// ListGroupMemberAccounts returns the sorted ids of the accounts in the auth
+// method whose users are members of the group.
+func (r *Repository) ListGroupMemberAccounts(ctx context.Context, groupId, authMethodId string, opt ...Option) ([]string, error) {
+	switch {
+	case groupId == "":
+		return nil, fmt.Errorf("list group member accounts: missing group id: %w", errors.ErrInvalidParameter)
+	case authMethodId == "":
+		return nil, fmt.Errorf("list group member accounts: missing auth method id: %w", errors.ErrInvalidParameter)
+	}
+	var accts []*authAccount
+	if err := r.reader.SearchWhere(ctx, &accts, "auth_method_id = ? and iam_user_id in (select member_id from iam_group_member_user where group_id = ?)", []interface{}{authMethodId, groupId}); err != nil {
+		return nil, fmt.Errorf("list group member accounts: %w", err)
+	}
+	ids := make([]string, 0, len(accts))
+	for _, a := range accts {
+		ids = append(ids, a.PublicId)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// SyncGroupMembers sets the members of a group bound to an external directory
+// to the users of the accounts with the given ids, which are the accounts of
+// the members of the group in the directory. The user of an account that has
+// none is created the same way it is when the account is first used to
+// authenticate, so that the member of the directory's group is the same user
+// whether it is synchronized or logs in first. Users that are no longer
+// members of the directory's group are removed from the group but not
+// deleted. The changes are written to the oplog.
+func (r *Repository) SyncGroupMembers(ctx context.Context, groupId string, accountIds []string, opt ...Option) ([]*GroupMember, error) {
+	if groupId == "" {
+		return nil, fmt.Errorf("sync group members: missing group id: %w", errors.ErrInvalidParameter)
+	}
+	group, _, err := r.LookupGroup(ctx, groupId)
+	if err != nil {
+		return nil, fmt.Errorf("sync group members: %w", err)
+	}
+	if group == nil {
+		return nil, fmt.Errorf("sync group members: group %s: %w", groupId, errors.ErrRecordNotFound)
+	}
+	if group.DirectoryType == "" {
+		return nil, fmt.Errorf("sync group members: group %s is not bound to a directory: %w", groupId, errors.ErrInvalidParameter)
+	}
+
+	userIds := make([]string, 0, len(accountIds))
+	seen := make(map[string]bool, len(accountIds))
+	for _, id := range accountIds {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		u, err := r.LookupUserWithLogin(ctx, id, WithAutoVivify(true))
+		if err != nil {
+			return nil, fmt.Errorf("sync group members: unable to look up user of account %s: %w", id, err)
+		}
+		if !seen[u.PublicId] {
+			seen[u.PublicId] = true
+			userIds = append(userIds, u.PublicId)
+		}
+	}
+
+	members, _, err := r.SetGroupMembers(ctx, groupId, group.Version, userIds)
+	if err != nil {
+		return nil, fmt.Errorf("sync group members: %w", err)
+	}
+	return members, nil
+}
@@ -0,0 +1,172 @@
+package iam
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ListDirectoryGroups(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+
+	ldapGrp := TestGroup(t, conn, org.PublicId, WithDirectoryType(DirectoryLdap), WithDirectoryGroup("cn=admins,dc=example,dc=com"))
+	scimGrp := TestGroup(t, conn, proj.PublicId, WithDirectoryType(DirectoryScim), WithDirectoryGroup("admins"))
+	TestGroup(t, conn, org.PublicId)
+
+	ctx := context.Background()
+	got, err := repo.ListDirectoryGroups(ctx, DirectoryLdap)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, ldapGrp.PublicId, got[0].PublicId)
+	assert.Equal(t, "cn=admins,dc=example,dc=com", got[0].DirectoryGroup)
+
+	got, err = repo.ListDirectoryGroups(ctx, DirectoryScim)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, scimGrp.PublicId, got[0].PublicId)
+
+	_, err = repo.ListDirectoryGroups(ctx, "")
+	assert.True(t, errors.Is(err, errors.ErrInvalidParameter))
+
+	// A SCIM group can only be bound to one group.
+	grp, err := NewGroup(org.PublicId, WithDirectoryType(DirectoryScim), WithDirectoryGroup("admins"))
+	require.NoError(t, err)
+	_, err = repo.CreateGroup(ctx, grp)
+	assert.Error(t, err)
+}
+
+func TestRepository_UpdateGroup_Directory(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, _ := TestScopes(t, repo)
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+
+	grp := TestGroup(t, conn, org.PublicId)
+	upd := grp.Clone().(*Group)
+	upd.DirectoryType = DirectoryLdap
+	upd.DirectoryGroup = "cn=admins,dc=example,dc=com"
+	got, _, rows, err := repo.UpdateGroup(ctx, upd, grp.Version, []string{"DirectoryType", "DirectoryGroup"})
+	require.NoError(err)
+	assert.Equal(1, rows)
+	assert.Equal(DirectoryLdap, got.DirectoryType)
+	assert.Equal("cn=admins,dc=example,dc=com", got.DirectoryGroup)
+
+	// The directory group cannot be cleared without the directory type.
+	upd = got.Clone().(*Group)
+	upd.DirectoryGroup = ""
+	_, _, _, err = repo.UpdateGroup(ctx, upd, got.Version, []string{"DirectoryGroup"})
+	require.Error(err)
+
+	upd.DirectoryType = ""
+	got, _, rows, err = repo.UpdateGroup(ctx, upd, got.Version, []string{"DirectoryType", "DirectoryGroup"})
+	require.NoError(err)
+	assert.Equal(1, rows)
+	assert.Empty(got.DirectoryType)
+	assert.Empty(got.DirectoryGroup)
+}
+
+func TestRepository_SyncGroupMembers(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+	ctx := context.Background()
+	authMethodId := testAuthMethod(t, conn, org.PublicId)
+
+	existing := TestUser(t, repo, org.PublicId, WithName("sue"))
+	sue := testAccount(t, conn, org.PublicId, authMethodId, existing.PublicId)
+	// A user with the name of a member is not matched.
+	TestUser(t, repo, org.PublicId, WithName("jim"))
+
+	tests := []struct {
+		name    string
+		scopeId string
+	}{
+		{name: "org", scopeId: org.PublicId},
+		{name: "project", scopeId: proj.PublicId},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			grp := TestGroup(t, conn, tt.scopeId, WithDirectoryType(DirectoryLdap), WithDirectoryGroup("cn=ops-"+tt.name+",dc=example,dc=com"))
+			jim := testAccount(t, conn, org.PublicId, authMethodId, "")
+			bob := testAccount(t, conn, org.PublicId, authMethodId, "")
+
+			members, err := repo.SyncGroupMembers(ctx, grp.PublicId, []string{jim.PublicId, sue.PublicId, sue.PublicId, ""})
+			require.NoError(err)
+			assert.Len(members, 2)
+			accts, err := repo.ListGroupMemberAccounts(ctx, grp.PublicId, authMethodId)
+			require.NoError(err)
+			assert.ElementsMatch([]string{jim.PublicId, sue.PublicId}, accts)
+
+			// The user of an account without one is created as it is on
+			// login, and the user of an account that has one is reused.
+			jimUser, err := repo.LookupUserWithLogin(ctx, jim.PublicId)
+			require.NoError(err)
+			assert.Empty(jimUser.Name)
+			var memberIds []string
+			for _, m := range members {
+				memberIds = append(memberIds, m.MemberId)
+				assert.Equal(org.PublicId, m.MemberScopeId)
+			}
+			assert.ElementsMatch([]string{jimUser.PublicId, existing.PublicId}, memberIds)
+			err = db.TestVerifyOplog(t, rw, grp.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_UPDATE), db.WithCreateNotBefore(10*time.Second))
+			assert.NoError(err)
+
+			// Users that left the directory group are removed from the group.
+			members, err = repo.SyncGroupMembers(ctx, grp.PublicId, []string{sue.PublicId, bob.PublicId})
+			require.NoError(err)
+			assert.Len(members, 2)
+			accts, err = repo.ListGroupMemberAccounts(ctx, grp.PublicId, authMethodId)
+			require.NoError(err)
+			assert.ElementsMatch([]string{bob.PublicId, sue.PublicId}, accts)
+
+			// The removed user still exists and keeps its account.
+			got, err := repo.LookupUserWithLogin(ctx, jim.PublicId)
+			require.NoError(err)
+			assert.Equal(jimUser.PublicId, got.PublicId)
+
+			members, err = repo.SyncGroupMembers(ctx, grp.PublicId, nil)
+			require.NoError(err)
+			assert.Empty(members)
+		})
+	}
+
+	t.Run("missing-account", func(t *testing.T) {
+		grp := TestGroup(t, conn, org.PublicId, WithDirectoryType(DirectoryScim), WithDirectoryGroup("missing-account"))
+		_, err := repo.SyncGroupMembers(ctx, grp.PublicId, []string{"aa_1234567890"})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrRecordNotFound))
+	})
+	t.Run("not-bound", func(t *testing.T) {
+		grp := TestGroup(t, conn, org.PublicId)
+		_, err := repo.SyncGroupMembers(ctx, grp.PublicId, []string{sue.PublicId})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrInvalidParameter))
+	})
+	t.Run("missing-group", func(t *testing.T) {
+		_, err := repo.SyncGroupMembers(ctx, "g_1234567890", []string{sue.PublicId})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrRecordNotFound))
+	})
+	t.Run("missing-id", func(t *testing.T) {
+		_, err := repo.SyncGroupMembers(ctx, "", []string{sue.PublicId})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrInvalidParameter))
+	})
+}
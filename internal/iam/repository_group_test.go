@@ -556,8 +556,8 @@ func TestRepository_ListGroups(t *testing.T) {
 	org, proj := TestScopes(t, repo)
 
 	type args struct {
-		withScopeId string
-		opt         []Option
+		withScopeIds []string
+		opt          []Option
 	}
 	tests := []struct {
 		name          string
@@ -572,8 +572,8 @@ func TestRepository_ListGroups(t *testing.T) {
 			createCnt:     repo.defaultLimit + 1,
 			createScopeId: org.PublicId,
 			args: args{
-				withScopeId: org.PublicId,
-				opt:         []Option{WithLimit(-1)},
+				withScopeIds: []string{org.PublicId},
+				opt:          []Option{WithLimit(-1)},
 			},
 			wantCnt: repo.defaultLimit + 1,
 			wantErr: false,
@@ -583,8 +583,8 @@ func TestRepository_ListGroups(t *testing.T) {
 			createCnt:     repo.defaultLimit + 1,
 			createScopeId: proj.PublicId,
 			args: args{
-				withScopeId: proj.PublicId,
-				opt:         []Option{WithLimit(-1)},
+				withScopeIds: []string{proj.PublicId},
+				opt:          []Option{WithLimit(-1)},
 			},
 			wantCnt: repo.defaultLimit + 1,
 			wantErr: false,
@@ -594,7 +594,7 @@ func TestRepository_ListGroups(t *testing.T) {
 			createCnt:     repo.defaultLimit + 1,
 			createScopeId: org.PublicId,
 			args: args{
-				withScopeId: org.PublicId,
+				withScopeIds: []string{org.PublicId},
 			},
 			wantCnt: repo.defaultLimit,
 			wantErr: false,
@@ -604,8 +604,8 @@ func TestRepository_ListGroups(t *testing.T) {
 			createCnt:     repo.defaultLimit + 1,
 			createScopeId: org.PublicId,
 			args: args{
-				withScopeId: org.PublicId,
-				opt:         []Option{WithLimit(3)},
+				withScopeIds: []string{org.PublicId},
+				opt:          []Option{WithLimit(3)},
 			},
 			wantCnt: 3,
 			wantErr: false,
@@ -615,7 +615,7 @@ func TestRepository_ListGroups(t *testing.T) {
 			createCnt:     1,
 			createScopeId: org.PublicId,
 			args: args{
-				withScopeId: "bad-id",
+				withScopeIds: []string{"bad-id"},
 			},
 			wantCnt: 0,
 			wantErr: false,
@@ -630,7 +630,7 @@ func TestRepository_ListGroups(t *testing.T) {
 				testGroups = append(testGroups, TestGroup(t, conn, tt.createScopeId))
 			}
 			assert.Equal(tt.createCnt, len(testGroups))
-			got, err := repo.ListGroups(context.Background(), tt.args.withScopeId, tt.args.opt...)
+			got, err := repo.ListGroups(context.Background(), tt.args.withScopeIds, tt.args.opt...)
 			if tt.wantErr {
 				require.Error(err)
 				return
@@ -15,7 +15,9 @@ import (
 // groupIds) to a role (roleId).  The role's current db version must match the
 // roleVersion or an error will be returned.  The list of current PrincipalRoles
 // after the adds will be returned on success. Zero is not a valid value for
-// the WithVersion option and will return an error.
+// the WithVersion option and will return an error. The WithNotBeforeTime and
+// WithExpirationTime options bound the time during which the added principals
+// receive the role's grants.
 func (r *Repository) AddPrincipalRoles(ctx context.Context, roleId string, roleVersion uint32, principalIds []string, opt ...Option) ([]PrincipalRole, error) {
 	if roleId == "" {
 		return nil, fmt.Errorf("add principal roles: missing role id: %w", errors.ErrInvalidParameter)
@@ -33,7 +35,7 @@ func (r *Repository) AddPrincipalRoles(ctx context.Context, roleId string, roleV
 
 	newUserRoles := make([]interface{}, 0, len(userIds))
 	for _, id := range userIds {
-		usrRole, err := NewUserRole(roleId, id)
+		usrRole, err := NewUserRole(roleId, id, opt...)
 		if err != nil {
 			return nil, fmt.Errorf("add principal roles: unable to create in memory user role: %w", err)
 		}
@@ -41,7 +43,7 @@ func (r *Repository) AddPrincipalRoles(ctx context.Context, roleId string, roleV
 	}
 	newGrpRoles := make([]interface{}, 0, len(groupIds))
 	for _, id := range groupIds {
-		grpRole, err := NewGroupRole(roleId, id)
+		grpRole, err := NewGroupRole(roleId, id, opt...)
 		if err != nil {
 			return nil, fmt.Errorf("add principal roles: unable to create in memory group role: %w", err)
 		}
@@ -397,6 +399,50 @@ func (r *Repository) ListPrincipalRoles(ctx context.Context, roleId string, opt
 	return principals, nil
 }
 
+// DeleteExpiredPrincipalRoles deletes the principal roles whose expiration
+// time has passed and returns the number of principal roles deleted. The
+// deletions are written to the oplog and increment the version of each role
+// they are deleted from. A role modified concurrently is skipped and its
+// expired principal roles are left for a later call.
+func (r *Repository) DeleteExpiredPrincipalRoles(ctx context.Context) (int, error) {
+	var expired []PrincipalRole
+	if err := r.reader.SearchWhere(ctx, &expired, "expiration_time <= current_timestamp", nil); err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete expired principal roles: unable to search for expired principal roles: %w", err)
+	}
+	if len(expired) == 0 {
+		return db.NoRowsAffected, nil
+	}
+	principalIds := make(map[string][]string)
+	for _, p := range expired {
+		principalIds[p.GetRoleId()] = append(principalIds[p.GetRoleId()], p.GetPrincipalId())
+	}
+
+	var totalRowsDeleted int
+	var errs []error
+	for roleId, ids := range principalIds {
+		role := allocRole()
+		role.PublicId = roleId
+		if err := r.reader.LookupByPublicId(ctx, &role); err != nil {
+			if errors.Is(err, errors.ErrRecordNotFound) {
+				// the role was deleted along with its principals
+				continue
+			}
+			errs = append(errs, fmt.Errorf("unable to look up role %s: %w", roleId, err))
+			continue
+		}
+		rowsDeleted, err := r.DeletePrincipalRoles(ctx, roleId, role.GetVersion(), ids)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		totalRowsDeleted += rowsDeleted
+	}
+	if len(errs) > 0 {
+		return totalRowsDeleted, fmt.Errorf("delete expired principal roles: %d of %d roles failed, first error: %w", len(errs), len(principalIds), errs[0])
+	}
+	return totalRowsDeleted, nil
+}
+
 type principalSet struct {
 	addUserRoles     []interface{}
 	addGroupRoles    []interface{}
@@ -511,6 +511,48 @@ func TestRepository_DeletePrincipalRoles(t *testing.T) {
 	}
 }
 
+func TestRepository_DeleteExpiredPrincipalRoles(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, _ := TestScopes(t, repo)
+	role := TestRole(t, conn, org.PublicId)
+
+	now := time.Now()
+	expiredUser := TestUser(t, repo, org.PublicId)
+	TestUserRole(t, conn, role.PublicId, expiredUser.PublicId, WithExpirationTime(now.Add(-time.Minute)))
+	expiredGroup := TestGroup(t, conn, org.PublicId)
+	TestGroupRole(t, conn, role.PublicId, expiredGroup.PublicId, WithNotBeforeTime(now.Add(-time.Hour)), WithExpirationTime(now.Add(-time.Minute)))
+	currentUser := TestUser(t, repo, org.PublicId)
+	TestUserRole(t, conn, role.PublicId, currentUser.PublicId, WithExpirationTime(now.Add(time.Hour)))
+	permanentUser := TestUser(t, repo, org.PublicId)
+	TestUserRole(t, conn, role.PublicId, permanentUser.PublicId)
+
+	deleted, err := repo.DeleteExpiredPrincipalRoles(context.Background())
+	require.NoError(err)
+	assert.Equal(2, deleted)
+
+	principals, err := repo.ListPrincipalRoles(context.Background(), role.PublicId)
+	require.NoError(err)
+	var ids []string
+	for _, p := range principals {
+		ids = append(ids, p.GetPrincipalId())
+	}
+	assert.ElementsMatch([]string{currentUser.PublicId, permanentUser.PublicId}, ids)
+
+	updated, _, _, err := repo.LookupRole(context.Background(), role.PublicId)
+	require.NoError(err)
+	assert.Equal(role.Version+1, updated.Version)
+	err = db.TestVerifyOplog(t, db.New(conn), role.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_DELETE), db.WithCreateNotBefore(10*time.Second))
+	assert.NoError(err)
+
+	deleted, err = repo.DeleteExpiredPrincipalRoles(context.Background())
+	require.NoError(err)
+	assert.Equal(0, deleted)
+}
+
 func TestRepository_SetPrincipalRoles(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
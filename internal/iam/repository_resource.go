@@ -0,0 +1,108 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/types/resource"
+)
+
+const lookupResourceQuery = `
+select 'scope' as type, coalesce(parent_id, 'global') as scope_id, '' as pin
+  from iam_scope
+ where public_id = $1
+ union all
+select 'user', scope_id, ''
+  from iam_user
+ where public_id = $1
+ union all
+select 'group', scope_id, ''
+  from iam_group
+ where public_id = $1
+ union all
+select 'role', scope_id, ''
+  from iam_role
+ where public_id = $1
+ union all
+select 'auth-method', scope_id, ''
+  from auth_method
+ where public_id = $1
+ union all
+select 'account', scope_id, auth_method_id
+  from auth_account
+ where public_id = $1
+ union all
+select 'auth-token', auth_account.scope_id, ''
+  from auth_token
+  join auth_account
+    on auth_token.auth_account_id = auth_account.public_id
+ where auth_token.public_id = $1
+ union all
+select 'host-catalog', scope_id, ''
+  from host_catalog
+ where public_id = $1
+ union all
+select 'host-set', host_catalog.scope_id, host_set.catalog_id
+  from host_set
+  join host_catalog
+    on host_set.catalog_id = host_catalog.public_id
+ where host_set.public_id = $1
+ union all
+select 'host', host_catalog.scope_id, host.catalog_id
+  from host
+  join host_catalog
+    on host.catalog_id = host_catalog.public_id
+ where host.public_id = $1
+ union all
+select 'target', scope_id, ''
+  from target
+ where public_id = $1
+ union all
+select 'session', scope_id, ''
+  from session
+ where public_id = $1
+ union all
+select 'credential-store', scope_id, ''
+  from credential_store
+ where public_id = $1
+ union all
+select 'credential', credential_store.scope_id, credential.store_id
+  from credential
+  join credential_store
+    on credential.store_id = credential_store.public_id
+ where credential.public_id = $1
+ union all
+select 'access-request', scope_id, ''
+  from iam_access_request
+ where public_id = $1;
+`
+
+// LookupResource returns the resource with the public id as it is seen by the
+// permissions engine: its type, the id of the scope containing it and, for
+// resources within a parent collection such as hosts, the id of the parent.
+// If no resource has the id, nil is returned.
+func (r *Repository) LookupResource(ctx context.Context, publicId string) (*perms.Resource, error) {
+	if publicId == "" {
+		return nil, fmt.Errorf("lookup resource: missing public id: %w", errors.ErrInvalidParameter)
+	}
+	rows, err := r.reader.Query(ctx, lookupResourceQuery, []interface{}{publicId})
+	if err != nil {
+		return nil, fmt.Errorf("lookup resource: %w", err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return nil, nil
+	}
+	var typ, scopeId, pin string
+	if err := rows.Scan(&typ, &scopeId, &pin); err != nil {
+		return nil, fmt.Errorf("lookup resource: unable to scan row: %w", err)
+	}
+	return &perms.Resource{
+		ScopeId: scopeId,
+		Id:      publicId,
+		Type:    resource.Map[typ],
+		Pin:     pin,
+	}, nil
+}
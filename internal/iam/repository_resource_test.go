@@ -0,0 +1,82 @@
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_LookupResource(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+	user := TestUser(t, repo, org.PublicId)
+	role := TestRole(t, conn, proj.PublicId)
+	targetId := testTarget(t, conn, proj.PublicId)
+	req := TestAccessRequest(t, conn, proj.PublicId, targetId, user.PublicId)
+
+	tests := []struct {
+		name string
+		id   string
+		want *perms.Resource
+	}{
+		{
+			name: "global",
+			id:   "global",
+			want: &perms.Resource{ScopeId: "global", Id: "global", Type: resource.Scope},
+		},
+		{
+			name: "org",
+			id:   org.PublicId,
+			want: &perms.Resource{ScopeId: "global", Id: org.PublicId, Type: resource.Scope},
+		},
+		{
+			name: "project",
+			id:   proj.PublicId,
+			want: &perms.Resource{ScopeId: org.PublicId, Id: proj.PublicId, Type: resource.Scope},
+		},
+		{
+			name: "user",
+			id:   user.PublicId,
+			want: &perms.Resource{ScopeId: org.PublicId, Id: user.PublicId, Type: resource.User},
+		},
+		{
+			name: "role",
+			id:   role.PublicId,
+			want: &perms.Resource{ScopeId: proj.PublicId, Id: role.PublicId, Type: resource.Role},
+		},
+		{
+			name: "target",
+			id:   targetId,
+			want: &perms.Resource{ScopeId: proj.PublicId, Id: targetId, Type: resource.Target},
+		},
+		{
+			name: "access request",
+			id:   req.PublicId,
+			want: &perms.Resource{ScopeId: proj.PublicId, Id: req.PublicId, Type: resource.AccessRequest},
+		},
+		{
+			name: "not found",
+			id:   "ttcp_doesntexist",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.LookupResource(context.Background(), tt.id)
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+
+	_, err := repo.LookupResource(context.Background(), "")
+	assert.True(t, errors.Is(err, errors.ErrInvalidParameter))
+}
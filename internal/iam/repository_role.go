@@ -44,9 +44,9 @@ func (r *Repository) CreateRole(ctx context.Context, role *Role, opt ...Option)
 // UpdateRole will update a role in the repository and return the written role.
 // fieldMaskPaths provides field_mask.proto paths for fields that should be
 // updated.  Fields will be set to NULL if the field is a zero value and
-// included in fieldMask. Name, Description, and GrantScopeId are the only
-// updatable fields, If no updatable fields are included in the fieldMaskPaths,
-// then an error is returned.
+// included in fieldMask. Name, Description, GrantScopeId and
+// GrantScopeInheritance are the only updatable fields, If no updatable fields
+// are included in the fieldMaskPaths, then an error is returned.
 func (r *Repository) UpdateRole(ctx context.Context, role *Role, version uint32, fieldMaskPaths []string, opt ...Option) (*Role, []PrincipalRole, []*RoleGrant, int, error) {
 	if role == nil {
 		return nil, nil, nil, db.NoRowsAffected, fmt.Errorf("update role: missing role %w", errors.ErrInvalidParameter)
@@ -62,6 +62,7 @@ func (r *Repository) UpdateRole(ctx context.Context, role *Role, version uint32,
 		case strings.EqualFold("name", f):
 		case strings.EqualFold("description", f):
 		case strings.EqualFold("grantscopeid", f):
+		case strings.EqualFold("grantscopeinheritance", f):
 		default:
 			return nil, nil, nil, db.NoRowsAffected, fmt.Errorf("update role: field: %s: %w", f, errors.ErrInvalidFieldMask)
 		}
@@ -69,9 +70,10 @@ func (r *Repository) UpdateRole(ctx context.Context, role *Role, version uint32,
 	var dbMask, nullFields []string
 	dbMask, nullFields = dbcommon.BuildUpdatePaths(
 		map[string]interface{}{
-			"name":         role.Name,
-			"description":  role.Description,
-			"GrantScopeId": role.GrantScopeId,
+			"name":                  role.Name,
+			"description":           role.Description,
+			"GrantScopeId":          role.GrantScopeId,
+			"GrantScopeInheritance": role.GrantScopeInheritance,
 		},
 		fieldMaskPaths,
 		nil,
@@ -177,13 +179,14 @@ func (r *Repository) DeleteRole(ctx context.Context, withPublicId string, opt ..
 	return rowsDeleted, nil
 }
 
-// ListRoles in a scope and supports WithLimit option.
-func (r *Repository) ListRoles(ctx context.Context, withScopeId string, opt ...Option) ([]*Role, error) {
-	if withScopeId == "" {
+// ListRoles in the given scopes and supports the WithLimit and
+// WithStartPageAfterId options.
+func (r *Repository) ListRoles(ctx context.Context, withScopeIds []string, opt ...Option) ([]*Role, error) {
+	if len(withScopeIds) == 0 {
 		return nil, fmt.Errorf("list roles: missing scope id %w", errors.ErrInvalidParameter)
 	}
 	var roles []*Role
-	err := r.list(ctx, &roles, "scope_id = ?", []interface{}{withScopeId}, opt...)
+	err := r.list(ctx, &roles, "scope_id in (?)", []interface{}{withScopeIds}, opt...)
 	if err != nil {
 		return nil, fmt.Errorf("list roles: %w", err)
 	}
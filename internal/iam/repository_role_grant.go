@@ -2,6 +2,7 @@ package iam
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 
 	"github.com/hashicorp/boundary/internal/db"
@@ -381,6 +382,12 @@ func (r *Repository) ListRoleGrants(ctx context.Context, roleId string, opt ...O
 	return roleGrants, nil
 }
 
+// GrantsForUser returns the grants of the roles the user is assigned to,
+// directly or through a group, along with a grant of authorize-session on the
+// target of each of the user's approved access requests that have not
+// expired. Each grant includes the ID of the role or access request it comes
+// from. The grants of a role inheriting its grant scope are returned for each
+// scope they apply to.
 func (r *Repository) GrantsForUser(ctx context.Context, userId string, opt ...Option) ([]perms.GrantPair, error) {
 	if userId == "" {
 		return nil, fmt.Errorf("get grants for user: missing user id: %w", errors.ErrInvalidParameter)
@@ -390,7 +397,7 @@ func (r *Repository) GrantsForUser(ctx context.Context, userId string, opt ...Op
 		anonUser    = `where public_id in ($1)`
 		authUser    = `where public_id in ('u_anon', 'u_auth', $1)`
 		grantsQuery = `
-with
+with recursive
 users (id) as (
   select public_id
     from iam_user
@@ -407,12 +414,16 @@ group_roles (role_id) as (
     from iam_group_role,
          user_groups
    where principal_id in (user_groups.id)
+     and (not_before_time is null or not_before_time <= current_timestamp)
+     and (expiration_time is null or expiration_time > current_timestamp)
 ),
 user_roles (role_id) as (
   select role_id
     from iam_user_role,
          users
    where principal_id in (users.id)
+     and (not_before_time is null or not_before_time <= current_timestamp)
+     and (expiration_time is null or expiration_time > current_timestamp)
 ),
 user_group_roles (role_id) as (
   select role_id
@@ -421,22 +432,60 @@ user_group_roles (role_id) as (
   select role_id
     from user_roles
 ),
-roles (role_id, grant_scope_id) as (
+roles (role_id, grant_scope_id, grant_scope_inheritance) as (
   select iam_role.public_id,
-         iam_role.grant_scope_id
+         iam_role.grant_scope_id,
+         iam_role.grant_scope_inheritance
     from iam_role,
          user_group_roles
    where public_id in (user_group_roles.role_id)
 ),
-final (role_scope, role_grant) as (
-  select roles.grant_scope_id,
-         iam_role_grant.canonical_grant
+-- Follows the scope parent chain down from each grant scope: one level for
+-- roles applying to children and every level for roles applying to
+-- descendants
+role_scopes (role_id, scope_id, grant_scope_inheritance, depth) as (
+  select role_id,
+         grant_scope_id,
+         grant_scope_inheritance,
+         0
     from roles
+   union
+  select role_scopes.role_id,
+         child.public_id,
+         role_scopes.grant_scope_inheritance,
+         role_scopes.depth + 1
+    from role_scopes
+   inner
+    join iam_scope child
+      on child.parent_id = role_scopes.scope_id
+   where role_scopes.grant_scope_inheritance = 'descendants'
+      or (role_scopes.grant_scope_inheritance = 'children' and role_scopes.depth = 0)
+),
+access_request_grants (role_id, role_scope, role_grant) as (
+  select iam_access_request.public_id,
+         iam_access_request.scope_id,
+         'id=' || iam_access_request.target_id || ';actions=authorize-session'
+    from iam_access_request,
+         users
+   where user_id in (users.id)
+     and status = 'approved'
+     and expiration_time > current_timestamp
+),
+final (role_id, role_scope, role_grant) as (
+  select role_scopes.role_id,
+         role_scopes.scope_id,
+         iam_role_grant.canonical_grant
+    from role_scopes
    inner
     join iam_role_grant
-      on roles.role_id = iam_role_grant.role_id
+      on role_scopes.role_id = iam_role_grant.role_id
+   union
+  select role_id,
+         role_scope,
+         role_grant
+    from access_request_grants
 )
-select role_scope as scope_id, role_grant as grant from final;
+select role_id, role_scope as scope_id, role_grant as grant from final;
 	`
 	)
 
@@ -463,3 +512,90 @@ select role_scope as scope_id, role_grant as grant from final;
 	}
 	return grants, nil
 }
+
+// GrantTemplateData returns the values about the user that are substituted for
+// templates in their grants: the user's name, the login name or claims of the
+// account, and the groups the user is a member of. The account is optional and
+// is ignored if it is not associated with the user.
+func (r *Repository) GrantTemplateData(ctx context.Context, userId, accountId string, opt ...Option) (perms.TemplateData, error) {
+	var data perms.TemplateData
+	if userId == "" {
+		return data, fmt.Errorf("get grant template data: missing user id: %w", errors.ErrInvalidParameter)
+	}
+
+	const (
+		userQuery = `
+select iam_user.name,
+       coalesce(auth_password_account.login_name, auth_ldap_account.login_name),
+       auth_oidc_account.subject,
+       coalesce(auth_oidc_account.email, auth_ldap_account.email),
+       coalesce(auth_oidc_account.full_name, auth_ldap_account.full_name),
+       auth_ldap_account.dn
+  from iam_user
+  left join auth_account
+    on auth_account.iam_user_id = iam_user.public_id
+   and auth_account.public_id = $2
+  left join auth_password_account
+    on auth_password_account.public_id = auth_account.public_id
+  left join auth_oidc_account
+    on auth_oidc_account.public_id = auth_account.public_id
+  left join auth_ldap_account
+    on auth_ldap_account.public_id = auth_account.public_id
+ where iam_user.public_id = $1;
+`
+		groupsQuery = `
+select iam_group.public_id,
+       iam_group.name
+  from iam_group
+ inner
+  join iam_group_member_user
+    on iam_group.public_id = iam_group_member_user.group_id
+ where iam_group_member_user.member_id = $1
+ order by iam_group.public_id;
+`
+	)
+
+	rows, err := r.reader.Query(ctx, userQuery, []interface{}{userId, accountId})
+	if err != nil {
+		return data, fmt.Errorf("get grant template data: %w", err)
+	}
+	defer rows.Close()
+	if rows.Next() {
+		var name, loginName, subject, email, fullName, dn sql.NullString
+		if err := rows.Scan(&name, &loginName, &subject, &email, &fullName, &dn); err != nil {
+			return data, fmt.Errorf("get grant template data: unable to scan user: %w", err)
+		}
+		data.UserName = name.String
+		data.AccountLoginName = loginName.String
+		switch {
+		case subject.Valid:
+			data.AccountClaims = map[string]string{
+				"sub":   subject.String,
+				"email": email.String,
+				"name":  fullName.String,
+			}
+		case dn.Valid:
+			data.AccountClaims = map[string]string{
+				"dn":    dn.String,
+				"email": email.String,
+				"name":  fullName.String,
+			}
+		}
+	}
+	rows.Close()
+
+	groupRows, err := r.reader.Query(ctx, groupsQuery, []interface{}{userId})
+	if err != nil {
+		return data, fmt.Errorf("get grant template data: %w", err)
+	}
+	defer groupRows.Close()
+	for groupRows.Next() {
+		var id string
+		var name sql.NullString
+		if err := groupRows.Scan(&id, &name); err != nil {
+			return data, fmt.Errorf("get grant template data: unable to scan group: %w", err)
+		}
+		data.Groups = append(data.Groups, perms.TemplateGroup{Id: id, Name: name.String})
+	}
+	return data, nil
+}
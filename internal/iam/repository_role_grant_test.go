@@ -10,6 +10,9 @@ import (
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -564,3 +567,128 @@ func TestRepository_SetRoleGrants_Parameters(t *testing.T) {
 		})
 	}
 }
+
+func TestRepository_GrantTemplateData(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, _ := TestScopes(t, repo)
+
+	user := TestUser(t, repo, org.PublicId, WithName("jim"))
+	authMethodId := testAuthMethod(t, conn, org.PublicId)
+	acct := testAccount(t, conn, org.PublicId, authMethodId, user.PublicId)
+	grpB := TestGroup(t, conn, org.PublicId, WithName("team-b"))
+	grpA := TestGroup(t, conn, org.PublicId, WithName("team-a"))
+	TestGroupMember(t, conn, grpA.PublicId, user.PublicId)
+	TestGroupMember(t, conn, grpB.PublicId, user.PublicId)
+	TestGroup(t, conn, org.PublicId, WithName("other"))
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		data, err := repo.GrantTemplateData(context.Background(), user.PublicId, acct.PublicId)
+		require.NoError(err)
+		assert.Equal("jim", data.UserName)
+		// The account is neither a password nor an OIDC account
+		assert.Empty(data.AccountLoginName)
+		assert.Empty(data.AccountClaims)
+		require.Len(data.Groups, 2)
+		names := []string{data.Groups[0].Name, data.Groups[1].Name}
+		assert.ElementsMatch([]string{"team-a", "team-b"}, names)
+	})
+	t.Run("no account", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		data, err := repo.GrantTemplateData(context.Background(), user.PublicId, "")
+		require.NoError(err)
+		assert.Equal("jim", data.UserName)
+		assert.Len(data.Groups, 2)
+	})
+	t.Run("missing user id", func(t *testing.T) {
+		_, err := repo.GrantTemplateData(context.Background(), "", "")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrInvalidParameter))
+	})
+}
+
+func TestRepository_GrantsForUser_GrantScopeInheritance(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+	user := TestUser(t, repo, org.PublicId)
+
+	tests := []struct {
+		name        string
+		scopeId     string
+		inheritance string
+		wantScopes  []string
+	}{
+		{
+			name:        "none",
+			scopeId:     org.PublicId,
+			inheritance: GrantScopeNoInheritance,
+			wantScopes:  []string{org.PublicId},
+		},
+		{
+			name:        "org-children",
+			scopeId:     org.PublicId,
+			inheritance: GrantScopeChildren,
+			wantScopes:  []string{org.PublicId, proj.PublicId},
+		},
+		{
+			name:        "global-children",
+			scopeId:     "global",
+			inheritance: GrantScopeChildren,
+			wantScopes:  []string{"global", org.PublicId},
+		},
+		{
+			name:        "global-descendants",
+			scopeId:     "global",
+			inheritance: GrantScopeDescendants,
+			wantScopes:  []string{"global", org.PublicId, proj.PublicId},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			role := TestRole(t, conn, tt.scopeId, WithGrantScopeId(tt.scopeId), WithGrantScopeInheritance(tt.inheritance))
+			TestRoleGrant(t, conn, role.PublicId, "id=*;type=host-catalog;actions=read")
+			TestUserRole(t, conn, role.PublicId, user.PublicId)
+
+			grants, err := repo.GrantsForUser(context.Background(), user.PublicId)
+			require.NoError(err)
+			var gotScopes []string
+			for _, g := range grants {
+				if g.RoleId == role.PublicId {
+					gotScopes = append(gotScopes, g.ScopeId)
+				}
+			}
+			assert.ElementsMatch(tt.wantScopes, gotScopes)
+		})
+	}
+}
+
+func TestRepository_GrantsForUser_GlobalRoleAppliesToProject(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+	user := TestUser(t, repo, org.PublicId)
+
+	role := TestRole(t, conn, "global", WithGrantScopeId("global"), WithGrantScopeInheritance(GrantScopeDescendants))
+	TestRoleGrant(t, conn, role.PublicId, "id=*;type=host-catalog;actions=read")
+	TestUserRole(t, conn, role.PublicId, user.PublicId)
+
+	grantPairs, err := repo.GrantsForUser(context.Background(), user.PublicId)
+	require.NoError(err)
+	grants, err := perms.ParseGrants(grantPairs, perms.WithUserId(user.PublicId))
+	require.NoError(err)
+	acl := perms.NewACL(grants...)
+
+	res := perms.Resource{ScopeId: proj.PublicId, Id: "hc_1234567890", Type: resource.HostCatalog}
+	assert.True(acl.Allowed(res, action.Read).Allowed)
+	assert.False(acl.Allowed(res, action.Update).Allowed)
+}
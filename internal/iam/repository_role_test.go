@@ -554,8 +554,8 @@ func TestRepository_ListRoles(t *testing.T) {
 	org, proj := TestScopes(t, repo)
 
 	type args struct {
-		withScopeId string
-		opt         []Option
+		withScopeIds []string
+		opt          []Option
 	}
 	tests := []struct {
 		name          string
@@ -570,8 +570,8 @@ func TestRepository_ListRoles(t *testing.T) {
 			createCnt:     repo.defaultLimit + 1,
 			createScopeId: org.PublicId,
 			args: args{
-				withScopeId: org.PublicId,
-				opt:         []Option{WithLimit(-1)},
+				withScopeIds: []string{org.PublicId},
+				opt:          []Option{WithLimit(-1)},
 			},
 			wantCnt: repo.defaultLimit + 2,
 			wantErr: false,
@@ -581,8 +581,8 @@ func TestRepository_ListRoles(t *testing.T) {
 			createCnt:     repo.defaultLimit + 1,
 			createScopeId: proj.PublicId,
 			args: args{
-				withScopeId: proj.PublicId,
-				opt:         []Option{WithLimit(-1)},
+				withScopeIds: []string{proj.PublicId},
+				opt:          []Option{WithLimit(-1)},
 			},
 			wantCnt: repo.defaultLimit + 1,
 			wantErr: false,
@@ -592,7 +592,7 @@ func TestRepository_ListRoles(t *testing.T) {
 			createCnt:     repo.defaultLimit + 1,
 			createScopeId: org.PublicId,
 			args: args{
-				withScopeId: org.PublicId,
+				withScopeIds: []string{org.PublicId},
 			},
 			wantCnt: repo.defaultLimit,
 			wantErr: false,
@@ -602,8 +602,8 @@ func TestRepository_ListRoles(t *testing.T) {
 			createCnt:     repo.defaultLimit + 1,
 			createScopeId: org.PublicId,
 			args: args{
-				withScopeId: org.PublicId,
-				opt:         []Option{WithLimit(3)},
+				withScopeIds: []string{org.PublicId},
+				opt:          []Option{WithLimit(3)},
 			},
 			wantCnt: 3,
 			wantErr: false,
@@ -613,7 +613,7 @@ func TestRepository_ListRoles(t *testing.T) {
 			createCnt:     1,
 			createScopeId: org.PublicId,
 			args: args{
-				withScopeId: "bad-id",
+				withScopeIds: []string{"bad-id"},
 			},
 			wantCnt: 0,
 			wantErr: false,
@@ -628,7 +628,7 @@ func TestRepository_ListRoles(t *testing.T) {
 				testRoles = append(testRoles, TestRole(t, conn, tt.createScopeId))
 			}
 			assert.Equal(tt.createCnt, len(testRoles))
-			got, err := repo.ListRoles(context.Background(), tt.args.withScopeId, tt.args.opt...)
+			got, err := repo.ListRoles(context.Background(), tt.args.withScopeIds, tt.args.opt...)
 			if tt.wantErr {
 				require.Error(err)
 				return
@@ -420,7 +420,8 @@ func (r *Repository) DeleteScope(ctx context.Context, withPublicId string, opt .
 	return rowsDeleted, nil
 }
 
-// ListProjects in an org and supports the WithLimit option.
+// ListProjects in an org and supports the WithLimit and
+// WithStartPageAfterId options.
 func (r *Repository) ListProjects(ctx context.Context, withOrgId string, opt ...Option) ([]*Scope, error) {
 	if withOrgId == "" {
 		return nil, fmt.Errorf("list projects: missing org id %w", errors.ErrInvalidParameter)
@@ -433,7 +434,42 @@ func (r *Repository) ListProjects(ctx context.Context, withOrgId string, opt ...
 	return projects, nil
 }
 
-// ListOrgs and supports the WithLimit option.
+// ListScopes whose parents are the given scopes and supports the WithLimit
+// and WithStartPageAfterId options.
+func (r *Repository) ListScopes(ctx context.Context, withParentIds []string, opt ...Option) ([]*Scope, error) {
+	if len(withParentIds) == 0 {
+		return nil, fmt.Errorf("list scopes: missing parent id %w", errors.ErrInvalidParameter)
+	}
+	var scopes []*Scope
+	err := r.list(ctx, &scopes, "parent_id in (?)", []interface{}{withParentIds}, opt...)
+	if err != nil {
+		return nil, fmt.Errorf("list scopes: %w", err)
+	}
+	return scopes, nil
+}
+
+// ListScopesRecursively returns the given scope and all of its descendants.
+func (r *Repository) ListScopesRecursively(ctx context.Context, rootScopeId string) ([]*Scope, error) {
+	var where string
+	var args []interface{}
+	switch {
+	case rootScopeId == scope.Global.String():
+		where = "1=1"
+	case strings.HasPrefix(rootScopeId, scope.Org.Prefix()):
+		where, args = "public_id = ? or parent_id = ?", []interface{}{rootScopeId, rootScopeId}
+	case strings.HasPrefix(rootScopeId, scope.Project.Prefix()):
+		where, args = "public_id = ?", []interface{}{rootScopeId}
+	default:
+		return nil, fmt.Errorf("list scopes recursively: invalid root scope id %q: %w", rootScopeId, errors.ErrInvalidParameter)
+	}
+	var scopes []*Scope
+	if err := r.reader.SearchWhere(ctx, &scopes, where, args, db.WithLimit(-1)); err != nil {
+		return nil, fmt.Errorf("list scopes recursively: %w", err)
+	}
+	return scopes, nil
+}
+
+// ListOrgs and supports the WithLimit and WithStartPageAfterId options.
 func (r *Repository) ListOrgs(ctx context.Context, opt ...Option) ([]*Scope, error) {
 	var orgs []*Scope
 	err := r.list(ctx, &orgs, "parent_id = ? and type = ?", []interface{}{"global", scope.Org.String()}, opt...)
@@ -120,7 +120,7 @@ func Test_Repository_Scope_Create(t *testing.T) {
 			require.NoError(err)
 			assert.True(proto.Equal(foundScope, s))
 
-			foundRoles, err := repo.ListRoles(context.Background(), foundScope.GetPublicId())
+			foundRoles, err := repo.ListRoles(context.Background(), []string{foundScope.GetPublicId()})
 			require.NoError(err)
 			numFound := 2
 			if skipCreate {
@@ -615,3 +615,49 @@ func Test_Repository_ListOrgs(t *testing.T) {
 		})
 	}
 }
+
+func Test_Repository_ListScopes(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org1 := testOrg(t, repo, "", "")
+	org2 := testOrg(t, repo, "", "")
+	proj1 := testProject(t, repo, org1.PublicId)
+	proj2 := testProject(t, repo, org2.PublicId)
+
+	t.Run("ListScopes", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.ListScopes(context.Background(), []string{org1.PublicId, org2.PublicId})
+		require.NoError(err)
+		var gotIds []string
+		for _, s := range got {
+			gotIds = append(gotIds, s.PublicId)
+		}
+		assert.ElementsMatch([]string{proj1.PublicId, proj2.PublicId}, gotIds)
+
+		_, err = repo.ListScopes(context.Background(), nil)
+		assert.Error(err)
+	})
+	t.Run("ListScopesRecursively", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.ListScopesRecursively(context.Background(), org1.PublicId)
+		require.NoError(err)
+		var gotIds []string
+		for _, s := range got {
+			gotIds = append(gotIds, s.PublicId)
+		}
+		assert.ElementsMatch([]string{org1.PublicId, proj1.PublicId}, gotIds)
+
+		got, err = repo.ListScopesRecursively(context.Background(), "global")
+		require.NoError(err)
+		gotIds = nil
+		for _, s := range got {
+			gotIds = append(gotIds, s.PublicId)
+		}
+		assert.Subset(gotIds, []string{"global", org1.PublicId, org2.PublicId, proj1.PublicId, proj2.PublicId})
+
+		_, err = repo.ListScopesRecursively(context.Background(), "bad-id")
+		assert.Error(err)
+	})
+}
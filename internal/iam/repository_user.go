@@ -195,13 +195,14 @@ func (r *Repository) DeleteUser(ctx context.Context, withPublicId string, opt ..
 	return rowsDeleted, nil
 }
 
-// ListUsers in an org and supports the WithLimit option.
-func (r *Repository) ListUsers(ctx context.Context, withOrgId string, opt ...Option) ([]*User, error) {
-	if withOrgId == "" {
-		return nil, fmt.Errorf("list users: missing org id %w", errors.ErrInvalidParameter)
+// ListUsers in the given scopes and supports the WithLimit and
+// WithStartPageAfterId options.
+func (r *Repository) ListUsers(ctx context.Context, withScopeIds []string, opt ...Option) ([]*User, error) {
+	if len(withScopeIds) == 0 {
+		return nil, fmt.Errorf("list users: missing scope id %w", errors.ErrInvalidParameter)
 	}
 	var users []*User
-	err := r.list(ctx, &users, "scope_id = ?", []interface{}{withOrgId}, opt...)
+	err := r.list(ctx, &users, "scope_id in (?)", []interface{}{withScopeIds}, opt...)
 	if err != nil {
 		return nil, fmt.Errorf("list users: %w", err)
 	}
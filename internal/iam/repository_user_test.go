@@ -471,8 +471,8 @@ func TestRepository_ListUsers(t *testing.T) {
 	org, _ := TestScopes(t, repo)
 
 	type args struct {
-		withOrgId string
-		opt       []Option
+		withScopeIds []string
+		opt          []Option
 	}
 	tests := []struct {
 		name      string
@@ -485,8 +485,8 @@ func TestRepository_ListUsers(t *testing.T) {
 			name:      "no-limit",
 			createCnt: repo.defaultLimit + 1,
 			args: args{
-				withOrgId: org.PublicId,
-				opt:       []Option{WithLimit(-1)},
+				withScopeIds: []string{org.PublicId},
+				opt:          []Option{WithLimit(-1)},
 			},
 			wantCnt: repo.defaultLimit + 1,
 			wantErr: false,
@@ -495,7 +495,7 @@ func TestRepository_ListUsers(t *testing.T) {
 			name:      "default-limit",
 			createCnt: repo.defaultLimit + 1,
 			args: args{
-				withOrgId: org.PublicId,
+				withScopeIds: []string{org.PublicId},
 			},
 			wantCnt: repo.defaultLimit,
 			wantErr: false,
@@ -504,8 +504,8 @@ func TestRepository_ListUsers(t *testing.T) {
 			name:      "custom-limit",
 			createCnt: repo.defaultLimit + 1,
 			args: args{
-				withOrgId: org.PublicId,
-				opt:       []Option{WithLimit(3)},
+				withScopeIds: []string{org.PublicId},
+				opt:          []Option{WithLimit(3)},
 			},
 			wantCnt: 3,
 			wantErr: false,
@@ -514,7 +514,7 @@ func TestRepository_ListUsers(t *testing.T) {
 			name:      "bad-org",
 			createCnt: 1,
 			args: args{
-				withOrgId: "bad-id",
+				withScopeIds: []string{"bad-id"},
 			},
 			wantCnt: 0,
 			wantErr: false,
@@ -529,7 +529,7 @@ func TestRepository_ListUsers(t *testing.T) {
 				testUsers = append(testUsers, TestUser(t, repo, org.PublicId))
 			}
 			assert.Equal(tt.createCnt, len(testUsers))
-			got, err := repo.ListUsers(context.Background(), tt.args.withOrgId, tt.args.opt...)
+			got, err := repo.ListUsers(context.Background(), tt.args.withScopeIds, tt.args.opt...)
 			if tt.wantErr {
 				require.Error(err)
 				return
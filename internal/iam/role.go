@@ -18,6 +18,22 @@ const (
 	defaultRoleTableName = "iam_role"
 )
 
+// The grant scope inheritance of a role determines the scopes its grants apply
+// to beyond its grant scope.
+const (
+	// GrantScopeNoInheritance applies the grants of the role to its grant
+	// scope only. This is the default.
+	GrantScopeNoInheritance = "none"
+
+	// GrantScopeChildren also applies the grants of the role to the direct
+	// child scopes of its grant scope.
+	GrantScopeChildren = "children"
+
+	// GrantScopeDescendants also applies the grants of the role to all of the
+	// descendant scopes of its grant scope.
+	GrantScopeDescendants = "descendants"
+)
+
 // Roles are granted permissions and assignable to Users and Groups.
 type Role struct {
 	*store.Role
@@ -30,18 +46,25 @@ var _ Cloneable = (*Role)(nil)
 var _ db.VetForWriter = (*Role)(nil)
 
 // NewRole creates a new in memory role with a scope (project/org)
-// allowed options include: withDescripion, WithName, withGrantScopeId.
+// allowed options include: withDescripion, WithName, withGrantScopeId,
+// WithGrantScopeInheritance.
 func NewRole(scopeId string, opt ...Option) (*Role, error) {
 	if scopeId == "" {
 		return nil, fmt.Errorf("new role: missing scope id %w", errors.ErrInvalidParameter)
 	}
 	opts := getOpts(opt...)
+	switch opts.withGrantScopeInheritance {
+	case "", GrantScopeNoInheritance, GrantScopeChildren, GrantScopeDescendants:
+	default:
+		return nil, fmt.Errorf("new role: unknown grant scope inheritance %q: %w", opts.withGrantScopeInheritance, errors.ErrInvalidParameter)
+	}
 	r := &Role{
 		Role: &store.Role{
-			ScopeId:      scopeId,
-			Name:         opts.withName,
-			Description:  opts.withDescription,
-			GrantScopeId: opts.withGrantScopeId,
+			ScopeId:               scopeId,
+			Name:                  opts.withName,
+			Description:           opts.withDescription,
+			GrantScopeId:          opts.withGrantScopeId,
+			GrantScopeInheritance: opts.withGrantScopeInheritance,
 		},
 	}
 	return r, nil
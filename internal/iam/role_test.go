@@ -74,6 +74,25 @@ func TestNewRole(t *testing.T) {
 			wantErrMsg: "new role: missing scope id invalid parameter:",
 			wantIsErr:  errors.ErrInvalidParameter,
 		},
+		{
+			name: "valid-grant-scope-inheritance",
+			args: args{
+				scopePublicId: org.PublicId,
+				opt:           []Option{WithName(id), WithGrantScopeId(org.PublicId), WithGrantScopeInheritance(GrantScopeDescendants)},
+			},
+			wantErr:  false,
+			wantName: id,
+		},
+		{
+			name: "unknown-grant-scope-inheritance",
+			args: args{
+				scopePublicId: org.PublicId,
+				opt:           []Option{WithGrantScopeInheritance("siblings")},
+			},
+			wantErr:    true,
+			wantErrMsg: `new role: unknown grant scope inheritance "siblings"`,
+			wantIsErr:  errors.ErrInvalidParameter,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -221,6 +221,22 @@ func TestGroupRole(t *testing.T, conn *gorm.DB, roleId, grpId string, opt ...Opt
 	return r
 }
 
+// TestAccessRequest creates a pending access request by the user for the
+// target, which must be in the scope, for one hour.
+func TestAccessRequest(t *testing.T, conn *gorm.DB, scopeId, targetId, userId string, opt ...Option) *AccessRequest {
+	t.Helper()
+	require := require.New(t)
+	rw := db.New(conn)
+	req, err := NewAccessRequest(scopeId, targetId, userId, "testing", 3600, opt...)
+	require.NoError(err)
+	id, err := newAccessRequestId()
+	require.NoError(err)
+	req.PublicId = id
+	err = rw.Create(context.Background(), req)
+	require.NoError(err)
+	return req
+}
+
 // testAccount is a temporary test function.  TODO - replace with an auth
 // subsystem testAccount function.  If userId is zero value, then an auth
 // account will be created with a null IamUserId
@@ -2,13 +2,16 @@ package alpnmux
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 )
@@ -23,8 +26,18 @@ const (
 	// connection that has protos defined, we will look for that proto first,
 	// then DefaultProto.
 	DefaultProto = "(*)"
+
+	// PassthroughProto is used for the listener registered with
+	// RegisterPassthrough
+	PassthroughProto = "(passthrough)"
+
+	// maxRecordLen is the largest TLS record that can hold a ClientHello
+	maxRecordLen = 5 + 16384
 )
 
+// errPeeked aborts the handshake used to parse a peeked ClientHello
+var errPeeked = errors.New("client hello peeked")
+
 type bufferedConn struct {
 	net.Conn
 	buffer *bufio.Reader
@@ -34,6 +47,15 @@ func (b *bufferedConn) Read(p []byte) (int, error) {
 	return b.buffer.Read(p)
 }
 
+// PassthroughConn is a connection returned by the listener registered with
+// RegisterPassthrough. Its TLS handshake has not been performed.
+type PassthroughConn struct {
+	net.Conn
+
+	// ServerName is the SNI of the connection's ClientHello
+	ServerName string
+}
+
 type muxedListener struct {
 	connMutex *sync.RWMutex
 	ctx       context.Context
@@ -44,6 +66,9 @@ type muxedListener struct {
 	closed    bool
 	closeFunc func()
 	closeOnce *sync.Once
+
+	// match is only set for the passthrough listener
+	match func(*tls.ClientHelloInfo) bool
 }
 
 type ALPNMux struct {
@@ -116,6 +141,43 @@ func (l *ALPNMux) RegisterProto(proto string, tlsConf *tls.Config) (net.Listener
 	return sub, nil
 }
 
+// RegisterPassthrough registers a listener which receives the TLS connections
+// whose ClientHello match returns true for, as *PassthroughConn. The
+// handshake of these connections is not performed; the ClientHello is left
+// unread so that they can be forwarded as-is to a server which can complete
+// it. match is called
+// for every TLS connection before its handshake and must be safe for
+// concurrent use. Use UnregisterProto with PassthroughProto to remove it.
+func (l *ALPNMux) RegisterPassthrough(match func(*tls.ClientHelloInfo) bool) (net.Listener, error) {
+	if match == nil {
+		return nil, errors.New("nil match function given")
+	}
+	sub := &muxedListener{
+		connMutex: new(sync.RWMutex),
+		ctx:       l.ctx,
+		addr:      l.baseLn.Addr(),
+		proto:     PassthroughProto,
+		connCh:    make(chan net.Conn),
+		closeOnce: new(sync.Once),
+		match:     match,
+	}
+	_, loaded := l.muxMap.LoadOrStore(PassthroughProto, sub)
+	if loaded {
+		close(sub.connCh)
+		return nil, errors.New("passthrough already registered")
+	}
+
+	sub.closeFunc = func() {
+		go l.UnregisterProto(PassthroughProto)
+	}
+
+	if l.log != nil && l.log.IsDebug() {
+		l.log.Debug("registered passthrough")
+	}
+
+	return sub, nil
+}
+
 func (l *ALPNMux) UnregisterProto(proto string) {
 	val, ok := l.muxMap.Load(proto)
 	if !ok {
@@ -203,9 +265,15 @@ func (l *ALPNMux) accept() {
 		// Do the rest in a goroutine so that a timeout in e.g. handshaking
 		// doesn't block acceptance of the next connection
 		go func() {
+			passthrough, hasPassthrough := l.muxMap.Load(PassthroughProto)
+			bufSize := 4096
+			if hasPassthrough {
+				// Make room for peeking the entire ClientHello
+				bufSize = maxRecordLen
+			}
 			bufConn := &bufferedConn{
 				Conn:   conn,
-				buffer: bufio.NewReader(conn),
+				buffer: bufio.NewReaderSize(conn, bufSize),
 			}
 			peeked, err := bufConn.buffer.Peek(3)
 			if err != nil {
@@ -244,6 +312,28 @@ func (l *ALPNMux) accept() {
 				if l.log != nil && l.log.IsTrace() {
 					l.log.Trace("connection is tls", "addr", conn.RemoteAddr())
 				}
+				if hasPassthrough {
+					ml := passthrough.(*muxedListener)
+					hello, err := peekClientHello(bufConn.buffer)
+					if err != nil {
+						if l.log != nil && l.log.IsDebug() {
+							l.log.Debug("error peeking client hello", "addr", conn.RemoteAddr(), "error", err)
+						}
+					}
+					if hello != nil && ml.match(hello) {
+						if l.log != nil && l.log.IsTrace() {
+							l.log.Trace("passing through connection", "addr", conn.RemoteAddr(), "server_name", hello.ServerName)
+						}
+						ml.connMutex.RLock()
+						if !ml.closed {
+							ml.connCh <- &PassthroughConn{Conn: bufConn, ServerName: hello.ServerName}
+						} else {
+							bufConn.Close()
+						}
+						ml.connMutex.RUnlock()
+						return
+					}
+				}
 				tlsConn := tls.Server(bufConn, baseTLSConf)
 				if l.log != nil && l.log.IsTrace() {
 					l.log.Trace("handshaking", "addr", conn.RemoteAddr())
@@ -284,6 +374,44 @@ func (l *ALPNMux) accept() {
 	}
 }
 
+// peekClientHello parses the ClientHello at the start of r without consuming
+// it. The ClientHello must fit in a single TLS record.
+func peekClientHello(r *bufio.Reader) (*tls.ClientHelloInfo, error) {
+	header, err := r.Peek(5)
+	if err != nil {
+		return nil, err
+	}
+	record, err := r.Peek(5 + (int(header[3])<<8 | int(header[4])))
+	if err != nil {
+		return nil, err
+	}
+	var hello *tls.ClientHelloInfo
+	err = tls.Server(&peekedConn{reader: bytes.NewReader(record)}, &tls.Config{
+		GetConfigForClient: func(h *tls.ClientHelloInfo) (*tls.Config, error) {
+			hello = h
+			return nil, errPeeked
+		},
+	}).Handshake()
+	if hello == nil {
+		return nil, err
+	}
+	return hello, nil
+}
+
+// peekedConn is a read-only net.Conn over peeked bytes
+type peekedConn struct {
+	reader io.Reader
+}
+
+func (c *peekedConn) Read(p []byte) (int, error)         { return c.reader.Read(p) }
+func (c *peekedConn) Write(p []byte) (int, error)        { return 0, io.ErrClosedPipe }
+func (c *peekedConn) Close() error                       { return nil }
+func (c *peekedConn) LocalAddr() net.Addr                { return nil }
+func (c *peekedConn) RemoteAddr() net.Addr               { return nil }
+func (c *peekedConn) SetDeadline(t time.Time) error      { return nil }
+func (c *peekedConn) SetReadDeadline(t time.Time) error  { return nil }
+func (c *peekedConn) SetWriteDeadline(t time.Time) error { return nil }
+
 func (m *muxedListener) Accept() (net.Conn, error) {
 	for {
 		select {
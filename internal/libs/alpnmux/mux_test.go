@@ -191,3 +191,74 @@ func TestListening(t *testing.T) {
 		t.Fatal("wrong number of conns")
 	}
 }
+
+func TestPassthrough(t *testing.T) {
+	listener := getListener(t)
+	mux := New(listener, nil)
+	defer mux.Close()
+
+	baseconfig := getTestTLS(t, nil)
+	if _, err := mux.RegisterPassthrough(nil); err == nil {
+		t.Fatal("expected error registering nil match function")
+	}
+	lpass, err := mux.RegisterPassthrough(func(hello *tls.ClientHelloInfo) bool {
+		return hello.ServerName == "pass"
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mux.RegisterPassthrough(func(*tls.ClientHelloInfo) bool { return true }); err == nil {
+		t.Fatal("expected error registering passthrough twice")
+	}
+	ldef, err := mux.RegisterProto(DefaultProto, baseconfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr := listener.Addr().String()
+	dial := func(serverName string) chan error {
+		errCh := make(chan error, 1)
+		go func() {
+			clientConf := baseconfig.Clone()
+			clientConf.ServerName = serverName
+			clientConf.InsecureSkipVerify = true
+			conn, err := tls.Dial("tcp4", addr, clientConf)
+			if err == nil {
+				conn.Close()
+			}
+			errCh <- err
+		}()
+		return errCh
+	}
+
+	// The passed through connection still needs its handshake completed
+	errCh := dial("pass")
+	conn, err := lpass.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := conn.(*PassthroughConn).ServerName; got != "pass" {
+		t.Fatalf("expected passthrough server name pass, got %q", got)
+	}
+	tlsConn := tls.Server(conn, baseconfig)
+	if err := tlsConn.Handshake(); err != nil {
+		t.Fatal(err)
+	}
+	if got := tlsConn.ConnectionState().ServerName; got != "pass" {
+		t.Fatalf("expected server name pass, got %q", got)
+	}
+	tlsConn.Close()
+	if err := <-errCh; err != nil {
+		t.Fatal(err)
+	}
+
+	errCh = dial("local")
+	conn, err = ldef.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+	if err := <-errCh; err != nil {
+		t.Fatal(err)
+	}
+}
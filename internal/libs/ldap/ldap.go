@@ -0,0 +1,102 @@
+// Package ldap connects to an LDAP server such as OpenLDAP or Active
+// Directory using the TLS settings shared by the LDAP auth method and group
+// synchronization. The protocol itself is implemented by
+// github.com/go-ldap/ldap/v3.
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// DefaultDialTimeout is used when the Config does not specify a timeout.
+const DefaultDialTimeout = 10 * time.Second
+
+// Config specifies how to connect to an LDAP server.
+type Config struct {
+	// Url is the address of the server, using the ldap or ldaps scheme. The
+	// port defaults to 389 and 636 respectively.
+	Url string
+
+	// StartTls upgrades an ldap connection to TLS before any other operation.
+	StartTls bool
+
+	// InsecureTls disables the verification of the server's certificate.
+	InsecureTls bool
+
+	// CaCert is a PEM bundle of the certificates used to verify the server.
+	// The system roots are used if it is empty.
+	CaCert string
+
+	// DialTimeout bounds the time taken to connect. DefaultDialTimeout is
+	// used if it is zero.
+	DialTimeout time.Duration
+}
+
+// TlsConfig returns the TLS configuration used to connect to the server
+// named by host.
+func (c Config) TlsConfig(host string) (*tls.Config, error) {
+	tlsConf := &tls.Config{
+		ServerName:         host,
+		InsecureSkipVerify: c.InsecureTls,
+		MinVersion:         tls.VersionTLS12,
+	}
+	if c.CaCert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(c.CaCert)) {
+			return nil, errors.New("no certificates found in ca cert bundle")
+		}
+		tlsConf.RootCAs = pool
+	}
+	return tlsConf, nil
+}
+
+// Dial connects to the server, performing StartTLS if it is configured. The
+// connection must be made before the deadline of ctx, if it has one.
+func Dial(ctx context.Context, c Config) (*ldap.Conn, error) {
+	u, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, fmt.Errorf("ldap dial: unable to parse url: %w", err)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "ldap":
+	case "ldaps":
+		if c.StartTls {
+			return nil, errors.New("ldap dial: start tls cannot be used with an ldaps url")
+		}
+	default:
+		return nil, fmt.Errorf("ldap dial: unsupported url scheme %q", u.Scheme)
+	}
+	tlsConf, err := c.TlsConfig(u.Hostname())
+	if err != nil {
+		return nil, fmt.Errorf("ldap dial: %w", err)
+	}
+	timeout := c.DialTimeout
+	if timeout == 0 {
+		timeout = DefaultDialTimeout
+	}
+	dialer := &net.Dialer{Timeout: timeout}
+	if deadline, ok := ctx.Deadline(); ok {
+		dialer.Deadline = deadline
+	}
+	conn, err := ldap.DialURL(c.Url, ldap.DialWithDialer(dialer), ldap.DialWithTLSConfig(tlsConf))
+	if err != nil {
+		return nil, fmt.Errorf("ldap dial: %w", err)
+	}
+	if c.StartTls {
+		if err := conn.StartTLS(tlsConf); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("ldap dial: start tls: %w", err)
+		}
+	}
+	return conn, nil
+}
@@ -0,0 +1,184 @@
+package ldap
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDirectory(t *testing.T, opt ...TestServerOption) *TestServer {
+	t.Helper()
+	s := NewTestServer(t, opt...)
+	s.AddEntry("dc=example,dc=com", map[string][]string{"objectClass": {"domain"}})
+	s.AddEntry("ou=people,dc=example,dc=com", map[string][]string{"objectClass": {"organizationalUnit"}})
+	s.AddEntry("uid=jim,ou=people,dc=example,dc=com", map[string][]string{
+		"objectClass": {"person"},
+		"uid":         {"jim"},
+		"mail":        {"jim@example.com"},
+	})
+	s.AddEntry("uid=sue,ou=people,dc=example,dc=com", map[string][]string{
+		"objectClass": {"person"},
+		"uid":         {"sue"},
+	})
+	s.SetPassword("uid=jim,ou=people,dc=example,dc=com", "jim-password")
+	return s
+}
+
+func TestConn_Bind(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name     string
+		opts     []TestServerOption
+		dn       string
+		password string
+		wantCode uint16
+	}{
+		{name: "valid", dn: "uid=jim,ou=people,dc=example,dc=com", password: "jim-password"},
+		{name: "valid dn case", dn: "UID=jim,ou=people,dc=example,dc=com", password: "jim-password"},
+		{name: "ldaps", opts: []TestServerOption{WithTestLdaps()}, dn: "uid=jim,ou=people,dc=example,dc=com", password: "jim-password"},
+		{name: "start tls", opts: []TestServerOption{WithTestStartTls()}, dn: "uid=jim,ou=people,dc=example,dc=com", password: "jim-password"},
+		{name: "wrong password", dn: "uid=jim,ou=people,dc=example,dc=com", password: "wrong", wantCode: ldap.LDAPResultInvalidCredentials},
+		// An unauthenticated bind, which servers report as successful, is
+		// refused by the client
+		{name: "no password", dn: "uid=sue,ou=people,dc=example,dc=com", password: "", wantCode: ldap.ErrorEmptyPassword},
+		{name: "unknown dn", dn: "uid=bob,ou=people,dc=example,dc=com", password: "jim-password", wantCode: ldap.LDAPResultInvalidCredentials},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			s := testDirectory(t, tt.opts...)
+			conn, err := Dial(ctx, s.Config())
+			require.NoError(err)
+			defer conn.Close()
+			err = conn.Bind(tt.dn, tt.password)
+			if tt.wantCode != 0 {
+				require.Error(err)
+				assert.True(ldap.IsErrorWithCode(err, tt.wantCode), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(err)
+		})
+	}
+}
+
+func TestDial(t *testing.T) {
+	ctx := context.Background()
+	s := testDirectory(t, WithTestLdaps())
+
+	// Without the server's certificate the handshake fails.
+	conf := s.Config()
+	conf.CaCert = ""
+	_, err := Dial(ctx, conf)
+	assert.Error(t, err)
+
+	conf.InsecureTls = true
+	conn, err := Dial(ctx, conf)
+	require.NoError(t, err)
+	conn.Close()
+
+	conf = s.Config()
+	conf.StartTls = true
+	_, err = Dial(ctx, conf)
+	assert.Error(t, err)
+
+	_, err = Dial(ctx, Config{Url: "http://localhost"})
+	assert.Error(t, err)
+}
+
+func TestConn_Search(t *testing.T) {
+	ctx := context.Background()
+	s := testDirectory(t)
+	conn, err := Dial(ctx, s.Config())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	tests := []struct {
+		name     string
+		req      *ldap.SearchRequest
+		wantDNs  []string
+		wantCode uint16
+	}{
+		{
+			name:    "subtree",
+			req:     testSearchRequest("dc=example,dc=com", ldap.ScopeWholeSubtree, "(objectClass=person)"),
+			wantDNs: []string{"uid=jim,ou=people,dc=example,dc=com", "uid=sue,ou=people,dc=example,dc=com"},
+		},
+		{
+			name:    "single level",
+			req:     testSearchRequest("dc=example,dc=com", ldap.ScopeSingleLevel, "(objectClass=*)"),
+			wantDNs: []string{"ou=people,dc=example,dc=com"},
+		},
+		{
+			name:    "base",
+			req:     testSearchRequest("uid=sue,ou=people,dc=example,dc=com", ldap.ScopeBaseObject, "(objectClass=*)"),
+			wantDNs: []string{"uid=sue,ou=people,dc=example,dc=com"},
+		},
+		{
+			name:    "filter",
+			req:     testSearchRequest("dc=example,dc=com", ldap.ScopeWholeSubtree, "(&(objectClass=person)(mail=*))"),
+			wantDNs: []string{"uid=jim,ou=people,dc=example,dc=com"},
+		},
+		{
+			name:     "missing base",
+			req:      testSearchRequest("ou=missing,dc=example,dc=com", ldap.ScopeBaseObject, "(objectClass=*)"),
+			wantCode: ldap.LDAPResultNoSuchObject,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			res, err := conn.Search(tt.req)
+			if tt.wantCode != 0 {
+				require.Error(err)
+				assert.True(ldap.IsErrorWithCode(err, tt.wantCode), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(err)
+			var dns []string
+			for _, e := range res.Entries {
+				dns = append(dns, e.DN)
+			}
+			sort.Strings(dns)
+			assert.Equal(tt.wantDNs, dns)
+		})
+	}
+
+	t.Run("attributes", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		req := testSearchRequest("uid=jim,ou=people,dc=example,dc=com", ldap.ScopeBaseObject, "(objectClass=*)")
+		req.Attributes = []string{"UID"}
+		res, err := conn.Search(req)
+		require.NoError(err)
+		require.Len(res.Entries, 1)
+		assert.Equal([]string{"jim"}, res.Entries[0].GetEqualFoldAttributeValues("uid"))
+		assert.Empty(res.Entries[0].GetEqualFoldAttributeValues("mail"))
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		for filter, wantDNs := range map[string][]string{
+			"(uid=JIM)":                          {"uid=jim,ou=people,dc=example,dc=com"},
+			"(mail=*@example.com)":               {"uid=jim,ou=people,dc=example,dc=com"},
+			"(uid=s*)":                           {"uid=sue,ou=people,dc=example,dc=com"},
+			"(|(uid=jim)(uid=sue))":              {"uid=jim,ou=people,dc=example,dc=com", "uid=sue,ou=people,dc=example,dc=com"},
+			"(&(objectClass=person)(!(mail=*)))": {"uid=sue,ou=people,dc=example,dc=com"},
+			"(uid=bob)":                          nil,
+		} {
+			res, err := conn.Search(testSearchRequest("dc=example,dc=com", ldap.ScopeWholeSubtree, filter))
+			require.NoError(t, err, filter)
+			var dns []string
+			for _, e := range res.Entries {
+				dns = append(dns, e.DN)
+			}
+			sort.Strings(dns)
+			assert.Equal(t, wantDNs, dns, filter)
+		}
+	})
+}
+
+func testSearchRequest(baseDn string, scope int, filter string) *ldap.SearchRequest {
+	return ldap.NewSearchRequest(baseDn, scope, ldap.NeverDerefAliases, 0, 0, false, filter, nil, nil)
+}
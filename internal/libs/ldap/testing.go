@@ -0,0 +1,418 @@
+package ldap
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+)
+
+// startTlsOid is the name of the StartTLS extended operation.
+const startTlsOid = "1.3.6.1.4.1.1466.20037"
+
+// TestServer is an in-memory LDAP directory supporting the bind, search and
+// StartTLS operations. Searches do not require a bind.
+type TestServer struct {
+	t        *testing.T
+	ln       net.Listener
+	ldaps    bool
+	startTls bool
+	tlsConf  *tls.Config
+	caCert   string
+
+	l         sync.Mutex
+	entries   []*ldap.Entry
+	passwords map[string]string
+}
+
+// TestServerOption configures a TestServer.
+type TestServerOption func(*TestServer)
+
+// WithTestLdaps serves LDAP over TLS.
+func WithTestLdaps() TestServerOption {
+	return func(s *TestServer) {
+		s.ldaps = true
+	}
+}
+
+// WithTestStartTls supports the StartTLS extended operation.
+func WithTestStartTls() TestServerOption {
+	return func(s *TestServer) {
+		s.startTls = true
+	}
+}
+
+// NewTestServer starts a TestServer listening on the loopback interface. It
+// is stopped when the test completes.
+func NewTestServer(t *testing.T, opt ...TestServerOption) *TestServer {
+	t.Helper()
+	s := &TestServer{
+		t:         t,
+		passwords: map[string]string{},
+	}
+	for _, o := range opt {
+		o(s)
+	}
+	if s.ldaps || s.startTls {
+		s.tlsConf, s.caCert = testTlsConfig(t)
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.ldaps {
+		ln = tls.NewListener(ln, s.tlsConf)
+	}
+	s.ln = ln
+	t.Cleanup(func() { s.ln.Close() })
+	go s.serve()
+	return s
+}
+
+// Url returns the URL of the server.
+func (s *TestServer) Url() string {
+	scheme := "ldap"
+	if s.ldaps {
+		scheme = "ldaps"
+	}
+	return fmt.Sprintf("%s://%s", scheme, s.ln.Addr().String())
+}
+
+// CaCert returns the PEM encoded certificate of the server, if it uses TLS.
+func (s *TestServer) CaCert() string {
+	return s.caCert
+}
+
+// Config returns the Config used to connect to the server.
+func (s *TestServer) Config() Config {
+	return Config{
+		Url:      s.Url(),
+		StartTls: s.startTls,
+		CaCert:   s.caCert,
+	}
+}
+
+// AddEntry adds an entry to the directory, replacing any entry with the same
+// DN.
+func (s *TestServer) AddEntry(dn string, attrs map[string][]string) {
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.removeEntry(dn)
+	cp := make(map[string][]string, len(attrs))
+	for k, v := range attrs {
+		cp[k] = append([]string(nil), v...)
+	}
+	s.entries = append(s.entries, ldap.NewEntry(dn, cp))
+}
+
+// RemoveEntry removes the entry with the DN from the directory.
+func (s *TestServer) RemoveEntry(dn string) {
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.removeEntry(dn)
+}
+
+func (s *TestServer) removeEntry(dn string) {
+	for i, e := range s.entries {
+		if strings.EqualFold(e.DN, dn) {
+			s.entries = append(s.entries[:i], s.entries[i+1:]...)
+			return
+		}
+	}
+}
+
+// SetPassword sets the password used to bind as the DN.
+func (s *TestServer) SetPassword(dn, password string) {
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.passwords[strings.ToLower(dn)] = password
+}
+
+func (s *TestServer) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *TestServer) handle(conn net.Conn) {
+	defer conn.Close()
+	for {
+		msg, err := ber.ReadPacket(conn)
+		if err != nil {
+			return
+		}
+		if len(msg.Children) < 2 {
+			return
+		}
+		id, _ := msg.Children[0].Value.(int64)
+		op := msg.Children[1]
+		write := func(resp *ber.Packet) {
+			env := ber.NewSequence("LDAP Message")
+			env.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "Message ID"))
+			env.AppendChild(resp)
+			_, _ = conn.Write(env.Bytes())
+		}
+		switch op.Tag {
+		case ldap.ApplicationUnbindRequest:
+			return
+		case ldap.ApplicationBindRequest:
+			write(s.bind(op))
+		case ldap.ApplicationSearchRequest:
+			entries, done := s.search(op)
+			for _, e := range entries {
+				write(encodeEntry(e))
+			}
+			write(done)
+		case ldap.ApplicationExtendedRequest:
+			if !s.startTls || len(op.Children) == 0 || op.Children[0].Data.String() != startTlsOid {
+				write(testResult(ldap.ApplicationExtendedResponse, ldap.LDAPResultProtocolError, "unsupported extended operation"))
+				continue
+			}
+			write(testResult(ldap.ApplicationExtendedResponse, ldap.LDAPResultSuccess, ""))
+			tlsConn := tls.Server(conn, s.tlsConf)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn = tlsConn
+		default:
+			return
+		}
+	}
+}
+
+func (s *TestServer) bind(op *ber.Packet) *ber.Packet {
+	if len(op.Children) < 3 {
+		return testResult(ldap.ApplicationBindResponse, ldap.LDAPResultProtocolError, "malformed bind request")
+	}
+	dn := op.Children[1].Data.String()
+	password := op.Children[2].Data.String()
+	s.l.Lock()
+	defer s.l.Unlock()
+	if want, ok := s.passwords[strings.ToLower(dn)]; !ok || want != password {
+		return testResult(ldap.ApplicationBindResponse, ldap.LDAPResultInvalidCredentials, "invalid credentials")
+	}
+	return testResult(ldap.ApplicationBindResponse, ldap.LDAPResultSuccess, "")
+}
+
+func (s *TestServer) search(op *ber.Packet) ([]*ldap.Entry, *ber.Packet) {
+	if len(op.Children) < 8 {
+		return nil, testResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultProtocolError, "malformed search request")
+	}
+	base := strings.ToLower(op.Children[0].Data.String())
+	scope, _ := op.Children[1].Value.(int64)
+	filter := op.Children[6]
+	var attrs []string
+	for _, a := range op.Children[7].Children {
+		attrs = append(attrs, a.Data.String())
+	}
+
+	s.l.Lock()
+	defer s.l.Unlock()
+	var baseFound bool
+	var ret []*ldap.Entry
+	for _, e := range s.entries {
+		dn := strings.ToLower(e.DN)
+		if dn == base {
+			baseFound = true
+		}
+		var inScope bool
+		switch int(scope) {
+		case ldap.ScopeBaseObject:
+			inScope = dn == base
+		case ldap.ScopeSingleLevel:
+			i := strings.IndexByte(dn, ',')
+			inScope = i >= 0 && dn[i+1:] == base
+		default:
+			inScope = dn == base || strings.HasSuffix(dn, ","+base)
+		}
+		if !inScope {
+			continue
+		}
+		match, err := testMatches(filter, e)
+		if err != nil {
+			return nil, testResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultProtocolError, err.Error())
+		}
+		if !match {
+			continue
+		}
+		ret = append(ret, selectAttributes(e, attrs))
+	}
+	if !baseFound && len(ret) == 0 {
+		return nil, testResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultNoSuchObject, "no such object")
+	}
+	return ret, testResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess, "")
+}
+
+func selectAttributes(e *ldap.Entry, attrs []string) *ldap.Entry {
+	ret := &ldap.Entry{DN: e.DN}
+	for _, ea := range e.Attributes {
+		if len(attrs) > 0 {
+			var want bool
+			for _, a := range attrs {
+				if strings.EqualFold(a, ea.Name) || a == "*" {
+					want = true
+				}
+			}
+			if !want {
+				continue
+			}
+		}
+		ret.Attributes = append(ret.Attributes, ea)
+	}
+	return ret
+}
+
+// testMatches reports whether the entry matches the encoded filter. Values
+// are compared case insensitively, as with the caseIgnoreMatch matching rule.
+// Extensible match filters are not supported.
+func testMatches(f *ber.Packet, e *ldap.Entry) (bool, error) {
+	if f.ClassType != ber.ClassContext {
+		return false, errors.New("malformed filter")
+	}
+	switch f.Tag {
+	case ldap.FilterAnd, ldap.FilterOr:
+		for _, c := range f.Children {
+			m, err := testMatches(c, e)
+			if err != nil {
+				return false, err
+			}
+			if m == (f.Tag == ldap.FilterOr) {
+				return m, nil
+			}
+		}
+		return f.Tag == ldap.FilterAnd, nil
+	case ldap.FilterNot:
+		if len(f.Children) != 1 {
+			return false, errors.New("malformed not filter")
+		}
+		m, err := testMatches(f.Children[0], e)
+		return !m, err
+	case ldap.FilterPresent:
+		attr := f.Data.String()
+		return strings.EqualFold(attr, "objectClass") || len(e.GetEqualFoldAttributeValues(attr)) > 0, nil
+	case ldap.FilterSubstrings:
+		if len(f.Children) != 2 {
+			return false, errors.New("malformed substrings filter")
+		}
+		for _, v := range e.GetEqualFoldAttributeValues(f.Children[0].Data.String()) {
+			if testMatchSubstrings(strings.ToLower(v), f.Children[1].Children) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case ldap.FilterEqualityMatch, ldap.FilterGreaterOrEqual, ldap.FilterLessOrEqual, ldap.FilterApproxMatch:
+		if len(f.Children) != 2 {
+			return false, errors.New("malformed attribute value assertion")
+		}
+		want := strings.ToLower(f.Children[1].Data.String())
+		for _, v := range e.GetEqualFoldAttributeValues(f.Children[0].Data.String()) {
+			v = strings.ToLower(v)
+			switch {
+			case f.Tag == ldap.FilterGreaterOrEqual && v >= want,
+				f.Tag == ldap.FilterLessOrEqual && v <= want,
+				(f.Tag == ldap.FilterEqualityMatch || f.Tag == ldap.FilterApproxMatch) && v == want:
+				return true, nil
+			}
+		}
+		return false, nil
+	default:
+		return false, fmt.Errorf("unsupported filter choice %d", f.Tag)
+	}
+}
+
+func testMatchSubstrings(v string, subs []*ber.Packet) bool {
+	for _, s := range subs {
+		sub := strings.ToLower(s.Data.String())
+		switch s.Tag {
+		case ldap.FilterSubstringsInitial:
+			if !strings.HasPrefix(v, sub) {
+				return false
+			}
+			v = v[len(sub):]
+		case ldap.FilterSubstringsAny:
+			i := strings.Index(v, sub)
+			if i < 0 {
+				return false
+			}
+			v = v[i+len(sub):]
+		case ldap.FilterSubstringsFinal:
+			if !strings.HasSuffix(v, sub) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func encodeEntry(e *ldap.Entry) *ber.Packet {
+	p := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+	p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.DN, "Object Name"))
+	attrs := ber.NewSequence("Attributes")
+	for _, ea := range e.Attributes {
+		attr := ber.NewSequence("Attribute")
+		attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, ea.Name, "Type"))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+		for _, v := range ea.Values {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "Value"))
+		}
+		attr.AppendChild(set)
+		attrs.AppendChild(attr)
+	}
+	p.AppendChild(attrs)
+	return p
+}
+
+func testResult(tag ber.Tag, code int, msg string) *ber.Packet {
+	p := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+	p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), "Result Code"))
+	p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, msg, "Diagnostic Message"))
+	return p
+}
+
+func testTlsConfig(t *testing.T) (*tls.Config, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now().Add(-30 * time.Second),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	return &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+		MinVersion:   tls.VersionTLS12,
+	}, string(certPem)
+}
@@ -11,9 +11,28 @@ with the code.
 
 This makes it actually quite simple to perform the ACL checking. Much of ACL
 construction is thus synthesizing something reasonable from a set of Grants.
+
+Grants can also deny the actions they match, by specifying effect=deny. Any
+matching deny grant overrides every grant that would otherwise allow the action,
+no matter which role either grant comes from.
+
+A grant with a filter only applies to the individual resources whose attributes
+match the filter. When the attributes of the resource are not known, an allow
+grant with a filter does not apply and a deny grant with a filter does, so that
+a missing attribute never grants more than intended. The same holds for a
+filter using a template that has no value for the user, such as a claim their
+account doesn't have.
+
+Grants can restrict the fields of the resources they apply to that are returned
+in responses with output_fields. The output fields of every allow grant that
+applies to a resource are merged, and a grant without output fields places no
+restriction on them. A grant on a collection, such as type=target;actions=list,
+applies to the output fields of the resources listed from it.
 */
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
 )
@@ -49,6 +68,11 @@ type Resource struct {
 	// Pin if defined would constrain the resource within the collection of the
 	// pin id.
 	Pin string
+
+	// Attributes is the API representation of the resource, which grant
+	// filters are evaluated against under "/item". It is nil when the
+	// attributes are not known.
+	Attributes map[string]interface{}
 }
 
 // NewACL creates an ACL from the grants provided.
@@ -65,68 +89,290 @@ func NewACL(grants ...Grant) ACL {
 }
 
 // Allowed determines if the grants for an ACL allow an action for a resource.
+// A matching deny grant overrides any matching grant allowing the action.
 func (a ACL) Allowed(r Resource, aType action.Type) (results ACLResults) {
 	// First, get the grants within the specified scope
 	grants := a.scopeMap[r.ScopeId]
 	results.scopeMap = a.scopeMap
 
-	// Now, go through and check the cases indicated above
+	// Deny grants take precedence, so check them all before any allow grant
 	for _, grant := range grants {
-		if !(grant.actions[aType] || grant.actions[action.All]) {
-			continue
+		if grant.deny && grant.matches(r, aType) {
+			return
 		}
-		switch {
-		// id=<resource.id>;actions=<action> where ID cannot be a wildcard
-		case grant.id == r.Id &&
-			grant.id != "" &&
-			grant.id != "*" &&
-			grant.typ == resource.Unknown:
-
+	}
+	for _, grant := range grants {
+		if !grant.deny && grant.matches(r, aType) {
 			results.Allowed = true
 			return
+		}
+	}
+	return
+}
 
-		// type=<resource.type>;actions=<action> when action is list or create.
-		// Must be a top level collection, otherwise must be one of the two
-		// formats specified below.
-		case grant.id == "" &&
-			r.Id == "" &&
-			grant.typ == r.Type &&
-			grant.typ != resource.Unknown &&
-			topLevelType(r.Type) &&
-			(aType == action.List || aType == action.Create):
+// Denied reports whether a deny grant matches the action on the resource, so
+// that no grant can allow it.
+func (a ACL) Denied(r Resource, aType action.Type) bool {
+	for _, grant := range a.scopeMap[r.ScopeId] {
+		if grant.deny && grant.matches(r, aType) {
+			return true
+		}
+	}
+	return false
+}
 
-			results.Allowed = true
-			return
+// OutputFields returns the fields of the resource that the grants in its scope
+// allow to be returned in responses. The output fields of the allow grants that
+// apply to the resource for any of their actions are merged. The result is nil,
+// placing no restriction on the fields, if any of those grants doesn't specify
+// output fields or if none of them apply.
+func (a ACL) OutputFields(r Resource) OutputFieldsMap {
+	var ret OutputFieldsMap
+	for _, grant := range a.scopeMap[r.ScopeId] {
+		if grant.deny || !grant.appliesToOutput(r) {
+			continue
+		}
+		if len(grant.outputFields) == 0 {
+			return nil
+		}
+		ret = ret.AddFields(grant.outputFields)
+	}
+	return ret
+}
 
-		// id=*;type=<resource.type>;actions=<action> where type cannot be
-		// unknown but can be a wildcard to allow any resource at all
-		case grant.id == "*" &&
-			grant.typ != resource.Unknown &&
-			(grant.typ == r.Type ||
-				grant.typ == resource.All):
+// appliesToOutput reports whether the grant applies to the resource for any of
+// its actions, or whether it allows listing the collection of the resource.
+func (g Grant) appliesToOutput(r Resource) bool {
+	if g.id == "" &&
+		g.typ == r.Type &&
+		topLevelType(r.Type) &&
+		(g.actions[action.List] || g.actions[action.All]) {
+		return true
+	}
+	for aType := range g.actions {
+		if g.matches(r, aType) {
+			return true
+		}
+	}
+	return false
+}
 
-			results.Allowed = true
-			return
+// GrantExplanation describes how a grant was evaluated for an action on a
+// resource.
+type GrantExplanation struct {
+	Grant Grant
 
-		// id=<pin>;type=<resource.type>;actions=<action> where type can be a
-		// wildcard and this this is operating on a non-top-level type
-		case grant.id != "" &&
-			grant.id == r.Pin &&
-			grant.typ != resource.Unknown &&
-			(grant.typ == r.Type || grant.typ == resource.All) &&
-			!topLevelType(r.Type):
+	// Matched is true if the grant applies to the action on the resource
+	Matched bool
 
-			results.Allowed = true
-			return
+	// Decisive is true for the grant that determined the result: the first
+	// matching deny grant or, without one, the first matching allow grant
+	Decisive bool
+
+	// Reason describes why the grant did or did not match
+	Reason string
+}
+
+// ACLExplanation describes how Allowed reached its result for an action on a
+// resource.
+type ACLExplanation struct {
+	Allowed bool
+
+	// Grants are the grants in the resource's scope, in the order Allowed
+	// evaluates them: deny grants first, then allow grants.
+	Grants []GrantExplanation
+
+	// Reason summarizes the result
+	Reason string
+}
+
+// Explain evaluates the action on the resource the same way as Allowed,
+// returning how each grant in the resource's scope was evaluated.
+func (a ACL) Explain(r Resource, aType action.Type) ACLExplanation {
+	var ret ACLExplanation
+	grants := a.scopeMap[r.ScopeId]
+	var denies, allows []GrantExplanation
+	for _, grant := range grants {
+		matched, reason := grant.explain(r, aType)
+		ge := GrantExplanation{Grant: grant, Matched: matched, Reason: reason}
+		if grant.deny {
+			denies = append(denies, ge)
+			continue
 		}
+		allows = append(allows, ge)
 	}
-	return
+	ret.Grants = append(denies, allows...)
+
+	for i, ge := range ret.Grants {
+		if !ge.Matched {
+			continue
+		}
+		ret.Grants[i].Decisive = true
+		if ge.Grant.deny {
+			ret.Reason = fmt.Sprintf("denied by grant %q", ge.Grant.CanonicalString())
+		} else {
+			ret.Allowed = true
+			ret.Reason = fmt.Sprintf("allowed by grant %q", ge.Grant.CanonicalString())
+		}
+		return ret
+	}
+	switch len(grants) {
+	case 0:
+		ret.Reason = fmt.Sprintf("no grants apply to scope %s", r.ScopeId)
+	default:
+		ret.Reason = fmt.Sprintf("none of the grants in scope %s match action %q", r.ScopeId, aType.String())
+	}
+	return ret
+}
+
+// explain reports whether the grant applies to the action on the resource
+// along with the reason, following the same rules as matches.
+func (g Grant) explain(r Resource, aType action.Type) (bool, string) {
+	if g.matchesPattern(r, aType) {
+		if matched, reason := g.explainFilter(r); reason != "" {
+			return matched, reason
+		}
+		switch {
+		case g.typ == resource.Unknown:
+			return true, "grant id matches the resource id"
+		case g.id == "":
+			return true, fmt.Sprintf("grant applies to the %s collection", g.typ.String())
+		case g.id == "*":
+			return true, fmt.Sprintf("grant applies to every resource of type %s", g.typ.String())
+		default:
+			return true, fmt.Sprintf("grant id matches the resource's parent %s", r.Pin)
+		}
+	}
+
+	if !(g.actions[aType] || g.actions[action.All]) {
+		return false, fmt.Sprintf("grant does not include action %q", aType.String())
+	}
+	typeMatches := g.typ == r.Type || (g.typ == resource.All && g.id != "")
+	switch {
+	case g.typ == resource.Unknown:
+		return false, fmt.Sprintf("grant id %s does not match the resource id", g.id)
+	case g.id == "" && r.Id != "":
+		return false, "grants without an id only apply to collections"
+	case !typeMatches:
+		return false, fmt.Sprintf("grant type %s does not match resource type %s", g.typ.String(), r.Type.String())
+	case g.id == "" && !topLevelType(r.Type):
+		return false, fmt.Sprintf("grants on %s resources must specify the id of their parent", r.Type.String())
+	case g.id == "":
+		return false, "grants without an id only allow list, create and watch"
+	case topLevelType(r.Type):
+		return false, fmt.Sprintf("%s resources have no parent, so grants with both an id and a type must use id=*", r.Type.String())
+	default:
+		return false, fmt.Sprintf("grant id %s does not match the resource's parent %s", g.id, r.Pin)
+	}
+}
+
+// explainFilter reports whether the grant's filter matches the resource along
+// with the reason, following the same rules as matchesFilter. The reason is
+// empty if the grant has no filter.
+func (g Grant) explainFilter(r Resource) (bool, string) {
+	switch {
+	case g.filterEval == nil:
+		return true, ""
+	case r.Id == "":
+		return false, "grants with a filter only apply to individual resources"
+	case g.filterUnresolved && g.deny:
+		return true, "the grant filter uses a template without a value, so the deny grant applies"
+	case g.filterUnresolved:
+		return false, "the grant filter uses a template without a value"
+	case r.Attributes == nil && g.deny:
+		return true, "the resource's attributes are not available to evaluate the grant filter, so the deny grant applies"
+	case r.Attributes == nil:
+		return false, "the resource's attributes are not available to evaluate the grant filter"
+	case g.matchesFilter(r):
+		return true, "grant filter matches the resource's attributes"
+	default:
+		return false, "grant filter does not match the resource's attributes"
+	}
+}
+
+// matches reports whether the grant applies to the action on the resource,
+// regardless of its effect.
+func (g Grant) matches(r Resource, aType action.Type) bool {
+	return g.matchesPattern(r, aType) && g.matchesFilter(r)
+}
+
+// matchesFilter reports whether the resource matches the grant's filter, if
+// any. Filters only apply to individual resources, and a deny grant's filter
+// matches resources whose attributes are not known or when it uses a template
+// without a value.
+func (g Grant) matchesFilter(r Resource) bool {
+	switch {
+	case g.filterEval == nil:
+		return true
+	case r.Id == "":
+		return false
+	case r.Attributes == nil, g.filterUnresolved:
+		return g.deny
+	}
+	ok, err := g.filterEval.Evaluate(map[string]interface{}{"item": r.Attributes})
+	if err != nil {
+		// Selectors referring to attributes the resource doesn't have don't
+		// match
+		return false
+	}
+	return ok
+}
+
+// matchesPattern reports whether the grant's id, type and actions apply to the
+// action on the resource.
+func (g Grant) matchesPattern(r Resource, aType action.Type) bool {
+	if !(g.actions[aType] || g.actions[action.All]) {
+		return false
+	}
+	switch {
+	// id=<resource.id>;actions=<action> where ID cannot be a wildcard
+	case g.id == r.Id &&
+		g.id != "" &&
+		g.id != "*" &&
+		g.typ == resource.Unknown:
+
+		return true
+
+	// type=<resource.type>;actions=<action> when action is list, create or
+	// watch. Must be a top level collection, otherwise must be one of the two
+	// formats specified below.
+	case g.id == "" &&
+		r.Id == "" &&
+		g.typ == r.Type &&
+		g.typ != resource.Unknown &&
+		topLevelType(r.Type) &&
+		(aType == action.List || aType == action.Create || aType == action.Watch):
+
+		return true
+
+	// id=*;type=<resource.type>;actions=<action> where type cannot be
+	// unknown but can be a wildcard to allow any resource at all
+	case g.id == "*" &&
+		g.typ != resource.Unknown &&
+		(g.typ == r.Type ||
+			g.typ == resource.All):
+
+		return true
+
+	// id=<pin>;type=<resource.type>;actions=<action> where type can be a
+	// wildcard and this this is operating on a non-top-level type
+	case g.id != "" &&
+		g.id == r.Pin &&
+		g.typ != resource.Unknown &&
+		(g.typ == r.Type || g.typ == resource.All) &&
+		!topLevelType(r.Type):
+
+		return true
+	}
+	return false
 }
 
 func topLevelType(typ resource.Type) bool {
 	switch typ {
-	case resource.AuthMethod,
+	case resource.AccessRequest,
+		resource.AuthMethod,
 		resource.AuthToken,
+		resource.CredentialStore,
 		resource.Group,
 		resource.HostCatalog,
 		resource.Role,
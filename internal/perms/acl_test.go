@@ -61,6 +61,15 @@ func Test_ACLAllowed(t *testing.T) {
 				"id=*;type=*;actions=create,update",
 			},
 		},
+		{
+			scope: "p_e",
+			grants: []string{
+				"id=*;type=*;actions=*",
+				"id=ttcp_prod;actions=*;effect=deny",
+				"id=*;type=session;actions=cancel;effect=deny",
+				"id=mypin;type=host;actions=delete;effect=deny",
+			},
+		},
 	}
 
 	// See acl.go for expected allowed formats. The goal here is to basically
@@ -252,6 +261,59 @@ func Test_ACLAllowed(t *testing.T) {
 			},
 			userId: "u_abcd1234",
 		},
+		{
+			name:        "deny by id overrides wildcard allow",
+			resource:    Resource{ScopeId: "p_e", Id: "ttcp_prod", Type: resource.Target},
+			scopeGrants: commonGrants,
+			actionsAllowed: []actionAllowed{
+				{action: action.Read},
+				{action: action.AuthorizeSession},
+			},
+		},
+		{
+			name:        "deny by id does not affect other ids",
+			resource:    Resource{ScopeId: "p_e", Id: "ttcp_dev", Type: resource.Target},
+			scopeGrants: commonGrants,
+			actionsAllowed: []actionAllowed{
+				{action: action.Read, allowed: true},
+				{action: action.AuthorizeSession, allowed: true},
+			},
+		},
+		{
+			name:        "deny by type only denies its actions",
+			resource:    Resource{ScopeId: "p_e", Id: "s_1234567890", Type: resource.Session},
+			scopeGrants: commonGrants,
+			actionsAllowed: []actionAllowed{
+				{action: action.Read, allowed: true},
+				{action: action.Cancel},
+			},
+		},
+		{
+			name:        "deny by pin",
+			resource:    Resource{ScopeId: "p_e", Id: "hst_1234567890", Pin: "mypin", Type: resource.Host},
+			scopeGrants: commonGrants,
+			actionsAllowed: []actionAllowed{
+				{action: action.Update, allowed: true},
+				{action: action.Delete},
+			},
+		},
+		{
+			name:     "deny in another scope",
+			resource: Resource{ScopeId: "p_f", Id: "ttcp_prod", Type: resource.Target},
+			scopeGrants: []scopeGrant{
+				{
+					scope:  "p_e",
+					grants: []string{"id=ttcp_prod;actions=*;effect=deny"},
+				},
+				{
+					scope:  "p_f",
+					grants: []string{"id=*;type=target;actions=read"},
+				},
+			},
+			actionsAllowed: []actionAllowed{
+				{action: action.Read, allowed: true},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -271,3 +333,278 @@ func Test_ACLAllowed(t *testing.T) {
 		})
 	}
 }
+
+func Test_ACLDenied(t *testing.T) {
+	t.Parallel()
+
+	var grants []Grant
+	for _, g := range []string{
+		"id=*;type=*;actions=*",
+		"id=ttcp_prod;actions=read;effect=deny",
+	} {
+		grant, err := Parse("p_a", g)
+		require.NoError(t, err)
+		grants = append(grants, grant)
+	}
+	acl := NewACL(grants...)
+
+	prod := Resource{ScopeId: "p_a", Id: "ttcp_prod", Type: resource.Target}
+	assert.True(t, acl.Denied(prod, action.Read))
+	// Allow grants never count as denials
+	assert.False(t, acl.Denied(prod, action.Update))
+	assert.False(t, acl.Denied(Resource{ScopeId: "p_a", Id: "ttcp_dev", Type: resource.Target}, action.Read))
+	assert.False(t, acl.Denied(Resource{ScopeId: "p_b", Id: "ttcp_prod", Type: resource.Target}, action.Read))
+}
+
+func Test_ACLExplain(t *testing.T) {
+	t.Parallel()
+
+	parse := func(t *testing.T, scopeId, roleId, grant string) Grant {
+		t.Helper()
+		g, err := Parse(scopeId, grant, WithRoleId(roleId))
+		require.NoError(t, err)
+		return g
+	}
+
+	t.Run("allowed", func(t *testing.T) {
+		assert := assert.New(t)
+		acl := NewACL(
+			parse(t, "p_a", "r_1", "id=*;type=session;actions=list"),
+			parse(t, "p_a", "r_2", "id=*;type=target;actions=read"),
+			parse(t, "p_b", "r_3", "id=*;type=*;actions=*"),
+		)
+		r := Resource{ScopeId: "p_a", Id: "ttcp_1", Type: resource.Target}
+		got := acl.Explain(r, action.Read)
+		assert.Equal(acl.Allowed(r, action.Read).Allowed, got.Allowed)
+		assert.True(got.Allowed)
+		assert.Contains(got.Reason, "id=*;type=target;actions=read")
+		// Only the grants in the resource's scope are evaluated
+		assert.Len(got.Grants, 2)
+		assert.False(got.Grants[0].Matched)
+		assert.Equal("r_1", got.Grants[0].Grant.RoleId())
+		assert.Contains(got.Grants[0].Reason, "does not include action")
+		assert.True(got.Grants[1].Matched)
+		assert.True(got.Grants[1].Decisive)
+		assert.Equal("r_2", got.Grants[1].Grant.RoleId())
+	})
+
+	t.Run("denied", func(t *testing.T) {
+		assert := assert.New(t)
+		acl := NewACL(
+			parse(t, "p_a", "r_1", "id=*;type=target;actions=*"),
+			parse(t, "p_a", "r_2", "id=ttcp_1;actions=delete;effect=deny"),
+		)
+		r := Resource{ScopeId: "p_a", Id: "ttcp_1", Type: resource.Target}
+		got := acl.Explain(r, action.Delete)
+		assert.Equal(acl.Allowed(r, action.Delete).Allowed, got.Allowed)
+		assert.False(got.Allowed)
+		assert.Contains(got.Reason, "denied by grant")
+		// Deny grants are evaluated first
+		assert.Len(got.Grants, 2)
+		assert.Equal("r_2", got.Grants[0].Grant.RoleId())
+		assert.True(got.Grants[0].Decisive)
+		assert.True(got.Grants[1].Matched)
+		assert.False(got.Grants[1].Decisive)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		assert := assert.New(t)
+		acl := NewACL(
+			parse(t, "p_a", "r_1", "id=ttcp_2;actions=read"),
+			parse(t, "p_a", "r_2", "type=target;actions=list"),
+			parse(t, "p_a", "r_3", "id=*;type=host-set;actions=read"),
+			parse(t, "p_a", "r_4", "id=hc_2;type=host;actions=read"),
+		)
+		r := Resource{ScopeId: "p_a", Id: "ttcp_1", Type: resource.Target}
+		got := acl.Explain(r, action.Read)
+		assert.False(got.Allowed)
+		assert.Contains(got.Reason, "none of the grants")
+		assert.Len(got.Grants, 4)
+		for _, g := range got.Grants {
+			assert.False(g.Matched)
+			assert.NotEmpty(g.Reason)
+		}
+
+		h := Resource{ScopeId: "p_a", Id: "hst_1", Type: resource.Host, Pin: "hc_1"}
+		got = acl.Explain(h, action.Read)
+		assert.False(got.Allowed)
+		assert.Contains(got.Grants[3].Reason, "does not match the resource's parent hc_1")
+	})
+
+	t.Run("no grants", func(t *testing.T) {
+		got := NewACL().Explain(Resource{ScopeId: "p_a", Id: "ttcp_1", Type: resource.Target}, action.Read)
+		assert.False(t, got.Allowed)
+		assert.Empty(t, got.Grants)
+		assert.Contains(t, got.Reason, "no grants")
+	})
+}
+
+func Test_ACLFilter(t *testing.T) {
+	t.Parallel()
+
+	parse := func(t *testing.T, grant string) Grant {
+		t.Helper()
+		g, err := Parse("p_a", grant)
+		require.NoError(t, err)
+		return g
+	}
+	team := map[string]interface{}{"name": "team-a-db", "description": "team:a"}
+	other := map[string]interface{}{"name": "team-b-db", "description": "team:b"}
+
+	t.Run("allow", func(t *testing.T) {
+		assert := assert.New(t)
+		acl := NewACL(
+			parse(t, `type=target;actions=list`),
+			parse(t, `id=*;type=target;actions=read,authorize-session;filter="/item/description" == "team:a"`),
+		)
+		r := Resource{ScopeId: "p_a", Id: "ttcp_1", Type: resource.Target, Attributes: team}
+		assert.True(acl.Allowed(r, action.Read).Allowed)
+		assert.True(acl.Allowed(r, action.AuthorizeSession).Allowed)
+
+		r.Attributes = other
+		assert.False(acl.Allowed(r, action.Read).Allowed)
+
+		// Without attributes the allow grant does not apply
+		r.Attributes = nil
+		assert.False(acl.Allowed(r, action.Read).Allowed)
+		assert.Contains(acl.Explain(r, action.Read).Grants[1].Reason, "not available")
+
+		// Filters only apply to individual resources
+		assert.True(acl.Allowed(Resource{ScopeId: "p_a", Type: resource.Target}, action.List).Allowed)
+	})
+
+	t.Run("missing attribute", func(t *testing.T) {
+		acl := NewACL(parse(t, `id=*;type=target;actions=read;filter="/item/worker_filter" == "foo"`))
+		r := Resource{ScopeId: "p_a", Id: "ttcp_1", Type: resource.Target, Attributes: team}
+		assert.False(t, acl.Allowed(r, action.Read).Allowed)
+	})
+
+	t.Run("deny", func(t *testing.T) {
+		assert := assert.New(t)
+		acl := NewACL(
+			parse(t, `id=*;type=target;actions=*`),
+			parse(t, `id=*;type=target;actions=authorize-session;effect=deny;filter="/item/name" matches "^team-b-"`),
+		)
+		r := Resource{ScopeId: "p_a", Id: "ttcp_1", Type: resource.Target, Attributes: team}
+		assert.True(acl.Allowed(r, action.AuthorizeSession).Allowed)
+
+		r.Attributes = other
+		assert.False(acl.Allowed(r, action.AuthorizeSession).Allowed)
+		exp := acl.Explain(r, action.AuthorizeSession)
+		assert.True(exp.Grants[0].Decisive)
+		assert.Contains(exp.Grants[0].Reason, "filter matches")
+
+		// Without attributes the deny grant applies
+		r.Attributes = nil
+		assert.False(acl.Allowed(r, action.AuthorizeSession).Allowed)
+		assert.True(acl.Allowed(r, action.Read).Allowed)
+	})
+}
+
+func Test_ACLOutputFields(t *testing.T) {
+	t.Parallel()
+
+	parse := func(t *testing.T, grant string) Grant {
+		t.Helper()
+		g, err := Parse("p_a", grant)
+		require.NoError(t, err)
+		return g
+	}
+	sess := Resource{ScopeId: "p_a", Id: "s_1", Type: resource.Session}
+
+	tests := []struct {
+		name   string
+		grants []string
+		r      Resource
+		want   []string
+	}{
+		{
+			name:   "no grants",
+			grants: nil,
+			r:      sess,
+			want:   nil,
+		},
+		{
+			name:   "unrestricted",
+			grants: []string{`id=*;type=session;actions=read`},
+			r:      sess,
+			want:   nil,
+		},
+		{
+			name:   "restricted",
+			grants: []string{`id=*;type=session;actions=read;output_fields=id,status`},
+			r:      sess,
+			want:   []string{"id", "status"},
+		},
+		{
+			name: "merged",
+			grants: []string{
+				`id=*;type=session;actions=read;output_fields=id,status`,
+				`id=s_1;actions=cancel;output_fields=id,user_id`,
+			},
+			r:    sess,
+			want: []string{"id", "status", "user_id"},
+		},
+		{
+			name: "merged with unrestricted",
+			grants: []string{
+				`id=*;type=session;actions=read;output_fields=id,status`,
+				`id=s_1;actions=cancel`,
+			},
+			r:    sess,
+			want: nil,
+		},
+		{
+			name: "collection grant",
+			grants: []string{
+				`type=session;actions=list;output_fields=id,status`,
+			},
+			r:    sess,
+			want: []string{"id", "status"},
+		},
+		{
+			name: "other type ignored",
+			grants: []string{
+				`id=*;type=session;actions=read;output_fields=id`,
+				`id=*;type=target;actions=read`,
+			},
+			r:    sess,
+			want: []string{"id"},
+		},
+		{
+			name: "deny ignored",
+			grants: []string{
+				`id=*;type=session;actions=read;output_fields=id`,
+				`id=*;type=session;actions=cancel;effect=deny`,
+			},
+			r:    sess,
+			want: []string{"id"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var grants []Grant
+			for _, g := range tt.grants {
+				grants = append(grants, parse(t, g))
+			}
+			got := NewACL(grants...).OutputFields(tt.r)
+			assert.Equal(t, tt.want, got.Fields())
+		})
+	}
+
+	t.Run("has", func(t *testing.T) {
+		assert := assert.New(t)
+		var all OutputFieldsMap
+		assert.True(all.Has("endpoint"))
+		some := OutputFieldsMap{}.AddFields([]string{"id"})
+		assert.True(some.Has("id"))
+		assert.False(some.Has("endpoint"))
+		assert.True(some.AddFields([]string{"*"}).Has("endpoint"))
+	})
+
+	t.Run("deny grant", func(t *testing.T) {
+		_, err := Parse("p_a", `id=*;type=session;actions=read;effect=deny;output_fields=id`)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "output fields cannot be specified on a deny grant")
+	})
+}
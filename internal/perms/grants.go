@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/go-bexpr"
 )
 
 // GrantPair is simply a struct that can be reference from other code to return
@@ -17,6 +18,10 @@ import (
 type GrantPair struct {
 	ScopeId string
 	Grant   string
+
+	// RoleId is the ID of the role the grant comes from, or of the access
+	// request for grants synthesized from approved access requests.
+	RoleId string
 }
 
 // Scope provides an in-memory representation of iam.Scope without the
@@ -29,6 +34,18 @@ type Scope struct {
 	Type scope.Type
 }
 
+// Effect is the effect a grant has on the actions it matches
+type Effect string
+
+const (
+	// AllowEffect allows the actions of a grant. This is the default.
+	AllowEffect Effect = "allow"
+
+	// DenyEffect denies the actions of a grant, overriding any grant
+	// allowing them.
+	DenyEffect Effect = "deny"
+)
+
 // Grant is a Go representation of a parsed grant
 type Grant struct {
 	// The scope ID, which will be a project ID or an org ID
@@ -43,6 +60,28 @@ type Grant struct {
 	// The set of actions being granted
 	actions map[action.Type]bool
 
+	// Whether the grant denies rather than allows its actions
+	deny bool
+
+	// The ID of the role the grant comes from, if known
+	roleId string
+
+	// The filter the resource's attributes must match, if provided, with any
+	// templates substituted
+	filter     string
+	filterEval *bexpr.Evaluator
+
+	// Whether a template in the filter has no value and was left in place
+	filterUnresolved bool
+
+	// The fields of matching resources returned in responses, if provided,
+	// sorted
+	outputFields []string
+
+	// Whether the grant refers to the user's groups without a group to
+	// substitute, in which case ParseGrants expands it
+	groupTemplated bool
+
 	// This is used as a temporary staging area before validating permissions to
 	// allow the same validation code across grant string formats
 	actionsBeingParsed []string
@@ -56,6 +95,32 @@ func (g Grant) Type() resource.Type {
 	return g.typ
 }
 
+// RoleId returns the ID of the role the grant comes from, if it was provided
+// when parsing the grant
+func (g Grant) RoleId() string {
+	return g.roleId
+}
+
+// Filter returns the filter the attributes of a resource must match for the
+// grant to apply to it, if any
+func (g Grant) Filter() string {
+	return g.filter
+}
+
+// OutputFields returns the fields of the resources the grant applies to that
+// are returned in responses. All fields are returned if none are specified.
+func (g Grant) OutputFields() []string {
+	return g.outputFields
+}
+
+// Effect returns the effect of the grant on its actions
+func (g Grant) Effect() Effect {
+	if g.deny {
+		return DenyEffect
+	}
+	return AllowEffect
+}
+
 func (g Grant) Actions() (typs []action.Type, strs []string) {
 	typs = make([]action.Type, 0, len(g.actions))
 	strs = make([]string, 0, len(g.actions))
@@ -72,9 +137,19 @@ func (g Grant) Actions() (typs []action.Type, strs []string) {
 
 func (g Grant) clone() *Grant {
 	ret := &Grant{
-		scope: g.scope,
-		id:    g.id,
-		typ:   g.typ,
+		scope:  g.scope,
+		id:     g.id,
+		typ:    g.typ,
+		deny:   g.deny,
+		roleId: g.roleId,
+
+		filter:           g.filter,
+		filterEval:       g.filterEval,
+		filterUnresolved: g.filterUnresolved,
+		groupTemplated:   g.groupTemplated,
+	}
+	if g.outputFields != nil {
+		ret.outputFields = append(ret.outputFields, g.outputFields...)
 	}
 	if g.actionsBeingParsed != nil {
 		ret.actionsBeingParsed = append(ret.actionsBeingParsed, g.actionsBeingParsed...)
@@ -109,12 +184,33 @@ func (g Grant) CanonicalString() string {
 		builder = append(builder, fmt.Sprintf("actions=%s", strings.Join(actions, ",")))
 	}
 
+	if g.deny {
+		builder = append(builder, fmt.Sprintf("effect=%s", DenyEffect))
+	}
+
+	if len(g.outputFields) > 0 {
+		builder = append(builder, fmt.Sprintf("output_fields=%s", strings.Join(g.outputFields, ",")))
+	}
+
+	if g.filter != "" {
+		builder = append(builder, fmt.Sprintf("filter=%s", g.filter))
+	}
+
 	return strings.Join(builder, ";")
 }
 
 // MarshalJSON provides a custom marshaller for grants
 func (g Grant) MarshalJSON() ([]byte, error) {
 	res := make(map[string]interface{}, 4)
+	if g.deny {
+		res["effect"] = string(DenyEffect)
+	}
+	if g.filter != "" {
+		res["filter"] = g.filter
+	}
+	if len(g.outputFields) > 0 {
+		res["output_fields"] = g.outputFields
+	}
 	if g.id != "" {
 		res["id"] = g.id
 	}
@@ -157,6 +253,37 @@ func (g *Grant) unmarshalJSON(data []byte) error {
 			return fmt.Errorf("unknown type specifier %q", typ)
 		}
 	}
+	if rawEffect, ok := raw["effect"]; ok {
+		effect, ok := rawEffect.(string)
+		if !ok {
+			return fmt.Errorf("unable to interpret %q as string", "effect")
+		}
+		if err := g.setEffect(effect); err != nil {
+			return err
+		}
+	}
+	if rawFilter, ok := raw["filter"]; ok {
+		filter, ok := rawFilter.(string)
+		if !ok {
+			return fmt.Errorf("unable to interpret %q as string", "filter")
+		}
+		g.filter = filter
+	}
+	if rawOutputFields, ok := raw["output_fields"]; ok {
+		interfaceFields, ok := rawOutputFields.([]interface{})
+		if !ok {
+			return fmt.Errorf("unable to interpret %q as array", "output_fields")
+		}
+		for _, v := range interfaceFields {
+			field, ok := v.(string)
+			if !ok {
+				return fmt.Errorf("unable to interpret %v in output_fields array as string", v)
+			}
+			if err := g.addOutputField(field); err != nil {
+				return err
+			}
+		}
+	}
 	if rawActions, ok := raw["actions"]; ok {
 		interfaceActions, ok := rawActions.([]interface{})
 		if !ok {
@@ -180,14 +307,45 @@ func (g *Grant) unmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (g *Grant) setEffect(effect string) error {
+	switch Effect(strings.ToLower(effect)) {
+	case AllowEffect:
+		g.deny = false
+	case DenyEffect:
+		g.deny = true
+	default:
+		return fmt.Errorf("unknown effect %q", effect)
+	}
+	return nil
+}
+
+// addOutputField adds a field to the output fields of the grant, keeping them
+// sorted and without duplicates
+func (g *Grant) addOutputField(field string) error {
+	field = strings.ToLower(strings.TrimSpace(field))
+	if field == "" {
+		return errors.New("empty output field found")
+	}
+	i := sort.SearchStrings(g.outputFields, field)
+	if i < len(g.outputFields) && g.outputFields[i] == field {
+		return nil
+	}
+	g.outputFields = append(g.outputFields, "")
+	copy(g.outputFields[i+1:], g.outputFields[i:])
+	g.outputFields[i] = field
+	return nil
+}
+
 func (g *Grant) unmarshalText(grantString string) error {
 	segments := strings.Split(grantString, ";")
 	for _, segment := range segments {
-		kv := strings.Split(segment, "=")
+		// Filters are expressions which may themselves contain equal signs
+		kv := strings.SplitN(segment, "=", 2)
 
 		// Ensure we don't accept "foo=bar=baz", "=foo", or "foo="
 		switch {
-		case len(kv) != 2:
+		case len(kv) != 2,
+			kv[0] != "filter" && strings.Contains(kv[1], "="):
 			return fmt.Errorf("segment %q not formatted correctly, wrong number of equal signs", segment)
 		case len(kv[0]) == 0:
 			return fmt.Errorf("segment %q not formatted correctly, missing key", segment)
@@ -206,6 +364,21 @@ func (g *Grant) unmarshalText(grantString string) error {
 				return fmt.Errorf("unknown type specifier %q", typeString)
 			}
 
+		case "effect":
+			if err := g.setEffect(kv[1]); err != nil {
+				return err
+			}
+
+		case "filter":
+			g.filter = kv[1]
+
+		case "output_fields":
+			for _, field := range strings.Split(kv[1], ",") {
+				if err := g.addOutputField(field); err != nil {
+					return err
+				}
+			}
+
 		case "actions":
 			actions := strings.Split(kv[1], ",")
 			if len(actions) > 0 {
@@ -225,6 +398,8 @@ func (g *Grant) unmarshalText(grantString string) error {
 
 // Parse parses a grant string. Note that this does not do checking
 // of the validity of IDs and such; that's left for other parts of the system.
+// Templates in the id and filter are substituted with the values given in the
+// options; see TemplateData for the supported templates.
 // We may not check at all (e.g. let it be an authz-time failure) or could check
 // after submission to catch errors.
 //
@@ -267,26 +442,53 @@ func Parse(scopeId, grantString string, opt ...Option) (Grant, error) {
 	}
 
 	opts := getOpts(opt...)
+	grant.roleId = opts.withRoleId
 
 	// Check for templated values ID, and substitute in with the authenticated values
-	// if so
+	// if so. Only templates with ID values can be used for the ID.
 	if grant.id != "" && strings.HasPrefix(grant.id, "{{") {
 		id := strings.TrimSuffix(strings.TrimPrefix(grant.id, "{{"), "}}")
 		id = strings.ToLower(strings.TrimSpace(id))
 		switch id {
-		case "user.id":
-			if opts.withUserId != "" {
-				grant.id = opts.withUserId
+		case "user.id", "account.id", "user.group.id":
+			value, group, err := opts.templateValue(id)
+			if err != nil {
+				return Grant{}, err
 			}
-		case "account.id":
-			if opts.withAccountId != "" {
-				grant.id = opts.withAccountId
+			if value != "" {
+				grant.id = value
 			}
+			grant.groupTemplated = group && opts.withGroup == nil
 		default:
 			return Grant{}, fmt.Errorf("unknown template %q in grant %q value", grant.id, "id")
 		}
 	}
 
+	if grant.filter != "" {
+		switch {
+		case grant.id == "":
+			return Grant{}, errors.New("a filter can only be specified with an id")
+		case strings.Contains(grant.filter, ";"):
+			// The canonical form of the grant would not be parseable
+			return Grant{}, errors.New("a filter cannot contain ';'")
+		}
+		filter, group, unresolved, err := opts.substituteFilterTemplates(grant.filter)
+		if err != nil {
+			return Grant{}, err
+		}
+		grant.groupTemplated = grant.groupTemplated || (group && opts.withGroup == nil)
+		grant.filter = filter
+		grant.filterUnresolved = unresolved
+		grant.filterEval, err = bexpr.CreateEvaluator(grant.filter)
+		if err != nil {
+			return Grant{}, fmt.Errorf("unable to parse grant filter: %w", err)
+		}
+	}
+
+	if grant.deny && len(grant.outputFields) > 0 {
+		return Grant{}, errors.New("output fields cannot be specified on a deny grant")
+	}
+
 	if err := grant.validateType(); err != nil {
 		return Grant{}, err
 	}
@@ -297,8 +499,12 @@ func Parse(scopeId, grantString string, opt ...Option) (Grant, error) {
 
 	if !opts.withSkipFinalValidation {
 		// Validate the grant. Create a dummy resource and pass it through
-		// Allowed and ensure that we get allowed.
-		acl := NewACL(grant)
+		// Allowed and ensure that we get allowed. A deny grant is validated
+		// as if it allowed its actions, to ensure that it matches something.
+		toValidate := grant
+		toValidate.deny = false
+		toValidate.filterEval = nil
+		acl := NewACL(toValidate)
 		r := Resource{
 			ScopeId: scopeId,
 			Id:      grant.id,
@@ -336,7 +542,10 @@ func (g Grant) validateType() error {
 		resource.HostSet,
 		resource.Host,
 		resource.Target,
-		resource.Session:
+		resource.Session,
+		resource.CredentialStore,
+		resource.Credential,
+		resource.AccessRequest:
 		return nil
 	}
 	return fmt.Errorf("unknown type specifier %q", g.typ)
@@ -175,6 +175,37 @@ func Test_MarshalingAndCloning(t *testing.T) {
 			jsonOutput:      `{"actions":["create","read"],"id":"baz","type":"group"}`,
 			canonicalString: `id=baz;type=group;actions=create,read`,
 		},
+		{
+			name: "deny",
+			input: Grant{
+				id: "baz",
+				scope: Scope{
+					Type: scope.Project,
+				},
+				actions: map[action.Type]bool{
+					action.All: true,
+				},
+				deny: true,
+			},
+			jsonOutput:      `{"actions":["*"],"effect":"deny","id":"baz"}`,
+			canonicalString: `id=baz;actions=*;effect=deny`,
+		},
+		{
+			name: "output fields",
+			input: Grant{
+				id: "*",
+				scope: Scope{
+					Type: scope.Project,
+				},
+				typ: resource.Session,
+				actions: map[action.Type]bool{
+					action.Read: true,
+				},
+				outputFields: []string{"id", "status"},
+			},
+			jsonOutput:      `{"actions":["read"],"id":"*","output_fields":["id","status"],"type":"session"}`,
+			canonicalString: `id=*;type=session;actions=read;output_fields=id,status`,
+		},
 	}
 
 	for _, test := range tests {
@@ -272,11 +303,52 @@ func Test_Unmarshaling(t *testing.T) {
 			textInput: `actions=,`,
 			textErr:   `empty action found`,
 		},
+		{
+			name: "deny effect",
+			expected: Grant{
+				deny: true,
+			},
+			jsonInput: `{"effect":"deny"}`,
+			textInput: `effect=DENY`,
+		},
+		{
+			name:      "allow effect",
+			expected:  Grant{},
+			jsonInput: `{"effect":"allow"}`,
+			textInput: `effect=allow`,
+		},
+		{
+			name:      "bad effect",
+			jsonInput: `{"effect":"maybe"}`,
+			jsonErr:   `unknown effect "maybe"`,
+			textInput: `effect=maybe`,
+			textErr:   `unknown effect "maybe"`,
+		},
+		{
+			name:      "bad json effect",
+			jsonInput: `{"effect":true}`,
+			jsonErr:   `unable to interpret "effect" as string`,
+		},
 		{
 			name:      "bad json action",
 			jsonInput: `{"actions":[1, true]}`,
 			jsonErr:   `unable to interpret 1 in actions array as string`,
 		},
+		{
+			name: "output fields",
+			expected: Grant{
+				outputFields: []string{"id", "name", "status"},
+			},
+			jsonInput: `{"output_fields":["status","ID","name","id"]}`,
+			textInput: `output_fields=status,ID,name,id`,
+		},
+		{
+			name:      "bad output fields",
+			jsonInput: `{"output_fields":"id"}`,
+			jsonErr:   `unable to interpret "output_fields" as array`,
+			textInput: `output_fields=id,,name`,
+			textErr:   `empty output field found`,
+		},
 	}
 
 	for _, test := range tests {
@@ -395,6 +467,27 @@ func Test_Parse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:  "good text deny",
+			input: `id=foobar;actions=read;effect=deny`,
+			expected: Grant{
+				scope: Scope{
+					Id:   "o_scope",
+					Type: scope.Org,
+				},
+				id:  "foobar",
+				typ: resource.Unknown,
+				actions: map[action.Type]bool{
+					action.Read: true,
+				},
+				deny: true,
+			},
+		},
+		{
+			name:  "deny with empty id and type",
+			input: "actions=create;effect=deny",
+			err:   `parsed grant string would not result in any action being authorized`,
+		},
 		{
 			name:  "good text id",
 			input: `id=foobar;actions=read`,
@@ -17,6 +17,9 @@ type options struct {
 	withUserId              string
 	withAccountId           string
 	withSkipFinalValidation bool
+	withRoleId              string
+	withTemplateData        TemplateData
+	withGroup               *TemplateGroup
 }
 
 func getDefaultOptions() options {
@@ -45,3 +48,27 @@ func WithSkipFinalValidation(skipFinalValidation bool) Option {
 		o.withSkipFinalValidation = skipFinalValidation
 	}
 }
+
+// WithRoleId provides the ID of the role the grant comes from, which is
+// reported when explaining an authorization decision
+func WithRoleId(roleId string) Option {
+	return func(o *options) {
+		o.withRoleId = roleId
+	}
+}
+
+// WithTemplateData provides values about the user, such as their name and
+// groups, to be used for templating in grant strings
+func WithTemplateData(data TemplateData) Option {
+	return func(o *options) {
+		o.withTemplateData = data
+	}
+}
+
+// withGroup provides the group to substitute for the templates referring to
+// the user's groups
+func withGroup(group TemplateGroup) Option {
+	return func(o *options) {
+		o.withGroup = &group
+	}
+}
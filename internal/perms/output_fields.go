@@ -0,0 +1,42 @@
+package perms
+
+import "sort"
+
+// OutputFieldsMap is the set of fields of a resource that are returned in
+// responses, keyed by the field's name in the API. A nil map places no
+// restriction on the fields returned.
+type OutputFieldsMap map[string]bool
+
+// AddFields adds the fields to the map, returning the map. A "*" field allows
+// every field to be returned.
+func (o OutputFieldsMap) AddFields(fields []string) OutputFieldsMap {
+	if o == nil {
+		o = make(OutputFieldsMap, len(fields))
+	}
+	for _, f := range fields {
+		o[f] = true
+	}
+	return o
+}
+
+// Has reports whether the field is returned in responses
+func (o OutputFieldsMap) Has(field string) bool {
+	if o == nil {
+		return true
+	}
+	return o["*"] || o[field]
+}
+
+// Fields returns the fields in the map, sorted. It returns nil if the map
+// places no restriction on the fields returned.
+func (o OutputFieldsMap) Fields() []string {
+	if o == nil {
+		return nil
+	}
+	ret := make([]string, 0, len(o))
+	for f := range o {
+		ret = append(ret, f)
+	}
+	sort.Strings(ret)
+	return ret
+}
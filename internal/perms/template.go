@@ -0,0 +1,160 @@
+package perms
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// TemplateData holds values about the user grants are parsed for which are
+// substituted for templates in the grants. The user and account IDs are
+// provided with WithUserId and WithAccountId instead.
+//
+// The supported templates are:
+//
+//	{{user.id}}                 the ID of the user
+//	{{user.name}}               the name of the user
+//	{{user.group.id}}           the ID of a group the user is a member of
+//	{{user.group.name}}         the name of a group the user is a member of
+//	{{account.id}}              the ID of the account the user authenticated with
+//	{{account.login_name}}      the login name of a password account
+//	{{account.claims.<claim>}}  a claim of the account's auth method
+//
+// Templates whose value is not known are left in place. An allow grant with
+// one in its filter matches nothing, while a deny grant with one applies to
+// every individual resource it otherwise matches, as when the attributes of
+// the resource are not known.
+type TemplateData struct {
+	// UserName is the name of the user
+	UserName string
+
+	// AccountLoginName is the login name of the account, for auth methods
+	// with login names
+	AccountLoginName string
+
+	// AccountClaims are the claims of the account provided by its auth
+	// method, keyed by claim name, e.g. the "email" of an OIDC account or
+	// the "dn" of an LDAP account
+	AccountClaims map[string]string
+
+	// Groups are the groups the user is a member of. ParseGrants expands a
+	// grant referring to the user's groups into a grant per group.
+	Groups []TemplateGroup
+}
+
+// TemplateGroup is a group substituted for the {{user.group.id}} and
+// {{user.group.name}} templates
+type TemplateGroup struct {
+	Id   string
+	Name string
+}
+
+const accountClaimsTemplatePrefix = "account.claims."
+
+var templateRegexp = regexp.MustCompile(`\{\{\s*([^{}\s]*)\s*\}\}`)
+
+// templateValue returns the value of the named template. group is true if the
+// template refers to the user's groups. An error is returned if the template
+// is unknown.
+func (o options) templateValue(name string) (value string, group bool, err error) {
+	switch name = strings.ToLower(strings.TrimSpace(name)); name {
+	case "user.id":
+		return o.withUserId, false, nil
+	case "user.name":
+		return o.withTemplateData.UserName, false, nil
+	case "user.group.id":
+		if o.withGroup != nil {
+			value = o.withGroup.Id
+		}
+		return value, true, nil
+	case "user.group.name":
+		if o.withGroup != nil {
+			value = o.withGroup.Name
+		}
+		return value, true, nil
+	case "account.id":
+		return o.withAccountId, false, nil
+	case "account.login_name":
+		return o.withTemplateData.AccountLoginName, false, nil
+	}
+	if strings.HasPrefix(name, accountClaimsTemplatePrefix) && len(name) > len(accountClaimsTemplatePrefix) {
+		return o.withTemplateData.AccountClaims[strings.TrimPrefix(name, accountClaimsTemplatePrefix)], false, nil
+	}
+	return "", false, fmt.Errorf("unknown template %q", name)
+}
+
+// substituteFilterTemplates replaces the templates in a grant filter with
+// their values. Values are escaped to be used within double-quoted strings of
+// the filter. unresolved is true if any template has no value and was left in
+// place.
+func (o options) substituteFilterTemplates(filter string) (ret string, group, unresolved bool, retErr error) {
+	ret = templateRegexp.ReplaceAllStringFunc(filter, func(tmpl string) string {
+		value, isGroup, err := o.templateValue(templateRegexp.FindStringSubmatch(tmpl)[1])
+		if err != nil {
+			if retErr == nil {
+				retErr = fmt.Errorf("unknown template %q in grant %q value", tmpl, "filter")
+			}
+			return tmpl
+		}
+		group = group || isGroup
+		if value == "" {
+			unresolved = true
+			return tmpl
+		}
+		return escapeFilterValue(value)
+	})
+	return ret, group, unresolved, retErr
+}
+
+// escapeFilterValue escapes a value to be used within a double-quoted string
+// of a filter. Filter strings cannot contain a double quote, but they are
+// unquoted, so one can be written as an escape sequence.
+func escapeFilterValue(value string) string {
+	quoted := strconv.Quote(value)
+	return strings.ReplaceAll(quoted[1:len(quoted)-1], `\"`, `\x22`)
+}
+
+// RequiresTemplateData reports whether any of the grants use a template whose
+// value comes from TemplateData, so that callers only look it up when needed.
+func RequiresTemplateData(pairs []GrantPair) bool {
+	for _, pair := range pairs {
+		for _, m := range templateRegexp.FindAllStringSubmatch(pair.Grant, -1) {
+			switch strings.ToLower(m[1]) {
+			case "user.id", "account.id":
+			default:
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ParseGrants parses the grants of a user, attributing each to the role it
+// comes from. A grant referring to the user's groups is expanded into a grant
+// for each group in the template data. If the user is not a member of any
+// group an allow grant is dropped, while a deny grant is kept with its
+// templates unresolved so that leaving every group doesn't lift it.
+func ParseGrants(pairs []GrantPair, opt ...Option) ([]Grant, error) {
+	opts := getOpts(opt...)
+	ret := make([]Grant, 0, len(pairs))
+	for _, pair := range pairs {
+		pairOpts := append(opt[:len(opt):len(opt)], WithRoleId(pair.RoleId))
+		grant, err := Parse(pair.ScopeId, pair.Grant, pairOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse grant %q: %w", pair.Grant, err)
+		}
+		if !grant.groupTemplated || (grant.deny && len(opts.withTemplateData.Groups) == 0) {
+			ret = append(ret, grant)
+			continue
+		}
+		for _, group := range opts.withTemplateData.Groups {
+			grant, err := Parse(pair.ScopeId, pair.Grant, append(pairOpts, withGroup(group))...)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse grant %q for group %q: %w", pair.Grant, group.Id, err)
+			}
+			ret = append(ret, grant)
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,226 @@
+package perms
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseTemplates(t *testing.T) {
+	t.Parallel()
+
+	data := TemplateData{
+		UserName:         "jim",
+		AccountLoginName: "jimmy",
+		AccountClaims: map[string]string{
+			"email": "jim@example.com",
+			"name":  `Jim "The Admin" Smith`,
+		},
+	}
+	opts := []Option{WithUserId("u_1234567890"), WithAccountId("apw_1234567890"), WithTemplateData(data)}
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     []Option
+		id       string
+		filter   string
+		grouped  bool
+		canonStr string
+		err      string
+	}{
+		{
+			name:     "text filter",
+			input:    `id=*;type=target;actions=read;filter="/item/name" == "{{user.name}}"`,
+			opts:     opts,
+			id:       "*",
+			filter:   `"/item/name" == "jim"`,
+			canonStr: `id=*;type=target;actions=read;filter="/item/name" == "jim"`,
+		},
+		{
+			name:   "json filter",
+			input:  `{"id":"*","type":"target","actions":["read"],"filter":"\"/item/name\" == \"{{ account.login_name }}\""}`,
+			opts:   opts,
+			id:     "*",
+			filter: `"/item/name" == "jimmy"`,
+		},
+		{
+			name:   "claims",
+			input:  `id=*;type=target;actions=read;filter="/item/description" contains "{{account.claims.email}}"`,
+			opts:   opts,
+			id:     "*",
+			filter: `"/item/description" contains "jim@example.com"`,
+		},
+		{
+			name:   "escaped value",
+			input:  `id=*;type=target;actions=read;filter="/item/name" == "{{account.claims.name}}"`,
+			opts:   opts,
+			id:     "*",
+			filter: `"/item/name" == "Jim \x22The Admin\x22 Smith"`,
+		},
+		{
+			name:   "unknown value left in place",
+			input:  `id=*;type=target;actions=read;filter="/item/name" == "{{account.claims.sub}}"`,
+			opts:   opts,
+			id:     "*",
+			filter: `"/item/name" == "{{account.claims.sub}}"`,
+		},
+		{
+			name:    "group id",
+			input:   `id={{user.group.id}};actions=read`,
+			id:      "{{user.group.id}}",
+			grouped: true,
+		},
+		{
+			name:    "group filter",
+			input:   `id=*;type=target;actions=read;filter="/item/description" == "team:{{user.group.name}}"`,
+			id:      "*",
+			filter:  `"/item/description" == "team:{{user.group.name}}"`,
+			grouped: true,
+		},
+		{
+			name:  "non-id template in id",
+			input: `id={{user.name}};actions=read`,
+			err:   `unknown template "{{user.name}}" in grant "id" value`,
+		},
+		{
+			name:  "unknown filter template",
+			input: `id=*;type=target;actions=read;filter="/item/name" == "{{user.email}}"`,
+			err:   `unknown template "{{user.email}}" in grant "filter" value`,
+		},
+		{
+			name:  "filter without id",
+			input: `type=target;actions=list;filter="/item/name" == "foo"`,
+			err:   "a filter can only be specified with an id",
+		},
+		{
+			name:  "invalid filter",
+			input: `id=*;type=target;actions=read;filter="/item/name" ==`,
+			err:   "unable to parse grant filter",
+		},
+		{
+			name:  "filter with semicolon",
+			input: `{"id":"*","type":"target","actions":["read"],"filter":"\"/item/name\" == \"a;b\""}`,
+			err:   "a filter cannot contain ';'",
+		},
+		{
+			name:  "equal signs outside filter",
+			input: `id=a=b;actions=read`,
+			err:   "wrong number of equal signs",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			g, err := Parse("p_1234567890", tt.input, tt.opts...)
+			if tt.err != "" {
+				require.Error(err)
+				assert.Contains(err.Error(), tt.err)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.id, g.Id())
+			assert.Equal(tt.filter, g.Filter())
+			assert.Equal(tt.grouped, g.groupTemplated)
+			if tt.canonStr != "" {
+				assert.Equal(tt.canonStr, g.CanonicalString())
+			}
+		})
+	}
+}
+
+func Test_ParseGrants(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+
+	pairs := []GrantPair{
+		{ScopeId: "p_1234567890", RoleId: "r_1", Grant: "id={{user.id}};actions=read"},
+		{ScopeId: "p_1234567890", RoleId: "r_2", Grant: `id=*;type=target;actions=read,authorize-session;filter="/item/description" == "team:{{user.group.name}}"`},
+	}
+	assert.True(RequiresTemplateData(pairs))
+	assert.False(RequiresTemplateData(pairs[:1]))
+
+	data := TemplateData{
+		Groups: []TemplateGroup{{Id: "g_a", Name: "a"}, {Id: "g_b", Name: "b"}},
+	}
+	grants, err := ParseGrants(pairs, WithUserId("u_1234567890"), WithTemplateData(data))
+	require.NoError(err)
+	require.Len(grants, 3)
+	assert.Equal("u_1234567890", grants[0].Id())
+	assert.Equal("r_1", grants[0].RoleId())
+	assert.Equal(`"/item/description" == "team:a"`, grants[1].Filter())
+	assert.Equal(`"/item/description" == "team:b"`, grants[2].Filter())
+	assert.Equal("r_2", grants[2].RoleId())
+
+	acl := NewACL(grants...)
+	r := Resource{ScopeId: "p_1234567890", Id: "ttcp_1", Type: resource.Target}
+	for desc, allowed := range map[string]bool{"team:a": true, "team:b": true, "team:c": false} {
+		r.Attributes = map[string]interface{}{"description": desc}
+		assert.Equal(allowed, acl.Allowed(r, action.AuthorizeSession).Allowed, desc)
+	}
+
+	// Without groups the group templated grant is dropped
+	grants, err = ParseGrants(pairs, WithUserId("u_1234567890"))
+	require.NoError(err)
+	require.Len(grants, 1)
+	assert.Equal("r_1", grants[0].RoleId())
+}
+
+func Test_UnresolvedTemplates(t *testing.T) {
+	t.Parallel()
+
+	allow := GrantPair{ScopeId: "p_1234567890", RoleId: "r_1", Grant: "id=*;type=target;actions=read,authorize-session"}
+	r := Resource{
+		ScopeId:    "p_1234567890",
+		Id:         "ttcp_1",
+		Type:       resource.Target,
+		Attributes: map[string]interface{}{"description": "team:a"},
+	}
+
+	tests := []struct {
+		name    string
+		grant   string
+		data    TemplateData
+		allowed bool
+	}{
+		{
+			name:    "deny claim resolved",
+			grant:   `id=*;type=target;actions=authorize-session;effect=deny;filter="/item/description" == "{{account.claims.email}}"`,
+			data:    TemplateData{AccountClaims: map[string]string{"email": "jim@example.com"}},
+			allowed: true,
+		},
+		{
+			name:  "deny claim unresolved",
+			grant: `id=*;type=target;actions=authorize-session;effect=deny;filter="/item/description" == "{{account.claims.email}}"`,
+		},
+		{
+			name:    "deny group resolved",
+			grant:   `id=*;type=target;actions=authorize-session;effect=deny;filter="/item/description" == "team:{{user.group.name}}"`,
+			data:    TemplateData{Groups: []TemplateGroup{{Id: "g_b", Name: "b"}}},
+			allowed: true,
+		},
+		{
+			name:  "deny group without groups",
+			grant: `id=*;type=target;actions=authorize-session;effect=deny;filter="/item/description" == "team:{{user.group.name}}"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			pairs := []GrantPair{allow, {ScopeId: "p_1234567890", RoleId: "r_2", Grant: tt.grant}}
+			grants, err := ParseGrants(pairs, WithUserId("u_1234567890"), WithTemplateData(tt.data))
+			require.NoError(err)
+			acl := NewACL(grants...)
+			assert.Equal(tt.allowed, acl.Allowed(r, action.AuthorizeSession).Allowed)
+			assert.True(acl.Allowed(r, action.Read).Allowed)
+		})
+	}
+
+	// An allow grant with an unresolved template matches nothing
+	g, err := Parse("p_1234567890", `id=*;type=target;actions=read;filter="/item/description" == "{{account.claims.email}}"`)
+	require.NoError(t, err)
+	assert.False(t, NewACL(g).Allowed(r, action.Read).Allowed)
+}
@@ -0,0 +1,102 @@
+package common
+
+import (
+	"sync"
+	"time"
+)
+
+// ConnectionEventType is the type of a ConnectionEvent
+type ConnectionEventType string
+
+const (
+	ConnectionAuthorizedEvent ConnectionEventType = "authorized"
+	ConnectionConnectedEvent  ConnectionEventType = "connected"
+	ConnectionClosedEvent     ConnectionEventType = "closed"
+
+	// ConnectionStatusEvent reports the number of bytes transferred so far on
+	// a connected connection. One is published for each connection with each
+	// status the worker handling it reports to this controller.
+	ConnectionStatusEvent ConnectionEventType = "status"
+
+	// The session events report the states of a session itself, so their
+	// connection id is empty
+	SessionPendingEvent    ConnectionEventType = "session_pending"
+	SessionActiveEvent     ConnectionEventType = "session_active"
+	SessionCancelingEvent  ConnectionEventType = "session_canceling"
+	SessionTerminatedEvent ConnectionEventType = "session_terminated"
+)
+
+// sessionEventsQueueLength is the number of events queued for a watcher.
+// Watchers that fall further behind miss events rather than holding up the
+// workers reporting them.
+const sessionEventsQueueLength = 256
+
+// ConnectionEvent is a change in the state of a session or of one of its
+// connections
+type ConnectionEvent struct {
+	Type             ConnectionEventType
+	Time             time.Time
+	SessionId        string
+	ConnectionId     string
+	ClientTcpAddress string
+	ClientTcpPort    uint32
+	BytesUp          uint64
+	BytesDown        uint64
+	// ClosedReason is the reason a connection was closed, or the termination
+	// reason of a terminated session
+	ClosedReason string
+}
+
+// SessionEvents distributes the events of sessions to the watchers
+// of the controller
+type SessionEvents struct {
+	l        sync.RWMutex
+	watchers map[chan *ConnectionEvent]struct{}
+}
+
+// NewSessionEvents returns a SessionEvents without watchers
+func NewSessionEvents() *SessionEvents {
+	return &SessionEvents{
+		watchers: make(map[chan *ConnectionEvent]struct{}),
+	}
+}
+
+// Publish sends e to the current watchers
+func (s *SessionEvents) Publish(e *ConnectionEvent) {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	s.l.RLock()
+	defer s.l.RUnlock()
+	for ch := range s.watchers {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
+
+// Watched reports whether there are current watchers
+func (s *SessionEvents) Watched() bool {
+	s.l.RLock()
+	defer s.l.RUnlock()
+	return len(s.watchers) > 0
+}
+
+// Watch returns a channel receiving the events published from now on, and a
+// function to stop watching which closes the channel.
+func (s *SessionEvents) Watch() (<-chan *ConnectionEvent, func()) {
+	ch := make(chan *ConnectionEvent, sessionEventsQueueLength)
+	s.l.Lock()
+	s.watchers[ch] = struct{}{}
+	s.l.Unlock()
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			s.l.Lock()
+			delete(s.watchers, ch)
+			s.l.Unlock()
+			close(ch)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package common
+
+import (
+	"sync"
+
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+)
+
+// workerJobChangesQueueLength is the number of job change requests queued for
+// a worker before further requests are left to its next status
+const workerJobChangesQueueLength = 64
+
+// WorkerJobChanges holds the streams of job change requests opened by the
+// workers connected to this controller, through which requests reach them
+// without waiting for their next status
+type WorkerJobChanges struct {
+	l       sync.Mutex
+	streams map[string]chan *pbs.JobChangeRequest
+}
+
+// NewWorkerJobChanges returns a WorkerJobChanges without streams
+func NewWorkerJobChanges() *WorkerJobChanges {
+	return &WorkerJobChanges{
+		streams: make(map[string]chan *pbs.JobChangeRequest),
+	}
+}
+
+// Open opens the stream of the named worker, replacing any it had open. It
+// returns the channel receiving the requests sent to the worker and a
+// function to close the stream which closes the channel.
+func (w *WorkerJobChanges) Open(workerId string) (<-chan *pbs.JobChangeRequest, func()) {
+	ch := make(chan *pbs.JobChangeRequest, workerJobChangesQueueLength)
+	w.l.Lock()
+	if prev, ok := w.streams[workerId]; ok {
+		close(prev)
+	}
+	w.streams[workerId] = ch
+	w.l.Unlock()
+	return ch, func() {
+		w.l.Lock()
+		defer w.l.Unlock()
+		// Only close the stream if it has not been replaced since
+		if w.streams[workerId] == ch {
+			delete(w.streams, workerId)
+			close(ch)
+		}
+	}
+}
+
+// Send sends req to the named worker. It returns false if the worker has no
+// stream open to this controller or its stream is full.
+func (w *WorkerJobChanges) Send(workerId string, req *pbs.JobChangeRequest) bool {
+	w.l.Lock()
+	defer w.l.Unlock()
+	ch, ok := w.streams[workerId]
+	if !ok {
+		return false
+	}
+	select {
+	case ch <- req:
+		return true
+	default:
+		return false
+	}
+}
+
+// CloseConnectionsRequest returns the job change request asking a worker to
+// close the given connections of a session, leaving the session and its other
+// connections open
+func CloseConnectionsRequest(sessionId string, connectionIds ...string) *pbs.JobChangeRequest {
+	connections := make([]*pbs.Connection, 0, len(connectionIds))
+	for _, id := range connectionIds {
+		connections = append(connections, &pbs.Connection{
+			ConnectionId: id,
+			Status:       pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CLOSED,
+		})
+	}
+	return &pbs.JobChangeRequest{
+		Job: &pbs.Job{
+			Type: pbs.JOBTYPE_JOBTYPE_SESSION,
+			JobInfo: &pbs.Job_SessionInfo{
+				SessionInfo: &pbs.SessionJobInfo{
+					SessionId:   sessionId,
+					Connections: connections,
+				},
+			},
+		},
+		RequestType: pbs.CHANGETYPE_CHANGETYPE_UPDATE_STATE,
+	}
+}
@@ -5,11 +5,17 @@ import (
 	"crypto/rand"
 	"fmt"
 	"sync"
+	"time"
 
+	"github.com/hashicorp/boundary/internal/auth/ldap"
+	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
 	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/cmd/config"
+	credstatic "github.com/hashicorp/boundary/internal/credential/static"
 	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/host/plugin"
+	"github.com/hashicorp/boundary/internal/host/plugin/file"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
@@ -34,17 +40,44 @@ type Controller struct {
 
 	workerAuthCache *cache.Cache
 
+	// reverseWorkers holds the yamux sessions of reverse-connected workers,
+	// keyed by worker name
+	reverseWorkers *sync.Map
+	// reverseRoutes holds the [][]string routes through the reverse-connected
+	// workers of this controller of the sessions they can handle, keyed by
+	// session ID
+	reverseRoutes *cache.Cache
+	// reverseRoutesChanged is closed and replaced when routes are recorded
+	// or reverse workers connect
+	reverseRoutesChanged chan struct{}
+	reverseRoutesL       *sync.Mutex
+	// reverseWorkerServers holds the []*servers.Server workers used to find
+	// routes to reverse-connected workers
+	reverseWorkerServers ua.Value
+
+	// workerJobChanges holds the job change streams of the workers connected
+	// to this controller
+	workerJobChanges *common.WorkerJobChanges
+	// sessionEvents distributes the state changes of sessions and their
+	// connections, and the connection statuses reported by workers, to
+	// session watchers
+	sessionEvents *common.SessionEvents
+
 	// Used for testing
 	workerStatusUpdateTimes *sync.Map
 
 	// Repo factory methods
-	AuthTokenRepoFn    common.AuthTokenRepoFactory
-	IamRepoFn          common.IamRepoFactory
-	PasswordAuthRepoFn common.PasswordAuthRepoFactory
-	ServersRepoFn      common.ServersRepoFactory
-	SessionRepoFn      common.SessionRepoFactory
-	StaticHostRepoFn   common.StaticRepoFactory
-	TargetRepoFn       common.TargetRepoFactory
+	AuthTokenRepoFn        common.AuthTokenRepoFactory
+	IamRepoFn              common.IamRepoFactory
+	PasswordAuthRepoFn     common.PasswordAuthRepoFactory
+	OidcAuthRepoFn         common.OidcAuthRepoFactory
+	LdapAuthRepoFn         common.LdapAuthRepoFactory
+	PluginHostRepoFn       common.PluginHostRepoFactory
+	ServersRepoFn          common.ServersRepoFactory
+	SessionRepoFn          common.SessionRepoFactory
+	StaticHostRepoFn       common.StaticRepoFactory
+	StaticCredentialRepoFn common.StaticCredentialRepoFactory
+	TargetRepoFn           common.TargetRepoFactory
 
 	kms *kms.Kms
 }
@@ -54,6 +87,12 @@ func New(conf *Config) (*Controller, error) {
 		conf:                    conf,
 		logger:                  conf.Logger.Named("controller"),
 		workerStatusUpdateTimes: new(sync.Map),
+		reverseWorkers:          new(sync.Map),
+		reverseRoutes:           cache.New(cache.NoExpiration, 5*time.Minute),
+		reverseRoutesChanged:    make(chan struct{}),
+		reverseRoutesL:          new(sync.Mutex),
+		workerJobChanges:        common.NewWorkerJobChanges(),
+		sessionEvents:           common.NewSessionEvents(),
 	}
 
 	c.started.Store(false)
@@ -111,6 +150,15 @@ func New(conf *Config) (*Controller, error) {
 	c.StaticHostRepoFn = func() (*static.Repository, error) {
 		return static.NewRepository(dbase, dbase, c.kms)
 	}
+	c.StaticCredentialRepoFn = func() (*credstatic.Repository, error) {
+		return credstatic.NewRepository(dbase, dbase, c.kms)
+	}
+	hostPlugins := map[string]plugin.HostPlugin{
+		file.PluginName: file.New(),
+	}
+	c.PluginHostRepoFn = func() (*plugin.Repository, error) {
+		return plugin.NewRepository(dbase, dbase, c.kms, hostPlugins)
+	}
 	c.AuthTokenRepoFn = func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(dbase, dbase, c.kms,
 			authtoken.WithTokenTimeToLiveDuration(c.conf.RawConfig.Controller.AuthTokenTimeToLiveDuration),
@@ -122,6 +170,12 @@ func New(conf *Config) (*Controller, error) {
 	c.PasswordAuthRepoFn = func() (*password.Repository, error) {
 		return password.NewRepository(dbase, dbase, c.kms)
 	}
+	c.OidcAuthRepoFn = func() (*oidc.Repository, error) {
+		return oidc.NewRepository(dbase, dbase, c.kms)
+	}
+	c.LdapAuthRepoFn = func() (*ldap.Repository, error) {
+		return ldap.NewRepository(dbase, dbase, c.kms)
+	}
 	c.TargetRepoFn = func() (*target.Repository, error) {
 		return target.NewRepository(dbase, dbase, c.kms)
 	}
@@ -148,6 +202,11 @@ func (c *Controller) Start() error {
 	c.startStatusTicking(c.baseContext)
 	c.startRecoveryNonceCleanupTicking(c.baseContext)
 	c.startTerminateCompletedSessionsTicking(c.baseContext)
+	c.startExpiredPrincipalRoleCleanupTicking(c.baseContext)
+	c.startLdapGroupSyncTicking(c.baseContext)
+	c.startReverseWorkerServersTicking(c.baseContext)
+	c.startReverseWorkerRoutesTicking(c.baseContext)
+	c.startSessionEventsTicking(c.baseContext)
 	c.started.Store(true)
 
 	return nil
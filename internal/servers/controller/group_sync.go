@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-ldap/ldap/v3"
+	ldapauth "github.com/hashicorp/boundary/internal/auth/ldap"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/iam"
+	ldapclient "github.com/hashicorp/boundary/internal/libs/ldap"
+	"github.com/hashicorp/go-multierror"
+)
+
+// syncLdapGroups sets the members of every group bound to an LDAP group to
+// the members of the LDAP group, returning the number of groups synchronized.
+// Members are matched to the accounts of the configured LDAP auth method by
+// the DN of their entry, and the accounts of members which have not logged in
+// yet are created. A group whose members cannot be read is left unchanged and
+// does not stop the other groups from being synchronized.
+func syncLdapGroups(ctx context.Context, repo *iam.Repository, ldapRepo *ldapauth.Repository, conf *config.GroupSyncLdap) (int, error) {
+	grps, err := repo.ListDirectoryGroups(ctx, iam.DirectoryLdap, iam.WithLimit(-1))
+	if err != nil {
+		return 0, err
+	}
+	if len(grps) == 0 {
+		return 0, nil
+	}
+	am, err := ldapRepo.LookupAuthMethod(ctx, conf.AuthMethodId)
+	if err != nil {
+		return 0, err
+	}
+	if am == nil {
+		return 0, fmt.Errorf("ldap auth method %s not found", conf.AuthMethodId)
+	}
+	conn, err := ldapclient.Dial(ctx, ldapclient.Config{
+		Url:         conf.Url,
+		StartTls:    conf.StartTls,
+		InsecureTls: conf.InsecureTls,
+		CaCert:      conf.CaCert,
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	if conf.BindDn != "" {
+		if err := conn.Bind(conf.BindDn, conf.BindPassword); err != nil {
+			return 0, err
+		}
+	}
+
+	var synced int
+	var errs *multierror.Error
+	for _, g := range grps {
+		members, err := ldapGroupMembers(conn, am, g.DirectoryGroup, conf.UserAttr)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("group %s: %w", g.PublicId, err))
+			continue
+		}
+		accountIds, err := ldapMemberAccounts(ctx, ldapRepo, am.PublicId, members)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("group %s: %w", g.PublicId, err))
+			continue
+		}
+		if _, err := repo.SyncGroupMembers(ctx, g.PublicId, accountIds); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("group %s: %w", g.PublicId, err))
+			continue
+		}
+		synced++
+	}
+	return synced, errs.ErrorOrNil()
+}
+
+// An ldapMember is a user entry which is a member of an LDAP group.
+type ldapMember struct {
+	dn        string
+	loginName string
+}
+
+// ldapMemberAccounts returns the ids of the accounts in the auth method of the
+// members, creating those that don't exist yet.
+func ldapMemberAccounts(ctx context.Context, ldapRepo *ldapauth.Repository, authMethodId string, members []ldapMember) ([]string, error) {
+	ids := make([]string, 0, len(members))
+	for _, m := range members {
+		acct, err := ldapRepo.EnsureAccount(ctx, authMethodId, m.dn, m.loginName)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, acct.PublicId)
+	}
+	return ids, nil
+}
+
+// ldapGroupMembers returns the user entries that are members of the LDAP
+// group with the DN. The members of groupOfNames and groupOfUniqueNames
+// groups are DNs, and the login name of each is read from its userAttr
+// attribute. Members without the attribute, such as nested groups, are
+// skipped. The members of posixGroup groups are login names, whose entries
+// are found with the user search of the auth method, as they are on login.
+func ldapGroupMembers(conn *ldap.Conn, am *ldapauth.AuthMethod, groupDn, userAttr string) ([]ldapMember, error) {
+	res, err := conn.Search(ldap.NewSearchRequest(groupDn, ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
+		"(objectClass=*)", []string{"member", "uniqueMember", "memberUid"}, nil))
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Entries) == 0 {
+		return nil, errors.New("ldap group not found")
+	}
+	grp := res.Entries[0]
+	var members []ldapMember
+	for _, uid := range grp.GetEqualFoldAttributeValues("memberUid") {
+		users, err := conn.Search(am.UserSearchRequest(uid))
+		switch {
+		case ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded):
+			// More than one entry matches, so the member can't log in.
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("searching for member %q: %w", uid, err)
+		}
+		if len(users.Entries) != 1 {
+			continue
+		}
+		members = append(members, ldapMember{dn: users.Entries[0].DN, loginName: uid})
+	}
+	for _, dn := range append(grp.GetEqualFoldAttributeValues("member"), grp.GetEqualFoldAttributeValues("uniqueMember")...) {
+		users, err := conn.Search(ldap.NewSearchRequest(dn, ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
+			"(objectClass=*)", []string{userAttr}, nil))
+		switch {
+		case ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject):
+			// The member no longer exists in the directory.
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("reading member %q: %w", dn, err)
+		}
+		if len(users.Entries) == 0 {
+			continue
+		}
+		if name := users.Entries[0].GetEqualFoldAttributeValue(userAttr); name != "" {
+			members = append(members, ldapMember{dn: users.Entries[0].DN, loginName: name})
+		}
+	}
+	return members, nil
+}
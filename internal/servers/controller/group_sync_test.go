@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/boundary/internal/auth"
+	ldapauth "github.com/hashicorp/boundary/internal/auth/ldap"
+	"github.com/hashicorp/boundary/internal/auth/oidc"
+	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	ldapclient "github.com/hashicorp/boundary/internal/libs/ldap"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/authmethods"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestSyncLdapGroups(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	repo := iam.TestRepo(t, conn, wrapper)
+	ldapRepo, err := ldapauth.NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+	org, proj := iam.TestScopes(t, repo)
+
+	s := ldapclient.NewTestServer(t)
+	s.AddEntry("dc=example,dc=com", map[string][]string{"objectClass": {"domain"}})
+	for _, uid := range []string{"jim", "sue", "bob"} {
+		s.AddEntry("uid="+uid+",ou=people,dc=example,dc=com", map[string][]string{
+			"objectClass": {"person"},
+			"uid":         {uid},
+		})
+		s.SetPassword("uid="+uid+",ou=people,dc=example,dc=com", uid+"-password")
+	}
+	s.AddEntry("cn=admins,ou=groups,dc=example,dc=com", map[string][]string{
+		"objectClass": {"groupOfNames"},
+		"member": {
+			"uid=jim,ou=people,dc=example,dc=com",
+			"uid=sue,ou=people,dc=example,dc=com",
+			"cn=nested,ou=groups,dc=example,dc=com",
+			"uid=gone,ou=people,dc=example,dc=com",
+		},
+	})
+	s.AddEntry("cn=nested,ou=groups,dc=example,dc=com", map[string][]string{"objectClass": {"groupOfNames"}})
+	s.AddEntry("cn=ops,ou=groups,dc=example,dc=com", map[string][]string{
+		"objectClass": {"posixGroup"},
+		"memberUid":   {"bob", "nobody"},
+	})
+	s.SetPassword("cn=reader,dc=example,dc=com", "reader-password")
+
+	am, err := ldapauth.NewAuthMethod(org.PublicId, s.Url(), "ou=people,dc=example,dc=com")
+	require.NoError(err)
+	am, err = ldapRepo.CreateAuthMethod(ctx, am)
+	require.NoError(err)
+
+	admins := iam.TestGroup(t, conn, org.PublicId, iam.WithDirectoryType(iam.DirectoryLdap), iam.WithDirectoryGroup("cn=admins,ou=groups,dc=example,dc=com"))
+	ops := iam.TestGroup(t, conn, proj.PublicId, iam.WithDirectoryType(iam.DirectoryLdap), iam.WithDirectoryGroup("cn=ops,ou=groups,dc=example,dc=com"))
+	missing := iam.TestGroup(t, conn, org.PublicId, iam.WithDirectoryType(iam.DirectoryLdap), iam.WithDirectoryGroup("cn=missing,ou=groups,dc=example,dc=com"))
+	iam.TestGroup(t, conn, org.PublicId, iam.WithDirectoryType(iam.DirectoryScim), iam.WithDirectoryGroup("admins"))
+	// A user named like a member is not matched to it.
+	iam.TestUser(t, repo, org.PublicId, iam.WithName("jim"))
+
+	memberDns := func(groupId string) []string {
+		t.Helper()
+		ids, err := repo.ListGroupMemberAccounts(ctx, groupId, am.PublicId)
+		require.NoError(err)
+		var dns []string
+		for _, id := range ids {
+			acct, err := ldapRepo.LookupAccount(ctx, id)
+			require.NoError(err)
+			require.NotNil(acct)
+			dns = append(dns, acct.Dn)
+		}
+		return dns
+	}
+
+	conf := &config.GroupSyncLdap{
+		Url:          s.Url(),
+		BindDn:       "cn=reader,dc=example,dc=com",
+		BindPassword: "reader-password",
+		AuthMethodId: am.PublicId,
+		UserAttr:     "uid",
+	}
+	synced, err := syncLdapGroups(ctx, repo, ldapRepo, conf)
+	require.Error(err)
+	assert.Contains(err.Error(), missing.PublicId)
+	assert.Equal(2, synced)
+
+	assert.ElementsMatch([]string{"uid=jim,ou=people,dc=example,dc=com", "uid=sue,ou=people,dc=example,dc=com"}, memberDns(admins.PublicId))
+	assert.Equal([]string{"uid=bob,ou=people,dc=example,dc=com"}, memberDns(ops.PublicId))
+
+	// A member logging in after the sync gets the grants of the group.
+	role := iam.TestRole(t, conn, org.PublicId)
+	iam.TestRoleGrant(t, conn, role.PublicId, "id=*;type=*;actions=read")
+	iam.TestGroupRole(t, conn, role.PublicId, admins.PublicId)
+
+	amService, err := authmethods.NewService(kmsCache,
+		func() (*password.Repository, error) { return password.NewRepository(rw, rw, kmsCache) },
+		func() (*oidc.Repository, error) { return oidc.NewRepository(rw, rw, kmsCache) },
+		func() (*ldapauth.Repository, error) { return ldapRepo, nil },
+		func() (*iam.Repository, error) { return repo, nil },
+		func() (*authtoken.Repository, error) { return authtoken.NewRepository(rw, rw, kmsCache) })
+	require.NoError(err)
+	resp, err := amService.Authenticate(auth.DisabledAuthTestContext(auth.WithScopeId(org.PublicId)), &pbs.AuthenticateRequest{
+		AuthMethodId: am.PublicId,
+		TokenType:    "token",
+		Credentials: &structpb.Struct{Fields: map[string]*structpb.Value{
+			"login_name": structpb.NewStringValue("jim"),
+			"password":   structpb.NewStringValue("jim-password"),
+		}},
+	})
+	require.NoError(err)
+
+	// The member logs in as the user the sync made a member of the
+	// group, so the group's grants apply to it.
+	members, err := repo.ListGroupMembers(ctx, admins.PublicId)
+	require.NoError(err)
+	var memberIds []string
+	for _, m := range members {
+		memberIds = append(memberIds, m.MemberId)
+	}
+	userId := resp.GetItem().GetUserId()
+	assert.Contains(memberIds, userId)
+	grants, err := repo.GrantsForUser(ctx, userId)
+	require.NoError(err)
+	var found bool
+	for _, g := range grants {
+		if g.RoleId == role.PublicId && g.Grant == "id=*;type=*;actions=read" {
+			found = true
+		}
+	}
+	assert.True(found, "grants %v", grants)
+
+	// Users that leave the directory group are removed on the next sync.
+	s.AddEntry("cn=admins,ou=groups,dc=example,dc=com", map[string][]string{
+		"objectClass": {"groupOfNames"},
+		"member":      {"uid=sue,ou=people,dc=example,dc=com"},
+	})
+	s.AddEntry("cn=missing,ou=groups,dc=example,dc=com", map[string][]string{"objectClass": {"groupOfNames"}})
+	synced, err = syncLdapGroups(ctx, repo, ldapRepo, conf)
+	require.NoError(err)
+	assert.Equal(3, synced)
+	assert.Equal([]string{"uid=sue,ou=people,dc=example,dc=com"}, memberDns(admins.PublicId))
+
+	conf.BindPassword = "wrong"
+	_, err = syncLdapGroups(ctx, repo, ldapRepo, conf)
+	assert.True(ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials))
+
+	conf.AuthMethodId = "amldap_1234567890"
+	_, err = syncLdapGroups(ctx, repo, ldapRepo, conf)
+	assert.Error(err)
+}
@@ -14,8 +14,11 @@ import (
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/auth"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/access_requests"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/accounts"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/authmethods"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/credential_stores"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/credentials"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/host_sets"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/sessions"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/targets"
@@ -29,6 +32,7 @@ import (
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/host_catalogs"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/hosts"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/roles"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/scim"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/scopes"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/users"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -50,6 +54,18 @@ func (c *Controller) handler(props HandlerProperties) (http.Handler, error) {
 		return nil, err
 	}
 	mux.Handle("/v1/", h)
+	wh, err := sessions.NewWatchHandler(c.logger, c.SessionRepoFn, c.IamRepoFn, c.sessionEvents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session watch handler: %w", err)
+	}
+	mux.Handle(sessions.WatchPath, wh)
+	if gs := c.conf.RawConfig.Controller.GroupSync; gs != nil && gs.Scim != nil {
+		sh, err := scim.NewHandler(c.IamRepoFn, c.LdapAuthRepoFn, gs.Scim.AuthMethodId, gs.Scim.BearerToken)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create scim handler: %w", err)
+		}
+		mux.Handle(scim.PathPrefix, sh)
+	}
 	mux.Handle("/", handleUi(c))
 
 	corsWrappedHandler := wrapHandlerWithCors(mux, props)
@@ -81,14 +97,14 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 		runtime.WithErrorHandler(handlers.ErrorHandler(c.logger)),
 		runtime.WithForwardResponseOption(handlers.OutgoingInterceptor),
 	)
-	hcs, err := host_catalogs.NewService(c.StaticHostRepoFn, c.IamRepoFn)
+	hcs, err := host_catalogs.NewService(c.StaticHostRepoFn, c.PluginHostRepoFn, c.IamRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create host catalog handler service: %w", err)
 	}
 	if err := services.RegisterHostCatalogServiceHandlerServer(ctx, mux, hcs); err != nil {
 		return nil, fmt.Errorf("failed to register host catalog service handler: %w", err)
 	}
-	hss, err := host_sets.NewService(c.StaticHostRepoFn)
+	hss, err := host_sets.NewService(c.StaticHostRepoFn, c.PluginHostRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create host set handler service: %w", err)
 	}
@@ -102,6 +118,20 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 	if err := services.RegisterHostServiceHandlerServer(ctx, mux, hs); err != nil {
 		return nil, fmt.Errorf("failed to register host service handler: %w", err)
 	}
+	css, err := credential_stores.NewService(c.StaticCredentialRepoFn, c.IamRepoFn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create credential store handler service: %w", err)
+	}
+	if err := services.RegisterCredentialStoreServiceHandlerServer(ctx, mux, css); err != nil {
+		return nil, fmt.Errorf("failed to register credential store service handler: %w", err)
+	}
+	creds, err := credentials.NewService(c.StaticCredentialRepoFn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create credential handler service: %w", err)
+	}
+	if err := services.RegisterCredentialServiceHandlerServer(ctx, mux, creds); err != nil {
+		return nil, fmt.Errorf("failed to register credential service handler: %w", err)
+	}
 	accts, err := accounts.NewService(c.PasswordAuthRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create account handler service: %w", err)
@@ -109,7 +139,7 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 	if err := services.RegisterAccountServiceHandlerServer(ctx, mux, accts); err != nil {
 		return nil, fmt.Errorf("failed to register account service handler: %w", err)
 	}
-	authMethods, err := authmethods.NewService(c.kms, c.PasswordAuthRepoFn, c.IamRepoFn, c.AuthTokenRepoFn)
+	authMethods, err := authmethods.NewService(c.kms, c.PasswordAuthRepoFn, c.OidcAuthRepoFn, c.LdapAuthRepoFn, c.IamRepoFn, c.AuthTokenRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create auth method handler service: %w", err)
 	}
@@ -143,7 +173,10 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 		c.IamRepoFn,
 		c.ServersRepoFn,
 		c.SessionRepoFn,
-		c.StaticHostRepoFn)
+		c.StaticHostRepoFn,
+		c.PluginHostRepoFn,
+		c.StaticCredentialRepoFn,
+		c.reverseWorkerSessionAuthorized)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create target handler service: %w", err)
 	}
@@ -157,20 +190,27 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 	if err := services.RegisterGroupServiceHandlerServer(ctx, mux, gs); err != nil {
 		return nil, fmt.Errorf("failed to register group service handler: %w", err)
 	}
-	rs, err := roles.NewService(c.IamRepoFn)
+	rs, err := roles.NewService(c.IamRepoFn, c.TargetRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create role handler service: %w", err)
 	}
 	if err := services.RegisterRoleServiceHandlerServer(ctx, mux, rs); err != nil {
 		return nil, fmt.Errorf("failed to register role service handler: %w", err)
 	}
-	ss, err := sessions.NewService(c.SessionRepoFn, c.IamRepoFn)
+	ss, err := sessions.NewService(c.SessionRepoFn, c.IamRepoFn, c.workerJobChanges)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create session handler service: %w", err)
 	}
 	if err := services.RegisterSessionServiceHandlerServer(ctx, mux, ss); err != nil {
 		return nil, fmt.Errorf("failed to register session service handler: %w", err)
 	}
+	ars, err := access_requests.NewService(c.IamRepoFn, c.TargetRepoFn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create access request handler service: %w", err)
+	}
+	if err := services.RegisterAccessRequestServiceHandlerServer(ctx, mux, ars); err != nil {
+		return nil, fmt.Errorf("failed to register access request service handler: %w", err)
+	}
 
 	return mux, nil
 }
@@ -0,0 +1,464 @@
+package access_requests
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/auth"
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/accessrequests"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"google.golang.org/grpc/codes"
+)
+
+// maxDurationSeconds is the longest duration access can be requested for.
+const maxDurationSeconds = 7 * 24 * 60 * 60
+
+var (
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.Read,
+		action.Approve,
+		action.Deny,
+	}
+)
+
+type Service struct {
+	pbs.UnimplementedAccessRequestServiceServer
+
+	repoFn       common.IamRepoFactory
+	targetRepoFn common.TargetRepoFactory
+}
+
+var _ pbs.AccessRequestServiceServer = Service{}
+
+// NewService returns an access request Service which handles access request
+// related requests to boundary and uses the provided repositories for storage
+// and retrieval.
+func NewService(repoFn common.IamRepoFactory, targetRepoFn common.TargetRepoFactory) (Service, error) {
+	if repoFn == nil {
+		return Service{}, fmt.Errorf("nil iam repository provided")
+	}
+	if targetRepoFn == nil {
+		return Service{}, fmt.Errorf("nil target repository provided")
+	}
+	return Service{repoFn: repoFn, targetRepoFn: targetRepoFn}, nil
+}
+
+// ListAccessRequests implements the interface pbs.AccessRequestServiceServer.
+func (s Service) ListAccessRequests(ctx context.Context, req *pbs.ListAccessRequestsRequest) (*pbs.ListAccessRequestsResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
+	scopeIds, scopeInfos, err := authResults.ScopesForList(ctx, resource.AccessRequest, req.GetRecursive())
+	if err != nil {
+		return nil, err
+	}
+	if len(scopeIds) == 0 {
+		return &pbs.ListAccessRequestsResponse{}, nil
+	}
+	fetch := func(afterId string, limit int) ([]handlers.ListItem, error) {
+		ul, err := s.listFromRepo(ctx, scopeIds, afterId, limit)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]handlers.ListItem, 0, len(ul))
+		for _, ar := range ul {
+			resOpt := auth.WithScopeId(ar.GetScopeId())
+			if authResults.DeniedForId(ctx, ar.GetPublicId(), action.Read, resOpt) {
+				items = append(items, handlers.HiddenListItem(ar.GetPublicId()))
+				continue
+			}
+			items = append(items, handlers.ListedItem(ar.GetPublicId(), toProto(ar,
+				handlers.WithScope(scopeInfos[ar.GetScopeId()]),
+				handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, ar.GetPublicId(), IdActions, resOpt).Strings()),
+				handlers.WithOutputFields(authResults.FetchOutputFields(ctx, ar.GetPublicId(), resOpt)))))
+		}
+		return items, nil
+	}
+	items, next, err := handlers.ListPage(fetch, req.GetFilter(), req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	var ul []*pb.AccessRequest
+	for _, item := range items {
+		ul = append(ul, item.(*pb.AccessRequest))
+	}
+	return &pbs.ListAccessRequestsResponse{Items: ul, NextPageToken: next}, nil
+}
+
+// GetAccessRequest implements the interface pbs.AccessRequestServiceServer.
+func (s Service) GetAccessRequest(ctx context.Context, req *pbs.GetAccessRequestRequest) (*pbs.GetAccessRequestResponse, error) {
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	ar, err := s.getFromRepo(ctx, req.GetId(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, req.GetId(), IdActions).Strings()),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.GetAccessRequestResponse{Item: ar}, nil
+}
+
+// CreateAccessRequest implements the interface pbs.AccessRequestServiceServer.
+// The access request is made by the user making the API request.
+func (s Service) CreateAccessRequest(ctx context.Context, req *pbs.CreateAccessRequestRequest) (*pbs.CreateAccessRequestResponse, error) {
+	if err := validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetItem().GetTargetId(), action.Create)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	switch authResults.UserId {
+	case "", "u_anon", "u_recovery":
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"user_id": "Access can only be requested by an authenticated user."})
+	}
+	ar, err := s.createInRepo(ctx, authResults.Scope.GetId(), authResults.UserId, req.GetItem(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, "")))
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.CreateAccessRequestResponse{
+		Item: ar,
+		Uri:  fmt.Sprintf("access-requests/%s", ar.GetId()),
+	}, nil
+}
+
+// ApproveAccessRequest implements the interface pbs.AccessRequestServiceServer.
+func (s Service) ApproveAccessRequest(ctx context.Context, req *pbs.ApproveAccessRequestRequest) (*pbs.ApproveAccessRequestResponse, error) {
+	if err := validateReviewRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Approve)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	ar, err := s.reviewInRepo(ctx, req.GetId(), req.GetVersion(), authResults.UserId, req.GetComment(), iam.AccessRequestApproved,
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ApproveAccessRequestResponse{Item: ar}, nil
+}
+
+// DenyAccessRequest implements the interface pbs.AccessRequestServiceServer.
+func (s Service) DenyAccessRequest(ctx context.Context, req *pbs.DenyAccessRequestRequest) (*pbs.DenyAccessRequestResponse, error) {
+	if err := validateReviewRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Deny)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	ar, err := s.reviewInRepo(ctx, req.GetId(), req.GetVersion(), authResults.UserId, req.GetComment(), iam.AccessRequestDenied,
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.DenyAccessRequestResponse{Item: ar}, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string, opt ...handlers.Option) (*pb.AccessRequest, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	ar, err := repo.LookupAccessRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ar == nil {
+		return nil, handlers.NotFoundErrorf("Access Request %q doesn't exist.", id)
+	}
+	return toProto(ar, opt...), nil
+}
+
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, afterId string, limit int) ([]*iam.AccessRequest, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	ul, err := repo.ListAccessRequests(ctx, scopeIds, iam.WithStartPageAfterId(afterId), iam.WithLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	return ul, nil
+}
+
+func (s Service) createInRepo(ctx context.Context, scopeId, userId string, item *pb.AccessRequest, opt ...handlers.Option) (*pb.AccessRequest, error) {
+	ar, err := iam.NewAccessRequest(scopeId, item.GetTargetId(), userId, item.GetJustification(), item.GetDurationSeconds())
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build access request for creation: %v.", err)
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.CreateAccessRequest(ctx, ar)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create access request: %w", err)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create access request but no error returned from repository.")
+	}
+	return toProto(out, opt...), nil
+}
+
+func (s Service) reviewInRepo(ctx context.Context, id string, version uint32, reviewerId, comment string, status iam.AccessRequestStatus, opt ...handlers.Option) (*pb.AccessRequest, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	existing, err := repo.LookupAccessRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, handlers.NotFoundErrorf("Access Request %q doesn't exist.", id)
+	}
+	if existing.GetUserId() == reviewerId {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"id": "Access requests cannot be reviewed by the user who made them."})
+	}
+	if existing.GetStatus() != string(iam.AccessRequestPending) {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"id": fmt.Sprintf("The access request has already been %s.", existing.GetStatus())})
+	}
+	var out *iam.AccessRequest
+	switch status {
+	case iam.AccessRequestApproved:
+		out, err = repo.ApproveAccessRequest(ctx, id, version, reviewerId, comment)
+	default:
+		out, err = repo.DenyAccessRequest(ctx, id, version, reviewerId, comment)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to review access request: %w", err)
+	}
+	return toProto(out, opt...), nil
+}
+
+func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
+	res := auth.VerifyResults{}
+
+	var parentId string
+	opts := []auth.Option{auth.WithType(resource.AccessRequest), auth.WithAction(a)}
+	switch a {
+	case action.List:
+		parentId = id
+		iamRepo, err := s.repoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		scp, err := iamRepo.LookupScope(ctx, parentId)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if scp == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+	case action.Create:
+		// access requests are created in the scope of their target
+		repo, err := s.targetRepoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		t, _, err := repo.LookupTarget(ctx, id)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if t == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+		parentId = t.GetScopeId()
+	default:
+		repo, err := s.repoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		ar, err := repo.LookupAccessRequest(ctx, id)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if ar == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+		parentId = ar.GetScopeId()
+		opts = append(opts, auth.WithId(id))
+	}
+	opts = append(opts, auth.WithScopeId(parentId))
+	return auth.Verify(ctx, opts...)
+}
+
+func toProto(in *iam.AccessRequest, opt ...handlers.Option) *pb.AccessRequest {
+	opts := handlers.GetOpts(opt...)
+	outputFields := opts.WithOutputFields
+	out := pb.AccessRequest{}
+	if outputFields.Has("id") {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has("scope_id") {
+		out.ScopeId = in.GetScopeId()
+	}
+	if outputFields.Has("scope") {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has("target_id") {
+		out.TargetId = in.GetTargetId()
+	}
+	if outputFields.Has("user_id") {
+		out.UserId = in.GetUserId()
+	}
+	if outputFields.Has("justification") {
+		out.Justification = in.GetJustification()
+	}
+	if outputFields.Has("duration_seconds") {
+		out.DurationSeconds = in.GetDurationSeconds()
+	}
+	if outputFields.Has("status") {
+		out.Status = in.GetStatus()
+	}
+	if outputFields.Has("reviewer_id") {
+		out.ReviewerId = in.GetReviewerId()
+	}
+	if outputFields.Has("review_comment") {
+		out.ReviewComment = in.GetReviewComment()
+	}
+	if outputFields.Has("review_time") {
+		out.ReviewTime = in.GetReviewTime().GetTimestamp()
+	}
+	if outputFields.Has("expiration_time") {
+		out.ExpirationTime = in.GetExpirationTime().GetTimestamp()
+	}
+	if outputFields.Has("created_time") {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
+	}
+	if outputFields.Has("updated_time") {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has("version") {
+		out.Version = in.GetVersion()
+	}
+	if outputFields.Has("authorized_actions") {
+		out.AuthorizedActions = opts.WithAuthorizedActions
+	}
+	return &out
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
+func validateGetRequest(req *pbs.GetAccessRequestRequest) error {
+	return handlers.ValidateGetRequest(iam.AccessRequestPrefix, req, handlers.NoopValidatorFn)
+}
+
+func validateCreateRequest(req *pbs.CreateAccessRequestRequest) error {
+	badFields := map[string]string{}
+	item := req.GetItem()
+	if item.GetId() != "" {
+		badFields["id"] = "This is a read only field."
+	}
+	if item.GetScopeId() != "" {
+		badFields["scope_id"] = "This is a read only field. The access request is created in the scope of the target."
+	}
+	if item.GetUserId() != "" {
+		badFields["user_id"] = "This is a read only field. Access is requested for the user making the request."
+	}
+	if target.SubtypeFromId(item.GetTargetId()) == target.UnknownSubtype {
+		badFields["target_id"] = "This field must be a valid target id."
+	}
+	if item.GetJustification() == "" {
+		badFields["justification"] = "This is a required field."
+	}
+	switch {
+	case item.GetDurationSeconds() == 0:
+		badFields["duration_seconds"] = "This is a required field."
+	case item.GetDurationSeconds() > maxDurationSeconds:
+		badFields["duration_seconds"] = fmt.Sprintf("Must not be greater than %d.", maxDurationSeconds)
+	}
+	if item.GetStatus() != "" {
+		badFields["status"] = "This is a read only field."
+	}
+	if item.GetReviewerId() != "" {
+		badFields["reviewer_id"] = "This is a read only field."
+	}
+	if item.GetReviewComment() != "" {
+		badFields["review_comment"] = "This is a read only field."
+	}
+	if item.GetReviewTime() != nil {
+		badFields["review_time"] = "This is a read only field."
+	}
+	if item.GetExpirationTime() != nil {
+		badFields["expiration_time"] = "This is a read only field."
+	}
+	if item.GetCreatedTime() != nil {
+		badFields["created_time"] = "This is a read only field."
+	}
+	if item.GetUpdatedTime() != nil {
+		badFields["updated_time"] = "This is a read only field."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+type reviewRequest interface {
+	GetId() string
+	GetVersion() uint32
+}
+
+func validateReviewRequest(req reviewRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(iam.AccessRequestPrefix, req.GetId()) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateListRequest(req *pbs.ListAccessRequestsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()) &&
+		!(req.GetRecursive() && (req.GetScopeId() == scope.Global.String() || handlers.ValidId(scope.Org.Prefix(), req.GetScopeId()))) {
+		badFields["scope_id"] = "This field must be a valid project scope id, or 'global' or a valid org scope id when listing recursively."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
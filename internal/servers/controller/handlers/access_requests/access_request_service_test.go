@@ -0,0 +1,329 @@
+package access_requests_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/db"
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/accessrequests"
+	scopepb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/access_requests"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+var testAuthorizedActions = []string{"read", "approve", "deny"}
+
+type testEnv struct {
+	service  access_requests.Service
+	proj     *iam.Scope
+	target   target.Target
+	user     *iam.User
+	reviewer *iam.User
+}
+
+func testService(t *testing.T) testEnv {
+	t.Helper()
+	require := require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, proj := iam.TestScopes(t, iamRepo)
+
+	rw := db.New(conn)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	targetRepoFn := func() (*target.Repository, error) {
+		return target.NewRepository(rw, rw, kms)
+	}
+	s, err := access_requests.NewService(iamRepoFn, targetRepoFn)
+	require.NoError(err, "Couldn't create a new access request service.")
+
+	return testEnv{
+		service:  s,
+		proj:     proj,
+		target:   target.TestTcpTarget(t, conn, proj.GetPublicId(), "test"),
+		user:     iam.TestUser(t, iamRepo, org.GetPublicId()),
+		reviewer: iam.TestUser(t, iamRepo, org.GetPublicId()),
+	}
+}
+
+func TestGet(t *testing.T) {
+	env := testService(t)
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(env.proj.GetPublicId()), auth.WithUserId(env.user.GetPublicId()))
+	created, err := env.service.CreateAccessRequest(ctx, &pbs.CreateAccessRequestRequest{Item: &pb.AccessRequest{
+		TargetId:        env.target.GetPublicId(),
+		Justification:   "on call",
+		DurationSeconds: 3600,
+	}})
+	require.NoError(t, err)
+	ar := created.GetItem()
+
+	want := &pb.AccessRequest{
+		Id:                ar.GetId(),
+		ScopeId:           env.proj.GetPublicId(),
+		Scope:             &scopepb.ScopeInfo{Id: env.proj.GetPublicId(), Type: scope.Project.String()},
+		TargetId:          env.target.GetPublicId(),
+		UserId:            env.user.GetPublicId(),
+		Justification:     "on call",
+		DurationSeconds:   3600,
+		Status:            string(iam.AccessRequestPending),
+		CreatedTime:       ar.GetCreatedTime(),
+		UpdatedTime:       ar.GetUpdatedTime(),
+		Version:           1,
+		AuthorizedActions: testAuthorizedActions,
+	}
+
+	cases := []struct {
+		name string
+		req  *pbs.GetAccessRequestRequest
+		res  *pbs.GetAccessRequestResponse
+		err  error
+	}{
+		{
+			name: "Get an existing access request",
+			req:  &pbs.GetAccessRequestRequest{Id: ar.GetId()},
+			res:  &pbs.GetAccessRequestResponse{Item: want},
+		},
+		{
+			name: "Get a non existing access request",
+			req:  &pbs.GetAccessRequestRequest{Id: iam.AccessRequestPrefix + "_DoesntExis"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Wrong id prefix",
+			req:  &pbs.GetAccessRequestRequest{Id: "j_1234567890"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := env.service.GetAccessRequest(ctx, tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "GetAccessRequest(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform()), "GetAccessRequest(%q) got response %q, wanted %q", tc.req, got, tc.res)
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	env := testService(t)
+
+	cases := []struct {
+		name   string
+		userId string
+		item   *pb.AccessRequest
+		err    error
+	}{
+		{
+			name:   "Create a valid access request",
+			userId: env.user.GetPublicId(),
+			item: &pb.AccessRequest{
+				TargetId:        env.target.GetPublicId(),
+				Justification:   "on call",
+				DurationSeconds: 3600,
+			},
+		},
+		{
+			name:   "Missing justification",
+			userId: env.user.GetPublicId(),
+			item: &pb.AccessRequest{
+				TargetId:        env.target.GetPublicId(),
+				DurationSeconds: 3600,
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:   "Missing duration",
+			userId: env.user.GetPublicId(),
+			item: &pb.AccessRequest{
+				TargetId:      env.target.GetPublicId(),
+				Justification: "on call",
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:   "Bad target id",
+			userId: env.user.GetPublicId(),
+			item: &pb.AccessRequest{
+				TargetId:        "j_1234567890",
+				Justification:   "on call",
+				DurationSeconds: 3600,
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:   "Non existing target",
+			userId: env.user.GetPublicId(),
+			item: &pb.AccessRequest{
+				TargetId:        target.TcpTargetPrefix + "_DoesntExis",
+				Justification:   "on call",
+				DurationSeconds: 3600,
+			},
+			err: handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name:   "Can't specify status",
+			userId: env.user.GetPublicId(),
+			item: &pb.AccessRequest{
+				TargetId:        env.target.GetPublicId(),
+				Justification:   "on call",
+				DurationSeconds: 3600,
+				Status:          string(iam.AccessRequestApproved),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:   "Anonymous user",
+			userId: "u_anon",
+			item: &pb.AccessRequest{
+				TargetId:        env.target.GetPublicId(),
+				Justification:   "on call",
+				DurationSeconds: 3600,
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := auth.DisabledAuthTestContext(auth.WithScopeId(env.proj.GetPublicId()), auth.WithUserId(tc.userId))
+			got, gErr := env.service.CreateAccessRequest(ctx, &pbs.CreateAccessRequestRequest{Item: tc.item})
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "CreateAccessRequest(%+v) got error %v, wanted %v", tc.item, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Contains(got.GetUri(), got.GetItem().GetId())
+			assert.Equal(env.proj.GetPublicId(), got.GetItem().GetScopeId())
+			assert.Equal(tc.userId, got.GetItem().GetUserId())
+			assert.Equal(string(iam.AccessRequestPending), got.GetItem().GetStatus())
+			assert.Equal(uint32(1), got.GetItem().GetVersion())
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	env := testService(t)
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(env.proj.GetPublicId()), auth.WithUserId(env.user.GetPublicId()))
+
+	var wantIds []string
+	for i := 0; i < 3; i++ {
+		created, err := env.service.CreateAccessRequest(ctx, &pbs.CreateAccessRequestRequest{Item: &pb.AccessRequest{
+			TargetId:        env.target.GetPublicId(),
+			Justification:   "on call",
+			DurationSeconds: 3600,
+		}})
+		require.NoError(t, err)
+		wantIds = append(wantIds, created.GetItem().GetId())
+	}
+
+	got, err := env.service.ListAccessRequests(ctx, &pbs.ListAccessRequestsRequest{ScopeId: env.proj.GetPublicId()})
+	require.NoError(t, err)
+	var gotIds []string
+	for _, item := range got.GetItems() {
+		gotIds = append(gotIds, item.GetId())
+		assert.Equal(t, testAuthorizedActions, item.GetAuthorizedActions())
+	}
+	assert.ElementsMatch(t, wantIds, gotIds)
+
+	got, err = env.service.ListAccessRequests(ctx, &pbs.ListAccessRequestsRequest{ScopeId: "global", Recursive: true})
+	require.NoError(t, err)
+	assert.Len(t, got.GetItems(), 3)
+
+	_, err = env.service.ListAccessRequests(ctx, &pbs.ListAccessRequestsRequest{ScopeId: env.proj.GetParentId()})
+	assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v", err)
+}
+
+func TestReview(t *testing.T) {
+	env := testService(t)
+	userCtx := auth.DisabledAuthTestContext(auth.WithScopeId(env.proj.GetPublicId()), auth.WithUserId(env.user.GetPublicId()))
+	reviewerCtx := auth.DisabledAuthTestContext(auth.WithScopeId(env.proj.GetPublicId()), auth.WithUserId(env.reviewer.GetPublicId()))
+
+	create := func(t *testing.T) *pb.AccessRequest {
+		t.Helper()
+		created, err := env.service.CreateAccessRequest(userCtx, &pbs.CreateAccessRequestRequest{Item: &pb.AccessRequest{
+			TargetId:        env.target.GetPublicId(),
+			Justification:   "on call",
+			DurationSeconds: 3600,
+		}})
+		require.NoError(t, err)
+		return created.GetItem()
+	}
+
+	t.Run("approve", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ar := create(t)
+		got, err := env.service.ApproveAccessRequest(reviewerCtx, &pbs.ApproveAccessRequestRequest{
+			Id:      ar.GetId(),
+			Version: ar.GetVersion(),
+			Comment: "ok",
+		})
+		require.NoError(err)
+		assert.Equal(string(iam.AccessRequestApproved), got.GetItem().GetStatus())
+		assert.Equal(env.reviewer.GetPublicId(), got.GetItem().GetReviewerId())
+		assert.Equal("ok", got.GetItem().GetReviewComment())
+		assert.NotNil(got.GetItem().GetReviewTime())
+		assert.NotNil(got.GetItem().GetExpirationTime())
+
+		_, err = env.service.DenyAccessRequest(reviewerCtx, &pbs.DenyAccessRequestRequest{
+			Id:      ar.GetId(),
+			Version: got.GetItem().GetVersion(),
+		})
+		assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v", err)
+	})
+
+	t.Run("deny", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ar := create(t)
+		got, err := env.service.DenyAccessRequest(reviewerCtx, &pbs.DenyAccessRequestRequest{
+			Id:      ar.GetId(),
+			Version: ar.GetVersion(),
+		})
+		require.NoError(err)
+		assert.Equal(string(iam.AccessRequestDenied), got.GetItem().GetStatus())
+		assert.Nil(got.GetItem().GetExpirationTime())
+	})
+
+	t.Run("own request", func(t *testing.T) {
+		ar := create(t)
+		_, err := env.service.ApproveAccessRequest(userCtx, &pbs.ApproveAccessRequestRequest{
+			Id:      ar.GetId(),
+			Version: ar.GetVersion(),
+		})
+		assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v", err)
+	})
+
+	t.Run("missing version", func(t *testing.T) {
+		ar := create(t)
+		_, err := env.service.ApproveAccessRequest(reviewerCtx, &pbs.ApproveAccessRequestRequest{Id: ar.GetId()})
+		assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v", err)
+	})
+
+	t.Run("non existing", func(t *testing.T) {
+		_, err := env.service.ApproveAccessRequest(reviewerCtx, &pbs.ApproveAccessRequestRequest{
+			Id:      iam.AccessRequestPrefix + "_DoesntExis",
+			Version: 1,
+		})
+		assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.NotFound)), "got error %v", err)
+	})
+}
@@ -20,6 +20,17 @@ import (
 
 var (
 	maskManager handlers.MaskManager
+
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.Read,
+		action.Update,
+		action.Delete,
+		action.SetPassword,
+		action.ChangePassword,
+		action.Unlock,
+	}
 )
 
 func init() {
@@ -55,14 +66,37 @@ func (s Service) ListAccounts(ctx context.Context, req *pbs.ListAccountsRequest)
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	ul, err := s.listFromRepo(ctx, req.GetAuthMethodId())
+	fetch := func(afterId string, limit int) ([]handlers.ListItem, error) {
+		ul, err := s.listFromRepo(ctx, req.GetAuthMethodId(), afterId, limit)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]handlers.ListItem, 0, len(ul))
+		for _, a := range ul {
+			if authResults.DeniedForId(ctx, a.GetPublicId(), action.Read) {
+				items = append(items, handlers.HiddenListItem(a.GetPublicId()))
+				continue
+			}
+			item, err := toProto(a,
+				handlers.WithScope(authResults.Scope),
+				handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, a.GetPublicId(), IdActions).Strings()),
+				handlers.WithOutputFields(authResults.FetchOutputFields(ctx, a.GetPublicId())))
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, handlers.ListedItem(a.GetPublicId(), item))
+		}
+		return items, nil
+	}
+	items, next, err := handlers.ListPage(fetch, req.GetFilter(), req.GetPageSize(), req.GetPageToken())
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range ul {
-		item.Scope = authResults.Scope
+	var ul []*pb.Account
+	for _, item := range items {
+		ul = append(ul, item.(*pb.Account))
 	}
-	return &pbs.ListAccountsResponse{Items: ul}, nil
+	return &pbs.ListAccountsResponse{Items: ul, NextPageToken: next}, nil
 }
 
 // GetAccount implements the interface pbs.AccountServiceServer.
@@ -74,11 +108,13 @@ func (s Service) GetAccount(ctx context.Context, req *pbs.GetAccountRequest) (*p
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	u, err := s.getFromRepo(ctx, req.GetId())
+	u, err := s.getFromRepo(ctx, req.GetId(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, req.GetId(), IdActions).Strings()),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	u.Scope = authResults.Scope
 	return &pbs.GetAccountResponse{Item: u}, nil
 }
 
@@ -91,11 +127,12 @@ func (s Service) CreateAccount(ctx context.Context, req *pbs.CreateAccountReques
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	u, err := s.createInRepo(ctx, authMeth.GetPublicId(), authResults.Scope.GetId(), req.GetItem())
+	u, err := s.createInRepo(ctx, authMeth.GetPublicId(), authResults.Scope.GetId(), req.GetItem(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, "")))
 	if err != nil {
 		return nil, err
 	}
-	u.Scope = authResults.Scope
 	return &pbs.CreateAccountResponse{Item: u, Uri: fmt.Sprintf("accounts/%s", u.GetId())}, nil
 }
 
@@ -108,11 +145,12 @@ func (s Service) UpdateAccount(ctx context.Context, req *pbs.UpdateAccountReques
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	u, err := s.updateInRepo(ctx, authResults.Scope.GetId(), authMeth.GetPublicId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	u, err := s.updateInRepo(ctx, authResults.Scope.GetId(), authMeth.GetPublicId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	u.Scope = authResults.Scope
 	return &pbs.UpdateAccountResponse{Item: u}, nil
 }
 
@@ -141,11 +179,12 @@ func (s Service) ChangePassword(ctx context.Context, req *pbs.ChangePasswordRequ
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	u, err := s.changePasswordInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetVersion(), req.GetCurrentPassword(), req.GetNewPassword())
+	u, err := s.changePasswordInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetVersion(), req.GetCurrentPassword(), req.GetNewPassword(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	u.Scope = authResults.Scope
 	return &pbs.ChangePasswordResponse{Item: u}, nil
 }
 
@@ -158,15 +197,34 @@ func (s Service) SetPassword(ctx context.Context, req *pbs.SetPasswordRequest) (
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	u, err := s.setPasswordInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetVersion(), req.GetPassword())
+	u, err := s.setPasswordInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetVersion(), req.GetPassword(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	u.Scope = authResults.Scope
 	return &pbs.SetPasswordResponse{Item: u}, nil
 }
 
-func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Account, error) {
+// UnlockAccount implements the interface pbs.AccountServiceServer.
+func (s Service) UnlockAccount(ctx context.Context, req *pbs.UnlockAccountRequest) (*pbs.UnlockAccountResponse, error) {
+	if err := validateUnlockAccountRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.Unlock)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	u, err := s.unlockInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetVersion(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.UnlockAccountResponse{Item: u}, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string, opt ...handlers.Option) (*pb.Account, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -181,10 +239,10 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Account, error
 	if u == nil {
 		return nil, handlers.NotFoundErrorf("Account %q doesn't exist.", id)
 	}
-	return toProto(u)
+	return toProto(u, opt...)
 }
 
-func (s Service) createInRepo(ctx context.Context, authMethodId, scopeId string, item *pb.Account) (*pb.Account, error) {
+func (s Service) createInRepo(ctx context.Context, authMethodId, scopeId string, item *pb.Account, opt ...handlers.Option) (*pb.Account, error) {
 	pwAttrs := &pb.PasswordAccountAttributes{}
 	if err := handlers.StructToProto(item.GetAttributes(), pwAttrs); err != nil {
 		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
@@ -201,6 +259,7 @@ func (s Service) createInRepo(ctx context.Context, authMethodId, scopeId string,
 	if err != nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build user for creation: %v.", err)
 	}
+	a.MustChangePassword = pwAttrs.GetMustChangePassword()
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -217,10 +276,10 @@ func (s Service) createInRepo(ctx context.Context, authMethodId, scopeId string,
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create user but no error returned from repository.")
 	}
-	return toProto(out)
+	return toProto(out, opt...)
 }
 
-func (s Service) updateInRepo(ctx context.Context, scopeId, authMethId, id string, mask []string, item *pb.Account) (*pb.Account, error) {
+func (s Service) updateInRepo(ctx context.Context, scopeId, authMethId, id string, mask []string, item *pb.Account, opt ...handlers.Option) (*pb.Account, error) {
 	var opts []password.Option
 	if desc := item.GetDescription(); desc != nil {
 		opts = append(opts, password.WithDescription(desc.GetValue()))
@@ -241,6 +300,7 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, authMethId, id strin
 	if pwAttrs.GetLoginName() != "" {
 		u.LoginName = pwAttrs.GetLoginName()
 	}
+	u.MustChangePassword = pwAttrs.GetMustChangePassword()
 	version := item.GetVersion()
 
 	dbMask := maskManager.Translate(mask)
@@ -263,7 +323,7 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, authMethId, id strin
 	if rowsUpdated == 0 {
 		return nil, handlers.NotFoundErrorf("Account %q doesn't exist or incorrect version provided.", id)
 	}
-	return toProto(out)
+	return toProto(out, opt...)
 }
 
 func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool, error) {
@@ -281,27 +341,19 @@ func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool,
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, authMethodId string) ([]*pb.Account, error) {
+func (s Service) listFromRepo(ctx context.Context, authMethodId string, afterId string, limit int) ([]*password.Account, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	ul, err := repo.ListAccounts(ctx, authMethodId)
+	ul, err := repo.ListAccounts(ctx, authMethodId, password.WithStartPageAfterId(afterId), password.WithLimit(limit))
 	if err != nil {
 		return nil, err
 	}
-	var outUl []*pb.Account
-	for _, u := range ul {
-		ou, err := toProto(u)
-		if err != nil {
-			return nil, err
-		}
-		outUl = append(outUl, ou)
-	}
-	return outUl, nil
+	return ul, nil
 }
 
-func (s Service) changePasswordInRepo(ctx context.Context, scopeId, id string, version uint32, currentPassword, newPassword string) (*pb.Account, error) {
+func (s Service) changePasswordInRepo(ctx context.Context, scopeId, id string, version uint32, currentPassword, newPassword string, opt ...handlers.Option) (*pb.Account, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -317,16 +369,24 @@ func (s Service) changePasswordInRepo(ctx context.Context, scopeId, id string, v
 		case errors.Is(err, password.ErrPasswordsEqual):
 			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
 				map[string]string{"new_password": "New password equal to current password."})
+		case errors.Is(err, password.ErrPasswordComplexity):
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+				map[string]string{"new_password": "Password does not meet the complexity requirements of the auth method."})
+		case errors.Is(err, password.ErrPasswordReused):
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+				map[string]string{"new_password": "Password was used recently."})
+		case errors.Is(err, password.ErrAccountLocked):
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.PermissionDenied, "Account is locked.")
 		}
 		return nil, fmt.Errorf("unable to change password: %w", err)
 	}
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.PermissionDenied, "Failed to change password.")
 	}
-	return toProto(out)
+	return toProto(out, opt...)
 }
 
-func (s Service) setPasswordInRepo(ctx context.Context, scopeId, id string, version uint32, pw string) (*pb.Account, error) {
+func (s Service) setPasswordInRepo(ctx context.Context, scopeId, id string, version uint32, pw string, opt ...handlers.Option) (*pb.Account, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -339,10 +399,31 @@ func (s Service) setPasswordInRepo(ctx context.Context, scopeId, id string, vers
 		case errors.Is(err, password.ErrTooShort):
 			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
 				map[string]string{"password": "Password is too short."})
+		case errors.Is(err, password.ErrPasswordComplexity):
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+				map[string]string{"password": "Password does not meet the complexity requirements of the auth method."})
+		case errors.Is(err, password.ErrPasswordReused):
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+				map[string]string{"password": "Password was used recently."})
 		}
 		return nil, fmt.Errorf("unable to set password: %w", err)
 	}
-	return toProto(out)
+	return toProto(out, opt...)
+}
+
+func (s Service) unlockInRepo(ctx context.Context, scopeId, id string, version uint32, opt ...handlers.Option) (*pb.Account, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.UnlockAccount(ctx, scopeId, id, version)
+	if err != nil {
+		if errors.Is(err, errors.ErrRecordNotFound) {
+			return nil, handlers.NotFoundErrorf("Account %q doesn't exist or incorrect version provided.", id)
+		}
+		return nil, fmt.Errorf("unable to unlock account: %w", err)
+	}
+	return toProto(out, opt...)
 }
 
 func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Type) (*password.AuthMethod, auth.VerifyResults) {
@@ -386,34 +467,60 @@ func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Ty
 	return authMeth, auth.Verify(ctx, opts...)
 }
 
-func toProto(in *password.Account) (*pb.Account, error) {
-	out := pb.Account{
-		Id:           in.GetPublicId(),
-		CreatedTime:  in.GetCreateTime().GetTimestamp(),
-		UpdatedTime:  in.GetUpdateTime().GetTimestamp(),
-		AuthMethodId: in.GetAuthMethodId(),
-		Version:      in.GetVersion(),
-		Type:         auth.PasswordSubtype.String(),
+func toProto(in *password.Account, opt ...handlers.Option) (*pb.Account, error) {
+	opts := handlers.GetOpts(opt...)
+	outputFields := opts.WithOutputFields
+	out := pb.Account{}
+	if outputFields.Has("id") {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has("scope") {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has("created_time") {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
 	}
-	if in.GetDescription() != "" {
+	if outputFields.Has("updated_time") {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has("auth_method_id") {
+		out.AuthMethodId = in.GetAuthMethodId()
+	}
+	if outputFields.Has("version") {
+		out.Version = in.GetVersion()
+	}
+	if outputFields.Has("type") {
+		out.Type = auth.PasswordSubtype.String()
+	}
+	if outputFields.Has("description") && in.GetDescription() != "" {
 		out.Description = &wrapperspb.StringValue{Value: in.GetDescription()}
 	}
-	if in.GetName() != "" {
+	if outputFields.Has("name") && in.GetName() != "" {
 		out.Name = &wrapperspb.StringValue{Value: in.GetName()}
 	}
-	if st, err := handlers.ProtoToStruct(&pb.PasswordAccountAttributes{LoginName: in.GetLoginName()}); err == nil {
+	if outputFields.Has("authorized_actions") {
+		out.AuthorizedActions = opts.WithAuthorizedActions
+	}
+	if outputFields.Has("attributes") {
+		attrs := &pb.PasswordAccountAttributes{
+			LoginName:          in.GetLoginName(),
+			MustChangePassword: in.GetMustChangePassword(),
+			LockedTime:         in.GetLockedTime().GetTimestamp(),
+		}
+		st, err := handlers.ProtoToStruct(attrs)
+		if err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "failed building password attribute struct: %v", err)
+		}
 		out.Attributes = st
-	} else {
-		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "failed building password attribute struct: %v", err)
 	}
 	return &out, nil
 }
 
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
-//  * The path passed in is correctly formatted
-//  * All required parameters are set
-//  * There are no conflicting parameters provided
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
 func validateGetRequest(req *pbs.GetAccountRequest) error {
 	return handlers.ValidateGetRequest(password.AccountPrefix, req, handlers.NoopValidatorFn)
 }
@@ -436,6 +543,9 @@ func validateCreateRequest(req *pbs.CreateAccountRequest) error {
 			if pwAttrs.GetLoginName() == "" {
 				badFields["login_name"] = "This is a required field for this type."
 			}
+			if pwAttrs.GetLockedTime() != nil {
+				badFields["attributes.locked_time"] = "This is a read only field."
+			}
 		default:
 			badFields["auth_method_id"] = "Unknown auth method type from ID."
 		}
@@ -454,6 +564,10 @@ func validateUpdateRequest(req *pbs.UpdateAccountRequest) error {
 			pwAttrs := &pb.PasswordAccountAttributes{}
 			if err := handlers.StructToProto(req.GetItem().GetAttributes(), pwAttrs); err != nil {
 				badFields["attributes"] = "Attribute fields do not match the expected format."
+				break
+			}
+			if pwAttrs.GetLockedTime() != nil {
+				badFields["attributes.locked_time"] = "This is a read only field."
 			}
 		}
 		return badFields
@@ -508,3 +622,17 @@ func validateSetPasswordRequest(req *pbs.SetPasswordRequest) error {
 	}
 	return nil
 }
+
+func validateUnlockAccountRequest(req *pbs.UnlockAccountRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(password.AccountPrefix, req.GetId()) {
+		badFields["id"] = "Improperly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Existing resource version is required for an update."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
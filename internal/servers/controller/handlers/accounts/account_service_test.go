@@ -3,6 +3,7 @@ package accounts_test
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"testing"
 
@@ -29,6 +30,8 @@ import (
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
+var testAuthorizedActions = []string{"read", "update", "delete", "set-password", "change-password", "unlock"}
+
 func TestGet(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	rw := db.New(conn)
@@ -46,14 +49,15 @@ func TestGet(t *testing.T) {
 	aa := password.TestAccounts(t, conn, am.GetPublicId(), 1)[0]
 
 	wireAccount := pb.Account{
-		Id:           aa.GetPublicId(),
-		AuthMethodId: aa.GetAuthMethodId(),
-		CreatedTime:  aa.GetCreateTime().GetTimestamp(),
-		UpdatedTime:  aa.GetUpdateTime().GetTimestamp(),
-		Scope:        &scopepb.ScopeInfo{Id: org.GetPublicId(), Type: scope.Org.String()},
-		Version:      1,
-		Type:         "password",
-		Attributes:   &structpb.Struct{Fields: map[string]*structpb.Value{"login_name": structpb.NewStringValue(aa.GetLoginName())}},
+		Id:                aa.GetPublicId(),
+		AuthMethodId:      aa.GetAuthMethodId(),
+		CreatedTime:       aa.GetCreateTime().GetTimestamp(),
+		UpdatedTime:       aa.GetUpdateTime().GetTimestamp(),
+		Scope:             &scopepb.ScopeInfo{Id: org.GetPublicId(), Type: scope.Org.String()},
+		Version:           1,
+		Type:              "password",
+		Attributes:        &structpb.Struct{Fields: map[string]*structpb.Value{"login_name": structpb.NewStringValue(aa.GetLoginName())}},
+		AuthorizedActions: testAuthorizedActions,
 	}
 
 	cases := []struct {
@@ -115,31 +119,37 @@ func TestList(t *testing.T) {
 	var wantSomeAccounts []*pb.Account
 	for _, aa := range password.TestAccounts(t, conn, amSomeAccounts.GetPublicId(), 3) {
 		wantSomeAccounts = append(wantSomeAccounts, &pb.Account{
-			Id:           aa.GetPublicId(),
-			AuthMethodId: aa.GetAuthMethodId(),
-			CreatedTime:  aa.GetCreateTime().GetTimestamp(),
-			UpdatedTime:  aa.GetUpdateTime().GetTimestamp(),
-			Scope:        &scopepb.ScopeInfo{Id: o.GetPublicId(), Type: scope.Org.String()},
-			Version:      1,
-			Type:         "password",
-			Attributes:   &structpb.Struct{Fields: map[string]*structpb.Value{"login_name": structpb.NewStringValue(aa.GetLoginName())}},
+			Id:                aa.GetPublicId(),
+			AuthMethodId:      aa.GetAuthMethodId(),
+			CreatedTime:       aa.GetCreateTime().GetTimestamp(),
+			UpdatedTime:       aa.GetUpdateTime().GetTimestamp(),
+			Scope:             &scopepb.ScopeInfo{Id: o.GetPublicId(), Type: scope.Org.String()},
+			Version:           1,
+			Type:              "password",
+			Attributes:        &structpb.Struct{Fields: map[string]*structpb.Value{"login_name": structpb.NewStringValue(aa.GetLoginName())}},
+			AuthorizedActions: testAuthorizedActions,
 		})
 	}
 
 	var wantOtherAccounts []*pb.Account
 	for _, aa := range password.TestAccounts(t, conn, amOtherAccounts.GetPublicId(), 3) {
 		wantOtherAccounts = append(wantOtherAccounts, &pb.Account{
-			Id:           aa.GetPublicId(),
-			AuthMethodId: aa.GetAuthMethodId(),
-			CreatedTime:  aa.GetCreateTime().GetTimestamp(),
-			UpdatedTime:  aa.GetUpdateTime().GetTimestamp(),
-			Scope:        &scopepb.ScopeInfo{Id: o.GetPublicId(), Type: scope.Org.String()},
-			Version:      1,
-			Type:         "password",
-			Attributes:   &structpb.Struct{Fields: map[string]*structpb.Value{"login_name": structpb.NewStringValue(aa.GetLoginName())}},
+			Id:                aa.GetPublicId(),
+			AuthMethodId:      aa.GetAuthMethodId(),
+			CreatedTime:       aa.GetCreateTime().GetTimestamp(),
+			UpdatedTime:       aa.GetUpdateTime().GetTimestamp(),
+			Scope:             &scopepb.ScopeInfo{Id: o.GetPublicId(), Type: scope.Org.String()},
+			Version:           1,
+			Type:              "password",
+			Attributes:        &structpb.Struct{Fields: map[string]*structpb.Value{"login_name": structpb.NewStringValue(aa.GetLoginName())}},
+			AuthorizedActions: testAuthorizedActions,
 		})
 	}
 
+	// Listed resources are ordered by id
+	sort.Slice(wantSomeAccounts, func(i, j int) bool { return wantSomeAccounts[i].GetId() < wantSomeAccounts[j].GetId() })
+	sort.Slice(wantOtherAccounts, func(i, j int) bool { return wantOtherAccounts[i].GetId() < wantOtherAccounts[j].GetId() })
+
 	cases := []struct {
 		name       string
 		authMethod string
@@ -4,12 +4,18 @@ import (
 	"context"
 	stderrors "errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/auth/ldap"
+	ldapstore "github.com/hashicorp/boundary/internal/auth/ldap/store"
+	"github.com/hashicorp/boundary/internal/auth/oidc"
+	oidcstore "github.com/hashicorp/boundary/internal/auth/oidc/store"
 	"github.com/hashicorp/boundary/internal/auth/password"
 	"github.com/hashicorp/boundary/internal/auth/password/store"
 	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/authmethods"
 	pba "github.com/hashicorp/boundary/internal/gen/controller/api/resources/authtokens"
@@ -29,10 +35,22 @@ import (
 const (
 	loginNameKey = "login_name"
 	pwKey        = "password"
+	newPwKey     = "new_password"
 )
 
 var (
-	maskManager handlers.MaskManager
+	maskManager     handlers.MaskManager
+	oidcMaskManager handlers.MaskManager
+	ldapMaskManager handlers.MaskManager
+
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.Read,
+		action.Update,
+		action.Delete,
+		action.Authenticate,
+	}
 )
 
 func init() {
@@ -40,30 +58,44 @@ func init() {
 	if maskManager, err = handlers.NewMaskManager(&store.AuthMethod{}, &pb.AuthMethod{}, &pb.PasswordAuthMethodAttributes{}); err != nil {
 		panic(err)
 	}
+	if oidcMaskManager, err = handlers.NewMaskManager(&oidcstore.AuthMethod{}, &pb.AuthMethod{}, &pb.OidcAuthMethodAttributes{}); err != nil {
+		panic(err)
+	}
+	if ldapMaskManager, err = handlers.NewMaskManager(&ldapstore.AuthMethod{}, &pb.AuthMethod{}, &pb.LdapAuthMethodAttributes{}); err != nil {
+		panic(err)
+	}
 }
 
 // Service handles request as described by the pbs.AuthMethodServiceServer interface.
 type Service struct {
 	pbs.UnimplementedAuthMethodServiceServer
 
-	kms       *kms.Kms
-	pwRepoFn  common.PasswordAuthRepoFactory
-	iamRepoFn common.IamRepoFactory
-	atRepoFn  common.AuthTokenRepoFactory
+	kms        *kms.Kms
+	pwRepoFn   common.PasswordAuthRepoFactory
+	oidcRepoFn common.OidcAuthRepoFactory
+	ldapRepoFn common.LdapAuthRepoFactory
+	iamRepoFn  common.IamRepoFactory
+	atRepoFn   common.AuthTokenRepoFactory
 }
 
 // NewService returns a auth method service which handles auth method related requests to boundary.
-func NewService(kms *kms.Kms, pwRepoFn common.PasswordAuthRepoFactory, iamRepoFn common.IamRepoFactory, atRepoFn common.AuthTokenRepoFactory) (Service, error) {
+func NewService(kms *kms.Kms, pwRepoFn common.PasswordAuthRepoFactory, oidcRepoFn common.OidcAuthRepoFactory, ldapRepoFn common.LdapAuthRepoFactory, iamRepoFn common.IamRepoFactory, atRepoFn common.AuthTokenRepoFactory) (Service, error) {
 	if kms == nil {
 		return Service{}, stderrors.New("nil kms provided")
 	}
 	if pwRepoFn == nil {
 		return Service{}, fmt.Errorf("nil password repository provided")
 	}
+	if oidcRepoFn == nil {
+		return Service{}, fmt.Errorf("nil oidc repository provided")
+	}
+	if ldapRepoFn == nil {
+		return Service{}, fmt.Errorf("nil ldap repository provided")
+	}
 	if iamRepoFn == nil {
 		return Service{}, fmt.Errorf("nil iam repository provided")
 	}
-	return Service{kms: kms, pwRepoFn: pwRepoFn, iamRepoFn: iamRepoFn, atRepoFn: atRepoFn}, nil
+	return Service{kms: kms, pwRepoFn: pwRepoFn, oidcRepoFn: oidcRepoFn, ldapRepoFn: ldapRepoFn, iamRepoFn: iamRepoFn, atRepoFn: atRepoFn}, nil
 }
 
 var _ pbs.AuthMethodServiceServer = Service{}
@@ -74,17 +106,36 @@ func (s Service) ListAuthMethods(ctx context.Context, req *pbs.ListAuthMethodsRe
 		return nil, err
 	}
 	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
-	if authResults.Error != nil {
-		return nil, authResults.Error
+	scopeIds, scopeInfos, err := authResults.ScopesForList(ctx, resource.AuthMethod, req.GetRecursive())
+	if err != nil {
+		return nil, err
 	}
-	ul, err := s.listFromRepo(ctx, authResults.Scope.GetId())
+	if len(scopeIds) == 0 {
+		return &pbs.ListAuthMethodsResponse{}, nil
+	}
+	fetch := func(afterId string, limit int) ([]handlers.ListItem, error) {
+		itemOpts := func(id, scopeId string) ([]handlers.Option, bool) {
+			resOpt := auth.WithScopeId(scopeId)
+			if authResults.DeniedForId(ctx, id, action.Read, resOpt) {
+				return nil, false
+			}
+			return []handlers.Option{
+				handlers.WithScope(scopeInfos[scopeId]),
+				handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, id, IdActions, resOpt).Strings()),
+				handlers.WithOutputFields(authResults.FetchOutputFields(ctx, id, resOpt)),
+			}, true
+		}
+		return s.listFromRepo(ctx, scopeIds, afterId, limit, itemOpts)
+	}
+	items, next, err := handlers.ListPage(fetch, req.GetFilter(), req.GetPageSize(), req.GetPageToken())
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range ul {
-		item.Scope = authResults.Scope
+	var ul []*pb.AuthMethod
+	for _, item := range items {
+		ul = append(ul, item.(*pb.AuthMethod))
 	}
-	return &pbs.ListAuthMethodsResponse{Items: ul}, nil
+	return &pbs.ListAuthMethodsResponse{Items: ul, NextPageToken: next}, nil
 }
 
 // GetAuthMethod implements the interface pbs.AuthMethodServiceServer.
@@ -96,11 +147,13 @@ func (s Service) GetAuthMethod(ctx context.Context, req *pbs.GetAuthMethodReques
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	u, err := s.getFromRepo(ctx, req.GetId())
+	u, err := s.getFromRepo(ctx, req.GetId(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, req.GetId(), IdActions).Strings()),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	u.Scope = authResults.Scope
 	return &pbs.GetAuthMethodResponse{Item: u}, nil
 }
 
@@ -113,11 +166,12 @@ func (s Service) CreateAuthMethod(ctx context.Context, req *pbs.CreateAuthMethod
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	u, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem())
+	u, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, "")))
 	if err != nil {
 		return nil, err
 	}
-	u.Scope = authResults.Scope
 	return &pbs.CreateAuthMethodResponse{Item: u, Uri: fmt.Sprintf("auth-methods/%s", u.GetId())}, nil
 }
 
@@ -130,11 +184,12 @@ func (s Service) UpdateAuthMethod(ctx context.Context, req *pbs.UpdateAuthMethod
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	u, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	u, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	u.Scope = authResults.Scope
 	return &pbs.UpdateAuthMethodResponse{Item: u}, nil
 }
 
@@ -164,14 +219,100 @@ func (s Service) Authenticate(ctx context.Context, req *pbs.AuthenticateRequest)
 		return nil, authResults.Error
 	}
 	creds := req.GetCredentials().GetFields()
-	tok, err := s.authenticateWithRepo(ctx, authResults.Scope.GetId(), req.GetAuthMethodId(), creds[loginNameKey].GetStringValue(), creds[pwKey].GetStringValue())
+	tok, err := s.authenticateWithRepo(ctx, authResults.Scope.GetId(), req.GetAuthMethodId(), creds[loginNameKey].GetStringValue(), creds[pwKey].GetStringValue(), creds[newPwKey].GetStringValue())
 	if err != nil {
 		return nil, err
 	}
 	return &pbs.AuthenticateResponse{Item: tok, TokenType: req.GetTokenType()}, nil
 }
 
-func (s Service) getFromRepo(ctx context.Context, id string) (*pb.AuthMethod, error) {
+// AuthenticateStart implements the interface pbs.AuthenticationServiceServer.
+func (s Service) AuthenticateStart(ctx context.Context, req *pbs.AuthenticateStartRequest) (*pbs.AuthenticateStartResponse, error) {
+	if err := validateAuthenticateStartRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetAuthMethodId(), action.Authenticate)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	repo, err := s.oidcRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	authUrl, tokenId, err := repo.StartAuth(ctx, req.GetAuthMethodId())
+	if err != nil {
+		return nil, fmt.Errorf("unable to start authentication: %w", err)
+	}
+	return &pbs.AuthenticateStartResponse{AuthUrl: authUrl, TokenId: tokenId}, nil
+}
+
+// AuthenticateCallback implements the interface pbs.AuthenticationServiceServer.
+func (s Service) AuthenticateCallback(ctx context.Context, req *pbs.AuthenticateCallbackRequest) (*pbs.AuthenticateCallbackResponse, error) {
+	if err := validateAuthenticateCallbackRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetAuthMethodId(), action.Authenticate)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	if req.GetError() != "" {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Authentication with the provider failed: %s: %s", req.GetError(), req.GetErrorDescription())
+	}
+	repo, err := s.oidcRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := repo.Callback(ctx, req.GetAuthMethodId(), req.GetState(), req.GetCode()); err != nil {
+		switch {
+		case errors.Is(err, oidc.ErrInvalidState), errors.Is(err, oidc.ErrTokenRequestExpired), errors.Is(err, oidc.ErrInvalidIdToken):
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate: %v.", err)
+		}
+		return nil, fmt.Errorf("unable to complete authentication: %w", err)
+	}
+	return &pbs.AuthenticateCallbackResponse{Message: "Authentication successful. You may close this window and return to the client."}, nil
+}
+
+// AuthenticateToken implements the interface pbs.AuthenticationServiceServer.
+func (s Service) AuthenticateToken(ctx context.Context, req *pbs.AuthenticateTokenRequest) (*pbs.AuthenticateResponse, error) {
+	if err := validateAuthenticateTokenRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetAuthMethodId(), action.Authenticate)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	repo, err := s.oidcRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	acctId, err := repo.RedeemTokenRequest(ctx, req.GetAuthMethodId(), req.GetTokenId())
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrRecordNotFound):
+			return nil, handlers.NotFoundErrorf("Token request %q doesn't exist.", req.GetTokenId())
+		case errors.Is(err, oidc.ErrTokenRequestExpired):
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Token request %q has expired.", req.GetTokenId())
+		}
+		return nil, err
+	}
+	if acctId == "" {
+		// Authentication with the provider has not completed yet.
+		return &pbs.AuthenticateResponse{TokenType: req.GetTokenType()}, nil
+	}
+	tok, err := s.issueAuthToken(ctx, authResults.Scope.GetId(), acctId)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.AuthenticateResponse{Item: tok, TokenType: req.GetTokenType()}, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string, opt ...handlers.Option) (*pb.AuthMethod, error) {
+	switch auth.SubtypeFromId(id) {
+	case auth.OidcSubtype:
+		return s.getOidcFromRepo(ctx, id, opt...)
+	case auth.LdapSubtype:
+		return s.getLdapFromRepo(ctx, id, opt...)
+	}
 	repo, err := s.pwRepoFn()
 	if err != nil {
 		return nil, err
@@ -189,27 +330,88 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*pb.AuthMethod, er
 	return toAuthMethodProto(u)
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeId string) ([]*pb.AuthMethod, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, afterId string, limit int, itemOpts func(id, scopeId string) ([]handlers.Option, bool)) ([]handlers.ListItem, error) {
 	repo, err := s.pwRepoFn()
 	if err != nil {
 		return nil, err
 	}
-	ul, err := repo.ListAuthMethods(ctx, scopeId)
+	ul, err := repo.ListAuthMethods(ctx, scopeIds, password.WithStartPageAfterId(afterId), password.WithLimit(limit))
 	if err != nil {
 		return nil, err
 	}
-	var outUl []*pb.AuthMethod
+	var all []handlers.ListItem
 	for _, u := range ul {
-		ou, err := toAuthMethodProto(u)
+		opts, ok := itemOpts(u.GetPublicId(), u.GetScopeId())
+		if !ok {
+			all = append(all, handlers.HiddenListItem(u.GetPublicId()))
+			continue
+		}
+		ou, err := toAuthMethodProto(u, opts...)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, handlers.ListedItem(u.GetPublicId(), ou))
+	}
+
+	oidcRepo, err := s.oidcRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	oidcUl, err := oidcRepo.ListAuthMethods(ctx, scopeIds, oidc.WithStartPageAfterId(afterId), oidc.WithLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range oidcUl {
+		opts, ok := itemOpts(u.GetPublicId(), u.GetScopeId())
+		if !ok {
+			all = append(all, handlers.HiddenListItem(u.GetPublicId()))
+			continue
+		}
+		ou, err := oidcToProto(u, opts...)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, handlers.ListedItem(u.GetPublicId(), ou))
+	}
+
+	ldapRepo, err := s.ldapRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	ldapUl, err := ldapRepo.ListAuthMethods(ctx, scopeIds, ldap.WithStartPageAfterId(afterId), ldap.WithLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range ldapUl {
+		opts, ok := itemOpts(u.GetPublicId(), u.GetScopeId())
+		if !ok {
+			all = append(all, handlers.HiddenListItem(u.GetPublicId()))
+			continue
+		}
+		ou, err := ldapToProto(u, opts...)
 		if err != nil {
 			return nil, err
 		}
-		outUl = append(outUl, ou)
+		all = append(all, handlers.ListedItem(u.GetPublicId(), ou))
 	}
-	return outUl, nil
+	// The results of the repositories are ordered by id, so the first limit
+	// of them combined are the next resources to list.
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].GetId() < all[j].GetId()
+	})
+	if limit > 0 && len(all) > limit {
+		all = all[:limit]
+	}
+	return all, nil
 }
 
-func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.AuthMethod) (*pb.AuthMethod, error) {
+func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.AuthMethod, opt ...handlers.Option) (*pb.AuthMethod, error) {
+	switch auth.SubtypeFromType(item.GetType()) {
+	case auth.OidcSubtype:
+		return s.createOidcInRepo(ctx, scopeId, item, opt...)
+	case auth.LdapSubtype:
+		return s.createLdapInRepo(ctx, scopeId, item, opt...)
+	}
 	var opts []password.Option
 	if item.GetName() != nil {
 		opts = append(opts, password.WithName(item.GetName().GetValue()))
@@ -221,6 +423,12 @@ func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Auth
 	if err != nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build auth method for creation: %v.", err)
 	}
+	pwAttrs := &pb.PasswordAuthMethodAttributes{}
+	if err := handlers.StructToProto(item.GetAttributes(), pwAttrs); err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes": "Attribute fields do not match the expected format."})
+	}
+	setPasswordAttributes(u, pwAttrs)
 	repo, err := s.pwRepoFn()
 	if err != nil {
 		return nil, err
@@ -235,7 +443,13 @@ func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Auth
 	return toAuthMethodProto(out)
 }
 
-func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.AuthMethod) (*pb.AuthMethod, error) {
+func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.AuthMethod, opt ...handlers.Option) (*pb.AuthMethod, error) {
+	switch auth.SubtypeFromId(id) {
+	case auth.OidcSubtype:
+		return s.updateOidcInRepo(ctx, scopeId, id, mask, item, opt...)
+	case auth.LdapSubtype:
+		return s.updateLdapInRepo(ctx, scopeId, id, mask, item, opt...)
+	}
 	var opts []password.Option
 	if desc := item.GetDescription(); desc != nil {
 		opts = append(opts, password.WithDescription(desc.GetValue()))
@@ -253,12 +467,7 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
 			map[string]string{"attributes": "Attribute fields do not match the expected format."})
 	}
-	if pwAttrs.GetMinLoginNameLength() != 0 {
-		u.MinLoginNameLength = pwAttrs.GetMinLoginNameLength()
-	}
-	if pwAttrs.GetMinPasswordLength() != 0 {
-		u.MinPasswordLength = pwAttrs.GetMinPasswordLength()
-	}
+	setPasswordAttributes(u, pwAttrs)
 	version := item.GetVersion()
 
 	u.PublicId = id
@@ -280,12 +489,51 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	return toAuthMethodProto(out)
 }
 
+// setPasswordAttributes sets the attributes of u from attrs. The minimum
+// lengths and the lockout window keep their defaults if they are not set.
+func setPasswordAttributes(u *password.AuthMethod, attrs *pb.PasswordAuthMethodAttributes) {
+	if attrs.GetMinLoginNameLength() != 0 {
+		u.MinLoginNameLength = attrs.GetMinLoginNameLength()
+	}
+	if attrs.GetMinPasswordLength() != 0 {
+		u.MinPasswordLength = attrs.GetMinPasswordLength()
+	}
+	u.RequireUppercase = attrs.GetRequireUppercase()
+	u.RequireLowercase = attrs.GetRequireLowercase()
+	u.RequireDigit = attrs.GetRequireDigit()
+	u.RequireSymbol = attrs.GetRequireSymbol()
+	u.PasswordHistoryCount = attrs.GetPasswordHistoryCount()
+	u.MaxPasswordAgeSeconds = attrs.GetMaxPasswordAgeSeconds()
+	u.LockoutThreshold = attrs.GetLockoutThreshold()
+	if attrs.GetLockoutWindowSeconds() != 0 {
+		u.LockoutWindowSeconds = attrs.GetLockoutWindowSeconds()
+	}
+	u.LockoutDurationSeconds = attrs.GetLockoutDurationSeconds()
+}
+
 func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool, error) {
-	repo, err := s.pwRepoFn()
-	if err != nil {
-		return false, err
+	var rows int
+	var err error
+	switch auth.SubtypeFromId(id) {
+	case auth.OidcSubtype:
+		repo, iErr := s.oidcRepoFn()
+		if iErr != nil {
+			return false, iErr
+		}
+		rows, err = repo.DeleteAuthMethod(ctx, scopeId, id)
+	case auth.LdapSubtype:
+		repo, iErr := s.ldapRepoFn()
+		if iErr != nil {
+			return false, iErr
+		}
+		rows, err = repo.DeleteAuthMethod(ctx, scopeId, id)
+	default:
+		repo, iErr := s.pwRepoFn()
+		if iErr != nil {
+			return false, iErr
+		}
+		rows, err = repo.DeleteAuthMethod(ctx, scopeId, id)
 	}
-	rows, err := repo.DeleteAuthMethod(ctx, scopeId, id)
 	if err != nil {
 		if errors.Is(err, errors.ErrRecordNotFound) {
 			return false, nil
@@ -295,33 +543,284 @@ func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool,
 	return rows > 0, nil
 }
 
-func (s Service) authenticateWithRepo(ctx context.Context, scopeId, authMethodId, loginName, pw string) (*pba.AuthToken, error) {
-	iamRepo, err := s.iamRepoFn()
+func (s Service) getOidcFromRepo(ctx context.Context, id string, opt ...handlers.Option) (*pb.AuthMethod, error) {
+	repo, err := s.oidcRepoFn()
 	if err != nil {
 		return nil, err
 	}
-	atRepo, err := s.atRepoFn()
+	u, err := repo.LookupAuthMethod(ctx, id)
+	if err != nil {
+		if errors.Is(err, errors.ErrRecordNotFound) {
+			return nil, handlers.NotFoundErrorf("AuthMethod %q doesn't exist.", id)
+		}
+		return nil, err
+	}
+	if u == nil {
+		return nil, handlers.NotFoundErrorf("AuthMethod %q doesn't exist.", id)
+	}
+	return oidcToProto(u, opt...)
+}
+
+func (s Service) createOidcInRepo(ctx context.Context, scopeId string, item *pb.AuthMethod, opt ...handlers.Option) (*pb.AuthMethod, error) {
+	attrs := &pb.OidcAuthMethodAttributes{}
+	if err := handlers.StructToProto(item.GetAttributes(), attrs); err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes": "Attribute fields do not match the expected format."})
+	}
+	var opts []oidc.Option
+	if item.GetName() != nil {
+		opts = append(opts, oidc.WithName(item.GetName().GetValue()))
+	}
+	if item.GetDescription() != nil {
+		opts = append(opts, oidc.WithDescription(item.GetDescription().GetValue()))
+	}
+	if len(attrs.GetAllowedAudiences()) > 0 {
+		opts = append(opts, oidc.WithAllowedAudiences(attrs.GetAllowedAudiences()...))
+	}
+	u, err := oidc.NewAuthMethod(scopeId, attrs.GetIssuer(), attrs.GetClientId(), attrs.GetClientSecret(), attrs.GetCallbackUrl(), opts...)
+	if err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes": fmt.Sprintf("Unable to build auth method for creation: %v.", err)})
+	}
+	repo, err := s.oidcRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.CreateAuthMethod(ctx, u)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create auth method: %w", err)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create auth method but no error returned from repository.")
+	}
+	return oidcToProto(out, opt...)
+}
+
+func (s Service) updateOidcInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.AuthMethod, opt ...handlers.Option) (*pb.AuthMethod, error) {
+	attrs := &pb.OidcAuthMethodAttributes{}
+	if err := handlers.StructToProto(item.GetAttributes(), attrs); err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes": "Attribute fields do not match the expected format."})
+	}
+	u := &oidc.AuthMethod{
+		AuthMethod: &oidcstore.AuthMethod{
+			PublicId:         id,
+			ScopeId:          scopeId,
+			Name:             item.GetName().GetValue(),
+			Description:      item.GetDescription().GetValue(),
+			Issuer:           attrs.GetIssuer(),
+			ClientId:         attrs.GetClientId(),
+			ClientSecret:     attrs.GetClientSecret(),
+			CallbackUrl:      attrs.GetCallbackUrl(),
+			AllowedAudiences: attrs.GetAllowedAudiences(),
+		},
+	}
+	version := item.GetVersion()
+
+	dbMask := oidcMaskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+	repo, err := s.oidcRepoFn()
 	if err != nil {
 		return nil, err
 	}
-	pwRepo, err := s.pwRepoFn()
+	out, rowsUpdated, err := repo.UpdateAuthMethod(ctx, u, version, dbMask)
+	if err != nil {
+		if errors.Is(err, errors.ErrInvalidParameter) {
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+				map[string]string{"attributes": fmt.Sprintf("Unable to update auth method: %v.", err)})
+		}
+		return nil, fmt.Errorf("unable to update auth method: %w", err)
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("AuthMethod %q doesn't exist or incorrect version provided.", id)
+	}
+	return oidcToProto(out, opt...)
+}
+
+func (s Service) getLdapFromRepo(ctx context.Context, id string, opt ...handlers.Option) (*pb.AuthMethod, error) {
+	repo, err := s.ldapRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	u, err := repo.LookupAuthMethod(ctx, id)
+	if err != nil {
+		if errors.Is(err, errors.ErrRecordNotFound) {
+			return nil, handlers.NotFoundErrorf("AuthMethod %q doesn't exist.", id)
+		}
+		return nil, err
+	}
+	if u == nil {
+		return nil, handlers.NotFoundErrorf("AuthMethod %q doesn't exist.", id)
+	}
+	return ldapToProto(u, opt...)
+}
+
+func (s Service) createLdapInRepo(ctx context.Context, scopeId string, item *pb.AuthMethod, opt ...handlers.Option) (*pb.AuthMethod, error) {
+	attrs := &pb.LdapAuthMethodAttributes{}
+	if err := handlers.StructToProto(item.GetAttributes(), attrs); err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes": "Attribute fields do not match the expected format."})
+	}
+	opts := []ldap.Option{
+		ldap.WithStartTls(attrs.GetStartTls()),
+		ldap.WithInsecureTls(attrs.GetInsecureTls()),
+		ldap.WithCertificate(attrs.GetCertificate()),
+		ldap.WithBindCredential(attrs.GetBindDn(), attrs.GetBindPassword()),
+		ldap.WithUserSearchFilter(attrs.GetUserSearchFilter()),
+		ldap.WithGroupSearch(attrs.GetGroupSearchBase(), attrs.GetGroupSearchFilter(), attrs.GetGroupAttr()),
+	}
+	if item.GetName() != nil {
+		opts = append(opts, ldap.WithName(item.GetName().GetValue()))
+	}
+	if item.GetDescription() != nil {
+		opts = append(opts, ldap.WithDescription(item.GetDescription().GetValue()))
+	}
+	u, err := ldap.NewAuthMethod(scopeId, attrs.GetUrl(), attrs.GetUserSearchBase(), opts...)
+	if err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes": fmt.Sprintf("Unable to build auth method for creation: %v.", err)})
+	}
+	repo, err := s.ldapRepoFn()
 	if err != nil {
 		return nil, err
 	}
+	out, err := repo.CreateAuthMethod(ctx, u)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create auth method: %w", err)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create auth method but no error returned from repository.")
+	}
+	return ldapToProto(out, opt...)
+}
 
-	acct, err := pwRepo.Authenticate(ctx, scopeId, authMethodId, loginName, pw)
+func (s Service) updateLdapInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.AuthMethod, opt ...handlers.Option) (*pb.AuthMethod, error) {
+	attrs := &pb.LdapAuthMethodAttributes{}
+	if err := handlers.StructToProto(item.GetAttributes(), attrs); err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes": "Attribute fields do not match the expected format."})
+	}
+	u := &ldap.AuthMethod{
+		AuthMethod: &ldapstore.AuthMethod{
+			PublicId:          id,
+			ScopeId:           scopeId,
+			Name:              item.GetName().GetValue(),
+			Description:       item.GetDescription().GetValue(),
+			Url:               attrs.GetUrl(),
+			StartTls:          attrs.GetStartTls(),
+			InsecureTls:       attrs.GetInsecureTls(),
+			Certificate:       attrs.GetCertificate(),
+			BindDn:            attrs.GetBindDn(),
+			BindPassword:      attrs.GetBindPassword(),
+			UserSearchBase:    attrs.GetUserSearchBase(),
+			UserSearchFilter:  attrs.GetUserSearchFilter(),
+			GroupSearchBase:   attrs.GetGroupSearchBase(),
+			GroupSearchFilter: attrs.GetGroupSearchFilter(),
+			GroupAttr:         attrs.GetGroupAttr(),
+		},
+	}
+	version := item.GetVersion()
+
+	dbMask := ldapMaskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+	repo, err := s.ldapRepoFn()
 	if err != nil {
 		return nil, err
 	}
-	if acct == nil {
+	out, rowsUpdated, err := repo.UpdateAuthMethod(ctx, u, version, dbMask)
+	if err != nil {
+		if errors.Is(err, errors.ErrInvalidParameter) {
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+				map[string]string{"attributes": fmt.Sprintf("Unable to update auth method: %v.", err)})
+		}
+		return nil, fmt.Errorf("unable to update auth method: %w", err)
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("AuthMethod %q doesn't exist or incorrect version provided.", id)
+	}
+	return ldapToProto(out, opt...)
+}
+
+func (s Service) authenticateWithRepo(ctx context.Context, scopeId, authMethodId, loginName, pw, newPw string) (*pba.AuthToken, error) {
+	var acctId string
+	switch auth.SubtypeFromId(authMethodId) {
+	case auth.LdapSubtype:
+		ldapRepo, err := s.ldapRepoFn()
+		if err != nil {
+			return nil, err
+		}
+		acct, err := ldapRepo.Authenticate(ctx, scopeId, authMethodId, loginName, pw)
+		if err != nil {
+			return nil, err
+		}
+		if acct != nil {
+			acctId = acct.GetPublicId()
+		}
+	default:
+		pwRepo, err := s.pwRepoFn()
+		if err != nil {
+			return nil, err
+		}
+		acct, err := pwRepo.Authenticate(ctx, scopeId, authMethodId, loginName, pw, password.WithNewPassword(newPw))
+		if err != nil {
+			switch {
+			case errors.Is(err, password.ErrAccountLocked):
+				return nil, handlers.ApiErrorWithCodeAndMessage(codes.PermissionDenied, "Account is locked.")
+			case errors.Is(err, password.ErrPasswordChangeRequired):
+				return nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "Password must be changed; provide a new password in credentials.new_password.")
+			case errors.Is(err, password.ErrTooShort), errors.Is(err, password.ErrPasswordComplexity),
+				errors.Is(err, password.ErrPasswordReused), errors.Is(err, password.ErrPasswordsEqual):
+				return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+					map[string]string{"credentials.new_password": newPasswordError(err)})
+			}
+			return nil, err
+		}
+		if acct != nil {
+			acctId = acct.GetPublicId()
+		}
+	}
+	if acctId == "" {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate.")
 	}
+	return s.issueAuthToken(ctx, scopeId, acctId)
+}
+
+// newPasswordError returns the description of a new password rejected by
+// the password policy of an auth method.
+func newPasswordError(err error) string {
+	switch {
+	case errors.Is(err, password.ErrTooShort):
+		return "Password is too short."
+	case errors.Is(err, password.ErrPasswordsEqual):
+		return "New password equal to current password."
+	case errors.Is(err, password.ErrPasswordReused):
+		return "Password was used recently."
+	}
+	return "Password does not meet the complexity requirements of the auth method."
+}
+
+// issueAuthToken creates an auth token for the account, creating the user
+// for the account if it does not exist, and returns it encrypted for
+// return to the client.
+func (s Service) issueAuthToken(ctx context.Context, scopeId, acctId string) (*pba.AuthToken, error) {
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	atRepo, err := s.atRepoFn()
+	if err != nil {
+		return nil, err
+	}
 
-	u, err := iamRepo.LookupUserWithLogin(ctx, acct.GetPublicId(), iam.WithAutoVivify(true))
+	u, err := iamRepo.LookupUserWithLogin(ctx, acctId, iam.WithAutoVivify(true))
 	if err != nil {
 		return nil, err
 	}
-	tok, err := atRepo.CreateAuthToken(ctx, u, acct.GetPublicId())
+	tok, err := atRepo.CreateAuthToken(ctx, u, acctId)
 	if err != nil {
 		return nil, err
 	}
@@ -373,51 +872,176 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 			return res
 		}
 	default:
-		repo, err := s.pwRepoFn()
+		var err error
+		parentId, err = s.lookupScopeId(ctx, id)
 		if err != nil {
 			res.Error = err
 			return res
 		}
-		authMeth, err := repo.LookupAuthMethod(ctx, id)
-		if err != nil {
-			res.Error = err
-			return res
-		}
-		if authMeth == nil {
+		if parentId == "" {
 			res.Error = handlers.NotFoundError()
 			return res
 		}
-		parentId = authMeth.GetScopeId()
 		opts = append(opts, auth.WithId(id))
 	}
 	opts = append(opts, auth.WithScopeId(parentId))
 	return auth.Verify(ctx, opts...)
 }
 
-func toAuthMethodProto(in *password.AuthMethod) (*pb.AuthMethod, error) {
-	out := pb.AuthMethod{
-		Id:          in.GetPublicId(),
-		ScopeId:     in.GetScopeId(),
-		CreatedTime: in.GetCreateTime().GetTimestamp(),
-		UpdatedTime: in.GetUpdateTime().GetTimestamp(),
-		Version:     in.GetVersion(),
-		Type:        auth.PasswordSubtype.String(),
-	}
-	if in.GetDescription() != "" {
-		out.Description = wrapperspb.String(in.GetDescription())
+// lookupScopeId returns the scope id of the auth method or an empty string
+// if the auth method does not exist.
+func (s Service) lookupScopeId(ctx context.Context, id string) (string, error) {
+	switch auth.SubtypeFromId(id) {
+	case auth.OidcSubtype:
+		repo, err := s.oidcRepoFn()
+		if err != nil {
+			return "", err
+		}
+		authMeth, err := repo.LookupAuthMethod(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		return authMeth.GetScopeId(), nil
+	case auth.LdapSubtype:
+		repo, err := s.ldapRepoFn()
+		if err != nil {
+			return "", err
+		}
+		authMeth, err := repo.LookupAuthMethod(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		return authMeth.GetScopeId(), nil
+	default:
+		repo, err := s.pwRepoFn()
+		if err != nil {
+			return "", err
+		}
+		authMeth, err := repo.LookupAuthMethod(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		return authMeth.GetScopeId(), nil
 	}
-	if in.GetName() != "" {
-		out.Name = wrapperspb.String(in.GetName())
+}
+
+func toAuthMethodProto(in *password.AuthMethod, opt ...handlers.Option) (*pb.AuthMethod, error) {
+	out := commonToProto(in, auth.PasswordSubtype, opt...)
+	if !handlers.GetOpts(opt...).WithOutputFields.Has("attributes") {
+		return out, nil
 	}
 	st, err := handlers.ProtoToStruct(&pb.PasswordAuthMethodAttributes{
-		MinLoginNameLength: in.GetMinLoginNameLength(),
-		MinPasswordLength:  in.GetMinPasswordLength(),
+		MinLoginNameLength:     in.GetMinLoginNameLength(),
+		MinPasswordLength:      in.GetMinPasswordLength(),
+		RequireUppercase:       in.GetRequireUppercase(),
+		RequireLowercase:       in.GetRequireLowercase(),
+		RequireDigit:           in.GetRequireDigit(),
+		RequireSymbol:          in.GetRequireSymbol(),
+		PasswordHistoryCount:   in.GetPasswordHistoryCount(),
+		MaxPasswordAgeSeconds:  in.GetMaxPasswordAgeSeconds(),
+		LockoutThreshold:       in.GetLockoutThreshold(),
+		LockoutWindowSeconds:   in.GetLockoutWindowSeconds(),
+		LockoutDurationSeconds: in.GetLockoutDurationSeconds(),
 	})
 	if err != nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "failed building password attribute struct: %v", err)
 	}
 	out.Attributes = st
-	return &out, nil
+	return out, nil
+}
+
+func oidcToProto(in *oidc.AuthMethod, opt ...handlers.Option) (*pb.AuthMethod, error) {
+	out := commonToProto(in, auth.OidcSubtype, opt...)
+	if !handlers.GetOpts(opt...).WithOutputFields.Has("attributes") {
+		return out, nil
+	}
+	// The client secret is never returned; the hmac allows a client to
+	// verify which secret is configured.
+	st, err := handlers.ProtoToStruct(&pb.OidcAuthMethodAttributes{
+		Issuer:           in.GetIssuer(),
+		ClientId:         in.GetClientId(),
+		ClientSecretHmac: in.GetClientSecretHmac(),
+		CallbackUrl:      in.GetCallbackUrl(),
+		AllowedAudiences: in.GetAllowedAudiences(),
+	})
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "failed building oidc attribute struct: %v", err)
+	}
+	out.Attributes = st
+	return out, nil
+}
+
+func ldapToProto(in *ldap.AuthMethod, opt ...handlers.Option) (*pb.AuthMethod, error) {
+	out := commonToProto(in, auth.LdapSubtype, opt...)
+	if !handlers.GetOpts(opt...).WithOutputFields.Has("attributes") {
+		return out, nil
+	}
+	// The bind password is never returned; the hmac allows a client to
+	// verify which password is configured.
+	st, err := handlers.ProtoToStruct(&pb.LdapAuthMethodAttributes{
+		Url:               in.GetUrl(),
+		StartTls:          in.GetStartTls(),
+		InsecureTls:       in.GetInsecureTls(),
+		Certificate:       in.GetCertificate(),
+		BindDn:            in.GetBindDn(),
+		BindPasswordHmac:  in.GetBindPasswordHmac(),
+		UserSearchBase:    in.GetUserSearchBase(),
+		UserSearchFilter:  in.GetUserSearchFilter(),
+		GroupSearchBase:   in.GetGroupSearchBase(),
+		GroupSearchFilter: in.GetGroupSearchFilter(),
+		GroupAttr:         in.GetGroupAttr(),
+	})
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "failed building ldap attribute struct: %v", err)
+	}
+	out.Attributes = st
+	return out, nil
+}
+
+// commonToProto returns the fields of an auth method shared by its subtypes.
+func commonToProto(in interface {
+	GetPublicId() string
+	GetScopeId() string
+	GetName() string
+	GetDescription() string
+	GetVersion() uint32
+	GetCreateTime() *timestamp.Timestamp
+	GetUpdateTime() *timestamp.Timestamp
+}, subtype auth.SubType, opt ...handlers.Option) *pb.AuthMethod {
+	opts := handlers.GetOpts(opt...)
+	outputFields := opts.WithOutputFields
+	out := pb.AuthMethod{}
+	if outputFields.Has("id") {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has("scope_id") {
+		out.ScopeId = in.GetScopeId()
+	}
+	if outputFields.Has("scope") {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has("created_time") {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
+	}
+	if outputFields.Has("updated_time") {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has("version") {
+		out.Version = in.GetVersion()
+	}
+	if outputFields.Has("type") {
+		out.Type = subtype.String()
+	}
+	if outputFields.Has("description") && in.GetDescription() != "" {
+		out.Description = wrapperspb.String(in.GetDescription())
+	}
+	if outputFields.Has("name") && in.GetName() != "" {
+		out.Name = wrapperspb.String(in.GetName())
+	}
+	if outputFields.Has("authorized_actions") {
+		out.AuthorizedActions = opts.WithAuthorizedActions
+	}
+	return &out
 }
 
 func toAuthTokenProto(t *authtoken.AuthToken) *pba.AuthToken {
@@ -436,11 +1060,11 @@ func toAuthTokenProto(t *authtoken.AuthToken) *pba.AuthToken {
 
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
-//  * The path passed in is correctly formatted
-//  * All required parameters are set
-//  * There are no conflicting parameters provided
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
 func validateGetRequest(req *pbs.GetAuthMethodRequest) error {
-	return handlers.ValidateGetRequest(password.AuthMethodPrefix, req, handlers.NoopValidatorFn)
+	return handlers.ValidateGetRequest(authMethodPrefix(req.GetId()), req, handlers.NoopValidatorFn)
 }
 
 func validateCreateRequest(req *pbs.CreateAuthMethodRequest) error {
@@ -456,15 +1080,54 @@ func validateCreateRequest(req *pbs.CreateAuthMethodRequest) error {
 			if err := handlers.StructToProto(req.GetItem().GetAttributes(), pwAttrs); err != nil {
 				badFields["attributes"] = "Attribute fields do not match the expected format."
 			}
+		case auth.OidcSubtype:
+			oidcAttrs := &pb.OidcAuthMethodAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), oidcAttrs); err != nil {
+				badFields["attributes"] = "Attribute fields do not match the expected format."
+				break
+			}
+			if oidcAttrs.GetIssuer() == "" {
+				badFields["attributes.issuer"] = "This is a required field."
+			}
+			if oidcAttrs.GetClientId() == "" {
+				badFields["attributes.client_id"] = "This is a required field."
+			}
+			if oidcAttrs.GetClientSecret() == "" {
+				badFields["attributes.client_secret"] = "This is a required field."
+			}
+			if oidcAttrs.GetCallbackUrl() == "" {
+				badFields["attributes.callback_url"] = "This is a required field."
+			}
+			if oidcAttrs.GetClientSecretHmac() != "" {
+				badFields["attributes.client_secret_hmac"] = "This is a read only field."
+			}
+		case auth.LdapSubtype:
+			ldapAttrs := &pb.LdapAuthMethodAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), ldapAttrs); err != nil {
+				badFields["attributes"] = "Attribute fields do not match the expected format."
+				break
+			}
+			if ldapAttrs.GetUrl() == "" {
+				badFields["attributes.url"] = "This is a required field."
+			}
+			if ldapAttrs.GetUserSearchBase() == "" {
+				badFields["attributes.user_search_base"] = "This is a required field."
+			}
+			if ldapAttrs.GetBindPassword() != "" && ldapAttrs.GetBindDn() == "" {
+				badFields["attributes.bind_dn"] = "This field is required when a bind password is provided."
+			}
+			if ldapAttrs.GetBindPasswordHmac() != "" {
+				badFields["attributes.bind_password_hmac"] = "This is a read only field."
+			}
 		default:
-			badFields["type"] = fmt.Sprintf("This is a required field and must be %q.", auth.PasswordSubtype.String())
+			badFields["type"] = fmt.Sprintf("This is a required field and must be %q, %q, or %q.", auth.PasswordSubtype.String(), auth.OidcSubtype.String(), auth.LdapSubtype.String())
 		}
 		return badFields
 	})
 }
 
 func validateUpdateRequest(req *pbs.UpdateAuthMethodRequest) error {
-	return handlers.ValidateUpdateRequest(password.AuthMethodPrefix, req, req.GetItem(), func() map[string]string {
+	return handlers.ValidateUpdateRequest(authMethodPrefix(req.GetId()), req, req.GetItem(), func() map[string]string {
 		badFields := map[string]string{}
 		switch auth.SubtypeFromId(req.GetId()) {
 		case auth.PasswordSubtype:
@@ -475,6 +1138,30 @@ func validateUpdateRequest(req *pbs.UpdateAuthMethodRequest) error {
 			if err := handlers.StructToProto(req.GetItem().GetAttributes(), pwAttrs); err != nil {
 				badFields["attributes"] = "Attribute fields do not match the expected format."
 			}
+		case auth.OidcSubtype:
+			if req.GetItem().GetType() != "" && auth.SubtypeFromType(req.GetItem().GetType()) != auth.OidcSubtype {
+				badFields["type"] = "Cannot modify the resource type."
+			}
+			oidcAttrs := &pb.OidcAuthMethodAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), oidcAttrs); err != nil {
+				badFields["attributes"] = "Attribute fields do not match the expected format."
+				break
+			}
+			if oidcAttrs.GetClientSecretHmac() != "" {
+				badFields["attributes.client_secret_hmac"] = "This is a read only field."
+			}
+		case auth.LdapSubtype:
+			if req.GetItem().GetType() != "" && auth.SubtypeFromType(req.GetItem().GetType()) != auth.LdapSubtype {
+				badFields["type"] = "Cannot modify the resource type."
+			}
+			ldapAttrs := &pb.LdapAuthMethodAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), ldapAttrs); err != nil {
+				badFields["attributes"] = "Attribute fields do not match the expected format."
+				break
+			}
+			if ldapAttrs.GetBindPasswordHmac() != "" {
+				badFields["attributes.bind_password_hmac"] = "This is a read only field."
+			}
 		default:
 			badFields["id"] = "Incorrectly formatted identifier."
 		}
@@ -483,7 +1170,7 @@ func validateUpdateRequest(req *pbs.UpdateAuthMethodRequest) error {
 }
 
 func validateDeleteRequest(req *pbs.DeleteAuthMethodRequest) error {
-	return handlers.ValidateDeleteRequest(password.AuthMethodPrefix, req, handlers.NoopValidatorFn)
+	return handlers.ValidateDeleteRequest(authMethodPrefix(req.GetId()), req, handlers.NoopValidatorFn)
 }
 
 func validateListRequest(req *pbs.ListAuthMethodsRequest) error {
@@ -502,10 +1189,14 @@ func validateAuthenticateRequest(req *pbs.AuthenticateRequest) error {
 	badFields := make(map[string]string)
 	if strings.TrimSpace(req.GetAuthMethodId()) == "" {
 		badFields["auth_method_id"] = "This is a required field."
-	} else if !handlers.ValidId(password.AuthMethodPrefix, req.GetAuthMethodId()) {
-		badFields["auth_method_id"] = "Invalid formatted identifier."
+	} else if !handlers.ValidId(password.AuthMethodPrefix, req.GetAuthMethodId()) &&
+		!handlers.ValidId(ldap.AuthMethodPrefix, req.GetAuthMethodId()) {
+		if handlers.ValidId(oidc.AuthMethodPrefix, req.GetAuthMethodId()) {
+			badFields["auth_method_id"] = "OIDC auth methods must use the authenticate-start and authenticate-token endpoints."
+		} else {
+			badFields["auth_method_id"] = "Invalid formatted identifier."
+		}
 	}
-	// TODO: Update this when we enable different auth method types.
 	if req.GetCredentials() == nil {
 		badFields["credentials"] = "This is a required field."
 	}
@@ -525,3 +1216,64 @@ func validateAuthenticateRequest(req *pbs.AuthenticateRequest) error {
 	}
 	return nil
 }
+
+func validateOidcAuthMethodId(badFields map[string]string, id string) {
+	switch {
+	case strings.TrimSpace(id) == "":
+		badFields["auth_method_id"] = "This is a required field."
+	case !handlers.ValidId(oidc.AuthMethodPrefix, id):
+		badFields["auth_method_id"] = "Invalid formatted identifier."
+	}
+}
+
+func validateAuthenticateStartRequest(req *pbs.AuthenticateStartRequest) error {
+	badFields := make(map[string]string)
+	validateOidcAuthMethodId(badFields, req.GetAuthMethodId())
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Invalid fields provided in request.", badFields)
+	}
+	return nil
+}
+
+func validateAuthenticateCallbackRequest(req *pbs.AuthenticateCallbackRequest) error {
+	badFields := make(map[string]string)
+	validateOidcAuthMethodId(badFields, req.GetAuthMethodId())
+	if req.GetState() == "" {
+		badFields["state"] = "This is a required field."
+	}
+	if req.GetError() == "" && req.GetCode() == "" {
+		badFields["code"] = "This is a required field."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Invalid fields provided in request.", badFields)
+	}
+	return nil
+}
+
+func validateAuthenticateTokenRequest(req *pbs.AuthenticateTokenRequest) error {
+	badFields := make(map[string]string)
+	validateOidcAuthMethodId(badFields, req.GetAuthMethodId())
+	if strings.TrimSpace(req.GetTokenId()) == "" {
+		badFields["token_id"] = "This is a required field."
+	} else if !handlers.ValidId(oidc.TokenRequestPrefix, req.GetTokenId()) {
+		badFields["token_id"] = "Invalid formatted identifier."
+	}
+	tType := strings.ToLower(strings.TrimSpace(req.GetTokenType()))
+	if tType != "" && tType != "token" && tType != "cookie" {
+		badFields["token_type"] = `The only accepted types are "token" and "cookie".`
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Invalid fields provided in request.", badFields)
+	}
+	return nil
+}
+
+func authMethodPrefix(id string) string {
+	switch auth.SubtypeFromId(id) {
+	case auth.OidcSubtype:
+		return oidc.AuthMethodPrefix
+	case auth.LdapSubtype:
+		return ldap.AuthMethodPrefix
+	}
+	return password.AuthMethodPrefix
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"testing"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/auth/ldap"
+	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
 	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/db"
@@ -18,6 +21,7 @@ import (
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	ldapclient "github.com/hashicorp/boundary/internal/libs/ldap"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/authmethods"
 	"github.com/hashicorp/boundary/internal/types/scope"
@@ -31,6 +35,8 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+var testAuthorizedActions = []string{"read", "update", "delete", "authenticate"}
+
 const (
 	testPassword  = "thetestpassword"
 	testLoginName = "default"
@@ -47,6 +53,12 @@ func TestGet(t *testing.T) {
 	pwRepoFn := func() (*password.Repository, error) {
 		return password.NewRepository(rw, rw, kms)
 	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kms)
+	}
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kms)
 	}
@@ -62,14 +74,16 @@ func TestGet(t *testing.T) {
 		UpdatedTime: am.UpdateTime.GetTimestamp(),
 		Type:        "password",
 		Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
-			"min_password_length":   structpb.NewNumberValue(8),
-			"min_login_name_length": structpb.NewNumberValue(3),
+			"min_password_length":    structpb.NewNumberValue(8),
+			"min_login_name_length":  structpb.NewNumberValue(3),
+			"lockout_window_seconds": structpb.NewNumberValue(900),
 		}},
 		Version: 1,
 		Scope: &scopepb.ScopeInfo{
 			Id:   o.GetPublicId(),
 			Type: o.GetType(),
 		},
+		AuthorizedActions: testAuthorizedActions,
 	}
 
 	cases := []struct {
@@ -111,7 +125,7 @@ func TestGet(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
 
-			s, err := authmethods.NewService(kms, pwRepoFn, iamRepoFn, atRepoFn)
+			s, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, ldapRepoFn, iamRepoFn, atRepoFn)
 			require.NoError(err, "Couldn't create new auth_method service.")
 
 			got, gErr := s.GetAuthMethod(auth.DisabledAuthTestContext(auth.WithScopeId(tc.scopeId)), tc.req)
@@ -135,6 +149,12 @@ func TestList(t *testing.T) {
 	pwRepoFn := func() (*password.Repository, error) {
 		return password.NewRepository(rw, rw, kms)
 	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kms)
+	}
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kms)
 	}
@@ -155,9 +175,11 @@ func TestList(t *testing.T) {
 			Version:     1,
 			Type:        "password",
 			Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
-				"min_password_length":   structpb.NewNumberValue(8),
-				"min_login_name_length": structpb.NewNumberValue(3),
+				"min_password_length":    structpb.NewNumberValue(8),
+				"min_login_name_length":  structpb.NewNumberValue(3),
+				"lockout_window_seconds": structpb.NewNumberValue(900),
 			}},
+			AuthorizedActions: testAuthorizedActions,
 		})
 	}
 
@@ -172,12 +194,18 @@ func TestList(t *testing.T) {
 			Version:     1,
 			Type:        "password",
 			Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
-				"min_password_length":   structpb.NewNumberValue(8),
-				"min_login_name_length": structpb.NewNumberValue(3),
+				"min_password_length":    structpb.NewNumberValue(8),
+				"min_login_name_length":  structpb.NewNumberValue(3),
+				"lockout_window_seconds": structpb.NewNumberValue(900),
 			}},
+			AuthorizedActions: testAuthorizedActions,
 		})
 	}
 
+	// Listed resources are ordered by id
+	sort.Slice(wantSomeAuthMethods, func(i, j int) bool { return wantSomeAuthMethods[i].GetId() < wantSomeAuthMethods[j].GetId() })
+	sort.Slice(wantOtherAuthMethods, func(i, j int) bool { return wantOtherAuthMethods[i].GetId() < wantOtherAuthMethods[j].GetId() })
+
 	cases := []struct {
 		name    string
 		scopeId string
@@ -208,7 +236,7 @@ func TestList(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := authmethods.NewService(kms, pwRepoFn, iamRepoFn, atRepoFn)
+			s, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, ldapRepoFn, iamRepoFn, atRepoFn)
 			require.NoError(err, "Couldn't create new auth_method service.")
 
 			got, gErr := s.ListAuthMethods(auth.DisabledAuthTestContext(auth.WithScopeId(tc.scopeId)), &pbs.ListAuthMethodsRequest{ScopeId: tc.scopeId})
@@ -232,6 +260,12 @@ func TestDelete(t *testing.T) {
 	pwRepoFn := func() (*password.Repository, error) {
 		return password.NewRepository(rw, rw, kms)
 	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kms)
+	}
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kms)
 	}
@@ -240,7 +274,7 @@ func TestDelete(t *testing.T) {
 	o, _ := iam.TestScopes(t, iamRepo)
 	am := password.TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
 
-	s, err := authmethods.NewService(kms, pwRepoFn, iamRepoFn, atRepoFn)
+	s, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, ldapRepoFn, iamRepoFn, atRepoFn)
 	require.NoError(t, err, "Error when getting new auth_method service.")
 
 	cases := []struct {
@@ -296,6 +330,12 @@ func TestDelete_twice(t *testing.T) {
 	pwRepoFn := func() (*password.Repository, error) {
 		return password.NewRepository(rw, rw, kms)
 	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kms)
+	}
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kms)
 	}
@@ -304,7 +344,7 @@ func TestDelete_twice(t *testing.T) {
 	o, _ := iam.TestScopes(t, iamRepo)
 	am := password.TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
 
-	s, err := authmethods.NewService(kms, pwRepoFn, iamRepoFn, atRepoFn)
+	s, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, ldapRepoFn, iamRepoFn, atRepoFn)
 	require.NoError(err, "Error when getting new auth_method service.")
 
 	req := &pbs.DeleteAuthMethodRequest{
@@ -328,6 +368,12 @@ func TestCreate(t *testing.T) {
 	pwRepoFn := func() (*password.Repository, error) {
 		return password.NewRepository(rw, rw, kms)
 	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kms)
+	}
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kms)
 	}
@@ -365,8 +411,9 @@ func TestCreate(t *testing.T) {
 					Version:     1,
 					Type:        "password",
 					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
-						"min_password_length":   structpb.NewNumberValue(8),
-						"min_login_name_length": structpb.NewNumberValue(3),
+						"min_password_length":    structpb.NewNumberValue(8),
+						"min_login_name_length":  structpb.NewNumberValue(3),
+						"lockout_window_seconds": structpb.NewNumberValue(900),
 					}},
 				},
 			},
@@ -392,8 +439,9 @@ func TestCreate(t *testing.T) {
 					Version:     1,
 					Type:        "password",
 					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
-						"min_password_length":   structpb.NewNumberValue(8),
-						"min_login_name_length": structpb.NewNumberValue(3),
+						"min_password_length":    structpb.NewNumberValue(8),
+						"min_login_name_length":  structpb.NewNumberValue(3),
+						"lockout_window_seconds": structpb.NewNumberValue(900),
 					}},
 				},
 			},
@@ -467,7 +515,7 @@ func TestCreate(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
 
-			s, err := authmethods.NewService(kms, pwRepoFn, iamRepoFn, atRepoFn)
+			s, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, ldapRepoFn, iamRepoFn, atRepoFn)
 			require.NoError(err, "Error when getting new auth_method service.")
 
 			got, gErr := s.CreateAuthMethod(auth.DisabledAuthTestContext(auth.WithScopeId(tc.req.GetItem().GetScopeId())), tc.req)
@@ -510,13 +558,19 @@ func TestUpdate(t *testing.T) {
 	pwRepoFn := func() (*password.Repository, error) {
 		return password.NewRepository(rw, rw, kms)
 	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kms)
+	}
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kms)
 	}
 	iamRepo := iam.TestRepo(t, conn, wrapper)
 
 	o, _ := iam.TestScopes(t, iamRepo)
-	tested, err := authmethods.NewService(kms, pwRepoFn, iamRepoFn, atRepoFn)
+	tested, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, ldapRepoFn, iamRepoFn, atRepoFn)
 	require.NoError(t, err, "Error when getting new auth_method service.")
 
 	defaultScopeInfo := &scopepb.ScopeInfo{Id: o.GetPublicId(), Type: o.GetType()}
@@ -565,8 +619,9 @@ func TestUpdate(t *testing.T) {
 					Description: &wrapperspb.StringValue{Value: "desc"},
 					Type:        "password",
 					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
-						"min_password_length":   structpb.NewNumberValue(8),
-						"min_login_name_length": structpb.NewNumberValue(3),
+						"min_password_length":    structpb.NewNumberValue(8),
+						"min_login_name_length":  structpb.NewNumberValue(3),
+						"lockout_window_seconds": structpb.NewNumberValue(900),
 					}},
 					Scope: defaultScopeInfo,
 				},
@@ -591,8 +646,9 @@ func TestUpdate(t *testing.T) {
 					Description: &wrapperspb.StringValue{Value: "desc"},
 					Type:        "password",
 					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
-						"min_password_length":   structpb.NewNumberValue(8),
-						"min_login_name_length": structpb.NewNumberValue(3),
+						"min_password_length":    structpb.NewNumberValue(8),
+						"min_login_name_length":  structpb.NewNumberValue(3),
+						"lockout_window_seconds": structpb.NewNumberValue(900),
 					}},
 					Scope: defaultScopeInfo,
 				},
@@ -657,8 +713,9 @@ func TestUpdate(t *testing.T) {
 					Description: &wrapperspb.StringValue{Value: "default"},
 					Type:        "password",
 					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
-						"min_password_length":   structpb.NewNumberValue(8),
-						"min_login_name_length": structpb.NewNumberValue(3),
+						"min_password_length":    structpb.NewNumberValue(8),
+						"min_login_name_length":  structpb.NewNumberValue(3),
+						"lockout_window_seconds": structpb.NewNumberValue(900),
 					}},
 					Scope: defaultScopeInfo,
 				},
@@ -682,8 +739,9 @@ func TestUpdate(t *testing.T) {
 					Description: &wrapperspb.StringValue{Value: "default"},
 					Type:        "password",
 					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
-						"min_password_length":   structpb.NewNumberValue(8),
-						"min_login_name_length": structpb.NewNumberValue(3),
+						"min_password_length":    structpb.NewNumberValue(8),
+						"min_login_name_length":  structpb.NewNumberValue(3),
+						"lockout_window_seconds": structpb.NewNumberValue(900),
 					}},
 					Scope: defaultScopeInfo,
 				},
@@ -707,8 +765,9 @@ func TestUpdate(t *testing.T) {
 					Description: &wrapperspb.StringValue{Value: "notignored"},
 					Type:        "password",
 					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
-						"min_password_length":   structpb.NewNumberValue(8),
-						"min_login_name_length": structpb.NewNumberValue(3),
+						"min_password_length":    structpb.NewNumberValue(8),
+						"min_login_name_length":  structpb.NewNumberValue(3),
+						"lockout_window_seconds": structpb.NewNumberValue(900),
 					}},
 					Scope: defaultScopeInfo,
 				},
@@ -803,8 +862,9 @@ func TestUpdate(t *testing.T) {
 					Description: &wrapperspb.StringValue{Value: "default"},
 					Type:        "password",
 					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
-						"min_password_length":   structpb.NewNumberValue(8),
-						"min_login_name_length": structpb.NewNumberValue(42),
+						"min_password_length":    structpb.NewNumberValue(8),
+						"min_login_name_length":  structpb.NewNumberValue(42),
+						"lockout_window_seconds": structpb.NewNumberValue(900),
 					}},
 					Scope: defaultScopeInfo,
 				},
@@ -832,8 +892,40 @@ func TestUpdate(t *testing.T) {
 					Description: &wrapperspb.StringValue{Value: "default"},
 					Type:        "password",
 					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
-						"min_password_length":   structpb.NewNumberValue(42),
-						"min_login_name_length": structpb.NewNumberValue(3),
+						"min_password_length":    structpb.NewNumberValue(42),
+						"min_login_name_length":  structpb.NewNumberValue(3),
+						"lockout_window_seconds": structpb.NewNumberValue(900),
+					}},
+					Scope: defaultScopeInfo,
+				},
+			},
+		},
+		{
+			name: "Update password policy",
+			req: &pbs.UpdateAuthMethodRequest{
+				UpdateMask: &field_mask.FieldMask{
+					Paths: []string{"attributes.require_digit", "attributes.lockout_threshold", "attributes.lockout_window_seconds"},
+				},
+				Item: &pb.AuthMethod{
+					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"require_digit":     structpb.NewBoolValue(true),
+						"require_symbol":    structpb.NewBoolValue(true),
+						"lockout_threshold": structpb.NewNumberValue(5),
+					}},
+				},
+			},
+			res: &pbs.UpdateAuthMethodResponse{
+				Item: &pb.AuthMethod{
+					ScopeId:     o.GetPublicId(),
+					Name:        &wrapperspb.StringValue{Value: "default"},
+					Description: &wrapperspb.StringValue{Value: "default"},
+					Type:        "password",
+					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"min_password_length":    structpb.NewNumberValue(8),
+						"min_login_name_length":  structpb.NewNumberValue(3),
+						"require_digit":          structpb.NewBoolValue(true),
+						"lockout_threshold":      structpb.NewNumberValue(5),
+						"lockout_window_seconds": structpb.NewNumberValue(900),
 					}},
 					Scope: defaultScopeInfo,
 				},
@@ -902,6 +994,12 @@ func TestAuthenticate(t *testing.T) {
 	pwRepoFn := func() (*password.Repository, error) {
 		return password.NewRepository(rw, rw, kms)
 	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kms)
+	}
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kms)
 	}
@@ -1028,7 +1126,7 @@ func TestAuthenticate(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := authmethods.NewService(kms, pwRepoFn, iamRepoFn, atRepoFn)
+			s, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, ldapRepoFn, iamRepoFn, atRepoFn)
 			require.NoError(err)
 
 			resp, err := s.Authenticate(auth.DisabledAuthTestContext(auth.WithScopeId(o.GetPublicId())), tc.request)
@@ -1066,6 +1164,12 @@ func TestAuthenticate_AuthAccountConnectedToIamUser(t *testing.T) {
 	pwRepoFn := func() (*password.Repository, error) {
 		return password.NewRepository(rw, rw, kms)
 	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kms)
+	}
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kms)
 	}
@@ -1085,7 +1189,7 @@ func TestAuthenticate_AuthAccountConnectedToIamUser(t *testing.T) {
 	iamUser, err := iamRepo.LookupUserWithLogin(context.Background(), acct.GetPublicId(), iam.WithAutoVivify(true))
 	require.NoError(err)
 
-	s, err := authmethods.NewService(kms, pwRepoFn, iamRepoFn, atRepoFn)
+	s, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, ldapRepoFn, iamRepoFn, atRepoFn)
 	require.NoError(err)
 	resp, err := s.Authenticate(auth.DisabledAuthTestContext(auth.WithScopeId(o.GetPublicId())), &pbs.AuthenticateRequest{
 		AuthMethodId: am.GetPublicId(),
@@ -1108,3 +1212,162 @@ func TestAuthenticate_AuthAccountConnectedToIamUser(t *testing.T) {
 	assert.NotEmpty(aToken.GetToken())
 	assert.True(strings.HasPrefix(aToken.GetToken(), aToken.GetId()))
 }
+
+func TestAuthenticate_Oidc(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.TestRepo(t, conn, wrapper), nil
+	}
+	pwRepoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kms)
+	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kms)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+
+	provider := oidc.NewTestProvider(t, "test-client", "test-secret")
+	s, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, ldapRepoFn, iamRepoFn, atRepoFn)
+	require.NoError(err)
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(o.GetPublicId()))
+
+	attrs, err := handlers.ProtoToStruct(&pb.OidcAuthMethodAttributes{
+		Issuer:       provider.Issuer(),
+		ClientId:     "test-client",
+		ClientSecret: "test-secret",
+		CallbackUrl:  "https://boundary.example.com/v1/auth-methods/callback",
+	})
+	require.NoError(err)
+	created, err := s.CreateAuthMethod(ctx, &pbs.CreateAuthMethodRequest{Item: &pb.AuthMethod{
+		ScopeId:    o.GetPublicId(),
+		Type:       auth.OidcSubtype.String(),
+		Attributes: attrs,
+	}})
+	require.NoError(err)
+	amId := created.GetItem().GetId()
+	assert.True(strings.HasPrefix(amId, oidc.AuthMethodPrefix+"_"))
+	assert.Nil(created.GetItem().GetAttributes().GetFields()["client_secret"])
+	assert.NotEmpty(created.GetItem().GetAttributes().GetFields()["client_secret_hmac"].GetStringValue())
+
+	list, err := s.ListAuthMethods(ctx, &pbs.ListAuthMethodsRequest{ScopeId: o.GetPublicId()})
+	require.NoError(err)
+	require.Len(list.GetItems(), 1)
+	assert.Equal(amId, list.GetItems()[0].GetId())
+
+	_, err = s.Authenticate(ctx, &pbs.AuthenticateRequest{AuthMethodId: amId})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)))
+
+	start, err := s.AuthenticateStart(ctx, &pbs.AuthenticateStartRequest{AuthMethodId: amId})
+	require.NoError(err)
+	assert.NotEmpty(start.GetTokenId())
+
+	pending, err := s.AuthenticateToken(ctx, &pbs.AuthenticateTokenRequest{AuthMethodId: amId, TokenId: start.GetTokenId()})
+	require.NoError(err)
+	assert.Nil(pending.GetItem())
+
+	code, state := provider.Authorize(t, start.GetAuthUrl(), "alice", "alice@example.com", "Alice", "test-client")
+	_, err = s.AuthenticateCallback(ctx, &pbs.AuthenticateCallbackRequest{AuthMethodId: amId, Code: code, State: state})
+	require.NoError(err)
+
+	resp, err := s.AuthenticateToken(ctx, &pbs.AuthenticateTokenRequest{AuthMethodId: amId, TokenId: start.GetTokenId(), TokenType: "token"})
+	require.NoError(err)
+	aToken := resp.GetItem()
+	require.NotNil(aToken)
+	assert.True(strings.HasPrefix(aToken.GetToken(), aToken.GetId()))
+	assert.Equal(amId, aToken.GetAuthMethodId())
+	assert.True(strings.HasPrefix(aToken.GetAccountId(), oidc.AccountPrefix+"_"))
+	assert.Equal("token", resp.GetTokenType())
+
+	_, err = s.AuthenticateToken(ctx, &pbs.AuthenticateTokenRequest{AuthMethodId: amId, TokenId: start.GetTokenId()})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.NotFound)))
+
+	_, err = s.AuthenticateCallback(ctx, &pbs.AuthenticateCallbackRequest{AuthMethodId: amId, State: state, Error: "access_denied"})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.Unauthenticated)))
+}
+
+func TestAuthenticate_Ldap(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.TestRepo(t, conn, wrapper), nil
+	}
+	pwRepoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kms)
+	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(rw, rw, kms)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+
+	const jimDn = "uid=jim,ou=people,dc=example,dc=com"
+	server := ldapclient.NewTestServer(t, ldapclient.WithTestLdaps())
+	server.AddEntry(jimDn, map[string][]string{"objectClass": {"person"}, "uid": {"jim"}})
+	server.SetPassword(jimDn, "jim-password")
+	server.SetPassword("cn=reader,dc=example,dc=com", "reader-password")
+
+	s, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, ldapRepoFn, iamRepoFn, atRepoFn)
+	require.NoError(err)
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(o.GetPublicId()))
+
+	attrs, err := handlers.ProtoToStruct(&pb.LdapAuthMethodAttributes{
+		Url:            server.Url(),
+		Certificate:    server.CaCert(),
+		BindDn:         "cn=reader,dc=example,dc=com",
+		BindPassword:   "reader-password",
+		UserSearchBase: "ou=people,dc=example,dc=com",
+	})
+	require.NoError(err)
+	created, err := s.CreateAuthMethod(ctx, &pbs.CreateAuthMethodRequest{Item: &pb.AuthMethod{
+		ScopeId:    o.GetPublicId(),
+		Type:       auth.LdapSubtype.String(),
+		Attributes: attrs,
+	}})
+	require.NoError(err)
+	amId := created.GetItem().GetId()
+	assert.True(strings.HasPrefix(amId, ldap.AuthMethodPrefix+"_"))
+	assert.Nil(created.GetItem().GetAttributes().GetFields()["bind_password"])
+	assert.NotEmpty(created.GetItem().GetAttributes().GetFields()["bind_password_hmac"].GetStringValue())
+	assert.Equal(ldap.DefaultUserSearchFilter, created.GetItem().GetAttributes().GetFields()["user_search_filter"].GetStringValue())
+
+	list, err := s.ListAuthMethods(ctx, &pbs.ListAuthMethodsRequest{ScopeId: o.GetPublicId()})
+	require.NoError(err)
+	require.Len(list.GetItems(), 1)
+	assert.Equal(amId, list.GetItems()[0].GetId())
+
+	creds := func(loginName, pw string) *structpb.Struct {
+		return &structpb.Struct{Fields: map[string]*structpb.Value{
+			"login_name": {Kind: &structpb.Value_StringValue{StringValue: loginName}},
+			"password":   {Kind: &structpb.Value_StringValue{StringValue: pw}},
+		}}
+	}
+	resp, err := s.Authenticate(ctx, &pbs.AuthenticateRequest{AuthMethodId: amId, TokenType: "token", Credentials: creds("jim", "jim-password")})
+	require.NoError(err)
+	aToken := resp.GetItem()
+	require.NotNil(aToken)
+	assert.Equal(amId, aToken.GetAuthMethodId())
+	assert.True(strings.HasPrefix(aToken.GetAccountId(), ldap.AccountPrefix+"_"))
+
+	_, err = s.Authenticate(ctx, &pbs.AuthenticateRequest{AuthMethodId: amId, Credentials: creds("jim", "wrong")})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.Unauthenticated)))
+}
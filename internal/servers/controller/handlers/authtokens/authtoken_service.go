@@ -16,6 +16,15 @@ import (
 	"github.com/hashicorp/boundary/internal/types/scope"
 )
 
+var (
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.Read,
+		action.Delete,
+	}
+)
+
 // Service handles request as described by the pbs.AuthTokenServiceServer interface.
 type Service struct {
 	pbs.UnimplementedAuthTokenServiceServer
@@ -43,17 +52,41 @@ func (s Service) ListAuthTokens(ctx context.Context, req *pbs.ListAuthTokensRequ
 		return nil, err
 	}
 	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
-	if authResults.Error != nil {
-		return nil, authResults.Error
+	scopeIds, scopeInfos, err := authResults.ScopesForList(ctx, resource.AuthToken, req.GetRecursive())
+	if err != nil {
+		return nil, err
+	}
+	if len(scopeIds) == 0 {
+		return &pbs.ListAuthTokensResponse{}, nil
+	}
+	fetch := func(afterId string, limit int) ([]handlers.ListItem, error) {
+		ul, err := s.listFromRepo(ctx, scopeIds, afterId, limit)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]handlers.ListItem, 0, len(ul))
+		for _, at := range ul {
+			resOpt := auth.WithScopeId(at.GetScopeId())
+			if authResults.DeniedForId(ctx, at.GetPublicId(), action.Read, resOpt) {
+				items = append(items, handlers.HiddenListItem(at.GetPublicId()))
+				continue
+			}
+			items = append(items, handlers.ListedItem(at.GetPublicId(), toProto(at,
+				handlers.WithScope(scopeInfos[at.GetScopeId()]),
+				handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, at.GetPublicId(), IdActions, resOpt).Strings()),
+				handlers.WithOutputFields(authResults.FetchOutputFields(ctx, at.GetPublicId(), resOpt)))))
+		}
+		return items, nil
 	}
-	ul, err := s.listFromRepo(ctx, req.GetScopeId())
+	items, next, err := handlers.ListPage(fetch, req.GetFilter(), req.GetPageSize(), req.GetPageToken())
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range ul {
-		item.Scope = authResults.Scope
+	var ul []*pb.AuthToken
+	for _, item := range items {
+		ul = append(ul, item.(*pb.AuthToken))
 	}
-	return &pbs.ListAuthTokensResponse{Items: ul}, nil
+	return &pbs.ListAuthTokensResponse{Items: ul, NextPageToken: next}, nil
 }
 
 // GetAuthToken implements the interface pbs.AuthTokenServiceServer.
@@ -65,11 +98,13 @@ func (s Service) GetAuthToken(ctx context.Context, req *pbs.GetAuthTokenRequest)
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	u, err := s.getFromRepo(ctx, req.GetId())
+	u, err := s.getFromRepo(ctx, req.GetId(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, req.GetId(), IdActions).Strings()),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	u.Scope = authResults.Scope
 	return &pbs.GetAuthTokenResponse{Item: u}, nil
 }
 
@@ -89,7 +124,7 @@ func (s Service) DeleteAuthToken(ctx context.Context, req *pbs.DeleteAuthTokenRe
 	return &pbs.DeleteAuthTokenResponse{}, nil
 }
 
-func (s Service) getFromRepo(ctx context.Context, id string) (*pb.AuthToken, error) {
+func (s Service) getFromRepo(ctx context.Context, id string, opt ...handlers.Option) (*pb.AuthToken, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -104,7 +139,7 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*pb.AuthToken, err
 	if u == nil {
 		return nil, handlers.NotFoundErrorf("AuthToken %q doesn't exist.", id)
 	}
-	return toProto(u), nil
+	return toProto(u, opt...), nil
 }
 
 func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
@@ -122,21 +157,16 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, orgId string) ([]*pb.AuthToken, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, afterId string, limit int) ([]*authtoken.AuthToken, error) {
 	repo, err := s.repoFn()
-	_ = repo
 	if err != nil {
 		return nil, err
 	}
-	ul, err := repo.ListAuthTokens(ctx, orgId)
+	ul, err := repo.ListAuthTokens(ctx, scopeIds, authtoken.WithStartPageAfterId(afterId), authtoken.WithLimit(limit))
 	if err != nil {
 		return nil, err
 	}
-	var outUl []*pb.AuthToken
-	for _, u := range ul {
-		outUl = append(outUl, toProto(u))
-	}
-	return outUl, nil
+	return ul, nil
 }
 
 func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
@@ -183,26 +213,51 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	return auth.Verify(ctx, opts...)
 }
 
-func toProto(in *authtoken.AuthToken) *pb.AuthToken {
-	out := pb.AuthToken{
-		Id:                      in.GetPublicId(),
-		ScopeId:                 in.GetScopeId(),
-		CreatedTime:             in.GetCreateTime().GetTimestamp(),
-		UpdatedTime:             in.GetUpdateTime().GetTimestamp(),
-		ApproximateLastUsedTime: in.GetApproximateLastAccessTime().GetTimestamp(),
-		ExpirationTime:          in.GetExpirationTime().GetTimestamp(),
-		UserId:                  in.GetIamUserId(),
-		AuthMethodId:            in.GetAuthMethodId(),
-		AccountId:               in.GetAuthAccountId(),
+func toProto(in *authtoken.AuthToken, opt ...handlers.Option) *pb.AuthToken {
+	opts := handlers.GetOpts(opt...)
+	outputFields := opts.WithOutputFields
+	out := pb.AuthToken{}
+	if outputFields.Has("id") {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has("scope_id") {
+		out.ScopeId = in.GetScopeId()
+	}
+	if outputFields.Has("scope") {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has("created_time") {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
+	}
+	if outputFields.Has("updated_time") {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has("approximate_last_used_time") {
+		out.ApproximateLastUsedTime = in.GetApproximateLastAccessTime().GetTimestamp()
+	}
+	if outputFields.Has("expiration_time") {
+		out.ExpirationTime = in.GetExpirationTime().GetTimestamp()
+	}
+	if outputFields.Has("user_id") {
+		out.UserId = in.GetIamUserId()
+	}
+	if outputFields.Has("auth_method_id") {
+		out.AuthMethodId = in.GetAuthMethodId()
+	}
+	if outputFields.Has("account_id") {
+		out.AccountId = in.GetAuthAccountId()
+	}
+	if outputFields.Has("authorized_actions") {
+		out.AuthorizedActions = opts.WithAuthorizedActions
 	}
 	return &out
 }
 
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
-//  * The path passed in is correctly formatted
-//  * All required parameters are set
-//  * There are no conflicting parameters provided
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
 func validateGetRequest(req *pbs.GetAuthTokenRequest) error {
 	return handlers.ValidateGetRequest(authtoken.AuthTokenPrefix, req, handlers.NoopValidatorFn)
 }
@@ -2,6 +2,7 @@ package authtokens_test
 
 import (
 	"errors"
+	"sort"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -23,6 +24,8 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+var testAuthorizedActions = []string{"read", "delete"}
+
 func TestGet(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	rw := db.New(conn)
@@ -52,6 +55,7 @@ func TestGet(t *testing.T) {
 		ApproximateLastUsedTime: at.GetApproximateLastAccessTime().GetTimestamp(),
 		ExpirationTime:          at.GetExpirationTime().GetTimestamp(),
 		Scope:                   &scopes.ScopeInfo{Id: org.GetPublicId(), Type: scope.Org.String()},
+		AuthorizedActions:       testAuthorizedActions,
 	}
 
 	cases := []struct {
@@ -127,6 +131,7 @@ func TestList(t *testing.T) {
 			ApproximateLastUsedTime: at.GetApproximateLastAccessTime().GetTimestamp(),
 			ExpirationTime:          at.GetExpirationTime().GetTimestamp(),
 			Scope:                   &scopes.ScopeInfo{Id: orgWithSomeTokens.GetPublicId(), Type: scope.Org.String()},
+			AuthorizedActions:       testAuthorizedActions,
 		})
 	}
 
@@ -145,9 +150,14 @@ func TestList(t *testing.T) {
 			ApproximateLastUsedTime: at.GetApproximateLastAccessTime().GetTimestamp(),
 			ExpirationTime:          at.GetExpirationTime().GetTimestamp(),
 			Scope:                   &scopes.ScopeInfo{Id: orgWithOtherTokens.GetPublicId(), Type: scope.Org.String()},
+			AuthorizedActions:       testAuthorizedActions,
 		})
 	}
 
+	// Listed resources are ordered by id
+	sort.Slice(wantSomeTokens, func(i, j int) bool { return wantSomeTokens[i].GetId() < wantSomeTokens[j].GetId() })
+	sort.Slice(wantOtherTokens, func(i, j int) bool { return wantOtherTokens[i].GetId() < wantOtherTokens[j].GetId() })
+
 	cases := []struct {
 		name  string
 		scope string
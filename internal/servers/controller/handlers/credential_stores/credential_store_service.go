@@ -0,0 +1,404 @@
+package credential_stores
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/credential/static"
+	"github.com/hashicorp/boundary/internal/credential/static/store"
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/credentialstores"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	maskManager handlers.MaskManager
+
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.Read,
+		action.Update,
+		action.Delete,
+	}
+)
+
+func init() {
+	var err error
+	if maskManager, err = handlers.NewMaskManager(&store.CredentialStore{}, &pb.CredentialStore{}); err != nil {
+		panic(err)
+	}
+}
+
+type Service struct {
+	pbs.UnimplementedCredentialStoreServiceServer
+
+	staticRepoFn common.StaticCredentialRepoFactory
+	iamRepoFn    common.IamRepoFactory
+}
+
+var _ pbs.CredentialStoreServiceServer = Service{}
+
+// NewService returns a credential store Service which handles credential store related requests to boundary and uses the provided
+// repositories for storage and retrieval.
+func NewService(repoFn common.StaticCredentialRepoFactory, iamRepoFn common.IamRepoFactory) (Service, error) {
+	if repoFn == nil {
+		return Service{}, fmt.Errorf("nil static credential repository provided")
+	}
+	if iamRepoFn == nil {
+		return Service{}, fmt.Errorf("nil iam repository provided")
+	}
+	return Service{staticRepoFn: repoFn, iamRepoFn: iamRepoFn}, nil
+}
+
+func (s Service) ListCredentialStores(ctx context.Context, req *pbs.ListCredentialStoresRequest) (*pbs.ListCredentialStoresResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
+	scopeIds, scopeInfos, err := authResults.ScopesForList(ctx, resource.CredentialStore, req.GetRecursive())
+	if err != nil {
+		return nil, err
+	}
+	if len(scopeIds) == 0 {
+		return &pbs.ListCredentialStoresResponse{}, nil
+	}
+	fetch := func(afterId string, limit int) ([]handlers.ListItem, error) {
+		ul, err := s.listFromRepo(ctx, scopeIds, afterId, limit)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]handlers.ListItem, 0, len(ul))
+		for _, cs := range ul {
+			resOpt := auth.WithScopeId(cs.GetScopeId())
+			if authResults.DeniedForId(ctx, cs.GetPublicId(), action.Read, resOpt) {
+				items = append(items, handlers.HiddenListItem(cs.GetPublicId()))
+				continue
+			}
+			items = append(items, handlers.ListedItem(cs.GetPublicId(), toProto(cs,
+				handlers.WithScope(scopeInfos[cs.GetScopeId()]),
+				handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, cs.GetPublicId(), IdActions, resOpt).Strings()),
+				handlers.WithOutputFields(authResults.FetchOutputFields(ctx, cs.GetPublicId(), resOpt)))))
+		}
+		return items, nil
+	}
+	items, next, err := handlers.ListPage(fetch, req.GetFilter(), req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	var ul []*pb.CredentialStore
+	for _, item := range items {
+		ul = append(ul, item.(*pb.CredentialStore))
+	}
+	return &pbs.ListCredentialStoresResponse{Items: ul, NextPageToken: next}, nil
+}
+
+// GetCredentialStore implements the interface pbs.CredentialStoreServiceServer.
+func (s Service) GetCredentialStore(ctx context.Context, req *pbs.GetCredentialStoreRequest) (*pbs.GetCredentialStoreResponse, error) {
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	cs, err := s.getFromRepo(ctx, req.GetId(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, req.GetId(), IdActions).Strings()),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.GetCredentialStoreResponse{Item: cs}, nil
+}
+
+// CreateCredentialStore implements the interface pbs.CredentialStoreServiceServer.
+func (s Service) CreateCredentialStore(ctx context.Context, req *pbs.CreateCredentialStoreRequest) (*pbs.CreateCredentialStoreResponse, error) {
+	if err := validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetItem().GetScopeId(), action.Create)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	cs, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, "")))
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.CreateCredentialStoreResponse{
+		Item: cs,
+		Uri:  fmt.Sprintf("credential-stores/%s", cs.GetId()),
+	}, nil
+}
+
+// UpdateCredentialStore implements the interface pbs.CredentialStoreServiceServer.
+func (s Service) UpdateCredentialStore(ctx context.Context, req *pbs.UpdateCredentialStoreRequest) (*pbs.UpdateCredentialStoreResponse, error) {
+	if err := validateUpdateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Update)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	cs, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.UpdateCredentialStoreResponse{Item: cs}, nil
+}
+
+// DeleteCredentialStore implements the interface pbs.CredentialStoreServiceServer.
+func (s Service) DeleteCredentialStore(ctx context.Context, req *pbs.DeleteCredentialStoreRequest) (*pbs.DeleteCredentialStoreResponse, error) {
+	if err := validateDeleteRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Delete)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	_, err := s.deleteFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.DeleteCredentialStoreResponse{}, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string, opt ...handlers.Option) (*pb.CredentialStore, error) {
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	cs, err := repo.LookupCredentialStore(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if cs == nil {
+		return nil, handlers.NotFoundErrorf("Credential Store %q doesn't exist.", id)
+	}
+	return toProto(cs, opt...), nil
+}
+
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, afterId string, limit int) ([]*static.CredentialStore, error) {
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	ul, err := repo.ListCredentialStores(ctx, scopeIds, static.WithStartPageAfterId(afterId), static.WithLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	return ul, nil
+}
+
+func (s Service) createInRepo(ctx context.Context, projId string, item *pb.CredentialStore, opt ...handlers.Option) (*pb.CredentialStore, error) {
+	var opts []static.Option
+	if item.GetName() != nil {
+		opts = append(opts, static.WithName(item.GetName().GetValue()))
+	}
+	if item.GetDescription() != nil {
+		opts = append(opts, static.WithDescription(item.GetDescription().GetValue()))
+	}
+	cs, err := static.NewCredentialStore(projId, opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build credential store for creation: %v.", err)
+	}
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.CreateCredentialStore(ctx, cs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create credential store: %w", err)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create credential store but no error returned from repository.")
+	}
+	return toProto(out, opt...), nil
+}
+
+func (s Service) updateInRepo(ctx context.Context, projId, id string, mask []string, item *pb.CredentialStore, opt ...handlers.Option) (*pb.CredentialStore, error) {
+	var opts []static.Option
+	if desc := item.GetDescription(); desc != nil {
+		opts = append(opts, static.WithDescription(desc.GetValue()))
+	}
+	if name := item.GetName(); name != nil {
+		opts = append(opts, static.WithName(name.GetValue()))
+	}
+	version := item.GetVersion()
+	cs, err := static.NewCredentialStore(projId, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build credential store for update: %w", err)
+	}
+	cs.PublicId = id
+	dbMask := maskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, rowsUpdated, err := repo.UpdateCredentialStore(ctx, cs, version, dbMask)
+	if err != nil {
+		return nil, fmt.Errorf("unable to update credential store: %w", err)
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Credential Store %q doesn't exist or incorrect version provided.", id)
+	}
+	return toProto(out, opt...), nil
+}
+
+func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return false, err
+	}
+	rows, err := repo.DeleteCredentialStore(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("unable to delete credential store: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
+	res := auth.VerifyResults{}
+
+	var parentId string
+	opts := []auth.Option{auth.WithType(resource.CredentialStore), auth.WithAction(a)}
+	switch a {
+	case action.List, action.Create:
+		parentId = id
+		iamRepo, err := s.iamRepoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		scp, err := iamRepo.LookupScope(ctx, parentId)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if scp == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+	default:
+		repo, err := s.staticRepoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		cs, err := repo.LookupCredentialStore(ctx, id)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if cs == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+		parentId = cs.GetScopeId()
+		opts = append(opts, auth.WithId(id))
+	}
+	opts = append(opts, auth.WithScopeId(parentId))
+	return auth.Verify(ctx, opts...)
+}
+
+func toProto(in *static.CredentialStore, opt ...handlers.Option) *pb.CredentialStore {
+	opts := handlers.GetOpts(opt...)
+	outputFields := opts.WithOutputFields
+	out := pb.CredentialStore{}
+	if outputFields.Has("id") {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has("scope_id") {
+		out.ScopeId = in.GetScopeId()
+	}
+	if outputFields.Has("scope") {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has("created_time") {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
+	}
+	if outputFields.Has("updated_time") {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has("version") {
+		out.Version = in.GetVersion()
+	}
+	if outputFields.Has("type") {
+		out.Type = credential.StaticSubtype.String()
+	}
+	if outputFields.Has("description") && in.GetDescription() != "" {
+		out.Description = &wrapperspb.StringValue{Value: in.GetDescription()}
+	}
+	if outputFields.Has("name") && in.GetName() != "" {
+		out.Name = &wrapperspb.StringValue{Value: in.GetName()}
+	}
+	if outputFields.Has("authorized_actions") {
+		out.AuthorizedActions = opts.WithAuthorizedActions
+	}
+	return &out
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
+//   - The type asserted by the ID and/or field is known
+//   - If relevant, the type derived from the id prefix matches what is claimed by the type field
+func validateGetRequest(req *pbs.GetCredentialStoreRequest) error {
+	return handlers.ValidateGetRequest(static.CredentialStorePrefix, req, handlers.NoopValidatorFn)
+}
+
+func validateCreateRequest(req *pbs.CreateCredentialStoreRequest) error {
+	return handlers.ValidateCreateRequest(req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		if !handlers.ValidId(scope.Project.Prefix(), req.GetItem().GetScopeId()) {
+			badFields["scope_id"] = "This field must be a valid project scope id."
+		}
+		if credential.SubtypeFromType(req.GetItem().GetType()) != credential.StaticSubtype {
+			badFields["type"] = fmt.Sprintf("This is a required field and must be %q.", credential.StaticSubtype.String())
+		}
+		return badFields
+	})
+}
+
+func validateUpdateRequest(req *pbs.UpdateCredentialStoreRequest) error {
+	return handlers.ValidateUpdateRequest(static.CredentialStorePrefix, req, req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		if req.GetItem().GetType() != "" && credential.SubtypeFromType(req.GetItem().GetType()) != credential.StaticSubtype {
+			badFields["type"] = "Cannot modify resource type."
+		}
+		return badFields
+	})
+}
+
+func validateDeleteRequest(req *pbs.DeleteCredentialStoreRequest) error {
+	return handlers.ValidateDeleteRequest(static.CredentialStorePrefix, req, handlers.NoopValidatorFn)
+}
+
+func validateListRequest(req *pbs.ListCredentialStoresRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()) &&
+		!(req.GetRecursive() && (req.GetScopeId() == scope.Global.String() || handlers.ValidId(scope.Org.Prefix(), req.GetScopeId()))) {
+		badFields["scope_id"] = "This field must be a valid project scope id, or 'global' or a valid org scope id when listing recursively."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package credential_stores_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/credential/static"
+	"github.com/hashicorp/boundary/internal/db"
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/credentialstores"
+	scopepb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/credential_stores"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+var testAuthorizedActions = []string{"read", "update", "delete"}
+
+func testService(t *testing.T) (credential_stores.Service, *iam.Scope, *static.CredentialStore) {
+	t.Helper()
+	require := require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	rw := db.New(conn)
+	repoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.TestRepo(t, conn, wrapper), nil
+	}
+	s, err := credential_stores.NewService(repoFn, iamRepoFn)
+	require.NoError(err, "Couldn't create a new credential store service.")
+
+	cs, err := static.NewCredentialStore(proj.GetPublicId(), static.WithName("default"), static.WithDescription("default"))
+	require.NoError(err)
+	repo, err := repoFn()
+	require.NoError(err)
+	cs, err = repo.CreateCredentialStore(context.Background(), cs)
+	require.NoError(err)
+	return s, proj, cs
+}
+
+func TestGet(t *testing.T) {
+	s, proj, cs := testService(t)
+
+	want := &pb.CredentialStore{
+		Id:                cs.GetPublicId(),
+		ScopeId:           cs.GetScopeId(),
+		Scope:             &scopepb.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+		Name:              &wrappers.StringValue{Value: cs.GetName()},
+		Description:       &wrappers.StringValue{Value: cs.GetDescription()},
+		CreatedTime:       cs.CreateTime.GetTimestamp(),
+		UpdatedTime:       cs.UpdateTime.GetTimestamp(),
+		Version:           1,
+		Type:              "static",
+		AuthorizedActions: testAuthorizedActions,
+	}
+
+	cases := []struct {
+		name string
+		req  *pbs.GetCredentialStoreRequest
+		res  *pbs.GetCredentialStoreResponse
+		err  error
+	}{
+		{
+			name: "Get an existing credential store",
+			req:  &pbs.GetCredentialStoreRequest{Id: cs.GetPublicId()},
+			res:  &pbs.GetCredentialStoreResponse{Item: want},
+		},
+		{
+			name: "Get a non existing credential store",
+			req:  &pbs.GetCredentialStoreRequest{Id: static.CredentialStorePrefix + "_DoesntExis"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Wrong id prefix",
+			req:  &pbs.GetCredentialStoreRequest{Id: "j_1234567890"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.GetCredentialStore(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "GetCredentialStore(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform()), "GetCredentialStore(%q) got response %q, wanted %q", tc.req, got, tc.res)
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	s, proj, _ := testService(t)
+
+	cases := []struct {
+		name string
+		item *pb.CredentialStore
+		err  error
+	}{
+		{
+			name: "Create a valid credential store",
+			item: &pb.CredentialStore{
+				ScopeId: proj.GetPublicId(),
+				Name:    &wrappers.StringValue{Value: "name"},
+				Type:    "static",
+			},
+		},
+		{
+			name: "Missing type",
+			item: &pb.CredentialStore{
+				ScopeId: proj.GetPublicId(),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Org scope",
+			item: &pb.CredentialStore{
+				ScopeId: proj.GetParentId(),
+				Type:    "static",
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Can't specify id",
+			item: &pb.CredentialStore{
+				Id:      static.CredentialStorePrefix + "_notallowed",
+				ScopeId: proj.GetPublicId(),
+				Type:    "static",
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.CreateCredentialStore(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), &pbs.CreateCredentialStoreRequest{Item: tc.item})
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "CreateCredentialStore(%+v) got error %v, wanted %v", tc.item, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Contains(got.GetUri(), got.GetItem().GetId())
+			assert.Equal(tc.item.GetName().GetValue(), got.GetItem().GetName().GetValue())
+			assert.Equal("static", got.GetItem().GetType())
+			assert.Equal(uint32(1), got.GetItem().GetVersion())
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	s, proj, cs := testService(t)
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId()))
+
+	got, err := s.UpdateCredentialStore(ctx, &pbs.UpdateCredentialStoreRequest{
+		Id:         cs.GetPublicId(),
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"name", "description"}},
+		Item: &pb.CredentialStore{
+			Name:    &wrappers.StringValue{Value: "updated"},
+			Version: cs.GetVersion(),
+		},
+	})
+	require.NoError(err)
+	assert.Equal("updated", got.GetItem().GetName().GetValue())
+	assert.Nil(got.GetItem().GetDescription())
+	assert.Equal(cs.GetVersion()+1, got.GetItem().GetVersion())
+
+	_, err = s.UpdateCredentialStore(ctx, &pbs.UpdateCredentialStoreRequest{
+		Id:         cs.GetPublicId(),
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"type"}},
+		Item: &pb.CredentialStore{
+			Type:    "vault",
+			Version: got.GetItem().GetVersion(),
+		},
+	})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v", err)
+}
+
+func TestDelete(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	s, proj, cs := testService(t)
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId()))
+
+	_, err := s.DeleteCredentialStore(ctx, &pbs.DeleteCredentialStoreRequest{Id: cs.GetPublicId()})
+	require.NoError(err)
+	_, err = s.DeleteCredentialStore(ctx, &pbs.DeleteCredentialStoreRequest{Id: cs.GetPublicId()})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.NotFound)), "got error %v", err)
+}
@@ -0,0 +1,445 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/credential/static"
+	"github.com/hashicorp/boundary/internal/credential/static/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/credentials"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	maskManager handlers.MaskManager
+
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.Read,
+		action.Update,
+		action.Delete,
+	}
+)
+
+func init() {
+	var err error
+	if maskManager, err = handlers.NewMaskManager(&store.UsernamePasswordCredential{}, &pb.Credential{}, &pb.UsernamePasswordCredentialAttributes{}); err != nil {
+		panic(err)
+	}
+}
+
+type Service struct {
+	pbs.UnimplementedCredentialServiceServer
+
+	staticRepoFn common.StaticCredentialRepoFactory
+}
+
+var _ pbs.CredentialServiceServer = Service{}
+
+// NewService returns a credential Service which handles credential related requests to boundary and uses the provided
+// repositories for storage and retrieval.
+func NewService(repoFn common.StaticCredentialRepoFactory) (Service, error) {
+	if repoFn == nil {
+		return Service{}, fmt.Errorf("nil static credential repository provided")
+	}
+	return Service{staticRepoFn: repoFn}, nil
+}
+
+func (s Service) ListCredentials(ctx context.Context, req *pbs.ListCredentialsRequest) (*pbs.ListCredentialsResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.parentAndAuthResult(ctx, req.GetCredentialStoreId(), action.List)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	fetch := func(afterId string, limit int) ([]handlers.ListItem, error) {
+		cl, err := s.listFromRepo(ctx, req.GetCredentialStoreId(), afterId, limit)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]handlers.ListItem, 0, len(cl))
+		for _, c := range cl {
+			if authResults.DeniedForId(ctx, c.GetPublicId(), action.Read) {
+				items = append(items, handlers.HiddenListItem(c.GetPublicId()))
+				continue
+			}
+			item, err := toProto(c,
+				handlers.WithScope(authResults.Scope),
+				handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, c.GetPublicId(), IdActions).Strings()),
+				handlers.WithOutputFields(authResults.FetchOutputFields(ctx, c.GetPublicId())))
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, handlers.ListedItem(c.GetPublicId(), item))
+		}
+		return items, nil
+	}
+	items, next, err := handlers.ListPage(fetch, req.GetFilter(), req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	var cl []*pb.Credential
+	for _, item := range items {
+		cl = append(cl, item.(*pb.Credential))
+	}
+	return &pbs.ListCredentialsResponse{Items: cl, NextPageToken: next}, nil
+}
+
+// GetCredential implements the interface pbs.CredentialServiceServer.
+func (s Service) GetCredential(ctx context.Context, req *pbs.GetCredentialRequest) (*pbs.GetCredentialResponse, error) {
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	c, err := s.getFromRepo(ctx, req.GetId(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, req.GetId(), IdActions).Strings()),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.GetCredentialResponse{Item: c}, nil
+}
+
+// CreateCredential implements the interface pbs.CredentialServiceServer.
+func (s Service) CreateCredential(ctx context.Context, req *pbs.CreateCredentialRequest) (*pbs.CreateCredentialResponse, error) {
+	if err := validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.parentAndAuthResult(ctx, req.GetItem().GetCredentialStoreId(), action.Create)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	c, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem().GetCredentialStoreId(), req.GetItem(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, "")))
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.CreateCredentialResponse{
+		Item: c,
+		Uri:  fmt.Sprintf("credentials/%s", c.GetId()),
+	}, nil
+}
+
+// UpdateCredential implements the interface pbs.CredentialServiceServer.
+func (s Service) UpdateCredential(ctx context.Context, req *pbs.UpdateCredentialRequest) (*pbs.UpdateCredentialResponse, error) {
+	if err := validateUpdateRequest(req); err != nil {
+		return nil, err
+	}
+	cs, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.Update)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	c, err := s.updateInRepo(ctx, authResults.Scope.GetId(), cs.GetPublicId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.UpdateCredentialResponse{Item: c}, nil
+}
+
+// DeleteCredential implements the interface pbs.CredentialServiceServer.
+func (s Service) DeleteCredential(ctx context.Context, req *pbs.DeleteCredentialRequest) (*pbs.DeleteCredentialResponse, error) {
+	if err := validateDeleteRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.Delete)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	_, err := s.deleteFromRepo(ctx, authResults.Scope.GetId(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.DeleteCredentialResponse{}, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string, opt ...handlers.Option) (*pb.Credential, error) {
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	c, err := repo.LookupCredential(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, handlers.NotFoundErrorf("Credential %q doesn't exist.", id)
+	}
+	return toProto(c, opt...)
+}
+
+func (s Service) createInRepo(ctx context.Context, scopeId, storeId string, item *pb.Credential, opt ...handlers.Option) (*pb.Credential, error) {
+	attrs := &pb.UsernamePasswordCredentialAttributes{}
+	if err := handlers.StructToProto(item.GetAttributes(), attrs); err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Failed converting attributes to subtype proto: %s", err)
+	}
+	var opts []static.Option
+	if item.GetName() != nil {
+		opts = append(opts, static.WithName(item.GetName().GetValue()))
+	}
+	if item.GetDescription() != nil {
+		opts = append(opts, static.WithDescription(item.GetDescription().GetValue()))
+	}
+	c, err := static.NewUsernamePasswordCredential(storeId, attrs.GetUsername().GetValue(), attrs.GetPassword().GetValue(), opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build credential for creation: %v.", err)
+	}
+
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.CreateCredential(ctx, scopeId, c)
+	if err != nil {
+		if errors.IsUniqueError(err) || errors.Is(err, errors.ErrNotUnique) {
+			// Push this error through so the error interceptor can interpret it correctly.
+			return nil, err
+		}
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create credential: %v.", err)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create credential but no error returned from repository.")
+	}
+	return toProto(out, opt...)
+}
+
+func (s Service) updateInRepo(ctx context.Context, scopeId, storeId, id string, mask []string, item *pb.Credential, opt ...handlers.Option) (*pb.Credential, error) {
+	attrs := &pb.UsernamePasswordCredentialAttributes{}
+	if err := handlers.StructToProto(item.GetAttributes(), attrs); err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Failed converting attributes to subtype proto: %s", err)
+	}
+	c := &static.UsernamePasswordCredential{
+		UsernamePasswordCredential: &store.UsernamePasswordCredential{
+			PublicId:    id,
+			StoreId:     storeId,
+			Name:        item.GetName().GetValue(),
+			Description: item.GetDescription().GetValue(),
+			Username:    attrs.GetUsername().GetValue(),
+			Password:    attrs.GetPassword().GetValue(),
+		},
+	}
+	dbMask := maskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, rowsUpdated, err := repo.UpdateCredential(ctx, scopeId, c, item.GetVersion(), dbMask)
+	if err != nil {
+		return nil, fmt.Errorf("unable to update credential: %w", err)
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Credential %q doesn't exist or incorrect version provided.", id)
+	}
+	return toProto(out, opt...)
+}
+
+func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool, error) {
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return false, err
+	}
+	rows, err := repo.DeleteCredential(ctx, scopeId, id)
+	if err != nil {
+		return false, fmt.Errorf("unable to delete credential: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (s Service) listFromRepo(ctx context.Context, storeId string, afterId string, limit int) ([]*static.UsernamePasswordCredential, error) {
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	cl, err := repo.ListCredentials(ctx, storeId, static.WithStartPageAfterId(afterId), static.WithLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	return cl, nil
+}
+
+func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Type) (*static.CredentialStore, auth.VerifyResults) {
+	res := auth.VerifyResults{}
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		res.Error = err
+		return nil, res
+	}
+
+	var parentId string
+	opts := []auth.Option{auth.WithType(resource.Credential), auth.WithAction(a)}
+	switch a {
+	case action.List, action.Create:
+		parentId = id
+	default:
+		c, err := repo.LookupCredential(ctx, id)
+		if err != nil {
+			res.Error = err
+			return nil, res
+		}
+		if c == nil {
+			res.Error = handlers.NotFoundError()
+			return nil, res
+		}
+		parentId = c.GetStoreId()
+		opts = append(opts, auth.WithId(id))
+	}
+
+	cs, err := repo.LookupCredentialStore(ctx, parentId)
+	if err != nil {
+		res.Error = err
+		return nil, res
+	}
+	if cs == nil {
+		res.Error = handlers.NotFoundError()
+		return nil, res
+	}
+	opts = append(opts, auth.WithScopeId(cs.GetScopeId()), auth.WithPin(parentId))
+	return cs, auth.Verify(ctx, opts...)
+}
+
+func toProto(in *static.UsernamePasswordCredential, opt ...handlers.Option) (*pb.Credential, error) {
+	opts := handlers.GetOpts(opt...)
+	outputFields := opts.WithOutputFields
+	out := pb.Credential{}
+	if outputFields.Has("id") {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has("credential_store_id") {
+		out.CredentialStoreId = in.GetStoreId()
+	}
+	if outputFields.Has("scope") {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has("type") {
+		out.Type = credential.UsernamePasswordType
+	}
+	if outputFields.Has("created_time") {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
+	}
+	if outputFields.Has("updated_time") {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has("version") {
+		out.Version = in.GetVersion()
+	}
+	if outputFields.Has("description") && in.GetDescription() != "" {
+		out.Description = wrapperspb.String(in.GetDescription())
+	}
+	if outputFields.Has("name") && in.GetName() != "" {
+		out.Name = wrapperspb.String(in.GetName())
+	}
+	if outputFields.Has("authorized_actions") {
+		out.AuthorizedActions = opts.WithAuthorizedActions
+	}
+	if outputFields.Has("attributes") {
+		st, err := handlers.ProtoToStruct(&pb.UsernamePasswordCredentialAttributes{
+			Username:     wrapperspb.String(in.GetUsername()),
+			PasswordHmac: in.GetPasswordHmac(),
+		})
+		if err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to convert username password attribute to struct: %s", err)
+		}
+		out.Attributes = st
+	}
+	return &out, nil
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
+//   - The type asserted by the ID and/or field is known
+//   - If relevant, the type derived from the id prefix matches what is claimed by the type field
+func validateGetRequest(req *pbs.GetCredentialRequest) error {
+	return handlers.ValidateGetRequest(static.UsernamePasswordCredentialPrefix, req, handlers.NoopValidatorFn)
+}
+
+func validateCreateRequest(req *pbs.CreateCredentialRequest) error {
+	return handlers.ValidateCreateRequest(req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		if !handlers.ValidId(static.CredentialStorePrefix, req.GetItem().GetCredentialStoreId()) {
+			badFields["credential_store_id"] = "The field is incorrectly formatted."
+		}
+		if req.GetItem().GetType() != "" && req.GetItem().GetType() != credential.UsernamePasswordType {
+			badFields["type"] = fmt.Sprintf("If set, this must be %q.", credential.UsernamePasswordType)
+		}
+		attrs := &pb.UsernamePasswordCredentialAttributes{}
+		if err := handlers.StructToProto(req.GetItem().GetAttributes(), attrs); err != nil {
+			badFields["attributes"] = "Attribute fields do not match the expected format."
+			return badFields
+		}
+		if strings.TrimSpace(attrs.GetUsername().GetValue()) == "" {
+			badFields["attributes.username"] = "This is a required field."
+		}
+		if attrs.GetPassword().GetValue() == "" {
+			badFields["attributes.password"] = "This is a required field."
+		}
+		if attrs.GetPasswordHmac() != "" {
+			badFields["attributes.password_hmac"] = "This is a read only field."
+		}
+		return badFields
+	})
+}
+
+func validateUpdateRequest(req *pbs.UpdateCredentialRequest) error {
+	return handlers.ValidateUpdateRequest(static.UsernamePasswordCredentialPrefix, req, req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		if req.GetItem().GetType() != "" && req.GetItem().GetType() != credential.UsernamePasswordType {
+			badFields["type"] = "Cannot modify the resource type."
+		}
+		attrs := &pb.UsernamePasswordCredentialAttributes{}
+		if err := handlers.StructToProto(req.GetItem().GetAttributes(), attrs); err != nil {
+			badFields["attributes"] = "Attribute fields do not match the expected format."
+			return badFields
+		}
+		if handlers.MaskContains(req.GetUpdateMask().GetPaths(), "attributes.username") &&
+			strings.TrimSpace(attrs.GetUsername().GetValue()) == "" {
+			badFields["attributes.username"] = "This field cannot be cleared."
+		}
+		if handlers.MaskContains(req.GetUpdateMask().GetPaths(), "attributes.password") &&
+			attrs.GetPassword().GetValue() == "" {
+			badFields["attributes.password"] = "This field cannot be cleared."
+		}
+		if attrs.GetPasswordHmac() != "" {
+			badFields["attributes.password_hmac"] = "This is a read only field."
+		}
+		return badFields
+	})
+}
+
+func validateDeleteRequest(req *pbs.DeleteCredentialRequest) error {
+	return handlers.ValidateDeleteRequest(static.UsernamePasswordCredentialPrefix, req, handlers.NoopValidatorFn)
+}
+
+func validateListRequest(req *pbs.ListCredentialsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(static.CredentialStorePrefix, req.GetCredentialStoreId()) {
+		badFields["credential_store_id"] = "The field is incorrectly formatted."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
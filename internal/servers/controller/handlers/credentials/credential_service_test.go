@@ -0,0 +1,171 @@
+package credentials_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/credential/static"
+	"github.com/hashicorp/boundary/internal/db"
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/credentials"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func testService(t *testing.T) (credentials.Service, *iam.Scope, *static.CredentialStore) {
+	t.Helper()
+	require := require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	rw := db.New(conn)
+	repoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	s, err := credentials.NewService(repoFn)
+	require.NoError(err, "Couldn't create a new credential service.")
+
+	cs := static.TestCredentialStores(t, conn, proj.GetPublicId(), 1)[0]
+	return s, proj, cs
+}
+
+func attributes(t *testing.T, m map[string]interface{}) *structpb.Struct {
+	t.Helper()
+	st, err := structpb.NewStruct(m)
+	require.NoError(t, err)
+	return st
+}
+
+func TestCreate(t *testing.T) {
+	s, proj, cs := testService(t)
+
+	cases := []struct {
+		name string
+		item *pb.Credential
+		err  error
+	}{
+		{
+			name: "Create a valid credential",
+			item: &pb.Credential{
+				CredentialStoreId: cs.GetPublicId(),
+				Name:              &wrappers.StringValue{Value: "name"},
+				Attributes:        attributes(t, map[string]interface{}{"username": "admin", "password": "secret"}),
+			},
+		},
+		{
+			name: "Missing password",
+			item: &pb.Credential{
+				CredentialStoreId: cs.GetPublicId(),
+				Attributes:        attributes(t, map[string]interface{}{"username": "admin"}),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Missing username",
+			item: &pb.Credential{
+				CredentialStoreId: cs.GetPublicId(),
+				Attributes:        attributes(t, map[string]interface{}{"password": "secret"}),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Bad store id",
+			item: &pb.Credential{
+				CredentialStoreId: "cs_1234567890",
+				Attributes:        attributes(t, map[string]interface{}{"username": "admin", "password": "secret"}),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Read only password hmac",
+			item: &pb.Credential{
+				CredentialStoreId: cs.GetPublicId(),
+				Attributes:        attributes(t, map[string]interface{}{"username": "admin", "password": "secret", "password_hmac": "hmac"}),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.CreateCredential(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), &pbs.CreateCredentialRequest{Item: tc.item})
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "CreateCredential(%+v) got error %v, wanted %v", tc.item, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			attrs := got.GetItem().GetAttributes().AsMap()
+			assert.Equal("admin", attrs["username"])
+			assert.NotContains(attrs, "password")
+			assert.NotEmpty(attrs["password_hmac"])
+			assert.Equal("username_password", got.GetItem().GetType())
+		})
+	}
+}
+
+func TestUpdateAndGet(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	s, proj, cs := testService(t)
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId()))
+
+	created, err := s.CreateCredential(ctx, &pbs.CreateCredentialRequest{Item: &pb.Credential{
+		CredentialStoreId: cs.GetPublicId(),
+		Attributes:        attributes(t, map[string]interface{}{"username": "admin", "password": "secret"}),
+	}})
+	require.NoError(err)
+	origHmac := created.GetItem().GetAttributes().AsMap()["password_hmac"]
+
+	updated, err := s.UpdateCredential(ctx, &pbs.UpdateCredentialRequest{
+		Id:         created.GetItem().GetId(),
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"attributes.password"}},
+		Item: &pb.Credential{
+			Version:    created.GetItem().GetVersion(),
+			Attributes: attributes(t, map[string]interface{}{"password": "changed"}),
+		},
+	})
+	require.NoError(err)
+	assert.NotEqual(origHmac, updated.GetItem().GetAttributes().AsMap()["password_hmac"])
+	assert.Equal("admin", updated.GetItem().GetAttributes().AsMap()["username"])
+
+	_, err = s.UpdateCredential(ctx, &pbs.UpdateCredentialRequest{
+		Id:         created.GetItem().GetId(),
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"attributes.username"}},
+		Item: &pb.Credential{
+			Version: updated.GetItem().GetVersion(),
+		},
+	})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v", err)
+
+	got, err := s.GetCredential(ctx, &pbs.GetCredentialRequest{Id: created.GetItem().GetId()})
+	require.NoError(err)
+	assert.Equal(updated.GetItem().GetVersion(), got.GetItem().GetVersion())
+	assert.NotContains(got.GetItem().GetAttributes().AsMap(), "password")
+
+	list, err := s.ListCredentials(ctx, &pbs.ListCredentialsRequest{CredentialStoreId: cs.GetPublicId()})
+	require.NoError(err)
+	assert.Len(list.GetItems(), 1)
+
+	_, err = s.DeleteCredential(ctx, &pbs.DeleteCredentialRequest{Id: created.GetItem().GetId()})
+	require.NoError(err)
+	_, err = s.GetCredential(ctx, &pbs.GetCredentialRequest{Id: created.GetItem().GetId()})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.NotFound)), "got error %v", err)
+}
+
+func TestList_BadStoreId(t *testing.T) {
+	s, proj, _ := testService(t)
+	_, err := s.ListCredentials(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), &pbs.ListCredentialsRequest{CredentialStoreId: "bad"})
+	assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v", err)
+}
@@ -22,6 +22,17 @@ import (
 
 var (
 	maskManager handlers.MaskManager
+
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.Read,
+		action.Update,
+		action.Delete,
+		action.AddMembers,
+		action.SetMembers,
+		action.RemoveMembers,
+	}
 )
 
 func init() {
@@ -54,17 +65,41 @@ func (s Service) ListGroups(ctx context.Context, req *pbs.ListGroupsRequest) (*p
 		return nil, err
 	}
 	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
-	if authResults.Error != nil {
-		return nil, authResults.Error
+	scopeIds, scopeInfos, err := authResults.ScopesForList(ctx, resource.Group, req.GetRecursive())
+	if err != nil {
+		return nil, err
+	}
+	if len(scopeIds) == 0 {
+		return &pbs.ListGroupsResponse{}, nil
+	}
+	fetch := func(afterId string, limit int) ([]handlers.ListItem, error) {
+		gl, err := s.listFromRepo(ctx, scopeIds, afterId, limit)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]handlers.ListItem, 0, len(gl))
+		for _, g := range gl {
+			resOpt := auth.WithScopeId(g.GetScopeId())
+			if authResults.DeniedForId(ctx, g.GetPublicId(), action.Read, resOpt) {
+				items = append(items, handlers.HiddenListItem(g.GetPublicId()))
+				continue
+			}
+			items = append(items, handlers.ListedItem(g.GetPublicId(), toProto(g, nil,
+				handlers.WithScope(scopeInfos[g.GetScopeId()]),
+				handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, g.GetPublicId(), IdActions, resOpt).Strings()),
+				handlers.WithOutputFields(authResults.FetchOutputFields(ctx, g.GetPublicId(), resOpt)))))
+		}
+		return items, nil
 	}
-	gl, err := s.listFromRepo(ctx, req.GetScopeId())
+	items, next, err := handlers.ListPage(fetch, req.GetFilter(), req.GetPageSize(), req.GetPageToken())
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range gl {
-		item.Scope = authResults.Scope
+	var gl []*pb.Group
+	for _, item := range items {
+		gl = append(gl, item.(*pb.Group))
 	}
-	return &pbs.ListGroupsResponse{Items: gl}, nil
+	return &pbs.ListGroupsResponse{Items: gl, NextPageToken: next}, nil
 }
 
 // GetGroups implements the interface pbs.GroupServiceServer.
@@ -76,11 +111,13 @@ func (s Service) GetGroup(ctx context.Context, req *pbs.GetGroupRequest) (*pbs.G
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	u, err := s.getFromRepo(ctx, req.GetId())
+	u, err := s.getFromRepo(ctx, req.GetId(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, req.GetId(), IdActions).Strings()),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	u.Scope = authResults.Scope
 	return &pbs.GetGroupResponse{Item: u}, nil
 }
 
@@ -93,11 +130,12 @@ func (s Service) CreateGroup(ctx context.Context, req *pbs.CreateGroupRequest) (
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	u, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem())
+	u, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, "")))
 	if err != nil {
 		return nil, err
 	}
-	u.Scope = authResults.Scope
 	return &pbs.CreateGroupResponse{Item: u, Uri: fmt.Sprintf("groups/%s", u.GetId())}, nil
 }
 
@@ -110,11 +148,12 @@ func (s Service) UpdateGroup(ctx context.Context, req *pbs.UpdateGroupRequest) (
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	u, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	u, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	u.Scope = authResults.Scope
 	return &pbs.UpdateGroupResponse{Item: u}, nil
 }
 
@@ -143,11 +182,12 @@ func (s Service) AddGroupMembers(ctx context.Context, req *pbs.AddGroupMembersRe
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	g, err := s.addMembersInRepo(ctx, req.GetId(), req.GetMemberIds(), req.GetVersion())
+	g, err := s.addMembersInRepo(ctx, req.GetId(), req.GetMemberIds(), req.GetVersion(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	g.Scope = authResults.Scope
 	return &pbs.AddGroupMembersResponse{Item: g}, nil
 }
 
@@ -160,11 +200,12 @@ func (s Service) SetGroupMembers(ctx context.Context, req *pbs.SetGroupMembersRe
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	g, err := s.setMembersInRepo(ctx, req.GetId(), req.GetMemberIds(), req.GetVersion())
+	g, err := s.setMembersInRepo(ctx, req.GetId(), req.GetMemberIds(), req.GetVersion(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	g.Scope = authResults.Scope
 	return &pbs.SetGroupMembersResponse{Item: g}, nil
 }
 
@@ -177,15 +218,16 @@ func (s Service) RemoveGroupMembers(ctx context.Context, req *pbs.RemoveGroupMem
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	g, err := s.removeMembersInRepo(ctx, req.GetId(), req.GetMemberIds(), req.GetVersion())
+	g, err := s.removeMembersInRepo(ctx, req.GetId(), req.GetMemberIds(), req.GetVersion(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	g.Scope = authResults.Scope
 	return &pbs.RemoveGroupMembersResponse{Item: g}, nil
 }
 
-func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Group, error) {
+func (s Service) getFromRepo(ctx context.Context, id string, opt ...handlers.Option) (*pb.Group, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -200,10 +242,10 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Group, error)
 	if g == nil {
 		return nil, handlers.NotFoundErrorf("Group %q doesn't exist.", id)
 	}
-	return toProto(g, m), nil
+	return toProto(g, m, opt...), nil
 }
 
-func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Group) (*pb.Group, error) {
+func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Group, opt ...handlers.Option) (*pb.Group, error) {
 	var opts []iam.Option
 	if item.GetName() != nil {
 		opts = append(opts, iam.WithName(item.GetName().GetValue()))
@@ -211,6 +253,12 @@ func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Grou
 	if item.GetDescription() != nil {
 		opts = append(opts, iam.WithDescription(item.GetDescription().GetValue()))
 	}
+	if item.GetDirectoryType() != nil {
+		opts = append(opts, iam.WithDirectoryType(item.GetDirectoryType().GetValue()))
+	}
+	if item.GetDirectoryGroup() != nil {
+		opts = append(opts, iam.WithDirectoryGroup(item.GetDirectoryGroup().GetValue()))
+	}
 	u, err := iam.NewGroup(scopeId, opts...)
 	if err != nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build group for creation: %v.", err)
@@ -226,10 +274,10 @@ func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Grou
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create group but no error returned from repository.")
 	}
-	return toProto(out, nil), nil
+	return toProto(out, nil, opt...), nil
 }
 
-func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.Group) (*pb.Group, error) {
+func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.Group, opt ...handlers.Option) (*pb.Group, error) {
 	var opts []iam.Option
 	if desc := item.GetDescription(); desc != nil {
 		opts = append(opts, iam.WithDescription(desc.GetValue()))
@@ -237,6 +285,12 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	if name := item.GetName(); name != nil {
 		opts = append(opts, iam.WithName(name.GetValue()))
 	}
+	if dirType := item.GetDirectoryType(); dirType != nil {
+		opts = append(opts, iam.WithDirectoryType(dirType.GetValue()))
+	}
+	if dirGroup := item.GetDirectoryGroup(); dirGroup != nil {
+		opts = append(opts, iam.WithDirectoryGroup(dirGroup.GetValue()))
+	}
 	version := item.GetVersion()
 	g, err := iam.NewGroup(scopeId, opts...)
 	if err != nil {
@@ -258,7 +312,7 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	if rowsUpdated == 0 {
 		return nil, handlers.NotFoundErrorf("Group %q doesn't exist or incorrect version provided.", id)
 	}
-	return toProto(out, m), nil
+	return toProto(out, m, opt...), nil
 }
 
 func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
@@ -276,23 +330,19 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeId string) ([]*pb.Group, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, afterId string, limit int) ([]*iam.Group, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	gl, err := repo.ListGroups(ctx, scopeId)
+	gl, err := repo.ListGroups(ctx, scopeIds, iam.WithStartPageAfterId(afterId), iam.WithLimit(limit))
 	if err != nil {
 		return nil, fmt.Errorf("unable to list groups: %w", err)
 	}
-	var outGl []*pb.Group
-	for _, g := range gl {
-		outGl = append(outGl, toProto(g, nil))
-	}
-	return outGl, nil
+	return gl, nil
 }
 
-func (s Service) addMembersInRepo(ctx context.Context, groupId string, userIds []string, version uint32) (*pb.Group, error) {
+func (s Service) addMembersInRepo(ctx context.Context, groupId string, userIds []string, version uint32, opt ...handlers.Option) (*pb.Group, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -309,10 +359,10 @@ func (s Service) addMembersInRepo(ctx context.Context, groupId string, userIds [
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup group after adding member to it.")
 	}
-	return toProto(out, m), nil
+	return toProto(out, m, opt...), nil
 }
 
-func (s Service) setMembersInRepo(ctx context.Context, groupId string, userIds []string, version uint32) (*pb.Group, error) {
+func (s Service) setMembersInRepo(ctx context.Context, groupId string, userIds []string, version uint32, opt ...handlers.Option) (*pb.Group, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -329,10 +379,10 @@ func (s Service) setMembersInRepo(ctx context.Context, groupId string, userIds [
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup group after setting members for it.")
 	}
-	return toProto(out, m), nil
+	return toProto(out, m, opt...), nil
 }
 
-func (s Service) removeMembersInRepo(ctx context.Context, groupId string, userIds []string, version uint32) (*pb.Group, error) {
+func (s Service) removeMembersInRepo(ctx context.Context, groupId string, userIds []string, version uint32, opt ...handlers.Option) (*pb.Group, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -349,7 +399,7 @@ func (s Service) removeMembersInRepo(ctx context.Context, groupId string, userId
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup group after removing members from it.")
 	}
-	return toProto(out, m), nil
+	return toProto(out, m, opt...), nil
 }
 
 func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
@@ -391,35 +441,62 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	return auth.Verify(ctx, opts...)
 }
 
-func toProto(in *iam.Group, members []*iam.GroupMember) *pb.Group {
-	out := pb.Group{
-		Id:          in.GetPublicId(),
-		ScopeId:     in.GetScopeId(),
-		CreatedTime: in.GetCreateTime().GetTimestamp(),
-		UpdatedTime: in.GetUpdateTime().GetTimestamp(),
-		Version:     in.Version,
+func toProto(in *iam.Group, members []*iam.GroupMember, opt ...handlers.Option) *pb.Group {
+	opts := handlers.GetOpts(opt...)
+	outputFields := opts.WithOutputFields
+	out := pb.Group{}
+	if outputFields.Has("id") {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has("scope_id") {
+		out.ScopeId = in.GetScopeId()
+	}
+	if outputFields.Has("scope") {
+		out.Scope = opts.WithScope
 	}
-	if in.GetDescription() != "" {
+	if outputFields.Has("created_time") {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
+	}
+	if outputFields.Has("updated_time") {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has("version") {
+		out.Version = in.Version
+	}
+	if outputFields.Has("description") && in.GetDescription() != "" {
 		out.Description = &wrapperspb.StringValue{Value: in.GetDescription()}
 	}
-	if in.GetName() != "" {
+	if outputFields.Has("name") && in.GetName() != "" {
 		out.Name = &wrapperspb.StringValue{Value: in.GetName()}
 	}
+	if outputFields.Has("directory_type") && in.GetDirectoryType() != "" {
+		out.DirectoryType = &wrapperspb.StringValue{Value: in.GetDirectoryType()}
+	}
+	if outputFields.Has("directory_group") && in.GetDirectoryGroup() != "" {
+		out.DirectoryGroup = &wrapperspb.StringValue{Value: in.GetDirectoryGroup()}
+	}
 	for _, m := range members {
-		out.MemberIds = append(out.MemberIds, m.GetMemberId())
-		out.Members = append(out.Members, &pb.Member{
-			Id:      m.GetMemberId(),
-			ScopeId: m.GetMemberScopeId(),
-		})
+		if outputFields.Has("member_ids") {
+			out.MemberIds = append(out.MemberIds, m.GetMemberId())
+		}
+		if outputFields.Has("members") {
+			out.Members = append(out.Members, &pb.Member{
+				Id:      m.GetMemberId(),
+				ScopeId: m.GetMemberScopeId(),
+			})
+		}
+	}
+	if outputFields.Has("authorized_actions") {
+		out.AuthorizedActions = opts.WithAuthorizedActions
 	}
 	return &out
 }
 
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
-//  * The path passed in is correctly formatted
-//  * All required parameters are set
-//  * There are no conflicting parameters provided
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
 func validateGetRequest(req *pbs.GetGroupRequest) error {
 	return handlers.ValidateGetRequest(iam.GroupPrefix, req, handlers.NoopValidatorFn)
 }
@@ -432,12 +509,36 @@ func validateCreateRequest(req *pbs.CreateGroupRequest) error {
 			scope.Global.String() != req.GetItem().GetScopeId() {
 			badFields["scope_id"] = "This field is missing or improperly formatted."
 		}
+		item := req.GetItem()
+		if (item.GetDirectoryType() == nil) != (item.GetDirectoryGroup() == nil) {
+			badFields["directory_type"] = "Directory type and directory group must be set together."
+		}
+		validateDirectory(item, badFields)
 		return badFields
 	})
 }
 
 func validateUpdateRequest(req *pbs.UpdateGroupRequest) error {
-	return handlers.ValidateUpdateRequest(iam.GroupPrefix, req, req.GetItem(), handlers.NoopValidatorFn)
+	return handlers.ValidateUpdateRequest(iam.GroupPrefix, req, req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		validateDirectory(req.GetItem(), badFields)
+		return badFields
+	})
+}
+
+// validateDirectory adds an entry to badFields if the group is bound to an
+// unknown type of directory or to an empty directory group.
+func validateDirectory(item *pb.Group, badFields map[string]string) {
+	if dirType := item.GetDirectoryType(); dirType != nil {
+		switch dirType.GetValue() {
+		case iam.DirectoryLdap, iam.DirectoryScim:
+		default:
+			badFields["directory_type"] = fmt.Sprintf("Must be %q or %q.", iam.DirectoryLdap, iam.DirectoryScim)
+		}
+	}
+	if dirGroup := item.GetDirectoryGroup(); dirGroup != nil && dirGroup.GetValue() == "" {
+		badFields["directory_group"] = "This field cannot be empty."
+	}
 }
 
 func validateDeleteRequest(req *pbs.DeleteGroupRequest) error {
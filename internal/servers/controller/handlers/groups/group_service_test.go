@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"testing"
 
@@ -29,6 +30,8 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+var testAuthorizedActions = []string{"read", "update", "delete", "add-members", "set-members", "remove-members"}
+
 // Creates an org scoped group and a project scoped group.
 func createDefaultGroupsAndRepo(t *testing.T) (*iam.Group, *iam.Group, func() (*iam.Repository, error)) {
 	t.Helper()
@@ -106,6 +109,7 @@ func TestGet(t *testing.T) {
 				ScopeId: u.GetScopeId(),
 			},
 		},
+		AuthorizedActions: testAuthorizedActions,
 	}
 
 	wantProjGroup := &pb.Group{
@@ -124,6 +128,7 @@ func TestGet(t *testing.T) {
 				ScopeId: u.GetScopeId(),
 			},
 		},
+		AuthorizedActions: testAuthorizedActions,
 	}
 
 	cases := []struct {
@@ -218,24 +223,30 @@ func TestList(t *testing.T) {
 	for i := 0; i < 10; i++ {
 		og := iam.TestGroup(t, conn, oWithGroups.GetPublicId())
 		wantOrgGroups = append(wantOrgGroups, &pb.Group{
-			Id:          og.GetPublicId(),
-			ScopeId:     og.GetScopeId(),
-			Scope:       &scopes.ScopeInfo{Id: oWithGroups.GetPublicId(), Type: scope.Org.String()},
-			CreatedTime: og.GetCreateTime().GetTimestamp(),
-			UpdatedTime: og.GetUpdateTime().GetTimestamp(),
-			Version:     1,
+			Id:                og.GetPublicId(),
+			ScopeId:           og.GetScopeId(),
+			Scope:             &scopes.ScopeInfo{Id: oWithGroups.GetPublicId(), Type: scope.Org.String()},
+			CreatedTime:       og.GetCreateTime().GetTimestamp(),
+			UpdatedTime:       og.GetUpdateTime().GetTimestamp(),
+			Version:           1,
+			AuthorizedActions: testAuthorizedActions,
 		})
 		pg := iam.TestGroup(t, conn, pWithGroups.GetPublicId())
 		wantProjGroups = append(wantProjGroups, &pb.Group{
-			Id:          pg.GetPublicId(),
-			ScopeId:     pg.GetScopeId(),
-			Scope:       &scopes.ScopeInfo{Id: pWithGroups.GetPublicId(), Type: scope.Project.String()},
-			CreatedTime: pg.GetCreateTime().GetTimestamp(),
-			UpdatedTime: pg.GetUpdateTime().GetTimestamp(),
-			Version:     1,
+			Id:                pg.GetPublicId(),
+			ScopeId:           pg.GetScopeId(),
+			Scope:             &scopes.ScopeInfo{Id: pWithGroups.GetPublicId(), Type: scope.Project.String()},
+			CreatedTime:       pg.GetCreateTime().GetTimestamp(),
+			UpdatedTime:       pg.GetUpdateTime().GetTimestamp(),
+			Version:           1,
+			AuthorizedActions: testAuthorizedActions,
 		})
 	}
 
+	// Listed resources are ordered by id
+	sort.Slice(wantOrgGroups, func(i, j int) bool { return wantOrgGroups[i].GetId() < wantOrgGroups[j].GetId() })
+	sort.Slice(wantProjGroups, func(i, j int) bool { return wantProjGroups[i].GetId() < wantProjGroups[j].GetId() })
+
 	cases := []struct {
 		name    string
 		scopeId string
@@ -443,6 +454,43 @@ func TestCreate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Create a directory bound Group",
+			req: &pbs.CreateGroupRequest{Item: &pb.Group{
+				ScopeId:        defaultOGroup.GetScopeId(),
+				DirectoryType:  &wrapperspb.StringValue{Value: iam.DirectoryLdap},
+				DirectoryGroup: &wrapperspb.StringValue{Value: "cn=admins,dc=example,dc=com"},
+			}},
+			res: &pbs.CreateGroupResponse{
+				Uri: fmt.Sprintf("groups/%s_", iam.GroupPrefix),
+				Item: &pb.Group{
+					ScopeId:        defaultOGroup.GetScopeId(),
+					Scope:          &scopes.ScopeInfo{Id: defaultOGroup.GetScopeId(), Type: scope.Org.String()},
+					DirectoryType:  &wrapperspb.StringValue{Value: iam.DirectoryLdap},
+					DirectoryGroup: &wrapperspb.StringValue{Value: "cn=admins,dc=example,dc=com"},
+					Version:        1,
+				},
+			},
+		},
+		{
+			name: "Unknown directory type",
+			req: &pbs.CreateGroupRequest{Item: &pb.Group{
+				ScopeId:        defaultOGroup.GetScopeId(),
+				DirectoryType:  &wrapperspb.StringValue{Value: "ad"},
+				DirectoryGroup: &wrapperspb.StringValue{Value: "admins"},
+			}},
+			res: nil,
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Directory type without directory group",
+			req: &pbs.CreateGroupRequest{Item: &pb.Group{
+				ScopeId:       defaultOGroup.GetScopeId(),
+				DirectoryType: &wrapperspb.StringValue{Value: iam.DirectoryScim},
+			}},
+			res: nil,
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
 		{
 			name: "Can't specify Id",
 			req: &pbs.CreateGroupRequest{Item: &pb.Group{
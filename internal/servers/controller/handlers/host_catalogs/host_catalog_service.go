@@ -3,11 +3,16 @@ package host_catalogs
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/hostcatalogs"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/host"
+	"github.com/hashicorp/boundary/internal/host/plugin"
+	pluginstore "github.com/hashicorp/boundary/internal/host/plugin/store"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/host/static/store"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
@@ -16,11 +21,21 @@ import (
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 var (
-	maskManager handlers.MaskManager
+	maskManager       handlers.MaskManager
+	pluginMaskManager handlers.MaskManager
+
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.Read,
+		action.Update,
+		action.Delete,
+	}
 )
 
 func init() {
@@ -28,12 +43,16 @@ func init() {
 	if maskManager, err = handlers.NewMaskManager(&store.HostCatalog{}, &pb.HostCatalog{}); err != nil {
 		panic(err)
 	}
+	if pluginMaskManager, err = handlers.NewMaskManager(&pluginstore.HostCatalog{}, &pb.HostCatalog{}, &pb.PluginHostCatalogAttributes{}); err != nil {
+		panic(err)
+	}
 }
 
 type Service struct {
 	pbs.UnimplementedHostCatalogServiceServer
 
 	staticRepoFn common.StaticRepoFactory
+	pluginRepoFn common.PluginHostRepoFactory
 	iamRepoFn    common.IamRepoFactory
 }
 
@@ -41,14 +60,17 @@ var _ pbs.HostCatalogServiceServer = Service{}
 
 // NewService returns a host catalog Service which handles host catalog related requests to boundary and uses the provided
 // repositories for storage and retrieval.
-func NewService(repoFn common.StaticRepoFactory, iamRepoFn common.IamRepoFactory) (Service, error) {
+func NewService(repoFn common.StaticRepoFactory, pluginRepoFn common.PluginHostRepoFactory, iamRepoFn common.IamRepoFactory) (Service, error) {
 	if repoFn == nil {
 		return Service{}, fmt.Errorf("nil static repository provided")
 	}
+	if pluginRepoFn == nil {
+		return Service{}, fmt.Errorf("nil plugin host repository provided")
+	}
 	if iamRepoFn == nil {
 		return Service{}, fmt.Errorf("nil iam repository provided")
 	}
-	return Service{staticRepoFn: repoFn, iamRepoFn: iamRepoFn}, nil
+	return Service{staticRepoFn: repoFn, pluginRepoFn: pluginRepoFn, iamRepoFn: iamRepoFn}, nil
 }
 
 func (s Service) ListHostCatalogs(ctx context.Context, req *pbs.ListHostCatalogsRequest) (*pbs.ListHostCatalogsResponse, error) {
@@ -56,17 +78,36 @@ func (s Service) ListHostCatalogs(ctx context.Context, req *pbs.ListHostCatalogs
 		return nil, err
 	}
 	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
-	if authResults.Error != nil {
-		return nil, authResults.Error
+	scopeIds, scopeInfos, err := authResults.ScopesForList(ctx, resource.HostCatalog, req.GetRecursive())
+	if err != nil {
+		return nil, err
 	}
-	ul, err := s.listFromRepo(ctx, authResults.Scope.GetId())
+	if len(scopeIds) == 0 {
+		return &pbs.ListHostCatalogsResponse{}, nil
+	}
+	fetch := func(afterId string, limit int) ([]handlers.ListItem, error) {
+		itemOpts := func(id, scopeId string) ([]handlers.Option, bool) {
+			resOpt := auth.WithScopeId(scopeId)
+			if authResults.DeniedForId(ctx, id, action.Read, resOpt) {
+				return nil, false
+			}
+			return []handlers.Option{
+				handlers.WithScope(scopeInfos[scopeId]),
+				handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, id, IdActions, resOpt).Strings()),
+				handlers.WithOutputFields(authResults.FetchOutputFields(ctx, id, resOpt)),
+			}, true
+		}
+		return s.listFromRepo(ctx, scopeIds, afterId, limit, itemOpts)
+	}
+	items, next, err := handlers.ListPage(fetch, req.GetFilter(), req.GetPageSize(), req.GetPageToken())
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range ul {
-		item.Scope = authResults.Scope
+	var ul []*pb.HostCatalog
+	for _, item := range items {
+		ul = append(ul, item.(*pb.HostCatalog))
 	}
-	return &pbs.ListHostCatalogsResponse{Items: ul}, nil
+	return &pbs.ListHostCatalogsResponse{Items: ul, NextPageToken: next}, nil
 }
 
 // GetHostCatalog implements the interface pbs.HostCatalogServiceServer.
@@ -78,11 +119,13 @@ func (s Service) GetHostCatalog(ctx context.Context, req *pbs.GetHostCatalogRequ
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	hc, err := s.getFromRepo(ctx, req.GetId())
+	hc, err := s.getFromRepo(ctx, req.GetId(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, req.GetId(), IdActions).Strings()),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	hc.Scope = authResults.Scope
 	return &pbs.GetHostCatalogResponse{Item: hc}, nil
 }
 
@@ -95,11 +138,12 @@ func (s Service) CreateHostCatalog(ctx context.Context, req *pbs.CreateHostCatal
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	hc, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem())
+	hc, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, "")))
 	if err != nil {
 		return nil, err
 	}
-	hc.Scope = authResults.Scope
 	return &pbs.CreateHostCatalogResponse{
 		Item: hc,
 		Uri:  fmt.Sprintf("host-catalogs/%s", hc.GetId()),
@@ -115,11 +159,12 @@ func (s Service) UpdateHostCatalog(ctx context.Context, req *pbs.UpdateHostCatal
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	hc, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	hc, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	hc.Scope = authResults.Scope
 	return &pbs.UpdateHostCatalogResponse{Item: hc}, nil
 }
 
@@ -139,7 +184,10 @@ func (s Service) DeleteHostCatalog(ctx context.Context, req *pbs.DeleteHostCatal
 	return &pbs.DeleteHostCatalogResponse{}, nil
 }
 
-func (s Service) getFromRepo(ctx context.Context, id string) (*pb.HostCatalog, error) {
+func (s Service) getFromRepo(ctx context.Context, id string, opt ...handlers.Option) (*pb.HostCatalog, error) {
+	if host.SubtypeFromId(id) == host.PluginSubtype {
+		return s.getPluginFromRepo(ctx, id, opt...)
+	}
 	repo, err := s.staticRepoFn()
 	if err != nil {
 		return nil, err
@@ -151,26 +199,65 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*pb.HostCatalog, e
 	if hc == nil {
 		return nil, handlers.NotFoundErrorf("Host Catalog %q doesn't exist.", id)
 	}
-	return toProto(hc), nil
+	return toProto(hc, opt...), nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeId string) ([]*pb.HostCatalog, error) {
+// listFromRepo lists the host catalogs of the scopes, converting each with the
+// options itemOpts returns for its id and scope id, or hiding it when
+// itemOpts doesn't list it.
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, afterId string, limit int, itemOpts func(id, scopeId string) ([]handlers.Option, bool)) ([]handlers.ListItem, error) {
 	repo, err := s.staticRepoFn()
 	if err != nil {
 		return nil, err
 	}
-	ul, err := repo.ListCatalogs(ctx, scopeId)
+	ul, err := repo.ListCatalogs(ctx, scopeIds, static.WithStartPageAfterId(afterId), static.WithLimit(limit))
 	if err != nil {
 		return nil, err
 	}
-	var outUl []*pb.HostCatalog
+	var all []handlers.ListItem
 	for _, u := range ul {
-		outUl = append(outUl, toProto(u))
+		opts, ok := itemOpts(u.GetPublicId(), u.GetScopeId())
+		if !ok {
+			all = append(all, handlers.HiddenListItem(u.GetPublicId()))
+			continue
+		}
+		all = append(all, handlers.ListedItem(u.GetPublicId(), toProto(u, opts...)))
+	}
+	pluginRepo, err := s.pluginRepoFn()
+	if err != nil {
+		return nil, err
 	}
-	return outUl, nil
+	pl, err := pluginRepo.ListCatalogs(ctx, scopeIds, plugin.WithStartPageAfterId(afterId), plugin.WithLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range pl {
+		opts, ok := itemOpts(p.GetPublicId(), p.GetScopeId())
+		if !ok {
+			all = append(all, handlers.HiddenListItem(p.GetPublicId()))
+			continue
+		}
+		hc, err := pluginToProto(p, opts...)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, handlers.ListedItem(p.GetPublicId(), hc))
+	}
+	// The results of both repositories are ordered by id, so the first limit
+	// of them combined are the next resources to list.
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].GetId() < all[j].GetId()
+	})
+	if limit > 0 && len(all) > limit {
+		all = all[:limit]
+	}
+	return all, nil
 }
 
-func (s Service) createInRepo(ctx context.Context, projId string, item *pb.HostCatalog) (*pb.HostCatalog, error) {
+func (s Service) createInRepo(ctx context.Context, projId string, item *pb.HostCatalog, opt ...handlers.Option) (*pb.HostCatalog, error) {
+	if host.SubtypeFromType(item.GetType()) == host.PluginSubtype {
+		return s.createPluginInRepo(ctx, projId, item, opt...)
+	}
 	var opts []static.Option
 	if item.GetName() != nil {
 		opts = append(opts, static.WithName(item.GetName().GetValue()))
@@ -193,10 +280,13 @@ func (s Service) createInRepo(ctx context.Context, projId string, item *pb.HostC
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create host catalog but no error returned from repository.")
 	}
-	return toProto(out), nil
+	return toProto(out, opt...), nil
 }
 
-func (s Service) updateInRepo(ctx context.Context, projId, id string, mask []string, item *pb.HostCatalog) (*pb.HostCatalog, error) {
+func (s Service) updateInRepo(ctx context.Context, projId, id string, mask []string, item *pb.HostCatalog, opt ...handlers.Option) (*pb.HostCatalog, error) {
+	if host.SubtypeFromId(id) == host.PluginSubtype {
+		return s.updatePluginInRepo(ctx, projId, id, mask, item, opt...)
+	}
 	var opts []static.Option
 	if desc := item.GetDescription(); desc != nil {
 		opts = append(opts, static.WithDescription(desc.GetValue()))
@@ -225,19 +315,136 @@ func (s Service) updateInRepo(ctx context.Context, projId, id string, mask []str
 	if rowsUpdated == 0 {
 		return nil, handlers.NotFoundErrorf("Host Catalog %q doesn't exist or incorrect version provided.", id)
 	}
-	return toProto(out), nil
+	return toProto(out, opt...), nil
 }
 
 func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
-	repo, err := s.staticRepoFn()
+	var rows int
+	switch host.SubtypeFromId(id) {
+	case host.PluginSubtype:
+		repo, err := s.pluginRepoFn()
+		if err != nil {
+			return false, err
+		}
+		if rows, err = repo.DeleteCatalog(ctx, id); err != nil {
+			return false, fmt.Errorf("unable to delete host catalog: %w", err)
+		}
+	default:
+		repo, err := s.staticRepoFn()
+		if err != nil {
+			return false, err
+		}
+		if rows, err = repo.DeleteCatalog(ctx, id); err != nil {
+			return false, fmt.Errorf("unable to delete host: %w", err)
+		}
+	}
+	return rows > 0, nil
+}
+
+func (s Service) getPluginFromRepo(ctx context.Context, id string, opt ...handlers.Option) (*pb.HostCatalog, error) {
+	repo, err := s.pluginRepoFn()
 	if err != nil {
-		return false, err
+		return nil, err
 	}
-	rows, err := repo.DeleteCatalog(ctx, id)
+	hc, err := repo.LookupCatalog(ctx, id)
 	if err != nil {
-		return false, fmt.Errorf("unable to delete host: %w", err)
+		return nil, err
 	}
-	return rows > 0, nil
+	if hc == nil {
+		return nil, handlers.NotFoundErrorf("Host Catalog %q doesn't exist.", id)
+	}
+	return pluginToProto(hc, opt...)
+}
+
+func (s Service) createPluginInRepo(ctx context.Context, projId string, item *pb.HostCatalog, opt ...handlers.Option) (*pb.HostCatalog, error) {
+	attrs := &pb.PluginHostCatalogAttributes{}
+	if err := handlers.StructToProto(item.GetAttributes(), attrs); err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes": "Attribute fields do not match the expected format."})
+	}
+	var opts []plugin.Option
+	if item.GetName() != nil {
+		opts = append(opts, plugin.WithName(item.GetName().GetValue()))
+	}
+	if item.GetDescription() != nil {
+		opts = append(opts, plugin.WithDescription(item.GetDescription().GetValue()))
+	}
+	if attrs.GetPluginConfig() != nil {
+		opts = append(opts, plugin.WithAttributes(attrs.GetPluginConfig().AsMap()))
+	}
+	h, err := plugin.NewHostCatalog(projId, attrs.GetPluginName().GetValue(), opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build host catalog for creation: %v.", err)
+	}
+	repo, err := s.pluginRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.CreateCatalog(ctx, h)
+	if err != nil {
+		if apiErr := pluginErrorToApiError(err); apiErr != nil {
+			return nil, apiErr
+		}
+		return nil, fmt.Errorf("unable to create host catalog: %w", err)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create host catalog but no error returned from repository.")
+	}
+	return pluginToProto(out, opt...)
+}
+
+func (s Service) updatePluginInRepo(ctx context.Context, projId, id string, mask []string, item *pb.HostCatalog, opt ...handlers.Option) (*pb.HostCatalog, error) {
+	attrs := &pb.PluginHostCatalogAttributes{}
+	if err := handlers.StructToProto(item.GetAttributes(), attrs); err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes": "Attribute fields do not match the expected format."})
+	}
+	h := &plugin.HostCatalog{
+		HostCatalog: &pluginstore.HostCatalog{
+			PublicId:    id,
+			ScopeId:     projId,
+			Name:        item.GetName().GetValue(),
+			Description: item.GetDescription().GetValue(),
+		},
+	}
+	if err := h.SetAttributes(attrs.GetPluginConfig().AsMap()); err != nil {
+		return nil, fmt.Errorf("unable to build host catalog for update: %w", err)
+	}
+	version := item.GetVersion()
+	dbMask := pluginMaskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+	repo, err := s.pluginRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, rowsUpdated, err := repo.UpdateCatalog(ctx, h, version, dbMask)
+	if err != nil {
+		if apiErr := pluginErrorToApiError(err); apiErr != nil {
+			return nil, apiErr
+		}
+		return nil, fmt.Errorf("unable to update host catalog: %w", err)
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Host Catalog %q doesn't exist or incorrect version provided.", id)
+	}
+	return pluginToProto(out, opt...)
+}
+
+// pluginErrorToApiError converts the errors returned when a plugin rejects
+// the attributes of a catalog into an invalid argument error. It returns
+// nil for all other errors.
+func pluginErrorToApiError(err error) error {
+	switch {
+	case errors.Is(err, plugin.ErrUnknownPlugin):
+		return handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes.plugin_name": "Unknown plugin."})
+	case errors.Is(err, plugin.ErrInvalidAttributes):
+		return handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes.plugin_config": fmt.Sprintf("Rejected by the plugin: %v.", err)})
+	}
+	return nil
 }
 
 func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
@@ -263,19 +470,40 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 			return res
 		}
 	default:
-		repo, err := s.staticRepoFn()
-		if err != nil {
-			res.Error = err
-			return res
-		}
-		cat, err := repo.LookupCatalog(ctx, id)
-		if err != nil {
-			res.Error = err
-			return res
-		}
-		if cat == nil {
-			res.Error = handlers.NotFoundError()
-			return res
+		var cat interface{ GetScopeId() string }
+		switch host.SubtypeFromId(id) {
+		case host.PluginSubtype:
+			repo, err := s.pluginRepoFn()
+			if err != nil {
+				res.Error = err
+				return res
+			}
+			pcat, err := repo.LookupCatalog(ctx, id)
+			if err != nil {
+				res.Error = err
+				return res
+			}
+			if pcat == nil {
+				res.Error = handlers.NotFoundError()
+				return res
+			}
+			cat = pcat
+		default:
+			repo, err := s.staticRepoFn()
+			if err != nil {
+				res.Error = err
+				return res
+			}
+			scat, err := repo.LookupCatalog(ctx, id)
+			if err != nil {
+				res.Error = err
+				return res
+			}
+			if scat == nil {
+				res.Error = handlers.NotFoundError()
+				return res
+			}
+			cat = scat
 		}
 		parentId = cat.GetScopeId()
 		opts = append(opts, auth.WithId(id))
@@ -284,33 +512,98 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	return auth.Verify(ctx, opts...)
 }
 
-func toProto(in *static.HostCatalog) *pb.HostCatalog {
-	out := pb.HostCatalog{
-		Id:          in.GetPublicId(),
-		ScopeId:     in.GetScopeId(),
-		CreatedTime: in.GetCreateTime().GetTimestamp(),
-		UpdatedTime: in.GetUpdateTime().GetTimestamp(),
-		Version:     in.GetVersion(),
-		Type:        host.StaticSubtype.String(),
+func toProto(in *static.HostCatalog, opt ...handlers.Option) *pb.HostCatalog {
+	return commonToProto(in, host.StaticSubtype, opt...)
+}
+
+func pluginToProto(in *plugin.HostCatalog, opt ...handlers.Option) (*pb.HostCatalog, error) {
+	out := commonToProto(in, host.PluginSubtype, opt...)
+	if !handlers.GetOpts(opt...).WithOutputFields.Has("attributes") {
+		return out, nil
+	}
+	attrs, err := in.DecodedAttributes()
+	if err != nil {
+		return nil, err
 	}
-	if in.GetDescription() != "" {
+	config, err := structpb.NewStruct(attrs)
+	if err != nil {
+		return nil, err
+	}
+	st, err := handlers.ProtoToStruct(&pb.PluginHostCatalogAttributes{
+		PluginName:   &wrapperspb.StringValue{Value: in.GetPluginName()},
+		PluginConfig: config,
+	})
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "failed building plugin attribute struct: %v", err)
+	}
+	out.Attributes = st
+	return out, nil
+}
+
+// commonToProto returns the fields of a host catalog shared by its subtypes.
+func commonToProto(in interface {
+	GetPublicId() string
+	GetScopeId() string
+	GetName() string
+	GetDescription() string
+	GetVersion() uint32
+	GetCreateTime() *timestamp.Timestamp
+	GetUpdateTime() *timestamp.Timestamp
+}, subtype host.SubType, opt ...handlers.Option) *pb.HostCatalog {
+	opts := handlers.GetOpts(opt...)
+	outputFields := opts.WithOutputFields
+	out := pb.HostCatalog{}
+	if outputFields.Has("id") {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has("scope_id") {
+		out.ScopeId = in.GetScopeId()
+	}
+	if outputFields.Has("scope") {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has("created_time") {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
+	}
+	if outputFields.Has("updated_time") {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has("version") {
+		out.Version = in.GetVersion()
+	}
+	if outputFields.Has("type") {
+		out.Type = subtype.String()
+	}
+	if outputFields.Has("description") && in.GetDescription() != "" {
 		out.Description = &wrapperspb.StringValue{Value: in.GetDescription()}
 	}
-	if in.GetName() != "" {
+	if outputFields.Has("name") && in.GetName() != "" {
 		out.Name = &wrapperspb.StringValue{Value: in.GetName()}
 	}
+	if outputFields.Has("authorized_actions") {
+		out.AuthorizedActions = opts.WithAuthorizedActions
+	}
 	return &out
 }
 
+// catalogPrefix returns the id prefix for the subtype of the host catalog
+// id.
+func catalogPrefix(id string) string {
+	if host.SubtypeFromId(id) == host.PluginSubtype {
+		return plugin.HostCatalogPrefix
+	}
+	return static.HostCatalogPrefix
+}
+
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
-//  * The path passed in is correctly formatted
-//  * All required parameters are set
-//  * There are no conflicting parameters provided
-//  * The type asserted by the ID and/or field is known
-//  * If relevant, the type derived from the id prefix matches what is claimed by the type field
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
+//   - The type asserted by the ID and/or field is known
+//   - If relevant, the type derived from the id prefix matches what is claimed by the type field
 func validateGetRequest(req *pbs.GetHostCatalogRequest) error {
-	return handlers.ValidateGetRequest(static.HostCatalogPrefix, req, handlers.NoopValidatorFn)
+	return handlers.ValidateGetRequest(catalogPrefix(req.GetId()), req, handlers.NoopValidatorFn)
 }
 
 func validateCreateRequest(req *pbs.CreateHostCatalogRequest) error {
@@ -321,34 +614,56 @@ func validateCreateRequest(req *pbs.CreateHostCatalogRequest) error {
 		}
 		switch host.SubtypeFromType(req.GetItem().GetType()) {
 		case host.StaticSubtype:
+		case host.PluginSubtype:
+			attrs := &pb.PluginHostCatalogAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), attrs); err != nil {
+				badFields["attributes"] = "Attribute fields do not match the expected format."
+				break
+			}
+			if attrs.GetPluginName().GetValue() == "" {
+				badFields["attributes.plugin_name"] = "This is a required field for plugin host catalogs."
+			}
 		default:
-			badFields["type"] = fmt.Sprintf("This is a required field and must be %q.", host.StaticSubtype.String())
+			badFields["type"] = fmt.Sprintf("This is a required field and must be %q or %q.", host.StaticSubtype.String(), host.PluginSubtype.String())
 		}
 		return badFields
 	})
 }
 
 func validateUpdateRequest(req *pbs.UpdateHostCatalogRequest) error {
-	return handlers.ValidateUpdateRequest(static.HostCatalogPrefix, req, req.GetItem(), func() map[string]string {
+	return handlers.ValidateUpdateRequest(catalogPrefix(req.GetId()), req, req.GetItem(), func() map[string]string {
 		badFields := map[string]string{}
 		switch host.SubtypeFromId(req.GetId()) {
 		case host.StaticSubtype:
 			if req.GetItem().GetType() != "" && host.SubtypeFromType(req.GetItem().GetType()) != host.StaticSubtype {
 				badFields["type"] = "Cannot modify resource type."
 			}
+		case host.PluginSubtype:
+			if req.GetItem().GetType() != "" && host.SubtypeFromType(req.GetItem().GetType()) != host.PluginSubtype {
+				badFields["type"] = "Cannot modify resource type."
+			}
+			attrs := &pb.PluginHostCatalogAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), attrs); err != nil {
+				badFields["attributes"] = "Attribute fields do not match the expected format."
+				break
+			}
+			if attrs.GetPluginName() != nil {
+				badFields["attributes.plugin_name"] = "This field cannot be updated."
+			}
 		}
 		return badFields
 	})
 }
 
 func validateDeleteRequest(req *pbs.DeleteHostCatalogRequest) error {
-	return handlers.ValidateDeleteRequest(static.HostCatalogPrefix, req, handlers.NoopValidatorFn)
+	return handlers.ValidateDeleteRequest(catalogPrefix(req.GetId()), req, handlers.NoopValidatorFn)
 }
 
 func validateListRequest(req *pbs.ListHostCatalogsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()) {
-		badFields["scope_id"] = "This field must be a valid project scope id."
+	if !handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()) &&
+		!(req.GetRecursive() && (req.GetScopeId() == scope.Global.String() || handlers.ValidId(scope.Org.Prefix(), req.GetScopeId()))) {
+		badFields["scope_id"] = "This field must be a valid project scope id, or 'global' or a valid org scope id when listing recursively."
 	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
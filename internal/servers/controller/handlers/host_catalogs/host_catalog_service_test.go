@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"testing"
 
@@ -11,10 +12,12 @@ import (
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/db"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/hostcatalogs"
 	scopepb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/host/plugin"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
@@ -26,12 +29,15 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/structpb"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func createDefaultHostCatalogAndRepo(t *testing.T) (*static.HostCatalog, *iam.Scope, common.StaticRepoFactory, common.IamRepoFactory) {
+var testAuthorizedActions = []string{"read", "update", "delete"}
+
+func createDefaultHostCatalogAndRepo(t *testing.T) (*static.HostCatalog, *iam.Scope, common.StaticRepoFactory, common.PluginHostRepoFactory, common.IamRepoFactory) {
 	t.Helper()
 	require := require.New(t)
 	conn, _ := db.TestSetup(t, "postgres")
@@ -44,6 +50,9 @@ func createDefaultHostCatalogAndRepo(t *testing.T) (*static.HostCatalog, *iam.Sc
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
+	pluginRepoFn := func() (*plugin.Repository, error) {
+		return plugin.NewRepository(rw, rw, kms, plugin.TestPlugins(&plugin.TestPlugin{}))
+	}
 	iamRepoFn := func() (*iam.Repository, error) {
 		return iam.TestRepo(t, conn, wrapper), nil
 	}
@@ -55,25 +64,26 @@ func createDefaultHostCatalogAndRepo(t *testing.T) (*static.HostCatalog, *iam.Sc
 	hcRes, err := repo.CreateCatalog(context.Background(), hc)
 	require.NoError(err, "Couldn't persist new catalog.")
 
-	return hcRes, pRes, repoFn, iamRepoFn
+	return hcRes, pRes, repoFn, pluginRepoFn, iamRepoFn
 }
 
 func TestGet(t *testing.T) {
 	t.Parallel()
-	hc, proj, repo, iamRepoFn := createDefaultHostCatalogAndRepo(t)
+	hc, proj, repo, pluginRepoFn, iamRepoFn := createDefaultHostCatalogAndRepo(t)
 	toMerge := &pbs.GetHostCatalogRequest{
 		Id: hc.GetPublicId(),
 	}
 
 	pHostCatalog := &pb.HostCatalog{
-		Id:          hc.GetPublicId(),
-		ScopeId:     hc.GetScopeId(),
-		Scope:       &scopepb.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
-		Name:        &wrappers.StringValue{Value: hc.GetName()},
-		Description: &wrappers.StringValue{Value: hc.GetDescription()},
-		CreatedTime: hc.CreateTime.GetTimestamp(),
-		UpdatedTime: hc.UpdateTime.GetTimestamp(),
-		Type:        "static",
+		Id:                hc.GetPublicId(),
+		ScopeId:           hc.GetScopeId(),
+		Scope:             &scopepb.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+		Name:              &wrappers.StringValue{Value: hc.GetName()},
+		Description:       &wrappers.StringValue{Value: hc.GetDescription()},
+		CreatedTime:       hc.CreateTime.GetTimestamp(),
+		UpdatedTime:       hc.UpdateTime.GetTimestamp(),
+		Type:              "static",
+		AuthorizedActions: testAuthorizedActions,
 	}
 
 	cases := []struct {
@@ -112,7 +122,7 @@ func TestGet(t *testing.T) {
 			req := proto.Clone(toMerge).(*pbs.GetHostCatalogRequest)
 			proto.Merge(req, tc.req)
 
-			s, err := host_catalogs.NewService(repo, iamRepoFn)
+			s, err := host_catalogs.NewService(repo, pluginRepoFn, iamRepoFn)
 			require.NoError(err, "Couldn't create a new host catalog service.")
 
 			got, gErr := s.GetHostCatalog(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), req)
@@ -129,6 +139,51 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestOutputFields(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	org, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	rw := db.New(conn)
+	repoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	pluginRepoFn := func() (*plugin.Repository, error) {
+		return plugin.NewRepository(rw, rw, kms, plugin.TestPlugins(&plugin.TestPlugin{}))
+	}
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.TestRepo(t, conn, wrapper), nil
+	}
+	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
+
+	at := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
+	r := iam.TestRole(t, conn, proj.GetPublicId())
+	_ = iam.TestRoleGrant(t, conn, r.GetPublicId(), "id=*;type=host-catalog;actions=read,list;output_fields=id,scope_id")
+	_ = iam.TestUserRole(t, conn, r.GetPublicId(), at.GetIamUserId())
+	ctx := auth.TestAuthContextFromToken(t, conn, kms, at)
+
+	// Fields left out of the grant, such as the type and authorized actions,
+	// aren't returned
+	want := &pb.HostCatalog{
+		Id:      hc.GetPublicId(),
+		ScopeId: proj.GetPublicId(),
+	}
+
+	s, err := host_catalogs.NewService(repoFn, pluginRepoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	got, err := s.GetHostCatalog(ctx, &pbs.GetHostCatalogRequest{Id: hc.GetPublicId()})
+	require.NoError(t, err)
+	assert.Empty(t, cmp.Diff(want, got.GetItem(), protocmp.Transform()))
+
+	list, err := s.ListHostCatalogs(ctx, &pbs.ListHostCatalogsRequest{ScopeId: proj.GetPublicId()})
+	require.NoError(t, err)
+	require.Len(t, list.GetItems(), 1)
+	assert.Empty(t, cmp.Diff(want, list.GetItems()[0], protocmp.Transform()))
+}
+
 func TestList(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	rw := db.New(conn)
@@ -140,6 +195,9 @@ func TestList(t *testing.T) {
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
+	pluginRepoFn := func() (*plugin.Repository, error) {
+		return plugin.NewRepository(rw, rw, kms, plugin.TestPlugins(&plugin.TestPlugin{}))
+	}
 	iamRepo := iam.TestRepo(t, conn, wrapper)
 
 	_, pNoCatalogs := iam.TestScopes(t, iamRepo)
@@ -149,29 +207,35 @@ func TestList(t *testing.T) {
 	var wantSomeCatalogs []*pb.HostCatalog
 	for _, hc := range static.TestCatalogs(t, conn, pWithCatalogs.GetPublicId(), 3) {
 		wantSomeCatalogs = append(wantSomeCatalogs, &pb.HostCatalog{
-			Id:          hc.GetPublicId(),
-			ScopeId:     hc.GetScopeId(),
-			CreatedTime: hc.GetCreateTime().GetTimestamp(),
-			UpdatedTime: hc.GetUpdateTime().GetTimestamp(),
-			Scope:       &scopepb.ScopeInfo{Id: pWithCatalogs.GetPublicId(), Type: scope.Project.String()},
-			Version:     1,
-			Type:        "static",
+			Id:                hc.GetPublicId(),
+			ScopeId:           hc.GetScopeId(),
+			CreatedTime:       hc.GetCreateTime().GetTimestamp(),
+			UpdatedTime:       hc.GetUpdateTime().GetTimestamp(),
+			Scope:             &scopepb.ScopeInfo{Id: pWithCatalogs.GetPublicId(), Type: scope.Project.String()},
+			Version:           1,
+			Type:              "static",
+			AuthorizedActions: testAuthorizedActions,
 		})
 	}
 
 	var wantOtherCatalogs []*pb.HostCatalog
 	for _, hc := range static.TestCatalogs(t, conn, pWithOtherCatalogs.GetPublicId(), 3) {
 		wantOtherCatalogs = append(wantOtherCatalogs, &pb.HostCatalog{
-			Id:          hc.GetPublicId(),
-			ScopeId:     hc.GetScopeId(),
-			CreatedTime: hc.GetCreateTime().GetTimestamp(),
-			UpdatedTime: hc.GetUpdateTime().GetTimestamp(),
-			Scope:       &scopepb.ScopeInfo{Id: pWithOtherCatalogs.GetPublicId(), Type: scope.Project.String()},
-			Version:     1,
-			Type:        "static",
+			Id:                hc.GetPublicId(),
+			ScopeId:           hc.GetScopeId(),
+			CreatedTime:       hc.GetCreateTime().GetTimestamp(),
+			UpdatedTime:       hc.GetUpdateTime().GetTimestamp(),
+			Scope:             &scopepb.ScopeInfo{Id: pWithOtherCatalogs.GetPublicId(), Type: scope.Project.String()},
+			Version:           1,
+			Type:              "static",
+			AuthorizedActions: testAuthorizedActions,
 		})
 	}
 
+	// Listed resources are ordered by id
+	sort.Slice(wantSomeCatalogs, func(i, j int) bool { return wantSomeCatalogs[i].GetId() < wantSomeCatalogs[j].GetId() })
+	sort.Slice(wantOtherCatalogs, func(i, j int) bool { return wantOtherCatalogs[i].GetId() < wantOtherCatalogs[j].GetId() })
+
 	cases := []struct {
 		name    string
 		scopeId string
@@ -207,7 +271,7 @@ func TestList(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := host_catalogs.NewService(repoFn, iamRepoFn)
+			s, err := host_catalogs.NewService(repoFn, pluginRepoFn, iamRepoFn)
 			require.NoError(err, "Couldn't create new auth_method service.")
 
 			got, gErr := s.ListHostCatalogs(auth.DisabledAuthTestContext(auth.WithScopeId(tc.scopeId)), &pbs.ListHostCatalogsRequest{ScopeId: tc.scopeId})
@@ -222,9 +286,9 @@ func TestList(t *testing.T) {
 
 func TestDelete(t *testing.T) {
 	t.Parallel()
-	hc, proj, repo, iamRepoFn := createDefaultHostCatalogAndRepo(t)
+	hc, proj, repo, pluginRepoFn, iamRepoFn := createDefaultHostCatalogAndRepo(t)
 
-	s, err := host_catalogs.NewService(repo, iamRepoFn)
+	s, err := host_catalogs.NewService(repo, pluginRepoFn, iamRepoFn)
 	require.NoError(t, err, "Couldn't create a new host catalog service.")
 
 	cases := []struct {
@@ -275,9 +339,9 @@ func TestDelete(t *testing.T) {
 func TestDelete_twice(t *testing.T) {
 	t.Parallel()
 	assert, require := assert.New(t), require.New(t)
-	hc, proj, repo, iamRepoFn := createDefaultHostCatalogAndRepo(t)
+	hc, proj, repo, pluginRepoFn, iamRepoFn := createDefaultHostCatalogAndRepo(t)
 
-	s, err := host_catalogs.NewService(repo, iamRepoFn)
+	s, err := host_catalogs.NewService(repo, pluginRepoFn, iamRepoFn)
 	require.NoError(err, "Couldn't create a new host catalog service.")
 	req := &pbs.DeleteHostCatalogRequest{
 		Id: hc.GetPublicId(),
@@ -292,7 +356,7 @@ func TestDelete_twice(t *testing.T) {
 
 func TestCreate(t *testing.T) {
 	t.Parallel()
-	defaultHc, proj, repo, iamRepoFn := createDefaultHostCatalogAndRepo(t)
+	defaultHc, proj, repo, pluginRepoFn, iamRepoFn := createDefaultHostCatalogAndRepo(t)
 	defaultHcCreated, err := ptypes.Timestamp(defaultHc.GetCreateTime().GetTimestamp())
 	require.NoError(t, err, "Error converting proto to timestamp.")
 	toMerge := &pbs.CreateHostCatalogRequest{}
@@ -390,7 +454,7 @@ func TestCreate(t *testing.T) {
 			req := proto.Clone(toMerge).(*pbs.CreateHostCatalogRequest)
 			proto.Merge(req, tc.req)
 
-			s, err := host_catalogs.NewService(repo, iamRepoFn)
+			s, err := host_catalogs.NewService(repo, pluginRepoFn, iamRepoFn)
 			require.NoError(err, "Failed to create a new host catalog service.")
 
 			got, gErr := s.CreateHostCatalog(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), req)
@@ -422,10 +486,80 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestCreate_plugin(t *testing.T) {
+	t.Parallel()
+	_, proj, repo, pluginRepoFn, iamRepoFn := createDefaultHostCatalogAndRepo(t)
+	s, err := host_catalogs.NewService(repo, pluginRepoFn, iamRepoFn)
+	require.NoError(t, err, "Failed to create a new host catalog service.")
+
+	attrs := func(m map[string]interface{}) *structpb.Struct {
+		st, err := structpb.NewStruct(m)
+		require.NoError(t, err)
+		return st
+	}
+
+	cases := []struct {
+		name  string
+		attrs *structpb.Struct
+		err   error
+	}{
+		{
+			name: "Create a valid plugin HostCatalog",
+			attrs: attrs(map[string]interface{}{
+				"plugin_name":   plugin.TestPluginName,
+				"plugin_config": map[string]interface{}{"region": "us-east-1"},
+			}),
+		},
+		{
+			name:  "No plugin name",
+			attrs: attrs(map[string]interface{}{"plugin_config": map[string]interface{}{"region": "us-east-1"}}),
+			err:   handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:  "Unknown plugin",
+			attrs: attrs(map[string]interface{}{"plugin_name": "thisismadeup"}),
+			err:   handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Config rejected by the plugin",
+			attrs: attrs(map[string]interface{}{
+				"plugin_name":   plugin.TestPluginName,
+				"plugin_config": map[string]interface{}{"invalid": true},
+			}),
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			req := &pbs.CreateHostCatalogRequest{Item: &pb.HostCatalog{
+				ScopeId:    proj.GetPublicId(),
+				Type:       "plugin",
+				Attributes: tc.attrs,
+			}}
+			ctx := auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId()))
+			got, gErr := s.CreateHostCatalog(ctx, req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "CreateHostCatalog(%+v) got error %v, wanted %v", req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.True(strings.HasPrefix(got.GetItem().GetId(), plugin.HostCatalogPrefix))
+			assert.Equal("plugin", got.GetItem().GetType())
+			assert.Empty(cmp.Diff(tc.attrs, got.GetItem().GetAttributes(), protocmp.Transform()))
+
+			read, gErr := s.GetHostCatalog(ctx, &pbs.GetHostCatalogRequest{Id: got.GetItem().GetId()})
+			require.NoError(gErr)
+			assert.Empty(cmp.Diff(got.GetItem(), read.GetItem(), protocmp.Transform()))
+		})
+	}
+}
+
 func TestUpdate(t *testing.T) {
 	t.Parallel()
-	hc, proj, repoFn, iamRepoFn := createDefaultHostCatalogAndRepo(t)
-	tested, err := host_catalogs.NewService(repoFn, iamRepoFn)
+	hc, proj, repoFn, pluginRepoFn, iamRepoFn := createDefaultHostCatalogAndRepo(t)
+	tested, err := host_catalogs.NewService(repoFn, pluginRepoFn, iamRepoFn)
 	require.NoError(t, err, "Failed to create a new host catalog service.")
 
 	var version uint32 = 1
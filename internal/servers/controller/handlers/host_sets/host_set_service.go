@@ -5,10 +5,13 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/hostsets"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/host"
+	"github.com/hashicorp/boundary/internal/host/plugin"
+	pluginstore "github.com/hashicorp/boundary/internal/host/plugin/store"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/host/static/store"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
@@ -21,7 +24,26 @@ import (
 )
 
 var (
-	maskManager handlers.MaskManager
+	maskManager       handlers.MaskManager
+	pluginMaskManager handlers.MaskManager
+
+	// IdActions contains the set of actions that can be performed on
+	// individual host sets of each subtype
+	IdActions = map[host.SubType]action.ActionSet{
+		host.StaticSubtype: {
+			action.Read,
+			action.Update,
+			action.Delete,
+			action.AddHostSets,
+			action.SetHostSets,
+			action.RemoveHostSets,
+		},
+		host.PluginSubtype: {
+			action.Read,
+			action.Update,
+			action.Delete,
+		},
+	}
 )
 
 func init() {
@@ -29,23 +51,30 @@ func init() {
 	if maskManager, err = handlers.NewMaskManager(&store.HostSet{}, &pb.HostSet{}); err != nil {
 		panic(err)
 	}
+	if pluginMaskManager, err = handlers.NewMaskManager(&pluginstore.HostSet{}, &pb.HostSet{}, &pb.PluginHostSetAttributes{}); err != nil {
+		panic(err)
+	}
 }
 
 type Service struct {
 	pbs.UnimplementedHostSetServiceServer
 
 	staticRepoFn common.StaticRepoFactory
+	pluginRepoFn common.PluginHostRepoFactory
 }
 
 var _ pbs.HostSetServiceServer = Service{}
 
 // NewService returns a host set Service which handles host set related requests to boundary and uses the provided
 // repositories for storage and retrieval.
-func NewService(repoFn common.StaticRepoFactory) (Service, error) {
+func NewService(repoFn common.StaticRepoFactory, pluginRepoFn common.PluginHostRepoFactory) (Service, error) {
 	if repoFn == nil {
 		return Service{}, fmt.Errorf("nil static repository provided")
 	}
-	return Service{staticRepoFn: repoFn}, nil
+	if pluginRepoFn == nil {
+		return Service{}, fmt.Errorf("nil plugin host repository provided")
+	}
+	return Service{staticRepoFn: repoFn, pluginRepoFn: pluginRepoFn}, nil
 }
 
 func (s Service) ListHostSets(ctx context.Context, req *pbs.ListHostSetsRequest) (*pbs.ListHostSetsResponse, error) {
@@ -56,14 +85,28 @@ func (s Service) ListHostSets(ctx context.Context, req *pbs.ListHostSetsRequest)
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	hl, err := s.listFromRepo(ctx, req.GetHostCatalogId())
+	fetch := func(afterId string, limit int) ([]handlers.ListItem, error) {
+		itemOpts := func(id string) ([]handlers.Option, bool) {
+			if authResults.DeniedForId(ctx, id, action.Read) {
+				return nil, false
+			}
+			return []handlers.Option{
+				handlers.WithScope(authResults.Scope),
+				handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, id, IdActions[host.SubtypeFromId(id)]).Strings()),
+				handlers.WithOutputFields(authResults.FetchOutputFields(ctx, id)),
+			}, true
+		}
+		return s.listFromRepo(ctx, req.GetHostCatalogId(), afterId, limit, itemOpts)
+	}
+	items, next, err := handlers.ListPage(fetch, req.GetFilter(), req.GetPageSize(), req.GetPageToken())
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range hl {
-		item.Scope = authResults.Scope
+	var hl []*pb.HostSet
+	for _, item := range items {
+		hl = append(hl, item.(*pb.HostSet))
 	}
-	return &pbs.ListHostSetsResponse{Items: hl}, nil
+	return &pbs.ListHostSetsResponse{Items: hl, NextPageToken: next}, nil
 }
 
 // GetHostSet implements the interface pbs.HostSetServiceServer.
@@ -75,11 +118,13 @@ func (s Service) GetHostSet(ctx context.Context, req *pbs.GetHostSetRequest) (*p
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	hc, err := s.getFromRepo(ctx, req.GetId())
+	hc, err := s.getFromRepo(ctx, req.GetId(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, req.GetId(), IdActions[host.SubtypeFromId(req.GetId())]).Strings()),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	hc.Scope = authResults.Scope
 	return &pbs.GetHostSetResponse{Item: hc}, nil
 }
 
@@ -88,15 +133,16 @@ func (s Service) CreateHostSet(ctx context.Context, req *pbs.CreateHostSetReques
 	if err := validateCreateRequest(req); err != nil {
 		return nil, err
 	}
-	cat, authResults := s.parentAndAuthResult(ctx, req.GetItem().GetHostCatalogId(), action.Create)
+	catalogId, authResults := s.parentAndAuthResult(ctx, req.GetItem().GetHostCatalogId(), action.Create)
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	h, err := s.createInRepo(ctx, authResults.Scope.GetId(), cat.GetPublicId(), req.GetItem())
+	h, err := s.createInRepo(ctx, authResults.Scope.GetId(), catalogId, req.GetItem(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, "")))
 	if err != nil {
 		return nil, err
 	}
-	h.Scope = authResults.Scope
 	return &pbs.CreateHostSetResponse{
 		Item: h,
 		Uri:  fmt.Sprintf("host-sets/%s", h.GetId()),
@@ -108,15 +154,16 @@ func (s Service) UpdateHostSet(ctx context.Context, req *pbs.UpdateHostSetReques
 	if err := validateUpdateRequest(req); err != nil {
 		return nil, err
 	}
-	cat, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.Update)
+	catalogId, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.Update)
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	hc, err := s.updateInRepo(ctx, authResults.Scope.GetId(), cat.GetPublicId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	hc, err := s.updateInRepo(ctx, authResults.Scope.GetId(), catalogId, req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	hc.Scope = authResults.Scope
 	return &pbs.UpdateHostSetResponse{Item: hc}, nil
 }
 
@@ -145,11 +192,12 @@ func (s Service) AddHostSetHosts(ctx context.Context, req *pbs.AddHostSetHostsRe
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	g, err := s.addInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetHostIds(), req.GetVersion())
+	g, err := s.addInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetHostIds(), req.GetVersion(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	g.Scope = authResults.Scope
 	return &pbs.AddHostSetHostsResponse{Item: g}, nil
 }
 
@@ -162,11 +210,12 @@ func (s Service) SetHostSetHosts(ctx context.Context, req *pbs.SetHostSetHostsRe
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	g, err := s.setInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetHostIds(), req.GetVersion())
+	g, err := s.setInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetHostIds(), req.GetVersion(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	g.Scope = authResults.Scope
 	return &pbs.SetHostSetHostsResponse{Item: g}, nil
 }
 
@@ -179,15 +228,19 @@ func (s Service) RemoveHostSetHosts(ctx context.Context, req *pbs.RemoveHostSetH
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	g, err := s.removeInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetHostIds(), req.GetVersion())
+	g, err := s.removeInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetHostIds(), req.GetVersion(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	g.Scope = authResults.Scope
 	return &pbs.RemoveHostSetHostsResponse{Item: g}, nil
 }
 
-func (s Service) getFromRepo(ctx context.Context, id string) (*pb.HostSet, error) {
+func (s Service) getFromRepo(ctx context.Context, id string, opt ...handlers.Option) (*pb.HostSet, error) {
+	if host.SubtypeFromId(id) == host.PluginSubtype {
+		return s.getPluginFromRepo(ctx, id, opt...)
+	}
 	repo, err := s.staticRepoFn()
 	if err != nil {
 		return nil, err
@@ -199,10 +252,13 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*pb.HostSet, error
 	if h == nil {
 		return nil, handlers.NotFoundErrorf("Host Set %q doesn't exist.", id)
 	}
-	return toProto(h, m), nil
+	return toProto(h, m, opt...), nil
 }
 
-func (s Service) createInRepo(ctx context.Context, scopeId, catalogId string, item *pb.HostSet) (*pb.HostSet, error) {
+func (s Service) createInRepo(ctx context.Context, scopeId, catalogId string, item *pb.HostSet, opt ...handlers.Option) (*pb.HostSet, error) {
+	if host.SubtypeFromId(catalogId) == host.PluginSubtype {
+		return s.createPluginInRepo(ctx, scopeId, catalogId, item, opt...)
+	}
 	var opts []static.Option
 	if item.GetName() != nil {
 		opts = append(opts, static.WithName(item.GetName().GetValue()))
@@ -229,10 +285,13 @@ func (s Service) createInRepo(ctx context.Context, scopeId, catalogId string, it
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create host set but no error returned from repository.")
 	}
-	return toProto(out, nil), nil
+	return toProto(out, nil, opt...), nil
 }
 
-func (s Service) updateInRepo(ctx context.Context, scopeId, catalogId, id string, mask []string, item *pb.HostSet) (*pb.HostSet, error) {
+func (s Service) updateInRepo(ctx context.Context, scopeId, catalogId, id string, mask []string, item *pb.HostSet, opt ...handlers.Option) (*pb.HostSet, error) {
+	if host.SubtypeFromId(id) == host.PluginSubtype {
+		return s.updatePluginInRepo(ctx, scopeId, catalogId, id, mask, item, opt...)
+	}
 	var opts []static.Option
 	if desc := item.GetDescription(); desc != nil {
 		opts = append(opts, static.WithDescription(desc.GetValue()))
@@ -260,38 +319,60 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, catalogId, id string
 	if rowsUpdated == 0 {
 		return nil, handlers.NotFoundErrorf("Host Set %q doesn't exist or incorrect version provided.", id)
 	}
-	return toProto(out, m), nil
+	return toProto(out, m, opt...), nil
 }
 
 func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool, error) {
-	repo, err := s.staticRepoFn()
-	if err != nil {
-		return false, err
-	}
-	rows, err := repo.DeleteSet(ctx, scopeId, id)
-	if err != nil {
-		return false, fmt.Errorf("unable to delete host: %w", err)
+	var rows int
+	switch host.SubtypeFromId(id) {
+	case host.PluginSubtype:
+		repo, err := s.pluginRepoFn()
+		if err != nil {
+			return false, err
+		}
+		if rows, err = repo.DeleteSet(ctx, scopeId, id); err != nil {
+			return false, fmt.Errorf("unable to delete host set: %w", err)
+		}
+	default:
+		repo, err := s.staticRepoFn()
+		if err != nil {
+			return false, err
+		}
+		if rows, err = repo.DeleteSet(ctx, scopeId, id); err != nil {
+			return false, fmt.Errorf("unable to delete host: %w", err)
+		}
 	}
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, catalogId string) ([]*pb.HostSet, error) {
+// listFromRepo lists the host sets of the catalog, converting each with the
+// options itemOpts returns for its id, or hiding it when itemOpts doesn't list
+// it.
+func (s Service) listFromRepo(ctx context.Context, catalogId string, afterId string, limit int, itemOpts func(id string) ([]handlers.Option, bool)) ([]handlers.ListItem, error) {
+	if host.SubtypeFromId(catalogId) == host.PluginSubtype {
+		return s.listPluginFromRepo(ctx, catalogId, afterId, limit, itemOpts)
+	}
 	repo, err := s.staticRepoFn()
 	if err != nil {
 		return nil, err
 	}
-	hl, err := repo.ListSets(ctx, catalogId)
+	hl, err := repo.ListSets(ctx, catalogId, static.WithStartPageAfterId(afterId), static.WithLimit(limit))
 	if err != nil {
 		return nil, err
 	}
-	var outH []*pb.HostSet
+	outH := make([]handlers.ListItem, 0, len(hl))
 	for _, h := range hl {
-		outH = append(outH, toProto(h, nil))
+		opts, ok := itemOpts(h.GetPublicId())
+		if !ok {
+			outH = append(outH, handlers.HiddenListItem(h.GetPublicId()))
+			continue
+		}
+		outH = append(outH, handlers.ListedItem(h.GetPublicId(), toProto(h, nil, opts...)))
 	}
 	return outH, nil
 }
 
-func (s Service) addInRepo(ctx context.Context, scopeId, setId string, hostIds []string, version uint32) (*pb.HostSet, error) {
+func (s Service) addInRepo(ctx context.Context, scopeId, setId string, hostIds []string, version uint32, opt ...handlers.Option) (*pb.HostSet, error) {
 	repo, err := s.staticRepoFn()
 	if err != nil {
 		return nil, err
@@ -308,10 +389,10 @@ func (s Service) addInRepo(ctx context.Context, scopeId, setId string, hostIds [
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup host set after adding hosts to it.")
 	}
-	return toProto(out, m), nil
+	return toProto(out, m, opt...), nil
 }
 
-func (s Service) setInRepo(ctx context.Context, scopeId, setId string, hostIds []string, version uint32) (*pb.HostSet, error) {
+func (s Service) setInRepo(ctx context.Context, scopeId, setId string, hostIds []string, version uint32, opt ...handlers.Option) (*pb.HostSet, error) {
 	repo, err := s.staticRepoFn()
 	if err != nil {
 		return nil, err
@@ -329,10 +410,10 @@ func (s Service) setInRepo(ctx context.Context, scopeId, setId string, hostIds [
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup host set after setting hosts for it.")
 	}
-	return toProto(out, m), nil
+	return toProto(out, m, opt...), nil
 }
 
-func (s Service) removeInRepo(ctx context.Context, scopeId, setId string, hostIds []string, version uint32) (*pb.HostSet, error) {
+func (s Service) removeInRepo(ctx context.Context, scopeId, setId string, hostIds []string, version uint32, opt ...handlers.Option) (*pb.HostSet, error) {
 	repo, err := s.staticRepoFn()
 	if err != nil {
 		return nil, err
@@ -349,16 +430,11 @@ func (s Service) removeInRepo(ctx context.Context, scopeId, setId string, hostId
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup host set after removing hosts from it.")
 	}
-	return toProto(out, m), nil
+	return toProto(out, m, opt...), nil
 }
 
-func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Type) (*static.HostCatalog, auth.VerifyResults) {
+func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Type) (string, auth.VerifyResults) {
 	res := auth.VerifyResults{}
-	repo, err := s.staticRepoFn()
-	if err != nil {
-		res.Error = err
-		return nil, res
-	}
 
 	var parentId string
 	opts := []auth.Option{auth.WithType(resource.HostSet), auth.WithAction(a)}
@@ -366,68 +442,320 @@ func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Ty
 	case action.List, action.Create:
 		parentId = id
 	default:
-		set, _, err := repo.LookupSet(ctx, id)
+		var set interface{ GetCatalogId() string }
+		switch host.SubtypeFromId(id) {
+		case host.PluginSubtype:
+			repo, err := s.pluginRepoFn()
+			if err != nil {
+				res.Error = err
+				return "", res
+			}
+			pset, err := repo.LookupSet(ctx, id)
+			if err != nil {
+				res.Error = err
+				return "", res
+			}
+			if pset == nil {
+				res.Error = handlers.NotFoundError()
+				return "", res
+			}
+			set = pset
+		default:
+			repo, err := s.staticRepoFn()
+			if err != nil {
+				res.Error = err
+				return "", res
+			}
+			sset, _, err := repo.LookupSet(ctx, id)
+			if err != nil {
+				res.Error = err
+				return "", res
+			}
+			if sset == nil {
+				res.Error = handlers.NotFoundError()
+				return "", res
+			}
+			set = sset
+		}
+		parentId = set.GetCatalogId()
+		opts = append(opts, auth.WithId(id))
+	}
+
+	var cat interface {
+		GetPublicId() string
+		GetScopeId() string
+	}
+	switch host.SubtypeFromId(parentId) {
+	case host.PluginSubtype:
+		repo, err := s.pluginRepoFn()
 		if err != nil {
 			res.Error = err
-			return nil, res
+			return "", res
 		}
-		if set == nil {
+		pcat, err := repo.LookupCatalog(ctx, parentId)
+		if err != nil {
+			res.Error = err
+			return "", res
+		}
+		if pcat == nil {
 			res.Error = handlers.NotFoundError()
-			return nil, res
+			return "", res
 		}
-		parentId = set.GetCatalogId()
-		opts = append(opts, auth.WithId(id))
+		cat = pcat
+	default:
+		repo, err := s.staticRepoFn()
+		if err != nil {
+			res.Error = err
+			return "", res
+		}
+		scat, err := repo.LookupCatalog(ctx, parentId)
+		if err != nil {
+			res.Error = err
+			return "", res
+		}
+		if scat == nil {
+			res.Error = handlers.NotFoundError()
+			return "", res
+		}
+		cat = scat
 	}
+	opts = append(opts, auth.WithScopeId(cat.GetScopeId()), auth.WithPin(parentId))
+	return cat.GetPublicId(), auth.Verify(ctx, opts...)
+}
 
-	cat, err := repo.LookupCatalog(ctx, parentId)
+func (s Service) getPluginFromRepo(ctx context.Context, id string, opt ...handlers.Option) (*pb.HostSet, error) {
+	repo, err := s.pluginRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	h, err := repo.LookupSet(ctx, id)
 	if err != nil {
-		res.Error = err
-		return nil, res
+		return nil, err
 	}
-	if cat == nil {
-		res.Error = handlers.NotFoundError()
-		return nil, res
+	if h == nil {
+		return nil, handlers.NotFoundErrorf("Host Set %q doesn't exist.", id)
 	}
-	opts = append(opts, auth.WithScopeId(cat.GetScopeId()), auth.WithPin(parentId))
-	return cat, auth.Verify(ctx, opts...)
+	m, err := repo.ListSetMembers(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list host set members: %w", err)
+	}
+	return pluginToProto(h, m, opt...)
 }
 
-func toProto(in *static.HostSet, hs []*static.Host) *pb.HostSet {
-	out := pb.HostSet{
-		Id:            in.GetPublicId(),
-		HostCatalogId: in.GetCatalogId(),
-		Type:          host.StaticSubtype.String(),
-		CreatedTime:   in.GetCreateTime().GetTimestamp(),
-		UpdatedTime:   in.GetUpdateTime().GetTimestamp(),
-		Version:       in.GetVersion(),
+func (s Service) createPluginInRepo(ctx context.Context, scopeId, catalogId string, item *pb.HostSet, opt ...handlers.Option) (*pb.HostSet, error) {
+	attrs := &pb.PluginHostSetAttributes{}
+	if err := handlers.StructToProto(item.GetAttributes(), attrs); err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes": "Attribute fields do not match the expected format."})
+	}
+	var opts []plugin.Option
+	if item.GetName() != nil {
+		opts = append(opts, plugin.WithName(item.GetName().GetValue()))
+	}
+	if item.GetDescription() != nil {
+		opts = append(opts, plugin.WithDescription(item.GetDescription().GetValue()))
+	}
+	h, err := plugin.NewHostSet(catalogId, attrs.GetFilter().GetValue(), opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build host set for creation: %v.", err)
+	}
+	repo, err := s.pluginRepoFn()
+	if err != nil {
+		return nil, err
 	}
-	if in.GetDescription() != "" {
+	out, err := repo.CreateSet(ctx, scopeId, h)
+	if err != nil {
+		if errors.IsUniqueError(err) || errors.Is(err, errors.ErrNotUnique) {
+			// Push this error through so the error interceptor can interpret it correctly.
+			return nil, err
+		}
+		if apiErr := pluginErrorToApiError(err); apiErr != nil {
+			return nil, apiErr
+		}
+		return nil, fmt.Errorf("unable to create host set: %w", err)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create host set but no error returned from repository.")
+	}
+	return pluginToProto(out, nil, opt...)
+}
+
+func (s Service) updatePluginInRepo(ctx context.Context, scopeId, catalogId, id string, mask []string, item *pb.HostSet, opt ...handlers.Option) (*pb.HostSet, error) {
+	attrs := &pb.PluginHostSetAttributes{}
+	if err := handlers.StructToProto(item.GetAttributes(), attrs); err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes": "Attribute fields do not match the expected format."})
+	}
+	h := &plugin.HostSet{
+		HostSet: &pluginstore.HostSet{
+			PublicId:    id,
+			CatalogId:   catalogId,
+			Name:        item.GetName().GetValue(),
+			Description: item.GetDescription().GetValue(),
+			Filter:      attrs.GetFilter().GetValue(),
+		},
+	}
+	dbMask := pluginMaskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+	repo, err := s.pluginRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, rowsUpdated, err := repo.UpdateSet(ctx, scopeId, h, item.GetVersion(), dbMask)
+	if err != nil {
+		if apiErr := pluginErrorToApiError(err); apiErr != nil {
+			return nil, apiErr
+		}
+		return nil, fmt.Errorf("unable to update host set: %w.", err)
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Host Set %q doesn't exist or incorrect version provided.", id)
+	}
+	m, err := repo.ListSetMembers(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list host set members: %w", err)
+	}
+	return pluginToProto(out, m, opt...)
+}
+
+func (s Service) listPluginFromRepo(ctx context.Context, catalogId string, afterId string, limit int, itemOpts func(id string) ([]handlers.Option, bool)) ([]handlers.ListItem, error) {
+	repo, err := s.pluginRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	hl, err := repo.ListSets(ctx, catalogId, plugin.WithStartPageAfterId(afterId), plugin.WithLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	outH := make([]handlers.ListItem, 0, len(hl))
+	for _, h := range hl {
+		opts, ok := itemOpts(h.GetPublicId())
+		if !ok {
+			outH = append(outH, handlers.HiddenListItem(h.GetPublicId()))
+			continue
+		}
+		hs, err := pluginToProto(h, nil, opts...)
+		if err != nil {
+			return nil, err
+		}
+		outH = append(outH, handlers.ListedItem(h.GetPublicId(), hs))
+	}
+	return outH, nil
+}
+
+// pluginErrorToApiError converts the error returned when a plugin rejects
+// the filter of a host set into an invalid argument error. It returns nil
+// for all other errors.
+func pluginErrorToApiError(err error) error {
+	if errors.Is(err, plugin.ErrInvalidFilter) {
+		return handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes.filter": fmt.Sprintf("Rejected by the plugin: %v.", err)})
+	}
+	return nil
+}
+
+func toProto(in *static.HostSet, hs []*static.Host, opt ...handlers.Option) *pb.HostSet {
+	out := commonToProto(in, host.StaticSubtype, opt...)
+	if handlers.GetOpts(opt...).WithOutputFields.Has("host_ids") {
+		for _, h := range hs {
+			out.HostIds = append(out.HostIds, h.GetPublicId())
+		}
+	}
+	return out
+}
+
+func pluginToProto(in *plugin.HostSet, hs []*plugin.Host, opt ...handlers.Option) (*pb.HostSet, error) {
+	out := commonToProto(in, host.PluginSubtype, opt...)
+	outputFields := handlers.GetOpts(opt...).WithOutputFields
+	if outputFields.Has("host_ids") {
+		for _, h := range hs {
+			out.HostIds = append(out.HostIds, h.GetPublicId())
+		}
+	}
+	if outputFields.Has("attributes") {
+		st, err := handlers.ProtoToStruct(&pb.PluginHostSetAttributes{
+			Filter: wrapperspb.String(in.GetFilter()),
+		})
+		if err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "failed building plugin attribute struct: %v", err)
+		}
+		out.Attributes = st
+	}
+	return out, nil
+}
+
+// commonToProto returns the fields of a host set shared by its subtypes.
+func commonToProto(in interface {
+	GetPublicId() string
+	GetCatalogId() string
+	GetName() string
+	GetDescription() string
+	GetVersion() uint32
+	GetCreateTime() *timestamp.Timestamp
+	GetUpdateTime() *timestamp.Timestamp
+}, subtype host.SubType, opt ...handlers.Option) *pb.HostSet {
+	opts := handlers.GetOpts(opt...)
+	outputFields := opts.WithOutputFields
+	out := pb.HostSet{}
+	if outputFields.Has("id") {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has("host_catalog_id") {
+		out.HostCatalogId = in.GetCatalogId()
+	}
+	if outputFields.Has("scope") {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has("type") {
+		out.Type = subtype.String()
+	}
+	if outputFields.Has("created_time") {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
+	}
+	if outputFields.Has("updated_time") {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has("version") {
+		out.Version = in.GetVersion()
+	}
+	if outputFields.Has("description") && in.GetDescription() != "" {
 		out.Description = wrapperspb.String(in.GetDescription())
 	}
-	if in.GetName() != "" {
+	if outputFields.Has("name") && in.GetName() != "" {
 		out.Name = wrapperspb.String(in.GetName())
 	}
-	for _, h := range hs {
-		out.HostIds = append(out.HostIds, h.GetPublicId())
+	if outputFields.Has("authorized_actions") {
+		out.AuthorizedActions = opts.WithAuthorizedActions
 	}
 	return &out
 }
 
+// setPrefix returns the id prefix for the subtype of the host set id.
+func setPrefix(id string) string {
+	if host.SubtypeFromId(id) == host.PluginSubtype {
+		return plugin.HostSetPrefix
+	}
+	return static.HostSetPrefix
+}
+
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
-//  * The path passed in is correctly formatted
-//  * All required parameters are set
-//  * There are no conflicting parameters provided
-//  * The type asserted by the ID and/or field is known
-//  * If relevant, the type derived from the id prefix matches what is claimed by the type field
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
+//   - The type asserted by the ID and/or field is known
+//   - If relevant, the type derived from the id prefix matches what is claimed by the type field
 func validateGetRequest(req *pbs.GetHostSetRequest) error {
-	return handlers.ValidateGetRequest(static.HostSetPrefix, req, handlers.NoopValidatorFn)
+	return handlers.ValidateGetRequest(setPrefix(req.GetId()), req, handlers.NoopValidatorFn)
 }
 
 func validateCreateRequest(req *pbs.CreateHostSetRequest) error {
 	return handlers.ValidateCreateRequest(req.GetItem(), func() map[string]string {
 		badFields := map[string]string{}
-		if !handlers.ValidId(static.HostCatalogPrefix, req.GetItem().GetHostCatalogId()) {
+		if !handlers.ValidId(static.HostCatalogPrefix, req.GetItem().GetHostCatalogId()) &&
+			!handlers.ValidId(plugin.HostCatalogPrefix, req.GetItem().GetHostCatalogId()) {
 			badFields["host_catalog_id"] = "The field is incorrectly formatted."
 		}
 		switch host.SubtypeFromId(req.GetItem().GetHostCatalogId()) {
@@ -435,31 +763,56 @@ func validateCreateRequest(req *pbs.CreateHostSetRequest) error {
 			if req.GetItem().GetType() != "" && req.GetItem().GetType() != host.StaticSubtype.String() {
 				badFields["type"] = "Doesn't match the parent resource's type."
 			}
+		case host.PluginSubtype:
+			if req.GetItem().GetType() != "" && req.GetItem().GetType() != host.PluginSubtype.String() {
+				badFields["type"] = "Doesn't match the parent resource's type."
+			}
+			attrs := &pb.PluginHostSetAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), attrs); err != nil {
+				badFields["attributes"] = "Attribute fields do not match the expected format."
+				break
+			}
+			if attrs.GetFilter().GetValue() == "" {
+				badFields["attributes.filter"] = "This is a required field for plugin host sets."
+			}
 		}
 		return badFields
 	})
 }
 
 func validateUpdateRequest(req *pbs.UpdateHostSetRequest) error {
-	return handlers.ValidateUpdateRequest(static.HostSetPrefix, req, req.GetItem(), func() map[string]string {
+	return handlers.ValidateUpdateRequest(setPrefix(req.GetId()), req, req.GetItem(), func() map[string]string {
 		badFields := map[string]string{}
 		switch host.SubtypeFromId(req.GetId()) {
 		case host.StaticSubtype:
 			if req.GetItem().GetType() != "" && req.GetItem().GetType() != host.StaticSubtype.String() {
 				badFields["type"] = "Cannot modify the resource type."
 			}
+		case host.PluginSubtype:
+			if req.GetItem().GetType() != "" && req.GetItem().GetType() != host.PluginSubtype.String() {
+				badFields["type"] = "Cannot modify the resource type."
+			}
+			attrs := &pb.PluginHostSetAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), attrs); err != nil {
+				badFields["attributes"] = "Attribute fields do not match the expected format."
+				break
+			}
+			if handlers.MaskContains(req.GetUpdateMask().GetPaths(), "attributes.filter") && attrs.GetFilter().GetValue() == "" {
+				badFields["attributes.filter"] = "This field cannot be unset for plugin host sets."
+			}
 		}
 		return badFields
 	})
 }
 
 func validateDeleteRequest(req *pbs.DeleteHostSetRequest) error {
-	return handlers.ValidateDeleteRequest(static.HostSetPrefix, req, handlers.NoopValidatorFn)
+	return handlers.ValidateDeleteRequest(setPrefix(req.GetId()), req, handlers.NoopValidatorFn)
 }
 
 func validateListRequest(req *pbs.ListHostSetsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(static.HostCatalogPrefix, req.GetHostCatalogId()) {
+	if !handlers.ValidId(static.HostCatalogPrefix, req.GetHostCatalogId()) &&
+		!handlers.ValidId(plugin.HostCatalogPrefix, req.GetHostCatalogId()) {
 		badFields["host_catalog_id"] = "The field is incorrectly formatted."
 	}
 	if len(badFields) > 0 {
@@ -470,7 +823,10 @@ func validateListRequest(req *pbs.ListHostSetsRequest) error {
 
 func validateAddRequest(req *pbs.AddHostSetHostsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(static.HostSetPrefix, req.GetId()) {
+	switch {
+	case handlers.ValidId(plugin.HostSetPrefix, req.GetId()):
+		badFields["id"] = "The hosts of a plugin host set are determined by its filter."
+	case !handlers.ValidId(static.HostSetPrefix, req.GetId()):
 		badFields["id"] = "Incorrectly formatted identifier."
 	}
 	if req.GetVersion() == 0 {
@@ -493,7 +849,10 @@ func validateAddRequest(req *pbs.AddHostSetHostsRequest) error {
 
 func validateSetRequest(req *pbs.SetHostSetHostsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(static.HostSetPrefix, req.GetId()) {
+	switch {
+	case handlers.ValidId(plugin.HostSetPrefix, req.GetId()):
+		badFields["id"] = "The hosts of a plugin host set are determined by its filter."
+	case !handlers.ValidId(static.HostSetPrefix, req.GetId()):
 		badFields["id"] = "Incorrectly formatted identifier."
 	}
 	if req.GetVersion() == 0 {
@@ -513,7 +872,10 @@ func validateSetRequest(req *pbs.SetHostSetHostsRequest) error {
 
 func validateRemoveRequest(req *pbs.RemoveHostSetHostsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(static.HostSetPrefix, req.GetId()) {
+	switch {
+	case handlers.ValidId(plugin.HostSetPrefix, req.GetId()):
+		badFields["id"] = "The hosts of a plugin host set are determined by its filter."
+	case !handlers.ValidId(static.HostSetPrefix, req.GetId()):
 		badFields["id"] = "Incorrectly formatted identifier."
 	}
 	if req.GetVersion() == 0 {
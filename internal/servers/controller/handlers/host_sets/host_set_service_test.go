@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"testing"
 
@@ -11,11 +12,13 @@ import (
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/db"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/hostsets"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/host"
+	"github.com/hashicorp/boundary/internal/host/plugin"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
@@ -29,8 +32,11 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
+var testAuthorizedActions = []string{"read", "update", "delete", "add-host-sets", "set-host-sets", "remove-host-sets"}
+
 func TestGet(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -43,6 +49,9 @@ func TestGet(t *testing.T) {
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
+	pluginRepoFn := func() (*plugin.Repository, error) {
+		return plugin.NewRepository(rw, rw, kms, plugin.TestPlugins(&plugin.TestPlugin{}))
+	}
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
 	hs := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
 
@@ -53,13 +62,14 @@ func TestGet(t *testing.T) {
 	toMerge := &pbs.GetHostSetRequest{}
 
 	pHost := &pb.HostSet{
-		HostCatalogId: hc.GetPublicId(),
-		Id:            hs.GetPublicId(),
-		CreatedTime:   hs.CreateTime.GetTimestamp(),
-		UpdatedTime:   hs.UpdateTime.GetTimestamp(),
-		Scope:         &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
-		Type:          "static",
-		HostIds:       hIds,
+		HostCatalogId:     hc.GetPublicId(),
+		Id:                hs.GetPublicId(),
+		CreatedTime:       hs.CreateTime.GetTimestamp(),
+		UpdatedTime:       hs.UpdateTime.GetTimestamp(),
+		Scope:             &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+		Type:              "static",
+		HostIds:           hIds,
+		AuthorizedActions: testAuthorizedActions,
 	}
 
 	cases := []struct {
@@ -98,7 +108,7 @@ func TestGet(t *testing.T) {
 			req := proto.Clone(toMerge).(*pbs.GetHostSetRequest)
 			proto.Merge(req, tc.req)
 
-			s, err := host_sets.NewService(repoFn)
+			s, err := host_sets.NewService(repoFn, pluginRepoFn)
 			require.NoError(err, "Couldn't create a new host set service.")
 
 			got, gErr := s.GetHostSet(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), req)
@@ -115,6 +125,50 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestOutputFields(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	org, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	rw := db.New(conn)
+	repoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	pluginRepoFn := func() (*plugin.Repository, error) {
+		return plugin.NewRepository(rw, rw, kms, plugin.TestPlugins(&plugin.TestPlugin{}))
+	}
+	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
+	hs := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
+	static.TestSetMembers(t, conn, hs.GetPublicId(), static.TestHosts(t, conn, hc.GetPublicId(), 2))
+
+	at := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
+	r := iam.TestRole(t, conn, proj.GetPublicId())
+	_ = iam.TestRoleGrant(t, conn, r.GetPublicId(), "id=*;type=host-set;actions=read,list;output_fields=id,type")
+	_ = iam.TestUserRole(t, conn, r.GetPublicId(), at.GetIamUserId())
+	ctx := auth.TestAuthContextFromToken(t, conn, kms, at)
+
+	// Fields left out of the grant, such as the member host ids, aren't
+	// returned
+	want := &pb.HostSet{
+		Id:   hs.GetPublicId(),
+		Type: "static",
+	}
+
+	s, err := host_sets.NewService(repoFn, pluginRepoFn)
+	require.NoError(t, err)
+
+	got, err := s.GetHostSet(ctx, &pbs.GetHostSetRequest{Id: hs.GetPublicId()})
+	require.NoError(t, err)
+	assert.Empty(t, cmp.Diff(want, got.GetItem(), protocmp.Transform()))
+
+	list, err := s.ListHostSets(ctx, &pbs.ListHostSetsRequest{HostCatalogId: hc.GetPublicId()})
+	require.NoError(t, err)
+	require.Len(t, list.GetItems(), 1)
+	assert.Empty(t, cmp.Diff(want, list.GetItems()[0], protocmp.Transform()))
+}
+
 func TestList(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	wrapper := db.TestWrapper(t)
@@ -126,22 +180,29 @@ func TestList(t *testing.T) {
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
+	pluginRepoFn := func() (*plugin.Repository, error) {
+		return plugin.NewRepository(rw, rw, kms, plugin.TestPlugins(&plugin.TestPlugin{}))
+	}
 	hcs := static.TestCatalogs(t, conn, proj.GetPublicId(), 2)
 	hc, hcNoHosts := hcs[0], hcs[1]
 
 	var wantHs []*pb.HostSet
 	for _, h := range static.TestSets(t, conn, hc.GetPublicId(), 10) {
 		wantHs = append(wantHs, &pb.HostSet{
-			Id:            h.GetPublicId(),
-			HostCatalogId: h.GetCatalogId(),
-			Scope:         &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
-			CreatedTime:   h.GetCreateTime().GetTimestamp(),
-			UpdatedTime:   h.GetUpdateTime().GetTimestamp(),
-			Version:       h.GetVersion(),
-			Type:          host.StaticSubtype.String(),
+			Id:                h.GetPublicId(),
+			HostCatalogId:     h.GetCatalogId(),
+			Scope:             &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+			CreatedTime:       h.GetCreateTime().GetTimestamp(),
+			UpdatedTime:       h.GetUpdateTime().GetTimestamp(),
+			Version:           h.GetVersion(),
+			Type:              host.StaticSubtype.String(),
+			AuthorizedActions: testAuthorizedActions,
 		})
 	}
 
+	// Listed resources are ordered by id
+	sort.Slice(wantHs, func(i, j int) bool { return wantHs[i].GetId() < wantHs[j].GetId() })
+
 	cases := []struct {
 		name          string
 		hostCatalogId string
@@ -162,7 +223,7 @@ func TestList(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := host_sets.NewService(repoFn)
+			s, err := host_sets.NewService(repoFn, pluginRepoFn)
 			require.NoError(err, "Couldn't create new host set service.")
 
 			got, gErr := s.ListHostSets(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), &pbs.ListHostSetsRequest{HostCatalogId: tc.hostCatalogId})
@@ -187,10 +248,13 @@ func TestDelete(t *testing.T) {
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
+	pluginRepoFn := func() (*plugin.Repository, error) {
+		return plugin.NewRepository(rw, rw, kms, plugin.TestPlugins(&plugin.TestPlugin{}))
+	}
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
 	h := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
 
-	s, err := host_sets.NewService(repoFn)
+	s, err := host_sets.NewService(repoFn, pluginRepoFn)
 	require.NoError(t, err, "Couldn't create a new host set service.")
 
 	cases := []struct {
@@ -259,10 +323,13 @@ func TestDelete_twice(t *testing.T) {
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
+	pluginRepoFn := func() (*plugin.Repository, error) {
+		return plugin.NewRepository(rw, rw, kms, plugin.TestPlugins(&plugin.TestPlugin{}))
+	}
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
 	h := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
 
-	s, err := host_sets.NewService(repoFn)
+	s, err := host_sets.NewService(repoFn, pluginRepoFn)
 	require.NoError(err, "Couldn't create a new host set service.")
 	req := &pbs.DeleteHostSetRequest{
 		Id: h.GetPublicId(),
@@ -287,6 +354,9 @@ func TestCreate(t *testing.T) {
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
+	pluginRepoFn := func() (*plugin.Repository, error) {
+		return plugin.NewRepository(rw, rw, kms, plugin.TestPlugins(&plugin.TestPlugin{}))
+	}
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
 
 	defaultHcCreated, err := ptypes.Timestamp(hc.GetCreateTime().GetTimestamp())
@@ -377,7 +447,7 @@ func TestCreate(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
 
-			s, err := host_sets.NewService(repoFn)
+			s, err := host_sets.NewService(repoFn, pluginRepoFn)
 			require.NoError(err, "Failed to create a new host set service.")
 
 			got, gErr := s.CreateHostSet(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), tc.req)
@@ -421,6 +491,9 @@ func TestUpdate(t *testing.T) {
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
+	pluginRepoFn := func() (*plugin.Repository, error) {
+		return plugin.NewRepository(rw, rw, kms, plugin.TestPlugins(&plugin.TestPlugin{}))
+	}
 	repo, err := repoFn()
 	require.NoError(t, err, "Couldn't create new static repo.")
 
@@ -451,7 +524,7 @@ func TestUpdate(t *testing.T) {
 		Id: hs.GetPublicId(),
 	}
 
-	tested, err := host_sets.NewService(repoFn)
+	tested, err := host_sets.NewService(repoFn, pluginRepoFn)
 	require.NoError(t, err, "Failed to create a new host set service.")
 
 	cases := []struct {
@@ -765,6 +838,109 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestPluginHostSet(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	rw := db.New(conn)
+	repoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	testPlugin := &plugin.TestPlugin{
+		Hosts: []*plugin.HostInfo{
+			{ExternalId: "i-1", Name: "web-1", Address: "10.0.0.1"},
+			{ExternalId: "i-2", Name: "web-2", Address: "10.0.0.2"},
+		},
+	}
+	pluginRepoFn := func() (*plugin.Repository, error) {
+		return plugin.NewRepository(rw, rw, kms, plugin.TestPlugins(testPlugin))
+	}
+	hc := plugin.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
+
+	s, err := host_sets.NewService(repoFn, pluginRepoFn)
+	require.NoError(t, err, "Couldn't create a new host set service.")
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId()))
+
+	filterAttrs := func(filter string) *structpb.Struct {
+		st, err := structpb.NewStruct(map[string]interface{}{"filter": filter})
+		require.NoError(t, err)
+		return st
+	}
+
+	t.Run("create requires a filter", func(t *testing.T) {
+		_, err := s.CreateHostSet(ctx, &pbs.CreateHostSetRequest{Item: &pb.HostSet{
+			HostCatalogId: hc.GetPublicId(),
+			Type:          "plugin",
+		}})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)))
+	})
+
+	t.Run("create with filter rejected by the plugin", func(t *testing.T) {
+		_, err := s.CreateHostSet(ctx, &pbs.CreateHostSetRequest{Item: &pb.HostSet{
+			HostCatalogId: hc.GetPublicId(),
+			Type:          "plugin",
+			Attributes:    filterAttrs("invalid"),
+		}})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)))
+	})
+
+	assert, require := assert.New(t), require.New(t)
+	created, err := s.CreateHostSet(ctx, &pbs.CreateHostSetRequest{Item: &pb.HostSet{
+		HostCatalogId: hc.GetPublicId(),
+		Name:          &wrappers.StringValue{Value: "web"},
+		Type:          "plugin",
+		Attributes:    filterAttrs(`"web" in "/tags/role"`),
+	}})
+	require.NoError(err)
+	assert.True(strings.HasPrefix(created.GetItem().GetId(), plugin.HostSetPrefix))
+	assert.Equal("plugin", created.GetItem().GetType())
+	assert.Empty(cmp.Diff(filterAttrs(`"web" in "/tags/role"`), created.GetItem().GetAttributes(), protocmp.Transform()))
+
+	got, err := s.GetHostSet(ctx, &pbs.GetHostSetRequest{Id: created.GetItem().GetId()})
+	require.NoError(err)
+	assert.Len(got.GetItem().GetHostIds(), 2)
+	assert.Equal(1, testPlugin.ListCalls)
+
+	// The members are cached so a second read does not call the plugin.
+	_, err = s.GetHostSet(ctx, &pbs.GetHostSetRequest{Id: created.GetItem().GetId()})
+	require.NoError(err)
+	assert.Equal(1, testPlugin.ListCalls)
+
+	testPlugin.Hosts = testPlugin.Hosts[:1]
+	updated, err := s.UpdateHostSet(ctx, &pbs.UpdateHostSetRequest{
+		Id:         created.GetItem().GetId(),
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"attributes.filter"}},
+		Item: &pb.HostSet{
+			Version:    created.GetItem().GetVersion(),
+			Attributes: filterAttrs(`"api" in "/tags/role"`),
+		},
+	})
+	require.NoError(err)
+	assert.Len(updated.GetItem().GetHostIds(), 1)
+	assert.Equal(2, testPlugin.ListCalls)
+
+	_, err = s.AddHostSetHosts(ctx, &pbs.AddHostSetHostsRequest{
+		Id:      created.GetItem().GetId(),
+		Version: updated.GetItem().GetVersion(),
+		HostIds: updated.GetItem().GetHostIds(),
+	})
+	require.Error(err)
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)))
+
+	list, err := s.ListHostSets(ctx, &pbs.ListHostSetsRequest{HostCatalogId: hc.GetPublicId()})
+	require.NoError(err)
+	assert.Len(list.GetItems(), 1)
+
+	_, err = s.DeleteHostSet(ctx, &pbs.DeleteHostSetRequest{Id: created.GetItem().GetId()})
+	require.NoError(err)
+}
+
 func TestAddHostSetHosts(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	wrapper := db.TestWrapper(t)
@@ -776,7 +952,10 @@ func TestAddHostSetHosts(t *testing.T) {
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
-	s, err := host_sets.NewService(repoFn)
+	pluginRepoFn := func() (*plugin.Repository, error) {
+		return plugin.NewRepository(rw, rw, kms, plugin.TestPlugins(&plugin.TestPlugin{}))
+	}
+	s, err := host_sets.NewService(repoFn, pluginRepoFn)
 	require.NoError(t, err, "Error when getting new host set service.")
 
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
@@ -888,7 +1067,10 @@ func TestSetHostSetHosts(t *testing.T) {
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
-	s, err := host_sets.NewService(repoFn)
+	pluginRepoFn := func() (*plugin.Repository, error) {
+		return plugin.NewRepository(rw, rw, kms, plugin.TestPlugins(&plugin.TestPlugin{}))
+	}
+	s, err := host_sets.NewService(repoFn, pluginRepoFn)
 	require.NoError(t, err, "Error when getting new host set service.")
 
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
@@ -996,7 +1178,10 @@ func TestRemoveHostSetHosts(t *testing.T) {
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
-	s, err := host_sets.NewService(repoFn)
+	pluginRepoFn := func() (*plugin.Repository, error) {
+		return plugin.NewRepository(rw, rw, kms, plugin.TestPlugins(&plugin.TestPlugin{}))
+	}
+	s, err := host_sets.NewService(repoFn, pluginRepoFn)
 	require.NoError(t, err, "Error when getting new host set service.")
 
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
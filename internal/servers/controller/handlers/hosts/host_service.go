@@ -23,6 +23,14 @@ import (
 
 var (
 	maskManager handlers.MaskManager
+
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.Read,
+		action.Update,
+		action.Delete,
+	}
 )
 
 func init() {
@@ -57,14 +65,37 @@ func (s Service) ListHosts(ctx context.Context, req *pbs.ListHostsRequest) (*pbs
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	hl, err := s.listFromRepo(ctx, req.GetHostCatalogId())
+	fetch := func(afterId string, limit int) ([]handlers.ListItem, error) {
+		hl, err := s.listFromRepo(ctx, req.GetHostCatalogId(), afterId, limit)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]handlers.ListItem, 0, len(hl))
+		for _, h := range hl {
+			if authResults.DeniedForId(ctx, h.GetPublicId(), action.Read) {
+				items = append(items, handlers.HiddenListItem(h.GetPublicId()))
+				continue
+			}
+			item, err := toProto(h, nil,
+				handlers.WithScope(authResults.Scope),
+				handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, h.GetPublicId(), IdActions).Strings()),
+				handlers.WithOutputFields(authResults.FetchOutputFields(ctx, h.GetPublicId())))
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, handlers.ListedItem(h.GetPublicId(), item))
+		}
+		return items, nil
+	}
+	items, next, err := handlers.ListPage(fetch, req.GetFilter(), req.GetPageSize(), req.GetPageToken())
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range hl {
-		item.Scope = authResults.Scope
+	var hl []*pb.Host
+	for _, item := range items {
+		hl = append(hl, item.(*pb.Host))
 	}
-	return &pbs.ListHostsResponse{Items: hl}, nil
+	return &pbs.ListHostsResponse{Items: hl, NextPageToken: next}, nil
 }
 
 // GetHost implements the interface pbs.HostServiceServer.
@@ -76,11 +107,13 @@ func (s Service) GetHost(ctx context.Context, req *pbs.GetHostRequest) (*pbs.Get
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	hc, err := s.getFromRepo(ctx, req.GetId())
+	hc, err := s.getFromRepo(ctx, req.GetId(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, req.GetId(), IdActions).Strings()),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	hc.Scope = authResults.Scope
 	return &pbs.GetHostResponse{Item: hc}, nil
 }
 
@@ -93,11 +126,12 @@ func (s Service) CreateHost(ctx context.Context, req *pbs.CreateHostRequest) (*p
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	h, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem().GetHostCatalogId(), req.GetItem())
+	h, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem().GetHostCatalogId(), req.GetItem(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, "")))
 	if err != nil {
 		return nil, err
 	}
-	h.Scope = authResults.Scope
 	return &pbs.CreateHostResponse{
 		Item: h,
 		Uri:  fmt.Sprintf("hosts/%s", h.GetId()),
@@ -113,11 +147,12 @@ func (s Service) UpdateHost(ctx context.Context, req *pbs.UpdateHostRequest) (*p
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	hc, err := s.updateInRepo(ctx, authResults.Scope.GetId(), cat.GetPublicId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	hc, err := s.updateInRepo(ctx, authResults.Scope.GetId(), cat.GetPublicId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	hc.Scope = authResults.Scope
 	return &pbs.UpdateHostResponse{Item: hc}, nil
 }
 
@@ -137,7 +172,7 @@ func (s Service) DeleteHost(ctx context.Context, req *pbs.DeleteHostRequest) (*p
 	return &pbs.DeleteHostResponse{}, nil
 }
 
-func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Host, error) {
+func (s Service) getFromRepo(ctx context.Context, id string, opt ...handlers.Option) (*pb.Host, error) {
 	repo, err := s.staticRepoFn()
 	if err != nil {
 		return nil, err
@@ -149,10 +184,10 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Host, error) {
 	if h == nil {
 		return nil, handlers.NotFoundErrorf("Host %q doesn't exist.", id)
 	}
-	return toProto(h, nil)
+	return toProto(h, nil, opt...)
 }
 
-func (s Service) createInRepo(ctx context.Context, scopeId, catalogId string, item *pb.Host) (*pb.Host, error) {
+func (s Service) createInRepo(ctx context.Context, scopeId, catalogId string, item *pb.Host, opt ...handlers.Option) (*pb.Host, error) {
 	ha := &pb.StaticHostAttributes{}
 	if err := handlers.StructToProto(item.GetAttributes(), ha); err != nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Failed converting attributes to subtype proto: %s", err)
@@ -187,10 +222,10 @@ func (s Service) createInRepo(ctx context.Context, scopeId, catalogId string, it
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create host but no error returned from repository.")
 	}
-	return toProto(out, nil)
+	return toProto(out, nil, opt...)
 }
 
-func (s Service) updateInRepo(ctx context.Context, scopeId, catalogId, id string, mask []string, item *pb.Host) (*pb.Host, error) {
+func (s Service) updateInRepo(ctx context.Context, scopeId, catalogId, id string, mask []string, item *pb.Host, opt ...handlers.Option) (*pb.Host, error) {
 	ha := &pb.StaticHostAttributes{}
 	if err := handlers.StructToProto(item.GetAttributes(), ha); err != nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Failed converting attributes to subtype proto: %s", err)
@@ -225,7 +260,7 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, catalogId, id string
 	if rowsUpdated == 0 {
 		return nil, handlers.NotFoundErrorf("Host %q doesn't exist or incorrect version provided.", id)
 	}
-	return toProto(out, nil)
+	return toProto(out, nil, opt...)
 }
 
 func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool, error) {
@@ -240,24 +275,16 @@ func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool,
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, catalogId string) ([]*pb.Host, error) {
+func (s Service) listFromRepo(ctx context.Context, catalogId string, afterId string, limit int) ([]*static.Host, error) {
 	repo, err := s.staticRepoFn()
 	if err != nil {
 		return nil, err
 	}
-	hl, err := repo.ListHosts(ctx, catalogId)
+	hl, err := repo.ListHosts(ctx, catalogId, static.WithStartPageAfterId(afterId), static.WithLimit(limit))
 	if err != nil {
 		return nil, err
 	}
-	var outHl []*pb.Host
-	for _, h := range hl {
-		p, err := toProto(h, nil)
-		if err != nil {
-			return nil, err
-		}
-		outHl = append(outHl, p)
-	}
-	return outHl, nil
+	return hl, nil
 }
 
 func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Type) (*static.HostCatalog, auth.VerifyResults) {
@@ -300,39 +327,62 @@ func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Ty
 	return cat, auth.Verify(ctx, opts...)
 }
 
-func toProto(in *static.Host, members []*static.HostSet) (*pb.Host, error) {
-	out := pb.Host{
-		Id:            in.GetPublicId(),
-		HostCatalogId: in.GetCatalogId(),
-		Type:          host.StaticSubtype.String(),
-		CreatedTime:   in.GetCreateTime().GetTimestamp(),
-		UpdatedTime:   in.GetUpdateTime().GetTimestamp(),
-		Version:       in.GetVersion(),
-	}
-	if in.GetDescription() != "" {
+func toProto(in *static.Host, members []*static.HostSet, opt ...handlers.Option) (*pb.Host, error) {
+	opts := handlers.GetOpts(opt...)
+	outputFields := opts.WithOutputFields
+	out := pb.Host{}
+	if outputFields.Has("id") {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has("host_catalog_id") {
+		out.HostCatalogId = in.GetCatalogId()
+	}
+	if outputFields.Has("scope") {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has("type") {
+		out.Type = host.StaticSubtype.String()
+	}
+	if outputFields.Has("created_time") {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
+	}
+	if outputFields.Has("updated_time") {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has("version") {
+		out.Version = in.GetVersion()
+	}
+	if outputFields.Has("description") && in.GetDescription() != "" {
 		out.Description = wrapperspb.String(in.GetDescription())
 	}
-	if in.GetName() != "" {
+	if outputFields.Has("name") && in.GetName() != "" {
 		out.Name = wrapperspb.String(in.GetName())
 	}
-	for _, m := range members {
-		out.HostSetIds = append(out.HostSetIds, m.GetPublicId())
+	if outputFields.Has("host_set_ids") {
+		for _, m := range members {
+			out.HostSetIds = append(out.HostSetIds, m.GetPublicId())
+		}
 	}
-	st, err := handlers.ProtoToStruct(&pb.StaticHostAttributes{Address: wrapperspb.String(in.GetAddress())})
-	if err != nil {
-		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to convert static attribute to struct: %s", err)
+	if outputFields.Has("attributes") {
+		st, err := handlers.ProtoToStruct(&pb.StaticHostAttributes{Address: wrapperspb.String(in.GetAddress())})
+		if err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to convert static attribute to struct: %s", err)
+		}
+		out.Attributes = st
+	}
+	if outputFields.Has("authorized_actions") {
+		out.AuthorizedActions = opts.WithAuthorizedActions
 	}
-	out.Attributes = st
 	return &out, nil
 }
 
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
-//  * The path passed in is correctly formatted
-//  * All required parameters are set
-//  * There are no conflicting parameters provided
-//  * The type asserted by the ID and/or field is known
-//  * If relevant, the type derived from the id prefix matches what is claimed by the type field
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
+//   - The type asserted by the ID and/or field is known
+//   - If relevant, the type derived from the id prefix matches what is claimed by the type field
 func validateGetRequest(req *pbs.GetHostRequest) error {
 	return handlers.ValidateGetRequest(static.HostPrefix, req, func() map[string]string {
 		badFields := map[string]string{}
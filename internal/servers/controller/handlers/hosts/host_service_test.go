@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"testing"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/db"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/hosts"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
@@ -31,6 +33,8 @@ import (
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
+var testAuthorizedActions = []string{"read", "update", "delete"}
+
 func TestGet(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -56,6 +60,7 @@ func TestGet(t *testing.T) {
 		Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
 			"address": structpb.NewStringValue(h.GetAddress()),
 		}},
+		AuthorizedActions: testAuthorizedActions,
 	}
 
 	cases := []struct {
@@ -108,6 +113,46 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestOutputFields(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	org, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	rw := db.New(conn)
+	repoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
+	h := static.TestHosts(t, conn, hc.GetPublicId(), 1)[0]
+
+	at := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
+	r := iam.TestRole(t, conn, proj.GetPublicId())
+	_ = iam.TestRoleGrant(t, conn, r.GetPublicId(), "id=*;type=host;actions=read,list;output_fields=id,host_catalog_id")
+	_ = iam.TestUserRole(t, conn, r.GetPublicId(), at.GetIamUserId())
+	ctx := auth.TestAuthContextFromToken(t, conn, kms, at)
+
+	// Fields left out of the grant, such as the attributes holding the
+	// address, aren't returned
+	want := &pb.Host{
+		Id:            h.GetPublicId(),
+		HostCatalogId: hc.GetPublicId(),
+	}
+
+	s, err := hosts.NewService(repoFn)
+	require.NoError(t, err)
+
+	got, err := s.GetHost(ctx, &pbs.GetHostRequest{Id: h.GetPublicId()})
+	require.NoError(t, err)
+	assert.Empty(t, cmp.Diff(want, got.GetItem(), protocmp.Transform()))
+
+	list, err := s.ListHosts(ctx, &pbs.ListHostsRequest{HostCatalogId: hc.GetPublicId()})
+	require.NoError(t, err)
+	require.Len(t, list.GetItems(), 1)
+	assert.Empty(t, cmp.Diff(want, list.GetItems()[0], protocmp.Transform()))
+}
+
 func TestList(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	wrapper := db.TestWrapper(t)
@@ -134,9 +179,13 @@ func TestList(t *testing.T) {
 			Type:          host.StaticSubtype.String(), Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
 				"address": structpb.NewStringValue(h.GetAddress()),
 			}},
+			AuthorizedActions: testAuthorizedActions,
 		})
 	}
 
+	// Listed resources are ordered by id
+	sort.Slice(wantHs, func(i, j int) bool { return wantHs[i].GetId() < wantHs[j].GetId() })
+
 	cases := []struct {
 		name          string
 		hostCatalogId string
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"unicode/utf8"
+
+	"github.com/hashicorp/go-bexpr"
+	"github.com/mitchellh/pointerstructure"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// ListItem is a resource returned by a List RPC.
+type ListItem interface {
+	proto.Message
+	GetId() string
+}
+
+// ListFunc fetches up to limit resources whose ids sort after afterId, ordered
+// by id. An empty afterId starts from the first resource and a limit of 0
+// uses the repository's default limit. Resources fetched but left out of the
+// response are returned as HiddenListItem so that they still count towards
+// paging.
+type ListFunc func(afterId string, limit int) ([]ListItem, error)
+
+// listedItem is an item listed under the id of its resource, whether or not
+// the item includes it.
+type listedItem struct {
+	ListItem
+	id     string
+	hidden bool
+}
+
+func (l *listedItem) GetId() string {
+	return l.id
+}
+
+// ListedItem returns item to be listed as the resource with the given id, for
+// items whose output fields may leave the id out.
+func ListedItem(id string, item ListItem) ListItem {
+	return &listedItem{ListItem: item, id: id}
+}
+
+// HiddenListItem returns an item standing for the fetched resource with the
+// given id which is left out of the response, such as one the user is denied
+// reading.
+func HiddenListItem(id string) ListItem {
+	return &listedItem{id: id, hidden: true}
+}
+
+// Filter matches resources against a boolean expression evaluated on their
+// API representation, which is available under "/item".
+type Filter struct {
+	eval *bexpr.Evaluator
+}
+
+// NewFilter returns a Filter for the expression. An empty expression matches
+// every resource.
+func NewFilter(expression string) (*Filter, error) {
+	if expression == "" {
+		return &Filter{}, nil
+	}
+	eval, err := bexpr.CreateEvaluator(expression)
+	if err != nil {
+		return nil, err
+	}
+	return &Filter{eval: eval}, nil
+}
+
+// Match reports whether the item matches the filter. Selectors referring to
+// fields that are not set on the item do not match.
+func (f *Filter) Match(item proto.Message) (bool, error) {
+	if f.eval == nil {
+		return true, nil
+	}
+	m, err := FilterItem(item)
+	if err != nil {
+		return false, err
+	}
+	ok, err := f.eval.Evaluate(map[string]interface{}{"item": m})
+	if err != nil {
+		if errors.Is(err, pointerstructure.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return ok, nil
+}
+
+// FilterItem returns the API representation of the item as filters see it,
+// which is also what grant filters are evaluated against.
+func FilterItem(item proto.Message) (map[string]interface{}, error) {
+	js, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(js, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ListPage returns the resources of a page of a List RPC, applying the request's
+// filter, page_size and page_token, along with the token of the next page.
+// Resources are fetched in batches of pageSize until the page is full so that
+// a page is complete even when the filter excludes resources. Without a
+// pageSize every resource is returned, fetched in batches of the repository's
+// default limit until one comes back empty. The next page token is empty once
+// all resources have been listed.
+func ListPage(fetch ListFunc, filter string, pageSize uint32, pageToken string) ([]ListItem, string, error) {
+	badFields := map[string]string{}
+	f, err := NewFilter(filter)
+	if err != nil {
+		badFields["filter"] = "Unable to successfully parse filter expression."
+	}
+	afterId, err := decodePageToken(pageToken)
+	if err != nil {
+		badFields["page_token"] = "Invalid page token."
+	}
+	if len(badFields) > 0 {
+		return nil, "", InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+
+	limit := int(pageSize)
+	var items []ListItem
+	for {
+		fetched, err := fetch(afterId, limit)
+		if err != nil {
+			return nil, "", err
+		}
+		for i, item := range fetched {
+			afterId = item.GetId()
+			if l, ok := item.(*listedItem); ok {
+				if l.hidden {
+					continue
+				}
+				item = l.ListItem
+			}
+			ok, err := f.Match(item)
+			if err != nil {
+				return nil, "", InvalidArgumentErrorf("Error in provided request.",
+					map[string]string{"filter": "Unable to evaluate filter expression."})
+			}
+			if !ok {
+				continue
+			}
+			items = append(items, item)
+			if limit > 0 && len(items) == limit {
+				if i < len(fetched)-1 || len(fetched) == limit {
+					return items, encodePageToken(afterId), nil
+				}
+				return items, "", nil
+			}
+		}
+		// The repository's default limit isn't known here, so without a page
+		// size only an empty batch tells us every resource has been fetched.
+		if len(fetched) == 0 || (limit > 0 && len(fetched) < limit) {
+			return items, "", nil
+		}
+	}
+}
+
+func encodePageToken(afterId string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(afterId))
+}
+
+func decodePageToken(token string) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", err
+	}
+	if len(b) == 0 || !utf8.Valid(b) {
+		return "", errors.New("invalid page token")
+	}
+	return string(b), nil
+}
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestFilter_Match(t *testing.T) {
+	item := &scopes.ScopeInfo{Id: "o_1234567890", Type: "org", Name: "prod"}
+	cases := []struct {
+		name   string
+		filter string
+		want   bool
+	}{
+		{name: "empty", filter: "", want: true},
+		{name: "equal", filter: `"/item/name" == "prod"`, want: true},
+		{name: "not equal", filter: `"/item/name" == "dev"`, want: false},
+		{name: "and", filter: `"/item/name" == "prod" and "/item/type" == "org"`, want: true},
+		{name: "unset field", filter: `"/item/description" == "prod"`, want: false},
+		{name: "matches", filter: `"/item/id" matches "^o_"`, want: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := NewFilter(tc.filter)
+			require.NoError(t, err)
+			got, err := f.Match(item)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	_, err := NewFilter(`"/item/name" ==`)
+	assert.Error(t, err)
+}
+
+// testFetcher lists the items, which must be ordered by id, and counts the
+// times it was called. A limit of 0 uses defaultLimit when it is set, like a
+// repository would.
+type testFetcher struct {
+	items        []*scopes.ScopeInfo
+	defaultLimit int
+	calls        int
+}
+
+func (f *testFetcher) fetch(afterId string, limit int) ([]ListItem, error) {
+	f.calls++
+	if limit == 0 {
+		limit = f.defaultLimit
+	}
+	var ret []ListItem
+	for _, i := range f.items {
+		if i.GetId() <= afterId {
+			continue
+		}
+		if limit > 0 && len(ret) == limit {
+			break
+		}
+		ret = append(ret, i)
+	}
+	return ret, nil
+}
+
+func ids(items []ListItem) []string {
+	var ret []string
+	for _, i := range items {
+		ret = append(ret, i.GetId())
+	}
+	return ret
+}
+
+func TestListPage(t *testing.T) {
+	fetcher := &testFetcher{}
+	for i := 0; i < 10; i++ {
+		typ := "org"
+		if i%2 == 1 {
+			typ = "project"
+		}
+		fetcher.items = append(fetcher.items, &scopes.ScopeInfo{Id: fmt.Sprintf("s_%d", i), Type: typ})
+	}
+
+	t.Run("all", func(t *testing.T) {
+		items, next, err := ListPage(fetcher.fetch, "", 0, "")
+		require.NoError(t, err)
+		assert.Len(t, items, 10)
+		assert.Empty(t, next)
+	})
+
+	t.Run("all past default limit", func(t *testing.T) {
+		limited := &testFetcher{items: fetcher.items, defaultLimit: 4}
+		items, next, err := ListPage(limited.fetch, "", 0, "")
+		require.NoError(t, err)
+		assert.Equal(t, ids(mustFetch(t, fetcher)), ids(items))
+		assert.Empty(t, next)
+		assert.Equal(t, 4, limited.calls)
+
+		limited.calls = 0
+		items, next, err = ListPage(limited.fetch, `"/item/type" == "project"`, 0, "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"s_1", "s_3", "s_5", "s_7", "s_9"}, ids(items))
+		assert.Empty(t, next)
+		assert.Equal(t, 4, limited.calls)
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		var got []string
+		var token string
+		pages := 0
+		for {
+			items, next, err := ListPage(fetcher.fetch, "", 4, token)
+			require.NoError(t, err)
+			got = append(got, ids(items)...)
+			pages++
+			if next == "" {
+				break
+			}
+			token = next
+		}
+		assert.Equal(t, 3, pages)
+		assert.Equal(t, ids(mustFetch(t, fetcher)), got)
+	})
+
+	t.Run("filtered pages", func(t *testing.T) {
+		fetcher.calls = 0
+		items, next, err := ListPage(fetcher.fetch, `"/item/type" == "project"`, 3, "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"s_1", "s_3", "s_5"}, ids(items))
+		assert.NotEmpty(t, next)
+		assert.Equal(t, 2, fetcher.calls)
+
+		items, next, err = ListPage(fetcher.fetch, `"/item/type" == "project"`, 3, next)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"s_7", "s_9"}, ids(items))
+		assert.Empty(t, next)
+	})
+
+	t.Run("hidden and listed items", func(t *testing.T) {
+		// Hide the first half of the items and list the others without their
+		// ids, as output fields may leave them out
+		hiding := func(afterId string, limit int) ([]ListItem, error) {
+			fetched, err := fetcher.fetch(afterId, limit)
+			if err != nil {
+				return nil, err
+			}
+			ret := make([]ListItem, 0, len(fetched))
+			for _, item := range fetched {
+				if item.GetId() < "s_5" {
+					ret = append(ret, HiddenListItem(item.GetId()))
+					continue
+				}
+				ret = append(ret, ListedItem(item.GetId(), &scopes.ScopeInfo{Type: item.(*scopes.ScopeInfo).GetType()}))
+			}
+			return ret, nil
+		}
+
+		items, next, err := ListPage(hiding, "", 0, "")
+		require.NoError(t, err)
+		assert.Len(t, items, 5)
+		assert.Empty(t, next)
+		for _, item := range items {
+			_, ok := item.(*scopes.ScopeInfo)
+			assert.True(t, ok)
+		}
+
+		// A batch of hidden items doesn't end the listing early
+		items, next, err = ListPage(hiding, "", 3, "")
+		require.NoError(t, err)
+		assert.Len(t, items, 3)
+		require.NotEmpty(t, next)
+		items, next, err = ListPage(hiding, "", 3, next)
+		require.NoError(t, err)
+		assert.Len(t, items, 2)
+		assert.Empty(t, next)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		_, _, err := ListPage(fetcher.fetch, `"/item/type" ==`, 0, "")
+		assert.True(t, errors.Is(err, ApiErrorWithCode(codes.InvalidArgument)), "got error %v", err)
+
+		_, _, err = ListPage(fetcher.fetch, "", 0, "not a token!")
+		assert.True(t, errors.Is(err, ApiErrorWithCode(codes.InvalidArgument)), "got error %v", err)
+	})
+}
+
+func mustFetch(t *testing.T, f *testFetcher) []ListItem {
+	t.Helper()
+	items, err := f.fetch("", 0)
+	require.NoError(t, err)
+	return items
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
+	"github.com/hashicorp/boundary/internal/perms"
+)
+
+// GetOpts - iterate the inbound Options and return a struct.
+func GetOpts(opt ...Option) Options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments.
+type Option func(*Options)
+
+// Options - how Options are represented, which are accessed by the service
+// handlers when converting resources to their API representation.
+type Options struct {
+	WithOutputFields      perms.OutputFieldsMap
+	WithScope             *scopes.ScopeInfo
+	WithAuthorizedActions []string
+}
+
+func getDefaultOptions() Options {
+	return Options{}
+}
+
+// WithOutputFields provides the fields of the resource returned in the
+// response. All fields are returned if it is not provided.
+func WithOutputFields(fields perms.OutputFieldsMap) Option {
+	return func(o *Options) {
+		o.WithOutputFields = fields
+	}
+}
+
+// WithScope provides the info of the scope of the resource to include in the
+// response.
+func WithScope(scp *scopes.ScopeInfo) Option {
+	return func(o *Options) {
+		o.WithScope = scp
+	}
+}
+
+// WithAuthorizedActions provides the actions the user may perform on the
+// resource to include in the response.
+func WithAuthorizedActions(actions []string) Option {
+	return func(o *Options) {
+		o.WithAuthorizedActions = actions
+	}
+}
@@ -18,7 +18,9 @@ func OutgoingInterceptor(ctx context.Context, w http.ResponseWriter, m proto.Mes
 	m = m.ProtoReflect().Interface()
 	switch m := m.(type) {
 	case *pbs.AuthenticateResponse:
-		if strings.EqualFold(m.GetTokenType(), "cookie") {
+		// A response without an item is returned while an authentication
+		// attempt with an external provider is pending.
+		if strings.EqualFold(m.GetTokenType(), "cookie") && m.GetItem() != nil {
 			tok := m.GetItem().GetToken()
 			m.GetItem().Token = ""
 			half := len(tok) / 2
@@ -22,3 +22,11 @@ func TestOutgoingSplitCookie(t *testing.T) {
 		{Name: JsVisibleCookieName, Value: "t_abc_12", Raw: "wt-js-token-cookie=t_abc_12"},
 	})
 }
+
+func TestOutgoingSplitCookie_NoItem(t *testing.T) {
+	rec := httptest.NewRecorder()
+	OutgoingInterceptor(context.Background(), rec, &pbs.AuthenticateResponse{
+		TokenType: "cookie",
+	})
+	assert.Empty(t, rec.Result().Cookies())
+}
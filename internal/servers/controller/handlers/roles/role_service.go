@@ -3,6 +3,7 @@ package roles
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/auth"
 	"github.com/hashicorp/boundary/internal/errors"
@@ -13,6 +14,7 @@ import (
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/targets"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
@@ -23,6 +25,20 @@ import (
 
 var (
 	maskManager handlers.MaskManager
+
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.Read,
+		action.Update,
+		action.Delete,
+		action.AddPrincipals,
+		action.SetPrincipals,
+		action.RemovePrincipals,
+		action.AddGrants,
+		action.SetGrants,
+		action.RemoveGrants,
+	}
 )
 
 func init() {
@@ -36,15 +52,19 @@ func init() {
 type Service struct {
 	pbs.UnimplementedRoleServiceServer
 
-	repoFn common.IamRepoFactory
+	repoFn       common.IamRepoFactory
+	targetRepoFn common.TargetRepoFactory
 }
 
 // NewService returns a role service which handles role related requests to boundary.
-func NewService(repo common.IamRepoFactory) (Service, error) {
+func NewService(repo common.IamRepoFactory, targetRepoFn common.TargetRepoFactory) (Service, error) {
 	if repo == nil {
 		return Service{}, fmt.Errorf("nil iam repository provided")
 	}
-	return Service{repoFn: repo}, nil
+	if targetRepoFn == nil {
+		return Service{}, fmt.Errorf("nil target repository provided")
+	}
+	return Service{repoFn: repo, targetRepoFn: targetRepoFn}, nil
 }
 
 var _ pbs.RoleServiceServer = Service{}
@@ -55,17 +75,42 @@ func (s Service) ListRoles(ctx context.Context, req *pbs.ListRolesRequest) (*pbs
 		return nil, err
 	}
 	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
-	if authResults.Error != nil {
-		return nil, authResults.Error
+	scopeIds, scopeInfos, err := authResults.ScopesForList(ctx, resource.Role, req.GetRecursive())
+	if err != nil {
+		return nil, err
+	}
+	if len(scopeIds) == 0 {
+		return &pbs.ListRolesResponse{}, nil
 	}
-	gl, err := s.listFromRepo(ctx, req.GetScopeId())
+	fetch := func(afterId string, limit int) ([]handlers.ListItem, error) {
+		gl, err := s.listFromRepo(ctx, scopeIds, afterId, limit)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]handlers.ListItem, 0, len(gl))
+		for _, r := range gl {
+			resOpt := auth.WithScopeId(r.GetScopeId())
+			if authResults.DeniedForId(ctx, r.GetPublicId(), action.Read, resOpt) {
+				items = append(items, handlers.HiddenListItem(r.GetPublicId()))
+				continue
+			}
+			// TODO: Attach principals and grants to ListRoles response.
+			items = append(items, handlers.ListedItem(r.GetPublicId(), toProto(r, nil, nil,
+				handlers.WithScope(scopeInfos[r.GetScopeId()]),
+				handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, r.GetPublicId(), IdActions, resOpt).Strings()),
+				handlers.WithOutputFields(authResults.FetchOutputFields(ctx, r.GetPublicId(), resOpt)))))
+		}
+		return items, nil
+	}
+	items, next, err := handlers.ListPage(fetch, req.GetFilter(), req.GetPageSize(), req.GetPageToken())
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range gl {
-		item.Scope = authResults.Scope
+	var gl []*pb.Role
+	for _, item := range items {
+		gl = append(gl, item.(*pb.Role))
 	}
-	return &pbs.ListRolesResponse{Items: gl}, nil
+	return &pbs.ListRolesResponse{Items: gl, NextPageToken: next}, nil
 }
 
 // GetRoles implements the interface pbs.RoleServiceServer.
@@ -77,11 +122,13 @@ func (s Service) GetRole(ctx context.Context, req *pbs.GetRoleRequest) (*pbs.Get
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	u, err := s.getFromRepo(ctx, req.GetId())
+	u, err := s.getFromRepo(ctx, req.GetId(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, req.GetId(), IdActions).Strings()),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	u.Scope = authResults.Scope
 	return &pbs.GetRoleResponse{Item: u}, nil
 }
 
@@ -94,11 +141,12 @@ func (s Service) CreateRole(ctx context.Context, req *pbs.CreateRoleRequest) (*p
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	r, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem())
+	r, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, "")))
 	if err != nil {
 		return nil, err
 	}
-	r.Scope = authResults.Scope
 	return &pbs.CreateRoleResponse{Item: r, Uri: fmt.Sprintf("roles/%s", r.GetId())}, nil
 }
 
@@ -111,11 +159,12 @@ func (s Service) UpdateRole(ctx context.Context, req *pbs.UpdateRoleRequest) (*p
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	u, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	u, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	u.Scope = authResults.Scope
 	return &pbs.UpdateRoleResponse{Item: u}, nil
 }
 
@@ -144,11 +193,19 @@ func (s Service) AddRolePrincipals(ctx context.Context, req *pbs.AddRolePrincipa
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	r, err := s.addPrinciplesInRepo(ctx, req.GetId(), req.GetPrincipalIds(), req.GetVersion())
+	var opts []iam.Option
+	if req.GetNotBeforeTime() != nil {
+		opts = append(opts, iam.WithNotBeforeTime(req.GetNotBeforeTime().AsTime()))
+	}
+	if req.GetExpirationTime() != nil {
+		opts = append(opts, iam.WithExpirationTime(req.GetExpirationTime().AsTime()))
+	}
+	r, err := s.addPrinciplesInRepo(ctx, req.GetId(), req.GetPrincipalIds(), req.GetVersion(), opts,
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	r.Scope = authResults.Scope
 	return &pbs.AddRolePrincipalsResponse{Item: r}, nil
 }
 
@@ -161,11 +218,12 @@ func (s Service) SetRolePrincipals(ctx context.Context, req *pbs.SetRolePrincipa
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	r, err := s.setPrinciplesInRepo(ctx, req.GetId(), req.GetPrincipalIds(), req.GetVersion())
+	r, err := s.setPrinciplesInRepo(ctx, req.GetId(), req.GetPrincipalIds(), req.GetVersion(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	r.Scope = authResults.Scope
 	return &pbs.SetRolePrincipalsResponse{Item: r}, nil
 }
 
@@ -178,11 +236,12 @@ func (s Service) RemoveRolePrincipals(ctx context.Context, req *pbs.RemoveRolePr
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	r, err := s.removePrinciplesInRepo(ctx, req.GetId(), req.GetPrincipalIds(), req.GetVersion())
+	r, err := s.removePrinciplesInRepo(ctx, req.GetId(), req.GetPrincipalIds(), req.GetVersion(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	r.Scope = authResults.Scope
 	return &pbs.RemoveRolePrincipalsResponse{Item: r}, nil
 }
 
@@ -195,11 +254,12 @@ func (s Service) AddRoleGrants(ctx context.Context, req *pbs.AddRoleGrantsReques
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	r, err := s.addGrantsInRepo(ctx, req.GetId(), req.GetGrantStrings(), req.GetVersion())
+	r, err := s.addGrantsInRepo(ctx, req.GetId(), req.GetGrantStrings(), req.GetVersion(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	r.Scope = authResults.Scope
 	return &pbs.AddRoleGrantsResponse{Item: r}, nil
 }
 
@@ -212,11 +272,12 @@ func (s Service) SetRoleGrants(ctx context.Context, req *pbs.SetRoleGrantsReques
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	r, err := s.setGrantsInRepo(ctx, req.GetId(), req.GetGrantStrings(), req.GetVersion())
+	r, err := s.setGrantsInRepo(ctx, req.GetId(), req.GetGrantStrings(), req.GetVersion(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	r.Scope = authResults.Scope
 	return &pbs.SetRoleGrantsResponse{Item: r}, nil
 }
 
@@ -229,15 +290,34 @@ func (s Service) RemoveRoleGrants(ctx context.Context, req *pbs.RemoveRoleGrants
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	r, err := s.removeGrantsInRepo(ctx, req.GetId(), req.GetGrantStrings(), req.GetVersion())
+	r, err := s.removeGrantsInRepo(ctx, req.GetId(), req.GetGrantStrings(), req.GetVersion(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	r.Scope = authResults.Scope
 	return &pbs.RemoveRoleGrantsResponse{Item: r}, nil
 }
 
-func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Role, error) {
+// ExplainAuthorization implements the interface pbs.RoleServiceServer. The
+// caller must be allowed the explain action on roles in the requested scope,
+// which must be the scope of the resource.
+func (s Service) ExplainAuthorization(ctx context.Context, req *pbs.ExplainAuthorizationRequest) (*pbs.ExplainAuthorizationResponse, error) {
+	if err := validateExplainAuthorizationRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.Explain)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	exp, err := s.explainInRepo(ctx, req.GetScopeId(), req.GetUserId(), req.GetResourceId(), action.Map[req.GetAction()])
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ExplainAuthorizationResponse{Item: exp}, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string, opt ...handlers.Option) (*pb.Role, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -252,10 +332,10 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Role, error) {
 	if out == nil {
 		return nil, handlers.NotFoundErrorf("Role %q doesn't exist.", id)
 	}
-	return toProto(out, pr, roleGrants), nil
+	return toProto(out, pr, roleGrants, opt...), nil
 }
 
-func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Role) (*pb.Role, error) {
+func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Role, opt ...handlers.Option) (*pb.Role, error) {
 	var opts []iam.Option
 	if item.GetName() != nil {
 		opts = append(opts, iam.WithName(item.GetName().GetValue()))
@@ -264,7 +344,7 @@ func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Role
 		opts = append(opts, iam.WithDescription(item.GetDescription().GetValue()))
 	}
 	if item.GetGrantScopeId() != nil {
-		opts = append(opts, iam.WithGrantScopeId(item.GetGrantScopeId().GetValue()))
+		opts = append(opts, grantScopeOpts(scopeId, item.GetGrantScopeId().GetValue())...)
 	}
 	u, err := iam.NewRole(scopeId, opts...)
 	if err != nil {
@@ -281,10 +361,10 @@ func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Role
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create role but no error returned from repository.")
 	}
-	return toProto(out, nil, nil), nil
+	return toProto(out, nil, nil, opt...), nil
 }
 
-func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.Role) (*pb.Role, error) {
+func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.Role, opt ...handlers.Option) (*pb.Role, error) {
 	var opts []iam.Option
 	if desc := item.GetDescription(); desc != nil {
 		opts = append(opts, iam.WithDescription(desc.GetValue()))
@@ -293,7 +373,7 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 		opts = append(opts, iam.WithName(name.GetValue()))
 	}
 	if grantScopeId := item.GetGrantScopeId(); grantScopeId != nil {
-		opts = append(opts, iam.WithGrantScopeId(grantScopeId.GetValue()))
+		opts = append(opts, grantScopeOpts(scopeId, grantScopeId.GetValue())...)
 	}
 	version := item.GetVersion()
 
@@ -306,6 +386,13 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	if len(dbMask) == 0 {
 		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
 	}
+	// The grant scope inheritance is set along with the grant scope
+	if strutil.StrListContains(dbMask, "GrantScopeId") {
+		dbMask = append(dbMask, "GrantScopeInheritance")
+		if u.GetGrantScopeInheritance() == "" {
+			u.GrantScopeInheritance = iam.GrantScopeNoInheritance
+		}
+	}
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -317,7 +404,7 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	if rowsUpdated == 0 {
 		return nil, handlers.NotFoundErrorf("Role %q doesn't exist or incorrect version provided.", id)
 	}
-	return toProto(out, pr, gr), nil
+	return toProto(out, pr, gr, opt...), nil
 }
 
 func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
@@ -335,29 +422,24 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeId string) ([]*pb.Role, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, afterId string, limit int) ([]*iam.Role, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	rl, err := repo.ListRoles(ctx, scopeId)
+	rl, err := repo.ListRoles(ctx, scopeIds, iam.WithStartPageAfterId(afterId), iam.WithLimit(limit))
 	if err != nil {
 		return nil, err
 	}
-	var outRl []*pb.Role
-	for _, g := range rl {
-		// TODO: Attach principals and grants to ListRoles response.
-		outRl = append(outRl, toProto(g, nil, nil))
-	}
-	return outRl, nil
+	return rl, nil
 }
 
-func (s Service) addPrinciplesInRepo(ctx context.Context, roleId string, principalIds []string, version uint32) (*pb.Role, error) {
+func (s Service) addPrinciplesInRepo(ctx context.Context, roleId string, principalIds []string, version uint32, principalOpts []iam.Option, opt ...handlers.Option) (*pb.Role, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	_, err = repo.AddPrincipalRoles(ctx, roleId, version, strutil.RemoveDuplicates(principalIds, false))
+	_, err = repo.AddPrincipalRoles(ctx, roleId, version, strutil.RemoveDuplicates(principalIds, false), principalOpts...)
 	if err != nil {
 		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to add principals to role: %v.", err)
@@ -369,10 +451,10 @@ func (s Service) addPrinciplesInRepo(ctx context.Context, roleId string, princip
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after adding principals to it.")
 	}
-	return toProto(out, pr, roleGrants), nil
+	return toProto(out, pr, roleGrants, opt...), nil
 }
 
-func (s Service) setPrinciplesInRepo(ctx context.Context, roleId string, principalIds []string, version uint32) (*pb.Role, error) {
+func (s Service) setPrinciplesInRepo(ctx context.Context, roleId string, principalIds []string, version uint32, opt ...handlers.Option) (*pb.Role, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -389,10 +471,10 @@ func (s Service) setPrinciplesInRepo(ctx context.Context, roleId string, princip
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after setting principals for it.")
 	}
-	return toProto(out, pr, roleGrants), nil
+	return toProto(out, pr, roleGrants, opt...), nil
 }
 
-func (s Service) removePrinciplesInRepo(ctx context.Context, roleId string, principalIds []string, version uint32) (*pb.Role, error) {
+func (s Service) removePrinciplesInRepo(ctx context.Context, roleId string, principalIds []string, version uint32, opt ...handlers.Option) (*pb.Role, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -409,10 +491,10 @@ func (s Service) removePrinciplesInRepo(ctx context.Context, roleId string, prin
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after removing principals from it.")
 	}
-	return toProto(out, pr, roleGrants), nil
+	return toProto(out, pr, roleGrants, opt...), nil
 }
 
-func (s Service) addGrantsInRepo(ctx context.Context, roleId string, grants []string, version uint32) (*pb.Role, error) {
+func (s Service) addGrantsInRepo(ctx context.Context, roleId string, grants []string, version uint32, opt ...handlers.Option) (*pb.Role, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -429,10 +511,10 @@ func (s Service) addGrantsInRepo(ctx context.Context, roleId string, grants []st
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after adding grants to it.")
 	}
-	return toProto(out, pr, roleGrants), nil
+	return toProto(out, pr, roleGrants, opt...), nil
 }
 
-func (s Service) setGrantsInRepo(ctx context.Context, roleId string, grants []string, version uint32) (*pb.Role, error) {
+func (s Service) setGrantsInRepo(ctx context.Context, roleId string, grants []string, version uint32, opt ...handlers.Option) (*pb.Role, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -453,10 +535,10 @@ func (s Service) setGrantsInRepo(ctx context.Context, roleId string, grants []st
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after setting grants on it.")
 	}
-	return toProto(out, pr, roleGrants), nil
+	return toProto(out, pr, roleGrants, opt...), nil
 }
 
-func (s Service) removeGrantsInRepo(ctx context.Context, roleId string, grants []string, version uint32) (*pb.Role, error) {
+func (s Service) removeGrantsInRepo(ctx context.Context, roleId string, grants []string, version uint32, opt ...handlers.Option) (*pb.Role, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -473,7 +555,96 @@ func (s Service) removeGrantsInRepo(ctx context.Context, roleId string, grants [
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after removing grants from it.")
 	}
-	return toProto(out, pr, roleGrants), nil
+	return toProto(out, pr, roleGrants, opt...), nil
+}
+
+func (s Service) explainInRepo(ctx context.Context, scopeId, userId, resourceId string, a action.Type) (*pb.AuthorizationExplanation, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	res, err := repo.LookupResource(ctx, resourceId)
+	if err != nil {
+		return nil, err
+	}
+	// A resource in another scope is reported as missing so the explain
+	// action in one scope doesn't reveal resources in others.
+	if res == nil || res.ScopeId != scopeId {
+		return nil, handlers.NotFoundErrorf("Resource %q doesn't exist.", resourceId)
+	}
+	if res.Type == resource.Target {
+		// Grant filters are evaluated against the target as auth.Verify sees it
+		targetRepo, err := s.targetRepoFn()
+		if err != nil {
+			return nil, err
+		}
+		t, _, err := targetRepo.LookupTarget(ctx, res.Id)
+		if err != nil {
+			return nil, err
+		}
+		if t == nil {
+			return nil, handlers.NotFoundErrorf("Resource %q doesn't exist.", resourceId)
+		}
+		if res.Attributes, err = targets.GrantAttributes(t); err != nil {
+			return nil, err
+		}
+	}
+	u, accountIds, err := repo.LookupUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, handlers.NotFoundErrorf("User %q doesn't exist.", userId)
+	}
+	// Grants templated with the account ID depend on the account the user
+	// authenticated with, which is only known when the user has one account.
+	var accountId string
+	if len(accountIds) == 1 {
+		accountId = accountIds[0]
+	}
+	grantPairs, err := repo.GrantsForUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	parseOpts := []perms.Option{
+		perms.WithUserId(userId),
+		perms.WithAccountId(accountId),
+		perms.WithSkipFinalValidation(true),
+	}
+	if perms.RequiresTemplateData(grantPairs) {
+		data, err := repo.GrantTemplateData(ctx, userId, accountId)
+		if err != nil {
+			return nil, err
+		}
+		parseOpts = append(parseOpts, perms.WithTemplateData(data))
+	}
+	grants, err := perms.ParseGrants(grantPairs, parseOpts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to parse grants: %v.", err)
+	}
+	exp := perms.NewACL(grants...).Explain(*res, a)
+
+	out := &pb.AuthorizationExplanation{
+		UserId:       userId,
+		ResourceId:   res.Id,
+		ResourceType: res.Type.String(),
+		ScopeId:      res.ScopeId,
+		ParentId:     res.Pin,
+		Action:       a.String(),
+		Allowed:      exp.Allowed,
+		Reason:       exp.Reason,
+	}
+	for _, ge := range exp.Grants {
+		out.Grants = append(out.Grants, &pb.GrantExplanation{
+			RoleId:         ge.Grant.RoleId(),
+			CanonicalGrant: ge.Grant.CanonicalString(),
+			Effect:         string(ge.Grant.Effect()),
+			Matched:        ge.Matched,
+			Decisive:       ge.Decisive,
+			Reason:         ge.Reason,
+		})
+	}
+	return out, nil
 }
 
 func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
@@ -487,7 +658,7 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	var parentId string
 	opts := []auth.Option{auth.WithType(resource.Role), auth.WithAction(a)}
 	switch a {
-	case action.List, action.Create:
+	case action.List, action.Create, action.Explain:
 		parentId = id
 		scp, err := repo.LookupScope(ctx, parentId)
 		if err != nil {
@@ -515,31 +686,58 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	return auth.Verify(ctx, opts...)
 }
 
-func toProto(in *iam.Role, principals []iam.PrincipalRole, grants []*iam.RoleGrant) *pb.Role {
-	out := pb.Role{
-		Id:          in.GetPublicId(),
-		ScopeId:     in.GetScopeId(),
-		CreatedTime: in.GetCreateTime().GetTimestamp(),
-		UpdatedTime: in.GetUpdateTime().GetTimestamp(),
-		Version:     in.GetVersion(),
+func toProto(in *iam.Role, principals []iam.PrincipalRole, grants []*iam.RoleGrant, opt ...handlers.Option) *pb.Role {
+	opts := handlers.GetOpts(opt...)
+	outputFields := opts.WithOutputFields
+	out := pb.Role{}
+	if outputFields.Has("id") {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has("scope_id") {
+		out.ScopeId = in.GetScopeId()
+	}
+	if outputFields.Has("scope") {
+		out.Scope = opts.WithScope
 	}
-	if in.GetDescription() != "" {
+	if outputFields.Has("created_time") {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
+	}
+	if outputFields.Has("updated_time") {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has("version") {
+		out.Version = in.GetVersion()
+	}
+	if outputFields.Has("description") && in.GetDescription() != "" {
 		out.Description = &wrapperspb.StringValue{Value: in.GetDescription()}
 	}
-	if in.GetName() != "" {
+	if outputFields.Has("name") && in.GetName() != "" {
 		out.Name = &wrapperspb.StringValue{Value: in.GetName()}
 	}
+	if outputFields.Has("authorized_actions") {
+		out.AuthorizedActions = opts.WithAuthorizedActions
+	}
 	for _, p := range principals {
-		principal := &pb.Principal{
-			Id:      p.GetPrincipalId(),
-			Type:    p.GetType(),
-			ScopeId: p.GetPrincipalScopeId(),
+		if outputFields.Has("principals") {
+			out.Principals = append(out.Principals, &pb.Principal{
+				Id:             p.GetPrincipalId(),
+				Type:           p.GetType(),
+				ScopeId:        p.GetPrincipalScopeId(),
+				NotBeforeTime:  p.GetNotBeforeTime().GetTimestamp(),
+				ExpirationTime: p.GetExpirationTime().GetTimestamp(),
+			})
+		}
+		if outputFields.Has("principal_ids") {
+			out.PrincipalIds = append(out.PrincipalIds, p.GetPrincipalId())
 		}
-		out.Principals = append(out.Principals, principal)
-		out.PrincipalIds = append(out.PrincipalIds, p.GetPrincipalId())
 	}
 	for _, g := range grants {
-		out.GrantStrings = append(out.GrantStrings, g.GetRawGrant())
+		if outputFields.Has("grant_strings") {
+			out.GrantStrings = append(out.GrantStrings, g.GetRawGrant())
+		}
+		if !outputFields.Has("grants") {
+			continue
+		}
 		parsed, err := perms.Parse(in.GetGrantScopeId(), g.GetRawGrant())
 		if err != nil {
 			// This should never happen as we validate on the way in, but let's
@@ -555,24 +753,43 @@ func toProto(in *iam.Role, principals []iam.PrincipalRole, grants []*iam.RoleGra
 				Raw:       g.GetRawGrant(),
 				Canonical: g.GetCanonicalGrant(),
 				Json: &pb.GrantJson{
-					Id:      parsed.Id(),
-					Type:    parsed.Type().String(),
-					Actions: actions,
+					Id:           parsed.Id(),
+					Type:         parsed.Type().String(),
+					Actions:      actions,
+					Effect:       string(parsed.Effect()),
+					OutputFields: parsed.OutputFields(),
 				},
 			})
 		}
 	}
-	if in.GetGrantScopeId() != "" {
+	switch {
+	case !outputFields.Has("grant_scope_id"):
+	case in.GetGrantScopeInheritance() == iam.GrantScopeChildren,
+		in.GetGrantScopeInheritance() == iam.GrantScopeDescendants:
+		out.GrantScopeId = &wrapperspb.StringValue{Value: in.GetGrantScopeInheritance()}
+	case in.GetGrantScopeId() != "":
 		out.GrantScopeId = &wrapperspb.StringValue{Value: in.GetGrantScopeId()}
 	}
 	return &out
 }
 
+// grantScopeOpts returns the options setting the grant scope of a role in the
+// scope from the grant_scope_id of a request. The "children" and "descendants"
+// values apply the role's grants to its own scope along with its child or
+// descendant scopes.
+func grantScopeOpts(scopeId, grantScopeId string) []iam.Option {
+	switch grantScopeId {
+	case iam.GrantScopeChildren, iam.GrantScopeDescendants:
+		return []iam.Option{iam.WithGrantScopeId(scopeId), iam.WithGrantScopeInheritance(grantScopeId)}
+	}
+	return []iam.Option{iam.WithGrantScopeId(grantScopeId)}
+}
+
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
-//  * The path passed in is correctly formatted
-//  * All required parameters are set
-//  * There are no conflicting parameters provided
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
 func validateGetRequest(req *pbs.GetRoleRequest) error {
 	return handlers.ValidateGetRequest(iam.RolePrefix, req, handlers.NoopValidatorFn)
 }
@@ -665,6 +882,14 @@ func validateAddRolePrincipalsRequest(req *pbs.AddRolePrincipalsRequest) error {
 			break
 		}
 	}
+	if req.GetExpirationTime() != nil {
+		if !req.GetExpirationTime().AsTime().After(time.Now()) {
+			badFields["expiration_time"] = "Must be in the future."
+		}
+		if req.GetNotBeforeTime() != nil && !req.GetNotBeforeTime().AsTime().Before(req.GetExpirationTime().AsTime()) {
+			badFields["not_before_time"] = "Must be before the expiration time."
+		}
+	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
 	}
@@ -795,3 +1020,26 @@ func validateRemoveRoleGrantsRequest(req *pbs.RemoveRoleGrantsRequest) error {
 	}
 	return nil
 }
+
+func validateExplainAuthorizationRequest(req *pbs.ExplainAuthorizationRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(scope.Org.Prefix(), req.GetScopeId()) &&
+		!handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()) &&
+		req.GetScopeId() != scope.Global.String() {
+		badFields["scope_id"] = "This field is missing or improperly formatted."
+	}
+	if !handlers.ValidId(iam.UserPrefix, req.GetUserId()) {
+		badFields["user_id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetResourceId() == "" {
+		badFields["resource_id"] = "This is a required field."
+	}
+	switch action.Map[req.GetAction()] {
+	case action.Unknown, action.All:
+		badFields["action"] = "Must be a known action."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/google/go-cmp/cmp"
@@ -15,21 +17,31 @@ import (
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/roles"
+	"github.com/hashicorp/boundary/internal/target"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"google.golang.org/genproto/protobuf/field_mask"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// testTargetRepoFn is used by tests which never look up targets.
+func testTargetRepoFn() (*target.Repository, error) {
+	return nil, fmt.Errorf("no target repository in this test")
+}
+
+var testAuthorizedActions = []string{"read", "update", "delete", "add-principals", "set-principals", "remove-principals", "add-grants", "set-grants", "remove-grants"}
+
 func createDefaultRolesAndRepo(t *testing.T) (*iam.Role, *iam.Role, func() (*iam.Repository, error)) {
 	t.Helper()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -77,27 +89,29 @@ func TestGet(t *testing.T) {
 	}
 
 	wantOrgRole := &pb.Role{
-		Id:           or.GetPublicId(),
-		ScopeId:      or.GetScopeId(),
-		Scope:        &scopes.ScopeInfo{Id: or.GetScopeId(), Type: scope.Org.String()},
-		Name:         &wrapperspb.StringValue{Value: or.GetName()},
-		Description:  &wrapperspb.StringValue{Value: or.GetDescription()},
-		GrantScopeId: &wrapperspb.StringValue{Value: pr.GetGrantScopeId()},
-		CreatedTime:  or.CreateTime.GetTimestamp(),
-		UpdatedTime:  or.UpdateTime.GetTimestamp(),
-		Version:      or.GetVersion(),
+		Id:                or.GetPublicId(),
+		ScopeId:           or.GetScopeId(),
+		Scope:             &scopes.ScopeInfo{Id: or.GetScopeId(), Type: scope.Org.String()},
+		Name:              &wrapperspb.StringValue{Value: or.GetName()},
+		Description:       &wrapperspb.StringValue{Value: or.GetDescription()},
+		GrantScopeId:      &wrapperspb.StringValue{Value: pr.GetGrantScopeId()},
+		CreatedTime:       or.CreateTime.GetTimestamp(),
+		UpdatedTime:       or.UpdateTime.GetTimestamp(),
+		Version:           or.GetVersion(),
+		AuthorizedActions: testAuthorizedActions,
 	}
 
 	wantProjRole := &pb.Role{
-		Id:           pr.GetPublicId(),
-		ScopeId:      pr.GetScopeId(),
-		Scope:        &scopes.ScopeInfo{Id: pr.GetScopeId(), Type: scope.Project.String()},
-		Name:         &wrapperspb.StringValue{Value: pr.GetName()},
-		Description:  &wrapperspb.StringValue{Value: pr.GetDescription()},
-		GrantScopeId: &wrapperspb.StringValue{Value: pr.GetGrantScopeId()},
-		CreatedTime:  pr.CreateTime.GetTimestamp(),
-		UpdatedTime:  pr.UpdateTime.GetTimestamp(),
-		Version:      pr.GetVersion(),
+		Id:                pr.GetPublicId(),
+		ScopeId:           pr.GetScopeId(),
+		Scope:             &scopes.ScopeInfo{Id: pr.GetScopeId(), Type: scope.Project.String()},
+		Name:              &wrapperspb.StringValue{Value: pr.GetName()},
+		Description:       &wrapperspb.StringValue{Value: pr.GetDescription()},
+		GrantScopeId:      &wrapperspb.StringValue{Value: pr.GetGrantScopeId()},
+		CreatedTime:       pr.CreateTime.GetTimestamp(),
+		UpdatedTime:       pr.UpdateTime.GetTimestamp(),
+		Version:           pr.GetVersion(),
+		AuthorizedActions: testAuthorizedActions,
 	}
 
 	cases := []struct {
@@ -164,7 +178,7 @@ func TestGet(t *testing.T) {
 			req := proto.Clone(toMerge).(*pbs.GetRoleRequest)
 			proto.Merge(req, tc.req)
 
-			s, err := roles.NewService(repo)
+			s, err := roles.NewService(repo, testTargetRepoFn)
 			require.NoError(err, "Couldn't create new role service.")
 
 			got, gErr := s.GetRole(auth.DisabledAuthTestContext(auth.WithScopeId(tc.scopeId)), req)
@@ -192,26 +206,32 @@ func TestList(t *testing.T) {
 	for i := 0; i < 10; i++ {
 		or := iam.TestRole(t, conn, oWithRoles.GetPublicId())
 		wantOrgRoles = append(wantOrgRoles, &pb.Role{
-			Id:           or.GetPublicId(),
-			ScopeId:      or.GetScopeId(),
-			Scope:        &scopes.ScopeInfo{Id: or.GetScopeId(), Type: scope.Org.String()},
-			CreatedTime:  or.GetCreateTime().GetTimestamp(),
-			UpdatedTime:  or.GetUpdateTime().GetTimestamp(),
-			GrantScopeId: &wrapperspb.StringValue{Value: or.GetGrantScopeId()},
-			Version:      or.GetVersion(),
+			Id:                or.GetPublicId(),
+			ScopeId:           or.GetScopeId(),
+			Scope:             &scopes.ScopeInfo{Id: or.GetScopeId(), Type: scope.Org.String()},
+			CreatedTime:       or.GetCreateTime().GetTimestamp(),
+			UpdatedTime:       or.GetUpdateTime().GetTimestamp(),
+			GrantScopeId:      &wrapperspb.StringValue{Value: or.GetGrantScopeId()},
+			Version:           or.GetVersion(),
+			AuthorizedActions: testAuthorizedActions,
 		})
 		pr := iam.TestRole(t, conn, pWithRoles.GetPublicId())
 		wantProjRoles = append(wantProjRoles, &pb.Role{
-			Id:           pr.GetPublicId(),
-			ScopeId:      pr.GetScopeId(),
-			Scope:        &scopes.ScopeInfo{Id: pr.GetScopeId(), Type: scope.Project.String()},
-			CreatedTime:  pr.GetCreateTime().GetTimestamp(),
-			UpdatedTime:  pr.GetUpdateTime().GetTimestamp(),
-			GrantScopeId: &wrapperspb.StringValue{Value: pr.GetGrantScopeId()},
-			Version:      pr.GetVersion(),
+			Id:                pr.GetPublicId(),
+			ScopeId:           pr.GetScopeId(),
+			Scope:             &scopes.ScopeInfo{Id: pr.GetScopeId(), Type: scope.Project.String()},
+			CreatedTime:       pr.GetCreateTime().GetTimestamp(),
+			UpdatedTime:       pr.GetUpdateTime().GetTimestamp(),
+			GrantScopeId:      &wrapperspb.StringValue{Value: pr.GetGrantScopeId()},
+			Version:           pr.GetVersion(),
+			AuthorizedActions: testAuthorizedActions,
 		})
 	}
 
+	// Listed resources are ordered by id
+	sort.Slice(wantOrgRoles, func(i, j int) bool { return wantOrgRoles[i].GetId() < wantOrgRoles[j].GetId() })
+	sort.Slice(wantProjRoles, func(i, j int) bool { return wantProjRoles[i].GetId() < wantProjRoles[j].GetId() })
+
 	cases := []struct {
 		name string
 		req  *pbs.ListRolesRequest
@@ -240,7 +260,7 @@ func TestList(t *testing.T) {
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			s, err := roles.NewService(repoFn)
+			s, err := roles.NewService(repoFn, testTargetRepoFn)
 			require.NoError(err, "Couldn't create new role service.")
 
 			got, gErr := s.ListRoles(auth.DisabledAuthTestContext(auth.WithScopeId(tc.req.GetScopeId())), tc.req)
@@ -253,7 +273,7 @@ func TestList(t *testing.T) {
 func TestDelete(t *testing.T) {
 	or, pr, repo := createDefaultRolesAndRepo(t)
 
-	s, err := roles.NewService(repo)
+	s, err := roles.NewService(repo, testTargetRepoFn)
 	require.NoError(t, err, "Error when getting new role service.")
 
 	cases := []struct {
@@ -321,7 +341,7 @@ func TestDelete_twice(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
 	or, pr, repo := createDefaultRolesAndRepo(t)
 
-	s, err := roles.NewService(repo)
+	s, err := roles.NewService(repo, testTargetRepoFn)
 	require.NoError(err, "Error when getting new role service")
 	req := &pbs.DeleteRoleRequest{
 		Id: or.GetPublicId(),
@@ -397,6 +417,24 @@ func TestCreate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Create a valid Global Role for descendant scopes",
+			req: &pbs.CreateRoleRequest{Item: &pb.Role{
+				ScopeId:      scope.Global.String(),
+				Name:         &wrapperspb.StringValue{Value: "descendants"},
+				GrantScopeId: &wrapperspb.StringValue{Value: iam.GrantScopeDescendants},
+			}},
+			res: &pbs.CreateRoleResponse{
+				Uri: fmt.Sprintf("roles/%s_", iam.RolePrefix),
+				Item: &pb.Role{
+					ScopeId:      scope.Global.String(),
+					Scope:        &scopes.ScopeInfo{Id: scope.Global.String(), Type: scope.Global.String()},
+					Name:         &wrapperspb.StringValue{Value: "descendants"},
+					GrantScopeId: &wrapperspb.StringValue{Value: iam.GrantScopeDescendants},
+					Version:      1,
+				},
+			},
+		},
 		{
 			name: "Create a valid Project Scoped Role",
 			req: &pbs.CreateRoleRequest{
@@ -465,7 +503,7 @@ func TestCreate(t *testing.T) {
 			req := proto.Clone(toMerge).(*pbs.CreateRoleRequest)
 			proto.Merge(req, tc.req)
 
-			s, err := roles.NewService(repo)
+			s, err := roles.NewService(repo, testTargetRepoFn)
 			require.NoError(err, "Error when getting new role service.")
 
 			got, gErr := s.CreateRole(auth.DisabledAuthTestContext(auth.WithScopeId(tc.req.GetItem().GetScopeId())), req)
@@ -506,6 +544,7 @@ func TestUpdate(t *testing.T) {
 			Id:      g.Id(),
 			Type:    g.Type().String(),
 			Actions: actions,
+			Effect:  string(g.Effect()),
 		},
 	}
 	conn, _ := db.TestSetup(t, "postgres")
@@ -535,7 +574,7 @@ func TestUpdate(t *testing.T) {
 	var orVersion uint32 = 1
 	var prVersion uint32 = 1
 
-	tested, err := roles.NewService(repoFn)
+	tested, err := roles.NewService(repoFn, testTargetRepoFn)
 	require.NoError(t, err, "Error when getting new role service.")
 
 	resetRoles := func(proj bool) {
@@ -935,7 +974,7 @@ func TestAddPrincipal(t *testing.T) {
 	repoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
-	s, err := roles.NewService(repoFn)
+	s, err := roles.NewService(repoFn, testTargetRepoFn)
 	require.NoError(t, err, "Error when getting new role service.")
 
 	o, p := iam.TestScopes(t, iamRepo)
@@ -1036,6 +1075,25 @@ func TestAddPrincipal(t *testing.T) {
 		}
 	}
 
+	t.Run("Add user with time bounds", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		role := iam.TestRole(t, conn, o.GetPublicId())
+		notBefore := timestamppb.New(time.Now().Truncate(time.Second))
+		expiration := timestamppb.New(notBefore.AsTime().Add(4 * time.Hour))
+		req := &pbs.AddRolePrincipalsRequest{
+			Id:             role.GetPublicId(),
+			Version:        role.GetVersion(),
+			PrincipalIds:   []string{users[2].GetPublicId()},
+			NotBeforeTime:  notBefore,
+			ExpirationTime: expiration,
+		}
+		got, err := s.AddRolePrincipals(auth.DisabledAuthTestContext(auth.WithScopeId(o.GetPublicId())), req)
+		require.NoError(err)
+		require.Len(got.GetItem().GetPrincipals(), 1)
+		assert.True(notBefore.AsTime().Equal(got.GetItem().GetPrincipals()[0].GetNotBeforeTime().AsTime()))
+		assert.True(expiration.AsTime().Equal(got.GetItem().GetPrincipals()[0].GetExpirationTime().AsTime()))
+	})
+
 	role := iam.TestRole(t, conn, p.GetPublicId())
 
 	failCases := []struct {
@@ -1069,6 +1127,27 @@ func TestAddPrincipal(t *testing.T) {
 			},
 			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
 		},
+		{
+			name: "Expiration In The Past",
+			req: &pbs.AddRolePrincipalsRequest{
+				Id:             role.GetPublicId(),
+				Version:        role.GetVersion(),
+				PrincipalIds:   []string{users[0].GetPublicId()},
+				ExpirationTime: timestamppb.New(time.Now().Add(-time.Hour)),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Not Before After Expiration",
+			req: &pbs.AddRolePrincipalsRequest{
+				Id:             role.GetPublicId(),
+				Version:        role.GetVersion(),
+				PrincipalIds:   []string{users[0].GetPublicId()},
+				NotBeforeTime:  timestamppb.New(time.Now().Add(2 * time.Hour)),
+				ExpirationTime: timestamppb.New(time.Now().Add(time.Hour)),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
 	}
 	for _, tc := range failCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1089,7 +1168,7 @@ func TestSetPrincipal(t *testing.T) {
 	repoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
-	s, err := roles.NewService(repoFn)
+	s, err := roles.NewService(repoFn, testTargetRepoFn)
 	require.NoError(t, err, "Error when getting new role service.")
 
 	o, p := iam.TestScopes(t, iamRepo)
@@ -1244,7 +1323,7 @@ func TestRemovePrincipal(t *testing.T) {
 	repoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
-	s, err := roles.NewService(repoFn)
+	s, err := roles.NewService(repoFn, testTargetRepoFn)
 	require.NoError(t, err, "Error when getting new role service.")
 
 	o, p := iam.TestScopes(t, iamRepo)
@@ -1440,7 +1519,7 @@ func TestAddGrants(t *testing.T) {
 	repoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
-	s, err := roles.NewService(repoFn)
+	s, err := roles.NewService(repoFn, testTargetRepoFn)
 	require.NoError(t, err, "Error when getting new role service.")
 
 	addCases := []struct {
@@ -1568,7 +1647,7 @@ func TestSetGrants(t *testing.T) {
 		return iamRepo, nil
 	}
 
-	s, err := roles.NewService(repoFn)
+	s, err := roles.NewService(repoFn, testTargetRepoFn)
 	require.NoError(t, err, "Error when getting new role service.")
 
 	setCases := []struct {
@@ -1693,7 +1772,7 @@ func TestRemoveGrants(t *testing.T) {
 	repoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
-	s, err := roles.NewService(repoFn)
+	s, err := roles.NewService(repoFn, testTargetRepoFn)
 	require.NoError(t, err, "Error when getting new role service.")
 
 	removeCases := []struct {
@@ -1821,3 +1900,145 @@ func TestRemoveGrants(t *testing.T) {
 		})
 	}
 }
+
+func TestExplainAuthorization(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	rw := db.New(conn)
+	kms := kms.TestKms(t, conn, wrap)
+	targetRepoFn := func() (*target.Repository, error) {
+		return target.NewRepository(rw, rw, kms)
+	}
+	s, err := roles.NewService(repoFn, targetRepoFn)
+	require.NoError(t, err, "Error when getting new role service.")
+
+	o, p := iam.TestScopes(t, iamRepo)
+	u := iam.TestUser(t, iamRepo, o.GetPublicId())
+	teamTarget := target.TestTcpTarget(t, conn, p.GetPublicId(), "team-a-target", target.WithDescription("team:a"))
+	otherTarget := target.TestTcpTarget(t, conn, p.GetPublicId(), "other-target")
+	filterRole := iam.TestRole(t, conn, p.GetPublicId())
+	iam.TestRoleGrant(t, conn, filterRole.GetPublicId(), `id=*;type=target;actions=authorize-session;filter="/item/description" == "team:a"`)
+	iam.TestUserRole(t, conn, filterRole.GetPublicId(), u.GetPublicId())
+	allowRole := iam.TestRole(t, conn, p.GetPublicId())
+	denyRole := iam.TestRole(t, conn, p.GetPublicId())
+	resourceRole := iam.TestRole(t, conn, p.GetPublicId())
+	iam.TestRoleGrant(t, conn, allowRole.GetPublicId(), "id=*;type=role;actions=read,update")
+	iam.TestRoleGrant(t, conn, denyRole.GetPublicId(), fmt.Sprintf("id=%s;actions=update;effect=deny", resourceRole.GetPublicId()))
+	iam.TestUserRole(t, conn, allowRole.GetPublicId(), u.GetPublicId())
+	iam.TestUserRole(t, conn, denyRole.GetPublicId(), u.GetPublicId())
+
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(p.GetPublicId()))
+	explain := func(t *testing.T, resId, act string) *pb.AuthorizationExplanation {
+		t.Helper()
+		got, err := s.ExplainAuthorization(ctx, &pbs.ExplainAuthorizationRequest{
+			ScopeId:    p.GetPublicId(),
+			UserId:     u.GetPublicId(),
+			ResourceId: resId,
+			Action:     act,
+		})
+		require.NoError(t, err)
+		return got.GetItem()
+	}
+	decisive := func(exp *pb.AuthorizationExplanation) *pb.GrantExplanation {
+		for _, g := range exp.GetGrants() {
+			if g.GetDecisive() {
+				return g
+			}
+		}
+		return nil
+	}
+
+	t.Run("allowed", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got := explain(t, resourceRole.GetPublicId(), "read")
+		assert.True(got.GetAllowed())
+		assert.Equal(p.GetPublicId(), got.GetScopeId())
+		assert.Equal("role", got.GetResourceType())
+		require.NotNil(decisive(got))
+		assert.Equal(allowRole.GetPublicId(), decisive(got).GetRoleId())
+		assert.Equal("allow", decisive(got).GetEffect())
+	})
+	t.Run("denied", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got := explain(t, resourceRole.GetPublicId(), "update")
+		assert.False(got.GetAllowed())
+		require.NotNil(decisive(got))
+		assert.Equal(denyRole.GetPublicId(), decisive(got).GetRoleId())
+		assert.Equal("deny", decisive(got).GetEffect())
+	})
+	t.Run("no match", func(t *testing.T) {
+		assert := assert.New(t)
+		got := explain(t, resourceRole.GetPublicId(), "delete")
+		assert.False(got.GetAllowed())
+		assert.Nil(decisive(got))
+		assert.NotEmpty(got.GetReason())
+		for _, g := range got.GetGrants() {
+			assert.False(g.GetMatched())
+			assert.NotEmpty(g.GetReason())
+		}
+	})
+	t.Run("target filter", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got := explain(t, teamTarget.GetPublicId(), "authorize-session")
+		assert.True(got.GetAllowed())
+		require.NotNil(decisive(got))
+		assert.Equal(filterRole.GetPublicId(), decisive(got).GetRoleId())
+
+		got = explain(t, otherTarget.GetPublicId(), "authorize-session")
+		assert.False(got.GetAllowed())
+		assert.Nil(decisive(got))
+	})
+
+	failCases := []struct {
+		name string
+		req  *pbs.ExplainAuthorizationRequest
+		err  error
+	}{
+		{
+			name: "Bad user id",
+			req:  &pbs.ExplainAuthorizationRequest{ScopeId: p.GetPublicId(), UserId: "j_1234567890", ResourceId: resourceRole.GetPublicId(), Action: "read"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Unknown action",
+			req:  &pbs.ExplainAuthorizationRequest{ScopeId: p.GetPublicId(), UserId: u.GetPublicId(), ResourceId: resourceRole.GetPublicId(), Action: "fly"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Missing resource id",
+			req:  &pbs.ExplainAuthorizationRequest{ScopeId: p.GetPublicId(), UserId: u.GetPublicId(), Action: "read"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Non existing resource",
+			req:  &pbs.ExplainAuthorizationRequest{ScopeId: p.GetPublicId(), UserId: u.GetPublicId(), ResourceId: iam.RolePrefix + "_DoesntExis", Action: "read"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Bad scope id",
+			req:  &pbs.ExplainAuthorizationRequest{ScopeId: "j_1234567890", UserId: u.GetPublicId(), ResourceId: resourceRole.GetPublicId(), Action: "read"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Resource in another scope",
+			req:  &pbs.ExplainAuthorizationRequest{ScopeId: o.GetPublicId(), UserId: u.GetPublicId(), ResourceId: resourceRole.GetPublicId(), Action: "read"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Non existing user",
+			req:  &pbs.ExplainAuthorizationRequest{ScopeId: p.GetPublicId(), UserId: iam.UserPrefix + "_DoesntExis", ResourceId: resourceRole.GetPublicId(), Action: "read"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+	}
+	for _, tc := range failCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, gErr := s.ExplainAuthorization(ctx, tc.req)
+			require.Error(t, gErr)
+			assert.True(t, errors.Is(gErr, tc.err), "ExplainAuthorization(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+		})
+	}
+}
@@ -0,0 +1,727 @@
+// Package scim implements the subset of the SCIM 2.0 protocol (RFC 7643 and
+// RFC 7644) that an identity provider uses to push the members of its groups
+// to the groups bound to it.
+//
+// SCIM users are the accounts of an LDAP auth method. A user's externalId is
+// the DN of its entry in the auth method's directory, its userName is the
+// login name of the account, and its id is the id of the account, which is
+// created when the user is created if it doesn't exist yet. The Boundary user
+// of an account is the one its logins authenticate as, and is created when it
+// is first added to a group if it doesn't exist yet. Deleting or deactivating
+// a user removes it from every group bound to a SCIM group but doesn't delete
+// its account or Boundary user.
+//
+// A group's id is the id of the Boundary group bound to it, and its display
+// name is the directory group of the Boundary group. Groups can't be created
+// or deleted through SCIM: creating a group binds it to the Boundary group
+// with a matching directory group, and deleting a group removes its members.
+package scim
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/auth/ldap"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+)
+
+// PathPrefix is the path the SCIM endpoint is mounted at.
+const PathPrefix = "/scim/v2/"
+
+const (
+	schemaUser         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	schemaGroup        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	schemaListResponse = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	schemaError        = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	contentType    = "application/scim+json"
+	maxRequestSize = 1 << 20
+)
+
+type user struct {
+	Schemas    []string `json:"schemas"`
+	Id         string   `json:"id"`
+	ExternalId string   `json:"externalId,omitempty"`
+	UserName   string   `json:"userName"`
+	Active     *bool    `json:"active,omitempty"`
+	Meta       *meta    `json:"meta,omitempty"`
+}
+
+type group struct {
+	Schemas     []string `json:"schemas"`
+	Id          string   `json:"id"`
+	DisplayName string   `json:"displayName"`
+	Members     []member `json:"members"`
+	Meta        *meta    `json:"meta,omitempty"`
+}
+
+type member struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+type meta struct {
+	ResourceType string `json:"resourceType"`
+	Location     string `json:"location,omitempty"`
+}
+
+type listResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int           `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+type patchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []patchOperation `json:"Operations"`
+}
+
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+type scimError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+type handler struct {
+	repoFn       common.IamRepoFactory
+	ldapRepoFn   common.LdapAuthRepoFactory
+	authMethodId string
+	bearerToken  string
+}
+
+// NewHandler returns a handler for the SCIM endpoint, to be mounted at
+// PathPrefix, whose users are the accounts of the LDAP auth method with
+// authMethodId. Requests must be authenticated with the bearer token.
+func NewHandler(repoFn common.IamRepoFactory, ldapRepoFn common.LdapAuthRepoFactory, authMethodId, bearerToken string) (http.Handler, error) {
+	if repoFn == nil {
+		return nil, errors.New("missing iam repository")
+	}
+	if ldapRepoFn == nil {
+		return nil, errors.New("missing ldap repository")
+	}
+	if authMethodId == "" {
+		return nil, errors.New("missing auth method id")
+	}
+	if bearerToken == "" {
+		return nil, errors.New("missing bearer token")
+	}
+	return &handler{repoFn: repoFn, ldapRepoFn: ldapRepoFn, authMethodId: authMethodId, bearerToken: bearerToken}, nil
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.authenticated(r) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="scim"`)
+		writeError(w, http.StatusUnauthorized, "", "Missing or invalid bearer token.")
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+
+	resource, id := splitPath(strings.TrimPrefix(r.URL.Path, strings.TrimSuffix(PathPrefix, "/")))
+	switch {
+	case resource == "Users" && id == "":
+		h.handleUsers(w, r)
+	case resource == "Users":
+		h.handleUser(w, r, id)
+	case resource == "Groups" && id == "":
+		h.handleGroups(w, r)
+	case resource == "Groups":
+		h.handleGroup(w, r, id)
+	default:
+		writeError(w, http.StatusNotFound, "", fmt.Sprintf("Unknown resource %q.", r.URL.Path))
+	}
+}
+
+func (h *handler) authenticated(r *http.Request) bool {
+	const prefix = "bearer "
+	hdr := r.Header.Get("Authorization")
+	if len(hdr) <= len(prefix) || !strings.EqualFold(hdr[:len(prefix)], prefix) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hdr[len(prefix):]), []byte(h.bearerToken)) == 1
+}
+
+func splitPath(p string) (resource, id string) {
+	p = strings.Trim(p, "/")
+	resource = p
+	if i := strings.Index(p, "/"); i >= 0 {
+		resource, id = p[:i], p[i+1:]
+	}
+	return resource, id
+}
+
+func (h *handler) handleUsers(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		var resources []interface{}
+		if f := r.URL.Query().Get("filter"); f != "" {
+			attr, value, err := parseFilter(f)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "invalidFilter", err.Error())
+				return
+			}
+			repo, err := h.ldapRepoFn()
+			if err != nil {
+				writeRepoError(w, err)
+				return
+			}
+			var acct *ldap.Account
+			switch {
+			case strings.EqualFold(attr, "userName"):
+				acct, err = repo.LookupAccountByLoginName(r.Context(), h.authMethodId, value)
+			case strings.EqualFold(attr, "externalId"):
+				acct, err = repo.LookupAccountByDn(r.Context(), h.authMethodId, value)
+			default:
+				writeError(w, http.StatusBadRequest, "invalidFilter", fmt.Sprintf("Users can't be filtered by %q.", attr))
+				return
+			}
+			if err != nil {
+				writeRepoError(w, err)
+				return
+			}
+			if acct != nil {
+				resources = append(resources, newUser(acct, true))
+			}
+		}
+		writeList(w, resources)
+
+	case http.MethodPost:
+		var u user
+		if err := decode(r, &u); err != nil {
+			writeError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+			return
+		}
+		if u.UserName == "" {
+			writeError(w, http.StatusBadRequest, "invalidValue", "Missing userName.")
+			return
+		}
+		if u.ExternalId == "" {
+			writeError(w, http.StatusBadRequest, "invalidValue", "Missing externalId, the DN of the user's entry.")
+			return
+		}
+		repo, err := h.ldapRepoFn()
+		if err != nil {
+			writeRepoError(w, err)
+			return
+		}
+		acct, err := repo.EnsureAccount(r.Context(), h.authMethodId, u.ExternalId, u.UserName)
+		if err != nil {
+			writeRepoError(w, err)
+			return
+		}
+		if u.Active != nil && !*u.Active {
+			if err := h.removeUser(r.Context(), acct.PublicId); err != nil {
+				writeRepoError(w, err)
+				return
+			}
+		}
+		writeJson(w, http.StatusCreated, newUser(acct, u.Active == nil || *u.Active))
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method %s isn't supported.", r.Method))
+	}
+}
+
+func (h *handler) handleUser(w http.ResponseWriter, r *http.Request, id string) {
+	acct, err := h.lookupUser(r.Context(), id)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if acct == nil {
+		writeError(w, http.StatusNotFound, "", fmt.Sprintf("User %q doesn't exist.", id))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJson(w, http.StatusOK, newUser(acct, true))
+
+	case http.MethodPut:
+		var u user
+		if err := decode(r, &u); err != nil {
+			writeError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+			return
+		}
+		active := u.Active == nil || *u.Active
+		if !active {
+			if err := h.removeUser(r.Context(), id); err != nil {
+				writeRepoError(w, err)
+				return
+			}
+		}
+		writeJson(w, http.StatusOK, newUser(acct, active))
+
+	case http.MethodPatch:
+		var req patchRequest
+		if err := decode(r, &req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+			return
+		}
+		active := true
+		for _, op := range req.Operations {
+			if a, ok := patchedActive(op); ok {
+				active = a
+			}
+		}
+		if !active {
+			if err := h.removeUser(r.Context(), id); err != nil {
+				writeRepoError(w, err)
+				return
+			}
+		}
+		writeJson(w, http.StatusOK, newUser(acct, active))
+
+	case http.MethodDelete:
+		if err := h.removeUser(r.Context(), id); err != nil {
+			writeRepoError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method %s isn't supported.", r.Method))
+	}
+}
+
+// lookupUser returns the account of the auth method with the id, or nil if
+// there is none.
+func (h *handler) lookupUser(ctx context.Context, id string) (*ldap.Account, error) {
+	if !strings.HasPrefix(id, ldap.AccountPrefix+"_") {
+		return nil, nil
+	}
+	repo, err := h.ldapRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	acct, err := repo.LookupAccount(ctx, id)
+	if err != nil || acct == nil || acct.AuthMethodId != h.authMethodId {
+		return nil, err
+	}
+	return acct, nil
+}
+
+// unknownMember returns the first of the ids which isn't a user, or "" if
+// they all are.
+func (h *handler) unknownMember(ctx context.Context, ids []string) (string, error) {
+	for _, id := range ids {
+		acct, err := h.lookupUser(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		if acct == nil {
+			return id, nil
+		}
+	}
+	return "", nil
+}
+
+// patchedActive returns the value a patch operation sets the active attribute
+// of a user to, if it sets it.
+func patchedActive(op patchOperation) (bool, bool) {
+	if !strings.EqualFold(op.Op, "replace") && !strings.EqualFold(op.Op, "add") {
+		return false, false
+	}
+	var active bool
+	if strings.EqualFold(op.Path, "active") {
+		if err := json.Unmarshal(op.Value, &active); err != nil {
+			return false, false
+		}
+		return active, true
+	}
+	if op.Path == "" {
+		var v struct {
+			Active *bool `json:"active"`
+		}
+		if err := json.Unmarshal(op.Value, &v); err != nil || v.Active == nil {
+			return false, false
+		}
+		return *v.Active, true
+	}
+	return false, false
+}
+
+// removeUser removes the user with the id from every group bound to a SCIM
+// group.
+func (h *handler) removeUser(ctx context.Context, id string) error {
+	repo, err := h.repoFn()
+	if err != nil {
+		return err
+	}
+	grps, err := repo.ListDirectoryGroups(ctx, iam.DirectoryScim, iam.WithLimit(-1))
+	if err != nil {
+		return err
+	}
+	for _, g := range grps {
+		ids, err := repo.ListGroupMemberAccounts(ctx, g.PublicId, h.authMethodId)
+		if err != nil {
+			return err
+		}
+		i := sort.SearchStrings(ids, id)
+		if i == len(ids) || ids[i] != id {
+			continue
+		}
+		ids = append(ids[:i], ids[i+1:]...)
+		if _, err := repo.SyncGroupMembers(ctx, g.PublicId, ids); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *handler) handleGroups(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		var displayName string
+		if f := r.URL.Query().Get("filter"); f != "" {
+			attr, value, err := parseFilter(f)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "invalidFilter", err.Error())
+				return
+			}
+			if !strings.EqualFold(attr, "displayName") {
+				writeError(w, http.StatusBadRequest, "invalidFilter", fmt.Sprintf("Groups can't be filtered by %q.", attr))
+				return
+			}
+			displayName = value
+		}
+		repo, err := h.repoFn()
+		if err != nil {
+			writeRepoError(w, err)
+			return
+		}
+		grps, err := repo.ListDirectoryGroups(r.Context(), iam.DirectoryScim, iam.WithLimit(-1))
+		if err != nil {
+			writeRepoError(w, err)
+			return
+		}
+		resources := []interface{}{}
+		for _, g := range grps {
+			if displayName != "" && g.DirectoryGroup != displayName {
+				continue
+			}
+			out, err := h.toGroup(r.Context(), repo, g)
+			if err != nil {
+				writeRepoError(w, err)
+				return
+			}
+			resources = append(resources, out)
+		}
+		writeList(w, resources)
+
+	case http.MethodPost:
+		var in group
+		if err := decode(r, &in); err != nil {
+			writeError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+			return
+		}
+		if in.DisplayName == "" {
+			writeError(w, http.StatusBadRequest, "invalidValue", "Missing displayName.")
+			return
+		}
+		repo, err := h.repoFn()
+		if err != nil {
+			writeRepoError(w, err)
+			return
+		}
+		grps, err := repo.ListDirectoryGroups(r.Context(), iam.DirectoryScim, iam.WithLimit(-1))
+		if err != nil {
+			writeRepoError(w, err)
+			return
+		}
+		var bound *iam.Group
+		for _, g := range grps {
+			if g.DirectoryGroup == in.DisplayName {
+				bound = g
+				break
+			}
+		}
+		if bound == nil {
+			writeError(w, http.StatusNotFound, "", fmt.Sprintf("No group is bound to the SCIM group %q.", in.DisplayName))
+			return
+		}
+		if !h.knownMembers(w, r, memberIds(in.Members)) {
+			return
+		}
+		if _, err := repo.SyncGroupMembers(r.Context(), bound.PublicId, memberIds(in.Members)); err != nil {
+			writeRepoError(w, err)
+			return
+		}
+		out, err := h.toGroup(r.Context(), repo, bound)
+		if err != nil {
+			writeRepoError(w, err)
+			return
+		}
+		writeJson(w, http.StatusCreated, out)
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method %s isn't supported.", r.Method))
+	}
+}
+
+func (h *handler) handleGroup(w http.ResponseWriter, r *http.Request, id string) {
+	repo, err := h.repoFn()
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	g, _, err := repo.LookupGroup(r.Context(), id)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	if g == nil || g.DirectoryType != iam.DirectoryScim {
+		writeError(w, http.StatusNotFound, "", fmt.Sprintf("Group %q doesn't exist.", id))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+
+	case http.MethodPut:
+		var in group
+		if err := decode(r, &in); err != nil {
+			writeError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+			return
+		}
+		if !h.knownMembers(w, r, memberIds(in.Members)) {
+			return
+		}
+		if _, err := repo.SyncGroupMembers(r.Context(), id, memberIds(in.Members)); err != nil {
+			writeRepoError(w, err)
+			return
+		}
+
+	case http.MethodPatch:
+		var req patchRequest
+		if err := decode(r, &req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+			return
+		}
+		ids, err := repo.ListGroupMemberAccounts(r.Context(), id, h.authMethodId)
+		if err != nil {
+			writeRepoError(w, err)
+			return
+		}
+		for _, op := range req.Operations {
+			if ids, err = patchMembers(ids, op); err != nil {
+				writeError(w, http.StatusBadRequest, "invalidValue", err.Error())
+				return
+			}
+		}
+		if !h.knownMembers(w, r, ids) {
+			return
+		}
+		if _, err := repo.SyncGroupMembers(r.Context(), id, ids); err != nil {
+			writeRepoError(w, err)
+			return
+		}
+
+	case http.MethodDelete:
+		if _, err := repo.SyncGroupMembers(r.Context(), id, nil); err != nil {
+			writeRepoError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method %s isn't supported.", r.Method))
+		return
+	}
+
+	out, err := h.toGroup(r.Context(), repo, g)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJson(w, http.StatusOK, out)
+}
+
+// knownMembers reports whether the ids are all users, writing an error if not.
+func (h *handler) knownMembers(w http.ResponseWriter, r *http.Request, ids []string) bool {
+	id, err := h.unknownMember(r.Context(), ids)
+	switch {
+	case err != nil:
+		writeRepoError(w, err)
+		return false
+	case id != "":
+		writeError(w, http.StatusBadRequest, "invalidValue", fmt.Sprintf("Member %q isn't a user.", id))
+		return false
+	}
+	return true
+}
+
+// patchMembers applies a patch operation to the ids of the members of a
+// group. Operations on attributes other than members are ignored.
+func patchMembers(ids []string, op patchOperation) ([]string, error) {
+	path := op.Path
+	var filterValue string
+	if i := strings.Index(path, "["); i >= 0 && strings.HasSuffix(path, "]") {
+		attr, value, err := parseFilter(path[i+1 : len(path)-1])
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(attr, "value") {
+			return nil, fmt.Errorf("members can't be filtered by %q", attr)
+		}
+		path, filterValue = path[:i], value
+	}
+
+	var values []member
+	switch {
+	case strings.EqualFold(path, "members"):
+		if len(op.Value) > 0 {
+			if err := json.Unmarshal(op.Value, &values); err != nil {
+				return nil, fmt.Errorf("invalid members: %w", err)
+			}
+		}
+	case path == "":
+		var v struct {
+			Members *[]member `json:"members"`
+		}
+		if err := json.Unmarshal(op.Value, &v); err != nil {
+			return nil, fmt.Errorf("invalid value: %w", err)
+		}
+		if v.Members == nil {
+			return ids, nil
+		}
+		values = *v.Members
+	default:
+		return ids, nil
+	}
+
+	switch strings.ToLower(op.Op) {
+	case "add":
+		return append(ids, memberIds(values)...), nil
+	case "replace":
+		return memberIds(values), nil
+	case "remove":
+		remove := make(map[string]bool)
+		switch {
+		case filterValue != "":
+			remove[filterValue] = true
+		case len(values) == 0:
+			return nil, nil
+		default:
+			for _, id := range memberIds(values) {
+				remove[id] = true
+			}
+		}
+		var kept []string
+		for _, id := range ids {
+			if !remove[id] {
+				kept = append(kept, id)
+			}
+		}
+		return kept, nil
+	default:
+		return nil, fmt.Errorf("unknown operation %q", op.Op)
+	}
+}
+
+func (h *handler) toGroup(ctx context.Context, repo *iam.Repository, g *iam.Group) (*group, error) {
+	ids, err := repo.ListGroupMemberAccounts(ctx, g.PublicId, h.authMethodId)
+	if err != nil {
+		return nil, err
+	}
+	out := &group{
+		Schemas:     []string{schemaGroup},
+		Id:          g.PublicId,
+		DisplayName: g.DirectoryGroup,
+		Members:     []member{},
+		Meta:        &meta{ResourceType: "Group", Location: PathPrefix + "Groups/" + g.PublicId},
+	}
+	for _, id := range ids {
+		out.Members = append(out.Members, member{Value: id})
+	}
+	return out, nil
+}
+
+func newUser(acct *ldap.Account, active bool) *user {
+	return &user{
+		Schemas:    []string{schemaUser},
+		Id:         acct.PublicId,
+		ExternalId: acct.Dn,
+		UserName:   acct.LoginName,
+		Active:     &active,
+		Meta:       &meta{ResourceType: "User", Location: PathPrefix + "Users/" + acct.PublicId},
+	}
+}
+
+func memberIds(members []member) []string {
+	ids := make([]string, 0, len(members))
+	for _, m := range members {
+		ids = append(ids, m.Value)
+	}
+	return ids
+}
+
+// parseFilter parses a filter of the form `attr eq "value"`, the only form
+// of filter that is supported.
+func parseFilter(f string) (string, string, error) {
+	parts := strings.SplitN(strings.TrimSpace(f), " ", 3)
+	if len(parts) != 3 || !strings.EqualFold(parts[1], "eq") {
+		return "", "", fmt.Errorf("unsupported filter %q", f)
+	}
+	value, err := strconv.Unquote(strings.TrimSpace(parts[2]))
+	if err != nil {
+		return "", "", fmt.Errorf("unsupported filter %q: value must be a quoted string", f)
+	}
+	return parts[0], value, nil
+}
+
+func decode(r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return fmt.Errorf("Unable to decode request body: %v.", err)
+	}
+	return nil
+}
+
+func writeList(w http.ResponseWriter, resources []interface{}) {
+	if resources == nil {
+		resources = []interface{}{}
+	}
+	writeJson(w, http.StatusOK, &listResponse{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: len(resources),
+		StartIndex:   1,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+func writeRepoError(w http.ResponseWriter, err error) {
+	writeError(w, http.StatusInternalServerError, "", err.Error())
+}
+
+func writeError(w http.ResponseWriter, status int, scimType, detail string) {
+	writeJson(w, status, &scimError{
+		Schemas:  []string{schemaError},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
+
+func writeJson(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,346 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth/ldap"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testToken = "test-bearer-token"
+
+func testRequest(t *testing.T, h http.Handler, method, path, body string) (int, map[string]interface{}) {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	req.Header.Set("Content-Type", contentType)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	var out map[string]interface{}
+	if rec.Body.Len() > 0 {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+	}
+	return rec.Code, out
+}
+
+func memberValues(t *testing.T, grp map[string]interface{}) []string {
+	t.Helper()
+	members, ok := grp["members"].([]interface{})
+	require.True(t, ok, "missing members in %v", grp)
+	var values []string
+	for _, m := range members {
+		values = append(values, m.(map[string]interface{})["value"].(string))
+	}
+	return values
+}
+
+func TestHandler_Authentication(t *testing.T) {
+	repoFn := func() (*iam.Repository, error) { return nil, nil }
+	ldapRepoFn := func() (*ldap.Repository, error) { return nil, nil }
+	h, err := NewHandler(repoFn, ldapRepoFn, "amldap_1234567890", testToken)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{name: "missing", want: http.StatusUnauthorized},
+		{name: "wrong token", header: "Bearer wrong", want: http.StatusUnauthorized},
+		{name: "wrong scheme", header: "Basic " + testToken, want: http.StatusUnauthorized},
+		{name: "valid", header: "bearer " + testToken, want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, PathPrefix+"Users", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			assert.Equal(t, tt.want, rec.Code)
+		})
+	}
+
+	_, err = NewHandler(repoFn, ldapRepoFn, "amldap_1234567890", "")
+	assert.Error(t, err)
+	_, err = NewHandler(repoFn, ldapRepoFn, "", testToken)
+	assert.Error(t, err)
+	_, err = NewHandler(repoFn, nil, "amldap_1234567890", testToken)
+	assert.Error(t, err)
+	_, err = NewHandler(nil, ldapRepoFn, "amldap_1234567890", testToken)
+	assert.Error(t, err)
+}
+
+func TestPatchMembers(t *testing.T) {
+	tests := []struct {
+		name    string
+		op      patchOperation
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "add",
+			op:   patchOperation{Op: "add", Path: "members", Value: json.RawMessage(`[{"value":"bob"}]`)},
+			want: []string{"jim", "sue", "bob"},
+		},
+		{
+			name: "add without path",
+			op:   patchOperation{Op: "Add", Value: json.RawMessage(`{"members":[{"value":"bob"}]}`)},
+			want: []string{"jim", "sue", "bob"},
+		},
+		{
+			name: "replace",
+			op:   patchOperation{Op: "replace", Path: "members", Value: json.RawMessage(`[{"value":"bob"}]`)},
+			want: []string{"bob"},
+		},
+		{
+			name: "remove filtered",
+			op:   patchOperation{Op: "remove", Path: `members[value eq "jim"]`},
+			want: []string{"sue"},
+		},
+		{
+			name: "remove values",
+			op:   patchOperation{Op: "Remove", Path: "members", Value: json.RawMessage(`[{"value":"sue"}]`)},
+			want: []string{"jim"},
+		},
+		{
+			name: "remove all",
+			op:   patchOperation{Op: "remove", Path: "members"},
+			want: nil,
+		},
+		{
+			name: "other attribute",
+			op:   patchOperation{Op: "replace", Path: "displayName", Value: json.RawMessage(`"admins"`)},
+			want: []string{"jim", "sue"},
+		},
+		{
+			name:    "unknown op",
+			op:      patchOperation{Op: "move", Path: "members"},
+			wantErr: true,
+		},
+		{
+			name:    "bad filter",
+			op:      patchOperation{Op: "remove", Path: `members[display eq "jim"]`},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := patchMembers([]string{"jim", "sue"}, tt.op)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	attr, value, err := parseFilter(`userName eq "jim smith"`)
+	require.NoError(t, err)
+	assert.Equal(t, "userName", attr)
+	assert.Equal(t, "jim smith", value)
+
+	_, _, err = parseFilter(`userName sw "jim"`)
+	assert.Error(t, err)
+	_, _, err = parseFilter(`userName eq jim`)
+	assert.Error(t, err)
+}
+
+func TestHandler_Users(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	repo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, repo)
+	ldapRepo, err := ldap.NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+	am := ldap.TestAuthMethods(t, conn, org.PublicId, "ldap://127.0.0.1", 2)
+	h, err := NewHandler(func() (*iam.Repository, error) { return repo, nil }, func() (*ldap.Repository, error) { return ldapRepo, nil }, am[0].PublicId, testToken)
+	require.NoError(t, err)
+
+	const jimDn = "uid=jim,ou=people,dc=example,dc=com"
+	var jimId string
+	t.Run("create", func(t *testing.T) {
+		code, out := testRequest(t, h, http.MethodPost, PathPrefix+"Users", `{"userName":"jim","externalId":"`+jimDn+`"}`)
+		require.Equal(t, http.StatusCreated, code, "%v", out)
+		jimId = out["id"].(string)
+		assert.True(t, strings.HasPrefix(jimId, ldap.AccountPrefix+"_"))
+		assert.Equal(t, jimDn, out["externalId"])
+		assert.Equal(t, "jim", out["userName"])
+
+		// The user is the account of the auth method for the DN, which the
+		// user's logins use.
+		acct, err := ldapRepo.LookupAccountByDn(context.Background(), am[0].PublicId, jimDn)
+		require.NoError(t, err)
+		require.NotNil(t, acct)
+		assert.Equal(t, jimId, acct.PublicId)
+
+		// Creating it again returns the same account.
+		code, out = testRequest(t, h, http.MethodPost, PathPrefix+"Users", `{"userName":"jim","externalId":"`+jimDn+`"}`)
+		require.Equal(t, http.StatusCreated, code, "%v", out)
+		assert.Equal(t, jimId, out["id"])
+
+		code, _ = testRequest(t, h, http.MethodPost, PathPrefix+"Users", `{"userName":"jim"}`)
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		code, out := testRequest(t, h, http.MethodGet, PathPrefix+"Users/"+jimId, "")
+		require.Equal(t, http.StatusOK, code, "%v", out)
+		assert.Equal(t, jimDn, out["externalId"])
+
+		// Accounts of other auth methods aren't users.
+		other := ldap.TestAccounts(t, conn, am[1].PublicId, 1)[0]
+		code, _ = testRequest(t, h, http.MethodGet, PathPrefix+"Users/"+other.PublicId, "")
+		assert.Equal(t, http.StatusNotFound, code)
+		code, _ = testRequest(t, h, http.MethodGet, PathPrefix+"Users/jim", "")
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		code, out := testRequest(t, h, http.MethodGet, PathPrefix+`Users?filter=userName+eq+"jim"`, "")
+		require.Equal(t, http.StatusOK, code, "%v", out)
+		assert.EqualValues(t, 1, out["totalResults"])
+		code, out = testRequest(t, h, http.MethodGet, PathPrefix+`Users?filter=externalId+eq+"`+jimDn+`"`, "")
+		require.Equal(t, http.StatusOK, code, "%v", out)
+		assert.EqualValues(t, 1, out["totalResults"])
+		code, out = testRequest(t, h, http.MethodGet, PathPrefix+`Users?filter=userName+eq+"sue"`, "")
+		require.Equal(t, http.StatusOK, code, "%v", out)
+		assert.EqualValues(t, 0, out["totalResults"])
+		code, _ = testRequest(t, h, http.MethodGet, PathPrefix+`Users?filter=emails+eq+"jim@example.com"`, "")
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+}
+
+func TestHandler_Groups(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	repo := iam.TestRepo(t, conn, wrapper)
+	org, proj := iam.TestScopes(t, repo)
+	ldapRepo, err := ldap.NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+	am := ldap.TestAuthMethods(t, conn, org.PublicId, "ldap://127.0.0.1", 1)[0]
+	h, err := NewHandler(func() (*iam.Repository, error) { return repo, nil }, func() (*ldap.Repository, error) { return ldapRepo, nil }, am.PublicId, testToken)
+	require.NoError(t, err)
+
+	orgGrp := iam.TestGroup(t, conn, org.PublicId, iam.WithDirectoryType(iam.DirectoryScim), iam.WithDirectoryGroup("admins"))
+	projGrp := iam.TestGroup(t, conn, proj.PublicId, iam.WithDirectoryType(iam.DirectoryScim), iam.WithDirectoryGroup("ops"))
+	ldapGrp := iam.TestGroup(t, conn, org.PublicId, iam.WithDirectoryType(iam.DirectoryLdap), iam.WithDirectoryGroup("cn=admins,dc=example,dc=com"))
+
+	ids := make(map[string]string)
+	for _, name := range []string{"jim", "sue", "bob"} {
+		code, out := testRequest(t, h, http.MethodPost, PathPrefix+"Users", `{"userName":"`+name+`","externalId":"uid=`+name+`,ou=people,dc=example,dc=com"}`)
+		require.Equal(t, http.StatusCreated, code, "%v", out)
+		ids[name] = out["id"].(string)
+	}
+	sorted := func(names ...string) []string {
+		var out []string
+		for _, n := range names {
+			out = append(out, ids[n])
+		}
+		sort.Strings(out)
+		return out
+	}
+	memberAccounts := func(groupId string) []string {
+		t.Helper()
+		accts, err := repo.ListGroupMemberAccounts(context.Background(), groupId, am.PublicId)
+		require.NoError(t, err)
+		return accts
+	}
+
+	t.Run("create binds group", func(t *testing.T) {
+		code, out := testRequest(t, h, http.MethodPost, PathPrefix+"Groups", `{"displayName":"admins","members":[{"value":"`+ids["jim"]+`"},{"value":"`+ids["sue"]+`"}]}`)
+		require.Equal(t, http.StatusCreated, code, "%v", out)
+		assert.Equal(t, orgGrp.PublicId, out["id"])
+		assert.Equal(t, sorted("jim", "sue"), memberValues(t, out))
+
+		code, _ = testRequest(t, h, http.MethodPost, PathPrefix+"Groups", `{"displayName":"unbound"}`)
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("unknown member", func(t *testing.T) {
+		code, _ := testRequest(t, h, http.MethodPut, PathPrefix+"Groups/"+projGrp.PublicId, `{"displayName":"ops","members":[{"value":"jim"}]}`)
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Empty(t, memberAccounts(projGrp.PublicId))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		code, out := testRequest(t, h, http.MethodGet, PathPrefix+`Groups?filter=displayName+eq+"ops"`, "")
+		require.Equal(t, http.StatusOK, code, "%v", out)
+		assert.EqualValues(t, 1, out["totalResults"])
+
+		code, out = testRequest(t, h, http.MethodGet, PathPrefix+"Groups", "")
+		require.Equal(t, http.StatusOK, code, "%v", out)
+		assert.EqualValues(t, 2, out["totalResults"])
+	})
+
+	t.Run("patch", func(t *testing.T) {
+		body := `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[
+			{"op":"add","path":"members","value":[{"value":"` + ids["bob"] + `"}]},
+			{"op":"remove","path":"members[value eq \"` + ids["jim"] + `\"]"}]}`
+		code, out := testRequest(t, h, http.MethodPatch, PathPrefix+"Groups/"+orgGrp.PublicId, body)
+		require.Equal(t, http.StatusOK, code, "%v", out)
+		assert.Equal(t, sorted("bob", "sue"), memberValues(t, out))
+	})
+
+	t.Run("put", func(t *testing.T) {
+		code, out := testRequest(t, h, http.MethodPut, PathPrefix+"Groups/"+projGrp.PublicId, `{"displayName":"ops","members":[{"value":"`+ids["sue"]+`"}]}`)
+		require.Equal(t, http.StatusOK, code, "%v", out)
+		assert.Equal(t, sorted("sue"), memberValues(t, out))
+	})
+
+	t.Run("deactivate user", func(t *testing.T) {
+		body := `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"replace","value":{"active":false}}]}`
+		code, out := testRequest(t, h, http.MethodPatch, PathPrefix+"Users/"+ids["sue"], body)
+		require.Equal(t, http.StatusOK, code, "%v", out)
+		assert.Equal(t, false, out["active"])
+
+		assert.Equal(t, sorted("bob"), memberAccounts(orgGrp.PublicId))
+		assert.Empty(t, memberAccounts(projGrp.PublicId))
+	})
+
+	t.Run("delete user", func(t *testing.T) {
+		code, _ := testRequest(t, h, http.MethodDelete, PathPrefix+"Users/"+ids["bob"], "")
+		require.Equal(t, http.StatusNoContent, code)
+		assert.Empty(t, memberAccounts(orgGrp.PublicId))
+
+		// The account and its user still exist.
+		acct, err := ldapRepo.LookupAccount(context.Background(), ids["bob"])
+		require.NoError(t, err)
+		require.NotNil(t, acct)
+		_, err = repo.LookupUserWithLogin(context.Background(), ids["bob"])
+		assert.NoError(t, err)
+	})
+
+	t.Run("not scim group", func(t *testing.T) {
+		code, _ := testRequest(t, h, http.MethodGet, PathPrefix+"Groups/"+ldapGrp.PublicId, "")
+		assert.Equal(t, http.StatusNotFound, code)
+		code, _ = testRequest(t, h, http.MethodGet, PathPrefix+"Groups/g_1234567890", "")
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("delete group", func(t *testing.T) {
+		_, err := repo.SyncGroupMembers(context.Background(), orgGrp.PublicId, []string{ids["jim"]})
+		require.NoError(t, err)
+		code, _ := testRequest(t, h, http.MethodDelete, PathPrefix+"Groups/"+orgGrp.PublicId, "")
+		require.Equal(t, http.StatusNoContent, code)
+		assert.Empty(t, memberAccounts(orgGrp.PublicId))
+	})
+}
@@ -23,6 +23,14 @@ import (
 
 var (
 	maskManager handlers.MaskManager
+
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.Read,
+		action.Update,
+		action.Delete,
+	}
 )
 
 func init() {
@@ -58,19 +66,41 @@ func (s Service) ListScopes(ctx context.Context, req *pbs.ListScopesRequest) (*p
 		return nil, err
 	}
 	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
-	if authResults.Error != nil {
-		return nil, authResults.Error
+	scopeIds, scopeInfos, err := authResults.ScopesForList(ctx, resource.Scope, req.GetRecursive())
+	if err != nil {
+		return nil, err
+	}
+	if len(scopeIds) == 0 {
+		return &pbs.ListScopesResponse{}, nil
 	}
-	pl, err := s.listFromRepo(ctx, authResults.Scope.GetId())
+	fetch := func(afterId string, limit int) ([]handlers.ListItem, error) {
+		pl, err := s.listFromRepo(ctx, scopeIds, afterId, limit)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]handlers.ListItem, 0, len(pl))
+		for _, scp := range pl {
+			resOpt := auth.WithScopeId(scp.GetParentId())
+			if authResults.DeniedForId(ctx, scp.GetPublicId(), action.Read, resOpt) {
+				items = append(items, handlers.HiddenListItem(scp.GetPublicId()))
+				continue
+			}
+			items = append(items, handlers.ListedItem(scp.GetPublicId(), ToProto(scp,
+				handlers.WithScope(scopeInfos[scp.GetParentId()]),
+				handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, scp.GetPublicId(), IdActions, resOpt).Strings()),
+				handlers.WithOutputFields(authResults.FetchOutputFields(ctx, scp.GetPublicId(), resOpt)))))
+		}
+		return items, nil
+	}
+	items, next, err := handlers.ListPage(fetch, req.GetFilter(), req.GetPageSize(), req.GetPageToken())
 	if err != nil {
 		return nil, err
 	}
-
-	for _, item := range pl {
-		item.Scope = authResults.Scope
+	var pl []*pb.Scope
+	for _, item := range items {
+		pl = append(pl, item.(*pb.Scope))
 	}
-
-	return &pbs.ListScopesResponse{Items: pl}, nil
+	return &pbs.ListScopesResponse{Items: pl, NextPageToken: next}, nil
 }
 
 // GetScopes implements the interface pbs.ScopeServiceServer.
@@ -82,11 +112,13 @@ func (s Service) GetScope(ctx context.Context, req *pbs.GetScopeRequest) (*pbs.G
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	p, err := s.getFromRepo(ctx, req.GetId())
+	p, err := s.getFromRepo(ctx, req.GetId(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, req.GetId(), IdActions).Strings()),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	p.Scope = authResults.Scope
 	return &pbs.GetScopeResponse{Item: p}, nil
 }
 
@@ -99,11 +131,12 @@ func (s Service) CreateScope(ctx context.Context, req *pbs.CreateScopeRequest) (
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	p, err := s.createInRepo(ctx, authResults, req)
+	p, err := s.createInRepo(ctx, authResults, req,
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, "")))
 	if err != nil {
 		return nil, err
 	}
-	p.Scope = authResults.Scope
 	return &pbs.CreateScopeResponse{Item: p, Uri: fmt.Sprintf("scopes/%s", p.GetId())}, nil
 }
 
@@ -116,11 +149,12 @@ func (s Service) UpdateScope(ctx context.Context, req *pbs.UpdateScopeRequest) (
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	p, err := s.updateInRepo(ctx, authResults.Scope, req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	p, err := s.updateInRepo(ctx, authResults.Scope, req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	p.Scope = authResults.Scope
 	return &pbs.UpdateScopeResponse{Item: p}, nil
 }
 
@@ -140,7 +174,7 @@ func (s Service) DeleteScope(ctx context.Context, req *pbs.DeleteScopeRequest) (
 	return &pbs.DeleteScopeResponse{}, nil
 }
 
-func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Scope, error) {
+func (s Service) getFromRepo(ctx context.Context, id string, opt ...handlers.Option) (*pb.Scope, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -152,10 +186,10 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Scope, error)
 	if p == nil {
 		return nil, handlers.NotFoundErrorf("Scope %q doesn't exist.", id)
 	}
-	return ToProto(p), nil
+	return ToProto(p, opt...), nil
 }
 
-func (s Service) createInRepo(ctx context.Context, authResults auth.VerifyResults, req *pbs.CreateScopeRequest) (*pb.Scope, error) {
+func (s Service) createInRepo(ctx context.Context, authResults auth.VerifyResults, req *pbs.CreateScopeRequest, opt ...handlers.Option) (*pb.Scope, error) {
 	item := req.GetItem()
 	var opts []iam.Option
 	if item.GetName() != nil {
@@ -190,10 +224,10 @@ func (s Service) createInRepo(ctx context.Context, authResults auth.VerifyResult
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create scope but no error returned from repository.")
 	}
-	return ToProto(out), nil
+	return ToProto(out, opt...), nil
 }
 
-func (s Service) updateInRepo(ctx context.Context, parentScope *scopes.ScopeInfo, scopeId string, mask []string, item *pb.Scope) (*pb.Scope, error) {
+func (s Service) updateInRepo(ctx context.Context, parentScope *scopes.ScopeInfo, scopeId string, mask []string, item *pb.Scope, opt ...handlers.Option) (*pb.Scope, error) {
 	var opts []iam.Option
 	if desc := item.GetDescription(); desc != nil {
 		opts = append(opts, iam.WithDescription(desc.GetValue()))
@@ -230,7 +264,7 @@ func (s Service) updateInRepo(ctx context.Context, parentScope *scopes.ScopeInfo
 	if rowsUpdated == 0 {
 		return nil, handlers.NotFoundErrorf("Scope %q doesn't exist or incorrect version provided.", scopeId)
 	}
-	return ToProto(out), nil
+	return ToProto(out, opt...), nil
 }
 
 func (s Service) deleteFromRepo(ctx context.Context, scopeId string) (bool, error) {
@@ -253,32 +287,21 @@ func SortScopes(scps []*pb.Scope) {
 	})
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeId string) ([]*pb.Scope, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, afterId string, limit int) ([]*iam.Scope, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
 
-	var scps []*iam.Scope
-	switch {
-	case scopeId == "global":
-		scps, err = repo.ListOrgs(ctx)
-	case strings.HasPrefix(scopeId, scope.Org.Prefix()):
-		scps, err = repo.ListProjects(ctx, scopeId)
-	default:
-		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
-			map[string]string{"scope_id": "This field must be 'global' or a valid org scope id."})
-	}
+	scps, err := repo.ListScopes(ctx, scopeIds, iam.WithStartPageAfterId(afterId), iam.WithLimit(limit))
 	if err != nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to list scopes: %v", err)
 	}
-
-	var outPl []*pb.Scope
-	for _, scp := range scps {
-		outPl = append(outPl, ToProto(scp))
-	}
-	SortScopes(outPl)
-	return outPl, nil
+	// Sorted by id the same way SortScopes sorts the protos.
+	sort.SliceStable(scps, func(i, j int) bool {
+		return scps[i].GetPublicId() < scps[j].GetPublicId()
+	})
+	return scps, nil
 }
 
 func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
@@ -320,29 +343,48 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	return auth.Verify(ctx, opts...)
 }
 
-func ToProto(in *iam.Scope) *pb.Scope {
-	out := pb.Scope{
-		Id:          in.GetPublicId(),
-		ScopeId:     in.GetParentId(),
-		CreatedTime: in.GetCreateTime().GetTimestamp(),
-		UpdatedTime: in.GetUpdateTime().GetTimestamp(),
-		Version:     in.GetVersion(),
-		Type:        in.GetType(),
+func ToProto(in *iam.Scope, opt ...handlers.Option) *pb.Scope {
+	opts := handlers.GetOpts(opt...)
+	outputFields := opts.WithOutputFields
+	out := pb.Scope{}
+	if outputFields.Has("id") {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has("scope_id") {
+		out.ScopeId = in.GetParentId()
 	}
-	if in.GetDescription() != "" {
+	if outputFields.Has("scope") {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has("created_time") {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
+	}
+	if outputFields.Has("updated_time") {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has("version") {
+		out.Version = in.GetVersion()
+	}
+	if outputFields.Has("type") {
+		out.Type = in.GetType()
+	}
+	if outputFields.Has("description") && in.GetDescription() != "" {
 		out.Description = &wrapperspb.StringValue{Value: in.GetDescription()}
 	}
-	if in.GetName() != "" {
+	if outputFields.Has("name") && in.GetName() != "" {
 		out.Name = &wrapperspb.StringValue{Value: in.GetName()}
 	}
+	if outputFields.Has("authorized_actions") {
+		out.AuthorizedActions = opts.WithAuthorizedActions
+	}
 	return &out
 }
 
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
-//  * The path passed in is correctly formatted
-//  * All required parameters are set
-//  * There are no conflicting parameters provided
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
 func validateGetRequest(req *pbs.GetScopeRequest) error {
 	badFields := map[string]string{}
 	id := req.GetId()
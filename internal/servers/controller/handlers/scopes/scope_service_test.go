@@ -28,6 +28,8 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+var testAuthorizedActions = []string{"read", "update", "delete"}
+
 func createDefaultScopesAndRepo(t *testing.T) (*iam.Scope, *iam.Scope, func() (*iam.Repository, error)) {
 	t.Helper()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -62,27 +64,29 @@ func TestGet(t *testing.T) {
 	}
 
 	oScope := &pb.Scope{
-		Id:          org.GetPublicId(),
-		ScopeId:     org.GetParentId(),
-		Scope:       &pb.ScopeInfo{Id: "global", Type: scope.Global.String()},
-		Name:        &wrapperspb.StringValue{Value: org.GetName()},
-		Description: &wrapperspb.StringValue{Value: org.GetDescription()},
-		CreatedTime: org.CreateTime.GetTimestamp(),
-		UpdatedTime: org.UpdateTime.GetTimestamp(),
-		Version:     2,
-		Type:        scope.Org.String(),
+		Id:                org.GetPublicId(),
+		ScopeId:           org.GetParentId(),
+		Scope:             &pb.ScopeInfo{Id: "global", Type: scope.Global.String()},
+		Name:              &wrapperspb.StringValue{Value: org.GetName()},
+		Description:       &wrapperspb.StringValue{Value: org.GetDescription()},
+		CreatedTime:       org.CreateTime.GetTimestamp(),
+		UpdatedTime:       org.UpdateTime.GetTimestamp(),
+		Version:           2,
+		Type:              scope.Org.String(),
+		AuthorizedActions: testAuthorizedActions,
 	}
 
 	pScope := &pb.Scope{
-		Id:          proj.GetPublicId(),
-		ScopeId:     proj.GetParentId(),
-		Scope:       &pb.ScopeInfo{Id: oScope.Id, Type: scope.Org.String()},
-		Name:        &wrapperspb.StringValue{Value: proj.GetName()},
-		Description: &wrapperspb.StringValue{Value: proj.GetDescription()},
-		CreatedTime: proj.CreateTime.GetTimestamp(),
-		UpdatedTime: proj.UpdateTime.GetTimestamp(),
-		Version:     2,
-		Type:        scope.Project.String(),
+		Id:                proj.GetPublicId(),
+		ScopeId:           proj.GetParentId(),
+		Scope:             &pb.ScopeInfo{Id: oScope.Id, Type: scope.Org.String()},
+		Name:              &wrapperspb.StringValue{Value: proj.GetName()},
+		Description:       &wrapperspb.StringValue{Value: proj.GetDescription()},
+		CreatedTime:       proj.CreateTime.GetTimestamp(),
+		UpdatedTime:       proj.UpdateTime.GetTimestamp(),
+		Version:           2,
+		Type:              scope.Project.String(),
+		AuthorizedActions: testAuthorizedActions,
 	}
 
 	cases := []struct {
@@ -174,8 +178,10 @@ func TestList(t *testing.T) {
 	globalScope := &pb.ScopeInfo{Id: "global", Type: scope.Global.String()}
 	oNoProjectsProto := scopes.ToProto(oNoProjects)
 	oNoProjectsProto.Scope = globalScope
+	oNoProjectsProto.AuthorizedActions = testAuthorizedActions
 	oWithProjectsProto := scopes.ToProto(oWithProjects)
 	oWithProjectsProto.Scope = globalScope
+	oWithProjectsProto.AuthorizedActions = testAuthorizedActions
 	initialOrgs = append(initialOrgs, oNoProjectsProto, oWithProjectsProto)
 	scopes.SortScopes(initialOrgs)
 
@@ -227,13 +233,14 @@ func TestList(t *testing.T) {
 		o, err := repo.CreateScope(context.Background(), newO, "")
 		require.NoError(t, err)
 		wantOrgs = append(wantOrgs, &pb.Scope{
-			Id:          o.GetPublicId(),
-			ScopeId:     globalScope.GetId(),
-			Scope:       globalScope,
-			CreatedTime: o.GetCreateTime().GetTimestamp(),
-			UpdatedTime: o.GetUpdateTime().GetTimestamp(),
-			Version:     1,
-			Type:        scope.Org.String(),
+			Id:                o.GetPublicId(),
+			ScopeId:           globalScope.GetId(),
+			Scope:             globalScope,
+			CreatedTime:       o.GetCreateTime().GetTimestamp(),
+			UpdatedTime:       o.GetUpdateTime().GetTimestamp(),
+			Version:           1,
+			Type:              scope.Org.String(),
+			AuthorizedActions: testAuthorizedActions,
 		})
 	}
 	wantOrgs = append(wantOrgs, initialOrgs...)
@@ -246,13 +253,14 @@ func TestList(t *testing.T) {
 		p, err := repo.CreateScope(context.Background(), newP, "")
 		require.NoError(t, err)
 		wantProjects = append(wantProjects, &pb.Scope{
-			Id:          p.GetPublicId(),
-			ScopeId:     oWithProjects.GetPublicId(),
-			Scope:       &pb.ScopeInfo{Id: oWithProjects.GetPublicId(), Type: scope.Org.String()},
-			CreatedTime: p.GetCreateTime().GetTimestamp(),
-			UpdatedTime: p.GetUpdateTime().GetTimestamp(),
-			Version:     1,
-			Type:        scope.Project.String(),
+			Id:                p.GetPublicId(),
+			ScopeId:           oWithProjects.GetPublicId(),
+			Scope:             &pb.ScopeInfo{Id: oWithProjects.GetPublicId(), Type: scope.Org.String()},
+			CreatedTime:       p.GetCreateTime().GetTimestamp(),
+			UpdatedTime:       p.GetUpdateTime().GetTimestamp(),
+			Version:           1,
+			Type:              scope.Project.String(),
+			AuthorizedActions: testAuthorizedActions,
 		})
 	}
 	scopes.SortScopes(wantProjects)
@@ -613,7 +621,7 @@ func TestCreate(t *testing.T) {
 					if withUserId {
 						repo, err := repoFn()
 						require.NoError(err)
-						roles, err := repo.ListRoles(ctx, got.GetItem().GetId())
+						roles, err := repo.ListRoles(ctx, []string{got.GetItem().GetId()})
 						require.NoError(err)
 						switch tc.scopeId {
 						case defaultOrg.PublicId:
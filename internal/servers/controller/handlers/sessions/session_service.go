@@ -18,23 +18,38 @@ import (
 	"github.com/hashicorp/boundary/internal/types/scope"
 )
 
+var (
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.Read,
+		action.Cancel,
+		action.DownloadRecording,
+		action.TerminateConnection,
+	}
+)
+
 // Service handles request as described by the pbs.SessionServiceServer interface.
 type Service struct {
 	pbs.UnimplementedSessionServiceServer
 
-	repoFn    common.SessionRepoFactory
-	iamRepoFn common.IamRepoFactory
+	repoFn     common.SessionRepoFactory
+	iamRepoFn  common.IamRepoFactory
+	jobChanges *common.WorkerJobChanges
 }
 
 // NewService returns a session service which handles session related requests to boundary.
-func NewService(repoFn common.SessionRepoFactory, iamRepoFn common.IamRepoFactory) (Service, error) {
+func NewService(repoFn common.SessionRepoFactory, iamRepoFn common.IamRepoFactory, jobChanges *common.WorkerJobChanges) (Service, error) {
 	if repoFn == nil {
 		return Service{}, fmt.Errorf("nil session repository provided")
 	}
 	if iamRepoFn == nil {
 		return Service{}, fmt.Errorf("nil iam repository provided")
 	}
-	return Service{repoFn: repoFn, iamRepoFn: iamRepoFn}, nil
+	if jobChanges == nil {
+		return Service{}, fmt.Errorf("nil worker job changes provided")
+	}
+	return Service{repoFn: repoFn, iamRepoFn: iamRepoFn, jobChanges: jobChanges}, nil
 }
 
 var _ pbs.SessionServiceServer = Service{}
@@ -48,11 +63,13 @@ func (s Service) GetSession(ctx context.Context, req *pbs.GetSessionRequest) (*p
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	ses, err := s.getFromRepo(ctx, req.GetId())
+	ses, err := s.getFromRepo(ctx, req.GetId(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, req.GetId(), IdActions).Strings()),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	ses.Scope = authResults.Scope
 	return &pbs.GetSessionResponse{Item: ses}, nil
 }
 
@@ -62,17 +79,41 @@ func (s Service) ListSessions(ctx context.Context, req *pbs.ListSessionsRequest)
 		return nil, err
 	}
 	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
-	if authResults.Error != nil {
-		return nil, authResults.Error
+	scopeIds, scopeInfos, err := authResults.ScopesForList(ctx, resource.Session, req.GetRecursive())
+	if err != nil {
+		return nil, err
+	}
+	if len(scopeIds) == 0 {
+		return &pbs.ListSessionsResponse{}, nil
 	}
-	seslist, err := s.listFromRepo(ctx, authResults.Scope.GetId())
+	fetch := func(afterId string, limit int) ([]handlers.ListItem, error) {
+		seslist, err := s.listFromRepo(ctx, scopeIds, afterId, limit)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]handlers.ListItem, 0, len(seslist))
+		for _, ses := range seslist {
+			resOpt := auth.WithScopeId(ses.ScopeId)
+			if authResults.DeniedForId(ctx, ses.GetPublicId(), action.Read, resOpt) {
+				items = append(items, handlers.HiddenListItem(ses.GetPublicId()))
+				continue
+			}
+			items = append(items, handlers.ListedItem(ses.GetPublicId(), toProto(ses,
+				handlers.WithScope(scopeInfos[ses.ScopeId]),
+				handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, ses.GetPublicId(), IdActions, resOpt).Strings()),
+				handlers.WithOutputFields(authResults.FetchOutputFields(ctx, ses.GetPublicId(), resOpt)))))
+		}
+		return items, nil
+	}
+	items, next, err := handlers.ListPage(fetch, req.GetFilter(), req.GetPageSize(), req.GetPageToken())
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range seslist {
-		item.Scope = authResults.Scope
+	var seslist []*pb.Session
+	for _, item := range items {
+		seslist = append(seslist, item.(*pb.Session))
 	}
-	return &pbs.ListSessionsResponse{Items: seslist}, nil
+	return &pbs.ListSessionsResponse{Items: seslist, NextPageToken: next}, nil
 }
 
 // CancelSession implements the interface pbs.SessionServiceServer.
@@ -84,15 +125,50 @@ func (s Service) CancelSession(ctx context.Context, req *pbs.CancelSessionReques
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	ses, err := s.cancelInRepo(ctx, req.GetId(), req.GetVersion())
+	ses, err := s.cancelInRepo(ctx, req.GetId(), req.GetVersion(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	ses.Scope = authResults.Scope
 	return &pbs.CancelSessionResponse{Item: ses}, nil
 }
 
-func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Session, error) {
+// TerminateSessionConnection implements the interface pbs.SessionServiceServer.
+func (s Service) TerminateSessionConnection(ctx context.Context, req *pbs.TerminateSessionConnectionRequest) (*pbs.TerminateSessionConnectionResponse, error) {
+	if err := validateTerminateConnectionRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.TerminateConnection)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	ses, err := s.terminateConnectionInRepo(ctx, req.GetId(), req.GetConnectionId(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.TerminateSessionConnectionResponse{Item: ses}, nil
+}
+
+// DownloadSessionRecording implements the interface pbs.SessionServiceServer.
+func (s Service) DownloadSessionRecording(ctx context.Context, req *pbs.DownloadSessionRecordingRequest) (*pbs.DownloadSessionRecordingResponse, error) {
+	if err := validateDownloadRecordingRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.DownloadRecording)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	recordings, err := s.listRecordingsFromRepo(ctx, req.GetId(), req.GetConnectionId())
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.DownloadSessionRecordingResponse{Items: recordings}, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string, opt ...handlers.Option) (*pb.Session, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -107,26 +183,22 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Session, error
 	if sess == nil {
 		return nil, handlers.NotFoundErrorf("Session %q doesn't exist.", id)
 	}
-	return toProto(sess), nil
+	return toProto(sess, opt...), nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeId string) ([]*pb.Session, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, afterId string, limit int) ([]*session.Session, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	seslist, err := repo.ListSessions(ctx, session.WithScopeId(scopeId))
+	seslist, err := repo.ListSessions(ctx, session.WithScopeIds(scopeIds...), session.WithStartPageAfterId(afterId), session.WithLimit(limit))
 	if err != nil {
 		return nil, err
 	}
-	var outSl []*pb.Session
-	for _, ses := range seslist {
-		outSl = append(outSl, toProto(ses))
-	}
-	return outSl, nil
+	return seslist, nil
 }
 
-func (s Service) cancelInRepo(ctx context.Context, id string, version uint32) (*pb.Session, error) {
+func (s Service) cancelInRepo(ctx context.Context, id string, version uint32, opt ...handlers.Option) (*pb.Session, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -135,7 +207,68 @@ func (s Service) cancelInRepo(ctx context.Context, id string, version uint32) (*
 	if err != nil {
 		return nil, fmt.Errorf("unable to update session: %w", err)
 	}
-	return toProto(out), nil
+	return toProto(out, opt...), nil
+}
+
+// terminateConnectionInRepo closes the connection in the repository and asks
+// the worker handling it to close it. Workers without a job changes stream to
+// this controller are asked with the response to their next status.
+func (s Service) terminateConnectionInRepo(ctx context.Context, id, connectionId string, opt ...handlers.Option) (*pb.Session, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	conn, states, err := repo.LookupConnection(ctx, connectionId)
+	if err != nil {
+		return nil, err
+	}
+	if conn == nil || conn.SessionId != id {
+		return nil, handlers.NotFoundErrorf("Connection %q doesn't exist in session %q.", connectionId, id)
+	}
+	if len(states) > 0 && states[0].Status == session.StatusClosed {
+		return nil, handlers.InvalidArgumentErrorf("Connection is already closed.", map[string]string{
+			"connection_id": "The connection is already closed.",
+		})
+	}
+	if _, err := repo.CloseConnections(ctx, []session.CloseWith{{
+		ConnectionId: connectionId,
+		BytesUp:      conn.BytesUp,
+		BytesDown:    conn.BytesDown,
+		ClosedReason: session.ConnectionCanceled,
+	}}); err != nil {
+		return nil, fmt.Errorf("unable to close connection: %w", err)
+	}
+	sess, _, err := repo.LookupSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sess == nil {
+		return nil, handlers.NotFoundErrorf("Session %q doesn't exist.", id)
+	}
+	if sess.ServerId != "" {
+		s.jobChanges.Send(sess.ServerId, common.CloseConnectionsRequest(id, connectionId))
+	}
+	return toProto(sess, opt...), nil
+}
+
+func (s Service) listRecordingsFromRepo(ctx context.Context, id, connectionId string) ([]*pb.SessionRecording, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	var opts []session.Option
+	if connectionId != "" {
+		opts = append(opts, session.WithConnectionId(connectionId))
+	}
+	recordings, err := repo.ListRecordings(ctx, id, append(opts, session.WithLimit(-1))...)
+	if err != nil {
+		return nil, err
+	}
+	var out []*pb.SessionRecording
+	for _, r := range recordings {
+		out = append(out, recordingToProto(r))
+	}
+	return out, nil
 }
 
 func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
@@ -160,7 +293,7 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 			res.Error = handlers.NotFoundError()
 			return res
 		}
-	case action.Read, action.Cancel:
+	case action.Read, action.Cancel, action.DownloadRecording, action.TerminateConnection:
 		repo, err := s.repoFn()
 		if err != nil {
 			res.Error = err
@@ -185,57 +318,108 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	return auth.Verify(ctx, opts...)
 }
 
-func toProto(in *session.Session) *pb.Session {
-	out := pb.Session{
-		Id:          in.GetPublicId(),
-		ScopeId:     in.ScopeId,
-		TargetId:    in.TargetId,
-		Version:     in.Version,
-		UserId:      in.UserId,
-		HostId:      in.HostId,
-		HostSetId:   in.HostSetId,
-		AuthTokenId: in.AuthTokenId,
-		Endpoint:    in.Endpoint,
-		Type:        target.SubtypeFromId(in.TargetId).String(),
-		// TODO: Provide the ServerType and the ServerId when that information becomes relevant in the API.
-
-		CreatedTime:       in.CreateTime.GetTimestamp(),
-		UpdatedTime:       in.UpdateTime.GetTimestamp(),
-		ExpirationTime:    in.ExpirationTime.GetTimestamp(),
-		Certificate:       in.Certificate,
-		TerminationReason: in.TerminationReason,
+func toProto(in *session.Session, opt ...handlers.Option) *pb.Session {
+	opts := handlers.GetOpts(opt...)
+	outputFields := opts.WithOutputFields
+	out := pb.Session{}
+	if outputFields.Has("id") {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has("scope_id") {
+		out.ScopeId = in.ScopeId
+	}
+	if outputFields.Has("scope") {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has("target_id") {
+		out.TargetId = in.TargetId
+	}
+	if outputFields.Has("version") {
+		out.Version = in.Version
+	}
+	if outputFields.Has("user_id") {
+		out.UserId = in.UserId
+	}
+	if outputFields.Has("host_id") {
+		out.HostId = in.HostId
+	}
+	if outputFields.Has("host_set_id") {
+		out.HostSetId = in.HostSetId
+	}
+	if outputFields.Has("auth_token_id") {
+		out.AuthTokenId = in.AuthTokenId
+	}
+	if outputFields.Has("endpoint") {
+		out.Endpoint = in.Endpoint
+	}
+	if outputFields.Has("type") {
+		out.Type = target.SubtypeFromId(in.TargetId).String()
+	}
+	// TODO: Provide the ServerType and the ServerId when that information becomes relevant in the API.
+	if outputFields.Has("created_time") {
+		out.CreatedTime = in.CreateTime.GetTimestamp()
+	}
+	if outputFields.Has("updated_time") {
+		out.UpdatedTime = in.UpdateTime.GetTimestamp()
+	}
+	if outputFields.Has("expiration_time") {
+		out.ExpirationTime = in.ExpirationTime.GetTimestamp()
+	}
+	if outputFields.Has("certificate") {
+		out.Certificate = in.Certificate
+	}
+	if outputFields.Has("termination_reason") {
+		out.TerminationReason = in.TerminationReason
+	}
+	if outputFields.Has("authorized_actions") {
+		out.AuthorizedActions = opts.WithAuthorizedActions
 	}
-	if len(in.States) > 0 {
+	if outputFields.Has("status") && len(in.States) > 0 {
 		out.Status = in.States[0].Status.String()
 	}
-	for _, s := range in.States {
-		sessState := &pb.SessionState{
-			Status: s.Status.String(),
+	if outputFields.Has("states") {
+		for _, s := range in.States {
+			sessState := &pb.SessionState{
+				Status: s.Status.String(),
+			}
+			if s.StartTime != nil {
+				sessState.StartTime = s.StartTime.GetTimestamp()
+			}
+			if s.EndTime != nil {
+				sessState.EndTime = s.EndTime.GetTimestamp()
+			}
+			out.States = append(out.States, sessState)
 		}
-		if s.StartTime != nil {
-			sessState.StartTime = s.StartTime.GetTimestamp()
-		}
-		if s.EndTime != nil {
-			sessState.EndTime = s.EndTime.GetTimestamp()
-		}
-		out.States = append(out.States, sessState)
 	}
 	return &out
 }
 
+func recordingToProto(in *session.Recording) *pb.SessionRecording {
+	return &pb.SessionRecording{
+		Id:           in.PublicId,
+		SessionId:    in.SessionId,
+		ConnectionId: in.ConnectionId,
+		ChannelId:    in.ChannelId,
+		StartTime:    in.StartTime.GetTimestamp(),
+		EndTime:      in.EndTime.GetTimestamp(),
+		Asciicast:    string(in.Data),
+	}
+}
+
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
-//  * The path passed in is correctly formatted
-//  * All required parameters are set
-//  * There are no conflicting parameters provided
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
 func validateGetRequest(req *pbs.GetSessionRequest) error {
 	return handlers.ValidateGetRequest(session.SessionPrefix, req, handlers.NoopValidatorFn)
 }
 
 func validateListRequest(req *pbs.ListSessionsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()) {
-		badFields["scope_id"] = "This field is required to have a properly formatted project scope id."
+	if !handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()) &&
+		!(req.GetRecursive() && (req.GetScopeId() == scope.Global.String() || handlers.ValidId(scope.Org.Prefix(), req.GetScopeId()))) {
+		badFields["scope_id"] = "This field is required to have a properly formatted project scope id, or 'global' or a valid org scope id when listing recursively."
 	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
@@ -256,3 +440,31 @@ func validateCancelRequest(req *pbs.CancelSessionRequest) error {
 	}
 	return nil
 }
+
+func validateTerminateConnectionRequest(req *pbs.TerminateSessionConnectionRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(session.SessionPrefix, req.GetId()) {
+		badFields["id"] = "Improperly formatted identifier."
+	}
+	if !handlers.ValidId(session.ConnectionPrefix, req.GetConnectionId()) {
+		badFields["connection_id"] = "Improperly formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
+
+func validateDownloadRecordingRequest(req *pbs.DownloadSessionRecordingRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(session.SessionPrefix, req.GetId()) {
+		badFields["id"] = "Improperly formatted identifier."
+	}
+	if req.GetConnectionId() != "" && !handlers.ValidId(session.ConnectionPrefix, req.GetConnectionId()) {
+		badFields["connection_id"] = "Improperly formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
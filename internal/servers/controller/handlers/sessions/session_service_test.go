@@ -1,7 +1,9 @@
 package sessions_test
 
 import (
+	"context"
 	"errors"
+	"sort"
 	"testing"
 	"time"
 
@@ -9,12 +11,14 @@ import (
 	"github.com/hashicorp/boundary/internal/auth"
 	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/sessions"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/sessions"
 	"github.com/hashicorp/boundary/internal/session"
@@ -24,8 +28,11 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+var testAuthorizedActions = []string{"read", "cancel", "download-recording", "terminate-connection"}
+
 func TestGetSession(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	wrap := db.TestWrapper(t)
@@ -64,23 +71,24 @@ func TestGetSession(t *testing.T) {
 	})
 
 	wireSess := &pb.Session{
-		Id:             sess.GetPublicId(),
-		ScopeId:        p.GetPublicId(),
-		AuthTokenId:    at.GetPublicId(),
-		Endpoint:       sess.Endpoint,
-		UserId:         at.GetIamUserId(),
-		TargetId:       sess.TargetId,
-		HostSetId:      sess.HostSetId,
-		HostId:         sess.HostId,
-		Version:        sess.Version,
-		Status:         session.StatusPending.String(),
-		UpdatedTime:    sess.UpdateTime.GetTimestamp(),
-		CreatedTime:    sess.CreateTime.GetTimestamp(),
-		ExpirationTime: sess.ExpirationTime.GetTimestamp(),
-		Scope:          &scopes.ScopeInfo{Id: p.GetPublicId(), Type: scope.Project.String()},
-		States:         []*pb.SessionState{{Status: session.StatusPending.String(), StartTime: sess.CreateTime.GetTimestamp()}},
-		Certificate:    sess.Certificate,
-		Type:           target.TcpSubType.String(),
+		Id:                sess.GetPublicId(),
+		ScopeId:           p.GetPublicId(),
+		AuthTokenId:       at.GetPublicId(),
+		Endpoint:          sess.Endpoint,
+		UserId:            at.GetIamUserId(),
+		TargetId:          sess.TargetId,
+		HostSetId:         sess.HostSetId,
+		HostId:            sess.HostId,
+		Version:           sess.Version,
+		Status:            session.StatusPending.String(),
+		UpdatedTime:       sess.UpdateTime.GetTimestamp(),
+		CreatedTime:       sess.CreateTime.GetTimestamp(),
+		ExpirationTime:    sess.ExpirationTime.GetTimestamp(),
+		Scope:             &scopes.ScopeInfo{Id: p.GetPublicId(), Type: scope.Project.String()},
+		States:            []*pb.SessionState{{Status: session.StatusPending.String(), StartTime: sess.CreateTime.GetTimestamp()}},
+		Certificate:       sess.Certificate,
+		Type:              target.TcpSubType.String(),
+		AuthorizedActions: testAuthorizedActions,
 	}
 
 	cases := []struct {
@@ -119,7 +127,7 @@ func TestGetSession(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
 
-			s, err := sessions.NewService(sessRepoFn, iamRepoFn)
+			s, err := sessions.NewService(sessRepoFn, iamRepoFn, common.NewWorkerJobChanges())
 			require.NoError(err, "Couldn't create new session service.")
 
 			got, gErr := s.GetSession(auth.DisabledAuthTestContext(auth.WithScopeId(tc.scopeId)), tc.req)
@@ -136,6 +144,69 @@ func TestGetSession(t *testing.T) {
 	}
 }
 
+func TestOutputFields(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+
+	iamRepo := iam.TestRepo(t, conn, wrap)
+
+	rw := db.New(conn)
+	sessRepo, err := session.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	sessRepoFn := func() (*session.Repository, error) {
+		return sessRepo, nil
+	}
+
+	o, p := iam.TestScopes(t, iamRepo)
+	at := authtoken.TestAuthToken(t, conn, kms, o.GetPublicId())
+	hc := static.TestCatalogs(t, conn, p.GetPublicId(), 1)[0]
+	hs := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
+	h := static.TestHosts(t, conn, hc.GetPublicId(), 1)[0]
+	static.TestSetMembers(t, conn, hs.GetPublicId(), []*static.Host{h})
+	tar := target.TestTcpTarget(t, conn, p.GetPublicId(), "test", target.WithHostSets([]string{hs.GetPublicId()}))
+
+	sess := session.TestSession(t, conn, wrap, session.ComposedOf{
+		UserId:      at.GetIamUserId(),
+		HostId:      h.GetPublicId(),
+		TargetId:    tar.GetPublicId(),
+		HostSetId:   hs.GetPublicId(),
+		AuthTokenId: at.GetPublicId(),
+		ScopeId:     p.GetPublicId(),
+		Endpoint:    "tcp://127.0.0.1:22",
+	})
+
+	r := iam.TestRole(t, conn, p.GetPublicId())
+	_ = iam.TestRoleGrant(t, conn, r.GetPublicId(), "id=*;type=session;actions=read,list;output_fields=id,target_id,user_id,status")
+	_ = iam.TestUserRole(t, conn, r.GetPublicId(), at.GetIamUserId())
+	ctx := auth.TestAuthContextFromToken(t, conn, kms, at)
+
+	// Fields left out of the grant, such as the endpoint, certificate and
+	// states, aren't returned
+	want := &pb.Session{
+		Id:       sess.GetPublicId(),
+		TargetId: tar.GetPublicId(),
+		UserId:   at.GetIamUserId(),
+		Status:   session.StatusPending.String(),
+	}
+
+	s, err := sessions.NewService(sessRepoFn, iamRepoFn, common.NewWorkerJobChanges())
+	require.NoError(t, err)
+
+	got, err := s.GetSession(ctx, &pbs.GetSessionRequest{Id: sess.GetPublicId()})
+	require.NoError(t, err)
+	assert.Empty(t, cmp.Diff(want, got.GetItem(), protocmp.Transform()))
+
+	list, err := s.ListSessions(ctx, &pbs.ListSessionsRequest{ScopeId: p.GetPublicId()})
+	require.NoError(t, err)
+	require.Len(t, list.GetItems(), 1)
+	assert.Empty(t, cmp.Diff(want, list.GetItems()[0], protocmp.Transform()))
+}
+
 func TestList(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	wrap := db.TestWrapper(t)
@@ -180,26 +251,30 @@ func TestList(t *testing.T) {
 		status, states := convertStates(sess.States)
 
 		wantSession = append(wantSession, &pb.Session{
-			Id:             sess.GetPublicId(),
-			ScopeId:        pWithSessions.GetPublicId(),
-			AuthTokenId:    at.GetPublicId(),
-			UserId:         at.GetIamUserId(),
-			TargetId:       sess.TargetId,
-			Endpoint:       sess.Endpoint,
-			HostSetId:      sess.HostSetId,
-			HostId:         sess.HostId,
-			Version:        sess.Version,
-			UpdatedTime:    sess.UpdateTime.GetTimestamp(),
-			CreatedTime:    sess.CreateTime.GetTimestamp(),
-			ExpirationTime: sess.ExpirationTime.GetTimestamp(),
-			Scope:          &scopes.ScopeInfo{Id: pWithSessions.GetPublicId(), Type: scope.Project.String()},
-			Status:         status,
-			States:         states,
-			Certificate:    sess.Certificate,
-			Type:           target.TcpSubType.String(),
+			Id:                sess.GetPublicId(),
+			ScopeId:           pWithSessions.GetPublicId(),
+			AuthTokenId:       at.GetPublicId(),
+			UserId:            at.GetIamUserId(),
+			TargetId:          sess.TargetId,
+			Endpoint:          sess.Endpoint,
+			HostSetId:         sess.HostSetId,
+			HostId:            sess.HostId,
+			Version:           sess.Version,
+			UpdatedTime:       sess.UpdateTime.GetTimestamp(),
+			CreatedTime:       sess.CreateTime.GetTimestamp(),
+			ExpirationTime:    sess.ExpirationTime.GetTimestamp(),
+			Scope:             &scopes.ScopeInfo{Id: pWithSessions.GetPublicId(), Type: scope.Project.String()},
+			Status:            status,
+			States:            states,
+			Certificate:       sess.Certificate,
+			Type:              target.TcpSubType.String(),
+			AuthorizedActions: testAuthorizedActions,
 		})
 	}
 
+	// Listed resources are ordered by id
+	sort.Slice(wantSession, func(i, j int) bool { return wantSession[i].GetId() < wantSession[j].GetId() })
+
 	cases := []struct {
 		name string
 		req  *pbs.ListSessionsRequest
@@ -219,7 +294,7 @@ func TestList(t *testing.T) {
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			s, err := sessions.NewService(sessRepoFn, iamRepoFn)
+			s, err := sessions.NewService(sessRepoFn, iamRepoFn, common.NewWorkerJobChanges())
 			require.NoError(t, err, "Couldn't create new session service.")
 
 			got, gErr := s.ListSessions(auth.DisabledAuthTestContext(auth.WithScopeId(tc.req.GetScopeId())), tc.req)
@@ -348,7 +423,7 @@ func TestCancel(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
 
-			s, err := sessions.NewService(sessRepoFn, iamRepoFn)
+			s, err := sessions.NewService(sessRepoFn, iamRepoFn, common.NewWorkerJobChanges())
 			require.NoError(err, "Couldn't create new session service.")
 
 			tc.req.Version = version
@@ -398,3 +473,223 @@ func TestCancel(t *testing.T) {
 		})
 	}
 }
+
+func TestDownloadRecording(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+
+	iamRepo := iam.TestRepo(t, conn, wrap)
+
+	rw := db.New(conn)
+	sessRepo, err := session.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	sessRepoFn := func() (*session.Repository, error) {
+		return sessRepo, nil
+	}
+
+	o, p := iam.TestScopes(t, iamRepo)
+	at := authtoken.TestAuthToken(t, conn, kms, o.GetPublicId())
+	uId := at.GetIamUserId()
+	hc := static.TestCatalogs(t, conn, p.GetPublicId(), 1)[0]
+	hs := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
+	h := static.TestHosts(t, conn, hc.GetPublicId(), 1)[0]
+	static.TestSetMembers(t, conn, hs.GetPublicId(), []*static.Host{h})
+	tar := target.TestSshTarget(t, conn, p.GetPublicId(), "test", target.WithHostSets([]string{hs.GetPublicId()}))
+
+	sess := session.TestSession(t, conn, wrap, session.ComposedOf{
+		UserId:      uId,
+		HostId:      h.GetPublicId(),
+		TargetId:    tar.GetPublicId(),
+		HostSetId:   hs.GetPublicId(),
+		AuthTokenId: at.GetPublicId(),
+		ScopeId:     p.GetPublicId(),
+		Endpoint:    "ssh://127.0.0.1:22",
+	})
+	c := session.TestConnection(t, conn, sess.GetPublicId(), "127.0.0.1", 22, "127.0.0.1", 2222)
+	start := time.Now().Add(-time.Minute).Truncate(time.Microsecond)
+	data := `{"version":2,"width":80,"height":24}` + "\n" + `[0.5,"i","ls\r"]` + "\n"
+	r, err := session.NewRecording(sess.GetPublicId(), c.GetPublicId(), 0,
+		&timestamp.Timestamp{Timestamp: timestamppb.New(start)},
+		&timestamp.Timestamp{Timestamp: timestamppb.New(start.Add(time.Second))},
+		[]byte(data))
+	require.NoError(t, err)
+	rec, err := sessRepo.CreateRecording(context.Background(), r)
+	require.NoError(t, err)
+
+	wireRec := &pb.SessionRecording{
+		Id:           rec.PublicId,
+		SessionId:    sess.GetPublicId(),
+		ConnectionId: c.GetPublicId(),
+		StartTime:    timestamppb.New(start),
+		EndTime:      timestamppb.New(start.Add(time.Second)),
+		Asciicast:    data,
+	}
+
+	cases := []struct {
+		name    string
+		scopeId string
+		req     *pbs.DownloadSessionRecordingRequest
+		res     *pbs.DownloadSessionRecordingResponse
+		err     error
+	}{
+		{
+			name:    "Download recordings",
+			scopeId: sess.ScopeId,
+			req:     &pbs.DownloadSessionRecordingRequest{Id: sess.GetPublicId()},
+			res:     &pbs.DownloadSessionRecordingResponse{Items: []*pb.SessionRecording{wireRec}},
+		},
+		{
+			name:    "Download recordings of a connection",
+			scopeId: sess.ScopeId,
+			req:     &pbs.DownloadSessionRecordingRequest{Id: sess.GetPublicId(), ConnectionId: c.GetPublicId()},
+			res:     &pbs.DownloadSessionRecordingResponse{Items: []*pb.SessionRecording{wireRec}},
+		},
+		{
+			name:    "Download recordings of another connection",
+			scopeId: sess.ScopeId,
+			req:     &pbs.DownloadSessionRecordingRequest{Id: sess.GetPublicId(), ConnectionId: session.ConnectionPrefix + "_1234567890"},
+			res:     &pbs.DownloadSessionRecordingResponse{},
+		},
+		{
+			name: "Download recordings of a non existing Session",
+			req:  &pbs.DownloadSessionRecordingRequest{Id: session.SessionPrefix + "_DoesntExis"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Wrong id prefix",
+			req:  &pbs.DownloadSessionRecordingRequest{Id: "j_1234567890"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Wrong connection id prefix",
+			req:  &pbs.DownloadSessionRecordingRequest{Id: sess.GetPublicId(), ConnectionId: "j_1234567890"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+
+			s, err := sessions.NewService(sessRepoFn, iamRepoFn, common.NewWorkerJobChanges())
+			require.NoError(err, "Couldn't create new session service.")
+
+			got, gErr := s.DownloadSessionRecording(auth.DisabledAuthTestContext(auth.WithScopeId(tc.scopeId)), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "DownloadSessionRecording(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform()), "DownloadSessionRecording(%q) got response\n%q, wanted\n%q", tc.req, got, tc.res)
+		})
+	}
+}
+
+func TestTerminateSessionConnection(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+
+	iamRepo := iam.TestRepo(t, conn, wrap)
+
+	rw := db.New(conn)
+	sessRepo, err := session.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	sessRepoFn := func() (*session.Repository, error) {
+		return sessRepo, nil
+	}
+
+	o, p := iam.TestScopes(t, iamRepo)
+	at := authtoken.TestAuthToken(t, conn, kms, o.GetPublicId())
+	uId := at.GetIamUserId()
+	hc := static.TestCatalogs(t, conn, p.GetPublicId(), 1)[0]
+	hs := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
+	h := static.TestHosts(t, conn, hc.GetPublicId(), 1)[0]
+	static.TestSetMembers(t, conn, hs.GetPublicId(), []*static.Host{h})
+	tar := target.TestTcpTarget(t, conn, p.GetPublicId(), "test", target.WithHostSets([]string{hs.GetPublicId()}))
+
+	composedOf := session.ComposedOf{
+		UserId:      uId,
+		HostId:      h.GetPublicId(),
+		TargetId:    tar.GetPublicId(),
+		HostSetId:   hs.GetPublicId(),
+		AuthTokenId: at.GetPublicId(),
+		ScopeId:     p.GetPublicId(),
+		Endpoint:    "tcp://127.0.0.1:22",
+	}
+	sess := session.TestSession(t, conn, wrap, composedOf)
+	otherSess := session.TestSession(t, conn, wrap, composedOf)
+	c := session.TestConnection(t, conn, sess.GetPublicId(), "127.0.0.1", 22, "127.0.0.1", 2222)
+	otherC := session.TestConnection(t, conn, otherSess.GetPublicId(), "127.0.0.1", 22, "127.0.0.1", 2222)
+
+	cases := []struct {
+		name    string
+		scopeId string
+		req     *pbs.TerminateSessionConnectionRequest
+		err     error
+	}{
+		{
+			name:    "Terminate a connection",
+			scopeId: sess.ScopeId,
+			req:     &pbs.TerminateSessionConnectionRequest{Id: sess.GetPublicId(), ConnectionId: c.GetPublicId()},
+		},
+		{
+			name:    "Terminate a closed connection",
+			scopeId: sess.ScopeId,
+			req:     &pbs.TerminateSessionConnectionRequest{Id: sess.GetPublicId(), ConnectionId: c.GetPublicId()},
+			err:     handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:    "Terminate a connection of another Session",
+			scopeId: sess.ScopeId,
+			req:     &pbs.TerminateSessionConnectionRequest{Id: sess.GetPublicId(), ConnectionId: otherC.GetPublicId()},
+			err:     handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Terminate a connection of a non existing Session",
+			req:  &pbs.TerminateSessionConnectionRequest{Id: session.SessionPrefix + "_DoesntExis", ConnectionId: c.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Wrong id prefix",
+			req:  &pbs.TerminateSessionConnectionRequest{Id: "j_1234567890", ConnectionId: c.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Wrong connection id prefix",
+			req:  &pbs.TerminateSessionConnectionRequest{Id: sess.GetPublicId(), ConnectionId: "j_1234567890"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+
+			s, err := sessions.NewService(sessRepoFn, iamRepoFn, common.NewWorkerJobChanges())
+			require.NoError(err, "Couldn't create new session service.")
+
+			got, gErr := s.TerminateSessionConnection(auth.DisabledAuthTestContext(auth.WithScopeId(tc.scopeId)), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "TerminateSessionConnection(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Equal(tc.req.GetId(), got.GetItem().GetId())
+
+			_, states, err := sessRepo.LookupConnection(context.Background(), tc.req.GetConnectionId())
+			require.NoError(err)
+			require.NotEmpty(states)
+			assert.Equal(session.StatusClosed, states[0].Status)
+		})
+	}
+}
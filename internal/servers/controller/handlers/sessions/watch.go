@@ -0,0 +1,217 @@
+package sessions
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/hashicorp/boundary/internal/auth"
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/sessions"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// WatchPath is the path of the endpoint streaming the events of sessions and
+// their connections. It is served outside of the gateway, which can't stream
+// responses of in-process services.
+const WatchPath = "/v1/sessions:watch"
+
+// maxWatchDuration bounds the length of a watch stream so that it ends within
+// the request timeouts of the API listeners. Clients reconnect to keep
+// watching, as documented with the endpoint in the API docs.
+const maxWatchDuration = 25 * time.Second
+
+var watchMarshaler = &runtime.JSONPb{
+	MarshalOptions: protojson.MarshalOptions{
+		UseProtoNames: true,
+	},
+}
+
+type watchHandler struct {
+	logger    hclog.Logger
+	repoFn    common.SessionRepoFactory
+	iamRepoFn common.IamRepoFactory
+	events    *common.SessionEvents
+}
+
+// NewWatchHandler returns the handler of WatchPath. For a GET request with a
+// scope_id query parameter, and optionally recursive=true, it streams the
+// events of the sessions of the scope, or of it and its descendants, as
+// newline-delimited JSON SessionConnectionEvent objects. The state changes of
+// the sessions and their connections are streamed whichever controller
+// recorded them, while status events are only streamed from the workers
+// reporting to this controller.
+func NewWatchHandler(logger hclog.Logger, repoFn common.SessionRepoFactory, iamRepoFn common.IamRepoFactory, events *common.SessionEvents) (http.Handler, error) {
+	if repoFn == nil {
+		return nil, fmt.Errorf("nil session repository provided")
+	}
+	if iamRepoFn == nil {
+		return nil, fmt.Errorf("nil iam repository provided")
+	}
+	if events == nil {
+		return nil, fmt.Errorf("nil session events provided")
+	}
+	return &watchHandler{
+		logger:    logger,
+		repoFn:    repoFn,
+		iamRepoFn: iamRepoFn,
+		events:    events,
+	}, nil
+}
+
+func (h *watchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		h.writeError(ctx, w, r, fmt.Errorf("response writer does not support streaming"))
+		return
+	}
+
+	scopeId := r.URL.Query().Get("scope_id")
+	var recursive bool
+	if v := r.URL.Query().Get("recursive"); v != "" {
+		var err error
+		if recursive, err = strconv.ParseBool(v); err != nil {
+			h.writeError(ctx, w, r, handlers.InvalidArgumentErrorf("Invalid query parameter.", map[string]string{
+				"recursive": "Must be a boolean.",
+			}))
+			return
+		}
+	}
+	if err := validateWatchRequest(scopeId, recursive); err != nil {
+		h.writeError(ctx, w, r, err)
+		return
+	}
+
+	iamRepo, err := h.iamRepoFn()
+	if err != nil {
+		h.writeError(ctx, w, r, err)
+		return
+	}
+	scp, err := iamRepo.LookupScope(ctx, scopeId)
+	if err != nil {
+		h.writeError(ctx, w, r, err)
+		return
+	}
+	if scp == nil {
+		h.writeError(ctx, w, r, handlers.NotFoundError())
+		return
+	}
+	authResults := auth.Verify(ctx, auth.WithType(resource.Session), auth.WithAction(action.Watch), auth.WithScopeId(scopeId))
+	scopeIds, _, err := authResults.ScopesForAction(ctx, resource.Session, action.Watch, recursive)
+	if err != nil {
+		h.writeError(ctx, w, r, err)
+		return
+	}
+	if len(scopeIds) == 0 {
+		h.writeError(ctx, w, r, handlers.ForbiddenError())
+		return
+	}
+	watched := make(map[string]bool, len(scopeIds))
+	for _, id := range scopeIds {
+		watched[id] = true
+	}
+
+	repo, err := h.repoFn()
+	if err != nil {
+		h.writeError(ctx, w, r, err)
+		return
+	}
+
+	events, stop := h.events.Watch()
+	defer stop()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	timer := time.NewTimer(maxWatchDuration)
+	defer timer.Stop()
+
+	// The sessions of the events seen so far, nil for those outside of the
+	// watched scopes
+	sessions := make(map[string]*session.Session)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			return
+		case e := <-events:
+			sess, ok := sessions[e.SessionId]
+			// The worker of a session is set when it's activated, so the
+			// session is looked up again with each of its states
+			if !ok || (sess != nil && e.ConnectionId == "") {
+				found, _, err := repo.LookupSession(ctx, e.SessionId)
+				if err != nil {
+					h.logger.Error("error looking up session of connection event", "session_id", e.SessionId, "error", err)
+					continue
+				}
+				if found != nil && watched[found.ScopeId] {
+					sess = found
+				}
+				sessions[e.SessionId] = sess
+			}
+			if sess == nil {
+				continue
+			}
+			buf, err := watchMarshaler.Marshal(eventToProto(e, sess))
+			if err != nil {
+				h.logger.Error("error marshaling connection event", "error", err)
+				return
+			}
+			if _, err := w.Write(append(buf, '\n')); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func (h *watchHandler) writeError(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) {
+	handlers.ErrorHandler(h.logger)(ctx, nil, watchMarshaler, w, r, err)
+}
+
+func eventToProto(e *common.ConnectionEvent, sess *session.Session) *pb.SessionConnectionEvent {
+	return &pb.SessionConnectionEvent{
+		Type:             string(e.Type),
+		Time:             timestamppb.New(e.Time),
+		SessionId:        e.SessionId,
+		ConnectionId:     e.ConnectionId,
+		ScopeId:          sess.ScopeId,
+		TargetId:         sess.TargetId,
+		UserId:           sess.UserId,
+		WorkerId:         sess.ServerId,
+		ClientTcpAddress: e.ClientTcpAddress,
+		ClientTcpPort:    e.ClientTcpPort,
+		BytesUp:          e.BytesUp,
+		BytesDown:        e.BytesDown,
+		ClosedReason:     e.ClosedReason,
+	}
+}
+
+func validateWatchRequest(scopeId string, recursive bool) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(scope.Project.Prefix(), scopeId) &&
+		!(recursive && (scopeId == scope.Global.String() || handlers.ValidId(scope.Org.Prefix(), scopeId))) {
+		badFields["scope_id"] = "This field is required to have a properly formatted project scope id, or 'global' or a valid org scope id when watching recursively."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
@@ -5,16 +5,20 @@ import (
 	stderrors "errors"
 	"fmt"
 	"math/rand"
+	"net/textproto"
 	"net/url"
 	"strings"
 
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/credential"
+	credstatic "github.com/hashicorp/boundary/internal/credential/static"
 	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/targets"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/host"
+	"github.com/hashicorp/boundary/internal/host/plugin"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/servers"
@@ -27,6 +31,7 @@ import (
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/boundary/sdk/strutil"
+	"github.com/hashicorp/go-bexpr"
 	"github.com/mr-tron/base58"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/proto"
@@ -35,7 +40,25 @@ import (
 )
 
 var (
-	maskManager handlers.MaskManager
+	maskManager     handlers.MaskManager
+	httpMaskManager handlers.MaskManager
+	sshMaskManager  handlers.MaskManager
+	udpMaskManager  handlers.MaskManager
+
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.Read,
+		action.Update,
+		action.Delete,
+		action.AddHostSets,
+		action.SetHostSets,
+		action.RemoveHostSets,
+		action.AddCredentials,
+		action.SetCredentials,
+		action.RemoveCredentials,
+		action.AuthorizeSession,
+	}
 )
 
 func init() {
@@ -43,6 +66,15 @@ func init() {
 	if maskManager, err = handlers.NewMaskManager(&store.TcpTarget{}, &pb.Target{}, &pb.TcpTargetAttributes{}); err != nil {
 		panic(err)
 	}
+	if httpMaskManager, err = handlers.NewMaskManager(&store.HttpTarget{}, &pb.Target{}, &pb.HttpTargetAttributes{}); err != nil {
+		panic(err)
+	}
+	if sshMaskManager, err = handlers.NewMaskManager(&store.SshTarget{}, &pb.Target{}, &pb.SshTargetAttributes{}); err != nil {
+		panic(err)
+	}
+	if udpMaskManager, err = handlers.NewMaskManager(&store.UdpTarget{}, &pb.Target{}, &pb.UdpTargetAttributes{}); err != nil {
+		panic(err)
+	}
 }
 
 // Service handles request as described by the pbs.TargetServiceServer interface.
@@ -54,7 +86,12 @@ type Service struct {
 	serversRepoFn    common.ServersRepoFactory
 	sessionRepoFn    common.SessionRepoFactory
 	staticHostRepoFn common.StaticRepoFactory
+	pluginHostRepoFn common.PluginHostRepoFactory
+	staticCredRepoFn common.StaticCredentialRepoFactory
 	kmsCache         *kms.Kms
+
+	// sessionAuthorized, if set, is called with each authorized session
+	sessionAuthorized common.SessionAuthorizedFunc
 }
 
 // NewService returns a target service which handles target related requests to boundary.
@@ -64,7 +101,10 @@ func NewService(
 	iamRepoFn common.IamRepoFactory,
 	serversRepoFn common.ServersRepoFactory,
 	sessionRepoFn common.SessionRepoFactory,
-	staticHostRepoFn common.StaticRepoFactory) (Service, error) {
+	staticHostRepoFn common.StaticRepoFactory,
+	pluginHostRepoFn common.PluginHostRepoFactory,
+	staticCredRepoFn common.StaticCredentialRepoFactory,
+	sessionAuthorized common.SessionAuthorizedFunc) (Service, error) {
 	if repoFn == nil {
 		return Service{}, fmt.Errorf("nil target repository provided")
 	}
@@ -80,13 +120,22 @@ func NewService(
 	if staticHostRepoFn == nil {
 		return Service{}, fmt.Errorf("nil static host repository provided")
 	}
+	if pluginHostRepoFn == nil {
+		return Service{}, fmt.Errorf("nil plugin host repository provided")
+	}
+	if staticCredRepoFn == nil {
+		return Service{}, fmt.Errorf("nil static credential repository provided")
+	}
 	return Service{
-		repoFn:           repoFn,
-		iamRepoFn:        iamRepoFn,
-		serversRepoFn:    serversRepoFn,
-		sessionRepoFn:    sessionRepoFn,
-		staticHostRepoFn: staticHostRepoFn,
-		kmsCache:         kmsCache,
+		repoFn:            repoFn,
+		iamRepoFn:         iamRepoFn,
+		serversRepoFn:     serversRepoFn,
+		sessionRepoFn:     sessionRepoFn,
+		staticHostRepoFn:  staticHostRepoFn,
+		pluginHostRepoFn:  pluginHostRepoFn,
+		staticCredRepoFn:  staticCredRepoFn,
+		kmsCache:          kmsCache,
+		sessionAuthorized: sessionAuthorized,
 	}, nil
 }
 
@@ -98,17 +147,49 @@ func (s Service) ListTargets(ctx context.Context, req *pbs.ListTargetsRequest) (
 		return nil, err
 	}
 	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
-	if authResults.Error != nil {
-		return nil, authResults.Error
+	scopeIds, scopeInfos, err := authResults.ScopesForList(ctx, resource.Target, req.GetRecursive())
+	if err != nil {
+		return nil, err
+	}
+	if len(scopeIds) == 0 {
+		return &pbs.ListTargetsResponse{}, nil
+	}
+	fetch := func(afterId string, limit int) ([]handlers.ListItem, error) {
+		tl, err := s.listFromRepo(ctx, scopeIds, afterId, limit)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]handlers.ListItem, 0, len(tl))
+		for _, t := range tl {
+			attrs, err := GrantAttributes(t)
+			if err != nil {
+				return nil, err
+			}
+			resOpts := []auth.Option{auth.WithScopeId(t.GetScopeId()), auth.WithAttributes(attrs)}
+			if authResults.DeniedForId(ctx, t.GetPublicId(), action.Read, resOpts...) {
+				items = append(items, handlers.HiddenListItem(t.GetPublicId()))
+				continue
+			}
+			item, err := toProto(t, nil, nil,
+				handlers.WithScope(scopeInfos[t.GetScopeId()]),
+				handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions, resOpts...).Strings()),
+				handlers.WithOutputFields(authResults.FetchOutputFields(ctx, t.GetPublicId(), resOpts...)))
+			if err != nil {
+				return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to convert value to proto: %v.", err)
+			}
+			items = append(items, handlers.ListedItem(t.GetPublicId(), item))
+		}
+		return items, nil
 	}
-	ul, err := s.listFromRepo(ctx, authResults.Scope.GetId())
+	items, next, err := handlers.ListPage(fetch, req.GetFilter(), req.GetPageSize(), req.GetPageToken())
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range ul {
-		item.Scope = authResults.Scope
+	var ul []*pb.Target
+	for _, item := range items {
+		ul = append(ul, item.(*pb.Target))
 	}
-	return &pbs.ListTargetsResponse{Items: ul}, nil
+	return &pbs.ListTargetsResponse{Items: ul, NextPageToken: next}, nil
 }
 
 // GetTargets implements the interface pbs.TargetServiceServer.
@@ -120,11 +201,12 @@ func (s Service) GetTarget(ctx context.Context, req *pbs.GetTargetRequest) (*pbs
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	u, err := s.getFromRepo(ctx, req.GetId())
+	opts := append(outputOpts(ctx, authResults, req.GetId()),
+		handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, req.GetId(), IdActions).Strings()))
+	u, err := s.getFromRepo(ctx, req.GetId(), opts...)
 	if err != nil {
 		return nil, err
 	}
-	u.Scope = authResults.Scope
 	return &pbs.GetTargetResponse{Item: u}, nil
 }
 
@@ -137,11 +219,10 @@ func (s Service) CreateTarget(ctx context.Context, req *pbs.CreateTargetRequest)
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	u, err := s.createInRepo(ctx, req.GetItem())
+	u, err := s.createInRepo(ctx, req.GetItem(), outputOpts(ctx, authResults, "")...)
 	if err != nil {
 		return nil, err
 	}
-	u.Scope = authResults.Scope
 	return &pbs.CreateTargetResponse{Item: u, Uri: fmt.Sprintf("targets/%s", u.GetId())}, nil
 }
 
@@ -154,11 +235,10 @@ func (s Service) UpdateTarget(ctx context.Context, req *pbs.UpdateTargetRequest)
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	u, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	u, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem(), outputOpts(ctx, authResults, req.GetId())...)
 	if err != nil {
 		return nil, err
 	}
-	u.Scope = authResults.Scope
 	return &pbs.UpdateTargetResponse{Item: u}, nil
 }
 
@@ -187,11 +267,10 @@ func (s Service) AddTargetHostSets(ctx context.Context, req *pbs.AddTargetHostSe
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	u, err := s.addInRepo(ctx, req.GetId(), req.GetHostSetIds(), req.GetVersion())
+	u, err := s.addInRepo(ctx, req.GetId(), req.GetHostSetIds(), req.GetVersion(), outputOpts(ctx, authResults, req.GetId())...)
 	if err != nil {
 		return nil, err
 	}
-	u.Scope = authResults.Scope
 	return &pbs.AddTargetHostSetsResponse{Item: u}, nil
 }
 
@@ -204,11 +283,10 @@ func (s Service) SetTargetHostSets(ctx context.Context, req *pbs.SetTargetHostSe
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	u, err := s.setInRepo(ctx, req.GetId(), req.GetHostSetIds(), req.GetVersion())
+	u, err := s.setInRepo(ctx, req.GetId(), req.GetHostSetIds(), req.GetVersion(), outputOpts(ctx, authResults, req.GetId())...)
 	if err != nil {
 		return nil, err
 	}
-	u.Scope = authResults.Scope
 	return &pbs.SetTargetHostSetsResponse{Item: u}, nil
 }
 
@@ -221,14 +299,61 @@ func (s Service) RemoveTargetHostSets(ctx context.Context, req *pbs.RemoveTarget
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	u, err := s.removeInRepo(ctx, req.GetId(), req.GetHostSetIds(), req.GetVersion())
+	u, err := s.removeInRepo(ctx, req.GetId(), req.GetHostSetIds(), req.GetVersion(), outputOpts(ctx, authResults, req.GetId())...)
 	if err != nil {
 		return nil, err
 	}
-	u.Scope = authResults.Scope
 	return &pbs.RemoveTargetHostSetsResponse{Item: u}, nil
 }
 
+// AddTargetCredentials implements the interface pbs.TargetServiceServer.
+func (s Service) AddTargetCredentials(ctx context.Context, req *pbs.AddTargetCredentialsRequest) (*pbs.AddTargetCredentialsResponse, error) {
+	if err := validateAddCredentialsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.AddCredentials)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	u, err := s.addCredentialsInRepo(ctx, req.GetId(), req.GetCredentialIds(), req.GetVersion(), outputOpts(ctx, authResults, req.GetId())...)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.AddTargetCredentialsResponse{Item: u}, nil
+}
+
+// SetTargetCredentials implements the interface pbs.TargetServiceServer.
+func (s Service) SetTargetCredentials(ctx context.Context, req *pbs.SetTargetCredentialsRequest) (*pbs.SetTargetCredentialsResponse, error) {
+	if err := validateSetCredentialsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.SetCredentials)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	u, err := s.setCredentialsInRepo(ctx, req.GetId(), req.GetCredentialIds(), req.GetVersion(), outputOpts(ctx, authResults, req.GetId())...)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.SetTargetCredentialsResponse{Item: u}, nil
+}
+
+// RemoveTargetCredentials implements the interface pbs.TargetServiceServer.
+func (s Service) RemoveTargetCredentials(ctx context.Context, req *pbs.RemoveTargetCredentialsRequest) (*pbs.RemoveTargetCredentialsResponse, error) {
+	if err := validateRemoveCredentialsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RemoveCredentials)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	u, err := s.removeCredentialsInRepo(ctx, req.GetId(), req.GetCredentialIds(), req.GetVersion(), outputOpts(ctx, authResults, req.GetId())...)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.RemoveTargetCredentialsResponse{Item: u}, nil
+}
+
 func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSessionRequest) (*pbs.AuthorizeSessionResponse, error) {
 	if err := validateAuthorizeSessionRequest(req); err != nil {
 		return nil, err
@@ -310,6 +435,10 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 	if err != nil {
 		return nil, err
 	}
+	pluginHostRepo, err := s.pluginHostRepoFn()
+	if err != nil {
+		return nil, err
+	}
 
 	hostIds := make([]compoundHost, 0, len(hostSets)*10)
 
@@ -330,6 +459,19 @@ HostSetIterationLoop:
 					break HostSetIterationLoop
 				}
 			}
+		case host.PluginSubtype:
+			hosts, err := pluginHostRepo.ListSetMembers(ctx, hsId)
+			if err != nil {
+				return nil, err
+			}
+			for _, host := range hosts {
+				compoundId := compoundHost{hostSetId: hsId, hostId: host.PublicId}
+				hostIds = append(hostIds, compoundId)
+				if host.PublicId == requestedId {
+					chosenId = &compoundId
+					break HostSetIterationLoop
+				}
+			}
 		}
 	}
 	if requestedId != "" && chosenId == nil {
@@ -364,6 +506,18 @@ HostSetIterationLoop:
 		if endpointHost == "" {
 			return nil, stderrors.New("host had empty address")
 		}
+	case host.PluginSubtype:
+		h, err := pluginHostRepo.LookupHost(ctx, chosenId.hostId)
+		if err != nil {
+			return nil, fmt.Errorf("error looking up host: %w", err)
+		}
+		if h == nil {
+			return nil, handlers.NotFoundErrorf("Host %q is no longer available.", chosenId.hostId)
+		}
+		endpointHost = h.Address
+		if endpointHost == "" {
+			return nil, stderrors.New("host had empty address")
+		}
 	}
 	if defaultPort != 0 {
 		endpointUrl.Host = fmt.Sprintf("%s:%d", endpointHost, defaultPort)
@@ -374,37 +528,42 @@ HostSetIterationLoop:
 	expTime := timestamppb.Now()
 	expTime.Seconds += int64(t.GetSessionMaxSeconds())
 	sessionComposition := session.ComposedOf{
-		UserId:          authResults.UserId,
-		HostId:          chosenId.hostId,
-		TargetId:        t.GetPublicId(),
-		HostSetId:       chosenId.hostSetId,
-		AuthTokenId:     authResults.AuthTokenId,
-		ScopeId:         authResults.Scope.Id,
-		Endpoint:        endpointUrl.String(),
-		ExpirationTime:  &timestamp.Timestamp{Timestamp: expTime},
-		ConnectionLimit: t.GetSessionConnectionLimit(),
+		UserId:             authResults.UserId,
+		HostId:             chosenId.hostId,
+		TargetId:           t.GetPublicId(),
+		HostSetId:          chosenId.hostSetId,
+		AuthTokenId:        authResults.AuthTokenId,
+		ScopeId:            authResults.Scope.Id,
+		Endpoint:           endpointUrl.String(),
+		ExpirationTime:     &timestamp.Timestamp{Timestamp: expTime},
+		ConnectionLimit:    t.GetSessionConnectionLimit(),
+		IdleTimeoutSeconds: t.GetSessionIdleTimeoutSeconds(),
 	}
 
-	sess, err := session.New(sessionComposition)
+	workers, err := selectWorkers(ctx, serversRepo, t.GetWorkerFilter())
 	if err != nil {
 		return nil, err
 	}
-	wrapper, err := s.kmsCache.GetWrapper(ctx, authResults.Scope.Id, kms.KeyPurposeSessions)
+
+	creds, err := s.sessionCredentials(ctx, repo, t)
 	if err != nil {
 		return nil, err
 	}
-	sess, privKey, err := sessionRepo.CreateSession(ctx, wrapper, sess)
+
+	sess, err := session.New(sessionComposition)
 	if err != nil {
 		return nil, err
 	}
-
-	var workers []*pb.WorkerInfo
-	servers, err := serversRepo.ListServers(ctx, servers.ServerTypeWorker)
+	wrapper, err := s.kmsCache.GetWrapper(ctx, authResults.Scope.Id, kms.KeyPurposeSessions)
 	if err != nil {
 		return nil, err
 	}
-	for _, v := range servers {
-		workers = append(workers, &pb.WorkerInfo{Address: v.Address})
+	sess, privKey, err := sessionRepo.CreateSession(ctx, wrapper, sess)
+	if err != nil {
+		return nil, err
+	}
+	if s.sessionAuthorized != nil {
+		s.sessionAuthorized(sess, t.GetWorkerFilter())
 	}
 
 	sad := &pb.SessionAuthorizationData{
@@ -418,6 +577,7 @@ HostSetIterationLoop:
 		HostId:          chosenId.hostId,
 		WorkerInfo:      workers,
 		ConnectionLimit: t.GetSessionConnectionLimit(),
+		Credentials:     creds,
 	}
 	marshaledSad, err := proto.Marshal(sad)
 	if err != nil {
@@ -435,11 +595,80 @@ HostSetIterationLoop:
 		UserId:             authResults.UserId,
 		HostId:             chosenId.hostId,
 		HostSetId:          chosenId.hostSetId,
+		Credentials:        creds,
 	}
 	return &pbs.AuthorizeSessionResponse{Item: ret}, nil
 }
 
-func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Target, error) {
+// sessionCredentials returns the credentials attached to the target t, with
+// their secrets, to be brokered to the client of a session.
+func (s Service) sessionCredentials(ctx context.Context, repo *target.Repository, t target.Target) ([]*pb.SessionCredential, error) {
+	tcs, err := repo.ListTargetCredentials(ctx, t.GetPublicId())
+	if err != nil {
+		return nil, err
+	}
+	if len(tcs) == 0 {
+		return nil, nil
+	}
+	ids := make([]string, 0, len(tcs))
+	for _, tc := range tcs {
+		ids = append(ids, tc.GetCredentialId())
+	}
+	credRepo, err := s.staticCredRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	cl, err := credRepo.ListCredentialsWithPasswords(ctx, t.GetScopeId(), ids)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving target credentials: %w", err)
+	}
+	creds := make([]*pb.SessionCredential, 0, len(cl))
+	for _, c := range cl {
+		creds = append(creds, &pb.SessionCredential{
+			CredentialId:      c.GetPublicId(),
+			CredentialStoreId: c.GetStoreId(),
+			Name:              c.GetName(),
+			Type:              credential.UsernamePasswordType,
+			Username:          c.GetUsername(),
+			Password:          c.GetPassword(),
+		})
+	}
+	return creds, nil
+}
+
+// selectWorkers returns the live workers that can handle sessions for a
+// target, honoring the target's worker filter if one is set. The filter is
+// evaluated against each worker's name and tags. A worker which is reached
+// through upstream workers is returned as the address of the worker at the
+// start of its chain, which forwards the session stream to it. Workers
+// without an address, such as reverse-connected workers which are not
+// currently connected, are skipped.
+func selectWorkers(ctx context.Context, serversRepo *servers.Repository, workerFilter string) ([]*pb.WorkerInfo, error) {
+	allWorkers, err := serversRepo.ListServers(ctx, servers.ServerTypeWorker)
+	if err != nil {
+		return nil, err
+	}
+	filtered, err := servers.FilterWorkers(allWorkers, workerFilter)
+	if err != nil {
+		return nil, err
+	}
+	var workers []*pb.WorkerInfo
+	seen := make(map[string]bool, len(filtered))
+	for _, v := range filtered {
+		ingress := servers.IngressWorker(allWorkers, v)
+		if ingress == nil || ingress.Address == "" || seen[ingress.Address] {
+			continue
+		}
+		seen[ingress.Address] = true
+		workers = append(workers, &pb.WorkerInfo{Address: ingress.Address})
+	}
+	if workerFilter != "" && len(workers) == 0 {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "No workers are available to handle this session, or all have been filtered.")
+	}
+	return workers, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string, opt ...handlers.Option) (*pb.Target, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -454,10 +683,14 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Target, error)
 	if u == nil {
 		return nil, handlers.NotFoundErrorf("Target %q doesn't exist.", id)
 	}
-	return toProto(u, m)
+	creds, err := repo.ListTargetCredentials(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toProto(u, m, creds, opt...)
 }
 
-func (s Service) createInRepo(ctx context.Context, item *pb.Target) (*pb.Target, error) {
+func (s Service) createInRepo(ctx context.Context, item *pb.Target, opt ...handlers.Option) (*pb.Target, error) {
 	opts := []target.Option{target.WithName(item.GetName().GetValue())}
 	if item.GetDescription() != nil {
 		opts = append(opts, target.WithDescription(item.GetDescription().GetValue()))
@@ -468,32 +701,103 @@ func (s Service) createInRepo(ctx context.Context, item *pb.Target) (*pb.Target,
 	if item.GetSessionConnectionLimit() != nil {
 		opts = append(opts, target.WithSessionConnectionLimit(item.GetSessionConnectionLimit().GetValue()))
 	}
-	tcpAttrs := &pb.TcpTargetAttributes{}
-	if err := handlers.StructToProto(item.GetAttributes(), tcpAttrs); err != nil {
-		return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
-	}
-	if tcpAttrs.GetDefaultPort().GetValue() != 0 {
-		opts = append(opts, target.WithDefaultPort(tcpAttrs.GetDefaultPort().GetValue()))
+	if item.GetWorkerFilter() != nil {
+		opts = append(opts, target.WithWorkerFilter(item.GetWorkerFilter().GetValue()))
 	}
-	u, err := target.NewTcpTarget(item.GetScopeId(), opts...)
-	if err != nil {
-		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for creation: %v.", err)
+	if item.GetSessionIdleTimeoutSeconds() != nil {
+		opts = append(opts, target.WithSessionIdleTimeoutSeconds(item.GetSessionIdleTimeoutSeconds().GetValue()))
 	}
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	out, m, err := repo.CreateTcpTarget(ctx, u)
-	if err != nil {
-		return nil, fmt.Errorf("unable to create target: %w", err)
+	var out target.Target
+	var m []*target.TargetSet
+	switch target.SubtypeFromType(item.GetType()) {
+	case target.HttpSubType:
+		httpAttrs := &pb.HttpTargetAttributes{}
+		if err := handlers.StructToProto(item.GetAttributes(), httpAttrs); err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
+		}
+		if httpAttrs.GetDefaultPort().GetValue() != 0 {
+			opts = append(opts, target.WithDefaultPort(httpAttrs.GetDefaultPort().GetValue()))
+		}
+		if len(httpAttrs.GetHeaders()) > 0 {
+			opts = append(opts, target.WithHeaders(httpAttrs.GetHeaders()))
+		}
+		u, err := target.NewHttpTarget(item.GetScopeId(), opts...)
+		if err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for creation: %v.", err)
+		}
+		out, m, err = repo.CreateHttpTarget(ctx, u)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create target: %w", err)
+		}
+	case target.SshSubType:
+		sshAttrs := &pb.SshTargetAttributes{}
+		if err := handlers.StructToProto(item.GetAttributes(), sshAttrs); err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
+		}
+		if sshAttrs.GetDefaultPort().GetValue() != 0 {
+			opts = append(opts, target.WithDefaultPort(sshAttrs.GetDefaultPort().GetValue()))
+		}
+		if sshAttrs.GetHostKeys() != nil {
+			opts = append(opts, target.WithHostKeys(sshAttrs.GetHostKeys().GetValue()))
+		}
+		if sshAttrs.GetHostCaKeys() != nil {
+			opts = append(opts, target.WithHostCaKeys(sshAttrs.GetHostCaKeys().GetValue()))
+		}
+		if sshAttrs.GetInsecureIgnoreHostKey().GetValue() {
+			opts = append(opts, target.WithInsecureIgnoreHostKey(true))
+		}
+		u, err := target.NewSshTarget(item.GetScopeId(), opts...)
+		if err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for creation: %v.", err)
+		}
+		out, m, err = repo.CreateSshTarget(ctx, u)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create target: %w", err)
+		}
+	case target.UdpSubType:
+		udpAttrs := &pb.UdpTargetAttributes{}
+		if err := handlers.StructToProto(item.GetAttributes(), udpAttrs); err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
+		}
+		if udpAttrs.GetDefaultPort().GetValue() != 0 {
+			opts = append(opts, target.WithDefaultPort(udpAttrs.GetDefaultPort().GetValue()))
+		}
+		u, err := target.NewUdpTarget(item.GetScopeId(), opts...)
+		if err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for creation: %v.", err)
+		}
+		out, m, err = repo.CreateUdpTarget(ctx, u)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create target: %w", err)
+		}
+	default:
+		tcpAttrs := &pb.TcpTargetAttributes{}
+		if err := handlers.StructToProto(item.GetAttributes(), tcpAttrs); err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
+		}
+		if tcpAttrs.GetDefaultPort().GetValue() != 0 {
+			opts = append(opts, target.WithDefaultPort(tcpAttrs.GetDefaultPort().GetValue()))
+		}
+		u, err := target.NewTcpTarget(item.GetScopeId(), opts...)
+		if err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for creation: %v.", err)
+		}
+		out, m, err = repo.CreateTcpTarget(ctx, u)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create target: %w", err)
+		}
 	}
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create target but no error returned from repository.")
 	}
-	return toProto(out, m)
+	return toProto(out, m, nil, opt...)
 }
 
-func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.Target) (*pb.Target, error) {
+func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.Target, opt ...handlers.Option) (*pb.Target, error) {
 	var opts []target.Option
 	if desc := item.GetDescription(); desc != nil {
 		opts = append(opts, target.WithDescription(desc.GetValue()))
@@ -507,35 +811,126 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	if item.GetSessionConnectionLimit() != nil {
 		opts = append(opts, target.WithSessionConnectionLimit(item.GetSessionConnectionLimit().GetValue()))
 	}
-	tcpAttrs := &pb.TcpTargetAttributes{}
-	if err := handlers.StructToProto(item.GetAttributes(), tcpAttrs); err != nil {
-		return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
+	if item.GetWorkerFilter() != nil {
+		opts = append(opts, target.WithWorkerFilter(item.GetWorkerFilter().GetValue()))
 	}
-	if tcpAttrs.GetDefaultPort().GetValue() != 0 {
-		opts = append(opts, target.WithDefaultPort(tcpAttrs.GetDefaultPort().GetValue()))
+	if item.GetSessionIdleTimeoutSeconds() != nil {
+		opts = append(opts, target.WithSessionIdleTimeoutSeconds(item.GetSessionIdleTimeoutSeconds().GetValue()))
 	}
 	version := item.GetVersion()
-	u, err := target.NewTcpTarget(scopeId, opts...)
-	if err != nil {
-		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for update: %v.", err)
-	}
-	u.PublicId = id
-	dbMask := maskManager.Translate(mask)
-	if len(dbMask) == 0 {
-		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid paths provided in the update mask."})
-	}
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	out, m, rowsUpdated, err := repo.UpdateTcpTarget(ctx, u, version, dbMask)
-	if err != nil {
-		return nil, fmt.Errorf("unable to update target: %w", err)
+	var out target.Target
+	var m []*target.TargetSet
+	var rowsUpdated int
+	switch target.SubtypeFromId(id) {
+	case target.HttpSubType:
+		httpAttrs := &pb.HttpTargetAttributes{}
+		if err := handlers.StructToProto(item.GetAttributes(), httpAttrs); err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
+		}
+		if httpAttrs.GetDefaultPort().GetValue() != 0 {
+			opts = append(opts, target.WithDefaultPort(httpAttrs.GetDefaultPort().GetValue()))
+		}
+		if len(httpAttrs.GetHeaders()) > 0 {
+			opts = append(opts, target.WithHeaders(httpAttrs.GetHeaders()))
+		}
+		u, err := target.NewHttpTarget(scopeId, opts...)
+		if err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for update: %v.", err)
+		}
+		u.PublicId = id
+		dbMask := httpMaskManager.Translate(mask)
+		if len(dbMask) == 0 {
+			return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid paths provided in the update mask."})
+		}
+		out, m, rowsUpdated, err = repo.UpdateHttpTarget(ctx, u, version, dbMask)
+		if err != nil {
+			return nil, fmt.Errorf("unable to update target: %w", err)
+		}
+	case target.SshSubType:
+		sshAttrs := &pb.SshTargetAttributes{}
+		if err := handlers.StructToProto(item.GetAttributes(), sshAttrs); err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
+		}
+		if sshAttrs.GetDefaultPort().GetValue() != 0 {
+			opts = append(opts, target.WithDefaultPort(sshAttrs.GetDefaultPort().GetValue()))
+		}
+		if sshAttrs.GetHostKeys() != nil {
+			opts = append(opts, target.WithHostKeys(sshAttrs.GetHostKeys().GetValue()))
+		}
+		if sshAttrs.GetHostCaKeys() != nil {
+			opts = append(opts, target.WithHostCaKeys(sshAttrs.GetHostCaKeys().GetValue()))
+		}
+		if sshAttrs.GetInsecureIgnoreHostKey().GetValue() {
+			opts = append(opts, target.WithInsecureIgnoreHostKey(true))
+		}
+		u, err := target.NewSshTarget(scopeId, opts...)
+		if err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for update: %v.", err)
+		}
+		u.PublicId = id
+		dbMask := sshMaskManager.Translate(mask)
+		if len(dbMask) == 0 {
+			return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid paths provided in the update mask."})
+		}
+		out, m, rowsUpdated, err = repo.UpdateSshTarget(ctx, u, version, dbMask)
+		if err != nil {
+			return nil, fmt.Errorf("unable to update target: %w", err)
+		}
+	case target.UdpSubType:
+		udpAttrs := &pb.UdpTargetAttributes{}
+		if err := handlers.StructToProto(item.GetAttributes(), udpAttrs); err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
+		}
+		if udpAttrs.GetDefaultPort().GetValue() != 0 {
+			opts = append(opts, target.WithDefaultPort(udpAttrs.GetDefaultPort().GetValue()))
+		}
+		u, err := target.NewUdpTarget(scopeId, opts...)
+		if err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for update: %v.", err)
+		}
+		u.PublicId = id
+		dbMask := udpMaskManager.Translate(mask)
+		if len(dbMask) == 0 {
+			return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid paths provided in the update mask."})
+		}
+		out, m, rowsUpdated, err = repo.UpdateUdpTarget(ctx, u, version, dbMask)
+		if err != nil {
+			return nil, fmt.Errorf("unable to update target: %w", err)
+		}
+	default:
+		tcpAttrs := &pb.TcpTargetAttributes{}
+		if err := handlers.StructToProto(item.GetAttributes(), tcpAttrs); err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
+		}
+		if tcpAttrs.GetDefaultPort().GetValue() != 0 {
+			opts = append(opts, target.WithDefaultPort(tcpAttrs.GetDefaultPort().GetValue()))
+		}
+		u, err := target.NewTcpTarget(scopeId, opts...)
+		if err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for update: %v.", err)
+		}
+		u.PublicId = id
+		dbMask := maskManager.Translate(mask)
+		if len(dbMask) == 0 {
+			return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid paths provided in the update mask."})
+		}
+		out, m, rowsUpdated, err = repo.UpdateTcpTarget(ctx, u, version, dbMask)
+		if err != nil {
+			return nil, fmt.Errorf("unable to update target: %w", err)
+		}
 	}
 	if rowsUpdated == 0 {
 		return nil, handlers.NotFoundErrorf("Target %q not found or incorrect version provided.", id)
 	}
-	return toProto(out, m)
+	creds, err := repo.ListTargetCredentials(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toProto(out, m, creds, opt...)
 }
 
 func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
@@ -553,27 +948,19 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeId string) ([]*pb.Target, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, afterId string, limit int) ([]target.Target, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	ul, err := repo.ListTargets(ctx, target.WithScopeId(scopeId))
+	ul, err := repo.ListTargets(ctx, target.WithScopeIds(scopeIds...), target.WithStartPageAfterId(afterId), target.WithLimit(limit))
 	if err != nil {
 		return nil, err
 	}
-	var outUl []*pb.Target
-	for _, u := range ul {
-		o, err := toProto(u, nil)
-		if err != nil {
-			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to convert value to proto: %v.", err)
-		}
-		outUl = append(outUl, o)
-	}
-	return outUl, nil
+	return ul, nil
 }
 
-func (s Service) addInRepo(ctx context.Context, targetId string, hostSetId []string, version uint32) (*pb.Target, error) {
+func (s Service) addInRepo(ctx context.Context, targetId string, hostSetId []string, version uint32, opt ...handlers.Option) (*pb.Target, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -586,10 +973,14 @@ func (s Service) addInRepo(ctx context.Context, targetId string, hostSetId []str
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup target after adding host sets to it.")
 	}
-	return toProto(out, m)
+	creds, err := repo.ListTargetCredentials(ctx, targetId)
+	if err != nil {
+		return nil, err
+	}
+	return toProto(out, m, creds, opt...)
 }
 
-func (s Service) setInRepo(ctx context.Context, targetId string, hostSetIds []string, version uint32) (*pb.Target, error) {
+func (s Service) setInRepo(ctx context.Context, targetId string, hostSetIds []string, version uint32, opt ...handlers.Option) (*pb.Target, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -607,10 +998,14 @@ func (s Service) setInRepo(ctx context.Context, targetId string, hostSetIds []st
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup target after setting host sets for it.")
 	}
-	return toProto(out, m)
+	creds, err := repo.ListTargetCredentials(ctx, targetId)
+	if err != nil {
+		return nil, err
+	}
+	return toProto(out, m, creds, opt...)
 }
 
-func (s Service) removeInRepo(ctx context.Context, targetId string, hostSetIds []string, version uint32) (*pb.Target, error) {
+func (s Service) removeInRepo(ctx context.Context, targetId string, hostSetIds []string, version uint32, opt ...handlers.Option) (*pb.Target, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -627,7 +1022,85 @@ func (s Service) removeInRepo(ctx context.Context, targetId string, hostSetIds [
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup target after removing host sets from it.")
 	}
-	return toProto(out, m)
+	creds, err := repo.ListTargetCredentials(ctx, targetId)
+	if err != nil {
+		return nil, err
+	}
+	return toProto(out, m, creds, opt...)
+}
+
+func (s Service) addCredentialsInRepo(ctx context.Context, targetId string, credentialIds []string, version uint32, opt ...handlers.Option) (*pb.Target, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	_, creds, err := repo.AddTargetCredentials(ctx, targetId, version, strutil.RemoveDuplicates(credentialIds, false))
+	if err != nil {
+		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to add credentials to target: %v.", err)
+	}
+	out, m, err := repo.LookupTarget(ctx, targetId)
+	if err != nil {
+		return nil, fmt.Errorf("unable to look up target after adding credentials: %w", err)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup target after adding credentials to it.")
+	}
+	return toProto(out, m, creds, opt...)
+}
+
+func (s Service) setCredentialsInRepo(ctx context.Context, targetId string, credentialIds []string, version uint32, opt ...handlers.Option) (*pb.Target, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	creds, _, err := repo.SetTargetCredentials(ctx, targetId, version, strutil.RemoveDuplicates(credentialIds, false))
+	if err != nil {
+		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to set credentials in target: %v.", err)
+	}
+	out, m, err := repo.LookupTarget(ctx, targetId)
+	if err != nil {
+		return nil, fmt.Errorf("unable to look up target after setting credentials: %w", err)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup target after setting credentials for it.")
+	}
+	return toProto(out, m, creds, opt...)
+}
+
+func (s Service) removeCredentialsInRepo(ctx context.Context, targetId string, credentialIds []string, version uint32, opt ...handlers.Option) (*pb.Target, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	_, err = repo.DeleteTargetCredentials(ctx, targetId, version, strutil.RemoveDuplicates(credentialIds, false))
+	if err != nil {
+		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to remove credentials from target: %v.", err)
+	}
+	out, m, err := repo.LookupTarget(ctx, targetId)
+	if err != nil {
+		return nil, fmt.Errorf("unable to look up target after removing credentials: %w", err)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup target after removing credentials from it.")
+	}
+	creds, err := repo.ListTargetCredentials(ctx, targetId)
+	if err != nil {
+		return nil, err
+	}
+	return toProto(out, m, creds, opt...)
+}
+
+// outputOpts returns the options for converting the target with the given id,
+// or a target being created if it's empty, to its API representation. Only the
+// fields the user's grants allow are output.
+func outputOpts(ctx context.Context, authResults auth.VerifyResults, id string) []handlers.Option {
+	return []handlers.Option{
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, id)),
+	}
 }
 
 func (s Service) authResult(ctx context.Context, id string, a action.Type, lookupOpt ...target.Option) auth.VerifyResults {
@@ -676,6 +1149,13 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type, looku
 		id = t.GetPublicId()
 		parentId = t.GetScopeId()
 		opts = append(opts, auth.WithId(id))
+
+		attrs, err := GrantAttributes(t)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		opts = append(opts, auth.WithAttributes(attrs))
 	}
 	opts = append(opts, auth.WithScopeId(parentId))
 	ret := auth.Verify(ctx, opts...)
@@ -683,49 +1163,208 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type, looku
 	return ret
 }
 
-func toProto(in target.Target, m []*target.TargetSet) (*pb.Target, error) {
-	out := pb.Target{
-		Id:                     in.GetPublicId(),
-		ScopeId:                in.GetScopeId(),
-		CreatedTime:            in.GetCreateTime().GetTimestamp(),
-		UpdatedTime:            in.GetUpdateTime().GetTimestamp(),
-		Version:                in.GetVersion(),
-		Type:                   target.TcpTargetType.String(),
-		SessionMaxSeconds:      wrapperspb.UInt32(in.GetSessionMaxSeconds()),
-		SessionConnectionLimit: wrapperspb.Int32(in.GetSessionConnectionLimit()),
+// GrantAttributes returns the attributes of the target that grant filters are
+// evaluated against: every field of the target as it is listed.
+func GrantAttributes(t target.Target) (map[string]interface{}, error) {
+	pbTarget, err := toProto(t, nil, nil)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to convert value to proto: %v.", err)
+	}
+	attrs, err := handlers.FilterItem(pbTarget)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to evaluate target attributes: %v.", err)
+	}
+	return attrs, nil
+}
+
+func toProto(in target.Target, m []*target.TargetSet, creds []*target.TargetCredential, opt ...handlers.Option) (*pb.Target, error) {
+	opts := handlers.GetOpts(opt...)
+	outputFields := opts.WithOutputFields
+	out := pb.Target{}
+	if outputFields.Has("id") {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has("scope_id") {
+		out.ScopeId = in.GetScopeId()
+	}
+	if outputFields.Has("scope") {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has("created_time") {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
+	}
+	if outputFields.Has("updated_time") {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has("version") {
+		out.Version = in.GetVersion()
 	}
-	if in.GetDescription() != "" {
+	if outputFields.Has("type") {
+		out.Type = in.GetType()
+	}
+	if outputFields.Has("session_max_seconds") {
+		out.SessionMaxSeconds = wrapperspb.UInt32(in.GetSessionMaxSeconds())
+	}
+	if outputFields.Has("session_connection_limit") {
+		out.SessionConnectionLimit = wrapperspb.Int32(in.GetSessionConnectionLimit())
+	}
+	if outputFields.Has("description") && in.GetDescription() != "" {
 		out.Description = wrapperspb.String(in.GetDescription())
 	}
-	if in.GetName() != "" {
+	if outputFields.Has("name") && in.GetName() != "" {
 		out.Name = wrapperspb.String(in.GetName())
 	}
-	attrs := &pb.TcpTargetAttributes{}
-	if in.GetDefaultPort() > 0 {
-		attrs.DefaultPort = &wrappers.UInt32Value{Value: in.GetDefaultPort()}
+	if outputFields.Has("worker_filter") && in.GetWorkerFilter() != "" {
+		out.WorkerFilter = wrapperspb.String(in.GetWorkerFilter())
 	}
-	st, err := handlers.ProtoToStruct(attrs)
-	if err != nil {
-		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "failed building password attribute struct: %v", err)
+	if outputFields.Has("session_idle_timeout_seconds") && in.GetSessionIdleTimeoutSeconds() != 0 {
+		out.SessionIdleTimeoutSeconds = wrapperspb.UInt32(in.GetSessionIdleTimeoutSeconds())
+	}
+	if outputFields.Has("authorized_actions") {
+		out.AuthorizedActions = opts.WithAuthorizedActions
+	}
+	if outputFields.Has("attributes") {
+		var attrs proto.Message
+		switch t := in.(type) {
+		case *target.HttpTarget:
+			httpAttrs := &pb.HttpTargetAttributes{
+				HeaderHmacs: t.GetHeaderHmacs(),
+			}
+			if in.GetDefaultPort() > 0 {
+				httpAttrs.DefaultPort = &wrappers.UInt32Value{Value: in.GetDefaultPort()}
+			}
+			attrs = httpAttrs
+		case *target.SshTarget:
+			sshAttrs := &pb.SshTargetAttributes{}
+			if in.GetDefaultPort() > 0 {
+				sshAttrs.DefaultPort = &wrappers.UInt32Value{Value: in.GetDefaultPort()}
+			}
+			if t.GetHostKeys() != "" {
+				sshAttrs.HostKeys = wrapperspb.String(t.GetHostKeys())
+			}
+			if t.GetHostCaKeys() != "" {
+				sshAttrs.HostCaKeys = wrapperspb.String(t.GetHostCaKeys())
+			}
+			if t.GetInsecureIgnoreHostKey() {
+				sshAttrs.InsecureIgnoreHostKey = wrapperspb.Bool(true)
+			}
+			attrs = sshAttrs
+		case *target.UdpTarget:
+			udpAttrs := &pb.UdpTargetAttributes{}
+			if in.GetDefaultPort() > 0 {
+				udpAttrs.DefaultPort = &wrappers.UInt32Value{Value: in.GetDefaultPort()}
+			}
+			attrs = udpAttrs
+		default:
+			tcpAttrs := &pb.TcpTargetAttributes{}
+			if in.GetDefaultPort() > 0 {
+				tcpAttrs.DefaultPort = &wrappers.UInt32Value{Value: in.GetDefaultPort()}
+			}
+			attrs = tcpAttrs
+		}
+		st, err := handlers.ProtoToStruct(attrs)
+		if err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "failed building target attribute struct: %v", err)
+		}
+		out.Attributes = st
 	}
-	out.Attributes = st
 	for _, hs := range m {
-		out.HostSetIds = append(out.HostSetIds, hs.GetPublicId())
-		out.HostSets = append(out.HostSets, &pb.HostSet{
-			Id:            hs.GetPublicId(),
-			HostCatalogId: hs.GetCatalogId(),
-		})
+		if outputFields.Has("host_set_ids") {
+			out.HostSetIds = append(out.HostSetIds, hs.GetPublicId())
+		}
+		if outputFields.Has("host_sets") {
+			out.HostSets = append(out.HostSets, &pb.HostSet{
+				Id:            hs.GetPublicId(),
+				HostCatalogId: hs.GetCatalogId(),
+			})
+		}
+	}
+	if outputFields.Has("credential_ids") {
+		for _, c := range creds {
+			out.CredentialIds = append(out.CredentialIds, c.GetCredentialId())
+		}
 	}
 	return &out, nil
 }
 
+// targetPrefix returns the id prefix for the subtype of the target id.
+func targetPrefix(id string) string {
+	switch target.SubtypeFromId(id) {
+	case target.HttpSubType:
+		return target.HttpTargetPrefix
+	case target.SshSubType:
+		return target.SshTargetPrefix
+	case target.UdpSubType:
+		return target.UdpTargetPrefix
+	}
+	return target.TcpTargetPrefix
+}
+
+// validateHeaders checks that the names and values of the headers of an http
+// target can be sent in an HTTP/1.1 request. Names may not repeat, ignoring
+// case, and the Host header is set by the worker.
+func validateHeaders(headers map[string]string, badFields map[string]string) {
+	seen := make(map[string]bool, len(headers))
+	for name, value := range headers {
+		canonical := textproto.CanonicalMIMEHeaderKey(name)
+		switch {
+		case !validHeaderName(name):
+			badFields["attributes.headers"] = fmt.Sprintf("Invalid header name %q.", name)
+		case canonical == "Host":
+			badFields["attributes.headers"] = "The Host header cannot be set."
+		case seen[canonical]:
+			badFields["attributes.headers"] = fmt.Sprintf("Header %q is set more than once.", canonical)
+		case strings.ContainsAny(value, "\r\n\x00"):
+			badFields["attributes.headers"] = fmt.Sprintf("Invalid value for header %q.", name)
+		}
+		seen[canonical] = true
+	}
+}
+
+// validateSshHostKeys checks that the host keys and host CA keys of an ssh
+// target, when set, hold at least one public key and nothing that fails to
+// parse.
+func validateSshHostKeys(attrs *pb.SshTargetAttributes, badFields map[string]string) {
+	for field, keys := range map[string]*wrapperspb.StringValue{
+		"attributes.host_keys":    attrs.GetHostKeys(),
+		"attributes.host_ca_keys": attrs.GetHostCaKeys(),
+	} {
+		if keys == nil {
+			continue
+		}
+		parsed, err := target.ParseSshHostKeys(keys.GetValue())
+		switch {
+		case err != nil:
+			badFields[field] = fmt.Sprintf("Unable to parse public keys: %v.", err)
+		case len(parsed) == 0:
+			badFields[field] = "This optional field must contain at least one public key."
+		}
+	}
+}
+
+// validHeaderName reports whether name is a token as defined by RFC 7230.
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case strings.ContainsRune("!#$%&'*+-.^_`|~", r):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
-//  * The path passed in is correctly formatted
-//  * All required parameters are set
-//  * There are no conflicting parameters provided
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
 func validateGetRequest(req *pbs.GetTargetRequest) error {
-	return handlers.ValidateGetRequest(target.TcpTargetPrefix, req, handlers.NoopValidatorFn)
+	return handlers.ValidateGetRequest(targetPrefix(req.GetId()), req, handlers.NoopValidatorFn)
 }
 
 func validateCreateRequest(req *pbs.CreateTargetRequest) error {
@@ -749,6 +1388,11 @@ func validateCreateRequest(req *pbs.CreateTargetRequest) error {
 		if req.GetItem().GetSessionMaxSeconds() != nil && req.GetItem().GetSessionMaxSeconds().GetValue() == 0 {
 			badFields["session_max_seconds"] = "This must be greater than zero."
 		}
+		if filter := req.GetItem().GetWorkerFilter(); filter != nil {
+			if _, err := bexpr.CreateEvaluator(filter.GetValue()); err != nil {
+				badFields["worker_filter"] = "Unable to successfully parse filter expression."
+			}
+		}
 		switch target.SubtypeFromType(req.GetItem().GetType()) {
 		case target.TcpSubType:
 			tcpAttrs := &pb.TcpTargetAttributes{}
@@ -758,9 +1402,41 @@ func validateCreateRequest(req *pbs.CreateTargetRequest) error {
 			if tcpAttrs.GetDefaultPort() != nil && tcpAttrs.GetDefaultPort().GetValue() == 0 {
 				badFields["attributes.default_port"] = "This optional field cannot be set to 0."
 			}
+		case target.HttpSubType:
+			httpAttrs := &pb.HttpTargetAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), httpAttrs); err != nil {
+				badFields["attributes"] = "Attribute fields do not match the expected format."
+				break
+			}
+			if httpAttrs.GetDefaultPort() != nil && httpAttrs.GetDefaultPort().GetValue() == 0 {
+				badFields["attributes.default_port"] = "This optional field cannot be set to 0."
+			}
+			validateHeaders(httpAttrs.GetHeaders(), badFields)
+			if len(httpAttrs.GetHeaderHmacs()) > 0 {
+				badFields["attributes.header_hmacs"] = "This is a read only field."
+			}
+		case target.SshSubType:
+			sshAttrs := &pb.SshTargetAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), sshAttrs); err != nil {
+				badFields["attributes"] = "Attribute fields do not match the expected format."
+				break
+			}
+			if sshAttrs.GetDefaultPort() != nil && sshAttrs.GetDefaultPort().GetValue() == 0 {
+				badFields["attributes.default_port"] = "This optional field cannot be set to 0."
+			}
+			validateSshHostKeys(sshAttrs, badFields)
+		case target.UdpSubType:
+			udpAttrs := &pb.UdpTargetAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), udpAttrs); err != nil {
+				badFields["attributes"] = "Attribute fields do not match the expected format."
+				break
+			}
+			if udpAttrs.GetDefaultPort() != nil && udpAttrs.GetDefaultPort().GetValue() == 0 {
+				badFields["attributes.default_port"] = "This optional field cannot be set to 0."
+			}
 		}
 		switch req.GetItem().GetType() {
-		case target.TcpTargetType.String():
+		case target.TcpTargetType.String(), target.HttpTargetType.String(), target.SshTargetType.String(), target.UdpTargetType.String():
 		case "":
 			badFields["type"] = "This is a required field."
 		default:
@@ -771,7 +1447,7 @@ func validateCreateRequest(req *pbs.CreateTargetRequest) error {
 }
 
 func validateUpdateRequest(req *pbs.UpdateTargetRequest) error {
-	return handlers.ValidateUpdateRequest(target.TcpTargetPrefix, req, req.GetItem(), func() map[string]string {
+	return handlers.ValidateUpdateRequest(targetPrefix(req.GetId()), req, req.GetItem(), func() map[string]string {
 		badFields := map[string]string{}
 		if handlers.MaskContains(req.GetUpdateMask().GetPaths(), "name") && req.GetItem().GetName().GetValue() == "" {
 			badFields["name"] = "This field cannot be set to empty."
@@ -788,7 +1464,12 @@ func validateUpdateRequest(req *pbs.UpdateTargetRequest) error {
 		if req.GetItem().GetSessionMaxSeconds() != nil && req.GetItem().GetSessionMaxSeconds().GetValue() == 0 {
 			badFields["session_max_seconds"] = "This must be greater than zero."
 		}
-		switch target.SubtypeFromId(req.GetItem().GetType()) {
+		if filter := req.GetItem().GetWorkerFilter(); filter != nil {
+			if _, err := bexpr.CreateEvaluator(filter.GetValue()); err != nil {
+				badFields["worker_filter"] = "Unable to successfully parse filter expression."
+			}
+		}
+		switch target.SubtypeFromId(req.GetId()) {
 		case target.TcpSubType:
 			if req.GetItem().GetType() != "" && target.SubtypeFromType(req.GetItem().GetType()) != target.TcpSubType {
 				badFields["type"] = "Cannot modify the resource type."
@@ -800,19 +1481,61 @@ func validateUpdateRequest(req *pbs.UpdateTargetRequest) error {
 			if tcpAttrs.GetDefaultPort() != nil && tcpAttrs.GetDefaultPort().GetValue() == 0 {
 				badFields["attributes.default_port"] = "This optional field cannot be set to 0."
 			}
+		case target.HttpSubType:
+			if req.GetItem().GetType() != "" && target.SubtypeFromType(req.GetItem().GetType()) != target.HttpSubType {
+				badFields["type"] = "Cannot modify the resource type."
+			}
+			httpAttrs := &pb.HttpTargetAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), httpAttrs); err != nil {
+				badFields["attributes"] = "Attribute fields do not match the expected format."
+				break
+			}
+			if httpAttrs.GetDefaultPort() != nil && httpAttrs.GetDefaultPort().GetValue() == 0 {
+				badFields["attributes.default_port"] = "This optional field cannot be set to 0."
+			}
+			validateHeaders(httpAttrs.GetHeaders(), badFields)
+			if len(httpAttrs.GetHeaderHmacs()) > 0 {
+				badFields["attributes.header_hmacs"] = "This is a read only field."
+			}
+		case target.SshSubType:
+			if req.GetItem().GetType() != "" && target.SubtypeFromType(req.GetItem().GetType()) != target.SshSubType {
+				badFields["type"] = "Cannot modify the resource type."
+			}
+			sshAttrs := &pb.SshTargetAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), sshAttrs); err != nil {
+				badFields["attributes"] = "Attribute fields do not match the expected format."
+				break
+			}
+			if sshAttrs.GetDefaultPort() != nil && sshAttrs.GetDefaultPort().GetValue() == 0 {
+				badFields["attributes.default_port"] = "This optional field cannot be set to 0."
+			}
+			validateSshHostKeys(sshAttrs, badFields)
+		case target.UdpSubType:
+			if req.GetItem().GetType() != "" && target.SubtypeFromType(req.GetItem().GetType()) != target.UdpSubType {
+				badFields["type"] = "Cannot modify the resource type."
+			}
+			udpAttrs := &pb.UdpTargetAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), udpAttrs); err != nil {
+				badFields["attributes"] = "Attribute fields do not match the expected format."
+				break
+			}
+			if udpAttrs.GetDefaultPort() != nil && udpAttrs.GetDefaultPort().GetValue() == 0 {
+				badFields["attributes.default_port"] = "This optional field cannot be set to 0."
+			}
 		}
 		return badFields
 	})
 }
 
 func validateDeleteRequest(req *pbs.DeleteTargetRequest) error {
-	return handlers.ValidateDeleteRequest(target.TcpTargetPrefix, req, handlers.NoopValidatorFn)
+	return handlers.ValidateDeleteRequest(targetPrefix(req.GetId()), req, handlers.NoopValidatorFn)
 }
 
 func validateListRequest(req *pbs.ListTargetsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()) {
-		badFields["scope_id"] = "This field is required to have a properly formatted project scope id."
+	if !handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()) &&
+		!(req.GetRecursive() && (req.GetScopeId() == scope.Global.String() || handlers.ValidId(scope.Org.Prefix(), req.GetScopeId()))) {
+		badFields["scope_id"] = "This field is required to have a properly formatted project scope id, or 'global' or a valid org scope id when listing recursively."
 	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
@@ -822,7 +1545,7 @@ func validateListRequest(req *pbs.ListTargetsRequest) error {
 
 func validateAddRequest(req *pbs.AddTargetHostSetsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(target.TcpTargetPrefix, req.GetId()) {
+	if !handlers.ValidId(targetPrefix(req.GetId()), req.GetId()) {
 		badFields["id"] = "Incorrectly formatted identifier."
 	}
 	if req.GetVersion() == 0 {
@@ -832,7 +1555,7 @@ func validateAddRequest(req *pbs.AddTargetHostSetsRequest) error {
 		badFields["host_set_ids"] = "Must be non-empty."
 	}
 	for _, id := range req.GetHostSetIds() {
-		if !handlers.ValidId(static.HostSetPrefix, id) {
+		if !handlers.ValidId(static.HostSetPrefix, id) && !handlers.ValidId(plugin.HostSetPrefix, id) {
 			badFields["host_set_ids"] = fmt.Sprintf("Incorrectly formatted host set identifier %q.", id)
 			break
 		}
@@ -845,14 +1568,14 @@ func validateAddRequest(req *pbs.AddTargetHostSetsRequest) error {
 
 func validateSetRequest(req *pbs.SetTargetHostSetsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(target.TcpTargetPrefix, req.GetId()) {
+	if !handlers.ValidId(targetPrefix(req.GetId()), req.GetId()) {
 		badFields["id"] = "Incorrectly formatted identifier."
 	}
 	if req.GetVersion() == 0 {
 		badFields["version"] = "Required field."
 	}
 	for _, id := range req.GetHostSetIds() {
-		if !handlers.ValidId(static.HostSetPrefix, id) {
+		if !handlers.ValidId(static.HostSetPrefix, id) && !handlers.ValidId(plugin.HostSetPrefix, id) {
 			badFields["host_set_ids"] = fmt.Sprintf("Incorrectly formatted host set identifier %q.", id)
 			break
 		}
@@ -865,7 +1588,7 @@ func validateSetRequest(req *pbs.SetTargetHostSetsRequest) error {
 
 func validateRemoveRequest(req *pbs.RemoveTargetHostSetsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(target.TcpTargetPrefix, req.GetId()) {
+	if !handlers.ValidId(targetPrefix(req.GetId()), req.GetId()) {
 		badFields["id"] = "Incorrectly formatted identifier."
 	}
 	if req.GetVersion() == 0 {
@@ -875,7 +1598,7 @@ func validateRemoveRequest(req *pbs.RemoveTargetHostSetsRequest) error {
 		badFields["host_set_ids"] = "Must be non-empty."
 	}
 	for _, id := range req.GetHostSetIds() {
-		if !handlers.ValidId(static.HostSetPrefix, id) {
+		if !handlers.ValidId(static.HostSetPrefix, id) && !handlers.ValidId(plugin.HostSetPrefix, id) {
 			badFields["host_set_ids"] = fmt.Sprintf("Incorrectly formatted host set identifier %q.", id)
 			break
 		}
@@ -886,13 +1609,73 @@ func validateRemoveRequest(req *pbs.RemoveTargetHostSetsRequest) error {
 	return nil
 }
 
+func validateAddCredentialsRequest(req *pbs.AddTargetCredentialsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(targetPrefix(req.GetId()), req.GetId()) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	if len(req.GetCredentialIds()) == 0 {
+		badFields["credential_ids"] = "Must be non-empty."
+	}
+	validateCredentialIds(req.GetCredentialIds(), badFields)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateSetCredentialsRequest(req *pbs.SetTargetCredentialsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(targetPrefix(req.GetId()), req.GetId()) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	validateCredentialIds(req.GetCredentialIds(), badFields)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateRemoveCredentialsRequest(req *pbs.RemoveTargetCredentialsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(targetPrefix(req.GetId()), req.GetId()) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	if len(req.GetCredentialIds()) == 0 {
+		badFields["credential_ids"] = "Must be non-empty."
+	}
+	validateCredentialIds(req.GetCredentialIds(), badFields)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateCredentialIds(ids []string, badFields map[string]string) {
+	for _, id := range ids {
+		if !handlers.ValidId(credstatic.UsernamePasswordCredentialPrefix, id) {
+			badFields["credential_ids"] = fmt.Sprintf("Incorrectly formatted credential identifier %q.", id)
+			return
+		}
+	}
+}
+
 func validateAuthorizeSessionRequest(req *pbs.AuthorizeSessionRequest) error {
 	badFields := map[string]string{}
 	nameEmpty := req.GetName() == ""
 	scopeIdEmpty := req.GetScopeId() == ""
 	scopeNameEmpty := req.GetScopeName() == ""
 	if nameEmpty {
-		if !handlers.ValidId(target.TcpTargetPrefix, req.GetId()) {
+		if !handlers.ValidId(targetPrefix(req.GetId()), req.GetId()) {
 			badFields["id"] = "Incorrectly formatted identifier."
 		}
 		if !scopeIdEmpty {
@@ -916,7 +1699,7 @@ func validateAuthorizeSessionRequest(req *pbs.AuthorizeSessionRequest) error {
 	}
 	if req.GetHostId() != "" {
 		switch host.SubtypeFromId(req.GetHostId()) {
-		case host.StaticSubtype:
+		case host.StaticSubtype, host.PluginSubtype:
 		default:
 			badFields["host_id"] = "Incorrectly formatted identifier."
 		}
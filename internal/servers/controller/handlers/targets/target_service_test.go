@@ -2,18 +2,24 @@ package targets_test
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"testing"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/authtoken"
+	credstatic "github.com/hashicorp/boundary/internal/credential/static"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/targets"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/host/plugin"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
@@ -27,6 +33,7 @@ import (
 	"github.com/jinzhu/gorm"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
 	"google.golang.org/genproto/protobuf/field_mask"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -36,6 +43,8 @@ import (
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
+var testAuthorizedActions = []string{"read", "update", "delete", "add-host-sets", "set-host-sets", "remove-host-sets", "add-credentials", "set-credentials", "remove-credentials", "authorize-session"}
+
 func testService(t *testing.T, conn *gorm.DB, kms *kms.Kms, wrapper wrapping.Wrapper) (targets.Service, error) {
 	rw := db.New(conn)
 	repoFn := func() (*target.Repository, error) {
@@ -53,7 +62,13 @@ func testService(t *testing.T, conn *gorm.DB, kms *kms.Kms, wrapper wrapping.Wra
 	staticHostRepoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
-	return targets.NewService(kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, staticHostRepoFn)
+	pluginHostRepoFn := func() (*plugin.Repository, error) {
+		return plugin.NewRepository(rw, rw, kms, plugin.TestPlugins(&plugin.TestPlugin{}))
+	}
+	staticCredRepoFn := func() (*credstatic.Repository, error) {
+		return credstatic.NewRepository(rw, rw, kms)
+	}
+	return targets.NewService(kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, staticHostRepoFn, pluginHostRepoFn, staticCredRepoFn, nil)
 }
 
 func TestGet(t *testing.T) {
@@ -81,6 +96,7 @@ func TestGet(t *testing.T) {
 		Attributes:             new(structpb.Struct),
 		SessionMaxSeconds:      wrapperspb.UInt32(28800),
 		SessionConnectionLimit: wrapperspb.Int32(1),
+		AuthorizedActions:      testAuthorizedActions,
 	}
 	for _, ihs := range hs {
 		pTar.HostSets = append(pTar.HostSets, &pb.HostSet{Id: ihs.GetPublicId(), HostCatalogId: ihs.GetCatalogId()})
@@ -137,13 +153,101 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestOutputFields(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	org, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
+	hs := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
+	tar := target.TestTcpTarget(t, conn, proj.GetPublicId(), "test", target.WithHostSets([]string{hs.GetPublicId()}))
+
+	at := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
+	r := iam.TestRole(t, conn, proj.GetPublicId())
+	_ = iam.TestRoleGrant(t, conn, r.GetPublicId(), "id=*;type=target;actions=read,list;output_fields=id,name,scope_id")
+	_ = iam.TestUserRole(t, conn, r.GetPublicId(), at.GetIamUserId())
+	ctx := auth.TestAuthContextFromToken(t, conn, kms, at)
+
+	// Fields left out of the grant, such as the attributes, host sets and
+	// authorized actions, aren't returned
+	want := &pb.Target{
+		Id:      tar.GetPublicId(),
+		ScopeId: proj.GetPublicId(),
+		Name:    wrapperspb.String("test"),
+	}
+
+	s, err := testService(t, conn, kms, wrapper)
+	require.NoError(t, err)
+
+	got, err := s.GetTarget(ctx, &pbs.GetTargetRequest{Id: tar.GetPublicId()})
+	require.NoError(t, err)
+	assert.Empty(t, cmp.Diff(want, got.GetItem(), protocmp.Transform()))
+
+	list, err := s.ListTargets(ctx, &pbs.ListTargetsRequest{ScopeId: proj.GetPublicId()})
+	require.NoError(t, err)
+	require.Len(t, list.GetItems(), 1)
+	assert.Empty(t, cmp.Diff(want, list.GetItems()[0], protocmp.Transform()))
+}
+
+func TestList_DenyGrant(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	org, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	denied := target.TestTcpTarget(t, conn, proj.GetPublicId(), "denied")
+	var wantIds []string
+	for i := 0; i < 3; i++ {
+		tar := target.TestTcpTarget(t, conn, proj.GetPublicId(), fmt.Sprintf("tar%d", i))
+		wantIds = append(wantIds, tar.GetPublicId())
+	}
+
+	at := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
+	r := iam.TestRole(t, conn, proj.GetPublicId())
+	_ = iam.TestRoleGrant(t, conn, r.GetPublicId(), "id=*;type=*;actions=*")
+	_ = iam.TestRoleGrant(t, conn, r.GetPublicId(), fmt.Sprintf("id=%s;actions=read;effect=deny", denied.GetPublicId()))
+	_ = iam.TestUserRole(t, conn, r.GetPublicId(), at.GetIamUserId())
+	ctx := auth.TestAuthContextFromToken(t, conn, kms, at)
+
+	s, err := testService(t, conn, kms, wrapper)
+	require.NoError(t, err)
+
+	// A target whose read is denied is left out of the list instead of being
+	// returned without authorized actions
+	got, err := s.ListTargets(ctx, &pbs.ListTargetsRequest{ScopeId: proj.GetPublicId()})
+	require.NoError(t, err)
+	var gotIds []string
+	for _, item := range got.GetItems() {
+		gotIds = append(gotIds, item.GetId())
+		assert.NotEmpty(t, item.GetAuthorizedActions())
+	}
+	assert.ElementsMatch(t, wantIds, gotIds)
+
+	// Paging doesn't stop at the denied target
+	gotIds = nil
+	var token string
+	for {
+		got, err := s.ListTargets(ctx, &pbs.ListTargetsRequest{ScopeId: proj.GetPublicId(), PageSize: 1, PageToken: token})
+		require.NoError(t, err)
+		for _, item := range got.GetItems() {
+			gotIds = append(gotIds, item.GetId())
+		}
+		if got.GetNextPageToken() == "" {
+			break
+		}
+		token = got.GetNextPageToken()
+	}
+	assert.ElementsMatch(t, wantIds, gotIds)
+}
+
 func TestList(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	wrapper := db.TestWrapper(t)
 	kms := kms.TestKms(t, conn, wrapper)
 
 	_, projNoTar := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
-	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	org, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
 	hss := static.TestSets(t, conn, hc.GetPublicId(), 2)
 
@@ -163,9 +267,13 @@ func TestList(t *testing.T) {
 			Attributes:             new(structpb.Struct),
 			SessionMaxSeconds:      wrapperspb.UInt32(28800),
 			SessionConnectionLimit: wrapperspb.Int32(1),
+			AuthorizedActions:      testAuthorizedActions,
 		})
 	}
 
+	// Listed resources are ordered by id
+	sort.Slice(wantTars, func(i, j int) bool { return wantTars[i].GetId() < wantTars[j].GetId() })
+
 	cases := []struct {
 		name    string
 		scopeId string
@@ -197,6 +305,28 @@ func TestList(t *testing.T) {
 			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform()), "ListTargets(%q) got response %q, wanted %q", tc.scopeId, got, tc.res)
 		})
 	}
+
+	t.Run("List Recursively", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s, err := testService(t, conn, kms, wrapper)
+		require.NoError(err, "Couldn't create new target service.")
+
+		got, err := s.ListTargets(auth.DisabledAuthTestContext(auth.WithScopeId(org.GetPublicId())), &pbs.ListTargetsRequest{ScopeId: org.GetPublicId(), Recursive: true})
+		require.NoError(err)
+		var wantIds, gotIds []string
+		for _, tar := range wantTars {
+			wantIds = append(wantIds, tar.GetId())
+		}
+		for _, item := range got.GetItems() {
+			gotIds = append(gotIds, item.GetId())
+			assert.Equal(proj.GetPublicId(), item.GetScope().GetId())
+			assert.Equal(org.GetPublicId(), item.GetScope().GetParentScopeId())
+		}
+		assert.ElementsMatch(wantIds, gotIds)
+
+		_, err = s.ListTargets(auth.DisabledAuthTestContext(auth.WithScopeId(org.GetPublicId())), &pbs.ListTargetsRequest{ScopeId: org.GetPublicId()})
+		assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v", err)
+	})
 }
 
 func TestDelete(t *testing.T) {
@@ -288,6 +418,12 @@ func TestCreate(t *testing.T) {
 
 	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
 
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sshPub, err := ssh.NewPublicKey(pub)
+	require.NoError(t, err)
+	hostKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshPub)))
+
 	cases := []struct {
 		name string
 		req  *pbs.CreateTargetRequest
@@ -321,6 +457,236 @@ func TestCreate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Create with worker filter",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId:      proj.GetPublicId(),
+				Name:         wrapperspb.String("filtered"),
+				Type:         target.TcpTargetType.String(),
+				WorkerFilter: wrapperspb.String(`"/tags/type" contains "prod"`),
+			}},
+			res: &pbs.CreateTargetResponse{
+				Uri: fmt.Sprintf("targets/%s_", target.TcpTargetPrefix),
+				Item: &pb.Target{
+					ScopeId:                proj.GetPublicId(),
+					Scope:                  &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+					Name:                   wrapperspb.String("filtered"),
+					Type:                   target.TcpTargetType.String(),
+					Attributes:             &structpb.Struct{},
+					SessionMaxSeconds:      wrapperspb.UInt32(28800),
+					SessionConnectionLimit: wrapperspb.Int32(1),
+					WorkerFilter:           wrapperspb.String(`"/tags/type" contains "prod"`),
+				},
+			},
+		},
+		{
+			name: "Create a valid http target",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId: proj.GetPublicId(),
+				Name:    wrapperspb.String("admin-ui"),
+				Type:    target.HttpTargetType.String(),
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"default_port": structpb.NewNumberValue(8080),
+					"headers": structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{
+						"X-Forwarded-User": structpb.NewStringValue("boundary"),
+					}}),
+				}},
+			}},
+			res: &pbs.CreateTargetResponse{
+				Uri: fmt.Sprintf("targets/%s_", target.HttpTargetPrefix),
+				Item: &pb.Target{
+					ScopeId: proj.GetPublicId(),
+					Scope:   &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+					Name:    wrapperspb.String("admin-ui"),
+					Type:    target.HttpTargetType.String(),
+					// The header values are never returned; the hmacs are
+					// checked separately since they depend on the key.
+					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"default_port": structpb.NewNumberValue(8080),
+					}},
+					SessionMaxSeconds:      wrapperspb.UInt32(28800),
+					SessionConnectionLimit: wrapperspb.Int32(1),
+				},
+			},
+		},
+		{
+			name: "Create a valid ssh target",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId: proj.GetPublicId(),
+				Name:    wrapperspb.String("bastion"),
+				Type:    target.SshTargetType.String(),
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"default_port": structpb.NewNumberValue(22),
+				}},
+			}},
+			res: &pbs.CreateTargetResponse{
+				Uri: fmt.Sprintf("targets/%s_", target.SshTargetPrefix),
+				Item: &pb.Target{
+					ScopeId: proj.GetPublicId(),
+					Scope:   &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+					Name:    wrapperspb.String("bastion"),
+					Type:    target.SshTargetType.String(),
+					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"default_port": structpb.NewNumberValue(22),
+					}},
+					SessionMaxSeconds:      wrapperspb.UInt32(28800),
+					SessionConnectionLimit: wrapperspb.Int32(1),
+				},
+			},
+		},
+		{
+			name: "Create a valid udp target",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId: proj.GetPublicId(),
+				Name:    wrapperspb.String("resolver"),
+				Type:    target.UdpTargetType.String(),
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"default_port": structpb.NewNumberValue(53),
+				}},
+			}},
+			res: &pbs.CreateTargetResponse{
+				Uri: fmt.Sprintf("targets/%s_", target.UdpTargetPrefix),
+				Item: &pb.Target{
+					ScopeId: proj.GetPublicId(),
+					Scope:   &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+					Name:    wrapperspb.String("resolver"),
+					Type:    target.UdpTargetType.String(),
+					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"default_port": structpb.NewNumberValue(53),
+					}},
+					SessionMaxSeconds:      wrapperspb.UInt32(28800),
+					SessionConnectionLimit: wrapperspb.Int32(1),
+				},
+			},
+		},
+		{
+			name: "Create http target with invalid header name",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId: proj.GetPublicId(),
+				Name:    wrapperspb.String("bad-header"),
+				Type:    target.HttpTargetType.String(),
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"headers": structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{
+						"Bad Header": structpb.NewStringValue("value"),
+					}}),
+				}},
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Create an ssh target with host keys",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId: proj.GetPublicId(),
+				Name:    wrapperspb.String("bastion-host-keys"),
+				Type:    target.SshTargetType.String(),
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"host_keys":    structpb.NewStringValue(hostKey),
+					"host_ca_keys": structpb.NewStringValue(hostKey),
+				}},
+			}},
+			res: &pbs.CreateTargetResponse{
+				Uri: fmt.Sprintf("targets/%s_", target.SshTargetPrefix),
+				Item: &pb.Target{
+					ScopeId: proj.GetPublicId(),
+					Scope:   &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+					Name:    wrapperspb.String("bastion-host-keys"),
+					Type:    target.SshTargetType.String(),
+					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"host_keys":    structpb.NewStringValue(hostKey),
+						"host_ca_keys": structpb.NewStringValue(hostKey),
+					}},
+					SessionMaxSeconds:      wrapperspb.UInt32(28800),
+					SessionConnectionLimit: wrapperspb.Int32(1),
+				},
+			},
+		},
+		{
+			name: "Create an ssh target ignoring host keys",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId: proj.GetPublicId(),
+				Name:    wrapperspb.String("bastion-insecure"),
+				Type:    target.SshTargetType.String(),
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"insecure_ignore_host_key": structpb.NewBoolValue(true),
+				}},
+			}},
+			res: &pbs.CreateTargetResponse{
+				Uri: fmt.Sprintf("targets/%s_", target.SshTargetPrefix),
+				Item: &pb.Target{
+					ScopeId: proj.GetPublicId(),
+					Scope:   &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String()},
+					Name:    wrapperspb.String("bastion-insecure"),
+					Type:    target.SshTargetType.String(),
+					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"insecure_ignore_host_key": structpb.NewBoolValue(true),
+					}},
+					SessionMaxSeconds:      wrapperspb.UInt32(28800),
+					SessionConnectionLimit: wrapperspb.Int32(1),
+				},
+			},
+		},
+		{
+			name: "Create ssh target with invalid host keys",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId: proj.GetPublicId(),
+				Name:    wrapperspb.String("bad-host-keys"),
+				Type:    target.SshTargetType.String(),
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"host_keys": structpb.NewStringValue("not a key"),
+				}},
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Create ssh target with empty host ca keys",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId: proj.GetPublicId(),
+				Name:    wrapperspb.String("empty-host-ca-keys"),
+				Type:    target.SshTargetType.String(),
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"host_ca_keys": structpb.NewStringValue("# no keys"),
+				}},
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Create http target with header hmacs",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId: proj.GetPublicId(),
+				Name:    wrapperspb.String("header-hmacs"),
+				Type:    target.HttpTargetType.String(),
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"header_hmacs": structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{
+						"X-Test": structpb.NewStringValue("hmac"),
+					}}),
+				}},
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Create http target setting host header",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId: proj.GetPublicId(),
+				Name:    wrapperspb.String("host-header"),
+				Type:    target.HttpTargetType.String(),
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"headers": structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{
+						"host": structpb.NewStringValue("example.com"),
+					}}),
+				}},
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Create with invalid worker filter",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId:      proj.GetPublicId(),
+				Name:         wrapperspb.String("bad-filter"),
+				Type:         target.TcpTargetType.String(),
+				WorkerFilter: wrapperspb.String(`"/tags/type" contains`),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
 		{
 			name: "Create with default port 0",
 			req: &pbs.CreateTargetRequest{Item: &pb.Target{
@@ -389,12 +755,20 @@ func TestCreate(t *testing.T) {
 			}
 			if got != nil {
 				assert.Contains(got.GetUri(), tc.res.GetUri())
-				assert.True(strings.HasPrefix(got.GetItem().GetId(), target.TcpTargetPrefix), got.GetItem().GetId())
+				assert.True(strings.HasPrefix(got.GetItem().GetId(), strings.TrimPrefix(tc.res.GetUri(), "targets/")), got.GetItem().GetId())
 
 				// Clear all values which are hard to compare against.
 				got.Uri, tc.res.Uri = "", ""
 				got.Item.Id, tc.res.Item.Id = "", ""
 				got.Item.CreatedTime, got.Item.UpdatedTime, tc.res.Item.CreatedTime, tc.res.Item.UpdatedTime = nil, nil, nil, nil
+				if hmacs, ok := got.GetItem().GetAttributes().GetFields()["header_hmacs"]; ok {
+					for n, v := range tc.req.GetItem().GetAttributes().GetFields()["headers"].GetStructValue().GetFields() {
+						h := hmacs.GetStructValue().GetFields()[n].GetStringValue()
+						assert.NotEmpty(h)
+						assert.NotEqual(v.GetStringValue(), h)
+					}
+					delete(got.Item.Attributes.Fields, "header_hmacs")
+				}
 			}
 			if tc.res != nil {
 				tc.res.Item.Version = 1
@@ -1136,3 +1510,120 @@ func TestRemoveTargetHostSets(t *testing.T) {
 		})
 	}
 }
+
+func TestAddTargetCredentials(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	s, err := testService(t, conn, kmsCache, wrapper)
+	require.NoError(t, err, "Error when getting new target service.")
+
+	projWrapper, err := kmsCache.GetWrapper(context.Background(), proj.GetPublicId(), kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+	cs := credstatic.TestCredentialStores(t, conn, proj.GetPublicId(), 1)[0]
+	creds := credstatic.TestCredentials(t, conn, projWrapper, cs.GetPublicId(), 2)
+
+	tar := target.TestTcpTarget(t, conn, proj.GetPublicId(), "test")
+	req := &pbs.AddTargetCredentialsRequest{
+		Id:            tar.GetPublicId(),
+		Version:       tar.GetVersion(),
+		CredentialIds: []string{creds[0].GetPublicId(), creds[1].GetPublicId(), creds[1].GetPublicId()},
+	}
+	got, err := s.AddTargetCredentials(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), req)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{creds[0].GetPublicId(), creds[1].GetPublicId()}, got.GetItem().GetCredentialIds())
+
+	failCases := []struct {
+		name string
+		req  *pbs.AddTargetCredentialsRequest
+		err  error
+	}{
+		{
+			name: "Bad version",
+			req: &pbs.AddTargetCredentialsRequest{
+				Id:            tar.GetPublicId(),
+				Version:       tar.GetVersion() + 5,
+				CredentialIds: []string{creds[0].GetPublicId()},
+			},
+			err: handlers.ApiErrorWithCode(codes.Internal),
+		},
+		{
+			name: "Empty credential list",
+			req: &pbs.AddTargetCredentialsRequest{
+				Id:      tar.GetPublicId(),
+				Version: got.GetItem().GetVersion(),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Incorrect credential ids",
+			req: &pbs.AddTargetCredentialsRequest{
+				Id:            tar.GetPublicId(),
+				Version:       got.GetItem().GetVersion(),
+				CredentialIds: []string{"incorrect"},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range failCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			_, gErr := s.AddTargetCredentials(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), tc.req)
+			require.Error(gErr)
+			assert.True(errors.Is(gErr, tc.err), "AddTargetCredentials(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+		})
+	}
+}
+
+func TestSetAndRemoveTargetCredentials(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	s, err := testService(t, conn, kmsCache, wrapper)
+	require.NoError(err, "Error when getting new target service.")
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId()))
+
+	projWrapper, err := kmsCache.GetWrapper(context.Background(), proj.GetPublicId(), kms.KeyPurposeDatabase)
+	require.NoError(err)
+	cs := credstatic.TestCredentialStores(t, conn, proj.GetPublicId(), 1)[0]
+	creds := credstatic.TestCredentials(t, conn, projWrapper, cs.GetPublicId(), 3)
+
+	tar := target.TestTcpTarget(t, conn, proj.GetPublicId(), "test")
+	set, err := s.SetTargetCredentials(ctx, &pbs.SetTargetCredentialsRequest{
+		Id:            tar.GetPublicId(),
+		Version:       tar.GetVersion(),
+		CredentialIds: []string{creds[0].GetPublicId(), creds[1].GetPublicId()},
+	})
+	require.NoError(err)
+	assert.ElementsMatch([]string{creds[0].GetPublicId(), creds[1].GetPublicId()}, set.GetItem().GetCredentialIds())
+
+	set, err = s.SetTargetCredentials(ctx, &pbs.SetTargetCredentialsRequest{
+		Id:            tar.GetPublicId(),
+		Version:       set.GetItem().GetVersion(),
+		CredentialIds: []string{creds[1].GetPublicId(), creds[2].GetPublicId()},
+	})
+	require.NoError(err)
+	assert.ElementsMatch([]string{creds[1].GetPublicId(), creds[2].GetPublicId()}, set.GetItem().GetCredentialIds())
+
+	removed, err := s.RemoveTargetCredentials(ctx, &pbs.RemoveTargetCredentialsRequest{
+		Id:            tar.GetPublicId(),
+		Version:       set.GetItem().GetVersion(),
+		CredentialIds: []string{creds[1].GetPublicId()},
+	})
+	require.NoError(err)
+	assert.ElementsMatch([]string{creds[2].GetPublicId()}, removed.GetItem().GetCredentialIds())
+
+	_, err = s.RemoveTargetCredentials(ctx, &pbs.RemoveTargetCredentialsRequest{
+		Id:            tar.GetPublicId(),
+		Version:       removed.GetItem().GetVersion(),
+		CredentialIds: []string{creds[0].GetPublicId()},
+	})
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.Internal)), "removing an unattached credential got error %v", err)
+}
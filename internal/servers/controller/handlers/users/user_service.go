@@ -23,6 +23,17 @@ import (
 
 var (
 	maskManager handlers.MaskManager
+
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.Read,
+		action.Update,
+		action.Delete,
+		action.AddAccounts,
+		action.SetAccounts,
+		action.RemoveAccounts,
+	}
 )
 
 func init() {
@@ -55,17 +66,41 @@ func (s Service) ListUsers(ctx context.Context, req *pbs.ListUsersRequest) (*pbs
 		return nil, err
 	}
 	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
-	if authResults.Error != nil {
-		return nil, authResults.Error
+	scopeIds, scopeInfos, err := authResults.ScopesForList(ctx, resource.User, req.GetRecursive())
+	if err != nil {
+		return nil, err
+	}
+	if len(scopeIds) == 0 {
+		return &pbs.ListUsersResponse{}, nil
+	}
+	fetch := func(afterId string, limit int) ([]handlers.ListItem, error) {
+		ul, err := s.listFromRepo(ctx, scopeIds, afterId, limit)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]handlers.ListItem, 0, len(ul))
+		for _, u := range ul {
+			resOpt := auth.WithScopeId(u.GetScopeId())
+			if authResults.DeniedForId(ctx, u.GetPublicId(), action.Read, resOpt) {
+				items = append(items, handlers.HiddenListItem(u.GetPublicId()))
+				continue
+			}
+			items = append(items, handlers.ListedItem(u.GetPublicId(), toProto(u, nil,
+				handlers.WithScope(scopeInfos[u.GetScopeId()]),
+				handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, u.GetPublicId(), IdActions, resOpt).Strings()),
+				handlers.WithOutputFields(authResults.FetchOutputFields(ctx, u.GetPublicId(), resOpt)))))
+		}
+		return items, nil
 	}
-	ul, err := s.listFromRepo(ctx, req.GetScopeId())
+	items, next, err := handlers.ListPage(fetch, req.GetFilter(), req.GetPageSize(), req.GetPageToken())
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range ul {
-		item.Scope = authResults.Scope
+	var ul []*pb.User
+	for _, item := range items {
+		ul = append(ul, item.(*pb.User))
 	}
-	return &pbs.ListUsersResponse{Items: ul}, nil
+	return &pbs.ListUsersResponse{Items: ul, NextPageToken: next}, nil
 }
 
 // GetUsers implements the interface pbs.UserServiceServer.
@@ -77,11 +112,13 @@ func (s Service) GetUser(ctx context.Context, req *pbs.GetUserRequest) (*pbs.Get
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	u, err := s.getFromRepo(ctx, req.GetId())
+	u, err := s.getFromRepo(ctx, req.GetId(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, req.GetId(), IdActions).Strings()),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	u.Scope = authResults.Scope
 	return &pbs.GetUserResponse{Item: u}, nil
 }
 
@@ -94,11 +131,12 @@ func (s Service) CreateUser(ctx context.Context, req *pbs.CreateUserRequest) (*p
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	u, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem())
+	u, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, "")))
 	if err != nil {
 		return nil, err
 	}
-	u.Scope = authResults.Scope
 	return &pbs.CreateUserResponse{Item: u, Uri: fmt.Sprintf("users/%s", u.GetId())}, nil
 }
 
@@ -111,11 +149,12 @@ func (s Service) UpdateUser(ctx context.Context, req *pbs.UpdateUserRequest) (*p
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	u, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	u, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	u.Scope = authResults.Scope
 	return &pbs.UpdateUserResponse{Item: u}, nil
 }
 
@@ -144,11 +183,12 @@ func (s Service) AddUserAccounts(ctx context.Context, req *pbs.AddUserAccountsRe
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	u, err := s.addInRepo(ctx, req.GetId(), req.GetAccountIds(), req.GetVersion())
+	u, err := s.addInRepo(ctx, req.GetId(), req.GetAccountIds(), req.GetVersion(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	u.Scope = authResults.Scope
 	return &pbs.AddUserAccountsResponse{Item: u}, nil
 }
 
@@ -161,11 +201,12 @@ func (s Service) SetUserAccounts(ctx context.Context, req *pbs.SetUserAccountsRe
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	u, err := s.setInRepo(ctx, req.GetId(), req.GetAccountIds(), req.GetVersion())
+	u, err := s.setInRepo(ctx, req.GetId(), req.GetAccountIds(), req.GetVersion(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	u.Scope = authResults.Scope
 	return &pbs.SetUserAccountsResponse{Item: u}, nil
 }
 
@@ -178,15 +219,16 @@ func (s Service) RemoveUserAccounts(ctx context.Context, req *pbs.RemoveUserAcco
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	u, err := s.removeInRepo(ctx, req.GetId(), req.GetAccountIds(), req.GetVersion())
+	u, err := s.removeInRepo(ctx, req.GetId(), req.GetAccountIds(), req.GetVersion(),
+		handlers.WithScope(authResults.Scope),
+		handlers.WithOutputFields(authResults.FetchOutputFields(ctx, req.GetId())))
 	if err != nil {
 		return nil, err
 	}
-	u.Scope = authResults.Scope
 	return &pbs.RemoveUserAccountsResponse{Item: u}, nil
 }
 
-func (s Service) getFromRepo(ctx context.Context, id string) (*pb.User, error) {
+func (s Service) getFromRepo(ctx context.Context, id string, opt ...handlers.Option) (*pb.User, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -201,10 +243,10 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*pb.User, error) {
 	if u == nil {
 		return nil, handlers.NotFoundErrorf("User %q doesn't exist.", id)
 	}
-	return toProto(u, accts), nil
+	return toProto(u, accts, opt...), nil
 }
 
-func (s Service) createInRepo(ctx context.Context, orgId string, item *pb.User) (*pb.User, error) {
+func (s Service) createInRepo(ctx context.Context, orgId string, item *pb.User, opt ...handlers.Option) (*pb.User, error) {
 	var opts []iam.Option
 	if item.GetName() != nil {
 		opts = append(opts, iam.WithName(item.GetName().GetValue()))
@@ -227,10 +269,10 @@ func (s Service) createInRepo(ctx context.Context, orgId string, item *pb.User)
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create user but no error returned from repository.")
 	}
-	return toProto(out, nil), nil
+	return toProto(out, nil, opt...), nil
 }
 
-func (s Service) updateInRepo(ctx context.Context, orgId, id string, mask []string, item *pb.User) (*pb.User, error) {
+func (s Service) updateInRepo(ctx context.Context, orgId, id string, mask []string, item *pb.User, opt ...handlers.Option) (*pb.User, error) {
 	var opts []iam.Option
 	if desc := item.GetDescription(); desc != nil {
 		opts = append(opts, iam.WithDescription(desc.GetValue()))
@@ -259,7 +301,7 @@ func (s Service) updateInRepo(ctx context.Context, orgId, id string, mask []stri
 	if rowsUpdated == 0 {
 		return nil, handlers.NotFoundErrorf("User %q doesn't exist or incorrect version provided.", id)
 	}
-	return toProto(out, accts), nil
+	return toProto(out, accts, opt...), nil
 }
 
 func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
@@ -277,23 +319,19 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, orgId string) ([]*pb.User, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, afterId string, limit int) ([]*iam.User, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	ul, err := repo.ListUsers(ctx, orgId)
+	ul, err := repo.ListUsers(ctx, scopeIds, iam.WithStartPageAfterId(afterId), iam.WithLimit(limit))
 	if err != nil {
 		return nil, err
 	}
-	var outUl []*pb.User
-	for _, u := range ul {
-		outUl = append(outUl, toProto(u, nil))
-	}
-	return outUl, nil
+	return ul, nil
 }
 
-func (s Service) addInRepo(ctx context.Context, userId string, accountIds []string, version uint32) (*pb.User, error) {
+func (s Service) addInRepo(ctx context.Context, userId string, accountIds []string, version uint32, opt ...handlers.Option) (*pb.User, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -309,10 +347,10 @@ func (s Service) addInRepo(ctx context.Context, userId string, accountIds []stri
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup user after adding accounts to it.")
 	}
-	return toProto(out, accts), nil
+	return toProto(out, accts, opt...), nil
 }
 
-func (s Service) setInRepo(ctx context.Context, userId string, accountIds []string, version uint32) (*pb.User, error) {
+func (s Service) setInRepo(ctx context.Context, userId string, accountIds []string, version uint32, opt ...handlers.Option) (*pb.User, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -328,10 +366,10 @@ func (s Service) setInRepo(ctx context.Context, userId string, accountIds []stri
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup user after setting accounts for it.")
 	}
-	return toProto(out, accts), nil
+	return toProto(out, accts, opt...), nil
 }
 
-func (s Service) removeInRepo(ctx context.Context, userId string, accountIds []string, version uint32) (*pb.User, error) {
+func (s Service) removeInRepo(ctx context.Context, userId string, accountIds []string, version uint32, opt ...handlers.Option) (*pb.User, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -347,7 +385,7 @@ func (s Service) removeInRepo(ctx context.Context, userId string, accountIds []s
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup user after removing accounts from it.")
 	}
-	return toProto(out, accts), nil
+	return toProto(out, accts, opt...), nil
 }
 
 func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
@@ -389,36 +427,57 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	return auth.Verify(ctx, opts...)
 }
 
-func toProto(in *iam.User, accts []string) *pb.User {
-	out := pb.User{
-		Id:          in.GetPublicId(),
-		ScopeId:     in.GetScopeId(),
-		CreatedTime: in.GetCreateTime().GetTimestamp(),
-		UpdatedTime: in.GetUpdateTime().GetTimestamp(),
-		Version:     in.GetVersion(),
-		AccountIds:  accts,
+func toProto(in *iam.User, accts []string, opt ...handlers.Option) *pb.User {
+	opts := handlers.GetOpts(opt...)
+	outputFields := opts.WithOutputFields
+	out := pb.User{}
+	if outputFields.Has("id") {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has("scope_id") {
+		out.ScopeId = in.GetScopeId()
+	}
+	if outputFields.Has("scope") {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has("created_time") {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
 	}
-	if in.GetDescription() != "" {
+	if outputFields.Has("updated_time") {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has("version") {
+		out.Version = in.GetVersion()
+	}
+	if outputFields.Has("description") && in.GetDescription() != "" {
 		out.Description = &wrapperspb.StringValue{Value: in.GetDescription()}
 	}
-	if in.GetName() != "" {
+	if outputFields.Has("name") && in.GetName() != "" {
 		out.Name = &wrapperspb.StringValue{Value: in.GetName()}
 	}
-	for _, a := range accts {
-		out.Accounts = append(out.Accounts, &pb.Account{
-			Id: a,
-			// TODO: Update this when an account can be associated with a user from a different scope.
-			ScopeId: in.GetScopeId(),
-		})
+	if outputFields.Has("account_ids") {
+		out.AccountIds = accts
+	}
+	if outputFields.Has("accounts") {
+		for _, a := range accts {
+			out.Accounts = append(out.Accounts, &pb.Account{
+				Id: a,
+				// TODO: Update this when an account can be associated with a user from a different scope.
+				ScopeId: in.GetScopeId(),
+			})
+		}
+	}
+	if outputFields.Has("authorized_actions") {
+		out.AuthorizedActions = opts.WithAuthorizedActions
 	}
 	return &out
 }
 
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
-//  * The path passed in is correctly formatted
-//  * All required parameters are set
-//  * There are no conflicting parameters provided
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
 func validateGetRequest(req *pbs.GetUserRequest) error {
 	return handlers.ValidateGetRequest(iam.UserPrefix, req, handlers.NoopValidatorFn)
 }
@@ -1,15 +1,21 @@
 package workers
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"net/url"
 	"sync"
 	"time"
 
+	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/targets"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/servers"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
 	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/boundary/internal/target"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/go-hclog"
 	"google.golang.org/grpc/codes"
@@ -23,22 +29,31 @@ type workerServiceServer struct {
 	logger        hclog.Logger
 	serversRepoFn common.ServersRepoFactory
 	sessionRepoFn common.SessionRepoFactory
+	targetRepoFn  common.TargetRepoFactory
 	updateTimes   *sync.Map
 	kms           *kms.Kms
+	jobChanges    *common.WorkerJobChanges
+	events        *common.SessionEvents
 }
 
 func NewWorkerServiceServer(
 	logger hclog.Logger,
 	serversRepoFn common.ServersRepoFactory,
 	sessionRepoFn common.SessionRepoFactory,
+	targetRepoFn common.TargetRepoFactory,
 	updateTimes *sync.Map,
-	kms *kms.Kms) *workerServiceServer {
+	kms *kms.Kms,
+	jobChanges *common.WorkerJobChanges,
+	events *common.SessionEvents) *workerServiceServer {
 	return &workerServiceServer{
 		logger:        logger,
 		serversRepoFn: serversRepoFn,
 		sessionRepoFn: sessionRepoFn,
+		targetRepoFn:  targetRepoFn,
 		updateTimes:   updateTimes,
 		kms:           kms,
+		jobChanges:    jobChanges,
+		events:        events,
 	}
 }
 
@@ -81,6 +96,17 @@ func (ws *workerServiceServer) Status(ctx context.Context, req *pbs.StatusReques
 			if si == nil {
 				return nil, status.Error(codes.Internal, "Error getting session info at status time")
 			}
+			for _, conn := range si.GetConnections() {
+				if conn.GetStatus() == pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CONNECTED {
+					ws.events.Publish(&common.ConnectionEvent{
+						Type:         common.ConnectionStatusEvent,
+						SessionId:    si.GetSessionId(),
+						ConnectionId: conn.GetConnectionId(),
+						BytesUp:      conn.GetBytesUp(),
+						BytesDown:    conn.GetBytesDown(),
+					})
+				}
+			}
 			switch si.Status {
 			case pbs.SESSIONSTATUS_SESSIONSTATUS_CANCELING,
 				pbs.SESSIONSTATUS_SESSIONSTATUS_TERMINATED:
@@ -121,13 +147,75 @@ func (ws *workerServiceServer) Status(ctx context.Context, req *pbs.StatusReques
 						},
 						RequestType: pbs.CHANGETYPE_CHANGETYPE_UPDATE_STATE,
 					})
+					continue
 				}
 			}
+
+			// Connections terminated while the worker had no job changes
+			// stream open to this controller are closed in the database but
+			// still open on the worker
+			toClose, err := ws.terminatedConnections(ctx, sessRepo, si)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "Error looking up connections of session %s: %v", sessionId, err)
+			}
+			if len(toClose) > 0 {
+				ret.JobsRequests = append(ret.JobsRequests, common.CloseConnectionsRequest(sessionId, toClose...))
+			}
 		}
 	}
 	return ret, nil
 }
 
+// terminatedConnections returns the IDs of the connections a worker reports
+// as open in the session job info si which are closed in the database.
+func (ws *workerServiceServer) terminatedConnections(ctx context.Context, sessRepo *session.Repository, si *pbs.SessionJobInfo) ([]string, error) {
+	open := make(map[string]bool, len(si.GetConnections()))
+	for _, conn := range si.GetConnections() {
+		if conn.GetStatus() != pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CLOSED {
+			open[conn.GetConnectionId()] = true
+		}
+	}
+	if len(open) == 0 {
+		return nil, nil
+	}
+	conns, err := sessRepo.ListConnections(ctx, si.GetSessionId(), session.WithLimit(-1))
+	if err != nil {
+		return nil, err
+	}
+	var ret []string
+	for _, conn := range conns {
+		if open[conn.GetPublicId()] && conn.ClosedReason != "" {
+			ret = append(ret, conn.GetPublicId())
+		}
+	}
+	return ret, nil
+}
+
+// JobChanges implements the interface pbs.ServerCoordinationServiceServer.
+func (ws *workerServiceServer) JobChanges(req *pbs.JobChangesRequest, stream pbs.ServerCoordinationService_JobChangesServer) error {
+	workerId := req.GetWorkerId()
+	if workerId == "" {
+		return status.Error(codes.InvalidArgument, "Missing worker id.")
+	}
+	ws.logger.Trace("worker opened job changes stream", "name", workerId)
+	changes, closeStream := ws.jobChanges.Open(workerId)
+	defer closeStream()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case change, ok := <-changes:
+			if !ok {
+				// The worker has opened another stream
+				return nil
+			}
+			if err := stream.Send(change); err != nil {
+				return err
+			}
+		}
+	}
+}
+
 func (ws *workerServiceServer) LookupSession(ctx context.Context, req *pbs.LookupSessionRequest) (*pbs.LookupSessionResponse, error) {
 	ws.logger.Trace("got validate session request from worker", "session_id", req.GetSessionId())
 
@@ -152,22 +240,60 @@ func (ws *workerServiceServer) LookupSession(ctx context.Context, req *pbs.Looku
 			SessionId:   sessionInfo.GetPublicId(),
 			Certificate: sessionInfo.Certificate,
 		},
-		Status:          sessionInfo.States[0].Status.ProtoVal(),
-		Version:         sessionInfo.Version,
-		TofuToken:       string(sessionInfo.TofuToken),
-		Endpoint:        sessionInfo.Endpoint,
-		Expiration:      sessionInfo.ExpirationTime.Timestamp,
-		ConnectionLimit: sessionInfo.ConnectionLimit,
-		ConnectionsLeft: authzSummary.ConnectionLimit,
-		HostId:          sessionInfo.HostId,
-		HostSetId:       sessionInfo.HostSetId,
-		TargetId:        sessionInfo.TargetId,
-		UserId:          sessionInfo.UserId,
+		Status:             sessionInfo.States[0].Status.ProtoVal(),
+		Version:            sessionInfo.Version,
+		TofuToken:          string(sessionInfo.TofuToken),
+		Endpoint:           sessionInfo.Endpoint,
+		Expiration:         sessionInfo.ExpirationTime.Timestamp,
+		ConnectionLimit:    sessionInfo.ConnectionLimit,
+		ConnectionsLeft:    authzSummary.ConnectionLimit,
+		HostId:             sessionInfo.HostId,
+		HostSetId:          sessionInfo.HostSetId,
+		TargetId:           sessionInfo.TargetId,
+		UserId:             sessionInfo.UserId,
+		IdleTimeoutSeconds: sessionInfo.IdleTimeoutSeconds,
 	}
 	if resp.ConnectionsLeft != -1 {
 		resp.ConnectionsLeft -= int32(authzSummary.CurrentConnectionCount)
 	}
 
+	// The worker adds the headers of an http target to every request it
+	// proxies and checks the host key of the endpoint of an ssh target, so
+	// these are looked up from the target's current state.
+	if endpointUrl, err := url.Parse(sessionInfo.Endpoint); err == nil {
+		switch endpointUrl.Scheme {
+		case target.HttpTargetType.String():
+			targetRepo, err := ws.targetRepoFn()
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "Error getting target repo: %v", err)
+			}
+			resp.HttpHeaders, err = targetRepo.LookupHttpTargetHeaders(ctx, sessionInfo.ScopeId, sessionInfo.TargetId)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "Error looking up target headers: %v", err)
+			}
+		case target.SshTargetType.String():
+			targetRepo, err := ws.targetRepoFn()
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "Error getting target repo: %v", err)
+			}
+			t, _, err := targetRepo.LookupTarget(ctx, sessionInfo.TargetId)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "Error looking up target: %v", err)
+			}
+			if sshT, ok := t.(*target.SshTarget); ok {
+				resp.SshHostKeys = sshT.GetHostKeys()
+				resp.SshHostCaKeys = sshT.GetHostCaKeys()
+				resp.SshInsecureIgnoreHostKey = sshT.GetInsecureIgnoreHostKey()
+			}
+		}
+	}
+
+	if req.GetWorkerId() != "" {
+		if resp.Route, err = ws.sessionRoute(ctx, req.GetWorkerId(), sessionInfo.TargetId); err != nil {
+			return nil, err
+		}
+	}
+
 	wrapper, err := ws.kms.GetWrapper(ctx, sessionInfo.ScopeId, kms.KeyPurposeSessions)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Error getting sessions wrapper: %v", err)
@@ -289,7 +415,7 @@ func (ws *workerServiceServer) ConnectConnection(ctx context.Context, req *pbs.C
 		"client_tcp_port", req.ClientTcpPort,
 	}
 	switch req.GetType() {
-	case "tcp":
+	case "tcp", "http", "ssh", "udp":
 		loggerPairs = append(loggerPairs,
 			"endpoint_tcp_address", connectionInfo.EndpointTcpAddress,
 			"endpoint_tcp_port", connectionInfo.EndpointTcpPort,
@@ -301,6 +427,79 @@ func (ws *workerServiceServer) ConnectConnection(ctx context.Context, req *pbs.C
 	return ret, nil
 }
 
+func (ws *workerServiceServer) RecordHttpRequest(ctx context.Context, req *pbs.RecordHttpRequestRequest) (*pbs.RecordHttpRequestResponse, error) {
+	ws.logger.Trace("got http request information from worker", "connection_id", req.GetConnectionId())
+
+	sessRepo, err := ws.sessionRepoFn()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error getting session repo: %v", err)
+	}
+
+	if _, err := sessRepo.RecordHttpRequest(ctx, req.GetConnectionId(), req.GetMethod(), req.GetPath(), req.GetStatusCode()); err != nil {
+		return nil, status.Errorf(codes.Internal, "error recording http request: %v", err)
+	}
+
+	ws.logger.Info("http request proxied",
+		"connection_id", req.GetConnectionId(),
+		"method", req.GetMethod(),
+		"path", req.GetPath(),
+		"status_code", req.GetStatusCode())
+
+	return &pbs.RecordHttpRequestResponse{}, nil
+}
+
+func (ws *workerServiceServer) UploadSessionRecording(stream pbs.SessionService_UploadSessionRecordingServer) error {
+	var first *pbs.UploadSessionRecordingRequest
+	var data bytes.Buffer
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "error receiving recording: %v", err)
+		}
+		if first == nil {
+			first = req
+		}
+		data.Write(req.GetData())
+	}
+	if first == nil {
+		return status.Error(codes.InvalidArgument, "No recording received.")
+	}
+	ws.logger.Trace("got session recording from worker", "session_id", first.GetSessionId(), "connection_id", first.GetConnectionId(), "channel_id", first.GetChannelId())
+
+	sessRepo, err := ws.sessionRepoFn()
+	if err != nil {
+		return status.Errorf(codes.Internal, "error getting session repo: %v", err)
+	}
+
+	recording, err := session.NewRecording(
+		first.GetSessionId(),
+		first.GetConnectionId(),
+		first.GetChannelId(),
+		&timestamp.Timestamp{Timestamp: first.GetStartTime()},
+		&timestamp.Timestamp{Timestamp: first.GetEndTime()},
+		data.Bytes(),
+	)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid recording: %v", err)
+	}
+	recording, err = sessRepo.CreateRecording(stream.Context(), recording)
+	if err != nil {
+		return status.Errorf(codes.Internal, "error storing recording: %v", err)
+	}
+
+	ws.logger.Info("session recording stored",
+		"session_id", recording.SessionId,
+		"connection_id", recording.ConnectionId,
+		"channel_id", recording.ChannelId,
+		"recording_id", recording.PublicId,
+		"size", len(recording.Data))
+
+	return stream.SendAndClose(&pbs.UploadSessionRecordingResponse{RecordingId: recording.PublicId})
+}
+
 func (ws *workerServiceServer) CloseConnection(ctx context.Context, req *pbs.CloseConnectionRequest) (*pbs.CloseConnectionResponse, error) {
 	numCloses := len(req.GetCloseRequestData())
 	if numCloses == 0 {
@@ -358,3 +557,56 @@ func (ws *workerServiceServer) CloseConnection(ctx context.Context, req *pbs.Clo
 
 	return ret, nil
 }
+
+// sessionRoute returns the route along which the worker named workerId
+// forwards the connections of a session to the target with the given ID to
+// a downstream worker able to handle them. It returns nil if the worker
+// handles the connections itself.
+func (ws *workerServiceServer) sessionRoute(ctx context.Context, workerId, targetId string) ([]string, error) {
+	serversRepo, err := ws.serversRepoFn()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Error getting servers repo: %v", err)
+	}
+	workers, err := serversRepo.ListServers(ctx, servers.ServerTypeWorker)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Error looking up workers: %v", err)
+	}
+	var hasUpstreams bool
+	for _, w := range workers {
+		if w.GetUpstream() != "" {
+			hasUpstreams = true
+			break
+		}
+	}
+	if !hasUpstreams {
+		return nil, nil
+	}
+
+	targetRepo, err := ws.targetRepoFn()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Error getting target repo: %v", err)
+	}
+	t, _, err := targetRepo.LookupTarget(ctx, targetId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Error looking up target: %v", err)
+	}
+	var workerFilter string
+	if t != nil {
+		workerFilter = t.GetWorkerFilter()
+	}
+	eligible, err := servers.FilterWorkers(workers, workerFilter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Error filtering workers: %v", err)
+	}
+	for _, w := range eligible {
+		if w.GetName() == workerId {
+			return nil, nil
+		}
+	}
+	for _, w := range eligible {
+		if route := servers.WorkerRoute(workers, workerId, w); route != nil {
+			return route, nil
+		}
+	}
+	return nil, nil
+}
@@ -119,7 +119,7 @@ func (c *Controller) startListeners() error {
 			grpc.MaxRecvMsgSize(math.MaxInt32),
 			grpc.MaxSendMsgSize(math.MaxInt32),
 		)
-		workerService := workers.NewWorkerServiceServer(c.logger.Named("worker-handler"), c.ServersRepoFn, c.SessionRepoFn, c.workerStatusUpdateTimes, c.kms)
+		workerService := workers.NewWorkerServiceServer(c.logger.Named("worker-handler"), c.ServersRepoFn, c.SessionRepoFn, c.TargetRepoFn, c.workerStatusUpdateTimes, c.kms, c.workerJobChanges, c.sessionEvents)
 		pbs.RegisterServerCoordinationServiceServer(workerServer, workerService)
 		pbs.RegisterSessionServiceServer(workerServer, workerService)
 
@@ -127,12 +127,27 @@ func (c *Controller) startListeners() error {
 		ln.ALPNListener = interceptor
 		ln.GrpcServer = workerServer
 
+		startReverseWorkers, err := c.registerReverseWorkerListener(ln)
+		if err != nil {
+			return err
+		}
+
 		servers = append(servers, func() {
 			go workerServer.Serve(interceptor)
+			startReverseWorkers()
 		})
 		return nil
 	}
 
+	configureForControllerProxy := func(ln *base.ServerListener) error {
+		startSessions, err := c.registerReverseWorkerSessionListener(ln)
+		if err != nil {
+			return err
+		}
+		servers = append(servers, startSessions)
+		return nil
+	}
+
 	for _, ln := range c.conf.Listeners {
 		var err error
 		for _, purpose := range ln.Config.Purpose {
@@ -141,6 +156,8 @@ func (c *Controller) startListeners() error {
 				err = configureForAPI(ln)
 			case "cluster":
 				err = configureForCluster(ln)
+			case "controller-proxy":
+				err = configureForControllerProxy(ln)
 			case "proxy":
 				// Do nothing, in a dev mode we might see it here
 			default:
@@ -0,0 +1,459 @@
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/libs/alpnmux"
+	"github.com/hashicorp/boundary/internal/servers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/yamux"
+)
+
+// Reverse-connected workers keep a connection to a controller's cluster
+// listener through which the controller forwards the connections of their
+// sessions. Workers report the controller's public proxy address as their
+// address, so clients connect to the controller's controller-proxy listener,
+// which passes the connections of those sessions through to the worker before
+// their TLS handshake.
+//
+// The handshakes of the controller-proxy listener are unauthenticated, so
+// their routes are only ever read from memory. Routes are recorded when a
+// session is authorized through this controller, and by polling the sessions
+// whose state changed for the sessions authorized through other controllers.
+const (
+	reverseHandshakeTimeout = 10 * time.Second
+	// reverseRoutePollInterval is how often the sessions whose state changed
+	// are polled for their routes
+	reverseRoutePollInterval = time.Second
+	// reverseRoutePollWindow is how far back each poll looks for state
+	// changes. It overlaps the previous poll so that changes committed late
+	// are not missed.
+	reverseRoutePollWindow = 5 * time.Second
+	// reverseRouteWait is how long a handshake whose route isn't known waits
+	// for it to be recorded, covering sessions just authorized through
+	// another controller
+	reverseRouteWait = 2 * reverseRoutePollInterval
+)
+
+// registerReverseWorkerListener registers the listener for reverse-connected
+// workers on a cluster listener. The returned function starts accepting on
+// it.
+func (c *Controller) registerReverseWorkerListener(ln *base.ServerListener) (func(), error) {
+	// Clear out in case this is a second start of the controller
+	ln.Mux.UnregisterProto(servers.HopProto)
+	hopLn, err := ln.Mux.RegisterProto(servers.HopProto, &tls.Config{
+		GetConfigForClient: c.validateReverseWorkerTls,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting sub-listener for reverse worker proto: %w", err)
+	}
+	return func() {
+		go c.acceptReverseWorkers(hopLn)
+	}, nil
+}
+
+// registerReverseWorkerSessionListener registers the listener for the
+// connections of the sessions of reverse-connected workers on a
+// controller-proxy listener. Any other connection to it is closed. The
+// returned function starts accepting on it.
+func (c *Controller) registerReverseWorkerSessionListener(ln *base.ServerListener) (func(), error) {
+	// Clear out in case this is a second start of the controller
+	ln.Mux.UnregisterProto(alpnmux.PassthroughProto)
+	passthroughLn, err := ln.Mux.RegisterPassthrough(c.matchReverseWorkerSession)
+	if err != nil {
+		return nil, fmt.Errorf("error getting passthrough listener: %w", err)
+	}
+	return func() {
+		go c.acceptReverseWorkerSessions(passthroughLn)
+	}, nil
+}
+
+func (c *Controller) validateReverseWorkerTls(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+	tlsConf, workerInfo, err := c.v1WorkerAuthConfig(hello.SupportedProtos)
+	if err != nil {
+		return nil, err
+	}
+	tlsConf.NextProtos = []string{servers.HopProto}
+	// Set the info we need to prevent replays
+	c.workerAuthCache.Set(workerInfo.ConnectionNonce, &workerAuthEntry{
+		WorkerAuthInfo: workerInfo,
+	}, 0)
+	return tlsConf, nil
+}
+
+func (c *Controller) yamuxConfig() *yamux.Config {
+	conf := yamux.DefaultConfig()
+	conf.LogOutput = nil
+	conf.Logger = c.logger.StandardLogger(nil)
+	return conf
+}
+
+func (c *Controller) acceptReverseWorkers(l net.Listener) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		go c.handleReverseWorker(conn)
+	}
+}
+
+func (c *Controller) handleReverseWorker(conn net.Conn) {
+	if err := conn.SetDeadline(time.Now().Add(reverseHandshakeTimeout)); err != nil {
+		c.logger.Error("error setting reverse worker handshake deadline", "error", err)
+		conn.Close()
+		return
+	}
+	nonce := make([]byte, 20)
+	if _, err := io.ReadFull(conn, nonce); err != nil {
+		c.logger.Error("error reading nonce from reverse worker", "error", err)
+		conn.Close()
+		return
+	}
+	workerInfoRaw, found := c.workerAuthCache.Get(string(nonce))
+	if !found {
+		c.logger.Error("did not find valid nonce for incoming reverse worker")
+		conn.Close()
+		return
+	}
+	c.workerAuthCache.Delete(string(nonce))
+	workerInfo := workerInfoRaw.(*workerAuthEntry)
+
+	proxyAddr := c.conf.RawConfig.Controller.PublicProxyAddr
+	if proxyAddr == "" {
+		c.logger.Error("reverse worker connected but no controller-proxy listener is configured", "name", workerInfo.Name)
+		conn.Close()
+		return
+	}
+	if err := servers.WriteHopFrame(conn, &servers.HopHello{
+		Name:    c.conf.RawConfig.Controller.Name,
+		Address: proxyAddr,
+	}); err != nil {
+		c.logger.Error("error writing hello to reverse worker", "name", workerInfo.Name, "error", err)
+		conn.Close()
+		return
+	}
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		c.logger.Error("error clearing reverse worker handshake deadline", "error", err)
+		conn.Close()
+		return
+	}
+
+	workerSession, err := yamux.Client(conn, c.yamuxConfig())
+	if err != nil {
+		c.logger.Error("error creating reverse worker session", "name", workerInfo.Name, "error", err)
+		conn.Close()
+		return
+	}
+	c.reverseWorkers.Store(workerInfo.Name, workerSession)
+	c.logger.Info("reverse worker connected", "name", workerInfo.Name)
+	c.notifyReverseRoutes()
+	go func() {
+		// Record the routes of sessions authorized before the worker
+		// connected, e.g. before this controller was restarted
+		if err := c.loadReverseWorkerRoutes(c.baseContext); err != nil {
+			c.logger.Error("error loading reverse worker routes", "error", err)
+		}
+	}()
+
+	select {
+	case <-workerSession.CloseChan():
+	case <-c.baseContext.Done():
+		workerSession.Close()
+	}
+	// Only forget the session if the worker has not reconnected since
+	if raw, ok := c.reverseWorkers.Load(workerInfo.Name); ok && raw.(*yamux.Session) == workerSession {
+		c.reverseWorkers.Delete(workerInfo.Name)
+	}
+	c.logger.Info("reverse worker disconnected", "name", workerInfo.Name)
+}
+
+func (c *Controller) hasReverseWorkers() bool {
+	var found bool
+	c.reverseWorkers.Range(func(_, _ interface{}) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
+// refreshReverseWorkerServers reloads the workers, as last reported by their
+// status updates, used to find the routes of sessions through
+// reverse-connected workers.
+func (c *Controller) refreshReverseWorkerServers(ctx context.Context) error {
+	serversRepo, err := c.ServersRepoFn()
+	if err != nil {
+		return err
+	}
+	workers, err := serversRepo.ListServers(ctx, servers.ServerTypeWorker)
+	if err != nil {
+		return err
+	}
+	c.reverseWorkerServers.Store(workers)
+	return nil
+}
+
+// reverseWorkerRoutes returns the routes to the workers matching
+// workerFilter which clients reach through this controller. Each route is
+// the names of the workers through which a session's connections are
+// forwarded, starting with a reverse-connected worker.
+func (c *Controller) reverseWorkerRoutes(workerFilter string) ([][]string, error) {
+	proxyAddr := c.conf.RawConfig.Controller.PublicProxyAddr
+	if proxyAddr == "" {
+		return nil, nil
+	}
+	workers, _ := c.reverseWorkerServers.Load().([]*servers.Server)
+	eligible, err := servers.FilterWorkers(workers, workerFilter)
+	if err != nil {
+		return nil, err
+	}
+	var routes [][]string
+	for _, w := range eligible {
+		ingress := servers.IngressWorker(workers, w)
+		if ingress == nil || ingress.GetAddress() != proxyAddr {
+			continue
+		}
+		routes = append(routes, append([]string{ingress.GetName()}, servers.WorkerRoute(workers, ingress.GetName(), w)...))
+	}
+	return routes, nil
+}
+
+// setReverseWorkerRoutes records the routes of a session until it expires. It
+// reports whether any were recorded.
+func (c *Controller) setReverseWorkerRoutes(sess *session.Session, workerFilter string) (bool, error) {
+	ttl := time.Until(sess.ExpirationTime.GetTimestamp().AsTime())
+	if ttl <= 0 {
+		return false, nil
+	}
+	routes, err := c.reverseWorkerRoutes(workerFilter)
+	if err != nil || len(routes) == 0 {
+		return false, err
+	}
+	c.reverseRoutes.Set(sess.PublicId, routes, ttl)
+	return true, nil
+}
+
+// notifyReverseRoutes wakes the handshakes waiting for a route.
+func (c *Controller) notifyReverseRoutes() {
+	c.reverseRoutesL.Lock()
+	defer c.reverseRoutesL.Unlock()
+	close(c.reverseRoutesChanged)
+	c.reverseRoutesChanged = make(chan struct{})
+}
+
+// reverseWorkerSessionAuthorized records the routes of a session authorized
+// through this controller, so its client can connect right away.
+func (c *Controller) reverseWorkerSessionAuthorized(sess *session.Session, workerFilter string) {
+	if c.conf.RawConfig.Controller.PublicProxyAddr == "" {
+		return
+	}
+	set, err := c.setReverseWorkerRoutes(sess, workerFilter)
+	if err != nil {
+		c.logger.Error("error recording reverse worker routes of session", "session_id", sess.PublicId, "error", err)
+		return
+	}
+	if set {
+		c.notifyReverseRoutes()
+	}
+}
+
+// updateReverseWorkerRoutes records the routes of sessions whose routes are
+// not known yet, and forgets those of sessions which are done.
+func (c *Controller) updateReverseWorkerRoutes(ctx context.Context, sessions []*session.Session) error {
+	targetRepo, err := c.TargetRepoFn()
+	if err != nil {
+		return err
+	}
+	workerFilters := make(map[string]string)
+	var set bool
+	for _, sess := range sessions {
+		if len(sess.States) == 0 {
+			continue
+		}
+		switch sess.States[0].Status {
+		case session.StatusCanceling, session.StatusTerminated:
+			c.reverseRoutes.Delete(sess.PublicId)
+			continue
+		}
+		if _, ok := c.reverseRoutes.Get(sess.PublicId); ok {
+			continue
+		}
+		workerFilter, ok := workerFilters[sess.TargetId]
+		if !ok {
+			t, _, err := targetRepo.LookupTarget(ctx, sess.TargetId)
+			if err != nil {
+				return err
+			}
+			if t != nil {
+				workerFilter = t.GetWorkerFilter()
+			}
+			workerFilters[sess.TargetId] = workerFilter
+		}
+		ok, err := c.setReverseWorkerRoutes(sess, workerFilter)
+		if err != nil {
+			return err
+		}
+		set = set || ok
+	}
+	if set {
+		c.notifyReverseRoutes()
+	}
+	return nil
+}
+
+// pollReverseWorkerRoutes updates the routes of the sessions whose state
+// changed recently.
+func (c *Controller) pollReverseWorkerRoutes(ctx context.Context) error {
+	sessRepo, err := c.SessionRepoFn()
+	if err != nil {
+		return err
+	}
+	sessions, err := sessRepo.ListSessions(ctx, session.WithStateChangedWithin(reverseRoutePollWindow), session.WithLimit(-1))
+	if err != nil {
+		return err
+	}
+	if len(sessions) == 0 {
+		return nil
+	}
+	// New sessions may be handled by workers which connected since the
+	// workers were last refreshed
+	if err := c.refreshReverseWorkerServers(ctx); err != nil {
+		return err
+	}
+	return c.updateReverseWorkerRoutes(ctx, sessions)
+}
+
+// loadReverseWorkerRoutes records the routes of all the sessions which are
+// not terminated.
+func (c *Controller) loadReverseWorkerRoutes(ctx context.Context) error {
+	if err := c.refreshReverseWorkerServers(ctx); err != nil {
+		return err
+	}
+	sessRepo, err := c.SessionRepoFn()
+	if err != nil {
+		return err
+	}
+	sessions, err := sessRepo.ListSessions(ctx, session.WithUnterminated(), session.WithLimit(-1))
+	if err != nil {
+		return err
+	}
+	return c.updateReverseWorkerRoutes(ctx, sessions)
+}
+
+// reverseWorkerRoute returns the recorded route of a session whose first
+// worker is connected to this controller, or nil if there is none.
+func (c *Controller) reverseWorkerRoute(sessionId string) []string {
+	raw, ok := c.reverseRoutes.Get(sessionId)
+	if !ok {
+		return nil
+	}
+	for _, route := range raw.([][]string) {
+		if _, ok := c.reverseWorkers.Load(route[0]); ok {
+			return route
+		}
+	}
+	return nil
+}
+
+// matchReverseWorkerSession is the passthrough match function of the
+// controller-proxy listeners. It reports whether the session named in the SNI
+// of hello can be handled through a reverse-connected worker. The handshake is
+// unauthenticated, so only recorded routes are used: a handshake whose route
+// isn't known waits a little for it to be recorded by the next poll, but
+// never looks it up.
+func (c *Controller) matchReverseWorkerSession(hello *tls.ClientHelloInfo) bool {
+	sessionId := hello.ServerName
+	if !handlers.ValidId(session.SessionPrefix, sessionId) || sessionId == session.SessionPrefix+"_" || !c.hasReverseWorkers() {
+		return false
+	}
+	timer := time.NewTimer(reverseRouteWait)
+	defer timer.Stop()
+	for {
+		c.reverseRoutesL.Lock()
+		changed := c.reverseRoutesChanged
+		c.reverseRoutesL.Unlock()
+		if c.reverseWorkerRoute(sessionId) != nil {
+			return true
+		}
+		select {
+		case <-changed:
+		case <-timer.C:
+			return false
+		case <-c.baseContext.Done():
+			return false
+		}
+	}
+}
+
+// acceptReverseWorkerSessions forwards the session connections matched by
+// matchReverseWorkerSession on a controller-proxy listener until it is
+// closed.
+func (c *Controller) acceptReverseWorkerSessions(l net.Listener) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		pc, ok := conn.(*alpnmux.PassthroughConn)
+		if !ok {
+			conn.Close()
+			continue
+		}
+		route := c.reverseWorkerRoute(pc.ServerName)
+		if route == nil {
+			c.logger.Error("no route found for session", "session_id", pc.ServerName)
+			conn.Close()
+			continue
+		}
+		go c.forwardReverseWorkerSession(conn, route)
+	}
+}
+
+// forwardReverseWorkerSession forwards conn, the stream of a session, to the
+// reverse-connected worker first in route, which forwards it along the rest
+// of route.
+func (c *Controller) forwardReverseWorkerSession(conn net.Conn, route []string) {
+	defer conn.Close()
+	raw, ok := c.reverseWorkers.Load(route[0])
+	if !ok {
+		c.logger.Error("reverse worker is not connected", "name", route[0])
+		return
+	}
+	stream, err := raw.(*yamux.Session).Open()
+	if err != nil {
+		c.logger.Error("error opening stream to reverse worker", "name", route[0], "error", err)
+		return
+	}
+	defer stream.Close()
+	if err := servers.WriteHopFrame(stream, &servers.HopHeader{
+		ClientAddr: conn.RemoteAddr().String(),
+		Route:      route[1:],
+	}); err != nil {
+		c.logger.Error("error writing header to reverse worker", "name", route[0], "error", err)
+		return
+	}
+
+	connWg := new(sync.WaitGroup)
+	connWg.Add(2)
+	go func() {
+		defer connWg.Done()
+		_, err := io.Copy(stream, conn)
+		c.logger.Debug("copy from client to reverse worker done", "error", err)
+		stream.Close()
+	}()
+	go func() {
+		defer connWg.Done()
+		_, err := io.Copy(conn, stream)
+		c.logger.Debug("copy from reverse worker to client done", "error", err)
+		conn.Close()
+	}()
+	connWg.Wait()
+}
@@ -0,0 +1,330 @@
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/servers"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/yamux"
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// testReverseController returns a controller with just what reverse workers
+// need. Its repositories can't be created, and the number of attempts to
+// create them is returned.
+func testReverseController(t *testing.T) (*Controller, *int32) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	lookups := new(int32)
+	c := &Controller{
+		conf: &Config{RawConfig: &config.Config{Controller: &config.Controller{
+			Name:            "test-controller",
+			PublicProxyAddr: "127.0.0.1:9203",
+		}}},
+		logger:               hclog.NewNullLogger(),
+		baseContext:          ctx,
+		baseCancel:           cancel,
+		workerAuthCache:      cache.New(0, 0),
+		reverseWorkers:       new(sync.Map),
+		reverseRoutes:        cache.New(cache.NoExpiration, 5*time.Minute),
+		reverseRoutesChanged: make(chan struct{}),
+		reverseRoutesL:       new(sync.Mutex),
+		SessionRepoFn: func() (*session.Repository, error) {
+			atomic.AddInt32(lookups, 1)
+			return nil, errors.New("no database")
+		},
+		ServersRepoFn: func() (*servers.Repository, error) {
+			atomic.AddInt32(lookups, 1)
+			return nil, errors.New("no database")
+		},
+	}
+	return c, lookups
+}
+
+// testReverseWorkerLink returns the controller's and the worker's ends of a
+// reverse worker's connection.
+func testReverseWorkerLink(t *testing.T) (*yamux.Session, *yamux.Session) {
+	t.Helper()
+	c1, c2 := net.Pipe()
+	client, err := yamux.Client(c1, nil)
+	require.NoError(t, err)
+	server, err := yamux.Server(c2, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		client.Close()
+		server.Close()
+	})
+	return client, server
+}
+
+// testAuthorizedSession returns a session expiring in a minute.
+func testAuthorizedSession(id string) *session.Session {
+	return &session.Session{
+		PublicId:       id,
+		ExpirationTime: &timestamp.Timestamp{Timestamp: timestamppb.New(time.Now().Add(time.Minute))},
+	}
+}
+
+func TestReverseWorkerRoutes(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	c, _ := testReverseController(t)
+	c.reverseWorkerServers.Store([]*servers.Server{
+		{Name: "w1", Address: "127.0.0.1:9203", Tags: map[string]*servers.TagValues{"type": {Values: []string{"reverse"}}}},
+		{Name: "w2", Address: "127.0.0.1:9203", Upstream: "w1", Tags: map[string]*servers.TagValues{"type": {Values: []string{"downstream"}}}},
+		{Name: "w3", Address: "10.0.0.1:9202", Tags: map[string]*servers.TagValues{"type": {Values: []string{"reverse"}}}},
+	})
+
+	// Only the workers reached through this controller have routes, starting
+	// with the reverse-connected worker
+	routes, err := c.reverseWorkerRoutes("")
+	require.NoError(err)
+	assert.Equal([][]string{{"w1"}, {"w1", "w2"}}, routes)
+	routes, err = c.reverseWorkerRoutes(`"downstream" in "/tags/type"`)
+	require.NoError(err)
+	assert.Equal([][]string{{"w1", "w2"}}, routes)
+	routes, err = c.reverseWorkerRoutes(`"/name" == "w3"`)
+	require.NoError(err)
+	assert.Empty(routes)
+
+	// Without a controller-proxy listener there are none
+	c.conf.RawConfig.Controller.PublicProxyAddr = ""
+	routes, err = c.reverseWorkerRoutes("")
+	require.NoError(err)
+	assert.Empty(routes)
+}
+
+func TestMatchReverseWorkerSession(t *testing.T) {
+	t.Parallel()
+	const sessionId = "s_1234567890"
+	workers := []*servers.Server{
+		{Name: "w1", Address: "127.0.0.1:9203"},
+		{Name: "w2", Address: "127.0.0.1:9203", Upstream: "w1"},
+	}
+
+	t.Run("no-reverse-workers", func(t *testing.T) {
+		c, lookups := testReverseController(t)
+		assert.False(t, c.matchReverseWorkerSession(&tls.ClientHelloInfo{ServerName: sessionId}))
+		assert.Zero(t, atomic.LoadInt32(lookups))
+	})
+
+	t.Run("invalid-session-id", func(t *testing.T) {
+		c, lookups := testReverseController(t)
+		link, _ := testReverseWorkerLink(t)
+		c.reverseWorkers.Store("w1", link)
+		for _, sni := range []string{"", "example.com", "s_", "s_abc/../def", "s_abc.example.com", "hst_1234567890"} {
+			assert.False(t, c.matchReverseWorkerSession(&tls.ClientHelloInfo{ServerName: sni}), "sni %q", sni)
+		}
+		assert.Zero(t, atomic.LoadInt32(lookups))
+	})
+
+	t.Run("routes", func(t *testing.T) {
+		assert := assert.New(t)
+		c, lookups := testReverseController(t)
+		c.reverseWorkerServers.Store(workers)
+		link, _ := testReverseWorkerLink(t)
+		c.reverseWorkers.Store("w1", link)
+
+		// A recorded route to a connected worker matches
+		c.reverseWorkerSessionAuthorized(testAuthorizedSession("s_0987654321"), `"/name" == "w2"`)
+		assert.Equal([]string{"w1", "w2"}, c.reverseWorkerRoute("s_0987654321"))
+		assert.True(c.matchReverseWorkerSession(&tls.ClientHelloInfo{ServerName: "s_0987654321"}))
+
+		// A route to a worker which has disconnected doesn't
+		c.reverseRoutes.Set("s_1111111111", [][]string{{"gone"}}, time.Minute)
+		assert.False(c.matchReverseWorkerSession(&tls.ClientHelloInfo{ServerName: "s_1111111111"}))
+
+		// Nor does an unknown session, once it has waited for its route
+		start := time.Now()
+		assert.False(c.matchReverseWorkerSession(&tls.ClientHelloInfo{ServerName: sessionId}))
+		assert.GreaterOrEqual(time.Since(start), reverseRouteWait)
+
+		// Handshakes never look up their routes
+		assert.Zero(atomic.LoadInt32(lookups))
+	})
+
+	t.Run("wait-for-route", func(t *testing.T) {
+		c, lookups := testReverseController(t)
+		c.reverseWorkerServers.Store(workers)
+		link, _ := testReverseWorkerLink(t)
+		c.reverseWorkers.Store("w1", link)
+
+		// A handshake racing its session's authorization matches once the
+		// route is recorded
+		matched := make(chan bool)
+		go func() {
+			matched <- c.matchReverseWorkerSession(&tls.ClientHelloInfo{ServerName: sessionId})
+		}()
+		time.Sleep(100 * time.Millisecond)
+		c.reverseWorkerSessionAuthorized(testAuthorizedSession(sessionId), "")
+		select {
+		case ok := <-matched:
+			assert.True(t, ok)
+		case <-time.After(reverseRouteWait / 2):
+			require.Fail(t, "match did not see the recorded route")
+		}
+		assert.Zero(t, atomic.LoadInt32(lookups))
+	})
+
+	t.Run("expired-session", func(t *testing.T) {
+		c, _ := testReverseController(t)
+		c.reverseWorkerServers.Store(workers)
+		sess := testAuthorizedSession(sessionId)
+		sess.ExpirationTime = &timestamp.Timestamp{Timestamp: timestamppb.New(time.Now().Add(-time.Second))}
+		c.reverseWorkerSessionAuthorized(sess, "")
+		_, ok := c.reverseRoutes.Get(sessionId)
+		assert.False(t, ok)
+	})
+}
+
+func TestForwardReverseWorkerSession(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	c, _ := testReverseController(t)
+	link, workerLink := testReverseWorkerLink(t)
+	c.reverseWorkers.Store("w1", link)
+
+	client, clientConn := net.Pipe()
+	defer client.Close()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.forwardReverseWorkerSession(clientConn, []string{"w1", "w2"})
+	}()
+
+	stream, err := workerLink.Accept()
+	require.NoError(err)
+	defer stream.Close()
+	header := new(servers.HopHeader)
+	require.NoError(servers.ReadHopFrame(stream, header))
+	assert.Equal(clientConn.RemoteAddr().String(), header.ClientAddr)
+	// The first worker of the route is the one forwarded to
+	assert.Equal([]string{"w2"}, header.Route)
+
+	go client.Write([]byte("ping"))
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(stream, buf)
+	require.NoError(err)
+	assert.Equal("ping", string(buf))
+
+	go stream.Write([]byte("pong"))
+	_, err = io.ReadFull(client, buf)
+	require.NoError(err)
+	assert.Equal("pong", string(buf))
+
+	// Closing the client closes the stream to the worker
+	require.NoError(client.Close())
+	_, err = stream.Read(buf)
+	assert.Equal(io.EOF, err)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		require.Fail("forwarding did not finish")
+	}
+}
+
+// testConnectReverseWorker connects a reverse worker named name to c,
+// returning the worker's end of the connection once its hello is read.
+func testConnectReverseWorker(t *testing.T, c *Controller, name string) *yamux.Session {
+	t.Helper()
+	require := require.New(t)
+	nonce := []byte(name + "-nonce-0123456789abcdef")[:20]
+	c.workerAuthCache.Set(string(nonce), &workerAuthEntry{
+		WorkerAuthInfo: &base.WorkerAuthInfo{Name: name},
+	}, 0)
+
+	workerConn, controllerConn := net.Pipe()
+	go c.handleReverseWorker(controllerConn)
+	_, err := workerConn.Write(nonce)
+	require.NoError(err)
+	hello := new(servers.HopHello)
+	require.NoError(servers.ReadHopFrame(workerConn, hello))
+	assert.Equal(t, "test-controller", hello.Name)
+	// Clients are sent to the controller-proxy listener
+	assert.Equal(t, "127.0.0.1:9203", hello.Address)
+
+	workerSession, err := yamux.Server(workerConn, nil)
+	require.NoError(err)
+	t.Cleanup(func() { workerSession.Close() })
+	return workerSession
+}
+
+func TestHandleReverseWorker(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reconnect", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		c, _ := testReverseController(t)
+		loaded := func() *yamux.Session {
+			raw, ok := c.reverseWorkers.Load("w1")
+			if !ok {
+				return nil
+			}
+			return raw.(*yamux.Session)
+		}
+
+		first := testConnectReverseWorker(t, c, "w1")
+		require.Eventually(func() bool { return loaded() != nil }, 5*time.Second, 10*time.Millisecond)
+		firstLink := loaded()
+
+		// A reconnecting worker replaces its previous connection
+		testConnectReverseWorker(t, c, "w1")
+		require.Eventually(func() bool { return loaded() != firstLink }, 5*time.Second, 10*time.Millisecond)
+		secondLink := loaded()
+		require.NotNil(secondLink)
+
+		// and the previous connection closing doesn't forget the new one
+		require.NoError(first.Close())
+		<-firstLink.CloseChan()
+		assert.Never(func() bool { return loaded() != secondLink }, 200*time.Millisecond, 10*time.Millisecond)
+
+		require.NoError(secondLink.Close())
+		assert.Eventually(func() bool { return loaded() == nil }, 5*time.Second, 10*time.Millisecond)
+		assert.False(c.hasReverseWorkers())
+	})
+
+	t.Run("unknown-nonce", func(t *testing.T) {
+		c, _ := testReverseController(t)
+		workerConn, controllerConn := net.Pipe()
+		defer workerConn.Close()
+		go c.handleReverseWorker(controllerConn)
+		_, err := workerConn.Write(make([]byte, 20))
+		require.NoError(t, err)
+		_, err = workerConn.Read(make([]byte, 1))
+		assert.Equal(t, io.EOF, err)
+		assert.False(t, c.hasReverseWorkers())
+	})
+
+	t.Run("no-proxy-listener", func(t *testing.T) {
+		c, _ := testReverseController(t)
+		c.conf.RawConfig.Controller.PublicProxyAddr = ""
+		nonce := []byte("01234567890123456789")
+		c.workerAuthCache.Set(string(nonce), &workerAuthEntry{
+			WorkerAuthInfo: &base.WorkerAuthInfo{Name: "w1"},
+		}, 0)
+		workerConn, controllerConn := net.Pipe()
+		defer workerConn.Close()
+		go c.handleReverseWorker(controllerConn)
+		_, err := workerConn.Write(nonce)
+		require.NoError(t, err)
+		_, err = workerConn.Read(make([]byte, 1))
+		assert.Equal(t, io.EOF, err)
+		assert.False(t, c.hasReverseWorkers())
+	})
+}
@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/session"
+)
+
+// The states of sessions and their connections are recorded in the repository
+// by whichever controller handles each change: a worker authorizing,
+// connecting or closing a connection, an admin canceling a session, or the
+// termination of completed and expired sessions. Each controller polls the
+// recent state changes and publishes them to its session watchers, so that
+// every controller streams every change.
+const (
+	// sessionEventsPollInterval is how often the state changes are polled
+	sessionEventsPollInterval = time.Second
+	// sessionEventsPollWindow is how far back each poll looks for state
+	// changes. It overlaps the previous polls so that changes committed late
+	// are not missed.
+	sessionEventsPollWindow = 5 * time.Second
+)
+
+// sessionStateChanges publishes state changes once, however many polls they
+// are seen by.
+type sessionStateChanges struct {
+	// seen holds the time each change was first seen, until it can no longer
+	// be within the poll window
+	seen map[string]time.Time
+}
+
+func newSessionStateChanges() *sessionStateChanges {
+	return &sessionStateChanges{seen: make(map[string]time.Time)}
+}
+
+// publish publishes the changes which weren't seen before to events.
+func (s *sessionStateChanges) publish(events *common.SessionEvents, changes []*session.StateChange, now time.Time) {
+	for k, t := range s.seen {
+		if now.Sub(t) > 2*sessionEventsPollWindow {
+			delete(s.seen, k)
+		}
+	}
+	for _, c := range changes {
+		key := c.SessionId + "/" + c.ConnectionId + "/" + c.State
+		if _, ok := s.seen[key]; ok {
+			continue
+		}
+		s.seen[key] = now
+		e := stateChangeToEvent(c)
+		if e == nil {
+			continue
+		}
+		events.Publish(e)
+	}
+}
+
+func stateChangeToEvent(c *session.StateChange) *common.ConnectionEvent {
+	var typ common.ConnectionEventType
+	switch c.ConnectionId {
+	case "":
+		switch session.Status(c.State) {
+		case session.StatusPending:
+			typ = common.SessionPendingEvent
+		case session.StatusActive:
+			typ = common.SessionActiveEvent
+		case session.StatusCanceling:
+			typ = common.SessionCancelingEvent
+		case session.StatusTerminated:
+			typ = common.SessionTerminatedEvent
+		default:
+			return nil
+		}
+	default:
+		switch session.ConnectionStatus(c.State) {
+		case session.StatusAuthorized:
+			typ = common.ConnectionAuthorizedEvent
+		case session.StatusConnected:
+			typ = common.ConnectionConnectedEvent
+		case session.StatusClosed:
+			typ = common.ConnectionClosedEvent
+		default:
+			return nil
+		}
+	}
+	e := &common.ConnectionEvent{
+		Type:             typ,
+		SessionId:        c.SessionId,
+		ConnectionId:     c.ConnectionId,
+		ClientTcpAddress: c.ClientTcpAddress,
+		ClientTcpPort:    c.ClientTcpPort,
+		BytesUp:          c.BytesUp,
+		BytesDown:        c.BytesDown,
+		ClosedReason:     c.Reason,
+	}
+	if ts := c.StartTime.GetTimestamp(); ts != nil {
+		e.Time = ts.AsTime()
+	}
+	return e
+}
+
+// pollSessionStateChanges publishes the state changes recorded within the
+// poll window which weren't published yet.
+func (c *Controller) pollSessionStateChanges(ctx context.Context, changes *sessionStateChanges) error {
+	sessRepo, err := c.SessionRepoFn()
+	if err != nil {
+		return err
+	}
+	found, err := sessRepo.ListStateChanges(ctx, sessionEventsPollWindow)
+	if err != nil {
+		return err
+	}
+	changes.publish(c.sessionEvents, found, time.Now())
+	return nil
+}
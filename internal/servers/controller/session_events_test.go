@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestSessionStateChanges(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	events := common.NewSessionEvents()
+	ch, stop := events.Watch()
+	defer stop()
+
+	start := time.Now().Add(-time.Second).Truncate(time.Millisecond)
+	changes := []*session.StateChange{
+		{
+			SessionId: "s_1",
+			State:     session.StatusCanceling.String(),
+			StartTime: &timestamp.Timestamp{Timestamp: timestamppb.New(start)},
+		},
+		{
+			SessionId:    "s_2",
+			ConnectionId: "sc_1",
+			State:        session.StatusClosed.String(),
+			BytesUp:      10,
+			BytesDown:    20,
+			Reason:       session.ConnectionCanceled.String(),
+		},
+		{
+			SessionId: "s_2",
+			State:     session.StatusTerminated.String(),
+			Reason:    "canceled",
+		},
+	}
+
+	now := time.Now()
+	s := newSessionStateChanges()
+	s.publish(events, changes, now)
+	require.Len(ch, 3)
+	e := <-ch
+	assert.Equal(common.SessionCancelingEvent, e.Type)
+	assert.Equal("s_1", e.SessionId)
+	assert.Empty(e.ConnectionId)
+	assert.True(start.Equal(e.Time))
+	e = <-ch
+	assert.Equal(common.ConnectionClosedEvent, e.Type)
+	assert.Equal("sc_1", e.ConnectionId)
+	assert.Equal(uint64(10), e.BytesUp)
+	assert.Equal(uint64(20), e.BytesDown)
+	assert.Equal(session.ConnectionCanceled.String(), e.ClosedReason)
+	e = <-ch
+	assert.Equal(common.SessionTerminatedEvent, e.Type)
+	assert.Equal("canceled", e.ClosedReason)
+
+	// Changes seen by overlapping polls are published once
+	s.publish(events, changes[1:], now.Add(sessionEventsPollInterval))
+	assert.Len(ch, 0)
+
+	// Changes are forgotten once they are out of every poll window
+	s.publish(events, nil, now.Add(3*sessionEventsPollWindow))
+	assert.Empty(s.seen)
+}
@@ -5,6 +5,7 @@ import (
 	"math/rand"
 	"time"
 
+	"github.com/hashicorp/boundary/internal/auth/ldap"
 	"github.com/hashicorp/boundary/internal/servers"
 	"github.com/hashicorp/boundary/internal/types/resource"
 )
@@ -15,6 +16,9 @@ const (
 	terminationInterval = 1 * time.Minute
 )
 
+// This is exported so it can be tweaked in tests
+var ExpiredPrincipalRoleCleanupInterval = 1 * time.Minute
+
 // This is exported so it can be tweaked in tests
 var RecoveryNonceCleanupInterval = 2 * time.Minute
 
@@ -52,6 +56,76 @@ func (c *Controller) startStatusTicking(cancelCtx context.Context) {
 	}()
 }
 
+// startReverseWorkerServersTicking keeps the workers used to route sessions
+// through reverse-connected workers up to date with their status updates.
+func (c *Controller) startReverseWorkerServersTicking(cancelCtx context.Context) {
+	go func() {
+		timer := time.NewTimer(0)
+		for {
+			select {
+			case <-cancelCtx.Done():
+				c.logger.Info("reverse worker servers ticking shutting down")
+				return
+
+			case <-timer.C:
+				if c.hasReverseWorkers() {
+					if err := c.refreshReverseWorkerServers(cancelCtx); err != nil {
+						c.logger.Error("error refreshing workers for reverse worker routes", "error", err)
+					}
+				}
+				timer.Reset(statusInterval)
+			}
+		}
+	}()
+}
+
+// startReverseWorkerRoutesTicking records the routes through reverse-connected
+// workers of the sessions authorized through other controllers.
+func (c *Controller) startReverseWorkerRoutesTicking(cancelCtx context.Context) {
+	go func() {
+		timer := time.NewTimer(0)
+		for {
+			select {
+			case <-cancelCtx.Done():
+				c.logger.Info("reverse worker routes ticking shutting down")
+				return
+
+			case <-timer.C:
+				if c.hasReverseWorkers() {
+					if err := c.pollReverseWorkerRoutes(cancelCtx); err != nil {
+						c.logger.Error("error polling reverse worker routes", "error", err)
+					}
+				}
+				timer.Reset(reverseRoutePollInterval)
+			}
+		}
+	}()
+}
+
+// startSessionEventsTicking publishes the state changes of sessions and their
+// connections to the session watchers of this controller.
+func (c *Controller) startSessionEventsTicking(cancelCtx context.Context) {
+	go func() {
+		changes := newSessionStateChanges()
+		timer := time.NewTimer(0)
+		for {
+			select {
+			case <-cancelCtx.Done():
+				c.logger.Info("session events ticking shutting down")
+				return
+
+			case <-timer.C:
+				if c.sessionEvents.Watched() {
+					if err := c.pollSessionStateChanges(cancelCtx, changes); err != nil {
+						c.logger.Error("error polling session state changes", "error", err)
+					}
+				}
+				timer.Reset(sessionEventsPollInterval)
+			}
+		}
+	}()
+}
+
 func (c *Controller) startRecoveryNonceCleanupTicking(cancelCtx context.Context) {
 	go func() {
 		timer := time.NewTimer(0)
@@ -116,3 +190,67 @@ func (c *Controller) startTerminateCompletedSessionsTicking(cancelCtx context.Co
 		}
 	}()
 }
+
+func (c *Controller) startExpiredPrincipalRoleCleanupTicking(cancelCtx context.Context) {
+	go func() {
+		timer := time.NewTimer(0)
+		for {
+			select {
+			case <-cancelCtx.Done():
+				c.logger.Info("expired principal role ticking shutting down")
+				return
+
+			case <-timer.C:
+				repo, err := c.IamRepoFn()
+				if err != nil {
+					c.logger.Error("error fetching repository for expired principal role cleanup", "error", err)
+				} else {
+					deleteCount, err := repo.DeleteExpiredPrincipalRoles(cancelCtx)
+					if err != nil {
+						c.logger.Error("error performing expired principal role cleanup", "error", err)
+					}
+					if deleteCount > 0 {
+						c.logger.Info("expired principal role cleanup successful", "principal_roles_deleted", deleteCount)
+					}
+				}
+				timer.Reset(ExpiredPrincipalRoleCleanupInterval)
+			}
+		}
+	}()
+}
+
+func (c *Controller) startLdapGroupSyncTicking(cancelCtx context.Context) {
+	gs := c.conf.RawConfig.Controller.GroupSync
+	if gs == nil || gs.Ldap == nil {
+		return
+	}
+	go func() {
+		timer := time.NewTimer(0)
+		for {
+			select {
+			case <-cancelCtx.Done():
+				c.logger.Info("ldap group sync ticking shutting down")
+				return
+
+			case <-timer.C:
+				repo, err := c.IamRepoFn()
+				var ldapRepo *ldap.Repository
+				if err == nil {
+					ldapRepo, err = c.LdapAuthRepoFn()
+				}
+				if err != nil {
+					c.logger.Error("error fetching repositories for ldap group sync", "error", err)
+				} else {
+					syncCount, err := syncLdapGroups(cancelCtx, repo, ldapRepo, gs.Ldap)
+					if err != nil {
+						c.logger.Error("error performing ldap group sync", "error", err)
+					}
+					if syncCount > 0 {
+						c.logger.Debug("ldap group sync successful", "groups_synced", syncCount)
+					}
+				}
+				timer.Reset(gs.IntervalDuration)
+			}
+		}
+	}()
+}
@@ -3,3 +3,7 @@ package servers
 func (s *Server) TableName() string {
 	return "server"
 }
+
+func (s *ServerTag) TableName() string {
+	return "server_tag"
+}
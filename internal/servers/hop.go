@@ -0,0 +1,71 @@
+package servers
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+)
+
+// HopProto is the ALPN protocol negotiated on the connections workers make
+// to receive session streams: to the proxy listener of an upstream worker,
+// or to the cluster listener of a controller for reverse-connected workers.
+// The connecting worker authenticates with the worker-auth KMS and writes
+// its connection nonce, the accepting server replies with a HopHello and
+// then forwards session streams, multiplexed with yamux, each preceded by a
+// HopHeader.
+const HopProto = "v1workerhop"
+
+const maxHopFrameLength = 64 * 1024
+
+// HopHello is sent to a worker once it has authenticated a hop connection
+type HopHello struct {
+	// Name is the name of the worker or controller accepting the connection
+	Name string `json:"name"`
+
+	// Address is set by controllers to the address at which clients reach
+	// the reverse-connected worker
+	Address string `json:"address,omitempty"`
+}
+
+// HopHeader precedes a session stream forwarded to a worker
+type HopHeader struct {
+	// ClientAddr is the address of the client of the session
+	ClientAddr string `json:"client_addr"`
+
+	// Route holds the names of the workers to which the receiving worker
+	// forwards the stream. It is empty if the receiving worker handles the
+	// session.
+	Route []string `json:"route,omitempty"`
+}
+
+// WriteHopFrame writes v as a length-prefixed JSON frame to conn
+func WriteHopFrame(conn net.Conn, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, 4+len(b))
+	binary.BigEndian.PutUint32(buf, uint32(len(b)))
+	copy(buf[4:], b)
+	_, err = conn.Write(buf)
+	return err
+}
+
+// ReadHopFrame reads a frame written by WriteHopFrame from conn into v
+func ReadHopFrame(conn net.Conn, v interface{}) error {
+	var length [4]byte
+	if _, err := io.ReadFull(conn, length[:]); err != nil {
+		return err
+	}
+	n := binary.BigEndian.Uint32(length[:])
+	if n > maxHopFrameLength {
+		return fmt.Errorf("hop frame of %d bytes exceeds the maximum length", n)
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(conn, b); err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
@@ -0,0 +1,31 @@
+package servers
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHopFrames(t *testing.T) {
+	c1, c2 := net.Pipe()
+	defer c1.Close()
+	defer c2.Close()
+
+	header := &HopHeader{ClientAddr: "127.0.0.1:5000", Route: []string{"mid", "egress"}}
+	go func() {
+		assert.NoError(t, WriteHopFrame(c1, header))
+	}()
+	got := new(HopHeader)
+	require.NoError(t, ReadHopFrame(c2, got))
+	assert.Equal(t, header, got)
+
+	go func() {
+		var length [4]byte
+		binary.BigEndian.PutUint32(length[:], maxHopFrameLength+1)
+		c1.Write(length[:])
+	}()
+	assert.Error(t, ReadHopFrame(c2, new(HopHello)))
+}
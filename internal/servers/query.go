@@ -2,4 +2,19 @@ package servers
 
 const (
 	deleteWhereSql = `create_time < $1`
+
+	deleteServerTagsSql = `
+	delete from server_tag
+	where
+		server_id = $1 and
+		server_type = $2;
+	`
+
+	insertServerTagSql = `
+	insert into server_tag
+		(server_id, server_type, key, value)
+	values
+		($1, $2, $3, $4)
+	on conflict do nothing;
+	`
 )
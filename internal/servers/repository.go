@@ -72,6 +72,40 @@ func (r *Repository) ListServers(ctx context.Context, serverType ServerType, opt
 	); err != nil {
 		return nil, fmt.Errorf("error listing servers: %w", err)
 	}
+	if len(servers) == 0 {
+		return servers, nil
+	}
+
+	// Attach tags to the returned servers
+	serverIds := make([]string, 0, len(servers))
+	for _, s := range servers {
+		serverIds = append(serverIds, s.PrivateId)
+	}
+	var serverTags []*ServerTag
+	if err := r.reader.SearchWhere(
+		ctx,
+		&serverTags,
+		"server_type = ? and server_id in (?)",
+		[]interface{}{serverType, serverIds},
+		db.WithLimit(-1),
+	); err != nil {
+		return nil, fmt.Errorf("error listing server tags: %w", err)
+	}
+	tagsById := make(map[string]map[string]*TagValues, len(servers))
+	for _, t := range serverTags {
+		tags := tagsById[t.ServerId]
+		if tags == nil {
+			tags = make(map[string]*TagValues)
+			tagsById[t.ServerId] = tags
+		}
+		if tags[t.Key] == nil {
+			tags[t.Key] = &TagValues{}
+		}
+		tags[t.Key].Values = append(tags[t.Key].Values, t.Value)
+	}
+	for _, s := range servers {
+		s.Tags = tagsById[s.PrivateId]
+	}
 	return servers, nil
 }
 
@@ -85,26 +119,52 @@ func (r *Repository) UpsertServer(ctx context.Context, server *Server, opt ...Op
 	// Build query
 	q := `
 	insert into server
-		(private_id, type, name, description, address, update_time)
+		(private_id, type, name, description, address, update_time, upstream)
 	values
-		($1, $2, $3, $4, $5, $6)
+		($1, $2, $3, $4, $5, $6, nullif($7, ''))
 	on conflict on constraint server_pkey
 	do update set
 		name = $3,
 		description = $4,
 		address = $5,
-		update_time = $6;
+		update_time = $6,
+		upstream = nullif($7, '');
 	`
 
-	rowsAffected, err := r.writer.Exec(ctx, q,
-		[]interface{}{server.PrivateId,
-			server.Type,
-			server.Name,
-			server.Description,
-			server.Address,
-			time.Now().Format(time.RFC3339)})
+	var rowsAffected int
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			var err error
+			rowsAffected, err = w.Exec(ctx, q,
+				[]interface{}{server.PrivateId,
+					server.Type,
+					server.Name,
+					server.Description,
+					server.Address,
+					time.Now().Format(time.RFC3339),
+					server.Upstream})
+			if err != nil {
+				return fmt.Errorf("error performing status upsert: %w", err)
+			}
+			// Replace the tags with the set most recently reported by the server
+			if _, err := w.Exec(ctx, deleteServerTagsSql, []interface{}{server.PrivateId, server.Type}); err != nil {
+				return fmt.Errorf("error removing existing server tags: %w", err)
+			}
+			for k, v := range server.Tags {
+				for _, val := range v.GetValues() {
+					if _, err := w.Exec(ctx, insertServerTagSql, []interface{}{server.PrivateId, server.Type, k, val}); err != nil {
+						return fmt.Errorf("error inserting server tag: %w", err)
+					}
+				}
+			}
+			return nil
+		},
+	)
 	if err != nil {
-		return nil, db.NoRowsAffected, fmt.Errorf("error performing status upsert: %w", err)
+		return nil, db.NoRowsAffected, err
 	}
 	// If updating a controller, done
 	if server.Type == resource.Controller.String() {
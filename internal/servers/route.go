@@ -0,0 +1,93 @@
+package servers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-bexpr"
+	"github.com/mitchellh/pointerstructure"
+)
+
+// FilterWorkers returns the workers matching the boolean expression filter,
+// which is evaluated against each worker's name and tags. All workers are
+// returned if filter is empty.
+func FilterWorkers(workers []*Server, filter string) ([]*Server, error) {
+	if filter == "" {
+		return workers, nil
+	}
+	eval, err := bexpr.CreateEvaluator(filter)
+	if err != nil {
+		return nil, fmt.Errorf("error creating worker filter evaluator: %w", err)
+	}
+	var ret []*Server
+	for _, w := range workers {
+		tags := make(map[string][]string, len(w.GetTags()))
+		for k, tv := range w.GetTags() {
+			tags[k] = tv.GetValues()
+		}
+		ok, err := eval.Evaluate(map[string]interface{}{
+			"name": w.GetName(),
+			"tags": tags,
+		})
+		if err != nil && !errors.Is(err, pointerstructure.ErrNotFound) {
+			return nil, fmt.Errorf("error evaluating worker filter: %w", err)
+		}
+		if ok {
+			ret = append(ret, w)
+		}
+	}
+	return ret, nil
+}
+
+// upstreamChain returns w followed by the workers through which it is
+// reached, ending with the worker that accepts client connections. It
+// returns nil if an upstream worker is not in workers, which happens when
+// it is no longer live, or if the upstreams form a cycle.
+func upstreamChain(workers []*Server, w *Server) []*Server {
+	byName := make(map[string]*Server, len(workers))
+	for _, v := range workers {
+		byName[v.GetName()] = v
+	}
+	chain := []*Server{w}
+	for w.GetUpstream() != "" {
+		w = byName[w.GetUpstream()]
+		if w == nil || len(chain) > len(workers) {
+			return nil
+		}
+		chain = append(chain, w)
+	}
+	return chain
+}
+
+// IngressWorker returns the worker to which clients connect to reach w:
+// w itself if it has no upstream, otherwise the last worker in its chain of
+// upstreams. workers must contain all live workers. It returns nil if w
+// cannot currently be reached.
+func IngressWorker(workers []*Server, w *Server) *Server {
+	chain := upstreamChain(workers, w)
+	if len(chain) == 0 {
+		return nil
+	}
+	return chain[len(chain)-1]
+}
+
+// WorkerRoute returns the names of the workers, in order, through which the
+// worker named from forwards a session stream to reach w, ending with w. It
+// returns nil if from is w or is not one of w's upstreams.
+func WorkerRoute(workers []*Server, from string, w *Server) []string {
+	chain := upstreamChain(workers, w)
+	for i, v := range chain {
+		if v.GetName() != from {
+			continue
+		}
+		route := make([]string, 0, i)
+		for j := i - 1; j >= 0; j-- {
+			route = append(route, chain[j].GetName())
+		}
+		if len(route) == 0 {
+			return nil
+		}
+		return route
+	}
+	return nil
+}
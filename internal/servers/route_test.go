@@ -0,0 +1,51 @@
+package servers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterWorkers(t *testing.T) {
+	workers := []*Server{
+		{Name: "w1", Tags: map[string]*TagValues{"region": {Values: []string{"east"}}}},
+		{Name: "w2", Tags: map[string]*TagValues{"region": {Values: []string{"west"}}}},
+		{Name: "w3"},
+	}
+	got, err := FilterWorkers(workers, "")
+	require.NoError(t, err)
+	assert.Equal(t, workers, got)
+
+	got, err = FilterWorkers(workers, `"east" in "/tags/region"`)
+	require.NoError(t, err)
+	assert.Equal(t, []*Server{workers[0]}, got)
+
+	got, err = FilterWorkers(workers, `"/name" == "w3"`)
+	require.NoError(t, err)
+	assert.Equal(t, []*Server{workers[2]}, got)
+
+	_, err = FilterWorkers(workers, `"/name" ==`)
+	assert.Error(t, err)
+}
+
+func TestWorkerRoute(t *testing.T) {
+	ingress := &Server{Name: "ingress", Address: "ingress:9202"}
+	mid := &Server{Name: "mid", Upstream: "ingress"}
+	egress := &Server{Name: "egress", Upstream: "mid"}
+	orphan := &Server{Name: "orphan", Upstream: "gone"}
+	loopA := &Server{Name: "a", Upstream: "b"}
+	loopB := &Server{Name: "b", Upstream: "a"}
+	workers := []*Server{ingress, mid, egress, orphan, loopA, loopB}
+
+	assert.Equal(t, ingress, IngressWorker(workers, ingress))
+	assert.Equal(t, ingress, IngressWorker(workers, egress))
+	assert.Nil(t, IngressWorker(workers, orphan))
+	assert.Nil(t, IngressWorker(workers, loopA))
+
+	assert.Equal(t, []string{"mid", "egress"}, WorkerRoute(workers, "ingress", egress))
+	assert.Equal(t, []string{"egress"}, WorkerRoute(workers, "mid", egress))
+	assert.Nil(t, WorkerRoute(workers, "egress", egress))
+	assert.Nil(t, WorkerRoute(workers, "ingress", ingress))
+	assert.Nil(t, WorkerRoute(workers, "orphan", egress))
+}
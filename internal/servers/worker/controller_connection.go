@@ -129,7 +129,10 @@ func (w *Worker) createClientConn(addr string) error {
 	return nil
 }
 
-func (w Worker) workerAuthTLSConfig() (*tls.Config, *base.WorkerAuthInfo, error) {
+// workerAuthTLSConfig returns the TLS configuration authenticating the
+// worker with the worker-auth KMS. extraAlpnProtos are offered in addition
+// to the auth information.
+func (w Worker) workerAuthTLSConfig(extraAlpnProtos ...string) (*tls.Config, *base.WorkerAuthInfo, error) {
 	var err error
 	info := &base.WorkerAuthInfo{
 		Name:        w.conf.RawConfig.Worker.Name,
@@ -206,6 +209,7 @@ func (w Worker) workerAuthTLSConfig() (*tls.Config, *base.WorkerAuthInfo, error)
 		nextProtos = append(nextProtos, fmt.Sprintf("v1workerauth-%02d-%s", count, b64alpn[i:end]))
 		count++
 	}
+	nextProtos = append(nextProtos, extraAlpnProtos...)
 
 	cert, err := x509.ParseCertificate(certBytes)
 	if err != nil {
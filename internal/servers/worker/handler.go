@@ -4,6 +4,7 @@ import (
 	"context"
 	"net"
 	"net/http"
+	"net/url"
 	"strconv"
 
 	"github.com/hashicorp/boundary/globals"
@@ -78,7 +79,7 @@ func (w *Worker) handleProxy() http.HandlerFunc {
 		w.logger.Trace("found session in session info map")
 
 		opts := &websocket.AcceptOptions{
-			Subprotocols: []string{globals.TcpProxyV1},
+			Subprotocols: []string{globals.TcpProxyV1, globals.UdpProxyV1},
 		}
 		conn, err := websocket.Accept(wr, r, opts)
 		if err != nil {
@@ -170,7 +171,25 @@ func (w *Worker) handleProxy() http.HandlerFunc {
 
 		switch conn.Subprotocol() {
 		case globals.TcpProxyV1:
-			w.handleTcpProxyV1(connCtx, clientAddr, conn, si, ci.id, endpoint)
+			// Clients always speak the tcp proxy protocol; for http and ssh
+			// targets the worker terminates the stream and speaks the
+			// target's protocol to the endpoint.
+			endpointUrl, err := url.Parse(endpoint)
+			if err != nil {
+				w.logger.Error("error parsing endpoint information", "error", err, "session_id", sessionId, "endpoint", endpoint)
+				conn.Close(websocket.StatusInternalError, "cannot parse endpoint url")
+				return
+			}
+			switch endpointUrl.Scheme {
+			case "http":
+				w.handleHttpProxyV1(connCtx, clientAddr, conn, si, ci.id, endpoint)
+			case "ssh":
+				w.handleSshProxyV1(connCtx, clientAddr, conn, si, ci.id, endpoint)
+			default:
+				w.handleTcpProxyV1(connCtx, clientAddr, conn, si, ci.id, endpoint)
+			}
+		case globals.UdpProxyV1:
+			w.handleUdpProxyV1(connCtx, clientAddr, conn, si, ci.id, endpoint)
 		default:
 			conn.Close(websocket.StatusProtocolError, "unsupported-protocol")
 			return
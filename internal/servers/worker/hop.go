@@ -0,0 +1,457 @@
+package worker
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/libs/alpnmux"
+	"github.com/hashicorp/boundary/internal/servers"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/yamux"
+	"google.golang.org/protobuf/proto"
+)
+
+// Workers which accept no inbound connections dial out to an upstream
+// worker's proxy listener, or with reverse_connect to a controller's cluster
+// listener, and authenticate with the worker-auth KMS the same way workers
+// authenticate with controllers. The upstream forwards the streams of the
+// sessions the worker handles over the connection. Streams are forwarded
+// before their TLS handshake, so the session TLS is still terminated by the
+// worker which handles the session.
+const (
+	hopProto = servers.HopProto
+
+	// connectionNonceLength is the length of the nonce written by a
+	// downstream worker after its handshake
+	connectionNonceLength = 20
+
+	hopHandshakeTimeout   = 10 * time.Second
+	upstreamRetryInterval = 5 * time.Second
+	downstreamRouteTTL    = time.Minute
+	// unknownSessionTTL is how long a session unknown to the controller is
+	// remembered, so repeated handshakes for it don't reach the controller
+	unknownSessionTTL = 10 * time.Second
+	// maxSessionLookups bounds the session lookups made at once for client
+	// handshakes
+	maxSessionLookups = 10
+)
+
+func (w *Worker) yamuxConfig() *yamux.Config {
+	conf := yamux.DefaultConfig()
+	conf.LogOutput = nil
+	conf.Logger = w.logger.StandardLogger(nil)
+	return conf
+}
+
+// validateHopTls returns the TLS configuration for a connection from a
+// downstream worker, built from the worker auth information it encrypted
+// with the worker-auth KMS.
+func (w *Worker) validateHopTls(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+	var encString string
+	for _, p := range hello.SupportedProtos {
+		if strings.HasPrefix(p, "v1workerauth-") && len(p) > len("v1workerauth-")+3 {
+			// Strip that and the number
+			encString += strings.TrimPrefix(p, "v1workerauth-")[3:]
+		}
+	}
+	if encString == "" {
+		return nil, errors.New("no worker auth information found")
+	}
+	marshaledEncInfo, err := base64.RawStdEncoding.DecodeString(encString)
+	if err != nil {
+		return nil, err
+	}
+	encInfo := new(wrapping.EncryptedBlobInfo)
+	if err := proto.Unmarshal(marshaledEncInfo, encInfo); err != nil {
+		return nil, err
+	}
+	marshaledInfo, err := w.conf.WorkerAuthKms.Decrypt(context.Background(), encInfo, nil)
+	if err != nil {
+		return nil, err
+	}
+	info := new(base.WorkerAuthInfo)
+	if err := json.Unmarshal(marshaledInfo, info); err != nil {
+		return nil, err
+	}
+
+	rootCAs := x509.NewCertPool()
+	if ok := rootCAs.AppendCertsFromPEM(info.CertPEM); !ok {
+		return nil, errors.New("unable to add ca cert to cert pool")
+	}
+	tlsCert, err := tls.X509KeyPair(info.CertPEM, info.KeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set the info we need to prevent replays
+	w.hopAuthCache.SetDefault(info.ConnectionNonce, info)
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{tlsCert},
+		ClientCAs:    rootCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		NextProtos:   []string{hopProto},
+		MinVersion:   tls.VersionTLS13,
+	}, nil
+}
+
+// acceptDownstreams accepts the connections of downstream workers on a
+// proxy listener until it is closed.
+func (w *Worker) acceptDownstreams(l net.Listener) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		go w.handleDownstream(conn)
+	}
+}
+
+func (w *Worker) handleDownstream(conn net.Conn) {
+	if err := conn.SetDeadline(time.Now().Add(hopHandshakeTimeout)); err != nil {
+		w.logger.Error("error setting downstream handshake deadline", "error", err)
+		conn.Close()
+		return
+	}
+	nonce := make([]byte, connectionNonceLength)
+	if _, err := io.ReadFull(conn, nonce); err != nil {
+		w.logger.Error("error reading downstream worker connection nonce", "error", err)
+		conn.Close()
+		return
+	}
+	raw, ok := w.hopAuthCache.Get(string(nonce))
+	if !ok {
+		w.logger.Error("unknown downstream worker connection nonce")
+		conn.Close()
+		return
+	}
+	w.hopAuthCache.Delete(string(nonce))
+	info := raw.(*base.WorkerAuthInfo)
+
+	if err := servers.WriteHopFrame(conn, &servers.HopHello{Name: w.conf.RawConfig.Worker.Name}); err != nil {
+		w.logger.Error("error writing hello to downstream worker", "name", info.Name, "error", err)
+		conn.Close()
+		return
+	}
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		w.logger.Error("error clearing downstream handshake deadline", "error", err)
+		conn.Close()
+		return
+	}
+
+	session, err := yamux.Client(conn, w.yamuxConfig())
+	if err != nil {
+		w.logger.Error("error creating downstream worker session", "name", info.Name, "error", err)
+		conn.Close()
+		return
+	}
+	w.downstreams.Store(info.Name, session)
+	w.logger.Info("downstream worker connected", "name", info.Name)
+
+	<-session.CloseChan()
+	// Only forget the session if the worker has not reconnected since
+	if raw, ok := w.downstreams.Load(info.Name); ok && raw.(*yamux.Session) == session {
+		w.downstreams.Delete(info.Name)
+	}
+	w.logger.Info("downstream worker disconnected", "name", info.Name)
+}
+
+func (w *Worker) hasDownstreams() bool {
+	var found bool
+	w.downstreams.Range(func(_, _ interface{}) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
+// matchDownstreamSession is the passthrough match function of the proxy
+// listeners. It reports whether the session named in the SNI of hello is
+// handled by a downstream worker, recording the route to that worker. A
+// session's route is looked up once; the lookup of a session handled by this
+// worker is kept for its session TLS configuration.
+func (w *Worker) matchDownstreamSession(hello *tls.ClientHelloInfo) bool {
+	sessionId := hello.ServerName
+	if !validSessionId(sessionId) || !w.hasDownstreams() {
+		return false
+	}
+	if raw, ok := w.downstreamRoutes.Get(sessionId); ok {
+		return len(raw.([]string)) > 0
+	}
+	resp, err := w.handshakeLookupSession(sessionId)
+	if err != nil {
+		// Let the session TLS lookup handle it, and any error
+		return false
+	}
+	if len(resp.GetRoute()) == 0 {
+		w.downstreamRoutes.SetDefault(sessionId, []string{})
+		w.sessionLookups.SetDefault(sessionId, resp)
+		return false
+	}
+	w.downstreamRoutes.SetDefault(sessionId, resp.GetRoute())
+	return true
+}
+
+// acceptPassthrough forwards the session connections matched by
+// matchDownstreamSession on a proxy listener until it is closed.
+func (w *Worker) acceptPassthrough(l net.Listener) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		pc, ok := conn.(*alpnmux.PassthroughConn)
+		if !ok {
+			conn.Close()
+			continue
+		}
+		raw, ok := w.downstreamRoutes.Get(pc.ServerName)
+		if !ok {
+			w.logger.Error("no route found for session", "session_id", pc.ServerName)
+			conn.Close()
+			continue
+		}
+		go w.forwardSession(conn, conn.RemoteAddr().String(), raw.([]string))
+	}
+}
+
+// forwardSession forwards conn, the stream of a session, to the first worker
+// in route, which forwards it along the rest of route.
+func (w *Worker) forwardSession(conn net.Conn, clientAddr string, route []string) {
+	defer conn.Close()
+	raw, ok := w.downstreams.Load(route[0])
+	if !ok {
+		w.logger.Error("downstream worker is not connected", "name", route[0])
+		return
+	}
+	stream, err := raw.(*yamux.Session).Open()
+	if err != nil {
+		w.logger.Error("error opening stream to downstream worker", "name", route[0], "error", err)
+		return
+	}
+	defer stream.Close()
+	if err := servers.WriteHopFrame(stream, &servers.HopHeader{ClientAddr: clientAddr, Route: route[1:]}); err != nil {
+		w.logger.Error("error writing header to downstream worker", "name", route[0], "error", err)
+		return
+	}
+
+	connWg := new(sync.WaitGroup)
+	connWg.Add(2)
+	go func() {
+		defer connWg.Done()
+		_, err := io.Copy(stream, conn)
+		w.logger.Debug("copy from client to downstream worker done", "error", err)
+		stream.Close()
+	}()
+	go func() {
+		defer connWg.Done()
+		_, err := io.Copy(conn, stream)
+		w.logger.Debug("copy from downstream worker to client done", "error", err)
+		conn.Close()
+	}()
+	connWg.Wait()
+}
+
+// startUpstreamConnections connects the worker to its upstream workers, or
+// to its controllers if it is reverse-connected, and serves the session
+// streams they forward to it.
+func (w *Worker) startUpstreamConnections() {
+	upstreams, defaultPort := w.conf.RawConfig.Worker.Upstreams, "9202"
+	if w.conf.RawConfig.Worker.ReverseConnect {
+		upstreams, defaultPort = w.conf.RawConfig.Worker.Controllers, "9201"
+	}
+	if len(upstreams) == 0 {
+		return
+	}
+	w.hopListener = &hopListener{
+		ctx:    w.baseContext,
+		connCh: make(chan net.Conn),
+	}
+	cancelCtx := w.baseContext
+	server := &http.Server{
+		Handler:           w.handler(HandlerProperties{}),
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		ErrorLog:          w.logger.StandardLogger(nil),
+		BaseContext: func(net.Listener) context.Context {
+			return cancelCtx
+		},
+	}
+	go server.Serve(tls.NewListener(w.hopListener, &tls.Config{
+		GetConfigForClient: w.getSessionTls,
+	}))
+	go w.connectUpstreams(w.baseContext, upstreams, defaultPort)
+}
+
+// connectUpstreams keeps the worker connected to one of upstreams, trying
+// them in order, until ctx is done. defaultPort is used for addresses
+// without a port.
+func (w *Worker) connectUpstreams(ctx context.Context, upstreams []string, defaultPort string) {
+	for i := 0; ; i = (i + 1) % len(upstreams) {
+		addr := upstreams[i]
+		if _, _, err := net.SplitHostPort(addr); err != nil && strings.Contains(err.Error(), "missing port in address") {
+			addr = net.JoinHostPort(addr, defaultPort)
+		}
+		if err := w.serveUpstream(ctx, addr); err != nil && ctx.Err() == nil {
+			w.logger.Error("error in connection to upstream", "address", addr, "error", err)
+		}
+		w.upstream.Store("")
+		w.reverseAddr.Store("")
+		select {
+		case <-ctx.Done():
+			w.logger.Info("upstream connections shutting down")
+			return
+		case <-time.After(upstreamRetryInterval):
+		}
+	}
+}
+
+// serveUpstream connects to the upstream worker or controller at addr and
+// handles the streams it forwards until the connection is closed.
+func (w *Worker) serveUpstream(ctx context.Context, addr string) error {
+	tlsConf, authInfo, err := w.workerAuthTLSConfig(hopProto)
+	if err != nil {
+		return fmt.Errorf("error creating tls config for worker auth: %w", err)
+	}
+	dialer := &net.Dialer{Timeout: hopHandshakeTimeout}
+	nonTlsConn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("unable to dial upstream: %w", err)
+	}
+	conn := tls.Client(nonTlsConn, tlsConf)
+	if err := conn.SetDeadline(time.Now().Add(hopHandshakeTimeout)); err != nil {
+		conn.Close()
+		return err
+	}
+	if _, err := conn.Write([]byte(authInfo.ConnectionNonce)); err != nil {
+		conn.Close()
+		return fmt.Errorf("unable to write connection nonce: %w", err)
+	}
+	var hello servers.HopHello
+	if err := servers.ReadHopFrame(conn, &hello); err != nil {
+		conn.Close()
+		return fmt.Errorf("unable to read hello: %w", err)
+	}
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		conn.Close()
+		return err
+	}
+
+	session, err := yamux.Server(conn, w.yamuxConfig())
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer session.Close()
+	go func() {
+		select {
+		case <-ctx.Done():
+			session.Close()
+		case <-session.CloseChan():
+		}
+	}()
+
+	if hello.Address != "" {
+		// Clients reach the worker through the controller
+		w.reverseAddr.Store(hello.Address)
+		w.logger.Info("reverse-connected to controller", "name", hello.Name, "address", addr)
+	} else {
+		w.upstream.Store(hello.Name)
+		w.logger.Info("connected to upstream worker", "name", hello.Name, "address", addr)
+	}
+	for {
+		stream, err := session.Accept()
+		if err != nil {
+			return fmt.Errorf("upstream connection closed: %w", err)
+		}
+		go w.handleHopStream(stream)
+	}
+}
+
+// handleHopStream handles a session stream forwarded by the upstream,
+// forwarding it further if its route continues.
+func (w *Worker) handleHopStream(stream net.Conn) {
+	var header servers.HopHeader
+	if err := stream.SetDeadline(time.Now().Add(hopHandshakeTimeout)); err != nil {
+		stream.Close()
+		return
+	}
+	if err := servers.ReadHopFrame(stream, &header); err != nil {
+		w.logger.Error("error reading header from upstream", "error", err)
+		stream.Close()
+		return
+	}
+	if err := stream.SetDeadline(time.Time{}); err != nil {
+		stream.Close()
+		return
+	}
+	if len(header.Route) > 0 {
+		w.forwardSession(stream, header.ClientAddr, header.Route)
+		return
+	}
+	conn := &hopConn{Conn: stream, remoteAddr: stream.RemoteAddr()}
+	if clientAddr, err := net.ResolveTCPAddr("tcp", header.ClientAddr); err == nil {
+		conn.remoteAddr = clientAddr
+	}
+	w.hopListener.deliver(conn)
+}
+
+// hopConn is a session stream forwarded by the upstream, which
+// reports the address of the session's client as its remote address.
+type hopConn struct {
+	net.Conn
+	remoteAddr net.Addr
+}
+
+func (c *hopConn) RemoteAddr() net.Addr {
+	return c.remoteAddr
+}
+
+// hopListener is a net.Listener of the session streams forwarded to the
+// worker by its upstream.
+type hopListener struct {
+	ctx    context.Context
+	connCh chan net.Conn
+}
+
+func (l *hopListener) deliver(conn net.Conn) {
+	select {
+	case l.connCh <- conn:
+	case <-l.ctx.Done():
+		conn.Close()
+	}
+}
+
+func (l *hopListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.connCh:
+		return conn, nil
+	case <-l.ctx.Done():
+		return nil, errors.New("hop listener closed")
+	}
+}
+
+func (l *hopListener) Close() error {
+	return nil
+}
+
+func (l *hopListener) Addr() net.Addr {
+	return hopAddr{}
+}
+
+type hopAddr struct{}
+
+func (hopAddr) Network() string { return "hop" }
+func (hopAddr) String() string  { return "hop" }
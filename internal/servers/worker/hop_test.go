@@ -0,0 +1,91 @@
+package worker
+
+import (
+	"crypto/tls"
+	"testing"
+
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidSessionId(t *testing.T) {
+	t.Parallel()
+	for id, want := range map[string]bool{
+		"s_1234567890":      true,
+		"s_AbC123":          true,
+		"":                  false,
+		"s_":                false,
+		"s_abc.example.com": false,
+		"s_abc/../def":      false,
+		"hst_1234567890":    false,
+		"example.com":       false,
+	} {
+		assert.Equal(t, want, validSessionId(id), "id %q", id)
+	}
+}
+
+func TestMatchDownstreamSession(t *testing.T) {
+	t.Parallel()
+	hello := func(sessionId string) *tls.ClientHelloInfo {
+		return &tls.ClientHelloInfo{ServerName: sessionId}
+	}
+	sessions := map[string]*pbs.LookupSessionResponse{
+		"s_downstream": {Route: []string{"w2", "w3"}},
+		"s_local":      {},
+	}
+
+	t.Run("no-downstreams", func(t *testing.T) {
+		client := &testSessionClient{sessions: sessions}
+		w := testWorker(t, client)
+		assert.False(t, w.matchDownstreamSession(hello("s_downstream")))
+		assert.Zero(t, client.lookupCount())
+	})
+
+	t.Run("lookups", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		client := &testSessionClient{sessions: sessions}
+		w := testWorker(t, client)
+		w.downstreams.Store("w2", nil)
+
+		// Malformed session IDs are never looked up
+		for _, sni := range []string{"", "example.com", "s_", "s_abc.example.com"} {
+			assert.False(w.matchDownstreamSession(hello(sni)), "sni %q", sni)
+		}
+		assert.Zero(client.lookupCount())
+
+		// The route of a session is looked up once
+		for i := 0; i < 2; i++ {
+			assert.True(w.matchDownstreamSession(hello("s_downstream")))
+		}
+		assert.Equal(1, client.lookupCount())
+		raw, ok := w.downstreamRoutes.Get("s_downstream")
+		require.True(ok)
+		assert.Equal([]string{"w2", "w3"}, raw.([]string))
+
+		// An unknown session is remembered
+		for i := 0; i < 2; i++ {
+			assert.False(w.matchDownstreamSession(hello("s_unknown")))
+			_, err := w.getSessionTls(hello("s_unknown"))
+			assert.Equal(errUnknownSession, err)
+		}
+		assert.Equal(2, client.lookupCount())
+
+		// The lookup of a session handled by this worker is used by its
+		// session TLS instead of being repeated
+		assert.False(w.matchDownstreamSession(hello("s_local")))
+		assert.Equal(3, client.lookupCount())
+		raw, ok = w.sessionLookups.Get("s_local")
+		require.True(ok)
+		assert.Equal(sessions["s_local"], raw)
+		// The session has no expiration, so its TLS configuration fails
+		// after the lookup
+		_, err := w.getSessionTls(hello("s_local"))
+		require.Error(err)
+		assert.Equal(3, client.lookupCount())
+		_, ok = w.sessionLookups.Get("s_local")
+		assert.False(ok)
+		assert.False(w.matchDownstreamSession(hello("s_local")))
+		assert.Equal(3, client.lookupCount())
+	})
+}
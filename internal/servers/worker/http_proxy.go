@@ -0,0 +1,223 @@
+package worker
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"nhooyr.io/websocket"
+
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+)
+
+// httpRecordQueueSize bounds the requests of a connection waiting to be
+// recorded. Proxying waits for room in the queue once it is full.
+const httpRecordQueueSize = 64
+
+// handleHttpProxyV1 terminates the client stream of a connection to an http
+// target. Requests are read from the client one at a time, the target's
+// headers are added, and the request is forwarded over a single connection to
+// the endpoint. The method, path and status of each request are recorded on
+// the connection in the background.
+func (w *Worker) handleHttpProxyV1(connCtx context.Context, clientAddr *net.TCPAddr, conn *websocket.Conn, si *sessionInfo, connectionId, endpoint string) {
+	si.RLock()
+	sessionId := si.lookupSessionResponse.GetAuthorization().GetSessionId()
+	headers := si.lookupSessionResponse.GetHttpHeaders()
+	si.RUnlock()
+
+	sessionUrl, err := url.Parse(endpoint)
+	if err != nil {
+		w.logger.Error("error parsing endpoint information", "error", err, "session_id", sessionId, "endpoint", endpoint)
+		conn.Close(websocket.StatusInternalError, "cannot parse endpoint url")
+		return
+	}
+	if sessionUrl.Scheme != "http" {
+		w.logger.Error("invalid scheme for http proxy", "session_id", sessionId, "endpoint", endpoint)
+		conn.Close(websocket.StatusInternalError, "invalid scheme for type")
+		return
+	}
+	remoteConn, err := net.Dial("tcp", sessionUrl.Host)
+	if err != nil {
+		w.logger.Error("error dialing endpoint", "error", err, "endpoint", endpoint)
+		conn.Close(websocket.StatusInternalError, "endpoint dialing failed")
+		return
+	}
+	tcpRemoteConn := remoteConn.(*net.TCPConn)
+	defer tcpRemoteConn.Close()
+
+	endpointAddr := tcpRemoteConn.RemoteAddr().(*net.TCPAddr)
+	connectionInfo := &pbs.ConnectConnectionRequest{
+		ConnectionId:       connectionId,
+		ClientTcpAddress:   clientAddr.IP.String(),
+		ClientTcpPort:      uint32(clientAddr.Port),
+		EndpointTcpAddress: endpointAddr.IP.String(),
+		EndpointTcpPort:    uint32(endpointAddr.Port),
+		Type:               "http",
+	}
+
+	connStatus, err := w.connectConnection(connCtx, connectionInfo)
+	if err != nil {
+		w.logger.Error("error marking connection as connected", "error", err)
+		conn.Close(websocket.StatusInternalError, "failed to mark connection as connected")
+		return
+	}
+	si.Lock()
+	ci := si.connInfoMap[connectionId]
+	ci.status = connStatus
+	si.Unlock()
+
+	// Get a wrapped net.Conn so we can read requests from it
+	netConn := websocket.NetConn(connCtx, conn, websocket.MessageBinary)
+	defer netConn.Close()
+
+	clientReader := bufio.NewReader(netConn)
+	clientWriter := &countingWriter{Writer: netConn, add: ci.addBytesDown}
+	remoteReader := bufio.NewReader(tcpRemoteConn)
+	remoteWriter := &countingWriter{Writer: tcpRemoteConn, add: ci.addBytesUp}
+
+	recorder := w.startHttpRequestRecorder(connectionId)
+	defer recorder.close()
+
+	for {
+		req, err := http.ReadRequest(clientReader)
+		if err != nil {
+			if err != io.EOF {
+				w.logger.Debug("error reading request from client", "error", err, "connection_id", connectionId)
+			}
+			return
+		}
+		path := req.URL.RequestURI()
+
+		// Requests sent to the local proxy carry its address as the host, which
+		// the endpoint won't recognize.
+		host := req.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if isLocalHost(host) {
+			req.Host = sessionUrl.Host
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+
+		var resp *http.Response
+		if err = req.Write(remoteWriter); err == nil {
+			resp, err = http.ReadResponse(remoteReader, req)
+		}
+		if err != nil {
+			w.logger.Error("error proxying request to endpoint", "error", err, "connection_id", connectionId, "endpoint", endpoint)
+			recorder.record(req.Method, path, http.StatusBadGateway)
+			fmt.Fprintf(clientWriter, "HTTP/1.1 %d %s\r\nContent-Length: 0\r\nConnection: close\r\n\r\n", http.StatusBadGateway, http.StatusText(http.StatusBadGateway))
+			return
+		}
+		recorder.record(req.Method, path, int32(resp.StatusCode))
+
+		if resp.StatusCode == http.StatusSwitchingProtocols {
+			// The endpoint agreed to switch protocols (e.g. to a websocket),
+			// so the rest of the connection is proxied as a plain stream.
+			err := resp.Write(clientWriter)
+			resp.Body.Close()
+			if err != nil {
+				w.logger.Debug("error writing response to client", "error", err, "connection_id", connectionId)
+				return
+			}
+			w.copyUpgraded(clientReader, remoteReader, clientWriter, remoteWriter, netConn, tcpRemoteConn)
+			return
+		}
+
+		err = resp.Write(clientWriter)
+		resp.Body.Close()
+		if err != nil {
+			w.logger.Debug("error writing response to client", "error", err, "connection_id", connectionId)
+			return
+		}
+		if req.Close || resp.Close {
+			return
+		}
+	}
+}
+
+// copyUpgraded proxies the connection in both directions after the endpoint
+// has switched protocols. Data already buffered by the readers is copied
+// first. The client connection is closed once the endpoint is done, which
+// ends the copy from the client.
+func (w *Worker) copyUpgraded(clientReader, remoteReader io.Reader, clientWriter, remoteWriter io.Writer, clientConn net.Conn, remoteConn *net.TCPConn) {
+	connWg := new(sync.WaitGroup)
+	connWg.Add(2)
+	go func() {
+		defer connWg.Done()
+		_, err := io.Copy(clientWriter, remoteReader)
+		w.logger.Debug("copy from endpoint to client done", "error", err)
+		clientConn.Close()
+	}()
+	go func() {
+		defer connWg.Done()
+		_, err := io.Copy(remoteWriter, clientReader)
+		_ = remoteConn.CloseWrite()
+		w.logger.Debug("copy from client to endpoint done", "error", err)
+	}()
+	connWg.Wait()
+}
+
+// httpRequestRecorder records the requests proxied over a connection, in
+// order, without holding up their responses.
+type httpRequestRecorder struct {
+	connectionId string
+	reqs         chan *pbs.RecordHttpRequestRequest
+	done         chan struct{}
+}
+
+// startHttpRequestRecorder starts recording the requests of a connection. The
+// recorder must be closed once the connection is done.
+func (w *Worker) startHttpRequestRecorder(connectionId string) *httpRequestRecorder {
+	r := &httpRequestRecorder{
+		connectionId: connectionId,
+		reqs:         make(chan *pbs.RecordHttpRequestRequest, httpRecordQueueSize),
+		done:         make(chan struct{}),
+	}
+	go func() {
+		defer close(r.done)
+		for req := range r.reqs {
+			// Requests are recorded after the connection is done too, so
+			// they don't use its context. Failing to record a request does
+			// not interrupt the connection.
+			if err := w.recordHttpRequest(w.baseContext, req); err != nil {
+				w.logger.Error("error recording http request", "error", err, "connection_id", connectionId, "method", req.GetMethod(), "path", req.GetPath())
+			}
+		}
+	}()
+	return r
+}
+
+// record queues a proxied request to be recorded.
+func (r *httpRequestRecorder) record(method, path string, statusCode int32) {
+	r.reqs <- &pbs.RecordHttpRequestRequest{
+		ConnectionId: r.connectionId,
+		Method:       method,
+		Path:         path,
+		StatusCode:   statusCode,
+	}
+}
+
+// close waits for the queued requests to be recorded.
+func (r *httpRequestRecorder) close() {
+	close(r.reqs)
+	<-r.done
+}
+
+// isLocalHost reports whether host refers to the local machine, which is
+// where clients connect to the local proxy started by boundary connect.
+func isLocalHost(host string) bool {
+	if host == "" || strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(strings.Trim(host, "[]"))
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,186 @@
+package worker
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"nhooyr.io/websocket"
+)
+
+func TestHandleHttpProxyV1(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+
+	var mu sync.Mutex
+	var gotHosts, gotHeaders []string
+	endpoint := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		gotHosts = append(gotHosts, r.Host)
+		gotHeaders = append(gotHeaders, r.Header.Get("X-Api-Key"))
+		mu.Unlock()
+		if r.URL.Path == "/missing" {
+			http.NotFound(wr, r)
+			return
+		}
+		fmt.Fprintf(wr, "hello from %s", r.URL.RequestURI())
+	}))
+	t.Cleanup(endpoint.Close)
+	endpointHost := endpoint.Listener.Addr().String()
+
+	client := &testSessionClient{}
+	w := testWorker(t, client)
+	si := testSessionInfo(t, &pbs.LookupSessionResponse{
+		HttpHeaders: map[string]string{"X-Api-Key": "secret"},
+	}, "sc_1234567890")
+	ci := si.connInfoMap["sc_1234567890"]
+	conn := testProxy(t, si, "sc_1234567890", func(ctx context.Context, clientAddr *net.TCPAddr, conn *websocket.Conn) {
+		w.handleHttpProxyV1(ctx, clientAddr, conn, si, "sc_1234567890", "http://"+endpointHost)
+	})
+	netConn := websocket.NetConn(context.Background(), conn, websocket.MessageBinary)
+	clientReader := bufio.NewReader(netConn)
+
+	// Requests are proxied one at a time over the same connection
+	send := func(method, target, host string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequest(method, "http://"+host+target, nil)
+		require.NoError(err)
+		// The client's value is replaced by the target's
+		req.Header.Set("X-Api-Key", "client")
+		require.NoError(req.Write(netConn))
+		resp, err := http.ReadResponse(clientReader, req)
+		require.NoError(err)
+		return resp
+	}
+
+	resp := send(http.MethodGet, "/hello?x=1", "localhost:9999")
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(err)
+	resp.Body.Close()
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal("hello from /hello?x=1", string(body))
+
+	resp = send(http.MethodDelete, "/missing", "api.example.com")
+	_, err = ioutil.ReadAll(resp.Body)
+	require.NoError(err)
+	resp.Body.Close()
+	assert.Equal(http.StatusNotFound, resp.StatusCode)
+
+	mu.Lock()
+	// Only the local proxy's address is rewritten to the endpoint's
+	assert.Equal([]string{endpointHost, "api.example.com"}, gotHosts)
+	assert.Equal([]string{"secret", "secret"}, gotHeaders)
+	mu.Unlock()
+
+	// Requests are recorded in the background
+	require.Eventually(func() bool {
+		return len(client.recordedHttpRequests()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	recorded := client.recordedHttpRequests()
+	assert.Equal("sc_1234567890", recorded[0].GetConnectionId())
+	assert.Equal(http.MethodGet, recorded[0].GetMethod())
+	assert.Equal("/hello?x=1", recorded[0].GetPath())
+	assert.Equal(int32(http.StatusOK), recorded[0].GetStatusCode())
+	assert.Equal(http.MethodDelete, recorded[1].GetMethod())
+	assert.Equal("/missing", recorded[1].GetPath())
+	assert.Equal(int32(http.StatusNotFound), recorded[1].GetStatusCode())
+
+	assert.Eventually(func() bool {
+		return ci.BytesUp() > 0 && ci.BytesDown() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Closing the endpoint connection fails the next request with a bad
+	// gateway response, after which the worker closes the connection
+	endpoint.CloseClientConnections()
+	resp = send(http.MethodGet, "/gone", "localhost")
+	resp.Body.Close()
+	assert.Equal(http.StatusBadGateway, resp.StatusCode)
+	assert.True(resp.Close)
+	_, err = clientReader.ReadByte()
+	assert.Error(err)
+
+	require.Eventually(func() bool {
+		return len(client.recordedHttpRequests()) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	recorded = client.recordedHttpRequests()
+	assert.Equal("/gone", recorded[2].GetPath())
+	assert.Equal(int32(http.StatusBadGateway), recorded[2].GetStatusCode())
+}
+
+func TestHandleHttpProxyV1_upgrade(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+
+	// The endpoint switches protocols, sends a greeting and hangs up
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		c, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		if _, err := http.ReadRequest(bufio.NewReader(c)); err != nil {
+			return
+		}
+		fmt.Fprint(c, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: test\r\nConnection: Upgrade\r\n\r\nhello")
+	}()
+
+	client := &testSessionClient{}
+	w := testWorker(t, client)
+	si := testSessionInfo(t, &pbs.LookupSessionResponse{}, "sc_1234567890")
+	conn := testProxy(t, si, "sc_1234567890", func(ctx context.Context, clientAddr *net.TCPAddr, conn *websocket.Conn) {
+		w.handleHttpProxyV1(ctx, clientAddr, conn, si, "sc_1234567890", "http://"+l.Addr().String())
+	})
+	netConn := websocket.NetConn(context.Background(), conn, websocket.MessageBinary)
+	clientReader := bufio.NewReader(netConn)
+
+	req, err := http.NewRequest(http.MethodGet, "http://localhost/upgrade", nil)
+	require.NoError(err)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "test")
+	require.NoError(req.Write(netConn))
+	resp, err := http.ReadResponse(clientReader, req)
+	require.NoError(err)
+	assert.Equal(http.StatusSwitchingProtocols, resp.StatusCode)
+
+	// The endpoint hanging up closes the client's connection rather than
+	// leaving it waiting on the client
+	read := make(chan []byte)
+	go func() {
+		got, _ := ioutil.ReadAll(clientReader)
+		read <- got
+	}()
+	select {
+	case got := <-read:
+		assert.Equal("hello", string(got))
+	case <-time.After(5 * time.Second):
+		require.Fail("client connection was not closed")
+	}
+}
+
+func TestIsLocalHost(t *testing.T) {
+	t.Parallel()
+	for host, want := range map[string]bool{
+		"":                true,
+		"localhost":       true,
+		"LOCALHOST":       true,
+		"127.0.0.1":       true,
+		"[::1]":           true,
+		"10.0.0.1":        false,
+		"api.example.com": false,
+	} {
+		assert.Equal(t, want, isLocalHost(host), "host %q", host)
+	}
+}
@@ -0,0 +1,45 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+)
+
+// startJobChanges keeps a stream of job change requests open to a controller,
+// through which requests such as the termination of a connection reach the
+// worker without waiting for its next status. The stream is reopened, to the
+// same or another controller, whenever it ends.
+func (w *Worker) startJobChanges(cancelCtx context.Context) {
+	go func() {
+		for {
+			if err := w.receiveJobChanges(cancelCtx); err != nil && cancelCtx.Err() == nil {
+				w.logger.Debug("job changes stream ended", "error", err)
+			}
+			select {
+			case <-cancelCtx.Done():
+				w.logger.Info("job changes stream shutting down")
+				return
+			case <-time.After(statusInterval):
+			}
+		}
+	}()
+}
+
+func (w *Worker) receiveJobChanges(ctx context.Context) error {
+	client := w.controllerStatusConn.Load().(pbs.ServerCoordinationServiceClient)
+	stream, err := client.JobChanges(ctx, &pbs.JobChangesRequest{
+		WorkerId: w.conf.RawConfig.Worker.Name,
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		request, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		w.handleJobChange(request)
+	}
+}
@@ -0,0 +1,33 @@
+package worker
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceiveJobChanges(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	w := testWorker(t, &testSessionClient{})
+	si := testSessionInfo(t, &pbs.LookupSessionResponse{}, "sc_terminate", "sc_keep")
+	w.sessionInfoMap.Store(si.id, si)
+	w.controllerStatusConn.Store(pbs.ServerCoordinationServiceClient(&testCoordinationClient{
+		jobChanges: []*pbs.JobChangeRequest{
+			testJobChange(si.id, pbs.SESSIONSTATUS_SESSIONSTATUS_UNSPECIFIED,
+				&pbs.Connection{ConnectionId: "sc_terminate", Status: pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CLOSED}),
+		},
+	}))
+
+	// Requests are applied as they arrive until the stream ends
+	assert.Equal(io.EOF, w.receiveJobChanges(context.Background()))
+	si.RLock()
+	assert.Error(si.connInfoMap["sc_terminate"].connCtx.Err())
+	assert.Equal(session.ConnectionCanceled, si.connInfoMap["sc_terminate"].closeReason)
+	assert.NoError(si.connInfoMap["sc_keep"].connCtx.Err())
+	si.RUnlock()
+}
@@ -21,7 +21,7 @@ func (w *Worker) startListeners() error {
 	for _, ln := range w.conf.Listeners {
 		for _, purpose := range ln.Config.Purpose {
 			switch purpose {
-			case "api", "cluster":
+			case "api", "cluster", "controller-proxy":
 				// We may have this in dev mode; ignore
 				continue
 
@@ -75,8 +75,25 @@ func (w *Worker) startListeners() error {
 				return errors.New("could not get tls listener")
 			}
 
+			// Accept downstream workers and forward the connections of the
+			// sessions they handle
+			ln.Mux.UnregisterProto(hopProto)
+			ln.Mux.UnregisterProto(alpnmux.PassthroughProto)
+			hopLn, err := ln.Mux.RegisterProto(hopProto, &tls.Config{
+				GetConfigForClient: w.validateHopTls,
+			})
+			if err != nil {
+				return fmt.Errorf("error getting downstream worker listener: %w", err)
+			}
+			passthroughLn, err := ln.Mux.RegisterPassthrough(w.matchDownstreamSession)
+			if err != nil {
+				return fmt.Errorf("error getting passthrough listener: %w", err)
+			}
+
 			servers = append(servers, func() {
 				go server.Serve(l)
+				go w.acceptDownstreams(hopLn)
+				go w.acceptPassthrough(passthroughLn)
 			})
 		}
 	}
@@ -7,12 +7,16 @@ import (
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/hashicorp/boundary/internal/session"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -20,11 +24,52 @@ const (
 )
 
 type connInfo struct {
-	id         string
-	connCtx    context.Context
-	connCancel context.CancelFunc
-	status     pbs.CONNECTIONSTATUS
-	closeTime  time.Time
+	// bytesUp, bytesDown and lastActivity are accessed atomically and are kept
+	// first in the struct to guarantee 64-bit alignment
+	bytesUp   uint64
+	bytesDown uint64
+	// lastActivity is the time, in Unix nanoseconds, at which the connection
+	// was authorized or last transferred bytes
+	lastActivity int64
+	id           string
+	connCtx      context.Context
+	connCancel   context.CancelFunc
+	status       pbs.CONNECTIONSTATUS
+	closeTime    time.Time
+	// closeReason is reported to the controller when the connection is
+	// closed. It is set when the connection is closed at the request of the
+	// controller or for being idle; otherwise the reason is unknown.
+	closeReason session.ClosedReason
+}
+
+// BytesUp returns the number of bytes sent from the client to the endpoint so
+// far on this connection.
+func (ci *connInfo) BytesUp() uint64 {
+	return atomic.LoadUint64(&ci.bytesUp)
+}
+
+// BytesDown returns the number of bytes sent from the endpoint to the client so
+// far on this connection.
+func (ci *connInfo) BytesDown() uint64 {
+	return atomic.LoadUint64(&ci.bytesDown)
+}
+
+// addBytesUp records n bytes sent from the client to the endpoint.
+func (ci *connInfo) addBytesUp(n uint64) {
+	atomic.AddUint64(&ci.bytesUp, n)
+	atomic.StoreInt64(&ci.lastActivity, time.Now().UnixNano())
+}
+
+// addBytesDown records n bytes sent from the endpoint to the client.
+func (ci *connInfo) addBytesDown(n uint64) {
+	atomic.AddUint64(&ci.bytesDown, n)
+	atomic.StoreInt64(&ci.lastActivity, time.Now().UnixNano())
+}
+
+// idleTime returns how long the connection has gone without transferring
+// bytes.
+func (ci *connInfo) idleTime() time.Duration {
+	return time.Since(time.Unix(0, atomic.LoadInt64(&ci.lastActivity)))
 }
 
 type sessionInfo struct {
@@ -39,7 +84,7 @@ type sessionInfo struct {
 func (w *Worker) getSessionTls(hello *tls.ClientHelloInfo) (*tls.Config, error) {
 	var sessionId string
 	switch {
-	case strings.HasPrefix(hello.ServerName, "s_"):
+	case validSessionId(hello.ServerName):
 		w.logger.Trace("got valid session in SNI", "session_id", hello.ServerName)
 		sessionId = hello.ServerName
 	default:
@@ -47,30 +92,19 @@ func (w *Worker) getSessionTls(hello *tls.ClientHelloInfo) (*tls.Config, error)
 		return nil, fmt.Errorf("could not find session ID in SNI")
 	}
 
-	rawConn := w.controllerSessionConn.Load()
-	if rawConn == nil {
-		w.logger.Trace("could not get a controller client", "session_id", sessionId)
-		return nil, errors.New("could not get a controller client")
-	}
-	conn, ok := rawConn.(pbs.SessionServiceClient)
-	if !ok {
-		w.logger.Trace("could not cast controller client to the real thing", "session_id", sessionId)
-		return nil, errors.New("could not cast atomic controller client to the real thing")
-	}
-	if conn == nil {
-		w.logger.Trace("controller client is nil", "session_id", sessionId)
-		return nil, errors.New("controller client is nil")
+	var resp *pbs.LookupSessionResponse
+	if raw, ok := w.sessionLookups.Get(sessionId); ok {
+		if r, ok := raw.(*pbs.LookupSessionResponse); ok {
+			// Looked up by matchDownstreamSession for this handshake
+			w.sessionLookups.Delete(sessionId)
+			resp = r
+		}
 	}
-
-	timeoutContext, cancel := context.WithTimeout(w.baseContext, validateSessionTimeout)
-	defer cancel()
-
-	w.logger.Trace("looking up session", "session_id", sessionId)
-	resp, err := conn.LookupSession(timeoutContext, &pbs.LookupSessionRequest{
-		SessionId: sessionId,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("error validating session: %w", err)
+	if resp == nil {
+		var err error
+		if resp, err = w.handshakeLookupSession(sessionId); err != nil {
+			return nil, err
+		}
 	}
 
 	if resp.GetExpiration().AsTime().Before(time.Now()) {
@@ -127,6 +161,82 @@ func (w *Worker) getSessionTls(hello *tls.ClientHelloInfo) (*tls.Config, error)
 	return tlsConf, nil
 }
 
+// errUnknownSession is returned by lookupSession for sessions the controller
+// doesn't know.
+var errUnknownSession = errors.New("unknown session")
+
+// validSessionId reports whether id is in the form of a session ID.
+func validSessionId(id string) bool {
+	if !strings.HasPrefix(id, "s_") || len(id) == len("s_") {
+		return false
+	}
+	for _, r := range id[len("s_"):] {
+		if !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// handshakeLookupSession looks up the session named by the SNI of a client
+// handshake. The handshake is unauthenticated, so sessions unknown to the
+// controller are remembered for a while instead of being looked up again,
+// and a bounded number of lookups are made at once.
+func (w *Worker) handshakeLookupSession(sessionId string) (*pbs.LookupSessionResponse, error) {
+	if raw, ok := w.sessionLookups.Get(sessionId); ok && raw == errUnknownSession {
+		return nil, errUnknownSession
+	}
+	timer := time.NewTimer(hopHandshakeTimeout)
+	defer timer.Stop()
+	select {
+	case w.sessionLookupSlots <- struct{}{}:
+		defer func() { <-w.sessionLookupSlots }()
+	case <-timer.C:
+		return nil, errors.New("too many session lookups in progress")
+	}
+	resp, err := w.lookupSession(sessionId)
+	if err == errUnknownSession {
+		// Only a lookup which found no session is remembered; other errors
+		// may be transient
+		w.sessionLookups.Set(sessionId, errUnknownSession, unknownSessionTTL)
+	}
+	return resp, err
+}
+
+// lookupSession looks up the session with the controller
+func (w *Worker) lookupSession(sessionId string) (*pbs.LookupSessionResponse, error) {
+	rawConn := w.controllerSessionConn.Load()
+	if rawConn == nil {
+		w.logger.Trace("could not get a controller client", "session_id", sessionId)
+		return nil, errors.New("could not get a controller client")
+	}
+	conn, ok := rawConn.(pbs.SessionServiceClient)
+	if !ok {
+		w.logger.Trace("could not cast controller client to the real thing", "session_id", sessionId)
+		return nil, errors.New("could not cast atomic controller client to the real thing")
+	}
+	if conn == nil {
+		w.logger.Trace("controller client is nil", "session_id", sessionId)
+		return nil, errors.New("controller client is nil")
+	}
+
+	timeoutContext, cancel := context.WithTimeout(w.baseContext, validateSessionTimeout)
+	defer cancel()
+
+	w.logger.Trace("looking up session", "session_id", sessionId)
+	resp, err := conn.LookupSession(timeoutContext, &pbs.LookupSessionRequest{
+		SessionId: sessionId,
+		WorkerId:  w.conf.RawConfig.Worker.Name,
+	})
+	if err != nil {
+		if status.Code(err) == codes.PermissionDenied {
+			return nil, errUnknownSession
+		}
+		return nil, fmt.Errorf("error validating session: %w", err)
+	}
+	return resp, nil
+}
+
 func (w *Worker) activateSession(ctx context.Context, sessionId, tofuToken string, version uint32) (pbs.SESSIONSTATUS, error) {
 	rawConn := w.controllerSessionConn.Load()
 	if rawConn == nil {
@@ -173,8 +283,9 @@ func (w *Worker) authorizeConnection(ctx context.Context, sessionId string) (*co
 	}
 
 	return &connInfo{
-		id:     resp.ConnectionId,
-		status: resp.GetStatus(),
+		id:           resp.ConnectionId,
+		status:       resp.GetStatus(),
+		lastActivity: time.Now().UnixNano(),
 	}, resp.GetConnectionsLeft(), nil
 }
 
@@ -203,6 +314,68 @@ func (w *Worker) connectConnection(ctx context.Context, req *pbs.ConnectConnecti
 	return resp.GetStatus(), nil
 }
 
+func (w *Worker) recordHttpRequest(ctx context.Context, req *pbs.RecordHttpRequestRequest) error {
+	rawConn := w.controllerSessionConn.Load()
+	if rawConn == nil {
+		return errors.New("could not get a controller client")
+	}
+	conn, ok := rawConn.(pbs.SessionServiceClient)
+	if !ok {
+		return errors.New("could not cast atomic controller client to the real thing")
+	}
+	if conn == nil {
+		return errors.New("controller client is nil")
+	}
+
+	_, err := conn.RecordHttpRequest(ctx, req)
+	return err
+}
+
+// uploadSessionRecording sends a recording to the controller. The data is
+// read from r and sent in chunks over several messages so that large
+// recordings don't have to fit in memory or in a single message.
+func (w *Worker) uploadSessionRecording(ctx context.Context, req *pbs.UploadSessionRecordingRequest, r io.Reader) (string, error) {
+	rawConn := w.controllerSessionConn.Load()
+	if rawConn == nil {
+		return "", errors.New("could not get a controller client")
+	}
+	conn, ok := rawConn.(pbs.SessionServiceClient)
+	if !ok {
+		return "", errors.New("could not cast atomic controller client to the real thing")
+	}
+	if conn == nil {
+		return "", errors.New("controller client is nil")
+	}
+
+	stream, err := conn.UploadSessionRecording(ctx)
+	if err != nil {
+		return "", err
+	}
+	buf := make([]byte, recordingChunkSize)
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			req.Data = buf[:n]
+			if err := stream.Send(req); err != nil {
+				return "", err
+			}
+			// The metadata only needs to be sent with the first message
+			req = &pbs.UploadSessionRecordingRequest{}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return "", err
+	}
+	return resp.GetRecordingId(), nil
+}
+
 func (w *Worker) closeConnection(ctx context.Context, req *pbs.CloseConnectionRequest) (*pbs.CloseConnectionResponse, error) {
 	rawConn := w.controllerSessionConn.Load()
 	if rawConn == nil {
@@ -231,10 +404,26 @@ func (w *Worker) closeConnections(ctx context.Context, closeMap map[string]strin
 	w.logger.Trace("marking connections as closed", "session_and_connection_ids", fmt.Sprintf("%#v", closeMap))
 
 	closeData := make([]*pbs.CloseConnectionRequestData, 0, len(closeMap))
-	for connId := range closeMap {
+	for connId, sessId := range closeMap {
+		var bytesUp, bytesDown uint64
+		reason := session.UnknownReason
+		if siRaw, ok := w.sessionInfoMap.Load(sessId); ok {
+			si := siRaw.(*sessionInfo)
+			si.RLock()
+			if ci, ok := si.connInfoMap[connId]; ok {
+				bytesUp, bytesDown = ci.BytesUp(), ci.BytesDown()
+				if ci.closeReason != "" {
+					reason = ci.closeReason
+				}
+			}
+			si.RUnlock()
+		}
 		closeData = append(closeData, &pbs.CloseConnectionRequestData{
 			ConnectionId: connId,
-			Reason:       session.UnknownReason.String(),
+			BytesUp:      bytesUp,
+			BytesDown:    bytesDown,
+			Reason:       reason.String(),
+			SessionId:    sessId,
 		})
 	}
 	closeInfo := &pbs.CloseConnectionRequest{
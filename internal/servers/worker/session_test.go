@@ -0,0 +1,113 @@
+package worker
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/targets"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/go-hclog"
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"nhooyr.io/websocket"
+)
+
+// testWorker returns a worker, without listeners or controller connections,
+// whose controller session service is client.
+func testWorker(t *testing.T, client pbs.SessionServiceClient) *Worker {
+	t.Helper()
+	w := &Worker{
+		conf: &Config{
+			RawConfig: &config.Config{Worker: &config.Worker{Name: "w_1234567890"}},
+		},
+		baseContext:           context.Background(),
+		logger:                hclog.NewNullLogger(),
+		controllerStatusConn:  new(atomic.Value),
+		lastStatusSuccess:     new(atomic.Value),
+		controllerSessionConn: new(atomic.Value),
+		sessionInfoMap:        new(sync.Map),
+		downstreams:           new(sync.Map),
+		downstreamRoutes:      cache.New(downstreamRouteTTL, 5*time.Minute),
+		sessionLookups:        cache.New(hopHandshakeTimeout, 5*time.Minute),
+		sessionLookupSlots:    make(chan struct{}, maxSessionLookups),
+	}
+	w.lastStatusSuccess.Store((*LastStatusInformation)(nil))
+	w.controllerSessionConn.Store(client)
+	return w
+}
+
+// testSessionInfo returns an active session with an authorized connection
+// for each of connectionIds.
+func testSessionInfo(t *testing.T, resp *pbs.LookupSessionResponse, connectionIds ...string) *sessionInfo {
+	t.Helper()
+	if resp.GetAuthorization() == nil {
+		resp.Authorization = &targets.SessionAuthorizationData{SessionId: "s_1234567890"}
+	}
+	si := &sessionInfo{
+		id:                    resp.GetAuthorization().GetSessionId(),
+		status:                pbs.SESSIONSTATUS_SESSIONSTATUS_ACTIVE,
+		lookupSessionResponse: resp,
+		connInfoMap:           make(map[string]*connInfo),
+	}
+	for _, id := range connectionIds {
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		si.connInfoMap[id] = &connInfo{
+			id:           id,
+			connCtx:      ctx,
+			connCancel:   cancel,
+			status:       pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_AUTHORIZED,
+			lastActivity: time.Now().UnixNano(),
+		}
+	}
+	return si
+}
+
+// testProxy runs proxy on the worker end of a websocket connection, within
+// the context of the connection connectionId of si, and returns the client
+// end.
+func testProxy(t *testing.T, si *sessionInfo, connectionId string, proxy func(ctx context.Context, clientAddr *net.TCPAddr, conn *websocket.Conn)) *websocket.Conn {
+	t.Helper()
+	connCtx := si.connInfoMap[connectionId].connCtx
+	srv := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(wr, r, nil)
+		if err != nil {
+			return
+		}
+		proxy(connCtx, &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 1}, conn)
+	}))
+	t.Cleanup(srv.Close)
+
+	conn, _, err := websocket.Dial(context.Background(), "ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close(websocket.StatusNormalClosure, "") })
+	return conn
+}
+
+func TestConnInfo(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	ci := &connInfo{lastActivity: time.Now().Add(-time.Minute).UnixNano()}
+	assert.Greater(int64(ci.idleTime()), int64(59*time.Second))
+
+	ci.addBytesUp(5)
+	ci.addBytesUp(7)
+	assert.Equal(uint64(12), ci.BytesUp())
+	assert.Zero(ci.BytesDown())
+	assert.Less(int64(ci.idleTime()), int64(time.Second), "adding bytes marks the connection active")
+
+	atomic.StoreInt64(&ci.lastActivity, time.Now().Add(-time.Minute).UnixNano())
+	ci.addBytesDown(3)
+	assert.Equal(uint64(3), ci.BytesDown())
+	assert.Equal(uint64(12), ci.BytesUp())
+	assert.Less(int64(ci.idleTime()), int64(time.Second), "adding bytes marks the connection active")
+}
@@ -0,0 +1,409 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"nhooyr.io/websocket"
+
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/target"
+)
+
+const (
+	defaultSshPort         = "22"
+	sshEndpointDialTimeout = 10 * time.Second
+	recordingUploadTimeout = 5 * time.Minute
+)
+
+// handleSshProxyV1 terminates the SSH protocol of a connection to an ssh
+// target. The client authenticates to the worker with the credentials it
+// would use for the endpoint, which the worker uses in turn to authenticate to
+// the endpoint. The worker's host key is derived from the session key, so the
+// client can verify it without prior knowledge of the worker, and the
+// endpoint's host key is checked against the host keys of the target. Session
+// channels are proxied to the endpoint and their terminal I/O is recorded in
+// asciicast format; all other channel types and agent and X11 forwarding are
+// refused so that nothing can bypass the recording.
+func (w *Worker) handleSshProxyV1(connCtx context.Context, clientAddr *net.TCPAddr, conn *websocket.Conn, si *sessionInfo, connectionId, endpoint string) {
+	si.RLock()
+	sessionId := si.lookupSessionResponse.GetAuthorization().GetSessionId()
+	privKey := si.lookupSessionResponse.GetAuthorization().GetPrivateKey()
+	hostKeys := si.lookupSessionResponse.GetSshHostKeys()
+	hostCaKeys := si.lookupSessionResponse.GetSshHostCaKeys()
+	insecureIgnoreHostKey := si.lookupSessionResponse.GetSshInsecureIgnoreHostKey()
+	si.RUnlock()
+
+	sessionUrl, err := url.Parse(endpoint)
+	if err != nil {
+		w.logger.Error("error parsing endpoint information", "error", err, "session_id", sessionId, "endpoint", endpoint)
+		conn.Close(websocket.StatusInternalError, "cannot parse endpoint url")
+		return
+	}
+	if sessionUrl.Scheme != "ssh" {
+		w.logger.Error("invalid scheme for ssh proxy", "session_id", sessionId, "endpoint", endpoint)
+		conn.Close(websocket.StatusInternalError, "invalid scheme for type")
+		return
+	}
+	endpointAddr := sessionUrl.Host
+	if sessionUrl.Port() == "" {
+		endpointAddr = net.JoinHostPort(sessionUrl.Hostname(), defaultSshPort)
+	}
+
+	endpointHostKeyCallback, err := sshHostKeyCallback(hostKeys, hostCaKeys, insecureIgnoreHostKey)
+	if err != nil {
+		w.logger.Error("refusing to connect to ssh endpoint", "error", err, "session_id", sessionId, "endpoint", endpoint)
+		conn.Close(websocket.StatusInternalError, "unable to verify endpoint host key")
+		return
+	}
+	if insecureIgnoreHostKey {
+		w.logger.Warn("not verifying ssh endpoint host key", "session_id", sessionId, "endpoint", endpoint)
+	}
+
+	hostKey, err := ssh.NewSignerFromKey(ed25519.PrivateKey(privKey))
+	if err != nil {
+		w.logger.Error("error creating ssh host key", "error", err, "session_id", sessionId)
+		conn.Close(websocket.StatusInternalError, "unable to create host key")
+		return
+	}
+
+	// The endpoint connection is made while authenticating the client, since
+	// its credentials are only known then
+	var endpointClient *ssh.Client
+	authenticate := func(user string, auth ...ssh.AuthMethod) error {
+		if endpointClient != nil {
+			return errors.New("already authenticated")
+		}
+		c, err := dialSshEndpoint(endpointAddr, user, endpointHostKeyCallback, auth...)
+		if err != nil {
+			w.logger.Debug("error authenticating to endpoint", "error", err, "connection_id", connectionId, "endpoint", endpoint)
+			return err
+		}
+		endpointClient = c
+		return nil
+	}
+	serverConfig := &ssh.ServerConfig{
+		PasswordCallback: func(meta ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			return nil, authenticate(meta.User(), ssh.Password(string(password)))
+		},
+		KeyboardInteractiveCallback: func(meta ssh.ConnMetadata, challenge ssh.KeyboardInteractiveChallenge) (*ssh.Permissions, error) {
+			answers, err := challenge("", "", []string{"Password: "}, []bool{false})
+			if err != nil {
+				return nil, err
+			}
+			if len(answers) != 1 {
+				return nil, errors.New("unexpected number of answers")
+			}
+			return nil, authenticate(meta.User(), ssh.Password(answers[0]), passwordChallenge(answers[0]))
+		},
+		ServerVersion: "SSH-2.0-Boundary",
+	}
+	serverConfig.AddHostKey(hostKey)
+
+	// Get a wrapped net.Conn so we can speak SSH over it
+	netConn := websocket.NetConn(connCtx, conn, websocket.MessageBinary)
+	defer netConn.Close()
+
+	serverConn, clientChans, clientReqs, err := ssh.NewServerConn(netConn, serverConfig)
+	if err != nil {
+		w.logger.Debug("error during ssh handshake with client", "error", err, "connection_id", connectionId)
+		if endpointClient != nil {
+			endpointClient.Close()
+		}
+		return
+	}
+	defer serverConn.Close()
+	defer endpointClient.Close()
+
+	endpointTcpAddr, _ := endpointClient.RemoteAddr().(*net.TCPAddr)
+	connectionInfo := &pbs.ConnectConnectionRequest{
+		ConnectionId:     connectionId,
+		ClientTcpAddress: clientAddr.IP.String(),
+		ClientTcpPort:    uint32(clientAddr.Port),
+		Type:             "ssh",
+	}
+	if endpointTcpAddr != nil {
+		connectionInfo.EndpointTcpAddress = endpointTcpAddr.IP.String()
+		connectionInfo.EndpointTcpPort = uint32(endpointTcpAddr.Port)
+	}
+
+	connStatus, err := w.connectConnection(connCtx, connectionInfo)
+	if err != nil {
+		w.logger.Error("error marking connection as connected", "error", err)
+		return
+	}
+	si.Lock()
+	ci := si.connInfoMap[connectionId]
+	ci.status = connStatus
+	si.Unlock()
+
+	go ssh.DiscardRequests(clientReqs)
+	go func() {
+		// The client is disconnected if the endpoint goes away
+		_ = endpointClient.Wait()
+		serverConn.Close()
+	}()
+	go func() {
+		// The endpoint is disconnected if the connection is canceled, e.g.
+		// for being idle
+		<-connCtx.Done()
+		endpointClient.Close()
+	}()
+
+	channelsWg := new(sync.WaitGroup)
+	var channelId uint32
+	for newChannel := range clientChans {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.Prohibited, "only session channels are allowed")
+			continue
+		}
+		channelsWg.Add(1)
+		go func(newChannel ssh.NewChannel, channelId uint32) {
+			defer channelsWg.Done()
+			w.proxySshChannel(newChannel, endpointClient, ci, sessionId, connectionId, channelId)
+		}(newChannel, channelId)
+		channelId++
+	}
+	channelsWg.Wait()
+}
+
+// dialSshEndpoint connects to the endpoint of an ssh target, checking its
+// host key with hostKeyCallback.
+func dialSshEndpoint(addr, user string, hostKeyCallback ssh.HostKeyCallback, auth ...ssh.AuthMethod) (*ssh.Client, error) {
+	config := &ssh.ClientConfig{
+		User:            user,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         sshEndpointDialTimeout,
+	}
+	return ssh.Dial("tcp", addr, config)
+}
+
+// sshHostKeyCallback returns the callback checking the host key of the
+// endpoint of an ssh target. The endpoint must present one of hostKeys, or a
+// host certificate signed by one of hostCaKeys, unless insecureIgnore is set,
+// in which case any host key is accepted. An error is returned when there is
+// nothing to check the host key against, so that the endpoint is never
+// connected to without verifying it by accident.
+func sshHostKeyCallback(hostKeys, hostCaKeys string, insecureIgnore bool) (ssh.HostKeyCallback, error) {
+	if insecureIgnore {
+		return ssh.InsecureIgnoreHostKey(), nil
+	}
+	keys, err := target.ParseSshHostKeys(hostKeys)
+	if err != nil {
+		return nil, fmt.Errorf("invalid host keys: %w", err)
+	}
+	caKeys, err := target.ParseSshHostKeys(hostCaKeys)
+	if err != nil {
+		return nil, fmt.Errorf("invalid host ca keys: %w", err)
+	}
+	if len(keys) == 0 && len(caKeys) == 0 {
+		return nil, errors.New("the target has no host keys or host ca keys and does not ignore the host key")
+	}
+	checker := &ssh.CertChecker{
+		IsHostAuthority: func(auth ssh.PublicKey, _ string) bool {
+			return containsSshKey(caKeys, auth)
+		},
+		HostKeyFallback: func(_ string, _ net.Addr, key ssh.PublicKey) error {
+			if containsSshKey(keys, key) {
+				return nil
+			}
+			return fmt.Errorf("unknown host key %s", ssh.FingerprintSHA256(key))
+		},
+	}
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		// An endpoint may present a host certificate even though only its
+		// key is known, which is as good as presenting the key itself
+		if cert, ok := key.(*ssh.Certificate); ok && containsSshKey(keys, cert.Key) {
+			return nil
+		}
+		return checker.CheckHostKey(hostname, remote, key)
+	}, nil
+}
+
+func containsSshKey(keys []ssh.PublicKey, key ssh.PublicKey) bool {
+	b := key.Marshal()
+	for _, k := range keys {
+		if bytes.Equal(k.Marshal(), b) {
+			return true
+		}
+	}
+	return false
+}
+
+// passwordChallenge answers every keyboard-interactive prompt of the endpoint
+// with password, for endpoints which don't allow password authentication.
+func passwordChallenge(password string) ssh.AuthMethod {
+	return ssh.KeyboardInteractive(func(user, instruction string, questions []string, echos []bool) ([]string, error) {
+		answers := make([]string, len(questions))
+		for i := range answers {
+			answers[i] = password
+		}
+		return answers, nil
+	})
+}
+
+// proxySshChannel opens a session channel to the endpoint for a session
+// channel opened by the client and proxies data and requests between the
+// two while recording the channel. When the channel closes the recording is
+// sent to the controller.
+func (w *Worker) proxySshChannel(newChannel ssh.NewChannel, endpointClient *ssh.Client, ci *connInfo, sessionId, connectionId string, channelId uint32) {
+	recorder, err := newChannelRecorder(w.recordingStoragePath, sessionId, connectionId, channelId)
+	if err != nil {
+		w.logger.Error("error creating session recording", "error", err, "connection_id", connectionId)
+		newChannel.Reject(ssh.ResourceShortage, "unable to record channel")
+		return
+	}
+	startTime := time.Now()
+
+	endpointChannel, endpointReqs, err := endpointClient.OpenChannel(newChannel.ChannelType(), newChannel.ExtraData())
+	if err != nil {
+		recorder.close()
+		os.Remove(recorder.path)
+		var openErr *ssh.OpenChannelError
+		if errors.As(err, &openErr) {
+			newChannel.Reject(openErr.Reason, openErr.Message)
+			return
+		}
+		newChannel.Reject(ssh.ConnectionFailed, "unable to open channel to endpoint")
+		return
+	}
+	defer endpointChannel.Close()
+	clientChannel, clientReqs, err := newChannel.Accept()
+	if err != nil {
+		recorder.close()
+		os.Remove(recorder.path)
+		w.logger.Debug("error accepting channel", "error", err, "connection_id", connectionId)
+		return
+	}
+	defer clientChannel.Close()
+
+	copyWg := new(sync.WaitGroup)
+	copyWg.Add(3)
+	go func() {
+		defer copyWg.Done()
+		for req := range endpointReqs {
+			// e.g. exit-status, which must reach the client before the
+			// channel is closed
+			ok, err := clientChannel.SendRequest(req.Type, req.WantReply, req.Payload)
+			if err != nil {
+				ok = false
+			}
+			if req.WantReply {
+				req.Reply(ok, nil)
+			}
+		}
+	}()
+	go func() {
+		defer copyWg.Done()
+		_, err := io.Copy(&countingWriter{Writer: &recordingWriter{w: clientChannel, record: recorder.output}, add: ci.addBytesDown}, endpointChannel)
+		w.logger.Debug("copy from endpoint to client done", "error", err)
+	}()
+	go func() {
+		defer copyWg.Done()
+		_, err := io.Copy(&countingWriter{Writer: &recordingWriter{w: clientChannel.Stderr(), record: recorder.output}, add: ci.addBytesDown}, endpointChannel.Stderr())
+		w.logger.Debug("copy of stderr from endpoint to client done", "error", err)
+	}()
+	go func() {
+		_, err := io.Copy(&countingWriter{Writer: &recordingWriter{w: endpointChannel, record: recorder.input}, add: ci.addBytesUp}, clientChannel)
+		_ = endpointChannel.CloseWrite()
+		w.logger.Debug("copy from client to endpoint done", "error", err)
+	}()
+	go w.forwardSshChannelRequests(clientReqs, endpointChannel, recorder)
+
+	// The channel ends when the endpoint closes it, after it has sent all of
+	// its output and the exit status of the command
+	copyWg.Wait()
+	clientChannel.Close()
+	endTime := time.Now()
+
+	if err := recorder.close(); err != nil {
+		w.logger.Error("error writing session recording", "error", err, "connection_id", connectionId, "path", recorder.path)
+	}
+	w.uploadRecording(sessionId, connectionId, channelId, startTime, endTime, recorder.path)
+}
+
+// forwardSshChannelRequests sends the requests the client makes on a channel
+// to the endpoint, noting those which describe the recorded terminal.
+// Forwarding of the SSH agent and of X11 is refused as it would let the
+// endpoint open channels to the client.
+func (w *Worker) forwardSshChannelRequests(reqs <-chan *ssh.Request, endpointChannel ssh.Channel, recorder *channelRecorder) {
+	for req := range reqs {
+		switch req.Type {
+		case "auth-agent-req@openssh.com", "x11-req":
+			if req.WantReply {
+				req.Reply(false, nil)
+			}
+			continue
+		case "pty-req":
+			var pty struct {
+				Term                    string
+				Columns, Rows           uint32
+				PixelWidth, PixelHeight uint32
+				Modes                   string
+			}
+			if err := ssh.Unmarshal(req.Payload, &pty); err == nil {
+				recorder.setTerminal(pty.Term, pty.Columns, pty.Rows)
+			}
+		case "window-change":
+			var size struct {
+				Columns, Rows           uint32
+				PixelWidth, PixelHeight uint32
+			}
+			if err := ssh.Unmarshal(req.Payload, &size); err == nil {
+				recorder.resize(size.Columns, size.Rows)
+			}
+		case "exec", "subsystem":
+			var command struct {
+				Value string
+			}
+			if err := ssh.Unmarshal(req.Payload, &command); err == nil {
+				recorder.setCommand(command.Value)
+			}
+		}
+		ok, err := endpointChannel.SendRequest(req.Type, req.WantReply, req.Payload)
+		if err != nil {
+			ok = false
+		}
+		if req.WantReply {
+			req.Reply(ok, nil)
+		}
+	}
+}
+
+// uploadRecording sends a finished recording to the controller. The local
+// copy is kept either way.
+func (w *Worker) uploadRecording(sessionId, connectionId string, channelId uint32, startTime, endTime time.Time, path string) {
+	f, err := os.Open(path)
+	if err != nil {
+		w.logger.Error("error reading session recording", "error", err, "connection_id", connectionId, "path", path)
+		return
+	}
+	defer f.Close()
+	// The connection may already be gone, so don't use its context
+	ctx, cancel := context.WithTimeout(w.baseContext, recordingUploadTimeout)
+	defer cancel()
+	recordingId, err := w.uploadSessionRecording(ctx, &pbs.UploadSessionRecordingRequest{
+		SessionId:    sessionId,
+		ConnectionId: connectionId,
+		ChannelId:    channelId,
+		StartTime:    timestamppb.New(startTime),
+		EndTime:      timestamppb.New(endTime),
+	}, f)
+	if err != nil {
+		w.logger.Error("error uploading session recording", "error", err, "connection_id", connectionId, "path", path)
+		return
+	}
+	w.logger.Debug("session recording uploaded", "recording_id", recordingId, "connection_id", connectionId, "path", path)
+}
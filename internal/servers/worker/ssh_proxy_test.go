@@ -0,0 +1,187 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func testSshSigner(t *testing.T) ssh.Signer {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(priv)
+	require.NoError(t, err)
+	return signer
+}
+
+func authorizedKey(k ssh.PublicKey) string {
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(k)))
+}
+
+func TestSshHostKeyCallback(t *testing.T) {
+	t.Parallel()
+	hostKey := testSshSigner(t)
+	otherKey := testSshSigner(t)
+	caKey := testSshSigner(t)
+
+	cert := &ssh.Certificate{
+		Key:         hostKey.PublicKey(),
+		CertType:    ssh.HostCert,
+		ValidBefore: ssh.CertTimeInfinity,
+	}
+	require.NoError(t, cert.SignCert(rand.Reader, caKey))
+	otherCert := &ssh.Certificate{
+		Key:         otherKey.PublicKey(),
+		CertType:    ssh.HostCert,
+		ValidBefore: ssh.CertTimeInfinity,
+	}
+	require.NoError(t, otherCert.SignCert(rand.Reader, otherKey))
+
+	remote := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 22}
+	tests := []struct {
+		name           string
+		hostKeys       string
+		hostCaKeys     string
+		insecureIgnore bool
+		presented      ssh.PublicKey
+		wantSetupErr   bool
+		wantErr        bool
+	}{
+		{name: "nothing-to-check", wantSetupErr: true},
+		{name: "invalid-host-keys", hostKeys: "not a key", wantSetupErr: true},
+		{name: "insecure", insecureIgnore: true, presented: otherKey.PublicKey()},
+		{name: "known-key", hostKeys: authorizedKey(hostKey.PublicKey()), presented: hostKey.PublicKey()},
+		{name: "unknown-key", hostKeys: authorizedKey(hostKey.PublicKey()), presented: otherKey.PublicKey(), wantErr: true},
+		{name: "cert-of-known-key", hostKeys: authorizedKey(hostKey.PublicKey()), presented: cert},
+		{name: "cert-from-known-ca", hostCaKeys: authorizedKey(caKey.PublicKey()), presented: cert},
+		{name: "cert-from-unknown-ca", hostCaKeys: authorizedKey(caKey.PublicKey()), presented: otherCert, wantErr: true},
+		{name: "plain-key-with-only-ca", hostCaKeys: authorizedKey(caKey.PublicKey()), presented: hostKey.PublicKey(), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			cb, err := sshHostKeyCallback(tt.hostKeys, tt.hostCaKeys, tt.insecureIgnore)
+			if tt.wantSetupErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			err = cb("endpoint.example.com:22", remote, tt.presented)
+			if tt.wantErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+		})
+	}
+}
+
+// testSessionClient is a controller session service which accepts every
+// connection and records the http requests and recordings sent to it. Only
+// the sessions in sessions are known to it.
+type testSessionClient struct {
+	pbs.SessionServiceClient
+	stream   *testUploadStream
+	sessions map[string]*pbs.LookupSessionResponse
+
+	mu           sync.Mutex
+	httpRequests []*pbs.RecordHttpRequestRequest
+	lookups      int
+}
+
+func (c *testSessionClient) LookupSession(_ context.Context, req *pbs.LookupSessionRequest, _ ...grpc.CallOption) (*pbs.LookupSessionResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lookups++
+	resp, ok := c.sessions[req.GetSessionId()]
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "Unknown session ID.")
+	}
+	return resp, nil
+}
+
+func (c *testSessionClient) lookupCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lookups
+}
+
+func (c *testSessionClient) ConnectConnection(context.Context, *pbs.ConnectConnectionRequest, ...grpc.CallOption) (*pbs.ConnectConnectionResponse, error) {
+	return &pbs.ConnectConnectionResponse{Status: pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CONNECTED}, nil
+}
+
+func (c *testSessionClient) RecordHttpRequest(_ context.Context, req *pbs.RecordHttpRequestRequest, _ ...grpc.CallOption) (*pbs.RecordHttpRequestResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.httpRequests = append(c.httpRequests, proto.Clone(req).(*pbs.RecordHttpRequestRequest))
+	return &pbs.RecordHttpRequestResponse{}, nil
+}
+
+func (c *testSessionClient) recordedHttpRequests() []*pbs.RecordHttpRequestRequest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*pbs.RecordHttpRequestRequest(nil), c.httpRequests...)
+}
+
+func (c *testSessionClient) UploadSessionRecording(context.Context, ...grpc.CallOption) (pbs.SessionService_UploadSessionRecordingClient, error) {
+	return c.stream, nil
+}
+
+type testUploadStream struct {
+	grpc.ClientStream
+	reqs []*pbs.UploadSessionRecordingRequest
+}
+
+func (s *testUploadStream) Send(req *pbs.UploadSessionRecordingRequest) error {
+	s.reqs = append(s.reqs, proto.Clone(req).(*pbs.UploadSessionRecordingRequest))
+	return nil
+}
+
+func (s *testUploadStream) CloseAndRecv() (*pbs.UploadSessionRecordingResponse, error) {
+	return &pbs.UploadSessionRecordingResponse{RecordingId: "sr_1234567890"}, nil
+}
+
+func TestUploadSessionRecording(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	stream := &testUploadStream{}
+	w := &Worker{controllerSessionConn: new(atomic.Value)}
+	w.controllerSessionConn.Store(pbs.SessionServiceClient(&testSessionClient{stream: stream}))
+
+	data := bytes.Repeat([]byte("x"), 2*recordingChunkSize+10)
+	id, err := w.uploadSessionRecording(context.Background(), &pbs.UploadSessionRecordingRequest{
+		SessionId:    "s_1234567890",
+		ConnectionId: "sc_1234567890",
+	}, bytes.NewReader(data))
+	require.NoError(err)
+	assert.Equal("sr_1234567890", id)
+
+	require.Len(stream.reqs, 3)
+	assert.Equal("s_1234567890", stream.reqs[0].GetSessionId())
+	assert.Equal("sc_1234567890", stream.reqs[0].GetConnectionId())
+	var got []byte
+	for i, req := range stream.reqs {
+		if i > 0 {
+			assert.Empty(req.GetSessionId(), "metadata is only sent with the first message")
+		}
+		got = append(got, req.GetData()...)
+	}
+	assert.Equal(data, got)
+	assert.Len(stream.reqs[2].GetData(), 10)
+}
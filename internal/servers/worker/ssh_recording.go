@@ -0,0 +1,213 @@
+package worker
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// recordingChunkSize is the amount of recording data sent to the
+	// controller in each message
+	recordingChunkSize = 1 << 20
+
+	defaultRecordingWidth  = 80
+	defaultRecordingHeight = 24
+)
+
+// asciicastHeader is the first line of an asciicast v2 recording.
+type asciicastHeader struct {
+	Version   int               `json:"version"`
+	Width     uint32            `json:"width"`
+	Height    uint32            `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Command   string            `json:"command,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// channelRecorder writes the terminal I/O of an ssh session channel to a
+// file in asciicast v2 format. The header is written when the first event is
+// recorded so that it can include the terminal requested by the client.
+// Events are lines of the form [time, type, data] where type is "i" for data
+// sent by the client, "o" for data sent by the endpoint and "r" for terminal
+// resizes.
+type channelRecorder struct {
+	sync.Mutex
+
+	path  string
+	file  *os.File
+	start time.Time
+
+	header        asciicastHeader
+	headerWritten bool
+
+	// Incomplete UTF-8 sequences at the end of the data of a stream are
+	// held back until the rest of the sequence arrives
+	pending map[string][]byte
+
+	err error
+}
+
+// newChannelRecorder creates the recording file for a channel of a connection
+// in a directory named after the session.
+func newChannelRecorder(storagePath, sessionId, connectionId string, channelId uint32) (*channelRecorder, error) {
+	dir := filepath.Join(storagePath, sessionId)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("error creating recording directory: %w", err)
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s-%d.cast", connectionId, channelId))
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("error creating recording file: %w", err)
+	}
+	start := time.Now()
+	return &channelRecorder{
+		path:  path,
+		file:  f,
+		start: start,
+		header: asciicastHeader{
+			Version:   2,
+			Width:     defaultRecordingWidth,
+			Height:    defaultRecordingHeight,
+			Timestamp: start.Unix(),
+		},
+		pending: make(map[string][]byte, 2),
+	}, nil
+}
+
+// setTerminal records the terminal requested by the client. It has no effect
+// once the header has been written.
+func (r *channelRecorder) setTerminal(term string, width, height uint32) {
+	r.Lock()
+	defer r.Unlock()
+	if r.headerWritten {
+		return
+	}
+	if term != "" {
+		r.header.Env = map[string]string{"TERM": term}
+	}
+	if width > 0 && height > 0 {
+		r.header.Width, r.header.Height = width, height
+	}
+}
+
+// setCommand records the command or subsystem requested by the client.
+func (r *channelRecorder) setCommand(command string) {
+	r.Lock()
+	defer r.Unlock()
+	if r.headerWritten {
+		return
+	}
+	r.header.Command = command
+}
+
+// resize records a change of the size of the client's terminal.
+func (r *channelRecorder) resize(width, height uint32) {
+	r.Lock()
+	defer r.Unlock()
+	if !r.headerWritten {
+		r.header.Width, r.header.Height = width, height
+		return
+	}
+	r.writeEvent("r", fmt.Sprintf("%dx%d", width, height))
+}
+
+// input records data sent by the client.
+func (r *channelRecorder) input(p []byte) {
+	r.record("i", p)
+}
+
+// output records data sent by the endpoint.
+func (r *channelRecorder) output(p []byte) {
+	r.record("o", p)
+}
+
+func (r *channelRecorder) record(typ string, p []byte) {
+	r.Lock()
+	defer r.Unlock()
+	data := append(r.pending[typ], p...)
+	cut := len(data)
+	// Look back at most utf8.UTFMax-1 bytes for the start of an incomplete
+	// sequence
+	for i := len(data) - 1; i >= 0 && i >= len(data)-(utf8.UTFMax-1); i-- {
+		if utf8.RuneStart(data[i]) {
+			if !utf8.FullRune(data[i:]) {
+				cut = i
+			}
+			break
+		}
+	}
+	r.pending[typ] = append([]byte(nil), data[cut:]...)
+	if cut > 0 {
+		r.writeEvent(typ, string(data[:cut]))
+	}
+}
+
+// writeEvent must be called with the lock held.
+func (r *channelRecorder) writeEvent(typ, data string) {
+	if r.err != nil {
+		return
+	}
+	if !r.headerWritten {
+		r.headerWritten = true
+		if r.err = r.writeLine(r.header); r.err != nil {
+			return
+		}
+	}
+	r.err = r.writeLine([]interface{}{time.Since(r.start).Seconds(), typ, data})
+}
+
+func (r *channelRecorder) writeLine(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	// Events are written as they happen so that a recording is complete up
+	// to the point at which a worker stops unexpectedly
+	if _, err := r.file.Write(append(b, '\n')); err != nil {
+		return err
+	}
+	return nil
+}
+
+// close records any held back data and closes the recording file. It returns
+// the first error encountered while recording.
+func (r *channelRecorder) close() error {
+	r.Lock()
+	defer r.Unlock()
+	for _, typ := range []string{"i", "o"} {
+		if len(r.pending[typ]) > 0 {
+			r.writeEvent(typ, string(r.pending[typ]))
+			r.pending[typ] = nil
+		}
+	}
+	if !r.headerWritten && r.err == nil {
+		// Nothing happened on the channel, but the recording still shows
+		// that it was opened
+		r.headerWritten = true
+		r.err = r.writeLine(r.header)
+	}
+	if err := r.file.Close(); err != nil && r.err == nil {
+		r.err = err
+	}
+	return r.err
+}
+
+// recordingWriter records everything written through it before passing it on.
+type recordingWriter struct {
+	w      io.Writer
+	record func([]byte)
+}
+
+func (rw *recordingWriter) Write(p []byte) (int, error) {
+	n, err := rw.w.Write(p)
+	if n > 0 {
+		rw.record(p[:n])
+	}
+	return n, err
+}
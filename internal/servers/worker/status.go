@@ -7,6 +7,7 @@ import (
 
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/hashicorp/boundary/internal/servers"
+	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"google.golang.org/grpc/resolver"
 )
@@ -59,6 +60,8 @@ func (w *Worker) startStatusTicking(cancelCtx context.Context) {
 						connections = append(connections, &pbs.Connection{
 							ConnectionId: k,
 							Status:       v.status,
+							BytesUp:      v.BytesUp(),
+							BytesDown:    v.BytesDown(),
 						})
 					}
 					si.RUnlock()
@@ -77,6 +80,12 @@ func (w *Worker) startStatusTicking(cancelCtx context.Context) {
 					})
 					return true
 				})
+				address := w.conf.RawConfig.Worker.PublicAddr
+				if w.conf.RawConfig.Worker.ReverseConnect {
+					// Empty until the worker is reverse-connected, so that no
+					// sessions are sent to it
+					address = w.reverseAddr.Load()
+				}
 				client := w.controllerStatusConn.Load().(pbs.ServerCoordinationServiceClient)
 				result, err := client.Status(cancelCtx, &pbs.StatusRequest{
 					Jobs: activeJobs,
@@ -85,7 +94,9 @@ func (w *Worker) startStatusTicking(cancelCtx context.Context) {
 						Name:        w.conf.RawConfig.Worker.Name,
 						Type:        resource.Worker.String(),
 						Description: w.conf.RawConfig.Worker.Description,
-						Address:     w.conf.RawConfig.Worker.PublicAddr,
+						Address:     address,
+						Tags:        w.tags,
+						Upstream:    w.upstream.Load(),
 					},
 				})
 				if err != nil {
@@ -108,28 +119,13 @@ func (w *Worker) startStatusTicking(cancelCtx context.Context) {
 					w.lastStatusSuccess.Store(&LastStatusInformation{StatusResponse: result, StatusTime: time.Now()})
 
 					for _, request := range result.GetJobsRequests() {
-						switch request.GetRequestType() {
-						case pbs.CHANGETYPE_CHANGETYPE_UPDATE_STATE:
-							switch request.GetJob().GetType() {
-							case pbs.JOBTYPE_JOBTYPE_SESSION:
-								sessInfo := request.GetJob().GetSessionInfo()
-								sessionId := sessInfo.GetSessionId()
-								siRaw, ok := w.sessionInfoMap.Load(sessionId)
-								if !ok {
-									w.logger.Warn("asked to cancel session but could not find a local information for it", "session_id", sessionId)
-									continue
-								}
-								si := siRaw.(*sessionInfo)
-								si.Lock()
-								si.status = sessInfo.GetStatus()
-								si.Unlock()
-							}
-						}
+						w.handleJobChange(request)
 					}
 				}
 
 				// Cleanup: Run through current jobs. Cancel connections for any
-				// canceling session or any session that is expired. Clear out
+				// canceling session or any session that is expired, and idle
+				// connections of other sessions. Clear out
 				// sessions that are canceled or expired with all connections
 				// marked as closed. Close any that aren't marked as such.
 				closeInfo := make(map[string]string)
@@ -157,6 +153,21 @@ func (w *Worker) startStatusTicking(cancelCtx context.Context) {
 						if toClose == 0 {
 							cleanSessionIds = append(cleanSessionIds, si.id)
 						}
+					default:
+						// Cancel connections that have gone without traffic
+						// for longer than the idle timeout of the session.
+						// They are marked closed when their handlers return.
+						idleTimeout := time.Duration(si.lookupSessionResponse.GetIdleTimeoutSeconds()) * time.Second
+						if idleTimeout == 0 {
+							break
+						}
+						for k, v := range si.connInfoMap {
+							if v.closeTime.IsZero() && v.closeReason == "" && v.idleTime() > idleTimeout {
+								v.closeReason = session.ConnectionIdleTimeout
+								v.connCancel()
+								w.logger.Info("terminated connection for being idle", "session_id", si.id, "connection_id", k)
+							}
+						}
 					}
 					si.Unlock()
 					return true
@@ -182,6 +193,44 @@ func (w *Worker) startStatusTicking(cancelCtx context.Context) {
 	}()
 }
 
+// handleJobChange applies a job change request made by a controller, returned
+// either with a status or through the job changes stream
+func (w *Worker) handleJobChange(request *pbs.JobChangeRequest) {
+	switch request.GetRequestType() {
+	case pbs.CHANGETYPE_CHANGETYPE_UPDATE_STATE:
+		switch request.GetJob().GetType() {
+		case pbs.JOBTYPE_JOBTYPE_SESSION:
+			sessInfo := request.GetJob().GetSessionInfo()
+			sessionId := sessInfo.GetSessionId()
+			siRaw, ok := w.sessionInfoMap.Load(sessionId)
+			if !ok {
+				w.logger.Warn("asked to update session but could not find a local information for it", "session_id", sessionId)
+				return
+			}
+			si := siRaw.(*sessionInfo)
+			si.Lock()
+			defer si.Unlock()
+			// Requests to close single connections leave the status of the
+			// session unspecified
+			if sessInfo.GetStatus() != pbs.SESSIONSTATUS_SESSIONSTATUS_UNSPECIFIED {
+				si.status = sessInfo.GetStatus()
+			}
+			for _, conn := range sessInfo.GetConnections() {
+				if conn.GetStatus() != pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CLOSED {
+					continue
+				}
+				ci, ok := si.connInfoMap[conn.GetConnectionId()]
+				if !ok || !ci.closeTime.IsZero() || ci.closeReason != "" {
+					continue
+				}
+				ci.closeReason = session.ConnectionCanceled
+				ci.connCancel()
+				w.logger.Info("terminated connection at the request of the controller", "session_id", si.id, "connection_id", ci.id)
+			}
+		}
+	}
+}
+
 func (w *Worker) LastStatusSuccess() *LastStatusInformation {
 	return w.lastStatusSuccess.Load().(*LastStatusInformation)
 }
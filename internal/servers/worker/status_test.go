@@ -0,0 +1,170 @@
+package worker
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// testCoordinationClient is a controller which answers every status with an
+// empty response and streams jobChanges to the worker.
+type testCoordinationClient struct {
+	pbs.ServerCoordinationServiceClient
+	jobChanges []*pbs.JobChangeRequest
+	// statuses, if set, receives the status requests made
+	statuses chan *pbs.StatusRequest
+}
+
+func (c *testCoordinationClient) Status(_ context.Context, req *pbs.StatusRequest, _ ...grpc.CallOption) (*pbs.StatusResponse, error) {
+	if c.statuses != nil {
+		c.statuses <- req
+	}
+	return &pbs.StatusResponse{}, nil
+}
+
+func (c *testCoordinationClient) JobChanges(context.Context, *pbs.JobChangesRequest, ...grpc.CallOption) (pbs.ServerCoordinationService_JobChangesClient, error) {
+	return &testJobChangesStream{requests: c.jobChanges}, nil
+}
+
+type testJobChangesStream struct {
+	grpc.ClientStream
+	requests []*pbs.JobChangeRequest
+}
+
+func (s *testJobChangesStream) Recv() (*pbs.JobChangeRequest, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+	return req, nil
+}
+
+func testJobChange(sessionId string, status pbs.SESSIONSTATUS, connections ...*pbs.Connection) *pbs.JobChangeRequest {
+	return &pbs.JobChangeRequest{
+		RequestType: pbs.CHANGETYPE_CHANGETYPE_UPDATE_STATE,
+		Job: &pbs.Job{
+			Type: pbs.JOBTYPE_JOBTYPE_SESSION,
+			JobInfo: &pbs.Job_SessionInfo{
+				SessionInfo: &pbs.SessionJobInfo{
+					SessionId:   sessionId,
+					Status:      status,
+					Connections: connections,
+				},
+			},
+		},
+	}
+}
+
+func TestHandleJobChange(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	w := testWorker(t, &testSessionClient{})
+	si := testSessionInfo(t, &pbs.LookupSessionResponse{}, "sc_terminate", "sc_keep", "sc_closed")
+	w.sessionInfoMap.Store(si.id, si)
+	terminate, keep, closed := si.connInfoMap["sc_terminate"], si.connInfoMap["sc_keep"], si.connInfoMap["sc_closed"]
+	closed.closeReason = session.ConnectionIdleTimeout
+
+	// Terminating a single connection leaves the session and its other
+	// connections alone, and doesn't override the reason a connection
+	// already being closed was closed for
+	w.handleJobChange(testJobChange(si.id, pbs.SESSIONSTATUS_SESSIONSTATUS_UNSPECIFIED,
+		&pbs.Connection{ConnectionId: "sc_terminate", Status: pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CLOSED},
+		&pbs.Connection{ConnectionId: "sc_keep", Status: pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CONNECTED},
+		&pbs.Connection{ConnectionId: "sc_closed", Status: pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CLOSED},
+		&pbs.Connection{ConnectionId: "sc_unknown", Status: pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CLOSED},
+	))
+	si.RLock()
+	assert.Equal(pbs.SESSIONSTATUS_SESSIONSTATUS_ACTIVE, si.status)
+	require.Error(terminate.connCtx.Err())
+	assert.Equal(session.ConnectionCanceled, terminate.closeReason)
+	assert.NoError(keep.connCtx.Err())
+	assert.Empty(keep.closeReason)
+	assert.NoError(closed.connCtx.Err())
+	assert.Equal(session.ConnectionIdleTimeout, closed.closeReason)
+	si.RUnlock()
+
+	// A status change updates the session, whose connections are then
+	// canceled with the next status
+	w.handleJobChange(testJobChange(si.id, pbs.SESSIONSTATUS_SESSIONSTATUS_CANCELING))
+	si.RLock()
+	assert.Equal(pbs.SESSIONSTATUS_SESSIONSTATUS_CANCELING, si.status)
+	assert.NoError(keep.connCtx.Err())
+	si.RUnlock()
+
+	// Changes to unknown sessions are ignored
+	w.handleJobChange(testJobChange("s_unknown", pbs.SESSIONSTATUS_SESSIONSTATUS_TERMINATED))
+}
+
+func TestStatusTicking_idleConnections(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	w := testWorker(t, &testSessionClient{})
+	w.controllerStatusConn.Store(pbs.ServerCoordinationServiceClient(&testCoordinationClient{}))
+
+	si := testSessionInfo(t, &pbs.LookupSessionResponse{
+		IdleTimeoutSeconds: 1,
+		Expiration:         timestamppb.New(time.Now().Add(time.Hour)),
+	}, "sc_idle", "sc_active")
+	idle, active := si.connInfoMap["sc_idle"], si.connInfoMap["sc_active"]
+	lastMinute := time.Now().Add(-time.Minute).UnixNano()
+	atomic.StoreInt64(&idle.lastActivity, lastMinute)
+	atomic.StoreInt64(&active.lastActivity, lastMinute)
+	// Transferring bytes keeps a connection from being idle
+	active.addBytesUp(1)
+	require.Greater(int64(idle.idleTime()), int64(time.Second))
+	require.Less(int64(active.idleTime()), int64(time.Second))
+	w.sessionInfoMap.Store(si.id, si)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	// The first status is sent, and connections cleaned up, right away
+	w.startStatusTicking(ctx)
+	select {
+	case <-idle.connCtx.Done():
+	case <-time.After(5 * time.Second):
+		require.Fail("idle connection was not canceled")
+	}
+	si.RLock()
+	assert.Equal(session.ConnectionIdleTimeout, idle.closeReason)
+	assert.NoError(active.connCtx.Err())
+	assert.Empty(active.closeReason)
+	si.RUnlock()
+}
+
+func TestStatusTicking_noIdleTimeout(t *testing.T) {
+	t.Parallel()
+	w := testWorker(t, &testSessionClient{})
+	client := &testCoordinationClient{statuses: make(chan *pbs.StatusRequest, 10)}
+	w.controllerStatusConn.Store(pbs.ServerCoordinationServiceClient(client))
+
+	si := testSessionInfo(t, &pbs.LookupSessionResponse{
+		Expiration: timestamppb.New(time.Now().Add(time.Hour)),
+	}, "sc_idle")
+	idle := si.connInfoMap["sc_idle"]
+	atomic.StoreInt64(&idle.lastActivity, time.Now().Add(-time.Hour).UnixNano())
+	w.sessionInfoMap.Store(si.id, si)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	w.startStatusTicking(ctx)
+	// Cleanup runs after the status is sent, so wait for a second status to
+	// be sure it ran
+	for i := 0; i < 2; i++ {
+		select {
+		case <-client.statuses:
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "no status was sent")
+		}
+	}
+	assert.NoError(t, idle.connCtx.Err(), "connections of sessions without an idle timeout are never idle")
+}
@@ -54,24 +54,45 @@ func (w *Worker) handleTcpProxyV1(connCtx context.Context, clientAddr *net.TCPAd
 		return
 	}
 	si.Lock()
-	si.connInfoMap[connectionId].status = connStatus
+	ci := si.connInfoMap[connectionId]
+	ci.status = connStatus
 	si.Unlock()
 
 	// Get a wrapped net.Conn so we can use io.Copy
 	netConn := websocket.NetConn(connCtx, conn, websocket.MessageBinary)
 
+	go func() {
+		// Closing the endpoint connection stops the copy from a silent
+		// endpoint when the connection is canceled, e.g. for being idle
+		<-connCtx.Done()
+		tcpRemoteConn.Close()
+	}()
+
 	connWg := new(sync.WaitGroup)
 	connWg.Add(2)
 	go func() {
 		defer connWg.Done()
-		_, err := io.Copy(netConn, tcpRemoteConn)
-		w.logger.Debug("copy from client to endpoint done", "error", err)
+		_, err := io.Copy(&countingWriter{Writer: netConn, add: ci.addBytesDown}, tcpRemoteConn)
+		w.logger.Debug("copy from endpoint to client done", "error", err)
 	}()
 	go func() {
 		defer connWg.Done()
-		_, err := io.Copy(tcpRemoteConn, netConn)
-		w.logger.Debug("copy from endpoint to client done", "error", err)
+		_, err := io.Copy(&countingWriter{Writer: tcpRemoteConn, add: ci.addBytesUp}, netConn)
+		w.logger.Debug("copy from client to endpoint done", "error", err)
 	}()
 	connWg.Wait()
+}
+
+// countingWriter wraps an io.Writer and passes the number of bytes
+// successfully written to add, allowing the totals to be read while the copy
+// is still in progress.
+type countingWriter struct {
+	io.Writer
+	add func(uint64)
+}
 
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.Writer.Write(p)
+	c.add(uint64(n))
+	return n, err
 }
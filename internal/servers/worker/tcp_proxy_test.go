@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// shortWriter accepts at most n bytes before failing.
+type shortWriter struct {
+	n int
+}
+
+func (s *shortWriter) Write(p []byte) (int, error) {
+	if len(p) > s.n {
+		n := s.n
+		s.n = 0
+		return n, errors.New("short write")
+	}
+	s.n -= len(p)
+	return len(p), nil
+}
+
+func TestCountingWriter(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	ci := &connInfo{}
+
+	var buf bytes.Buffer
+	up := &countingWriter{Writer: &buf, add: ci.addBytesUp}
+	_, err := up.Write([]byte("hello"))
+	require.NoError(err)
+	_, err = up.Write([]byte(" world"))
+	require.NoError(err)
+	assert.Equal("hello world", buf.String())
+	assert.Equal(uint64(11), ci.BytesUp())
+	assert.Zero(ci.BytesDown())
+
+	// Only the bytes actually written are counted
+	down := &countingWriter{Writer: &shortWriter{n: 3}, add: ci.addBytesDown}
+	n, err := down.Write([]byte("abcdef"))
+	require.Error(err)
+	assert.Equal(3, n)
+	assert.Equal(uint64(3), ci.BytesDown())
+	assert.Equal(uint64(11), ci.BytesUp())
+}
@@ -0,0 +1,107 @@
+package worker
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"sync"
+
+	"github.com/hashicorp/boundary/globals"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"nhooyr.io/websocket"
+)
+
+// handleUdpProxyV1 relays the datagrams of a connection to a udp target. Each
+// binary message from the client is sent to the endpoint as one datagram, and
+// each datagram from the endpoint is sent to the client as one message.
+func (w *Worker) handleUdpProxyV1(connCtx context.Context, clientAddr *net.TCPAddr, conn *websocket.Conn, si *sessionInfo, connectionId, endpoint string) {
+	si.RLock()
+	sessionId := si.lookupSessionResponse.GetAuthorization().GetSessionId()
+	si.RUnlock()
+
+	sessionUrl, err := url.Parse(endpoint)
+	if err != nil {
+		w.logger.Error("error parsing endpoint information", "error", err, "session_id", sessionId, "endpoint", endpoint)
+		conn.Close(websocket.StatusInternalError, "cannot parse endpoint url")
+		return
+	}
+	if sessionUrl.Scheme != "udp" {
+		w.logger.Error("invalid scheme for udp proxy", "session_id", sessionId, "endpoint", endpoint)
+		conn.Close(websocket.StatusInternalError, "invalid scheme for type")
+		return
+	}
+	remoteConn, err := net.Dial("udp", sessionUrl.Host)
+	if err != nil {
+		w.logger.Error("error dialing endpoint", "error", err, "endpoint", endpoint)
+		conn.Close(websocket.StatusInternalError, "endpoint dialing failed")
+		return
+	}
+	udpRemoteConn := remoteConn.(*net.UDPConn)
+	defer udpRemoteConn.Close()
+
+	endpointAddr := udpRemoteConn.RemoteAddr().(*net.UDPAddr)
+	connectionInfo := &pbs.ConnectConnectionRequest{
+		ConnectionId:       connectionId,
+		ClientTcpAddress:   clientAddr.IP.String(),
+		ClientTcpPort:      uint32(clientAddr.Port),
+		EndpointTcpAddress: endpointAddr.IP.String(),
+		EndpointTcpPort:    uint32(endpointAddr.Port),
+		Type:               "udp",
+	}
+
+	connStatus, err := w.connectConnection(connCtx, connectionInfo)
+	if err != nil {
+		w.logger.Error("error marking connection as connected", "error", err)
+		conn.Close(websocket.StatusInternalError, "failed to mark connection as connected")
+		return
+	}
+	si.Lock()
+	ci := si.connInfoMap[connectionId]
+	ci.status = connStatus
+	si.Unlock()
+
+	conn.SetReadLimit(globals.MaxUdpDatagramSize)
+
+	connWg := new(sync.WaitGroup)
+	connWg.Add(2)
+	go func() {
+		defer connWg.Done()
+		buf := make([]byte, globals.MaxUdpDatagramSize)
+		for {
+			n, err := udpRemoteConn.Read(buf)
+			if err != nil {
+				w.logger.Debug("read from endpoint done", "error", err)
+				conn.Close(websocket.StatusNormalClosure, "endpoint closed")
+				return
+			}
+			if err := conn.Write(connCtx, websocket.MessageBinary, buf[:n]); err != nil {
+				w.logger.Debug("write to client done", "error", err)
+				return
+			}
+			ci.addBytesDown(uint64(n))
+		}
+	}()
+	go func() {
+		defer connWg.Done()
+		// Closing the endpoint connection stops the other direction
+		defer udpRemoteConn.Close()
+		for {
+			typ, datagram, err := conn.Read(connCtx)
+			if err != nil {
+				w.logger.Debug("read from client done", "error", err)
+				return
+			}
+			if typ != websocket.MessageBinary {
+				conn.Close(websocket.StatusUnsupportedData, "datagrams must be binary messages")
+				return
+			}
+			n, err := udpRemoteConn.Write(datagram)
+			if err != nil {
+				w.logger.Debug("write to endpoint done", "error", err)
+				return
+			}
+			ci.addBytesUp(uint64(n))
+		}
+	}()
+	connWg.Wait()
+}
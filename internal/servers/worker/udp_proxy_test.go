@@ -0,0 +1,101 @@
+package worker
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/globals"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"nhooyr.io/websocket"
+)
+
+// testUdpEcho starts an endpoint which replies to each datagram with the
+// datagram prefixed by "echo:".
+func testUdpEcho(t *testing.T) string {
+	t.Helper()
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { pc.Close() })
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, addr, err := pc.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if _, err := pc.WriteTo(append([]byte("echo:"), buf[:n]...), addr); err != nil {
+				return
+			}
+		}
+	}()
+	return pc.LocalAddr().String()
+}
+
+func TestHandleUdpProxyV1(t *testing.T) {
+	t.Parallel()
+	endpoint := "udp://" + testUdpEcho(t)
+
+	t.Run("datagrams", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		w := testWorker(t, &testSessionClient{})
+		si := testSessionInfo(t, &pbs.LookupSessionResponse{}, "sc_1234567890")
+		ci := si.connInfoMap["sc_1234567890"]
+		conn := testProxy(t, si, "sc_1234567890", func(ctx context.Context, clientAddr *net.TCPAddr, conn *websocket.Conn) {
+			w.handleUdpProxyV1(ctx, clientAddr, conn, si, "sc_1234567890", endpoint)
+		})
+
+		// Each message is one datagram, so message boundaries survive the
+		// round trip even for messages written back to back
+		ctx := context.Background()
+		datagrams := []string{"one", "two-longer", ""}
+		for _, d := range datagrams {
+			require.NoError(conn.Write(ctx, websocket.MessageBinary, []byte(d)))
+		}
+		var wantUp, wantDown uint64
+		for _, d := range datagrams {
+			typ, got, err := conn.Read(ctx)
+			require.NoError(err)
+			assert.Equal(websocket.MessageBinary, typ)
+			assert.Equal("echo:"+d, string(got))
+			wantUp += uint64(len(d))
+			wantDown += uint64(len("echo:" + d))
+		}
+		assert.Eventually(func() bool {
+			return ci.BytesUp() == wantUp && ci.BytesDown() == wantDown
+		}, 5*time.Second, 10*time.Millisecond, "got %d bytes up and %d bytes down", ci.BytesUp(), ci.BytesDown())
+	})
+
+	t.Run("text-message", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		w := testWorker(t, &testSessionClient{})
+		si := testSessionInfo(t, &pbs.LookupSessionResponse{}, "sc_1234567890")
+		conn := testProxy(t, si, "sc_1234567890", func(ctx context.Context, clientAddr *net.TCPAddr, conn *websocket.Conn) {
+			w.handleUdpProxyV1(ctx, clientAddr, conn, si, "sc_1234567890", endpoint)
+		})
+
+		ctx := context.Background()
+		require.NoError(conn.Write(ctx, websocket.MessageText, []byte("not a datagram")))
+		_, _, err := conn.Read(ctx)
+		require.Error(err)
+		assert.Equal(websocket.StatusUnsupportedData, websocket.CloseStatus(err))
+	})
+
+	t.Run("oversized-message", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		w := testWorker(t, &testSessionClient{})
+		si := testSessionInfo(t, &pbs.LookupSessionResponse{}, "sc_1234567890")
+		conn := testProxy(t, si, "sc_1234567890", func(ctx context.Context, clientAddr *net.TCPAddr, conn *websocket.Conn) {
+			w.handleUdpProxyV1(ctx, clientAddr, conn, si, "sc_1234567890", endpoint)
+		})
+
+		ctx := context.Background()
+		require.NoError(conn.Write(ctx, websocket.MessageBinary, make([]byte, globals.MaxUdpDatagramSize+1)))
+		_, _, err := conn.Read(ctx)
+		require.Error(err)
+		assert.Equal(websocket.StatusMessageTooBig, websocket.CloseStatus(err))
+	})
+}
@@ -4,13 +4,18 @@ import (
 	"context"
 	"crypto/rand"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/servers"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/vault/sdk/helper/base62"
 	"github.com/hashicorp/vault/sdk/helper/mlock"
+	"github.com/patrickmn/go-cache"
 	ua "go.uber.org/atomic"
 	"google.golang.org/grpc/resolver"
 	"google.golang.org/grpc/resolver/manual"
@@ -32,6 +37,43 @@ type Worker struct {
 
 	controllerSessionConn *atomic.Value
 	sessionInfoMap        *sync.Map
+
+	// tags holds the worker's configured tags in the form reported to the
+	// controller in status updates
+	tags map[string]*servers.TagValues
+
+	// downstreams holds the yamux sessions of connected downstream workers,
+	// keyed by worker name
+	downstreams *sync.Map
+	// hopAuthCache holds the auth information of downstream workers
+	// between their TLS handshake and their connection nonce
+	hopAuthCache *cache.Cache
+	// downstreamRoutes holds the routes to downstream workers of session
+	// connections, keyed by session ID. An empty route records that a
+	// session is not handled by a downstream worker.
+	downstreamRoutes *cache.Cache
+	// sessionLookups holds the results of the session lookups of client
+	// handshakes, keyed by session ID: the lookup response until the
+	// handshake uses it, or errUnknownSession for a while for sessions the
+	// controller doesn't know
+	sessionLookups *cache.Cache
+	// sessionLookupSlots bounds the session lookups of client handshakes
+	// made at once
+	sessionLookupSlots chan struct{}
+
+	// upstream is the name of the upstream worker the worker is connected
+	// to, if any
+	upstream ua.String
+	// reverseAddr is the address at which clients reach the worker through
+	// the controller it is reverse-connected to, if any
+	reverseAddr ua.String
+	// hopListener receives the session streams forwarded by the upstream
+	// worker
+	hopListener *hopListener
+
+	// recordingStoragePath is the directory in which recordings of sessions
+	// to ssh targets are written
+	recordingStoragePath string
 }
 
 func New(conf *Config) (*Worker, error) {
@@ -44,6 +86,11 @@ func New(conf *Config) (*Worker, error) {
 		controllerResolverCleanup: new(atomic.Value),
 		controllerSessionConn:     new(atomic.Value),
 		sessionInfoMap:            new(sync.Map),
+		downstreams:               new(sync.Map),
+		hopAuthCache:              cache.New(hopHandshakeTimeout, 5*time.Minute),
+		downstreamRoutes:          cache.New(downstreamRouteTTL, 5*time.Minute),
+		sessionLookups:            cache.New(hopHandshakeTimeout, 5*time.Minute),
+		sessionLookupSlots:        make(chan struct{}, maxSessionLookups),
 	}
 
 	w.lastStatusSuccess.Store((*LastStatusInformation)(nil))
@@ -65,6 +112,18 @@ func New(conf *Config) (*Worker, error) {
 		}
 	}
 
+	if len(conf.RawConfig.Worker.Tags) > 0 {
+		w.tags = make(map[string]*servers.TagValues, len(conf.RawConfig.Worker.Tags))
+		for k, v := range conf.RawConfig.Worker.Tags {
+			w.tags[k] = &servers.TagValues{Values: v}
+		}
+	}
+
+	w.recordingStoragePath = conf.RawConfig.Worker.RecordingStoragePath
+	if w.recordingStoragePath == "" {
+		w.recordingStoragePath = filepath.Join(os.TempDir(), "boundary", "recordings")
+	}
+
 	if !conf.RawConfig.DisableMlock {
 		// Ensure our memory usage is locked into physical RAM
 		if err := mlock.LockMemory(); err != nil {
@@ -103,7 +162,9 @@ func (w *Worker) Start() error {
 		return fmt.Errorf("error making controller connections: %w", err)
 	}
 
+	w.startUpstreamConnections()
 	w.startStatusTicking(w.baseContext)
+	w.startJobChanges(w.baseContext)
 	w.started.Store(true)
 
 	return nil
@@ -16,6 +16,7 @@ const (
 	ConnectionCanceled     ClosedReason = "canceled"
 	ConnectionNetworkError ClosedReason = "network error"
 	ConnectionSystemError  ClosedReason = "system error"
+	ConnectionIdleTimeout  ClosedReason = "idle timeout"
 )
 
 // String representation of the termination reason
@@ -36,6 +37,8 @@ func convertToClosedReason(s string) (ClosedReason, error) {
 		return ConnectionNetworkError, nil
 	case ConnectionSystemError.String():
 		return ConnectionSystemError, nil
+	case ConnectionIdleTimeout.String():
+		return ConnectionIdleTimeout, nil
 	default:
 		return "", fmt.Errorf("closed reason: %s is not a valid reason: %w", s, errors.ErrInvalidParameter)
 	}
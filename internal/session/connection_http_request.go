@@ -0,0 +1,119 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultConnectionHttpRequestTableName = "session_connection_http_request"
+)
+
+// ConnectionHttpRequest is an HTTP request which was proxied by a worker over
+// a connection of an http target session.
+type ConnectionHttpRequest struct {
+	// ConnectionId of the connection the request was made over
+	ConnectionId string `json:"connection_id,omitempty" gorm:"primary_key"`
+	// CreateTime from the RDBMS
+	CreateTime *timestamp.Timestamp `json:"create_time,omitempty" gorm:"default:current_timestamp;primary_key"`
+	// Method of the request
+	Method string `json:"method,omitempty" gorm:"default:null"`
+	// Path of the request, including any query
+	Path string `json:"path,omitempty"`
+	// StatusCode of the response returned by the endpoint
+	StatusCode int32 `json:"status_code,omitempty" gorm:"default:null"`
+
+	tableName string `gorm:"-"`
+}
+
+var _ Cloneable = (*ConnectionHttpRequest)(nil)
+var _ db.VetForWriter = (*ConnectionHttpRequest)(nil)
+
+// NewConnectionHttpRequest creates a new in memory connection http request.
+// No options are currently supported.
+func NewConnectionHttpRequest(connectionId, method, path string, statusCode int32, opt ...Option) (*ConnectionHttpRequest, error) {
+	r := ConnectionHttpRequest{
+		ConnectionId: connectionId,
+		Method:       method,
+		Path:         path,
+		StatusCode:   statusCode,
+	}
+	if err := r.validate("new connection http request:"); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// AllocConnectionHttpRequest will allocate a ConnectionHttpRequest
+func AllocConnectionHttpRequest() ConnectionHttpRequest {
+	return ConnectionHttpRequest{}
+}
+
+// Clone creates a clone of the ConnectionHttpRequest
+func (r *ConnectionHttpRequest) Clone() interface{} {
+	clone := &ConnectionHttpRequest{
+		ConnectionId: r.ConnectionId,
+		Method:       r.Method,
+		Path:         r.Path,
+		StatusCode:   r.StatusCode,
+	}
+	if r.CreateTime != nil {
+		clone.CreateTime = &timestamp.Timestamp{
+			Timestamp: &timestamppb.Timestamp{
+				Seconds: r.CreateTime.Timestamp.Seconds,
+				Nanos:   r.CreateTime.Timestamp.Nanos,
+			},
+		}
+	}
+	return clone
+}
+
+// VetForWrite implements db.VetForWrite() interface and validates the
+// request before it's written.
+func (r *ConnectionHttpRequest) VetForWrite(ctx context.Context, _ db.Reader, opType db.OpType, opt ...db.Option) error {
+	if opType != db.CreateOp {
+		return fmt.Errorf("connection http request vet for write: requests are immutable: %w", errors.ErrInvalidParameter)
+	}
+	if err := r.validate("connection http request vet for write:"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (r *ConnectionHttpRequest) TableName() string {
+	if r.tableName != "" {
+		return r.tableName
+	}
+	return defaultConnectionHttpRequestTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (r *ConnectionHttpRequest) SetTableName(n string) {
+	r.tableName = n
+}
+
+// validate checks the connection http request
+func (r *ConnectionHttpRequest) validate(errorPrefix string) error {
+	if r.ConnectionId == "" {
+		return fmt.Errorf("%s missing connection id: %w", errorPrefix, errors.ErrInvalidParameter)
+	}
+	if strings.TrimSpace(r.Method) == "" {
+		return fmt.Errorf("%s missing method: %w", errorPrefix, errors.ErrInvalidParameter)
+	}
+	if r.StatusCode < 100 || r.StatusCode > 599 {
+		return fmt.Errorf("%s invalid status code %d: %w", errorPrefix, r.StatusCode, errors.ErrInvalidParameter)
+	}
+	if r.CreateTime != nil {
+		return fmt.Errorf("%s create time is not settable: %w", errorPrefix, errors.ErrInvalidParameter)
+	}
+	return nil
+}
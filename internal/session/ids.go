@@ -18,6 +18,9 @@ const (
 
 	// ConnectionStatePrefix for connection state PK ids
 	ConnectionStatePrefix = "scs"
+
+	// RecordingPrefix for recording PK ids
+	RecordingPrefix = "sr"
 )
 
 func newId() (string, error) {
@@ -51,3 +54,11 @@ func newConnectionStateId() (string, error) {
 	}
 	return id, nil
 }
+
+func newRecordingId() (string, error) {
+	id, err := db.NewPublicId(RecordingPrefix)
+	if err != nil {
+		return "", fmt.Errorf("new session recording id: %w", err)
+	}
+	return id, nil
+}
@@ -30,4 +30,9 @@ func Test_Ids(t *testing.T) {
 		require.NoError(t, err)
 		assert.True(t, strings.HasPrefix(id, ConnectionStatePrefix+"_"))
 	})
+	t.Run("sr", func(t *testing.T) {
+		id, err := newRecordingId()
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(id, RecordingPrefix+"_"))
+	})
 }
@@ -1,6 +1,8 @@
 package session
 
 import (
+	"time"
+
 	"github.com/hashicorp/boundary/internal/db/timestamp"
 )
 
@@ -18,14 +20,20 @@ type Option func(*options)
 
 // options = how options are represented
 type options struct {
-	withLimit          int
-	withOrder          string
-	withScopeId        string
-	withUserId         string
-	withExpirationTime *timestamp.Timestamp
-	withTestTofu       []byte
-	withListingConvert bool
-	withSessionIds     []string
+	withLimit            int
+	withStartPageAfterId string
+	withOrder            string
+	withScopeId          string
+	withScopeIds         []string
+	withUserId           string
+	withExpirationTime   *timestamp.Timestamp
+	withTestTofu         []byte
+	withListingConvert   bool
+	withSessionIds       []string
+	withConnectionId     string
+
+	withStateChangedWithin time.Duration
+	withUnterminated       bool
 }
 
 func getDefaultOptions() options {
@@ -41,6 +49,15 @@ func WithLimit(limit int) Option {
 	}
 }
 
+// WithStartPageAfterId provides an option to list only the resources whose
+// public ids sort after the given id. Results are ordered by public id when
+// it is used, allowing listing to be resumed where a previous page ended.
+func WithStartPageAfterId(id string) Option {
+	return func(o *options) {
+		o.withStartPageAfterId = id
+	}
+}
+
 // WithOrder allows specifying an order for returned values
 func WithOrder(order string) Option {
 	return func(o *options) {
@@ -55,6 +72,14 @@ func WithScopeId(scopeId string) Option {
 	}
 }
 
+// WithScopeIds allows specifying several scope IDs, any of which match, as
+// criteria for the function.
+func WithScopeIds(scopeIds ...string) Option {
+	return func(o *options) {
+		o.withScopeIds = scopeIds
+	}
+}
+
 // WithUserId allows specifying a user ID criteria for the function.
 func WithUserId(userId string) Option {
 	return func(o *options) {
@@ -84,6 +109,30 @@ func WithSessionIds(ids ...string) Option {
 	}
 }
 
+// WithConnectionId allows specifying a connection ID criteria for the
+// function.
+func WithConnectionId(connectionId string) Option {
+	return func(o *options) {
+		o.withConnectionId = connectionId
+	}
+}
+
+// WithStateChangedWithin allows specifying that only the sessions whose state
+// changed within the last d, measured by the database's clock, match.
+func WithStateChangedWithin(d time.Duration) Option {
+	return func(o *options) {
+		o.withStateChangedWithin = d
+	}
+}
+
+// WithUnterminated allows specifying that only the sessions which are not
+// terminated match.
+func WithUnterminated() Option {
+	return func(o *options) {
+		o.withUnterminated = true
+	}
+}
+
 func withListingConvert(withListingConvert bool) Option {
 	return func(o *options) {
 		o.withListingConvert = withListingConvert
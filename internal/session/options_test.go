@@ -31,6 +31,13 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withLimit = 1
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithStartPageAfterId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithStartPageAfterId("s_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withStartPageAfterId = "s_1234567890"
+		assert.Equal(opts, testOpts)
+	})
 	t.Run("WithScopeId", func(t *testing.T) {
 		assert := assert.New(t)
 		opts := getOpts(WithScopeId("o_1234"))
@@ -38,6 +45,13 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withScopeId = "o_1234"
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithScopeIds", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithScopeIds("o_1234", "o_5678"))
+		testOpts := getDefaultOptions()
+		testOpts.withScopeIds = []string{"o_1234", "o_5678"}
+		assert.Equal(opts, testOpts)
+	})
 	t.Run("WithOrder", func(t *testing.T) {
 		assert := assert.New(t)
 		opts := getOpts(WithOrder("create_time asc"))
@@ -77,4 +91,11 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withSessionIds = []string{"s_1", "s_2", "s_3"}
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithConnectionId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithConnectionId("sc_1"))
+		testOpts := getDefaultOptions()
+		testOpts.withConnectionId = "sc_1"
+		assert.Equal(opts, testOpts)
+	})
 }
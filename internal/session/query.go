@@ -151,15 +151,56 @@ from
 	session_with_state ss
 where 
 	s.public_id = ss.public_id 
-	%s
 %s
 `
 
+	// listStateChanges selects the states which sessions and their connections
+	// entered within the last $1 seconds, with the details of the connections
+	// and the reasons of terminations and closes.
+	listStateChanges = `
+select
+	ss.session_id,
+	'' as connection_id,
+	ss.state,
+	ss.start_time,
+	'' as client_tcp_address,
+	0 as client_tcp_port,
+	0 as bytes_up,
+	0 as bytes_down,
+	case when ss.state = 'terminated' then coalesce(s.termination_reason, '') else '' end as reason
+from
+	session_state ss,
+	session s
+where
+	s.public_id = ss.session_id and
+	ss.start_time > now() - $1 * interval '1 second'
+union all
+select
+	sc.session_id,
+	cs.connection_id,
+	cs.state,
+	cs.start_time,
+	coalesce(host(sc.client_tcp_address), ''),
+	coalesce(sc.client_tcp_port, 0),
+	case when cs.state = 'closed' then coalesce(sc.bytes_up, 0) else 0 end,
+	case when cs.state = 'closed' then coalesce(sc.bytes_down, 0) else 0 end,
+	case when cs.state = 'closed' then coalesce(sc.closed_reason, '') else '' end
+from
+	session_connection_state cs,
+	session_connection sc
+where
+	sc.public_id = cs.connection_id and
+	cs.start_time > now() - $1 * interval '1 second'
+order by
+	start_time
+`
+
 	// termSessionUpdate is one stmt that terminates sessions for the following
 	// reasons:
 	//	* sessions that are expired and all their connections are closed.
 	// 	* sessions that are canceling and all their connections are closed
 	//  * sessions that have exhausted their connection limit and all their connections are closed.
+	//  * sessions with a connection closed for being idle and all their connections are closed.
 	termSessionsUpdate = `
 with canceling_session(session_id) as
 (
@@ -170,6 +211,15 @@ with canceling_session(session_id) as
 	where 
 		ss.state = 'canceling' and 
 		ss.end_time is null
+),
+idle_session(session_id) as
+(
+	select 
+		session_id
+	from
+		session_connection sc
+	where 
+		sc.closed_reason = 'idle timeout'
 )
 update session us
 	set termination_reason = 
@@ -185,12 +235,21 @@ update session us
 			where 
 				us.public_id = cs.session_id
 			) then 'canceled' 
+		-- idle sessions
+		when us.public_id in(
+			select 
+				session_id 
+			from 
+				idle_session ids 
+			where 
+				us.public_id = ids.session_id
+			) then 'idle timeout' 
 		-- default: session connection limit reached.
 		else 'connection limit'
 	end
 where
 	termination_reason is null and
-	-- session expired or connection limit reached
+	-- session expired, connection limit reached, canceled or idle
 	(
 		-- expired sessions...
 		now() > us.expiration_time or 
@@ -213,6 +272,15 @@ where
 				canceling_session cs
 			where 
 				us.public_id = cs.session_id 
+		) or 
+		-- idle sessions
+		us.public_id in (
+			select 
+				session_id
+			from
+				idle_session ids
+			where 
+				us.public_id = ids.session_id 
 		)
 	) and 
 	-- make sure there are no existing connections
@@ -0,0 +1,151 @@
+package session
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultRecordingTableName = "session_recording"
+)
+
+// Recording is the recording of a channel of a session connection. Workers
+// record the terminal I/O of the channels opened on connections to ssh
+// targets.
+type Recording struct {
+	// PublicId is used to access the recording via an API
+	PublicId string `json:"public_id,omitempty" gorm:"primary_key"`
+	// SessionId of the recording
+	SessionId string `json:"session_id,omitempty" gorm:"default:null"`
+	// ConnectionId of the connection the recorded channel was opened on
+	ConnectionId string `json:"connection_id,omitempty" gorm:"default:null"`
+	// ChannelId is the position of the recorded channel among the channels
+	// of the connection
+	ChannelId uint32 `json:"channel_id,omitempty"`
+	// StartTime of the recorded channel
+	StartTime *timestamp.Timestamp `json:"start_time,omitempty" gorm:"default:null"`
+	// EndTime of the recorded channel
+	EndTime *timestamp.Timestamp `json:"end_time,omitempty" gorm:"default:null"`
+	// Data of the recording in asciicast v2 format
+	Data []byte `json:"data,omitempty" gorm:"default:null"`
+	// CreateTime from the RDBMS
+	CreateTime *timestamp.Timestamp `json:"create_time,omitempty" gorm:"default:current_timestamp"`
+
+	tableName string `gorm:"-"`
+}
+
+var _ Cloneable = (*Recording)(nil)
+var _ db.VetForWriter = (*Recording)(nil)
+
+// NewRecording creates a new in memory recording.  No options are currently
+// supported.
+func NewRecording(sessionId, connectionId string, channelId uint32, startTime, endTime *timestamp.Timestamp, data []byte, opt ...Option) (*Recording, error) {
+	r := Recording{
+		SessionId:    sessionId,
+		ConnectionId: connectionId,
+		ChannelId:    channelId,
+		StartTime:    startTime,
+		EndTime:      endTime,
+		Data:         data,
+	}
+	if err := r.validate("new recording:"); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// AllocRecording will allocate a Recording
+func AllocRecording() Recording {
+	return Recording{}
+}
+
+// Clone creates a clone of the Recording
+func (r *Recording) Clone() interface{} {
+	clone := &Recording{
+		PublicId:     r.PublicId,
+		SessionId:    r.SessionId,
+		ConnectionId: r.ConnectionId,
+		ChannelId:    r.ChannelId,
+		StartTime:    cloneTimestamp(r.StartTime),
+		EndTime:      cloneTimestamp(r.EndTime),
+		CreateTime:   cloneTimestamp(r.CreateTime),
+	}
+	if r.Data != nil {
+		clone.Data = make([]byte, len(r.Data))
+		copy(clone.Data, r.Data)
+	}
+	return clone
+}
+
+// VetForWrite implements db.VetForWrite() interface and validates the
+// recording before it's written.
+func (r *Recording) VetForWrite(ctx context.Context, _ db.Reader, opType db.OpType, opt ...db.Option) error {
+	if r.PublicId == "" {
+		return fmt.Errorf("recording vet for write: missing public id: %w", errors.ErrInvalidParameter)
+	}
+	if opType != db.CreateOp {
+		return fmt.Errorf("recording vet for write: recordings are immutable: %w", errors.ErrInvalidParameter)
+	}
+	if err := r.validate("recording vet for write:"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (r *Recording) TableName() string {
+	if r.tableName != "" {
+		return r.tableName
+	}
+	return defaultRecordingTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (r *Recording) SetTableName(n string) {
+	r.tableName = n
+}
+
+// validate checks the recording
+func (r *Recording) validate(errorPrefix string) error {
+	if r.SessionId == "" {
+		return fmt.Errorf("%s missing session id: %w", errorPrefix, errors.ErrInvalidParameter)
+	}
+	if r.ConnectionId == "" {
+		return fmt.Errorf("%s missing connection id: %w", errorPrefix, errors.ErrInvalidParameter)
+	}
+	if r.StartTime.GetTimestamp() == nil {
+		return fmt.Errorf("%s missing start time: %w", errorPrefix, errors.ErrInvalidParameter)
+	}
+	if r.EndTime.GetTimestamp() == nil {
+		return fmt.Errorf("%s missing end time: %w", errorPrefix, errors.ErrInvalidParameter)
+	}
+	if r.EndTime.GetTimestamp().AsTime().Before(r.StartTime.GetTimestamp().AsTime()) {
+		return fmt.Errorf("%s end time is before start time: %w", errorPrefix, errors.ErrInvalidParameter)
+	}
+	if len(r.Data) == 0 {
+		return fmt.Errorf("%s missing data: %w", errorPrefix, errors.ErrInvalidParameter)
+	}
+	if r.CreateTime != nil {
+		return fmt.Errorf("%s create time is not settable: %w", errorPrefix, errors.ErrInvalidParameter)
+	}
+	return nil
+}
+
+func cloneTimestamp(ts *timestamp.Timestamp) *timestamp.Timestamp {
+	if ts == nil || ts.Timestamp == nil {
+		return nil
+	}
+	return &timestamp.Timestamp{
+		Timestamp: &timestamppb.Timestamp{
+			Seconds: ts.Timestamp.Seconds,
+			Nanos:   ts.Timestamp.Nanos,
+		},
+	}
+}
@@ -90,6 +90,46 @@ func (r *Repository) DeleteConnection(ctx context.Context, publicId string, opt
 	return rowsDeleted, nil
 }
 
+// RecordHttpRequest records an HTTP request which was proxied over the
+// connection. No options are currently supported.
+func (r *Repository) RecordHttpRequest(ctx context.Context, connectionId, method, path string, statusCode int32, opt ...Option) (*ConnectionHttpRequest, error) {
+	req, err := NewConnectionHttpRequest(connectionId, method, path, statusCode)
+	if err != nil {
+		return nil, fmt.Errorf("record http request: %w", err)
+	}
+	var returnedReq *ConnectionHttpRequest
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedReq = req.Clone().(*ConnectionHttpRequest)
+			return w.Create(ctx, returnedReq)
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("record http request: failed %w for %s", err, connectionId)
+	}
+	return returnedReq, nil
+}
+
+// ListHttpRequests will list the HTTP requests recorded for a connection,
+// oldest first. Supports the WithLimit and WithOrder options.
+func (r *Repository) ListHttpRequests(ctx context.Context, connectionId string, opt ...Option) ([]*ConnectionHttpRequest, error) {
+	if connectionId == "" {
+		return nil, fmt.Errorf("list http requests: missing connection id: %w", errors.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	if opts.withOrder == "" {
+		opts.withOrder = "create_time asc"
+	}
+	var reqs []*ConnectionHttpRequest
+	if err := r.list(ctx, &reqs, "connection_id = ?", []interface{}{connectionId}, opts); err != nil {
+		return nil, fmt.Errorf("list http requests: %w", err)
+	}
+	return reqs, nil
+}
+
 func fetchConnectionStates(ctx context.Context, r db.Reader, connectionId string, opt ...db.Option) ([]*ConnectionState, error) {
 	var states []*ConnectionState
 	if err := r.SearchWhere(ctx, &states, "connection_id = ?", []interface{}{connectionId}, opt...); err != nil {
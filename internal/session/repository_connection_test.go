@@ -211,3 +211,52 @@ func TestRepository_DeleteConnection(t *testing.T) {
 		})
 	}
 }
+
+func TestRepository_RecordHttpRequest(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	session := TestDefaultSession(t, conn, wrapper, iamRepo)
+	c := TestConnection(t, conn, session.PublicId, "127.0.0.1", 22, "127.0.0.1", 2222)
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.RecordHttpRequest(context.Background(), c.PublicId, "GET", "/admin?page=2", 200)
+		require.NoError(err)
+		assert.NotNil(got.CreateTime)
+		_, err = repo.RecordHttpRequest(context.Background(), c.PublicId, "POST", "/admin/users", 403)
+		require.NoError(err)
+
+		reqs, err := repo.ListHttpRequests(context.Background(), c.PublicId)
+		require.NoError(err)
+		require.Len(reqs, 2)
+		assert.Equal("GET", reqs[0].Method)
+		assert.Equal("/admin?page=2", reqs[0].Path)
+		assert.Equal(int32(200), reqs[0].StatusCode)
+		assert.Equal("POST", reqs[1].Method)
+		assert.Equal(int32(403), reqs[1].StatusCode)
+	})
+	t.Run("missing-method", func(t *testing.T) {
+		assert := assert.New(t)
+		_, err := repo.RecordHttpRequest(context.Background(), c.PublicId, "", "/", 200)
+		assert.Error(err)
+		assert.True(errors.Is(err, errors.ErrInvalidParameter))
+	})
+	t.Run("invalid-status", func(t *testing.T) {
+		assert := assert.New(t)
+		_, err := repo.RecordHttpRequest(context.Background(), c.PublicId, "GET", "/", 42)
+		assert.Error(err)
+		assert.True(errors.Is(err, errors.ErrInvalidParameter))
+	})
+	t.Run("unknown-connection", func(t *testing.T) {
+		id, err := newConnectionId()
+		require.NoError(t, err)
+		_, err = repo.RecordHttpRequest(context.Background(), id, "GET", "/", 200)
+		assert.Error(t, err)
+	})
+}
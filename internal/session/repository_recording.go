@@ -0,0 +1,73 @@
+package session
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// CreateRecording inserts the recording of a channel of a session connection
+// into the repository and returns the new recording. The connection must
+// belong to the recording's session. No options are currently supported.
+func (r *Repository) CreateRecording(ctx context.Context, recording *Recording, opt ...Option) (*Recording, error) {
+	if recording == nil {
+		return nil, fmt.Errorf("create recording: missing recording: %w", errors.ErrInvalidParameter)
+	}
+	if recording.PublicId != "" {
+		return nil, fmt.Errorf("create recording: public id is not empty: %w", errors.ErrInvalidParameter)
+	}
+	if err := recording.validate("create recording:"); err != nil {
+		return nil, err
+	}
+	id, err := newRecordingId()
+	if err != nil {
+		return nil, fmt.Errorf("create recording: %w", err)
+	}
+
+	var returnedRecording *Recording
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(read db.Reader, w db.Writer) error {
+			connection := AllocConnection()
+			connection.PublicId = recording.ConnectionId
+			if err := read.LookupById(ctx, &connection); err != nil {
+				return fmt.Errorf("unable to look up connection: %w", err)
+			}
+			if connection.SessionId != recording.SessionId {
+				return fmt.Errorf("connection %s does not belong to session %s: %w", recording.ConnectionId, recording.SessionId, errors.ErrInvalidParameter)
+			}
+			returnedRecording = recording.Clone().(*Recording)
+			returnedRecording.PublicId = id
+			return w.Create(ctx, returnedRecording)
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create recording: %w for %s", err, recording.ConnectionId)
+	}
+	return returnedRecording, nil
+}
+
+// ListRecordings will list the recordings of a session, oldest first.
+// Supports the WithConnectionId, WithLimit and WithOrder options.
+func (r *Repository) ListRecordings(ctx context.Context, sessionId string, opt ...Option) ([]*Recording, error) {
+	if sessionId == "" {
+		return nil, fmt.Errorf("list recordings: missing session id: %w", errors.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	if opts.withOrder == "" {
+		opts.withOrder = "start_time asc, channel_id asc"
+	}
+	where, args := "session_id = ?", []interface{}{sessionId}
+	if opts.withConnectionId != "" {
+		where, args = where+" and connection_id = ?", append(args, opts.withConnectionId)
+	}
+	var recordings []*Recording
+	if err := r.list(ctx, &recordings, where, args, opts); err != nil {
+		return nil, fmt.Errorf("list recordings: %w", err)
+	}
+	return recordings, nil
+}
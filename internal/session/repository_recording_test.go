@@ -0,0 +1,91 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestRepository_CreateRecording(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	session := TestDefaultSession(t, conn, wrapper, iamRepo)
+	c1 := TestConnection(t, conn, session.PublicId, "127.0.0.1", 22, "127.0.0.1", 2222)
+	c2 := TestConnection(t, conn, session.PublicId, "127.0.0.1", 22, "127.0.0.1", 2222)
+	otherSession := TestDefaultSession(t, conn, wrapper, iamRepo)
+
+	start := time.Now().Add(-time.Minute)
+	startTime := &timestamp.Timestamp{Timestamp: timestamppb.New(start)}
+	endTime := &timestamp.Timestamp{Timestamp: timestamppb.New(start.Add(30 * time.Second))}
+	data := []byte(`{"version":2,"width":80,"height":24}` + "\n" + `[0.5,"i","ls\r"]` + "\n")
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		for i, c := range []*Connection{c1, c1, c2} {
+			r, err := NewRecording(session.PublicId, c.PublicId, uint32(i), startTime, endTime, data)
+			require.NoError(err)
+			got, err := repo.CreateRecording(context.Background(), r)
+			require.NoError(err)
+			assert.NotEmpty(got.PublicId)
+			assert.NotNil(got.CreateTime)
+		}
+
+		got, err := repo.ListRecordings(context.Background(), session.PublicId)
+		require.NoError(err)
+		require.Len(got, 3)
+		assert.Equal(data, got[0].Data)
+
+		got, err = repo.ListRecordings(context.Background(), session.PublicId, WithConnectionId(c2.PublicId))
+		require.NoError(err)
+		require.Len(got, 1)
+		assert.Equal(c2.PublicId, got[0].ConnectionId)
+	})
+	t.Run("duplicate-channel", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		r, err := NewRecording(session.PublicId, c1.PublicId, 0, startTime, endTime, data)
+		require.NoError(err)
+		_, err = repo.CreateRecording(context.Background(), r)
+		assert.Error(err)
+	})
+	t.Run("connection-of-other-session", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		r, err := NewRecording(otherSession.PublicId, c1.PublicId, 10, startTime, endTime, data)
+		require.NoError(err)
+		_, err = repo.CreateRecording(context.Background(), r)
+		assert.Error(err)
+		assert.True(errors.Is(err, errors.ErrInvalidParameter))
+	})
+	t.Run("end-before-start", func(t *testing.T) {
+		assert := assert.New(t)
+		_, err := NewRecording(session.PublicId, c1.PublicId, 11, endTime, startTime, data)
+		assert.Error(err)
+		assert.True(errors.Is(err, errors.ErrInvalidParameter))
+	})
+	t.Run("missing-data", func(t *testing.T) {
+		assert := assert.New(t)
+		_, err := NewRecording(session.PublicId, c1.PublicId, 12, startTime, endTime, nil)
+		assert.Error(err)
+		assert.True(errors.Is(err, errors.ErrInvalidParameter))
+	})
+	t.Run("list-missing-session-id", func(t *testing.T) {
+		assert := assert.New(t)
+		_, err := repo.ListRecordings(context.Background(), "")
+		assert.Error(err)
+		assert.True(errors.Is(err, errors.ErrInvalidParameter))
+	})
+}
@@ -163,7 +163,9 @@ func (r *Repository) LookupSession(ctx context.Context, sessionId string, opt ..
 	return &session, authzSummary, nil
 }
 
-// ListSessions will sessions.  Supports the WithLimit, WithScopeId and WithSessionIds options.
+// ListSessions will sessions.  Supports the WithLimit, WithScopeId,
+// WithScopeIds, WithSessionIds, WithStartPageAfterId, WithStateChangedWithin
+// and WithUnterminated options.
 func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Session, error) {
 	opts := getOpts(opt...)
 	var where []string
@@ -174,6 +176,13 @@ func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Sessio
 	case opts.withScopeId != "":
 		inClauseCnt += 1
 		where, args = append(where, fmt.Sprintf("scope_id = $%d", inClauseCnt)), append(args, opts.withScopeId)
+	case len(opts.withScopeIds) > 0:
+		scopesInClause := make([]string, 0, len(opts.withScopeIds))
+		for _, id := range opts.withScopeIds {
+			inClauseCnt += 1
+			scopesInClause, args = append(scopesInClause, fmt.Sprintf("$%d", inClauseCnt)), append(args, id)
+		}
+		where = append(where, fmt.Sprintf("scope_id in(%s)", strings.Join(scopesInClause, ",")))
 	case opts.withUserId != "":
 		inClauseCnt += 1
 		where, args = append(where, fmt.Sprintf("user_id = $%d", inClauseCnt)), append(args, opts.withUserId)
@@ -184,7 +193,18 @@ func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Sessio
 			inClauseCnt += 1
 			idsInClause, args = append(idsInClause, fmt.Sprintf("$%d", inClauseCnt)), append(args, id)
 		}
-		where = append(where, fmt.Sprintf("s.public_id in(%s)", strings.Join(idsInClause, ",")))
+		where = append(where, fmt.Sprintf("public_id in(%s)", strings.Join(idsInClause, ",")))
+	}
+	if opts.withStateChangedWithin > 0 {
+		inClauseCnt += 1
+		where, args = append(where, fmt.Sprintf("public_id in(select session_id from session_state where start_time > now() - $%d * interval '1 second')", inClauseCnt)), append(args, opts.withStateChangedWithin.Seconds())
+	}
+	if opts.withUnterminated {
+		where = append(where, "public_id in(select session_id from session_state where end_time is null and state != 'terminated')")
+	}
+	if opts.withStartPageAfterId != "" {
+		inClauseCnt += 1
+		where, args = append(where, fmt.Sprintf("public_id > $%d", inClauseCnt)), append(args, opts.withStartPageAfterId)
 	}
 
 	var limit string
@@ -197,16 +217,21 @@ func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Sessio
 		limit = fmt.Sprintf("limit %d", opts.withLimit)
 	}
 
-	if opts.withOrder != "" {
-		opts.withOrder = fmt.Sprintf("order by %s", opts.withOrder)
+	// The conditions and limit are applied when selecting the sessions so
+	// that the limit counts sessions rather than their states, and sessions
+	// are selected in order of their ids so that listing can be resumed with
+	// WithStartPageAfterId.
+	sessionClause := fmt.Sprintf("order by public_id %s", limit)
+	if len(where) > 0 {
+		sessionClause = fmt.Sprintf("where %s %s", strings.Join(where, " and "), sessionClause)
 	}
 
-	var whereClause string
-	if len(where) > 0 {
-		whereClause = " and " + strings.Join(where, " and")
+	order := "order by ss.public_id"
+	if opts.withOrder != "" {
+		order = fmt.Sprintf("order by %s", opts.withOrder)
 	}
-	q := sessionList
-	query := fmt.Sprintf(q, limit, whereClause, opts.withOrder)
+
+	query := fmt.Sprintf(sessionList, sessionClause, order)
 
 	rows, err := r.reader.Query(ctx, query, args)
 	if err != nil {
@@ -336,6 +361,7 @@ func (r *Repository) TerminateSession(ctx context.Context, sessionId string, ses
 //  * sessions that have exhausted their connection limit and all their connections are closed.
 //	* sessions that are expired and all their connections are closed.
 //	* sessions that are canceling and all their connections are closed
+//	* sessions with a connection closed for being idle and all their connections are closed.
 // This function should called on a periodic basis a Controllers via it's
 // "ticker" pattern.
 func (r *Repository) TerminateCompletedSessions(ctx context.Context) (int, error) {
@@ -2,6 +2,7 @@ package session
 
 import (
 	"context"
+	"sort"
 	"testing"
 	"time"
 
@@ -166,6 +167,61 @@ func TestRepository_ListSession(t *testing.T) {
 		assert.Equal(StatusActive, got[0].States[0].Status)
 		assert.Equal(StatusPending, got[0].States[1].Status)
 	})
+	t.Run("WithStateChangedWithin", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		require.NoError(conn.Where("1=1").Delete(AllocSession()).Error)
+		old := TestSession(t, conn, wrapper, composedOf)
+		time.Sleep(3 * time.Second)
+		changed := TestSession(t, conn, wrapper, composedOf)
+
+		got, err := repo.ListSessions(context.Background(), WithStateChangedWithin(2*time.Second))
+		require.NoError(err)
+		require.Len(got, 1)
+		assert.Equal(changed.PublicId, got[0].PublicId)
+
+		// A state change brings a session back
+		_ = TestState(t, conn, old.PublicId, StatusActive)
+		got, err = repo.ListSessions(context.Background(), WithStateChangedWithin(2*time.Second))
+		require.NoError(err)
+		assert.Len(got, 2)
+	})
+	t.Run("WithUnterminated", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		require.NoError(conn.Where("1=1").Delete(AllocSession()).Error)
+		live := TestSession(t, conn, wrapper, composedOf)
+		terminated := TestSession(t, conn, wrapper, composedOf)
+		_ = TestState(t, conn, terminated.PublicId, StatusTerminated)
+
+		got, err := repo.ListSessions(context.Background(), WithUnterminated())
+		require.NoError(err)
+		require.Len(got, 1)
+		assert.Equal(live.PublicId, got[0].PublicId)
+	})
+	t.Run("WithStartPageAfterId", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		require.NoError(conn.Where("1=1").Delete(AllocSession()).Error)
+		var wantIds []string
+		for i := 0; i < 10; i++ {
+			s := TestSession(t, conn, wrapper, composedOf)
+			wantIds = append(wantIds, s.PublicId)
+		}
+		sort.Strings(wantIds)
+
+		var gotIds []string
+		var afterId string
+		for {
+			got, err := repo.ListSessions(context.Background(), WithScopeId(composedOf.ScopeId), WithStartPageAfterId(afterId), WithLimit(4))
+			require.NoError(err)
+			for _, s := range got {
+				gotIds = append(gotIds, s.PublicId)
+			}
+			if len(got) < 4 {
+				break
+			}
+			afterId = got[len(got)-1].PublicId
+		}
+		assert.Equal(wantIds, gotIds)
+	})
 }
 
 func TestRepository_CreateSession(t *testing.T) {
@@ -880,6 +936,38 @@ func TestRepository_TerminateCompletedSessions(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "idle-sessions",
+			setup: func() testArgs {
+				cnt := 5
+				wantTermed := map[string]TerminationReason{}
+				sessions := make([]*Session, 0, 5)
+				for i := 0; i < cnt; i++ {
+					// make one with a connection closed for being idle
+					s := setupFn(3, time.Hour+1, true)
+					connections, err := repo.ListConnections(context.Background(), s.PublicId)
+					require.NoError(t, err)
+					require.Len(t, connections, 1)
+					cw := CloseWith{
+						ConnectionId: connections[0].PublicId,
+						BytesUp:      1,
+						BytesDown:    1,
+						ClosedReason: ConnectionIdleTimeout,
+					}
+					_, err = repo.CloseConnections(context.Background(), []CloseWith{cw})
+					require.NoError(t, err)
+					wantTermed[s.PublicId] = SessionIdleTimeout
+
+					// make one with a connection closed by the user
+					s2 := setupFn(3, time.Hour+1, false)
+					sessions = append(sessions, s, s2)
+				}
+				return testArgs{
+					sessions:   sessions,
+					wantTermed: wantTermed,
+				}
+			},
+		},
 		{
 			name: "sessions-with-unlimited-connections",
 			setup: func() testArgs {
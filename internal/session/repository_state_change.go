@@ -0,0 +1,59 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// StateChange is a state which a session, or one of its connections, entered
+type StateChange struct {
+	// SessionId of the session
+	SessionId string
+	// ConnectionId of the connection, empty for the states of the session
+	ConnectionId string
+	// State is a Status for the states of the session, and a
+	// ConnectionStatus for the states of the connection
+	State string
+	// StartTime of the state from the RDBMS
+	StartTime *timestamp.Timestamp
+	// ClientTcpAddress of the connection
+	ClientTcpAddress string
+	// ClientTcpPort of the connection
+	ClientTcpPort uint32
+	// BytesUp of the connection, set for closed connections
+	BytesUp uint64
+	// BytesDown of the connection, set for closed connections
+	BytesDown uint64
+	// Reason is the termination reason of a terminated session, or the
+	// closed reason of a closed connection
+	Reason string
+}
+
+// ListStateChanges returns the states which sessions and their connections
+// entered within d, ordered by their start time. States are recorded by the
+// controller handling each change, so this lets every controller find out
+// about them.
+func (r *Repository) ListStateChanges(ctx context.Context, d time.Duration) ([]*StateChange, error) {
+	if d <= 0 {
+		return nil, fmt.Errorf("list state changes: duration must be positive: %w", errors.ErrInvalidParameter)
+	}
+	rows, err := r.reader.Query(ctx, listStateChanges, []interface{}{d.Seconds()})
+	if err != nil {
+		return nil, fmt.Errorf("list state changes: query failed: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []*StateChange
+	for rows.Next() {
+		var c StateChange
+		if err := r.reader.ScanRows(rows, &c); err != nil {
+			return nil, fmt.Errorf("list state changes: scan row failed: %w", err)
+		}
+		changes = append(changes, &c)
+	}
+	return changes, nil
+}
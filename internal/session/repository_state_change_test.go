@@ -0,0 +1,56 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ListStateChanges(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	t.Run("invalid-duration", func(t *testing.T) {
+		_, err := repo.ListStateChanges(context.Background(), 0)
+		assert.Error(t, err)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		old := TestDefaultSession(t, conn, wrapper, iamRepo)
+		time.Sleep(3 * time.Second)
+		sess := TestDefaultSession(t, conn, wrapper, iamRepo)
+		c := TestConnection(t, conn, sess.PublicId, "127.0.0.1", 22, "127.0.0.1", 2222)
+		_ = TestState(t, conn, old.PublicId, StatusCanceling)
+
+		got, err := repo.ListStateChanges(context.Background(), 2*time.Second)
+		require.NoError(err)
+		require.Len(got, 3)
+
+		assert.Equal(sess.PublicId, got[0].SessionId)
+		assert.Empty(got[0].ConnectionId)
+		assert.Equal(StatusPending.String(), got[0].State)
+		assert.NotNil(got[0].StartTime)
+
+		assert.Equal(sess.PublicId, got[1].SessionId)
+		assert.Equal(c.PublicId, got[1].ConnectionId)
+		assert.Equal(StatusConnected.String(), got[1].State)
+		assert.Equal("127.0.0.1", got[1].ClientTcpAddress)
+		assert.Equal(uint32(22), got[1].ClientTcpPort)
+
+		assert.Equal(old.PublicId, got[2].SessionId)
+		assert.Equal(StatusCanceling.String(), got[2].State)
+	})
+}
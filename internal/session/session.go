@@ -45,6 +45,9 @@ type ComposedOf struct {
 	ExpirationTime *timestamp.Timestamp
 	// Max connections for the session
 	ConnectionLimit int32
+	// Seconds without traffic after which a connection of the session is
+	// closed, zero for no idle timeout
+	IdleTimeoutSeconds uint32
 }
 
 // Session contains information about a user's session with a target
@@ -89,6 +92,9 @@ type Session struct {
 	Endpoint string `json:"-" gorm:"default:null"`
 	// Maximum number of connections in a session
 	ConnectionLimit int32 `json:"connection_limit,omitempty" gorm:"default:null"`
+	// IdleTimeoutSeconds is the number of seconds without traffic after which
+	// the worker closes a connection of the session. Zero for no idle timeout.
+	IdleTimeoutSeconds uint32 `json:"idle_timeout_seconds,omitempty" gorm:"default:null"`
 
 	// key_id is the key ID that was used for the encryption operation. It can be
 	// used to identify a specific version of the key needed to decrypt the value,
@@ -112,15 +118,16 @@ var _ db.VetForWriter = (*Session)(nil)
 // New creates a new in memory session.
 func New(c ComposedOf, opt ...Option) (*Session, error) {
 	s := Session{
-		UserId:          c.UserId,
-		HostId:          c.HostId,
-		TargetId:        c.TargetId,
-		HostSetId:       c.HostSetId,
-		AuthTokenId:     c.AuthTokenId,
-		ScopeId:         c.ScopeId,
-		Endpoint:        c.Endpoint,
-		ExpirationTime:  c.ExpirationTime,
-		ConnectionLimit: c.ConnectionLimit,
+		UserId:             c.UserId,
+		HostId:             c.HostId,
+		TargetId:           c.TargetId,
+		HostSetId:          c.HostSetId,
+		AuthTokenId:        c.AuthTokenId,
+		ScopeId:            c.ScopeId,
+		Endpoint:           c.Endpoint,
+		ExpirationTime:     c.ExpirationTime,
+		ConnectionLimit:    c.ConnectionLimit,
+		IdleTimeoutSeconds: c.IdleTimeoutSeconds,
 	}
 	if err := s.validateNewSession("new session:"); err != nil {
 		return nil, err
@@ -136,19 +143,20 @@ func AllocSession() Session {
 // Clone creates a clone of the Session
 func (s *Session) Clone() interface{} {
 	clone := &Session{
-		PublicId:          s.PublicId,
-		UserId:            s.UserId,
-		HostId:            s.HostId,
-		ServerId:          s.ServerId,
-		ServerType:        s.ServerType,
-		TargetId:          s.TargetId,
-		HostSetId:         s.HostSetId,
-		AuthTokenId:       s.AuthTokenId,
-		ScopeId:           s.ScopeId,
-		TerminationReason: s.TerminationReason,
-		Version:           s.Version,
-		Endpoint:          s.Endpoint,
-		ConnectionLimit:   s.ConnectionLimit,
+		PublicId:           s.PublicId,
+		UserId:             s.UserId,
+		HostId:             s.HostId,
+		ServerId:           s.ServerId,
+		ServerType:         s.ServerType,
+		TargetId:           s.TargetId,
+		HostSetId:          s.HostSetId,
+		AuthTokenId:        s.AuthTokenId,
+		ScopeId:            s.ScopeId,
+		TerminationReason:  s.TerminationReason,
+		Version:            s.Version,
+		Endpoint:           s.Endpoint,
+		ConnectionLimit:    s.ConnectionLimit,
+		IdleTimeoutSeconds: s.IdleTimeoutSeconds,
 	}
 	if len(s.States) > 0 {
 		clone.States = make([]*State, 0, len(s.States))
@@ -237,6 +245,8 @@ func (s *Session) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType
 			return fmt.Errorf("session vet for write: expiration time is immutable: %w", errors.ErrInvalidParameter)
 		case contains(opts.WithFieldMaskPaths, "ConnectionLimit"):
 			return fmt.Errorf("session vet for write: connection limit is immutable: %w", errors.ErrInvalidParameter)
+		case contains(opts.WithFieldMaskPaths, "IdleTimeoutSeconds"):
+			return fmt.Errorf("session vet for write: idle timeout seconds is immutable: %w", errors.ErrInvalidParameter)
 		case contains(opts.WithFieldMaskPaths, "TerminationReason"):
 			if _, err := convertToReason(s.TerminationReason); err != nil {
 				return fmt.Errorf("session vet for write: termination reason '%s' is invalid: %w", s.TerminationReason, errors.ErrInvalidParameter)
@@ -366,26 +376,27 @@ func (s *Session) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
 
 type sessionView struct {
 	// Session fields
-	PublicId          string               `json:"public_id,omitempty" gorm:"primary_key"`
-	UserId            string               `json:"user_id,omitempty" gorm:"default:null"`
-	HostId            string               `json:"host_id,omitempty" gorm:"default:null"`
-	ServerId          string               `json:"server_id,omitempty" gorm:"default:null"`
-	ServerType        string               `json:"server_type,omitempty" gorm:"default:null"`
-	TargetId          string               `json:"target_id,omitempty" gorm:"default:null"`
-	HostSetId         string               `json:"host_set_id,omitempty" gorm:"default:null"`
-	AuthTokenId       string               `json:"auth_token_id,omitempty" gorm:"default:null"`
-	ScopeId           string               `json:"scope_id,omitempty" gorm:"default:null"`
-	Certificate       []byte               `json:"certificate,omitempty" gorm:"default:null"`
-	ExpirationTime    *timestamp.Timestamp `json:"expiration_time,omitempty" gorm:"default:null"`
-	CtTofuToken       []byte               `json:"ct_tofu_token,omitempty" gorm:"column:tofu_token;default:null" wrapping:"ct,tofu_token"`
-	TofuToken         []byte               `json:"tofu_token,omitempty" gorm:"-" wrapping:"pt,tofu_token"`
-	TerminationReason string               `json:"termination_reason,omitempty" gorm:"default:null"`
-	CreateTime        *timestamp.Timestamp `json:"create_time,omitempty" gorm:"default:current_timestamp"`
-	UpdateTime        *timestamp.Timestamp `json:"update_time,omitempty" gorm:"default:current_timestamp"`
-	Version           uint32               `json:"version,omitempty" gorm:"default:null"`
-	Endpoint          string               `json:"-" gorm:"default:null"`
-	ConnectionLimit   int32                `json:"connection_limit,omitempty" gorm:"default:null"`
-	KeyId             string               `json:"key_id,omitempty" gorm:"not_null"`
+	PublicId           string               `json:"public_id,omitempty" gorm:"primary_key"`
+	UserId             string               `json:"user_id,omitempty" gorm:"default:null"`
+	HostId             string               `json:"host_id,omitempty" gorm:"default:null"`
+	ServerId           string               `json:"server_id,omitempty" gorm:"default:null"`
+	ServerType         string               `json:"server_type,omitempty" gorm:"default:null"`
+	TargetId           string               `json:"target_id,omitempty" gorm:"default:null"`
+	HostSetId          string               `json:"host_set_id,omitempty" gorm:"default:null"`
+	AuthTokenId        string               `json:"auth_token_id,omitempty" gorm:"default:null"`
+	ScopeId            string               `json:"scope_id,omitempty" gorm:"default:null"`
+	Certificate        []byte               `json:"certificate,omitempty" gorm:"default:null"`
+	ExpirationTime     *timestamp.Timestamp `json:"expiration_time,omitempty" gorm:"default:null"`
+	CtTofuToken        []byte               `json:"ct_tofu_token,omitempty" gorm:"column:tofu_token;default:null" wrapping:"ct,tofu_token"`
+	TofuToken          []byte               `json:"tofu_token,omitempty" gorm:"-" wrapping:"pt,tofu_token"`
+	TerminationReason  string               `json:"termination_reason,omitempty" gorm:"default:null"`
+	CreateTime         *timestamp.Timestamp `json:"create_time,omitempty" gorm:"default:current_timestamp"`
+	UpdateTime         *timestamp.Timestamp `json:"update_time,omitempty" gorm:"default:current_timestamp"`
+	Version            uint32               `json:"version,omitempty" gorm:"default:null"`
+	Endpoint           string               `json:"-" gorm:"default:null"`
+	ConnectionLimit    int32                `json:"connection_limit,omitempty" gorm:"default:null"`
+	IdleTimeoutSeconds uint32               `json:"idle_timeout_seconds,omitempty" gorm:"default:null"`
+	KeyId              string               `json:"key_id,omitempty" gorm:"not_null"`
 
 	// State fields
 	Status          string               `json:"state,omitempty" gorm:"column:state"`
@@ -0,0 +1,113 @@
+package target
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/target/store"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	DefaultHttpTableName = "target_http"
+)
+
+// HttpTarget is a target whose sessions are proxied by the worker at the HTTP
+// layer. The worker forwards each request to the endpoint, adding the
+// target's headers, and records the method, path and status of the request.
+type HttpTarget struct {
+	*store.HttpTarget
+	tableName string `gorm:"-"`
+}
+
+var _ Target = (*HttpTarget)(nil)
+var _ db.VetForWriter = (*HttpTarget)(nil)
+var _ oplog.ReplayableMessage = (*HttpTarget)(nil)
+
+// NewHttpTarget creates a new in memory http target. WithName,
+// WithDescription, WithDefaultPort, WithWorkerFilter and WithHeaders options
+// are supported
+func NewHttpTarget(scopeId string, opt ...Option) (*HttpTarget, error) {
+	opts := getOpts(opt...)
+	if scopeId == "" {
+		return nil, fmt.Errorf("new http target: missing scope id: %w", errors.ErrInvalidParameter)
+	}
+	t := &HttpTarget{
+		HttpTarget: &store.HttpTarget{
+			ScopeId:                   scopeId,
+			Name:                      opts.withName,
+			Description:               opts.withDescription,
+			DefaultPort:               opts.withDefaultPort,
+			SessionConnectionLimit:    opts.withSessionConnectionLimit,
+			SessionMaxSeconds:         opts.withSessionMaxSeconds,
+			WorkerFilter:              opts.withWorkerFilter,
+			SessionIdleTimeoutSeconds: opts.withSessionIdleTimeout,
+			Headers:                   opts.withHeaders,
+		},
+	}
+	return t, nil
+}
+
+// allocHttpTarget will allocate an http target
+func allocHttpTarget() HttpTarget {
+	return HttpTarget{
+		HttpTarget: &store.HttpTarget{},
+	}
+}
+
+// Clone creates a clone of the HttpTarget
+func (t *HttpTarget) Clone() interface{} {
+	cp := proto.Clone(t.HttpTarget)
+	return &HttpTarget{
+		HttpTarget: cp.(*store.HttpTarget),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface and validates the http
+// target before it's written.
+func (t *HttpTarget) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType, opt ...db.Option) error {
+	if t.PublicId == "" {
+		return fmt.Errorf("http target vet for write: missing public id: %w", errors.ErrInvalidParameter)
+	}
+	if opType == db.CreateOp {
+		if t.ScopeId == "" {
+			return fmt.Errorf("http target vet for write: missing scope id: %w", errors.ErrInvalidParameter)
+		}
+		if t.Name == "" {
+			return fmt.Errorf("http target vet for write: missing name id: %w", errors.ErrInvalidParameter)
+		}
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (t *HttpTarget) TableName() string {
+	if t.tableName != "" {
+		return t.tableName
+	}
+	return DefaultHttpTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (t *HttpTarget) SetTableName(n string) {
+	t.tableName = n
+}
+
+func (t *HttpTarget) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{t.PublicId},
+		"resource-type":      []string{"http target"},
+		"op-type":            []string{op.String()},
+		"scope-id":           []string{t.ScopeId},
+	}
+	return metadata
+}
+
+func (t HttpTarget) GetType() string {
+	return "http"
+}
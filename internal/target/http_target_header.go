@@ -0,0 +1,133 @@
+package target
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/target/store"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/structwrapping"
+	"github.com/hashicorp/go-kms-wrapping/wrappers/aead"
+	"github.com/hashicorp/go-kms-wrapping/wrappers/multiwrapper"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	DefaultHttpTargetHeaderTableName = "target_http_header"
+)
+
+// HttpTargetHeader is a header added by the worker to the requests it
+// forwards for an http target. The value of the header is stored encrypted.
+type HttpTargetHeader struct {
+	*store.HttpTargetHeader
+	tableName string `gorm:"-"`
+}
+
+var _ db.VetForWriter = (*HttpTargetHeader)(nil)
+var _ oplog.ReplayableMessage = (*HttpTargetHeader)(nil)
+
+// NewHttpTargetHeader creates a new in memory http target header. No options
+// are currently supported.
+func NewHttpTargetHeader(targetId, name, value string, opt ...Option) (*HttpTargetHeader, error) {
+	if targetId == "" {
+		return nil, fmt.Errorf("new http target header: missing target id: %w", errors.ErrInvalidParameter)
+	}
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("new http target header: missing name: %w", errors.ErrInvalidParameter)
+	}
+	h := &HttpTargetHeader{
+		HttpTargetHeader: &store.HttpTargetHeader{
+			TargetId: targetId,
+			Name:     name,
+			Value:    value,
+		},
+	}
+	return h, nil
+}
+
+// Clone creates a clone of the HttpTargetHeader
+func (h *HttpTargetHeader) Clone() interface{} {
+	cp := proto.Clone(h.HttpTargetHeader)
+	return &HttpTargetHeader{
+		HttpTargetHeader: cp.(*store.HttpTargetHeader),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface and validates the http
+// target header before it's written.
+func (h *HttpTargetHeader) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType, opt ...db.Option) error {
+	if h.TargetId == "" {
+		return fmt.Errorf("http target header vet for write: missing target id: %w", errors.ErrInvalidParameter)
+	}
+	if h.Name == "" {
+		return fmt.Errorf("http target header vet for write: missing name: %w", errors.ErrInvalidParameter)
+	}
+	if opType == db.CreateOp && len(h.CtValue) == 0 {
+		return fmt.Errorf("http target header vet for write: value is not encrypted: %w", errors.ErrInvalidParameter)
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (h *HttpTargetHeader) TableName() string {
+	if h.tableName != "" {
+		return h.tableName
+	}
+	return DefaultHttpTargetHeaderTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (h *HttpTargetHeader) SetTableName(n string) {
+	h.tableName = n
+}
+
+// encrypt encrypts the value and sets the value hmac and the key id.
+func (h *HttpTargetHeader) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	// structwrapping doesn't support embedding, so we'll pass in the store.HttpTargetHeader directly
+	if err := structwrapping.WrapStruct(ctx, cipher, h.HttpTargetHeader, nil); err != nil {
+		return fmt.Errorf("error encrypting header value: %w", err)
+	}
+	mac, err := hmacHeaderValue(cipher, h.Value)
+	if err != nil {
+		return err
+	}
+	h.ValueHmac = mac
+	h.KeyId = cipher.KeyID()
+	return nil
+}
+
+func (h *HttpTargetHeader) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	// structwrapping doesn't support embedding, so we'll pass in the store.HttpTargetHeader directly
+	if err := structwrapping.UnwrapStruct(ctx, cipher, h.HttpTargetHeader, nil); err != nil {
+		return fmt.Errorf("error decrypting header value: %w", err)
+	}
+	return nil
+}
+
+// hmacHeaderValue returns a sha256-hmac of value keyed with the key of
+// cipher. The hmac lets callers detect a change to the value of a header
+// without the value being returned to them.
+func hmacHeaderValue(cipher wrapping.Wrapper, value string) (string, error) {
+	w := cipher
+	if mw, ok := w.(*multiwrapper.MultiWrapper); ok {
+		w = mw.WrapperForKeyID(mw.KeyID())
+	}
+	aw, ok := w.(*aead.Wrapper)
+	if !ok {
+		return "", fmt.Errorf("unable to hmac header value: unsupported wrapper type %T: %w", cipher, errors.ErrInvalidParameter)
+	}
+	mac := hmac.New(sha256.New, aw.GetKeyBytes())
+	if _, err := mac.Write([]byte(value)); err != nil {
+		return "", fmt.Errorf("unable to hmac header value: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
@@ -0,0 +1,151 @@
+package target
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestHttpTarget_Create(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	tests := []struct {
+		name      string
+		scopeId   string
+		opt       []Option
+		want      *HttpTarget
+		wantErr   bool
+		wantIsErr error
+		create    bool
+	}{
+		{
+			name:      "empty-scopeId",
+			wantErr:   true,
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name:    "valid-proj-scope",
+			scopeId: prj.PublicId,
+			opt: []Option{
+				WithName("valid-proj-scope"),
+				WithHeaders(map[string]string{"X-Forwarded-User": "admin"}),
+			},
+			want: func() *HttpTarget {
+				t := allocHttpTarget()
+				t.ScopeId = prj.PublicId
+				t.Name = "valid-proj-scope"
+				t.SessionMaxSeconds = uint32((8 * time.Hour).Seconds())
+				t.SessionConnectionLimit = 1
+				t.Headers = map[string]string{"X-Forwarded-User": "admin"}
+				return &t
+			}(),
+			create: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewHttpTarget(tt.scopeId, tt.opt...)
+			if tt.wantErr {
+				require.Error(err)
+				assert.True(errors.Is(err, tt.wantIsErr))
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+			if tt.create {
+				id, err := newHttpTargetId()
+				require.NoError(err)
+				got.PublicId = id
+				require.NoError(db.New(conn).Create(context.Background(), got))
+			}
+		})
+	}
+}
+
+func TestHttpTarget_Clone(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	target := TestHttpTarget(t, conn, wrapper, proj.PublicId, testTargetName(t, proj.PublicId), WithHeaders(map[string]string{"X-Test": "one"}))
+	cp := target.Clone()
+	assert.True(t, proto.Equal(cp.(*HttpTarget).HttpTarget, target.HttpTarget))
+}
+
+func TestHttpTarget_oplog(t *testing.T) {
+	id := testId(t)
+	target := allocHttpTarget()
+	target.PublicId = id
+	target.ScopeId = id
+	want := oplog.Metadata{
+		"resource-public-id": []string{id},
+		"resource-type":      []string{"http target"},
+		"op-type":            []string{oplog.OpType_OP_TYPE_CREATE.String()},
+		"scope-id":           []string{id},
+	}
+	assert.Equal(t, want, target.oplog(oplog.OpType_OP_TYPE_CREATE))
+}
+
+func TestNewHttpTargetHeader(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		targetId string
+		hdrName  string
+		wantErr  bool
+	}{
+		{name: "valid", targetId: "thttp_1234567890", hdrName: "X-Test"},
+		{name: "missing-target", hdrName: "X-Test", wantErr: true},
+		{name: "missing-name", targetId: "thttp_1234567890", hdrName: " ", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewHttpTargetHeader(tt.targetId, tt.hdrName, "value")
+			if tt.wantErr {
+				require.Error(err)
+				assert.True(errors.Is(err, errors.ErrInvalidParameter))
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.targetId, got.TargetId)
+			assert.Equal(tt.hdrName, got.Name)
+			assert.Equal("value", got.Value)
+		})
+	}
+}
+
+func TestHttpTargetHeader_encrypt(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	wrapper := db.TestWrapper(t)
+
+	h, err := NewHttpTargetHeader("thttp_1234567890", "X-Test", "secret")
+	require.NoError(err)
+	require.NoError(h.encrypt(ctx, wrapper))
+	assert.NotEmpty(h.CtValue)
+	assert.NotEmpty(h.ValueHmac)
+	assert.NotEqual("secret", h.ValueHmac)
+	assert.Equal(wrapper.KeyID(), h.KeyId)
+
+	other, err := NewHttpTargetHeader("thttp_1234567890", "X-Test", "other")
+	require.NoError(err)
+	require.NoError(other.encrypt(ctx, wrapper))
+	assert.NotEqual(h.ValueHmac, other.ValueHmac)
+
+	h.Value = ""
+	require.NoError(h.decrypt(ctx, wrapper))
+	assert.Equal("secret", h.Value)
+}
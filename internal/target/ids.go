@@ -7,7 +7,10 @@ import (
 )
 
 const (
-	TcpTargetPrefix = "ttcp"
+	TcpTargetPrefix  = "ttcp"
+	HttpTargetPrefix = "thttp"
+	SshTargetPrefix  = "tssh"
+	UdpTargetPrefix  = "tudp"
 )
 
 func newTcpTargetId() (string, error) {
@@ -17,3 +20,27 @@ func newTcpTargetId() (string, error) {
 	}
 	return id, nil
 }
+
+func newHttpTargetId() (string, error) {
+	id, err := db.NewPublicId(HttpTargetPrefix)
+	if err != nil {
+		return "", fmt.Errorf("new http target id: %w", err)
+	}
+	return id, nil
+}
+
+func newSshTargetId() (string, error) {
+	id, err := db.NewPublicId(SshTargetPrefix)
+	if err != nil {
+		return "", fmt.Errorf("new ssh target id: %w", err)
+	}
+	return id, nil
+}
+
+func newUdpTargetId() (string, error) {
+	id, err := db.NewPublicId(UdpTargetPrefix)
+	if err != nil {
+		return "", fmt.Errorf("new udp target id: %w", err)
+	}
+	return id, nil
+}
@@ -20,14 +20,22 @@ type options struct {
 	withDescription            string
 	withDefaultPort            uint32
 	withLimit                  int
+	withStartPageAfterId       string
 	withScopeId                string
+	withScopeIds               []string
 	withScopeName              string
 	withUserId                 string
 	withTargetType             *TargetType
 	withHostSets               []string
 	withSessionMaxSeconds      uint32
 	withSessionConnectionLimit int32
+	withSessionIdleTimeout     uint32
 	withPublicId               string
+	withWorkerFilter           string
+	withHeaders                map[string]string
+	withHostKeys               string
+	withHostCaKeys             string
+	withInsecureIgnoreHostKey  bool
 }
 
 func getDefaultOptions() options {
@@ -43,7 +51,13 @@ func getDefaultOptions() options {
 		withHostSets:               nil,
 		withSessionMaxSeconds:      uint32((8 * time.Hour).Seconds()),
 		withSessionConnectionLimit: 1,
+		withSessionIdleTimeout:     0,
 		withPublicId:               "",
+		withWorkerFilter:           "",
+		withHeaders:                nil,
+		withHostKeys:               "",
+		withHostCaKeys:             "",
+		withInsecureIgnoreHostKey:  false,
 	}
 }
 
@@ -70,6 +84,15 @@ func WithLimit(limit int) Option {
 	}
 }
 
+// WithStartPageAfterId provides an option to list only the resources whose
+// public ids sort after the given id. Results are ordered by public id when
+// it is used, allowing listing to be resumed where a previous page ended.
+func WithStartPageAfterId(id string) Option {
+	return func(o *options) {
+		o.withStartPageAfterId = id
+	}
+}
+
 // WithDefaultPort provides an option to specify the default target port.
 func WithDefaultPort(p uint32) Option {
 	return func(o *options) {
@@ -84,6 +107,13 @@ func WithScopeId(scopeId string) Option {
 	}
 }
 
+// WithScopeIds provides an option to search by any of several scope ids
+func WithScopeIds(scopeIds ...string) Option {
+	return func(o *options) {
+		o.withScopeIds = scopeIds
+	}
+}
+
 // WithScopeId provides an option to search by a scope name
 func WithScopeName(scopeName string) Option {
 	return func(o *options) {
@@ -124,9 +154,56 @@ func WithSessionConnectionLimit(limit int32) Option {
 	}
 }
 
+// WithSessionIdleTimeoutSeconds provides an optional number of seconds
+// without traffic after which a connection of a session is closed
+func WithSessionIdleTimeoutSeconds(secs uint32) Option {
+	return func(o *options) {
+		o.withSessionIdleTimeout = secs
+	}
+}
+
 // WithPublicId provides an optional public id
 func WithPublicId(id string) Option {
 	return func(o *options) {
 		o.withPublicId = id
 	}
 }
+
+// WithWorkerFilter provides an optional worker filter
+func WithWorkerFilter(filter string) Option {
+	return func(o *options) {
+		o.withWorkerFilter = filter
+	}
+}
+
+// WithHeaders provides an optional set of headers for an http target
+func WithHeaders(h map[string]string) Option {
+	return func(o *options) {
+		o.withHeaders = h
+	}
+}
+
+// WithHostKeys provides the optional public keys, in authorized_keys format,
+// of the endpoint's host for an ssh target
+func WithHostKeys(keys string) Option {
+	return func(o *options) {
+		o.withHostKeys = keys
+	}
+}
+
+// WithHostCaKeys provides the optional public keys, in authorized_keys
+// format, of the certificate authorities of the endpoint's host certificate
+// for an ssh target
+func WithHostCaKeys(keys string) Option {
+	return func(o *options) {
+		o.withHostCaKeys = keys
+	}
+}
+
+// WithInsecureIgnoreHostKey provides an optional flag for an ssh target to
+// accept any host key from the endpoint
+func WithInsecureIgnoreHostKey(ignore bool) Option {
+	return func(o *options) {
+		o.withInsecureIgnoreHostKey = ignore
+	}
+}
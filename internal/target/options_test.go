@@ -41,6 +41,13 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withLimit = 1
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithStartPageAfterId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithStartPageAfterId("ttcp_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withStartPageAfterId = "ttcp_1234567890"
+		assert.Equal(opts, testOpts)
+	})
 	t.Run("WithDefaultPort", func(t *testing.T) {
 		assert := assert.New(t)
 		// test default of 0
@@ -68,6 +75,13 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withScopeId = "testId"
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithScopeIds", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithScopeIds("testId", "otherTestId"))
+		testOpts := getDefaultOptions()
+		testOpts.withScopeIds = []string{"testId", "otherTestId"}
+		assert.Equal(opts, testOpts)
+	})
 	t.Run("WithScopeName", func(t *testing.T) {
 		assert := assert.New(t)
 		opts := getOpts(WithScopeName("testName"))
@@ -97,4 +111,46 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withHostSets = []string{"alice", "bob"}
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithWorkerFilter", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithWorkerFilter(`"/name" == "test"`))
+		testOpts := getDefaultOptions()
+		testOpts.withWorkerFilter = `"/name" == "test"`
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithSessionIdleTimeoutSeconds", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithSessionIdleTimeoutSeconds(600))
+		testOpts := getDefaultOptions()
+		testOpts.withSessionIdleTimeout = 600
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithHeaders", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithHeaders(map[string]string{"X-Test": "value"}))
+		testOpts := getDefaultOptions()
+		testOpts.withHeaders = map[string]string{"X-Test": "value"}
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithHostKeys", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithHostKeys("ssh-ed25519 AAAA"))
+		testOpts := getDefaultOptions()
+		testOpts.withHostKeys = "ssh-ed25519 AAAA"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithHostCaKeys", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithHostCaKeys("ssh-ed25519 AAAA"))
+		testOpts := getDefaultOptions()
+		testOpts.withHostCaKeys = "ssh-ed25519 AAAA"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithInsecureIgnoreHostKey", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithInsecureIgnoreHostKey(true))
+		testOpts := getDefaultOptions()
+		testOpts.withInsecureIgnoreHostKey = true
+		assert.Equal(opts, testOpts)
+	})
 }
@@ -100,6 +100,8 @@ func (r *Repository) LookupTarget(ctx context.Context, publicIdOrName string, op
 	target := allocTargetView()
 	target.PublicId = publicIdOrName
 	var hostSets []*TargetSet
+	var headerHmacs map[string]string
+	var sshHostKeys *SshTarget
 	_, err := r.writer.DoTx(
 		ctx,
 		db.StdRetryCnt,
@@ -120,6 +122,21 @@ func (r *Repository) LookupTarget(ctx context.Context, publicIdOrName string, op
 			if hostSets, err = fetchSets(ctx, read, target.PublicId); err != nil {
 				return err
 			}
+			if target.Type == HttpTargetType.String() {
+				if headerHmacs, err = fetchHeaderHmacs(ctx, read, target.PublicId); err != nil {
+					return err
+				}
+			}
+			if target.Type == SshTargetType.String() {
+				// The host key fields of an ssh target are not part of the
+				// target view.
+				t := allocSshTarget()
+				t.PublicId = target.PublicId
+				if err := read.LookupByPublicId(ctx, &t); err != nil {
+					return err
+				}
+				sshHostKeys = &t
+			}
 			return nil
 		},
 	)
@@ -133,6 +150,12 @@ func (r *Repository) LookupTarget(ctx context.Context, publicIdOrName string, op
 	if err != nil {
 		return nil, nil, fmt.Errorf("lookup target: %w", err)
 	}
+	switch t := subType.(type) {
+	case *HttpTarget:
+		t.HeaderHmacs = headerHmacs
+	case *SshTarget:
+		setSshHostKeys(t, sshHostKeys)
+	}
 	return subType, hostSets, nil
 }
 
@@ -147,10 +170,11 @@ func fetchSets(ctx context.Context, r db.Reader, targetId string) ([]*TargetSet,
 	return hostSets, nil
 }
 
-// ListTargets in targets in a scope.  Supports the WithScopeId, WithLimit, WithTargetType options.
+// ListTargets in targets in a scope.  Supports the WithScopeId, WithScopeIds,
+// WithLimit, WithTargetType and WithStartPageAfterId options.
 func (r *Repository) ListTargets(ctx context.Context, opt ...Option) ([]Target, error) {
 	opts := getOpts(opt...)
-	if opts.withScopeId == "" && opts.withUserId == "" {
+	if opts.withScopeId == "" && len(opts.withScopeIds) == 0 && opts.withUserId == "" {
 		return nil, fmt.Errorf("list targets: must specify either a scope id or user id: %w", errors.ErrInvalidParameter)
 	}
 	// TODO (jimlambrt 8/2020) - implement WithUserId() optional filtering.
@@ -159,6 +183,9 @@ func (r *Repository) ListTargets(ctx context.Context, opt ...Option) ([]Target,
 	if opts.withScopeId != "" {
 		where, args = append(where, "scope_id = ?"), append(args, opts.withScopeId)
 	}
+	if len(opts.withScopeIds) > 0 {
+		where, args = append(where, "scope_id in (?)"), append(args, opts.withScopeIds)
+	}
 	if opts.withTargetType != nil {
 		where, args = append(where, "type = ?"), append(args, opts.withTargetType.String())
 	}
@@ -170,17 +197,67 @@ func (r *Repository) ListTargets(ctx context.Context, opt ...Option) ([]Target,
 	}
 
 	targets := make([]Target, 0, len(foundTargets))
-
+	var httpTargetIds, sshTargetIds []string
 	for _, t := range foundTargets {
 		subType, err := t.targetSubType()
 		if err != nil {
 			return nil, fmt.Errorf("list targets: %w", err)
 		}
+		switch t.Type {
+		case HttpTargetType.String():
+			httpTargetIds = append(httpTargetIds, t.PublicId)
+		case SshTargetType.String():
+			sshTargetIds = append(sshTargetIds, t.PublicId)
+		}
 		targets = append(targets, subType)
 	}
+	if len(httpTargetIds) > 0 {
+		var headers []*HttpTargetHeader
+		if err := r.reader.SearchWhere(ctx, &headers, "target_id in (?)", []interface{}{httpTargetIds}, db.WithLimit(-1)); err != nil {
+			return nil, fmt.Errorf("list targets: unable to search headers: %w", err)
+		}
+		byTarget := make(map[string]map[string]string, len(httpTargetIds))
+		for _, h := range headers {
+			if byTarget[h.TargetId] == nil {
+				byTarget[h.TargetId] = make(map[string]string)
+			}
+			byTarget[h.TargetId][h.Name] = h.ValueHmac
+		}
+		for _, t := range targets {
+			if httpT, ok := t.(*HttpTarget); ok {
+				httpT.HeaderHmacs = byTarget[httpT.PublicId]
+			}
+		}
+	}
+	if len(sshTargetIds) > 0 {
+		var sshTargets []*SshTarget
+		if err := r.reader.SearchWhere(ctx, &sshTargets, "public_id in (?)", []interface{}{sshTargetIds}, db.WithLimit(-1)); err != nil {
+			return nil, fmt.Errorf("list targets: unable to search ssh targets: %w", err)
+		}
+		byId := make(map[string]*SshTarget, len(sshTargets))
+		for _, t := range sshTargets {
+			byId[t.PublicId] = t
+		}
+		for _, t := range targets {
+			if sshT, ok := t.(*SshTarget); ok {
+				setSshHostKeys(sshT, byId[sshT.PublicId])
+			}
+		}
+	}
 	return targets, nil
 }
 
+// setSshHostKeys copies the host key fields of from, which is read from the
+// target_ssh table, to t.
+func setSshHostKeys(t, from *SshTarget) {
+	if from == nil {
+		return
+	}
+	t.HostKeys = from.HostKeys
+	t.HostCaKeys = from.HostCaKeys
+	t.InsecureIgnoreHostKey = from.InsecureIgnoreHostKey
+}
+
 // list will return a listing of resources and honor the WithLimit option or the
 // repo defaultLimit
 func (r *Repository) list(ctx context.Context, resources interface{}, where string, args []interface{}, opt ...Option) error {
@@ -191,7 +268,14 @@ func (r *Repository) list(ctx context.Context, resources interface{}, where stri
 		// non-zero signals an override of the default limit for the repo.
 		limit = opts.withLimit
 	}
-	dbOpts = append(dbOpts, db.WithLimit(limit))
+	switch {
+	case opts.withStartPageAfterId == "":
+	case where == "":
+		where, args = "public_id > ?", append(args, opts.withStartPageAfterId)
+	default:
+		where, args = fmt.Sprintf("(%s) and public_id > ?", where), append(args, opts.withStartPageAfterId)
+	}
+	dbOpts = append(dbOpts, db.WithLimit(limit), db.WithOrder("public_id"))
 	return r.reader.SearchWhere(ctx, resources, where, args, dbOpts...)
 }
 
@@ -213,6 +297,21 @@ func (r *Repository) DeleteTarget(ctx context.Context, publicId string, opt ...O
 		tcpT.PublicId = publicId
 		deleteTarget = &tcpT
 		metadata = tcpT.oplog(oplog.OpType_OP_TYPE_DELETE)
+	case HttpTargetType.String():
+		httpT := allocHttpTarget()
+		httpT.PublicId = publicId
+		deleteTarget = &httpT
+		metadata = httpT.oplog(oplog.OpType_OP_TYPE_DELETE)
+	case SshTargetType.String():
+		sshT := allocSshTarget()
+		sshT.PublicId = publicId
+		deleteTarget = &sshT
+		metadata = sshT.oplog(oplog.OpType_OP_TYPE_DELETE)
+	case UdpTargetType.String():
+		udpT := allocUdpTarget()
+		udpT.PublicId = publicId
+		deleteTarget = &udpT
+		metadata = udpT.oplog(oplog.OpType_OP_TYPE_DELETE)
 	default:
 		return db.NoRowsAffected, fmt.Errorf("delete target: %s is an unsupported target type %s", publicId, t.Type)
 	}
@@ -348,6 +447,27 @@ func (r *Repository) AddTargetHostSets(ctx context.Context, targetId string, tar
 		target = &tcpT
 		metadata = tcpT.oplog(oplog.OpType_OP_TYPE_UPDATE)
 		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_CREATE.String())
+	case HttpTargetType.String():
+		httpT := allocHttpTarget()
+		httpT.PublicId = t.PublicId
+		httpT.Version = targetVersion + 1
+		target = &httpT
+		metadata = httpT.oplog(oplog.OpType_OP_TYPE_UPDATE)
+		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_CREATE.String())
+	case SshTargetType.String():
+		sshT := allocSshTarget()
+		sshT.PublicId = t.PublicId
+		sshT.Version = targetVersion + 1
+		target = &sshT
+		metadata = sshT.oplog(oplog.OpType_OP_TYPE_UPDATE)
+		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_CREATE.String())
+	case UdpTargetType.String():
+		udpT := allocUdpTarget()
+		udpT.PublicId = t.PublicId
+		udpT.Version = targetVersion + 1
+		target = &udpT
+		metadata = udpT.oplog(oplog.OpType_OP_TYPE_UPDATE)
+		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_CREATE.String())
 	default:
 		return nil, nil, fmt.Errorf("delete target host sets: %s is an unsupported target type %s", t.PublicId, t.Type)
 	}
@@ -439,6 +559,27 @@ func (r *Repository) DeleteTargeHostSets(ctx context.Context, targetId string, t
 		target = &tcpT
 		metadata = tcpT.oplog(oplog.OpType_OP_TYPE_UPDATE)
 		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_DELETE.String())
+	case HttpTargetType.String():
+		httpT := allocHttpTarget()
+		httpT.PublicId = t.PublicId
+		httpT.Version = targetVersion + 1
+		target = &httpT
+		metadata = httpT.oplog(oplog.OpType_OP_TYPE_UPDATE)
+		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_DELETE.String())
+	case SshTargetType.String():
+		sshT := allocSshTarget()
+		sshT.PublicId = t.PublicId
+		sshT.Version = targetVersion + 1
+		target = &sshT
+		metadata = sshT.oplog(oplog.OpType_OP_TYPE_UPDATE)
+		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_DELETE.String())
+	case UdpTargetType.String():
+		udpT := allocUdpTarget()
+		udpT.PublicId = t.PublicId
+		udpT.Version = targetVersion + 1
+		target = &udpT
+		metadata = udpT.oplog(oplog.OpType_OP_TYPE_UPDATE)
+		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_DELETE.String())
 	default:
 		return db.NoRowsAffected, fmt.Errorf("delete target host sets: %s is an unsupported target type %s", t.PublicId, t.Type)
 	}
@@ -560,6 +701,24 @@ func (r *Repository) SetTargetHostSets(ctx context.Context, targetId string, tar
 		tcpT.Version = targetVersion + 1
 		target = &tcpT
 		metadata = tcpT.oplog(oplog.OpType_OP_TYPE_UPDATE)
+	case HttpTargetType.String():
+		httpT := allocHttpTarget()
+		httpT.PublicId = t.PublicId
+		httpT.Version = targetVersion + 1
+		target = &httpT
+		metadata = httpT.oplog(oplog.OpType_OP_TYPE_UPDATE)
+	case SshTargetType.String():
+		sshT := allocSshTarget()
+		sshT.PublicId = t.PublicId
+		sshT.Version = targetVersion + 1
+		target = &sshT
+		metadata = sshT.oplog(oplog.OpType_OP_TYPE_UPDATE)
+	case UdpTargetType.String():
+		udpT := allocUdpTarget()
+		udpT.PublicId = t.PublicId
+		udpT.Version = targetVersion + 1
+		target = &udpT
+		metadata = udpT.oplog(oplog.OpType_OP_TYPE_UPDATE)
 	default:
 		return nil, db.NoRowsAffected, fmt.Errorf("set target host sets: %s is an unsupported target type %s", t.PublicId, t.Type)
 	}
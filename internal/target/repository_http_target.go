@@ -0,0 +1,378 @@
+package target
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	dbcommon "github.com/hashicorp/boundary/internal/db/common"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+// CreateHttpTarget inserts into the repository and returns the new Target with
+// its list of host sets. The headers of the target are written along with it,
+// their values encrypted with the database key of the target's scope. The
+// returned Target has the hmacs of the header values in place of the values.
+// WithHostSets and WithPublicId are the only supported options.
+func (r *Repository) CreateHttpTarget(ctx context.Context, target *HttpTarget, opt ...Option) (Target, []*TargetSet, error) {
+	opts := getOpts(opt...)
+	if target == nil {
+		return nil, nil, fmt.Errorf("create http target: missing target: %w", errors.ErrInvalidParameter)
+	}
+	if target.HttpTarget == nil {
+		return nil, nil, fmt.Errorf("create http target: missing target store: %w", errors.ErrInvalidParameter)
+	}
+	if target.ScopeId == "" {
+		return nil, nil, fmt.Errorf("create http target: scope id empty: %w", errors.ErrInvalidParameter)
+	}
+	if target.Name == "" {
+		return nil, nil, fmt.Errorf("create http target: name empty: %w", errors.ErrInvalidParameter)
+	}
+	if target.PublicId != "" {
+		return nil, nil, fmt.Errorf("create http target: public id not empty: %w", errors.ErrInvalidParameter)
+	}
+
+	t := target.Clone().(*HttpTarget)
+
+	if opts.withPublicId != "" {
+		if !strings.HasPrefix(opts.withPublicId, HttpTargetPrefix+"_") {
+			return nil, nil, fmt.Errorf("create http target: passed-in public ID %q has wrong prefix, should be %q: %w", opts.withPublicId, HttpTargetPrefix, errors.ErrInvalidPublicId)
+		}
+		t.PublicId = opts.withPublicId
+	} else {
+		id, err := newHttpTargetId()
+		if err != nil {
+			return nil, nil, fmt.Errorf("create http target: %w", err)
+		}
+		t.PublicId = id
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, target.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create http target: unable to get oplog wrapper: %w", err)
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, target.ScopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create http target: unable to get database wrapper: %w", err)
+	}
+
+	newHostSets := make([]interface{}, 0, len(opts.withHostSets))
+	for _, hsId := range opts.withHostSets {
+		hostSet, err := NewTargetHostSet(t.PublicId, hsId)
+		if err != nil {
+			return nil, nil, fmt.Errorf("create http target: unable to create in memory target host set: %w", err)
+		}
+		newHostSets = append(newHostSets, hostSet)
+	}
+	newHeaders, err := newHttpTargetHeaders(ctx, databaseWrapper, t.PublicId, t.Headers)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create http target: %w", err)
+	}
+
+	metadata := t.oplog(oplog.OpType_OP_TYPE_CREATE)
+	var returnedTarget *HttpTarget
+	var returnedHostSet []*TargetSet
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(read db.Reader, w db.Writer) error {
+			targetTicket, err := w.GetTicket(t)
+			if err != nil {
+				return fmt.Errorf("create http target: unable to get ticket: %w", err)
+			}
+			msgs := make([]*oplog.Message, 0, 3)
+			var targetOplogMsg oplog.Message
+			returnedTarget = t.Clone().(*HttpTarget)
+			if err := w.Create(ctx, returnedTarget, db.NewOplogMsg(&targetOplogMsg)); err != nil {
+				return err
+			}
+			msgs = append(msgs, &targetOplogMsg)
+			if len(newHeaders) > 0 {
+				headerOplogMsgs := make([]*oplog.Message, 0, len(newHeaders))
+				if err := w.CreateItems(ctx, newHeaders, db.NewOplogMsgs(&headerOplogMsgs)); err != nil {
+					return fmt.Errorf("create http target: unable to add headers: %w", err)
+				}
+				msgs = append(msgs, headerOplogMsgs...)
+			}
+			if len(newHostSets) > 0 {
+				hostSetOplogMsgs := make([]*oplog.Message, 0, len(newHostSets))
+				if err := w.CreateItems(ctx, newHostSets, db.NewOplogMsgs(&hostSetOplogMsgs)); err != nil {
+					return fmt.Errorf("create http target: unable to add host sets: %w", err)
+				}
+				if returnedHostSet, err = fetchSets(ctx, read, t.PublicId); err != nil {
+					return fmt.Errorf("create http target: unable to read host sets: %w", err)
+				}
+				msgs = append(msgs, hostSetOplogMsgs...)
+			}
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, targetTicket, metadata, msgs); err != nil {
+				return fmt.Errorf("create http target: unable to write oplog: %w", err)
+			}
+			returnedTarget.Headers = nil
+			if returnedTarget.HeaderHmacs, err = fetchHeaderHmacs(ctx, read, t.PublicId); err != nil {
+				return fmt.Errorf("create http target: unable to read headers: %w", err)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create http target: %w for %s target id id", err, t.PublicId)
+	}
+	return returnedTarget, returnedHostSet, err
+}
+
+// UpdateHttpTarget will update a target in the repository and return the
+// written target. fieldMaskPaths provides field_mask.proto paths for fields
+// that should be updated.  Fields will be set to NULL if the field is a zero
+// value and included in fieldMask. Headers replaces all of the headers of the
+// target and may be empty. If no updatable fields are included in the
+// fieldMaskPaths, then an error is returned.
+func (r *Repository) UpdateHttpTarget(ctx context.Context, target *HttpTarget, version uint32, fieldMaskPaths []string, opt ...Option) (Target, []*TargetSet, int, error) {
+	if target == nil {
+		return nil, nil, db.NoRowsAffected, fmt.Errorf("update http target: missing target %w", errors.ErrInvalidParameter)
+	}
+	if target.HttpTarget == nil {
+		return nil, nil, db.NoRowsAffected, fmt.Errorf("update http target: missing target store %w", errors.ErrInvalidParameter)
+	}
+	if target.PublicId == "" {
+		return nil, nil, db.NoRowsAffected, fmt.Errorf("update http target: missing target public id %w", errors.ErrInvalidParameter)
+	}
+	if version == 0 {
+		return nil, nil, db.NoRowsAffected, fmt.Errorf("update http target: version cannot be zero: %w", errors.ErrInvalidParameter)
+	}
+	var updateHeaders bool
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold("name", f):
+		case strings.EqualFold("description", f):
+		case strings.EqualFold("defaultport", f):
+		case strings.EqualFold("sessionmaxseconds", f):
+		case strings.EqualFold("sessionconnectionlimit", f):
+		case strings.EqualFold("workerfilter", f):
+		case strings.EqualFold("sessionidletimeoutseconds", f):
+		case strings.EqualFold("headers", f):
+			updateHeaders = true
+		default:
+			return nil, nil, db.NoRowsAffected, fmt.Errorf("update http target: field: %s: %w", f, errors.ErrInvalidFieldMask)
+		}
+	}
+	var dbMask, nullFields []string
+	dbMask, nullFields = dbcommon.BuildUpdatePaths(
+		map[string]interface{}{
+			"Name":                      target.Name,
+			"Description":               target.Description,
+			"DefaultPort":               target.DefaultPort,
+			"SessionMaxSeconds":         target.SessionMaxSeconds,
+			"SessionConnectionLimit":    target.SessionConnectionLimit,
+			"WorkerFilter":              target.WorkerFilter,
+			"SessionIdleTimeoutSeconds": target.SessionIdleTimeoutSeconds,
+		},
+		fieldMaskPaths,
+		[]string{"SessionMaxSeconds", "SessionConnectionLimit", "SessionIdleTimeoutSeconds"},
+	)
+	if len(dbMask) == 0 && len(nullFields) == 0 && !updateHeaders {
+		return nil, nil, db.NoRowsAffected, fmt.Errorf("update http target: %w", errors.ErrEmptyFieldMask)
+	}
+
+	t := target.Clone().(*HttpTarget)
+	if len(dbMask) == 0 && len(nullFields) == 0 {
+		// Only the headers are changing; the version of the target is still
+		// incremented.
+		t.Version = version + 1
+		dbMask = append(dbMask, "Version")
+	}
+
+	scopeId := t.ScopeId
+	if scopeId == "" {
+		tv := allocTargetView()
+		tv.PublicId = t.PublicId
+		if err := r.reader.LookupByPublicId(ctx, &tv); err != nil {
+			return nil, nil, db.NoRowsAffected, fmt.Errorf("update http target: lookup failed %w for %s", err, t.PublicId)
+		}
+		scopeId = tv.ScopeId
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, nil, db.NoRowsAffected, fmt.Errorf("update http target: unable to get oplog wrapper: %w", err)
+	}
+	var databaseWrapper wrapping.Wrapper
+	if updateHeaders {
+		databaseWrapper, err = r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+		if err != nil {
+			return nil, nil, db.NoRowsAffected, fmt.Errorf("update http target: unable to get database wrapper: %w", err)
+		}
+	}
+
+	var returnedTarget *HttpTarget
+	var rowsUpdated int
+	var targetSets []*TargetSet
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(read db.Reader, w db.Writer) error {
+			targetTicket, err := w.GetTicket(t)
+			if err != nil {
+				return fmt.Errorf("unable to get ticket: %w", err)
+			}
+			msgs := make([]*oplog.Message, 0, 2)
+			var targetOplogMsg oplog.Message
+			returnedTarget = t.Clone().(*HttpTarget)
+			rowsUpdated, err = w.Update(
+				ctx,
+				returnedTarget,
+				dbMask,
+				nullFields,
+				db.NewOplogMsg(&targetOplogMsg),
+				db.WithVersion(&version),
+			)
+			switch {
+			case err != nil:
+				return err
+			case rowsUpdated > 1:
+				// return err, which will result in a rollback of the update
+				return fmt.Errorf("error more than 1 target would have been updated: %w", errors.ErrMultipleRecords)
+			case rowsUpdated == 0:
+				return nil
+			}
+			msgs = append(msgs, &targetOplogMsg)
+
+			if updateHeaders {
+				headerMsgs, err := replaceHeaders(ctx, read, w, databaseWrapper, t.PublicId, t.Headers)
+				if err != nil {
+					return err
+				}
+				msgs = append(msgs, headerMsgs...)
+			}
+
+			metadata := t.oplog(oplog.OpType_OP_TYPE_UPDATE)
+			metadata["scope-id"] = []string{scopeId}
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, targetTicket, metadata, msgs); err != nil {
+				return fmt.Errorf("unable to write oplog: %w", err)
+			}
+
+			if targetSets, err = fetchSets(ctx, read, t.PublicId); err != nil {
+				return err
+			}
+			returnedTarget.Headers = nil
+			if returnedTarget.HeaderHmacs, err = fetchHeaderHmacs(ctx, read, t.PublicId); err != nil {
+				return err
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, nil, db.NoRowsAffected, fmt.Errorf("update http target: target %s already exists in scope %s: %w", target.Name, target.ScopeId, errors.ErrNotUnique)
+		}
+		return nil, nil, db.NoRowsAffected, fmt.Errorf("update http target: %w for %s", err, target.PublicId)
+	}
+	return returnedTarget, targetSets, rowsUpdated, nil
+}
+
+// replaceHeaders makes the stored headers of the target match headers,
+// encrypting their values with cipher, and returns the oplog messages for the
+// changes.
+func replaceHeaders(ctx context.Context, read db.Reader, w db.Writer, cipher wrapping.Wrapper, targetId string, headers map[string]string) ([]*oplog.Message, error) {
+	var current []*HttpTargetHeader
+	if err := read.SearchWhere(ctx, &current, "target_id = ?", []interface{}{targetId}, db.WithLimit(-1)); err != nil {
+		return nil, fmt.Errorf("unable to search headers: %w", err)
+	}
+	var msgs []*oplog.Message
+	if len(current) > 0 {
+		deletes := make([]interface{}, 0, len(current))
+		for _, h := range current {
+			deletes = append(deletes, h.Clone())
+		}
+		if _, err := w.DeleteItems(ctx, deletes, db.NewOplogMsgs(&msgs)); err != nil {
+			return nil, fmt.Errorf("unable to delete headers: %w", err)
+		}
+	}
+	creates, err := newHttpTargetHeaders(ctx, cipher, targetId, headers)
+	if err != nil {
+		return nil, err
+	}
+	if len(creates) > 0 {
+		if err := w.CreateItems(ctx, creates, db.NewOplogMsgs(&msgs)); err != nil {
+			return nil, fmt.Errorf("unable to create headers: %w", err)
+		}
+	}
+	return msgs, nil
+}
+
+// newHttpTargetHeaders returns the in memory headers for the target with
+// their values encrypted with cipher, sorted by name so that the resulting
+// oplog entries are deterministic.
+func newHttpTargetHeaders(ctx context.Context, cipher wrapping.Wrapper, targetId string, headers map[string]string) ([]interface{}, error) {
+	names := make([]string, 0, len(headers))
+	for n := range headers {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	items := make([]interface{}, 0, len(names))
+	for _, n := range names {
+		h, err := NewHttpTargetHeader(targetId, n, headers[n])
+		if err != nil {
+			return nil, err
+		}
+		if err := h.encrypt(ctx, cipher); err != nil {
+			return nil, err
+		}
+		items = append(items, h)
+	}
+	return items, nil
+}
+
+// fetchHeaderHmacs returns the hmacs of the values of the headers of the
+// target by header name.
+func fetchHeaderHmacs(ctx context.Context, r db.Reader, targetId string) (map[string]string, error) {
+	var headers []*HttpTargetHeader
+	if err := r.SearchWhere(ctx, &headers, "target_id = ?", []interface{}{targetId}, db.WithLimit(-1)); err != nil {
+		return nil, fmt.Errorf("fetch headers: %w", err)
+	}
+	if len(headers) == 0 {
+		return nil, nil
+	}
+	m := make(map[string]string, len(headers))
+	for _, h := range headers {
+		m[h.Name] = h.ValueHmac
+	}
+	return m, nil
+}
+
+// LookupHttpTargetHeaders returns the headers of the http target with their
+// values decrypted, by header name. The target must be in scopeId. The
+// headers are only meant to be handed to the worker proxying a session for
+// the target. No options are currently supported.
+func (r *Repository) LookupHttpTargetHeaders(ctx context.Context, scopeId, targetId string, opt ...Option) (map[string]string, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("lookup http target headers: missing scope id: %w", errors.ErrInvalidParameter)
+	}
+	if targetId == "" {
+		return nil, fmt.Errorf("lookup http target headers: missing target id: %w", errors.ErrInvalidParameter)
+	}
+	var headers []*HttpTargetHeader
+	if err := r.reader.SearchWhere(ctx, &headers, "target_id = ?", []interface{}{targetId}, db.WithLimit(-1)); err != nil {
+		return nil, fmt.Errorf("lookup http target headers: %w", err)
+	}
+	if len(headers) == 0 {
+		return nil, nil
+	}
+	m := make(map[string]string, len(headers))
+	for _, h := range headers {
+		databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(h.KeyId))
+		if err != nil {
+			return nil, fmt.Errorf("lookup http target headers: unable to get database wrapper: %w", err)
+		}
+		if err := h.decrypt(ctx, databaseWrapper); err != nil {
+			return nil, fmt.Errorf("lookup http target headers: %s: %w", h.Name, err)
+		}
+		m[h.Name] = h.Value
+	}
+	return m, nil
+}
@@ -0,0 +1,186 @@
+package target
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_CreateHttpTarget(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, testKms)
+	require.NoError(t, err)
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	cats := static.TestCatalogs(t, conn, proj.PublicId, 1)
+	hsets := static.TestSets(t, conn, cats[0].GetPublicId(), 2)
+	var sets []string
+	for _, s := range hsets {
+		sets = append(sets, s.PublicId)
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		headers := map[string]string{"X-Forwarded-User": "admin", "X-Env": "prod"}
+		target, err := NewHttpTarget(proj.PublicId,
+			WithName("valid"),
+			WithDefaultPort(uint32(8080)),
+			WithHeaders(headers))
+		require.NoError(err)
+		got, gotHostSets, err := repo.CreateHttpTarget(context.Background(), target, WithHostSets(sets))
+		require.NoError(err)
+		require.NotNil(got)
+		assert.Equal(HttpTargetType.String(), got.GetType())
+		assert.Empty(got.(*HttpTarget).Headers)
+		hmacs := got.(*HttpTarget).HeaderHmacs
+		require.Len(hmacs, len(headers))
+		for n, v := range headers {
+			assert.NotEmpty(hmacs[n])
+			assert.NotEqual(v, hmacs[n])
+		}
+		assert.Len(gotHostSets, len(sets))
+		assert.NotNil(got.GetCreateTime())
+
+		err = db.TestVerifyOplog(t, rw, got.GetPublicId(), db.WithOperation(oplog.OpType_OP_TYPE_CREATE), db.WithCreateNotBefore(10e9))
+		assert.NoError(err)
+
+		found, _, err := repo.LookupTarget(context.Background(), got.GetPublicId())
+		require.NoError(err)
+		assert.Empty(found.(*HttpTarget).Headers)
+		assert.Equal(hmacs, found.(*HttpTarget).HeaderHmacs)
+
+		plain, err := repo.LookupHttpTargetHeaders(context.Background(), proj.PublicId, got.GetPublicId())
+		require.NoError(err)
+		assert.Equal(headers, plain)
+	})
+	t.Run("dup-name-across-subtypes", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		tcp := TestTcpTarget(t, conn, proj.PublicId, "dup-name")
+		target, err := NewHttpTarget(proj.PublicId, WithName(tcp.Name))
+		require.NoError(err)
+		_, _, err = repo.CreateHttpTarget(context.Background(), target)
+		require.Error(err)
+		assert.True(errors.IsUniqueError(err))
+	})
+	t.Run("wrong-public-id-prefix", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		target, err := NewHttpTarget(proj.PublicId, WithName("wrong-prefix"))
+		require.NoError(err)
+		_, _, err = repo.CreateHttpTarget(context.Background(), target, WithPublicId("ttcp_1234567890"))
+		require.Error(err)
+		assert.True(errors.Is(err, errors.ErrInvalidPublicId))
+	})
+	t.Run("nil-target", func(t *testing.T) {
+		_, _, err := repo.CreateHttpTarget(context.Background(), nil)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.ErrInvalidParameter))
+	})
+}
+
+func TestRepository_UpdateHttpTarget(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, testKms)
+	require.NoError(t, err)
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	databaseWrapper, err := testKms.GetWrapper(context.Background(), proj.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+
+	t.Run("headers-only", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		target := TestHttpTarget(t, conn, databaseWrapper, proj.PublicId, testTargetName(t, proj.PublicId), WithHeaders(map[string]string{"X-Old": "old"}))
+		upd := target.Clone().(*HttpTarget)
+		upd.Headers = map[string]string{"X-New": "new"}
+		got, _, rowsUpdated, err := repo.UpdateHttpTarget(context.Background(), upd, target.Version, []string{"Headers"})
+		require.NoError(err)
+		assert.Equal(1, rowsUpdated)
+		assert.Empty(got.(*HttpTarget).Headers)
+		require.Len(got.(*HttpTarget).HeaderHmacs, 1)
+		assert.NotEmpty(got.(*HttpTarget).HeaderHmacs["X-New"])
+
+		found, _, err := repo.LookupTarget(context.Background(), target.PublicId)
+		require.NoError(err)
+		assert.Equal(target.Version+1, found.GetVersion())
+		assert.Equal(got.(*HttpTarget).HeaderHmacs, found.(*HttpTarget).HeaderHmacs)
+
+		plain, err := repo.LookupHttpTargetHeaders(context.Background(), proj.PublicId, target.PublicId)
+		require.NoError(err)
+		assert.Equal(map[string]string{"X-New": "new"}, plain)
+	})
+	t.Run("name-and-clear-headers", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		target := TestHttpTarget(t, conn, databaseWrapper, proj.PublicId, testTargetName(t, proj.PublicId), WithHeaders(map[string]string{"X-Old": "old"}))
+		upd := target.Clone().(*HttpTarget)
+		upd.Name = "renamed-" + target.Name
+		upd.Headers = nil
+		got, _, rowsUpdated, err := repo.UpdateHttpTarget(context.Background(), upd, target.Version, []string{"Name", "Headers"})
+		require.NoError(err)
+		assert.Equal(1, rowsUpdated)
+		assert.Equal(upd.Name, got.GetName())
+		assert.Empty(got.(*HttpTarget).HeaderHmacs)
+
+		err = db.TestVerifyOplog(t, rw, target.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_UPDATE), db.WithCreateNotBefore(10e9))
+		assert.NoError(err)
+	})
+	t.Run("bad-field", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		target := TestHttpTarget(t, conn, databaseWrapper, proj.PublicId, testTargetName(t, proj.PublicId))
+		_, _, _, err := repo.UpdateHttpTarget(context.Background(), target, target.Version, []string{"ScopeId"})
+		require.Error(err)
+		assert.True(errors.Is(err, errors.ErrInvalidFieldMask))
+	})
+	t.Run("empty-mask", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		target := TestHttpTarget(t, conn, databaseWrapper, proj.PublicId, testTargetName(t, proj.PublicId))
+		_, _, _, err := repo.UpdateHttpTarget(context.Background(), target, target.Version, nil)
+		require.Error(err)
+		assert.True(errors.Is(err, errors.ErrEmptyFieldMask))
+	})
+}
+
+func TestRepository_ListTargets_HttpHeaders(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, testKms)
+	require.NoError(t, err)
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	assert, require := assert.New(t), require.New(t)
+	TestTcpTarget(t, conn, proj.PublicId, testTargetName(t, proj.PublicId))
+	httpT := TestHttpTarget(t, conn, wrapper, proj.PublicId, testTargetName(t, proj.PublicId), WithHeaders(map[string]string{"X-Test": "one"}))
+
+	got, err := repo.ListTargets(context.Background(), WithScopeId(proj.PublicId))
+	require.NoError(err)
+	require.Len(got, 2)
+	for _, tar := range got {
+		if h, ok := tar.(*HttpTarget); ok {
+			assert.Equal(httpT.PublicId, h.PublicId)
+			assert.Empty(h.Headers)
+			require.Len(h.HeaderHmacs, 1)
+			assert.NotEmpty(h.HeaderHmacs["X-Test"])
+			assert.NotEqual("one", h.HeaderHmacs["X-Test"])
+		}
+	}
+
+	rowsDeleted, err := repo.DeleteTarget(context.Background(), httpT.PublicId)
+	require.NoError(err)
+	assert.Equal(1, rowsDeleted)
+}
@@ -0,0 +1,142 @@
+package target
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_CreateSshTarget(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, testKms)
+	require.NoError(t, err)
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	cats := static.TestCatalogs(t, conn, proj.PublicId, 1)
+	hsets := static.TestSets(t, conn, cats[0].GetPublicId(), 1)
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		target, err := NewSshTarget(proj.PublicId, WithName(testTargetName(t, proj.PublicId)), WithDefaultPort(22))
+		require.NoError(err)
+		got, gotHostSets, err := repo.CreateSshTarget(context.Background(), target, WithHostSets([]string{hsets[0].PublicId}))
+		require.NoError(err)
+		assert.True(got.GetPublicId() != "")
+		assert.Equal(uint32(22), got.GetDefaultPort())
+		assert.Len(gotHostSets, 1)
+
+		found, _, err := repo.LookupTarget(context.Background(), got.GetPublicId())
+		require.NoError(err)
+		assert.IsType(&SshTarget{}, found)
+
+		err = db.TestVerifyOplog(t, rw, got.GetPublicId(), db.WithOperation(oplog.OpType_OP_TYPE_CREATE), db.WithCreateNotBefore(10e9))
+		assert.NoError(err)
+	})
+	t.Run("host-keys", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		const hostKeys = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIO0iWZLHmDfhKw6KRdDhFVmBd8gA2ROmOEzLTzCnQnrp"
+		target, err := NewSshTarget(proj.PublicId, WithName(testTargetName(t, proj.PublicId)), WithHostKeys(hostKeys))
+		require.NoError(err)
+		got, _, err := repo.CreateSshTarget(context.Background(), target)
+		require.NoError(err)
+		assert.Equal(hostKeys, got.(*SshTarget).HostKeys)
+		assert.False(got.(*SshTarget).InsecureIgnoreHostKey)
+
+		found, _, err := repo.LookupTarget(context.Background(), got.GetPublicId())
+		require.NoError(err)
+		assert.Equal(hostKeys, found.(*SshTarget).HostKeys)
+
+		listed, err := repo.ListTargets(context.Background(), WithScopeId(proj.PublicId), WithTargetType(SshTargetType))
+		require.NoError(err)
+		var listedKeys string
+		for _, l := range listed {
+			if l.GetPublicId() == got.GetPublicId() {
+				listedKeys = l.(*SshTarget).HostKeys
+			}
+		}
+		assert.Equal(hostKeys, listedKeys)
+	})
+	t.Run("name-unique-across-subtypes", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		tcpT := TestTcpTarget(t, conn, proj.PublicId, testTargetName(t, proj.PublicId))
+		target, err := NewSshTarget(proj.PublicId, WithName(tcpT.Name))
+		require.NoError(err)
+		_, _, err = repo.CreateSshTarget(context.Background(), target)
+		require.Error(err)
+		assert.True(errors.IsUniqueError(err))
+	})
+	t.Run("wrong-public-id-prefix", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		target, err := NewSshTarget(proj.PublicId, WithName(testTargetName(t, proj.PublicId)))
+		require.NoError(err)
+		id, err := newTcpTargetId()
+		require.NoError(err)
+		_, _, err = repo.CreateSshTarget(context.Background(), target, WithPublicId(id))
+		require.Error(err)
+		assert.True(errors.Is(err, errors.ErrInvalidPublicId))
+	})
+}
+
+func TestRepository_UpdateSshTarget(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, testKms)
+	require.NoError(t, err)
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		target := TestSshTarget(t, conn, proj.PublicId, testTargetName(t, proj.PublicId))
+		upd := target.Clone().(*SshTarget)
+		upd.Name = "renamed-" + target.Name
+		upd.DefaultPort = 2222
+		got, _, rowsUpdated, err := repo.UpdateSshTarget(context.Background(), upd, target.Version, []string{"Name", "DefaultPort"})
+		require.NoError(err)
+		assert.Equal(1, rowsUpdated)
+		assert.Equal(upd.Name, got.GetName())
+		assert.Equal(uint32(2222), got.GetDefaultPort())
+	})
+	t.Run("insecure-ignore-host-key", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		target := TestSshTarget(t, conn, proj.PublicId, testTargetName(t, proj.PublicId))
+		upd := target.Clone().(*SshTarget)
+		upd.InsecureIgnoreHostKey = true
+		got, _, _, err := repo.UpdateSshTarget(context.Background(), upd, target.Version, []string{"InsecureIgnoreHostKey"})
+		require.NoError(err)
+		assert.True(got.(*SshTarget).InsecureIgnoreHostKey)
+
+		upd.InsecureIgnoreHostKey = false
+		got, _, _, err = repo.UpdateSshTarget(context.Background(), upd, got.GetVersion(), []string{"InsecureIgnoreHostKey"})
+		require.NoError(err)
+		assert.False(got.(*SshTarget).InsecureIgnoreHostKey)
+	})
+	t.Run("bad-field", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		target := TestSshTarget(t, conn, proj.PublicId, testTargetName(t, proj.PublicId))
+		_, _, _, err := repo.UpdateSshTarget(context.Background(), target, target.Version, []string{"ScopeId"})
+		require.Error(err)
+		assert.True(errors.Is(err, errors.ErrInvalidFieldMask))
+	})
+	t.Run("delete", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		target := TestSshTarget(t, conn, proj.PublicId, testTargetName(t, proj.PublicId))
+		rowsDeleted, err := repo.DeleteTarget(context.Background(), target.PublicId)
+		require.NoError(err)
+		assert.Equal(1, rowsDeleted)
+	})
+}
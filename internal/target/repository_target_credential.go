@@ -0,0 +1,351 @@
+package target
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// ListTargetCredentials returns the credentials attached to the target
+// (targetId). No options are currently supported.
+func (r *Repository) ListTargetCredentials(ctx context.Context, targetId string, opt ...Option) ([]*TargetCredential, error) {
+	if targetId == "" {
+		return nil, fmt.Errorf("list target credentials: missing target id: %w", errors.ErrInvalidParameter)
+	}
+	creds, err := fetchCredentials(ctx, r.reader, targetId)
+	if err != nil {
+		return nil, fmt.Errorf("list target credentials: %w", err)
+	}
+	return creds, nil
+}
+
+func fetchCredentials(ctx context.Context, r db.Reader, targetId string) ([]*TargetCredential, error) {
+	var creds []*TargetCredential
+	if err := r.SearchWhere(ctx, &creds, "target_id = ?", []interface{}{targetId}, db.WithLimit(-1)); err != nil {
+		return nil, fmt.Errorf("fetch credentials: %w", err)
+	}
+	if len(creds) == 0 {
+		return nil, nil
+	}
+	return creds, nil
+}
+
+// AddTargetCredentials attaches credentials (credentialIds) to a target
+// (targetId). The target's current db version must match the targetVersion
+// or an error will be returned. The target and its current credentials are
+// returned on success. The credentials must be in the same scope as the
+// target.
+func (r *Repository) AddTargetCredentials(ctx context.Context, targetId string, targetVersion uint32, credentialIds []string, opt ...Option) (Target, []*TargetCredential, error) {
+	if targetId == "" {
+		return nil, nil, fmt.Errorf("add target credentials: missing target id: %w", errors.ErrInvalidParameter)
+	}
+	if targetVersion == 0 {
+		return nil, nil, fmt.Errorf("add target credentials: version cannot be zero: %w", errors.ErrInvalidParameter)
+	}
+	if len(credentialIds) == 0 {
+		return nil, nil, fmt.Errorf("add target credentials: missing credential ids: %w", errors.ErrInvalidParameter)
+	}
+	newCreds := make([]interface{}, 0, len(credentialIds))
+	for _, id := range credentialIds {
+		tc, err := NewTargetCredential(targetId, id)
+		if err != nil {
+			return nil, nil, fmt.Errorf("add target credentials: unable to create in memory target credential: %w", err)
+		}
+		newCreds = append(newCreds, tc)
+	}
+	t := allocTargetView()
+	t.PublicId = targetId
+	if err := r.reader.LookupByPublicId(ctx, &t); err != nil {
+		return nil, nil, fmt.Errorf("add target credentials: failed %w for %s", err, targetId)
+	}
+	target, metadata, err := targetForVersionUpdate(&t, targetVersion)
+	if err != nil {
+		return nil, nil, fmt.Errorf("add target credentials: %w", err)
+	}
+	metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_CREATE.String())
+	oplogWrapper, err := r.kms.GetWrapper(ctx, t.GetScopeId(), kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, nil, fmt.Errorf("add target credentials: unable to get oplog wrapper: %w", err)
+	}
+	var currentCreds []*TargetCredential
+	var updatedTarget interface{}
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			msgs := make([]*oplog.Message, 0, 2)
+			targetTicket, err := w.GetTicket(target)
+			if err != nil {
+				return fmt.Errorf("add target credentials: unable to get ticket: %w", err)
+			}
+			updatedTarget = target.(Cloneable).Clone()
+			var targetOplogMsg oplog.Message
+			rowsUpdated, err := w.Update(ctx, updatedTarget, []string{"Version"}, nil, db.NewOplogMsg(&targetOplogMsg), db.WithVersion(&targetVersion))
+			if err != nil {
+				return fmt.Errorf("add target credentials: unable to update target version: %w", err)
+			}
+			if rowsUpdated != 1 {
+				return fmt.Errorf("add target credentials: updated target and %d rows updated", rowsUpdated)
+			}
+			msgs = append(msgs, &targetOplogMsg)
+
+			credOplogMsgs := make([]*oplog.Message, 0, len(newCreds))
+			if err := w.CreateItems(ctx, newCreds, db.NewOplogMsgs(&credOplogMsgs)); err != nil {
+				return fmt.Errorf("add target credentials: unable to add target credentials: %w", err)
+			}
+			msgs = append(msgs, credOplogMsgs...)
+
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, targetTicket, metadata, msgs); err != nil {
+				return fmt.Errorf("add target credentials: unable to write oplog: %w", err)
+			}
+			currentCreds, err = fetchCredentials(ctx, reader, targetId)
+			if err != nil {
+				return fmt.Errorf("add target credentials: unable to retrieve current credentials after adds: %w", err)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("add target credentials: error adding credentials: %w", err)
+	}
+	return updatedTarget.(Target), currentCreds, nil
+}
+
+// DeleteTargetCredentials detaches credentials (credentialIds) from a target
+// (targetId). The target's current db version must match the targetVersion
+// or an error will be returned. The number of credentials detached is
+// returned on success.
+func (r *Repository) DeleteTargetCredentials(ctx context.Context, targetId string, targetVersion uint32, credentialIds []string, opt ...Option) (int, error) {
+	if targetId == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete target credentials: missing target id: %w", errors.ErrInvalidParameter)
+	}
+	if targetVersion == 0 {
+		return db.NoRowsAffected, fmt.Errorf("delete target credentials: version cannot be zero: %w", errors.ErrInvalidParameter)
+	}
+	if len(credentialIds) == 0 {
+		return db.NoRowsAffected, fmt.Errorf("delete target credentials: missing credential ids: %w", errors.ErrInvalidParameter)
+	}
+	deleteCreds := make([]interface{}, 0, len(credentialIds))
+	for _, id := range credentialIds {
+		tc, err := NewTargetCredential(targetId, id)
+		if err != nil {
+			return db.NoRowsAffected, fmt.Errorf("delete target credentials: unable to create in memory target credential: %w", err)
+		}
+		deleteCreds = append(deleteCreds, tc)
+	}
+	t := allocTargetView()
+	t.PublicId = targetId
+	if err := r.reader.LookupByPublicId(ctx, &t); err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete target credentials: failed %w for %s", err, targetId)
+	}
+	target, metadata, err := targetForVersionUpdate(&t, targetVersion)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete target credentials: %w", err)
+	}
+	metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_DELETE.String())
+	oplogWrapper, err := r.kms.GetWrapper(ctx, t.GetScopeId(), kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete target credentials: unable to get oplog wrapper: %w", err)
+	}
+
+	var totalRowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			msgs := make([]*oplog.Message, 0, 2)
+			targetTicket, err := w.GetTicket(target)
+			if err != nil {
+				return fmt.Errorf("delete target credentials: unable to get ticket: %w", err)
+			}
+			updatedTarget := target.(Cloneable).Clone()
+			var targetOplogMsg oplog.Message
+			rowsUpdated, err := w.Update(ctx, updatedTarget, []string{"Version"}, nil, db.NewOplogMsg(&targetOplogMsg), db.WithVersion(&targetVersion))
+			if err != nil {
+				return fmt.Errorf("delete target credentials: unable to update target version: %w", err)
+			}
+			if rowsUpdated != 1 {
+				return fmt.Errorf("delete target credentials: updated target and %d rows updated", rowsUpdated)
+			}
+			msgs = append(msgs, &targetOplogMsg)
+
+			credOplogMsgs := make([]*oplog.Message, 0, len(deleteCreds))
+			rowsDeleted, err := w.DeleteItems(ctx, deleteCreds, db.NewOplogMsgs(&credOplogMsgs))
+			if err != nil {
+				return fmt.Errorf("delete target credentials: unable to delete target credentials: %w", err)
+			}
+			if rowsDeleted != len(deleteCreds) {
+				return fmt.Errorf("delete target credentials: target credentials deleted %d did not match request for %d", rowsDeleted, len(deleteCreds))
+			}
+			totalRowsDeleted += rowsDeleted
+			msgs = append(msgs, credOplogMsgs...)
+
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, targetTicket, metadata, msgs); err != nil {
+				return fmt.Errorf("delete target credentials: unable to write oplog: %w", err)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete target credentials: error deleting target credentials: %w", err)
+	}
+	return totalRowsDeleted, nil
+}
+
+// SetTargetCredentials will set the target's credentials. Set adds and/or
+// deletes target credentials as needed to reconcile the existing credentials
+// with the credentials requested. If credentialIds is empty, the target
+// credentials will be cleared. The current credentials and the number of
+// rows affected are returned on success.
+func (r *Repository) SetTargetCredentials(ctx context.Context, targetId string, targetVersion uint32, credentialIds []string, opt ...Option) ([]*TargetCredential, int, error) {
+	if targetId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("set target credentials: missing target id: %w", errors.ErrInvalidParameter)
+	}
+	if targetVersion == 0 {
+		return nil, db.NoRowsAffected, fmt.Errorf("set target credentials: version cannot be zero: %w", errors.ErrInvalidParameter)
+	}
+	t := allocTargetView()
+	t.PublicId = targetId
+	if err := r.reader.LookupByPublicId(ctx, &t); err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("set target credentials: failed %w for %s", err, targetId)
+	}
+
+	// NOTE: calculating the changes can safely happen outside of the write
+	// transaction since targetVersion ensures that the write only happens
+	// if the target has not changed.
+	foundCreds, err := fetchCredentials(ctx, r.reader, targetId)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("set target credentials: unable to search for existing target credentials: %w", err)
+	}
+	found := map[string]*TargetCredential{}
+	for _, c := range foundCreds {
+		found[c.CredentialId] = c
+	}
+	addCreds := make([]interface{}, 0, len(credentialIds))
+	for _, id := range credentialIds {
+		if _, ok := found[id]; ok {
+			// found a match, so do nothing (we want to keep it), but remove it
+			// from found
+			delete(found, id)
+			continue
+		}
+		tc, err := NewTargetCredential(targetId, id)
+		if err != nil {
+			return nil, db.NoRowsAffected, fmt.Errorf("set target credentials: unable to create in memory target credential: %w", err)
+		}
+		addCreds = append(addCreds, tc)
+	}
+	deleteCreds := make([]interface{}, 0, len(found))
+	for _, c := range found {
+		deleteCreds = append(deleteCreds, c.Clone())
+	}
+	if len(addCreds) == 0 && len(deleteCreds) == 0 {
+		return foundCreds, db.NoRowsAffected, nil
+	}
+
+	target, metadata, err := targetForVersionUpdate(&t, targetVersion)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("set target credentials: %w", err)
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, t.GetScopeId(), kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("set target credentials: unable to get oplog wrapper: %w", err)
+	}
+
+	var totalRowsAffected int
+	var currentCreds []*TargetCredential
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			msgs := make([]*oplog.Message, 0, 2)
+			targetTicket, err := w.GetTicket(target)
+			if err != nil {
+				return fmt.Errorf("set target credentials: unable to get ticket: %w", err)
+			}
+			updatedTarget := target.(Cloneable).Clone()
+			var targetOplogMsg oplog.Message
+			rowsUpdated, err := w.Update(ctx, updatedTarget, []string{"Version"}, nil, db.NewOplogMsg(&targetOplogMsg), db.WithVersion(&targetVersion))
+			if err != nil {
+				return fmt.Errorf("set target credentials: unable to update target version: %w", err)
+			}
+			if rowsUpdated != 1 {
+				return fmt.Errorf("set target credentials: updated target and %d rows updated", rowsUpdated)
+			}
+			msgs = append(msgs, &targetOplogMsg)
+
+			if len(addCreds) > 0 {
+				credOplogMsgs := make([]*oplog.Message, 0, len(addCreds))
+				if err := w.CreateItems(ctx, addCreds, db.NewOplogMsgs(&credOplogMsgs)); err != nil {
+					return fmt.Errorf("set target credentials: unable to add target credentials: %w", err)
+				}
+				totalRowsAffected += len(addCreds)
+				msgs = append(msgs, credOplogMsgs...)
+				metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_CREATE.String())
+			}
+
+			// Anything we didn't take out of found needs to be removed
+			if len(deleteCreds) > 0 {
+				credOplogMsgs := make([]*oplog.Message, 0, len(deleteCreds))
+				rowsDeleted, err := w.DeleteItems(ctx, deleteCreds, db.NewOplogMsgs(&credOplogMsgs))
+				if err != nil {
+					return fmt.Errorf("set target credentials: unable to delete target credentials: %w", err)
+				}
+				if rowsDeleted != len(deleteCreds) {
+					return fmt.Errorf("set target credentials: target credentials deleted %d did not match request for %d", rowsDeleted, len(deleteCreds))
+				}
+				totalRowsAffected += rowsDeleted
+				msgs = append(msgs, credOplogMsgs...)
+				metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_DELETE.String())
+			}
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, targetTicket, metadata, msgs); err != nil {
+				return fmt.Errorf("set target credentials: unable to write oplog: %w", err)
+			}
+
+			currentCreds, err = fetchCredentials(ctx, reader, targetId)
+			if err != nil {
+				return fmt.Errorf("set target credentials: unable to retrieve current target credentials after set: %w", err)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("set target credentials: error setting target credentials: %w", err)
+	}
+	return currentCreds, totalRowsAffected, nil
+}
+
+// targetForVersionUpdate returns the subtype of the target t with its
+// version incremented from version and the oplog metadata for updating it.
+func targetForVersionUpdate(t *targetView, version uint32) (interface{}, oplog.Metadata, error) {
+	switch t.Type {
+	case TcpTargetType.String():
+		tcpT := allocTcpTarget()
+		tcpT.PublicId = t.PublicId
+		tcpT.Version = version + 1
+		return &tcpT, tcpT.oplog(oplog.OpType_OP_TYPE_UPDATE), nil
+	case HttpTargetType.String():
+		httpT := allocHttpTarget()
+		httpT.PublicId = t.PublicId
+		httpT.Version = version + 1
+		return &httpT, httpT.oplog(oplog.OpType_OP_TYPE_UPDATE), nil
+	case SshTargetType.String():
+		sshT := allocSshTarget()
+		sshT.PublicId = t.PublicId
+		sshT.Version = version + 1
+		return &sshT, sshT.oplog(oplog.OpType_OP_TYPE_UPDATE), nil
+	case UdpTargetType.String():
+		udpT := allocUdpTarget()
+		udpT.PublicId = t.PublicId
+		udpT.Version = version + 1
+		return &udpT, udpT.oplog(oplog.OpType_OP_TYPE_UPDATE), nil
+	}
+	return nil, nil, fmt.Errorf("%s is an unsupported target type %s", t.PublicId, t.Type)
+}
@@ -0,0 +1,193 @@
+package target
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/credential/static"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCredentialIds(t *testing.T, conn *gorm.DB, wrapper wrapping.Wrapper, scopeId string, count int) []string {
+	t.Helper()
+	stores := static.TestCredentialStores(t, conn, scopeId, 1)
+	creds := static.TestCredentials(t, conn, wrapper, stores[0].GetPublicId(), count)
+	ids := make([]string, 0, count)
+	for _, c := range creds {
+		ids = append(ids, c.GetPublicId())
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func targetCredentialIds(creds []*TargetCredential) []string {
+	ids := make([]string, 0, len(creds))
+	for _, c := range creds {
+		ids = append(ids, c.CredentialId)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestRepository_AddTargetCredentials(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, proj := iam.TestScopes(t, iamRepo)
+	_, otherProj := iam.TestScopes(t, iamRepo)
+	repo, err := NewRepository(rw, rw, testKms)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	projWrapper, err := testKms.GetWrapper(ctx, proj.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+	otherProjWrapper, err := testKms.GetWrapper(ctx, otherProj.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		tar := TestTcpTarget(t, conn, proj.PublicId, "add-valid")
+		credIds := testCredentialIds(t, conn, projWrapper, proj.PublicId, 3)
+
+		gotTarget, gotCreds, err := repo.AddTargetCredentials(ctx, tar.PublicId, 1, credIds)
+		require.NoError(err)
+		assert.Equal(uint32(2), gotTarget.GetVersion())
+		assert.Equal(credIds, targetCredentialIds(gotCreds))
+
+		listed, err := repo.ListTargetCredentials(ctx, tar.PublicId)
+		require.NoError(err)
+		assert.Equal(credIds, targetCredentialIds(listed))
+	})
+	t.Run("bad-version", func(t *testing.T) {
+		assert := assert.New(t)
+		tar := TestTcpTarget(t, conn, proj.PublicId, "add-bad-version")
+		credIds := testCredentialIds(t, conn, projWrapper, proj.PublicId, 1)
+		_, _, err := repo.AddTargetCredentials(ctx, tar.PublicId, 1000, credIds)
+		assert.Error(err)
+	})
+	t.Run("zero-version", func(t *testing.T) {
+		assert := assert.New(t)
+		tar := TestTcpTarget(t, conn, proj.PublicId, "add-zero-version")
+		credIds := testCredentialIds(t, conn, projWrapper, proj.PublicId, 1)
+		_, _, err := repo.AddTargetCredentials(ctx, tar.PublicId, 0, credIds)
+		assert.Error(err)
+	})
+	t.Run("no-credentials", func(t *testing.T) {
+		assert := assert.New(t)
+		tar := TestTcpTarget(t, conn, proj.PublicId, "add-no-credentials")
+		_, _, err := repo.AddTargetCredentials(ctx, tar.PublicId, 1, nil)
+		assert.Error(err)
+	})
+	t.Run("different-scope", func(t *testing.T) {
+		assert := assert.New(t)
+		tar := TestTcpTarget(t, conn, proj.PublicId, "add-different-scope")
+		credIds := testCredentialIds(t, conn, otherProjWrapper, otherProj.PublicId, 1)
+		_, _, err := repo.AddTargetCredentials(ctx, tar.PublicId, 1, credIds)
+		assert.Error(err)
+	})
+}
+
+func TestRepository_DeleteTargetCredentials(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, proj := iam.TestScopes(t, iamRepo)
+	repo, err := NewRepository(rw, rw, testKms)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	projWrapper, err := testKms.GetWrapper(ctx, proj.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		tar := TestTcpTarget(t, conn, proj.PublicId, "delete-valid")
+		credIds := testCredentialIds(t, conn, projWrapper, proj.PublicId, 3)
+		_, _, err := repo.AddTargetCredentials(ctx, tar.PublicId, 1, credIds)
+		require.NoError(err)
+
+		deleted, err := repo.DeleteTargetCredentials(ctx, tar.PublicId, 2, credIds[:2])
+		require.NoError(err)
+		assert.Equal(2, deleted)
+
+		listed, err := repo.ListTargetCredentials(ctx, tar.PublicId)
+		require.NoError(err)
+		assert.Equal(credIds[2:], targetCredentialIds(listed))
+	})
+	t.Run("not-attached", func(t *testing.T) {
+		assert := assert.New(t)
+		tar := TestTcpTarget(t, conn, proj.PublicId, "delete-not-attached")
+		credIds := testCredentialIds(t, conn, projWrapper, proj.PublicId, 1)
+		deleted, err := repo.DeleteTargetCredentials(ctx, tar.PublicId, 1, credIds)
+		assert.Error(err)
+		assert.Equal(db.NoRowsAffected, deleted)
+	})
+	t.Run("bad-version", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		tar := TestTcpTarget(t, conn, proj.PublicId, "delete-bad-version")
+		credIds := testCredentialIds(t, conn, projWrapper, proj.PublicId, 1)
+		_, _, err := repo.AddTargetCredentials(ctx, tar.PublicId, 1, credIds)
+		require.NoError(err)
+		_, err = repo.DeleteTargetCredentials(ctx, tar.PublicId, 1000, credIds)
+		assert.Error(err)
+	})
+}
+
+func TestRepository_SetTargetCredentials(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, proj := iam.TestScopes(t, iamRepo)
+	repo, err := NewRepository(rw, rw, testKms)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	projWrapper, err := testKms.GetWrapper(ctx, proj.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+
+	assert, require := assert.New(t), require.New(t)
+	tar := TestTcpTarget(t, conn, proj.PublicId, "set")
+	credIds := testCredentialIds(t, conn, projWrapper, proj.PublicId, 4)
+
+	got, affected, err := repo.SetTargetCredentials(ctx, tar.PublicId, 1, credIds[:2])
+	require.NoError(err)
+	assert.Equal(2, affected)
+	assert.Equal(credIds[:2], targetCredentialIds(got))
+
+	// Replace one credential and add another
+	got, affected, err = repo.SetTargetCredentials(ctx, tar.PublicId, 2, credIds[1:])
+	require.NoError(err)
+	assert.Equal(3, affected)
+	assert.Equal(credIds[1:], targetCredentialIds(got))
+
+	// Setting the same credentials is a no-op
+	got, affected, err = repo.SetTargetCredentials(ctx, tar.PublicId, 3, credIds[1:])
+	require.NoError(err)
+	assert.Equal(db.NoRowsAffected, affected)
+	assert.Equal(credIds[1:], targetCredentialIds(got))
+
+	// Clear the credentials
+	got, affected, err = repo.SetTargetCredentials(ctx, tar.PublicId, 3, nil)
+	require.NoError(err)
+	assert.Equal(3, affected)
+	assert.Empty(got)
+
+	_, _, err = repo.SetTargetCredentials(ctx, tar.PublicId, 1000, credIds)
+	assert.Error(err)
+}
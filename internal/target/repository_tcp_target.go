@@ -128,6 +128,8 @@ func (r *Repository) UpdateTcpTarget(ctx context.Context, target *TcpTarget, ver
 		case strings.EqualFold("defaultport", f):
 		case strings.EqualFold("sessionmaxseconds", f):
 		case strings.EqualFold("sessionconnectionlimit", f):
+		case strings.EqualFold("workerfilter", f):
+		case strings.EqualFold("sessionidletimeoutseconds", f):
 		default:
 			return nil, nil, db.NoRowsAffected, fmt.Errorf("update tcp target: field: %s: %w", f, errors.ErrInvalidFieldMask)
 		}
@@ -135,14 +137,16 @@ func (r *Repository) UpdateTcpTarget(ctx context.Context, target *TcpTarget, ver
 	var dbMask, nullFields []string
 	dbMask, nullFields = dbcommon.BuildUpdatePaths(
 		map[string]interface{}{
-			"Name":                   target.Name,
-			"Description":            target.Description,
-			"DefaultPort":            target.DefaultPort,
-			"SessionMaxSeconds":      target.SessionMaxSeconds,
-			"SessionConnectionLimit": target.SessionConnectionLimit,
+			"Name":                      target.Name,
+			"Description":               target.Description,
+			"DefaultPort":               target.DefaultPort,
+			"SessionMaxSeconds":         target.SessionMaxSeconds,
+			"SessionConnectionLimit":    target.SessionConnectionLimit,
+			"WorkerFilter":              target.WorkerFilter,
+			"SessionIdleTimeoutSeconds": target.SessionIdleTimeoutSeconds,
 		},
 		fieldMaskPaths,
-		[]string{"SessionMaxSeconds", "SessionConnectionLimit"},
+		[]string{"SessionMaxSeconds", "SessionConnectionLimit", "SessionIdleTimeoutSeconds"},
 	)
 	if len(dbMask) == 0 && len(nullFields) == 0 {
 		return nil, nil, db.NoRowsAffected, fmt.Errorf("update tcp target: %w", errors.ErrEmptyFieldMask)
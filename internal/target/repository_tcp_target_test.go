@@ -168,6 +168,7 @@ func TestRepository_UpdateTcpTarget(t *testing.T) {
 		name           string
 		description    string
 		port           uint32
+		workerFilter   string
 		fieldMaskPaths []string
 		opt            []Option
 		ScopeId        string
@@ -247,6 +248,30 @@ func TestRepository_UpdateTcpTarget(t *testing.T) {
 			wantErr:        false,
 			wantRowsUpdate: 1,
 		},
+		{
+			name: "valid-worker-filter",
+			args: args{
+				name:           "valid-worker-filter" + id,
+				workerFilter:   `"/name" matches "test"`,
+				fieldMaskPaths: []string{"WorkerFilter"},
+				ScopeId:        proj.PublicId,
+			},
+			newScopeId:     proj.PublicId,
+			wantErr:        false,
+			wantRowsUpdate: 1,
+		},
+		{
+			name: "null-worker-filter",
+			args: args{
+				name:           "null-worker-filter" + id,
+				fieldMaskPaths: []string{"WorkerFilter"},
+				ScopeId:        proj.PublicId,
+			},
+			newScopeId:     proj.PublicId,
+			newTargetOpts:  []Option{WithWorkerFilter(`"/name" matches "test"`)},
+			wantErr:        false,
+			wantRowsUpdate: 1,
+		},
 		{
 			name: "empty-field-mask",
 			args: args{
@@ -377,6 +402,7 @@ func TestRepository_UpdateTcpTarget(t *testing.T) {
 			updateTarget.Name = tt.args.name
 			updateTarget.Description = tt.args.description
 			updateTarget.DefaultPort = tt.args.port
+			updateTarget.WorkerFilter = tt.args.workerFilter
 
 			targetAfterUpdate, hostSets, updatedRows, err := repo.UpdateTcpTarget(context.Background(), &updateTarget, target.Version, tt.args.fieldMaskPaths, tt.args.opt...)
 			if tt.wantErr {
@@ -415,6 +441,12 @@ func TestRepository_UpdateTcpTarget(t *testing.T) {
 				assert.Equal(foundTarget.GetDescription(), "")
 				dbassert.IsNull(foundTarget, "description")
 			}
+			if tt.args.workerFilter == "" {
+				assert.Equal(foundTarget.GetWorkerFilter(), "")
+				dbassert.IsNull(foundTarget, "worker_filter")
+			} else {
+				assert.Equal(tt.args.workerFilter, foundTarget.GetWorkerFilter())
+			}
 			err = db.TestVerifyOplog(t, rw, target.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_UPDATE), db.WithCreateNotBefore(10*time.Second))
 			assert.NoError(err)
 		})
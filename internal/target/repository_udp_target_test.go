@@ -0,0 +1,104 @@
+package target
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_CreateUdpTarget(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, testKms)
+	require.NoError(t, err)
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	cats := static.TestCatalogs(t, conn, proj.PublicId, 1)
+	hsets := static.TestSets(t, conn, cats[0].GetPublicId(), 1)
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		target, err := NewUdpTarget(proj.PublicId, WithName(testTargetName(t, proj.PublicId)), WithDefaultPort(22))
+		require.NoError(err)
+		got, gotHostSets, err := repo.CreateUdpTarget(context.Background(), target, WithHostSets([]string{hsets[0].PublicId}))
+		require.NoError(err)
+		assert.True(got.GetPublicId() != "")
+		assert.Equal(uint32(22), got.GetDefaultPort())
+		assert.Len(gotHostSets, 1)
+
+		found, _, err := repo.LookupTarget(context.Background(), got.GetPublicId())
+		require.NoError(err)
+		assert.IsType(&UdpTarget{}, found)
+
+		err = db.TestVerifyOplog(t, rw, got.GetPublicId(), db.WithOperation(oplog.OpType_OP_TYPE_CREATE), db.WithCreateNotBefore(10e9))
+		assert.NoError(err)
+	})
+	t.Run("name-unique-across-subtypes", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		tcpT := TestTcpTarget(t, conn, proj.PublicId, testTargetName(t, proj.PublicId))
+		target, err := NewUdpTarget(proj.PublicId, WithName(tcpT.Name))
+		require.NoError(err)
+		_, _, err = repo.CreateUdpTarget(context.Background(), target)
+		require.Error(err)
+		assert.True(errors.IsUniqueError(err))
+	})
+	t.Run("wrong-public-id-prefix", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		target, err := NewUdpTarget(proj.PublicId, WithName(testTargetName(t, proj.PublicId)))
+		require.NoError(err)
+		id, err := newTcpTargetId()
+		require.NoError(err)
+		_, _, err = repo.CreateUdpTarget(context.Background(), target, WithPublicId(id))
+		require.Error(err)
+		assert.True(errors.Is(err, errors.ErrInvalidPublicId))
+	})
+}
+
+func TestRepository_UpdateUdpTarget(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, testKms)
+	require.NoError(t, err)
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		target := TestUdpTarget(t, conn, proj.PublicId, testTargetName(t, proj.PublicId))
+		upd := target.Clone().(*UdpTarget)
+		upd.Name = "renamed-" + target.Name
+		upd.DefaultPort = 2222
+		got, _, rowsUpdated, err := repo.UpdateUdpTarget(context.Background(), upd, target.Version, []string{"Name", "DefaultPort"})
+		require.NoError(err)
+		assert.Equal(1, rowsUpdated)
+		assert.Equal(upd.Name, got.GetName())
+		assert.Equal(uint32(2222), got.GetDefaultPort())
+	})
+	t.Run("bad-field", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		target := TestUdpTarget(t, conn, proj.PublicId, testTargetName(t, proj.PublicId))
+		_, _, _, err := repo.UpdateUdpTarget(context.Background(), target, target.Version, []string{"ScopeId"})
+		require.Error(err)
+		assert.True(errors.Is(err, errors.ErrInvalidFieldMask))
+	})
+	t.Run("delete", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		target := TestUdpTarget(t, conn, proj.PublicId, testTargetName(t, proj.PublicId))
+		rowsDeleted, err := repo.DeleteTarget(context.Background(), target.PublicId)
+		require.NoError(err)
+		assert.Equal(1, rowsDeleted)
+	})
+}
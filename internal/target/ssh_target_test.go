@@ -0,0 +1,138 @@
+package target
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSshTarget_Create(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	tests := []struct {
+		name      string
+		scopeId   string
+		opt       []Option
+		want      *SshTarget
+		wantErr   bool
+		wantIsErr error
+		create    bool
+	}{
+		{
+			name:      "empty-scopeId",
+			wantErr:   true,
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name:    "valid-proj-scope",
+			scopeId: prj.PublicId,
+			opt: []Option{
+				WithName("valid-proj-scope"),
+				WithDefaultPort(22),
+			},
+			want: func() *SshTarget {
+				t := allocSshTarget()
+				t.ScopeId = prj.PublicId
+				t.Name = "valid-proj-scope"
+				t.DefaultPort = 22
+				t.SessionMaxSeconds = uint32((8 * time.Hour).Seconds())
+				t.SessionConnectionLimit = 1
+				return &t
+			}(),
+			create: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewSshTarget(tt.scopeId, tt.opt...)
+			if tt.wantErr {
+				require.Error(err)
+				assert.True(errors.Is(err, tt.wantIsErr))
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+			if tt.create {
+				id, err := newSshTargetId()
+				require.NoError(err)
+				got.PublicId = id
+				require.NoError(db.New(conn).Create(context.Background(), got))
+			}
+		})
+	}
+}
+
+func TestSshTarget_Clone(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	target := TestSshTarget(t, conn, proj.PublicId, testTargetName(t, proj.PublicId))
+	cp := target.Clone()
+	assert.True(t, proto.Equal(cp.(*SshTarget).SshTarget, target.SshTarget))
+}
+
+func TestSshTarget_oplog(t *testing.T) {
+	id := testId(t)
+	target := allocSshTarget()
+	target.PublicId = id
+	target.ScopeId = id
+	want := oplog.Metadata{
+		"resource-public-id": []string{id},
+		"resource-type":      []string{"ssh target"},
+		"op-type":            []string{oplog.OpType_OP_TYPE_CREATE.String()},
+		"scope-id":           []string{id},
+	}
+	assert.Equal(t, want, target.oplog(oplog.OpType_OP_TYPE_CREATE))
+}
+
+func TestParseSshHostKeys(t *testing.T) {
+	t.Parallel()
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	key, err := ssh.NewPublicKey(pub)
+	require.NoError(t, err)
+	line := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key)))
+
+	tests := []struct {
+		name    string
+		keys    string
+		want    int
+		wantErr bool
+	}{
+		{name: "empty", keys: ""},
+		{name: "one", keys: line, want: 1},
+		{name: "comments-and-blanks", keys: "# endpoint\n\n" + line + " host.example.com\n" + line + "\n", want: 2},
+		{name: "invalid", keys: line + "\nnot a key", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := ParseSshHostKeys(tt.keys)
+			if tt.wantErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			require.Len(got, tt.want)
+			for _, k := range got {
+				assert.Equal(key.Marshal(), k.Marshal())
+			}
+		})
+	}
+}
@@ -9,12 +9,21 @@ type SubType int
 const (
 	UnknownSubtype SubType = iota
 	TcpSubType
+	HttpSubType
+	SshSubType
+	UdpSubType
 )
 
 func (t SubType) String() string {
 	switch t {
 	case TcpSubType:
 		return "tcp"
+	case HttpSubType:
+		return "http"
+	case SshSubType:
+		return "ssh"
+	case UdpSubType:
+		return "udp"
 	}
 	return "unknown"
 }
@@ -25,6 +34,12 @@ func SubtypeFromType(t string) SubType {
 	switch {
 	case strings.EqualFold(strings.TrimSpace(t), TcpSubType.String()):
 		return TcpSubType
+	case strings.EqualFold(strings.TrimSpace(t), HttpSubType.String()):
+		return HttpSubType
+	case strings.EqualFold(strings.TrimSpace(t), SshSubType.String()):
+		return SshSubType
+	case strings.EqualFold(strings.TrimSpace(t), UdpSubType.String()):
+		return UdpSubType
 	}
 	return UnknownSubtype
 }
@@ -36,6 +51,12 @@ func SubtypeFromId(id string) SubType {
 	switch {
 	case strings.HasPrefix(strings.TrimSpace(id), TcpTargetPrefix):
 		return TcpSubType
+	case strings.HasPrefix(strings.TrimSpace(id), HttpTargetPrefix):
+		return HttpSubType
+	case strings.HasPrefix(strings.TrimSpace(id), SshTargetPrefix):
+		return SshSubType
+	case strings.HasPrefix(strings.TrimSpace(id), UdpTargetPrefix):
+		return UdpSubType
 	}
 	return UnknownSubtype
 }
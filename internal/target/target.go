@@ -21,6 +21,8 @@ type Target interface {
 	GetUpdateTime() *timestamp.Timestamp
 	GetSessionMaxSeconds() uint32
 	GetSessionConnectionLimit() int32
+	GetWorkerFilter() string
+	GetSessionIdleTimeoutSeconds() uint32
 	oplog(op oplog.OpType) oplog.Metadata
 }
 
@@ -30,6 +32,9 @@ type TargetType uint32
 const (
 	UnknownTargetType TargetType = 0
 	TcpTargetType     TargetType = 1
+	HttpTargetType    TargetType = 2
+	SshTargetType     TargetType = 3
+	UdpTargetType     TargetType = 4
 )
 
 // String returns a string representation of the target type.
@@ -37,6 +42,9 @@ func (t TargetType) String() string {
 	return [...]string{
 		"unknown",
 		"tcp",
+		"http",
+		"ssh",
+		"udp",
 	}[t]
 }
 
@@ -92,7 +100,54 @@ func (t *targetView) targetSubType() (Target, error) {
 		tcpTarget.Version = t.Version
 		tcpTarget.SessionMaxSeconds = t.SessionMaxSeconds
 		tcpTarget.SessionConnectionLimit = t.SessionConnectionLimit
+		tcpTarget.WorkerFilter = t.WorkerFilter
+		tcpTarget.SessionIdleTimeoutSeconds = t.SessionIdleTimeoutSeconds
 		return &tcpTarget, nil
+	case HttpTargetType.String():
+		httpTarget := allocHttpTarget()
+		httpTarget.PublicId = t.PublicId
+		httpTarget.ScopeId = t.ScopeId
+		httpTarget.Name = t.Name
+		httpTarget.Description = t.Description
+		httpTarget.DefaultPort = t.DefaultPort
+		httpTarget.CreateTime = t.CreateTime
+		httpTarget.UpdateTime = t.UpdateTime
+		httpTarget.Version = t.Version
+		httpTarget.SessionMaxSeconds = t.SessionMaxSeconds
+		httpTarget.SessionConnectionLimit = t.SessionConnectionLimit
+		httpTarget.WorkerFilter = t.WorkerFilter
+		httpTarget.SessionIdleTimeoutSeconds = t.SessionIdleTimeoutSeconds
+		return &httpTarget, nil
+	case SshTargetType.String():
+		sshTarget := allocSshTarget()
+		sshTarget.PublicId = t.PublicId
+		sshTarget.ScopeId = t.ScopeId
+		sshTarget.Name = t.Name
+		sshTarget.Description = t.Description
+		sshTarget.DefaultPort = t.DefaultPort
+		sshTarget.CreateTime = t.CreateTime
+		sshTarget.UpdateTime = t.UpdateTime
+		sshTarget.Version = t.Version
+		sshTarget.SessionMaxSeconds = t.SessionMaxSeconds
+		sshTarget.SessionConnectionLimit = t.SessionConnectionLimit
+		sshTarget.WorkerFilter = t.WorkerFilter
+		sshTarget.SessionIdleTimeoutSeconds = t.SessionIdleTimeoutSeconds
+		return &sshTarget, nil
+	case UdpTargetType.String():
+		udpTarget := allocUdpTarget()
+		udpTarget.PublicId = t.PublicId
+		udpTarget.ScopeId = t.ScopeId
+		udpTarget.Name = t.Name
+		udpTarget.Description = t.Description
+		udpTarget.DefaultPort = t.DefaultPort
+		udpTarget.CreateTime = t.CreateTime
+		udpTarget.UpdateTime = t.UpdateTime
+		udpTarget.Version = t.Version
+		udpTarget.SessionMaxSeconds = t.SessionMaxSeconds
+		udpTarget.SessionConnectionLimit = t.SessionConnectionLimit
+		udpTarget.WorkerFilter = t.WorkerFilter
+		udpTarget.SessionIdleTimeoutSeconds = t.SessionIdleTimeoutSeconds
+		return &udpTarget, nil
 	}
 	return nil, fmt.Errorf("%s is an unknown target subtype of %s", t.PublicId, t.Type)
 }
@@ -0,0 +1,96 @@
+package target
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/target/store"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	DefaultTargetCredentialTableName = "target_credential"
+)
+
+// TargetCredential attaches a credential to a target. The credential is
+// returned to the client when a session is authorized for the target.
+type TargetCredential struct {
+	*store.TargetCredential
+	tableName string `gorm:"-"`
+}
+
+var _ db.VetForWriter = (*TargetCredential)(nil)
+
+// NewTargetCredential creates a new in memory target credential. No options
+// are currently supported.
+func NewTargetCredential(targetId, credentialId string, opt ...Option) (*TargetCredential, error) {
+	if targetId == "" {
+		return nil, fmt.Errorf("new target credential: missing target id: %w", errors.ErrInvalidParameter)
+	}
+	if credentialId == "" {
+		return nil, fmt.Errorf("new target credential: missing credential id: %w", errors.ErrInvalidParameter)
+	}
+	t := &TargetCredential{
+		TargetCredential: &store.TargetCredential{
+			TargetId:     targetId,
+			CredentialId: credentialId,
+		},
+	}
+	return t, nil
+}
+
+// allocTargetCredential will allocate a target credential
+func allocTargetCredential() TargetCredential {
+	return TargetCredential{
+		TargetCredential: &store.TargetCredential{},
+	}
+}
+
+// Clone creates a clone of the target credential
+func (t *TargetCredential) Clone() interface{} {
+	cp := proto.Clone(t.TargetCredential)
+	return &TargetCredential{
+		TargetCredential: cp.(*store.TargetCredential),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface and validates the target
+// credential before it's written.
+func (t *TargetCredential) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType, opt ...db.Option) error {
+	if opType == db.CreateOp {
+		if t.TargetId == "" {
+			return fmt.Errorf("target credential: vet for write: missing target id: %w", errors.ErrInvalidParameter)
+		}
+		if t.CredentialId == "" {
+			return fmt.Errorf("target credential: vet for write: missing credential id: %w", errors.ErrInvalidParameter)
+		}
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (t *TargetCredential) TableName() string {
+	if t.tableName != "" {
+		return t.tableName
+	}
+	return DefaultTargetCredentialTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (t *TargetCredential) SetTableName(n string) {
+	t.tableName = n
+}
+
+func (t *TargetCredential) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{fmt.Sprintf("%s:%s", t.TargetId, t.CredentialId)},
+		"resource-type":      []string{"target credential"},
+		"op-type":            []string{op.String()},
+	}
+	return metadata
+}
@@ -24,8 +24,8 @@ var _ Target = (*TcpTarget)(nil)
 var _ db.VetForWriter = (*TcpTarget)(nil)
 var _ oplog.ReplayableMessage = (*TcpTarget)(nil)
 
-// NewTcpTarget creates a new in memory tcp target.  WithName, WithDescription and
-// WithDefaultPort options are supported
+// NewTcpTarget creates a new in memory tcp target.  WithName, WithDescription,
+// WithDefaultPort and WithWorkerFilter options are supported
 func NewTcpTarget(scopeId string, opt ...Option) (*TcpTarget, error) {
 	opts := getOpts(opt...)
 	if scopeId == "" {
@@ -33,12 +33,14 @@ func NewTcpTarget(scopeId string, opt ...Option) (*TcpTarget, error) {
 	}
 	t := &TcpTarget{
 		TcpTarget: &store.TcpTarget{
-			ScopeId:                scopeId,
-			Name:                   opts.withName,
-			Description:            opts.withDescription,
-			DefaultPort:            opts.withDefaultPort,
-			SessionConnectionLimit: opts.withSessionConnectionLimit,
-			SessionMaxSeconds:      opts.withSessionMaxSeconds,
+			ScopeId:                   scopeId,
+			Name:                      opts.withName,
+			Description:               opts.withDescription,
+			DefaultPort:               opts.withDefaultPort,
+			SessionConnectionLimit:    opts.withSessionConnectionLimit,
+			SessionMaxSeconds:         opts.withSessionMaxSeconds,
+			WorkerFilter:              opts.withWorkerFilter,
+			SessionIdleTimeoutSeconds: opts.withSessionIdleTimeout,
 		},
 	}
 	return t, nil
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/boundary/internal/db"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
 	"github.com/hashicorp/go-uuid"
 	"github.com/jinzhu/gorm"
 	"github.com/stretchr/testify/require"
@@ -38,6 +39,98 @@ func TestTcpTarget(t *testing.T, conn *gorm.DB, scopeId, name string, opt ...Opt
 	return target
 }
 
+// TestSshTarget creates an ssh target in the repository.
+func TestSshTarget(t *testing.T, conn *gorm.DB, scopeId, name string, opt ...Option) *SshTarget {
+	t.Helper()
+	opt = append(opt, WithName(name))
+	opts := getOpts(opt...)
+	require := require.New(t)
+	rw := db.New(conn)
+	target, err := NewSshTarget(scopeId, opt...)
+	require.NoError(err)
+	id, err := newSshTargetId()
+	require.NoError(err)
+	target.PublicId = id
+	err = rw.Create(context.Background(), target)
+	require.NoError(err)
+
+	if len(opts.withHostSets) > 0 {
+		newHostSets := make([]interface{}, 0, len(opts.withHostSets))
+		for _, s := range opts.withHostSets {
+			hostSet, err := NewTargetHostSet(target.PublicId, s)
+			require.NoError(err)
+			newHostSets = append(newHostSets, hostSet)
+		}
+		err := rw.CreateItems(context.Background(), newHostSets)
+		require.NoError(err)
+	}
+	return target
+}
+
+// TestUdpTarget creates a udp target in the repository.
+func TestUdpTarget(t *testing.T, conn *gorm.DB, scopeId, name string, opt ...Option) *UdpTarget {
+	t.Helper()
+	opt = append(opt, WithName(name))
+	opts := getOpts(opt...)
+	require := require.New(t)
+	rw := db.New(conn)
+	target, err := NewUdpTarget(scopeId, opt...)
+	require.NoError(err)
+	id, err := newUdpTargetId()
+	require.NoError(err)
+	target.PublicId = id
+	err = rw.Create(context.Background(), target)
+	require.NoError(err)
+
+	if len(opts.withHostSets) > 0 {
+		newHostSets := make([]interface{}, 0, len(opts.withHostSets))
+		for _, s := range opts.withHostSets {
+			hostSet, err := NewTargetHostSet(target.PublicId, s)
+			require.NoError(err)
+			newHostSets = append(newHostSets, hostSet)
+		}
+		err := rw.CreateItems(context.Background(), newHostSets)
+		require.NoError(err)
+	}
+	return target
+}
+
+// TestHttpTarget creates an http target and its headers in the repository.
+// The header values are encrypted with wrapper, which should be a database
+// wrapper of the scope for them to be decrypted by the repository.
+func TestHttpTarget(t *testing.T, conn *gorm.DB, wrapper wrapping.Wrapper, scopeId, name string, opt ...Option) *HttpTarget {
+	t.Helper()
+	opt = append(opt, WithName(name))
+	opts := getOpts(opt...)
+	require := require.New(t)
+	rw := db.New(conn)
+	target, err := NewHttpTarget(scopeId, opt...)
+	require.NoError(err)
+	id, err := newHttpTargetId()
+	require.NoError(err)
+	target.PublicId = id
+	err = rw.Create(context.Background(), target)
+	require.NoError(err)
+
+	if len(opts.withHeaders) > 0 {
+		newHeaders, err := newHttpTargetHeaders(context.Background(), wrapper, target.PublicId, opts.withHeaders)
+		require.NoError(err)
+		err = rw.CreateItems(context.Background(), newHeaders)
+		require.NoError(err)
+	}
+	if len(opts.withHostSets) > 0 {
+		newHostSets := make([]interface{}, 0, len(opts.withHostSets))
+		for _, s := range opts.withHostSets {
+			hostSet, err := NewTargetHostSet(target.PublicId, s)
+			require.NoError(err)
+			newHostSets = append(newHostSets, hostSet)
+		}
+		err := rw.CreateItems(context.Background(), newHostSets)
+		require.NoError(err)
+	}
+	return target
+}
+
 func testTargetName(t *testing.T, scopeId string) string {
 	t.Helper()
 	return fmt.Sprintf("%s-%s", scopeId, testId(t))
@@ -0,0 +1,98 @@
+package target
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestUdpTarget_Create(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	tests := []struct {
+		name      string
+		scopeId   string
+		opt       []Option
+		want      *UdpTarget
+		wantErr   bool
+		wantIsErr error
+		create    bool
+	}{
+		{
+			name:      "empty-scopeId",
+			wantErr:   true,
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name:    "valid-proj-scope",
+			scopeId: prj.PublicId,
+			opt: []Option{
+				WithName("valid-proj-scope"),
+				WithDefaultPort(22),
+			},
+			want: func() *UdpTarget {
+				t := allocUdpTarget()
+				t.ScopeId = prj.PublicId
+				t.Name = "valid-proj-scope"
+				t.DefaultPort = 22
+				t.SessionMaxSeconds = uint32((8 * time.Hour).Seconds())
+				t.SessionConnectionLimit = 1
+				return &t
+			}(),
+			create: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewUdpTarget(tt.scopeId, tt.opt...)
+			if tt.wantErr {
+				require.Error(err)
+				assert.True(errors.Is(err, tt.wantIsErr))
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+			if tt.create {
+				id, err := newUdpTargetId()
+				require.NoError(err)
+				got.PublicId = id
+				require.NoError(db.New(conn).Create(context.Background(), got))
+			}
+		})
+	}
+}
+
+func TestUdpTarget_Clone(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	target := TestUdpTarget(t, conn, proj.PublicId, testTargetName(t, proj.PublicId))
+	cp := target.Clone()
+	assert.True(t, proto.Equal(cp.(*UdpTarget).UdpTarget, target.UdpTarget))
+}
+
+func TestUdpTarget_oplog(t *testing.T) {
+	id := testId(t)
+	target := allocUdpTarget()
+	target.PublicId = id
+	target.ScopeId = id
+	want := oplog.Metadata{
+		"resource-public-id": []string{id},
+		"resource-type":      []string{"udp target"},
+		"op-type":            []string{oplog.OpType_OP_TYPE_CREATE.String()},
+		"scope-id":           []string{id},
+	}
+	assert.Equal(t, want, target.oplog(oplog.OpType_OP_TYPE_CREATE))
+}
@@ -6,70 +6,90 @@ type Type int
 
 // not using iota intentionally, since the values are stored in the db as well.
 const (
-	Unknown          Type = 0
-	List             Type = 1
-	Create           Type = 2
-	Update           Type = 3
-	Read             Type = 4
-	Delete           Type = 5
-	Authenticate     Type = 6
-	All              Type = 7
-	AuthorizeSession Type = 8
-	AddGrants        Type = 9
-	RemoveGrants     Type = 10
-	SetGrants        Type = 11
-	AddPrincipals    Type = 12
-	SetPrincipals    Type = 13
-	RemovePrincipals Type = 14
-	Deauthenticate   Type = 15
-	AddMembers       Type = 16
-	SetMembers       Type = 17
-	RemoveMembers    Type = 18
-	SetPassword      Type = 19
-	ChangePassword   Type = 20
-	AddHosts         Type = 21
-	SetHosts         Type = 22
-	RemoveHosts      Type = 23
-	AddHostSets      Type = 24
-	SetHostSets      Type = 25
-	RemoveHostSets   Type = 26
-	Cancel           Type = 27
-	AddAccounts      Type = 28
-	SetAccounts      Type = 29
-	RemoveAccounts   Type = 30
+	Unknown             Type = 0
+	List                Type = 1
+	Create              Type = 2
+	Update              Type = 3
+	Read                Type = 4
+	Delete              Type = 5
+	Authenticate        Type = 6
+	All                 Type = 7
+	AuthorizeSession    Type = 8
+	AddGrants           Type = 9
+	RemoveGrants        Type = 10
+	SetGrants           Type = 11
+	AddPrincipals       Type = 12
+	SetPrincipals       Type = 13
+	RemovePrincipals    Type = 14
+	Deauthenticate      Type = 15
+	AddMembers          Type = 16
+	SetMembers          Type = 17
+	RemoveMembers       Type = 18
+	SetPassword         Type = 19
+	ChangePassword      Type = 20
+	AddHosts            Type = 21
+	SetHosts            Type = 22
+	RemoveHosts         Type = 23
+	AddHostSets         Type = 24
+	SetHostSets         Type = 25
+	RemoveHostSets      Type = 26
+	Cancel              Type = 27
+	AddAccounts         Type = 28
+	SetAccounts         Type = 29
+	RemoveAccounts      Type = 30
+	DownloadRecording   Type = 31
+	AddCredentials      Type = 32
+	SetCredentials      Type = 33
+	RemoveCredentials   Type = 34
+	Approve             Type = 35
+	Deny                Type = 36
+	Explain             Type = 37
+	Unlock              Type = 38
+	TerminateConnection Type = 39
+	Watch               Type = 40
 )
 
 var Map = map[string]Type{
-	Create.String():           Create,
-	List.String():             List,
-	Update.String():           Update,
-	Read.String():             Read,
-	Delete.String():           Delete,
-	Authenticate.String():     Authenticate,
-	All.String():              All,
-	AuthorizeSession.String(): AuthorizeSession,
-	AddGrants.String():        AddGrants,
-	RemoveGrants.String():     RemoveGrants,
-	SetGrants.String():        SetGrants,
-	AddPrincipals.String():    AddPrincipals,
-	SetPrincipals.String():    SetPrincipals,
-	RemovePrincipals.String(): RemovePrincipals,
-	Deauthenticate.String():   Deauthenticate,
-	AddMembers.String():       AddMembers,
-	SetMembers.String():       SetMembers,
-	RemoveMembers.String():    RemoveMembers,
-	SetPassword.String():      SetPassword,
-	ChangePassword.String():   ChangePassword,
-	AddHosts.String():         AddHosts,
-	SetHosts.String():         SetHosts,
-	RemoveHosts.String():      RemoveHosts,
-	AddHostSets.String():      AddHostSets,
-	SetHostSets.String():      SetHostSets,
-	RemoveHostSets.String():   RemoveHostSets,
-	Cancel.String():           Cancel,
-	AddAccounts.String():      AddAccounts,
-	SetAccounts.String():      SetAccounts,
-	RemoveAccounts.String():   RemoveAccounts,
+	Create.String():              Create,
+	List.String():                List,
+	Update.String():              Update,
+	Read.String():                Read,
+	Delete.String():              Delete,
+	Authenticate.String():        Authenticate,
+	All.String():                 All,
+	AuthorizeSession.String():    AuthorizeSession,
+	AddGrants.String():           AddGrants,
+	RemoveGrants.String():        RemoveGrants,
+	SetGrants.String():           SetGrants,
+	AddPrincipals.String():       AddPrincipals,
+	SetPrincipals.String():       SetPrincipals,
+	RemovePrincipals.String():    RemovePrincipals,
+	Deauthenticate.String():      Deauthenticate,
+	AddMembers.String():          AddMembers,
+	SetMembers.String():          SetMembers,
+	RemoveMembers.String():       RemoveMembers,
+	SetPassword.String():         SetPassword,
+	ChangePassword.String():      ChangePassword,
+	AddHosts.String():            AddHosts,
+	SetHosts.String():            SetHosts,
+	RemoveHosts.String():         RemoveHosts,
+	AddHostSets.String():         AddHostSets,
+	SetHostSets.String():         SetHostSets,
+	RemoveHostSets.String():      RemoveHostSets,
+	Cancel.String():              Cancel,
+	AddAccounts.String():         AddAccounts,
+	SetAccounts.String():         SetAccounts,
+	RemoveAccounts.String():      RemoveAccounts,
+	DownloadRecording.String():   DownloadRecording,
+	AddCredentials.String():      AddCredentials,
+	SetCredentials.String():      SetCredentials,
+	RemoveCredentials.String():   RemoveCredentials,
+	Approve.String():             Approve,
+	Deny.String():                Deny,
+	Explain.String():             Explain,
+	Unlock.String():              Unlock,
+	TerminateConnection.String(): TerminateConnection,
+	Watch.String():               Watch,
 }
 
 func (a Type) String() string {
@@ -105,5 +125,31 @@ func (a Type) String() string {
 		"add-accounts",
 		"set-accounts",
 		"remove-accounts",
+		"download-recording",
+		"add-credentials",
+		"set-credentials",
+		"remove-credentials",
+		"approve",
+		"deny",
+		"explain",
+		"unlock",
+		"terminate-connection",
+		"watch",
 	}[a]
 }
+
+// ActionSet is a set of actions, such as the actions that can be performed on
+// a resource.
+type ActionSet []Type
+
+// Strings returns the string values of the actions in the set, in order.
+func (a ActionSet) Strings() []string {
+	if a == nil {
+		return nil
+	}
+	ret := make([]string, 0, len(a))
+	for _, act := range a {
+		ret = append(ret, act.String())
+	}
+	return ret
+}
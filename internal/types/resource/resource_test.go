@@ -76,6 +76,18 @@ func Test_Resource(t *testing.T) {
 			typeString: "session",
 			want:       Session,
 		},
+		{
+			typeString: "credential-store",
+			want:       CredentialStore,
+		},
+		{
+			typeString: "credential",
+			want:       Credential,
+		},
+		{
+			typeString: "access-request",
+			want:       AccessRequest,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.typeString, func(t *testing.T) {
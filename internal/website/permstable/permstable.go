@@ -16,6 +16,7 @@ var (
 )
 
 type Table struct {
+	Notes  []string
 	Header *Header
 	Body   *Body
 }
@@ -47,6 +48,12 @@ type Action struct {
 }
 
 var table = &Table{
+	Notes: []string{
+		"Any of the example grants can deny rather than allow its actions by " +
+			"adding `effect=deny`, e.g. `id=<id>;actions=delete;effect=deny`. " +
+			"A matching deny grant overrides every grant allowing the action, " +
+			"regardless of the roles the grants come from.",
+	},
 	Header: &Header{
 		Titles: []string{
 			"Resource Type",
@@ -63,6 +70,7 @@ var table = &Table{
 
 func main() {
 	table.Body.Resources = append(table.Body.Resources,
+		accessRequest,
 		account,
 		authMethod,
 		authToken,
@@ -120,6 +128,9 @@ func main() {
 }
 
 func (t *Table) Marshal() (ret []string) {
+	for _, v := range t.Notes {
+		ret = append(ret, v, "")
+	}
 	ret = append(ret, "<table>")
 	ret = append(ret, "  <thead>")
 	ret = append(ret, t.Header.Marshal()...)
@@ -303,6 +314,52 @@ func rudActions(typ string, pin bool) []*Action {
 	return ret
 }
 
+var accessRequest = &Resource{
+	Type:   "Access Request",
+	Scopes: infraScope,
+	Endpoints: []*Endpoint{
+		{
+			Path: "/access-requests",
+			Params: map[string]string{
+				"Type": "access-request",
+			},
+			Actions: clActions("an access request"),
+		},
+		{
+			Path: "/access-requests/<id>",
+			Params: map[string]string{
+				"ID":   "<id>",
+				"Type": "access-request",
+			},
+			Actions: []*Action{
+				{
+					Name:        "read",
+					Description: "Read an access request",
+					Examples: []string{
+						"id=<id>;actions=read",
+					},
+				},
+				{
+					Name:        "approve",
+					Description: "Approve an access request, allowing its user to authorize sessions to its target for the requested duration",
+					Examples: []string{
+						"id=<id>;actions=approve",
+						"id=*;type=<type>;actions=approve",
+					},
+				},
+				{
+					Name:        "deny",
+					Description: "Deny an access request",
+					Examples: []string{
+						"id=<id>;actions=deny",
+						"id=*;type=<type>;actions=deny",
+					},
+				},
+			},
+		},
+	},
+}
+
 var account = &Resource{
 	Type:   "Account",
 	Scopes: iamScopes,
@@ -339,6 +396,14 @@ var account = &Resource{
 						"id=<pin>;type=<type>;actions=change-password",
 					},
 				},
+				&Action{
+					Name:        "unlock",
+					Description: "Unlock an account locked after too many failed authentication attempts",
+					Examples: []string{
+						"id=<id>;actions=unlock",
+						"id=<pin>;type=<type>;actions=unlock",
+					},
+				},
 			),
 		},
 	},
@@ -622,6 +687,21 @@ var role = &Resource{
 				},
 			),
 		},
+		{
+			Path: "/roles:explain",
+			Params: map[string]string{
+				"Type": "role",
+			},
+			Actions: []*Action{
+				{
+					Name:        "explain",
+					Description: "Explain how a user's grants apply to an action on a resource in the scope",
+					Examples: []string{
+						"id=*;type=<type>;actions=explain",
+					},
+				},
+			},
+		},
 	},
 }
 